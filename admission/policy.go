@@ -0,0 +1,137 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package admission filters the transactions a node admits into its mempool
+// and includes in the blocks it builds according to a local policy file.
+//
+// Admission only affects the transactions a node handles itself: blocks
+// built by other validators are still verified and accepted regardless of
+// the policy of the node.
+package admission
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+)
+
+var (
+	ErrInvalidAddress     = errors.New("invalid address")
+	ErrSponsorNotAllowed  = errors.New("sponsor not allowed")
+	ErrSponsorDenied      = errors.New("sponsor denied")
+	ErrRecipientDenied    = errors.New("recipient denied")
+	ErrActionDenied       = errors.New("action denied")
+	ErrPolicyFileRequired = errors.New("policy file required")
+)
+
+// File is the JSON policy file. Addresses can be provided in bech32m or bech32
+// (with any HRP) or as hex.
+type File struct {
+	// AllowSponsors are the only sponsors whose transactions are admitted
+	// (if not empty)
+	AllowSponsors []string `json:"allowSponsors"`
+	// DenySponsors are never admitted
+	DenySponsors []string `json:"denySponsors"`
+	// DenyRecipients can't be referenced by the actions of admitted
+	// transactions (like the recipient of a transfer)
+	DenyRecipients []string `json:"denyRecipients"`
+	// DenyActions are the type IDs of the actions that admitted transactions
+	// can't include
+	DenyActions []uint8 `json:"denyActions"`
+}
+
+// Policy decides which transactions are admitted. The zero Policy admits
+// every transaction.
+type Policy struct {
+	allowSponsors  set.Set[codec.Address]
+	denySponsors   set.Set[codec.Address]
+	denyRecipients set.Set[codec.Address]
+	denyActions    set.Set[uint8]
+}
+
+// ParsePolicy parses the policy file [b].
+func ParsePolicy(b []byte) (*Policy, error) {
+	var f File
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+	return NewPolicy(&f)
+}
+
+func NewPolicy(f *File) (*Policy, error) {
+	p := &Policy{denyActions: set.Of(f.DenyActions...)}
+	for _, l := range []struct {
+		addrs []string
+		s     *set.Set[codec.Address]
+	}{
+		{f.AllowSponsors, &p.allowSponsors},
+		{f.DenySponsors, &p.denySponsors},
+		{f.DenyRecipients, &p.denyRecipients},
+	} {
+		for _, saddr := range l.addrs {
+			addr, err := parseAddress(saddr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", err, saddr)
+			}
+			l.s.Add(addr)
+		}
+	}
+	return p, nil
+}
+
+func parseAddress(saddr string) (codec.Address, error) {
+	if _, addr, err := address.Decode(saddr); err == nil {
+		return addr, nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(saddr, "0x"))
+	if err != nil || len(b) != codec.AddressLen {
+		return codec.EmptyAddress, ErrInvalidAddress
+	}
+	return codec.Address(b), nil
+}
+
+// Check returns an error if [tx] is not admitted. The recipients of [tx] are
+// returned by [recipients] if it is not nil. Otherwise, any denied recipient
+// whose address is contained in [tx] (other than its actor and sponsor) is
+// considered a recipient, as actions pack addresses as fixed bytes.
+func (p *Policy) Check(tx *chain.Transaction, recipients func(*chain.Transaction) []codec.Address) error {
+	sponsor := tx.Sponsor()
+	if p.allowSponsors.Len() > 0 && !p.allowSponsors.Contains(sponsor) {
+		return ErrSponsorNotAllowed
+	}
+	if p.denySponsors.Contains(sponsor) {
+		return ErrSponsorDenied
+	}
+	for _, action := range tx.Actions {
+		if typeID := action.GetTypeID(); p.denyActions.Contains(typeID) {
+			return fmt.Errorf("%w: %d", ErrActionDenied, typeID)
+		}
+	}
+	if p.denyRecipients.Len() == 0 {
+		return nil
+	}
+	if recipients != nil {
+		for _, addr := range recipients(tx) {
+			if p.denyRecipients.Contains(addr) {
+				return ErrRecipientDenied
+			}
+		}
+		return nil
+	}
+	actor := tx.Auth.Actor()
+	for addr := range p.denyRecipients {
+		if addr != actor && addr != sponsor && bytes.Contains(tx.Bytes(), addr[:]) {
+			return ErrRecipientDenied
+		}
+	}
+	return nil
+}
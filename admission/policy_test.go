@@ -0,0 +1,140 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admission
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+)
+
+const testHRP = "test"
+
+// testAction transfers to [to].
+type testAction struct {
+	chain.Action
+
+	typeID uint8
+	to     codec.Address
+}
+
+func (a *testAction) GetTypeID() uint8 { return a.typeID }
+
+func (*testAction) Size() int { return codec.AddressLen }
+
+func (a *testAction) Marshal(p *codec.Packer) { p.PackAddress(a.to) }
+
+func unmarshalTestAction(typeID uint8) func(*codec.Packer) (chain.Action, error) {
+	return func(p *codec.Packer) (chain.Action, error) {
+		a := &testAction{typeID: typeID}
+		p.UnpackAddress(&a.to)
+		return a, p.Err()
+	}
+}
+
+// testAuth authorizes transactions of [actor] without a signature.
+type testAuth struct {
+	chain.Auth
+
+	actor codec.Address
+}
+
+func (*testAuth) GetTypeID() uint8 { return 0 }
+
+func (*testAuth) Size() int { return codec.AddressLen }
+
+func (a *testAuth) Marshal(p *codec.Packer) { p.PackAddress(a.actor) }
+
+func (a *testAuth) Actor() codec.Address { return a.actor }
+
+func (a *testAuth) Sponsor() codec.Address { return a.actor }
+
+func unmarshalTestAuth(p *codec.Packer) (chain.Auth, error) {
+	var auth testAuth
+	p.UnpackAddress(&auth.actor)
+	return &auth, p.Err()
+}
+
+type testAuthFactory struct {
+	chain.AuthFactory
+
+	actor codec.Address
+}
+
+func (f *testAuthFactory) Sign([]byte) (chain.Auth, error) {
+	return &testAuth{actor: f.actor}, nil
+}
+
+func newTestTx(t *testing.T, sponsor codec.Address, actions ...chain.Action) *chain.Transaction {
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	for _, typeID := range []uint8{0, 1} {
+		require.NoError(t, actionRegistry.Register(typeID, unmarshalTestAction(typeID)))
+	}
+	require.NoError(t, authRegistry.Register(0, unmarshalTestAuth))
+	tx, err := chain.NewTx(
+		&chain.Base{Timestamp: 1_000, ChainID: ids.GenerateTestID(), MaxFee: 100},
+		actions,
+	).Sign(&testAuthFactory{actor: sponsor}, actionRegistry, authRegistry)
+	require.NoError(t, err)
+	return tx
+}
+
+func newTestAddress() codec.Address {
+	return codec.CreateAddress(0, ids.GenerateTestID())
+}
+
+func TestPolicy(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		allowed   = newTestAddress()
+		denied    = newTestAddress()
+		other     = newTestAddress()
+		recipient = newTestAddress()
+	)
+	f := &File{
+		DenySponsors:   []string{address.MustNew(testHRP, nil).Encode(denied)},
+		DenyRecipients: []string{"0x" + hex.EncodeToString(recipient[:])},
+		DenyActions:    []uint8{1},
+	}
+	b, err := json.Marshal(f)
+	require.NoError(err)
+	p, err := ParsePolicy(b)
+	require.NoError(err)
+
+	require.NoError(p.Check(newTestTx(t, allowed, &testAction{to: other}), nil))
+	require.ErrorIs(p.Check(newTestTx(t, denied, &testAction{to: other}), nil), ErrSponsorDenied)
+	require.ErrorIs(p.Check(newTestTx(t, allowed, &testAction{typeID: 1, to: other}), nil), ErrActionDenied)
+
+	// Recipients are found in the transaction bytes unless the controller
+	// provides them
+	tx := newTestTx(t, allowed, &testAction{to: other}, &testAction{to: recipient})
+	require.ErrorIs(p.Check(tx, nil), ErrRecipientDenied)
+	require.NoError(p.Check(tx, func(*chain.Transaction) []codec.Address {
+		return []codec.Address{other}
+	}))
+	require.ErrorIs(p.Check(tx, func(*chain.Transaction) []codec.Address {
+		return []codec.Address{recipient}
+	}), ErrRecipientDenied)
+
+	// Only allowed sponsors are admitted if any are provided
+	p, err = NewPolicy(&File{AllowSponsors: []string{address.MustNew(testHRP, nil).Encode(allowed)}})
+	require.NoError(err)
+	require.NoError(p.Check(newTestTx(t, allowed, &testAction{to: other}), nil))
+	require.ErrorIs(p.Check(newTestTx(t, other, &testAction{to: other}), nil), ErrSponsorNotAllowed)
+
+	// The zero policy admits every transaction
+	require.NoError((&Policy{}).Check(tx, nil))
+
+	_, err = NewPolicy(&File{DenySponsors: []string{"invalid"}})
+	require.ErrorIs(err, ErrInvalidAddress)
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admission
+
+import (
+	"crypto/sha256"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+// Watcher holds the [Policy] of a policy file and reloads it whenever the
+// file changes, so operators can update the policy of a running node.
+type Watcher struct {
+	log      logging.Logger
+	path     string
+	onReload func(digest [sha256.Size]byte, err error)
+
+	policy atomic.Pointer[Policy]
+
+	l      sync.Mutex
+	digest [sha256.Size]byte // of the last file loaded (even if invalid)
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewWatcher loads the policy file at [path] and checks it for changes every
+// [interval] (if positive). [onReload] (if not nil) is called with the digest
+// of the file whenever it is loaded, with the error that prevented it from
+// being applied (if any). The previous policy is kept if the file can't be
+// loaded.
+func NewWatcher(
+	log logging.Logger,
+	path string,
+	interval time.Duration,
+	onReload func(digest [sha256.Size]byte, err error),
+) (*Watcher, error) {
+	if len(path) == 0 {
+		return nil, ErrPolicyFileRequired
+	}
+	w := &Watcher{
+		log:      log,
+		path:     path,
+		onReload: onReload,
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+	if interval > 0 {
+		go w.run(interval)
+	} else {
+		close(w.stopped)
+	}
+	return w, nil
+}
+
+// Policy returns the policy that is currently applied.
+func (w *Watcher) Policy() *Policy {
+	return w.policy.Load()
+}
+
+// Reload loads the policy file if it changed since it was last loaded and
+// returns true if the applied policy was replaced.
+func (w *Watcher) Reload() (bool, error) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	b, err := os.ReadFile(w.path)
+	if err != nil {
+		return false, err
+	}
+	digest := sha256.Sum256(b)
+	if w.policy.Load() != nil && digest == w.digest {
+		return false, nil
+	}
+	w.digest = digest
+	policy, err := ParsePolicy(b)
+	if w.onReload != nil {
+		w.onReload(digest, err)
+	}
+	if err != nil {
+		return false, err
+	}
+	w.policy.Store(policy)
+	return true, nil
+}
+
+func (w *Watcher) run(interval time.Duration) {
+	defer close(w.stopped)
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			reloaded, err := w.Reload()
+			if err != nil {
+				// An invalid file is only reported once it changes again
+				w.log.Warn("unable to reload admission policy",
+					zap.String("path", w.path),
+					zap.Error(err),
+				)
+				continue
+			}
+			if reloaded {
+				w.log.Info("reloaded admission policy", zap.String("path", w.path))
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Close stops checking the policy file for changes.
+func (w *Watcher) Close() {
+	close(w.stop)
+	<-w.stopped
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admission
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec/address"
+)
+
+func TestWatcher(t *testing.T) {
+	require := require.New(t)
+
+	sponsor := newTestAddress()
+	tx := newTestTx(t, sponsor, &testAction{to: newTestAddress()})
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(os.WriteFile(path, []byte(`{}`), 0o600))
+
+	loaded := []error{}
+	w, err := NewWatcher(logging.NoLog{}, path, time.Millisecond, func(_ [sha256.Size]byte, err error) {
+		loaded = append(loaded, err)
+	})
+	require.NoError(err)
+	require.NoError(w.Policy().Check(tx, nil))
+
+	// Changes are applied while the node is running
+	require.NoError(os.WriteFile(path, []byte(`{"denySponsors":["`+address.MustNew(testHRP, nil).Encode(sponsor)+`"]}`), 0o600))
+	require.Eventually(func() bool {
+		return w.Policy().Check(tx, nil) != nil
+	}, 5*time.Second, time.Millisecond)
+	w.Close()
+
+	// Invalid files are only reported once and don't replace the policy
+	require.NoError(os.WriteFile(path, []byte(`{`), 0o600))
+	_, err = w.Reload()
+	require.Error(err)
+	reloaded, err := w.Reload()
+	require.NoError(err)
+	require.False(reloaded)
+	require.ErrorIs(w.Policy().Check(tx, nil), ErrSponsorDenied)
+	require.Len(loaded, 3)
+	require.NoError(loaded[0])
+	require.NoError(loaded[1])
+	require.Error(loaded[2])
+
+	_, err = NewWatcher(logging.NoLog{}, path, 0, nil)
+	require.Error(err)
+	_, err = NewWatcher(logging.NoLog{}, "", 0, nil)
+	require.ErrorIs(err, ErrPolicyFileRequired)
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package audit records security-relevant events (like admin RPC calls and
+// the use of private keys) as JSON lines in a rotated file, separate from the
+// debug log. Events can also be shipped in batches to a remote collector.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileName is the name of the (current) audit log in [Config.Directory].
+// Rotated logs are named after it with the time they were rotated.
+const FileName = "audit.log"
+
+const (
+	AdminCategory   Category = "admin"
+	KeyCategory     Category = "key"
+	MempoolCategory Category = "mempool"
+	ConfigCategory  Category = "config"
+
+	Success Outcome = "success"
+	Failure Outcome = "failure"
+)
+
+var (
+	ErrNoDirectory         = errors.New("no audit log directory")
+	ErrInvalidRemoteConfig = errors.New("remote batch size and interval must be positive")
+	ErrShipmentStatus      = errors.New("unexpected audit shipment status")
+)
+
+// Category groups the events recorded by similar components.
+type Category string
+
+// Outcome is whether the action of an event succeeded.
+type Outcome string
+
+// Event is a line of the audit log.
+type Event struct {
+	Time     time.Time `json:"time"`
+	Category Category  `json:"category"`
+	Action   string    `json:"action"`
+	// Actor identifies who performed the action (like the remote address of
+	// an RPC request), if known
+	Actor   string         `json:"actor,omitempty"`
+	Outcome Outcome        `json:"outcome"`
+	Error   string         `json:"error,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Directory is where the audit log is written
+	Directory string `json:"directory"`
+
+	// The audit log is rotated once it is larger than [MaxSize] megabytes.
+	// Rotated logs are deleted once there are more than [MaxFiles] of them
+	// or they are older than [MaxAge] days (if not 0).
+	MaxSize  int  `json:"maxSize"`
+	MaxFiles int  `json:"maxFiles"`
+	MaxAge   int  `json:"maxAge"`
+	Compress bool `json:"compress"`
+
+	// RemoteURL receives batches of events as JSON arrays in POST requests
+	// (if not empty)
+	RemoteURL string `json:"remoteURL"`
+	// RemoteAuthToken is sent as a bearer token with shipments (if not empty)
+	RemoteAuthToken string `json:"remoteAuthToken"`
+	// RemoteTimeout bounds how long a shipment can take
+	RemoteTimeout time.Duration `json:"remoteTimeout"`
+	// Events are shipped once [RemoteBatchSize] are pending or every
+	// [RemoteInterval], whichever comes first
+	RemoteBatchSize int           `json:"remoteBatchSize"`
+	RemoteInterval  time.Duration `json:"remoteInterval"`
+	// RemoteQueueSize is the number of events waiting to be shipped after
+	// which new events are only written to the audit log
+	RemoteQueueSize int `json:"remoteQueueSize"`
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Enabled:         false,
+		MaxSize:         64,
+		MaxFiles:        16,
+		Compress:        true,
+		RemoteTimeout:   10 * time.Second,
+		RemoteBatchSize: 256,
+		RemoteInterval:  5 * time.Second,
+		RemoteQueueSize: 16_384,
+	}
+}
+
+// Logger writes events to the audit log. A nil Logger discards all events, so
+// components can record events whether or not auditing is enabled.
+type Logger struct {
+	log logging.Logger
+
+	l      sync.Mutex
+	w      *lumberjack.Logger
+	closed bool
+
+	shipper *shipper // nil if events aren't shipped
+}
+
+// New opens the audit log in [config.Directory]. [log] reports events that
+// could not be written or shipped.
+func New(log logging.Logger, config Config) (*Logger, error) {
+	if len(config.Directory) == 0 {
+		return nil, ErrNoDirectory
+	}
+	if len(config.RemoteURL) > 0 && (config.RemoteBatchSize <= 0 || config.RemoteInterval <= 0) {
+		return nil, ErrInvalidRemoteConfig
+	}
+	if err := os.MkdirAll(config.Directory, 0o750); err != nil {
+		return nil, err
+	}
+	l := &Logger{
+		log: log,
+		w: &lumberjack.Logger{
+			Filename:   filepath.Join(config.Directory, FileName),
+			MaxSize:    config.MaxSize,  // megabytes
+			MaxAge:     config.MaxAge,   // days
+			MaxBackups: config.MaxFiles, // files
+			Compress:   config.Compress,
+		},
+	}
+	if len(config.RemoteURL) > 0 {
+		l.shipper = newShipper(log, config)
+		go l.shipper.run()
+	}
+	return l, nil
+}
+
+// Record writes an event of [category] in which [actor] performed [action]
+// with [fields]. The action failed if [err] is not nil.
+func (l *Logger) Record(category Category, action string, actor string, err error, fields map[string]any) {
+	if l == nil {
+		return
+	}
+	e := &Event{
+		Time:     time.Now().UTC(),
+		Category: category,
+		Action:   action,
+		Actor:    actor,
+		Outcome:  Success,
+		Fields:   fields,
+	}
+	if err != nil {
+		e.Outcome = Failure
+		e.Error = err.Error()
+	}
+	b, mErr := json.Marshal(e)
+	if mErr != nil {
+		l.log.Warn("unable to marshal audit event",
+			zap.String("category", string(category)),
+			zap.String("action", action),
+			zap.Error(mErr),
+		)
+		return
+	}
+
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	if l.closed {
+		return
+	}
+	if _, wErr := l.w.Write(append(b, '\n')); wErr != nil {
+		l.log.Warn("unable to write audit event",
+			zap.String("category", string(category)),
+			zap.String("action", action),
+			zap.Error(wErr),
+		)
+	}
+	if l.shipper != nil {
+		l.shipper.enqueue(b)
+	}
+}
+
+// Close ships the pending events (if shipping is enabled) and closes the
+// audit log. Events recorded after Close are discarded.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.l.Lock()
+	if l.closed {
+		l.l.Unlock()
+		return nil
+	}
+	l.closed = true
+	l.l.Unlock()
+
+	if l.shipper != nil {
+		l.shipper.done()
+	}
+	return l.w.Close()
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test")
+
+func readEvents(t *testing.T, dir string) []*Event {
+	f, err := os.Open(filepath.Join(dir, FileName))
+	require.NoError(t, err)
+	defer f.Close()
+
+	events := []*Event{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, &e)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestLogger(t *testing.T) {
+	require := require.New(t)
+
+	config := NewDefaultConfig()
+	config.Directory = t.TempDir()
+	l, err := New(logging.NoLog{}, config)
+	require.NoError(err)
+	l.Record(AdminCategory, "registerWebhook", "127.0.0.1:1234", nil, map[string]any{"id": "a"})
+	l.Record(KeyCategory, "decrypt", "", errTest, nil)
+	require.NoError(l.Close())
+
+	// Events recorded after closing are discarded
+	l.Record(KeyCategory, "decrypt", "", nil, nil)
+	require.NoError(l.Close())
+
+	events := readEvents(t, config.Directory)
+	require.Len(events, 2)
+	require.Equal(AdminCategory, events[0].Category)
+	require.Equal("registerWebhook", events[0].Action)
+	require.Equal("127.0.0.1:1234", events[0].Actor)
+	require.Equal(Success, events[0].Outcome)
+	require.Equal(map[string]any{"id": "a"}, events[0].Fields)
+	require.False(events[0].Time.IsZero())
+	require.Equal(Failure, events[1].Outcome)
+	require.Equal(errTest.Error(), events[1].Error)
+
+	// A nil logger discards events
+	var nilLogger *Logger
+	nilLogger.Record(KeyCategory, "decrypt", "", nil, nil)
+	require.NoError(nilLogger.Close())
+
+	_, err = New(logging.NoLog{}, NewDefaultConfig())
+	require.ErrorIs(err, ErrNoDirectory)
+}
+
+func TestLoggerShipping(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l       sync.Mutex
+		batches [][]*Event
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var batch []*Event
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		l.Lock()
+		batches = append(batches, batch)
+		l.Unlock()
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.Directory = t.TempDir()
+	config.RemoteURL = server.URL
+	config.RemoteAuthToken = "token"
+	config.RemoteBatchSize = 2
+	config.RemoteInterval = time.Hour
+	logger, err := New(logging.NoLog{}, config)
+	require.NoError(err)
+	for _, action := range []string{"a", "b", "c"} {
+		logger.Record(MempoolCategory, action, "", nil, nil)
+	}
+
+	// Full batches are shipped immediately and the rest when closing
+	require.Eventually(func() bool {
+		l.Lock()
+		defer l.Unlock()
+		return len(batches) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(logger.Close())
+	require.Len(batches, 2)
+	require.Len(batches[0], 2)
+	require.Equal("a", batches[0][0].Action)
+	require.Equal("b", batches[0][1].Action)
+	require.Len(batches[1], 1)
+	require.Equal("c", batches[1][0].Action)
+	require.Len(readEvents(t, config.Directory), 3)
+
+	config.RemoteInterval = 0
+	_, err = New(logging.NoLog{}, config)
+	require.ErrorIs(err, ErrInvalidRemoteConfig)
+}
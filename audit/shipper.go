@@ -0,0 +1,114 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+// shipper POSTs batches of events to [Config.RemoteURL]. Batches that can't
+// be shipped are dropped (they remain in the audit log).
+type shipper struct {
+	log    logging.Logger
+	config Config
+	cli    *http.Client
+
+	queue   chan json.RawMessage
+	stopped chan struct{}
+}
+
+func newShipper(log logging.Logger, config Config) *shipper {
+	return &shipper{
+		log:     log,
+		config:  config,
+		cli:     &http.Client{Timeout: config.RemoteTimeout},
+		queue:   make(chan json.RawMessage, config.RemoteQueueSize),
+		stopped: make(chan struct{}),
+	}
+}
+
+// enqueue must not be called after [done].
+func (s *shipper) enqueue(event json.RawMessage) {
+	select {
+	case s.queue <- event:
+	default:
+		s.log.Debug("dropping audit event shipment due to full queue")
+	}
+}
+
+func (s *shipper) run() {
+	defer close(s.stopped)
+
+	t := time.NewTicker(s.config.RemoteInterval)
+	defer t.Stop()
+
+	batch := make([]json.RawMessage, 0, s.config.RemoteBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.ship(batch); err != nil {
+			s.log.Warn("unable to ship audit events",
+				zap.Int("events", len(batch)),
+				zap.Error(err),
+			)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case event, ok := <-s.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= s.config.RemoteBatchSize {
+				flush()
+			}
+		case <-t.C:
+			flush()
+		}
+	}
+}
+
+func (s *shipper) ship(batch []json.RawMessage) error {
+	b, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.config.RemoteURL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.config.RemoteAuthToken) > 0 {
+		req.Header.Set("Authorization", "Bearer "+s.config.RemoteAuthToken)
+	}
+	resp, err := s.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", ErrShipmentStatus, resp.StatusCode)
+	}
+	return nil
+}
+
+// done ships the queued events and waits for [run] to return.
+func (s *shipper) done() {
+	close(s.queue)
+	<-s.stopped
+}
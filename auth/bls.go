@@ -8,9 +8,9 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/crypto/bls"
-	"github.com/ava-labs/hypersdk/utils"
 )
 
 var _ chain.Auth = (*BLS)(nil)
@@ -112,5 +112,5 @@ func (*BLSFactory) MaxUnits() (uint64, uint64) {
 }
 
 func NewBLSAddress(pk *bls.PublicKey) codec.Address {
-	return codec.CreateAddress(BLSID, utils.ToID(bls.PublicKeyToBytes(pk)))
+	return address.FromPublicKey(BLSID, bls.PublicKeyToBytes(pk))
 }
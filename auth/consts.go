@@ -15,6 +15,16 @@ const (
 	BLSID       uint8 = 2
 )
 
+// Types returns the names of the auth types (used by the address formats of
+// VMs to describe addresses).
+func Types() map[uint8]string {
+	return map[uint8]string{
+		ED25519ID:   "ed25519",
+		SECP256R1ID: "secp256r1",
+		BLSID:       "bls",
+	}
+}
+
 func Engines() map[uint8]vm.AuthEngine {
 	return map[uint8]vm.AuthEngine{
 		ED25519ID: &ED25519AuthEngine{},
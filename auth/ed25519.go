@@ -8,9 +8,9 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
-	"github.com/ava-labs/hypersdk/utils"
 )
 
 var _ chain.Auth = (*ED25519)(nil)
@@ -149,5 +149,5 @@ func (b *ED25519Batch) Done() []func() error {
 }
 
 func NewED25519Address(pk ed25519.PublicKey) codec.Address {
-	return codec.CreateAddress(ED25519ID, utils.ToID(pk[:]))
+	return address.FromPublicKey(ED25519ID, pk[:])
 }
@@ -8,9 +8,9 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/crypto/secp256r1"
-	"github.com/ava-labs/hypersdk/utils"
 )
 
 var _ chain.Auth = (*SECP256R1)(nil)
@@ -102,5 +102,5 @@ func (*SECP256R1Factory) MaxUnits() (uint64, uint64) {
 }
 
 func NewSECP256R1Address(pk secp256r1.PublicKey) codec.Address {
-	return codec.CreateAddress(SECP256R1ID, utils.ToID(pk[:]))
+	return address.FromPublicKey(SECP256R1ID, pk[:])
 }
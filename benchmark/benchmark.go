@@ -0,0 +1,195 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package benchmark measures the throughput of a hypervm on standardized
+// workloads, either in-process (see [RunMemory]) or against a running node
+// (see [RunRemote]), and reports the results as JSON so the reports of two
+// commits can be compared to catch performance regressions.
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+const (
+	MemoryTarget = "memory"
+	RemoteTarget = "remote"
+)
+
+// Workload is a fixed sequence of transactions submitted in batches of
+// [TxsPerBlock].
+type Workload struct {
+	Name        string
+	Blocks      int
+	TxsPerBlock int
+	// Tx returns the actions of the [i]th transaction and the factory that
+	// signs it. Each transaction must be unique (transactions have no nonce,
+	// so the actions of a sender must differ).
+	Tx func(i int) ([]chain.Action, chain.AuthFactory)
+}
+
+func (w *Workload) verify() error {
+	if w.Blocks <= 0 || w.TxsPerBlock <= 0 || w.Tx == nil {
+		return fmt.Errorf("%w: %s", ErrInvalidWorkload, w.Name)
+	}
+	return nil
+}
+
+// Timing summarizes the durations of an operation (in nanoseconds).
+type Timing struct {
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	Max  time.Duration `json:"max"`
+}
+
+func newTiming(durations []time.Duration) *Timing {
+	if len(durations) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return &Timing{
+		Mean: total / time.Duration(len(sorted)),
+		P50:  sorted[(len(sorted)-1)*50/100],
+		P90:  sorted[(len(sorted)-1)*90/100],
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// Result is the outcome of running a [Workload] against a target.
+type Result struct {
+	Workload string `json:"workload"`
+	Target   string `json:"target"`
+	Blocks   int    `json:"blocks"`
+	// Txs is the number of transactions included in accepted blocks, of which
+	// [Failed] did not succeed.
+	Txs    int `json:"txs"`
+	Failed int `json:"failed"`
+	// BuildTime and VerifyTime are only measured by [RunMemory].
+	BuildTime  *Timing `json:"buildTime,omitempty"`
+	VerifyTime *Timing `json:"verifyTime,omitempty"`
+	// Duration is the time from the submission of the first transaction to
+	// the acceptance of the last one (excluding signing).
+	Duration time.Duration `json:"duration"`
+	TPS      float64       `json:"tps"`
+}
+
+func (r *Result) key() string {
+	return r.Workload + "/" + r.Target
+}
+
+// Report is the machine-readable output of a benchmark run.
+type Report struct {
+	Commit    string    `json:"commit"`
+	GoVersion string    `json:"goVersion"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	CPUs      int       `json:"cpus"`
+	Results   []*Result `json:"results"`
+}
+
+// NewReport creates an empty [Report] of [commit] (or of the VCS revision the
+// binary was built from if empty, which is not recorded by `go test`).
+func NewReport(commit string) *Report {
+	if len(commit) == 0 {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" {
+					commit = setting.Value
+				}
+			}
+		}
+	}
+	return &Report{
+		Commit:    commit,
+		GoVersion: runtime.Version(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+	}
+}
+
+func (r *Report) Add(result *Result) {
+	r.Results = append(r.Results, result)
+}
+
+func (r *Report) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func ReadReport(r io.Reader) (*Report, error) {
+	report := &Report{}
+	if err := json.NewDecoder(r).Decode(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Regression is a metric of a [Result] that is worse than in the baseline.
+type Regression struct {
+	Workload string  `json:"workload"`
+	Target   string  `json:"target"`
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+}
+
+func (r *Regression) String() string {
+	return fmt.Sprintf("%s/%s: %s regressed from %.2f to %.2f", r.Workload, r.Target, r.Metric, r.Baseline, r.Current)
+}
+
+// Compare returns the metrics of [current] that are more than [tolerance]
+// (as a fraction of the baseline) worse than in [baseline]. Results are
+// matched by workload and target, and results missing from either report are
+// ignored.
+func Compare(baseline *Report, current *Report, tolerance float64) []*Regression {
+	previous := make(map[string]*Result, len(baseline.Results))
+	for _, result := range baseline.Results {
+		previous[result.key()] = result
+	}
+	regressions := []*Regression{}
+	for _, result := range current.Results {
+		base, ok := previous[result.key()]
+		if !ok {
+			continue
+		}
+		check := func(metric string, baseline float64, current float64, higherIsBetter bool) {
+			worse := current > baseline*(1+tolerance)
+			if higherIsBetter {
+				worse = current < baseline*(1-tolerance)
+			}
+			if worse {
+				regressions = append(regressions, &Regression{
+					Workload: result.Workload,
+					Target:   result.Target,
+					Metric:   metric,
+					Baseline: baseline,
+					Current:  current,
+				})
+			}
+		}
+		check("tps", base.TPS, result.TPS, true)
+		if base.BuildTime != nil && result.BuildTime != nil {
+			check("buildTime", float64(base.BuildTime.Mean), float64(result.BuildTime.Mean), false)
+		}
+		if base.VerifyTime != nil && result.VerifyTime != nil {
+			check("verifyTime", float64(base.VerifyTime.Mean), float64(result.VerifyTime.Mean), false)
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchmark
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTiming(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(newTiming(nil))
+	durations := make([]time.Duration, 10)
+	for i := range durations {
+		durations[i] = time.Duration(10-i) * time.Millisecond
+	}
+	require.Equal(&Timing{
+		Mean: 5500 * time.Microsecond,
+		P50:  5 * time.Millisecond,
+		P90:  9 * time.Millisecond,
+		Max:  10 * time.Millisecond,
+	}, newTiming(durations))
+	// The durations are not reordered
+	require.Equal(10*time.Millisecond, durations[0])
+}
+
+func TestCompare(t *testing.T) {
+	require := require.New(t)
+
+	baseline := NewReport("base")
+	baseline.Add(&Result{
+		Workload:   "transfers",
+		Target:     MemoryTarget,
+		TPS:        1_000,
+		BuildTime:  &Timing{Mean: 10 * time.Millisecond},
+		VerifyTime: &Timing{Mean: 10 * time.Millisecond},
+	})
+	baseline.Add(&Result{Workload: "transfers", Target: RemoteTarget, TPS: 1_000})
+
+	// Reports are read back as written
+	var buf bytes.Buffer
+	require.NoError(baseline.Write(&buf))
+	read, err := ReadReport(&buf)
+	require.NoError(err)
+	require.Equal(baseline, read)
+
+	current := NewReport("current")
+	current.Add(&Result{
+		Workload:   "transfers",
+		Target:     MemoryTarget,
+		TPS:        950,
+		BuildTime:  &Timing{Mean: 12 * time.Millisecond},
+		VerifyTime: &Timing{Mean: 9 * time.Millisecond},
+	})
+	current.Add(&Result{Workload: "transfers", Target: RemoteTarget, TPS: 800})
+	current.Add(&Result{Workload: "new", Target: MemoryTarget, TPS: 1})
+
+	regressions := Compare(read, current, 0.1)
+	require.Len(regressions, 2)
+	require.Equal(&Regression{
+		Workload: "transfers",
+		Target:   MemoryTarget,
+		Metric:   "buildTime",
+		Baseline: float64(10 * time.Millisecond),
+		Current:  float64(12 * time.Millisecond),
+	}, regressions[0])
+	require.Equal(&Regression{
+		Workload: "transfers",
+		Target:   RemoteTarget,
+		Metric:   "tps",
+		Baseline: 1_000,
+		Current:  800,
+	}, regressions[1])
+	require.Empty(Compare(read, current, 0.5))
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchmark
+
+import "errors"
+
+var (
+	ErrInvalidWorkload = errors.New("invalid workload")
+	ErrTxDropped       = errors.New("tx dropped")
+)
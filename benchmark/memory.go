@@ -0,0 +1,75 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchmark
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/vm"
+	"github.com/ava-labs/hypersdk/vm/vmtest"
+)
+
+// RunMemory runs [w] against two in-memory VMs created by [newVM]: one builds
+// every block and the other verifies it, as a validator that didn't build
+// the block would. Blocks are built as soon as their transactions are
+// submitted, so the genesis in [config] should not enforce a minimum block
+// gap and must allow every batch of transactions to fit in a block.
+func RunMemory(t testing.TB, newVM func() *vm.VM, config vmtest.Config, w *Workload) *Result {
+	require := require.New(t)
+	require.NoError(w.verify())
+	ctx := context.Background()
+
+	if config.ChainID == ids.Empty {
+		config.ChainID = ids.GenerateTestID()
+	}
+	builder := vmtest.New(t, newVM(), config)
+	verifier := vmtest.New(t, newVM(), config)
+
+	var (
+		result = &Result{
+			Workload: w.Name,
+			Target:   MemoryTarget,
+			Blocks:   w.Blocks,
+		}
+		buildTimes  = make([]time.Duration, 0, w.Blocks)
+		verifyTimes = make([]time.Duration, 0, w.Blocks)
+		txs         = make([]*chain.Transaction, w.TxsPerBlock)
+	)
+	for b := 0; b < w.Blocks; b++ {
+		for i := range txs {
+			actions, factory := w.Tx(b*w.TxsPerBlock + i)
+			txs[i] = builder.GenerateTx(actions, factory)
+		}
+
+		start := time.Now()
+		builder.Submit(ctx, txs...)
+		buildStart := time.Now()
+		blk := builder.BuildBlock(ctx)
+		buildTimes = append(buildTimes, time.Since(buildStart))
+		require.Equal(len(txs), len(blk.Txs), "block does not fit the workload (the max block units of the genesis are too low)")
+		verifyStart := time.Now()
+		verified := verifier.VerifyBlock(ctx, blk.Bytes())
+		verifyTimes = append(verifyTimes, time.Since(verifyStart))
+		builder.AcceptBlock(ctx, blk)
+		results := verifier.AcceptBlock(ctx, verified)
+		result.Duration += time.Since(start)
+
+		result.Txs += len(results)
+		for _, r := range results {
+			if !r.Success {
+				result.Failed++
+			}
+		}
+	}
+	result.BuildTime = newTiming(buildTimes)
+	result.VerifyTime = newTiming(verifyTimes)
+	result.TPS = float64(result.Txs) / result.Duration.Seconds()
+	return result
+}
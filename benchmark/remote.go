@@ -0,0 +1,82 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+// RunRemote runs [w] against the node at [uri] (like a validator of a network
+// launched by the devnet package). Each batch of [Workload.TxsPerBlock]
+// transactions is submitted over a websocket once the previous batch was
+// accepted, so the batches of a [Result] may span any number of blocks.
+func RunRemote(ctx context.Context, uri string, parser chain.Parser, w *Workload) (*Result, error) {
+	if err := w.verify(); err != nil {
+		return nil, err
+	}
+	cli := rpc.NewJSONRPCClient(uri)
+	ws, err := rpc.NewWebSocketClient(uri, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.Close()
+
+	result := &Result{
+		Workload: w.Name,
+		Target:   RemoteTarget,
+		Blocks:   w.Blocks,
+	}
+	txs := make([]*chain.Transaction, w.TxsPerBlock)
+	for b := 0; b < w.Blocks; b++ {
+		unitPrices, err := cli.UnitPrices(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+		for i := range txs {
+			actions, factory := w.Tx(b*w.TxsPerBlock + i)
+			units, err := chain.EstimateUnits(parser.Rules(time.Now().UnixMilli()), actions, factory)
+			if err != nil {
+				return nil, err
+			}
+			maxFee, err := fees.MulSum(unitPrices, units)
+			if err != nil {
+				return nil, err
+			}
+			_, txs[i], err = cli.GenerateTransactionManual(parser, actions, factory, maxFee)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		start := time.Now()
+		for _, tx := range txs {
+			if err := ws.RegisterTx(tx); err != nil {
+				return nil, err
+			}
+		}
+		for range txs {
+			txID, dErr, txResult, err := ws.ListenTx(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if dErr != nil {
+				return nil, fmt.Errorf("%w: %s (%w)", ErrTxDropped, txID, dErr)
+			}
+			result.Txs++
+			if !txResult.Success {
+				result.Failed++
+			}
+		}
+		result.Duration += time.Since(start)
+	}
+	result.TPS = float64(result.Txs) / result.Duration.Seconds()
+	return result, nil
+}
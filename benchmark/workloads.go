@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchmark
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// TransferFunc returns an action that transfers [value] of the native asset
+// of a hypervm to [to].
+type TransferFunc func(to codec.Address, value uint64) chain.Action
+
+// Transfers returns the standard workloads of [blocks] blocks of
+// [txsPerBlock] transfers, which differ in how the transactions of a block
+// conflict:
+//   - "transfers-disjoint": each transaction has its own sender and
+//     recipient, so all of them can execute in parallel
+//   - "transfers-hotspot": each transaction has its own sender but they all
+//     pay the same recipient
+//   - "transfers-serial": all transactions are sent by the same account
+//
+// [factories] must control at least [txsPerBlock] funded accounts for the
+// first two workloads to keep senders distinct.
+func Transfers(blocks int, txsPerBlock int, factories []chain.AuthFactory, transfer TransferFunc) []*Workload {
+	recipient := func(j int) codec.Address {
+		return codec.CreateAddress(0, ids.Empty.Prefix(uint64(j)))
+	}
+	workload := func(name string, tx func(i int, j int) ([]chain.Action, chain.AuthFactory)) *Workload {
+		return &Workload{
+			Name:        name,
+			Blocks:      blocks,
+			TxsPerBlock: txsPerBlock,
+			Tx: func(i int) ([]chain.Action, chain.AuthFactory) {
+				return tx(i, i%txsPerBlock)
+			},
+		}
+	}
+	// The value of each transfer is unique, so senders never issue the same
+	// transaction twice
+	return []*Workload{
+		workload("transfers-disjoint", func(i int, j int) ([]chain.Action, chain.AuthFactory) {
+			return []chain.Action{transfer(recipient(j), uint64(i+1))}, factories[j%len(factories)]
+		}),
+		workload("transfers-hotspot", func(i int, j int) ([]chain.Action, chain.AuthFactory) {
+			return []chain.Action{transfer(recipient(0), uint64(i+1))}, factories[j%len(factories)]
+		}),
+		workload("transfers-serial", func(i int, j int) ([]chain.Action, chain.AuthFactory) {
+			return []chain.Action{transfer(recipient(j), uint64(i+1))}, factories[0]
+		}),
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bridge defines the warp payload of the reference token bridge
+// between the morpheusvm and the tokenvm, which any pair of hypervms can use
+// to move fungible assets between their chains.
+//
+// The bridge follows a lock/mint and burn/release pattern:
+//
+//  1. On the origin chain, funds are locked (escrowed for the destination
+//     chain) and a [Transfer] is sent to the destination chain.
+//  2. On the destination chain, a verified [Transfer] mints the same amount of
+//     a wrapped asset identified by [WrappedAssetID].
+//  3. Burning the wrapped asset sends a [Transfer] with [Transfer.Return] set
+//     back to the origin chain, which releases funds locked for the chain that
+//     burned them.
+//
+// Every chain must only accept each message once (by recording the IDs of
+// consumed messages in state) and must only release funds that were locked for
+// the [warp.UnsignedMessage.SourceChainID] of the message.
+//
+// A [Transfer] may also carry a [Transfer.Fee], which is locked (or burned)
+// together with its value when it is sent and paid on the destination chain to
+// whoever submits the transaction that delivers it. This rewards relayers
+// without requiring them to be trusted: the fee is only paid once the message
+// is delivered and only out of funds escrowed by the sender.
+package bridge
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const MaxSymbolSize = 8
+
+var (
+	ErrValueZero        = errors.New("value is zero")
+	ErrWrongDestination = errors.New("transfer is not addressed to this chain")
+)
+
+// Transfer is the payload of a warp message that moves [Value] of [Asset] to
+// [To] on [DestinationChainID].
+type Transfer struct {
+	// ActionID is the ID of the action that sent the transfer, which makes
+	// the ID of every message unique.
+	ActionID ids.ID `json:"actionID"`
+
+	DestinationChainID ids.ID `json:"destinationChainID"`
+
+	// Asset is the ID of the asset on the chain it was created on (ids.Empty
+	// for the native asset of a chain).
+	Asset    ids.ID `json:"asset"`
+	Symbol   []byte `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+
+	To    codec.Address `json:"to"`
+	Value uint64        `json:"value"`
+
+	// Fee is paid to the actor that delivers the transfer on
+	// [DestinationChainID] (in addition to [Value]).
+	Fee uint64 `json:"fee"`
+
+	// Return is true if the transfer sends a wrapped asset back to the chain
+	// it was created on.
+	Return bool `json:"return"`
+}
+
+func (t *Transfer) Size() int {
+	return ids.IDLen*3 + codec.BytesLen(t.Symbol) + consts.Uint8Len + codec.AddressLen + consts.Uint64Len*2 + consts.BoolLen
+}
+
+func (t *Transfer) Marshal(p *codec.Packer) {
+	p.PackID(t.ActionID)
+	p.PackID(t.DestinationChainID)
+	p.PackID(t.Asset)
+	p.PackBytes(t.Symbol)
+	p.PackByte(t.Decimals)
+	p.PackAddress(t.To)
+	p.PackUint64(t.Value)
+	p.PackUint64(t.Fee)
+	p.PackBool(t.Return)
+}
+
+// Total is the amount that must be escrowed to send [t].
+func (t *Transfer) Total() (uint64, error) {
+	return smath.Add64(t.Value, t.Fee)
+}
+
+func (t *Transfer) Bytes() []byte {
+	p := codec.NewWriter(t.Size(), t.Size())
+	t.Marshal(p)
+	return p.Bytes()
+}
+
+func UnmarshalTransfer(b []byte) (*Transfer, error) {
+	var transfer Transfer
+	p := codec.NewReader(b, len(b))
+	p.UnpackID(true, &transfer.ActionID)
+	p.UnpackID(true, &transfer.DestinationChainID)
+	p.UnpackID(false, &transfer.Asset)
+	p.UnpackBytes(MaxSymbolSize, true, &transfer.Symbol)
+	transfer.Decimals = p.UnpackByte()
+	p.UnpackAddress(&transfer.To)
+	transfer.Value = p.UnpackUint64(true)
+	transfer.Fee = p.UnpackUint64(false)
+	transfer.Return = p.UnpackBool()
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &transfer, nil
+}
+
+// ParseTransfer returns the [Transfer] carried by [msg] if it is addressed to
+// [chainID].
+func ParseTransfer(msg *warp.UnsignedMessage, chainID ids.ID) (*Transfer, error) {
+	transfer, err := UnmarshalTransfer(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.DestinationChainID != chainID {
+		return nil, ErrWrongDestination
+	}
+	return transfer, nil
+}
+
+// Filter returns true if [msg] is a [Transfer] addressed to
+// [destinationChainID]. It can be used to only relay bridge transfers.
+func Filter(destinationChainID ids.ID, msg *warp.UnsignedMessage) bool {
+	_, err := ParseTransfer(msg, destinationChainID)
+	return err == nil
+}
+
+// FeeFilter returns a filter that only accepts transfers addressed to the
+// destination chain that pay a relay fee of at least [minFee].
+func FeeFilter(minFee uint64) func(ids.ID, *warp.UnsignedMessage) bool {
+	return func(destinationChainID ids.ID, msg *warp.UnsignedMessage) bool {
+		transfer, err := ParseTransfer(msg, destinationChainID)
+		return err == nil && transfer.Fee >= minFee
+	}
+}
+
+// WrappedAssetID is the ID of the asset minted on the destination chain for
+// [asset] created on [sourceChainID].
+func WrappedAssetID(sourceChainID ids.ID, asset ids.ID) ids.ID {
+	k := make([]byte, 0, ids.IDLen*2)
+	k = append(k, sourceChainID[:]...)
+	k = append(k, asset[:]...)
+	return utils.ToID(k)
+}
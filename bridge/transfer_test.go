@@ -0,0 +1,70 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bridge
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+func TestTransfer(t *testing.T) {
+	require := require.New(t)
+
+	destination := ids.GenerateTestID()
+	transfer := &Transfer{
+		ActionID:           ids.GenerateTestID(),
+		DestinationChainID: destination,
+		Symbol:             []byte("RED"),
+		Decimals:           9,
+		To:                 codec.CreateAddress(0, ids.GenerateTestID()),
+		Value:              100,
+		Fee:                5,
+	}
+	b := transfer.Bytes()
+	require.Len(b, transfer.Size())
+
+	msg, err := warp.NewUnsignedMessage(1, ids.GenerateTestID(), b)
+	require.NoError(err)
+	parsed, err := ParseTransfer(msg, destination)
+	require.NoError(err)
+	require.Equal(transfer, parsed)
+	require.True(Filter(destination, msg))
+	require.True(FeeFilter(5)(destination, msg))
+	require.False(FeeFilter(6)(destination, msg))
+	total, err := transfer.Total()
+	require.NoError(err)
+	require.Equal(uint64(105), total)
+
+	_, err = ParseTransfer(msg, ids.GenerateTestID())
+	require.ErrorIs(err, ErrWrongDestination)
+	require.False(Filter(ids.GenerateTestID(), msg))
+	require.False(FeeFilter(0)(ids.GenerateTestID(), msg))
+
+	transfer.Value = math.MaxUint64
+	_, err = transfer.Total()
+	require.ErrorIs(err, smath.ErrOverflow)
+
+	_, err = UnmarshalTransfer(append(b, 0))
+	require.ErrorIs(err, chain.ErrInvalidObject)
+	require.False(Filter(destination, &warp.UnsignedMessage{Payload: []byte("not a transfer")}))
+}
+
+func TestWrappedAssetID(t *testing.T) {
+	require := require.New(t)
+
+	chainA := ids.GenerateTestID()
+	chainB := ids.GenerateTestID()
+	require.Equal(WrappedAssetID(chainA, ids.Empty), WrappedAssetID(chainA, ids.Empty))
+	require.NotEqual(WrappedAssetID(chainA, ids.Empty), WrappedAssetID(chainB, ids.Empty))
+	require.NotEqual(WrappedAssetID(chainA, ids.Empty), WrappedAssetID(chainA, chainB))
+}
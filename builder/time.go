@@ -11,6 +11,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
 )
 
 // minBuildGap ensures we don't build blocks too quickly (can fail
@@ -29,6 +31,9 @@ type Time struct {
 	timer     *timer.Timer
 	lastQueue int64
 	waiting   atomic.Bool
+	// waitingEmpty is set if the timer is waiting to build a block without
+	// transactions (which can be built sooner once transactions arrive)
+	waitingEmpty atomic.Bool
 }
 
 func NewTime(vm VM) *Time {
@@ -52,11 +57,20 @@ func (b *Time) handleTimerNotify() {
 		txs := b.vm.Mempool().Len(context.TODO())
 		b.vm.Logger().Debug("trigger to notify", zap.Int("txs", txs))
 	}
+	b.waitingEmpty.Store(false)
 	b.waiting.Store(false)
 }
 
-func (b *Time) nextTime(now int64, preferred int64) int64 {
-	gap := b.vm.Rules(now).GetMinBlockGap()
+// nextTime returns when to notify the engine to build a block on top of
+// [preferred] (or -1 if it should be notified now). If there are no
+// transactions, the engine is only notified once the empty block gap has
+// elapsed, so an idle chain still produces a block at least this often.
+func (b *Time) nextTime(now int64, preferred int64, empty bool) int64 {
+	r := b.vm.Rules(now)
+	gap := r.GetMinBlockGap()
+	if empty {
+		gap = max(gap, chain.EmptyBlockGap(r))
+	}
 	next := max(b.lastQueue+minBuildGap, preferred+gap)
 	if next < now {
 		return -1
@@ -65,18 +79,24 @@ func (b *Time) nextTime(now int64, preferred int64) int64 {
 }
 
 func (b *Time) Queue(ctx context.Context) {
+	empty := b.vm.Mempool().Len(ctx) == 0
 	if !b.waiting.CompareAndSwap(false, true) {
-		b.vm.Logger().Debug("unable to acquire waiting lock")
-		return
+		// If we are waiting to build an empty block, transactions that just
+		// arrived can be built into a block sooner
+		if empty || !b.waitingEmpty.CompareAndSwap(true, false) {
+			b.vm.Logger().Debug("unable to acquire waiting lock")
+			return
+		}
 	}
 	preferredBlk, err := b.vm.PreferredBlock(context.TODO())
 	if err != nil {
+		b.waitingEmpty.Store(false)
 		b.waiting.Store(false)
 		b.vm.Logger().Warn("unable to load preferred block", zap.Error(err))
 		return
 	}
 	now := time.Now().UnixMilli()
-	next := b.nextTime(now, preferredBlk.Tmstmp)
+	next := b.nextTime(now, preferredBlk.Tmstmp, empty)
 	if next < 0 {
 		if err := b.Force(ctx); err != nil {
 			b.vm.Logger().Warn("unable to build", zap.Error(err))
@@ -84,9 +104,11 @@ func (b *Time) Queue(ctx context.Context) {
 			txs := b.vm.Mempool().Len(context.TODO())
 			b.vm.Logger().Debug("notifying to build without waiting", zap.Int("txs", txs))
 		}
+		b.waitingEmpty.Store(false)
 		b.waiting.Store(false)
 		return
 	}
+	b.waitingEmpty.Store(empty)
 	sleep := next - now
 	sleepDur := time.Duration(sleep * int64(time.Millisecond))
 	b.timer.SetTimeoutIn(sleepDur)
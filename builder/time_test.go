@@ -0,0 +1,163 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+type testMempool struct {
+	chain.Mempool
+
+	len atomic.Int64
+}
+
+func (m *testMempool) Len(context.Context) int {
+	return int(m.len.Load())
+}
+
+type testVM struct {
+	rules     chain.Rules
+	mempool   *testMempool
+	preferred int64
+	engine    chan common.Message
+}
+
+func newTestVM(t *testing.T, minGap, minEmptyGap, maxEmptyGap int64, preferred int64) *testVM {
+	ctrl := gomock.NewController(t)
+	rules := chain.NewMockRules(ctrl)
+	rules.EXPECT().GetMinBlockGap().Return(minGap).AnyTimes()
+	rules.EXPECT().GetMinEmptyBlockGap().Return(minEmptyGap).AnyTimes()
+	rules.EXPECT().GetMaxEmptyBlockGap().Return(maxEmptyGap).AnyTimes()
+	return &testVM{
+		rules:     rules,
+		mempool:   &testMempool{},
+		preferred: preferred,
+		engine:    make(chan common.Message, 1),
+	}
+}
+
+func (*testVM) StopChan() chan struct{} { return nil }
+
+func (vm *testVM) EngineChan() chan<- common.Message { return vm.engine }
+
+func (vm *testVM) PreferredBlock(context.Context) (*chain.StatelessBlock, error) {
+	return &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Tmstmp: vm.preferred}}, nil
+}
+
+func (*testVM) Logger() logging.Logger { return logging.NoLog{} }
+
+func (vm *testVM) Mempool() chain.Mempool { return vm.mempool }
+
+func (vm *testVM) Rules(int64) chain.Rules { return vm.rules }
+
+func TestTimeNextTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		minEmptyGap int64
+		maxEmptyGap int64
+		empty       bool
+		now         int64
+		next        int64
+	}{
+		{
+			name:        "txs wait for min gap",
+			minEmptyGap: 300,
+			maxEmptyGap: 1_000,
+			now:         10_000,
+			next:        10_100,
+		},
+		{
+			name:        "empty waits for max empty gap",
+			minEmptyGap: 300,
+			maxEmptyGap: 1_000,
+			empty:       true,
+			now:         10_000,
+			next:        11_000,
+		},
+		{
+			name:        "empty waits for min empty gap",
+			minEmptyGap: 1_000,
+			maxEmptyGap: 300,
+			empty:       true,
+			now:         10_000,
+			next:        11_000,
+		},
+		{
+			name:        "empty gap elapsed",
+			minEmptyGap: 300,
+			maxEmptyGap: 1_000,
+			empty:       true,
+			now:         11_001,
+			next:        -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := newTestVM(t, 100, tt.minEmptyGap, tt.maxEmptyGap, 10_000)
+			b := NewTime(vm)
+			require.Equal(t, tt.next, b.nextTime(tt.now, vm.preferred, tt.empty))
+		})
+	}
+}
+
+func TestTimeQueueEmpty(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Now()
+	vm := newTestVM(t, 10, 50, 250, start.UnixMilli())
+	b := NewTime(vm)
+	go b.timer.Dispatch()
+	defer b.Done()
+
+	// Without transactions, the engine is only notified once the max empty
+	// block gap has elapsed
+	b.Queue(context.Background())
+	select {
+	case msg := <-vm.engine:
+		require.Equal(common.PendingTxs, msg)
+		require.GreaterOrEqual(time.Since(start), 200*time.Millisecond)
+	case <-time.After(5 * time.Second):
+		require.FailNow("engine not notified")
+	}
+}
+
+func TestTimeQueueTxsArrive(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestVM(t, 10, 50, time.Hour.Milliseconds(), time.Now().UnixMilli())
+	b := NewTime(vm)
+	go b.timer.Dispatch()
+	defer b.Done()
+
+	// Wait for an empty block
+	b.Queue(context.Background())
+	require.True(b.waiting.Load())
+	require.True(b.waitingEmpty.Load())
+
+	// Queueing again without transactions does not change anything
+	b.Queue(context.Background())
+	require.True(b.waitingEmpty.Load())
+
+	// Once transactions arrive, the engine is notified after the min block gap
+	vm.mempool.len.Store(1)
+	b.Queue(context.Background())
+	require.False(b.waitingEmpty.Load())
+	select {
+	case msg := <-vm.engine:
+		require.Equal(common.PendingTxs, msg)
+	case <-time.After(5 * time.Second):
+		require.FailNow("engine not notified")
+	}
+}
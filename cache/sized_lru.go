@@ -0,0 +1,121 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/linked"
+)
+
+// SizedLRU is a Least-Recently-Used cache that is bounded by the
+// total size of its items (as computed by [size]) instead of by
+// the number of items it contains.
+type SizedLRU[K comparable, V any] struct {
+	l           sync.Mutex
+	elements    *linked.Hashmap[K, V]
+	maxLen      int // 0 if the number of items is not bounded
+	maxSize     int
+	currentSize int
+	size        func(K, V) int
+}
+
+// NewSizedLRU creates a new [SizedLRU] that will hold at most [maxSize]
+// worth of items.
+func NewSizedLRU[K comparable, V any](maxSize int, size func(K, V) int) *SizedLRU[K, V] {
+	return &SizedLRU[K, V]{
+		elements: linked.NewHashmap[K, V](),
+		maxSize:  maxSize,
+		size:     size,
+	}
+}
+
+// NewLimitedLRU creates a new [SizedLRU] that will hold at most [maxLen]
+// items and at most [maxSize] worth of items.
+func NewLimitedLRU[K comparable, V any](maxLen int, maxSize int, size func(K, V) int) *SizedLRU[K, V] {
+	c := NewSizedLRU(maxSize, size)
+	c.maxLen = maxLen
+	return c
+}
+
+// Put inserts (or replaces) [key] and returns the number of items
+// evicted to make room for it.
+//
+// If the item is larger than [maxSize], it is not stored.
+func (c *SizedLRU[K, V]) Put(key K, val V) int {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	c.evict(key)
+	newSize := c.size(key, val)
+	if newSize > c.maxSize {
+		return 0
+	}
+	evicted := 0
+	for c.currentSize+newSize > c.maxSize || (c.maxLen > 0 && c.elements.Len() >= c.maxLen) {
+		oldestKey, oldestVal, _ := c.elements.Oldest()
+		c.elements.Delete(oldestKey)
+		c.currentSize -= c.size(oldestKey, oldestVal)
+		evicted++
+	}
+	c.elements.Put(key, val)
+	c.currentSize += newSize
+	return evicted
+}
+
+// Get returns the value of [key] (if it exists) and marks it as
+// the most recently used item.
+func (c *SizedLRU[K, V]) Get(key K) (V, bool) {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	val, ok := c.elements.Get(key)
+	if !ok {
+		return val, false
+	}
+	c.elements.Put(key, val) // mark as most recently used
+	return val, true
+}
+
+// Evict removes [key] from the cache, if it exists.
+func (c *SizedLRU[K, V]) Evict(key K) {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	c.evict(key)
+}
+
+// Flush removes all items from the cache.
+func (c *SizedLRU[K, V]) Flush() {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	c.elements.Clear()
+	c.currentSize = 0
+}
+
+// Size returns the total size of all items in the cache.
+func (c *SizedLRU[K, V]) Size() int {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	return c.currentSize
+}
+
+// Len returns the number of items in the cache.
+func (c *SizedLRU[K, V]) Len() int {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	return c.elements.Len()
+}
+
+func (c *SizedLRU[K, V]) evict(key K) {
+	val, ok := c.elements.Get(key)
+	if !ok {
+		return
+	}
+	c.elements.Delete(key)
+	c.currentSize -= c.size(key, val)
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// ActionActivation schedules the activation and deactivation of an action
+// type (like in the upgrade bytes of a chain), so a fork can introduce or
+// retire it (see [codec.TypeParser.SetActivation]).
+type ActionActivation struct {
+	TypeID uint8 `json:"typeID"`
+	// Activation is the timestamp (in ms) from which the action type is
+	// active (0 if it was always active)
+	Activation int64 `json:"activation"`
+	// Deactivation is the timestamp (in ms) from which the action type is no
+	// longer active (0 if it is never deactivated)
+	Deactivation int64 `json:"deactivation"`
+}
+
+// ScheduleActions returns a copy of [registry] in which the action types of
+// [schedule] are only active between their activation and deactivation.
+//
+// Transactions with an action that is not active at their expiry are rejected
+// when they are parsed and transactions with an action that is not active yet
+// can't be included in a block.
+func ScheduleActions(registry *codec.TypeParser[Action], schedule []*ActionActivation) (ActionRegistry, error) {
+	scheduled := registry.Clone()
+	for _, a := range schedule {
+		activation, deactivation := a.Activation, a.Deactivation
+		if activation <= 0 {
+			activation = -1
+		}
+		if deactivation <= 0 {
+			deactivation = -1
+		}
+		if err := scheduled.SetActivation(a.TypeID, activation, deactivation); err != nil {
+			return nil, fmt.Errorf("%w: action type %d", err, a.TypeID)
+		}
+	}
+	return scheduled, nil
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/ava-labs/hypersdk/consts"
 )
 
-const BaseSize = consts.Uint64Len*2 + ids.IDLen
+const BaseSize = consts.Uint64Len*3 + ids.IDLen
 
 type Base struct {
 	// Timestamp is the expiry of the transaction (inclusive). Once this time passes and the
@@ -27,6 +27,10 @@ type Base struct {
 	//
 	// If the fee is too low to pay all fees, the transaction will be dropped.
 	MaxFee uint64 `json:"maxFee"`
+
+	// Tip is paid on top of the base fee (as long as the sum does not exceed [MaxFee])
+	// to the [TipHandler] of the VM. It is ignored if the VM does not accept tips.
+	Tip uint64 `json:"tip"`
 }
 
 func (b *Base) Execute(chainID ids.ID, r Rules, timestamp int64) error {
@@ -53,6 +57,7 @@ func (b *Base) Marshal(p *codec.Packer) {
 	p.PackInt64(b.Timestamp)
 	p.PackID(b.ChainID)
 	p.PackUint64(b.MaxFee)
+	p.PackUint64(b.Tip)
 }
 
 func UnmarshalBase(p *codec.Packer) (*Base, error) {
@@ -64,5 +69,6 @@ func UnmarshalBase(p *codec.Packer) (*Base, error) {
 	}
 	p.UnpackID(true, &base.ChainID)
 	base.MaxFee = p.UnpackUint64(true)
+	base.Tip = p.UnpackUint64(false)
 	return &base, p.Err()
 }
@@ -6,6 +6,7 @@ package chain
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/fetcher"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/window"
@@ -29,8 +31,9 @@ import (
 )
 
 var (
-	_ snowman.Block      = &StatelessBlock{}
-	_ block.StateSummary = &SyncableBlock{}
+	_ snowman.Block           = &StatelessBlock{}
+	_ block.WithVerifyContext = &StatelessBlock{}
+	_ block.StateSummary      = &SyncableBlock{}
 )
 
 type StatefulBlock struct {
@@ -38,6 +41,10 @@ type StatefulBlock struct {
 	Tmstmp int64  `json:"timestamp"`
 	Hght   uint64 `json:"height"`
 
+	// Txs are the transactions executed by the block: the transactions of
+	// [Chunks] (in order) followed by the transactions included directly.
+	// The transactions of [Chunks] are only known once they are attached
+	// (see [AttachChunks]).
 	Txs []*Transaction `json:"txs"`
 
 	// StateRoot is the root of the post-execution state
@@ -50,6 +57,24 @@ type StatefulBlock struct {
 	// starting the verification of another block, etc.
 	StateRoot ids.ID `json:"stateRoot"`
 
+	// Chunks are the certificates of the chunks whose transactions are
+	// executed by the block (see [Chunk]).
+	Chunks []*ChunkCertificate `json:"chunks"`
+
+	// Beacon derives the randomness read by the actions of the block (if
+	// [RandomnessRules] are enabled and the block was built with a block
+	// context).
+	Beacon *Beacon `json:"beacon"`
+
+	// Proposer is credited with the proposer rewards of the block (see
+	// [ProposerRewardRules]). It must be set if and only if proposer rewards
+	// are enabled at [Tmstmp] (except in the genesis block), and may be
+	// [codec.EmptyAddress] (in which case they are burned).
+	Proposer *codec.Address `json:"proposer"`
+
+	chunks   []*Chunk
+	chunkTxs int // number of [Txs] attached from [chunks]
+
 	size int
 
 	// authCounts can be used by batch signature verification
@@ -61,6 +86,44 @@ func (b *StatefulBlock) Size() int {
 	return b.size
 }
 
+// AttachChunks prepends the transactions of [chunks] (the chunks certified by
+// [Chunks], in order) to [Txs].
+func (b *StatefulBlock) AttachChunks(chunks []*Chunk) error {
+	if b.chunks != nil {
+		return nil
+	}
+	if len(chunks) != len(b.Chunks) {
+		return fmt.Errorf("%w: expected %d chunks, got %d", ErrChunkMismatch, len(b.Chunks), len(chunks))
+	}
+	txs := []*Transaction{}
+	for i, chunk := range chunks {
+		if chunk.ID() != b.Chunks[i].Chunk {
+			return fmt.Errorf("%w: expected=%s found=%s", ErrChunkMismatch, b.Chunks[i].Chunk, chunk.ID())
+		}
+		txs = append(txs, chunk.Txs...)
+	}
+	if b.authCounts == nil {
+		b.authCounts = map[uint8]int{}
+	}
+	for _, tx := range txs {
+		b.authCounts[tx.Auth.GetTypeID()]++
+	}
+	b.Txs = append(txs, b.Txs...)
+	b.chunks = chunks
+	b.chunkTxs = len(txs)
+	return nil
+}
+
+// GetChunks returns the chunks attached to [b] (see [AttachChunks]).
+func (b *StatefulBlock) GetChunks() []*Chunk {
+	return b.chunks
+}
+
+// chunksAttached is true if [b] does not include chunks or they are attached.
+func (b *StatefulBlock) chunksAttached() bool {
+	return len(b.Chunks) == 0 || b.chunks != nil
+}
+
 func (b *StatefulBlock) ID() (ids.ID, error) {
 	blk, err := b.Marshal()
 	if err != nil {
@@ -98,13 +161,21 @@ type StatelessBlock struct {
 	bytes  []byte
 	txsSet set.Set[ids.ID]
 
-	results    []*Result
-	feeManager *fees.Manager
+	// containsWarp is true if any transaction carries an incoming warp
+	// message, which requires the block context to verify.
+	containsWarp bool
+	bctx         *block.Context
+
+	results      []*Result
+	feeManager   *fees.Manager
+	feeSplit     *FeeSplit
+	stateChanges []*StateChange
 
 	vm   VM
 	view merkledb.View
 
-	sigJob workers.Job
+	sigJob       workers.Job
+	stopPrefetch context.CancelFunc
 }
 
 func NewBlock(vm VM, parent snowman.Block, tmstp int64) *StatelessBlock {
@@ -132,6 +203,21 @@ func ParseBlock(
 	if err != nil {
 		return nil, err
 	}
+	if len(blk.Chunks) > 0 {
+		// Chunks that are not stored locally are never fetched while parsing.
+		// Processing blocks attach them once they are available (see
+		// [StatelessBlock.attachChunks]).
+		chunks, err := vm.GetChunks(ctx, blk.Chunks)
+		switch {
+		case err == nil:
+			if err := blk.AttachChunks(chunks); err != nil {
+				return nil, err
+			}
+		case errors.Is(err, ErrChunksNotAvailable) && status == choices.Processing:
+		default:
+			return nil, err
+		}
+	}
 	// Not guaranteed that a parsed block is verified
 	return ParseStatefulBlock(ctx, blk, source, status, vm)
 }
@@ -166,6 +252,9 @@ func (b *StatelessBlock) populateTxs(ctx context.Context) error {
 			return ErrDuplicateTx
 		}
 		b.txsSet.Add(tx.ID())
+		if len(tx.WarpMessages()) > 0 {
+			b.containsWarp = true
+		}
 
 		// Verify signature async
 		if b.vm.GetVerifyAuth() {
@@ -190,7 +279,7 @@ func ParseStatefulBlock(
 	defer span.End()
 
 	// Perform basic correctness checks before doing any expensive work
-	if blk.Tmstmp > time.Now().Add(FutureBound).UnixMilli() {
+	if blk.Tmstmp > time.Now().UnixMilli()+vm.Rules(blk.Tmstmp).GetBlockTimestampTolerance() {
 		return nil, ErrTimestampTooLate
 	}
 
@@ -217,8 +306,83 @@ func ParseStatefulBlock(
 		return b, nil
 	}
 
+	// The transactions of chunks that are not attached yet are populated once
+	// they are attached
+	if !b.chunksAttached() {
+		return b, nil
+	}
+
 	// Populate hashes and tx set
-	return b, b.populateTxs(ctx)
+	if err := b.populateTxs(ctx); err != nil {
+		return nil, err
+	}
+
+	// Begin loading state while signatures are verified
+	b.prefetchState()
+	return b, nil
+}
+
+// prefetchState asynchronously loads all keys that will be touched during
+// the execution of [b] from disk. This populates the caches of [merkledb],
+// so that reads during execution do not need to go to disk.
+//
+// Because it is not guaranteed that the parent of [b] is verified (or that
+// it will be by the time we verify [b]), we prefetch from the accepted state.
+// Any key modified by a processing ancestor will be read again during execution.
+//
+// Keys are loaded by the workers of a new [fetcher.Fetcher] (not the shared
+// workers that verify signatures), so prefetching never delays verification.
+func (b *StatelessBlock) prefetchState() {
+	if !b.vm.GetStatePrefetch() || len(b.Txs) == 0 {
+		return
+	}
+	db, err := b.vm.State()
+	if err != nil {
+		// State is not ready, so there is nothing to prefetch
+		return
+	}
+
+	// We compute state keys synchronously because they are cached on
+	// each transaction (and modifying them async would race with execution).
+	var (
+		sm        = b.vm.StateManager()
+		stateKeys = make([]state.Keys, len(b.Txs))
+	)
+	for i, tx := range b.Txs {
+		txStateKeys, err := tx.StateKeys(sm)
+		if err != nil {
+			// Block will fail verification
+			return
+		}
+		stateKeys[i] = txStateKeys
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.stopPrefetch = cancel
+	go func() {
+		defer cancel()
+
+		start := time.Now()
+		f := fetcher.New(db, len(b.Txs), b.vm.GetStateFetchConcurrency(), b.vm.GetStateFetchRecorder())
+		go func() {
+			<-ctx.Done()
+			f.Stop()
+		}()
+		for i, tx := range b.Txs {
+			if err := f.Fetch(ctx, tx.ID(), stateKeys[i]); err != nil {
+				return
+			}
+		}
+		if err := f.Wait(); err != nil {
+			b.vm.Logger().Debug("state prefetch stopped",
+				zap.Uint64("height", b.Hght),
+				zap.Stringer("blkID", b.ID()),
+				zap.Error(err),
+			)
+			return
+		}
+		b.vm.RecordStatePrefetch(time.Since(start))
+	}()
 }
 
 // [initializeBuilt] is invoked after a block is built
@@ -244,13 +408,99 @@ func (b *StatelessBlock) initializeBuilt(
 	b.txsSet = set.NewSet[ids.ID](len(b.Txs))
 	for _, tx := range b.Txs {
 		b.txsSet.Add(tx.ID())
+		if len(tx.WarpMessages()) > 0 {
+			b.containsWarp = true
+		}
+	}
+	return nil
+}
+
+// attachChunks attaches the chunks of [b] that were not stored locally when it
+// was parsed and populates their transactions. It returns
+// [ErrChunksNotAvailable] if they have not been fetched yet.
+func (b *StatelessBlock) attachChunks(ctx context.Context) error {
+	if b.chunksAttached() {
+		return nil
+	}
+	chunks, err := b.vm.GetChunks(ctx, b.Chunks)
+	if err != nil {
+		return err
+	}
+	if err := b.AttachChunks(chunks); err != nil {
+		return err
+	}
+	if err := b.populateTxs(ctx); err != nil {
+		return err
+	}
+	b.prefetchState()
+	return nil
+}
+
+func (b *StatelessBlock) verifyChunks(ctx context.Context, r Rules) error {
+	_, span := b.vm.Tracer().Start(ctx, "StatelessBlock.Verify.Chunks")
+	defer span.End()
+
+	if len(b.Chunks) > MaxBlockChunks {
+		return fmt.Errorf("%w: %d", ErrTooManyChunks, len(b.Chunks))
+	}
+	chunkIDs := set.NewSet[ids.ID](len(b.Chunks))
+	for i, cert := range b.Chunks {
+		if chunkIDs.Contains(cert.Chunk) {
+			return fmt.Errorf("%w: %s", ErrDuplicateChunk, cert.Chunk)
+		}
+		chunkIDs.Add(cert.Chunk)
+		if b.chunks[i].Expiry < b.Tmstmp {
+			return fmt.Errorf("%w: %s", ErrChunkExpired, cert.Chunk)
+		}
+		if err := cert.Verify(ctx, r, b.vm.ValidatorState(), b.bctx.PChainHeight); err != nil {
+			return fmt.Errorf("%w: chunk %s", err, cert.Chunk)
+		}
 	}
 	return nil
 }
 
+// verifyBeacon ensures [b] includes a valid [Beacon] if and only if it is
+// required. Blocks verified without a block context were built without one,
+// so they can't include a beacon (see [BuildBlock]).
+func (b *StatelessBlock) verifyBeacon(ctx context.Context, r Rules) error {
+	switch {
+	case b.Beacon == nil:
+		if randomnessEnabled(r) && b.bctx != nil {
+			return ErrMissingBeacon
+		}
+		return nil
+	case !randomnessEnabled(r):
+		return ErrUnexpectedBeacon
+	case b.bctx == nil:
+		return ErrMissingBlockContext
+	}
+	_, span := b.vm.Tracer().Start(ctx, "StatelessBlock.Verify.Beacon")
+	defer span.End()
+
+	return b.Beacon.Verify(ctx, r, b.vm.ValidatorState(), b.bctx.PChainHeight, b.Prnt)
+}
+
 // implements "snowman.Block.choices.Decidable"
 func (b *StatelessBlock) ID() ids.ID { return b.id }
 
+// implements "block.WithVerifyContext"
+func (b *StatelessBlock) ShouldVerifyWithContext(context.Context) (bool, error) {
+	return b.containsWarp || len(b.Chunks) > 0 || b.Beacon != nil || randomnessEnabled(b.vm.Rules(b.Tmstmp)), nil
+}
+
+// implements "block.WithVerifyContext"
+func (b *StatelessBlock) VerifyWithContext(ctx context.Context, bctx *block.Context) error {
+	// The P-Chain height is the height at which the validator set is read to
+	// verify:
+	//   - the signatures of incoming warp messages
+	//   - the certificates of the chunks included by the block
+	//   - the signature of the beacon, which derives the randomness read by
+	//     its actions (a beacon is only required if the block is verified
+	//     with a context, see [verifyBeacon])
+	b.bctx = bctx
+	return b.Verify(ctx)
+}
+
 // implements "snowman.Block"
 func (b *StatelessBlock) Verify(ctx context.Context) error {
 	start := time.Now()
@@ -258,6 +508,17 @@ func (b *StatelessBlock) Verify(ctx context.Context) error {
 		b.vm.RecordBlockVerify(time.Since(start))
 	}()
 
+	// Blocks can't be verified (or accepted) without the transactions of
+	// their chunks
+	if err := b.attachChunks(ctx); err != nil {
+		b.vm.Logger().Warn("unable to attach chunks",
+			zap.Uint64("height", b.Hght),
+			zap.Stringer("blkID", b.ID()),
+			zap.Error(err),
+		)
+		return err
+	}
+
 	stateReady := b.vm.StateReady()
 	ctx, span := b.vm.Tracer().Start(
 		ctx, "StatelessBlock.Verify",
@@ -344,9 +605,15 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 	)
 
 	// Perform basic correctness checks before doing any expensive work
-	if b.Timestamp().UnixMilli() > time.Now().Add(FutureBound).UnixMilli() {
+	if b.Tmstmp > time.Now().UnixMilli()+r.GetBlockTimestampTolerance() {
 		return ErrTimestampTooLate
 	}
+	switch rewards := proposerRewardPercentage(r) > 0; {
+	case rewards && b.Proposer == nil:
+		return ErrMissingProposer
+	case !rewards && b.Proposer != nil:
+		return ErrUnexpectedProposer
+	}
 
 	// Fetch view where we will apply block state transitions
 	//
@@ -406,6 +673,43 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 		}
 	}
 
+	// Ensure incoming warp messages are signed by their source subnet
+	//
+	// If a block is already accepted, the network has already verified them
+	// (and the block context is not provided when re-executing it).
+	if b.containsWarp && b.st != choices.Accepted {
+		if b.bctx == nil {
+			return ErrMissingBlockContext
+		}
+		_, warpSpan := b.vm.Tracer().Start(ctx, "StatelessBlock.Verify.WarpMessages")
+		for _, tx := range b.Txs {
+			if err := tx.VerifyWarpMessages(ctx, b.vm.StateManager(), r, b.vm.ValidatorState(), b.bctx.PChainHeight); err != nil {
+				warpSpan.End()
+				return fmt.Errorf("%w: tx %s", err, tx.ID())
+			}
+		}
+		warpSpan.End()
+	}
+
+	// Ensure chunks are certified by the validators at the P-Chain height of
+	// the block (for the same reason as incoming warp messages)
+	if len(b.Chunks) > 0 && b.st != choices.Accepted {
+		if b.bctx == nil {
+			return ErrMissingBlockContext
+		}
+		if err := b.verifyChunks(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	// Ensure the beacon is signed by a validator at the P-Chain height of the
+	// block (for the same reason as incoming warp messages)
+	if b.st != choices.Accepted {
+		if err := b.verifyBeacon(ctx, r); err != nil {
+			return err
+		}
+	}
+
 	// Compute next unit prices to use
 	feeKey := FeeKey(b.vm.StateManager().FeeKey())
 	feeRaw, err := parentView.GetValue(ctx, feeKey)
@@ -419,7 +723,7 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 	}
 
 	// Process transactions
-	results, ts, err := b.Execute(ctx, b.vm.Tracer(), parentView, feeManager, r)
+	results, ts, err := b.Execute(withRandomness(withHeight(ctx, b.Hght), b.Beacon), b.vm.Tracer(), parentView, feeManager, r)
 	if err != nil {
 		log.Error("failed to execute block", zap.Error(err))
 		return err
@@ -427,6 +731,22 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 	b.results = results
 	b.feeManager = feeManager
 
+	// Credit the proposer with its share of the base fees
+	feeSplit, err := rewardProposer(ctx, b.vm.StateManager(), r, parentView, ts, b.Proposer, results)
+	if err != nil {
+		return err
+	}
+	b.feeSplit = feeSplit
+
+	// Remove expired keys
+	if rm, ok := b.vm.StateManager().(RentManager); ok {
+		swept, err := sweepRent(ctx, rm, parentView, ts, b.Tmstmp)
+		if err != nil {
+			return err
+		}
+		b.vm.RecordRentSwept(swept)
+	}
+
 	// Update chain metadata
 	heightKeyStr := string(heightKey)
 	timestampKeyStr := string(timestampKey)
@@ -486,6 +806,7 @@ func (b *StatelessBlock) innerVerify(ctx context.Context, vctx VerifyContext) er
 	// Get view from [tstate] after processing all state transitions
 	b.vm.RecordStateChanges(ts.PendingChanges())
 	b.vm.RecordStateOperations(ts.OpIndex())
+	b.stateChanges = newStateChanges(ts.ChangedKeys())
 	view, err := ts.ExportMerkleDBView(ctx, b.vm.Tracer(), parentView)
 	if err != nil {
 		return err
@@ -570,6 +891,9 @@ func (b *StatelessBlock) Accept(ctx context.Context) error {
 
 func (b *StatelessBlock) MarkAccepted(ctx context.Context) {
 	// Accept block and free unnecessary memory
+	if b.stopPrefetch != nil {
+		b.stopPrefetch()
+	}
 	b.st = choices.Accepted
 	b.txsSet = nil // only used for replay protection when processing
 
@@ -585,6 +909,9 @@ func (b *StatelessBlock) Reject(ctx context.Context) error {
 	ctx, span := b.vm.Tracer().Start(ctx, "StatelessBlock.Reject")
 	defer span.End()
 
+	if b.stopPrefetch != nil {
+		b.stopPrefetch()
+	}
 	b.st = choices.Rejected
 	b.vm.Rejected(ctx, b)
 	return nil
@@ -798,11 +1125,34 @@ func (b *StatelessBlock) FeeManager() *fees.Manager {
 	return b.feeManager
 }
 
+// FeeSplit returns how the base fees collected by [b] were split between its
+// proposer and burning them, which is only known if [b] was executed.
+func (b *StatelessBlock) FeeSplit() *FeeSplit {
+	return b.feeSplit
+}
+
+// StateChanges returns the changes [b] made to state (sorted by key), which
+// are only known if [b] was executed.
+func (b *StatelessBlock) StateChanges() []*StateChange {
+	return b.stateChanges
+}
+
 func (b *StatefulBlock) Marshal() ([]byte, error) {
+	if !b.chunksAttached() {
+		return nil, ErrChunksNotAttached
+	}
+	txs := b.Txs[b.chunkTxs:]
 	size := ids.IDLen + consts.Uint64Len + consts.Uint64Len +
 		consts.Uint64Len + window.WindowSliceSize +
-		consts.IntLen + codec.CummSize(b.Txs) +
-		ids.IDLen + consts.Uint64Len + consts.Uint64Len
+		consts.IntLen + codec.CummSize(txs) +
+		ids.IDLen + consts.Uint64Len + consts.Uint64Len +
+		consts.IntLen + codec.CummSize(b.Chunks)
+	if b.Proposer != nil {
+		size += codec.AddressLen
+	}
+	if b.Beacon != nil {
+		size += b.Beacon.Size()
+	}
 
 	p := codec.NewWriter(size, consts.NetworkSizeLimit)
 
@@ -810,16 +1160,34 @@ func (b *StatefulBlock) Marshal() ([]byte, error) {
 	p.PackInt64(b.Tmstmp)
 	p.PackUint64(b.Hght)
 
-	p.PackInt(len(b.Txs))
-	b.authCounts = map[uint8]int{}
-	for _, tx := range b.Txs {
+	p.PackInt(len(txs))
+	for _, tx := range txs {
 		if err := tx.Marshal(p); err != nil {
 			return nil, err
 		}
+	}
+	b.authCounts = map[uint8]int{}
+	for _, tx := range b.Txs {
 		b.authCounts[tx.Auth.GetTypeID()]++
 	}
 
 	p.PackID(b.StateRoot)
+
+	// Chunks, the beacon and the proposer are only encoded if there are any,
+	// so the encoding of blocks that include all of their transactions (and
+	// were built before proposer rewards were enabled) is unchanged
+	if len(b.Chunks) > 0 || b.Beacon != nil || b.Proposer != nil {
+		p.PackInt(len(b.Chunks))
+		for _, cert := range b.Chunks {
+			cert.Marshal(p)
+		}
+	}
+	if b.Beacon != nil {
+		b.Beacon.Marshal(p)
+	}
+	if b.Proposer != nil {
+		p.PackFixedBytes(b.Proposer[:])
+	}
 	bytes := p.Bytes()
 	if err := p.Err(); err != nil {
 		return nil, err
@@ -855,6 +1223,45 @@ func UnmarshalBlock(raw []byte, parser Parser) (*StatefulBlock, error) {
 
 	p.UnpackID(false, &b.StateRoot)
 
+	// Parse chunk certificates, beacon and proposer (if any)
+	if !p.Empty() {
+		chunkCount := p.UnpackInt(false)
+		if chunkCount > MaxBlockChunks {
+			return nil, fmt.Errorf("%w: %d", ErrTooManyChunks, chunkCount)
+		}
+		for i := 0; i < chunkCount; i++ {
+			cert, err := UnmarshalChunkCertificate(p)
+			if err != nil {
+				return nil, err
+			}
+			b.Chunks = append(b.Chunks, cert)
+		}
+
+		// The beacon and the proposer have a fixed size, so the bytes left
+		// tell which of them the block includes (whether the proposer must
+		// be present is checked during verification)
+		if len(raw)-p.Offset() > codec.AddressLen {
+			beacon, err := UnmarshalBeacon(p)
+			if err != nil {
+				return nil, err
+			}
+			b.Beacon = beacon
+		}
+		if !p.Empty() {
+			// The proposer address may be empty, so it is not unpacked with
+			// [UnpackAddress]
+			proposer := make([]byte, codec.AddressLen)
+			p.UnpackFixedBytes(codec.AddressLen, &proposer)
+			b.Proposer = &codec.Address{}
+			copy(b.Proposer[:], proposer)
+		}
+		if chunkCount == 0 && b.Beacon == nil && b.Proposer == nil {
+			// Blocks without chunks, a beacon or a proposer have a single
+			// encoding
+			return nil, fmt.Errorf("%w: empty chunks", ErrInvalidObject)
+		}
+	}
+
 	// Ensure no leftover bytes
 	if !p.Empty() {
 		return nil, fmt.Errorf("%w: remaining=%d", ErrInvalidObject, len(raw)-p.Offset())
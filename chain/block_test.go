@@ -0,0 +1,104 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+type testParser struct{}
+
+func (*testParser) Rules(int64) Rules { return nil }
+
+func (*testParser) Registry() (ActionRegistry, AuthRegistry) {
+	return codec.NewTypeParser[Action](), codec.NewTypeParser[Auth]()
+}
+
+func TestUnmarshalBaselineBlock(t *testing.T) {
+	require := require.New(t)
+
+	// Blocks built before chunks, beacons and proposer rewards end with
+	// their state root
+	var (
+		parent = ids.GenerateTestID()
+		root   = ids.GenerateTestID()
+		p      = codec.NewWriter(0, consts.NetworkSizeLimit)
+	)
+	p.PackID(parent)
+	p.PackInt64(10)
+	p.PackUint64(2)
+	p.PackInt(0)
+	p.PackID(root)
+	raw := p.Bytes()
+	require.NoError(p.Err())
+
+	blk, err := UnmarshalBlock(raw, &testParser{})
+	require.NoError(err)
+	require.Equal(parent, blk.Prnt)
+	require.Equal(int64(10), blk.Tmstmp)
+	require.Equal(uint64(2), blk.Hght)
+	require.Empty(blk.Txs)
+	require.Equal(root, blk.StateRoot)
+	require.Empty(blk.Chunks)
+	require.Nil(blk.Beacon)
+	require.Nil(blk.Proposer)
+
+	// Their encoding is unchanged
+	b, err := blk.Marshal()
+	require.NoError(err)
+	require.Equal(raw, b)
+}
+
+func TestBlockProposerEncoding(t *testing.T) {
+	var (
+		proposer = codec.CreateAddress(0, ids.GenerateTestID())
+		empty    = codec.EmptyAddress
+		beacon   = &Beacon{Proposer: ids.GenerateTestNodeID(), Signature: make([]byte, bls.SignatureLen)}
+		cert     = &ChunkCertificate{Chunk: ids.GenerateTestID(), Signature: &warp.BitSetSignature{Signers: []byte{1}}}
+	)
+	tests := []struct {
+		name     string
+		chunks   []*ChunkCertificate
+		beacon   *Beacon
+		proposer *codec.Address
+	}{
+		{name: "proposer", proposer: &proposer},
+		{name: "empty proposer", proposer: &empty},
+		{name: "beacon", beacon: beacon},
+		{name: "beacon and proposer", beacon: beacon, proposer: &proposer},
+		{name: "chunks and proposer", chunks: []*ChunkCertificate{cert}, proposer: &proposer},
+		{name: "chunks, beacon and proposer", chunks: []*ChunkCertificate{cert}, beacon: beacon, proposer: &proposer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			blk := &StatefulBlock{
+				Prnt:     ids.GenerateTestID(),
+				Tmstmp:   1,
+				Hght:     1,
+				Txs:      []*Transaction{},
+				Chunks:   tt.chunks,
+				Beacon:   tt.beacon,
+				Proposer: tt.proposer,
+				chunks:   []*Chunk{},
+			}
+			raw, err := blk.Marshal()
+			require.NoError(err)
+			parsed, err := UnmarshalBlock(raw, &testParser{})
+			require.NoError(err)
+			require.Equal(tt.chunks, parsed.Chunks)
+			require.Equal(tt.beacon, parsed.Beacon)
+			require.Equal(tt.proposer, parsed.Proposer)
+		})
+	}
+}
@@ -8,19 +8,23 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/math"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/tstate"
 )
@@ -52,6 +56,8 @@ func HandlePreExecute(log logging.Logger, err error) bool {
 		return false
 	case errors.Is(err, ErrActionNotActivated):
 		return false
+	case errors.Is(err, ErrActionFuelTooLarge):
+		return false
 	default:
 		// If unknown error, drop
 		log.Warn("unknown PreExecute error", zap.Error(err))
@@ -59,11 +65,23 @@ func HandlePreExecute(log logging.Logger, err error) bool {
 	}
 }
 
+// EmptyBlockGap returns how long after its parent block builders wait before
+// building a block without transactions (see [Rules.GetMaxEmptyBlockGap]).
+// Such a block is valid once [Rules.GetMinEmptyBlockGap] has elapsed.
+func EmptyBlockGap(r Rules) int64 {
+	return max(r.GetMinEmptyBlockGap(), r.GetMaxEmptyBlockGap())
+}
+
 // TODO: This code is terrible and will be removed during the Vryx integration.
+//
+// [bctx] is nil if the block is built without a block context, in which case
+// transactions carrying incoming warp messages, chunks, and the [Beacon] are not
+// included.
 func BuildBlock(
 	ctx context.Context,
 	vm VM,
 	parent *StatelessBlock,
+	bctx *block.Context,
 ) (*StatelessBlock, error) {
 	ctx, span := vm.Tracer().Start(ctx, "chain.BuildBlock")
 	defer span.End()
@@ -80,6 +98,23 @@ func BuildBlock(
 		return nil, ErrTimestampTooEarly
 	}
 	b := NewBlock(vm, parent, nextTime)
+	ctx = withHeight(ctx, b.Hght)
+	if proposerRewardPercentage(r) > 0 {
+		proposer := vm.ProposerAddress()
+		b.Proposer = &proposer
+	}
+
+	// Sign the parent before executing any transaction, as actions may read
+	// the randomness derived from the signature
+	if bctx != nil && randomnessEnabled(r) {
+		beacon, err := NewBeacon(r, vm.NodeID(), parent.ID(), vm.Sign)
+		if err != nil {
+			log.Warn("block building failed: couldn't sign beacon", zap.Error(err))
+			return nil, err
+		}
+		b.Beacon = beacon
+		ctx = withRandomness(ctx, beacon)
+	}
 
 	// Fetch view where we will apply block state transitions
 	//
@@ -137,9 +172,29 @@ func BuildBlock(
 		stop bool
 	)
 
+	// Execute the transactions of certified chunks before the transactions of
+	// the mempool (see [StatefulBlock.Txs])
+	b.Txs = []*Transaction{}
+	chunkTxs := set.Set[ids.ID]{}
+	if bctx != nil {
+		b.Chunks, b.chunks, results, ts, feeManager = buildChunks(ctx, vm, parent, parentView, bctx, r, feeManager, nextTime, changesEstimate)
+		for _, chunk := range b.chunks {
+			for _, tx := range chunk.Txs {
+				b.Txs = append(b.Txs, tx)
+				chunkTxs.Add(tx.ID())
+			}
+		}
+		b.chunkTxs = len(b.Txs)
+	}
+	var (
+		discounted    = countDiscounted(r, b.Txs)
+		maxDiscounted = maxDiscountedTxs(r)
+		fuel          = countFuel(b.Txs)
+		maxFuel       = maxBlockFuel(r)
+	)
+
 	// Batch fetch items from mempool to unblock incoming RPC/Gossip traffic
 	mempool.StartStreaming(ctx)
-	b.Txs = []*Transaction{}
 	for time.Since(start) < vm.GetTargetBuildDuration() && !stop {
 		prepareStreamLock.Lock()
 		txs := mempool.Stream(ctx, streamBatch)
@@ -166,7 +221,14 @@ func BuildBlock(
 			tx := ltx
 
 			// Skip any duplicates before going async
-			if dup.Contains(i) {
+			if dup.Contains(i) || chunkTxs.Contains(tx.ID()) {
+				continue
+			}
+
+			// Drop transactions that are no longer admitted (the admission
+			// policy may have changed since they were added to the mempool)
+			if err := vm.AdmitTx(ctx, tx); err != nil {
+				log.Debug("dropping tx that is not admitted", zap.Stringer("txID", tx.ID()), zap.Error(err))
 				continue
 			}
 
@@ -179,6 +241,21 @@ func BuildBlock(
 				continue
 			}
 
+			// Incoming warp messages can only be verified with a block context
+			if len(tx.WarpMessages()) > 0 {
+				if bctx == nil {
+					restorableLock.Lock()
+					restorable = append(restorable, tx)
+					restorableLock.Unlock()
+					continue
+				}
+				if err := tx.VerifyWarpMessages(ctx, sm, r, vm.ValidatorState(), bctx.PChainHeight); err != nil {
+					// Drop transactions that can't be included at this P-Chain height
+					log.Debug("dropping tx with invalid warp message", zap.Stringer("txID", tx.ID()), zap.Error(err))
+					continue
+				}
+			}
+
 			// Once we get part way through a prefetching job, we start
 			// to prepare for the next stream.
 			if i == streamPrefetchThreshold {
@@ -289,6 +366,22 @@ func BuildBlock(
 				blockLock.Lock()
 				defer blockLock.Unlock()
 
+				// Defer discounted transactions to a later block once the block
+				// includes [maxDiscounted] of them
+				discount := feeDiscount(r, tx) > 0
+				if discount && discounted == maxDiscounted {
+					restore = true
+					return nil
+				}
+
+				// Defer transactions that would exceed the fuel the block may
+				// execute
+				txFuel := tx.Fuel()
+				if txFuel > maxFuel-fuel {
+					restore = true
+					return nil
+				}
+
 				// Ensure block isn't too big
 				if ok, dimension := feeManager.Consume(result.Units, maxUnits); !ok {
 					log.Debug(
@@ -313,6 +406,10 @@ func BuildBlock(
 				}
 
 				// Update block with new transaction
+				if discount {
+					discounted++
+				}
+				fuel += txFuel
 				tsv.Commit()
 				b.Txs = append(b.Txs, tx)
 				results = append(results, result)
@@ -362,12 +459,26 @@ func BuildBlock(
 
 	// Perform basic validity checks to make sure the block is well-formatted
 	if len(b.Txs) == 0 {
-		if nextTime < parent.Tmstmp+r.GetMinEmptyBlockGap() {
-			return nil, fmt.Errorf("%w: allowed in %d ms", ErrNoTxs, parent.Tmstmp+r.GetMinEmptyBlockGap()-nextTime) //nolint:spancheck
+		if gap := r.GetMinEmptyBlockGap(); nextTime < parent.Tmstmp+gap {
+			return nil, fmt.Errorf("%w: allowed in %d ms", ErrNoTxs, parent.Tmstmp+gap-nextTime) //nolint:spancheck
 		}
 		vm.RecordEmptyBlockBuilt()
 	}
 
+	// Credit the proposer with its share of the base fees
+	feeSplit, err := rewardProposer(ctx, sm, r, parentView, ts, b.Proposer, results)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to reward proposer", err)
+	}
+	b.feeSplit = feeSplit
+
+	// Remove expired keys
+	if rm, ok := sm.(RentManager); ok {
+		if _, err := sweepRent(ctx, rm, parentView, ts, b.Tmstmp); err != nil {
+			return nil, fmt.Errorf("%w: unable to sweep rent", err)
+		}
+	}
+
 	// Update chain metadata
 	heightKey := HeightKey(sm.HeightKey())
 	heightKeyStr := string(heightKey)
@@ -404,6 +515,7 @@ func BuildBlock(
 	b.StateRoot = root
 
 	// Get view from [tstate] after writing all changed keys
+	b.stateChanges = newStateChanges(ts.ChangedKeys())
 	view, err := ts.ExportMerkleDBView(ctx, vm.Tracer(), parentView)
 	if err != nil {
 		return nil, err
@@ -443,3 +555,187 @@ func BuildBlock(
 	)
 	return b, nil
 }
+
+// buildChunks selects the certified chunks included by a block built on
+// [parent] at [t] and executes their transactions on a new [tstate.TState].
+//
+// A chunk is only included if all of its transactions can be executed. Because
+// a chunk that fails may have modified the state before failing, the chunks
+// selected before it are executed again on a new [tstate.TState].
+func buildChunks(
+	ctx context.Context,
+	vm VM,
+	parent *StatelessBlock,
+	parentView state.View,
+	bctx *block.Context,
+	r Rules,
+	feeManager *fees.Manager,
+	t int64,
+	changesEstimate int,
+) ([]*ChunkCertificate, []*Chunk, []*Result, *tstate.TState, *fees.Manager) {
+	ctx, span := vm.Tracer().Start(ctx, "chain.BuildBlock.Chunks")
+	defer span.End()
+	log := vm.Logger()
+
+	var (
+		feeRaw        = slices.Clone(feeManager.Bytes())
+		oldestAllowed = t - r.GetValidityWindow()
+
+		certs      []*ChunkCertificate
+		chunks     []*Chunk
+		results    []*Result
+		txIDs      = set.Set[ids.ID]{}
+		discounted int
+		fuel       uint64
+		ts         = tstate.New(changesEstimate)
+		fm         = fees.NewManager(slices.Clone(feeRaw))
+	)
+	candidateCerts, candidates := vm.CertifiedChunks(ctx, t)
+	for i, chunk := range candidates {
+		if len(chunks) == MaxBlockChunks {
+			break
+		}
+		cert := candidateCerts[i]
+		if chunk.Expiry < t {
+			continue
+		}
+		if err := cert.Verify(ctx, r, vm.ValidatorState(), bctx.PChainHeight); err != nil {
+			log.Debug("skipping chunk", zap.Stringer("chunkID", chunk.ID()), zap.Error(err))
+			continue
+		}
+		overlaps := false
+		for _, tx := range chunk.Txs {
+			if txIDs.Contains(tx.ID()) {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+		dup, err := parent.IsRepeat(ctx, oldestAllowed, chunk.Txs, set.NewBits(), true)
+		if err != nil || dup.Len() > 0 {
+			continue
+		}
+		chunkDiscounted := countDiscounted(r, chunk.Txs)
+		if discounted+chunkDiscounted > maxDiscountedTxs(r) {
+			continue
+		}
+		chunkFuel := countFuel(chunk.Txs)
+		if chunkFuel > maxBlockFuel(r)-fuel {
+			continue
+		}
+		chunkResults, err := executeChunk(ctx, vm, r, bctx, parentView, ts, fm, chunk, t)
+		if err != nil {
+			log.Debug("skipping chunk", zap.Stringer("chunkID", chunk.ID()), zap.Error(err))
+			ts = tstate.New(changesEstimate)
+			fm = fees.NewManager(slices.Clone(feeRaw))
+			results = results[:0]
+			for _, included := range chunks {
+				chunkResults, err := executeChunk(ctx, vm, r, bctx, parentView, ts, fm, included, t)
+				if err != nil {
+					// This should never happen because the execution of the
+					// chunks that were already selected is deterministic.
+					log.Warn("unable to execute selected chunk", zap.Stringer("chunkID", included.ID()), zap.Error(err))
+					return nil, nil, []*Result{}, tstate.New(changesEstimate), fees.NewManager(feeRaw)
+				}
+				results = append(results, chunkResults...)
+			}
+			continue
+		}
+		certs = append(certs, cert)
+		chunks = append(chunks, chunk)
+		results = append(results, chunkResults...)
+		discounted += chunkDiscounted
+		fuel += chunkFuel
+		for _, tx := range chunk.Txs {
+			txIDs.Add(tx.ID())
+		}
+	}
+	span.SetAttributes(
+		attribute.Int("candidates", len(candidates)),
+		attribute.Int("included", len(chunks)),
+	)
+	if results == nil {
+		results = []*Result{}
+	}
+	return certs, chunks, results, ts, fm
+}
+
+// countDiscounted returns the number of [txs] with a fee discount (see
+// [FeeDiscountRules]).
+func countDiscounted(r Rules, txs []*Transaction) int {
+	var count int
+	for _, tx := range txs {
+		if feeDiscount(r, tx) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// countFuel returns the total fuel declared by [txs] (see [FuelRules]), capped
+// at [consts.MaxUint64].
+func countFuel(txs []*Transaction) uint64 {
+	fuelOp := math.NewUint64Operator(0)
+	for _, tx := range txs {
+		fuelOp.Add(tx.Fuel())
+	}
+	fuel, err := fuelOp.Value()
+	if err != nil {
+		return consts.MaxUint64
+	}
+	return fuel
+}
+
+// executeChunk executes the transactions of [chunk] (in order) on [ts].
+func executeChunk(
+	ctx context.Context,
+	vm VM,
+	r Rules,
+	bctx *block.Context,
+	parentView state.View,
+	ts *tstate.TState,
+	feeManager *fees.Manager,
+	chunk *Chunk,
+	t int64,
+) ([]*Result, error) {
+	sm := vm.StateManager()
+	results := make([]*Result, 0, len(chunk.Txs))
+	for _, tx := range chunk.Txs {
+		if err := tx.VerifyWarpMessages(ctx, sm, r, vm.ValidatorState(), bctx.PChainHeight); err != nil {
+			return nil, err
+		}
+		stateKeys, err := tx.StateKeys(sm)
+		if err != nil {
+			return nil, err
+		}
+		storage := make(map[string][]byte, len(stateKeys))
+		for k := range stateKeys {
+			v, err := parentView.GetValue(ctx, []byte(k))
+			if errors.Is(err, database.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if _, ok := keys.NumChunks(v); !ok {
+				return nil, ErrInvalidKeyValue
+			}
+			storage[k] = v
+		}
+		tsv := ts.NewView(stateKeys, storage)
+		if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, t); err != nil {
+			return nil, err
+		}
+		result, err := tx.Execute(ctx, feeManager, sm, r, tsv, t)
+		if err != nil {
+			return nil, err
+		}
+		if ok, d := feeManager.Consume(result.Units, r.GetMaxBlockUnits()); !ok {
+			return nil, fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
+		}
+		tsv.Commit()
+		results = append(results, result)
+	}
+	return results, nil
+}
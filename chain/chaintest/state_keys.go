@@ -0,0 +1,226 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package chaintest provides helpers to test the [chain.Action]s of hypervms
+// without building blocks.
+package chaintest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+var ErrStateKeysMismatch = errors.New("state keys mismatch")
+
+// StateKeysReport compares the keys an action accessed when executed by
+// [AnalyzeStateKeys] with the keys it declared in [chain.Action.StateKeys].
+type StateKeysReport struct {
+	// Declared are the keys returned by [chain.Action.StateKeys]
+	Declared state.Keys
+	// Accessed are the keys the action accessed and the permissions those
+	// accesses require (an insert of a key that doesn't exist requires
+	// [state.Allocate] and [state.Write])
+	Accessed state.Keys
+
+	// Undeclared are the accessed keys that were not declared with the
+	// permissions they require (which would revert the action on-chain)
+	Undeclared state.Keys
+	// Unused are the declared keys that were never accessed (which the
+	// action still pays for)
+	Unused state.Keys
+
+	// Outputs and Err are the result of [chain.Action.Execute]
+	Outputs [][]byte
+	Err     error
+}
+
+// Mismatch returns [ErrStateKeysMismatch] if any accessed key was not declared
+// or, if the action succeeded, any declared key was not accessed. Declared
+// keys are not required to be accessed when the action fails because it may
+// fail before reaching them.
+func (r *StateKeysReport) Mismatch() error {
+	var details []string
+	if len(r.Undeclared) > 0 {
+		details = append(details, "undeclared "+formatKeys(r.Undeclared, r.Declared))
+	}
+	if r.Err == nil && len(r.Unused) > 0 {
+		details = append(details, "unused "+formatKeys(r.Unused, nil))
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrStateKeysMismatch, strings.Join(details, ", "))
+}
+
+// AnalyzeStateKeys executes [action] on top of [im] (without modifying it)
+// and records every key it accesses, so it can be compared with the keys it
+// declares. Unlike [tstate.TStateView], accesses of undeclared keys are not
+// rejected, so all mismatches are reported at once.
+//
+// Actions should be analyzed on each path they can take (like transfers to
+// accounts that do and don't exist) because only the keys accessed on the
+// analyzed path are recorded.
+func AnalyzeStateKeys(
+	ctx context.Context,
+	action chain.Action,
+	r chain.Rules,
+	im state.Immutable,
+	timestamp int64,
+	actor codec.Address,
+	actionID ids.ID,
+) *StateKeysReport {
+	var (
+		declared = action.StateKeys(actor, actionID)
+		view     = newRecordingView(im)
+	)
+	outputs, err := action.Execute(ctx, r, view, timestamp, actor, actionID)
+	report := &StateKeysReport{
+		Declared:   declared,
+		Accessed:   view.accessed,
+		Undeclared: state.Keys{},
+		Unused:     state.Keys{},
+		Outputs:    outputs,
+		Err:        err,
+	}
+	for k, perm := range view.accessed {
+		if !declared[k].Has(perm) {
+			report.Undeclared[k] = perm
+		}
+	}
+	for k, perm := range declared {
+		if _, ok := view.accessed[k]; !ok {
+			report.Unused[k] = perm
+		}
+	}
+	return report
+}
+
+// RequireStateKeys fails [t] if the keys accessed by [action] don't match the
+// keys it declares (see [StateKeysReport.Mismatch]) and returns the report,
+// so the caller can check the outcome of the execution.
+func RequireStateKeys(
+	t testing.TB,
+	action chain.Action,
+	r chain.Rules,
+	im state.Immutable,
+	timestamp int64,
+	actor codec.Address,
+	actionID ids.ID,
+) *StateKeysReport {
+	report := AnalyzeStateKeys(context.Background(), action, r, im, timestamp, actor, actionID)
+	require.NoError(t, report.Mismatch())
+	return report
+}
+
+// formatKeys lists [ks] in order, with the permissions they were declared
+// with in [declared] (if not nil).
+func formatKeys(ks state.Keys, declared state.Keys) string {
+	sorted := make([]string, 0, len(ks))
+	for k := range ks {
+		sorted = append(sorted, k)
+	}
+	slices.SortFunc(sorted, func(a, b string) int {
+		return bytes.Compare([]byte(a), []byte(b))
+	})
+	entries := make([]string, 0, len(sorted))
+	for _, k := range sorted {
+		entry := fmt.Sprintf("%x (%s", k, formatPermissions(ks[k]))
+		if declared != nil {
+			entry += ", declared " + formatPermissions(declared[k])
+		}
+		entries = append(entries, entry+")")
+	}
+	return "[" + strings.Join(entries, " ") + "]"
+}
+
+func formatPermissions(p state.Permissions) string {
+	var names []string
+	for _, perm := range []struct {
+		name string
+		p    state.Permissions
+	}{
+		{"read", state.Read},
+		{"allocate", state.Allocate},
+		{"write", state.Write},
+	} {
+		if p.Has(perm.p) {
+			names = append(names, perm.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+var _ state.Mutable = (*recordingView)(nil)
+
+// recordingView applies changes to a copy of [im] and records the permissions
+// each access requires from a [tstate.TStateView].
+type recordingView struct {
+	im       state.Immutable
+	changes  map[string]maybe.Maybe[[]byte]
+	accessed state.Keys
+}
+
+func newRecordingView(im state.Immutable) *recordingView {
+	return &recordingView{
+		im:       im,
+		changes:  map[string]maybe.Maybe[[]byte]{},
+		accessed: state.Keys{},
+	}
+}
+
+func (v *recordingView) getValue(ctx context.Context, key []byte) ([]byte, error) {
+	if change, ok := v.changes[string(key)]; ok {
+		if change.IsNothing() {
+			return nil, database.ErrNotFound
+		}
+		return change.Value(), nil
+	}
+	return v.im.GetValue(ctx, key)
+}
+
+func (v *recordingView) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	v.accessed[string(key)] |= state.Read
+	return v.getValue(ctx, key)
+}
+
+func (v *recordingView) Insert(ctx context.Context, key []byte, value []byte) error {
+	_, err := v.getValue(ctx, key)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		v.accessed[string(key)] |= state.Allocate | state.Write
+	case err != nil:
+		return err
+	default:
+		v.accessed[string(key)] |= state.Write
+	}
+	if !keys.VerifyValue(key, value) {
+		return tstate.ErrInvalidKeyValue
+	}
+	v.changes[string(key)] = maybe.Some(value)
+	return nil
+}
+
+func (v *recordingView) Remove(_ context.Context, key []byte) error {
+	v.accessed[string(key)] |= state.Write
+	v.changes[string(key)] = maybe.Nothing[[]byte]()
+	return nil
+}
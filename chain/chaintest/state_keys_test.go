@@ -0,0 +1,173 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chaintest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var (
+	_ chain.Action    = (*testAction)(nil)
+	_ state.Immutable = (testState)(nil)
+
+	errTestFailed = errors.New("test failed")
+
+	existingKey = string(keys.EncodeChunks([]byte{0}, 1))
+	newKey      = string(keys.EncodeChunks([]byte{1}, 1))
+)
+
+type testState map[string][]byte
+
+func (s testState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+type testAction struct {
+	declared state.Keys
+	execute  func(context.Context, state.Mutable) error
+}
+
+func (*testAction) GetTypeID() uint8 { return 0 }
+
+func (*testAction) ValidRange(chain.Rules) (int64, int64) { return -1, -1 }
+
+func (*testAction) Marshal(*codec.Packer) {}
+
+func (*testAction) Size() int { return 0 }
+
+func (*testAction) ComputeUnits(chain.Rules) uint64 { return 1 }
+
+func (*testAction) StateKeysMaxChunks() []uint16 { return nil }
+
+func (a *testAction) StateKeys(codec.Address, ids.ID) state.Keys { return a.declared }
+
+func (a *testAction) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	_ codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	return nil, a.execute(ctx, mu)
+}
+
+// move reads [existingKey], copies it to [newKey], and removes it
+func move(ctx context.Context, mu state.Mutable) error {
+	v, err := mu.GetValue(ctx, []byte(existingKey))
+	if err != nil {
+		return err
+	}
+	if err := mu.Insert(ctx, []byte(newKey), v); err != nil {
+		return err
+	}
+	return mu.Remove(ctx, []byte(existingKey))
+}
+
+func TestAnalyzeStateKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     *testAction
+		accessed   state.Keys
+		undeclared state.Keys
+		unused     state.Keys
+		mismatch   bool
+	}{
+		{
+			name: "matching",
+			action: &testAction{
+				declared: state.Keys{existingKey: state.Write, newKey: state.All},
+				execute:  move,
+			},
+			accessed:   state.Keys{existingKey: state.Write, newKey: state.Allocate | state.Write},
+			undeclared: state.Keys{},
+			unused:     state.Keys{},
+		},
+		{
+			name: "missing allocate",
+			action: &testAction{
+				declared: state.Keys{existingKey: state.Write, newKey: state.Write},
+				execute:  move,
+			},
+			accessed:   state.Keys{existingKey: state.Write, newKey: state.Allocate | state.Write},
+			undeclared: state.Keys{newKey: state.Allocate | state.Write},
+			unused:     state.Keys{},
+			mismatch:   true,
+		},
+		{
+			name: "undeclared",
+			action: &testAction{
+				declared: state.Keys{newKey: state.All},
+				execute:  move,
+			},
+			accessed:   state.Keys{existingKey: state.Write, newKey: state.Allocate | state.Write},
+			undeclared: state.Keys{existingKey: state.Write},
+			unused:     state.Keys{},
+			mismatch:   true,
+		},
+		{
+			name: "unused",
+			action: &testAction{
+				declared: state.Keys{existingKey: state.Read, newKey: state.All},
+				execute: func(ctx context.Context, mu state.Mutable) error {
+					_, err := mu.GetValue(ctx, []byte(existingKey))
+					return err
+				},
+			},
+			accessed:   state.Keys{existingKey: state.Read},
+			undeclared: state.Keys{},
+			unused:     state.Keys{newKey: state.All},
+			mismatch:   true,
+		},
+		{
+			name: "unused after failure",
+			action: &testAction{
+				declared: state.Keys{existingKey: state.Read, newKey: state.All},
+				execute: func(ctx context.Context, mu state.Mutable) error {
+					if _, err := mu.GetValue(ctx, []byte(existingKey)); err != nil {
+						return err
+					}
+					return errTestFailed
+				},
+			},
+			accessed:   state.Keys{existingKey: state.Read},
+			undeclared: state.Keys{},
+			unused:     state.Keys{newKey: state.All},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			im := testState{existingKey: {1}}
+			report := AnalyzeStateKeys(context.Background(), tt.action, nil, im, 0, codec.EmptyAddress, ids.Empty)
+			require.Equal(tt.accessed, report.Accessed)
+			require.Equal(tt.undeclared, report.Undeclared)
+			require.Equal(tt.unused, report.Unused)
+			if tt.mismatch {
+				require.ErrorIs(report.Mismatch(), ErrStateKeysMismatch)
+			} else {
+				require.NoError(report.Mismatch())
+			}
+
+			// The analyzed state is never modified
+			require.Equal(testState{existingKey: {1}}, im)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// chunkPayloadPrefix separates the warp messages validators sign over chunks
+// from the messages sent by actions (see [SendWarpMessage]).
+var chunkPayloadPrefix = []byte("hypersdk/chunk/")
+
+// Chunk is a batch of transactions disseminated by a validator (its
+// [Producer]) before any block includes them.
+//
+// Validators that store a chunk sign its ID and the signatures of a quorum of
+// the stake are aggregated into a [ChunkCertificate]. Blocks include the
+// certificates of chunks instead of their transactions, so the size of a
+// proposal (and the time it takes to send it) does not depend on the number of
+// transactions it executes.
+type Chunk struct {
+	Producer ids.NodeID `json:"producer"`
+	// Expiry is the latest timestamp of a block that can include the chunk
+	Expiry int64          `json:"expiry"`
+	Txs    []*Transaction `json:"txs"`
+
+	bytes []byte
+	id    ids.ID
+}
+
+func NewChunk(producer ids.NodeID, expiry int64, txs []*Transaction) (*Chunk, error) {
+	if len(txs) == 0 {
+		return nil, ErrNoTxs
+	}
+	c := &Chunk{Producer: producer, Expiry: expiry, Txs: txs}
+	size := ids.NodeIDLen + consts.Int64Len + consts.IntLen + codec.CummSize(txs)
+	p := codec.NewWriter(size, consts.NetworkSizeLimit)
+	p.PackFixedBytes(producer[:])
+	p.PackInt64(expiry)
+	p.PackInt(len(txs))
+	for _, tx := range txs {
+		if err := tx.Marshal(p); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	c.bytes = p.Bytes()
+	c.id = utils.ToID(c.bytes)
+	return c, nil
+}
+
+func (c *Chunk) ID() ids.ID { return c.id }
+
+func (c *Chunk) Bytes() []byte { return c.bytes }
+
+func (c *Chunk) Size() int { return len(c.bytes) }
+
+func UnmarshalChunk(raw []byte, parser Parser) (*Chunk, error) {
+	var (
+		p        = codec.NewReader(raw, consts.NetworkSizeLimit)
+		c        Chunk
+		producer = make([]byte, ids.NodeIDLen)
+	)
+	p.UnpackFixedBytes(ids.NodeIDLen, &producer)
+	copy(c.Producer[:], producer)
+	c.Expiry = p.UnpackInt64(true)
+
+	txCount := p.UnpackInt(true)
+	actionRegistry, authRegistry := parser.Registry()
+	c.Txs = []*Transaction{} // don't preallocate all to avoid DoS
+	for i := 0; i < txCount; i++ {
+		tx, err := UnmarshalTx(p, actionRegistry, authRegistry)
+		if err != nil {
+			return nil, err
+		}
+		c.Txs = append(c.Txs, tx)
+	}
+	if !p.Empty() {
+		return nil, fmt.Errorf("%w: remaining=%d", ErrInvalidObject, len(raw)-p.Offset())
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	c.bytes = raw
+	c.id = utils.ToID(raw)
+	return &c, nil
+}
+
+// NewChunkMessage returns the warp message validators sign to attest that they
+// store the chunk [chunkID].
+func NewChunkMessage(networkID uint32, chainID ids.ID, chunkID ids.ID) (*warp.UnsignedMessage, error) {
+	payload := make([]byte, 0, len(chunkPayloadPrefix)+ids.IDLen)
+	payload = append(payload, chunkPayloadPrefix...)
+	payload = append(payload, chunkID[:]...)
+	return warp.NewUnsignedMessage(networkID, chainID, payload)
+}
+
+// isChunkPayload is true if [payload] could be mistaken for the payload of a
+// chunk message.
+func isChunkPayload(payload []byte) bool {
+	return bytes.HasPrefix(payload, chunkPayloadPrefix)
+}
+
+// ChunkCertificate proves that validators with at least
+// [ChunkQuorumNum]/[ChunkQuorumDen] of the stake store the chunk [Chunk].
+type ChunkCertificate struct {
+	Chunk     ids.ID                `json:"chunk"`
+	Signature *warp.BitSetSignature `json:"signature"`
+}
+
+func (c *ChunkCertificate) Size() int {
+	return ids.IDLen + codec.BytesLen(c.Signature.Signers) + bls.SignatureLen
+}
+
+func (c *ChunkCertificate) Marshal(p *codec.Packer) {
+	p.PackID(c.Chunk)
+	p.PackBytes(c.Signature.Signers)
+	p.PackFixedBytes(c.Signature.Signature[:])
+}
+
+func UnmarshalChunkCertificate(p *codec.Packer) (*ChunkCertificate, error) {
+	c := &ChunkCertificate{Signature: &warp.BitSetSignature{}}
+	p.UnpackID(true, &c.Chunk)
+	p.UnpackBytes(-1, true, &c.Signature.Signers)
+	signature := make([]byte, bls.SignatureLen)
+	p.UnpackFixedBytes(bls.SignatureLen, &signature)
+	copy(c.Signature.Signature[:], signature)
+	return c, p.Err()
+}
+
+// Verify ensures [c] is signed by the quorum of the validators of the chain
+// at [pChainHeight].
+func (c *ChunkCertificate) Verify(
+	ctx context.Context,
+	r Rules,
+	vdrState validators.State,
+	pChainHeight uint64,
+) error {
+	msg, err := NewChunkMessage(r.NetworkID(), r.ChainID(), c.Chunk)
+	if err != nil {
+		return err
+	}
+	if err := c.Signature.Verify(
+		ctx,
+		msg,
+		r.NetworkID(),
+		vdrState,
+		pChainHeight,
+		ChunkQuorumNum,
+		ChunkQuorumDen,
+	); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+	return nil
+}
@@ -4,22 +4,31 @@
 package chain
 
 import (
-	"time"
-
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/keys"
 )
 
 const (
-	// FutureBound is used to ignore blocks that have a timestamp more than
-	// [FutureBound] ahead of the current time (when verifying a block).
-	//
-	// This value should be (much) less than the value of [ProposerWindow], otherwise honest
-	// nodes may not build during their allocated window to avoid increasing the skew of the
-	// chain time.
-	FutureBound        = 1 * time.Second
 	HeightKeyChunks    = 1
 	TimestampKeyChunks = 1
-	FeeKeyChunks       = 8 // 96 (per dimension) * 5 (num dimensions)
+	FeeKeyChunks       = 8                // 96 (per dimension) * 5 (num dimensions)
+	RentSweepKeyChunks = consts.MaxUint16 // stores an arbitrary state key
+
+	// RentPrefixLen is the number of bytes prepended to the value of a rented key.
+	RentPrefixLen = consts.Int64Len
+
+	// MaxOutgoingWarpMessages is the maximum number of warp messages the actions
+	// of a single transaction can send.
+	MaxOutgoingWarpMessages = 16
+
+	// MaxBlockChunks is the maximum number of chunk certificates a block can
+	// include.
+	MaxBlockChunks = 64
+
+	// ChunkQuorumNum/ChunkQuorumDen is the fraction of the stake of the
+	// validators that must sign a chunk for it to be certified.
+	ChunkQuorumNum = 67
+	ChunkQuorumDen = 100
 
 	// MaxKeyDependencies must be greater than the maximum number of key dependencies
 	// any single task could have when executing a task.
@@ -37,3 +46,7 @@ func TimestampKey(prefix []byte) []byte {
 func FeeKey(prefix []byte) []byte {
 	return keys.EncodeChunks(prefix, FeeKeyChunks)
 }
+
+func RentSweepKey(prefix []byte) []byte {
+	return keys.EncodeChunks(prefix, RentSweepKeyChunks)
+}
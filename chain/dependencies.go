@@ -13,11 +13,13 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/fetcher"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/workers"
 )
@@ -37,6 +39,11 @@ type Metrics interface {
 	RecordWaitRoot(time.Duration)       // only called in Verify
 	RecordWaitSignatures(time.Duration) // only called in Verify
 
+	RecordExecuteConservative(time.Duration) // only called in Verify
+	RecordExecuteOptimistic(time.Duration)   // only called in Verify
+	RecordStatePrefetch(time.Duration)       // only called in Parse
+	RecordRentSwept(int)                     // only called in Verify
+
 	RecordBlockVerify(time.Duration)
 	RecordBlockAccept(time.Duration)
 	RecordStateChanges(int)
@@ -46,6 +53,8 @@ type Metrics interface {
 	RecordClearedMempool()
 	GetExecutorBuildRecorder() executor.Metrics
 	GetExecutorVerifyRecorder() executor.Metrics
+	GetExecutorOptimisticRecorder() executor.OptimisticMetrics
+	GetStateFetchRecorder() fetcher.Metrics
 }
 
 type Monitoring interface {
@@ -64,6 +73,15 @@ type VM interface {
 	GetAuthBatchVerifier(authTypeID uint8, cores int, count int) (AuthBatchVerifier, bool)
 	GetVerifyAuth() bool
 
+	// NodeID is the node the VM runs on and Sign signs [msg] with its BLS key
+	// (used to sign the [Beacon] of the blocks it builds).
+	NodeID() ids.NodeID
+	Sign(msg *warp.UnsignedMessage) ([]byte, error)
+	// ProposerAddress is credited with the proposer rewards of the blocks the
+	// VM builds (see [ProposerRewardRules]). If it is [codec.EmptyAddress],
+	// those rewards are burned.
+	ProposerAddress() codec.Address
+
 	IsBootstrapped() bool
 	LastAcceptedBlock() *StatelessBlock
 	GetStatelessBlock(context.Context, ids.ID) (*StatelessBlock, error)
@@ -75,10 +93,22 @@ type VM interface {
 	ValidatorState() validators.State
 
 	Mempool() Mempool
+	// GetChunks returns the chunks certified by [certs] (in order) if they are
+	// all stored locally. Otherwise, it returns [ErrChunksNotAvailable] (and
+	// may fetch the missing chunks in the background).
+	GetChunks(ctx context.Context, certs []*ChunkCertificate) ([]*Chunk, error)
+	// CertifiedChunks returns the certified chunks that can be included in a
+	// block with timestamp [t].
+	CertifiedChunks(ctx context.Context, t int64) ([]*ChunkCertificate, []*Chunk)
 	IsRepeat(context.Context, []*Transaction, set.Bits, bool) set.Bits
+	// AdmitTx returns an error if the node should not include [tx] in the
+	// blocks it builds.
+	AdmitTx(context.Context, *Transaction) error
 	GetTargetBuildDuration() time.Duration
 	GetTransactionExecutionCores() int
+	GetOptimisticExecution() bool
 	GetStateFetchConcurrency() int
+	GetStatePrefetch() bool
 
 	Verified(context.Context, *StatelessBlock)
 	Rejected(context.Context, *StatelessBlock)
@@ -123,7 +153,20 @@ type Rules interface {
 
 	GetMinBlockGap() int64      // in milliseconds
 	GetMinEmptyBlockGap() int64 // in milliseconds
-	GetValidityWindow() int64   // in milliseconds
+	// GetMaxEmptyBlockGap is how long (after the parent) the builder waits for
+	// transactions before it builds a block without any, so an idle chain
+	// still advances at least this often. Verification only enforces
+	// [GetMinEmptyBlockGap], so if it is less than that, empty blocks are
+	// built as soon as they are valid.
+	GetMaxEmptyBlockGap() int64 // in milliseconds
+	// GetBlockTimestampTolerance is how far ahead of the current time the
+	// timestamp of a block can be (when verifying it).
+	//
+	// This value should be (much) less than the proposer window of the
+	// proposervm, otherwise honest nodes may not build during their allocated
+	// window to avoid increasing the skew of the chain time.
+	GetBlockTimestampTolerance() int64 // in milliseconds
+	GetValidityWindow() int64          // in milliseconds
 
 	GetMaxActionsPerTx() uint8
 	GetMaxOutputsPerAction() uint8
@@ -152,6 +195,38 @@ type Rules interface {
 	FetchCustom(string) (any, bool)
 }
 
+// FeeDiscountRules is an optional extension of [Rules] that waives some or all
+// of the base fee of certain transactions (like the system transactions of the
+// VM or those sponsored by whitelisted relayers). If the [Rules] returned by
+// the VM implement [FeeDiscountRules], [FeeDiscount] is called for every
+// transaction. Tips are always paid in full and discounted transactions still
+// count against the max units of a block.
+//
+// To bound abuse, a block may include at most [GetMaxDiscountedTxs]
+// transactions with a discount. Blocks that include more are invalid (the
+// builder defers the rest to later blocks).
+type FeeDiscountRules interface {
+	// FeeDiscount returns the percentage of the base fee of [tx] that is waived
+	// (100 for fee-exempt transactions, 0 for no discount).
+	FeeDiscount(tx *Transaction) uint8
+	GetMaxDiscountedTxs() int
+}
+
+// FuelRules is an optional extension of [Rules] that bounds the execution of
+// [FueledAction]s (like calls to WASM programs), so a block can't take
+// longer to verify than its units suggest. Limits apply to the fuel declared
+// by each action (not the fuel it ends up consuming), so they can be checked
+// before execution.
+//
+// A transaction with an action that declares more than [GetMaxActionFuel] is
+// invalid. A block may include transactions that declare at most
+// [GetMaxBlockFuel] in total. Blocks that include more are invalid (the
+// builder defers the rest to later blocks).
+type FuelRules interface {
+	GetMaxActionFuel() uint64
+	GetMaxBlockFuel() uint64
+}
+
 type MetadataManager interface {
 	HeightKey() []byte
 	TimestampKey() []byte
@@ -174,6 +249,54 @@ type FeeHandler interface {
 	Deduct(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
 }
 
+// TipHandler is an optional extension of [StateManager] that passes tips
+// through to a recipient chosen by the VM (like the producer of the block).
+//
+// If the [StateManager] provided by the VM implements [TipHandler], the sponsor
+// of a transaction pays the base fee (the units consumed by the transaction
+// multiplied by the unit prices of its block), which is burned, and its
+// [Base.Tip] (up to [Base.MaxFee]), which is paid with [PayTip]. Otherwise, only
+// the base fee is charged. Tips do not change the order in which transactions
+// are executed.
+type TipHandler interface {
+	// TipStateKeys is a full enumeration of all database keys that could be touched
+	// by [PayTip] (formatted like the keys of [FeeHandler.SponsorStateKeys]).
+	TipStateKeys() state.Keys
+
+	// PayTip credits [amount] to the recipient of tips during transaction execution.
+	PayTip(ctx context.Context, mu state.Mutable, amount uint64) error
+}
+
+// ProposerRewardRules is an optional extension of [Rules] that credits part of
+// the base fees collected by each block to its proposer instead of burning all
+// of them (tips are unaffected, see [TipHandler]).
+//
+// Blocks produced while [GetProposerRewardPercentage] is non-zero include the
+// address of their proposer ([StatefulBlock.Proposer]), which is credited with
+// [ProposerRewarder.RewardProposer] after the transactions of the block are
+// executed. The split of the fees of each block is returned by
+// [StatelessBlock.FeeSplit].
+type ProposerRewardRules interface {
+	// GetProposerRewardPercentage returns the percentage of the base fees
+	// collected by a block that is credited to its proposer (the rest is
+	// burned).
+	GetProposerRewardPercentage() uint8
+}
+
+// ProposerRewarder is an optional extension of [StateManager] that credits
+// proposer rewards (see [ProposerRewardRules]). If the [StateManager] provided
+// by the VM doesn't implement it, all base fees are burned.
+type ProposerRewarder interface {
+	// ProposerStateKeys is a full enumeration of all database keys that could
+	// be touched by [RewardProposer] for [addr] (formatted like the keys of
+	// [FeeHandler.SponsorStateKeys]).
+	ProposerStateKeys(addr codec.Address) state.Keys
+
+	// RewardProposer credits [amount] to [addr] after the transactions of a
+	// block are executed.
+	RewardProposer(ctx context.Context, addr codec.Address, mu state.Mutable, amount uint64) error
+}
+
 // StateManager allows [Chain] to safely store certain types of items in state
 // in a structured manner. If we did not use [StateManager], we may overwrite
 // state written by actions or auth.
@@ -185,6 +308,85 @@ type StateManager interface {
 	MetadataManager
 }
 
+// ReservedKeyManager is an optional extension of [StateManager] that reserves
+// keys for the VM (like the [MetadataManager] keys or the keys of delivered
+// warp messages). If the [StateManager] provided by the VM implements
+// [ReservedKeyManager], transactions with an action that declares a key
+// starting with any of [ReservedPrefixes] are invalid and actions fail if they
+// touch such a key (even if it was declared by the [StateManager], like the
+// keys of [WarpManager.WarpStateKeys]).
+//
+// The [StateManager] itself (when paying fees or applying warp messages) can
+// still touch reserved keys.
+type ReservedKeyManager interface {
+	ReservedPrefixes() [][]byte
+}
+
+// RentManager is an optional extension of [StateManager] that enables storage
+// rent. If the [StateManager] provided by the VM implements [RentManager], the
+// values of rented keys are prefixed with the timestamp (in ms) they are paid
+// through and each write to a rented key extends this timestamp to [RentPeriod]
+// after the timestamp of the block the write is included in.
+//
+// Once a rented key is expired, reads return [database.ErrNotFound] and writes
+// are treated as a new allocation (so the key must be specified with
+// [state.Allocate] and the allocation fee will be charged). Expired keys are
+// eventually removed from state by a sweep performed at the end of each block.
+// Because each write reads the prefix of the existing value to check whether
+// it is expired, rented keys must always be specified with [state.Read] (which
+// [state.Allocate] and [state.Write] include).
+//
+// The max chunks of rented keys must account for the [RentPrefixLen] bytes
+// added to each value. If rent is enabled for keys written during genesis, these
+// values must be encoded with [EncodeRentValue]. Values read by the VM on
+// behalf of RPC clients have the prefix removed (see [StripRentValues]).
+type RentManager interface {
+	// RentSweepKey is where the position of the expired key sweep is stored.
+	RentSweepKey() []byte
+
+	// Rented returns true if [key] is subject to rent.
+	Rented(key []byte) bool
+
+	// RentPeriod is how long (in ms) a write to a rented key pays rent for.
+	RentPeriod() int64
+
+	// RentSweepLimit is the maximum number of keys inspected at the end of each
+	// block when searching for expired keys.
+	RentSweepLimit() int
+}
+
+// WarpManager is an optional extension of [StateManager] that allows actions
+// to carry incoming warp messages (see [WarpAction]). Transactions carrying
+// warp messages are rejected if the [StateManager] provided by the VM does not
+// implement [WarpManager].
+//
+// Incoming messages are verified against the validators of their source subnet
+// at the P-Chain height of the block that includes them, so [WarpQuorum] must
+// be deterministic.
+type WarpManager interface {
+	// WarpQuorum returns the fraction of the stake of the source subnet that
+	// must have signed [msg]. If an error is returned, [msg] is rejected (for
+	// example, because its source chain is not trusted).
+	WarpQuorum(r Rules, msg *warp.UnsignedMessage) (num uint64, den uint64, err error)
+
+	// WarpStateKeys enumerates all keys that could be touched by
+	// [ApplyWarpMessage] for [msg].
+	WarpStateKeys(msg *warp.UnsignedMessage) state.Keys
+
+	// ApplyWarpMessage is invoked with a verified [msg] right before the action
+	// carrying it is executed (for example, to prevent [msg] from being
+	// replayed). If an error is returned, the transaction fails like it would
+	// if the action returned an error.
+	ApplyWarpMessage(
+		ctx context.Context,
+		r Rules,
+		mu state.Mutable,
+		timestamp int64,
+		actor codec.Address,
+		msg *warp.UnsignedMessage,
+	) error
+}
+
 type Object interface {
 	// GetTypeID uniquely identifies each supported [Action]. We use IDs to avoid
 	// reflection.
@@ -242,6 +444,43 @@ type Action interface {
 	) (outputs [][]byte, err error)
 }
 
+// WarpAction is an optional extension of [Action] for actions that carry an
+// incoming warp message. A block including a [WarpAction] is only valid if its
+// message is signed by a sufficient stake of its source subnet (as determined
+// by [WarpManager]), so [Execute] can trust the contents of the message.
+//
+// [ComputeUnits] should account for the cost of verifying the message and
+// [StateKeysMaxChunks] should include the keys returned by
+// [WarpManager.WarpStateKeys].
+type WarpAction interface {
+	Action
+
+	// WarpMessage returns the message carried by the action (or nil if there
+	// is none).
+	WarpMessage() *warp.Message
+}
+
+// FueledAction is an optional extension of [Action] for actions that execute
+// metered code. [MaxFuel] is the most fuel [Execute] can consume (which should
+// be paid for by [ComputeUnits]) and is bounded by [FuelRules].
+type FueledAction interface {
+	Action
+
+	MaxFuel() uint64
+}
+
+// RecipientsAction is an optional extension of [Action] for actions that
+// affect accounts other than the actor (like the recipient of a transfer). It
+// is used to match transactions to the accounts they involve (for example, to
+// filter the transactions streamed to a subscriber).
+type RecipientsAction interface {
+	Action
+
+	// Recipients returns the accounts (other than the actor) affected by the
+	// action.
+	Recipients() []codec.Address
+}
+
 type Auth interface {
 	Object
 
@@ -28,6 +28,9 @@ var (
 	ErrStateRootMismatch    = errors.New("state root mismatch")
 	ErrInvalidResult        = errors.New("invalid result")
 	ErrInvalidBlockHeight   = errors.New("invalid block height")
+	ErrMissingBlockContext  = errors.New("missing block context")
+	ErrTooManyDiscounted    = errors.New("too many discounted transactions")
+	ErrTooMuchFuel          = errors.New("too much fuel")
 
 	// Tx Correctness
 	ErrInvalidSignature     = errors.New("invalid signature")
@@ -51,7 +54,13 @@ var (
 	ErrInvalidActor         = errors.New("invalid actor")
 	ErrInvalidSponsor       = errors.New("invalid sponsor")
 	ErrTooManyActions       = errors.New("too many actions")
+	ErrActionFuelTooLarge   = errors.New("action fuel is too large")
 	ErrTooManyOutputs       = errors.New("too many outputs")
+	ErrWarpNotSupported     = errors.New("warp messages not supported")
+	ErrInvalidWarpMessage   = errors.New("invalid warp message")
+	ErrTooManyWarpMessages  = errors.New("too many warp messages")
+	ErrNoWarpOutbox         = errors.New("warp messages can only be sent during execution")
+	ErrReservedWarpPayload  = errors.New("warp payload is reserved")
 
 	// Execution Correctness
 	ErrInvalidBalance  = errors.New("invalid balance")
@@ -63,4 +72,30 @@ var (
 	ErrBlockNotProcessed      = errors.New("block is not processed")
 	ErrInvalidKeyValue        = errors.New("invalid key or value")
 	ErrModificationNotAllowed = errors.New("modification not allowed")
+
+	// Chunks
+	ErrTooManyChunks      = errors.New("too many chunks")
+	ErrDuplicateChunk     = errors.New("duplicate chunk")
+	ErrChunkExpired       = errors.New("chunk expired")
+	ErrChunkMismatch      = errors.New("chunk does not match certificate")
+	ErrInvalidCertificate = errors.New("invalid chunk certificate")
+	ErrChunksNotAttached  = errors.New("chunks not attached")
+	ErrChunksNotAvailable = errors.New("chunks not available")
+
+	// Randomness
+	ErrMissingBeacon    = errors.New("missing randomness beacon")
+	ErrUnexpectedBeacon = errors.New("randomness not enabled")
+	ErrInvalidBeacon    = errors.New("invalid randomness beacon")
+	ErrNoRandomness     = errors.New("randomness can only be read during the execution of a block with a beacon")
+
+	// Proposer rewards
+	ErrMissingProposer    = errors.New("missing proposer")
+	ErrUnexpectedProposer = errors.New("proposer rewards not enabled")
+
+	// Height
+	ErrNoHeight = errors.New("height can only be read during the execution of a block")
+
+	// Rent
+	ErrInvalidRentValue     = errors.New("invalid rent value")
+	ErrRentSweepUnsupported = errors.New("parent view does not support iteration")
 )
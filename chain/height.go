@@ -0,0 +1,25 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import "context"
+
+// heightKey is the context key of the height of the block being executed.
+type heightKey struct{}
+
+// withHeight provides [height] to the actions executed with the returned
+// context.
+func withHeight(ctx context.Context, height uint64) context.Context {
+	return context.WithValue(ctx, heightKey{}, height)
+}
+
+// Height returns the height of the block being executed. It may only be
+// called from [Action.Execute] (with the context it was provided).
+func Height(ctx context.Context) (uint64, error) {
+	height, ok := ctx.Value(heightKey{}).(uint64)
+	if !ok {
+		return 0, ErrNoHeight
+	}
+	return height, nil
+}
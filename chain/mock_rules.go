@@ -86,6 +86,20 @@ func (mr *MockRulesMockRecorder) GetBaseComputeUnits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseComputeUnits", reflect.TypeOf((*MockRules)(nil).GetBaseComputeUnits))
 }
 
+// GetBlockTimestampTolerance mocks base method.
+func (m *MockRules) GetBlockTimestampTolerance() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockTimestampTolerance")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetBlockTimestampTolerance indicates an expected call of GetBlockTimestampTolerance.
+func (mr *MockRulesMockRecorder) GetBlockTimestampTolerance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockTimestampTolerance", reflect.TypeOf((*MockRules)(nil).GetBlockTimestampTolerance))
+}
+
 // GetMaxActionsPerTx mocks base method.
 func (m *MockRules) GetMaxActionsPerTx() byte {
 	m.ctrl.T.Helper()
@@ -114,6 +128,20 @@ func (mr *MockRulesMockRecorder) GetMaxBlockUnits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxBlockUnits", reflect.TypeOf((*MockRules)(nil).GetMaxBlockUnits))
 }
 
+// GetMaxEmptyBlockGap mocks base method.
+func (m *MockRules) GetMaxEmptyBlockGap() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxEmptyBlockGap")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetMaxEmptyBlockGap indicates an expected call of GetMaxEmptyBlockGap.
+func (mr *MockRulesMockRecorder) GetMaxEmptyBlockGap() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxEmptyBlockGap", reflect.TypeOf((*MockRules)(nil).GetMaxEmptyBlockGap))
+}
+
 // GetMaxOutputsPerAction mocks base method.
 func (m *MockRules) GetMaxOutputsPerAction() byte {
 	m.ctrl.T.Helper()
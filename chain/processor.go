@@ -6,6 +6,7 @@ package chain
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/trace"
 
@@ -34,38 +35,74 @@ func (b *StatelessBlock) Execute(
 	defer span.End()
 
 	var (
-		sm     = b.vm.StateManager()
-		numTxs = len(b.Txs)
-		t      = b.GetTimestamp()
+		sm         = b.vm.StateManager()
+		numTxs     = len(b.Txs)
+		t          = b.GetTimestamp()
+		optimistic = b.vm.GetOptimisticExecution()
+		start      = time.Now()
 
-		f       = fetcher.New(im, numTxs, b.vm.GetStateFetchConcurrency())
-		e       = executor.New(numTxs, b.vm.GetTransactionExecutionCores(), MaxKeyDependencies, b.vm.GetExecutorVerifyRecorder())
+		f       = fetcher.New(im, numTxs, b.vm.GetStateFetchConcurrency(), b.vm.GetStateFetchRecorder())
 		ts      = tstate.New(numTxs * 2) // TODO: tune this heuristic
 		results = make([]*Result, numTxs)
+
+		// Only one of [e] or [o] is populated, depending on
+		// whether we are executing optimistically.
+		e *executor.Executor
+		o *executor.Optimistic
 	)
+	if optimistic {
+		o = executor.NewOptimistic(numTxs, b.vm.GetTransactionExecutionCores(), b.vm.GetExecutorOptimisticRecorder())
+	} else {
+		e = executor.New(numTxs, b.vm.GetTransactionExecutionCores(), MaxKeyDependencies, b.vm.GetExecutorVerifyRecorder())
+	}
+	stop := func() {
+		f.Stop()
+		if optimistic {
+			o.Stop()
+		} else {
+			e.Stop()
+		}
+	}
 
 	// Fetch required keys and execute transactions
+	var (
+		discounted    int
+		maxDiscounted = maxDiscountedTxs(r)
+		fuel          uint64
+		maxFuel       = maxBlockFuel(r)
+	)
 	for li, ltx := range b.Txs {
 		i := li
 		tx := ltx
 
+		if feeDiscount(r, tx) > 0 {
+			discounted++
+			if discounted > maxDiscounted {
+				stop()
+				return nil, nil, fmt.Errorf("%w: max=%d", ErrTooManyDiscounted, maxDiscounted)
+			}
+		}
+		txFuel := tx.Fuel()
+		if txFuel > maxFuel-fuel {
+			stop()
+			return nil, nil, fmt.Errorf("%w: max=%d", ErrTooMuchFuel, maxFuel)
+		}
+		fuel += txFuel
+
 		stateKeys, err := tx.StateKeys(sm)
 		if err != nil {
-			f.Stop()
-			e.Stop()
+			stop()
 			return nil, nil, err
 		}
 
 		// Ensure we don't consume too many units
 		units, err := tx.Units(sm, r)
 		if err != nil {
-			f.Stop()
-			e.Stop()
+			stop()
 			return nil, nil, err
 		}
 		if ok, d := feeManager.Consume(units, r.GetMaxBlockUnits()); !ok {
-			f.Stop()
-			e.Stop()
+			stop()
 			return nil, nil, fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
 		}
 
@@ -74,7 +111,14 @@ func (b *StatelessBlock) Execute(
 		if err := f.Fetch(ctx, txID, stateKeys); err != nil {
 			return nil, nil, err
 		}
-		e.Run(stateKeys, func() error {
+		execute := func(tsv *tstate.TStateView) (*Result, error) {
+			// Ensure we have enough funds to pay fees
+			if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, t); err != nil {
+				return nil, err
+			}
+			return tx.Execute(ctx, feeManager, sm, r, tsv, t)
+		}
+		run := func() error {
 			// Wait for stateKeys to be read from disk
 			storage, err := f.Get(txID)
 			if err != nil {
@@ -86,13 +130,7 @@ func (b *StatelessBlock) Execute(
 			// It is critical we explicitly set the scope before each transaction is
 			// processed
 			tsv := ts.NewView(stateKeys, storage)
-
-			// Ensure we have enough funds to pay fees
-			if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, t); err != nil {
-				return err
-			}
-
-			result, err := tx.Execute(ctx, feeManager, sm, r, tsv, t)
+			result, err := execute(tsv)
 			if err != nil {
 				return err
 			}
@@ -101,13 +139,43 @@ func (b *StatelessBlock) Execute(
 			// Commit results to parent [TState]
 			tsv.Commit()
 			return nil
-		})
+		}
+		if !optimistic {
+			e.Run(stateKeys, run)
+			continue
+		}
+		o.Run(stateKeys, func() (func(), error) {
+			storage, err := f.Get(txID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Speculative execution must not observe the changes of any other
+			// transaction (they may be committed in any order).
+			tsv := ts.NewSnapshotView(stateKeys, storage)
+			result, err := execute(tsv)
+			if err != nil {
+				return nil, err
+			}
+			return func() {
+				results[i] = result
+				tsv.Commit()
+			}, nil
+		}, run)
 	}
 	if err := f.Wait(); err != nil {
 		return nil, nil, err
 	}
-	if err := e.Wait(); err != nil {
-		return nil, nil, err
+	if optimistic {
+		if err := o.Wait(); err != nil {
+			return nil, nil, err
+		}
+		b.vm.RecordExecuteOptimistic(time.Since(start))
+	} else {
+		if err := e.Wait(); err != nil {
+			return nil, nil, err
+		}
+		b.vm.RecordExecuteConservative(time.Since(start))
 	}
 
 	// Return tstate that can be used to add block-level keys to state
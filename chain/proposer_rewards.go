@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/math"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+const FeeSplitSize = codec.AddressLen + 3*consts.Uint64Len
+
+// FeeSplit is how the base fees collected by a block were split between its
+// proposer and burning them (see [ProposerRewardRules]).
+type FeeSplit struct {
+	// Proposer is [codec.EmptyAddress] if the block doesn't have a proposer
+	// address (in which case all base fees are burned).
+	Proposer codec.Address `json:"proposer"`
+	// Collected is the sum of the base fees paid by the transactions of the
+	// block (excluding tips).
+	Collected uint64 `json:"collected"`
+	Rewarded  uint64 `json:"rewarded"`
+	Burned    uint64 `json:"burned"`
+}
+
+func (f *FeeSplit) Marshal() ([]byte, error) {
+	p := codec.NewWriter(FeeSplitSize, FeeSplitSize)
+	p.PackAddress(f.Proposer)
+	p.PackUint64(f.Collected)
+	p.PackUint64(f.Rewarded)
+	p.PackUint64(f.Burned)
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalFeeSplit(b []byte) (*FeeSplit, error) {
+	var (
+		p = codec.NewReader(b, FeeSplitSize)
+		f FeeSplit
+	)
+	// The proposer may be empty, so it is not unpacked with [UnpackAddress]
+	proposer := make([]byte, codec.AddressLen)
+	p.UnpackFixedBytes(codec.AddressLen, &proposer)
+	copy(f.Proposer[:], proposer)
+	f.Collected = p.UnpackUint64(false)
+	f.Rewarded = p.UnpackUint64(false)
+	f.Burned = p.UnpackUint64(false)
+	if !p.Empty() {
+		return nil, ErrInvalidObject
+	}
+	return &f, p.Err()
+}
+
+// proposerRewardPercentage returns the percentage of the base fees of a block
+// credited to its proposer by [r] (see [ProposerRewardRules]).
+func proposerRewardPercentage(r Rules) uint8 {
+	pr, ok := r.(ProposerRewardRules)
+	if !ok {
+		return 0
+	}
+	return min(pr.GetProposerRewardPercentage(), 100)
+}
+
+// rewardProposer credits the share of the base fees paid by [results] that
+// [r] rewards to [proposer] and returns how those fees were split. [proposer]
+// is nil if the block doesn't have a proposer address.
+func rewardProposer(
+	ctx context.Context,
+	sm StateManager,
+	r Rules,
+	parentView state.Immutable,
+	ts *tstate.TState,
+	proposer *codec.Address,
+	results []*Result,
+) (*FeeSplit, error) {
+	collectedOp := math.NewUint64Operator(0)
+	for _, result := range results {
+		collectedOp.Add(result.Fee - result.Tip)
+	}
+	collected, err := collectedOp.Value()
+	if err != nil {
+		return nil, err
+	}
+	split := &FeeSplit{Collected: collected, Burned: collected}
+	if proposer == nil {
+		return split, nil
+	}
+	split.Proposer = *proposer
+	pr, ok := sm.(ProposerRewarder)
+	if !ok || split.Proposer == codec.EmptyAddress {
+		return split, nil
+	}
+	// Split the product to avoid overflowing
+	percentage := uint64(proposerRewardPercentage(r))
+	split.Rewarded = collected/100*percentage + collected%100*percentage/100
+	split.Burned -= split.Rewarded
+	if split.Rewarded == 0 {
+		return split, nil
+	}
+
+	// Keys changed by the transactions of the block are read from [ts]
+	scope := pr.ProposerStateKeys(split.Proposer)
+	storage := make(map[string][]byte, len(scope))
+	for k := range scope {
+		v, err := parentView.GetValue(ctx, []byte(k))
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		storage[k] = v
+	}
+	tsv := ts.NewView(scope, storage)
+	if err := pr.RewardProposer(ctx, split.Proposer, tsv, split.Rewarded); err != nil {
+		return nil, err
+	}
+	tsv.Commit()
+	return split, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// randomnessPayloadPrefix separates the warp messages proposers sign to derive
+// the randomness of their blocks from the messages sent by actions (see
+// [SendWarpMessage]).
+var randomnessPayloadPrefix = []byte("hypersdk/randomness/")
+
+// RandomnessRules is an optional extension of [Rules] that provides actions
+// with randomness that can't be manipulated by the proposer of a block (unlike
+// its timestamp). If the [Rules] returned by the VM implement
+// [RandomnessRules] and [GetRandomnessEnabled] is true, each block built with
+// a block context must include a [Beacon] and actions can read the randomness
+// derived from it with [Randomness].
+type RandomnessRules interface {
+	GetRandomnessEnabled() bool
+}
+
+func randomnessEnabled(r Rules) bool {
+	rr, ok := r.(RandomnessRules)
+	return ok && rr.GetRandomnessEnabled()
+}
+
+// Beacon is the BLS signature of the proposer of a block over the ID of its
+// parent (see [NewRandomnessMessage]). The randomness of the block is the
+// hash of [Signature].
+//
+// BLS signatures are unique, so the proposer can't grind the randomness of its
+// block: it can only withhold it (by not building the block) or, if other
+// validators built blocks on the same parent, pick one of their signatures.
+type Beacon struct {
+	Proposer  ids.NodeID `json:"proposer"`
+	Signature []byte     `json:"signature"`
+}
+
+// NewBeacon signs the randomness message of a block built on [parent] as the
+// validator [proposer].
+func NewBeacon(r Rules, proposer ids.NodeID, parent ids.ID, sign func(*warp.UnsignedMessage) ([]byte, error)) (*Beacon, error) {
+	msg, err := NewRandomnessMessage(r.NetworkID(), r.ChainID(), parent)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &Beacon{Proposer: proposer, Signature: signature}, nil
+}
+
+// NewRandomnessMessage returns the warp message the proposer of a block built
+// on [parent] signs to derive its randomness.
+func NewRandomnessMessage(networkID uint32, chainID ids.ID, parent ids.ID) (*warp.UnsignedMessage, error) {
+	payload := make([]byte, 0, len(randomnessPayloadPrefix)+ids.IDLen)
+	payload = append(payload, randomnessPayloadPrefix...)
+	payload = append(payload, parent[:]...)
+	return warp.NewUnsignedMessage(networkID, chainID, payload)
+}
+
+// isRandomnessPayload is true if [payload] could be mistaken for the payload
+// of a randomness message.
+func isRandomnessPayload(payload []byte) bool {
+	return bytes.HasPrefix(payload, randomnessPayloadPrefix)
+}
+
+// Randomness is the randomness of the block that includes [b].
+func (b *Beacon) Randomness() ids.ID {
+	return utils.ToID(b.Signature)
+}
+
+func (*Beacon) Size() int {
+	return ids.NodeIDLen + bls.SignatureLen
+}
+
+func (b *Beacon) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(b.Proposer[:])
+	p.PackFixedBytes(b.Signature)
+}
+
+func UnmarshalBeacon(p *codec.Packer) (*Beacon, error) {
+	var (
+		b        = Beacon{Signature: make([]byte, bls.SignatureLen)}
+		proposer = make([]byte, ids.NodeIDLen)
+	)
+	p.UnpackFixedBytes(ids.NodeIDLen, &proposer)
+	copy(b.Proposer[:], proposer)
+	p.UnpackFixedBytes(bls.SignatureLen, &b.Signature)
+	return &b, p.Err()
+}
+
+// Verify ensures [b] is the signature of a validator of the chain at
+// [pChainHeight] over [parent].
+func (b *Beacon) Verify(
+	ctx context.Context,
+	r Rules,
+	vdrState validators.State,
+	pChainHeight uint64,
+	parent ids.ID,
+) error {
+	subnetID, err := vdrState.GetSubnetID(ctx, r.ChainID())
+	if err != nil {
+		return err
+	}
+	vdrs, err := vdrState.GetValidatorSet(ctx, pChainHeight, subnetID)
+	if err != nil {
+		return err
+	}
+	vdr, ok := vdrs[b.Proposer]
+	if !ok || vdr.PublicKey == nil {
+		return fmt.Errorf("%w: %s is not a validator with a BLS key", ErrInvalidBeacon, b.Proposer)
+	}
+	signature, err := bls.SignatureFromBytes(b.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBeacon, err)
+	}
+	msg, err := NewRandomnessMessage(r.NetworkID(), r.ChainID(), parent)
+	if err != nil {
+		return err
+	}
+	if !bls.Verify(vdr.PublicKey, signature, msg.Bytes()) {
+		return fmt.Errorf("%w: invalid signature", ErrInvalidBeacon)
+	}
+	return nil
+}
+
+// randomnessKey is the context key of the randomness of the block being
+// executed.
+type randomnessKey struct{}
+
+// withRandomness provides the randomness of [beacon] (if not nil) to the
+// actions executed with the returned context.
+func withRandomness(ctx context.Context, beacon *Beacon) context.Context {
+	if beacon == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, randomnessKey{}, beacon.Randomness())
+}
+
+// Randomness returns the randomness of the block being executed. It may only
+// be called from [Action.Execute] (with the context it was provided).
+//
+// All actions of a block read the same randomness, so actions that need
+// independent values should use [ActionRandomness].
+func Randomness(ctx context.Context) (ids.ID, error) {
+	randomness, ok := ctx.Value(randomnessKey{}).(ids.ID)
+	if !ok {
+		return ids.Empty, ErrNoRandomness
+	}
+	return randomness, nil
+}
+
+// ActionRandomness returns the randomness of the block being executed mixed
+// with [actionID] (provided to [Action.Execute]), which is unique to each
+// action.
+func ActionRandomness(ctx context.Context, actionID ids.ID) (ids.ID, error) {
+	randomness, err := Randomness(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return utils.ToID(append(randomness[:], actionID[:]...)), nil
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// EncodeRentValue prefixes [value] with the timestamp it is paid through.
+func EncodeRentValue(value []byte, paidThrough int64) []byte {
+	v := make([]byte, RentPrefixLen, RentPrefixLen+len(value))
+	binary.BigEndian.PutUint64(v, uint64(paidThrough))
+	return append(v, value...)
+}
+
+// DecodeRentValue returns the value and paid through timestamp of a value
+// encoded with [EncodeRentValue].
+func DecodeRentValue(raw []byte) ([]byte, int64, error) {
+	if len(raw) < RentPrefixLen {
+		return nil, 0, ErrInvalidRentValue
+	}
+	return raw[RentPrefixLen:], int64(binary.BigEndian.Uint64(raw)), nil
+}
+
+// StripRentValues removes the rent prefix from the [values] of rented [keys]
+// (read from the state produced by the block at [timestamp]). Expired keys
+// are reported as [database.ErrNotFound], like they are to actions.
+func StripRentValues(rm RentManager, keys [][]byte, values [][]byte, errs []error, timestamp int64) {
+	for i, key := range keys {
+		if errs[i] != nil || !rm.Rented(key) {
+			continue
+		}
+		value, paidThrough, err := DecodeRentValue(values[i])
+		switch {
+		case err != nil:
+			values[i], errs[i] = nil, err
+		case paidThrough < timestamp:
+			values[i], errs[i] = nil, database.ErrNotFound
+		default:
+			values[i] = value
+		}
+	}
+}
+
+var _ state.Mutable = (*rentState)(nil)
+
+// rentState enforces storage rent on all rented keys accessed
+// by a transaction.
+type rentState struct {
+	rm        RentManager
+	im        state.Immutable
+	mu        state.Mutable // nil if only reads are allowed
+	timestamp int64
+}
+
+func newRentImmutable(rm RentManager, im state.Immutable, timestamp int64) *rentState {
+	return &rentState{rm: rm, im: im, timestamp: timestamp}
+}
+
+func newRentMutable(rm RentManager, mu state.Mutable, timestamp int64) *rentState {
+	return &rentState{rm: rm, im: mu, mu: mu, timestamp: timestamp}
+}
+
+// get returns the value of [key] and whether it is expired.
+func (r *rentState) get(ctx context.Context, key []byte) ([]byte, bool, error) {
+	raw, err := r.im.GetValue(ctx, key)
+	if err != nil {
+		return nil, false, err
+	}
+	value, paidThrough, err := DecodeRentValue(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, paidThrough < r.timestamp, nil
+}
+
+func (r *rentState) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	if !r.rm.Rented(key) {
+		return r.im.GetValue(ctx, key)
+	}
+	value, expired, err := r.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, database.ErrNotFound
+	}
+	return value, nil
+}
+
+func (r *rentState) Insert(ctx context.Context, key []byte, value []byte) error {
+	if !r.rm.Rented(key) {
+		return r.mu.Insert(ctx, key, value)
+	}
+
+	// Reviving an expired key is treated as a new allocation. Reading the
+	// prefix only requires [state.Read], which is included in [state.Write].
+	_, expired, err := r.get(ctx, key)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+	case err != nil:
+		return err
+	case expired:
+		if err := r.mu.Remove(ctx, key); err != nil {
+			return err
+		}
+	}
+	return r.mu.Insert(ctx, key, EncodeRentValue(value, r.timestamp+r.rm.RentPeriod()))
+}
+
+func (r *rentState) Remove(ctx context.Context, key []byte) error {
+	return r.mu.Remove(ctx, key)
+}
+
+// sweepRent removes up to [RentSweepLimit] expired keys from state, starting
+// after the last key inspected by the previous block.
+//
+// Keys are iterated over in [parentView] (which is identical for all nodes
+// verifying a block) but are removed only if they are still expired after all
+// changes in [ts] are applied.
+func sweepRent(
+	ctx context.Context,
+	rm RentManager,
+	parentView state.View,
+	ts *tstate.TState,
+	timestamp int64,
+) (int, error) {
+	limit := rm.RentSweepLimit()
+	if limit <= 0 {
+		return 0, nil
+	}
+	iteratee, ok := parentView.(database.Iteratee)
+	if !ok {
+		return 0, ErrRentSweepUnsupported
+	}
+	sweepKey := RentSweepKey(rm.RentSweepKey())
+	sweepKeyStr := string(sweepKey)
+	cursor, err := parentView.GetValue(ctx, sweepKey)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return 0, err
+	}
+
+	// Find expired keys
+	var (
+		inspected  = 0
+		next       []byte // nil if iteration should restart
+		candidates = [][]byte{}
+		scope      = make(state.Keys)
+		storage    = map[string][]byte{}
+	)
+	iter := iteratee.NewIteratorWithStart(cursor)
+	for inspected < limit && iter.Next() {
+		k := iter.Key()
+		if bytes.Equal(k, cursor) {
+			continue
+		}
+		inspected++
+		if inspected == limit {
+			next = slices.Clone(k)
+		}
+		if !rm.Rented(k) {
+			continue
+		}
+		_, paidThrough, err := DecodeRentValue(iter.Value())
+		if err != nil || paidThrough >= timestamp {
+			continue
+		}
+		key := slices.Clone(k)
+		candidates = append(candidates, key)
+		scope.Add(string(key), state.Write)
+		storage[string(key)] = slices.Clone(iter.Value())
+	}
+	err = iter.Error()
+	iter.Release()
+	if err != nil {
+		return 0, err
+	}
+
+	// Remove keys that were not written during the block
+	scope.Add(sweepKeyStr, state.Allocate|state.Write)
+	if cursor != nil {
+		storage[sweepKeyStr] = cursor
+	}
+	tsv := ts.NewView(scope, storage)
+	r := newRentMutable(rm, tsv, timestamp)
+	swept := 0
+	for _, key := range candidates {
+		_, expired, err := r.get(ctx, key)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if !expired {
+			continue
+		}
+		if err := tsv.Remove(ctx, key); err != nil {
+			return 0, err
+		}
+		swept++
+	}
+	if next == nil {
+		err = tsv.Remove(ctx, sweepKey)
+	} else {
+		err = tsv.Insert(ctx, sweepKey, next)
+	}
+	if err != nil {
+		return 0, err
+	}
+	tsv.Commit()
+	return swept, nil
+}
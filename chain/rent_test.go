@@ -0,0 +1,262 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+const testRentPeriod = 100
+
+var (
+	_ RentManager = (*testRentManager)(nil)
+	_ state.View  = (*testRentView)(nil)
+
+	rentedKey   = keys.EncodeChunks([]byte("rented"), 2)
+	unrentedKey = keys.EncodeChunks([]byte("unrented"), 2)
+)
+
+type testRentManager struct {
+	sweepLimit int
+}
+
+func (*testRentManager) RentSweepKey() []byte { return []byte("sweep") }
+
+func (*testRentManager) Rented(key []byte) bool { return key[0] == 'r' }
+
+func (*testRentManager) RentPeriod() int64 { return testRentPeriod }
+
+func (m *testRentManager) RentSweepLimit() int { return m.sweepLimit }
+
+// testRentView is an iterable parent view
+type testRentView struct {
+	*memdb.Database
+}
+
+func (v *testRentView) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	return v.Get(key)
+}
+
+func (*testRentView) NewView(context.Context, merkledb.ViewChanges) (merkledb.View, error) {
+	return nil, nil
+}
+
+func (*testRentView) GetMerkleRoot(context.Context) (ids.ID, error) {
+	return ids.Empty, nil
+}
+
+func TestRentValue(t *testing.T) {
+	require := require.New(t)
+
+	value, paidThrough, err := DecodeRentValue(EncodeRentValue([]byte("value"), 10))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+	require.Equal(int64(10), paidThrough)
+
+	value, paidThrough, err = DecodeRentValue(EncodeRentValue(nil, 10))
+	require.NoError(err)
+	require.Empty(value)
+	require.Equal(int64(10), paidThrough)
+
+	_, _, err = DecodeRentValue([]byte{1, 2, 3})
+	require.ErrorIs(err, ErrInvalidRentValue)
+}
+
+func TestRentInsert(t *testing.T) {
+	tests := []struct {
+		name        string
+		paidThrough int64 // 0 if the key doesn't exist
+		permissions state.Permissions
+		err         error
+	}{
+		{
+			name:        "new key",
+			permissions: state.Allocate | state.Write,
+		},
+		{
+			name:        "new key without allocate",
+			permissions: state.Write,
+			err:         tstate.ErrInvalidKeyOrPermission,
+		},
+		{
+			name:        "paid key",
+			paidThrough: 1_000,
+			permissions: state.Write,
+		},
+		{
+			name:        "expired key",
+			paidThrough: 999,
+			permissions: state.Allocate | state.Write,
+		},
+		{
+			name:        "expired key without allocate",
+			paidThrough: 999,
+			permissions: state.Write,
+			err:         tstate.ErrInvalidKeyOrPermission,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			storage := map[string][]byte{}
+			if tt.paidThrough > 0 {
+				storage[string(rentedKey)] = EncodeRentValue([]byte("old"), tt.paidThrough)
+			}
+			ts := tstate.New(1)
+			tsv := ts.NewView(state.Keys{string(rentedKey): tt.permissions}, storage)
+			r := newRentMutable(&testRentManager{}, tsv, 1_000)
+			err := r.Insert(ctx, rentedKey, []byte("new"))
+			require.ErrorIs(err, tt.err)
+			if tt.err != nil {
+				return
+			}
+
+			// Writes pay rent through [RentPeriod] after the timestamp
+			raw, err := tsv.GetValue(ctx, rentedKey)
+			require.NoError(err)
+			require.Equal(EncodeRentValue([]byte("new"), 1_000+testRentPeriod), raw)
+			value, err := r.GetValue(ctx, rentedKey)
+			require.NoError(err)
+			require.Equal([]byte("new"), value)
+		})
+	}
+}
+
+func TestRentUnrented(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ts := tstate.New(1)
+	tsv := ts.NewView(state.Keys{string(unrentedKey): state.All}, map[string][]byte{})
+	r := newRentMutable(&testRentManager{}, tsv, 1_000)
+	require.NoError(r.Insert(ctx, unrentedKey, []byte("value")))
+
+	// Keys that aren't rented are not prefixed
+	raw, err := tsv.GetValue(ctx, unrentedKey)
+	require.NoError(err)
+	require.Equal([]byte("value"), raw)
+	value, err := r.GetValue(ctx, unrentedKey)
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func TestRentExpiry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	storage := map[string][]byte{string(rentedKey): EncodeRentValue([]byte("value"), 1_000)}
+	ts := tstate.New(1)
+	tsv := ts.NewView(state.Keys{string(rentedKey): state.Read}, storage)
+
+	// Keys can be read until the timestamp they are paid through
+	value, err := newRentImmutable(&testRentManager{}, tsv, 1_000).GetValue(ctx, rentedKey)
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+
+	_, err = newRentImmutable(&testRentManager{}, tsv, 1_001).GetValue(ctx, rentedKey)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStripRentValues(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		missingKey = keys.EncodeChunks([]byte("rmissing"), 1)
+		invalidKey = keys.EncodeChunks([]byte("rinvalid"), 1)
+		expiredKey = keys.EncodeChunks([]byte("rexpired"), 1)
+
+		stateKeys = [][]byte{rentedKey, unrentedKey, missingKey, invalidKey, expiredKey}
+		values    = [][]byte{
+			EncodeRentValue([]byte("rented"), 1_000),
+			[]byte("unrented"),
+			nil,
+			{1},
+			EncodeRentValue([]byte("expired"), 999),
+		}
+		errs = []error{nil, nil, database.ErrNotFound, nil, nil}
+	)
+	StripRentValues(&testRentManager{}, stateKeys, values, errs, 1_000)
+	require.Equal([][]byte{[]byte("rented"), []byte("unrented"), nil, nil, nil}, values)
+	require.NoError(errs[0])
+	require.NoError(errs[1])
+	require.ErrorIs(errs[2], database.ErrNotFound)
+	require.ErrorIs(errs[3], ErrInvalidRentValue)
+	require.ErrorIs(errs[4], database.ErrNotFound)
+}
+
+func TestSweepRent(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		rm       = &testRentManager{sweepLimit: 3}
+		sweepKey = RentSweepKey(rm.RentSweepKey())
+		db       = memdb.New()
+		expired  = [][]byte{
+			keys.EncodeChunks([]byte("ra"), 1),
+			keys.EncodeChunks([]byte("rb"), 1),
+			keys.EncodeChunks([]byte("rd"), 1),
+		}
+		paid    = keys.EncodeChunks([]byte("rc"), 1)
+		revived = expired[1]
+	)
+	for _, key := range expired {
+		require.NoError(db.Put(key, EncodeRentValue([]byte("value"), 999)))
+	}
+	require.NoError(db.Put(paid, EncodeRentValue([]byte("value"), 1_000)))
+	require.NoError(db.Put(unrentedKey, []byte("value")))
+	parentView := &testRentView{db}
+
+	// A key written during the block is not swept
+	ts := tstate.New(1)
+	tsv := ts.NewView(state.Keys{string(revived): state.All}, map[string][]byte{
+		string(revived): EncodeRentValue([]byte("value"), 999),
+	})
+	require.NoError(newRentMutable(rm, tsv, 1_000).Insert(ctx, revived, []byte("revived")))
+	tsv.Commit()
+
+	// The sweep inspects the first [RentSweepLimit] keys and stores where it
+	// stopped
+	swept, err := sweepRent(ctx, rm, parentView, ts, 1_000)
+	require.NoError(err)
+	require.Equal(1, swept)
+	changes := ts.ChangedKeys()
+	require.True(changes[string(expired[0])].IsNothing())
+	require.Equal(EncodeRentValue([]byte("revived"), 1_000+testRentPeriod), changes[string(revived)].Value())
+	require.NotContains(changes, string(paid))
+	require.Equal(paid, changes[string(sweepKey)].Value())
+
+	// The next sweep resumes after the cursor...
+	require.NoError(db.Put(sweepKey, paid))
+	ts = tstate.New(1)
+	swept, err = sweepRent(ctx, rm, parentView, ts, 1_000)
+	require.NoError(err)
+	require.Equal(1, swept)
+	changes = ts.ChangedKeys()
+	require.True(changes[string(expired[2])].IsNothing())
+	require.Equal(unrentedKey, changes[string(sweepKey)].Value())
+
+	// ...and restarts once it reaches the end of state
+	require.NoError(db.Put(sweepKey, unrentedKey))
+	ts = tstate.New(1)
+	swept, err = sweepRent(ctx, rm, parentView, ts, 1_000)
+	require.NoError(err)
+	require.Zero(swept)
+	changes = ts.ChangedKeys()
+	require.Len(changes, 1)
+	require.True(changes[string(sweepKey)].IsNothing())
+}
@@ -4,6 +4,8 @@
 package chain
 
 import (
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/fees"
@@ -19,6 +21,12 @@ type Result struct {
 	// to make life easier for indexers.
 	Units fees.Dimensions
 	Fee   uint64
+	// Tip is the part of [Fee] paid to the [TipHandler] (the rest is burned).
+	Tip uint64
+
+	// WarpMessages are the messages sent by the actions of a successful
+	// transaction (see [SendWarpMessage]).
+	WarpMessages []*warp.UnsignedMessage
 }
 
 func (r *Result) Size() int {
@@ -29,7 +37,11 @@ func (r *Result) Size() int {
 			outputSize += codec.BytesLen(output)
 		}
 	}
-	return consts.BoolLen + codec.BytesLen(r.Error) + outputSize + fees.DimensionsLen + consts.Uint64Len
+	warpSize := consts.Uint8Len
+	for _, msg := range r.WarpMessages {
+		warpSize += codec.BytesLen(msg.Bytes())
+	}
+	return consts.BoolLen + codec.BytesLen(r.Error) + outputSize + fees.DimensionsLen + consts.Uint64Len*2 + warpSize
 }
 
 func (r *Result) Marshal(p *codec.Packer) error {
@@ -44,6 +56,11 @@ func (r *Result) Marshal(p *codec.Packer) error {
 	}
 	p.PackFixedBytes(r.Units.Bytes())
 	p.PackUint64(r.Fee)
+	p.PackUint64(r.Tip)
+	p.PackByte(uint8(len(r.WarpMessages)))
+	for _, msg := range r.WarpMessages {
+		p.PackBytes(msg.Bytes())
+	}
 	return nil
 }
 
@@ -85,6 +102,20 @@ func UnmarshalResult(p *codec.Packer) (*Result, error) {
 	}
 	result.Units = units
 	result.Fee = p.UnpackUint64(false)
+	result.Tip = p.UnpackUint64(false)
+	numWarpMessages := p.UnpackByte()
+	for i := uint8(0); i < numWarpMessages; i++ {
+		var rawMsg []byte
+		p.UnpackBytes(consts.MaxInt, true, &rawMsg)
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+		msg, err := warp.ParseUnsignedMessage(rawMsg)
+		if err != nil {
+			return nil, err
+		}
+		result.WarpMessages = append(result.WarpMessages, msg)
+	}
 	// Wait to check if empty until after all results are unpacked.
 	return result, p.Err()
 }
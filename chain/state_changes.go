@@ -0,0 +1,79 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/utils/maybe"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// StateChange is the change of a key of state made by a block. Applying the
+// changes of each accepted block (in order) to a copy of state keeps it in
+// sync without executing the transactions of the blocks.
+type StateChange struct {
+	Key []byte `json:"key"`
+	// Value is nil if [Key] was deleted
+	Value   []byte `json:"value"`
+	Deleted bool   `json:"deleted"`
+}
+
+func (c *StateChange) Size() int {
+	return codec.BytesLen(c.Key) + consts.BoolLen + codec.BytesLen(c.Value)
+}
+
+// newStateChanges returns the changes of [changedKeys] (as tracked by
+// [tstate.TState]) sorted by key.
+func newStateChanges(changedKeys map[string]maybe.Maybe[[]byte]) []*StateChange {
+	changes := make([]*StateChange, 0, len(changedKeys))
+	for k, v := range changedKeys {
+		c := &StateChange{Key: []byte(k)}
+		if v.IsNothing() {
+			c.Deleted = true
+		} else {
+			c.Value = v.Value()
+		}
+		changes = append(changes, c)
+	}
+	slices.SortFunc(changes, func(a, b *StateChange) int {
+		return bytes.Compare(a.Key, b.Key)
+	})
+	return changes
+}
+
+func MarshalStateChanges(changes []*StateChange) ([]byte, error) {
+	size := consts.IntLen + codec.CummSize(changes)
+	p := codec.NewWriter(size, consts.MaxInt) // could be much larger than [NetworkSizeLimit]
+	p.PackInt(len(changes))
+	for _, c := range changes {
+		p.PackBytes(c.Key)
+		p.PackBool(c.Deleted)
+		p.PackBytes(c.Value)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalStateChanges(raw []byte) ([]*StateChange, error) {
+	p := codec.NewReader(raw, consts.MaxInt)
+	count := p.UnpackInt(false)
+	changes := make([]*StateChange, 0, min(count, len(raw)))
+	for i := 0; i < count; i++ {
+		c := &StateChange{}
+		p.UnpackBytes(consts.MaxInt, true, &c.Key)
+		c.Deleted = p.UnpackBool()
+		p.UnpackBytes(consts.MaxInt, false, &c.Value)
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	if !p.Empty() {
+		return nil, ErrInvalidObject
+	}
+	return changes, p.Err()
+}
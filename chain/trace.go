@@ -0,0 +1,279 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+// Phases of the execution of a transaction (see [TracePhase]). The phase of
+// each action is named "action <index>".
+const (
+	PreExecutePhase = "preExecute"
+	FeePhase        = "fee"
+)
+
+// TxTrace is the re-execution of an accepted transaction (see
+// [StatelessBlock.TraceTx]).
+type TxTrace struct {
+	TxID    ids.ID `json:"txId"`
+	BlockID ids.ID `json:"blockId"`
+	Height  uint64 `json:"height"`
+	Index   int    `json:"index"`
+
+	// Phases are the state accesses of the transaction, in the order they
+	// were made (grouped by the phase of execution that made them).
+	Phases []*TracePhase `json:"phases"`
+	// Units are the units charged to the transaction (which only depend on
+	// the declared state keys, not on the keys that were accessed).
+	Units *UnitBreakdown `json:"units"`
+
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	// Outputs are the outputs of each action (empty if an action failed)
+	Outputs      [][][]byte `json:"outputs"`
+	Fee          uint64     `json:"fee"`
+	Tip          uint64     `json:"tip"`
+	WarpMessages [][]byte   `json:"warpMessages"`
+}
+
+// TracePhase is a phase of the execution of a transaction.
+type TracePhase struct {
+	Name     string         `json:"name"`
+	Accesses []*StateAccess `json:"accesses"`
+}
+
+// StateAccess is a read, write, or removal of a key of state.
+type StateAccess struct {
+	Type tstate.AccessType `json:"type"`
+	Key  []byte            `json:"key"`
+	// Value is the value read or written (nil if the key doesn't exist)
+	Value []byte `json:"value"`
+	// Error is set if the access failed (like reads of keys that were not
+	// declared by the transaction)
+	Error string `json:"error,omitempty"`
+}
+
+// UnitBreakdown attributes the units charged to a transaction to what they
+// pay for.
+type UnitBreakdown struct {
+	Bandwidth     uint64      `json:"bandwidth"`
+	BaseCompute   uint64      `json:"baseCompute"`
+	ActionCompute []uint64    `json:"actionCompute"`
+	AuthCompute   uint64      `json:"authCompute"`
+	Keys          []*KeyUnits `json:"keys"`
+
+	Total fees.Dimensions `json:"total"`
+}
+
+// KeyUnits are the storage units charged for a declared state key.
+type KeyUnits struct {
+	Key       []byte `json:"key"`
+	MaxChunks uint16 `json:"maxChunks"`
+	Read      uint64 `json:"read"`
+	Allocate  uint64 `json:"allocate"`
+	Write     uint64 `json:"write"`
+}
+
+// NewUnitBreakdown returns the breakdown of the units charged to [tx].
+func NewUnitBreakdown(tx *Transaction, sm StateManager, r Rules) (*UnitBreakdown, error) {
+	total, err := tx.Units(sm, r)
+	if err != nil {
+		return nil, err
+	}
+	stateKeys, err := tx.StateKeys(sm)
+	if err != nil {
+		return nil, err
+	}
+	u := &UnitBreakdown{
+		Bandwidth:     uint64(tx.Size()),
+		BaseCompute:   r.GetBaseComputeUnits(),
+		ActionCompute: make([]uint64, 0, len(tx.Actions)),
+		AuthCompute:   tx.Auth.ComputeUnits(r),
+		Keys:          make([]*KeyUnits, 0, len(stateKeys)),
+		Total:         total,
+	}
+	for _, action := range tx.Actions {
+		u.ActionCompute = append(u.ActionCompute, action.ComputeUnits(r))
+	}
+	for k := range stateKeys {
+		// [Units] would have failed if any key was invalid
+		maxChunks, _ := keys.MaxChunks([]byte(k))
+		chunks := uint64(maxChunks)
+		u.Keys = append(u.Keys, &KeyUnits{
+			Key:       []byte(k),
+			MaxChunks: maxChunks,
+			Read:      r.GetStorageKeyReadUnits() + chunks*r.GetStorageValueReadUnits(),
+			Allocate:  r.GetStorageKeyAllocateUnits() + chunks*r.GetStorageValueAllocateUnits(),
+			Write:     r.GetStorageKeyWriteUnits() + chunks*r.GetStorageValueWriteUnits(),
+		})
+	}
+	slices.SortFunc(u.Keys, func(a, b *KeyUnits) int {
+		return bytes.Compare(a.Key, b.Key)
+	})
+	return u, nil
+}
+
+// txTracerKey is the context key of the [txTracer] of the transaction being
+// traced.
+type txTracerKey struct{}
+
+// txTracer records the state accesses of a transaction. Its methods may be
+// called on a nil [txTracer] (when the transaction is not traced).
+type txTracer struct {
+	phases []*TracePhase
+}
+
+func withTxTracer(ctx context.Context, t *txTracer) context.Context {
+	return context.WithValue(ctx, txTracerKey{}, t)
+}
+
+// getTxTracer returns nil if the transaction executed with [ctx] is not
+// traced.
+func getTxTracer(ctx context.Context) *txTracer {
+	t, _ := ctx.Value(txTracerKey{}).(*txTracer)
+	return t
+}
+
+func (t *txTracer) start(name string) {
+	if t == nil {
+		return
+	}
+	t.phases = append(t.phases, &TracePhase{Name: name, Accesses: []*StateAccess{}})
+}
+
+func (t *txTracer) startAction(i int) {
+	if t == nil {
+		return
+	}
+	t.start(fmt.Sprintf("action %d", i))
+}
+
+func (t *txTracer) record(typ tstate.AccessType, key []byte, value []byte, err error) {
+	access := &StateAccess{
+		Type:  typ,
+		Key:   slices.Clone(key),
+		Value: slices.Clone(value),
+	}
+	if err != nil {
+		access.Error = err.Error()
+	}
+	phase := t.phases[len(t.phases)-1]
+	phase.Accesses = append(phase.Accesses, access)
+}
+
+// TraceTx re-executes the transaction at [index] of [b] (after the
+// transactions before it) on top of [im], which must be the state [b] was
+// built on, and records every state access it makes.
+//
+// Transactions are executed one at a time, so this should only be used to
+// debug transactions (not to verify blocks).
+func (b *StatelessBlock) TraceTx(ctx context.Context, im state.Immutable, index int) (*TxTrace, error) {
+	ctx, span := b.vm.Tracer().Start(ctx, "StatelessBlock.TraceTx")
+	defer span.End()
+
+	if index < 0 || index >= len(b.Txs) {
+		return nil, fmt.Errorf("%w: index %d of %d txs", ErrInvalidObject, index, len(b.Txs))
+	}
+	var (
+		sm = b.vm.StateManager()
+		r  = b.vm.Rules(b.Tmstmp)
+		t  = b.GetTimestamp()
+		ts = tstate.New(len(b.Txs) * 2)
+	)
+	feeRaw, err := im.GetValue(ctx, FeeKey(sm.FeeKey()))
+	if err != nil {
+		return nil, err
+	}
+	feeManager, err := fees.NewManager(feeRaw).ComputeNext(b.Tmstmp, r)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withRandomness(withHeight(ctx, b.Hght), b.Beacon)
+	execute := func(ctx context.Context, tx *Transaction, tracer *txTracer) (*Result, error) {
+		stateKeys, err := tx.StateKeys(sm)
+		if err != nil {
+			return nil, err
+		}
+		units, err := tx.Units(sm, r)
+		if err != nil {
+			return nil, err
+		}
+		if ok, d := feeManager.Consume(units, r.GetMaxBlockUnits()); !ok {
+			return nil, fmt.Errorf("%w: %d too large", ErrInvalidUnitsConsumed, d)
+		}
+		storage := make(map[string][]byte, len(stateKeys))
+		for k := range stateKeys {
+			v, err := im.GetValue(ctx, []byte(k))
+			if errors.Is(err, database.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			storage[k] = v
+		}
+		tsv := ts.NewView(stateKeys, storage)
+		if tracer != nil {
+			tsv.Trace(tracer.record)
+		}
+		tracer.start(PreExecutePhase)
+		if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, t); err != nil {
+			return nil, fmt.Errorf("%w: tx %s can't be executed", err, tx.ID())
+		}
+		result, err := tx.Execute(ctx, feeManager, sm, r, tsv, t)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tx %s can't be executed", err, tx.ID())
+		}
+		tsv.Commit()
+		return result, nil
+	}
+	for _, tx := range b.Txs[:index] {
+		if _, err := execute(ctx, tx, nil); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		tx     = b.Txs[index]
+		tracer = &txTracer{}
+	)
+	result, err := execute(withTxTracer(ctx, tracer), tx, tracer)
+	if err != nil {
+		return nil, err
+	}
+	units, err := NewUnitBreakdown(tx, sm, r)
+	if err != nil {
+		return nil, err
+	}
+	warpMessages := make([][]byte, 0, len(result.WarpMessages))
+	for _, msg := range result.WarpMessages {
+		warpMessages = append(warpMessages, msg.Bytes())
+	}
+	return &TxTrace{
+		TxID:         tx.ID(),
+		BlockID:      b.ID(),
+		Height:       b.Hght,
+		Index:        index,
+		Phases:       tracer.phases,
+		Units:        units,
+		Success:      result.Success,
+		Error:        string(result.Error),
+		Outputs:      result.Outputs,
+		Fee:          result.Fee,
+		Tip:          result.Tip,
+		WarpMessages: warpMessages,
+	}, nil
+}
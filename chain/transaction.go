@@ -37,6 +37,11 @@ type Transaction struct {
 	size      int
 	id        ids.ID
 	stateKeys state.Keys
+
+	// activation is the latest activation timestamp of the action types of
+	// the transaction in the registry it was parsed with (see
+	// [codec.TypeParser.SetActivation])
+	activation int64
 }
 
 func NewTx(base *Base, actions []Action) *Transaction {
@@ -110,8 +115,15 @@ func (t *Transaction) StateKeys(sm StateManager) (state.Keys, error) {
 	stateKeys := make(state.Keys)
 
 	// Verify the formatting of state keys passed by the controller
+	var reserved [][]byte
+	if rk, ok := sm.(ReservedKeyManager); ok {
+		reserved = rk.ReservedPrefixes()
+	}
 	for i, action := range t.Actions {
 		for k, v := range action.StateKeys(t.Auth.Actor(), CreateActionID(t.ID(), uint8(i))) {
+			if err := tstate.CheckReserved([]byte(k), reserved); err != nil {
+				return nil, fmt.Errorf("action %d (type %d): %w", i, action.GetTypeID(), err)
+			}
 			if !stateKeys.Add(k, v) {
 				return nil, ErrInvalidKeyValue
 			}
@@ -122,6 +134,26 @@ func (t *Transaction) StateKeys(sm StateManager) (state.Keys, error) {
 			return nil, ErrInvalidKeyValue
 		}
 	}
+	if th, ok := sm.(TipHandler); ok {
+		for k, v := range th.TipStateKeys() {
+			if !stateKeys.Add(k, v) {
+				return nil, ErrInvalidKeyValue
+			}
+		}
+	}
+	if msgs := t.WarpMessages(); len(msgs) > 0 {
+		wm, ok := sm.(WarpManager)
+		if !ok {
+			return nil, ErrWarpNotSupported
+		}
+		for _, msg := range msgs {
+			for k, v := range wm.WarpStateKeys(&msg.UnsignedMessage) {
+				if !stateKeys.Add(k, v) {
+					return nil, ErrInvalidKeyValue
+				}
+			}
+		}
+	}
 
 	// Cache keys if called again
 	t.stateKeys = stateKeys
@@ -217,7 +249,7 @@ func EstimateUnits(r Rules, actions []Action, authFactory AuthFactory) (fees.Dim
 	}
 
 	// Estimate storage costs
-	for maxChunks := range stateKeysMaxChunks {
+	for _, maxChunks := range stateKeysMaxChunks {
 		// Compute key costs
 		readsOp.Add(r.GetStorageKeyReadUnits())
 		allocatesOp.Add(r.GetStorageKeyAllocateUnits())
@@ -257,9 +289,13 @@ func (t *Transaction) PreExecute(
 	if len(t.Actions) > int(r.GetMaxActionsPerTx()) {
 		return ErrTooManyActions
 	}
+	maxActionFuel, limitFuel := maxActionFuel(r)
 	for i, action := range t.Actions {
+		if fa, ok := action.(FueledAction); ok && limitFuel && fa.MaxFuel() > maxActionFuel {
+			return fmt.Errorf("%w: action type %d at index %d: max=%d", ErrActionFuelTooLarge, action.GetTypeID(), i, maxActionFuel)
+		}
 		start, end := action.ValidRange(r)
-		if start >= 0 && timestamp < start {
+		if timestamp < t.activation || start >= 0 && timestamp < start {
 			return fmt.Errorf("%w: action type %d at index %d", ErrActionNotActivated, action.GetTypeID(), i)
 		}
 		if end >= 0 && timestamp > end {
@@ -277,13 +313,93 @@ func (t *Transaction) PreExecute(
 	if err != nil {
 		return err
 	}
-	fee, err := feeManager.Fee(units)
+	fee, _, err := t.fees(feeManager, s, r, units)
 	if err != nil {
 		return err
 	}
+	if rm, ok := s.(RentManager); ok {
+		im = newRentImmutable(rm, im, timestamp)
+	}
 	return s.CanDeduct(ctx, t.Auth.Sponsor(), im, fee)
 }
 
+// fees returns the fee paid by a transaction that consumes [units] and the
+// part of it that is tipped (see [TipHandler]). The rest of the fee is the base
+// fee (less any [FeeDiscountRules] discount), which is burned.
+func (t *Transaction) fees(feeManager *fees.Manager, s StateManager, r Rules, units fees.Dimensions) (uint64, uint64, error) {
+	baseFee, err := feeManager.Fee(units)
+	if err != nil {
+		return 0, 0, err
+	}
+	if discount := uint64(feeDiscount(r, t)); discount > 0 {
+		// Split the product to avoid overflowing
+		baseFee -= baseFee/100*discount + baseFee%100*discount/100
+	}
+	if baseFee > t.Base.MaxFee {
+		return 0, 0, fmt.Errorf("%w: required=%d max=%d", ErrInsufficientPrice, baseFee, t.Base.MaxFee)
+	}
+	if _, ok := s.(TipHandler); !ok {
+		return baseFee, 0, nil
+	}
+	tip := min(t.Base.Tip, t.Base.MaxFee-baseFee)
+	return baseFee + tip, tip, nil
+}
+
+// feeDiscount returns the percentage of the base fee of [tx] waived by [r] (see
+// [FeeDiscountRules]).
+func feeDiscount(r Rules, tx *Transaction) uint8 {
+	dr, ok := r.(FeeDiscountRules)
+	if !ok {
+		return 0
+	}
+	return min(dr.FeeDiscount(tx), 100)
+}
+
+// maxDiscountedTxs returns the number of transactions with a fee discount that
+// a block may include.
+func maxDiscountedTxs(r Rules) int {
+	dr, ok := r.(FeeDiscountRules)
+	if !ok {
+		return 0
+	}
+	return dr.GetMaxDiscountedTxs()
+}
+
+// Fuel returns the total fuel declared by the [FueledAction]s of [t] (capped
+// at [consts.MaxUint64]).
+func (t *Transaction) Fuel() uint64 {
+	fuelOp := math.NewUint64Operator(0)
+	for _, action := range t.Actions {
+		if fa, ok := action.(FueledAction); ok {
+			fuelOp.Add(fa.MaxFuel())
+		}
+	}
+	fuel, err := fuelOp.Value()
+	if err != nil {
+		return consts.MaxUint64
+	}
+	return fuel
+}
+
+// maxActionFuel returns the fuel a single [FueledAction] may declare (and
+// false if [r] doesn't limit it).
+func maxActionFuel(r Rules) (uint64, bool) {
+	fr, ok := r.(FuelRules)
+	if !ok {
+		return 0, false
+	}
+	return fr.GetMaxActionFuel(), true
+}
+
+// maxBlockFuel returns the total fuel the transactions of a block may declare.
+func maxBlockFuel(r Rules) uint64 {
+	fr, ok := r.(FuelRules)
+	if !ok {
+		return consts.MaxUint64
+	}
+	return fr.GetMaxBlockFuel()
+}
+
 // Execute after knowing a transaction can pay a fee. Attempt
 // to charge the fee in as many cases as possible.
 //
@@ -302,16 +418,28 @@ func (t *Transaction) Execute(
 		// Should never happen
 		return nil, err
 	}
-	fee, err := feeManager.Fee(units)
+	fee, tip, err := t.fees(feeManager, s, r, units)
 	if err != nil {
 		// Should never happen
 		return nil, err
 	}
-	if err := s.Deduct(ctx, t.Auth.Sponsor(), ts, fee); err != nil {
+	var mu state.Mutable = ts
+	if rm, ok := s.(RentManager); ok {
+		mu = newRentMutable(rm, ts, timestamp)
+	}
+	tracer := getTxTracer(ctx) // nil unless the transaction is traced
+	tracer.start(FeePhase)
+	if err := s.Deduct(ctx, t.Auth.Sponsor(), mu, fee); err != nil {
 		// This should never fail for low balance (as we check [CanDeductFee]
 		// immediately before).
 		return nil, err
 	}
+	if tip > 0 {
+		// [fees] only returns a tip if [s] is a [TipHandler].
+		if err := s.(TipHandler).PayTip(ctx, mu, tip); err != nil {
+			return nil, err
+		}
+	}
 
 	// We create a temp state checkpoint to ensure we don't commit failed actions to state.
 	//
@@ -321,11 +449,31 @@ func (t *Transaction) Execute(
 		actionStart   = ts.OpIndex()
 		resultOutputs = [][][]byte{}
 	)
+
+	// Actions can't touch keys reserved for the VM (unlike fee payment and
+	// warp message delivery).
+	actionMu := mu
+	if rk, ok := s.(ReservedKeyManager); ok {
+		actionMu = tstate.NewReservedView(mu, rk.ReservedPrefixes())
+	}
+
+	// Messages sent by actions are only included in the result if all
+	// actions succeed.
+	actionCtx, outbox := withWarpOutbox(ctx, r)
 	for i, action := range t.Actions {
-		outputs, err := action.Execute(ctx, r, ts, timestamp, t.Auth.Actor(), CreateActionID(t.ID(), uint8(i)))
+		tracer.startAction(i)
+		if msg := actionWarpMessage(action); msg != nil {
+			// [StateKeys] ensures [s] is a [WarpManager] if any action
+			// carries a message.
+			if err := s.(WarpManager).ApplyWarpMessage(ctx, r, mu, timestamp, t.Auth.Actor(), &msg.UnsignedMessage); err != nil {
+				ts.Rollback(ctx, actionStart)
+				return &Result{false, utils.ErrBytes(err), resultOutputs, units, fee, tip, nil}, nil
+			}
+		}
+		outputs, err := action.Execute(actionCtx, r, actionMu, timestamp, t.Auth.Actor(), CreateActionID(t.ID(), uint8(i)))
 		if err != nil {
 			ts.Rollback(ctx, actionStart)
-			return &Result{false, utils.ErrBytes(err), resultOutputs, units, fee}, nil
+			return &Result{false, utils.ErrBytes(err), resultOutputs, units, fee, tip, nil}, nil
 		}
 		if outputs == nil {
 			// Ensure output standardization (match form we will
@@ -336,7 +484,7 @@ func (t *Transaction) Execute(
 		// Wait to append outputs until after we check that there aren't too many
 		if len(outputs) > int(r.GetMaxOutputsPerAction()) {
 			ts.Rollback(ctx, actionStart)
-			return &Result{false, utils.ErrBytes(ErrTooManyOutputs), resultOutputs, units, fee}, nil
+			return &Result{false, utils.ErrBytes(ErrTooManyOutputs), resultOutputs, units, fee, tip, nil}, nil
 		}
 		resultOutputs = append(resultOutputs, outputs)
 	}
@@ -348,6 +496,9 @@ func (t *Transaction) Execute(
 
 		Units: units,
 		Fee:   fee,
+		Tip:   tip,
+
+		WarpMessages: outbox.messages,
 	}, nil
 }
 
@@ -424,7 +575,7 @@ func UnmarshalTx(
 	if err != nil {
 		return nil, fmt.Errorf("%w: could not unmarshal base", err)
 	}
-	actions, err := unmarshalActions(p, actionRegistry)
+	actions, activation, err := unmarshalActions(p, actionRegistry, base.Timestamp)
 	if err != nil {
 		return nil, fmt.Errorf("%w: could not unmarshal actions", err)
 	}
@@ -449,6 +600,7 @@ func UnmarshalTx(
 	tx.Base = base
 	tx.Actions = actions
 	tx.Auth = auth
+	tx.activation = activation
 	if err := p.Err(); err != nil {
 		return nil, p.Err()
 	}
@@ -460,26 +612,66 @@ func UnmarshalTx(
 	return &tx, nil
 }
 
+// UnmarshalUnsignedTx parses a transaction encoded with [Transaction.Digest].
+//
+// The returned transaction has no [Auth] and must be signed with
+// [Transaction.Sign] before it can be issued. This allows a transaction to be
+// constructed on one machine and signed on another.
+func UnmarshalUnsignedTx(
+	p *codec.Packer,
+	actionRegistry *codec.TypeParser[Action],
+) (*Transaction, error) {
+	start := p.Offset()
+	base, err := UnmarshalBase(p)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not unmarshal base", err)
+	}
+	actions, activation, err := unmarshalActions(p, actionRegistry, base.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not unmarshal actions", err)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	tx := NewTx(base, actions)
+	tx.activation = activation
+	tx.digest = p.Bytes()[start:p.Offset()]
+	return tx, nil
+}
+
+// unmarshalActions rejects any action type that is not active at [timestamp]
+// (the expiry of the transaction) and returns the latest activation timestamp
+// of the action types (as the transaction may still be included in a block
+// before it).
 func unmarshalActions(
 	p *codec.Packer,
 	actionRegistry *codec.TypeParser[Action],
-) ([]Action, error) {
+	timestamp int64,
+) ([]Action, int64, error) {
 	actionCount := p.UnpackByte()
 	if actionCount == 0 {
-		return nil, fmt.Errorf("%w: no actions", ErrInvalidObject)
+		return nil, 0, fmt.Errorf("%w: no actions", ErrInvalidObject)
 	}
-	actions := []Action{}
+	var (
+		actions       = []Action{}
+		maxActivation int64
+	)
 	for i := uint8(0); i < actionCount; i++ {
 		actionType := p.UnpackByte()
 		unmarshalAction, ok := actionRegistry.LookupIndex(actionType)
 		if !ok {
-			return nil, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
+			return nil, 0, fmt.Errorf("%w: %d is unknown action type", ErrInvalidObject, actionType)
+		}
+		if !actionRegistry.Active(actionType, timestamp) {
+			return nil, 0, fmt.Errorf("%w: action type %d at index %d", ErrActionNotActivated, actionType, i)
 		}
+		activation, _ := actionRegistry.Activation(actionType)
+		maxActivation = max(maxActivation, activation)
 		action, err := unmarshalAction(p)
 		if err != nil {
-			return nil, fmt.Errorf("%w: could not unmarshal action", err)
+			return nil, 0, fmt.Errorf("%w: could not unmarshal action", err)
 		}
 		actions = append(actions, action)
 	}
-	return actions, nil
+	return actions, maxActivation, nil
 }
@@ -0,0 +1,245 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var (
+	_ Action           = (*testAction)(nil)
+	_ AuthFactory      = (*testAuthFactory)(nil)
+	_ FeeDiscountRules = (*testDiscountRules)(nil)
+	_ TipHandler       = (*testTipHandler)(nil)
+)
+
+type testAction struct {
+	computeUnits uint64
+	maxChunks    []uint16
+}
+
+func (*testAction) GetTypeID() uint8 { return 0 }
+
+func (*testAction) ValidRange(Rules) (int64, int64) { return -1, -1 }
+
+func (*testAction) Marshal(*codec.Packer) {}
+
+func (*testAction) Size() int { return 0 }
+
+func (a *testAction) ComputeUnits(Rules) uint64 { return a.computeUnits }
+
+func (a *testAction) StateKeysMaxChunks() []uint16 { return a.maxChunks }
+
+func (*testAction) StateKeys(codec.Address, ids.ID) state.Keys { return state.Keys{} }
+
+func (*testAction) Execute(
+	context.Context,
+	Rules,
+	state.Mutable,
+	int64,
+	codec.Address,
+	ids.ID,
+) ([][]byte, error) {
+	return nil, nil
+}
+
+type testAuthFactory struct{}
+
+func (testAuthFactory) Sign([]byte) (Auth, error) { return nil, nil }
+
+func (testAuthFactory) MaxUnits() (uint64, uint64) { return 0, 0 }
+
+func TestEstimateUnits(t *testing.T) {
+	require := require.New(t)
+
+	r := NewMockRules(gomock.NewController(t))
+	r.EXPECT().GetBaseComputeUnits().Return(uint64(1)).AnyTimes()
+	r.EXPECT().GetSponsorStateKeysMaxChunks().Return([]uint16{1}).AnyTimes()
+	r.EXPECT().GetStorageKeyReadUnits().Return(uint64(5)).AnyTimes()
+	r.EXPECT().GetStorageValueReadUnits().Return(uint64(2)).AnyTimes()
+	r.EXPECT().GetStorageKeyAllocateUnits().Return(uint64(20)).AnyTimes()
+	r.EXPECT().GetStorageValueAllocateUnits().Return(uint64(5)).AnyTimes()
+	r.EXPECT().GetStorageKeyWriteUnits().Return(uint64(10)).AnyTimes()
+	r.EXPECT().GetStorageValueWriteUnits().Return(uint64(3)).AnyTimes()
+
+	// Storage units are charged for the max chunks of each key (not for its
+	// index in the list of keys)
+	units, err := EstimateUnits(r, []Action{&testAction{computeUnits: 2, maxChunks: []uint16{4, 4}}}, testAuthFactory{})
+	require.NoError(err)
+	chunks := uint64(4 + 4 + 1)
+	require.Equal(uint64(1+2), units[fees.Compute])
+	require.Equal(3*5+chunks*2, units[fees.StorageRead])
+	require.Equal(3*20+chunks*5, units[fees.StorageAllocate])
+	require.Equal(3*10+chunks*3, units[fees.StorageWrite])
+}
+
+// testDiscountRules waives [discount] percent of the base fee of transactions
+// with a [MaxFee] of [discountedMaxFee].
+type testDiscountRules struct {
+	Rules
+
+	discountedMaxFee uint64
+	discount         uint8
+	max              int
+}
+
+func (r *testDiscountRules) FeeDiscount(tx *Transaction) uint8 {
+	if tx.Base.MaxFee != r.discountedMaxFee {
+		return 0
+	}
+	return r.discount
+}
+
+func (r *testDiscountRules) GetMaxDiscountedTxs() int { return r.max }
+
+// testTipHandler charges nothing and discards tips.
+type testTipHandler struct {
+	testFeeHandler
+}
+
+func (*testTipHandler) TipStateKeys() state.Keys { return state.Keys{} }
+
+func (*testTipHandler) PayTip(context.Context, state.Mutable, uint64) error { return nil }
+
+func TestFeeDiscount(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		discounted = &Transaction{Base: &Base{MaxFee: 100}}
+		full       = &Transaction{Base: &Base{MaxFee: 200}}
+		r          = &testDiscountRules{discountedMaxFee: 100, discount: 50, max: 2}
+	)
+	require.Equal(uint8(50), feeDiscount(r, discounted))
+	require.Zero(feeDiscount(r, full))
+	require.Equal(2, maxDiscountedTxs(r))
+	require.Equal(1, countDiscounted(r, []*Transaction{discounted, full}))
+
+	// Discounts are capped at the whole base fee
+	r.discount = 150
+	require.Equal(uint8(100), feeDiscount(r, discounted))
+
+	// Without [FeeDiscountRules], there are no discounts (and blocks can't
+	// include any discounted transaction)
+	mr := NewMockRules(gomock.NewController(t))
+	require.Zero(feeDiscount(mr, discounted))
+	require.Zero(maxDiscountedTxs(mr))
+	require.Zero(countDiscounted(mr, []*Transaction{discounted, full}))
+}
+
+func TestFees(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseFee  uint64
+		maxFee   uint64
+		tip      uint64
+		discount uint8
+		tips     bool
+		fee      uint64
+		tipped   uint64
+		err      error
+	}{
+		{
+			name:    "no discount",
+			baseFee: 250,
+			maxFee:  1_000,
+			fee:     250,
+		},
+		{
+			name:     "discount",
+			baseFee:  250,
+			maxFee:   1_000,
+			discount: 40,
+			fee:      150,
+		},
+		{
+			name:     "discount rounds down",
+			baseFee:  7,
+			maxFee:   1_000,
+			discount: 33,
+			fee:      5,
+		},
+		{
+			name:     "discount above 100",
+			baseFee:  250,
+			maxFee:   1_000,
+			discount: 150,
+			fee:      0,
+		},
+		{
+			name:     "discount without overflow",
+			baseFee:  consts.MaxUint64,
+			maxFee:   consts.MaxUint64,
+			discount: 50,
+			fee:      consts.MaxUint64 - consts.MaxUint64/2,
+		},
+		{
+			name:     "discounted fee above max fee",
+			baseFee:  250,
+			maxFee:   100,
+			discount: 50,
+			err:      ErrInsufficientPrice,
+		},
+		{
+			name:     "discounted fee at max fee",
+			baseFee:  250,
+			maxFee:   125,
+			discount: 50,
+			fee:      125,
+		},
+		{
+			name:    "tip without tip handler",
+			baseFee: 250,
+			maxFee:  1_000,
+			tip:     100,
+			fee:     250,
+		},
+		{
+			name:     "tip",
+			baseFee:  250,
+			maxFee:   1_000,
+			tip:      100,
+			discount: 40,
+			tips:     true,
+			fee:      250,
+			tipped:   100,
+		},
+		{
+			name:    "tip capped at max fee",
+			baseFee: 250,
+			maxFee:  300,
+			tip:     100,
+			tips:    true,
+			fee:     300,
+			tipped:  50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			fm := fees.NewManager(nil)
+			fm.SetUnitPrice(fees.Bandwidth, 1)
+			var sm StateManager = &testFeeHandler{}
+			if tt.tips {
+				sm = &testTipHandler{}
+			}
+			r := &testDiscountRules{discountedMaxFee: tt.maxFee, discount: tt.discount}
+			tx := &Transaction{Base: &Base{MaxFee: tt.maxFee, Tip: tt.tip}}
+			fee, tipped, err := tx.fees(fm, sm, r, fees.Dimensions{tt.baseFee})
+			require.ErrorIs(err, tt.err)
+			require.Equal(tt.fee, fee)
+			require.Equal(tt.tipped, tipped)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// WarpSignature is the signature of a validator over an outgoing warp message.
+type WarpSignature struct {
+	PublicKey []byte `json:"publicKey"`
+	Signature []byte `json:"signature"`
+}
+
+// warpOutboxKey is the context key of the [warpOutbox] of the transaction
+// being executed.
+type warpOutboxKey struct{}
+
+// warpOutbox collects the messages sent by the actions of a transaction.
+type warpOutbox struct {
+	networkID uint32
+	chainID   ids.ID
+
+	messages []*warp.UnsignedMessage
+}
+
+func withWarpOutbox(ctx context.Context, r Rules) (context.Context, *warpOutbox) {
+	outbox := &warpOutbox{
+		networkID: r.NetworkID(),
+		chainID:   r.ChainID(),
+	}
+	return context.WithValue(ctx, warpOutboxKey{}, outbox), outbox
+}
+
+// SendWarpMessage creates an outgoing warp message with [payload] sent by this
+// chain. It may only be called from [Action.Execute] (with the context it was
+// provided).
+//
+// If the transaction succeeds, the message is included in its [Result]. Once
+// the block that includes the transaction is accepted, each validator of the
+// chain signs the message and collects the signatures of its peers (which can
+// be fetched over RPC and aggregated by a relayer).
+func SendWarpMessage(ctx context.Context, payload []byte) (*warp.UnsignedMessage, error) {
+	outbox, ok := ctx.Value(warpOutboxKey{}).(*warpOutbox)
+	if !ok {
+		return nil, ErrNoWarpOutbox
+	}
+	if len(outbox.messages) >= MaxOutgoingWarpMessages {
+		return nil, ErrTooManyWarpMessages
+	}
+	if isChunkPayload(payload) || isRandomnessPayload(payload) {
+		return nil, ErrReservedWarpPayload
+	}
+	msg, err := warp.NewUnsignedMessage(outbox.networkID, outbox.chainID, payload)
+	if err != nil {
+		return nil, err
+	}
+	outbox.messages = append(outbox.messages, msg)
+	return msg, nil
+}
+
+// actionWarpMessage returns the incoming message carried by [action] (if
+// any).
+func actionWarpMessage(action Action) *warp.Message {
+	wa, ok := action.(WarpAction)
+	if !ok {
+		return nil
+	}
+	return wa.WarpMessage()
+}
+
+// WarpMessages returns the incoming warp messages carried by the actions of
+// [t].
+func (t *Transaction) WarpMessages() []*warp.Message {
+	var msgs []*warp.Message
+	for _, action := range t.Actions {
+		if msg := actionWarpMessage(action); msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// VerifyWarpMessages ensures each incoming warp message carried by [t] is
+// signed by the quorum required by [WarpManager] of the validators of its
+// source subnet at [pChainHeight].
+func (t *Transaction) VerifyWarpMessages(
+	ctx context.Context,
+	sm StateManager,
+	r Rules,
+	vdrState validators.State,
+	pChainHeight uint64,
+) error {
+	msgs := t.WarpMessages()
+	if len(msgs) == 0 {
+		return nil
+	}
+	wm, ok := sm.(WarpManager)
+	if !ok {
+		return ErrWarpNotSupported
+	}
+	for _, msg := range msgs {
+		num, den, err := wm.WarpQuorum(r, &msg.UnsignedMessage)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidWarpMessage, err)
+		}
+		if err := msg.Signature.Verify(
+			ctx,
+			&msg.UnsignedMessage,
+			r.NetworkID(),
+			vdrState,
+			pChainHeight,
+			num,
+			den,
+		); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidWarpMessage, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+)
+
+const testWarpNetworkID = 5
+
+var (
+	_ WarpAction  = (*testWarpAction)(nil)
+	_ WarpManager = (*testWarpManager)(nil)
+
+	errTestAction = errors.New("action failed")
+	errTestQuorum = errors.New("untrusted source")
+)
+
+// testWarpAction carries [msg] (if any), sends [send] (if any), and then fails
+// if [fail] is set.
+type testWarpAction struct {
+	testAction
+
+	msg  *warp.Message
+	send []byte
+	fail bool
+}
+
+func (a *testWarpAction) WarpMessage() *warp.Message { return a.msg }
+
+func (a *testWarpAction) Execute(
+	ctx context.Context,
+	_ Rules,
+	_ state.Mutable,
+	_ int64,
+	_ codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if a.send != nil {
+		if _, err := SendWarpMessage(ctx, a.send); err != nil {
+			return nil, err
+		}
+	}
+	if a.fail {
+		return nil, errTestAction
+	}
+	return nil, nil
+}
+
+type testAuth struct {
+	Auth
+}
+
+func (*testAuth) ComputeUnits(Rules) uint64 { return 0 }
+
+func (*testAuth) Actor() codec.Address { return codec.EmptyAddress }
+
+func (*testAuth) Sponsor() codec.Address { return codec.EmptyAddress }
+
+// testFeeHandler charges nothing.
+type testFeeHandler struct {
+	StateManager
+}
+
+func (*testFeeHandler) SponsorStateKeys(codec.Address) state.Keys { return state.Keys{} }
+
+func (*testFeeHandler) Deduct(context.Context, codec.Address, state.Mutable, uint64) error {
+	return nil
+}
+
+// testWarpManager requires 2/3 of the stake of the source subnet (unless
+// [quorumErr] is set).
+type testWarpManager struct {
+	testFeeHandler
+
+	quorumErr error
+}
+
+func (m *testWarpManager) WarpQuorum(Rules, *warp.UnsignedMessage) (uint64, uint64, error) {
+	return 2, 3, m.quorumErr
+}
+
+func (*testWarpManager) WarpStateKeys(*warp.UnsignedMessage) state.Keys { return state.Keys{} }
+
+func (*testWarpManager) ApplyWarpMessage(
+	context.Context,
+	Rules,
+	state.Mutable,
+	int64,
+	codec.Address,
+	*warp.UnsignedMessage,
+) error {
+	return nil
+}
+
+// testValidatorState returns the same validators for every subnet and height.
+type testValidatorState struct {
+	validators.State
+
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput
+}
+
+func (*testValidatorState) GetSubnetID(context.Context, ids.ID) (ids.ID, error) {
+	return ids.Empty, nil
+}
+
+func (s *testValidatorState) GetValidatorSet(
+	context.Context,
+	uint64,
+	ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return s.vdrs, nil
+}
+
+// newTestWarpValidators returns a validator set of [n] validators with equal
+// weight and their secret keys (in canonical order).
+func newTestWarpValidators(t *testing.T, n int) (*testValidatorState, []*bls.SecretKey) {
+	require := require.New(t)
+
+	var (
+		vdrState = &testValidatorState{vdrs: map[ids.NodeID]*validators.GetValidatorOutput{}}
+		sks      = []*bls.SecretKey{}
+	)
+	for i := 0; i < n; i++ {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		nodeID := ids.GenerateTestNodeID()
+		vdrState.vdrs[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    1,
+		}
+		sks = append(sks, sk)
+	}
+	canonical, _, err := warp.GetCanonicalValidatorSet(context.Background(), vdrState, 1, ids.Empty)
+	require.NoError(err)
+	ordered := make([]*bls.SecretKey, len(canonical))
+	for i, vdr := range canonical {
+		for _, sk := range sks {
+			if bytes.Equal(bls.PublicKeyToUncompressedBytes(bls.PublicFromSecretKey(sk)), vdr.PublicKeyBytes) {
+				ordered[i] = sk
+			}
+		}
+	}
+	return vdrState, ordered
+}
+
+// signTestWarpMessage returns [unsigned] signed by [sks] with the signer bits
+// set to [signers] (so the signature is only valid if [sks] are the keys of
+// [signers]).
+func signTestWarpMessage(t *testing.T, unsigned *warp.UnsignedMessage, signers []int, sks []*bls.SecretKey) *warp.Message {
+	require := require.New(t)
+
+	bits := set.NewBits(signers...)
+	sigs := make([]*bls.Signature, len(sks))
+	for i, sk := range sks {
+		sigs[i] = bls.Sign(sk, unsigned.Bytes())
+	}
+	aggSig, err := bls.AggregateSignatures(sigs)
+	require.NoError(err)
+	sig := &warp.BitSetSignature{Signers: bits.Bytes()}
+	copy(sig.Signature[:], bls.SignatureToBytes(aggSig))
+	msg, err := warp.NewMessage(unsigned, sig)
+	require.NoError(err)
+	return msg
+}
+
+func TestSendWarpMessage(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	r := NewMockRules(ctrl)
+	r.EXPECT().NetworkID().Return(uint32(testWarpNetworkID)).AnyTimes()
+	chainID := ids.GenerateTestID()
+	r.EXPECT().ChainID().Return(chainID).AnyTimes()
+
+	// Messages can only be sent during execution
+	_, err := SendWarpMessage(context.Background(), []byte("payload"))
+	require.ErrorIs(err, ErrNoWarpOutbox)
+
+	ctx, outbox := withWarpOutbox(context.Background(), r)
+	msg, err := SendWarpMessage(ctx, []byte("payload"))
+	require.NoError(err)
+	require.Equal(uint32(testWarpNetworkID), msg.NetworkID)
+	require.Equal(chainID, msg.SourceChainID)
+	require.Equal([]byte("payload"), msg.Payload)
+	require.Equal([]*warp.UnsignedMessage{msg}, outbox.messages)
+
+	// Payloads signed by validators for the VM can't be sent by actions
+	_, err = SendWarpMessage(ctx, chunkPayloadPrefix)
+	require.ErrorIs(err, ErrReservedWarpPayload)
+	_, err = SendWarpMessage(ctx, randomnessPayloadPrefix)
+	require.ErrorIs(err, ErrReservedWarpPayload)
+
+	for len(outbox.messages) < MaxOutgoingWarpMessages {
+		_, err := SendWarpMessage(ctx, []byte("payload"))
+		require.NoError(err)
+	}
+	_, err = SendWarpMessage(ctx, []byte("payload"))
+	require.ErrorIs(err, ErrTooManyWarpMessages)
+}
+
+func TestVerifyWarpMessages(t *testing.T) {
+	vdrState, sks := newTestWarpValidators(t, 3)
+	unsigned, err := warp.NewUnsignedMessage(testWarpNetworkID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(t, err)
+	otherNetwork, err := warp.NewUnsignedMessage(testWarpNetworkID+1, unsigned.SourceChainID, unsigned.Payload)
+	require.NoError(t, err)
+	otherKey, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		sm   StateManager
+		msgs []*warp.Message
+		err  error
+	}{
+		{
+			name: "no messages",
+			sm:   &testFeeHandler{},
+		},
+		{
+			name: "warp not supported",
+			sm:   &testFeeHandler{},
+			msgs: []*warp.Message{signTestWarpMessage(t, unsigned, []int{0, 1, 2}, sks)},
+			err:  ErrWarpNotSupported,
+		},
+		{
+			name: "quorum",
+			sm:   &testWarpManager{},
+			msgs: []*warp.Message{signTestWarpMessage(t, unsigned, []int{0, 1}, sks[:2])},
+		},
+		{
+			name: "all validators",
+			sm:   &testWarpManager{},
+			msgs: []*warp.Message{
+				signTestWarpMessage(t, unsigned, []int{0, 1, 2}, sks),
+				signTestWarpMessage(t, unsigned, []int{1, 2}, sks[1:]),
+			},
+		},
+		{
+			name: "untrusted source",
+			sm:   &testWarpManager{quorumErr: errTestQuorum},
+			msgs: []*warp.Message{signTestWarpMessage(t, unsigned, []int{0, 1, 2}, sks)},
+			err:  errTestQuorum,
+		},
+		{
+			name: "insufficient weight",
+			sm:   &testWarpManager{},
+			msgs: []*warp.Message{signTestWarpMessage(t, unsigned, []int{0}, sks[:1])},
+			err:  warp.ErrInsufficientWeight,
+		},
+		{
+			name: "invalid signature",
+			sm:   &testWarpManager{},
+			// Claims to be signed by validators 0 and 1 (but is only signed by
+			// 0)
+			msgs: []*warp.Message{signTestWarpMessage(t, unsigned, []int{0, 1}, sks[:1])},
+			err:  warp.ErrInvalidSignature,
+		},
+		{
+			name: "signature of unknown key",
+			sm:   &testWarpManager{},
+			msgs: []*warp.Message{signTestWarpMessage(t, unsigned, []int{0, 1}, []*bls.SecretKey{sks[0], otherKey})},
+			err:  warp.ErrInvalidSignature,
+		},
+		{
+			name: "one invalid message",
+			sm:   &testWarpManager{},
+			msgs: []*warp.Message{
+				signTestWarpMessage(t, unsigned, []int{0, 1, 2}, sks),
+				signTestWarpMessage(t, unsigned, []int{0, 1}, sks[:1]),
+			},
+			err: warp.ErrInvalidSignature,
+		},
+		{
+			name: "wrong network",
+			sm:   &testWarpManager{},
+			msgs: []*warp.Message{signTestWarpMessage(t, otherNetwork, []int{0, 1, 2}, sks)},
+			err:  warp.ErrWrongNetworkID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := NewMockRules(gomock.NewController(t))
+			r.EXPECT().NetworkID().Return(uint32(testWarpNetworkID)).AnyTimes()
+			actions := []Action{&testAction{}}
+			for _, msg := range tt.msgs {
+				actions = append(actions, &testWarpAction{msg: msg})
+			}
+			tx := &Transaction{Base: &Base{}, Actions: actions, Auth: &testAuth{}}
+			err := tx.VerifyWarpMessages(context.Background(), tt.sm, r, vdrState, 1)
+			require.ErrorIs(err, tt.err)
+			if tt.err != nil && !errors.Is(tt.err, ErrWarpNotSupported) {
+				require.ErrorIs(err, ErrInvalidWarpMessage)
+			}
+		})
+	}
+}
+
+func TestExecuteWarpOutbox(t *testing.T) {
+	tests := []struct {
+		name     string
+		actions  []Action
+		success  bool
+		messages [][]byte
+	}{
+		{
+			name:    "no messages",
+			actions: []Action{&testWarpAction{}},
+			success: true,
+		},
+		{
+			name: "messages of all actions",
+			actions: []Action{
+				&testWarpAction{send: []byte("first")},
+				&testWarpAction{},
+				&testWarpAction{send: []byte("second")},
+			},
+			success:  true,
+			messages: [][]byte{[]byte("first"), []byte("second")},
+		},
+		{
+			name:    "sending action fails",
+			actions: []Action{&testWarpAction{send: []byte("first"), fail: true}},
+		},
+		{
+			name: "later action fails",
+			actions: []Action{
+				&testWarpAction{send: []byte("first")},
+				&testWarpAction{fail: true},
+			},
+		},
+		{
+			name: "reserved payload",
+			actions: []Action{
+				&testWarpAction{send: []byte("first")},
+				&testWarpAction{send: chunkPayloadPrefix},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			r := NewMockRules(ctrl)
+			r.EXPECT().NetworkID().Return(uint32(testWarpNetworkID)).AnyTimes()
+			r.EXPECT().ChainID().Return(ids.Empty).AnyTimes()
+			r.EXPECT().GetBaseComputeUnits().Return(uint64(1)).AnyTimes()
+			r.EXPECT().GetMaxOutputsPerAction().Return(uint8(1)).AnyTimes()
+
+			tx := &Transaction{Base: &Base{}, Actions: tt.actions, Auth: &testAuth{}}
+			ts := tstate.New(0)
+			tsv := ts.NewView(state.Keys{}, map[string][]byte{})
+			result, err := tx.Execute(ctx, fees.NewManager(nil), &testWarpManager{}, r, tsv, 1_000)
+			require.NoError(err)
+			require.Equal(tt.success, result.Success)
+
+			// Messages are only included in the result if all actions succeed
+			payloads := make([][]byte, len(result.WarpMessages))
+			for i, msg := range result.WarpMessages {
+				require.Equal(uint32(testWarpNetworkID), msg.NetworkID)
+				payloads[i] = msg.Payload
+			}
+			require.Equal(len(tt.messages), len(payloads))
+			for i, payload := range tt.messages {
+				require.Equal(payload, payloads[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package checkpoint reads and writes checkpoints: the state of a chain at an
+// accepted block, signed by a trusted publisher, that can be distributed
+// out-of-band to initialize new nodes (instead of syncing state from peers).
+//
+// A checkpoint starts with [Magic] followed by a version byte, the [Header]
+// (prefixed by its length as a 4-byte big-endian integer), and the key/value
+// pairs of the state (each key and value prefixed by its length as a 4-byte
+// big-endian integer) until the end of the file.
+//
+// The state of a checkpoint is the state the last block of its [Header] was
+// built on, so nodes can verify it by comparing its root with the StateRoot of
+// that block.
+package checkpoint
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	Magic   = "hckp"
+	Version = 1
+
+	// FileExtension is the extension of checkpoint files
+	FileExtension = ".hckp"
+
+	maxHeaderSize = 512 * units.MiB
+	maxRecordSize = 64 * units.MiB
+)
+
+var (
+	ErrInvalidHeader       = errors.New("invalid checkpoint header")
+	ErrNoBlocks            = errors.New("checkpoint has no blocks")
+	ErrUntrustedPublisher  = errors.New("untrusted publisher")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrRecordTooLarge      = errors.New("record too large")
+	ErrTruncatedCheckpoint = errors.New("truncated checkpoint")
+)
+
+// Header identifies the block the state of a checkpoint is verified against.
+type Header struct {
+	// Blocks are the accepted blocks that end at the checkpoint block (oldest
+	// first), so nodes can prevent replays of the transactions in their
+	// validity window. Each block must be the parent of the next one.
+	Blocks [][]byte
+
+	Publisher ed25519.PublicKey
+	// Signature is the signature of [Digest] of the last block of [Blocks]
+	// by [Publisher]
+	Signature ed25519.Signature
+}
+
+// Digest returns the message publishers sign to vouch for [blkID] (accepted
+// on [chainID]).
+func Digest(chainID ids.ID, blkID ids.ID) []byte {
+	msg := make([]byte, 0, len(Magic)+2*ids.IDLen)
+	msg = append(msg, Magic...)
+	msg = append(msg, chainID[:]...)
+	return append(msg, blkID[:]...)
+}
+
+// BlockID returns the ID of the checkpoint block.
+func (h *Header) BlockID() (ids.ID, error) {
+	if len(h.Blocks) == 0 {
+		return ids.Empty, ErrNoBlocks
+	}
+	return utils.ToID(h.Blocks[len(h.Blocks)-1]), nil
+}
+
+// Sign sets the [Publisher] and [Signature] of [h] using [priv].
+func (h *Header) Sign(chainID ids.ID, priv ed25519.PrivateKey) error {
+	blkID, err := h.BlockID()
+	if err != nil {
+		return err
+	}
+	h.Publisher = priv.PublicKey()
+	h.Signature = ed25519.Sign(Digest(chainID, blkID), priv)
+	return nil
+}
+
+// Verify ensures [h] is signed by one of [trusted]. It does not verify
+// [Blocks].
+func (h *Header) Verify(chainID ids.ID, trusted []ed25519.PublicKey) error {
+	blkID, err := h.BlockID()
+	if err != nil {
+		return err
+	}
+	isTrusted := false
+	for _, pk := range trusted {
+		if pk == h.Publisher {
+			isTrusted = true
+			break
+		}
+	}
+	if !isTrusted {
+		return fmt.Errorf("%w: %x", ErrUntrustedPublisher, h.Publisher[:])
+	}
+	if !ed25519.Verify(Digest(chainID, blkID), h.Publisher, h.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (h *Header) Marshal() ([]byte, error) {
+	size := consts.IntLen + ed25519.PublicKeyLen + ed25519.SignatureLen
+	for _, blk := range h.Blocks {
+		size += codec.BytesLen(blk)
+	}
+	p := codec.NewWriter(size, maxHeaderSize)
+	p.PackInt(len(h.Blocks))
+	for _, blk := range h.Blocks {
+		p.PackBytes(blk)
+	}
+	p.PackFixedBytes(h.Publisher[:])
+	p.PackFixedBytes(h.Signature[:])
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalHeader(b []byte) (*Header, error) {
+	p := codec.NewReader(b, maxHeaderSize)
+	count := p.UnpackInt(true)
+	h := &Header{Blocks: make([][]byte, 0, min(count, len(b)))}
+	for i := 0; i < count; i++ {
+		var blk []byte
+		p.UnpackBytes(-1, true, &blk)
+		if err := p.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+		}
+		h.Blocks = append(h.Blocks, blk)
+	}
+	publisher, signature := h.Publisher[:], h.Signature[:]
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &publisher)
+	p.UnpackFixedBytes(ed25519.SignatureLen, &signature)
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if !p.Empty() {
+		return nil, fmt.Errorf("%w: unexpected trailing bytes", ErrInvalidHeader)
+	}
+	return h, nil
+}
+
+// Writer writes a checkpoint. The state can be written in any order.
+type Writer struct {
+	w *bufio.Writer
+}
+
+// NewWriter writes the start of a checkpoint with [h] to [w].
+func NewWriter(w io.Writer, h *Header) (*Writer, error) {
+	header, err := h.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	cw := &Writer{w: bufio.NewWriter(w)}
+	if _, err := cw.w.Write(append([]byte(Magic), Version)); err != nil {
+		return nil, err
+	}
+	if err := cw.write(header); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (w *Writer) write(b []byte) error {
+	if _, err := w.w.Write(binary.BigEndian.AppendUint32(nil, uint32(len(b)))); err != nil {
+		return err
+	}
+	_, err := w.w.Write(b)
+	return err
+}
+
+// Put writes the value of [key] in the state of the checkpoint.
+func (w *Writer) Put(key []byte, value []byte) error {
+	if len(key) > maxRecordSize || len(value) > maxRecordSize {
+		return ErrRecordTooLarge
+	}
+	if err := w.write(key); err != nil {
+		return err
+	}
+	return w.write(value)
+}
+
+// Flush writes any buffered data to the underlying [io.Writer].
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// Read reads a checkpoint from [r], calling [onHeader] with its [Header]
+// (which should be verified before the state is used) and then [onValue] with
+// each key/value pair of its state, until either returns an error.
+func Read(
+	r io.Reader,
+	onHeader func(*Header) error,
+	onValue func(key []byte, value []byte) error,
+) error {
+	br := bufio.NewReader(r)
+	start := make([]byte, len(Magic)+1)
+	if _, err := io.ReadFull(br, start); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if string(start[:len(Magic)]) != Magic || start[len(Magic)] != Version {
+		return ErrInvalidHeader
+	}
+	raw, err := readRecord(br, maxHeaderSize)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	h, err := UnmarshalHeader(raw)
+	if err != nil {
+		return err
+	}
+	if err := onHeader(h); err != nil {
+		return err
+	}
+	for {
+		key, err := readRecord(br, maxRecordSize)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		value, err := readRecord(br, maxRecordSize)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("%w: %w", ErrTruncatedCheckpoint, err)
+		}
+		if err := onValue(key, value); err != nil {
+			return err
+		}
+	}
+}
+
+// readRecord reads a length-prefixed record from [r]. It returns [io.EOF]
+// only if there are no more records.
+func readRecord(r io.Reader, limit int) ([]byte, error) {
+	size := make([]byte, consts.Uint32Len)
+	if _, err := io.ReadFull(r, size); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: %w", ErrTruncatedCheckpoint, err)
+		}
+		return nil, err
+	}
+	l := binary.BigEndian.Uint32(size)
+	if int64(l) > int64(limit) {
+		return nil, fmt.Errorf("%w: %d bytes", ErrRecordTooLarge, l)
+	}
+	b := make([]byte, l)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("%w: %w", ErrTruncatedCheckpoint, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package checkpoint
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+func TestHeaderVerify(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	other, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+
+	h := &Header{}
+	require.ErrorIs(h.Sign(chainID, priv), ErrNoBlocks)
+	h.Blocks = [][]byte{{1}, {2}}
+	require.NoError(h.Sign(chainID, priv))
+	require.NoError(h.Verify(chainID, []ed25519.PublicKey{other.PublicKey(), priv.PublicKey()}))
+	require.ErrorIs(h.Verify(chainID, []ed25519.PublicKey{other.PublicKey()}), ErrUntrustedPublisher)
+	require.ErrorIs(h.Verify(ids.GenerateTestID(), []ed25519.PublicKey{priv.PublicKey()}), ErrInvalidSignature)
+
+	// The signature only covers the last block
+	h.Blocks[1] = []byte{3}
+	require.ErrorIs(h.Verify(chainID, []ed25519.PublicKey{priv.PublicKey()}), ErrInvalidSignature)
+}
+
+func TestReadWrite(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	h := &Header{Blocks: [][]byte{{1, 2, 3}, {4, 5}}}
+	require.NoError(h.Sign(chainID, priv))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, h)
+	require.NoError(err)
+	kvs := [][2][]byte{{{1}, {2}}, {{3}, {}}, {{4, 5}, {6, 7, 8}}}
+	for _, kv := range kvs {
+		require.NoError(w.Put(kv[0], kv[1]))
+	}
+	require.NoError(w.Flush())
+	raw := buf.Bytes()
+
+	var (
+		header *Header
+		read   [][2][]byte
+	)
+	require.NoError(Read(bytes.NewReader(raw), func(h *Header) error {
+		header = h
+		return nil
+	}, func(key []byte, value []byte) error {
+		read = append(read, [2][]byte{key, value})
+		return nil
+	}))
+	require.Equal(h, header)
+	require.Equal(kvs, read)
+	require.NoError(header.Verify(chainID, []ed25519.PublicKey{priv.PublicKey()}))
+
+	// Reading stops at the first error
+	require.ErrorIs(Read(bytes.NewReader(raw), func(*Header) error {
+		return ErrUntrustedPublisher
+	}, nil), ErrUntrustedPublisher)
+
+	// Truncated checkpoints are rejected
+	onHeader := func(*Header) error { return nil }
+	onValue := func([]byte, []byte) error { return nil }
+	require.ErrorIs(Read(bytes.NewReader(raw[:len(raw)-1]), onHeader, onValue), ErrTruncatedCheckpoint)
+	require.ErrorIs(Read(bytes.NewReader(raw[:len(raw)-5]), onHeader, onValue), ErrTruncatedCheckpoint)
+	require.ErrorIs(Read(bytes.NewReader(raw[:len(raw)-7]), onHeader, onValue), ErrTruncatedCheckpoint)
+	require.ErrorIs(Read(bytes.NewReader(raw[:3]), onHeader, onValue), io.ErrUnexpectedEOF)
+
+	// Other formats are rejected
+	invalid := bytes.Clone(raw)
+	invalid[len(Magic)] = Version + 1
+	require.ErrorIs(Read(bytes.NewReader(invalid), onHeader, onValue), ErrInvalidHeader)
+}
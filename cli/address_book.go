@@ -0,0 +1,248 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// MaxLabelLen is the maximum length of an address book label.
+const MaxLabelLen = 64
+
+// AddressLabel is an entry of the address book (as exported by
+// [Handler.ExportAddressBook]).
+type AddressLabel struct {
+	Label   string `json:"label"`
+	Address string `json:"address"`
+}
+
+func labelKey(label string) []byte {
+	k := make([]byte, 1+len(label))
+	k[0] = labelPrefix
+	copy(k[1:], label)
+	return k
+}
+
+// checkLabel returns an error if [label] can't be stored in the address book.
+// Labels can't contain whitespace or be valid addresses themselves (so it is
+// never ambiguous which one is meant).
+func (h *Handler) checkLabel(label string) error {
+	if len(label) == 0 {
+		return ErrInputEmpty
+	}
+	if strings.IndexFunc(label, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%w: %q contains whitespace", ErrInvalidLabel, label)
+	}
+	if _, err := h.c.ParseAddress(label); err == nil {
+		return fmt.Errorf("%w: %q is an address", ErrInvalidLabel, label)
+	}
+	if len(label) > MaxLabelLen {
+		return ErrInputTooLarge
+	}
+	return nil
+}
+
+// StoreLabel labels [addr] with [label] in the address book.
+func (h *Handler) StoreLabel(label string, addr codec.Address) error {
+	if err := h.checkLabel(label); err != nil {
+		return err
+	}
+	k := labelKey(label)
+	has, err := h.db.Has(k)
+	if err != nil {
+		return err
+	}
+	if has {
+		return fmt.Errorf("%w: %s", ErrDuplicate, label)
+	}
+	if err := h.db.Put(k, addr[:]); err != nil {
+		return err
+	}
+	h.labels = nil
+	return nil
+}
+
+// DeleteLabel removes [label] from the address book.
+func (h *Handler) DeleteLabel(label string) error {
+	k := labelKey(label)
+	has, err := h.db.Has(k)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("%w: %s", ErrUnknownLabel, label)
+	}
+	if err := h.db.Delete(k); err != nil {
+		return err
+	}
+	h.labels = nil
+	return nil
+}
+
+// GetLabels returns the address of each label in the address book.
+func (h *Handler) GetLabels() (map[string]codec.Address, error) {
+	iter := h.db.NewIteratorWithPrefix([]byte{labelPrefix})
+	defer iter.Release()
+
+	labels := map[string]codec.Address{}
+	for iter.Next() {
+		labels[string(iter.Key()[1:])] = codec.Address(iter.Value())
+	}
+	return labels, iter.Error()
+}
+
+// ResolveAddress parses [input] as an address book label or (if it isn't
+// one) as an address.
+func (h *Handler) ResolveAddress(input string) (codec.Address, error) {
+	input = strings.TrimSpace(input)
+	if h.db != nil && len(input) > 0 {
+		v, err := h.db.Get(labelKey(input))
+		if err == nil {
+			return codec.Address(v), nil
+		}
+		if !errors.Is(err, database.ErrNotFound) {
+			return codec.EmptyAddress, err
+		}
+	}
+	return h.c.ParseAddress(input)
+}
+
+// FormatAddress returns [addr] prefixed with its label (if it has one).
+//
+// Labels are loaded on first use, so commands that close the database before
+// printing addresses must call [Handler.loadLabels] first.
+func (h *Handler) FormatAddress(addr codec.Address) string {
+	if h.labels == nil && h.db != nil {
+		// Labels are only decoration, so addresses are still printed if they
+		// can't be loaded
+		_ = h.loadLabels()
+	}
+	if label, ok := h.labels[addr]; ok {
+		return fmt.Sprintf("%s (%s)", label, h.c.Address(addr))
+	}
+	return h.c.Address(addr)
+}
+
+// loadLabels caches the label of each address in the address book (the
+// first label in lexicographic order if an address has several).
+func (h *Handler) loadLabels() error {
+	labels, err := h.GetLabels()
+	if err != nil {
+		return err
+	}
+	h.labels = make(map[codec.Address]string, len(labels))
+	for label, addr := range labels {
+		if existing, ok := h.labels[addr]; !ok || label < existing {
+			h.labels[addr] = label
+		}
+	}
+	return nil
+}
+
+// PrintAddressBook prints the labels in the address book.
+func (h *Handler) PrintAddressBook() error {
+	entries, err := h.addressBook()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		utils.Outf("{{red}}no stored labels{{/}}\n")
+		return nil
+	}
+	utils.Outf("{{cyan}}stored labels:{{/}} %d\n", len(entries))
+	for _, entry := range entries {
+		utils.Outf("{{yellow}}%s:{{/}} %s\n", entry.Label, entry.Address)
+	}
+	return nil
+}
+
+// ExportAddressBook writes the address book to [path] as JSON.
+func (h *Handler) ExportAddressBook(path string) error {
+	entries, err := h.addressBook()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := utils.SaveBytes(path, b); err != nil {
+		return err
+	}
+	utils.Outf("{{green}}exported %d labels to:{{/}} %s\n", len(entries), path)
+	return nil
+}
+
+// ImportAddressBook adds the labels written by [Handler.ExportAddressBook]
+// at [path] to the address book. Labels that are already stored with the
+// same address are skipped, and nothing is imported if any label is stored
+// with a different address.
+func (h *Handler) ImportAddressBook(path string) error {
+	b, err := utils.LoadBytes(path, -1)
+	if err != nil {
+		return err
+	}
+	var entries []*AddressLabel
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+	labels, err := h.GetLabels()
+	if err != nil {
+		return err
+	}
+	batch := h.db.NewBatch()
+	imported := 0
+	for _, entry := range entries {
+		if err := h.checkLabel(entry.Label); err != nil {
+			return err
+		}
+		addr, err := h.c.ParseAddress(entry.Address)
+		if err != nil {
+			return fmt.Errorf("%w: label %s", err, entry.Label)
+		}
+		if existing, ok := labels[entry.Label]; ok {
+			if existing != addr {
+				return fmt.Errorf("%w: %s", ErrDuplicate, entry.Label)
+			}
+			continue
+		}
+		labels[entry.Label] = addr
+		if err := batch.Put(labelKey(entry.Label), addr[:]); err != nil {
+			return err
+		}
+		imported++
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	h.labels = nil
+	utils.Outf("{{green}}imported %d labels (%d already stored){{/}}\n", imported, len(entries)-imported)
+	return nil
+}
+
+// addressBook returns the entries of the address book sorted by label.
+func (h *Handler) addressBook() ([]*AddressLabel, error) {
+	labels, err := h.GetLabels()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*AddressLabel, 0, len(labels))
+	for label, addr := range labels {
+		entries = append(entries, &AddressLabel{Label: label, Address: h.c.Address(addr)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Label < entries[j].Label
+	})
+	return entries, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+func TestAddressBook(t *testing.T) {
+	require := require.New(t)
+	h := &Handler{c: testController{}, db: memdb.New()}
+	alice := codec.Address{1}
+	bob := codec.Address{2}
+
+	// Labels must not be ambiguous
+	require.ErrorIs(h.StoreLabel("", alice), ErrInputEmpty)
+	require.ErrorIs(h.StoreLabel("alice smith", alice), ErrInvalidLabel)
+	require.ErrorIs(h.StoreLabel(h.c.Address(bob), alice), ErrInvalidLabel)
+
+	require.NoError(h.StoreLabel("alice", alice))
+	require.ErrorIs(h.StoreLabel("alice", bob), ErrDuplicate)
+	require.Equal("alice ("+h.c.Address(alice)+")", h.FormatAddress(alice))
+	require.Equal(h.c.Address(bob), h.FormatAddress(bob))
+
+	// Labels and addresses are both accepted
+	addr, err := h.ResolveAddress(" alice ")
+	require.NoError(err)
+	require.Equal(alice, addr)
+	addr, err = h.ResolveAddress(h.c.Address(bob))
+	require.NoError(err)
+	require.Equal(bob, addr)
+	_, err = h.ResolveAddress("bob")
+	require.Error(err)
+
+	// The book can be moved to another database
+	require.NoError(h.StoreLabel("bob", bob))
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	require.NoError(h.ExportAddressBook(path))
+
+	other := &Handler{c: testController{}, db: memdb.New()}
+	require.NoError(other.StoreLabel("bob", bob))
+	require.NoError(other.ImportAddressBook(path))
+	labels, err := other.GetLabels()
+	require.NoError(err)
+	require.Equal(map[string]codec.Address{"alice": alice, "bob": bob}, labels)
+
+	// Conflicting labels are not imported
+	require.NoError(h.DeleteLabel("alice"))
+	require.ErrorIs(h.DeleteLabel("alice"), ErrUnknownLabel)
+	require.Equal(h.c.Address(alice), h.FormatAddress(alice))
+	require.NoError(h.StoreLabel("alice", bob))
+	require.NoError(h.ExportAddressBook(path))
+	require.ErrorIs(other.ImportAddressBook(path), ErrDuplicate)
+	labels, err = other.GetLabels()
+	require.NoError(err)
+	require.Equal(alice, labels["alice"])
+}
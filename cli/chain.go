@@ -17,6 +17,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/pubsub"
@@ -191,12 +192,107 @@ func (h *Handler) PrintChainInfo() error {
 	return nil
 }
 
-func (h *Handler) WatchChain(hideTxs bool, getParser func(string, uint32, ids.ID) (chain.Parser, error), handleTx func(*chain.Transaction, *chain.Result)) error {
+// PrintUnitProfile prints where the units consumed by the accepted blocks with
+// heights in [start, end] were spent (by action type, fee dimension, and state
+// key namespace). The node must set [vm.Config.UnitProfileSize].
+func (h *Handler) PrintUnitProfile(start uint64, end uint64) error {
+	_, uris, err := h.PromptChain("select chainID", nil)
+	if err != nil {
+		return err
+	}
+	cli := rpc.NewJSONRPCClient(uris[0])
+	profile, err := cli.UnitProfile(context.Background(), start, end)
+	if err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{cyan}}blocks:{{/}} %d-%d (%d blocks, %d txs) {{cyan}}units:{{/}} %s\n",
+		profile.StartHeight,
+		profile.EndHeight,
+		profile.Blocks,
+		profile.Txs,
+		ParseDimensions(profile.Units),
+	)
+	for _, action := range profile.Actions {
+		utils.Outf(
+			"{{yellow}}action %s (%d):{{/}} count=%d units=%s\n",
+			action.Name,
+			action.TypeID,
+			action.Count,
+			ParseDimensions(action.Units),
+		)
+	}
+	for _, ns := range profile.Namespaces {
+		utils.Outf(
+			"{{yellow}}namespace %s:{{/}} keys=%d read=%d allocate=%d write=%d\n",
+			ns.Namespace,
+			ns.Keys,
+			ns.Read,
+			ns.Allocate,
+			ns.Write,
+		)
+	}
+	return nil
+}
+
+// ParseActionTypes returns the type IDs of the actions in [names], using
+// [types] to look up the ID of each name.
+func ParseActionTypes(names []string, types map[string]uint8) ([]uint8, error) {
+	typeIDs := make([]uint8, len(names))
+	for i, name := range names {
+		typeID, ok := types[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownActionType, name)
+		}
+		typeIDs[i] = typeID
+	}
+	return typeIDs, nil
+}
+
+// ParseTxFilter returns a filter for [WatchChain] or nil if no filter
+// fields are provided. [minFee] is formatted like a balance and may be empty.
+func (h *Handler) ParseTxFilter(addresses []string, actionTypes []uint8, minFee string, failedOnly bool) (*rpc.TxFilter, error) {
+	if len(addresses) == 0 && len(actionTypes) == 0 && len(minFee) == 0 && !failedOnly {
+		return nil, nil
+	}
+	if len(addresses) > rpc.MaxFilterAddresses {
+		return nil, rpc.ErrTooManyFilterAddresses
+	}
+	filter := &rpc.TxFilter{
+		Addresses:   make([]codec.Address, len(addresses)),
+		ActionTypes: actionTypes,
+		FailedOnly:  failedOnly,
+	}
+	for i, addr := range addresses {
+		parsed, err := h.ResolveAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Addresses[i] = parsed
+	}
+	if len(minFee) > 0 {
+		fee, err := utils.ParseBalance(minFee, h.c.Decimals())
+		if err != nil {
+			return nil, err
+		}
+		filter.MinFee = fee
+	}
+	return filter, nil
+}
+
+// WatchChain prints accepted blocks (and their transactions unless [hideTxs]
+// is set) until interrupted. If [filter] is provided, only the transactions
+// that match it are printed and they are selected by the server.
+func (h *Handler) WatchChain(hideTxs bool, filter *rpc.TxFilter, getParser func(string, uint32, ids.ID) (chain.Parser, error), handleTx func(*chain.Transaction, *chain.Result)) error {
 	ctx := context.Background()
 	chainID, uris, err := h.PromptChain("select chainID", nil)
 	if err != nil {
 		return err
 	}
+	// [handleTx] labels addresses after the database is closed
+	if err := h.loadLabels(); err != nil {
+		return err
+	}
 	if err := h.CloseDatabase(); err != nil {
 		return err
 	}
@@ -215,6 +311,9 @@ func (h *Handler) WatchChain(hideTxs bool, getParser func(string, uint32, ids.ID
 		return err
 	}
 	defer scli.Close()
+	if filter != nil {
+		return watchFiltered(ctx, scli, chainID, filter, parser, handleTx)
+	}
 	if err := scli.RegisterBlocks(); err != nil {
 		return err
 	}
@@ -287,3 +386,35 @@ func (h *Handler) WatchChain(hideTxs bool, getParser func(string, uint32, ids.ID
 	}
 	return nil
 }
+
+func watchFiltered(
+	ctx context.Context,
+	scli *rpc.WebSocketClient,
+	chainID ids.ID,
+	filter *rpc.TxFilter,
+	parser chain.Parser,
+	handleTx func(*chain.Transaction, *chain.Result),
+) error {
+	if err := scli.RegisterFilteredTxs(filter); err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{green}}watching for txs on %s 👀{{/}} {{yellow}}addresses:{{/}}%d {{yellow}}action types:{{/}}%v {{yellow}}min fee:{{/}}%d {{yellow}}failed only:{{/}}%t\n",
+		chainID,
+		len(filter.Addresses),
+		filter.ActionTypes,
+		filter.MinFee,
+		filter.FailedOnly,
+	)
+	for ctx.Err() == nil {
+		height, txs, results, err := scli.ListenFilteredTxs(ctx, parser)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{green}}height:{{/}}%d {{green}}matching txs:{{/}}%d\n", height, len(txs))
+		for i, tx := range txs {
+			handleTx(tx, results[i])
+		}
+	}
+	return nil
+}
@@ -5,7 +5,10 @@ package cli
 
 import (
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
 
+	"github.com/ava-labs/hypersdk/audit"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/pebble"
 )
 
@@ -13,6 +16,22 @@ type Handler struct {
 	c Controller
 
 	db database.Database
+
+	// keystoreKey is cached after the keystore is unlocked so that the
+	// password is only requested once per session.
+	keystoreKey []byte
+
+	// audit records the use of stored keys (nil if the [Controller] doesn't
+	// enable it)
+	audit *audit.Logger
+
+	// labels caches the address book label of each address (nil until
+	// loaded by [Handler.FormatAddress])
+	labels map[codec.Address]string
+
+	plugins       []ActionPlugin
+	pluginsByName map[string]ActionPlugin
+	pluginsByType map[uint8]ActionPlugin
 }
 
 func New(c Controller) (*Handler, error) {
@@ -20,5 +39,20 @@ func New(c Controller) (*Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Handler{c, db}, nil
+	h := &Handler{
+		c:             c,
+		db:            db,
+		pluginsByName: map[string]ActionPlugin{},
+		pluginsByType: map[uint8]ActionPlugin{},
+	}
+	if ac, ok := c.(AuditController); ok {
+		if config := ac.AuditConfig(); config.Enabled {
+			h.audit, err = audit.New(logging.NoLog{}, config)
+			if err != nil {
+				_ = db.Close()
+				return nil, err
+			}
+		}
+	}
+	return h, nil
 }
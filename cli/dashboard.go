@@ -0,0 +1,184 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/window"
+)
+
+const (
+	dashboardBlocks = 10
+
+	// clearScreen moves the cursor to the top-left corner and clears the
+	// terminal.
+	clearScreen = "\033[H\033[2J"
+)
+
+type dashboardBlock struct {
+	height   uint64
+	txs      int
+	size     int
+	consumed fees.Dimensions
+	latency  int64
+}
+
+// Dashboard redraws a summary of the chain every time a block is accepted.
+//
+// If [watched] is empty, the balances of all stored keys are shown. Watched
+// addresses may be address book labels.
+func (h *Handler) Dashboard(
+	watched []string,
+	getParser func(string, uint32, ids.ID) (chain.Parser, error),
+	getBalance func(context.Context, string, uint32, ids.ID, string) (uint64, error),
+) error {
+	ctx := context.Background()
+	chainID, uris, err := h.PromptChain("select chainID", nil)
+	if err != nil {
+		return err
+	}
+	if len(watched) == 0 {
+		addrs, err := h.GetAddresses()
+		if err != nil {
+			return err
+		}
+		for _, addr := range addrs {
+			watched = append(watched, h.c.Address(addr))
+		}
+	}
+	// Watched addresses may be labels, which must be resolved before the
+	// database is closed
+	addrs := make([]string, len(watched))
+	names := make([]string, len(watched))
+	for i, input := range watched {
+		addr, err := h.ResolveAddress(input)
+		if err != nil {
+			return err
+		}
+		addrs[i] = h.c.Address(addr)
+		names[i] = h.FormatAddress(addr)
+	}
+	if err := h.CloseDatabase(); err != nil {
+		return err
+	}
+	rcli := rpc.NewJSONRPCClient(uris[0])
+	networkID, _, _, err := rcli.Network(ctx)
+	if err != nil {
+		return err
+	}
+	parser, err := getParser(uris[0], networkID, chainID)
+	if err != nil {
+		return err
+	}
+	scli, err := rpc.NewWebSocketClient(uris[0], rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize) // we write the max read
+	if err != nil {
+		return err
+	}
+	defer scli.Close()
+	if err := scli.RegisterBlocks(); err != nil {
+		return err
+	}
+	utils.Outf("{{green}}waiting for new blocks on %s 👀{{/}}\n", chainID)
+
+	var (
+		start     time.Time
+		lastBlock int64
+		tpsWindow = window.Window{}
+		blocks    = make([]*dashboardBlock, 0, dashboardBlocks)
+	)
+	for ctx.Err() == nil {
+		blk, results, prices, err := scli.ListenBlock(ctx, parser)
+		if err != nil {
+			return err
+		}
+		consumed := fees.Dimensions{}
+		for _, result := range results {
+			nconsumed, err := fees.Add(consumed, result.Units)
+			if err != nil {
+				return err
+			}
+			consumed = nconsumed
+		}
+
+		// Update TPS
+		now := time.Now()
+		if start.IsZero() {
+			start = now
+		}
+		if lastBlock != 0 {
+			newWindow, err := window.Roll(tpsWindow, now.Unix()-lastBlock)
+			if err != nil {
+				return err
+			}
+			tpsWindow = newWindow
+		}
+		window.Update(&tpsWindow, window.WindowSliceSize-consts.Uint64Len, uint64(len(blk.Txs)))
+		lastBlock = now.Unix()
+		tpsDivisor := min(window.WindowSize, max(time.Since(start).Seconds(), 1))
+
+		// Record block
+		if len(blocks) == dashboardBlocks {
+			blocks = blocks[1:]
+		}
+		blocks = append(blocks, &dashboardBlock{
+			height:   blk.Hght,
+			txs:      len(blk.Txs),
+			size:     blk.Size(),
+			consumed: consumed,
+			latency:  now.UnixMilli() - blk.Tmstmp,
+		})
+
+		// Fetch node state
+		mempoolTxs, mempoolSize, err := rcli.Mempool(ctx)
+		if err != nil {
+			return err
+		}
+		balances := make([]string, len(addrs))
+		for i, addr := range addrs {
+			balance, err := getBalance(ctx, uris[0], networkID, chainID, addr)
+			if err != nil {
+				balances[i] = err.Error()
+				continue
+			}
+			balances[i] = fmt.Sprintf("%s %s", utils.FormatBalance(balance, h.c.Decimals()), h.c.Symbol())
+		}
+
+		// Draw
+		fmt.Print(clearScreen)
+		utils.Outf("{{cyan}}{{bold}}chain:{{/}} %s {{cyan}}{{bold}}uri:{{/}} %s\n\n", chainID, uris[0])
+		utils.Outf("{{yellow}}TPS:{{/}} %.2f {{yellow}}mempool:{{/}} %d txs (%.2fKB)\n", float64(window.Sum(tpsWindow))/tpsDivisor, mempoolTxs, float64(mempoolSize)/units.KiB)
+		utils.Outf("{{yellow}}unit prices:{{/}} [%s]\n\n", ParseDimensions(prices))
+		utils.Outf("{{cyan}}{{bold}}recent blocks{{/}}\n")
+		for i := len(blocks) - 1; i >= 0; i-- {
+			blk := blocks[i]
+			utils.Outf(
+				"{{green}}height:{{/}}%d {{green}}txs:{{/}}%d {{green}}size:{{/}}%.2fKB {{green}}units consumed:{{/}} [%s] {{green}}latency:{{/}}%dms\n",
+				blk.height,
+				blk.txs,
+				float64(blk.size)/units.KiB,
+				ParseDimensions(blk.consumed),
+				blk.latency,
+			)
+		}
+		if len(names) > 0 {
+			utils.Outf("\n{{cyan}}{{bold}}watched addresses{{/}}\n")
+			for i, name := range names {
+				utils.Outf("%s: %s\n", name, balances[i])
+			}
+		}
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 )
@@ -20,6 +21,14 @@ type Controller interface {
 	ParseAddress(string) (codec.Address, error)
 }
 
+// AuditController is implemented by a [Controller] whose cli records the
+// use of stored private keys in an audit log.
+type AuditController interface {
+	// AuditConfig returns the config of the audit log (which is only opened
+	// if enabled).
+	AuditConfig() audit.Config
+}
+
 type SpamHelper interface {
 	// CreateAccount generates a new account and returns the [PrivateKey].
 	//
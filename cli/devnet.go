@@ -0,0 +1,89 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/devnet"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// RunDevnet starts a local network with [cfg], replaces the stored chains
+// with it, and stores its funded keys (the first becomes the default key).
+// It blocks until interrupted and then tears the network down.
+func (h *Handler) RunDevnet(cfg *devnet.Config) error {
+	ctx := context.Background()
+
+	utils.Outf(
+		"{{yellow}}starting devnet with %d validators (%d initial):{{/}} %s\n",
+		cfg.Validators,
+		cfg.InitialValidators,
+		cfg.VMName,
+	)
+	d, err := devnet.Start(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if err := h.importDevnet(d); err != nil {
+		if stopErr := d.Stop(ctx); stopErr != nil {
+			utils.Outf("{{red}}unable to stop devnet:{{/}} %v\n", stopErr)
+		}
+		return err
+	}
+	utils.Outf("{{green}}devnet is ready, press ctrl-c to stop{{/}}\n")
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	signal.Stop(signals)
+
+	utils.Outf("{{yellow}}stopping devnet{{/}}\n")
+	if err := d.Stop(ctx); err != nil {
+		return err
+	}
+	utils.Outf("{{green}}stopped devnet{{/}}\n")
+	return nil
+}
+
+func (h *Handler) importDevnet(d *devnet.Devnet) error {
+	oldChains, err := h.DeleteChains()
+	if err != nil {
+		return err
+	}
+	if len(oldChains) > 0 {
+		utils.Outf("{{yellow}}deleted old chains:{{/}} %+v\n", oldChains)
+	}
+	for _, node := range d.Nodes {
+		if err := h.StoreChain(d.ChainID, node.URI); err != nil {
+			return err
+		}
+		utils.Outf(
+			"{{yellow}}stored chainID:{{/}} %s {{yellow}}node:{{/}} %s {{yellow}}uri:{{/}} %s\n",
+			d.ChainID,
+			node.Name,
+			node.URI,
+		)
+	}
+	if err := h.StoreDefaultChain(d.ChainID); err != nil {
+		return err
+	}
+	for i, priv := range d.Keys {
+		addr := auth.NewED25519Address(priv.PublicKey())
+		if err := h.StoreKey(&PrivateKey{Address: addr, Bytes: priv[:]}); err != nil {
+			return err
+		}
+		if i == 0 {
+			if err := h.StoreDefaultKey(addr); err != nil {
+				return err
+			}
+		}
+		utils.Outf("{{yellow}}stored funded key:{{/}} %s\n", h.c.Address(addr))
+	}
+	return nil
+}
@@ -16,4 +16,12 @@ var (
 	ErrNoKeys               = errors.New("no available keys")
 	ErrTxFailed             = errors.New("tx failed on-chain")
 	ErrInsufficientAccounts = errors.New("insufficient accounts")
+	ErrTxExpired            = errors.New("tx expired")
+	ErrTxNotYetValid        = errors.New("tx not yet valid")
+	ErrUnknownActionType    = errors.New("unknown action type")
+	ErrInvalidPassword      = errors.New("invalid password")
+	ErrPasswordMismatch     = errors.New("passwords do not match")
+	ErrCorruptKeystore      = errors.New("corrupt keystore")
+	ErrInvalidLabel         = errors.New("invalid label")
+	ErrUnknownLabel         = errors.New("unknown label")
 )
@@ -9,16 +9,17 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/hd"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
 func (h *Handler) SetKey(lookupBalance func(int, string, string, uint32, ids.ID) error) error {
-	keys, err := h.GetKeys()
+	addrs, err := h.GetAddresses()
 	if err != nil {
 		return err
 	}
-	if len(keys) == 0 {
+	if len(addrs) == 0 {
 		utils.Outf("{{red}}no stored keys{{/}}\n")
 		return nil
 	}
@@ -35,24 +36,23 @@ func (h *Handler) SetKey(lookupBalance func(int, string, string, uint32, ids.ID)
 	if err != nil {
 		return err
 	}
-	utils.Outf("{{cyan}}stored keys:{{/}} %d\n", len(keys))
-	for i := 0; i < len(keys); i++ {
-		if err := lookupBalance(i, h.c.Address(keys[i].Address), uris[0], networkID, chainID); err != nil {
+	utils.Outf("{{cyan}}stored keys:{{/}} %d\n", len(addrs))
+	for i := 0; i < len(addrs); i++ {
+		if err := lookupBalance(i, h.c.Address(addrs[i]), uris[0], networkID, chainID); err != nil {
 			return err
 		}
 	}
 
 	// Select key
-	keyIndex, err := h.PromptChoice("set default key", len(keys))
+	keyIndex, err := h.PromptChoice("set default key", len(addrs))
 	if err != nil {
 		return err
 	}
-	key := keys[keyIndex]
-	return h.StoreDefaultKey(key.Address)
+	return h.StoreDefaultKey(addrs[keyIndex])
 }
 
 func (h *Handler) Balance(checkAllChains bool, promptAsset bool, printBalance func(codec.Address, string, uint32, ids.ID, ids.ID) error) error {
-	addr, _, err := h.GetDefaultKey(true)
+	addr, err := h.GetDefaultAddress(true)
 	if err != nil {
 		return err
 	}
@@ -85,3 +85,15 @@ func (h *Handler) Balance(checkAllChains bool, promptAsset bool, printBalance fu
 	}
 	return nil
 }
+
+// GenerateMnemonic returns a new mnemonic after showing it to the user so that
+// it can be backed up. The mnemonic is never stored.
+func (*Handler) GenerateMnemonic() (string, error) {
+	mnemonic, err := hd.NewMnemonic()
+	if err != nil {
+		return "", err
+	}
+	utils.Outf("{{red}}write down this mnemonic, anyone that knows it can access all accounts derived from it:{{/}}\n")
+	utils.Outf("%s\n", mnemonic)
+	return mnemonic, nil
+}
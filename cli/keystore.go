@@ -0,0 +1,318 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	// KeystorePasswordEnv can be set to unlock the keystore without being
+	// prompted (useful when scripting the cli).
+	KeystorePasswordEnv = "HYPERSDK_KEYSTORE_PASSWORD"
+
+	keystoreKey = "keystore"
+
+	// Parameters recommended by RFC 9106 for memory-constrained environments.
+	argonTime    = 3
+	argonMemory  = 64 * 1024 // KiB
+	argonThreads = 4
+	argonKeyLen  = 32
+	argonSaltLen = 16
+
+	// Parameters are read from untrusted key files, so they are bounded to
+	// prevent a file from requiring an unreasonable amount of work to open.
+	maxArgonTime   = 16
+	maxArgonMemory = 1024 * 1024 // KiB
+
+	keystoreParamsLen = consts.Uint32Len*2 + consts.ByteLen + argonSaltLen
+)
+
+// keyFileMagic prefixes all key files written by [Handler.ExportKey].
+var keyFileMagic = []byte("hypersdk-key-v1")
+
+// keystoreParams are the argon2id parameters used to derive an encryption
+// key from a password. They are stored alongside anything they encrypt so that
+// the defaults can be changed without breaking existing keystores.
+type keystoreParams struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+}
+
+func newKeystoreParams() (*keystoreParams, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return &keystoreParams{
+		time:    argonTime,
+		memory:  argonMemory,
+		threads: argonThreads,
+		salt:    salt,
+	}, nil
+}
+
+func (p *keystoreParams) bytes() []byte {
+	b := make([]byte, keystoreParamsLen)
+	binary.BigEndian.PutUint32(b, p.time)
+	binary.BigEndian.PutUint32(b[consts.Uint32Len:], p.memory)
+	b[consts.Uint32Len*2] = p.threads
+	copy(b[consts.Uint32Len*2+consts.ByteLen:], p.salt)
+	return b
+}
+
+func parseKeystoreParams(b []byte) (*keystoreParams, []byte, error) {
+	if len(b) < keystoreParamsLen {
+		return nil, nil, ErrCorruptKeystore
+	}
+	p := &keystoreParams{
+		time:    binary.BigEndian.Uint32(b),
+		memory:  binary.BigEndian.Uint32(b[consts.Uint32Len:]),
+		threads: b[consts.Uint32Len*2],
+		salt:    b[consts.Uint32Len*2+consts.ByteLen : keystoreParamsLen],
+	}
+	if p.time == 0 || p.time > maxArgonTime || p.memory > maxArgonMemory || p.threads == 0 {
+		return nil, nil, ErrCorruptKeystore
+	}
+	return p, b[keystoreParamsLen:], nil
+}
+
+func (p *keystoreParams) deriveKey(password string) []byte {
+	return argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, argonKeyLen)
+}
+
+// seal encrypts [plaintext] with AES-GCM and returns the nonce followed by
+// the ciphertext. [aad] is authenticated but not encrypted.
+func seal(key []byte, plaintext []byte, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// unseal decrypts a value encrypted with [seal].
+func unseal(key []byte, sealed []byte, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrCorruptKeystore
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+	if err != nil {
+		// GCM only returns an error if authentication fails
+		return nil, ErrInvalidPassword
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptKeyFile encrypts [priv] with [password] in a self-contained format
+// that can be read with [DecryptKeyFile] on any machine.
+func EncryptKeyFile(priv []byte, password string) ([]byte, error) {
+	params, err := newKeystoreParams()
+	if err != nil {
+		return nil, err
+	}
+	header := append(bytes.Clone(keyFileMagic), params.bytes()...)
+	sealed, err := seal(params.deriveKey(password), priv, header)
+	if err != nil {
+		return nil, err
+	}
+	return append(header, sealed...), nil
+}
+
+// IsEncryptedKeyFile returns true if [b] was created with [EncryptKeyFile].
+func IsEncryptedKeyFile(b []byte) bool {
+	return bytes.HasPrefix(b, keyFileMagic)
+}
+
+// DecryptKeyFile returns the private key in a file created with
+// [EncryptKeyFile].
+func DecryptKeyFile(b []byte, password string) ([]byte, error) {
+	if !IsEncryptedKeyFile(b) {
+		return nil, ErrCorruptKeystore
+	}
+	params, sealed, err := parseKeystoreParams(b[len(keyFileMagic):])
+	if err != nil {
+		return nil, err
+	}
+	header := b[:len(keyFileMagic)+keystoreParamsLen]
+	return unseal(params.deriveKey(password), sealed, header)
+}
+
+// getPassword returns the password in [KeystorePasswordEnv] if it is set and
+// otherwise prompts for one.
+func (h *Handler) getPassword(label string, confirm bool) (string, error) {
+	if password, ok := os.LookupEnv(KeystorePasswordEnv); ok {
+		return password, nil
+	}
+	return h.PromptPassword(label, confirm)
+}
+
+// unlock returns the key used to encrypt stored private keys, prompting for
+// the keystore password if it has not been provided yet during this session.
+//
+// If no keystore exists, a new one is created and any keys stored in
+// plaintext by previous versions of the cli are encrypted.
+func (h *Handler) unlock() ([]byte, error) {
+	if h.keystoreKey != nil {
+		return h.keystoreKey, nil
+	}
+	record, err := h.GetDefault(keystoreKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(record) == 0 {
+		return h.createKeystore()
+	}
+	params, verifier, err := parseKeystoreParams(record)
+	if err != nil {
+		return nil, err
+	}
+	password, err := h.getPassword("keystore password", false)
+	if err != nil {
+		return nil, err
+	}
+	key := params.deriveKey(password)
+	if _, err := unseal(key, verifier, []byte(keystoreKey)); err != nil {
+		return nil, err
+	}
+	h.keystoreKey = key
+	return key, nil
+}
+
+func (h *Handler) createKeystore() ([]byte, error) {
+	utils.Outf("{{yellow}}creating encrypted keystore{{/}}\n")
+	password, err := h.getPassword("new keystore password", true)
+	if err != nil {
+		return nil, err
+	}
+	params, err := newKeystoreParams()
+	if err != nil {
+		return nil, err
+	}
+	key := params.deriveKey(password)
+
+	// The verifier is used to detect an incorrect password before any keys
+	// are read.
+	verifier, err := seal(key, nil, []byte(keystoreKey))
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt keys stored in plaintext
+	batch := h.db.NewBatch()
+	iter := h.db.NewIteratorWithPrefix([]byte{keyPrefix})
+	migrated := 0
+	for iter.Next() {
+		k := iter.Key()
+		sealed, err := seal(key, iter.Value(), k)
+		if err != nil {
+			iter.Release()
+			return nil, err
+		}
+		if err := batch.Put(k, sealed); err != nil {
+			iter.Release()
+			return nil, err
+		}
+		migrated++
+	}
+	err = iter.Error()
+	iter.Release()
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.Put(defaultKey(keystoreKey), append(params.bytes(), verifier...)); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	if migrated > 0 {
+		utils.Outf("{{yellow}}encrypted %d stored keys{{/}}\n", migrated)
+	}
+	h.keystoreKey = key
+	return key, nil
+}
+
+// Lock removes the cached keystore key from memory. Accessing a private key
+// after calling [Lock] requires the keystore password again.
+func (h *Handler) Lock() {
+	clear(h.keystoreKey)
+	h.keystoreKey = nil
+}
+
+// ExportKey writes the default private key to [path], encrypted with a
+// password that can be different from the keystore password.
+func (h *Handler) ExportKey(path string) (err error) {
+	addr, priv, err := h.GetDefaultKey(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		h.auditKey("export", addr, err, map[string]any{"path": path})
+	}()
+	password, err := h.PromptPassword("export password", true)
+	if err != nil {
+		return err
+	}
+	b, err := EncryptKeyFile(priv, password)
+	if err != nil {
+		return err
+	}
+	if err := utils.SaveBytes(path, b); err != nil {
+		return err
+	}
+	utils.Outf("{{green}}exported %s to:{{/}} %s\n", h.c.Address(addr), path)
+	return nil
+}
+
+// LoadKeyFile reads a private key of [size] bytes from [path]. If the key
+// was written with [Handler.ExportKey], the export password is prompted for.
+func (h *Handler) LoadKeyFile(path string, size int) ([]byte, error) {
+	b, err := utils.LoadBytes(path, -1)
+	if err != nil {
+		return nil, err
+	}
+	if IsEncryptedKeyFile(b) {
+		password, err := h.PromptPassword("key file password", false)
+		if err != nil {
+			return nil, err
+		}
+		b, err = DecryptKeyFile(b, password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(b) != size {
+		return nil, utils.ErrInvalidSize
+	}
+	return b, nil
+}
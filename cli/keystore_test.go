@@ -0,0 +1,152 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/audit"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+type testController struct {
+	Controller
+}
+
+func (testController) Address(addr codec.Address) string {
+	return hex.EncodeToString(addr[:])
+}
+
+func (testController) ParseAddress(s string) (codec.Address, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return codec.EmptyAddress, err
+	}
+	if len(b) != codec.AddressLen {
+		return codec.EmptyAddress, ErrInvalidChoice
+	}
+	return codec.Address(b), nil
+}
+
+func TestKeyFile(t *testing.T) {
+	require := require.New(t)
+	priv := []byte("private key")
+
+	b, err := EncryptKeyFile(priv, "password")
+	require.NoError(err)
+	require.True(IsEncryptedKeyFile(b))
+	require.NotContains(string(b), string(priv))
+
+	decrypted, err := DecryptKeyFile(b, "password")
+	require.NoError(err)
+	require.Equal(priv, decrypted)
+
+	_, err = DecryptKeyFile(b, "wrong")
+	require.ErrorIs(err, ErrInvalidPassword)
+
+	// Modifying the header must invalidate the file
+	b[len(keyFileMagic)+keystoreParamsLen-1] ^= 1
+	_, err = DecryptKeyFile(b, "password")
+	require.ErrorIs(err, ErrInvalidPassword)
+
+	// Unreasonable parameters are rejected before deriving a key
+	b[len(keyFileMagic)] = 0xFF
+	_, err = DecryptKeyFile(b, "password")
+	require.ErrorIs(err, ErrCorruptKeystore)
+}
+
+func TestKeystore(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(KeystorePasswordEnv, "password")
+
+	// Keys stored by older versions of the cli are encrypted on first unlock
+	db := memdb.New()
+	plaintext := &PrivateKey{Address: codec.Address{1}, Bytes: []byte("plaintext")}
+	require.NoError(db.Put(keyKey(plaintext.Address), plaintext.Bytes))
+	h := &Handler{db: db}
+	priv, err := h.GetKey(plaintext.Address)
+	require.NoError(err)
+	require.Equal(plaintext.Bytes, priv)
+	raw, err := db.Get(keyKey(plaintext.Address))
+	require.NoError(err)
+	require.NotEqual(plaintext.Bytes, raw)
+
+	// New keys are encrypted
+	stored := &PrivateKey{Address: codec.Address{2}, Bytes: []byte("stored")}
+	require.NoError(h.StoreKey(stored))
+	raw, err = db.Get(keyKey(stored.Address))
+	require.NoError(err)
+	require.NotContains(string(raw), string(stored.Bytes))
+
+	// Keys can be read after locking
+	h.Lock()
+	keys, err := h.GetKeys()
+	require.NoError(err)
+	require.Equal([]*PrivateKey{plaintext, stored}, keys)
+
+	// Wrong password is rejected
+	h.Lock()
+	t.Setenv(KeystorePasswordEnv, "wrong")
+	_, err = h.GetKey(stored.Address)
+	require.ErrorIs(err, ErrInvalidPassword)
+}
+
+func TestKeystoreAudit(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(KeystorePasswordEnv, "password")
+
+	config := audit.NewDefaultConfig()
+	config.Directory = t.TempDir()
+	l, err := audit.New(logging.NoLog{}, config)
+	require.NoError(err)
+	h := &Handler{c: testController{}, db: memdb.New(), audit: l}
+
+	stored := &PrivateKey{Address: codec.Address{1}, Bytes: []byte("stored")}
+	require.NoError(h.StoreKey(stored))
+	require.ErrorIs(h.StoreKey(stored), ErrDuplicate)
+	_, err = h.GetKey(stored.Address)
+	require.NoError(err)
+	h.Lock()
+	t.Setenv(KeystorePasswordEnv, "wrong")
+	_, err = h.GetKey(stored.Address)
+	require.ErrorIs(err, ErrInvalidPassword)
+	require.NoError(h.CloseDatabase())
+
+	f, err := os.Open(filepath.Join(config.Directory, audit.FileName))
+	require.NoError(err)
+	defer f.Close()
+	events := []*audit.Event{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e audit.Event
+		require.NoError(json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, &e)
+	}
+	require.NoError(scanner.Err())
+
+	require.Len(events, 4)
+	for i, expected := range []struct {
+		action  string
+		outcome audit.Outcome
+	}{
+		{"store", audit.Success},
+		{"store", audit.Failure},
+		{"use", audit.Success},
+		{"use", audit.Failure},
+	} {
+		require.Equal(audit.KeyCategory, events[i].Category)
+		require.Equal(expected.action, events[i].Action)
+		require.Equal(expected.outcome, events[i].Outcome)
+		require.Equal(h.c.Address(stored.Address), events[i].Fields["address"])
+	}
+}
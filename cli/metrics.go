@@ -0,0 +1,300 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/ava-labs/hypersdk/utils"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	metricsNamespace     = "hypersdk_aggregate"
+	metricsScrapeTimeout = 5 * time.Second
+	metricsReadTimeout   = 10 * time.Second
+
+	gossipSentMetric     = "avalanche_network_app_gossip_sent_bytes"
+	gossipReceivedMetric = "avalanche_network_app_gossip_received_bytes"
+)
+
+var ErrMetricsEndpointFailed = errors.New("metrics endpoint returned an error")
+
+// metricsSample is the value of the scraped counters of a node at one point
+// in time.
+type metricsSample struct {
+	time time.Time
+
+	txsAccepted    float64
+	acceptedSum    float64
+	acceptedCount  float64
+	gossipSent     float64
+	gossipReceived float64
+}
+
+// MetricsAggregator scrapes the metrics endpoints of the nodes of a chain and
+// exports chain-wide rates computed from the change between scrapes.
+//
+// Every node accepts every transaction, so TPS and inclusion latency are
+// averaged over the nodes while gossip bandwidth is summed.
+type MetricsAggregator struct {
+	chainID   ids.ID
+	endpoints []string
+	client    *http.Client
+
+	l    sync.Mutex
+	prev map[string]*metricsSample
+
+	registry         *prometheus.Registry
+	nodesUp          prometheus.Gauge
+	tps              prometheus.Gauge
+	inclusionLatency prometheus.Gauge
+	gossipSent       prometheus.Gauge
+	gossipReceived   prometheus.Gauge
+	nodeTPS          *prometheus.GaugeVec
+}
+
+// NewMetricsAggregator creates a [MetricsAggregator] for [chainID] that
+// scrapes [endpoints] (each of the form http://<host>:<port>/ext/metrics).
+func NewMetricsAggregator(chainID ids.ID, endpoints []string) (*MetricsAggregator, error) {
+	m := &MetricsAggregator{
+		chainID:   chainID,
+		endpoints: endpoints,
+		client:    &http.Client{Timeout: metricsScrapeTimeout},
+		prev:      map[string]*metricsSample{},
+		registry:  prometheus.NewRegistry(),
+		nodesUp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "nodes_up",
+			Help:      "number of nodes scraped successfully",
+		}),
+		tps: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "tps",
+			Help:      "transactions accepted per second (averaged over nodes)",
+		}),
+		inclusionLatency: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "inclusion_latency_ms",
+			Help:      "average time from block issuance to acceptance (averaged over nodes)",
+		}),
+		gossipSent: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "gossip_sent_bytes_per_second",
+			Help:      "app gossip bytes sent per second (summed over nodes)",
+		}),
+		gossipReceived: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "gossip_received_bytes_per_second",
+			Help:      "app gossip bytes received per second (summed over nodes)",
+		}),
+		nodeTPS: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "node_tps",
+			Help:      "transactions accepted per second by each node",
+		}, []string{"node"}),
+	}
+	errs := []error{
+		m.registry.Register(m.nodesUp),
+		m.registry.Register(m.tps),
+		m.registry.Register(m.inclusionLatency),
+		m.registry.Register(m.gossipSent),
+		m.registry.Register(m.gossipReceived),
+		m.registry.Register(m.nodeTPS),
+	}
+	return m, errors.Join(errs...)
+}
+
+// Handler serves the aggregated metrics.
+func (m *MetricsAggregator) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// familyValue returns the sum of all series of the metric [name].
+func familyValue(families map[string]*dto.MetricFamily, name string) float64 {
+	family, ok := families[name]
+	if !ok {
+		return 0
+	}
+	var total float64
+	for _, metric := range family.Metric {
+		switch {
+		case metric.Counter != nil:
+			total += metric.Counter.GetValue()
+		case metric.Gauge != nil:
+			total += metric.Gauge.GetValue()
+		case metric.Untyped != nil:
+			total += metric.Untyped.GetValue()
+		}
+	}
+	return total
+}
+
+func (m *MetricsAggregator) scrapeNode(ctx context.Context, endpoint string) (*metricsSample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrMetricsEndpointFailed, resp.Status)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsSample{
+		time:           time.Now(),
+		txsAccepted:    familyValue(families, fmt.Sprintf("avalanche_%s_vm_hypersdk_vm_txs_accepted", m.chainID)),
+		acceptedSum:    familyValue(families, fmt.Sprintf("avalanche_%s_blks_accepted_sum", m.chainID)),
+		acceptedCount:  familyValue(families, fmt.Sprintf("avalanche_%s_blks_accepted_count", m.chainID)),
+		gossipSent:     familyValue(families, gossipSentMetric),
+		gossipReceived: familyValue(families, gossipReceivedMetric),
+	}, nil
+}
+
+// Scrape collects the metrics of every node and updates the aggregated
+// rates. Rates of a node are only included once it has been scraped twice.
+// Unreachable nodes are skipped and returned as an error.
+func (m *MetricsAggregator) Scrape(ctx context.Context) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	var (
+		errs []error
+		up   int
+
+		rates          int
+		tps            float64
+		latencyNodes   int
+		latency        float64
+		gossipSent     float64
+		gossipReceived float64
+	)
+	for _, endpoint := range m.endpoints {
+		sample, err := m.scrapeNode(ctx, endpoint)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+			delete(m.prev, endpoint)
+			continue
+		}
+		up++
+		prev, ok := m.prev[endpoint]
+		m.prev[endpoint] = sample
+		if !ok {
+			continue
+		}
+		elapsed := sample.time.Sub(prev.time).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+
+		// Counters reset when a node restarts
+		nodeTPS := max(sample.txsAccepted-prev.txsAccepted, 0) / elapsed
+		m.nodeTPS.WithLabelValues(endpoint).Set(nodeTPS)
+		rates++
+		tps += nodeTPS
+		if accepted := sample.acceptedCount - prev.acceptedCount; accepted > 0 {
+			latencyNodes++
+			latency += (sample.acceptedSum - prev.acceptedSum) / accepted / float64(time.Millisecond)
+		}
+		gossipSent += max(sample.gossipSent-prev.gossipSent, 0) / elapsed
+		gossipReceived += max(sample.gossipReceived-prev.gossipReceived, 0) / elapsed
+	}
+	m.nodesUp.Set(float64(up))
+	if rates > 0 {
+		m.tps.Set(tps / float64(rates))
+		m.gossipSent.Set(gossipSent)
+		m.gossipReceived.Set(gossipReceived)
+	}
+	if latencyNodes > 0 {
+		m.inclusionLatency.Set(latency / float64(latencyNodes))
+	}
+	return errors.Join(errs...)
+}
+
+// AggregateMetrics scrapes the nodes of a chain every [interval] and serves
+// the aggregated metrics at http://[listen]/metrics until interrupted.
+func (h *Handler) AggregateMetrics(listen string, interval time.Duration) error {
+	chainID, uris, err := h.PromptChain("select chainID", nil)
+	if err != nil {
+		return err
+	}
+	if err := h.CloseDatabase(); err != nil {
+		return err
+	}
+	endpoints := make([]string, len(uris))
+	for i, uri := range uris {
+		host, err := utils.GetHost(uri)
+		if err != nil {
+			return err
+		}
+		port, err := utils.GetPort(uri)
+		if err != nil {
+			return err
+		}
+		endpoints[i] = fmt.Sprintf("http://%s:%s/ext/metrics", host, port)
+	}
+	aggregator, err := NewMetricsAggregator(chainID, endpoints)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", aggregator.Handler())
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadTimeout,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	utils.Outf(
+		"{{green}}aggregating metrics of %d nodes at:{{/}} http://%s/metrics\n",
+		len(endpoints),
+		listen,
+	)
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := aggregator.Scrape(ctx); err != nil {
+				utils.Outf("{{orange}}unable to scrape nodes:{{/}} %v\n", err)
+			}
+			cancel()
+		case err := <-serverErr:
+			return err
+		case <-signals:
+			utils.Outf("{{cyan}}stopping metrics server{{/}}\n")
+			return server.Shutdown(context.Background())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testNode serves chain metrics that grow by a fixed amount on every scrape.
+func testNode(t *testing.T, chainID ids.ID) *httptest.Server {
+	var scrapes atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := scrapes.Add(1)
+		fmt.Fprintf(w, "# TYPE avalanche_%s_vm_hypersdk_vm_txs_accepted counter\n", chainID)
+		fmt.Fprintf(w, "avalanche_%s_vm_hypersdk_vm_txs_accepted %d\n", chainID, n*100)
+		fmt.Fprintf(w, "# TYPE avalanche_%s_blks_accepted_count counter\n", chainID)
+		fmt.Fprintf(w, "avalanche_%s_blks_accepted_count %d\n", chainID, n*2)
+		fmt.Fprintf(w, "# TYPE avalanche_%s_blks_accepted_sum gauge\n", chainID)
+		fmt.Fprintf(w, "avalanche_%s_blks_accepted_sum %d\n", chainID, n*2*int64(50*time.Millisecond))
+		fmt.Fprintf(w, "# TYPE %s counter\n", gossipSentMetric)
+		fmt.Fprintf(w, "%s{op=\"app_gossip\"} %d\n", gossipSentMetric, n*1000)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMetricsAggregator(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	a := testNode(t, chainID)
+	b := testNode(t, chainID)
+	down := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(down.Close)
+
+	m, err := NewMetricsAggregator(chainID, []string{a.URL, b.URL, down.URL})
+	require.NoError(err)
+
+	// The first scrape only records samples
+	ctx := context.Background()
+	require.ErrorIs(m.Scrape(ctx), ErrMetricsEndpointFailed)
+	require.Equal(float64(2), testutil.ToFloat64(m.nodesUp))
+	require.Zero(testutil.ToFloat64(m.tps))
+
+	time.Sleep(10 * time.Millisecond)
+	require.ErrorIs(m.Scrape(ctx), ErrMetricsEndpointFailed)
+	require.Equal(float64(2), testutil.ToFloat64(m.nodesUp))
+	require.Positive(testutil.ToFloat64(m.tps))
+	require.Positive(testutil.ToFloat64(m.gossipSent))
+	require.Zero(testutil.ToFloat64(m.gossipReceived))
+	require.InDelta(float64(50), testutil.ToFloat64(m.inclusionLatency), 0.001)
+	require.Positive(testutil.ToFloat64(m.nodeTPS.WithLabelValues(b.URL)))
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// SaveUnsignedTx writes the canonical encoding of [tx] (without its auth) to
+// [path] so that it can be signed on another machine.
+func SaveUnsignedTx(path string, tx *chain.Transaction) error {
+	digest, err := tx.Digest()
+	if err != nil {
+		return err
+	}
+	return utils.SaveBytes(path, digest)
+}
+
+// LoadUnsignedTx reads a transaction written with [SaveUnsignedTx].
+func LoadUnsignedTx(path string, actionRegistry chain.ActionRegistry) (*chain.Transaction, error) {
+	b, err := utils.LoadBytes(path, -1)
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(b, consts.NetworkSizeLimit)
+	tx, err := chain.UnmarshalUnsignedTx(p, actionRegistry)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, errors.New("unsigned tx has extra bytes")
+	}
+	return tx, nil
+}
+
+// SaveSignedTx writes [tx] to [path] so that it can be broadcast later.
+func SaveSignedTx(path string, tx *chain.Transaction) error {
+	return utils.SaveBytes(path, tx.Bytes())
+}
+
+// LoadSignedTx reads a transaction written with [SaveSignedTx].
+func LoadSignedTx(
+	path string,
+	actionRegistry chain.ActionRegistry,
+	authRegistry chain.AuthRegistry,
+) (*chain.Transaction, error) {
+	b, err := utils.LoadBytes(path, -1)
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(b, consts.NetworkSizeLimit)
+	tx, err := chain.UnmarshalTx(p, actionRegistry, authRegistry)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, errors.New("signed tx has extra bytes")
+	}
+	return tx, nil
+}
+
+// CheckExpiry returns [ErrTxExpired] if [tx] can no longer be included in a
+// block. Expired transactions must be re-based (which changes their
+// encoding) and signed again.
+func CheckExpiry(tx *chain.Transaction) error {
+	expiry := time.UnixMilli(tx.Expiry())
+	if time.Now().After(expiry) {
+		return fmt.Errorf("%w: expired at %s", ErrTxExpired, expiry.Format(time.RFC3339))
+	}
+	utils.Outf("{{yellow}}expires:{{/}} %s (in %s)\n", expiry.Format(time.RFC3339), time.Until(expiry).Truncate(time.Second))
+	return nil
+}
+
+// CheckValidFrom returns [ErrTxNotYetValid] if [tx] cannot be included in a
+// block yet because its expiry is more than [validityWindow] away.
+func CheckValidFrom(tx *chain.Transaction, validityWindow int64) error {
+	validFrom := time.UnixMilli(tx.Expiry() - validityWindow)
+	if time.Now().Before(validFrom) {
+		return fmt.Errorf("%w: valid from %s", ErrTxNotYetValid, validFrom.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// DelayModifier postpones the time a transaction can first be included in a
+// block by the wrapped duration (truncated to the second). This gives time to
+// sign a transaction on an offline machine before broadcasting it.
+type DelayModifier time.Duration
+
+func (d DelayModifier) Base(b *chain.Base) {
+	b.Timestamp += time.Duration(d).Truncate(time.Second).Milliseconds()
+}
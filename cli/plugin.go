@@ -0,0 +1,91 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// ActionPlugin adds an action of a VM to the cli. Registered plugins are
+// used to build actions from prompts, to select action types when filtering
+// transactions, and to summarize accepted actions.
+type ActionPlugin interface {
+	// Name identifies the plugin (for example, "transfer"). It is used as the
+	// name of the action command and in action type filters.
+	Name() string
+	// TypeID is the type ID of the actions built and summarized by the
+	// plugin.
+	TypeID() uint8
+
+	// Prompt asks for the fields of the actions to send from [actor]. If no
+	// actions should be sent (for example, because [actor] has no balance),
+	// it returns nil.
+	Prompt(ctx context.Context, h *Handler, actor codec.Address) ([]chain.Action, error)
+	// Summary describes [action], which was decoded by the action registry of
+	// the VM and has type [TypeID].
+	Summary(action chain.Action) string
+}
+
+// RegisterActionPlugin adds [p] to the plugins of [h]. Names and type IDs
+// must be unique.
+func (h *Handler) RegisterActionPlugin(p ActionPlugin) error {
+	if _, ok := h.pluginsByName[p.Name()]; ok {
+		return fmt.Errorf("%w: action plugin %s", ErrDuplicate, p.Name())
+	}
+	if _, ok := h.pluginsByType[p.TypeID()]; ok {
+		return fmt.Errorf("%w: action plugin type %d", ErrDuplicate, p.TypeID())
+	}
+	h.plugins = append(h.plugins, p)
+	h.pluginsByName[p.Name()] = p
+	h.pluginsByType[p.TypeID()] = p
+	return nil
+}
+
+// ActionPlugins returns the registered plugins in registration order.
+func (h *Handler) ActionPlugins() []ActionPlugin {
+	return h.plugins
+}
+
+// ActionTypes maps the name of each registered plugin to its type ID (for
+// use with [ParseActionTypes]).
+func (h *Handler) ActionTypes() map[string]uint8 {
+	types := make(map[string]uint8, len(h.plugins))
+	for _, p := range h.plugins {
+		types[p.Name()] = p.TypeID()
+	}
+	return types
+}
+
+// ActionSummary describes [action] with the plugin registered for its type
+// or returns an empty string if there is none.
+func (h *Handler) ActionSummary(action chain.Action) string {
+	p, ok := h.pluginsByType[action.GetTypeID()]
+	if !ok {
+		return ""
+	}
+	return p.Summary(action)
+}
+
+// PromptAction builds the actions of the plugin [name] sent from [actor] and
+// asks for confirmation. It returns nil if there is nothing to send or the
+// user did not confirm.
+func (h *Handler) PromptAction(ctx context.Context, name string, actor codec.Address) ([]chain.Action, error) {
+	p, ok := h.pluginsByName[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownActionType, name)
+	}
+	actions, err := p.Prompt(ctx, h, actor)
+	if len(actions) == 0 || err != nil {
+		return nil, err
+	}
+	cont, err := h.PromptContinue()
+	if !cont || err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+type testAction struct {
+	chain.Action
+
+	typeID uint8
+}
+
+func (a *testAction) GetTypeID() uint8 {
+	return a.typeID
+}
+
+type testPlugin struct {
+	name   string
+	typeID uint8
+}
+
+func (p *testPlugin) Name() string {
+	return p.name
+}
+
+func (p *testPlugin) TypeID() uint8 {
+	return p.typeID
+}
+
+func (p *testPlugin) Prompt(context.Context, *Handler, codec.Address) ([]chain.Action, error) {
+	return []chain.Action{&testAction{typeID: p.typeID}}, nil
+}
+
+func (p *testPlugin) Summary(chain.Action) string {
+	return "summary of " + p.name
+}
+
+func TestRegisterActionPlugin(t *testing.T) {
+	require := require.New(t)
+
+	h := &Handler{
+		pluginsByName: map[string]ActionPlugin{},
+		pluginsByType: map[uint8]ActionPlugin{},
+	}
+	a := &testPlugin{name: "a", typeID: 1}
+	b := &testPlugin{name: "b", typeID: 2}
+	require.NoError(h.RegisterActionPlugin(a))
+	require.NoError(h.RegisterActionPlugin(b))
+	require.ErrorIs(h.RegisterActionPlugin(&testPlugin{name: "a", typeID: 3}), ErrDuplicate)
+	require.ErrorIs(h.RegisterActionPlugin(&testPlugin{name: "c", typeID: 2}), ErrDuplicate)
+
+	require.Equal([]ActionPlugin{a, b}, h.ActionPlugins())
+	require.Equal(map[string]uint8{"a": 1, "b": 2}, h.ActionTypes())
+	require.Equal("summary of b", h.ActionSummary(&testAction{typeID: 2}))
+	require.Empty(h.ActionSummary(&testAction{typeID: 3}))
+
+	_, err := h.PromptAction(context.Background(), "c", codec.EmptyAddress)
+	require.ErrorIs(err, ErrUnknownActionType)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/manifoldco/promptui"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/hd"
 	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/utils"
 )
@@ -24,7 +25,7 @@ func (h *Handler) PromptAddress(label string) (codec.Address, error) {
 			if len(input) == 0 {
 				return ErrInputEmpty
 			}
-			_, err := h.c.ParseAddress(input)
+			_, err := h.ResolveAddress(input)
 			return err
 		},
 	}
@@ -32,8 +33,7 @@ func (h *Handler) PromptAddress(label string) (codec.Address, error) {
 	if err != nil {
 		return codec.EmptyAddress, err
 	}
-	recipient = strings.TrimSpace(recipient)
-	return h.c.ParseAddress(recipient)
+	return h.ResolveAddress(recipient)
 }
 
 func (*Handler) PromptString(label string, min int, max int) (string, error) {
@@ -234,6 +234,59 @@ func (*Handler) PromptTime(label string) (int64, error) {
 	return strconv.ParseInt(rawTime, 10, 64)
 }
 
+// PromptMnemonic reads a BIP-39 mnemonic without echoing it.
+func (*Handler) PromptMnemonic(label string) (string, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Mask:  '*',
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			if !hd.ValidMnemonic(input) {
+				return hd.ErrInvalidMnemonic
+			}
+			return nil
+		},
+	}
+	mnemonic, err := promptText.Run()
+	if err != nil {
+		return "", err
+	}
+	return hd.NormalizeMnemonic(mnemonic), nil
+}
+
+// PromptPassword reads a password without echoing it. If [confirm] is true,
+// the password must be entered twice.
+func (*Handler) PromptPassword(label string, confirm bool) (string, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Mask:  '*',
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			return nil
+		},
+	}
+	password, err := promptText.Run()
+	if err != nil {
+		return "", err
+	}
+	if !confirm {
+		return password, nil
+	}
+	promptText.Label = "confirm " + label
+	confirmation, err := promptText.Run()
+	if err != nil {
+		return "", err
+	}
+	if password != confirmation {
+		return "", ErrPasswordMismatch
+	}
+	return password, nil
+}
+
 func (*Handler) PromptContinue() (bool, error) {
 	promptText := promptui.Prompt{
 		Label: "continue (y/n)",
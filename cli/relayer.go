@@ -0,0 +1,71 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/relayer"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// RunRelayer relays warp messages with [cfg] until interrupted. If the source
+// or destination chain of [cfg] is not set, it is selected from the stored
+// chains.
+func (h *Handler) RunRelayer(cfg *relayer.Config) error {
+	var sourceID ids.ID
+	if len(cfg.SourceURI) == 0 {
+		chainID, uris, err := h.PromptChain("select source chainID", nil)
+		if err != nil {
+			return err
+		}
+		sourceID = chainID
+		cfg.SourceURI = uris[0]
+	}
+	if len(cfg.DestinationURI) == 0 {
+		excluded := set.Set[ids.ID]{}
+		if sourceID != ids.Empty {
+			excluded.Add(sourceID)
+		}
+		_, uris, err := h.PromptChain("select destination chainID", excluded)
+		if err != nil {
+			return err
+		}
+		cfg.DestinationURI = uris[0]
+	}
+	if err := h.CloseDatabase(); err != nil {
+		return err
+	}
+	r, err := relayer.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case <-signals:
+			utils.Outf("{{yellow}}stopping relayer{{/}}\n")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	utils.Outf(
+		"{{green}}relaying warp messages:{{/}} %s {{green}}->{{/}} %s\n",
+		cfg.SourceURI,
+		cfg.DestinationURI,
+	)
+	return r.Run(ctx)
+}
@@ -48,9 +48,16 @@ var (
 
 	inflight atomic.Int64
 	sent     atomic.Int64
+
+	tracker = newTxTracker()
 )
 
-func (h *Handler) Spam(sh SpamHelper) error {
+// Spam issues transfers between newly created accounts until interrupted.
+//
+// If [workload] is nil, parameters are prompted for and a uniform transfer
+// workload is issued at an adaptive rate. Otherwise, the mix of actions and
+// TPS curve in [workload] is issued until all of its stages complete.
+func (h *Handler) Spam(sh SpamHelper, workload *Workload) error {
 	ctx := context.Background()
 
 	// Select chain
@@ -65,27 +72,31 @@ func (h *Handler) Spam(sh SpamHelper) error {
 	}
 
 	// Select root key
-	keys, err := h.GetKeys()
+	addrs, err := h.GetAddresses()
 	if err != nil {
 		return err
 	}
-	balances := make([]uint64, len(keys))
+	balances := make([]uint64, len(addrs))
 	if err := sh.CreateClient(uris[0], networkID, chainID); err != nil {
 		return err
 	}
-	for i := 0; i < len(keys); i++ {
-		address := h.c.Address(keys[i].Address)
+	for i := 0; i < len(addrs); i++ {
+		address := h.c.Address(addrs[i])
 		balance, err := sh.LookupBalance(i, address)
 		if err != nil {
 			return err
 		}
 		balances[i] = balance
 	}
-	keyIndex, err := h.PromptChoice("select root key", len(keys))
+	keyIndex, err := h.PromptChoice("select root key", len(addrs))
 	if err != nil {
 		return err
 	}
-	key := keys[keyIndex]
+	priv, err := h.GetKey(addrs[keyIndex])
+	if err != nil {
+		return err
+	}
+	key := &PrivateKey{Address: addrs[keyIndex], Bytes: priv}
 	balance := balances[keyIndex]
 	factory, err := sh.GetFactory(key)
 	if err != nil {
@@ -102,43 +113,86 @@ func (h *Handler) Spam(sh SpamHelper) error {
 	if err != nil {
 		return err
 	}
-	actions := sh.GetTransfer(keys[0].Address, 0, uniqueBytes())
+	actions := sh.GetTransfer(addrs[0], 0, uniqueBytes())
 	maxUnits, err := chain.EstimateUnits(parser.Rules(time.Now().UnixMilli()), actions, factory)
 	if err != nil {
 		return err
 	}
 
-	// Collect parameters
-	numAccounts, err := h.PromptInt("number of accounts", consts.MaxInt)
-	if err != nil {
-		return err
-	}
-	if numAccounts < 2 {
-		return ErrInsufficientAccounts
-	}
-	sZipf, err := h.PromptFloat("s (Zipf distribution = [(v+k)^(-s)], Default = 1.01)", consts.MaxFloat64)
-	if err != nil {
-		return err
-	}
-	vZipf, err := h.PromptFloat("v (Zipf distribution = [(v+k)^(-s)], Default = 2.7)", consts.MaxFloat64)
-	if err != nil {
-		return err
-	}
-	txsPerSecond, err := h.PromptInt("txs to try and issue per second", consts.MaxInt)
-	if err != nil {
-		return err
-	}
-	minTxsPerSecond, err := h.PromptInt("minimum txs to issue per second", consts.MaxInt)
-	if err != nil {
-		return err
-	}
-	txsPerSecondStep, err := h.PromptInt("txs to increase per second", consts.MaxInt)
-	if err != nil {
-		return err
+	// Compute max units of each workload action
+	actionUnits := map[*WorkloadAction]fees.Dimensions{}
+	if workload != nil {
+		for _, action := range workload.Actions {
+			recipients := make([]codec.Address, action.Recipients)
+			for i := range recipients {
+				recipients[i] = addrs[0]
+			}
+			actions, err := getWorkloadActions(sh, action, recipients, workloadPayload(action.PayloadSize))
+			if err != nil {
+				return err
+			}
+			units, err := chain.EstimateUnits(parser.Rules(time.Now().UnixMilli()), actions, factory)
+			if err != nil {
+				return err
+			}
+			actionUnits[action] = units
+		}
 	}
-	numClients, err := h.PromptInt("number of clients per node", consts.MaxInt)
-	if err != nil {
-		return err
+
+	// Collect parameters
+	var (
+		numAccounts      int
+		sZipf            float64
+		vZipf            float64
+		txsPerSecond     int
+		minTxsPerSecond  int
+		txsPerSecondStep int
+		numClients       int
+	)
+	if workload == nil {
+		numAccounts, err = h.PromptInt("number of accounts", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+		if numAccounts < 2 {
+			return ErrInsufficientAccounts
+		}
+		sZipf, err = h.PromptFloat("s (Zipf distribution = [(v+k)^(-s)], Default = 1.01)", consts.MaxFloat64)
+		if err != nil {
+			return err
+		}
+		vZipf, err = h.PromptFloat("v (Zipf distribution = [(v+k)^(-s)], Default = 2.7)", consts.MaxFloat64)
+		if err != nil {
+			return err
+		}
+		txsPerSecond, err = h.PromptInt("txs to try and issue per second", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+		minTxsPerSecond, err = h.PromptInt("minimum txs to issue per second", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+		txsPerSecondStep, err = h.PromptInt("txs to increase per second", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+		numClients, err = h.PromptInt("number of clients per node", consts.MaxInt)
+		if err != nil {
+			return err
+		}
+	} else {
+		numAccounts = workload.Accounts
+		sZipf, vZipf = workload.ZipfS, workload.ZipfV
+		txsPerSecond = workload.MaxTPS()
+		minTxsPerSecond = max(txsPerSecond, 1)
+		numClients = workload.ClientsPerNode
+		utils.Outf(
+			"{{yellow}}running workload:{{/}} %d actions over %s (max %d TPS)\n",
+			len(workload.Actions),
+			workload.Duration(),
+			txsPerSecond,
+		)
 	}
 
 	// Log Zipf participants
@@ -160,6 +214,14 @@ func (h *Handler) Spam(sh SpamHelper) error {
 	if err != nil {
 		return err
 	}
+	actionFees := map[*WorkloadAction]uint64{}
+	for action, units := range actionUnits {
+		fee, err := fees.MulSum(unitPrices, units)
+		if err != nil {
+			return err
+		}
+		actionFees[action] = fee
+	}
 	withholding := feePerTx * uint64(numAccounts)
 	if balance < withholding {
 		return fmt.Errorf("insufficient funds (have=%d need=%d)", balance, withholding)
@@ -275,27 +337,45 @@ func (h *Handler) Spam(sh SpamHelper) error {
 
 	// Broadcast txs
 	var (
-		// Do not call this function concurrently (math.Rand is not safe for concurrent use)
-		z = rand.NewZipf(zipfSeed, sZipf, vZipf, uint64(numAccounts)-1)
+		// Do not call these functions concurrently (math.Rand is not safe for concurrent use)
+		z           = rand.NewZipf(zipfSeed, sZipf, vZipf, uint64(numAccounts)-1)
+		nextAccount = z.Uint64
+		mixRand     = rand.New(rand.NewSource(1))
 
 		it                      = time.NewTimer(0)
+		workloadStart           = time.Now()
 		currentTarget           = min(txsPerSecond, minTxsPerSecond)
 		consecutiveUnderBacklog int
 		consecutiveAboveBacklog int
 
 		stop bool
 	)
+	if workload != nil && workload.Distribution == UniformDistribution {
+		nextAccount = func() uint64 { return uint64(zipfSeed.Intn(numAccounts)) }
+	}
 	utils.Outf("{{cyan}}initial target tps:{{/}} %d\n", currentTarget)
 	for !stop {
 		select {
 		case <-it.C:
 			start := time.Now()
+			if workload != nil {
+				target, ok := workload.TargetTPS(time.Since(workloadStart))
+				if !ok {
+					stop = true
+					utils.Outf("{{yellow}}workload complete{{/}}\n")
+					break
+				}
+				if target != currentTarget {
+					utils.Outf("{{cyan}}workload target tps:{{/}} %d\n", target)
+				}
+				currentTarget = target
+			}
 
 			// Check to see if we should wait for pending txs
 			if int64(currentTarget)+inflight.Load() > int64(currentTarget*pendingTargetMultiplier) {
 				consecutiveUnderBacklog = 0
 				consecutiveAboveBacklog++
-				if consecutiveAboveBacklog >= failedRunsToDecreaseTarget {
+				if workload == nil && consecutiveAboveBacklog >= failedRunsToDecreaseTarget {
 					if currentTarget > txsPerSecondStep {
 						currentTarget -= txsPerSecondStep
 						utils.Outf("{{cyan}}skipping issuance because large backlog detected, decreasing target tps:{{/}} %d\n", currentTarget)
@@ -312,32 +392,52 @@ func (h *Handler) Spam(sh SpamHelper) error {
 			g := &errgroup.Group{}
 			g.SetLimit(maxConcurrency)
 			for i := 0; i < currentTarget; i++ {
-				senderIndex, recipientIndex := z.Uint64(), z.Uint64()
+				senderIndex := nextAccount()
 				sender := accounts[senderIndex]
-				if recipientIndex == senderIndex {
-					if recipientIndex == uint64(numAccounts-1) {
-						recipientIndex--
-					} else {
-						recipientIndex++
+				var (
+					action     *WorkloadAction
+					recipients = make([]codec.Address, 1)
+					fee        = feePerTx
+				)
+				if workload != nil {
+					action = workload.pick(mixRand)
+					recipients = make([]codec.Address, action.Recipients)
+					fee = actionFees[action]
+				}
+				for j := range recipients {
+					recipientIndex := nextAccount()
+					if recipientIndex == senderIndex {
+						if recipientIndex == uint64(numAccounts-1) {
+							recipientIndex--
+						} else {
+							recipientIndex++
+						}
 					}
+					recipients[j] = accounts[recipientIndex].Address
 				}
-				recipient := accounts[recipientIndex].Address
 				issuer := getRandomIssuer(issuers)
 				g.Go(func() error {
 					factory := factories[senderIndex]
 					fundsL.Lock()
 					balance := funds[sender.Address]
-					if feePerTx > balance {
+					if fee > balance {
 						fundsL.Unlock()
 						utils.Outf("{{orange}}tx has insufficient funds:{{/}} %s\n", sender.Address)
 						return fmt.Errorf("%s has insufficient funds", sender.Address)
 					}
-					funds[sender.Address] = balance - feePerTx
+					funds[sender.Address] = balance - fee
 					fundsL.Unlock()
 
 					// Send transaction
-					actions := sh.GetTransfer(recipient, 1, uniqueBytes())
-					return issuer.Send(cctx, actions, factory, feePerTx)
+					if action == nil {
+						actions := sh.GetTransfer(recipients[0], 1, uniqueBytes())
+						return issuer.Send(cctx, actions, factory, fee, TransferWorkload)
+					}
+					actions, err := getWorkloadActions(sh, action, recipients, workloadPayload(action.PayloadSize))
+					if err != nil {
+						return err
+					}
+					return issuer.Send(cctx, actions, factory, fee, action.Name)
 				})
 			}
 
@@ -357,7 +457,7 @@ func (h *Handler) Spam(sh SpamHelper) error {
 			// Check to see if we should increase target
 			consecutiveAboveBacklog = 0
 			consecutiveUnderBacklog++
-			if consecutiveUnderBacklog >= successfulRunsToIncreaseTarget && currentTarget < txsPerSecond {
+			if workload == nil && consecutiveUnderBacklog >= successfulRunsToIncreaseTarget && currentTarget < txsPerSecond {
 				currentTarget = min(currentTarget+txsPerSecondStep, txsPerSecond)
 				utils.Outf("{{cyan}}increasing target tps:{{/}} %d\n", currentTarget)
 				consecutiveUnderBacklog = 0
@@ -375,6 +475,7 @@ func (h *Handler) Spam(sh SpamHelper) error {
 	// Wait for all issuers to finish
 	utils.Outf("{{yellow}}waiting for issuers to return{{/}}\n")
 	issuerWg.Wait()
+	tracker.Report()
 
 	// Return funds
 	utils.Outf("{{yellow}}returning funds to %s{{/}}\n", h.c.Address(key.Address))
@@ -488,10 +589,11 @@ func (i *issuer) Start(ctx context.Context) {
 	issuerWg.Add(1)
 	go func() {
 		for {
-			_, wsErr, result, err := i.ws.ListenTx(context.TODO())
+			txID, wsErr, result, err := i.ws.ListenTx(context.TODO())
 			if err != nil {
 				return
 			}
+			tracker.Done(txID, result)
 			i.l.Lock()
 			i.outstandingTxs--
 			i.l.Unlock()
@@ -538,7 +640,7 @@ func (i *issuer) Start(ctx context.Context) {
 	}()
 }
 
-func (i *issuer) Send(ctx context.Context, actions []chain.Action, factory chain.AuthFactory, feePerTx uint64) error {
+func (i *issuer) Send(ctx context.Context, actions []chain.Action, factory chain.AuthFactory, feePerTx uint64, name string) error {
 	// Construct transaction
 	_, tx, err := i.cli.GenerateTransactionManual(i.parser, actions, factory, feePerTx)
 	if err != nil {
@@ -547,6 +649,7 @@ func (i *issuer) Send(ctx context.Context, actions []chain.Action, factory chain
 	}
 
 	// Increase outstanding txs for issuer
+	tracker.Issued(tx.ID(), name)
 	i.l.Lock()
 	i.outstandingTxs++
 	i.l.Unlock()
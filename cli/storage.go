@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/utils"
 )
@@ -18,23 +19,25 @@ const (
 	defaultPrefix = 0x0
 	keyPrefix     = 0x1
 	chainPrefix   = 0x2
+	labelPrefix   = 0x3
 
 	defaultKeyKey   = "key"
 	defaultChainKey = "chain"
 )
 
-func (h *Handler) StoreDefault(key string, value []byte) error {
+func defaultKey(key string) []byte {
 	k := make([]byte, 1+len(key))
 	k[0] = defaultPrefix
 	copy(k[1:], []byte(key))
-	return h.db.Put(k, value)
+	return k
+}
+
+func (h *Handler) StoreDefault(key string, value []byte) error {
+	return h.db.Put(defaultKey(key), value)
 }
 
 func (h *Handler) GetDefault(key string) ([]byte, error) {
-	k := make([]byte, 1+len(key))
-	k[0] = defaultPrefix
-	copy(k[1:], []byte(key))
-	v, err := h.db.Get(k)
+	v, err := h.db.Get(defaultKey(key))
 	if errors.Is(err, database.ErrNotFound) {
 		return nil, nil
 	}
@@ -67,10 +70,20 @@ func (h *Handler) GetDefaultChain(log bool) (ids.ID, []string, error) {
 	return chainID, uris, nil
 }
 
-func (h *Handler) StoreKey(priv *PrivateKey) error {
+func keyKey(addr codec.Address) []byte {
 	k := make([]byte, 1+codec.AddressLen)
 	k[0] = keyPrefix
-	copy(k[1:], priv.Address[:])
+	copy(k[1:], addr[:])
+	return k
+}
+
+// StoreKey encrypts [priv] with the keystore key before persisting it.
+func (h *Handler) StoreKey(priv *PrivateKey) (err error) {
+	defer func() {
+		h.auditKey("store", priv.Address, err, nil)
+	}()
+
+	k := keyKey(priv.Address)
 	has, err := h.db.Has(k)
 	if err != nil {
 		return err
@@ -78,13 +91,29 @@ func (h *Handler) StoreKey(priv *PrivateKey) error {
 	if has {
 		return ErrDuplicate
 	}
-	return h.db.Put(k, priv.Bytes)
+	key, err := h.unlock()
+	if err != nil {
+		return err
+	}
+	sealed, err := seal(key, priv.Bytes, k)
+	if err != nil {
+		return err
+	}
+	return h.db.Put(k, sealed)
 }
 
-func (h *Handler) GetKey(addr codec.Address) ([]byte, error) {
-	k := make([]byte, 1+codec.AddressLen)
-	k[0] = keyPrefix
-	copy(k[1:], addr[:])
+func (h *Handler) GetKey(addr codec.Address) (priv []byte, err error) {
+	defer func() {
+		h.auditKey("use", addr, err, nil)
+	}()
+
+	// Unlocking may encrypt plaintext keys, so it must happen before the key
+	// is read.
+	key, err := h.unlock()
+	if err != nil {
+		return nil, err
+	}
+	k := keyKey(addr)
 	v, err := h.db.Get(k)
 	// TODO: return error if not found?
 	if errors.Is(err, database.ErrNotFound) {
@@ -93,7 +122,19 @@ func (h *Handler) GetKey(addr codec.Address) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return v, nil
+	return unseal(key, v, k)
+}
+
+// auditKey records [action] on the stored key of [addr].
+func (h *Handler) auditKey(action string, addr codec.Address, err error, fields map[string]any) {
+	if h.audit == nil {
+		return
+	}
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	fields["address"] = h.c.Address(addr)
+	h.audit.Record(audit.KeyCategory, action, "", err, fields)
 }
 
 type PrivateKey struct {
@@ -101,41 +142,67 @@ type PrivateKey struct {
 	Bytes   []byte
 }
 
-func (h *Handler) GetKeys() ([]*PrivateKey, error) {
+// GetAddresses returns the addresses of all stored keys without unlocking
+// the keystore.
+func (h *Handler) GetAddresses() ([]codec.Address, error) {
 	iter := h.db.NewIteratorWithPrefix([]byte{keyPrefix})
 	defer iter.Release()
 
-	privateKeys := []*PrivateKey{}
+	addrs := []codec.Address{}
 	for iter.Next() {
-		// It is safe to use these bytes directly because the database copies the
-		// iterator value for us.
+		addrs = append(addrs, codec.Address(iter.Key()[1:]))
+	}
+	return addrs, iter.Error()
+}
+
+func (h *Handler) GetKeys() ([]*PrivateKey, error) {
+	addrs, err := h.GetAddresses()
+	if err != nil {
+		return nil, err
+	}
+	privateKeys := make([]*PrivateKey, 0, len(addrs))
+	for _, addr := range addrs {
+		priv, err := h.GetKey(addr)
+		if err != nil {
+			return nil, err
+		}
 		privateKeys = append(privateKeys, &PrivateKey{
-			Address: codec.Address(iter.Key()[1:]),
-			Bytes:   iter.Value(),
+			Address: addr,
+			Bytes:   priv,
 		})
 	}
-	return privateKeys, iter.Error()
+	return privateKeys, nil
 }
 
 func (h *Handler) StoreDefaultKey(addr codec.Address) error {
 	return h.StoreDefault(defaultKeyKey, addr[:])
 }
 
-func (h *Handler) GetDefaultKey(log bool) (codec.Address, []byte, error) {
+// GetDefaultAddress returns the address of the default key without
+// unlocking the keystore.
+func (h *Handler) GetDefaultAddress(log bool) (codec.Address, error) {
 	raddr, err := h.GetDefault(defaultKeyKey)
 	if err != nil {
-		return codec.EmptyAddress, nil, err
+		return codec.EmptyAddress, err
 	}
 	if len(raddr) == 0 {
-		return codec.EmptyAddress, nil, ErrNoKeys
+		return codec.EmptyAddress, ErrNoKeys
 	}
 	addr := codec.Address(raddr)
-	priv, err := h.GetKey(addr)
+	if log {
+		utils.Outf("{{yellow}}address:{{/}} %s\n", h.FormatAddress(addr))
+	}
+	return addr, nil
+}
+
+func (h *Handler) GetDefaultKey(log bool) (codec.Address, []byte, error) {
+	addr, err := h.GetDefaultAddress(log)
 	if err != nil {
 		return codec.EmptyAddress, nil, err
 	}
-	if log {
-		utils.Outf("{{yellow}}address:{{/}} %s\n", h.c.Address(addr))
+	priv, err := h.GetKey(addr)
+	if err != nil {
+		return codec.EmptyAddress, nil, err
 	}
 	return addr, priv, nil
 }
@@ -225,5 +292,9 @@ func (h *Handler) CloseDatabase() error {
 	}
 	// Allow DB to be closed multiple times
 	h.db = nil
+	h.Lock()
+	if err := h.audit.Close(); err != nil {
+		return fmt.Errorf("unable to close audit log: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,329 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//nolint:gosec
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	// TransferWorkload is the workload action that is always supported (via
+	// [SpamHelper.GetTransfer]).
+	TransferWorkload = "transfer"
+
+	// UniformDistribution selects senders and recipients uniformly instead of
+	// with a Zipf distribution.
+	UniformDistribution = "uniform"
+	ZipfDistribution    = "zipf"
+
+	// minPayloadSize is the number of bytes used to keep each transaction
+	// unique.
+	minPayloadSize = 8
+)
+
+var (
+	ErrNoWorkloadActions     = errors.New("workload has no actions")
+	ErrNoWorkloadStages      = errors.New("workload has no stages")
+	ErrUnsupportedWorkload   = errors.New("unsupported workload action")
+	ErrInvalidWorkloadWeight = errors.New("workload action weight must be positive")
+	ErrInvalidDistribution   = errors.New("invalid account distribution")
+)
+
+// WorkloadHelper can optionally be implemented by a [SpamHelper] to allow
+// workloads to include actions other than transfers.
+type WorkloadHelper interface {
+	// GetWorkloadAction returns the actions of the workload action [name]
+	// sent to [recipients].
+	//
+	// [payload] must be included in the actions to ensure that each
+	// transaction is unique.
+	GetWorkloadAction(name string, recipients []codec.Address, payload []byte) ([]chain.Action, error)
+}
+
+// WorkloadAction is a type of transaction issued by a [Workload].
+type WorkloadAction struct {
+	// Name is [TransferWorkload] or a name supported by [WorkloadHelper].
+	Name string `yaml:"name"`
+	// Weight is the relative frequency of this action in the mix.
+	Weight int `yaml:"weight"`
+	// PayloadSize is the number of bytes of memo (or similar) data included
+	// in each action. It is at least [minPayloadSize].
+	PayloadSize int `yaml:"payloadSize"`
+	// Recipients is the number of recipients of each action (only used by
+	// actions that support multiple recipients).
+	Recipients int `yaml:"recipients"`
+}
+
+// WorkloadStage targets a TPS for some duration of a [Workload].
+type WorkloadStage struct {
+	Duration time.Duration `yaml:"duration"`
+	TPS      int           `yaml:"tps"`
+	// Ramp linearly increases (or decreases) the TPS from the previous stage
+	// to [TPS] over [Duration] instead of jumping to it.
+	Ramp bool `yaml:"ramp"`
+}
+
+// Workload configures a non-interactive spam run with a mix of actions and a
+// TPS curve.
+type Workload struct {
+	Accounts       int     `yaml:"accounts"`
+	ClientsPerNode int     `yaml:"clientsPerNode"`
+	Distribution   string  `yaml:"distribution"`
+	ZipfS          float64 `yaml:"zipfS"`
+	ZipfV          float64 `yaml:"zipfV"`
+
+	Actions []*WorkloadAction `yaml:"actions"`
+	Stages  []*WorkloadStage  `yaml:"stages"`
+
+	totalWeight int
+}
+
+// LoadWorkload reads a YAML (or JSON) workload description from [path].
+func LoadWorkload(path string) (*Workload, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	w := &Workload{
+		ClientsPerNode: 1,
+		Distribution:   ZipfDistribution,
+		ZipfS:          1.01,
+		ZipfV:          2.7,
+	}
+	if err := yaml.UnmarshalStrict(b, w); err != nil {
+		return nil, err
+	}
+	if err := w.verify(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Workload) verify() error {
+	if w.Accounts < 2 {
+		return ErrInsufficientAccounts
+	}
+	if w.Distribution != ZipfDistribution && w.Distribution != UniformDistribution {
+		return fmt.Errorf("%w: %s", ErrInvalidDistribution, w.Distribution)
+	}
+	if len(w.Actions) == 0 {
+		return ErrNoWorkloadActions
+	}
+	if len(w.Stages) == 0 {
+		return ErrNoWorkloadStages
+	}
+	w.totalWeight = 0
+	for _, action := range w.Actions {
+		if action.Weight <= 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidWorkloadWeight, action.Name)
+		}
+		w.totalWeight += action.Weight
+		action.PayloadSize = max(action.PayloadSize, minPayloadSize)
+		action.Recipients = max(action.Recipients, 1)
+	}
+	return nil
+}
+
+// Duration is the total length of all stages.
+func (w *Workload) Duration() time.Duration {
+	var total time.Duration
+	for _, stage := range w.Stages {
+		total += stage.Duration
+	}
+	return total
+}
+
+// MaxTPS is the largest TPS targeted by any stage.
+func (w *Workload) MaxTPS() int {
+	var m int
+	for _, stage := range w.Stages {
+		m = max(m, stage.TPS)
+	}
+	return m
+}
+
+// TargetTPS returns the TPS to issue [elapsed] after the workload started and
+// false once all stages have finished.
+func (w *Workload) TargetTPS(elapsed time.Duration) (int, bool) {
+	var (
+		start   time.Duration
+		prevTPS int
+	)
+	for _, stage := range w.Stages {
+		if elapsed < start+stage.Duration {
+			if !stage.Ramp {
+				return stage.TPS, true
+			}
+			progress := float64(elapsed-start) / float64(stage.Duration)
+			return prevTPS + int(float64(stage.TPS-prevTPS)*progress), true
+		}
+		start += stage.Duration
+		prevTPS = stage.TPS
+	}
+	return 0, false
+}
+
+// pick returns a random action from the mix according to its weight.
+//
+// This must not be called concurrently with the same [r].
+func (w *Workload) pick(r *rand.Rand) *WorkloadAction {
+	n := r.Intn(w.totalWeight)
+	for _, action := range w.Actions {
+		if n < action.Weight {
+			return action
+		}
+		n -= action.Weight
+	}
+	return w.Actions[len(w.Actions)-1]
+}
+
+// getWorkloadActions returns the actions of [action] sent to [recipients].
+func getWorkloadActions(
+	sh SpamHelper,
+	action *WorkloadAction,
+	recipients []codec.Address,
+	payload []byte,
+) ([]chain.Action, error) {
+	if action.Name == TransferWorkload {
+		return sh.GetTransfer(recipients[0], 1, payload), nil
+	}
+	wh, ok := sh.(WorkloadHelper)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedWorkload, action.Name)
+	}
+	return wh.GetWorkloadAction(action.Name, recipients, payload)
+}
+
+// workloadPayload returns a unique payload of [size] bytes.
+func workloadPayload(size int) []byte {
+	payload := make([]byte, size)
+	copy(payload, uniqueBytes())
+	return payload
+}
+
+type trackedTx struct {
+	name string
+	sent time.Time
+}
+
+type actionStats struct {
+	issued    int
+	included  int
+	failed    int
+	dropped   int
+	latencies []time.Duration
+}
+
+// txTracker records the outcome of every transaction issued by the spammer so
+// that a report can be printed once it exits.
+type txTracker struct {
+	l       sync.Mutex
+	pending map[ids.ID]*trackedTx
+	stats   map[string]*actionStats
+}
+
+func newTxTracker() *txTracker {
+	return &txTracker{
+		pending: map[ids.ID]*trackedTx{},
+		stats:   map[string]*actionStats{},
+	}
+}
+
+func (t *txTracker) statsFor(name string) *actionStats {
+	s, ok := t.stats[name]
+	if !ok {
+		s = &actionStats{}
+		t.stats[name] = s
+	}
+	return s
+}
+
+// Issued must be called before a transaction is sent so that its result
+// cannot be observed first.
+func (t *txTracker) Issued(txID ids.ID, name string) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	t.pending[txID] = &trackedTx{name: name, sent: time.Now()}
+	t.statsFor(name).issued++
+}
+
+// Done records the result of a transaction. [result] is nil if the
+// transaction was dropped before execution.
+func (t *txTracker) Done(txID ids.ID, result *chain.Result) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	tx, ok := t.pending[txID]
+	if !ok {
+		return
+	}
+	delete(t.pending, txID)
+	s := t.statsFor(tx.name)
+	switch {
+	case result == nil:
+		s.dropped++
+	case result.Success:
+		s.included++
+		s.latencies = append(s.latencies, time.Since(tx.sent))
+	default:
+		s.failed++
+		s.latencies = append(s.latencies, time.Since(tx.sent))
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
+
+// Report prints inclusion and latency statistics for each action type.
+func (t *txTracker) Report() {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	names := make([]string, 0, len(t.stats))
+	for name := range t.stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	utils.Outf("{{cyan}}{{bold}}workload report{{/}}\n")
+	for _, name := range names {
+		s := t.stats[name]
+		latencies := slices.Clone(s.latencies)
+		slices.Sort(latencies)
+		pending := s.issued - s.included - s.failed - s.dropped
+		utils.Outf(
+			"{{yellow}}%s:{{/}} issued=%d included=%d (%.2f%%) failed=%d dropped=%d pending=%d {{yellow}}latency:{{/}} p50=%s p90=%s p99=%s max=%s\n",
+			name,
+			s.issued,
+			s.included,
+			float64(s.included)/float64(max(s.issued, 1))*100,
+			s.failed,
+			s.dropped,
+			pending,
+			percentile(latencies, 0.5).Truncate(time.Millisecond),
+			percentile(latencies, 0.9).Truncate(time.Millisecond),
+			percentile(latencies, 0.99).Truncate(time.Millisecond),
+			percentile(latencies, 1).Truncate(time.Millisecond),
+		)
+	}
+}
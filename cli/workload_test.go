@@ -0,0 +1,113 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cli
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWorkload(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "workload.yaml")
+	require.NoError(os.WriteFile(path, []byte(`
+accounts: 10
+actions:
+  - name: transfer
+    weight: 3
+  - name: transfer-multiple
+    weight: 1
+    recipients: 4
+    payloadSize: 128
+stages:
+  - duration: 10s
+    tps: 100
+    ramp: true
+  - duration: 1m
+    tps: 50
+`), 0o600))
+	w, err := LoadWorkload(path)
+	require.NoError(err)
+	require.Equal(1, w.ClientsPerNode)
+	require.Equal(ZipfDistribution, w.Distribution)
+	require.Equal(minPayloadSize, w.Actions[0].PayloadSize)
+	require.Equal(1, w.Actions[0].Recipients)
+	require.Equal(128, w.Actions[1].PayloadSize)
+	require.Equal(70*time.Second, w.Duration())
+	require.Equal(100, w.MaxTPS())
+
+	// Unknown fields are rejected
+	require.NoError(os.WriteFile(path, []byte("accounts: 10\ntps: 5\n"), 0o600))
+	_, err = LoadWorkload(path)
+	require.Error(err)
+
+	// Weights must be positive
+	require.NoError(os.WriteFile(path, []byte(`
+accounts: 10
+actions:
+  - name: transfer
+stages:
+  - duration: 10s
+    tps: 100
+`), 0o600))
+	_, err = LoadWorkload(path)
+	require.ErrorIs(err, ErrInvalidWorkloadWeight)
+}
+
+func TestWorkloadTargetTPS(t *testing.T) {
+	require := require.New(t)
+
+	w := &Workload{
+		Stages: []*WorkloadStage{
+			{Duration: 10 * time.Second, TPS: 100, Ramp: true},
+			{Duration: 10 * time.Second, TPS: 100},
+			{Duration: 10 * time.Second, TPS: 0, Ramp: true},
+		},
+	}
+	tests := []struct {
+		elapsed time.Duration
+		tps     int
+	}{
+		{0, 0},
+		{5 * time.Second, 50},
+		{10 * time.Second, 100},
+		{19 * time.Second, 100},
+		{25 * time.Second, 50},
+	}
+	for _, tt := range tests {
+		tps, ok := w.TargetTPS(tt.elapsed)
+		require.True(ok)
+		require.Equal(tt.tps, tps)
+	}
+	_, ok := w.TargetTPS(30 * time.Second)
+	require.False(ok)
+}
+
+func TestWorkloadPick(t *testing.T) {
+	require := require.New(t)
+
+	w := &Workload{
+		Accounts:     2,
+		Distribution: UniformDistribution,
+		Actions: []*WorkloadAction{
+			{Name: "a", Weight: 3},
+			{Name: "b", Weight: 1},
+		},
+		Stages: []*WorkloadStage{{Duration: time.Second, TPS: 1}},
+	}
+	require.NoError(w.verify())
+	r := rand.New(rand.NewSource(0)) //nolint:gosec
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		counts[w.pick(r).Name]++
+	}
+	require.InDelta(3000, counts["a"], 200)
+	require.InDelta(1000, counts["b"], 200)
+}
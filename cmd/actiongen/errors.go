@@ -0,0 +1,16 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import "errors"
+
+var (
+	ErrNoTypes          = errors.New("no types to generate")
+	ErrTypeNotFound     = errors.New("type not found")
+	ErrNotStruct        = errors.New("type is not a struct")
+	ErrUnsupportedField = errors.New("unsupported field type")
+	ErrInvalidTag       = errors.New("invalid tag")
+	ErrMissingMax       = errors.New("missing max tag")
+	ErrAlreadyDefined   = errors.New("method already defined")
+)
@@ -0,0 +1,394 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	tagKey      = "hypersdk"
+	requiredTag = "required"
+	maxTag      = "max="
+
+	constsPath = "github.com/ava-labs/hypersdk/consts"
+	codecPath  = "github.com/ava-labs/hypersdk/codec"
+	idsPath    = "github.com/ava-labs/avalanchego/ids"
+
+	registryFile = "registry_gen.go"
+)
+
+// generatedMethods are the methods of [chain.Action] written by actiongen and
+// stubbedMethods the ones it stubs (in the order they are written).
+var (
+	generatedMethods = []string{"Size", "Marshal"}
+	stubbedMethods   = []string{"GetTypeID", "ValidRange", "ComputeUnits", "StateKeys", "StateKeysMaxChunks", "Execute"}
+)
+
+// kind describes how fields of a type are encoded.
+type kind struct {
+	// size is the expression of the size of the field, which is formatted
+	// with the field if it contains a verb.
+	size string
+	pack string
+	// unpack is formatted with the field, whether it is required and its max
+	// length. Kinds that support the required tag use the second argument.
+	unpack string
+	// sample is a non-empty value used by the test skeleton.
+	sample  string
+	imports []string
+	max     bool
+}
+
+func (k *kind) fixed() bool {
+	return !strings.Contains(k.size, "%")
+}
+
+func (k *kind) requirable() bool {
+	return strings.Contains(k.unpack, "%[2]t")
+}
+
+var kinds = map[string]*kind{
+	"bool": {
+		size:    "consts.BoolLen",
+		pack:    "PackBool",
+		unpack:  "%[1]s = p.UnpackBool()",
+		sample:  "true",
+		imports: []string{constsPath},
+	},
+	"uint8": {
+		size:    "consts.Uint8Len",
+		pack:    "PackByte",
+		unpack:  "%[1]s = p.UnpackByte()",
+		sample:  "1",
+		imports: []string{constsPath},
+	},
+	"int": {
+		size:    "consts.IntLen",
+		pack:    "PackInt",
+		unpack:  "%[1]s = p.UnpackInt(%[2]t)",
+		sample:  "1",
+		imports: []string{constsPath},
+	},
+	"int64": {
+		size:    "consts.Int64Len",
+		pack:    "PackInt64",
+		unpack:  "%[1]s = p.UnpackInt64(%[2]t)",
+		sample:  "1",
+		imports: []string{constsPath},
+	},
+	"uint64": {
+		size:    "consts.Uint64Len",
+		pack:    "PackUint64",
+		unpack:  "%[1]s = p.UnpackUint64(%[2]t)",
+		sample:  "1",
+		imports: []string{constsPath},
+	},
+	"string": {
+		// Strings are prefixed with a uint16 length ([codec.StringLen]
+		// overestimates it).
+		size:    "consts.Uint16Len + len(%[1]s)",
+		pack:    "PackString",
+		unpack:  "%[1]s = p.UnpackString(%[2]t)",
+		sample:  `"a"`,
+		imports: []string{constsPath},
+	},
+	"[]byte": {
+		size:   "codec.BytesLen(%[1]s)",
+		pack:   "PackBytes",
+		unpack: "p.UnpackBytes(%[3]s, %[2]t, &%[1]s)",
+		sample: "[]byte{1}",
+		max:    true,
+	},
+	codecPath + ".Address": {
+		size:    "codec.AddressLen",
+		pack:    "PackAddress",
+		unpack:  "p.UnpackAddress(&%[1]s)",
+		sample:  "codec.Address{1}",
+		imports: []string{codecPath},
+	},
+	idsPath + ".ID": {
+		size:    "ids.IDLen",
+		pack:    "PackID",
+		unpack:  "p.UnpackID(%[2]t, &%[1]s)",
+		sample:  "ids.ID{1}",
+		imports: []string{idsPath},
+	},
+}
+
+type field struct {
+	name     string
+	kind     *kind
+	required bool
+	limit    string
+}
+
+type action struct {
+	name   string
+	file   string
+	fields []*field
+	stubs  []string
+}
+
+// pkg is the parsed package of the actions.
+type pkg struct {
+	name  string
+	types map[string]*ast.TypeSpec
+	// imports are the imports of the file of every type (by local name).
+	imports map[string]map[string]string
+	// defined is the file of every method (keyed by type and name) and
+	// function of the package.
+	defined map[string]string
+}
+
+func generate(dir string, names []string) error {
+	if len(names) == 0 {
+		return ErrNoTypes
+	}
+	p, err := parsePackage(dir)
+	if err != nil {
+		return err
+	}
+	actions := make([]*action, 0, len(names))
+	for _, name := range names {
+		a, err := p.action(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		actions = append(actions, a)
+	}
+
+	for _, a := range actions {
+		if err := writeFile(dir, a.file+"_gen.go", genFile(p.name, a), true); err != nil {
+			return err
+		}
+		if len(a.stubs) > 0 {
+			if err := writeFile(dir, a.file+"_stubs.go", stubsFile(p.name, a), false); err != nil {
+				return err
+			}
+		}
+		if err := writeFile(dir, a.file+"_test.go", testFile(p.name, a), false); err != nil {
+			return err
+		}
+	}
+	return writeFile(dir, registryFile, registryGenFile(p.name, actions), true)
+}
+
+func parsePackage(dir string) (*pkg, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{
+		types:   map[string]*ast.TypeSpec{},
+		imports: map[string]map[string]string{},
+		defined: map[string]string{},
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		p.name = f.Name.Name
+		p.addFile(name, f)
+	}
+	return p, nil
+}
+
+func (p *pkg) addFile(name string, f *ast.File) {
+	imports := map[string]string{}
+	for _, imp := range f.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		local := filepath.Base(path)
+		if imp.Name != nil {
+			local = imp.Name.Name
+		}
+		imports[local] = path
+	}
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				p.types[spec.Name.Name] = spec
+				p.imports[spec.Name.Name] = imports
+			}
+		case *ast.FuncDecl:
+			p.defined[funcKey(decl)] = name
+		}
+	}
+}
+
+func funcKey(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	recv := decl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name + "." + decl.Name.Name
+	}
+	return decl.Name.Name
+}
+
+func (p *pkg) action(name string) (*action, error) {
+	spec, ok := p.types[name]
+	if !ok {
+		return nil, ErrTypeNotFound
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, ErrNotStruct
+	}
+	a := &action{name: name, file: snakeCase(name)}
+	for _, f := range st.Fields.List {
+		fields, err := parseField(f, p.imports[name])
+		if err != nil {
+			return nil, err
+		}
+		a.fields = append(a.fields, fields...)
+	}
+
+	// Generated code is rewritten by every run, so it must not conflict with
+	// code written by hand.
+	gen := a.file + "_gen.go"
+	owned := []string{"Unmarshal" + name, "Parse" + name + "JSON"}
+	for _, method := range generatedMethods {
+		owned = append(owned, name+"."+method)
+	}
+	for _, key := range owned {
+		if file, ok := p.defined[key]; ok && file != gen {
+			return nil, fmt.Errorf("%w: %s in %s", ErrAlreadyDefined, key, file)
+		}
+	}
+	for _, method := range stubbedMethods {
+		if _, ok := p.defined[name+"."+method]; !ok {
+			a.stubs = append(a.stubs, method)
+		}
+	}
+	return a, nil
+}
+
+func parseField(f *ast.Field, imports map[string]string) ([]*field, error) {
+	if len(f.Names) == 0 {
+		return nil, fmt.Errorf("%w: embedded field", ErrUnsupportedField)
+	}
+	k, ok := kinds[typeKey(f.Type, imports)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedField, f.Names[0].Name)
+	}
+	var (
+		required bool
+		limit    string
+	)
+	if f.Tag != nil {
+		tag, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := reflect.StructTag(tag).Lookup(tagKey); ok {
+			for _, opt := range strings.Split(value, ",") {
+				switch {
+				case opt == requiredTag && k.requirable():
+					required = true
+				case strings.HasPrefix(opt, maxTag) && k.max:
+					limit = strings.TrimPrefix(opt, maxTag)
+				default:
+					return nil, fmt.Errorf("%w: %q of %s", ErrInvalidTag, opt, f.Names[0].Name)
+				}
+			}
+		}
+	}
+	if k.max && len(limit) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingMax, f.Names[0].Name)
+	}
+
+	fields := make([]*field, 0, len(f.Names))
+	for _, name := range f.Names {
+		if !name.IsExported() {
+			return nil, fmt.Errorf("%w: %s is not exported", ErrUnsupportedField, name.Name)
+		}
+		fields = append(fields, &field{name: name.Name, kind: k, required: required, limit: limit})
+	}
+	return fields, nil
+}
+
+func typeKey(expr ast.Expr, imports map[string]string) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if expr.Name == "byte" {
+			return "uint8"
+		}
+		return expr.Name
+	case *ast.SelectorExpr:
+		if x, ok := expr.X.(*ast.Ident); ok {
+			return imports[x.Name] + "." + expr.Sel.Name
+		}
+	case *ast.ArrayType:
+		if elt, ok := expr.Elt.(*ast.Ident); ok && expr.Len == nil && (elt.Name == "byte" || elt.Name == "uint8") {
+			return "[]byte"
+		}
+	}
+	return ""
+}
+
+// writeFile writes the formatted [src] to [name] in [dir] if it doesn't
+// exist or [overwrite] is set.
+func writeFile(dir, name string, src []byte, overwrite bool) error {
+	path := filepath.Join(dir, name)
+	if !overwrite {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return os.WriteFile(path, formatted, 0o644) //nolint:gosec
+}
+
+// snakeCase converts a type name to a file name (TransferNFT becomes
+// transfer_nft).
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
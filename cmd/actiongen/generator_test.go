@@ -0,0 +1,138 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("testdata", "actions", "actions.go"))
+	require.NoError(err)
+	require.NoError(os.WriteFile(filepath.Join(dir, "actions.go"), src, 0o600))
+	require.NoError(generate(dir, []string{"MintAsset", "Ping"}))
+
+	files := []string{
+		"mint_asset_gen.go",
+		"mint_asset_stubs.go",
+		"mint_asset_test.go",
+		"ping_gen.go",
+		"ping_stubs.go",
+		"ping_test.go",
+		"registry_gen.go",
+	}
+	for _, name := range files {
+		generated, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(err)
+		golden := filepath.Join("testdata", "golden", name+".golden")
+		if *update {
+			require.NoError(os.WriteFile(golden, generated, 0o600))
+		}
+		expected, err := os.ReadFile(golden)
+		require.NoError(err)
+		require.Equal(string(expected), string(generated), name)
+	}
+	// GetTypeID is defined by hand
+	stubs, err := os.ReadFile(filepath.Join(dir, "mint_asset_stubs.go"))
+	require.NoError(err)
+	require.NotContains(string(stubs), "GetTypeID")
+
+	// Stubs and tests are not overwritten once they've been edited
+	test := filepath.Join(dir, "ping_test.go")
+	require.NoError(os.WriteFile(test, []byte("package actions\n"), 0o600))
+	require.NoError(generate(dir, []string{"MintAsset", "Ping"}))
+	edited, err := os.ReadFile(test)
+	require.NoError(err)
+	require.Equal("package actions\n", string(edited))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		types []string
+		err   error
+	}{
+		{
+			name: "no types",
+			src:  "type A struct{}",
+			err:  ErrNoTypes,
+		},
+		{
+			name:  "unknown type",
+			src:   "type A struct{}",
+			types: []string{"B"},
+			err:   ErrTypeNotFound,
+		},
+		{
+			name:  "not a struct",
+			src:   "type A uint64",
+			types: []string{"A"},
+			err:   ErrNotStruct,
+		},
+		{
+			name:  "unsupported field",
+			src:   "type A struct{ V float64 }",
+			types: []string{"A"},
+			err:   ErrUnsupportedField,
+		},
+		{
+			name:  "unexported field",
+			src:   "type A struct{ v uint64 }",
+			types: []string{"A"},
+			err:   ErrUnsupportedField,
+		},
+		{
+			name:  "missing max",
+			src:   "type A struct{ V []byte }",
+			types: []string{"A"},
+			err:   ErrMissingMax,
+		},
+		{
+			name:  "required bool",
+			src:   "type A struct{ V bool `hypersdk:\"required\"` }",
+			types: []string{"A"},
+			err:   ErrInvalidTag,
+		},
+		{
+			name:  "max of uint64",
+			src:   "type A struct{ V uint64 `hypersdk:\"max=1\"` }",
+			types: []string{"A"},
+			err:   ErrInvalidTag,
+		},
+		{
+			name:  "size defined by hand",
+			src:   "type A struct{}\n\nfunc (*A) Size() int { return 0 }",
+			types: []string{"A"},
+			err:   ErrAlreadyDefined,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := "package actions\n\n" + tt.src + "\n"
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "actions.go"), []byte(src), 0o600))
+			require.ErrorIs(t, generate(dir, tt.types), tt.err)
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("transfer", snakeCase("Transfer"))
+	require.Equal("transfer_multiple", snakeCase("TransferMultiple"))
+	require.Equal("transfer_nft", snakeCase("TransferNFT"))
+	require.Equal("nft_mint", snakeCase("NFTMint"))
+}
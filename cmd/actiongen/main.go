@@ -0,0 +1,45 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// actiongen generates the boilerplate of [chain.Action] implementations from
+// their struct definitions. It is meant to be run with go:generate from the
+// package of the actions:
+//
+//	//go:generate go run github.com/ava-labs/hypersdk/cmd/actiongen -type Transfer,Burn
+//
+// For each type, actiongen (re)writes <type>_gen.go with its Size, Marshal,
+// Unmarshal<Type> and Parse<Type>JSON functions. With the first run, it also
+// writes <type>_stubs.go with stubs of the methods of [chain.Action] the type
+// doesn't define yet and a <type>_test.go skeleton; these files are never
+// overwritten. registry_gen.go registers all the types in the order they are
+// listed.
+//
+// The fields of the struct are encoded in order and can be tagged with
+// `hypersdk:"required"` (an error is returned by Unmarshal<Type> if the
+// field is empty) and `hypersdk:"max=<expr>"` (the max length of a []byte
+// field, which is required for them).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	types := flag.String("type", "", "comma-separated list of the action types to generate")
+	dir := flag.String("dir", ".", "directory of the package of the actions")
+	flag.Parse()
+
+	var names []string
+	if len(*types) > 0 {
+		names = strings.Split(*types, ",")
+	}
+	if err := generate(*dir, names); err != nil {
+		if _, err := fmt.Fprintln(os.Stderr, "actiongen:", err); err != nil {
+			panic(err)
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	header = "// Code generated by actiongen. DO NOT EDIT.\n\n"
+
+	chainPath    = "github.com/ava-labs/hypersdk/chain"
+	statePath    = "github.com/ava-labs/hypersdk/state"
+	wrappersPath = "github.com/ava-labs/avalanchego/utils/wrappers"
+	requirePath  = "github.com/stretchr/testify/require"
+
+	localPrefix = "github.com/ava-labs/hypersdk"
+)
+
+var genTemplate = template.Must(template.New("gen").Parse(`
+var _ chain.Action = (*{{.Name}})(nil)
+
+func ({{.SizeRecv}}*{{.Name}}) Size() int {
+	return {{.Size}}
+}
+
+func ({{.MarshalRecv}}*{{.Name}}) Marshal({{if .Marshal}}p {{end}}*codec.Packer) {
+{{- range .Marshal}}
+	{{.}}
+{{- end}}
+}
+
+func Unmarshal{{.Name}}(p *codec.Packer) (chain.Action, error) {
+	var {{.Var}} {{.Name}}
+{{- range .Unmarshal}}
+	{{.}}
+{{- end}}
+	return &{{.Var}}, p.Err()
+}
+
+// Parse{{.Name}}JSON decodes a [{{.Name}}] from JSON (like the arguments of
+// an RPC) and checks it like [Unmarshal{{.Name}}].
+func Parse{{.Name}}JSON(b []byte) (chain.Action, error) {
+	var {{.Var}} {{.Name}}
+	if err := json.Unmarshal(b, &{{.Var}}); err != nil {
+		return nil, err
+	}
+	p := codec.NewWriter({{.Var}}.Size(), consts.NetworkSizeLimit)
+	{{.Var}}.Marshal(p)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return Unmarshal{{.Name}}(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit))
+}
+`))
+
+type stubTemplate struct {
+	src     string
+	imports []string
+}
+
+// stubTemplates are formatted with the name of the action.
+var stubTemplates = map[string]*stubTemplate{
+	"GetTypeID": {
+		src: `func (*%[1]s) GetTypeID() uint8 {
+	// TODO: return the unique type ID of the action
+	return 0
+}
+`,
+	},
+	"ValidRange": {
+		src: `func (*%[1]s) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+`,
+		imports: []string{chainPath},
+	},
+	"ComputeUnits": {
+		src: `func (*%[1]s) ComputeUnits(chain.Rules) uint64 {
+	// TODO: return the compute units used by [Execute]
+	return 1
+}
+`,
+		imports: []string{chainPath},
+	},
+	"StateKeys": {
+		src: `func (*%[1]s) StateKeys(codec.Address, ids.ID) state.Keys {
+	// TODO: return the keys read and written by [Execute]
+	return state.Keys{}
+}
+`,
+		imports: []string{codecPath, idsPath, statePath},
+	},
+	"StateKeysMaxChunks": {
+		src: `func (*%[1]s) StateKeysMaxChunks() []uint16 {
+	// TODO: return the max chunks of the keys, in the order of [StateKeys]
+	return []uint16{}
+}
+`,
+	},
+	"Execute": {
+		src: `func (*%[1]s) Execute(
+	context.Context,
+	chain.Rules,
+	state.Mutable,
+	int64,
+	codec.Address,
+	ids.ID,
+) ([][]byte, error) {
+	// TODO: execute the action
+	return nil, nil
+}
+`,
+		imports: []string{"context", chainPath, codecPath, idsPath, statePath},
+	},
+}
+
+var testTemplate = template.Must(template.New("test").Parse(`
+func Test{{.Name}}Marshal(t *testing.T) {
+	require := require.New(t)
+
+	// TODO: test the edge cases of the fields
+	action := &{{.Name}}{
+{{- range .Samples}}
+		{{.}},
+{{- end}}
+	}
+	p := codec.NewWriter(action.Size(), consts.NetworkSizeLimit)
+	action.Marshal(p)
+	require.NoError(p.Err())
+	require.Equal(action.Size(), len(p.Bytes()))
+	parsed, err := Unmarshal{{.Name}}(codec.NewReader(p.Bytes(), consts.NetworkSizeLimit))
+	require.NoError(err)
+	require.Equal(action, parsed)
+
+	b, err := json.Marshal(action)
+	require.NoError(err)
+	parsed, err = Parse{{.Name}}JSON(b)
+	require.NoError(err)
+	require.Equal(action, parsed)
+}
+
+func Test{{.Name}}Execute(t *testing.T) {
+	t.Skip("TODO: test the execution of {{.Name}}")
+}
+`))
+
+var registryTemplate = template.Must(template.New("registry").Parse(`
+// RegisterActions registers the decoders of the generated actions with
+// [registry], in the order they are listed to actiongen.
+func RegisterActions(registry *codec.TypeParser[chain.Action]) error {
+	errs := &wrappers.Errs{}
+	errs.Add(
+{{- range .}}
+		registry.Register((&{{.}}{}).GetTypeID(), Unmarshal{{.}}),
+{{- end}}
+	)
+	return errs.Err
+}
+
+var jsonParsers = map[uint8]func([]byte) (chain.Action, error){
+{{- range .}}
+	(&{{.}}{}).GetTypeID(): Parse{{.}}JSON,
+{{- end}}
+}
+
+// ParseActionJSON decodes a generated action of type [typeID] from JSON.
+func ParseActionJSON(typeID uint8, b []byte) (chain.Action, error) {
+	parse, ok := jsonParsers[typeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d is unknown action type", chain.ErrInvalidObject, typeID)
+	}
+	return parse(b)
+}
+`))
+
+func genFile(pkgName string, a *action) []byte {
+	var (
+		recv     = receiver(a.name)
+		v        = variable(a.name)
+		sizes    = make([]string, 0, len(a.fields))
+		sizeRecv string
+		data     = struct {
+			Name        string
+			Var         string
+			Size        string
+			SizeRecv    string
+			MarshalRecv string
+			Marshal     []string
+			Unmarshal   []string
+		}{Name: a.name, Var: v}
+		imports = []string{"encoding/json", chainPath, codecPath, constsPath}
+	)
+	for _, f := range a.fields {
+		if f.kind.fixed() {
+			sizes = append(sizes, f.kind.size)
+		} else {
+			sizes = append(sizes, fmt.Sprintf(f.kind.size, recv+"."+f.name))
+			sizeRecv = recv + " "
+		}
+		data.Marshal = append(data.Marshal, fmt.Sprintf("p.%s(%s.%s)", f.kind.pack, recv, f.name))
+		data.Unmarshal = append(data.Unmarshal, fmt.Sprintf(f.kind.unpack, v+"."+f.name, f.required, f.limit))
+		imports = append(imports, f.kind.imports...)
+	}
+	data.Size = "0"
+	if len(sizes) > 0 {
+		data.Size = strings.Join(sizes, " + ")
+	}
+	data.SizeRecv = sizeRecv
+	if len(a.fields) > 0 {
+		data.MarshalRecv = recv + " "
+	}
+	return render(pkgName, true, imports, genTemplate, data)
+}
+
+func stubsFile(pkgName string, a *action) []byte {
+	var (
+		imports []string
+		body    strings.Builder
+	)
+	for _, method := range a.stubs {
+		stub := stubTemplates[method]
+		imports = append(imports, stub.imports...)
+		body.WriteString("\n")
+		fmt.Fprintf(&body, stub.src, a.name)
+	}
+	var b bytes.Buffer
+	b.WriteString(packageClause(pkgName, false, imports))
+	b.WriteString(body.String())
+	return b.Bytes()
+}
+
+func testFile(pkgName string, a *action) []byte {
+	imports := []string{"encoding/json", "testing", requirePath, codecPath, constsPath}
+	samples := make([]string, 0, len(a.fields))
+	for _, f := range a.fields {
+		samples = append(samples, f.name+": "+f.kind.sample)
+		imports = append(imports, f.kind.imports...)
+	}
+	return render(pkgName, false, imports, testTemplate, struct {
+		Name    string
+		Samples []string
+	}{a.name, samples})
+}
+
+func registryGenFile(pkgName string, actions []*action) []byte {
+	names := make([]string, 0, len(actions))
+	for _, a := range actions {
+		names = append(names, a.name)
+	}
+	imports := []string{"fmt", wrappersPath, chainPath, codecPath}
+	return render(pkgName, true, imports, registryTemplate, names)
+}
+
+func render(pkgName string, generated bool, imports []string, t *template.Template, data any) []byte {
+	var b bytes.Buffer
+	b.WriteString(packageClause(pkgName, generated, imports))
+	if err := t.Execute(&b, data); err != nil {
+		// Templates are only executed with the data they are written for.
+		panic(err)
+	}
+	return b.Bytes()
+}
+
+// packageClause returns the header of a file importing [imports], grouped
+// like gci does.
+func packageClause(pkgName string, generated bool, imports []string) string {
+	var b strings.Builder
+	if generated {
+		b.WriteString(header)
+	}
+	fmt.Fprintf(&b, "package %s\n", pkgName)
+
+	var groups [3][]string
+	seen := map[string]bool{}
+	for _, imp := range imports {
+		if seen[imp] {
+			continue
+		}
+		seen[imp] = true
+		switch {
+		case !strings.Contains(strings.Split(imp, "/")[0], "."):
+			groups[0] = append(groups[0], imp)
+		case strings.HasPrefix(imp, localPrefix):
+			groups[2] = append(groups[2], imp)
+		default:
+			groups[1] = append(groups[1], imp)
+		}
+	}
+	if len(seen) == 0 {
+		return b.String()
+	}
+	b.WriteString("\nimport (\n")
+	first := true
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+		sort.Strings(group)
+		for _, imp := range group {
+			fmt.Fprintf(&b, "\t%q\n", imp)
+		}
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// receiver returns the receiver name of the methods of [name] (which must
+// not shadow the packer).
+func receiver(name string) string {
+	r := string(unicode.ToLower([]rune(name)[0]))
+	if r == "p" {
+		return "a"
+	}
+	return r
+}
+
+// variable returns the name of the local variables of type [name].
+func variable(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	v := string(runes)
+	if token.IsKeyword(v) || v == "p" || v == "b" {
+		return "action"
+	}
+	return v
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+const MaxMemoSize = 256
+
+type MintAsset struct {
+	To    codec.Address `json:"to"`
+	Asset ids.ID        `json:"asset" hypersdk:"required"`
+	Value uint64        `json:"value" hypersdk:"required"`
+	Name  string        `json:"name"`
+	Memo  []byte        `json:"memo" hypersdk:"max=MaxMemoSize"`
+}
+
+func (*MintAsset) GetTypeID() uint8 {
+	return 0
+}
+
+type Ping struct{}
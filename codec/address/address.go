@@ -0,0 +1,186 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package address encodes [codec.Address]es as bech32m strings with a
+// human-readable part (HRP) chosen by each VM.
+//
+// Addresses encoded with bech32 (as done by earlier versions of the hypersdk)
+// are still parsed, so existing configs and genesis files remain valid.
+package address
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+const (
+	charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// These consts are pulled from BIP-173: https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+	fromBits      = 8
+	toBits        = 5
+	separatorLen  = 1
+	checksumLen   = 6
+	maxBech32Size = 90
+
+	// dataLen is the number of characters used to encode an address
+	dataLen = (codec.AddressLen*fromBits + toBits - 1) / toBits
+
+	// MaxHRPLen is the longest HRP an address can be encoded with
+	MaxHRPLen = maxBech32Size - separatorLen - dataLen - checksumLen
+)
+
+var (
+	ErrInvalidHRP       = errors.New("invalid hrp")
+	ErrIncorrectHRP     = errors.New("incorrect hrp")
+	ErrMissingSeparator = errors.New("missing separator")
+	ErrMixedCase        = errors.New("address mixes upper and lower case")
+	ErrInvalidCharacter = errors.New("invalid character")
+	ErrInvalidChecksum  = errors.New("invalid checksum")
+	ErrInvalidLength    = errors.New("invalid address length")
+	ErrUnknownType      = errors.New("unknown address type")
+)
+
+// Format encodes and parses the addresses of a VM.
+type Format struct {
+	hrp   string
+	types map[uint8]string
+}
+
+// New returns a [Format] for addresses with [hrp]. If [types] is not empty,
+// [Parse] only accepts addresses whose first byte is one of its keys (the
+// values are used in errors and by [Format.TypeName]).
+func New(hrp string, types map[uint8]string) (*Format, error) {
+	if len(hrp) == 0 || len(hrp) > MaxHRPLen {
+		return nil, fmt.Errorf("%w: length must be between 1 and %d", ErrInvalidHRP, MaxHRPLen)
+	}
+	for _, c := range hrp {
+		if c < 33 || c > 126 || (c >= 'A' && c <= 'Z') {
+			return nil, fmt.Errorf("%w: %q must be lowercase printable ASCII", ErrInvalidHRP, hrp)
+		}
+	}
+	return &Format{hrp: hrp, types: maps.Clone(types)}, nil
+}
+
+// MustNew returns a [Format] for [hrp] and [types] or panics.
+func MustNew(hrp string, types map[uint8]string) *Format {
+	f, err := New(hrp, types)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (f *Format) HRP() string {
+	return f.hrp
+}
+
+// Encode returns the bech32m encoding of [addr].
+func (f *Format) Encode(addr codec.Address) string {
+	s, err := Encode(f.hrp, addr)
+	if err != nil {
+		// [New] ensures that [f.hrp] is valid
+		panic(err)
+	}
+	return s
+}
+
+// Parse returns the address encoded by [s], which must use the HRP of [f]
+// (and one of its types).
+func (f *Format) Parse(s string) (codec.Address, error) {
+	hrp, addr, err := Decode(s)
+	if err != nil {
+		return codec.EmptyAddress, err
+	}
+	if hrp != f.hrp {
+		return codec.EmptyAddress, fmt.Errorf("%w: expected %q but found %q", ErrIncorrectHRP, f.hrp, hrp)
+	}
+	if len(f.types) > 0 {
+		if _, ok := f.types[addr[0]]; !ok {
+			names := maps.Values(f.types)
+			slices.Sort(names)
+			return codec.EmptyAddress, fmt.Errorf("%w: %d (expected %s)", ErrUnknownType, addr[0], strings.Join(names, ", "))
+		}
+	}
+	return addr, nil
+}
+
+// TypeName returns the name of the type of [addr] (if it is one of the types
+// of [f]).
+func (f *Format) TypeName(addr codec.Address) (string, bool) {
+	name, ok := f.types[addr[0]]
+	return name, ok
+}
+
+// Encode returns the bech32m encoding of [addr] with [hrp].
+func Encode(hrp string, addr codec.Address) (string, error) {
+	if len(hrp) > MaxHRPLen {
+		return "", fmt.Errorf("%w: max length is %d", ErrInvalidHRP, MaxHRPLen)
+	}
+	data, err := bech32.ConvertBits(addr[:], fromBits, toBits, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.EncodeM(hrp, data)
+}
+
+// Decode returns the HRP and address encoded by [s] (with bech32m or
+// bech32). Errors describe what is wrong with [s], so they can be shown to
+// users.
+func Decode(s string) (string, codec.Address, error) {
+	hrp, data, _, err := bech32.DecodeGeneric(s)
+	if err != nil {
+		return "", codec.EmptyAddress, describe(s, err)
+	}
+	b, err := bech32.ConvertBits(data, toBits, fromBits, false)
+	if err != nil || len(b) != codec.AddressLen {
+		return "", codec.EmptyAddress, fmt.Errorf("%w: expected %d characters after the separator", ErrInvalidLength, dataLen+checksumLen)
+	}
+	return hrp, codec.Address(b), nil
+}
+
+// FromPublicKey returns the address of [publicKey] for the auth [typeID].
+func FromPublicKey(typeID uint8, publicKey []byte) codec.Address {
+	return codec.CreateAddress(typeID, hashing.ComputeHash256Array(publicKey))
+}
+
+// describe converts an error of the bech32 library into one of the errors of
+// this package.
+func describe(s string, err error) error {
+	var (
+		nonCharset       bech32.ErrNonCharsetChar
+		invalidCharacter bech32.ErrInvalidCharacter
+		invalidChecksum  bech32.ErrInvalidChecksum
+		invalidSeparator bech32.ErrInvalidSeparatorIndex
+	)
+	switch {
+	case errors.As(err, &bech32.ErrMixedCase{}):
+		return ErrMixedCase
+	case errors.As(err, &nonCharset):
+		// Only the characters after the separator must be in the charset
+		sep := strings.LastIndexByte(s, '1')
+		i := strings.IndexFunc(strings.ToLower(s[sep+1:]), func(c rune) bool {
+			return !strings.ContainsRune(charset, c)
+		})
+		return fmt.Errorf("%w: %q at position %d (1, b, i, and o are never used)", ErrInvalidCharacter, rune(nonCharset), sep+1+i)
+	case errors.As(err, &invalidCharacter):
+		return fmt.Errorf("%w: %q", ErrInvalidCharacter, rune(invalidCharacter))
+	case errors.As(err, &invalidChecksum):
+		return fmt.Errorf("%w: a character is likely mistyped", ErrInvalidChecksum)
+	case errors.As(err, &invalidSeparator):
+		if !strings.Contains(s, "1") {
+			return ErrMissingSeparator
+		}
+		return fmt.Errorf("%w: expected %d characters after the separator", ErrInvalidLength, dataLen+checksumLen)
+	default:
+		return fmt.Errorf("%w: %w", ErrInvalidLength, err)
+	}
+}
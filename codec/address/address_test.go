@@ -0,0 +1,102 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avaaddress "github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+const hrp = "blah"
+
+var types = map[uint8]string{0: "ed25519", 1: "secp256r1"}
+
+func TestEncodeParse(t *testing.T) {
+	require := require.New(t)
+
+	f, err := New(hrp, types)
+	require.NoError(err)
+	addr := codec.CreateAddress(1, ids.GenerateTestID())
+	s := f.Encode(addr)
+	require.True(strings.HasPrefix(s, hrp+"1"))
+	parsed, err := f.Parse(s)
+	require.NoError(err)
+	require.Equal(addr, parsed)
+	name, ok := f.TypeName(parsed)
+	require.True(ok)
+	require.Equal("secp256r1", name)
+
+	// Addresses are case-insensitive (but can't mix cases)
+	parsed, err = f.Parse(strings.ToUpper(s))
+	require.NoError(err)
+	require.Equal(addr, parsed)
+	_, err = f.Parse(strings.ToUpper(s[:10]) + s[10:])
+	require.ErrorIs(err, ErrMixedCase)
+
+	// Addresses encoded with bech32 are still accepted
+	legacy, err := avaaddress.FormatBech32(hrp, addr[:])
+	require.NoError(err)
+	require.NotEqual(s, legacy)
+	parsed, err = f.Parse(legacy)
+	require.NoError(err)
+	require.Equal(addr, parsed)
+}
+
+func TestParseErrors(t *testing.T) {
+	f := MustNew(hrp, types)
+	s := f.Encode(codec.CreateAddress(0, ids.GenerateTestID()))
+
+	// Swap the last character of the data for another character
+	last := s[len(s)-1]
+	swapped := charset[(strings.IndexByte(charset, last)+1)%len(charset)]
+
+	tests := []struct {
+		name string
+		s    string
+		err  error
+	}{
+		{"wrong hrp", MustNew("test", nil).Encode(codec.CreateAddress(0, ids.Empty)), ErrIncorrectHRP},
+		{"unknown type", f.Encode(codec.CreateAddress(2, ids.Empty)), ErrUnknownType},
+		{"mistyped character", s[:len(s)-1] + string(swapped), ErrInvalidChecksum},
+		{"excluded character", s[:len(s)-1] + "b", ErrInvalidCharacter},
+		{"truncated", s[:len(s)-2], ErrInvalidChecksum},
+		{"no separator", "blahblah", ErrMissingSeparator},
+		{"empty", "", ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.Parse(tt.s)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	require := require.New(t)
+
+	_, err := New("", nil)
+	require.ErrorIs(err, ErrInvalidHRP)
+	_, err = New("Upper", nil)
+	require.ErrorIs(err, ErrInvalidHRP)
+	_, err = New(strings.Repeat("a", MaxHRPLen+1), nil)
+	require.ErrorIs(err, ErrInvalidHRP)
+	f, err := New(strings.Repeat("a", MaxHRPLen), nil)
+	require.NoError(err)
+	require.Len(f.Encode(codec.EmptyAddress), maxBech32Size)
+}
+
+func TestFromPublicKey(t *testing.T) {
+	require := require.New(t)
+
+	pk := []byte{1, 2, 3}
+	addr := FromPublicKey(2, pk)
+	require.Equal(uint8(2), addr[0])
+	require.NotEqual(addr, FromPublicKey(1, pk))
+}
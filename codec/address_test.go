@@ -4,45 +4,23 @@
 package codec
 
 import (
-	"bytes"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/btcsuite/btcd/btcutil/bech32"
 	"github.com/stretchr/testify/require"
 )
 
-const hrp = "blah"
-
 func TestIDAddress(t *testing.T) {
 	require := require.New(t)
 
 	id := ids.GenerateTestID()
-	addrBytes := CreateAddress(0, id)
-	addr, err := AddressBech32(hrp, addrBytes)
-	require.NoError(err)
+	addr := CreateAddress(1, id)
+	require.Equal(uint8(1), addr[0])
+	require.Equal(id[:], addr[1:])
 
-	sb, err := ParseAddressBech32(hrp, addr)
+	parsed, err := ToAddress(addr[:])
 	require.NoError(err)
-	require.True(bytes.Equal(addrBytes[:], sb[:]))
-}
-
-func TestInvalidAddressHRP(t *testing.T) {
-	require := require.New(t)
-	addr := "blah1859dz2uwazfgahey3j53ef2kqrans0c8cv4l78tda3rjkfw0txns8u2e8k"
-
-	_, err := ParseAddressBech32("test", addr)
-	require.ErrorIs(err, ErrIncorrectHRP)
-}
-
-func TestInvalidAddressChecksum(t *testing.T) {
-	require := require.New(t)
-	addr := "blah1859dz2uwazfgahey3j53ef2kqrans0c8cv4l78tda3rjkfw0txns8u2e7k"
-
-	_, err := ParseAddressBech32(hrp, addr)
-	require.ErrorIs(err, bech32.ErrInvalidChecksum{
-		Expected:  "8u2e8k",
-		ExpectedM: "8u2e8kjq64z5",
-		Actual:    "8u2e7k",
-	})
+	require.Equal(addr, parsed)
+	_, err = ToAddress(addr[1:])
+	require.Error(err)
 }
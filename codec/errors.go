@@ -6,11 +6,11 @@ package codec
 import "errors"
 
 var (
-	ErrTooManyItems       = errors.New("too many items")
-	ErrDuplicateItem      = errors.New("duplicate item")
-	ErrFieldNotPopulated  = errors.New("field is not populated")
-	ErrInvalidBitset      = errors.New("invalid bitset")
-	ErrIncorrectHRP       = errors.New("incorrect hrp")
-	ErrInsufficientLength = errors.New("insufficient length")
-	ErrInvalidSize        = errors.New("invalid size")
+	ErrTooManyItems      = errors.New("too many items")
+	ErrDuplicateItem     = errors.New("duplicate item")
+	ErrUnknownItem       = errors.New("unknown item")
+	ErrInvalidActivation = errors.New("invalid activation")
+	ErrFieldNotPopulated = errors.New("field is not populated")
+	ErrInvalidBitset     = errors.New("invalid bitset")
+	ErrInvalidSize       = errors.New("invalid size")
 )
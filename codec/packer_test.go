@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/window"
 )
 
@@ -135,3 +136,66 @@ func TestNewReader(t *testing.T) {
 	require.Zero(rp.UnpackUint64(true), "Reader unpacked correctly.")
 	require.ErrorIs(rp.Err(), wrappers.ErrInsufficientLength)
 }
+
+// FuzzPacker unpacks [raw] as the sequence of values selected by [ops] and
+// requires that packing the unpacked values reproduces the bytes that were
+// read.
+func FuzzPacker(f *testing.F) {
+	wp := NewWriter(0, consts.NetworkSizeLimit)
+	wp.PackBool(true)
+	wp.PackByte(7)
+	wp.PackID(ids.GenerateTestID())
+	wp.PackAddress(CreateAddress(1, ids.GenerateTestID()))
+	wp.PackBytes([]byte("bytes"))
+	wp.PackUint64(1)
+	wp.PackInt64(-1)
+	wp.PackInt(900)
+	wp.PackWindow(window.Window{1, 2, 3})
+	wp.PackString(TestString)
+	f.Add([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, wp.Bytes())
+	f.Add([]byte{4, 4, 9}, []byte{})
+
+	f.Fuzz(func(t *testing.T, ops []byte, raw []byte) {
+		require := require.New(t)
+
+		rp := NewReader(raw, consts.NetworkSizeLimit)
+		wp := NewWriter(len(raw), consts.NetworkSizeLimit)
+		for _, op := range ops {
+			switch op % 10 {
+			case 0:
+				wp.PackBool(rp.UnpackBool())
+			case 1:
+				wp.PackByte(rp.UnpackByte())
+			case 2:
+				var id ids.ID
+				rp.UnpackID(false, &id)
+				wp.PackID(id)
+			case 3:
+				var addr Address
+				rp.UnpackAddress(&addr)
+				wp.PackAddress(addr)
+			case 4:
+				var b []byte
+				rp.UnpackBytes(-1, false, &b)
+				wp.PackBytes(b)
+			case 5:
+				wp.PackUint64(rp.UnpackUint64(false))
+			case 6:
+				wp.PackInt64(rp.UnpackInt64(false))
+			case 7:
+				wp.PackInt(rp.UnpackInt(false))
+			case 8:
+				var w window.Window
+				rp.UnpackWindow(&w)
+				wp.PackWindow(w)
+			case 9:
+				wp.PackString(rp.UnpackString(false))
+			}
+			if rp.Err() != nil {
+				return
+			}
+		}
+		require.NoError(wp.Err())
+		require.Equal(raw[:rp.Offset()], wp.Bytes())
+	})
+}
@@ -9,6 +9,10 @@ import (
 
 type decoder[T any] struct {
 	f func(*Packer) (T, error)
+
+	// activation and deactivation are -1 if not set (see [SetActivation])
+	activation   int64
+	deactivation int64
 }
 
 // The number of types is limited to 255.
@@ -35,10 +39,66 @@ func (p *TypeParser[T]) Register(id uint8, f func(*Packer) (T, error)) error {
 	if _, ok := p.indexToDecoder[id]; ok {
 		return ErrDuplicateItem
 	}
-	p.indexToDecoder[id] = &decoder[T]{f: f}
+	p.indexToDecoder[id] = &decoder[T]{f: f, activation: -1, deactivation: -1}
 	return nil
 }
 
+// SetActivation sets the timestamps (in ms) from which the type registered
+// at [id] is active ([activation]) and from which it is no longer active
+// ([deactivation]), so forks can introduce or retire types. -1 means no
+// activation or deactivation.
+//
+// [TypeParser] doesn't enforce activation itself: parsers of objects with a
+// timestamp (like transactions) reject types that are not [Active] at it.
+func (p *TypeParser[T]) SetActivation(id uint8, activation int64, deactivation int64) error {
+	d, ok := p.indexToDecoder[id]
+	if !ok {
+		return ErrUnknownItem
+	}
+	if activation >= 0 && deactivation >= 0 && deactivation <= activation {
+		return ErrInvalidActivation
+	}
+	d.activation = activation
+	d.deactivation = deactivation
+	return nil
+}
+
+// Activation returns the timestamps set by [SetActivation] for [id] (-1, -1
+// if the type is always active or not registered).
+func (p *TypeParser[T]) Activation(id uint8) (activation int64, deactivation int64) {
+	d, ok := p.indexToDecoder[id]
+	if !ok {
+		return -1, -1
+	}
+	return d.activation, d.deactivation
+}
+
+// Active returns whether the type registered at [id] is active at
+// [timestamp].
+func (p *TypeParser[T]) Active(id uint8, timestamp int64) bool {
+	d, ok := p.indexToDecoder[id]
+	if !ok {
+		return false
+	}
+	if d.activation >= 0 && timestamp < d.activation {
+		return false
+	}
+	return d.deactivation < 0 || timestamp < d.deactivation
+}
+
+// Clone returns a copy of [p] that can be modified (like with
+// [SetActivation]) without modifying [p].
+func (p *TypeParser[T]) Clone() *TypeParser[T] {
+	c := NewTypeParser[T]()
+	for k, v := range p.typeToIndex {
+		c.typeToIndex[k] = v
+	}
+	for id, d := range p.indexToDecoder {
+		c.indexToDecoder[id] = &decoder[T]{f: d.f, activation: d.activation, deactivation: d.deactivation}
+	}
+	return c
+}
+
 // LookupIndex returns the decoder function and success of lookup of [index]
 // from Typeparser [p].
 func (p *TypeParser[T]) LookupIndex(index uint8) (func(*Packer) (T, error), bool) {
@@ -86,3 +86,34 @@ func TestTypeParser(t *testing.T) {
 		require.ErrorIs(tp.Register(uint8(4), nil), ErrTooManyItems)
 	})
 }
+
+func TestTypeParserActivation(t *testing.T) {
+	require := require.New(t)
+
+	tp := NewTypeParser[Blah]()
+	id := (&Blah1{}).GetTypeID()
+	require.ErrorIs(tp.SetActivation(id, 10, 20), ErrUnknownItem)
+	require.False(tp.Active(id, 0))
+	require.NoError(tp.Register(id, nil))
+
+	// Types are always active by default
+	activation, deactivation := tp.Activation(id)
+	require.Equal(int64(-1), activation)
+	require.Equal(int64(-1), deactivation)
+	require.True(tp.Active(id, 0))
+
+	require.ErrorIs(tp.SetActivation(id, 20, 20), ErrInvalidActivation)
+	require.NoError(tp.SetActivation(id, 10, 20))
+	require.False(tp.Active(id, 9))
+	require.True(tp.Active(id, 10))
+	require.True(tp.Active(id, 19))
+	require.False(tp.Active(id, 20))
+
+	// Modifying a clone doesn't modify the original
+	clone := tp.Clone()
+	require.NoError(clone.SetActivation(id, -1, 20))
+	require.True(clone.Active(id, 0))
+	require.False(tp.Active(id, 0))
+	_, ok := clone.LookupIndex(id)
+	require.True(ok)
+}
@@ -0,0 +1,463 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package config loads the JSON config of a node strictly: fields that the
+// config doesn't have (like a misspelled name), values of the wrong type, and
+// values outside of the range of a field are rejected (with the path of the
+// field) instead of silently falling back to the default.
+//
+// Each struct in a config is a section named by the path of its JSON names
+// (like "chunkConfig"), and any field can be overridden with an environment
+// variable named by its prefix and path (like HYPERSDK_CHUNK_CONFIG_MAX_TXS).
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrUnknownField = errors.New("unknown field")
+	ErrInvalidType  = errors.New("invalid type")
+	ErrOutOfRange   = errors.New("out of range")
+	ErrInvalidEnv   = errors.New("invalid environment variable")
+	ErrInvalidBound = errors.New("invalid bound")
+)
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	verifierType    = reflect.TypeOf((*Verifier)(nil)).Elem()
+	durationType    = reflect.TypeOf(time.Duration(0))
+)
+
+// Verifier is implemented by sections of a config that check their fields
+// against each other. It is called after all fields are loaded.
+type Verifier interface {
+	Verify() error
+}
+
+// Load unmarshals [b] into [v] (a pointer to a struct populated with the
+// defaults), applies the environment variables that start with [envPrefix]
+// (if it is not empty), and checks the result.
+//
+// Numeric fields can be bounded with the "min" and "max" struct tags (a
+// [time.Duration] bound is parsed with [time.ParseDuration]).
+func Load(b []byte, v any, envPrefix string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a pointer to a struct", ErrInvalidType, v)
+	}
+	if len(b) > 0 {
+		if err := Decode(b, v); err != nil {
+			return err
+		}
+	}
+	if len(envPrefix) > 0 {
+		if err := applyEnv(rv.Elem(), envPrefix); err != nil {
+			return err
+		}
+	}
+	return check(rv.Elem(), "")
+}
+
+// Decode unmarshals [b] into [v] and returns an error naming every field of
+// [b] that [v] doesn't have (and the closest field it does have).
+func Decode(b []byte, v any) error {
+	if errs := unknownFields(b, reflect.TypeOf(v), ""); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("%w: %s: expected %s but got %s", ErrInvalidType, typeErr.Field, typeErr.Type, typeErr.Value)
+		}
+		return err
+	}
+	return nil
+}
+
+// field is a field of a struct that is (un)marshalled by encoding/json.
+type field struct {
+	name  string
+	index []int
+	typ   reflect.Type
+	tag   reflect.StructTag
+}
+
+// fields returns the fields of [t] as encoding/json sees them (fields of
+// embedded structs are promoted).
+func fields(t reflect.Type) []*field {
+	var fs []*field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		ft := sf.Type
+		if sf.Anonymous && len(name) == 0 {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, f := range fields(ft) {
+					f.index = append([]int{i}, f.index...)
+					fs = append(fs, f)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = sf.Name
+		}
+		fs = append(fs, &field{name: name, index: []int{i}, typ: ft, tag: sf.Tag})
+	}
+	return fs
+}
+
+// leaf returns true if [t] is not checked field by field.
+func leaf(t reflect.Type) bool {
+	return t.Implements(unmarshalerType) || reflect.PointerTo(t).Implements(unmarshalerType)
+}
+
+// unknownFields returns an error for every field of [b] that [t] doesn't
+// have. Values of the wrong type are skipped (they are reported by
+// [json.Unmarshal] with the type expected).
+func unknownFields(b []byte, t reflect.Type, path string) []error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if leaf(t) {
+		return nil
+	}
+	var errs []error
+	switch t.Kind() {
+	case reflect.Struct:
+		var values map[string]json.RawMessage
+		if json.Unmarshal(b, &values) != nil {
+			return nil
+		}
+		fs := fields(t)
+		for _, name := range sortedKeys(values) {
+			value := values[name]
+			// encoding/json matches names case-insensitively
+			var match *field
+			for _, f := range fs {
+				if strings.EqualFold(f.name, name) {
+					match = f
+					break
+				}
+			}
+			if match == nil {
+				errs = append(errs, unknownField(join(path, name), name, fs))
+				continue
+			}
+			errs = append(errs, unknownFields(value, match.typ, join(path, match.name))...)
+		}
+	case reflect.Slice, reflect.Array:
+		var values []json.RawMessage
+		if json.Unmarshal(b, &values) != nil {
+			return nil
+		}
+		for i, value := range values {
+			errs = append(errs, unknownFields(value, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if json.Unmarshal(b, &values) != nil {
+			return nil
+		}
+		for _, key := range sortedKeys(values) {
+			errs = append(errs, unknownFields(values[key], t.Elem(), join(path, key))...)
+		}
+	}
+	return errs
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func unknownField(path string, name string, fs []*field) error {
+	best, bestDistance := "", len(name)/2+1
+	for _, f := range fs {
+		if d := distance(strings.ToLower(name), strings.ToLower(f.name)); d < bestDistance {
+			best, bestDistance = f.name, d
+		}
+	}
+	if len(best) == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownField, path)
+	}
+	return fmt.Errorf("%w: %s (did you mean %q?)", ErrUnknownField, path, best)
+}
+
+// distance returns the Levenshtein distance between [a] and [b].
+func distance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func join(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+// EnvName returns the environment variable that overrides the field at
+// [path] (like "chunkConfig.maxTxs").
+func EnvName(prefix string, path string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for _, name := range strings.Split(path, ".") {
+		sb.WriteByte('_')
+		rs := []rune(name)
+		for i, r := range rs {
+			// Words start at an uppercase letter after a lowercase one
+			// or at the last letter of an acronym ("ethRPCEnabled" is
+			// ETH_RPC_ENABLED)
+			if i > 0 && unicode.IsUpper(r) &&
+				(!unicode.IsUpper(rs[i-1]) || (i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return sb.String()
+}
+
+func applyEnv(v reflect.Value, prefix string) error {
+	return walk(v, "", func(fv reflect.Value, path string, _ reflect.StructTag) (bool, error) {
+		if fv.Kind() == reflect.Struct && !leaf(fv.Type()) {
+			return true, nil
+		}
+		name := EnvName(prefix, path)
+		s, ok := os.LookupEnv(name)
+		if !ok {
+			return false, nil
+		}
+		if err := setEnv(fv, s); err != nil {
+			return false, fmt.Errorf("%w: %s: %w", ErrInvalidEnv, name, err)
+		}
+		return false, nil
+	})
+}
+
+// setEnv sets [v] to [s]. Strings are used as is, durations can be formatted
+// like "5s", and other values are parsed as JSON (or as a JSON string).
+func setEnv(v reflect.Value, s string) error {
+	switch {
+	case v.Kind() == reflect.String && !leaf(v.Type()):
+		v.SetString(s)
+		return nil
+	case v.Type() == durationType:
+		if d, err := time.ParseDuration(s); err == nil {
+			v.SetInt(int64(d))
+			return nil
+		}
+	}
+	ptr := reflect.New(v.Type())
+	if err := json.Unmarshal([]byte(s), ptr.Interface()); err != nil {
+		if err := json.Unmarshal([]byte(strconv.Quote(s)), ptr.Interface()); err != nil {
+			return err
+		}
+	}
+	v.Set(ptr.Elem())
+	return nil
+}
+
+// walk calls [f] with every field of the struct [v] (recursing into the
+// fields of structs if [f] returns true).
+func walk(v reflect.Value, path string, f func(reflect.Value, string, reflect.StructTag) (bool, error)) error {
+	for _, fd := range fields(v.Type()) {
+		fv, ok := fieldByIndex(v, fd.index)
+		if !ok {
+			continue
+		}
+		fpath := join(path, fd.name)
+		recurse, err := f(fv, fpath, fd.tag)
+		if err != nil {
+			return err
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if recurse && fv.Kind() == reflect.Struct {
+			if err := walk(fv, fpath, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldByIndex is [reflect.Value.FieldByIndex] that returns false instead of
+// panicking on a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// check returns an error for every field of [v] out of its bounds and calls
+// [Verifier.Verify] on [v] and its sections.
+func check(v reflect.Value, path string) error {
+	var errs []error
+	if err := walk(v, path, func(fv reflect.Value, fpath string, tag reflect.StructTag) (bool, error) {
+		if err := checkBounds(fv, fpath, tag); err != nil {
+			if errors.Is(err, ErrInvalidBound) {
+				return false, err
+			}
+			errs = append(errs, err)
+		}
+		if fv.Kind() == reflect.Struct && !leaf(fv.Type()) {
+			if err := verify(fv, fpath); err != nil {
+				errs = append(errs, err)
+			}
+			return true, nil
+		}
+		return false, nil
+	}); err != nil {
+		return err
+	}
+	if err := verify(v, path); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func verify(v reflect.Value, path string) error {
+	var verifier Verifier
+	switch {
+	case v.CanAddr() && v.Addr().Type().Implements(verifierType):
+		verifier = v.Addr().Interface().(Verifier)
+	case v.Type().Implements(verifierType):
+		verifier = v.Interface().(Verifier)
+	default:
+		return nil
+	}
+	if err := verifier.Verify(); err != nil {
+		if len(path) == 0 {
+			return err
+		}
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func checkBounds(v reflect.Value, path string, tag reflect.StructTag) error {
+	for _, bound := range []string{"min", "max"} {
+		s, ok := tag.Lookup(bound)
+		if !ok {
+			continue
+		}
+		cmp, err := compare(v, s)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s %q: %w", ErrInvalidBound, path, bound, s, err)
+		}
+		if (bound == "min" && cmp < 0) || (bound == "max" && cmp > 0) {
+			limit := "least"
+			if bound == "max" {
+				limit = "most"
+			}
+			return fmt.Errorf("%w: %s must be at %s %s but is %s", ErrOutOfRange, path, limit, s, format(v))
+		}
+	}
+	return nil
+}
+
+// compare returns -1, 0, or 1 if [v] is less than, equal to, or greater than
+// the bound [s].
+func compare(v reflect.Value, s string) (int, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var b int64
+		if v.Type() == durationType {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return 0, err
+			}
+			b = int64(d)
+		} else {
+			var err error
+			b, err = strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return compareOrdered(v.Int(), b), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(v.Uint(), b), nil
+	case reflect.Float32, reflect.Float64:
+		b, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(v.Float(), b), nil
+	default:
+		return 0, fmt.Errorf("%s is not a number", v.Type())
+	}
+}
+
+func compareOrdered[T int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func format(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	return fmt.Sprint(v.Interface())
+}
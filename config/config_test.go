@@ -0,0 +1,156 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+var errNoPeers = errors.New("no peers")
+
+type testSection struct {
+	MaxTxs   int           `json:"maxTxs" min:"1"`
+	Interval time.Duration `json:"interval" min:"10ms" max:"1m"`
+	Peers    []string      `json:"peers"`
+	Required bool          `json:"required"`
+}
+
+func (s *testSection) Verify() error {
+	if s.Required && len(s.Peers) == 0 {
+		return errNoPeers
+	}
+	return nil
+}
+
+type testConfig struct {
+	MempoolSize   int            `json:"mempoolSize" min:"1"`
+	ChunkConfig   testSection    `json:"chunkConfig"`
+	Sections      []testSection  `json:"sections"`
+	LogLevel      logging.Level  `json:"logLevel"`
+	EthRPCEnabled bool           `json:"ethRPCEnabled"`
+	Config        map[string]any `json:"config"`
+}
+
+func newTestConfig() *testConfig {
+	return &testConfig{
+		MempoolSize: 2_048,
+		ChunkConfig: testSection{MaxTxs: 10, Interval: time.Second},
+		LogLevel:    logging.Info,
+	}
+}
+
+func TestLoad(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestConfig()
+	require.NoError(Load([]byte(`{"mempoolSize": 10, "chunkConfig": {"maxTxs": 5}, "logLevel": "debug", "config": {"anything": 1}}`), c, ""))
+	require.Equal(10, c.MempoolSize)
+	require.Equal(testSection{MaxTxs: 5, Interval: time.Second}, c.ChunkConfig)
+	require.Equal(logging.Debug, c.LogLevel)
+	require.Equal(map[string]any{"anything": float64(1)}, c.Config)
+
+	// Defaults are checked too
+	c = newTestConfig()
+	require.NoError(Load(nil, c, ""))
+	c.MempoolSize = 0
+	require.ErrorIs(Load(nil, c, ""), ErrOutOfRange)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    error
+		msg    string
+	}{
+		{
+			name:   "typo",
+			config: `{"mempolSize": 10}`,
+			err:    ErrUnknownField,
+			msg:    `unknown field: mempolSize (did you mean "mempoolSize"?)`,
+		},
+		{
+			name:   "nested typo",
+			config: `{"chunkConfig": {"maxTx": 10}}`,
+			err:    ErrUnknownField,
+			msg:    `unknown field: chunkConfig.maxTx (did you mean "maxTxs"?)`,
+		},
+		{
+			name:   "unknown field in slice",
+			config: `{"sections": [{}, {"unrelated": true}]}`,
+			err:    ErrUnknownField,
+			msg:    `unknown field: sections[1].unrelated`,
+		},
+		{
+			name:   "type mismatch",
+			config: `{"chunkConfig": {"maxTxs": "10"}}`,
+			err:    ErrInvalidType,
+			msg:    `invalid type: chunkConfig.maxTxs: expected int but got string`,
+		},
+		{
+			name:   "below min",
+			config: `{"chunkConfig": {"maxTxs": 0}}`,
+			err:    ErrOutOfRange,
+			msg:    `out of range: chunkConfig.maxTxs must be at least 1 but is 0`,
+		},
+		{
+			name:   "above max",
+			config: `{"chunkConfig": {"interval": 120000000000}}`,
+			err:    ErrOutOfRange,
+			msg:    `out of range: chunkConfig.interval must be at most 1m but is 2m0s`,
+		},
+		{
+			name:   "section verification",
+			config: `{"chunkConfig": {"required": true}}`,
+			err:    errNoPeers,
+			msg:    `chunkConfig: no peers`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Load([]byte(tt.config), newTestConfig(), "")
+			require.ErrorIs(t, err, tt.err)
+			require.EqualError(t, err, tt.msg)
+		})
+	}
+
+	// Names are matched case-insensitively (like encoding/json) and all
+	// unknown fields are reported
+	err := Load([]byte(`{"MempoolSize": 1, "a": 1, "b": 2}`), newTestConfig(), "")
+	require.EqualError(t, err, "unknown field: a\nunknown field: b")
+}
+
+func TestLoadEnv(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("TEST_MEMPOOL_SIZE", EnvName("TEST", "mempoolSize"))
+	require.Equal("TEST_CHUNK_CONFIG_MAX_TXS", EnvName("TEST", "chunkConfig.maxTxs"))
+	require.Equal("TEST_ETH_RPC_ENABLED", EnvName("TEST", "ethRPCEnabled"))
+
+	t.Setenv("TEST_MEMPOOL_SIZE", "32")
+	t.Setenv("TEST_CHUNK_CONFIG_INTERVAL", "500ms")
+	t.Setenv("TEST_CHUNK_CONFIG_PEERS", `["a", "b"]`)
+	t.Setenv("TEST_LOG_LEVEL", "warn")
+	t.Setenv("TEST_ETH_RPC_ENABLED", "true")
+	c := newTestConfig()
+	require.NoError(Load([]byte(`{"mempoolSize": 10}`), c, "TEST"))
+	require.Equal(32, c.MempoolSize)
+	require.Equal(500*time.Millisecond, c.ChunkConfig.Interval)
+	require.Equal([]string{"a", "b"}, c.ChunkConfig.Peers)
+	require.Equal(logging.Warn, c.LogLevel)
+	require.True(c.EthRPCEnabled)
+
+	// Overrides are checked like the config
+	t.Setenv("TEST_CHUNK_CONFIG_MAX_TXS", "0")
+	require.ErrorIs(Load(nil, newTestConfig(), "TEST"), ErrOutOfRange)
+	t.Setenv("TEST_CHUNK_CONFIG_MAX_TXS", "ten")
+	err := Load(nil, newTestConfig(), "TEST")
+	require.ErrorIs(err, ErrInvalidEnv)
+	require.ErrorContains(err, "TEST_CHUNK_CONFIG_MAX_TXS")
+}
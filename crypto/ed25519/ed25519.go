@@ -57,6 +57,15 @@ func GeneratePrivateKey() (PrivateKey, error) {
 	return PrivateKey(k), nil
 }
 
+// PrivateKeyFromSeed returns the Ed25519 PrivateKey derived from a 32 byte
+// seed (as defined in RFC 8032).
+func PrivateKeyFromSeed(seed []byte) (PrivateKey, error) {
+	if len(seed) != PrivateKeySeedLen {
+		return EmptyPrivateKey, crypto.ErrInvalidPrivateKey
+	}
+	return PrivateKey(ed25519.NewKeyFromSeed(seed)), nil
+}
+
 // PublicKey returns a PublicKey associated with the Ed25519 PrivateKey p.
 // The PublicKey is the last 32 bytes of p.
 func (p PrivateKey) PublicKey() PublicKey {
@@ -0,0 +1,119 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package hd derives keys from a BIP-39 mnemonic so that many accounts can be
+// recovered from a single backup.
+//
+// ed25519 keys are derived using SLIP-0010
+// (https://github.com/satoshilabs/slips/blob/master/slip-0010.md), which only
+// supports hardened derivation.
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+const (
+	// MnemonicEntropyBits is the amount of entropy in generated mnemonics
+	// (24 words).
+	MnemonicEntropyBits = 256
+
+	// HardenedOffset is added to an index to indicate hardened derivation.
+	HardenedOffset uint32 = 0x80000000
+
+	// Purpose and CoinType are the first components of [AccountPath]
+	// (BIP-44 with the Avalanche coin type).
+	Purpose  uint32 = 44
+	CoinType uint32 = 9000
+
+	ed25519Curve = "ed25519 seed"
+)
+
+var (
+	ErrInvalidMnemonic  = errors.New("invalid mnemonic")
+	ErrNonHardenedIndex = errors.New("ed25519 only supports hardened derivation")
+)
+
+// NewMnemonic returns a new random 24-word mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NormalizeMnemonic collapses whitespace and casing differences in
+// [mnemonic] (users often copy it from somewhere else).
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
+
+// ValidMnemonic returns true if [mnemonic] has a valid length and checksum.
+func ValidMnemonic(mnemonic string) bool {
+	return bip39.IsMnemonicValid(NormalizeMnemonic(mnemonic))
+}
+
+// Seed returns the BIP-39 seed of [mnemonic]. [passphrase] may be empty.
+func Seed(mnemonic string, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(NormalizeMnemonic(mnemonic), passphrase)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	return seed, nil
+}
+
+// AccountPath returns the hardened path m/44'/9000'/[account]'/0'/0'
+// of an account.
+func AccountPath(account uint32) []uint32 {
+	return []uint32{
+		Purpose + HardenedOffset,
+		CoinType + HardenedOffset,
+		account + HardenedOffset,
+		HardenedOffset,
+		HardenedOffset,
+	}
+}
+
+// DeriveED25519 derives the ed25519 private key at [path] from [seed].
+//
+// All indices in [path] must include [HardenedOffset].
+func DeriveED25519(seed []byte, path []uint32) (ed25519.PrivateKey, error) {
+	key, chainCode := hmacSplit([]byte(ed25519Curve), seed)
+	for _, index := range path {
+		if index < HardenedOffset {
+			return ed25519.EmptyPrivateKey, ErrNonHardenedIndex
+		}
+		data := make([]byte, 1+len(key)+4)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[1+len(key):], index)
+		key, chainCode = hmacSplit(chainCode, data)
+	}
+	return ed25519.PrivateKeyFromSeed(key)
+}
+
+// DeriveED25519Account derives the ed25519 private key of [account] (at
+// [AccountPath]) from [mnemonic].
+func DeriveED25519Account(mnemonic string, passphrase string, account uint32) (ed25519.PrivateKey, error) {
+	seed, err := Seed(mnemonic, passphrase)
+	if err != nil {
+		return ed25519.EmptyPrivateKey, err
+	}
+	return DeriveED25519(seed, AccountPath(account))
+}
+
+// hmacSplit returns the left and right halves of HMAC-SHA512([key], [data]).
+func hmacSplit(key []byte, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package hd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestSeed(t *testing.T) {
+	require := require.New(t)
+
+	// BIP-39 test vector
+	seed, err := Seed(testMnemonic, "TREZOR")
+	require.NoError(err)
+	require.Equal(
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		hex.EncodeToString(seed),
+	)
+
+	// Formatting differences are ignored
+	normalized, err := Seed("  ABANDON abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon about ", "TREZOR")
+	require.NoError(err)
+	require.Equal(seed, normalized)
+
+	// Bad checksum
+	_, err = Seed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+	require.ErrorIs(err, ErrInvalidMnemonic)
+}
+
+func TestNewMnemonic(t *testing.T) {
+	require := require.New(t)
+	mnemonic, err := NewMnemonic()
+	require.NoError(err)
+	require.True(ValidMnemonic(mnemonic))
+	require.Len(strings.Fields(mnemonic), 24)
+}
+
+func TestDeriveED25519(t *testing.T) {
+	require := require.New(t)
+
+	// SLIP-0010 test vector 1 for ed25519
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(err)
+	tests := []struct {
+		path []uint32
+		key  string
+	}{
+		{
+			path: []uint32{},
+			key:  "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		},
+		{
+			path: []uint32{HardenedOffset},
+			key:  "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		},
+		{
+			path: []uint32{HardenedOffset, 1 + HardenedOffset},
+			key:  "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+		},
+		{
+			path: []uint32{HardenedOffset, 1 + HardenedOffset, 2 + HardenedOffset},
+			key:  "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+		},
+	}
+	for _, tt := range tests {
+		priv, err := DeriveED25519(seed, tt.path)
+		require.NoError(err)
+		require.Equal(tt.key, hex.EncodeToString(priv[:ed25519.PrivateKeySeedLen]))
+	}
+
+	_, err = DeriveED25519(seed, []uint32{0})
+	require.ErrorIs(err, ErrNonHardenedIndex)
+
+	// Accounts are distinct
+	account0, err := DeriveED25519(seed, AccountPath(0))
+	require.NoError(err)
+	account1, err := DeriveED25519(seed, AccountPath(1))
+	require.NoError(err)
+	require.NotEqual(account0, account1)
+}
@@ -0,0 +1,538 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package devnet launches local networks running a HyperSDK VM with
+// avalanche-network-runner. It is used by the example CLIs and can be
+// embedded in the integration tests of any hypervm (see [StartTest]).
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+
+	runner_sdk "github.com/ava-labs/avalanche-network-runner/client"
+)
+
+const (
+	DefaultRunnerEndpoint = "0.0.0.0:12352"
+	DefaultValidators     = 5
+	DefaultLogLevel       = "info"
+
+	// DefaultSubnetConfig lets proposers build blocks as soon as they are
+	// ready.
+	DefaultSubnetConfig = `{"proposerMinBlockDelay":0,"proposerNumHistoricalBlocks":50000}`
+
+	dialTimeout     = 10 * time.Second
+	healthTimeout   = 2 * time.Minute
+	readyRetries    = 30
+	readyRetryDelay = time.Second
+	stopTimeout     = 2 * time.Minute
+
+	// trackSubnetsKey is the avalanchego flag of the subnets a node syncs.
+	trackSubnetsKey = "track-subnets"
+)
+
+var (
+	ErrMissingExecPath       = errors.New("missing avalanchego path")
+	ErrMissingPluginDir      = errors.New("missing plugin dir")
+	ErrMissingVMName         = errors.New("missing vm name")
+	ErrMissingGenesis        = errors.New("missing genesis")
+	ErrInvalidValidators     = errors.New("invalid number of validators")
+	ErrInvalidFundedKeys     = errors.New("invalid number of funded keys")
+	ErrMissingChain          = errors.New("chain was not created")
+	ErrNodeNotFound          = errors.New("node not found")
+	ErrInvalidRunnerEndpoint = errors.New("invalid runner endpoint")
+)
+
+// GenesisFunc returns the genesis of the VM where each of [funded] is
+// allocated a balance.
+type GenesisFunc func(funded []codec.Address) ([]byte, error)
+
+type Config struct {
+	// RunnerEndpoint is the gRPC endpoint of the avalanche-network-runner
+	// server.
+	RunnerEndpoint string
+	// RunnerPath is the avalanche-network-runner binary. If set, a server is
+	// started at [RunnerEndpoint] (with its gRPC gateway on the next port)
+	// and is stopped with the network. Otherwise, a server must already be
+	// running.
+	RunnerPath string
+
+	// ExecPath is the avalanchego binary and [PluginDir] must contain the VM
+	// plugin named by the ID of [VMName].
+	ExecPath  string
+	PluginDir string
+	VMName    string
+
+	Genesis      GenesisFunc
+	ChainConfig  []byte
+	SubnetConfig []byte
+
+	// Validators is the number of nodes validating the chain once [Start]
+	// returns.
+	Validators int
+	// InitialValidators is the number of those nodes that validate the chain
+	// when it is created (all of them if 0). The rest are added as subnet
+	// validators one at a time, [StakeInterval] apart.
+	InitialValidators int
+	StakeInterval     time.Duration
+
+	// FundedKeys is the number of ed25519 keys generated and passed to
+	// [Genesis].
+	FundedKeys int
+
+	LogLevel string
+	Log      logging.Logger
+}
+
+// DefaultConfig returns a [Config] for 5 validators and 1 funded key that
+// must be completed with paths, a VM name, and a genesis.
+func DefaultConfig() *Config {
+	return &Config{
+		RunnerEndpoint: DefaultRunnerEndpoint,
+		Validators:     DefaultValidators,
+		SubnetConfig:   []byte(DefaultSubnetConfig),
+		FundedKeys:     1,
+		LogLevel:       DefaultLogLevel,
+		Log:            logging.NoLog{},
+	}
+}
+
+func (c *Config) verify() error {
+	switch {
+	case len(c.ExecPath) == 0:
+		return ErrMissingExecPath
+	case len(c.PluginDir) == 0:
+		return ErrMissingPluginDir
+	case len(c.VMName) == 0:
+		return ErrMissingVMName
+	case c.Genesis == nil:
+		return ErrMissingGenesis
+	case c.Validators <= 0 || c.InitialValidators < 0 || c.InitialValidators > c.Validators:
+		return fmt.Errorf("%w: validators=%d initial=%d", ErrInvalidValidators, c.Validators, c.InitialValidators)
+	case c.FundedKeys < 0:
+		return fmt.Errorf("%w: %d", ErrInvalidFundedKeys, c.FundedKeys)
+	}
+	if c.InitialValidators == 0 {
+		c.InitialValidators = c.Validators
+	}
+	if len(c.RunnerEndpoint) == 0 {
+		c.RunnerEndpoint = DefaultRunnerEndpoint
+	}
+	if len(c.LogLevel) == 0 {
+		c.LogLevel = DefaultLogLevel
+	}
+	if c.Log == nil {
+		c.Log = logging.NoLog{}
+	}
+	return nil
+}
+
+// Node is a validator of the devnet chain.
+type Node struct {
+	Name   string
+	NodeID ids.NodeID
+	// URI is the chain endpoint of the node (<node uri>/ext/bc/<chainID>).
+	URI string
+}
+
+// JSONRPCClient returns a client of the hypersdk API served by the node.
+func (n *Node) JSONRPCClient() *rpc.JSONRPCClient {
+	return rpc.NewJSONRPCClient(n.URI)
+}
+
+// WebSocketClient connects to the websocket server of the node. It must be
+// closed by the caller.
+func (n *Node) WebSocketClient() (*rpc.WebSocketClient, error) {
+	return rpc.NewWebSocketClient(n.URI, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+}
+
+// Devnet is a running local network. It must be stopped with [Stop].
+type Devnet struct {
+	cfg    *Config
+	runner *exec.Cmd
+	cli    runner_sdk.Client
+
+	NetworkID uint32
+	ChainID   ids.ID
+	SubnetID  ids.ID
+	Nodes     []*Node
+	// Keys are funded in the genesis of the chain.
+	Keys []ed25519.PrivateKey
+}
+
+func nodeName(i int) string {
+	return fmt.Sprintf("node%d", i+1)
+}
+
+// Start launches a network of [cfg.InitialValidators] nodes that validate a
+// new chain of [cfg.VMName], then stakes the remaining validators one at a
+// time. It returns once every validator serves the chain.
+//
+// If an error is returned, anything that was started has been torn down.
+func Start(ctx context.Context, cfg *Config) (*Devnet, error) {
+	if err := cfg.verify(); err != nil {
+		return nil, err
+	}
+	d := &Devnet{cfg: cfg}
+	if err := d.start(ctx); err != nil {
+		if stopErr := d.Stop(context.Background()); stopErr != nil {
+			cfg.Log.Warn("unable to stop devnet", zap.Error(stopErr))
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Devnet) start(ctx context.Context) error {
+	if len(d.cfg.RunnerPath) > 0 {
+		if err := d.startRunner(); err != nil {
+			return err
+		}
+	}
+	cli, err := runner_sdk.New(runner_sdk.Config{
+		Endpoint:    d.cfg.RunnerEndpoint,
+		DialTimeout: dialTimeout,
+	}, d.cfg.Log)
+	if err != nil {
+		return err
+	}
+	d.cli = cli
+
+	// Start the primary network with only the initial validators so that every
+	// later validator joins (and stakes) after the chain is live.
+	if _, err := d.cli.Start(
+		ctx,
+		d.cfg.ExecPath,
+		runner_sdk.WithPluginDir(d.cfg.PluginDir),
+		runner_sdk.WithNumNodes(uint32(d.cfg.InitialValidators)),
+		runner_sdk.WithGlobalNodeConfig(fmt.Sprintf(`{
+				"log-level":"%s",
+				"log-display-level":"%s",
+				"proposervm-use-current-height":true,
+				"http-host":"",
+				"http-allowed-origins": "*",
+				"http-allowed-hosts": "*"
+			}`,
+			d.cfg.LogLevel,
+			d.cfg.LogLevel,
+		)),
+	); err != nil {
+		return err
+	}
+
+	// Fund the keys in genesis
+	d.Keys = make([]ed25519.PrivateKey, d.cfg.FundedKeys)
+	funded := make([]codec.Address, d.cfg.FundedKeys)
+	for i := range d.Keys {
+		priv, err := ed25519.GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+		d.Keys[i] = priv
+		funded[i] = auth.NewED25519Address(priv.PublicKey())
+	}
+	genesis, err := d.cfg.Genesis(funded)
+	if err != nil {
+		return err
+	}
+
+	// Create the chain
+	participants := make([]string, d.cfg.InitialValidators)
+	for i := range participants {
+		participants[i] = nodeName(i)
+	}
+	spec := &rpcpb.BlockchainSpec{
+		VmName:      d.cfg.VMName,
+		Genesis:     string(genesis),
+		ChainConfig: d.chainConfig(),
+		SubnetSpec: &rpcpb.SubnetSpec{
+			SubnetConfig: string(d.cfg.SubnetConfig),
+			Participants: participants,
+		},
+	}
+	resp, err := d.cli.CreateBlockchains(ctx, []*rpcpb.BlockchainSpec{spec})
+	if err != nil {
+		return err
+	}
+	if len(resp.ChainIds) == 0 {
+		return ErrMissingChain
+	}
+	chainInfo, ok := resp.ClusterInfo.CustomChains[resp.ChainIds[0]]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingChain, resp.ChainIds[0])
+	}
+	d.ChainID, err = ids.FromString(chainInfo.ChainId)
+	if err != nil {
+		return err
+	}
+	d.SubnetID, err = ids.FromString(chainInfo.SubnetId)
+	if err != nil {
+		return err
+	}
+	d.cfg.Log.Info("created chain",
+		zap.Stringer("chainID", d.ChainID),
+		zap.Stringer("subnetID", d.SubnetID),
+		zap.Strings("participants", participants),
+	)
+
+	// Stake the remaining validators one at a time
+	for i := d.cfg.InitialValidators; i < d.cfg.Validators; i++ {
+		if d.cfg.StakeInterval > 0 {
+			select {
+			case <-time.After(d.cfg.StakeInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		name := nodeName(i)
+		if _, err := d.cli.AddSubnetValidators(ctx, []*rpcpb.SubnetValidatorsSpec{{
+			SubnetId:  d.SubnetID.String(),
+			NodeNames: []string{name},
+		}}); err != nil {
+			return fmt.Errorf("unable to stake %s: %w", name, err)
+		}
+		d.cfg.Log.Info("added validator", zap.String("name", name))
+	}
+	return d.waitReady(ctx)
+}
+
+// startRunner launches an avalanche-network-runner server at
+// [cfg.RunnerEndpoint].
+func (d *Devnet) startRunner() error {
+	_, portStr, err := net.SplitHostPort(d.cfg.RunnerEndpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRunnerEndpoint, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRunnerEndpoint, err)
+	}
+	//nolint:gosec
+	cmd := exec.Command(
+		d.cfg.RunnerPath,
+		"server",
+		"--log-level="+d.cfg.LogLevel,
+		fmt.Sprintf("--port=:%d", port),
+		fmt.Sprintf("--grpc-gateway-port=:%d", port+1),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	d.runner = cmd
+	return nil
+}
+
+// WaitHealthy returns once every node of the network passes its health
+// checks (like after a node was restarted).
+func (d *Devnet) WaitHealthy(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	// The runner only responds once the network is healthy
+	_, err := d.cli.Health(ctx)
+	return err
+}
+
+// waitReady populates [Nodes] and waits for each of them to serve the chain.
+func (d *Devnet) waitReady(ctx context.Context) error {
+	if err := d.WaitHealthy(ctx); err != nil {
+		return fmt.Errorf("network is not healthy: %w", err)
+	}
+	status, err := d.cli.Status(ctx)
+	if err != nil {
+		return err
+	}
+	nodeInfos := status.GetClusterInfo().GetNodeInfos()
+	d.Nodes = make([]*Node, d.cfg.Validators)
+	for i := range d.Nodes {
+		d.Nodes[i], err = d.serving(ctx, nodeName(i), nodeInfos)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serving waits for the node [name] of [nodeInfos] to serve the chain.
+func (d *Devnet) serving(ctx context.Context, name string, nodeInfos map[string]*rpcpb.NodeInfo) (*Node, error) {
+	info, ok := nodeInfos[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, name)
+	}
+	nodeID, err := ids.NodeIDFromString(info.GetId())
+	if err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf("%s/ext/bc/%s", info.GetUri(), d.ChainID)
+
+	// After the network is healthy, the chain may not respond right away
+	cli := rpc.NewJSONRPCClient(uri)
+	for j := 0; ; j++ {
+		d.NetworkID, _, _, err = cli.Network(ctx)
+		if err == nil || j == readyRetries {
+			break
+		}
+		select {
+		case <-time.After(readyRetryDelay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s is not ready: %w", name, err)
+	}
+	return &Node{
+		Name:   name,
+		NodeID: nodeID,
+		URI:    uri,
+	}, nil
+}
+
+// refresh waits for the node [name] to serve the chain after it was started
+// and updates it in [Nodes] (its URI may change when it restarts).
+func (d *Devnet) refresh(ctx context.Context, name string) (*Node, error) {
+	if err := d.WaitHealthy(ctx); err != nil {
+		return nil, fmt.Errorf("network is not healthy: %w", err)
+	}
+	status, err := d.cli.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	node, err := d.serving(ctx, name, status.GetClusterInfo().GetNodeInfos())
+	if err != nil {
+		return nil, err
+	}
+	for i, validator := range d.Nodes {
+		if validator.Name == name {
+			d.Nodes[i] = node
+		}
+	}
+	return node, nil
+}
+
+func (d *Devnet) chainConfig() string {
+	if len(d.cfg.ChainConfig) == 0 {
+		return "{}"
+	}
+	return string(d.cfg.ChainConfig)
+}
+
+// AddNode launches a node that tracks the subnet of the chain without
+// validating it (so it syncs the chain like any new node would) and returns
+// once it serves the chain. The node is not added to [Nodes].
+func (d *Devnet) AddNode(ctx context.Context, name string) (*Node, error) {
+	if _, err := d.cli.AddNode(
+		ctx,
+		name,
+		d.cfg.ExecPath,
+		runner_sdk.WithGlobalNodeConfig(fmt.Sprintf(`{%q:%q}`, trackSubnetsKey, d.SubnetID)),
+		runner_sdk.WithChainConfigs(map[string]string{d.ChainID.String(): d.chainConfig()}),
+	); err != nil {
+		return nil, err
+	}
+	d.cfg.Log.Info("added node", zap.String("name", name))
+	return d.refresh(ctx, name)
+}
+
+// RestartNode restarts the node [name] and returns once it serves the
+// chain again.
+func (d *Devnet) RestartNode(ctx context.Context, name string) (*Node, error) {
+	if _, err := d.cli.RestartNode(ctx, name); err != nil {
+		return nil, err
+	}
+	return d.refresh(ctx, name)
+}
+
+// UpgradeNode restarts the node [name] with the avalanchego binary at
+// [execPath] and the plugins of [pluginDir] (either may be empty to keep
+// the current one) and returns once it serves the chain again.
+func (d *Devnet) UpgradeNode(ctx context.Context, name string, execPath string, pluginDir string) (*Node, error) {
+	if _, err := d.cli.RestartNode(
+		ctx,
+		name,
+		runner_sdk.WithExecPath(execPath),
+		runner_sdk.WithPluginDir(pluginDir),
+	); err != nil {
+		return nil, err
+	}
+	d.cfg.Log.Info("upgraded node",
+		zap.String("name", name),
+		zap.String("execPath", execPath),
+		zap.String("pluginDir", pluginDir),
+	)
+	return d.refresh(ctx, name)
+}
+
+// PauseNode stops the node [name] but keeps its data so it can be resumed
+// with [ResumeNode].
+func (d *Devnet) PauseNode(ctx context.Context, name string) error {
+	if _, err := d.cli.PauseNode(ctx, name); err != nil {
+		return err
+	}
+	return d.WaitHealthy(ctx)
+}
+
+// ResumeNode starts a node paused by [PauseNode] and returns once it serves
+// the chain again.
+func (d *Devnet) ResumeNode(ctx context.Context, name string) (*Node, error) {
+	if _, err := d.cli.ResumeNode(ctx, name); err != nil {
+		return nil, err
+	}
+	return d.refresh(ctx, name)
+}
+
+// URIs returns the chain endpoint of each validator.
+func (d *Devnet) URIs() []string {
+	uris := make([]string, len(d.Nodes))
+	for i, node := range d.Nodes {
+		uris[i] = node.URI
+	}
+	return uris
+}
+
+// Stop shuts down the network and, if it was started by [Start], the
+// avalanche-network-runner server. It is safe to call on a partially started
+// [Devnet].
+func (d *Devnet) Stop(ctx context.Context) error {
+	var errs []error
+	if d.cli != nil {
+		ctx, cancel := context.WithTimeout(ctx, stopTimeout)
+		_, err := d.cli.Stop(ctx)
+		cancel()
+		errs = append(errs, err, d.cli.Close())
+		d.cli = nil
+	}
+	if d.runner != nil {
+		if err := d.runner.Process.Signal(syscall.SIGTERM); err != nil {
+			errs = append(errs, err)
+		} else if err := d.runner.Wait(); err != nil {
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				errs = append(errs, err)
+			}
+		}
+		d.runner = nil
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package devnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+func testConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.ExecPath = "avalanchego"
+	cfg.PluginDir = "plugins"
+	cfg.VMName = "testvm"
+	cfg.Genesis = func([]codec.Address) ([]byte, error) { return []byte("{}"), nil }
+	return cfg
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "missing exec path",
+			modify: func(c *Config) { c.ExecPath = "" },
+			err:    ErrMissingExecPath,
+		},
+		{
+			name:   "missing genesis",
+			modify: func(c *Config) { c.Genesis = nil },
+			err:    ErrMissingGenesis,
+		},
+		{
+			name:   "no validators",
+			modify: func(c *Config) { c.Validators = 0 },
+			err:    ErrInvalidValidators,
+		},
+		{
+			name:   "too many initial validators",
+			modify: func(c *Config) { c.InitialValidators = c.Validators + 1 },
+			err:    ErrInvalidValidators,
+		},
+		{
+			name:   "negative funded keys",
+			modify: func(c *Config) { c.FundedKeys = -1 },
+			err:    ErrInvalidFundedKeys,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			tt.modify(cfg)
+			require.ErrorIs(t, cfg.verify(), tt.err)
+		})
+	}
+}
+
+func TestConfigVerifyDefaults(t *testing.T) {
+	require := require.New(t)
+
+	cfg := testConfig()
+	cfg.RunnerEndpoint = ""
+	cfg.LogLevel = ""
+	cfg.Log = nil
+	require.NoError(cfg.verify())
+	require.Equal(DefaultValidators, cfg.InitialValidators)
+	require.Equal(DefaultRunnerEndpoint, cfg.RunnerEndpoint)
+	require.Equal(DefaultLogLevel, cfg.LogLevel)
+	require.NotNil(cfg.Log)
+
+	cfg = testConfig()
+	cfg.InitialValidators = 2
+	require.NoError(cfg.verify())
+	require.Equal(2, cfg.InitialValidators)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	require := require.New(t)
+
+	genesis := func([]codec.Address) ([]byte, error) { return []byte("{}"), nil }
+	t.Setenv(ExecPathEnv, "avalanchego")
+	t.Setenv(PluginDirEnv, "plugins")
+	t.Setenv(RunnerPathEnv, "")
+	t.Setenv(RunnerEndpointEnv, "")
+	cfg := ConfigFromEnv("testvm", genesis)
+	require.NoError(cfg.verify())
+	require.Equal("avalanchego", cfg.ExecPath)
+	require.Equal("plugins", cfg.PluginDir)
+	require.Empty(cfg.RunnerPath)
+	require.Equal(DefaultRunnerEndpoint, cfg.RunnerEndpoint)
+
+	t.Setenv(RunnerPathEnv, "avalanche-network-runner")
+	t.Setenv(RunnerEndpointEnv, "127.0.0.1:9000")
+	cfg = ConfigFromEnv("testvm", genesis)
+	require.Equal("avalanche-network-runner", cfg.RunnerPath)
+	require.Equal("127.0.0.1:9000", cfg.RunnerEndpoint)
+
+	// Tests are skipped without an avalanchego binary
+	t.Setenv(ExecPathEnv, "")
+	var skipped bool
+	t.Run("start", func(t *testing.T) {
+		defer func() { skipped = t.Skipped() }()
+		StartTest(t, ConfigFromEnv("testvm", genesis))
+	})
+	require.True(skipped)
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package devnet
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Environment variables read by [ConfigFromEnv] (named like the variables of
+// the scripts of the example hypervms).
+const (
+	ExecPathEnv       = "AVALANCHEGO_PATH"
+	PluginDirEnv      = "AVALANCHEGO_PLUGIN_DIR"
+	RunnerPathEnv     = "ANR_PATH"
+	RunnerEndpointEnv = "ANR_ENDPOINT"
+)
+
+// ConfigFromEnv returns the [DefaultConfig] of a network of [vmName] with the
+// binaries and runner endpoint set by the environment, so integration tests
+// don't need to define flags for them.
+func ConfigFromEnv(vmName string, genesis GenesisFunc) *Config {
+	cfg := DefaultConfig()
+	cfg.VMName = vmName
+	cfg.Genesis = genesis
+	cfg.ExecPath = os.Getenv(ExecPathEnv)
+	cfg.PluginDir = os.Getenv(PluginDirEnv)
+	cfg.RunnerPath = os.Getenv(RunnerPathEnv)
+	if endpoint := os.Getenv(RunnerEndpointEnv); len(endpoint) > 0 {
+		cfg.RunnerEndpoint = endpoint
+	}
+	return cfg
+}
+
+// StartTest launches a network for a test and stops it when the test
+// completes. The test is skipped if [cfg] has no avalanchego binary (like when
+// [ExecPathEnv] is not set).
+func StartTest(t testing.TB, cfg *Config) *Devnet {
+	if len(cfg.ExecPath) == 0 {
+		t.Skipf("%s is not set", ExecPathEnv)
+	}
+	d, err := Start(context.Background(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, d.Stop(context.Background()))
+	})
+	return d
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/devnet"
+)
+
+const (
+	bootstrapNode      = "bootstrap"
+	syncNode           = "sync"
+	concurrentSyncNode = "sync-concurrent"
+
+	// resyncBlocks are produced while a synced node is paused.
+	resyncBlocks = 256
+
+	confirmRetryDelay   = time.Second
+	produceRetryDelay   = 5 * time.Second
+	submitDelay         = 10 * time.Millisecond
+	concurrentSyncDelay = 5 * time.Second
+)
+
+var checks = map[Check]func(context.Context, *testing.T, *suite){
+	Issue:     checkIssue,
+	Bootstrap: checkBootstrap,
+	Restart:   checkRestart,
+	StateSync: checkStateSync,
+	Upgrade:   checkUpgrade,
+}
+
+type suite struct {
+	cfg     *Config
+	d       *devnet.Devnet
+	parser  chain.Parser
+	factory chain.AuthFactory
+
+	// txs is the number of transactions generated (the index of the next
+	// one).
+	txs atomic.Int64
+	// added are the running nodes added by the checks.
+	added []*devnet.Node
+}
+
+func newSuite(t *testing.T, cfg *Config, d *devnet.Devnet) *suite {
+	require := require.New(t)
+	require.NotEmpty(d.Keys, "the devnet must fund a key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.TxTimeout)
+	defer cancel()
+	parser, err := cfg.Workload.Parser(ctx, d, d.Nodes[0])
+	require.NoError(err)
+	return &suite{
+		cfg:     cfg,
+		d:       d,
+		parser:  parser,
+		factory: auth.NewED25519Factory(d.Keys[0]),
+	}
+}
+
+// serving returns the nodes that serve the chain (the validators, which may
+// have been restarted, and the running nodes that were added).
+func (s *suite) serving() []*devnet.Node {
+	nodes := make([]*devnet.Node, 0, len(s.d.Nodes)+len(s.added))
+	nodes = append(nodes, s.d.Nodes...)
+	return append(nodes, s.added...)
+}
+
+func (s *suite) add(node *devnet.Node) {
+	s.remove(node.Name)
+	s.added = append(s.added, node)
+}
+
+func (s *suite) remove(name string) {
+	added := s.added[:0]
+	for _, node := range s.added {
+		if node.Name != name {
+			added = append(added, node)
+		}
+	}
+	s.added = added
+}
+
+func (s *suite) generate(ctx context.Context, node *devnet.Node) (int, func(context.Context) error, *chain.Transaction, error) {
+	i := int(s.txs.Add(1) - 1)
+	submit, tx, _, err := node.JSONRPCClient().GenerateTransaction(ctx, s.parser, s.cfg.Workload.Actions(i), s.factory)
+	return i, submit, tx, err
+}
+
+// accept issues a transaction to [node] and waits for every node to confirm
+// it.
+func (s *suite) accept(ctx context.Context, t *testing.T, node *devnet.Node) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.TxTimeout)
+	defer cancel()
+
+	i, _, tx, err := s.generate(ctx, node)
+	require.NoError(err)
+	ws, err := node.WebSocketClient()
+	require.NoError(err)
+	defer ws.Close()
+	require.NoError(ws.RegisterTx(tx))
+	txID, dErr, result, err := ws.ListenTx(ctx)
+	require.NoError(err)
+	require.NoError(dErr, "%s dropped transaction %d", node.Name, i)
+	require.Equal(tx.ID(), txID)
+	require.True(result.Success, "transaction %d failed: %s", i, result.Error)
+
+	for _, node := range s.serving() {
+		for {
+			err := s.cfg.Workload.Confirm(ctx, s.d, node, i)
+			if err == nil {
+				break
+			}
+			sleep(ctx, confirmRetryDelay)
+			require.NoError(ctx.Err(), "%s did not confirm transaction %d: %v", node.Name, i, err)
+		}
+	}
+}
+
+// produce issues transactions to the validators (without waiting for them)
+// until [blocks] are accepted or, if [blocks] is 0, until [ctx] is done. If
+// [tolerant] is set, errors are logged and retried instead of returned (like
+// while nodes join the network).
+func (s *suite) produce(ctx context.Context, t *testing.T, blocks uint64, tolerant bool) error {
+	var target uint64
+	if blocks > 0 {
+		_, height, _, err := s.d.Nodes[0].JSONRPCClient().Accepted(ctx)
+		if err != nil {
+			return err
+		}
+		target = height + blocks
+	}
+	for ctx.Err() == nil {
+		height, err := s.submit(ctx)
+		switch {
+		case err != nil && !tolerant:
+			return err
+		case err != nil:
+			t.Logf("unable to produce blocks: %v", err)
+			sleep(ctx, produceRetryDelay)
+		case target > 0 && height >= target:
+			return nil
+		default:
+			// Transactions can be generated much faster than blocks
+			sleep(ctx, submitDelay)
+		}
+	}
+	if target == 0 {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// submit issues a transaction to the next validator and returns the height
+// of the last accepted block.
+func (s *suite) submit(ctx context.Context) (uint64, error) {
+	node := s.d.Nodes[int(s.txs.Load())%len(s.d.Nodes)]
+	_, submit, _, err := s.generate(ctx, node)
+	if err != nil {
+		return 0, err
+	}
+	if err := submit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", node.Name, err)
+	}
+	_, height, _, err := s.d.Nodes[0].JSONRPCClient().Accepted(ctx)
+	return height, err
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
+
+func checkIssue(ctx context.Context, t *testing.T, s *suite) {
+	require := require.New(t)
+
+	for _, node := range s.d.Nodes {
+		cli := node.JSONRPCClient()
+		ok, err := cli.Ping(ctx)
+		require.NoError(err)
+		require.True(ok)
+		networkID, _, chainID, err := cli.Network(ctx)
+		require.NoError(err)
+		require.Equal(s.d.NetworkID, networkID)
+		require.Equal(s.d.ChainID, chainID)
+	}
+	s.accept(ctx, t, s.d.Nodes[0])
+}
+
+func checkBootstrap(ctx context.Context, t *testing.T, s *suite) {
+	require := require.New(t)
+
+	require.NoError(s.produce(ctx, t, s.cfg.BootstrapBlocks, false))
+	node, err := s.d.AddNode(ctx, bootstrapNode)
+	require.NoError(err)
+	s.add(node)
+	s.accept(ctx, t, node)
+}
+
+func checkRestart(ctx context.Context, t *testing.T, s *suite) {
+	require := require.New(t)
+
+	start := time.Now()
+	node, err := s.d.RestartNode(ctx, s.d.Nodes[len(s.d.Nodes)-1].Name)
+	require.NoError(err)
+	require.Less(time.Since(start), s.cfg.RestartTimeout, "restarted node was not ready in time")
+	s.accept(ctx, t, node)
+}
+
+func checkStateSync(ctx context.Context, t *testing.T, s *suite) {
+	require := require.New(t)
+
+	require.NoError(s.produce(ctx, t, s.cfg.StateSyncBlocks, false))
+	node, err := s.d.AddNode(ctx, syncNode)
+	require.NoError(err)
+	s.add(node)
+	s.accept(ctx, t, node)
+
+	// A paused node syncs the blocks it missed once it is resumed
+	require.NoError(s.d.PauseNode(ctx, syncNode))
+	s.remove(syncNode)
+	require.NoError(s.produce(ctx, t, resyncBlocks, false))
+	node, err = s.d.ResumeNode(ctx, syncNode)
+	require.NoError(err)
+	s.add(node)
+	s.accept(ctx, t, node)
+
+	// New nodes also sync while transactions are issued
+	produceCtx, cancel := context.WithCancel(ctx)
+	produced := make(chan error, 1)
+	go func() {
+		produced <- s.produce(produceCtx, t, 0, true)
+	}()
+	sleep(ctx, concurrentSyncDelay)
+	node, err = s.d.AddNode(ctx, concurrentSyncNode)
+	cancel()
+	require.NoError(<-produced)
+	require.NoError(err)
+	s.add(node)
+	s.accept(ctx, t, node)
+}
+
+func checkUpgrade(ctx context.Context, t *testing.T, s *suite) {
+	if len(s.cfg.UpgradeExecPath) == 0 && len(s.cfg.UpgradePluginDir) == 0 {
+		t.Skip("no upgrade binaries")
+	}
+	require := require.New(t)
+
+	// Nodes are upgraded one at a time so the validators keep a quorum
+	for _, old := range s.serving() {
+		node, err := s.d.UpgradeNode(ctx, old.Name, s.cfg.UpgradeExecPath, s.cfg.UpgradePluginDir)
+		require.NoError(err)
+		added := false
+		for _, n := range s.added {
+			added = added || n.Name == node.Name
+		}
+		if added {
+			s.add(node)
+		}
+		s.accept(ctx, t, node)
+	}
+}
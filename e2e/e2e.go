@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package e2e is a conformance suite for hypervms. It launches a devnet of
+// the VM and runs the network checks of the e2e tests of the example
+// hypervms (issuance, bootstrapping, restarts, state sync, and upgrades)
+// with the transactions of a [Workload], so a new VM only needs to describe
+// its transactions to be tested like the examples are.
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/devnet"
+)
+
+const (
+	DefaultBootstrapBlocks = 128
+	// DefaultStateSyncBlocks exceeds the default min blocks of state sync
+	// (so a new node state syncs rather than bootstraps) with enough blocks
+	// to fetch a number of ranges of state.
+	DefaultStateSyncBlocks = 1024
+	DefaultRestartTimeout  = 30 * time.Second
+	DefaultTxTimeout       = 2 * time.Minute
+	DefaultCheckTimeout    = 30 * time.Minute
+)
+
+// Workload describes the transactions of a VM issued by the suite. They are
+// signed by the first funded key of the devnet.
+type Workload interface {
+	// Parser returns the parser of the chain served by [node].
+	Parser(ctx context.Context, d *devnet.Devnet, node *devnet.Node) (chain.Parser, error)
+	// Actions returns the actions of the [i]th transaction issued by the
+	// suite. Every transaction must succeed and differ from the previous
+	// ones.
+	Actions(i int) []chain.Action
+	// Confirm returns an error if the effects of the [i]th transaction are
+	// not visible through [node]. It is retried until the transaction is
+	// confirmed by every node.
+	Confirm(ctx context.Context, d *devnet.Devnet, node *devnet.Node, i int) error
+}
+
+// Check is a standard check of the suite.
+type Check string
+
+const (
+	// Issue checks that every validator serves the chain and confirms a
+	// transaction.
+	Issue Check = "issue"
+	// Bootstrap checks that a new node bootstraps the chain.
+	Bootstrap Check = "bootstrap"
+	// Restart checks that a restarted validator becomes ready quickly (by
+	// reading its blocks from disk).
+	Restart Check = "restart"
+	// StateSync checks that new nodes state sync the chain, while it is
+	// idle and while transactions are issued, and that a paused node syncs
+	// the blocks it missed once it is resumed.
+	StateSync Check = "state-sync"
+	// Upgrade checks that the chain keeps accepting transactions while every
+	// validator is restarted, one at a time, with new binaries.
+	Upgrade Check = "upgrade"
+)
+
+// AllChecks are the checks run by default, in order.
+var AllChecks = []Check{Issue, Bootstrap, Restart, StateSync, Upgrade}
+
+type Config struct {
+	Devnet   *devnet.Config
+	Workload Workload
+	Checks   []Check
+
+	// BootstrapBlocks are produced before a new node bootstraps and
+	// StateSyncBlocks before one state syncs.
+	BootstrapBlocks uint64
+	StateSyncBlocks uint64
+	// RestartTimeout is the time a restarted validator has to serve the
+	// chain again.
+	RestartTimeout time.Duration
+
+	// UpgradeExecPath and UpgradePluginDir are the binaries validators are
+	// restarted with by [Upgrade], which is skipped if neither is set.
+	UpgradeExecPath  string
+	UpgradePluginDir string
+
+	// TxTimeout bounds the confirmation of each transaction and
+	// CheckTimeout each check.
+	TxTimeout    time.Duration
+	CheckTimeout time.Duration
+}
+
+// DefaultConfig returns a [Config] running [AllChecks] against a devnet of
+// [devnetConfig] (like one returned by [devnet.ConfigFromEnv]).
+func DefaultConfig(devnetConfig *devnet.Config, workload Workload) *Config {
+	return &Config{
+		Devnet:          devnetConfig,
+		Workload:        workload,
+		Checks:          AllChecks,
+		BootstrapBlocks: DefaultBootstrapBlocks,
+		StateSyncBlocks: DefaultStateSyncBlocks,
+		RestartTimeout:  DefaultRestartTimeout,
+		TxTimeout:       DefaultTxTimeout,
+		CheckTimeout:    DefaultCheckTimeout,
+	}
+}
+
+// Environment variables read by [ConfigFromEnv] (in addition to those of
+// [devnet.ConfigFromEnv]).
+const (
+	UpgradeExecPathEnv  = "UPGRADE_AVALANCHEGO_PATH"
+	UpgradePluginDirEnv = "UPGRADE_AVALANCHEGO_PLUGIN_DIR"
+)
+
+// ConfigFromEnv returns the [DefaultConfig] of a devnet of [vmName] configured
+// by the environment.
+func ConfigFromEnv(vmName string, genesis devnet.GenesisFunc, workload Workload) *Config {
+	cfg := DefaultConfig(devnet.ConfigFromEnv(vmName, genesis), workload)
+	cfg.UpgradeExecPath = os.Getenv(UpgradeExecPathEnv)
+	cfg.UpgradePluginDir = os.Getenv(UpgradePluginDirEnv)
+	return cfg
+}
+
+func (c *Config) verify() error {
+	switch {
+	case c.Devnet == nil:
+		return ErrMissingDevnet
+	case c.Workload == nil:
+		return ErrMissingWorkload
+	}
+	for _, check := range c.Checks {
+		if _, ok := checks[check]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownCheck, check)
+		}
+	}
+	return nil
+}
+
+// Run launches a devnet (see [devnet.StartTest], which skips the test if
+// the devnet is not configured) and runs each check of [cfg] as a subtest.
+// Checks share the devnet: each one leaves the chain able to confirm
+// transactions for the next.
+func Run(t *testing.T, cfg *Config) {
+	require.NoError(t, cfg.verify())
+	d := devnet.StartTest(t, cfg.Devnet)
+	s := newSuite(t, cfg, d)
+	for _, check := range cfg.Checks {
+		run := checks[check]
+		t.Run(string(check), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.CheckTimeout)
+			defer cancel()
+
+			run(ctx, t, s)
+		})
+	}
+}
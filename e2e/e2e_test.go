@@ -0,0 +1,42 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/devnet"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	require := require.New(t)
+
+	t.Setenv(devnet.ExecPathEnv, "/avalanchego")
+	t.Setenv(UpgradePluginDirEnv, "/upgrade/plugins")
+	t.Setenv(UpgradeExecPathEnv, "")
+	cfg := ConfigFromEnv("vm", nil, nil)
+	require.Equal("/avalanchego", cfg.Devnet.ExecPath)
+	require.Equal("/upgrade/plugins", cfg.UpgradePluginDir)
+	require.Empty(cfg.UpgradeExecPath)
+	require.Equal(AllChecks, cfg.Checks)
+	require.ErrorIs(cfg.verify(), ErrMissingWorkload)
+
+	cfg.Devnet = nil
+	require.ErrorIs(cfg.verify(), ErrMissingDevnet)
+}
+
+func TestConfigChecks(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig(devnet.DefaultConfig(), &workload{})
+	require.NoError(cfg.verify())
+	cfg.Checks = []Check{Issue, "unknown"}
+	require.ErrorIs(cfg.verify(), ErrUnknownCheck)
+}
+
+type workload struct {
+	Workload
+}
@@ -0,0 +1,12 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package e2e
+
+import "errors"
+
+var (
+	ErrMissingDevnet   = errors.New("missing devnet config")
+	ErrMissingWorkload = errors.New("missing workload")
+	ErrUnknownCheck    = errors.New("unknown check")
+)
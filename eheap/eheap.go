@@ -4,8 +4,12 @@
 package eheap
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/emap"
 	"github.com/ava-labs/hypersdk/heap"
 )
 
@@ -22,14 +26,26 @@ type Item interface {
 // instead of grouping by expiry to support this feature, which makes it
 // less efficient).
 type ExpiryHeap[T Item] struct {
+	policy   emap.Policy
+	onExpire func([]T)
+
 	minHeap *heap.Heap[T, int64]
 }
 
 // New returns an instance of ExpiryHeap with minHeap and maxHeap
 // containing [items].
 func New[T Item](items int) *ExpiryHeap[T] {
+	return NewWithPolicy[T](items, emap.ExactPolicy{}, nil)
+}
+
+// NewWithPolicy returns an instance of ExpiryHeap that orders and expires
+// items by the buckets of [policy]. If [onExpire] is not nil, it is called
+// with the items removed by each call to [SetMin] that removes any.
+func NewWithPolicy[T Item](items int, policy emap.Policy, onExpire func([]T)) *ExpiryHeap[T] {
 	return &ExpiryHeap[T]{
-		minHeap: heap.New[T, int64](items, true),
+		policy:   policy,
+		onExpire: onExpire,
+		minHeap:  heap.New[T, int64](items, true),
 	}
 }
 
@@ -39,7 +55,7 @@ func (eh *ExpiryHeap[T]) Add(item T) {
 	poolLen := eh.minHeap.Len()
 	eh.minHeap.Push(&heap.Entry[T, int64]{
 		ID:    itemID,
-		Val:   item.Expiry(),
+		Val:   eh.policy.Bucket(item.Expiry()),
 		Item:  item,
 		Index: poolLen,
 	})
@@ -57,25 +73,44 @@ func (eh *ExpiryHeap[T]) Remove(id ids.ID) (T, bool) {
 	return minEntry.Item, true
 }
 
-// SetMin removes all elements in eh with a value less than [val]. Returns
-// the list of removed elements.
+// SetMin removes all elements in eh that are expired at [val] (with the
+// default policy, those with a value less than [val]). Returns the list of
+// removed elements.
 func (eh *ExpiryHeap[T]) SetMin(val int64) []T {
 	removed := []T{}
 	for {
-		min, ok := eh.PeekMin()
-		if !ok {
+		first := eh.minHeap.First()
+		if first == nil {
 			break
 		}
-		if min.Expiry() < val {
+		if eh.policy.Expired(first.Val, val) {
 			eh.PopMin() // Assumes that there is not concurrent access to [ExpiryHeap]
-			removed = append(removed, min)
+			removed = append(removed, first.Item)
 			continue
 		}
 		break
 	}
+	if eh.onExpire != nil && len(removed) > 0 {
+		eh.onExpire(removed)
+	}
 	return removed
 }
 
+// Range returns the elements in eh with an expiry from [start] (inclusive) to
+// [end] (exclusive), ordered by expiry.
+func (eh *ExpiryHeap[T]) Range(start int64, end int64) []T {
+	items := []T{}
+	for _, entry := range eh.minHeap.Items() {
+		if expiry := entry.Item.Expiry(); expiry >= start && expiry < end {
+			items = append(items, entry.Item)
+		}
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		return cmp.Compare(a.Expiry(), b.Expiry())
+	})
+	return items
+}
+
 // PeekMin returns the minimum value in eh.
 func (eh *ExpiryHeap[T]) PeekMin() (T, bool) {
 	first := eh.minHeap.First()
@@ -96,6 +131,15 @@ func (eh *ExpiryHeap[T]) PopMin() (T, bool) {
 	return item, true
 }
 
+// Get returns the item [id] if it is in eh.
+func (eh *ExpiryHeap[T]) Get(id ids.ID) (T, bool) {
+	entry, ok := eh.minHeap.Get(id)
+	if !ok {
+		return *new(T), false
+	}
+	return entry.Item, true
+}
+
 // Has returns if [item] is in eh.
 func (eh *ExpiryHeap[T]) Has(item ids.ID) bool {
 	return eh.minHeap.Has(item)
@@ -8,6 +8,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/emap"
 )
 
 const testSponsor = "testSponsor"
@@ -201,3 +203,41 @@ func TestLen(t *testing.T) {
 	}
 	require.Equal(5, eheap.Len(), "Length of mempool is not as expected.")
 }
+
+func TestSetMinIntervalPolicy(t *testing.T) {
+	require := require.New(t)
+
+	expired := []*TestItem{}
+	eheap := NewWithPolicy[*TestItem](0, emap.IntervalPolicy{Interval: 10}, func(items []*TestItem) {
+		expired = append(expired, items...)
+	})
+	items := []*TestItem{}
+	for _, ts := range []int64{1, 9, 10, 11, 25} {
+		item := GenerateTestItem(testSponsor, ts)
+		items = append(items, item)
+		eheap.Add(item)
+	}
+	// Items are only expired once the end of their bucket is less than the
+	// minimum
+	require.Empty(eheap.SetMin(10))
+	require.Empty(expired)
+	removed := eheap.SetMin(11)
+	require.ElementsMatch(items[:3], removed)
+	require.ElementsMatch(items[:3], expired)
+	require.Equal(2, eheap.Len())
+}
+
+func TestRange(t *testing.T) {
+	require := require.New(t)
+
+	eheap := New[*TestItem](0)
+	items := []*TestItem{}
+	for _, ts := range []int64{5, 3, 1, 4, 2} {
+		item := GenerateTestItem(testSponsor, ts)
+		items = append(items, item)
+		eheap.Add(item)
+	}
+	require.Equal([]*TestItem{items[4], items[1], items[3]}, eheap.Range(2, 5))
+	require.Empty(eheap.Range(6, 10))
+	require.Equal(5, eheap.Len())
+}
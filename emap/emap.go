@@ -4,6 +4,8 @@
 package emap
 
 import (
+	"cmp"
+	"slices"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -29,17 +31,38 @@ type Item interface {
 type EMap[T Item] struct {
 	mu sync.RWMutex
 
+	policy   Policy
+	onExpire func([]ids.ID)
+
 	bh    *heap.Heap[*bucket, int64]
 	seen  set.Set[ids.ID]   // Stores a set of unique tx ids
-	times map[int64]*bucket // Uses timestamp as keys to map to buckets of ids.
+	times map[int64]*bucket // Uses bucket keys to map to buckets of ids.
 }
 
-// NewEMap returns a pointer to a instance of an empty EMap struct.
+// NewEMap returns a pointer to a instance of an empty EMap struct that keeps
+// a bucket per expiry ([ExactPolicy]).
 func NewEMap[T Item]() *EMap[T] {
+	return NewEMapWithPolicy[T](ExactPolicy{}, nil)
+}
+
+// NewEMapWithPolicy returns an empty EMap that buckets and expires items
+// according to [policy]. If [onExpire] is not nil, it is called with the ids
+// removed by each call to [SetMin] that removes any (while the EMap is locked,
+// so it must not call back into it).
+func NewEMapWithPolicy[T Item](policy Policy, onExpire func([]ids.ID)) *EMap[T] {
+	return NewEMapWithCapacity[T](policy, onExpire, 0)
+}
+
+// NewEMapWithCapacity returns an empty EMap like [NewEMapWithPolicy] that
+// preallocates space for [capacity] ids (so large EMaps don't repeatedly
+// grow while they fill up).
+func NewEMapWithCapacity[T Item](policy Policy, onExpire func([]ids.ID), capacity int) *EMap[T] {
 	return &EMap[T]{
-		seen:  set.Set[ids.ID]{},
-		times: make(map[int64]*bucket),
-		bh:    heap.New[*bucket, int64](120, true),
+		policy:   policy,
+		onExpire: onExpire,
+		seen:     set.NewSet[ids.ID](capacity),
+		times:    make(map[int64]*bucket),
+		bh:       heap.New[*bucket, int64](120, true),
 	}
 }
 
@@ -55,8 +78,8 @@ func (e *EMap[T]) Add(items []T) {
 
 // Add adds an id with a timestampt [t] to the EMap. If the timestamp
 // is genesis(0) or the id has been seen already, add returns. The id is
-// added to a bucket with timestamp [t]. If no bucket exists, add creates a
-// new bucket and pushes it to the binaryHeap.
+// added to the bucket of [t] (as defined by the policy of the EMap). If no
+// bucket exists, add creates a new bucket and pushes it to the binaryHeap.
 func (e *EMap[T]) add(id ids.ID, t int64) {
 	// Assume genesis txs can't be placed in seen tracker
 	if t == 0 {
@@ -70,27 +93,28 @@ func (e *EMap[T]) add(id ids.ID, t int64) {
 	e.seen.Add(id)
 
 	// Check if bucket with time already exists
-	if b, ok := e.times[t]; ok {
+	k := e.policy.Bucket(t)
+	if b, ok := e.times[k]; ok {
 		b.items = append(b.items, id)
 		return
 	}
 
 	// Create new bucket
 	b := &bucket{
-		t:     t,
+		t:     k,
 		items: []ids.ID{id},
 	}
-	e.times[t] = b
+	e.times[k] = b
 	e.bh.Push(&heap.Entry[*bucket, int64]{
 		ID:    id,
-		Val:   t,
+		Val:   k,
 		Item:  b,
 		Index: e.bh.Len(),
 	})
 }
 
-// SetMin removes all buckets with a lower
-// timestamp than [t] from e's bucketHeap.
+// SetMin removes all buckets that are expired at [t] (with the default
+// policy, those with a lower timestamp than [t]) from e's bucketHeap.
 func (e *EMap[T]) SetMin(t int64) []ids.ID {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -98,7 +122,7 @@ func (e *EMap[T]) SetMin(t int64) []ids.ID {
 	evicted := []ids.ID{}
 	for {
 		b := e.bh.First()
-		if b == nil || b.Val >= t {
+		if b == nil || !e.policy.Expired(b.Val, t) {
 			break
 		}
 		e.bh.Pop()
@@ -109,9 +133,58 @@ func (e *EMap[T]) SetMin(t int64) []ids.ID {
 		// Delete from times map
 		delete(e.times, b.Val)
 	}
+	if e.onExpire != nil && len(evicted) > 0 {
+		e.onExpire(evicted)
+	}
 	return evicted
 }
 
+// Range returns the ids in the buckets from [start] (inclusive) to [end]
+// (exclusive), ordered by bucket and then by the order they were added.
+func (e *EMap[T]) Range(start int64, end int64) []ids.ID {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	buckets := []*bucket{}
+	for k, b := range e.times {
+		if k >= start && k < end {
+			buckets = append(buckets, b)
+		}
+	}
+	slices.SortFunc(buckets, func(a, b *bucket) int {
+		return cmp.Compare(a.t, b.t)
+	})
+	r := []ids.ID{}
+	for _, b := range buckets {
+		r = append(r, b.items...)
+	}
+	return r
+}
+
+// Has returns true if [id] has been seen by EMap.
+func (e *EMap[T]) Has(id ids.ID) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.seen.Contains(id)
+}
+
+// Len returns the number of ids in EMap.
+func (e *EMap[T]) Len() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.seen.Len()
+}
+
+// Buckets returns the number of buckets in EMap.
+func (e *EMap[T]) Buckets() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.times)
+}
+
 // Any returns true if any items have been seen by EMap.
 func (e *EMap[T]) Any(items []T) bool {
 	e.mu.RLock()
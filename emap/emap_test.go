@@ -244,3 +244,59 @@ func TestSetMinPopsAll(t *testing.T) {
 
 	require.Equal(emptyEmap, e, "EMap not empty")
 }
+
+func TestEmapIntervalPolicy(t *testing.T) {
+	require := require.New(t)
+
+	expired := []ids.ID{}
+	e := NewEMapWithPolicy[*TestTx](IntervalPolicy{Interval: 10}, func(evicted []ids.ID) {
+		expired = append(expired, evicted...)
+	})
+	txs := []*TestTx{}
+	for _, ts := range []int64{1, 9, 10, 11, 25} {
+		txs = append(txs, &TestTx{id: ids.GenerateTestID(), t: ts})
+	}
+	e.Add(txs)
+	// Expiries are grouped by the end of their interval
+	require.Len(e.times, 3, "Expiries not bucketed by interval")
+	require.Len(e.times[10].items, 3, "Bucket length is incorrect")
+	require.Equal(5, e.Len())
+	require.Equal(3, e.Buckets())
+
+	require.Empty(e.SetMin(10))
+	require.Empty(expired)
+	removed := e.SetMin(11)
+	require.Equal([]ids.ID{txs[0].id, txs[1].id, txs[2].id}, removed)
+	require.Equal(removed, expired)
+	require.False(e.Has(txs[0].id))
+	require.True(e.Has(txs[3].id))
+	require.Equal(2, e.Len())
+	require.Equal(2, e.Buckets())
+}
+
+func TestEmapRange(t *testing.T) {
+	require := require.New(t)
+
+	e := NewEMap[*TestTx]()
+	txs := []*TestTx{}
+	for _, ts := range []int64{3, 1, 2, 3, 4} {
+		txs = append(txs, &TestTx{id: ids.GenerateTestID(), t: ts})
+	}
+	e.Add(txs)
+	require.Equal([]ids.ID{txs[2].id, txs[0].id, txs[3].id}, e.Range(2, 4))
+	require.Empty(e.Range(5, 10))
+	require.Equal(5, e.Len())
+}
+
+func TestIntervalPolicyBucket(t *testing.T) {
+	require := require.New(t)
+
+	p := IntervalPolicy{Interval: 10}
+	require.Equal(int64(0), p.Bucket(0))
+	require.Equal(int64(10), p.Bucket(1))
+	require.Equal(int64(10), p.Bucket(10))
+	require.Equal(int64(20), p.Bucket(11))
+	require.Equal(int64(0), p.Bucket(-5))
+	require.Equal(int64(-10), p.Bucket(-10))
+	require.Equal(int64(7), IntervalPolicy{}.Bucket(7))
+}
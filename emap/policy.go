@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package emap
+
+var (
+	_ Policy = ExactPolicy{}
+	_ Policy = IntervalPolicy{}
+)
+
+// Policy defines how expiring containers ([EMap] and eheap.ExpiryHeap)
+// group items by expiry and when a group is expired.
+//
+// [Bucket] must not decrease as expiry increases and [Expired] must be
+// monotonic in [bucket], so that once a bucket is expired so are all buckets
+// before it.
+type Policy interface {
+	// Bucket returns the bucket of an item that expires at [expiry].
+	Bucket(expiry int64) int64
+	// Expired returns true if the items in [bucket] are expired once the
+	// minimum of the container is set to [min].
+	Expired(bucket int64, min int64) bool
+}
+
+// ExactPolicy keeps a bucket per expiry and expires items whose expiry is
+// less than the minimum. It is the default [Policy].
+type ExactPolicy struct{}
+
+func (ExactPolicy) Bucket(expiry int64) int64 { return expiry }
+
+func (ExactPolicy) Expired(bucket int64, min int64) bool { return bucket < min }
+
+// IntervalPolicy groups expiries into buckets of [Interval], rounded up to
+// the end of the bucket, and expires a bucket once its end is less than the
+// minimum.
+//
+// This trades how promptly items are expired (they may be kept up to
+// [Interval] longer than with [ExactPolicy]) for fewer buckets to track.
+type IntervalPolicy struct {
+	Interval int64
+}
+
+func (p IntervalPolicy) Bucket(expiry int64) int64 {
+	if p.Interval <= 1 {
+		return expiry
+	}
+	if r := expiry % p.Interval; r > 0 {
+		return expiry - r + p.Interval
+	} else if r < 0 {
+		return expiry - r
+	}
+	return expiry
+}
+
+func (IntervalPolicy) Expired(bucket int64, min int64) bool { return bucket < min }
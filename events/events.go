@@ -0,0 +1,153 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package events publishes accepted blocks, transactions, and their results to
+// NATS JetStream as JSON.
+//
+// Events are published at least once: a node that restarts (or loses its
+// connection to the broker) before a publish is acknowledged publishes the
+// event again, so consumers should deduplicate events by their key (the block
+// or transaction ID).
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+const (
+	blocksTopic = "blocks"
+	txsTopic    = "txs"
+	stateTopic  = "state"
+)
+
+// Block is published to the "<prefix>blocks" topic for every accepted block.
+type Block struct {
+	ID         ids.ID          `json:"id"`
+	Parent     ids.ID          `json:"parent"`
+	Height     uint64          `json:"height"`
+	Timestamp  int64           `json:"timestamp"`
+	StateRoot  ids.ID          `json:"stateRoot"`
+	TxIDs      []ids.ID        `json:"txIds"`
+	UnitPrices fees.Dimensions `json:"unitPrices"`
+}
+
+// Tx is published to the "<prefix>txs" topic for every transaction in an
+// accepted block (in the order they were executed).
+type Tx struct {
+	ID          ids.ID `json:"id"`
+	BlockID     ids.ID `json:"blockId"`
+	BlockHeight uint64 `json:"blockHeight"`
+	Index       uint32 `json:"index"`
+
+	// Tx is the transaction, which can be parsed with the registries of the
+	// hypervm (see [chain.UnmarshalTx])
+	Tx          []byte  `json:"tx"`
+	Actor       []byte  `json:"actor"`
+	Sponsor     []byte  `json:"sponsor"`
+	ActionTypes []uint8 `json:"actionTypes"`
+
+	Success bool            `json:"success"`
+	Error   []byte          `json:"error"`
+	Outputs [][][]byte      `json:"outputs"`
+	Units   fees.Dimensions `json:"units"`
+	Fee     uint64          `json:"fee"`
+}
+
+// StateDiff is published to the "<prefix>state" topic for every accepted
+// block (if enabled) with the changes it made to state, sorted by key.
+type StateDiff struct {
+	BlockID ids.ID               `json:"blockId"`
+	Height  uint64               `json:"height"`
+	Changes []*chain.StateChange `json:"changes"`
+}
+
+// Message is an event ready to be published.
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// NewEvents returns the events of [blk], which must have been executed.
+func NewEvents(blk *chain.StatelessBlock) (*Block, []*Tx) {
+	blkID := blk.ID()
+	results := blk.Results()
+	b := &Block{
+		ID:         blkID,
+		Parent:     blk.Prnt,
+		Height:     blk.Hght,
+		Timestamp:  blk.Tmstmp,
+		StateRoot:  blk.StateRoot,
+		TxIDs:      make([]ids.ID, len(blk.Txs)),
+		UnitPrices: blk.FeeManager().UnitPrices(),
+	}
+	txs := make([]*Tx, len(blk.Txs))
+	for i, tx := range blk.Txs {
+		actionTypes := make([]uint8, len(tx.Actions))
+		for j, action := range tx.Actions {
+			actionTypes[j] = action.GetTypeID()
+		}
+		var (
+			result  = results[i]
+			actor   = tx.Auth.Actor()
+			sponsor = tx.Sponsor()
+		)
+		b.TxIDs[i] = tx.ID()
+		txs[i] = &Tx{
+			ID:          tx.ID(),
+			BlockID:     blkID,
+			BlockHeight: blk.Hght,
+			Index:       uint32(i),
+			Tx:          tx.Bytes(),
+			Actor:       actor[:],
+			Sponsor:     sponsor[:],
+			ActionTypes: actionTypes,
+			Success:     result.Success,
+			Error:       result.Error,
+			Outputs:     result.Outputs,
+			Units:       result.Units,
+			Fee:         result.Fee,
+		}
+	}
+	return b, txs
+}
+
+// NewMessages encodes the events of [blk] into messages for topics starting
+// with [topicPrefix].
+func NewMessages(blk *chain.StatelessBlock, topicPrefix string) ([]*Message, error) {
+	b, txs := NewEvents(blk)
+	msgs := make([]*Message, 0, 1+len(txs))
+	v, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	msgs = append(msgs, &Message{Topic: topicPrefix + blocksTopic, Key: b.ID[:], Value: v})
+	for _, tx := range txs {
+		v, err := json.Marshal(tx)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, &Message{Topic: topicPrefix + txsTopic, Key: tx.ID[:], Value: v})
+	}
+	return msgs, nil
+}
+
+// NewStateDiffMessage encodes the [StateDiff] of [blk] (which must have been
+// executed) into a message for the topic starting with [topicPrefix].
+func NewStateDiffMessage(blk *chain.StatelessBlock, topicPrefix string) (*Message, error) {
+	d := &StateDiff{
+		BlockID: blk.ID(),
+		Height:  blk.Hght,
+		Changes: blk.StateChanges(),
+	}
+	v, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{Topic: topicPrefix + stateTopic, Key: d.BlockID[:], Value: v}, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+func TestBlockEncoding(t *testing.T) {
+	require := require.New(t)
+
+	b := &Block{
+		ID:         ids.GenerateTestID(),
+		Parent:     ids.GenerateTestID(),
+		Height:     10,
+		Timestamp:  1_000,
+		StateRoot:  ids.GenerateTestID(),
+		TxIDs:      []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()},
+		UnitPrices: fees.Dimensions{1, 2, 3, 4, 5},
+	}
+	v, err := json.Marshal(b)
+	require.NoError(err)
+	var decoded Block
+	require.NoError(json.Unmarshal(v, &decoded))
+	require.Equal(*b, decoded)
+}
+
+func TestStateDiffEncoding(t *testing.T) {
+	require := require.New(t)
+
+	d := &StateDiff{
+		BlockID: ids.GenerateTestID(),
+		Height:  10,
+		Changes: []*chain.StateChange{
+			{Key: []byte{1}, Value: []byte{2}},
+			{Key: []byte{3}, Deleted: true},
+		},
+	}
+	v, err := json.Marshal(d)
+	require.NoError(err)
+	var decoded StateDiff
+	require.NoError(json.Unmarshal(v, &decoded))
+	require.Equal(*d, decoded)
+}
+
+func TestNew(t *testing.T) {
+	require := require.New(t)
+
+	cfg := NewDefaultConfig()
+	_, err := New(cfg)
+	require.ErrorIs(err, ErrNoEndpoints)
+
+	cfg.Endpoints = []string{"nats://127.0.0.1:4222"}
+	sink, err := New(cfg)
+	require.NoError(err)
+	require.IsType(&NATSSink{}, sink)
+	require.NoError(sink.Close())
+}
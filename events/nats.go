@@ -0,0 +1,331 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	natsDefaultPort = "4222"
+	natsSID         = "1"
+	natsInboxLen    = 12
+)
+
+var (
+	ErrNATSServer      = errors.New("nats server error")
+	ErrNATSNoHeaders   = errors.New("nats server does not support headers")
+	ErrNATSNoStream    = errors.New("no jetstream stream bound to subject")
+	ErrNATSPayloadSize = errors.New("payload exceeds max nats payload")
+	ErrNATSProtocol    = errors.New("unexpected nats protocol message")
+)
+
+var _ Sink = (*NATSSink)(nil)
+
+// NATSSink publishes messages to NATS JetStream. A message is only considered
+// published once the stream that the subject is bound to acknowledged it.
+//
+// Messages are published with their key as the "Nats-Msg-Id", so streams
+// discard messages that are published again within their duplicate window.
+type NATSSink struct {
+	endpoints []string
+	timeout   time.Duration
+
+	conn       net.Conn
+	r          *bufio.Reader
+	w          *bufio.Writer
+	inbox      string
+	maxPayload int
+	seq        uint64
+}
+
+func NewNATSSink(endpoints []string, timeout time.Duration) *NATSSink {
+	return &NATSSink{endpoints: endpoints, timeout: timeout}
+}
+
+type natsInfo struct {
+	Headers    bool `json:"headers"`
+	MaxPayload int  `json:"max_payload"`
+}
+
+type natsConnect struct {
+	Verbose      bool   `json:"verbose"`
+	Pedantic     bool   `json:"pedantic"`
+	Lang         string `json:"lang"`
+	Name         string `json:"name"`
+	Protocol     int    `json:"protocol"`
+	Headers      bool   `json:"headers"`
+	NoResponders bool   `json:"no_responders"`
+	User         string `json:"user,omitempty"`
+	Pass         string `json:"pass,omitempty"`
+	AuthToken    string `json:"auth_token,omitempty"`
+}
+
+type natsPubAck struct {
+	Stream string `json:"stream"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+func (n *NATSSink) connect(ctx context.Context, deadline time.Time) error {
+	var errs []error
+	for _, endpoint := range n.endpoints {
+		err := n.dial(ctx, endpoint, deadline)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+		n.reset()
+	}
+	return errors.Join(errs...)
+}
+
+func (n *NATSSink) dial(ctx context.Context, endpoint string, deadline time.Time) error {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "nats://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	n.conn = conn
+	n.r = bufio.NewReader(conn)
+	n.w = bufio.NewWriter(conn)
+
+	// The server greets clients with its INFO
+	line, err := n.readLine()
+	if err != nil {
+		return err
+	}
+	op, args, _ := strings.Cut(line, " ")
+	if op != "INFO" {
+		return fmt.Errorf("%w: %s", ErrNATSProtocol, op)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(args), &info); err != nil {
+		return err
+	}
+	if !info.Headers {
+		return ErrNATSNoHeaders
+	}
+	n.maxPayload = info.MaxPayload
+
+	cmsg := natsConnect{
+		Lang:         "go",
+		Name:         "hypersdk",
+		Protocol:     1,
+		Headers:      true,
+		NoResponders: true,
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			cmsg.User = u.User.Username()
+			cmsg.Pass = pass
+		} else {
+			cmsg.AuthToken = u.User.Username()
+		}
+	}
+	connect, err := json.Marshal(cmsg)
+	if err != nil {
+		return err
+	}
+	inbox := make([]byte, natsInboxLen)
+	if _, err := rand.Read(inbox); err != nil {
+		return err
+	}
+	n.inbox = "_INBOX." + hex.EncodeToString(inbox)
+	fmt.Fprintf(n.w, "CONNECT %s\r\nSUB %s.* %s\r\nPING\r\n", connect, n.inbox, natsSID)
+	if err := n.w.Flush(); err != nil {
+		return err
+	}
+
+	// Authorization errors are returned before the PONG
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return err
+		}
+		switch op, args, _ := strings.Cut(line, " "); op {
+		case "PONG":
+			return nil
+		case "PING":
+			if err := n.pong(); err != nil {
+				return err
+			}
+		case "+OK", "INFO":
+		case "-ERR":
+			return fmt.Errorf("%w: %s", ErrNATSServer, args)
+		default:
+			return fmt.Errorf("%w: %s", ErrNATSProtocol, op)
+		}
+	}
+}
+
+func (n *NATSSink) reset() {
+	if n.conn != nil {
+		_ = n.conn.Close()
+	}
+	n.conn = nil
+	n.r = nil
+	n.w = nil
+}
+
+func (n *NATSSink) readLine() (string, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (n *NATSSink) pong() error {
+	if _, err := n.w.WriteString("PONG\r\n"); err != nil {
+		return err
+	}
+	return n.w.Flush()
+}
+
+func (n *NATSSink) Publish(ctx context.Context, msgs []*Message) error {
+	deadline := time.Now().Add(n.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if n.conn == nil {
+		if err := n.connect(ctx, deadline); err != nil {
+			return err
+		}
+	} else if err := n.conn.SetDeadline(deadline); err != nil {
+		n.reset()
+		return err
+	}
+	if err := n.publish(msgs); err != nil {
+		n.reset()
+		return err
+	}
+	return nil
+}
+
+func (n *NATSSink) publish(msgs []*Message) error {
+	pending := make(map[string]struct{}, len(msgs))
+	for _, msg := range msgs {
+		if n.maxPayload > 0 && len(msg.Value) > n.maxPayload {
+			return fmt.Errorf("%w: %d > %d", ErrNATSPayloadSize, len(msg.Value), n.maxPayload)
+		}
+		n.seq++
+		reply := strconv.FormatUint(n.seq, 10)
+		pending[reply] = struct{}{}
+		headers := "NATS/1.0\r\nNats-Msg-Id: " + hex.EncodeToString(msg.Key) + "\r\n\r\n"
+		fmt.Fprintf(
+			n.w,
+			"HPUB %s %s.%s %d %d\r\n%s",
+			msg.Topic,
+			n.inbox,
+			reply,
+			len(headers),
+			len(headers)+len(msg.Value),
+			headers,
+		)
+		if _, err := n.w.Write(msg.Value); err != nil {
+			return err
+		}
+		if _, err := n.w.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+	if err := n.w.Flush(); err != nil {
+		return err
+	}
+
+	// Wait for the acknowledgement of every message
+	for len(pending) > 0 {
+		line, err := n.readLine()
+		if err != nil {
+			return err
+		}
+		op, args, _ := strings.Cut(line, " ")
+		switch op {
+		case "PING":
+			if err := n.pong(); err != nil {
+				return err
+			}
+			continue
+		case "PONG", "+OK", "INFO":
+			continue
+		case "-ERR":
+			return fmt.Errorf("%w: %s", ErrNATSServer, args)
+		case "MSG", "HMSG":
+		default:
+			return fmt.Errorf("%w: %s", ErrNATSProtocol, op)
+		}
+
+		// MSG <subject> <sid> [reply-to] <#bytes>
+		// HMSG <subject> <sid> [reply-to] <#header bytes> <#total bytes>
+		fields := strings.Fields(args)
+		if len(fields) < 3 {
+			return fmt.Errorf("%w: %s", ErrNATSProtocol, line)
+		}
+		size, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return err
+		}
+		payload := make([]byte, size+2)
+		if _, err := io.ReadFull(n.r, payload); err != nil {
+			return err
+		}
+		payload = payload[:size]
+		reply, ok := strings.CutPrefix(fields[0], n.inbox+".")
+		if !ok {
+			continue
+		}
+		delete(pending, reply)
+		if op == "HMSG" {
+			// Status messages (like "503 No Responders") only have headers
+			return ErrNATSNoStream
+		}
+		var ack natsPubAck
+		if err := json.Unmarshal(payload, &ack); err != nil {
+			return err
+		}
+		if ack.Error != nil {
+			return fmt.Errorf("%w: %d %s", ErrNATSServer, ack.Error.Code, ack.Error.Description)
+		}
+	}
+	return nil
+}
+
+func (n *NATSSink) Close() error {
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn = nil
+	return err
+}
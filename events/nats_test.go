@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testNATSServer acknowledges messages published to subjects starting with
+// "events." like a JetStream stream and answers others with "no responders".
+type testNATSServer struct {
+	t        *testing.T
+	listener net.Listener
+
+	l        sync.Mutex
+	msgIDs   []string
+	payloads []string
+}
+
+func newTestNATSServer(t *testing.T) *testNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &testNATSServer{t: t, listener: listener}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *testNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"headers\":true,\"max_payload\":1024}\r\n")
+	seq := 0
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		switch op {
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "HPUB":
+			// HPUB <subject> <reply> <#header bytes> <#total bytes>
+			fields := strings.Fields(args)
+			hdrLen, _ := strconv.Atoi(fields[2])
+			totalLen, _ := strconv.Atoi(fields[3])
+			msg := make([]byte, totalLen+2)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			if !strings.HasPrefix(fields[0], "events.") {
+				fmt.Fprintf(conn, "HMSG %s 1 16 16\r\nNATS/1.0 503\r\n\r\n\r\n", fields[1])
+				continue
+			}
+			_, msgID, _ := strings.Cut(string(msg[:hdrLen]), "Nats-Msg-Id: ")
+			s.l.Lock()
+			s.msgIDs = append(s.msgIDs, strings.TrimSpace(msgID))
+			s.payloads = append(s.payloads, string(msg[hdrLen:totalLen]))
+			s.l.Unlock()
+
+			// Servers may ping clients at any time
+			seq++
+			ack := fmt.Sprintf("{\"stream\":\"EVENTS\",\"seq\":%d}", seq)
+			fmt.Fprintf(conn, "PING\r\nMSG %s 1 %d\r\n%s\r\n", fields[1], len(ack), ack)
+		}
+	}
+}
+
+func (s *testNATSServer) published() ([]string, []string) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return slices.Clone(s.msgIDs), slices.Clone(s.payloads)
+}
+
+func TestNATSSink(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestNATSServer(t)
+	sink := NewNATSSink([]string{"127.0.0.1:1", s.listener.Addr().String()}, time.Second)
+	defer sink.Close()
+
+	ctx := context.Background()
+	require.NoError(sink.Publish(ctx, []*Message{
+		{Topic: "events.blocks", Key: []byte{1}, Value: []byte("block")},
+		{Topic: "events.txs", Key: []byte{2}, Value: []byte("tx")},
+	}))
+	msgIDs, payloads := s.published()
+	require.Equal([]string{"01", "02"}, msgIDs)
+	require.Equal([]string{"block", "tx"}, payloads)
+
+	// Subjects that are not bound to a stream are rejected
+	require.ErrorIs(sink.Publish(ctx, []*Message{
+		{Topic: "other.blocks", Key: []byte{3}, Value: []byte("block")},
+	}), ErrNATSNoStream)
+
+	// The sink reconnects after failures
+	require.ErrorIs(sink.Publish(ctx, []*Message{
+		{Topic: "events.blocks", Key: []byte{4}, Value: make([]byte, 1025)},
+	}), ErrNATSPayloadSize)
+	require.NoError(sink.Publish(ctx, []*Message{
+		{Topic: "events.blocks", Key: []byte{5}, Value: []byte("block")},
+	}))
+	msgIDs, _ = s.published()
+	require.Equal([]string{"01", "02", "05"}, msgIDs)
+}
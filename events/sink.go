@@ -0,0 +1,62 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNoEndpoints = errors.New("no endpoints")
+
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Endpoints are the addresses of the NATS servers
+	// ("nats://[user:password@]host:port") to connect to. Only one needs to
+	// be reachable.
+	Endpoints []string `json:"endpoints"`
+
+	// Events are published to "<TopicPrefix>blocks" and "<TopicPrefix>txs"
+	// (which must already be bound to a JetStream stream).
+	TopicPrefix string `json:"topicPrefix"`
+
+	// StateDiffs also publishes the changes each block made to state to
+	// "<TopicPrefix>state" (see [StateDiff])
+	StateDiffs bool `json:"stateDiffs"`
+
+	// RetryDelay is how long to wait before publishing again after a failure
+	RetryDelay time.Duration `json:"retryDelay"`
+
+	// Timeout bounds how long to wait for the broker to acknowledge a batch
+	// of events
+	Timeout time.Duration `json:"timeout"`
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Enabled:     false,
+		TopicPrefix: "hypersdk.",
+		RetryDelay:  time.Second,
+		Timeout:     10 * time.Second,
+	}
+}
+
+// Sink publishes messages to a broker.
+type Sink interface {
+	// Publish returns once the broker acknowledged all of [msgs]. If it
+	// returns an error, any subset of [msgs] may have been published.
+	Publish(ctx context.Context, msgs []*Message) error
+	Close() error
+}
+
+// New returns a [NATSSink] for [cfg]. Connections are established lazily
+// (and re-established after failures) by [Sink.Publish].
+func New(cfg Config) (Sink, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	return NewNATSSink(cfg.Endpoints, cfg.Timeout), nil
+}
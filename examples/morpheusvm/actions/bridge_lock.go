@@ -0,0 +1,129 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/bridge"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+)
+
+var _ chain.RecipientsAction = (*BridgeLock)(nil)
+
+type BridgeLock struct {
+	// DestinationChainID is the chain [Value] is bridged to.
+	DestinationChainID ids.ID `json:"destinationChainID"`
+
+	// To is the recipient of the wrapped funds on [DestinationChainID].
+	To codec.Address `json:"to"`
+
+	// Value is locked until the wrapped funds are sent back by a
+	// [BridgeRelease].
+	Value uint64 `json:"value"`
+
+	// Fee is locked with [Value] and rewards whoever delivers the transfer on
+	// [DestinationChainID].
+	Fee uint64 `json:"fee"`
+}
+
+func (*BridgeLock) GetTypeID() uint8 {
+	return mconsts.BridgeLockID
+}
+
+func (b *BridgeLock) Recipients() []codec.Address {
+	return []codec.Address{b.To}
+}
+
+func (b *BridgeLock) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(actor)):               state.Read | state.Write,
+		string(storage.LockedKey(b.DestinationChainID)): state.All,
+	}
+}
+
+func (*BridgeLock) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks, storage.LockedChunks}
+}
+
+// Execute returns the ID of the warp message that must be delivered to
+// [DestinationChainID].
+func (b *BridgeLock) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	actionID ids.ID,
+) ([][]byte, error) {
+	if b.Value == 0 {
+		return nil, ErrOutputValueZero
+	}
+	if b.DestinationChainID == r.ChainID() {
+		return nil, ErrOutputBridgeToSelf
+	}
+	transfer := &bridge.Transfer{
+		ActionID:           actionID,
+		DestinationChainID: b.DestinationChainID,
+		Asset:              ids.Empty,
+		Symbol:             []byte(mconsts.Symbol),
+		Decimals:           mconsts.Decimals,
+		To:                 b.To,
+		Value:              b.Value,
+		Fee:                b.Fee,
+	}
+	total, err := transfer.Total()
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SubBalance(ctx, mu, actor, total); err != nil {
+		return nil, err
+	}
+	if err := storage.AddLocked(ctx, mu, b.DestinationChainID, total); err != nil {
+		return nil, err
+	}
+	msg, err := chain.SendWarpMessage(ctx, transfer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	msgID := msg.ID()
+	return [][]byte{msgID[:]}, nil
+}
+
+func (*BridgeLock) ComputeUnits(chain.Rules) uint64 {
+	return BridgeLockComputeUnits
+}
+
+func (*BridgeLock) Size() int {
+	return ids.IDLen + codec.AddressLen + consts.Uint64Len*2
+}
+
+func (b *BridgeLock) Marshal(p *codec.Packer) {
+	p.PackID(b.DestinationChainID)
+	p.PackAddress(b.To)
+	p.PackUint64(b.Value)
+	p.PackUint64(b.Fee)
+}
+
+func UnmarshalBridgeLock(p *codec.Packer) (chain.Action, error) {
+	var lock BridgeLock
+	p.UnpackID(true, &lock.DestinationChainID)
+	p.UnpackAddress(&lock.To)
+	lock.Value = p.UnpackUint64(true)
+	lock.Fee = p.UnpackUint64(false)
+	return &lock, p.Err()
+}
+
+func (*BridgeLock) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
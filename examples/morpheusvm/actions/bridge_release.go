@@ -0,0 +1,121 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/bridge"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+)
+
+var _ chain.WarpAction = (*BridgeRelease)(nil)
+
+// BridgeRelease delivers a [bridge.Transfer] that returns funds locked by a
+// [BridgeLock] to this chain. Anyone (usually a relayer) can deliver it and
+// receives the [bridge.Transfer.Fee] of the transfer.
+type BridgeRelease struct {
+	// Message is the signed transfer sent by the chain the funds were locked
+	// for.
+	Message *warp.Message `json:"message"`
+}
+
+func (*BridgeRelease) GetTypeID() uint8 {
+	return mconsts.BridgeReleaseID
+}
+
+func (b *BridgeRelease) WarpMessage() *warp.Message {
+	return b.Message
+}
+
+func (b *BridgeRelease) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	// Invalid payloads are rejected by [Execute]
+	transfer, err := bridge.UnmarshalTransfer(b.Message.Payload)
+	if err != nil {
+		return state.Keys{}
+	}
+	return state.Keys{
+		string(storage.LockedKey(b.Message.SourceChainID)): state.Read | state.Write,
+		string(storage.BalanceKey(transfer.To)):            state.All,
+		string(storage.BalanceKey(actor)):                  state.All,
+	}
+}
+
+func (*BridgeRelease) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.LockedChunks, storage.BalanceChunks, storage.BalanceChunks, storage.WarpMessageChunks}
+}
+
+func (b *BridgeRelease) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	transfer, err := bridge.ParseTransfer(&b.Message.UnsignedMessage, r.ChainID())
+	if err != nil {
+		return nil, err
+	}
+	if !transfer.Return || transfer.Asset != ids.Empty {
+		return nil, ErrOutputNotBridged
+	}
+
+	total, err := transfer.Total()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only funds locked for the source chain can be released by it
+	if err := storage.SubLocked(ctx, mu, b.Message.SourceChainID, total); err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, transfer.To, transfer.Value, true); err != nil {
+		return nil, err
+	}
+	if transfer.Fee > 0 {
+		if err := storage.AddBalance(ctx, mu, actor, transfer.Fee, true); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (*BridgeRelease) ComputeUnits(chain.Rules) uint64 {
+	return BridgeReleaseComputeUnits
+}
+
+func (b *BridgeRelease) Size() int {
+	return codec.BytesLen(b.Message.Bytes())
+}
+
+func (b *BridgeRelease) Marshal(p *codec.Packer) {
+	p.PackBytes(b.Message.Bytes())
+}
+
+func UnmarshalBridgeRelease(p *codec.Packer) (chain.Action, error) {
+	var msgBytes []byte
+	p.UnpackBytes(MaxWarpMessageSize, true, &msgBytes)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	msg, err := warp.ParseMessage(msgBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeRelease{Message: msg}, nil
+}
+
+func (*BridgeRelease) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
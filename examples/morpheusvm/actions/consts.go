@@ -6,4 +6,21 @@ package actions
 const (
 	TransferComputeUnits = 1
 	MaxMemoSize          = 256
+
+	MaxTransferMultipleRecipients = 32
+
+	NameComputeUnits = 1
+	MinNameLength    = 3
+	MaxNameLength    = 32
+
+	// NamePeriod is the duration (in milliseconds) a name is registered for
+	// each [NameFeePerPeriod] paid.
+	NamePeriod       int64  = 30 * 24 * 60 * 60 * 1000
+	NameFeePerPeriod uint64 = 100_000
+	MaxNamePeriods          = 12
+
+	BridgeLockComputeUnits = 1
+	// Releases verify the aggregate signature of a warp message
+	BridgeReleaseComputeUnits = 10
+	MaxWarpMessageSize        = 2048
 )
@@ -0,0 +1,63 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// ValidName returns true if [name] may be registered. Names are between
+// [MinNameLength] and [MaxNameLength] characters of lowercase letters,
+// digits, and hyphens.
+func ValidName(name []byte) bool {
+	if len(name) < MinNameLength || len(name) > MaxNameLength {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9':
+		case c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NameExpired returns true if a name with [expiry] can no longer be used at
+// [timestamp].
+func NameExpired(expiry int64, timestamp int64) bool {
+	return expiry < timestamp
+}
+
+// chargeNameFee burns the fee for registering a name for [periods] from
+// [actor] and returns the duration that was paid for.
+func chargeNameFee(ctx context.Context, mu state.Mutable, actor codec.Address, periods uint64) (int64, error) {
+	if periods == 0 || periods > MaxNamePeriods {
+		return 0, ErrOutputInvalidPeriods
+	}
+	fee, err := smath.Mul64(NameFeePerPeriod, periods)
+	if err != nil {
+		return 0, err
+	}
+	if err := storage.SubBalance(ctx, mu, actor, fee); err != nil {
+		return 0, err
+	}
+	return NamePeriod * int64(periods), nil
+}
+
+func unpackPeriods(p *codec.Packer) (uint64, error) {
+	periods := p.UnpackUint64(true)
+	if periods > MaxNamePeriods {
+		return 0, ErrOutputInvalidPeriods
+	}
+	return periods, nil
+}
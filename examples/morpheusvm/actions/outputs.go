@@ -6,6 +6,17 @@ package actions
 import "errors"
 
 var (
-	ErrOutputValueZero    = errors.New("value is zero")
-	ErrOutputMemoTooLarge = errors.New("memo is too large")
+	ErrOutputValueZero         = errors.New("value is zero")
+	ErrOutputMemoTooLarge      = errors.New("memo is too large")
+	ErrOutputNoRecipients      = errors.New("no recipients provided")
+	ErrOutputTooManyRecipients = errors.New("too many recipients")
+	ErrOutputValuesMisaligned  = errors.New("recipients and values are misaligned")
+	ErrOutputInvalidName       = errors.New("invalid name")
+	ErrOutputInvalidPeriods    = errors.New("invalid number of periods")
+	ErrOutputNameTaken         = errors.New("name is already registered")
+	ErrOutputNameMissing       = errors.New("name is not registered")
+	ErrOutputNameExpiryTooLong = errors.New("name expiry is too far in the future")
+	ErrOutputWrongNameOwner    = errors.New("wrong name owner")
+	ErrOutputBridgeToSelf      = errors.New("cannot bridge to the same chain")
+	ErrOutputNotBridged        = errors.New("transfer does not return bridged funds")
 )
@@ -0,0 +1,100 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+)
+
+var _ chain.Action = (*RegisterName)(nil)
+
+type RegisterName struct {
+	// Name to register to the actor. Expired names may be registered again.
+	Name []byte `json:"name"`
+
+	// Periods is the number of [NamePeriod] to pay for.
+	Periods uint64 `json:"periods"`
+}
+
+func (*RegisterName) GetTypeID() uint8 {
+	return mconsts.RegisterNameID
+}
+
+func (r *RegisterName) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.NameKey(r.Name)):   state.All,
+		string(storage.BalanceKey(actor)): state.Read | state.Write,
+	}
+}
+
+func (*RegisterName) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.NameChunks, storage.BalanceChunks}
+}
+
+func (r *RegisterName) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if !ValidName(r.Name) {
+		return nil, ErrOutputInvalidName
+	}
+	exists, _, expiry, err := storage.GetName(ctx, mu, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists && !NameExpired(expiry, timestamp) {
+		return nil, ErrOutputNameTaken
+	}
+	duration, err := chargeNameFee(ctx, mu, actor, r.Periods)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetName(ctx, mu, r.Name, actor, timestamp+duration); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*RegisterName) ComputeUnits(chain.Rules) uint64 {
+	return NameComputeUnits
+}
+
+func (r *RegisterName) Size() int {
+	return codec.BytesLen(r.Name) + consts.Uint64Len
+}
+
+func (r *RegisterName) Marshal(p *codec.Packer) {
+	p.PackBytes(r.Name)
+	p.PackUint64(r.Periods)
+}
+
+func UnmarshalRegisterName(p *codec.Packer) (chain.Action, error) {
+	var register RegisterName
+	p.UnpackBytes(MaxNameLength, true, &register.Name)
+	periods, err := unpackPeriods(p)
+	if err != nil {
+		return nil, err
+	}
+	register.Periods = periods
+	return &register, p.Err()
+}
+
+func (*RegisterName) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
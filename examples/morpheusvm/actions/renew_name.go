@@ -0,0 +1,102 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+)
+
+var _ chain.Action = (*RenewName)(nil)
+
+type RenewName struct {
+	// Name to extend the registration of. Anyone may renew a name but it
+	// must not be expired.
+	Name []byte `json:"name"`
+
+	// Periods is the number of [NamePeriod] to add to the expiry of [Name].
+	Periods uint64 `json:"periods"`
+}
+
+func (*RenewName) GetTypeID() uint8 {
+	return mconsts.RenewNameID
+}
+
+func (r *RenewName) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.NameKey(r.Name)):   state.Read | state.Write,
+		string(storage.BalanceKey(actor)): state.Read | state.Write,
+	}
+}
+
+func (*RenewName) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.NameChunks, storage.BalanceChunks}
+}
+
+func (r *RenewName) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, owner, expiry, err := storage.GetName(ctx, mu, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || NameExpired(expiry, timestamp) {
+		return nil, ErrOutputNameMissing
+	}
+	duration, err := chargeNameFee(ctx, mu, actor, r.Periods)
+	if err != nil {
+		return nil, err
+	}
+	newExpiry := expiry + duration
+	if newExpiry > timestamp+NamePeriod*MaxNamePeriods {
+		return nil, ErrOutputNameExpiryTooLong
+	}
+	if err := storage.SetName(ctx, mu, r.Name, owner, newExpiry); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*RenewName) ComputeUnits(chain.Rules) uint64 {
+	return NameComputeUnits
+}
+
+func (r *RenewName) Size() int {
+	return codec.BytesLen(r.Name) + consts.Uint64Len
+}
+
+func (r *RenewName) Marshal(p *codec.Packer) {
+	p.PackBytes(r.Name)
+	p.PackUint64(r.Periods)
+}
+
+func UnmarshalRenewName(p *codec.Packer) (chain.Action, error) {
+	var renew RenewName
+	p.UnpackBytes(MaxNameLength, true, &renew.Name)
+	periods, err := unpackPeriods(p)
+	if err != nil {
+		return nil, err
+	}
+	renew.Periods = periods
+	return &renew, p.Err()
+}
+
+func (*RenewName) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
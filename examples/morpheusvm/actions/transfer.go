@@ -17,7 +17,7 @@ import (
 	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var _ chain.RecipientsAction = (*Transfer)(nil)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -34,6 +34,10 @@ func (*Transfer) GetTypeID() uint8 {
 	return mconsts.TransferID
 }
 
+func (t *Transfer) Recipients() []codec.Address {
+	return []codec.Address{t.To}
+}
+
 func (t *Transfer) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
 	return state.Keys{
 		string(storage.BalanceKey(actor)): state.Read | state.Write,
@@ -0,0 +1,193 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+)
+
+var _ chain.RecipientsAction = (*TransferMultiple)(nil)
+
+type TransferMultiple struct {
+	// To are the recipients of [Values]. A recipient may appear more than
+	// once.
+	To []codec.Address `json:"to"`
+
+	// Values[i] is transferred to To[i].
+	Values []uint64 `json:"values"`
+
+	// Optional message to accompany transaction.
+	Memo []byte `json:"memo"`
+}
+
+func (*TransferMultiple) GetTypeID() uint8 {
+	return mconsts.TransferMultipleID
+}
+
+func (t *TransferMultiple) Recipients() []codec.Address {
+	return t.To
+}
+
+func (t *TransferMultiple) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	keys := make(state.Keys, 1+len(t.To))
+	keys.Add(string(storage.BalanceKey(actor)), state.Read|state.Write)
+	for _, to := range t.To {
+		keys.Add(string(storage.BalanceKey(to)), state.All)
+	}
+	return keys
+}
+
+func (t *TransferMultiple) StateKeysMaxChunks() []uint16 {
+	chunks := make([]uint16, 0, 1+len(t.To))
+	chunks = append(chunks, storage.BalanceChunks)
+	for range t.To {
+		chunks = append(chunks, storage.BalanceChunks)
+	}
+	return chunks
+}
+
+func (t *TransferMultiple) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if len(t.To) == 0 {
+		return nil, ErrOutputNoRecipients
+	}
+	if len(t.To) > MaxTransferMultipleRecipients {
+		return nil, ErrOutputTooManyRecipients
+	}
+	if len(t.To) != len(t.Values) {
+		return nil, ErrOutputValuesMisaligned
+	}
+	if len(t.Memo) > MaxMemoSize {
+		return nil, ErrOutputMemoTooLarge
+	}
+
+	// Debit the total from the sender before crediting any recipient so that
+	// an insufficient balance fails the action without partial effects.
+	var total uint64
+	for _, value := range t.Values {
+		if value == 0 {
+			return nil, ErrOutputValueZero
+		}
+		ntotal, err := smath.Add64(total, value)
+		if err != nil {
+			return nil, err
+		}
+		total = ntotal
+	}
+	if err := storage.SubBalance(ctx, mu, actor, total); err != nil {
+		return nil, err
+	}
+	result := &TransferMultipleResult{Balances: make([]uint64, len(t.To))}
+	for i, to := range t.To {
+		if err := storage.AddBalance(ctx, mu, to, t.Values[i], true); err != nil {
+			return nil, err
+		}
+		balance, err := storage.GetBalance(ctx, mu, to)
+		if err != nil {
+			return nil, err
+		}
+		result.Balances[i] = balance
+	}
+	output, err := result.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{output}, nil
+}
+
+func (t *TransferMultiple) ComputeUnits(chain.Rules) uint64 {
+	return TransferComputeUnits * uint64(len(t.To))
+}
+
+func (t *TransferMultiple) Size() int {
+	return consts.IntLen + codec.AddressLen*len(t.To) +
+		consts.IntLen + consts.Uint64Len*len(t.Values) +
+		codec.BytesLen(t.Memo)
+}
+
+func (t *TransferMultiple) Marshal(p *codec.Packer) {
+	p.PackInt(len(t.To))
+	for _, to := range t.To {
+		p.PackAddress(to)
+	}
+	p.PackInt(len(t.Values))
+	for _, value := range t.Values {
+		p.PackUint64(value)
+	}
+	p.PackBytes(t.Memo)
+}
+
+func UnmarshalTransferMultiple(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferMultiple
+	recipients := p.UnpackInt(true)
+	if recipients > MaxTransferMultipleRecipients {
+		return nil, ErrOutputTooManyRecipients
+	}
+	transfer.To = make([]codec.Address, recipients)
+	for i := range transfer.To {
+		p.UnpackAddress(&transfer.To[i])
+	}
+	values := p.UnpackInt(true)
+	if values != recipients {
+		return nil, ErrOutputValuesMisaligned
+	}
+	transfer.Values = make([]uint64, values)
+	for i := range transfer.Values {
+		transfer.Values[i] = p.UnpackUint64(true)
+	}
+	p.UnpackBytes(MaxMemoSize, false, &transfer.Memo)
+	return &transfer, p.Err()
+}
+
+func (*TransferMultiple) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// TransferMultipleResult is the output of a successful [TransferMultiple].
+//
+// Balances[i] is the balance of To[i] after the transfer.
+type TransferMultipleResult struct {
+	Balances []uint64 `json:"balances"`
+}
+
+func UnmarshalTransferMultipleResult(b []byte) (*TransferMultipleResult, error) {
+	p := codec.NewReader(b, consts.IntLen+consts.Uint64Len*MaxTransferMultipleRecipients)
+	count := p.UnpackInt(true)
+	if count > MaxTransferMultipleRecipients {
+		return nil, ErrOutputTooManyRecipients
+	}
+	result := &TransferMultipleResult{Balances: make([]uint64, count)}
+	for i := range result.Balances {
+		result.Balances[i] = p.UnpackUint64(false)
+	}
+	return result, p.Err()
+}
+
+func (r *TransferMultipleResult) Marshal() ([]byte, error) {
+	size := consts.IntLen + consts.Uint64Len*len(r.Balances)
+	p := codec.NewWriter(size, size)
+	p.PackInt(len(r.Balances))
+	for _, balance := range r.Balances {
+		p.PackUint64(balance)
+	}
+	return p.Bytes(), p.Err()
+}
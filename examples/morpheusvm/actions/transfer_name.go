@@ -0,0 +1,94 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	mconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+)
+
+var _ chain.RecipientsAction = (*TransferName)(nil)
+
+type TransferName struct {
+	// Name owned by the actor to transfer. The expiry of [Name] is unchanged.
+	Name []byte `json:"name"`
+
+	// To is the new owner of [Name].
+	To codec.Address `json:"to"`
+}
+
+func (*TransferName) GetTypeID() uint8 {
+	return mconsts.TransferNameID
+}
+
+func (t *TransferName) Recipients() []codec.Address {
+	return []codec.Address{t.To}
+}
+
+func (t *TransferName) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.NameKey(t.Name)): state.Read | state.Write,
+	}
+}
+
+func (*TransferName) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.NameChunks}
+}
+
+func (t *TransferName) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, owner, expiry, err := storage.GetName(ctx, mu, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || NameExpired(expiry, timestamp) {
+		return nil, ErrOutputNameMissing
+	}
+	if owner != actor {
+		return nil, ErrOutputWrongNameOwner
+	}
+	if err := storage.SetName(ctx, mu, t.Name, t.To, expiry); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*TransferName) ComputeUnits(chain.Rules) uint64 {
+	return NameComputeUnits
+}
+
+func (t *TransferName) Size() int {
+	return codec.BytesLen(t.Name) + codec.AddressLen
+}
+
+func (t *TransferName) Marshal(p *codec.Packer) {
+	p.PackBytes(t.Name)
+	p.PackAddress(t.To)
+}
+
+func UnmarshalTransferName(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferName
+	p.UnpackBytes(MaxNameLength, true, &transfer.Name)
+	p.UnpackAddress(&transfer.To)
+	return &transfer, p.Err()
+}
+
+func (*TransferName) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -5,12 +5,18 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/utils"
 )
 
 var actionCmd = &cobra.Command{
@@ -20,44 +26,346 @@ var actionCmd = &cobra.Command{
 	},
 }
 
-var transferCmd = &cobra.Command{
-	Use: "transfer",
-	RunE: func(*cobra.Command, []string) error {
-		ctx := context.Background()
-		_, priv, factory, cli, bcli, ws, err := handler.DefaultActor()
-		if err != nil {
-			return err
-		}
+// actionPlugins are registered with the cli handler and each is exposed as
+// an "action" subcommand.
+var actionPlugins = []cli.ActionPlugin{
+	&transferPlugin{},
+	&transferMultiplePlugin{},
+	&registerNamePlugin{},
+	&renewNamePlugin{},
+	&transferNamePlugin{},
+	&bridgeLockPlugin{},
+}
+
+func newActionCmd(p cli.ActionPlugin) *cobra.Command {
+	return &cobra.Command{
+		Use: p.Name(),
+		RunE: func(*cobra.Command, []string) error {
+			ctx := context.Background()
+			_, priv, factory, cli, bcli, ws, err := handler.DefaultActor()
+			if err != nil {
+				return err
+			}
+			actions, err := handler.Root().PromptAction(ctx, p.Name(), priv.Address)
+			if len(actions) == 0 || err != nil {
+				return err
+			}
 
-		// Get balance info
-		balance, err := handler.GetBalance(ctx, bcli, priv.Address)
-		if balance == 0 || err != nil {
+			// Generate transaction
+			_, _, err = sendAndWait(ctx, actions, cli, bcli, ws, factory, true)
 			return err
-		}
+		},
+	}
+}
 
-		// Select recipient
-		recipient, err := handler.Root().PromptAddress("recipient")
+func formatMemo(memo []byte) string {
+	if len(memo) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (memo: %s)", memo)
+}
+
+// promptBalance prints the balance of [actor] and returns 0 if it has no
+// funds to send.
+func promptBalance(ctx context.Context, actor codec.Address) (uint64, error) {
+	_, _, _, bcli, err := defaultClients()
+	if err != nil {
+		return 0, err
+	}
+	return handler.GetBalance(ctx, bcli, actor)
+}
+
+type transferPlugin struct{}
+
+func (*transferPlugin) Name() string {
+	return "transfer"
+}
+
+func (*transferPlugin) TypeID() uint8 {
+	return consts.TransferID
+}
+
+func (*transferPlugin) Prompt(ctx context.Context, h *cli.Handler, actor codec.Address) ([]chain.Action, error) {
+	// Get balance info
+	balance, err := promptBalance(ctx, actor)
+	if balance == 0 || err != nil {
+		return nil, err
+	}
+
+	// Select recipient
+	recipient, err := h.PromptAddress("recipient")
+	if err != nil {
+		return nil, err
+	}
+
+	// Select amount
+	amount, err := h.PromptAmount("amount", consts.Decimals, balance, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select memo
+	memo, err := h.PromptString("memo", 0, actions.MaxMemoSize)
+	if err != nil {
+		return nil, err
+	}
+	return []chain.Action{&actions.Transfer{
+		To:    recipient,
+		Value: amount,
+		Memo:  []byte(memo),
+	}}, nil
+}
+
+func (*transferPlugin) Summary(action chain.Action) string {
+	act := action.(*actions.Transfer)
+	return fmt.Sprintf(
+		"%s %s -> %s%s",
+		utils.FormatBalance(act.Value, consts.Decimals),
+		consts.Symbol,
+		handler.Root().FormatAddress(act.To),
+		formatMemo(act.Memo),
+	)
+}
+
+type transferMultiplePlugin struct{}
+
+func (*transferMultiplePlugin) Name() string {
+	return "transfer-multiple"
+}
+
+func (*transferMultiplePlugin) TypeID() uint8 {
+	return consts.TransferMultipleID
+}
+
+func (*transferMultiplePlugin) Prompt(ctx context.Context, h *cli.Handler, actor codec.Address) ([]chain.Action, error) {
+	// Get balance info
+	balance, err := promptBalance(ctx, actor)
+	if balance == 0 || err != nil {
+		return nil, err
+	}
+
+	// Select recipients and amounts
+	recipients, err := h.PromptInt("recipients", actions.MaxTransferMultipleRecipients)
+	if err != nil {
+		return nil, err
+	}
+	transfer := &actions.TransferMultiple{
+		To:     make([]codec.Address, recipients),
+		Values: make([]uint64, recipients),
+	}
+	for i := 0; i < recipients; i++ {
+		transfer.To[i], err = h.PromptAddress(fmt.Sprintf("recipient %d", i))
 		if err != nil {
-			return err
+			return nil, err
 		}
-
-		// Select amount
-		amount, err := handler.Root().PromptAmount("amount", consts.Decimals, balance, nil)
+		transfer.Values[i], err = h.PromptAmount(fmt.Sprintf("amount %d", i), consts.Decimals, balance, nil)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		balance -= transfer.Values[i]
+	}
 
-		// Confirm action
-		cont, err := handler.Root().PromptContinue()
-		if !cont || err != nil {
-			return err
-		}
+	// Select memo
+	memo, err := h.PromptString("memo", 0, actions.MaxMemoSize)
+	if err != nil {
+		return nil, err
+	}
+	transfer.Memo = []byte(memo)
+	return []chain.Action{transfer}, nil
+}
 
-		// Generate transaction
-		_, _, err = sendAndWait(ctx, []chain.Action{&actions.Transfer{
-			To:    recipient,
-			Value: amount,
-		}}, cli, bcli, ws, factory, true)
-		return err
-	},
+func (*transferMultiplePlugin) Summary(action chain.Action) string {
+	act := action.(*actions.TransferMultiple)
+	var total uint64
+	for _, value := range act.Values {
+		total += value
+	}
+	return fmt.Sprintf(
+		"%s %s -> %d recipients%s",
+		utils.FormatBalance(total, consts.Decimals),
+		consts.Symbol,
+		len(act.To),
+		formatMemo(act.Memo),
+	)
+}
+
+type registerNamePlugin struct{}
+
+func (*registerNamePlugin) Name() string {
+	return "register-name"
+}
+
+func (*registerNamePlugin) TypeID() uint8 {
+	return consts.RegisterNameID
+}
+
+func (*registerNamePlugin) Prompt(_ context.Context, h *cli.Handler, _ codec.Address) ([]chain.Action, error) {
+	// Select name
+	name, err := h.PromptString("name", actions.MinNameLength, actions.MaxNameLength)
+	if err != nil {
+		return nil, err
+	}
+	if !actions.ValidName([]byte(name)) {
+		return nil, actions.ErrOutputInvalidName
+	}
+
+	// Select periods
+	periods, err := h.PromptInt("periods", actions.MaxNamePeriods)
+	if err != nil {
+		return nil, err
+	}
+	return []chain.Action{&actions.RegisterName{
+		Name:    []byte(name),
+		Periods: uint64(periods),
+	}}, nil
+}
+
+func (*registerNamePlugin) Summary(action chain.Action) string {
+	act := action.(*actions.RegisterName)
+	return fmt.Sprintf("name: %s periods: %d", act.Name, act.Periods)
+}
+
+type renewNamePlugin struct{}
+
+func (*renewNamePlugin) Name() string {
+	return "renew-name"
+}
+
+func (*renewNamePlugin) TypeID() uint8 {
+	return consts.RenewNameID
+}
+
+func (*renewNamePlugin) Prompt(ctx context.Context, h *cli.Handler, _ codec.Address) ([]chain.Action, error) {
+	_, _, _, bcli, err := defaultClients()
+	if err != nil {
+		return nil, err
+	}
+
+	// Select name
+	name, err := h.PromptString("name", actions.MinNameLength, actions.MaxNameLength)
+	if err != nil {
+		return nil, err
+	}
+	exists, _, expiry, err := bcli.ResolveName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		utils.Outf("{{red}}%s is not registered{{/}}\n", name)
+		return nil, nil
+	}
+	utils.Outf("{{yellow}}expiry:{{/}} %s\n", time.UnixMilli(expiry).Format(time.RFC3339))
+
+	// Select periods
+	periods, err := h.PromptInt("periods", actions.MaxNamePeriods)
+	if err != nil {
+		return nil, err
+	}
+	return []chain.Action{&actions.RenewName{
+		Name:    []byte(name),
+		Periods: uint64(periods),
+	}}, nil
+}
+
+func (*renewNamePlugin) Summary(action chain.Action) string {
+	act := action.(*actions.RenewName)
+	return fmt.Sprintf("name: %s periods: %d", act.Name, act.Periods)
+}
+
+type transferNamePlugin struct{}
+
+func (*transferNamePlugin) Name() string {
+	return "transfer-name"
+}
+
+func (*transferNamePlugin) TypeID() uint8 {
+	return consts.TransferNameID
+}
+
+func (*transferNamePlugin) Prompt(_ context.Context, h *cli.Handler, _ codec.Address) ([]chain.Action, error) {
+	// Select name
+	name, err := h.PromptString("name", actions.MinNameLength, actions.MaxNameLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select recipient
+	recipient, err := h.PromptAddress("recipient")
+	if err != nil {
+		return nil, err
+	}
+	return []chain.Action{&actions.TransferName{
+		Name: []byte(name),
+		To:   recipient,
+	}}, nil
+}
+
+func (*transferNamePlugin) Summary(action chain.Action) string {
+	act := action.(*actions.TransferName)
+	return fmt.Sprintf("name: %s -> %s", act.Name, handler.Root().FormatAddress(act.To))
+}
+
+type bridgeLockPlugin struct{}
+
+func (*bridgeLockPlugin) Name() string {
+	return "bridge-lock"
+}
+
+func (*bridgeLockPlugin) TypeID() uint8 {
+	return consts.BridgeLockID
+}
+
+func (*bridgeLockPlugin) Prompt(ctx context.Context, h *cli.Handler, actor codec.Address) ([]chain.Action, error) {
+	// Get balance info
+	balance, err := promptBalance(ctx, actor)
+	if balance == 0 || err != nil {
+		return nil, err
+	}
+
+	// Select destination
+	chainID, _, err := h.GetDefaultChain(false)
+	if err != nil {
+		return nil, err
+	}
+	destination, _, err := h.PromptChain("destination chainID", set.Of(chainID))
+	if err != nil {
+		return nil, err
+	}
+
+	// Select recipient
+	recipient, err := h.PromptAddress("recipient")
+	if err != nil {
+		return nil, err
+	}
+
+	// Select amount
+	amount, err := h.PromptAmount("amount", consts.Decimals, balance, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select reward of the relayer
+	fee, err := h.PromptAmount("relay fee", consts.Decimals, balance-amount, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []chain.Action{&actions.BridgeLock{
+		DestinationChainID: destination,
+		To:                 recipient,
+		Value:              amount,
+		Fee:                fee,
+	}}, nil
+}
+
+func (*bridgeLockPlugin) Summary(action chain.Action) string {
+	act := action.(*actions.BridgeLock)
+	return fmt.Sprintf(
+		"%s %s -> %s on %s (relay fee: %s %s)",
+		utils.FormatBalance(act.Value, consts.Decimals),
+		consts.Symbol,
+		consts.AddressFormat.Encode(act.To),
+		act.DestinationChainID,
+		utils.FormatBalance(act.Fee, consts.Decimals),
+		consts.Symbol,
+	)
 }
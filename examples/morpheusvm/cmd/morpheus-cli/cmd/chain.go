@@ -5,11 +5,13 @@ package cmd
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli"
 
 	brpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
 )
@@ -62,12 +64,54 @@ var chainInfoCmd = &cobra.Command{
 	},
 }
 
+var unitProfileChainCmd = &cobra.Command{
+	Use: "unit-profile [start] [end]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		start, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		end, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		return handler.Root().PrintUnitProfile(start, end)
+	},
+}
+
 var watchChainCmd = &cobra.Command{
 	Use: "watch",
 	RunE: func(_ *cobra.Command, args []string) error {
-		return handler.Root().WatchChain(hideTxs, func(uri string, networkID uint32, chainID ids.ID) (chain.Parser, error) {
+		types, err := cli.ParseActionTypes(filterActions, handler.Root().ActionTypes())
+		if err != nil {
+			return err
+		}
+		filter, err := handler.Root().ParseTxFilter(filterAddresses, types, filterMinFee, filterFailedOnly)
+		if err != nil {
+			return err
+		}
+		return handler.Root().WatchChain(hideTxs, filter, func(uri string, networkID uint32, chainID ids.ID) (chain.Parser, error) {
 			cli := brpc.NewJSONRPCClient(uri, networkID, chainID)
 			return cli.Parser(context.TODO())
 		}, handleTx)
 	},
 }
+
+var dashboardChainCmd = &cobra.Command{
+	Use: "dashboard",
+	RunE: func(*cobra.Command, []string) error {
+		var cli *brpc.JSONRPCClient
+		return handler.Root().Dashboard(watchAddresses, func(uri string, networkID uint32, chainID ids.ID) (chain.Parser, error) {
+			cli = brpc.NewJSONRPCClient(uri, networkID, chainID)
+			return cli.Parser(context.TODO())
+		}, func(ctx context.Context, _ string, _ uint32, _ ids.ID, addr string) (uint64, error) {
+			return cli.Balance(ctx, addr)
+		})
+	},
+}
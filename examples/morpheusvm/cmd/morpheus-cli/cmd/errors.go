@@ -10,4 +10,7 @@ var (
 	ErrMissingSubcommand = errors.New("must specify a subcommand")
 	ErrInvalidAddress    = errors.New("invalid address")
 	ErrInvalidKeyType    = errors.New("invalid key type")
+	ErrWrongChain        = errors.New("tx was built for a different chain")
+	ErrInvalidFormat     = errors.New("invalid format")
+	ErrTxNotIndexed      = errors.New("tx not indexed (is storeTransactions enabled?)")
 )
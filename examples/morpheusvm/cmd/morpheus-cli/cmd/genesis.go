@@ -12,6 +12,9 @@ import (
 
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
 	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
+
+	hgenesis "github.com/ava-labs/hypersdk/genesis"
 )
 
 var genesisCmd = &cobra.Command{
@@ -78,3 +81,38 @@ var genGenesisCmd = &cobra.Command{
 		return nil
 	},
 }
+
+var buildGenesisCmd = &cobra.Command{
+	Use:   "build [spec file] [options]",
+	Short: "Builds a genesis from a spec and saves it in the default location",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		spec, err := hgenesis.LoadSpec(args[0])
+		if err != nil {
+			return err
+		}
+		g, err := hgenesis.Build[*genesis.Genesis](genesis.Builder{}, spec)
+		if err != nil {
+			return err
+		}
+		for _, p := range hgenesis.Describe(genesis.Builder{}.Rules(g)) {
+			utils.Outf("{{yellow}}%s:{{/}} %v\n", p.Name, p.Value)
+		}
+		utils.Outf("{{yellow}}allocations:{{/}} %d\n", len(g.CustomAllocation))
+
+		b, err := json.Marshal(g)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(genesisFile, b, fsModeWrite); err != nil {
+			return err
+		}
+		color.Green("created genesis and saved to %s", genesisFile)
+		return nil
+	},
+}
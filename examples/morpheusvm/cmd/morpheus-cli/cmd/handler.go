@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/auth"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli"
@@ -45,20 +46,9 @@ func (h *Handler) DefaultActor() (
 	if err != nil {
 		return ids.Empty, nil, nil, nil, nil, nil, err
 	}
-	var factory chain.AuthFactory
-	switch addr[0] {
-	case auth.ED25519ID:
-		factory = auth.NewED25519Factory(ed25519.PrivateKey(priv))
-	case auth.SECP256R1ID:
-		factory = auth.NewSECP256R1Factory(secp256r1.PrivateKey(priv))
-	case auth.BLSID:
-		p, err := bls.PrivateKeyFromBytes(priv)
-		if err != nil {
-			return ids.Empty, nil, nil, nil, nil, nil, err
-		}
-		factory = auth.NewBLSFactory(p)
-	default:
-		return ids.Empty, nil, nil, nil, nil, nil, ErrInvalidAddress
+	factory, err := getFactory(addr, priv)
+	if err != nil {
+		return ids.Empty, nil, nil, nil, nil, nil, err
 	}
 	chainID, uris, err := h.h.GetDefaultChain(true)
 	if err != nil {
@@ -85,15 +75,44 @@ func (h *Handler) DefaultActor() (
 		), ws, nil
 }
 
+func getFactory(addr codec.Address, priv []byte) (chain.AuthFactory, error) {
+	switch addr[0] {
+	case auth.ED25519ID:
+		return auth.NewED25519Factory(ed25519.PrivateKey(priv)), nil
+	case auth.SECP256R1ID:
+		return auth.NewSECP256R1Factory(secp256r1.PrivateKey(priv)), nil
+	case auth.BLSID:
+		p, err := bls.PrivateKeyFromBytes(priv)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewBLSFactory(p), nil
+	default:
+		return nil, ErrInvalidAddress
+	}
+}
+
+// estimationFactory returns a factory that can estimate the units of a
+// transaction signed by [addr] without access to its private key.
+func estimationFactory(addr codec.Address) (chain.AuthFactory, error) {
+	switch addr[0] {
+	case auth.ED25519ID:
+		return &auth.ED25519Factory{}, nil
+	case auth.SECP256R1ID:
+		return &auth.SECP256R1Factory{}, nil
+	case auth.BLSID:
+		return &auth.BLSFactory{}, nil
+	default:
+		return nil, ErrInvalidAddress
+	}
+}
+
 func (*Handler) GetBalance(
 	ctx context.Context,
 	cli *brpc.JSONRPCClient,
 	addr codec.Address,
 ) (uint64, error) {
-	saddr, err := codec.AddressBech32(consts.HRP, addr)
-	if err != nil {
-		return 0, err
-	}
+	saddr := consts.AddressFormat.Encode(addr)
 	balance, err := cli.Balance(ctx, saddr)
 	if err != nil {
 		return 0, err
@@ -112,18 +131,29 @@ func (*Handler) GetBalance(
 	return balance, nil
 }
 
+var _ cli.AuditController = (*Controller)(nil)
+
 type Controller struct {
 	databasePath string
+	auditPath    string
 }
 
-func NewController(databasePath string) *Controller {
-	return &Controller{databasePath}
+func NewController(databasePath string, auditPath string) *Controller {
+	return &Controller{databasePath, auditPath}
 }
 
 func (c *Controller) DatabasePath() string {
 	return c.databasePath
 }
 
+// AuditConfig enables the audit log if an audit path was provided.
+func (c *Controller) AuditConfig() audit.Config {
+	config := audit.NewDefaultConfig()
+	config.Enabled = len(c.auditPath) > 0
+	config.Directory = c.auditPath
+	return config
+}
+
 func (*Controller) Symbol() string {
 	return consts.Symbol
 }
@@ -133,9 +163,9 @@ func (*Controller) Decimals() uint8 {
 }
 
 func (*Controller) Address(addr codec.Address) string {
-	return codec.MustAddressBech32(consts.HRP, addr)
+	return consts.AddressFormat.Encode(addr)
 }
 
 func (*Controller) ParseAddress(addr string) (codec.Address, error) {
-	return codec.ParseAddressBech32(consts.HRP, addr)
+	return consts.AddressFormat.Parse(addr)
 }
@@ -0,0 +1,185 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/utils"
+
+	brpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+)
+
+const (
+	csvFormat  = "csv"
+	jsonFormat = "json"
+
+	receiveRecord = "receive"
+	sendRecord    = "send"
+	feeRecord     = "fee"
+)
+
+// historyRecord is a single row of an exported account history. Amounts are
+// formatted as decimal strings so that they are not rounded by tools that
+// parse numbers as floats.
+type historyRecord struct {
+	TxID         ids.ID `json:"txId"`
+	Time         string `json:"time"`
+	Type         string `json:"type"`
+	Counterparty string `json:"counterparty"`
+	Amount       string `json:"amount"`
+	Fee          string `json:"fee"`
+	Symbol       string `json:"symbol"`
+	Success      bool   `json:"success"`
+}
+
+var historyHeader = []string{"txId", "time", "type", "counterparty", "amount", "fee", "symbol", "success"}
+
+func (r *historyRecord) csv() []string {
+	return []string{
+		r.TxID.String(),
+		r.Time,
+		r.Type,
+		r.Counterparty,
+		r.Amount,
+		r.Fee,
+		r.Symbol,
+		strconv.FormatBool(r.Success),
+	}
+}
+
+// getHistory pages through the history of [addr] and returns a record for
+// each entry.
+//
+// The fee of each transaction sent by [addr] is looked up with [brpc.Tx] and
+// attributed to the first record of that transaction, so summing the fee
+// column gives the total fees paid.
+func getHistory(ctx context.Context, bcli *brpc.JSONRPCClient, addr string) ([]*historyRecord, error) {
+	var (
+		records = []*historyRecord{}
+		feePaid = map[ids.ID]struct{}{}
+		cursor  []byte
+	)
+	for {
+		entries, next, err := bcli.History(ctx, addr, cursor, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			record := &historyRecord{
+				TxID:         entry.TxID,
+				Time:         time.UnixMilli(entry.Timestamp).UTC().Format(time.RFC3339),
+				Counterparty: entry.Counterparty,
+				Amount:       utils.FormatBalance(entry.Amount, consts.Decimals),
+				Fee:          utils.FormatBalance(0, consts.Decimals),
+				Symbol:       consts.Symbol,
+				Success:      true,
+			}
+			switch {
+			case entry.Incoming:
+				record.Type = receiveRecord
+			case len(entry.Counterparty) == 0:
+				record.Type = feeRecord
+			default:
+				record.Type = sendRecord
+			}
+			if _, ok := feePaid[entry.TxID]; !entry.Incoming && !ok {
+				found, success, _, fee, err := bcli.Tx(ctx, entry.TxID)
+				if err != nil {
+					return nil, err
+				}
+				if !found {
+					return nil, fmt.Errorf("%w: %s", ErrTxNotIndexed, entry.TxID)
+				}
+				record.Fee = utils.FormatBalance(fee, consts.Decimals)
+				record.Success = success
+				feePaid[entry.TxID] = struct{}{}
+			}
+			records = append(records, record)
+		}
+		if next == nil {
+			return records, nil
+		}
+		cursor = next
+	}
+}
+
+func marshalHistory(records []*historyRecord, format string) ([]byte, error) {
+	switch format {
+	case csvFormat:
+		var b bytes.Buffer
+		w := csv.NewWriter(&b)
+		if err := w.Write(historyHeader); err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			if err := w.Write(record.csv()); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		return b.Bytes(), w.Error()
+	case jsonFormat:
+		return json.MarshalIndent(records, "", "  ")
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFormat, format)
+	}
+}
+
+var exportHistoryCmd = &cobra.Command{
+	Use: "export-history [path]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		if historyFormat != csvFormat && historyFormat != jsonFormat {
+			return fmt.Errorf("%w: %s", ErrInvalidFormat, historyFormat)
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var (
+			target codec.Address
+			err    error
+		)
+		if len(historyAddress) == 0 {
+			target, err = handler.Root().GetDefaultAddress(true)
+		} else {
+			target, err = handler.Root().ResolveAddress(historyAddress)
+		}
+		if err != nil {
+			return err
+		}
+		addr := consts.AddressFormat.Encode(target)
+		_, _, _, bcli, err := defaultClients()
+		if err != nil {
+			return err
+		}
+		records, err := getHistory(ctx, bcli, addr)
+		if err != nil {
+			return err
+		}
+		b, err := marshalHistory(records, historyFormat)
+		if err != nil {
+			return err
+		}
+		if err := utils.SaveBytes(args[0], b); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}exported %d history records of %s to:{{/}} %s\n", len(records), addr, args[0])
+		return nil
+	},
+}
@@ -15,8 +15,10 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/crypto/bls"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/hd"
 	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/faucet"
 	"github.com/ava-labs/hypersdk/utils"
 
 	brpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
@@ -37,19 +39,6 @@ func checkKeyType(k string) error {
 	}
 }
 
-func getKeyType(addr codec.Address) (string, error) {
-	switch addr[0] {
-	case auth.ED25519ID:
-		return ed25519Key, nil
-	case auth.SECP256R1ID:
-		return secp256r1Key, nil
-	case auth.BLSID:
-		return blsKey, nil
-	default:
-		return "", ErrInvalidKeyType
-	}
-}
-
 func generatePrivateKey(k string) (*cli.PrivateKey, error) {
 	switch k {
 	case ed25519Key:
@@ -87,7 +76,7 @@ func generatePrivateKey(k string) (*cli.PrivateKey, error) {
 func loadPrivateKey(k string, path string) (*cli.PrivateKey, error) {
 	switch k {
 	case ed25519Key:
-		p, err := utils.LoadBytes(path, ed25519.PrivateKeyLen)
+		p, err := handler.Root().LoadKeyFile(path, ed25519.PrivateKeyLen)
 		if err != nil {
 			return nil, err
 		}
@@ -97,7 +86,7 @@ func loadPrivateKey(k string, path string) (*cli.PrivateKey, error) {
 			Bytes:   p,
 		}, nil
 	case secp256r1Key:
-		p, err := utils.LoadBytes(path, secp256r1.PrivateKeyLen)
+		p, err := handler.Root().LoadKeyFile(path, secp256r1.PrivateKeyLen)
 		if err != nil {
 			return nil, err
 		}
@@ -107,7 +96,7 @@ func loadPrivateKey(k string, path string) (*cli.PrivateKey, error) {
 			Bytes:   p,
 		}, nil
 	case blsKey:
-		p, err := utils.LoadBytes(path, bls.PrivateKeyLen)
+		p, err := handler.Root().LoadKeyFile(path, bls.PrivateKeyLen)
 		if err != nil {
 			return nil, err
 		}
@@ -153,7 +142,7 @@ var genKeyCmd = &cobra.Command{
 		}
 		utils.Outf(
 			"{{green}}created address:{{/}} %s",
-			codec.MustAddressBech32(consts.HRP, priv.Address),
+			consts.AddressFormat.Encode(priv.Address),
 		)
 		return nil
 	},
@@ -180,12 +169,72 @@ var importKeyCmd = &cobra.Command{
 		}
 		utils.Outf(
 			"{{green}}imported address:{{/}} %s",
-			codec.MustAddressBech32(consts.HRP, priv.Address),
+			consts.AddressFormat.Encode(priv.Address),
 		)
 		return nil
 	},
 }
 
+// storeMnemonicAccount stores the ed25519 key of [mnemonicAccount] derived
+// from [mnemonic] and makes it the default key.
+func storeMnemonicAccount(mnemonic string) error {
+	p, err := hd.DeriveED25519Account(mnemonic, "", mnemonicAccount)
+	if err != nil {
+		return err
+	}
+	priv := &cli.PrivateKey{
+		Address: auth.NewED25519Address(p.PublicKey()),
+		Bytes:   p[:],
+	}
+	if err := handler.h.StoreKey(priv); err != nil {
+		return err
+	}
+	if err := handler.h.StoreDefaultKey(priv.Address); err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{green}}stored account %d:{{/}} %s\n",
+		mnemonicAccount,
+		consts.AddressFormat.Encode(priv.Address),
+	)
+	return nil
+}
+
+var generateMnemonicKeyCmd = &cobra.Command{
+	Use: "generate-mnemonic",
+	RunE: func(*cobra.Command, []string) error {
+		mnemonic, err := handler.Root().GenerateMnemonic()
+		if err != nil {
+			return err
+		}
+		return storeMnemonicAccount(mnemonic)
+	},
+}
+
+var importMnemonicKeyCmd = &cobra.Command{
+	Use: "import-mnemonic",
+	RunE: func(*cobra.Command, []string) error {
+		mnemonic, err := handler.Root().PromptMnemonic("mnemonic")
+		if err != nil {
+			return err
+		}
+		return storeMnemonicAccount(mnemonic)
+	},
+}
+
+var exportKeyCmd = &cobra.Command{
+	Use: "export [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return handler.Root().ExportKey(args[0])
+	},
+}
+
 func lookupSetKeyBalance(choice int, address string, uri string, networkID uint32, chainID ids.ID) error {
 	// TODO: just load once
 	cli := brpc.NewJSONRPCClient(uri, networkID, chainID)
@@ -193,14 +242,12 @@ func lookupSetKeyBalance(choice int, address string, uri string, networkID uint3
 	if err != nil {
 		return err
 	}
-	addr, err := codec.ParseAddressBech32(consts.HRP, address)
-	if err != nil {
-		return err
-	}
-	keyType, err := getKeyType(addr)
+	addr, err := consts.AddressFormat.Parse(address)
 	if err != nil {
 		return err
 	}
+	// [Parse] only accepts addresses of the auth types
+	keyType, _ := consts.AddressFormat.TypeName(addr)
 	utils.Outf(
 		"%d) {{cyan}}address (%s):{{/}} %s {{cyan}}balance:{{/}} %s %s\n",
 		choice,
@@ -230,3 +277,25 @@ var balanceKeyCmd = &cobra.Command{
 		return handler.Root().Balance(checkAllChains, false, lookupKeyBalance)
 	},
 }
+
+var faucetKeyCmd = &cobra.Command{
+	Use: "faucet",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		addr, err := handler.h.GetDefaultAddress(true)
+		if err != nil {
+			return err
+		}
+		_, uris, err := handler.h.GetDefaultChain(true)
+		if err != nil {
+			return err
+		}
+		fcli := faucet.NewJSONRPCClient(uris[0])
+		txID, amount, err := fcli.Request(ctx, consts.AddressFormat.Encode(addr))
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{green}}faucet funds incoming (%s %s):{{/}} %s\n", utils.FormatBalance(amount, consts.Decimals), consts.Symbol, txID)
+		return nil
+	},
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
+
+	brpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+)
+
+var offlineCmd = &cobra.Command{
+	Use: "offline",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+// defaultClients returns clients for the default chain without requiring a
+// default key (which may only exist on the signing machine).
+func defaultClients() (ids.ID, string, *rpc.JSONRPCClient, *brpc.JSONRPCClient, error) {
+	chainID, uris, err := handler.Root().GetDefaultChain(true)
+	if err != nil {
+		return ids.Empty, "", nil, nil, err
+	}
+	jcli := rpc.NewJSONRPCClient(uris[0])
+	networkID, _, _, err := jcli.Network(context.TODO())
+	if err != nil {
+		return ids.Empty, "", nil, nil, err
+	}
+	return chainID, uris[0], jcli, brpc.NewJSONRPCClient(uris[0], networkID, chainID), nil
+}
+
+// prepareUnsigned builds an unsigned transaction for [actions] that will be
+// signed by [signer] and writes it to [path].
+func prepareUnsigned(
+	ctx context.Context,
+	path string,
+	actions []chain.Action,
+	signer codec.Address,
+	jcli *rpc.JSONRPCClient,
+	bcli *brpc.JSONRPCClient,
+) error {
+	parser, err := bcli.Parser(ctx)
+	if err != nil {
+		return err
+	}
+	factory, err := estimationFactory(signer)
+	if err != nil {
+		return err
+	}
+	tx, err := jcli.GenerateUnsignedTransaction(ctx, parser, actions, factory, cli.DelayModifier(broadcastDelay))
+	if err != nil {
+		return err
+	}
+	if err := cli.SaveUnsignedTx(path, tx); err != nil {
+		return err
+	}
+	utils.Outf("{{green}}saved unsigned tx to:{{/}} %s\n", path)
+	return cli.CheckExpiry(tx)
+}
+
+func printActions(tx *chain.Transaction) {
+	for _, action := range tx.Actions {
+		utils.Outf("{{yellow}}action (%s):{{/}} [%s]\n", reflect.TypeOf(action), handler.Root().ActionSummary(action))
+	}
+	utils.Outf(
+		"{{yellow}}max fee:{{/}} %s %s\n",
+		utils.FormatBalance(tx.Base.MaxFee, consts.Decimals),
+		consts.Symbol,
+	)
+}
+
+var prepareTransferCmd = &cobra.Command{
+	Use: "prepare-transfer [output]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		_, _, jcli, bcli, err := defaultClients()
+		if err != nil {
+			return err
+		}
+
+		// Select signer
+		signer, err := handler.Root().PromptAddress("signer")
+		if err != nil {
+			return err
+		}
+		balance, err := handler.GetBalance(ctx, bcli, signer)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := handler.Root().PromptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := handler.Root().PromptAmount("amount", consts.Decimals, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select memo
+		memo, err := handler.Root().PromptString("memo", 0, actions.MaxMemoSize)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		return prepareUnsigned(ctx, args[0], []chain.Action{&actions.Transfer{
+			To:    recipient,
+			Value: amount,
+			Memo:  []byte(memo),
+		}}, signer, jcli, bcli)
+	},
+}
+
+var rebaseCmd = &cobra.Command{
+	Use: "rebase [path]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		tx, err := cli.LoadUnsignedTx(args[0], consts.ActionRegistry)
+		if err != nil {
+			return err
+		}
+		printActions(tx)
+		if err := cli.CheckExpiry(tx); err == nil {
+			utils.Outf("{{yellow}}tx has not expired, rebasing will invalidate any existing signatures{{/}}\n")
+			cont, err := handler.Root().PromptContinue()
+			if !cont || err != nil {
+				return err
+			}
+		}
+		_, _, jcli, bcli, err := defaultClients()
+		if err != nil {
+			return err
+		}
+
+		// Select signer (fees depend on the auth type)
+		signer, err := handler.Root().PromptAddress("signer")
+		if err != nil {
+			return err
+		}
+		return prepareUnsigned(ctx, args[0], tx.Actions, signer, jcli, bcli)
+	},
+}
+
+var signCmd = &cobra.Command{
+	Use: "sign [input] [output]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		// Signing never contacts the network so that it can be done on an
+		// air-gapped machine.
+		addr, priv, err := handler.Root().GetDefaultKey(true)
+		if err != nil {
+			return err
+		}
+		factory, err := getFactory(addr, priv)
+		if err != nil {
+			return err
+		}
+		tx, err := cli.LoadUnsignedTx(args[0], consts.ActionRegistry)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}chainID:{{/}} %s\n", tx.Base.ChainID)
+		printActions(tx)
+		if err := cli.CheckExpiry(tx); err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		signed, err := tx.Sign(factory, consts.ActionRegistry, consts.AuthRegistry)
+		if err != nil {
+			return err
+		}
+		if err := cli.SaveSignedTx(args[1], signed); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}saved signed tx %s to:{{/}} %s\n", signed.ID(), args[1])
+		return nil
+	},
+}
+
+var broadcastCmd = &cobra.Command{
+	Use: "broadcast [path]",
+	PreRunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		ctx := context.Background()
+		tx, err := cli.LoadSignedTx(args[0], consts.ActionRegistry, consts.AuthRegistry)
+		if err != nil {
+			return err
+		}
+		utils.Outf("{{yellow}}actor:{{/}} %s\n", consts.AddressFormat.Encode(tx.Auth.Actor()))
+		printActions(tx)
+		if err := cli.CheckExpiry(tx); err != nil {
+			utils.Outf("{{red}}rebase the unsigned tx and sign it again{{/}}\n")
+			return err
+		}
+		chainID, uri, _, bcli, err := defaultClients()
+		if err != nil {
+			return err
+		}
+		if chainID != tx.Base.ChainID {
+			return ErrWrongChain
+		}
+		parser, err := bcli.Parser(ctx)
+		if err != nil {
+			return err
+		}
+		if err := cli.CheckValidFrom(tx, parser.Rules(time.Now().UnixMilli()).GetValidityWindow()); err != nil {
+			return err
+		}
+		ws, err := rpc.NewWebSocketClient(uri, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		if err != nil {
+			return err
+		}
+		defer ws.Close()
+		_, _, err = issueAndWait(ctx, ws, tx, true)
+		return err
+	},
+}
@@ -5,15 +5,12 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 
 	"github.com/ava-labs/avalanchego/ids"
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli"
-	"github.com/ava-labs/hypersdk/codec"
-	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
@@ -34,6 +31,13 @@ func sendAndWait(
 	if err != nil {
 		return false, ids.Empty, err
 	}
+	return issueAndWait(ctx, ws, tx, printStatus)
+}
+
+// issueAndWait may not be used concurrently
+func issueAndWait(
+	ctx context.Context, ws *rpc.WebSocketClient, tx *chain.Transaction, printStatus bool,
+) (bool, ids.ID, error) {
 	if err := ws.RegisterTx(tx); err != nil {
 		return false, ids.Empty, err
 	}
@@ -65,7 +69,7 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 			"%s {{yellow}}%s{{/}} {{yellow}}actor:{{/}} %s {{yellow}}error:{{/}} [%s] {{yellow}}fee (max %.2f%%):{{/}} %s %s {{yellow}}consumed:{{/}} [%s]\n",
 			"❌",
 			tx.ID(),
-			codec.MustAddressBech32(consts.HRP, actor),
+			handler.Root().FormatAddress(actor),
 			result.Error,
 			float64(result.Fee)/float64(tx.Base.MaxFee)*100,
 			utils.FormatBalance(result.Fee, consts.Decimals),
@@ -76,16 +80,12 @@ func handleTx(tx *chain.Transaction, result *chain.Result) {
 	}
 
 	for _, action := range tx.Actions {
-		var summaryStr string
-		switch act := action.(type) { //nolint:gocritic
-		case *actions.Transfer:
-			summaryStr = fmt.Sprintf("%s %s -> %s\n", utils.FormatBalance(act.Value, consts.Decimals), consts.Symbol, codec.MustAddressBech32(consts.HRP, act.To))
-		}
+		summaryStr := handler.Root().ActionSummary(action)
 		utils.Outf(
 			"%s {{yellow}}%s{{/}} {{yellow}}actor:{{/}} %s {{yellow}}summary (%s):{{/}} [%s] {{yellow}}fee (max %.2f%%):{{/}} %s %s {{yellow}}consumed:{{/}} [%s]\n",
 			"✅",
 			tx.ID(),
-			codec.MustAddressBech32(consts.HRP, actor),
+			handler.Root().FormatAddress(actor),
 			reflect.TypeOf(action),
 			summaryStr,
 			float64(result.Fee)/float64(tx.Base.MaxFee)*100,
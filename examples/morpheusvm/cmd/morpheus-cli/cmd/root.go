@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/devnet"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
@@ -23,18 +24,43 @@ var (
 	handler *Handler
 
 	dbPath                string
+	auditPath             string
 	genesisFile           string
 	minUnitPrice          []string
 	maxBlockUnits         []string
 	windowTargetUnits     []string
 	minBlockGap           int64
 	hideTxs               bool
+	watchAddresses        []string
+	filterAddresses       []string
+	filterActions         []string
+	filterMinFee          string
+	filterFailedOnly      bool
+	broadcastDelay        time.Duration
 	checkAllChains        bool
+	mnemonicAccount       uint32
+	workloadFile          string
+	historyAddress        string
+	historyFormat         string
 	prometheusBaseURI     string
 	prometheusOpenBrowser bool
 	prometheusFile        string
 	prometheusData        string
 	startPrometheus       bool
+	aggregateListen       string
+	aggregateInterval     time.Duration
+	devnetRunnerEndpoint  string
+	devnetRunnerPath      string
+	devnetExecPath        string
+	devnetPluginDir       string
+	devnetValidators      int
+	initialValidators     int
+	devnetStakeInterval   time.Duration
+	devnetFundedKeys      int
+	devnetBalance         uint64
+	devnetChainConfig     string
+	devnetSubnetConfig    string
+	devnetLogLevel        string
 
 	rootCmd = &cobra.Command{
 		Use:        "morpheus-cli",
@@ -48,10 +74,13 @@ func init() {
 	rootCmd.AddCommand(
 		genesisCmd,
 		keyCmd,
+		addressCmd,
 		chainCmd,
 		actionCmd,
+		offlineCmd,
 		spamCmd,
 		prometheusCmd,
+		devnetCmd,
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&dbPath,
@@ -59,15 +88,26 @@ func init() {
 		defaultDatabase,
 		"path to database (will create it missing)",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&auditPath,
+		"audit-log",
+		"",
+		"directory of the audit log recording the use of stored keys (disabled if empty)",
+	)
 	rootCmd.PersistentPreRunE = func(*cobra.Command, []string) error {
 		utils.Outf("{{yellow}}database:{{/}} %s\n", dbPath)
-		controller := NewController(dbPath)
+		controller := NewController(dbPath, auditPath)
 		root, err := cli.New(controller)
 		if err != nil {
 			return err
 		}
+		for _, p := range actionPlugins {
+			if err := root.RegisterActionPlugin(p); err != nil {
+				return err
+			}
+		}
 		handler = NewHandler(root)
-		return err
+		return nil
 	}
 	rootCmd.PersistentPostRunE = func(*cobra.Command, []string) error {
 		return handler.Root().CloseDatabase()
@@ -105,8 +145,15 @@ func init() {
 		-1,
 		"minimum block gap (ms)",
 	)
+	buildGenesisCmd.PersistentFlags().StringVar(
+		&genesisFile,
+		"genesis-file",
+		defaultGenesis,
+		"genesis file path",
+	)
 	genesisCmd.AddCommand(
 		genGenesisCmd,
+		buildGenesisCmd,
 	)
 
 	// key
@@ -116,11 +163,45 @@ func init() {
 		false,
 		"check all chains",
 	)
+	for _, cmd := range []*cobra.Command{generateMnemonicKeyCmd, importMnemonicKeyCmd} {
+		cmd.PersistentFlags().Uint32Var(
+			&mnemonicAccount,
+			"account",
+			0,
+			"index of the account to derive from the mnemonic",
+		)
+	}
+	exportHistoryCmd.PersistentFlags().StringVar(
+		&historyAddress,
+		"address",
+		"",
+		"address or label to export the history of (defaults to the default key)",
+	)
+	exportHistoryCmd.PersistentFlags().StringVar(
+		&historyFormat,
+		"format",
+		csvFormat,
+		"output format (csv or json)",
+	)
 	keyCmd.AddCommand(
 		genKeyCmd,
 		importKeyCmd,
+		generateMnemonicKeyCmd,
+		importMnemonicKeyCmd,
+		exportKeyCmd,
+		exportHistoryCmd,
 		setKeyCmd,
 		balanceKeyCmd,
+		faucetKeyCmd,
+	)
+
+	// address
+	addressCmd.AddCommand(
+		addAddressCmd,
+		removeAddressCmd,
+		listAddressCmd,
+		importAddressCmd,
+		exportAddressCmd,
 	)
 
 	// chain
@@ -130,21 +211,75 @@ func init() {
 		false,
 		"hide txs",
 	)
+	watchChainCmd.PersistentFlags().StringSliceVar(
+		&filterAddresses,
+		"address",
+		[]string{},
+		"only show txs sent by or referencing these addresses",
+	)
+	watchChainCmd.PersistentFlags().StringSliceVar(
+		&filterActions,
+		"action",
+		[]string{},
+		"only show txs containing these action types",
+	)
+	watchChainCmd.PersistentFlags().StringVar(
+		&filterMinFee,
+		"min-fee",
+		"",
+		"only show txs that paid at least this fee",
+	)
+	watchChainCmd.PersistentFlags().BoolVar(
+		&filterFailedOnly,
+		"failed-only",
+		false,
+		"only show failed txs",
+	)
+	dashboardChainCmd.PersistentFlags().StringSliceVar(
+		&watchAddresses,
+		"watch",
+		[]string{},
+		"addresses to show balances of (defaults to all stored keys)",
+	)
 	chainCmd.AddCommand(
 		importChainCmd,
 		importANRChainCmd,
 		importAvalancheOpsChainCmd,
 		setChainCmd,
 		chainInfoCmd,
+		unitProfileChainCmd,
 		watchChainCmd,
+		dashboardChainCmd,
 	)
 
 	// actions
-	actionCmd.AddCommand(
-		transferCmd,
+	for _, p := range actionPlugins {
+		actionCmd.AddCommand(newActionCmd(p))
+	}
+
+	// offline
+	for _, cmd := range []*cobra.Command{prepareTransferCmd, rebaseCmd} {
+		cmd.PersistentFlags().DurationVar(
+			&broadcastDelay,
+			"broadcast-delay",
+			0,
+			"how long until the tx will be broadcast (the tx is valid for one validity window after)",
+		)
+	}
+	offlineCmd.AddCommand(
+		prepareTransferCmd,
+		rebaseCmd,
+		signCmd,
+		broadcastCmd,
 	)
 
 	// spam
+	runSpamCmd.PersistentFlags().StringVar(
+		&workloadFile,
+		"workload",
+		"",
+		"path to a workload config (skips prompts and reports latency on exit)",
+	)
 	spamCmd.AddCommand(
 		runSpamCmd,
 	)
@@ -180,8 +315,98 @@ func init() {
 		true,
 		"start local prometheus server",
 	)
+	aggregatePrometheusCmd.PersistentFlags().StringVar(
+		&aggregateListen,
+		"listen",
+		"127.0.0.1:9095",
+		"address to serve aggregated metrics on",
+	)
+	aggregatePrometheusCmd.PersistentFlags().DurationVar(
+		&aggregateInterval,
+		"interval",
+		5*time.Second,
+		"how often to scrape each node",
+	)
 	prometheusCmd.AddCommand(
 		generatePrometheusCmd,
+		aggregatePrometheusCmd,
+	)
+
+	// devnet
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetRunnerEndpoint,
+		"runner-endpoint",
+		devnet.DefaultRunnerEndpoint,
+		"avalanche-network-runner gRPC endpoint",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetRunnerPath,
+		"runner-path",
+		"",
+		"avalanche-network-runner binary to start a server with (uses a running server if empty)",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetExecPath,
+		"avalanchego-path",
+		"",
+		"avalanchego binary",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetPluginDir,
+		"plugin-dir",
+		"",
+		"directory containing the vm plugin",
+	)
+	startDevnetCmd.PersistentFlags().IntVar(
+		&devnetValidators,
+		"validators",
+		devnet.DefaultValidators,
+		"number of validators",
+	)
+	startDevnetCmd.PersistentFlags().IntVar(
+		&initialValidators,
+		"initial-validators",
+		0,
+		"number of validators when the chain is created (defaults to all)",
+	)
+	startDevnetCmd.PersistentFlags().DurationVar(
+		&devnetStakeInterval,
+		"stake-interval",
+		0,
+		"delay between adding each of the remaining validators",
+	)
+	startDevnetCmd.PersistentFlags().IntVar(
+		&devnetFundedKeys,
+		"funded-keys",
+		1,
+		"number of keys to fund in genesis",
+	)
+	startDevnetCmd.PersistentFlags().Uint64Var(
+		&devnetBalance,
+		"balance",
+		10_000_000_000_000_000_000,
+		"genesis balance of each funded key",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetChainConfig,
+		"chain-config",
+		"",
+		"chain config file path",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetSubnetConfig,
+		"subnet-config",
+		"",
+		"subnet config file path",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetLogLevel,
+		"log-level",
+		devnet.DefaultLogLevel,
+		"avalanchego log level",
+	)
+	devnetCmd.AddCommand(
+		startDevnetCmd,
 	)
 }
 
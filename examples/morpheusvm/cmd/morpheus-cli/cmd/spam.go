@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/spf13/cobra"
@@ -25,6 +26,8 @@ import (
 	mrpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
 )
 
+const transferMultipleWorkload = "transfer-multiple"
+
 type SpamHelper struct {
 	keyType string
 	cli     *mrpc.JSONRPCClient
@@ -89,6 +92,31 @@ func (*SpamHelper) GetTransfer(address codec.Address, amount uint64, memo []byte
 	}}
 }
 
+// GetWorkloadAction supports "transfer-multiple", which sends 1 unit to each
+// recipient in a single action.
+func (*SpamHelper) GetWorkloadAction(name string, recipients []codec.Address, payload []byte) ([]chain.Action, error) {
+	switch name {
+	case transferMultipleWorkload:
+		if len(recipients) > actions.MaxTransferMultipleRecipients {
+			return nil, actions.ErrOutputTooManyRecipients
+		}
+		if len(payload) > actions.MaxMemoSize {
+			return nil, actions.ErrOutputMemoTooLarge
+		}
+		values := make([]uint64, len(recipients))
+		for i := range values {
+			values[i] = 1
+		}
+		return []chain.Action{&actions.TransferMultiple{
+			To:     recipients,
+			Values: values,
+			Memo:   payload,
+		}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", cli.ErrUnsupportedWorkload, name)
+	}
+}
+
 var spamCmd = &cobra.Command{
 	Use: "spam",
 	RunE: func(*cobra.Command, []string) error {
@@ -105,6 +133,14 @@ var runSpamCmd = &cobra.Command{
 		return checkKeyType(args[0])
 	},
 	RunE: func(_ *cobra.Command, args []string) error {
-		return handler.Root().Spam(&SpamHelper{keyType: args[0]})
+		var workload *cli.Workload
+		if len(workloadFile) > 0 {
+			var err error
+			workload, err = cli.LoadWorkload(workloadFile)
+			if err != nil {
+				return err
+			}
+		}
+		return handler.Root().Spam(&SpamHelper{keyType: args[0]}, workload)
 	},
 }
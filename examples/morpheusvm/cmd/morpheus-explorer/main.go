@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// "morpheus-explorer" serves the explorer API of a morpheusvm chain.
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/explorer"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
+
+	mrpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+	hexplorer "github.com/ava-labs/hypersdk/explorer"
+)
+
+func main() {
+	uri := flag.String("uri", "http://127.0.0.1:9650/ext/bc/morpheusvm", "URI of a node running the indexer")
+	listen := flag.String("listen", ":8080", "address to serve the explorer API on")
+	prefix := flag.String("prefix", "/api", "path to serve the explorer API under")
+	flag.Parse()
+
+	if err := run(*uri, *listen, *prefix); err != nil {
+		utils.Outf("{{red}}morpheus-explorer exited with error:{{/}} %+v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func run(uri string, listen string, prefix string) error {
+	ctx := context.Background()
+	cli := rpc.NewJSONRPCClient(uri)
+	networkID, _, chainID, err := cli.Network(ctx)
+	if err != nil {
+		return err
+	}
+	c, err := explorer.NewController(ctx, mrpc.NewJSONRPCClient(uri, networkID, chainID))
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(prefix+"/", http.StripPrefix(prefix, hexplorer.NewServer(c, cli)))
+	utils.Outf("{{green}}serving explorer API for %s on %s%s{{/}}\n", chainID, listen, prefix)
+	srv := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
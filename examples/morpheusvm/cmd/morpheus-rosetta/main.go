@@ -0,0 +1,53 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// "morpheus-rosetta" serves the Rosetta API of a morpheusvm chain.
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/rosetta"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/version"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
+
+	hrosetta "github.com/ava-labs/hypersdk/rosetta"
+	mrpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+)
+
+func main() {
+	uri := flag.String("uri", "http://127.0.0.1:9650/ext/bc/morpheusvm", "URI of a node running the indexer")
+	listen := flag.String("listen", ":8080", "address to serve the Rosetta API on")
+	flag.Parse()
+
+	if err := run(*uri, *listen); err != nil {
+		utils.Outf("{{red}}morpheus-rosetta exited with error:{{/}} %+v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func run(uri string, listen string) error {
+	ctx := context.Background()
+	cli := rpc.NewJSONRPCClient(uri)
+	networkID, _, chainID, err := cli.Network(ctx)
+	if err != nil {
+		return err
+	}
+	c, err := rosetta.NewController(ctx, mrpc.NewJSONRPCClient(uri, networkID, chainID))
+	if err != nil {
+		return err
+	}
+	utils.Outf("{{green}}serving Rosetta API for %s on %s{{/}}\n", chainID, listen)
+	srv := &http.Server{
+		Addr:              listen,
+		Handler:           hrosetta.NewServer(c, cli, version.Version.String()),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
@@ -4,28 +4,38 @@
 package config
 
 import (
-	"encoding/json"
-
 	"github.com/ava-labs/avalanchego/utils/logging"
+
+	hconfig "github.com/ava-labs/hypersdk/config"
 )
 
+// EnvPrefix starts the environment variables that override the [Config] of
+// a node (like MORPHEUSVM_LOG_LEVEL for "logLevel").
+const EnvPrefix = "MORPHEUSVM"
+
 type Config struct {
 	StoreTransactions bool          `json:"storeTransactions"`
-	TestMode          bool          `json:"testMode"` // makes gossip/building manual
+	StoreHistory      bool          `json:"storeHistory"` // indexes transfers by account
+	TestMode          bool          `json:"testMode"`     // makes gossip/building manual
 	LogLevel          logging.Level `json:"logLevel"`
+
+	// Faucet is only enabled if [FaucetKeyPath] points to a funded ed25519
+	// private key. It should only be enabled on test networks.
+	FaucetKeyPath  string `json:"faucetKeyPath"`
+	FaucetAmount   uint64 `json:"faucetAmount"`
+	FaucetCooldown int64  `json:"faucetCooldown" min:"0"` // seconds
 }
 
 func New(b []byte) (*Config, error) {
 	c := &Config{
 		StoreTransactions: true,
 		LogLevel:          logging.Info,
+		FaucetAmount:      10_000_000_000,
+		FaucetCooldown:    3600,
 	}
 
-	if len(b) > 0 {
-		if err := json.Unmarshal(b, c); err != nil {
-			return nil, err
-		}
+	if err := hconfig.Load(b, c, EnvPrefix); err != nil {
+		return nil, err
 	}
-
 	return c, nil
 }
@@ -6,8 +6,10 @@ package consts
 import (
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/auth"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
 )
 
 const (
@@ -19,6 +21,9 @@ const (
 
 var ID ids.ID
 
+// AddressFormat encodes and parses the addresses of the VM
+var AddressFormat = address.MustNew(HRP, auth.Types())
+
 func init() {
 	b := make([]byte, ids.IDLen)
 	copy(b, []byte(Name))
@@ -5,5 +5,11 @@ package consts
 
 const (
 	// Action TypeIDs
-	TransferID uint8 = 0
+	TransferID         uint8 = 0
+	TransferMultipleID uint8 = 1
+	RegisterNameID     uint8 = 2
+	RenewNameID        uint8 = 3
+	TransferNameID     uint8 = 4
+	BridgeLockID       uint8 = 5
+	BridgeReleaseID    uint8 = 6
 )
@@ -0,0 +1,163 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/benchmark"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/vm/vmtest"
+
+	hrpc "github.com/ava-labs/hypersdk/rpc"
+)
+
+const (
+	benchmarkBlocks      = 10
+	benchmarkTxsPerBlock = 500
+	// benchmarkTolerance is how much worse than the baseline a metric can be
+	// before it is reported as a regression
+	benchmarkTolerance = 0.1
+)
+
+var (
+	benchmarkReport   string
+	benchmarkCommit   string
+	benchmarkBaseline string
+	benchmarkURI      string
+	benchmarkKey      string
+)
+
+func init() {
+	flag.StringVar(
+		&benchmarkReport,
+		"benchmark-report",
+		"",
+		"file the JSON report of BenchmarkTPS is written to",
+	)
+	flag.StringVar(
+		&benchmarkCommit,
+		"benchmark-commit",
+		"",
+		"commit recorded in the report of BenchmarkTPS",
+	)
+	flag.StringVar(
+		&benchmarkBaseline,
+		"benchmark-baseline",
+		"",
+		"report of a previous run of BenchmarkTPS that regressions are reported against",
+	)
+	flag.StringVar(
+		&benchmarkURI,
+		"benchmark-uri",
+		"",
+		"chain endpoint of a node (like a devnet validator) BenchmarkTPS also runs against",
+	)
+	flag.StringVar(
+		&benchmarkKey,
+		"benchmark-key",
+		"",
+		"hex-encoded ed25519 private key funded on the chain of benchmark-uri",
+	)
+}
+
+func transfer(to codec.Address, value uint64) chain.Action {
+	return &actions.Transfer{To: to, Value: value}
+}
+
+// BenchmarkTPS runs the standard transfer workloads in memory (and against
+// [benchmarkURI] if set), writes the results to [benchmarkReport], and fails
+// if any metric regressed from [benchmarkBaseline].
+func BenchmarkTPS(b *testing.B) {
+	require := require.New(b)
+	ctx := context.Background()
+
+	gen := genesis.Default()
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.MaxBlockUnits = fees.Dimensions{1_800_000, 100_000, 100_000, 100_000, 100_000}
+	factories := make([]chain.AuthFactory, benchmarkTxsPerBlock)
+	for i := range factories {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		factories[i] = auth.NewED25519Factory(priv)
+		gen.CustomAllocation = append(gen.CustomAllocation, &genesis.CustomAllocation{
+			Address: consts.AddressFormat.Encode(auth.NewED25519Address(priv.PublicKey())),
+			Balance: 10_000_000_000,
+		})
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+	config := vmtest.Config{
+		Genesis:   genesisBytes,
+		VMConfig:  []byte(`{"mempoolSize":4096,"mempoolSponsorSize":4096,"config":{"testMode":true}}`),
+		NetworkID: 1,
+	}
+
+	report := benchmark.NewReport(benchmarkCommit)
+	for _, w := range benchmark.Transfers(benchmarkBlocks, benchmarkTxsPerBlock, factories, transfer) {
+		var result *benchmark.Result
+		b.Run(w.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				result = benchmark.RunMemory(b, New, config, w)
+			}
+			b.ReportMetric(result.TPS, "tps")
+			b.ReportMetric(float64(result.BuildTime.Mean.Microseconds()), "build-us/block")
+			b.ReportMetric(float64(result.VerifyTime.Mean.Microseconds()), "verify-us/block")
+		})
+		if result != nil {
+			report.Add(result)
+		}
+	}
+
+	if len(benchmarkURI) > 0 {
+		priv, err := codec.LoadHex(benchmarkKey, ed25519.PrivateKeyLen)
+		require.NoError(err)
+		networkID, _, chainID, err := hrpc.NewJSONRPCClient(benchmarkURI).Network(ctx)
+		require.NoError(err)
+		parser, err := rpc.NewJSONRPCClient(benchmarkURI, networkID, chainID).Parser(ctx)
+		require.NoError(err)
+
+		// A single funded key sends every transaction, so the transfers of
+		// all workloads execute sequentially
+		remote := []chain.AuthFactory{auth.NewED25519Factory(ed25519.PrivateKey(priv))}
+		for _, w := range benchmark.Transfers(benchmarkBlocks, benchmarkTxsPerBlock, remote, transfer) {
+			result, err := benchmark.RunRemote(ctx, benchmarkURI, parser, w)
+			require.NoError(err)
+			b.Logf("%s: %.2f tps against %s", w.Name, result.TPS, benchmarkURI)
+			report.Add(result)
+		}
+	}
+
+	if len(benchmarkReport) > 0 {
+		f, err := os.Create(benchmarkReport)
+		require.NoError(err)
+		defer f.Close()
+		require.NoError(report.Write(f))
+	}
+	if len(benchmarkBaseline) > 0 {
+		f, err := os.Open(benchmarkBaseline)
+		require.NoError(err)
+		defer f.Close()
+		baseline, err := benchmark.ReadReport(f)
+		require.NoError(err)
+		for _, regression := range benchmark.Compare(baseline, report, benchmarkTolerance) {
+			b.Error(regression)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/snow"
@@ -15,15 +16,18 @@ import (
 	"github.com/ava-labs/hypersdk/auth"
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/config"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/faucet"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/version"
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/pebble"
+	"github.com/ava-labs/hypersdk/utils"
 	"github.com/ava-labs/hypersdk/vm"
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
@@ -41,8 +45,6 @@ type Controller struct {
 	config       *config.Config
 	stateManager *storage.StateManager
 
-	metrics *metrics
-
 	db database.Database
 }
 
@@ -71,13 +73,7 @@ func (c *Controller) Initialize(
 	c.snowCtx = snowCtx
 	c.stateManager = &storage.StateManager{}
 
-	// Instantiate metrics
 	var err error
-	c.metrics, err = newMetrics(gatherer)
-	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
-	}
-
 	// Load config and genesis
 	c.config, err = config.New(configBytes)
 	if err != nil {
@@ -96,6 +92,16 @@ func (c *Controller) Initialize(
 	}
 	snowCtx.Log.Info("loaded genesis", zap.Any("genesis", c.genesis))
 
+	upgrade, err := genesis.NewUpgrade(upgradeBytes)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+	snowCtx.Log.Info("loaded upgrade", zap.Any("upgrade", upgrade))
+	actionRegistry, err := chain.ScheduleActions(consts.ActionRegistry, upgrade.Actions)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to schedule actions: %w", err)
+	}
+
 	c.db, err = hstorage.New(pebble.NewDefaultConfig(), snowCtx.ChainDataDir, "db", gatherer)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, err
@@ -114,6 +120,19 @@ func (c *Controller) Initialize(
 		return nil, nil, nil, nil, nil, nil, nil, err
 	}
 	apis[rpc.JSONRPCEndpoint] = jsonRPCHandler
+	if len(c.config.FaucetKeyPath) > 0 {
+		priv, err := utils.LoadBytes(c.config.FaucetKeyPath, ed25519.PrivateKeyLen)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("unable to load faucet key: %w", err)
+		}
+		f := faucet.New(inner, ed25519.PrivateKey(priv), c.config.FaucetAmount, time.Duration(c.config.FaucetCooldown)*time.Second)
+		faucetHandler, err := hrpc.NewJSONRPCHandler(faucet.Name, faucet.NewJSONRPCServer(f))
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, err
+		}
+		apis[faucet.JSONRPCEndpoint] = faucetHandler
+		snowCtx.Log.Info("enabled faucet", zap.String("address", consts.AddressFormat.Encode(f.Address())))
+	}
 
 	// Create builder and gossiper
 	var (
@@ -132,7 +151,7 @@ func (c *Controller) Initialize(
 			return nil, nil, nil, nil, nil, nil, nil, err
 		}
 	}
-	return c.genesis, build, gossip, apis, consts.ActionRegistry, consts.AuthRegistry, auth.Engines(), nil
+	return c.genesis, build, gossip, apis, actionRegistry, consts.AuthRegistry, auth.Engines(), nil
 }
 
 func (c *Controller) Rules(t int64) chain.Rules {
@@ -152,6 +171,15 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 	for i, tx := range blk.Txs {
 		result := results[i]
 		if c.config.StoreTransactions {
+			memos := make([][]byte, len(tx.Actions))
+			for j, action := range tx.Actions {
+				switch act := action.(type) {
+				case *actions.Transfer:
+					memos[j] = act.Memo
+				case *actions.TransferMultiple:
+					memos[j] = act.Memo
+				}
+			}
 			err := storage.StoreTransaction(
 				ctx,
 				batch,
@@ -160,19 +188,66 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				result.Success,
 				result.Units,
 				result.Fee,
+				memos,
 			)
 			if err != nil {
 				return err
 			}
 		}
+		sentTransfer := false
 		if result.Success {
-			for _, action := range tx.Actions {
-				switch action.(type) { //nolint:gocritic
+			for j, action := range tx.Actions {
+				switch act := action.(type) {
 				case *actions.Transfer:
-					c.metrics.transfer.Inc()
+					if !c.config.StoreHistory {
+						continue
+					}
+					sentTransfer = true
+					if err := storage.StoreTransfer(
+						ctx,
+						batch,
+						blk.Hght,
+						i,
+						j,
+						0,
+						tx.ID(),
+						blk.GetTimestamp(),
+						tx.Auth.Actor(),
+						act.To,
+						act.Value,
+					); err != nil {
+						return err
+					}
+				case *actions.TransferMultiple:
+					if !c.config.StoreHistory {
+						continue
+					}
+					sentTransfer = true
+					for k, to := range act.To {
+						if err := storage.StoreTransfer(
+							ctx,
+							batch,
+							blk.Hght,
+							i,
+							j,
+							k,
+							tx.ID(),
+							blk.GetTimestamp(),
+							tx.Auth.Actor(),
+							to,
+							act.Values[k],
+						); err != nil {
+							return err
+						}
+					}
 				}
 			}
 		}
+		if c.config.StoreHistory && !sentTransfer {
+			if err := storage.StoreSentTx(ctx, batch, blk.Hght, i, tx.ID(), blk.GetTimestamp(), tx.Auth.Actor()); err != nil {
+				return err
+			}
+		}
 	}
 	return batch.Write()
 }
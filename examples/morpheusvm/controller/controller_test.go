@@ -0,0 +1,573 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/chain/chaintest"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/version"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/vm"
+	"github.com/ava-labs/hypersdk/vm/vmtest"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+)
+
+func TestTransfer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	factory := auth.NewED25519Factory(priv)
+	addr := auth.NewED25519Address(priv.PublicKey())
+	to := codec.CreateAddress(0, ids.GenerateTestID())
+
+	gen := genesis.Default()
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.CustomAllocation = []*genesis.CustomAllocation{
+		{Address: consts.AddressFormat.Encode(addr), Balance: 10_000},
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+	h := vmtest.New(t, New(), vmtest.Config{
+		Genesis:   genesisBytes,
+		VMConfig:  []byte(`{"config":{"testMode":true}}`),
+		NetworkID: 1,
+	})
+
+	h.Submit(ctx, h.GenerateTx([]chain.Action{&actions.Transfer{To: to, Value: 1_000}}, factory))
+	results := h.ProduceBlock(ctx)
+	require.Len(results, 1)
+	h.RequireSuccess(results)
+	balance, err := storage.GetBalanceFromState(ctx, h.VM().ReadState, addr)
+	require.NoError(err)
+	require.Equal(10_000-1_000-results[0].Fee, balance)
+	received, err := storage.GetBalanceFromState(ctx, h.VM().ReadState, to)
+	require.NoError(err)
+	require.Equal(uint64(1_000), received)
+
+	// Transfers of more than the balance left after fees fail (but still pay
+	// fees)
+	h.Submit(ctx, h.GenerateTx([]chain.Action{&actions.Transfer{To: to, Value: balance + 1}}, factory))
+	results = h.ProduceBlock(ctx)
+	require.Len(results, 1)
+	h.RequireFailure(results[0], storage.ErrInvalidBalance)
+	h.RequireValue(ctx, storage.BalanceKey(codec.CreateAddress(0, ids.GenerateTestID())), nil)
+
+	// Executed actions are counted by the VM
+	families, err := h.Metrics().Gather()
+	require.NoError(err)
+	executed := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "hypersdk_actions_executed" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			executed[labels["action"]+"/"+labels["result"]] = m.GetCounter().GetValue()
+		}
+	}
+	require.Equal(map[string]float64{"transfer/success": 1, "transfer/failure": 1}, executed)
+}
+
+func TestActionStateKeys(t *testing.T) {
+	require := require.New(t)
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	addr := auth.NewED25519Address(priv.PublicKey())
+	existing := codec.CreateAddress(0, ids.GenerateTestID())
+
+	gen := genesis.Default()
+	gen.CustomAllocation = []*genesis.CustomAllocation{
+		{Address: consts.AddressFormat.Encode(addr), Balance: 1_000_000},
+		{Address: consts.AddressFormat.Encode(existing), Balance: 1},
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+	h := vmtest.New(t, New(), vmtest.Config{
+		Genesis:   genesisBytes,
+		VMConfig:  []byte(`{"config":{"testMode":true}}`),
+		NetworkID: 1,
+	})
+	im, err := h.VM().State()
+	require.NoError(err)
+	timestamp := time.Now().UnixMilli()
+	r := h.VM().Rules(timestamp)
+
+	for name, action := range map[string]chain.Action{
+		"transfer to new account":      &actions.Transfer{To: codec.CreateAddress(0, ids.GenerateTestID()), Value: 1},
+		"transfer to existing account": &actions.Transfer{To: existing, Value: 1},
+		"transfer of whole balance":    &actions.Transfer{To: existing, Value: 1_000_000},
+		"transfer to multiple accounts": &actions.TransferMultiple{
+			To:     []codec.Address{existing, codec.CreateAddress(0, ids.GenerateTestID()), existing},
+			Values: []uint64{1, 2, 3},
+		},
+		"register name": &actions.RegisterName{Name: []byte("state-keys"), Periods: 1},
+	} {
+		report := chaintest.RequireStateKeys(t, action, r, im, timestamp, addr, ids.GenerateTestID())
+		require.NoError(report.Err, name)
+	}
+}
+
+func TestActionActivation(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	factory := auth.NewED25519Factory(priv)
+	addr := auth.NewED25519Address(priv.PublicKey())
+	to := codec.CreateAddress(0, ids.GenerateTestID())
+
+	gen := genesis.Default()
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.CustomAllocation = []*genesis.CustomAllocation{
+		{Address: consts.AddressFormat.Encode(addr), Balance: 1_000_000},
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+	// [TransferMultiple] activates before the transactions generated below
+	// expire (but after they are submitted) and [RegisterName] is already
+	// deactivated
+	now := time.Now().UnixMilli()
+	upgradeBytes, err := json.Marshal(&genesis.Upgrade{
+		Actions: []*chain.ActionActivation{
+			{TypeID: consts.TransferMultipleID, Activation: now + gen.ValidityWindow/2},
+			{TypeID: consts.RegisterNameID, Deactivation: now},
+		},
+	})
+	require.NoError(err)
+	h := vmtest.New(t, New(), vmtest.Config{
+		Genesis:   genesisBytes,
+		Upgrade:   upgradeBytes,
+		VMConfig:  []byte(`{"config":{"testMode":true}}`),
+		NetworkID: 1,
+	})
+	actionRegistry, authRegistry := h.VM().Registry()
+
+	// Other actions are always active
+	h.Submit(ctx, h.GenerateTx([]chain.Action{&actions.Transfer{To: to, Value: 1}}, factory))
+	h.RequireSuccess(h.ProduceBlock(ctx))
+
+	// Transactions can't be included in a block before their actions are
+	// activated
+	tx := h.GenerateTx([]chain.Action{&actions.TransferMultiple{To: []codec.Address{to}, Values: []uint64{1}}}, factory)
+	errs := h.VM().Submit(ctx, true, []*chain.Transaction{tx})
+	require.Len(errs, 1)
+	require.ErrorIs(errs[0], chain.ErrActionNotActivated)
+
+	// Transactions with deactivated actions are rejected when parsed (even
+	// if they were signed with a registry that doesn't know the schedule)
+	r := h.VM().Rules(now)
+	base := &chain.Base{
+		Timestamp: utils.UnixRMilli(now, r.GetValidityWindow()),
+		ChainID:   r.ChainID(),
+		MaxFee:    1_000_000,
+	}
+	tx, err = chain.NewTx(base, []chain.Action{&actions.RegisterName{Name: []byte("deactivated"), Periods: 1}}).
+		Sign(factory, consts.ActionRegistry, consts.AuthRegistry)
+	require.NoError(err)
+	_, err = chain.UnmarshalTx(codec.NewReader(tx.Bytes(), hconsts.NetworkSizeLimit), actionRegistry, authRegistry)
+	require.ErrorIs(err, chain.ErrActionNotActivated)
+}
+
+func TestEmptyBlockGap(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	factory := auth.NewED25519Factory(priv)
+	addr := auth.NewED25519Address(priv.PublicKey())
+	to := codec.CreateAddress(0, ids.GenerateTestID())
+
+	gen := genesis.Default()
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.MinEmptyBlockGap = time.Hour.Milliseconds()
+	gen.CustomAllocation = []*genesis.CustomAllocation{
+		{Address: consts.AddressFormat.Encode(addr), Balance: 10_000},
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+	h := vmtest.New(t, New(), vmtest.Config{
+		Genesis:   genesisBytes,
+		VMConfig:  []byte(`{"config":{"testMode":true}}`),
+		NetworkID: 1,
+	})
+	h.Submit(ctx, h.GenerateTx([]chain.Action{&actions.Transfer{To: to, Value: 1}}, factory))
+	h.RequireSuccess(h.ProduceBlock(ctx))
+	parent := h.VM().LastAcceptedBlock()
+
+	// Blocks without transactions aren't built before the min empty block
+	// gap elapses...
+	_, err = h.VM().BuildBlock(ctx)
+	require.ErrorIs(err, chain.ErrNoTxs)
+
+	// ...and are rejected if another builder does
+	blk := &chain.StatefulBlock{
+		Prnt:   parent.ID(),
+		Tmstmp: parent.Tmstmp + 1,
+		Hght:   parent.Hght + 1,
+	}
+	blkBytes, err := blk.Marshal()
+	require.NoError(err)
+	parsed, err := h.VM().ParseBlock(ctx, blkBytes)
+	require.NoError(err)
+	require.ErrorIs(parsed.Verify(ctx), chain.ErrTimestampTooEarly)
+}
+
+func TestProposerPresence(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage uint8
+		proposer   *codec.Address
+		err        error
+	}{
+		{
+			name:       "missing proposer",
+			percentage: 10,
+			err:        chain.ErrMissingProposer,
+		},
+		{
+			name:     "unexpected proposer",
+			proposer: &codec.EmptyAddress,
+			err:      chain.ErrUnexpectedProposer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			gen := genesis.Default()
+			gen.ProposerRewardPercentage = tt.percentage
+			genesisBytes, err := json.Marshal(gen)
+			require.NoError(err)
+			h := vmtest.New(t, New(), vmtest.Config{
+				Genesis:   genesisBytes,
+				VMConfig:  []byte(`{"config":{"testMode":true}}`),
+				NetworkID: 1,
+			})
+
+			// Blocks include a proposer if and only if proposer rewards are
+			// enabled (which is not known when they are parsed)
+			parent := h.VM().LastAcceptedBlock()
+			blk := &chain.StatefulBlock{
+				Prnt:     parent.ID(),
+				Tmstmp:   time.Now().UnixMilli(),
+				Hght:     parent.Hght + 1,
+				Proposer: tt.proposer,
+			}
+			blkBytes, err := blk.Marshal()
+			require.NoError(err)
+			parsed, err := h.VM().ParseBlock(ctx, blkBytes)
+			require.NoError(err)
+			require.ErrorIs(parsed.Verify(ctx), tt.err)
+		})
+	}
+}
+
+// discountController waives half of the base fee of transfers to [to] (in at
+// most [max] transactions per block).
+type discountController struct {
+	*Controller
+
+	to  codec.Address
+	max int
+}
+
+func (c *discountController) Rules(t int64) chain.Rules {
+	return &discountRules{Rules: c.Controller.Rules(t).(*genesis.Rules), c: c}
+}
+
+type discountRules struct {
+	*genesis.Rules
+
+	c *discountController
+}
+
+func (r *discountRules) FeeDiscount(tx *chain.Transaction) uint8 {
+	if transfer, ok := tx.Actions[0].(*actions.Transfer); ok && transfer.To == r.c.to {
+		return 50
+	}
+	return 0
+}
+
+func (r *discountRules) GetMaxDiscountedTxs() int { return r.c.max }
+
+func TestFeeDiscount(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	factory := auth.NewED25519Factory(priv)
+	addr := auth.NewED25519Address(priv.PublicKey())
+	var (
+		discounted = codec.CreateAddress(0, ids.GenerateTestID())
+		other      = codec.CreateAddress(0, ids.GenerateTestID())
+	)
+
+	gen := genesis.Default()
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.CustomAllocation = []*genesis.CustomAllocation{
+		{Address: consts.AddressFormat.Encode(addr), Balance: 1_000_000},
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+	c := &discountController{Controller: &Controller{}, to: discounted, max: 1}
+	h := vmtest.New(t, vm.New(c, version.Version), vmtest.Config{
+		Genesis:   genesisBytes,
+		VMConfig:  []byte(`{"config":{"testMode":true}}`),
+		NetworkID: 1,
+	})
+
+	// Blocks include at most [GetMaxDiscountedTxs] discounted transactions...
+	h.Submit(ctx,
+		h.GenerateTx([]chain.Action{&actions.Transfer{To: discounted, Value: 1}}, factory),
+		h.GenerateTx([]chain.Action{&actions.Transfer{To: discounted, Value: 2}}, factory),
+		h.GenerateTx([]chain.Action{&actions.Transfer{To: other, Value: 1}}, factory),
+	)
+	blk := h.BuildBlock(ctx)
+	require.Len(blk.Txs, 2)
+	results := h.AcceptBlock(ctx, blk)
+	h.RequireSuccess(results)
+	var discountedFee, fullFee uint64
+	for i, tx := range blk.Txs {
+		if tx.Actions[0].(*actions.Transfer).To == discounted {
+			discountedFee = results[i].Fee
+		} else {
+			fullFee = results[i].Fee
+		}
+	}
+	require.NotZero(fullFee)
+	require.Equal(fullFee-fullFee/2, discountedFee)
+
+	// ...and defer the others to later blocks
+	require.Eventually(func() bool {
+		return h.VM().Mempool().Len(ctx) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	blk = h.BuildBlock(ctx)
+	require.Len(blk.Txs, 1)
+	require.Equal(discounted, blk.Txs[0].Actions[0].(*actions.Transfer).To)
+	h.RequireSuccess(h.AcceptBlock(ctx, blk))
+
+	// Blocks with more discounted transactions are rejected if another
+	// builder produces them
+	parent := h.VM().LastAcceptedBlock()
+	stateful := &chain.StatefulBlock{
+		Prnt:   parent.ID(),
+		Tmstmp: time.Now().UnixMilli(),
+		Hght:   parent.Hght + 1,
+		Txs: []*chain.Transaction{
+			h.GenerateTx([]chain.Action{&actions.Transfer{To: discounted, Value: 3}}, factory),
+			h.GenerateTx([]chain.Action{&actions.Transfer{To: other, Value: 2}}, factory),
+			h.GenerateTx([]chain.Action{&actions.Transfer{To: discounted, Value: 4}}, factory),
+		},
+	}
+	blkBytes, err := stateful.Marshal()
+	require.NoError(err)
+	parsed, err := h.VM().ParseBlock(ctx, blkBytes)
+	require.NoError(err)
+	require.ErrorIs(parsed.Verify(ctx), chain.ErrTooManyDiscounted)
+}
+
+func TestStatePrefetch(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		factories   = make([]chain.AuthFactory, 3)
+		addrs       = make([]codec.Address, 3)
+		allocations = make([]*genesis.CustomAllocation, 3)
+	)
+	for i := range factories {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		factories[i] = auth.NewED25519Factory(priv)
+		addrs[i] = auth.NewED25519Address(priv.PublicKey())
+		allocations[i] = &genesis.CustomAllocation{Address: consts.AddressFormat.Encode(addrs[i]), Balance: 1_000_000}
+	}
+	gen := genesis.Default()
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.CustomAllocation = allocations
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+
+	// Blocks built by [builder] are verified by a node that prefetches state
+	// and a node that doesn't
+	chainID := ids.GenerateTestID()
+	newHarness := func(prefetch bool) *vmtest.Harness {
+		vmConfig, err := json.Marshal(map[string]any{
+			"statePrefetch": prefetch,
+			"config":        map[string]any{"testMode": true},
+		})
+		require.NoError(err)
+		return vmtest.New(t, New(), vmtest.Config{
+			Genesis:   genesisBytes,
+			VMConfig:  vmConfig,
+			NetworkID: 1,
+			ChainID:   chainID,
+		})
+	}
+	var (
+		builder     = newHarness(true)
+		prefetching = newHarness(true)
+		fetching    = newHarness(false)
+	)
+
+	// The second block is built before the first is accepted, so keys
+	// prefetched from the accepted state are modified by a processing
+	// ancestor
+	var blks []*chain.StatelessBlock
+	for i := 0; i < 2; i++ {
+		for j, factory := range factories {
+			builder.Submit(ctx, builder.GenerateTx([]chain.Action{
+				&actions.Transfer{To: addrs[(j+1)%len(addrs)], Value: uint64(100*i + j + 1)},
+				&actions.Transfer{To: codec.CreateAddress(0, ids.GenerateTestID()), Value: 1},
+			}, factory))
+		}
+		blks = append(blks, builder.BuildBlock(ctx))
+	}
+	for _, h := range []*vmtest.Harness{prefetching, fetching} {
+		for _, blk := range blks {
+			verified := h.VerifyBlock(ctx, blk.Bytes())
+			require.Equal(blk.ID(), verified.ID())
+		}
+	}
+	prefetched := func(h *vmtest.Harness) float64 {
+		families, err := h.Metrics().Gather()
+		require.NoError(err)
+		for _, family := range families {
+			if family.GetName() == "hypersdk_chain_state_prefetch_count" {
+				return family.GetMetric()[0].GetCounter().GetValue()
+			}
+		}
+		return 0
+	}
+	require.Eventually(func() bool {
+		return prefetched(prefetching) == float64(len(blks))
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Zero(prefetched(fetching))
+	for _, blk := range blks {
+		results := builder.AcceptBlock(ctx, blk)
+		builder.RequireSuccess(results)
+		for _, h := range []*vmtest.Harness{prefetching, fetching} {
+			verified, err := h.VM().GetStatelessBlock(ctx, blk.ID())
+			require.NoError(err)
+			require.Equal(results, h.AcceptBlock(ctx, verified))
+		}
+	}
+
+	// Prefetching does not change the resulting state
+	var roots []ids.ID
+	for _, h := range []*vmtest.Harness{builder, prefetching, fetching} {
+		db, err := h.VM().State()
+		require.NoError(err)
+		root, err := db.GetMerkleRoot(ctx)
+		require.NoError(err)
+		roots = append(roots, root)
+		for _, addr := range addrs {
+			expected, err := storage.GetBalanceFromState(ctx, builder.VM().ReadState, addr)
+			require.NoError(err)
+			balance, err := storage.GetBalanceFromState(ctx, h.VM().ReadState, addr)
+			require.NoError(err)
+			require.Equal(expected, balance)
+		}
+	}
+	require.Equal(roots[0], roots[1])
+	require.Equal(roots[0], roots[2])
+}
+
+func TestReadStateAt(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	factory := auth.NewED25519Factory(priv)
+	addr := auth.NewED25519Address(priv.PublicKey())
+	to := codec.CreateAddress(0, ids.GenerateTestID())
+
+	gen := genesis.Default()
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	gen.CustomAllocation = []*genesis.CustomAllocation{
+		{Address: consts.AddressFormat.Encode(addr), Balance: 1_000_000},
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(err)
+	h := vmtest.New(t, New(), vmtest.Config{
+		Genesis:   genesisBytes,
+		VMConfig:  []byte(`{"config":{"testMode":true}}`),
+		NetworkID: 1,
+	})
+	start := h.VM().LastAcceptedBlock().Hght
+	balanceAt := func(height uint64) (uint64, error) {
+		return storage.GetBalanceFromState(ctx, func(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+			return h.VM().ReadStateAt(ctx, height, keys)
+		}, to)
+	}
+
+	const blocks = 5
+	for i := 0; i < blocks; i++ {
+		h.Submit(ctx, h.GenerateTx([]chain.Action{
+			&actions.Transfer{To: to, Value: 1},
+			&actions.Transfer{To: codec.CreateAddress(0, ids.GenerateTestID()), Value: 1},
+		}, factory))
+		h.RequireSuccess(h.ProduceBlock(ctx))
+	}
+
+	// Blocks are committed to state before they are marked accepted, so the
+	// last accepted block is served from the root it produced (not the
+	// latest root of state)
+	db, err := h.VM().State()
+	require.NoError(err)
+	view, err := db.NewView(ctx, merkledb.ViewChanges{BatchOps: []database.BatchOp{
+		{Key: storage.BalanceKey(to), Value: binary.BigEndian.AppendUint64(nil, 1_000)},
+	}})
+	require.NoError(err)
+	require.NoError(view.CommitToDB(ctx))
+	balance, err := storage.GetBalanceFromState(ctx, h.VM().ReadState, to)
+	require.NoError(err)
+	require.Equal(uint64(1_000), balance)
+
+	// Past heights are served from the roots retained by the state
+	for height := start; height <= start+blocks; height++ {
+		balance, err := balanceAt(height)
+		require.NoError(err)
+		require.Equal(height-start, balance)
+	}
+	_, err = balanceAt(start + blocks + 1)
+	require.ErrorIs(err, vm.ErrHeightNotAccepted)
+}
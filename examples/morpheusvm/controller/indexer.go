@@ -0,0 +1,57 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"github.com/ava-labs/hypersdk/bridge"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+var _ vm.IndexController = (*Controller)(nil)
+
+// TxAddresses returns the recipients of the transfers in [tx].
+func (*Controller) TxAddresses(tx *chain.Transaction) []codec.Address {
+	addrs := []codec.Address{}
+	for _, action := range tx.Actions {
+		switch act := action.(type) {
+		case *actions.Transfer:
+			addrs = append(addrs, act.To)
+		case *actions.TransferMultiple:
+			addrs = append(addrs, act.To...)
+		case *actions.TransferName:
+			addrs = append(addrs, act.To)
+		case *actions.BridgeRelease:
+			if transfer, err := bridge.UnmarshalTransfer(act.Message.Payload); err == nil {
+				addrs = append(addrs, transfer.To)
+			}
+		}
+	}
+	return addrs
+}
+
+// TxIndexKeys indexes [tx] by the names it registers, renews, or transfers
+// and by the warp messages it sends or delivers.
+func (*Controller) TxIndexKeys(tx *chain.Transaction, result *chain.Result) [][]byte {
+	keys := [][]byte{}
+	for _, action := range tx.Actions {
+		switch act := action.(type) {
+		case *actions.RegisterName:
+			keys = append(keys, storage.NameIndexKey(act.Name))
+		case *actions.RenewName:
+			keys = append(keys, storage.NameIndexKey(act.Name))
+		case *actions.TransferName:
+			keys = append(keys, storage.NameIndexKey(act.Name))
+		case *actions.BridgeRelease:
+			keys = append(keys, storage.WarpMessageIndexKey(act.Message.UnsignedMessage.ID()))
+		}
+	}
+	for _, msg := range result.WarpMessages {
+		keys = append(keys, storage.WarpMessageIndexKey(msg.ID()))
+	}
+	return keys
+}
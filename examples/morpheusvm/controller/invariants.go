@@ -0,0 +1,26 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+var _ vm.InvariantController = (*Controller)(nil)
+
+func (*Controller) Invariants() []*vm.Invariant {
+	return []*vm.Invariant{
+		{
+			Name: "balances",
+			Check: func(_ context.Context, db merkledb.Trie) error {
+				return storage.CheckBalances(db)
+			},
+		},
+	}
+}
@@ -14,8 +14,11 @@ import (
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
 	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/vm"
 )
 
+var _ vm.BalanceController = (*Controller)(nil)
+
 func (c *Controller) Genesis() *genesis.Genesis {
 	return c.genesis
 }
@@ -31,13 +34,46 @@ func (c *Controller) Tracer() trace.Tracer {
 func (c *Controller) GetTransaction(
 	ctx context.Context,
 	txID ids.ID,
-) (bool, int64, bool, fees.Dimensions, uint64, error) {
+) (bool, int64, bool, fees.Dimensions, uint64, [][]byte, error) {
 	return storage.GetTransaction(ctx, c.db, txID)
 }
 
+func (c *Controller) GetHistory(
+	ctx context.Context,
+	addr codec.Address,
+	cursor []byte,
+	limit int,
+) ([]*storage.HistoryEntry, []byte, error) {
+	return storage.GetHistory(ctx, c.db, addr, cursor, limit)
+}
+
 func (c *Controller) GetBalanceFromState(
 	ctx context.Context,
 	acct codec.Address,
 ) (uint64, error) {
 	return storage.GetBalanceFromState(ctx, c.inner.ReadState, acct)
 }
+
+func (c *Controller) GetBalanceAtFromState(
+	ctx context.Context,
+	acct codec.Address,
+	height uint64,
+) (uint64, error) {
+	return storage.GetBalanceFromState(ctx, func(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+		return c.inner.ReadStateAt(ctx, height, keys)
+	}, acct)
+}
+
+func (c *Controller) GetNameFromState(
+	ctx context.Context,
+	name []byte,
+) (bool, codec.Address, int64, error) {
+	return storage.GetNameFromState(ctx, c.inner.ReadState, name)
+}
+
+func (c *Controller) GetLockedFromState(
+	ctx context.Context,
+	destination ids.ID,
+) (uint64, error) {
+	return storage.GetLockedFromState(ctx, c.inner.ReadState, destination)
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
+	"github.com/ava-labs/hypersdk/simulator"
+)
+
+type simulationParser struct {
+	genesis *genesis.Genesis
+	chainID ids.ID
+}
+
+func (p *simulationParser) Rules(t int64) chain.Rules {
+	return p.genesis.Rules(t, 1, p.chainID)
+}
+
+func (*simulationParser) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return consts.ActionRegistry, consts.AuthRegistry
+}
+
+// simulate produces [blocks] blocks of random transfers between [accounts]
+// funded accounts and returns the blocks produced.
+func simulate(t *testing.T, seed int64, accounts int, blocks int) []*simulator.Block {
+	require := require.New(t)
+	ctx := context.Background()
+
+	gen := genesis.Default()
+	factories := make([]chain.AuthFactory, accounts)
+	addrs := make([]codec.Address, accounts)
+	for i := range factories {
+		seed := make([]byte, ed25519.PrivateKeySeedLen)
+		seed[0] = byte(i + 1)
+		priv, err := ed25519.PrivateKeyFromSeed(seed)
+		require.NoError(err)
+		factories[i] = auth.NewED25519Factory(priv)
+		addrs[i] = auth.NewED25519Address(priv.PublicKey())
+		gen.CustomAllocation = append(gen.CustomAllocation, &genesis.CustomAllocation{
+			Address: consts.AddressFormat.Encode(addrs[i]),
+			Balance: 10_000_000_000,
+		})
+	}
+	s, err := simulator.New(ctx, simulator.Config{
+		Genesis:      gen,
+		Parser:       &simulationParser{genesis: gen, chainID: ids.Empty.Prefix(1)},
+		StateManager: &storage.StateManager{},
+		Seed:         seed,
+		Start:        1_000,
+	})
+	require.NoError(err)
+
+	blks, err := s.Run(ctx, simulator.Random(blocks, gen.MinBlockGap, 2*gen.MinEmptyBlockGap, func(_ context.Context, s *simulator.Simulator) ([]*chain.Transaction, error) {
+		txs := make([]*chain.Transaction, 1+s.Rand().Intn(50))
+		for i := range txs {
+			from := s.Rand().Intn(accounts)
+			transfer := &actions.Transfer{
+				To:    addrs[s.Rand().Intn(accounts)],
+				Value: 1 + s.Rand().Uint64()%1_000_000,
+			}
+			tx, err := s.GenerateTx([]chain.Action{transfer}, factories[from])
+			if err != nil {
+				return nil, err
+			}
+			txs[i] = tx
+		}
+		return txs, nil
+	}))
+	require.NoError(err)
+	require.Len(blks, blocks)
+	return blks
+}
+
+func TestSimulation(t *testing.T) {
+	require := require.New(t)
+
+	blks := simulate(t, 1, 10, 25)
+	var included int
+	for i, blk := range blks {
+		require.Equal(uint64(i+1), blk.Height)
+		require.Len(blk.Results, len(blk.Txs))
+		included += len(blk.Txs)
+	}
+	require.Positive(included)
+
+	// Blocks consume more than the target units, so unit prices rise
+	require.NotEqual(blks[0].UnitPrices, blks[len(blks)-1].UnitPrices)
+
+	// The same seed reproduces the same blocks
+	for i, blk := range simulate(t, 1, 10, 25) {
+		require.Equal(blks[i].Timestamp, blk.Timestamp)
+		require.Equal(blks[i].UnitPrices, blk.UnitPrices)
+		require.Equal(blks[i].StateRoot, blk.StateRoot)
+	}
+
+	// A different seed produces different transactions
+	other := simulate(t, 2, 10, 25)
+	require.NotEqual(blks[len(blks)-1].StateRoot, other[len(other)-1].StateRoot)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package explorer adapts the actions and state of morpheusvm to the explorer
+// API served by [explorer.Server].
+package explorer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/explorer"
+
+	mrpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+)
+
+// NativeAssetID is the ID of the only asset of morpheusvm.
+const NativeAssetID = "native"
+
+var (
+	_ explorer.Controller = (*Controller)(nil)
+
+	actionNames = map[uint8]string{
+		consts.TransferID:         "Transfer",
+		consts.TransferMultipleID: "TransferMultiple",
+		consts.RegisterNameID:     "RegisterName",
+		consts.RenewNameID:        "RenewName",
+		consts.TransferNameID:     "TransferName",
+		consts.BridgeLockID:       "BridgeLock",
+		consts.BridgeReleaseID:    "BridgeRelease",
+	}
+
+	nativeAsset = &explorer.Asset{ID: NativeAssetID, Symbol: consts.Symbol, Decimals: consts.Decimals}
+)
+
+// Controller names morpheusvm actions and fetches balances for
+// [explorer.Server].
+type Controller struct {
+	chain.Parser
+
+	cli *mrpc.JSONRPCClient
+}
+
+func NewController(ctx context.Context, cli *mrpc.JSONRPCClient) (*Controller, error) {
+	parser, err := cli.Parser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Controller{Parser: parser, cli: cli}, nil
+}
+
+func (*Controller) ParseAddress(address string) (codec.Address, error) {
+	return consts.AddressFormat.Parse(address)
+}
+
+func (*Controller) Address(addr codec.Address) string {
+	return consts.AddressFormat.Encode(addr)
+}
+
+func (*Controller) ActionName(typeID uint8) string {
+	if name, ok := actionNames[typeID]; ok {
+		return name
+	}
+	return strconv.Itoa(int(typeID))
+}
+
+func (*Controller) Assets(context.Context) ([]*explorer.Asset, error) {
+	return []*explorer.Asset{nativeAsset}, nil
+}
+
+func (c *Controller) Balances(ctx context.Context, addr codec.Address) ([]*explorer.Balance, error) {
+	balance, err := c.cli.Balance(ctx, c.Address(addr))
+	if err != nil {
+		return nil, err
+	}
+	if balance == 0 {
+		return []*explorer.Balance{}, nil
+	}
+	return []*explorer.Balance{{Asset: NativeAssetID, Amount: balance}}, nil
+}
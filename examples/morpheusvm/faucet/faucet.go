@@ -0,0 +1,131 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var ErrRateLimited = errors.New("rate limited")
+
+type VM interface {
+	Logger() logging.Logger
+	Rules(int64) chain.Rules
+	Registry() (chain.ActionRegistry, chain.AuthRegistry)
+	UnitPrices(context.Context) (fees.Dimensions, error)
+	Submit(context.Context, bool, []*chain.Transaction) []error
+}
+
+// Faucet transfers [amount] from a funded key to anyone who asks, at most
+// once per [cooldown] for each address and each requesting IP.
+type Faucet struct {
+	vm       VM
+	factory  *auth.ED25519Factory
+	address  codec.Address
+	amount   uint64
+	cooldown time.Duration
+
+	l           sync.Mutex
+	lastRequest map[string]time.Time
+}
+
+func New(vm VM, privateKey ed25519.PrivateKey, amount uint64, cooldown time.Duration) *Faucet {
+	return &Faucet{
+		vm:          vm,
+		factory:     auth.NewED25519Factory(privateKey),
+		address:     auth.NewED25519Address(privateKey.PublicKey()),
+		amount:      amount,
+		cooldown:    cooldown,
+		lastRequest: map[string]time.Time{},
+	}
+}
+
+func (f *Faucet) Address() codec.Address {
+	return f.address
+}
+
+// Request issues a transfer to [to] on behalf of a requester at [ip].
+func (f *Faucet) Request(ctx context.Context, ip string, to codec.Address) (ids.ID, uint64, error) {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	now := time.Now()
+	for k, t := range f.lastRequest {
+		if now.Sub(t) >= f.cooldown {
+			delete(f.lastRequest, k)
+		}
+	}
+	addrKey := "addr:" + string(to[:])
+	ipKey := "ip:" + ip
+	if _, ok := f.lastRequest[addrKey]; ok {
+		return ids.Empty, 0, fmt.Errorf("%w: address", ErrRateLimited)
+	}
+	if _, ok := f.lastRequest[ipKey]; ok {
+		return ids.Empty, 0, fmt.Errorf("%w: ip", ErrRateLimited)
+	}
+
+	txID, err := f.send(ctx, to)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	f.lastRequest[addrKey] = now
+	f.lastRequest[ipKey] = now
+	f.vm.Logger().Info("fauceted funds",
+		zap.Stringer("txID", txID),
+		zap.String("destination", consts.AddressFormat.Encode(to)),
+		zap.String("amount", utils.FormatBalance(f.amount, consts.Decimals)),
+	)
+	return txID, f.amount, nil
+}
+
+func (f *Faucet) send(ctx context.Context, to codec.Address) (ids.ID, error) {
+	var (
+		now   = time.Now().UnixMilli()
+		rules = f.vm.Rules(now)
+		acts  = []chain.Action{&actions.Transfer{
+			To:    to,
+			Value: f.amount,
+		}}
+	)
+	unitPrices, err := f.vm.UnitPrices(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	units, err := chain.EstimateUnits(rules, acts, f.factory)
+	if err != nil {
+		return ids.Empty, err
+	}
+	maxFee, err := fees.MulSum(unitPrices, units)
+	if err != nil {
+		return ids.Empty, err
+	}
+	base := &chain.Base{
+		Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
+		ChainID:   rules.ChainID(),
+		MaxFee:    maxFee,
+	}
+	actionRegistry, authRegistry := f.vm.Registry()
+	tx, err := chain.NewTx(base, acts).Sign(f.factory, actionRegistry, authRegistry)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return tx.ID(), f.vm.Submit(ctx, true, []*chain.Transaction{tx})[0]
+}
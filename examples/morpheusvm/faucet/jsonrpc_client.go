@@ -0,0 +1,51 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faucet
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/requester"
+)
+
+type JSONRPCClient struct {
+	requester *requester.EndpointRequester
+}
+
+// New creates a new client object.
+func NewJSONRPCClient(uri string) *JSONRPCClient {
+	uri = strings.TrimSuffix(uri, "/")
+	uri += JSONRPCEndpoint
+	req := requester.New(uri, Name)
+	return &JSONRPCClient{
+		requester: req,
+	}
+}
+
+func (cli *JSONRPCClient) FaucetAddress(ctx context.Context) (string, error) {
+	resp := new(FaucetAddressReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"faucetAddress",
+		nil,
+		resp,
+	)
+	return resp.Address, err
+}
+
+func (cli *JSONRPCClient) Request(ctx context.Context, addr string) (ids.ID, uint64, error) {
+	resp := new(RequestReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"request",
+		&RequestArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.TxID, resp.Amount, err
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faucet
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+)
+
+const (
+	Name            = "faucet"
+	JSONRPCEndpoint = "/faucet"
+)
+
+type JSONRPCServer struct {
+	f *Faucet
+}
+
+func NewJSONRPCServer(f *Faucet) *JSONRPCServer {
+	return &JSONRPCServer{f}
+}
+
+type FaucetAddressReply struct {
+	Address string `json:"address"`
+}
+
+func (j *JSONRPCServer) FaucetAddress(_ *http.Request, _ *struct{}, reply *FaucetAddressReply) error {
+	reply.Address = consts.AddressFormat.Encode(j.f.Address())
+	return nil
+}
+
+type RequestArgs struct {
+	Address string `json:"address"`
+}
+
+type RequestReply struct {
+	TxID   ids.ID `json:"txID"`
+	Amount uint64 `json:"amount"`
+}
+
+// Request sends funds to [Address]. Requests are rate limited by the address
+// that is funded and by the IP of the connection that made the request.
+func (j *JSONRPCServer) Request(req *http.Request, args *RequestArgs, reply *RequestReply) error {
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return err
+	}
+	txID, amount, err := j.f.Request(req.Context(), ip, addr)
+	if err != nil {
+		return err
+	}
+	reply.TxID = txID
+	reply.Amount = amount
+	return nil
+}
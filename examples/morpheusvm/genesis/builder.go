@@ -0,0 +1,40 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+
+	hgenesis "github.com/ava-labs/hypersdk/genesis"
+)
+
+var _ hgenesis.Controller[*Genesis] = Builder{}
+
+// Builder builds a [Genesis] from a [hgenesis.Spec]. Custom state entries are
+// not supported (balances are the only state at genesis).
+type Builder struct{}
+
+func (Builder) Default() *Genesis {
+	return Default()
+}
+
+func (Builder) Allocate(g *Genesis, allocations []*hgenesis.Allocation) error {
+	for _, alloc := range allocations {
+		g.CustomAllocation = append(g.CustomAllocation, &CustomAllocation{
+			Address: alloc.Address,
+			Balance: alloc.Balance,
+		})
+	}
+	return nil
+}
+
+func (Builder) Verify(g *Genesis) error {
+	return g.Verify()
+}
+
+func (Builder) Rules(g *Genesis) chain.Rules {
+	return g.Rules(0, 0, ids.Empty)
+}
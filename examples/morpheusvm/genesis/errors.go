@@ -8,4 +8,7 @@ import "errors"
 var (
 	ErrInvalidHRP    = errors.New("invalid HRP")
 	ErrInvalidTarget = errors.New("invalid target")
+	ErrInvalidGap    = errors.New("invalid block gap")
+	ErrInvalidWindow = errors.New("invalid validity window")
+	ErrInvalidReward = errors.New("invalid proposer reward percentage")
 )
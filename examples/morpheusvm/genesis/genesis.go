@@ -11,7 +11,6 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
-	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
 	"github.com/ava-labs/hypersdk/fees"
@@ -34,14 +33,17 @@ type Genesis struct {
 	StateBranchFactor merkledb.BranchFactor `json:"stateBranchFactor"`
 
 	// Chain Parameters
-	MinBlockGap      int64 `json:"minBlockGap"`      // ms
-	MinEmptyBlockGap int64 `json:"minEmptyBlockGap"` // ms
+	MinBlockGap             int64 `json:"minBlockGap"`             // ms
+	MinEmptyBlockGap        int64 `json:"minEmptyBlockGap"`        // ms
+	MaxEmptyBlockGap        int64 `json:"maxEmptyBlockGap"`        // ms
+	BlockTimestampTolerance int64 `json:"blockTimestampTolerance"` // ms
 
 	// Chain Fee Parameters
 	MinUnitPrice               fees.Dimensions `json:"minUnitPrice"`
 	UnitPriceChangeDenominator fees.Dimensions `json:"unitPriceChangeDenominator"`
-	WindowTargetUnits          fees.Dimensions `json:"windowTargetUnits"` // 10s
-	MaxBlockUnits              fees.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
+	WindowTargetUnits          fees.Dimensions `json:"windowTargetUnits"`        // 10s
+	MaxBlockUnits              fees.Dimensions `json:"maxBlockUnits"`            // must be possible to reach before block too large
+	ProposerRewardPercentage   uint8           `json:"proposerRewardPercentage"` // of base fees credited to the proposer of each block (the rest is burned)
 
 	// Tx Parameters
 	ValidityWindow      int64 `json:"validityWindow"` // ms
@@ -67,8 +69,10 @@ func Default() *Genesis {
 		StateBranchFactor: merkledb.BranchFactor16,
 
 		// Chain Parameters
-		MinBlockGap:      100,
-		MinEmptyBlockGap: 750,
+		MinBlockGap:             100,
+		MinEmptyBlockGap:        750,
+		MaxEmptyBlockGap:        750,
+		BlockTimestampTolerance: 1_000,
 
 		// Chain Fee Parameters
 		MinUnitPrice:               fees.Dimensions{100, 100, 100, 100, 100},
@@ -106,6 +110,35 @@ func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
 	return g, nil
 }
 
+// Verify returns an error if [g] can't be loaded or would create a chain
+// that can't make progress.
+func (g *Genesis) Verify() error {
+	if err := g.StateBranchFactor.Valid(); err != nil {
+		return err
+	}
+	if g.MinBlockGap < 0 || g.MinEmptyBlockGap < 0 || g.MaxEmptyBlockGap < 0 || g.BlockTimestampTolerance < 0 {
+		return ErrInvalidGap
+	}
+	if g.ValidityWindow <= 0 {
+		return ErrInvalidWindow
+	}
+	if g.ProposerRewardPercentage > 100 {
+		return ErrInvalidReward
+	}
+	supply := uint64(0)
+	for _, alloc := range g.CustomAllocation {
+		if _, err := consts.AddressFormat.Parse(alloc.Address); err != nil {
+			return fmt.Errorf("%w: %s", err, alloc.Address)
+		}
+		var err error
+		supply, err = smath.Add64(supply, alloc.Balance)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutable) error {
 	ctx, span := tracer.Start(ctx, "Genesis.Load")
 	defer span.End()
@@ -116,7 +149,7 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutabl
 
 	supply := uint64(0)
 	for _, alloc := range g.CustomAllocation {
-		addr, err := codec.ParseAddressBech32(consts.HRP, alloc.Address)
+		addr, err := consts.AddressFormat.Parse(alloc.Address)
 		if err != nil {
 			return fmt.Errorf("%w: %s", err, alloc.Address)
 		}
@@ -41,6 +41,14 @@ func (r *Rules) GetMinEmptyBlockGap() int64 {
 	return r.g.MinEmptyBlockGap
 }
 
+func (r *Rules) GetMaxEmptyBlockGap() int64 {
+	return r.g.MaxEmptyBlockGap
+}
+
+func (r *Rules) GetBlockTimestampTolerance() int64 {
+	return r.g.BlockTimestampTolerance
+}
+
 func (r *Rules) GetValidityWindow() int64 {
 	return r.g.ValidityWindow
 }
@@ -101,6 +109,12 @@ func (r *Rules) GetWindowTargetUnits() fees.Dimensions {
 	return r.g.WindowTargetUnits
 }
 
+var _ chain.ProposerRewardRules = (*Rules)(nil)
+
+func (r *Rules) GetProposerRewardPercentage() uint8 {
+	return r.g.ProposerRewardPercentage
+}
+
 func (*Rules) FetchCustom(string) (any, bool) {
 	return nil, false
 }
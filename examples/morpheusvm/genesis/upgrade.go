@@ -0,0 +1,28 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// Upgrade is the upgrade schedule of a chain (loaded from its upgrade bytes).
+type Upgrade struct {
+	// Actions schedule the activation and deactivation of action types
+	// (other action types are always active)
+	Actions []*chain.ActionActivation `json:"actions"`
+}
+
+func NewUpgrade(b []byte) (*Upgrade, error) {
+	u := &Upgrade{}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, u); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal upgrade %s: %w", string(b), err)
+		}
+	}
+	return u, nil
+}
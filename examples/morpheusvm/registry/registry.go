@@ -22,6 +22,12 @@ func init() {
 	errs.Add(
 		// When registering new actions, ALWAYS make sure to append at the end.
 		consts.ActionRegistry.Register((&actions.Transfer{}).GetTypeID(), actions.UnmarshalTransfer),
+		consts.ActionRegistry.Register((&actions.TransferMultiple{}).GetTypeID(), actions.UnmarshalTransferMultiple),
+		consts.ActionRegistry.Register((&actions.RegisterName{}).GetTypeID(), actions.UnmarshalRegisterName),
+		consts.ActionRegistry.Register((&actions.RenewName{}).GetTypeID(), actions.UnmarshalRenewName),
+		consts.ActionRegistry.Register((&actions.TransferName{}).GetTypeID(), actions.UnmarshalTransferName),
+		consts.ActionRegistry.Register((&actions.BridgeLock{}).GetTypeID(), actions.UnmarshalBridgeLock),
+		consts.ActionRegistry.Register((&actions.BridgeRelease{}).GetTypeID(), actions.UnmarshalBridgeRelease),
 
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519),
@@ -0,0 +1,71 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/fuzz"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+)
+
+// seedActions returns a valid instance of each action of the morpheusvm that
+// doesn't carry a warp message.
+func seedActions() []chain.Action {
+	to := codec.CreateAddress(auth.ED25519ID, ids.GenerateTestID())
+	return []chain.Action{
+		&actions.Transfer{To: to, Value: 10, Memo: []byte("memo")},
+		&actions.TransferMultiple{To: []codec.Address{to, to}, Values: []uint64{1, 2}},
+		&actions.RegisterName{Name: []byte("alice"), Periods: 1},
+		&actions.RenewName{Name: []byte("alice"), Periods: 2},
+		&actions.TransferName{Name: []byte("alice"), To: to},
+		&actions.BridgeLock{DestinationChainID: ids.GenerateTestID(), To: to, Value: 10, Fee: 1},
+	}
+}
+
+func FuzzUnmarshalAction(f *testing.F) {
+	for _, action := range seedActions() {
+		fuzz.Seed(f, fuzz.Encode(action))
+	}
+	f.Add([]byte{(&actions.BridgeRelease{}).GetTypeID()})
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckObject(t, raw, consts.ActionRegistry)
+	})
+}
+
+func FuzzUnmarshalTx(f *testing.F) {
+	require := require.New(f)
+
+	edPriv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	rPriv, err := secp256r1.GeneratePrivateKey()
+	require.NoError(err)
+	for _, factory := range []chain.AuthFactory{
+		auth.NewED25519Factory(edPriv),
+		auth.NewSECP256R1Factory(rPriv),
+	} {
+		base := &chain.Base{
+			Timestamp: hconsts.MillisecondsPerSecond,
+			ChainID:   ids.GenerateTestID(),
+			MaxFee:    1_000,
+		}
+		tx, err := chain.NewTx(base, seedActions()).Sign(factory, consts.ActionRegistry, consts.AuthRegistry)
+		require.NoError(err)
+		fuzz.Seed(f, tx.Bytes())
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckTx(t, raw, consts.ActionRegistry, consts.AuthRegistry)
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package rosetta adapts the actions of morpheusvm to the Rosetta API served
+// by [rosetta.Server].
+package rosetta
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/hypersdk/bridge"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/rosetta"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	mrpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+)
+
+const (
+	// TransferOperation debits the actor of a [actions.Transfer] (or
+	// [actions.TransferMultiple]) and credits its recipients.
+	TransferOperation = "TRANSFER"
+	// NameFeeOperation burns the fee of a [actions.RegisterName] or
+	// [actions.RenewName].
+	NameFeeOperation = "NAME_FEE"
+	// BridgeLockOperation debits the value and relay fee locked by a
+	// [actions.BridgeLock].
+	BridgeLockOperation = "BRIDGE_LOCK"
+	// BridgeReleaseOperation credits the recipient and relayer of a
+	// [actions.BridgeRelease].
+	BridgeReleaseOperation = "BRIDGE_RELEASE"
+)
+
+var (
+	_ rosetta.Controller = (*Controller)(nil)
+
+	currency = &rosetta.Currency{Symbol: consts.Symbol, Decimals: consts.Decimals}
+
+	ErrUnsupportedOperations = errors.New("only transfers can be constructed")
+	ErrMultipleActors        = errors.New("transfers must be sent by the same actor")
+)
+
+// Controller converts morpheusvm actions to and from Rosetta operations.
+type Controller struct {
+	chain.Parser
+
+	cli *mrpc.JSONRPCClient
+}
+
+func NewController(ctx context.Context, cli *mrpc.JSONRPCClient) (*Controller, error) {
+	parser, err := cli.Parser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Controller{Parser: parser, cli: cli}, nil
+}
+
+func (*Controller) Blockchain() string {
+	return consts.Name
+}
+
+func (*Controller) Currency() *rosetta.Currency {
+	return currency
+}
+
+func (*Controller) ParseAddress(address string) (codec.Address, error) {
+	return consts.AddressFormat.Parse(address)
+}
+
+func (*Controller) Address(addr codec.Address) string {
+	return consts.AddressFormat.Encode(addr)
+}
+
+func (*Controller) OperationTypes() []string {
+	return []string{TransferOperation, NameFeeOperation, BridgeLockOperation, BridgeReleaseOperation}
+}
+
+func operation(typ string, addr codec.Address, value uint64, debit bool) *rosetta.Operation {
+	return &rosetta.Operation{
+		Type:    typ,
+		Account: &rosetta.AccountIdentifier{Address: consts.AddressFormat.Encode(addr)},
+		Amount:  rosetta.NewAmount(value, debit, currency),
+	}
+}
+
+func (*Controller) Operations(actor codec.Address, action chain.Action) ([]*rosetta.Operation, error) {
+	switch act := action.(type) {
+	case *actions.Transfer:
+		return []*rosetta.Operation{
+			operation(TransferOperation, actor, act.Value, true),
+			operation(TransferOperation, act.To, act.Value, false),
+		}, nil
+	case *actions.TransferMultiple:
+		ops := make([]*rosetta.Operation, 0, 1+len(act.To))
+		var total uint64
+		for i, to := range act.To {
+			var err error
+			total, err = smath.Add64(total, act.Values[i])
+			if err != nil {
+				return nil, err
+			}
+			ops = append(ops, operation(TransferOperation, to, act.Values[i], false))
+		}
+		return append([]*rosetta.Operation{operation(TransferOperation, actor, total, true)}, ops...), nil
+	case *actions.RegisterName:
+		return nameFee(actor, act.Periods)
+	case *actions.RenewName:
+		return nameFee(actor, act.Periods)
+	case *actions.BridgeLock:
+		total, err := smath.Add64(act.Value, act.Fee)
+		if err != nil {
+			return nil, err
+		}
+		return []*rosetta.Operation{operation(BridgeLockOperation, actor, total, true)}, nil
+	case *actions.BridgeRelease:
+		// Transfers that can't be parsed are rejected by [actions.BridgeRelease.Execute]
+		transfer, err := bridge.UnmarshalTransfer(act.Message.Payload)
+		if err != nil {
+			return nil, nil
+		}
+		ops := []*rosetta.Operation{operation(BridgeReleaseOperation, transfer.To, transfer.Value, false)}
+		if transfer.Fee > 0 {
+			ops = append(ops, operation(BridgeReleaseOperation, actor, transfer.Fee, false))
+		}
+		return ops, nil
+	default:
+		// [actions.TransferName] does not change any balance
+		return nil, nil
+	}
+}
+
+func nameFee(actor codec.Address, periods uint64) ([]*rosetta.Operation, error) {
+	fee, err := smath.Mul64(actions.NameFeePerPeriod, periods)
+	if err != nil {
+		return nil, err
+	}
+	return []*rosetta.Operation{operation(NameFeeOperation, actor, fee, true)}, nil
+}
+
+// Actions converts pairs of [TransferOperation]s (the debit of the actor
+// followed by the credit of the recipient) to [actions.Transfer]s.
+func (c *Controller) Actions(ops []*rosetta.Operation) (codec.Address, []chain.Action, error) {
+	if len(ops)%2 != 0 {
+		return codec.EmptyAddress, nil, ErrUnsupportedOperations
+	}
+	var (
+		actor     codec.Address
+		transfers = make([]chain.Action, 0, len(ops)/2)
+	)
+	for i := 0; i < len(ops); i += 2 {
+		from, value, err := c.parseOperation(ops[i], true)
+		if err != nil {
+			return codec.EmptyAddress, nil, err
+		}
+		to, credit, err := c.parseOperation(ops[i+1], false)
+		if err != nil {
+			return codec.EmptyAddress, nil, err
+		}
+		if value != credit || value == 0 {
+			return codec.EmptyAddress, nil, ErrUnsupportedOperations
+		}
+		if i == 0 {
+			actor = from
+		} else if from != actor {
+			return codec.EmptyAddress, nil, ErrMultipleActors
+		}
+		transfers = append(transfers, &actions.Transfer{To: to, Value: value})
+	}
+	return actor, transfers, nil
+}
+
+func (c *Controller) parseOperation(op *rosetta.Operation, debit bool) (codec.Address, uint64, error) {
+	if op.Type != TransferOperation || op.Account == nil {
+		return codec.EmptyAddress, 0, ErrUnsupportedOperations
+	}
+	addr, err := c.ParseAddress(op.Account.Address)
+	if err != nil {
+		return codec.EmptyAddress, 0, err
+	}
+	value, isDebit, err := rosetta.ParseAmount(op.Amount, currency)
+	if err != nil {
+		return codec.EmptyAddress, 0, err
+	}
+	if isDebit != debit {
+		return codec.EmptyAddress, 0, ErrUnsupportedOperations
+	}
+	return addr, value, nil
+}
+
+func (c *Controller) Balance(ctx context.Context, addr codec.Address, height uint64) (uint64, error) {
+	return c.cli.BalanceAt(ctx, c.Address(addr), height)
+}
+
+func (c *Controller) GenesisOperations(ctx context.Context) ([]*rosetta.Operation, error) {
+	g, err := c.cli.Genesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]*rosetta.Operation, 0, len(g.CustomAllocation))
+	for _, alloc := range g.CustomAllocation {
+		addr, err := c.ParseAddress(alloc.Address)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, operation(TransferOperation, addr, alloc.Balance, false))
+	}
+	return ops, nil
+}
@@ -11,12 +11,17 @@ import (
 
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
 	"github.com/ava-labs/hypersdk/fees"
 )
 
 type Controller interface {
 	Genesis() *genesis.Genesis
 	Tracer() trace.Tracer
-	GetTransaction(context.Context, ids.ID) (bool, int64, bool, fees.Dimensions, uint64, error)
+	GetTransaction(context.Context, ids.ID) (bool, int64, bool, fees.Dimensions, uint64, [][]byte, error)
 	GetBalanceFromState(context.Context, codec.Address) (uint64, error)
+	GetBalanceAtFromState(context.Context, codec.Address, uint64) (uint64, error)
+	GetHistory(context.Context, codec.Address, []byte, int) ([]*storage.HistoryEntry, []byte, error)
+	GetNameFromState(context.Context, []byte) (bool, codec.Address, int64, error)
+	GetLockedFromState(context.Context, ids.ID) (uint64, error)
 }
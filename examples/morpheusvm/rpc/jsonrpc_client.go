@@ -74,6 +74,82 @@ func (cli *JSONRPCClient) Tx(ctx context.Context, id ids.ID) (bool, bool, int64,
 	return true, resp.Success, resp.Timestamp, resp.Fee, nil
 }
 
+// TxMemos returns the memo of each action in [id].
+func (cli *JSONRPCClient) TxMemos(ctx context.Context, id ids.ID) (bool, [][]byte, error) {
+	resp := new(TxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"tx",
+		&TxArgs{TxID: id},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrTxNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp.Memos, nil
+}
+
+// History returns up to [limit] transfers of [addr] starting at [cursor] and
+// the cursor of the next page (nil if there are no more transfers).
+func (cli *JSONRPCClient) History(
+	ctx context.Context,
+	addr string,
+	cursor []byte,
+	limit int,
+) ([]*HistoryEntry, []byte, error) {
+	resp := new(HistoryReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"history",
+		&HistoryArgs{
+			Address: addr,
+			Cursor:  cursor,
+			Limit:   limit,
+		},
+		resp,
+	)
+	return resp.Entries, resp.Next, err
+}
+
+// ResolveName returns the owner of [name] and the timestamp its registration
+// expires at.
+func (cli *JSONRPCClient) ResolveName(ctx context.Context, name string) (bool, string, int64, error) {
+	resp := new(ResolveNameReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"resolveName",
+		&ResolveNameArgs{Name: name},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrNameNotFound.Error()):
+		return false, "", 0, nil
+	case err != nil:
+		return false, "", 0, err
+	}
+	return true, resp.Address, resp.Expiry, nil
+}
+
+// Locked returns the amount bridged to [destination] that has not been
+// released yet.
+func (cli *JSONRPCClient) Locked(ctx context.Context, destination ids.ID) (uint64, error) {
+	resp := new(LockedReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"locked",
+		&LockedArgs{DestinationChainID: destination},
+		resp,
+	)
+	return resp.Amount, err
+}
+
 func (cli *JSONRPCClient) Balance(ctx context.Context, addr string) (uint64, error) {
 	resp := new(BalanceReply)
 	err := cli.requester.SendRequest(
@@ -87,6 +163,22 @@ func (cli *JSONRPCClient) Balance(ctx context.Context, addr string) (uint64, err
 	return resp.Amount, err
 }
 
+// BalanceAt returns the balance of [addr] after the block at [height] was
+// accepted.
+func (cli *JSONRPCClient) BalanceAt(ctx context.Context, addr string, height uint64) (uint64, error) {
+	resp := new(BalanceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"balanceAt",
+		&BalanceAtArgs{
+			Address: addr,
+			Height:  height,
+		},
+		resp,
+	)
+	return resp.Amount, err
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr string,
@@ -5,10 +5,12 @@ package rpc
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
 	"github.com/ava-labs/hypersdk/fees"
@@ -40,13 +42,17 @@ type TxReply struct {
 	Success   bool            `json:"success"`
 	Units     fees.Dimensions `json:"units"`
 	Fee       uint64          `json:"fee"`
+
+	// Memos contains the memo of each action in the transaction (empty if
+	// the action has no memo).
+	Memos [][]byte `json:"memos"`
 }
 
 func (j *JSONRPCServer) Tx(req *http.Request, args *TxArgs, reply *TxReply) error {
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Tx")
 	defer span.End()
 
-	found, t, success, units, fee, err := j.c.GetTransaction(ctx, args.TxID)
+	found, t, success, units, fee, memos, err := j.c.GetTransaction(ctx, args.TxID)
 	if err != nil {
 		return err
 	}
@@ -57,6 +63,7 @@ func (j *JSONRPCServer) Tx(req *http.Request, args *TxArgs, reply *TxReply) erro
 	reply.Success = success
 	reply.Units = units
 	reply.Fee = fee
+	reply.Memos = memos
 	return nil
 }
 
@@ -72,7 +79,7 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Balance")
 	defer span.End()
 
-	addr, err := codec.ParseAddressBech32(consts.HRP, args.Address)
+	addr, err := consts.AddressFormat.Parse(args.Address)
 	if err != nil {
 		return err
 	}
@@ -83,3 +90,134 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	reply.Amount = balance
 	return err
 }
+
+type BalanceAtArgs struct {
+	Address string `json:"address"`
+	Height  uint64 `json:"height"`
+}
+
+// BalanceAt returns the balance of [Address] after the block at [Height] was
+// accepted. Only recent heights (see [StateHistoryLength]) can be queried.
+func (j *JSONRPCServer) BalanceAt(req *http.Request, args *BalanceAtArgs, reply *BalanceReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.BalanceAt")
+	defer span.End()
+
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	balance, err := j.c.GetBalanceAtFromState(ctx, addr, args.Height)
+	if err != nil {
+		return err
+	}
+	reply.Amount = balance
+	return nil
+}
+
+type HistoryArgs struct {
+	Address string `json:"address"`
+
+	// Cursor is the [HistoryReply.Next] of the previous page. If empty, the
+	// history is returned from the first transfer.
+	Cursor []byte `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type HistoryEntry struct {
+	TxID         ids.ID `json:"txId"`
+	Timestamp    int64  `json:"timestamp"`
+	Counterparty string `json:"counterparty"`
+	Amount       uint64 `json:"amount"`
+	Incoming     bool   `json:"incoming"`
+}
+
+type HistoryReply struct {
+	Entries []*HistoryEntry `json:"entries"`
+	Next    []byte          `json:"next"`
+}
+
+// History returns the transfers into and out of [Address], oldest first.
+// Transactions sent by [Address] that did not transfer any funds are
+// returned with an empty [HistoryEntry.Counterparty].
+// Transfers are only indexed if the node is configured with [storeHistory].
+func (j *JSONRPCServer) History(req *http.Request, args *HistoryArgs, reply *HistoryReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.History")
+	defer span.End()
+
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	limit := args.Limit
+	if limit <= 0 || limit > historyToSend {
+		limit = historyToSend
+	}
+	entries, next, err := j.c.GetHistory(ctx, addr, args.Cursor, limit)
+	if err != nil {
+		return err
+	}
+	reply.Entries = make([]*HistoryEntry, len(entries))
+	for i, entry := range entries {
+		var counterparty string
+		if entry.Counterparty != codec.EmptyAddress {
+			counterparty = consts.AddressFormat.Encode(entry.Counterparty)
+		}
+		reply.Entries[i] = &HistoryEntry{
+			TxID:         entry.TxID,
+			Timestamp:    entry.Timestamp,
+			Counterparty: counterparty,
+			Amount:       entry.Amount,
+			Incoming:     entry.Incoming,
+		}
+	}
+	reply.Next = next
+	return nil
+}
+
+type ResolveNameArgs struct {
+	Name string `json:"name"`
+}
+
+type ResolveNameReply struct {
+	Address string `json:"address"`
+	Expiry  int64  `json:"expiry"`
+}
+
+// ResolveName returns the owner of [Name]. Expired names are not resolved.
+func (j *JSONRPCServer) ResolveName(req *http.Request, args *ResolveNameArgs, reply *ResolveNameReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ResolveName")
+	defer span.End()
+
+	exists, owner, expiry, err := j.c.GetNameFromState(ctx, []byte(args.Name))
+	if err != nil {
+		return err
+	}
+	if !exists || actions.NameExpired(expiry, time.Now().UnixMilli()) {
+		return ErrNameNotFound
+	}
+	reply.Address = consts.AddressFormat.Encode(owner)
+	reply.Expiry = expiry
+	return nil
+}
+
+type LockedArgs struct {
+	DestinationChainID ids.ID `json:"destinationChainID"`
+}
+
+type LockedReply struct {
+	Amount uint64 `json:"amount"`
+}
+
+// Locked returns the amount bridged to [DestinationChainID] that has not been
+// released yet.
+func (j *JSONRPCServer) Locked(req *http.Request, args *LockedArgs, reply *LockedReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Locked")
+	defer span.End()
+
+	amount, err := j.c.GetLockedFromState(ctx, args.DestinationChainID)
+	if err != nil {
+		return err
+	}
+	reply.Amount = amount
+	return nil
+}
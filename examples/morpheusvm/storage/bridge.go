@@ -0,0 +1,107 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// Funds bridged to another chain are locked for that chain and can only be
+// released by a warp message sent by it, so a chain can never release more
+// than was bridged to it.
+
+// [lockedPrefix] + [destinationChainID]
+func LockedKey(destination ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = lockedPrefix
+	copy(k[1:], destination[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], LockedChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetLockedFromState(ctx context.Context, f ReadState, destination ids.ID) (uint64, error) {
+	values, errs := f(ctx, [][]byte{LockedKey(destination)})
+	return innerGetLocked(values[0], errs[0])
+}
+
+func GetLocked(ctx context.Context, im state.Immutable, destination ids.ID) (uint64, error) {
+	return innerGetLocked(im.GetValue(ctx, LockedKey(destination)))
+}
+
+func innerGetLocked(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func AddLocked(ctx context.Context, mu state.Mutable, destination ids.ID, amount uint64) error {
+	locked, err := GetLocked(ctx, mu, destination)
+	if err != nil {
+		return err
+	}
+	nlocked, err := smath.Add64(locked, amount)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, LockedKey(destination), binary.BigEndian.AppendUint64(nil, nlocked))
+}
+
+func SubLocked(ctx context.Context, mu state.Mutable, destination ids.ID, amount uint64) error {
+	locked, err := GetLocked(ctx, mu, destination)
+	if err != nil {
+		return err
+	}
+	nlocked, err := smath.Sub(locked, amount)
+	if err != nil {
+		return fmt.Errorf("%w: locked=%d amount=%d", ErrInsufficientLocked, locked, amount)
+	}
+	if nlocked == 0 {
+		return mu.Remove(ctx, LockedKey(destination))
+	}
+	return mu.Insert(ctx, LockedKey(destination), binary.BigEndian.AppendUint64(nil, nlocked))
+}
+
+// [warpPrefix] + [msgID]
+func WarpMessageKey(msgID ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = warpPrefix
+	copy(k[1:], msgID[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], WarpMessageChunks)
+	return
+}
+
+// [warpIndexPrefix] + [msgID]
+func WarpMessageIndexKey(msgID ids.ID) []byte {
+	return append([]byte{warpIndexPrefix}, msgID[:]...)
+}
+
+// ConsumeWarpMessage records that [msgID] was delivered and errors if it was
+// delivered before.
+func ConsumeWarpMessage(ctx context.Context, mu state.Mutable, msgID ids.ID) error {
+	k := WarpMessageKey(msgID)
+	_, err := mu.GetValue(ctx, k)
+	if err == nil {
+		return ErrWarpMessageConsumed
+	}
+	if !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	return mu.Insert(ctx, k, []byte{successByte})
+}
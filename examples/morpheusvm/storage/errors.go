@@ -5,4 +5,8 @@ package storage
 
 import "errors"
 
-var ErrInvalidBalance = errors.New("invalid balance")
+var (
+	ErrInvalidBalance      = errors.New("invalid balance")
+	ErrInsufficientLocked  = errors.New("insufficient locked funds")
+	ErrWarpMessageConsumed = errors.New("warp message already consumed")
+)
@@ -0,0 +1,75 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// [namePrefix] + [name]
+func NameKey(name []byte) (k []byte) {
+	k = make([]byte, 1+len(name)+consts.Uint16Len)
+	k[0] = namePrefix
+	copy(k[1:], name)
+	binary.BigEndian.PutUint16(k[1+len(name):], NameChunks)
+	return
+}
+
+// [nameIndexPrefix] + [name]
+func NameIndexKey(name []byte) []byte {
+	return append([]byte{nameIndexPrefix}, name...)
+}
+
+// Used to serve RPC queries
+func GetNameFromState(
+	ctx context.Context,
+	f ReadState,
+	name []byte,
+) (bool, codec.Address, int64, error) {
+	values, errs := f(ctx, [][]byte{NameKey(name)})
+	return innerGetName(values[0], errs[0])
+}
+
+// GetName returns the owner of [name] and the timestamp it expires at. Expired
+// names are returned until they are registered again.
+func GetName(
+	ctx context.Context,
+	im state.Immutable,
+	name []byte,
+) (bool, codec.Address, int64, error) {
+	return innerGetName(im.GetValue(ctx, NameKey(name)))
+}
+
+func innerGetName(v []byte, err error) (bool, codec.Address, int64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, 0, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, 0, err
+	}
+	var owner codec.Address
+	copy(owner[:], v)
+	return true, owner, int64(binary.BigEndian.Uint64(v[codec.AddressLen:])), nil
+}
+
+func SetName(
+	ctx context.Context,
+	mu state.Mutable,
+	name []byte,
+	owner codec.Address,
+	expiry int64,
+) error {
+	v := make([]byte, codec.AddressLen+consts.Int64Len)
+	copy(v, owner[:])
+	binary.BigEndian.PutUint64(v[codec.AddressLen:], uint64(expiry))
+	return mu.Insert(ctx, NameKey(name), v)
+}
@@ -6,6 +6,8 @@ package storage
 import (
 	"context"
 
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/state"
@@ -27,6 +29,12 @@ func (*StateManager) FeeKey() []byte {
 	return FeeKey()
 }
 
+var _ (chain.ReservedKeyManager) = (*StateManager)(nil)
+
+func (*StateManager) ReservedPrefixes() [][]byte {
+	return ReservedPrefixes()
+}
+
 func (*StateManager) SponsorStateKeys(addr codec.Address) state.Keys {
 	return state.Keys{
 		string(BalanceKey(addr)): state.Read | state.Write,
@@ -57,3 +65,51 @@ func (*StateManager) Deduct(
 ) error {
 	return SubBalance(ctx, mu, addr, amount)
 }
+
+var _ (chain.ProposerRewarder) = (*StateManager)(nil)
+
+func (*StateManager) ProposerStateKeys(addr codec.Address) state.Keys {
+	return state.Keys{
+		string(BalanceKey(addr)): state.All,
+	}
+}
+
+func (*StateManager) RewardProposer(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return AddBalance(ctx, mu, addr, amount, true)
+}
+
+// Incoming warp messages must be signed by [WarpQuorumNum]/[WarpQuorumDen] of
+// the stake of their source subnet.
+const (
+	WarpQuorumNum = 67
+	WarpQuorumDen = 100
+)
+
+var _ (chain.WarpManager) = (*StateManager)(nil)
+
+func (*StateManager) WarpQuorum(chain.Rules, *warp.UnsignedMessage) (uint64, uint64, error) {
+	return WarpQuorumNum, WarpQuorumDen, nil
+}
+
+func (*StateManager) WarpStateKeys(msg *warp.UnsignedMessage) state.Keys {
+	return state.Keys{
+		string(WarpMessageKey(msg.ID())): state.All,
+	}
+}
+
+// ApplyWarpMessage ensures every message is only delivered once.
+func (*StateManager) ApplyWarpMessage(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	_ codec.Address,
+	msg *warp.UnsignedMessage,
+) error {
+	return ConsumeWarpMessage(ctx, mu, msg.ID())
+}
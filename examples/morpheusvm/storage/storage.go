@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -25,7 +26,9 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 
 // Metadata
 // 0x0/ (tx)
-//   -> [txID] => timestamp
+//   -> [txID] => timestamp|success|units|fee|memos
+// 0x1/ (history)
+//   -> [owner|height|txIndex|actionIndex|transferIndex|incoming] => txID|timestamp|counterparty|amount
 //
 // State
 // / (height) => store in root
@@ -35,19 +38,44 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // 0x1/ (hypersdk-height)
 // 0x2/ (hypersdk-timestamp)
 // 0x3/ (hypersdk-fee)
+// 0x4/ (name)
+//   -> [name] => owner|expiry
+// 0x5/ (bridge locked)
+//   -> [destinationChainID] => amount
+// 0x6/ (consumed warp messages)
+//   -> [msgID] => 1
+//
+// Indexer keys (see [vm.IndexController])
+// 0x0/ (name)
+//   -> [name]
+// 0x1/ (sent or delivered warp messages)
+//   -> [msgID]
 
 const (
 	// Indexes
-	txPrefix = 0x0
+	txPrefix      = 0x0
+	historyPrefix = 0x1
 
 	// Active state
 	balancePrefix   = 0x0
 	heightPrefix    = 0x1
 	timestampPrefix = 0x2
 	feePrefix       = 0x3
+	namePrefix      = 0x4
+	lockedPrefix    = 0x5
+	warpPrefix      = 0x6
+
+	// Indexer keys
+	nameIndexPrefix = 0x0
+	warpIndexPrefix = 0x1
 )
 
-const BalanceChunks uint16 = 1
+const (
+	BalanceChunks     uint16 = 1
+	NameChunks        uint16 = 1
+	LockedChunks      uint16 = 1
+	WarpMessageChunks uint16 = 1
+)
 
 var (
 	failureByte  = byte(0x0)
@@ -73,9 +101,14 @@ func StoreTransaction(
 	success bool,
 	units fees.Dimensions,
 	fee uint64,
+	memos [][]byte,
 ) error {
 	k := TxKey(id)
-	v := make([]byte, consts.Uint64Len+1+fees.DimensionsLen+consts.Uint64Len)
+	memosLen := 0
+	for _, memo := range memos {
+		memosLen += consts.Uint16Len + len(memo)
+	}
+	v := make([]byte, consts.Uint64Len+1+fees.DimensionsLen+consts.Uint64Len, consts.Uint64Len+1+fees.DimensionsLen+consts.Uint64Len+memosLen)
 	binary.BigEndian.PutUint64(v, uint64(t))
 	if success {
 		v[consts.Uint64Len] = successByte
@@ -84,6 +117,10 @@ func StoreTransaction(
 	}
 	copy(v[consts.Uint64Len+1:], units.Bytes())
 	binary.BigEndian.PutUint64(v[consts.Uint64Len+1+fees.DimensionsLen:], fee)
+	for _, memo := range memos {
+		v = binary.BigEndian.AppendUint16(v, uint16(len(memo)))
+		v = append(v, memo...)
+	}
 	return db.Put(k, v)
 }
 
@@ -91,14 +128,14 @@ func GetTransaction(
 	_ context.Context,
 	db database.KeyValueReader,
 	id ids.ID,
-) (bool, int64, bool, fees.Dimensions, uint64, error) {
+) (bool, int64, bool, fees.Dimensions, uint64, [][]byte, error) {
 	k := TxKey(id)
 	v, err := db.Get(k)
 	if errors.Is(err, database.ErrNotFound) {
-		return false, 0, false, fees.Dimensions{}, 0, nil
+		return false, 0, false, fees.Dimensions{}, 0, nil, nil
 	}
 	if err != nil {
-		return false, 0, false, fees.Dimensions{}, 0, err
+		return false, 0, false, fees.Dimensions{}, 0, nil, err
 	}
 	t := int64(binary.BigEndian.Uint64(v))
 	success := true
@@ -107,10 +144,130 @@ func GetTransaction(
 	}
 	d, err := fees.UnpackDimensions(v[consts.Uint64Len+1 : consts.Uint64Len+1+fees.DimensionsLen])
 	if err != nil {
-		return false, 0, false, fees.Dimensions{}, 0, err
+		return false, 0, false, fees.Dimensions{}, 0, nil, err
 	}
 	fee := binary.BigEndian.Uint64(v[consts.Uint64Len+1+fees.DimensionsLen:])
-	return true, t, success, d, fee, nil
+
+	// Transactions stored before memos were indexed have no memos
+	memos := [][]byte{}
+	for r := v[consts.Uint64Len+1+fees.DimensionsLen+consts.Uint64Len:]; len(r) > 0; {
+		memoLen := int(binary.BigEndian.Uint16(r))
+		memos = append(memos, r[consts.Uint16Len:consts.Uint16Len+memoLen])
+		r = r[consts.Uint16Len+memoLen:]
+	}
+	return true, t, success, d, fee, memos, nil
+}
+
+// HistoryEntry is a change in the balance of an account caused by a
+// transfer. Transactions sent by an account that did not transfer any funds
+// (including those that failed) are recorded with an empty [Counterparty]
+// and no [Amount] so that the fees they paid can be accounted for.
+type HistoryEntry struct {
+	TxID         ids.ID        `json:"txId"`
+	Timestamp    int64         `json:"timestamp"`
+	Counterparty codec.Address `json:"counterparty"`
+	Amount       uint64        `json:"amount"`
+	Incoming     bool          `json:"incoming"`
+}
+
+const (
+	historyPositionLen = consts.Uint64Len + consts.Uint32Len + consts.Uint8Len + consts.Uint8Len + consts.BoolLen
+	historyEntryLen    = ids.IDLen + consts.Int64Len + codec.AddressLen + consts.Uint64Len
+)
+
+// [historyPrefix] + [owner] + [height] + [txIndex] + [actionIndex] + [transferIndex] + [incoming]
+//
+// Entries of an account are ordered by the position of the transfer in the
+// chain. [transferIndex] distinguishes the recipients of a single action.
+func historyKey(owner codec.Address, height uint64, txIndex int, actionIndex int, transferIndex int, incoming bool) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen, 1+codec.AddressLen+historyPositionLen)
+	k[0] = historyPrefix
+	copy(k[1:], owner[:])
+	k = binary.BigEndian.AppendUint64(k, height)
+	k = binary.BigEndian.AppendUint32(k, uint32(txIndex))
+	k = append(k, uint8(actionIndex), uint8(transferIndex))
+	if incoming {
+		return append(k, successByte)
+	}
+	return append(k, failureByte)
+}
+
+// StoreTransfer records a transfer of [amount] from [from] to [to] in the
+// history of both accounts.
+func StoreTransfer(
+	_ context.Context,
+	db database.KeyValueWriter,
+	height uint64,
+	txIndex int,
+	actionIndex int,
+	transferIndex int,
+	txID ids.ID,
+	t int64,
+	from codec.Address,
+	to codec.Address,
+	amount uint64,
+) error {
+	if err := db.Put(historyKey(from, height, txIndex, actionIndex, transferIndex, false), historyValue(txID, t, to, amount)); err != nil {
+		return err
+	}
+	return db.Put(historyKey(to, height, txIndex, actionIndex, transferIndex, true), historyValue(txID, t, from, amount))
+}
+
+// StoreSentTx records [txID] in the history of [actor] when it did not
+// transfer any funds.
+func StoreSentTx(
+	_ context.Context,
+	db database.KeyValueWriter,
+	height uint64,
+	txIndex int,
+	txID ids.ID,
+	t int64,
+	actor codec.Address,
+) error {
+	return db.Put(historyKey(actor, height, txIndex, 0, 0, false), historyValue(txID, t, codec.EmptyAddress, 0))
+}
+
+func historyValue(txID ids.ID, t int64, counterparty codec.Address, amount uint64) []byte {
+	v := make([]byte, 0, historyEntryLen)
+	v = append(v, txID[:]...)
+	v = binary.BigEndian.AppendUint64(v, uint64(t))
+	v = append(v, counterparty[:]...)
+	return binary.BigEndian.AppendUint64(v, amount)
+}
+
+// GetHistory returns up to [limit] history entries of [owner], starting at
+// [cursor]. If there are more entries, it also returns the cursor of the next
+// entry.
+func GetHistory(
+	_ context.Context,
+	db database.Iteratee,
+	owner codec.Address,
+	cursor []byte,
+	limit int,
+) ([]*HistoryEntry, []byte, error) {
+	prefix := make([]byte, 1+codec.AddressLen)
+	prefix[0] = historyPrefix
+	copy(prefix[1:], owner[:])
+	iter := db.NewIteratorWithStartAndPrefix(append(prefix, cursor...), prefix)
+	defer iter.Release()
+
+	entries := []*HistoryEntry{}
+	for iter.Next() {
+		k := iter.Key()
+		if len(entries) == limit {
+			return entries, slices.Clone(k[len(prefix):]), iter.Error()
+		}
+		v := iter.Value()
+		entry := &HistoryEntry{
+			Timestamp: int64(binary.BigEndian.Uint64(v[ids.IDLen:])),
+			Amount:    binary.BigEndian.Uint64(v[ids.IDLen+consts.Int64Len+codec.AddressLen:]),
+			Incoming:  k[len(k)-1] == successByte,
+		}
+		copy(entry.TxID[:], v)
+		copy(entry.Counterparty[:], v[ids.IDLen+consts.Int64Len:])
+		entries = append(entries, entry)
+	}
+	return entries, nil, iter.Error()
 }
 
 // [balancePrefix] + [address]
@@ -208,7 +365,7 @@ func AddBalance(
 			"%w: could not add balance (bal=%d, addr=%v, amount=%d)",
 			ErrInvalidBalance,
 			bal,
-			codec.MustAddressBech32(mconsts.HRP, addr),
+			mconsts.AddressFormat.Encode(addr),
 			amount,
 		)
 	}
@@ -231,7 +388,7 @@ func SubBalance(
 			"%w: could not subtract balance (bal=%d, addr=%v, amount=%d)",
 			ErrInvalidBalance,
 			bal,
-			codec.MustAddressBech32(mconsts.HRP, addr),
+			mconsts.AddressFormat.Encode(addr),
 			amount,
 		)
 	}
@@ -243,6 +400,31 @@ func SubBalance(
 	return setBalance(ctx, mu, key, nbal)
 }
 
+// CheckBalances returns an error if any balance in [db] is malformed or zero
+// (empty accounts are removed) or if the total supply overflows.
+func CheckBalances(db database.Iteratee) error {
+	iter := db.NewIteratorWithPrefix([]byte{balancePrefix})
+	defer iter.Release()
+
+	var supply uint64
+	for iter.Next() {
+		k, v := iter.Key(), iter.Value()
+		if len(k) != 1+codec.AddressLen+consts.Uint16Len || len(v) != consts.Uint64Len {
+			return fmt.Errorf("%w: malformed entry %x", ErrInvalidBalance, k)
+		}
+		bal := binary.BigEndian.Uint64(v)
+		if bal == 0 {
+			return fmt.Errorf("%w: zero balance stored at %x", ErrInvalidBalance, k)
+		}
+		var err error
+		supply, err = smath.Add64(supply, bal)
+		if err != nil {
+			return fmt.Errorf("%w: supply overflows", ErrInvalidBalance)
+		}
+	}
+	return iter.Error()
+}
+
 func HeightKey() (k []byte) {
 	return heightKey
 }
@@ -254,3 +436,8 @@ func TimestampKey() (k []byte) {
 func FeeKey() (k []byte) {
 	return feeKey
 }
+
+// ReservedPrefixes are the prefixes of the keys that actions can't touch (the chain metadata and delivered warp messages).
+func ReservedPrefixes() [][]byte {
+	return [][]byte{{heightPrefix}, {timestampPrefix}, {feePrefix}, {warpPrefix}}
+}
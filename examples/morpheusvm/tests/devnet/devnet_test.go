@@ -0,0 +1,65 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package devnet_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/devnet"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
+)
+
+const transferTimeout = time.Minute
+
+func fundedGenesis(funded []codec.Address) ([]byte, error) {
+	g := genesis.Default()
+	for _, addr := range funded {
+		g.CustomAllocation = append(g.CustomAllocation, &genesis.CustomAllocation{
+			Address: consts.AddressFormat.Encode(addr),
+			Balance: 10_000_000_000,
+		})
+	}
+	return json.Marshal(g)
+}
+
+// TestTransfer launches a 5-node network (configured by the environment, see
+// [devnet.ConfigFromEnv]) and checks that a transfer submitted to one node is
+// accepted by all of them.
+func TestTransfer(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	d := devnet.StartTest(t, devnet.ConfigFromEnv(consts.Name, fundedGenesis))
+	require.Len(d.Nodes, devnet.DefaultValidators)
+
+	cli := rpc.NewJSONRPCClient(d.Nodes[0].URI, d.NetworkID, d.ChainID)
+	parser, err := cli.Parser(ctx)
+	require.NoError(err)
+	to := codec.CreateAddress(auth.ED25519ID, ids.GenerateTestID())
+	submit, _, _, err := d.Nodes[0].JSONRPCClient().GenerateTransaction(
+		ctx,
+		parser,
+		[]chain.Action{&actions.Transfer{To: to, Value: 1_000}},
+		auth.NewED25519Factory(d.Keys[0]),
+	)
+	require.NoError(err)
+	require.NoError(submit(ctx))
+	for _, node := range d.Nodes {
+		lcli := rpc.NewJSONRPCClient(node.URI, d.NetworkID, d.ChainID)
+		require.NoError(lcli.WaitForBalance(ctx, consts.AddressFormat.Encode(to), 1_000))
+	}
+}
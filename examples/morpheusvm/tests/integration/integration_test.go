@@ -4,46 +4,61 @@
 package integration_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/fatih/color"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/hypersdk/bridge"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/checkpoint"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/crypto/secp256r1"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/actions"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/controller"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/faucet"
 	"github.com/ava-labs/hypersdk/examples/morpheusvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/morpheusvm/storage"
 	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/tstate"
 	"github.com/ava-labs/hypersdk/vm"
 
 	auth "github.com/ava-labs/hypersdk/auth"
+	hcli "github.com/ava-labs/hypersdk/cli"
 	hbls "github.com/ava-labs/hypersdk/crypto/bls"
 	lconsts "github.com/ava-labs/hypersdk/examples/morpheusvm/consts"
 	lrpc "github.com/ava-labs/hypersdk/examples/morpheusvm/rpc"
@@ -83,6 +98,17 @@ var (
 
 	networkID uint32
 	gen       *genesis.Genesis
+
+	// single validator subnet that bridged funds are returned from
+	bridgeChainID ids.ID
+	bridgeSK      *bls.SecretKey
+
+	// signs the checkpoints published by the embedded VMs
+	checkpointKey ed25519.PrivateKey
+
+	// credited with the proposer rewards of the blocks built by the
+	// embedded VMs
+	proposerAddr codec.Address
 )
 
 func init() {
@@ -118,13 +144,17 @@ func init() {
 type instance struct {
 	chainID           ids.ID
 	nodeID            ids.NodeID
+	snowCtx           *snow.Context
 	vm                *vm.VM
 	toEngine          chan common.Message
 	JSONRPCServer     *httptest.Server
 	BaseJSONRPCServer *httptest.Server
 	WebSocketServer   *httptest.Server
+	FaucetServer      *httptest.Server
+	CheckpointServer  *httptest.Server
 	cli               *rpc.JSONRPCClient // clients for embedded VMs
 	lcli              *lrpc.JSONRPCClient
+	fcli              *faucet.JSONRPCClient
 }
 
 var _ = ginkgo.BeforeSuite(func() {
@@ -139,7 +169,7 @@ var _ = ginkgo.BeforeSuite(func() {
 	pk = priv.PublicKey()
 	factory = auth.NewED25519Factory(priv)
 	addr = auth.NewED25519Address(pk)
-	addrStr = codec.MustAddressBech32(lconsts.HRP, addr)
+	addrStr = lconsts.AddressFormat.Encode(addr)
 	log.Debug(
 		"generated key",
 		zap.String("addr", addrStr),
@@ -151,7 +181,7 @@ var _ = ginkgo.BeforeSuite(func() {
 	pk2 = priv2.PublicKey()
 	factory2 = auth.NewED25519Factory(priv2)
 	addr2 = auth.NewED25519Address(pk2)
-	addrStr2 = codec.MustAddressBech32(lconsts.HRP, addr2)
+	addrStr2 = lconsts.AddressFormat.Encode(addr2)
 	log.Debug(
 		"generated key",
 		zap.String("addr", addrStr2),
@@ -163,19 +193,28 @@ var _ = ginkgo.BeforeSuite(func() {
 	pk3 = priv3.PublicKey()
 	factory3 = auth.NewED25519Factory(priv3)
 	addr3 = auth.NewED25519Address(pk3)
-	addrStr3 = codec.MustAddressBech32(lconsts.HRP, addr3)
+	addrStr3 = lconsts.AddressFormat.Encode(addr3)
 	log.Debug(
 		"generated key",
 		zap.String("addr", addrStr3),
 		zap.String("pk", hex.EncodeToString(priv3[:])),
 	)
 
+	checkpointKey, err = ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	proposerKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	proposerAddr = auth.NewED25519Address(proposerKey.PublicKey())
+
 	// create embedded VMs
 	instances = make([]instance, vms)
 
 	gen = genesis.Default()
 	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	// Keep unit prices at their minimum (the fees asserted below assume it)
+	gen.WindowTargetUnits = fees.Dimensions{1_000_000_000, 1_000_000_000, 1_000_000_000, 1_000_000_000, 1_000_000_000}
 	gen.MinBlockGap = 0
+	gen.ProposerRewardPercentage = 50
 	gen.CustomAllocation = []*genesis.CustomAllocation{
 		{
 			Address: addrStr,
@@ -189,6 +228,35 @@ var _ = ginkgo.BeforeSuite(func() {
 	subnetID := ids.GenerateTestID()
 	chainID := ids.GenerateTestID()
 
+	bridgeSubnetID := ids.GenerateTestID()
+	bridgeChainID = ids.GenerateTestID()
+	bridgeSK, err = bls.NewSecretKey()
+	require.NoError(err)
+	vdrState := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 1, nil
+		},
+		GetSubnetIDF: func(_ context.Context, chainID ids.ID) (ids.ID, error) {
+			if chainID != bridgeChainID {
+				return ids.Empty, database.ErrNotFound
+			}
+			return bridgeSubnetID, nil
+		},
+		GetValidatorSetF: func(_ context.Context, _ uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			if subnetID != bridgeSubnetID {
+				return nil, database.ErrNotFound
+			}
+			nodeID := ids.GenerateTestNodeID()
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(bridgeSK),
+					Weight:    1,
+				},
+			}, nil
+		},
+	}
+
 	app := &appSender{}
 	for i := range instances {
 		nodeID := ids.GenerateTestNodeID()
@@ -198,6 +266,8 @@ var _ = ginkgo.BeforeSuite(func() {
 		require.NoError(err)
 		dname, err := os.MkdirTemp("", fmt.Sprintf("%s-chainData", nodeID.String()))
 		require.NoError(err)
+		faucetKeyPath := filepath.Join(dname, "faucet.pk")
+		require.NoError(hutils.SaveBytes(faucetKeyPath, priv[:]))
 		snowCtx := &snow.Context{
 			NetworkID:      networkID,
 			SubnetID:       subnetID,
@@ -207,7 +277,8 @@ var _ = ginkgo.BeforeSuite(func() {
 			ChainDataDir:   dname,
 			Metrics:        metrics.NewPrefixGatherer(),
 			PublicKey:      bls.PublicFromSecretKey(sk),
-			ValidatorState: &validators.TestState{},
+			WarpSigner:     warp.NewSigner(sk, networkID, chainID),
+			ValidatorState: vdrState,
 		}
 
 		toEngine := make(chan common.Message, 1)
@@ -220,14 +291,32 @@ var _ = ginkgo.BeforeSuite(func() {
 			db,
 			genesisBytes,
 			nil,
-			[]byte(
+			[]byte(fmt.Sprintf(
 				`{
+				  "indexerEnabled":true,
+				  "stateDiffSize":16,
+				  "unitProfileSize":16,
+				  "proposerAddress":%q,
+				  "txTraceConfig": {
+				    "authToken":"trace"
+				  },
+				  "checkpointConfig": {
+				    "publisherEnabled":true,
+				    "authToken":"checkpoint",
+				    "signingKey":%q
+				  },
 				  "config": {
 				    "testMode":true,
+				    "storeHistory":true,
+				    "faucetKeyPath":%q,
+				    "faucetAmount":1000,
 				    "logLevel":"debug"
 				  }
 				}`,
-			),
+				lconsts.AddressFormat.Encode(proposerAddr),
+				hex.EncodeToString(checkpointKey[:]),
+				faucetKeyPath,
+			)),
 			toEngine,
 			nil,
 			app,
@@ -241,16 +330,22 @@ var _ = ginkgo.BeforeSuite(func() {
 		jsonRPCServer := httptest.NewServer(hd[rpc.JSONRPCEndpoint])
 		ljsonRPCServer := httptest.NewServer(hd[lrpc.JSONRPCEndpoint])
 		webSocketServer := httptest.NewServer(hd[rpc.WebSocketEndpoint])
+		faucetServer := httptest.NewServer(hd[faucet.JSONRPCEndpoint])
+		checkpointServer := httptest.NewServer(hd[rpc.CheckpointEndpoint])
 		instances[i] = instance{
 			chainID:           snowCtx.ChainID,
 			nodeID:            snowCtx.NodeID,
+			snowCtx:           snowCtx,
 			vm:                v,
 			toEngine:          toEngine,
 			JSONRPCServer:     jsonRPCServer,
 			BaseJSONRPCServer: ljsonRPCServer,
 			WebSocketServer:   webSocketServer,
+			FaucetServer:      faucetServer,
+			CheckpointServer:  checkpointServer,
 			cli:               rpc.NewJSONRPCClient(jsonRPCServer.URL),
 			lcli:              lrpc.NewJSONRPCClient(ljsonRPCServer.URL, snowCtx.NetworkID, snowCtx.ChainID),
+			fcli:              faucet.NewJSONRPCClient(faucetServer.URL),
 		}
 
 		// Force sync ready (to mimic bootstrapping from genesis)
@@ -286,6 +381,8 @@ var _ = ginkgo.AfterSuite(func() {
 		iv.JSONRPCServer.Close()
 		iv.BaseJSONRPCServer.Close()
 		iv.WebSocketServer.Close()
+		iv.FaucetServer.Close()
+		iv.CheckpointServer.Close()
 		err := iv.vm.Shutdown(context.TODO())
 		require.NoError(err)
 	}
@@ -329,8 +426,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 	// read: 2 keys reads
 	// allocate: 1 key created with 1 chunk
 	// write: 2 keys modified
-	transferTxUnits := fees.Dimensions{192, 7, 14, 50, 26}
-	transferTxFee := uint64(289)
+	transferTxUnits := fees.Dimensions{200, 7, 14, 50, 26}
+	transferTxFee := uint64(297)
 
 	ginkgo.It("get currently accepted block ID", func() {
 		for _, inst := range instances {
@@ -451,7 +548,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		ginkgo.By("ensure balance is updated", func() {
 			balance, err := instances[1].lcli.Balance(context.Background(), addrStr)
 			require.NoError(err)
-			require.Equal(balance, uint64(9_899_711))
+			require.Equal(balance, uint64(9_899_703))
 			balance2, err := instances[1].lcli.Balance(context.Background(), addrStr2)
 			require.NoError(err)
 			require.Equal(balance2, uint64(100_000))
@@ -808,7 +905,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			require.Len(results, 1)
 			require.True(results[0].Success)
 
-			balance, err := instances[0].lcli.Balance(context.TODO(), codec.MustAddressBech32(lconsts.HRP, r1addr))
+			balance, err := instances[0].lcli.Balance(context.TODO(), lconsts.AddressFormat.Encode(r1addr))
 			require.NoError(err)
 			require.Equal(balance, uint64(2000))
 		})
@@ -860,13 +957,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			require.Len(results, 1)
 			require.True(results[0].Success)
 
-			balance, err := instances[0].lcli.Balance(context.TODO(), codec.MustAddressBech32(lconsts.HRP, r1addr))
+			balance, err := instances[0].lcli.Balance(context.TODO(), lconsts.AddressFormat.Encode(r1addr))
 			require.NoError(err)
 			require.Equal(balance, uint64(2000))
 		})
 
 		ginkgo.By("send back to ed25519 (in separate actions)", func() {
-			bbalance, err := instances[0].lcli.Balance(context.TODO(), codec.MustAddressBech32(lconsts.HRP, addr))
+			bbalance, err := instances[0].lcli.Balance(context.TODO(), lconsts.AddressFormat.Encode(addr))
 			require.NoError(err)
 
 			parser, err := instances[0].lcli.Parser(context.Background())
@@ -893,14 +990,1128 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			require.Len(results, 1)
 			require.True(results[0].Success)
 
-			balance, err := instances[0].lcli.Balance(context.TODO(), codec.MustAddressBech32(lconsts.HRP, addr))
+			balance, err := instances[0].lcli.Balance(context.TODO(), lconsts.AddressFormat.Encode(addr))
 			require.NoError(err)
 			require.Equal(balance, bbalance+100)
 		})
 	})
+
+	ginkgo.It("indexes transfer memos", func() {
+		parser, err := instances[0].lcli.Parser(context.Background())
+		require.NoError(err)
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{
+				&actions.Transfer{
+					To:    addr2,
+					Value: 300,
+					Memo:  []byte("deposit"),
+				},
+				&actions.Transfer{
+					To:    addr2,
+					Value: 301,
+				},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		accept := expectBlk(instances[0])
+		results := accept(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		found, memos, err := instances[0].lcli.TxMemos(context.Background(), tx.ID())
+		require.NoError(err)
+		require.True(found)
+		require.Equal([][]byte{[]byte("deposit"), {}}, memos)
+
+		// Reject memo larger than [MaxMemoSize]
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    addr2,
+				Value: 302,
+				Memo:  make([]byte, actions.MaxMemoSize+1),
+			}},
+			factory,
+		)
+		require.Error(err)
+	})
+
+	ginkgo.It("pages through transfer history", func() {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		hfactory := auth.NewED25519Factory(priv)
+		haddr := auth.NewED25519Address(priv.PublicKey())
+		haddrStr := lconsts.AddressFormat.Encode(haddr)
+
+		parser, err := instances[0].lcli.Parser(context.Background())
+		require.NoError(err)
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{
+				&actions.Transfer{
+					To:    haddr,
+					Value: 100_000, // must be more than StateLockup
+				},
+				&actions.Transfer{
+					To:    haddr,
+					Value: 400,
+				},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		fundTxID := tx.ID()
+
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    addr2,
+				Value: 401,
+			}},
+			hfactory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Blocks are indexed asynchronously after they are accepted
+		_, _, err = instances[0].lcli.WaitForTransaction(context.Background(), tx.ID())
+		require.NoError(err)
+		entries, next, err := instances[0].lcli.History(context.Background(), haddrStr, nil, 2)
+		require.NoError(err)
+		require.Len(entries, 2)
+		require.NotNil(next)
+		require.Equal(fundTxID, entries[0].TxID)
+		require.Equal(addrStr, entries[0].Counterparty)
+		require.Equal(uint64(100_000), entries[0].Amount)
+		require.True(entries[0].Incoming)
+		require.Equal(fundTxID, entries[1].TxID)
+		require.Equal(uint64(400), entries[1].Amount)
+		require.True(entries[1].Incoming)
+
+		entries, next, err = instances[0].lcli.History(context.Background(), haddrStr, next, 2)
+		require.NoError(err)
+		require.Len(entries, 1)
+		require.Nil(next)
+		require.Equal(tx.ID(), entries[0].TxID)
+		require.Equal(addrStr2, entries[0].Counterparty)
+		require.Equal(uint64(401), entries[0].Amount)
+		require.False(entries[0].Incoming)
+		require.Positive(entries[0].Timestamp)
+
+		// Failed transactions are recorded without a counterparty
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    addr2,
+				Value: 1_000_000,
+			}},
+			hfactory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		_, _, err = instances[0].lcli.WaitForTransaction(context.Background(), tx.ID())
+		require.NoError(err)
+		entries, _, err = instances[0].lcli.History(context.Background(), haddrStr, nil, 10)
+		require.NoError(err)
+		require.Len(entries, 4)
+		require.Equal(tx.ID(), entries[3].TxID)
+		require.Empty(entries[3].Counterparty)
+		require.Zero(entries[3].Amount)
+		require.False(entries[3].Incoming)
+	})
+
+	ginkgo.It("rate limits faucet requests", func() {
+		faucetAddr, err := instances[0].fcli.FaucetAddress(context.Background())
+		require.NoError(err)
+		require.Equal(addrStr, faucetAddr)
+
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		faddrStr := lconsts.AddressFormat.Encode(auth.NewED25519Address(priv.PublicKey()))
+		txID, amount, err := instances[0].fcli.Request(context.Background(), faddrStr)
+		require.NoError(err)
+		require.Equal(uint64(1000), amount)
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		found, success, _, _, err := instances[0].lcli.Tx(context.Background(), txID)
+		require.NoError(err)
+		require.True(found)
+		require.True(success)
+		balance, err := instances[0].lcli.Balance(context.Background(), faddrStr)
+		require.NoError(err)
+		require.Equal(uint64(1000), balance)
+
+		// Same address
+		_, _, err = instances[0].fcli.Request(context.Background(), faddrStr)
+		require.ErrorContains(err, "rate limited: address")
+
+		// Same IP
+		_, _, err = instances[0].fcli.Request(context.Background(), addrStr3)
+		require.ErrorContains(err, "rate limited: ip")
+	})
+
+	ginkgo.It("transfers to multiple recipients", func() {
+		privA, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		addrA := auth.NewED25519Address(privA.PublicKey())
+		privB, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		addrB := auth.NewED25519Address(privB.PublicKey())
+
+		parser, err := instances[0].lcli.Parser(context.Background())
+		require.NoError(err)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.TransferMultiple{
+				To:     []codec.Address{addrA, addrB, addrA},
+				Values: []uint64{100_000, 200_000, 500},
+				Memo:   []byte("payroll"),
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		require.Len(results[0].Outputs, 1)
+		require.Len(results[0].Outputs[0], 1)
+		result, err := actions.UnmarshalTransferMultipleResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal([]uint64{100_000, 200_000, 100_500}, result.Balances)
+
+		balance, err := instances[0].lcli.Balance(context.Background(), lconsts.AddressFormat.Encode(addrA))
+		require.NoError(err)
+		require.Equal(uint64(100_500), balance)
+		balance, err = instances[0].lcli.Balance(context.Background(), lconsts.AddressFormat.Encode(addrB))
+		require.NoError(err)
+		require.Equal(uint64(200_000), balance)
+
+		// Each recipient is recorded separately in history
+		entries, _, err := instances[0].lcli.History(context.Background(), lconsts.AddressFormat.Encode(addrA), nil, 10)
+		require.NoError(err)
+		require.Len(entries, 2)
+		require.Equal(uint64(100_000), entries[0].Amount)
+		require.Equal(uint64(500), entries[1].Amount)
+
+		// Reject misaligned values
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.TransferMultiple{
+				To:     []codec.Address{addrA, addrB},
+				Values: []uint64{1},
+			}},
+			factory,
+		)
+		require.ErrorIs(err, actions.ErrOutputValuesMisaligned)
+	})
+
+	ginkgo.It("registers and resolves names", func() {
+		parser, err := instances[0].lcli.Parser(context.Background())
+		require.NoError(err)
+		balance, err := instances[0].lcli.Balance(context.Background(), addrStr)
+		require.NoError(err)
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.RegisterName{
+				Name:    []byte("alice"),
+				Periods: 2,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		nbalance, err := instances[0].lcli.Balance(context.Background(), addrStr)
+		require.NoError(err)
+		require.Equal(balance-2*actions.NameFeePerPeriod-results[0].Fee, nbalance)
+
+		found, owner, expiry, err := instances[0].lcli.ResolveName(context.Background(), "alice")
+		require.NoError(err)
+		require.True(found)
+		require.Equal(addrStr, owner)
+		found, _, _, err = instances[0].lcli.ResolveName(context.Background(), "bob")
+		require.NoError(err)
+		require.False(found)
+
+		// Cannot register a name that is taken
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.RegisterName{
+				Name:    []byte("alice"),
+				Periods: 1,
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), actions.ErrOutputNameTaken.Error())
+
+		// Anyone can renew a name
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.RenewName{
+				Name:    []byte("alice"),
+				Periods: 1,
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		_, owner, nexpiry, err := instances[0].lcli.ResolveName(context.Background(), "alice")
+		require.NoError(err)
+		require.Equal(addrStr, owner)
+		require.Equal(expiry+actions.NamePeriod, nexpiry)
+
+		// Only the owner can transfer a name
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.TransferName{
+				Name: []byte("alice"),
+				To:   addr3,
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), actions.ErrOutputWrongNameOwner.Error())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.TransferName{
+				Name: []byte("alice"),
+				To:   addr2,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		_, owner, _, err = instances[0].lcli.ResolveName(context.Background(), "alice")
+		require.NoError(err)
+		require.Equal(addrStr2, owner)
+
+		// Reject invalid names
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.RegisterName{
+				Name:    []byte("Alice!"),
+				Periods: 1,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), actions.ErrOutputInvalidName.Error())
+	})
+
+	ginkgo.It("queries balances at past heights", func() {
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		baddr := auth.NewED25519Address(priv.PublicKey())
+		baddrStr := lconsts.AddressFormat.Encode(baddr)
+
+		parser, err := instances[0].lcli.Parser(context.Background())
+		require.NoError(err)
+		_, start, _, err := instances[0].cli.Accepted(context.Background())
+		require.NoError(err)
+		heights := []uint64{}
+		for _, value := range []uint64{1_000, 2_000} {
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				parser,
+				[]chain.Action{&actions.Transfer{
+					To:    baddr,
+					Value: value,
+				}},
+				factory,
+			)
+			require.NoError(err)
+			require.NoError(submit(context.Background()))
+			results := expectBlk(instances[0])(false)
+			require.Len(results, 1)
+			require.True(results[0].Success)
+			_, height, _, err := instances[0].cli.Accepted(context.Background())
+			require.NoError(err)
+			heights = append(heights, height)
+		}
+
+		balance, err := instances[0].lcli.BalanceAt(context.Background(), baddrStr, start)
+		require.NoError(err)
+		require.Zero(balance)
+		balance, err = instances[0].lcli.BalanceAt(context.Background(), baddrStr, heights[0])
+		require.NoError(err)
+		require.Equal(uint64(1_000), balance)
+		balance, err = instances[0].lcli.BalanceAt(context.Background(), baddrStr, heights[1])
+		require.NoError(err)
+		require.Equal(uint64(3_000), balance)
+
+		_, err = instances[0].lcli.BalanceAt(context.Background(), baddrStr, heights[1]+1)
+		require.ErrorContains(err, vm.ErrHeightNotAccepted.Error())
+	})
+
+	ginkgo.It("serves the state diff of accepted blocks", func() {
+		ctx := context.Background()
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		daddr := auth.NewED25519Address(priv.PublicKey())
+
+		parser, err := instances[0].lcli.Parser(ctx)
+		require.NoError(err)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    daddr,
+				Value: 1_234,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		blkID, height, _, err := instances[0].cli.Accepted(ctx)
+		require.NoError(err)
+
+		// Diffs are stored asynchronously after blocks are accepted
+		var diff *rpc.StateDiff
+		require.NoError(rpc.Wait(ctx, func(ctx context.Context) (bool, error) {
+			diff, err = instances[0].cli.StateDiff(ctx, height)
+			if err != nil && strings.Contains(err.Error(), rpc.ErrStateDiffMissing.Error()) {
+				return false, nil
+			}
+			return err == nil, err
+		}))
+		require.Equal(blkID, diff.BlockID)
+		balanceKey := storage.BalanceKey(daddr)
+		var balance *chain.StateChange
+		for _, change := range diff.Changes {
+			if bytes.Equal(change.Key, balanceKey) {
+				balance = change
+			}
+		}
+		require.NotNil(balance)
+		require.False(balance.Deleted)
+		require.Equal(uint64(1_234), binary.BigEndian.Uint64(balance.Value))
+
+		_, err = instances[0].cli.StateDiff(ctx, height+1)
+		require.ErrorContains(err, rpc.ErrStateDiffMissing.Error())
+	})
+
+	ginkgo.It("traces an accepted transaction", func() {
+		ctx := context.Background()
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		daddr := auth.NewED25519Address(priv.PublicKey())
+
+		parser, err := instances[0].lcli.Parser(ctx)
+		require.NoError(err)
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    daddr,
+				Value: 4_321,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Transactions are indexed asynchronously after blocks are accepted
+		require.NoError(rpc.Wait(ctx, func(ctx context.Context) (bool, error) {
+			_, _, _, _, err := instances[0].cli.GetIndexedTx(ctx, parser, tx.ID())
+			if err != nil && strings.Contains(err.Error(), rpc.ErrTxMissing.Error()) {
+				return false, nil
+			}
+			return err == nil, err
+		}))
+		_, err = instances[0].cli.TraceTx(ctx, "wrong", tx.ID())
+		require.ErrorContains(err, rpc.ErrUnauthorized.Error())
+		_, err = instances[0].cli.TraceTx(ctx, "trace", ids.GenerateTestID())
+		require.ErrorContains(err, rpc.ErrTxMissing.Error())
+
+		trace, err := instances[0].cli.TraceTx(ctx, "trace", tx.ID())
+		require.NoError(err)
+		require.Equal(tx.ID(), trace.TxID)
+		require.True(trace.Success)
+		require.Equal(results[0].Fee, trace.Fee)
+		require.Equal(results[0].Units, trace.Units.Total)
+		require.Len(trace.Units.ActionCompute, 1)
+		require.Len(trace.Phases, 3)
+		require.Equal(chain.PreExecutePhase, trace.Phases[0].Name)
+		require.Equal(chain.FeePhase, trace.Phases[1].Name)
+		require.Equal("action 0", trace.Phases[2].Name)
+
+		// The recipient didn't have a balance before the transfer
+		balanceKey := storage.BalanceKey(daddr)
+		accesses := trace.Phases[2].Accesses
+		require.Len(accesses, 4) // read and write of both balances
+		require.Equal(tstate.ReadAccess, accesses[2].Type)
+		require.Equal(balanceKey, accesses[2].Key)
+		require.Nil(accesses[2].Value)
+		require.Equal(tstate.WriteAccess, accesses[3].Type)
+		require.Equal(balanceKey, accesses[3].Key)
+		require.Equal(uint64(4_321), binary.BigEndian.Uint64(accesses[3].Value))
+	})
+
+	ginkgo.It("profiles the units of accepted blocks", func() {
+		ctx := context.Background()
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		daddr := auth.NewED25519Address(priv.PublicKey())
+
+		parser, err := instances[0].lcli.Parser(ctx)
+		require.NoError(err)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    daddr,
+				Value: 1_000,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		_, height, _, err := instances[0].cli.Accepted(ctx)
+		require.NoError(err)
+
+		// Blocks are profiled asynchronously after they are accepted
+		var profile *rpc.UnitProfile
+		require.NoError(rpc.Wait(ctx, func(ctx context.Context) (bool, error) {
+			profile, err = instances[0].cli.UnitProfile(ctx, height, height)
+			if err != nil && strings.Contains(err.Error(), rpc.ErrUnitProfileMissing.Error()) {
+				return false, nil
+			}
+			return err == nil, err
+		}))
+		require.Equal(height, profile.StartHeight)
+		require.Equal(height, profile.EndHeight)
+		require.Equal(1, profile.Blocks)
+		require.Equal(1, profile.Txs)
+		require.Equal(results[0].Units, profile.Units)
+		require.Len(profile.Actions, 1)
+		require.Equal("transfer", profile.Actions[0].Name)
+		require.Equal(uint64(1), profile.Actions[0].Count)
+		require.Equal(results[0].Units, profile.Actions[0].Units)
+		namespace := fmt.Sprintf("0x%02x", storage.BalanceKey(daddr)[0])
+		require.True(slices.ContainsFunc(profile.Namespaces, func(ns *rpc.NamespaceUnits) bool {
+			return ns.Namespace == namespace
+		}))
+
+		_, err = instances[0].cli.UnitProfile(ctx, height, height-1)
+		require.ErrorContains(err, rpc.ErrInvalidHeightRange.Error())
+		_, err = instances[0].cli.UnitProfile(ctx, height+1, height+1)
+		require.ErrorContains(err, rpc.ErrUnitProfileMissing.Error())
+	})
+
+	ginkgo.It("credits the proposer with its share of the fees", func() {
+		ctx := context.Background()
+		proposerStr := lconsts.AddressFormat.Encode(proposerAddr)
+		before, err := instances[0].lcli.Balance(ctx, proposerStr)
+		require.NoError(err)
+
+		parser, err := instances[0].lcli.Parser(ctx)
+		require.NoError(err)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    addr2,
+				Value: 1_000,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		_, height, _, err := instances[0].cli.Accepted(ctx)
+		require.NoError(err)
+
+		split, err := instances[0].cli.FeeSplit(ctx, height)
+		require.NoError(err)
+		require.Equal(proposerAddr, split.Proposer)
+		require.Equal(results[0].Fee, split.Collected)
+		require.Equal(results[0].Fee/2, split.Rewarded)
+		require.Equal(results[0].Fee-split.Rewarded, split.Burned)
+		after, err := instances[0].lcli.Balance(ctx, proposerStr)
+		require.NoError(err)
+		require.Equal(before+results[0].Fee/2, after)
+
+		_, err = instances[0].cli.FeeSplit(ctx, height+1)
+		require.ErrorContains(err, rpc.ErrFeeSplitMissing.Error())
+	})
+
+	ginkgo.It("initializes a node from a published checkpoint", func() {
+		ctx := context.Background()
+		cpriv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		caddr := auth.NewED25519Address(cpriv.PublicKey())
+		transfer := func(value uint64) {
+			parser, err := instances[0].lcli.Parser(ctx)
+			require.NoError(err)
+			submit, _, _, err := instances[0].cli.GenerateTransaction(
+				ctx,
+				parser,
+				[]chain.Action{&actions.Transfer{To: caddr, Value: value}},
+				factory,
+			)
+			require.NoError(err)
+			require.NoError(submit(ctx))
+			results := expectBlk(instances[0])(false)
+			require.Len(results, 1)
+			require.True(results[0].Success)
+		}
+		transfer(1_000)
+		blkID, _, _, err := instances[0].cli.Accepted(ctx)
+		require.NoError(err)
+
+		// Checkpoints can only be fetched with the auth token
+		fetch := func(token string) *http.Response {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, instances[0].CheckpointServer.URL, nil)
+			require.NoError(err)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(err)
+			return resp
+		}
+		resp := fetch("wrong")
+		require.NoError(resp.Body.Close())
+		require.Equal(http.StatusUnauthorized, resp.StatusCode)
+		resp = fetch("checkpoint")
+		require.Equal(http.StatusOK, resp.StatusCode)
+		dir, err := os.MkdirTemp("", "checkpoint")
+		require.NoError(err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "checkpoint.hckp")
+		f, err := os.Create(path)
+		require.NoError(err)
+		_, err = io.Copy(f, resp.Body)
+		require.NoError(err)
+		require.NoError(resp.Body.Close())
+		require.NoError(f.Close())
+
+		newVM := func(publisher ed25519.PublicKey) (*vm.VM, error) {
+			chainDataDir, err := os.MkdirTemp(dir, "chainData")
+			require.NoError(err)
+			sk, err := bls.NewSecretKey()
+			require.NoError(err)
+			base := instances[0].snowCtx
+			snowCtx := &snow.Context{
+				NetworkID:      base.NetworkID,
+				SubnetID:       base.SubnetID,
+				ChainID:        base.ChainID,
+				NodeID:         ids.GenerateTestNodeID(),
+				Log:            base.Log,
+				ChainDataDir:   chainDataDir,
+				Metrics:        metrics.NewPrefixGatherer(),
+				PublicKey:      bls.PublicFromSecretKey(sk),
+				WarpSigner:     warp.NewSigner(sk, base.NetworkID, base.ChainID),
+				ValidatorState: base.ValidatorState,
+			}
+			v := controller.New()
+			return v, v.Initialize(
+				ctx,
+				snowCtx,
+				memdb.New(),
+				genesisBytes,
+				nil,
+				[]byte(fmt.Sprintf(
+					`{
+					  "checkpointConfig": {
+					    "path":%q,
+					    "trustedPublishers":[%q]
+					  },
+					  "config": {"testMode":true}
+					}`,
+					path,
+					hex.EncodeToString(publisher[:]),
+				)),
+				make(chan common.Message, 1),
+				nil,
+				&appSender{},
+			)
+		}
+
+		// Checkpoints must be signed by a trusted publisher
+		untrusted, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		_, err = newVM(untrusted.PublicKey())
+		require.ErrorIs(err, checkpoint.ErrUntrustedPublisher)
+
+		v, err := newVM(checkpointKey.PublicKey())
+		require.NoError(err)
+		defer func() {
+			require.NoError(v.Shutdown(ctx))
+		}()
+		lastAccepted, err := v.LastAccepted(ctx)
+		require.NoError(err)
+		require.Equal(blkID, lastAccepted)
+		v.ForceReady()
+
+		// The checkpoint block is executed when its child is verified
+		transfer(2_000)
+		child := instances[0].vm.LastAcceptedBlock()
+		blk, err := v.ParseBlock(ctx, child.Bytes())
+		require.NoError(err)
+		require.NoError(blk.Verify(ctx))
+		require.NoError(blk.Accept(ctx))
+		handlers, err := v.CreateHandlers(ctx)
+		require.NoError(err)
+		server := httptest.NewServer(handlers[lrpc.JSONRPCEndpoint])
+		defer server.Close()
+		balance, err := lrpc.NewJSONRPCClient(server.URL, networkID, instances[0].chainID).Balance(ctx, lconsts.AddressFormat.Encode(caddr))
+		require.NoError(err)
+		require.Equal(uint64(3_000), balance)
+	})
+
+	ginkgo.It("reports mempool stats", func() {
+		parser, err := instances[0].lcli.Parser(context.Background())
+		require.NoError(err)
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    addr2,
+				Value: 303,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		txs, size, err := instances[0].cli.Mempool(context.Background())
+		require.NoError(err)
+		require.Equal(1, txs)
+		require.Equal(tx.Size(), size)
+
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		txs, size, err = instances[0].cli.Mempool(context.Background())
+		require.NoError(err)
+		require.Zero(txs)
+		require.Zero(size)
+	})
+
+	ginkgo.It("signs transactions offline", func() {
+		ctx := context.Background()
+		dir, err := os.MkdirTemp("", "offline")
+		require.NoError(err)
+		defer os.RemoveAll(dir)
+
+		// Prepare without access to the private key
+		parser, err := instances[0].lcli.Parser(ctx)
+		require.NoError(err)
+		unsigned, err := instances[0].cli.GenerateUnsignedTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    addr3,
+				Value: 404,
+				Memo:  []byte("offline"),
+			}},
+			&auth.ED25519Factory{},
+		)
+		require.NoError(err)
+		unsignedPath := filepath.Join(dir, "unsigned.tx")
+		require.NoError(hcli.SaveUnsignedTx(unsignedPath, unsigned))
+
+		// Sign
+		loaded, err := hcli.LoadUnsignedTx(unsignedPath, lconsts.ActionRegistry)
+		require.NoError(err)
+		require.NoError(hcli.CheckExpiry(loaded))
+		require.Equal(unsigned.Base, loaded.Base)
+		signed, err := loaded.Sign(factory, lconsts.ActionRegistry, lconsts.AuthRegistry)
+		require.NoError(err)
+		signedPath := filepath.Join(dir, "signed.tx")
+		require.NoError(hcli.SaveSignedTx(signedPath, signed))
+
+		// Broadcast
+		tx, err := hcli.LoadSignedTx(signedPath, lconsts.ActionRegistry, lconsts.AuthRegistry)
+		require.NoError(err)
+		require.Equal(signed.ID(), tx.ID())
+		require.NoError(hcli.CheckValidFrom(tx, parser.Rules(time.Now().UnixMilli()).GetValidityWindow()))
+		_, err = instances[0].cli.SubmitTx(ctx, tx.Bytes())
+		require.NoError(err)
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Transactions cannot be broadcast before their window opens
+		delayed, err := instances[0].cli.GenerateUnsignedTransaction(
+			ctx,
+			parser,
+			unsigned.Actions,
+			&auth.ED25519Factory{},
+			hcli.DelayModifier(time.Hour),
+		)
+		require.NoError(err)
+		require.ErrorIs(hcli.CheckValidFrom(delayed, parser.Rules(time.Now().UnixMilli()).GetValidityWindow()), hcli.ErrTxNotYetValid)
+	})
+
+	ginkgo.It("streams transactions matching a filter", func() {
+		// Subscribe to txs sent to [other]
+		other, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		otherAddr := auth.NewED25519Address(other.PublicKey())
+		cli, err := rpc.NewWebSocketClient(instances[0].WebSocketServer.URL, rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		require.NoError(err)
+		require.NoError(cli.RegisterFilteredTxs(&rpc.TxFilter{
+			Addresses:   []codec.Address{otherAddr},
+			ActionTypes: []uint8{lconsts.TransferID},
+		}))
+
+		// Wait for message to be sent
+		time.Sleep(2 * pubsub.MaxMessageWait)
+
+		// Send a matching and a non-matching tx
+		parser, err := instances[0].lcli.Parser(context.Background())
+		require.NoError(err)
+		submit, matching, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    otherAddr,
+				Value: 1,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    addr2,
+				Value: 1,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(context.Background()))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 2)
+
+		// Only the matching tx is streamed
+		height, txs, lresults, err := cli.ListenFilteredTxs(context.TODO(), parser)
+		require.NoError(err)
+		require.Equal(instances[0].vm.LastAcceptedBlock().Hght, height)
+		require.Len(txs, 1)
+		require.Equal(matching.ID(), txs[0].ID())
+		require.Len(lresults, 1)
+		require.True(lresults[0].Success)
+
+		// Close connection when done
+		require.NoError(cli.Close())
+	})
+
+	ginkgo.It("locks and releases bridged funds", func() {
+		ctx := context.Background()
+		parser, err := instances[0].lcli.Parser(ctx)
+		require.NoError(err)
+
+		// Reject lock to this chain
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeLock{
+				DestinationChainID: instances[0].chainID,
+				To:                 addr2,
+				Value:              1_000,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "cannot bridge to the same chain")
+
+		// Lock
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeLock{
+				DestinationChainID: bridgeChainID,
+				To:                 addr2,
+				Value:              1_000,
+				Fee:                10,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		require.Len(results[0].WarpMessages, 1)
+		out := results[0].WarpMessages[0]
+		msgID := out.ID()
+		require.Equal(msgID[:], results[0].Outputs[0][0])
+		require.Equal(instances[0].chainID, out.SourceChainID)
+		transfer, err := bridge.ParseTransfer(out, bridgeChainID)
+		require.NoError(err)
+		require.False(transfer.Return)
+		require.Equal(ids.Empty, transfer.Asset)
+		require.Equal([]byte(lconsts.Symbol), transfer.Symbol)
+		require.Equal(uint8(lconsts.Decimals), transfer.Decimals)
+		require.Equal(addr2, transfer.To)
+		require.Equal(uint64(1_000), transfer.Value)
+		require.Equal(uint64(10), transfer.Fee)
+		locked, err := instances[0].lcli.Locked(ctx, bridgeChainID)
+		require.NoError(err)
+		require.Equal(uint64(1_010), locked)
+
+		// Reject release of more than was locked
+		other, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		otherAddr := auth.NewED25519Address(other.PublicKey())
+		otherStr := lconsts.AddressFormat.Encode(otherAddr)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeRelease{Message: bridgeMessage(&bridge.Transfer{
+				ActionID:           ids.GenerateTestID(),
+				DestinationChainID: instances[0].chainID,
+				Symbol:             []byte(lconsts.Symbol),
+				Decimals:           lconsts.Decimals,
+				To:                 otherAddr,
+				Value:              1_011,
+				Return:             true,
+			})}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlkWithContext(instances[0], &block.Context{PChainHeight: 1})(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "insufficient locked funds")
+
+		// Release (and fund fees of the replay)
+		msg := bridgeMessage(&bridge.Transfer{
+			ActionID:           ids.GenerateTestID(),
+			DestinationChainID: instances[0].chainID,
+			Symbol:             []byte(lconsts.Symbol),
+			Decimals:           lconsts.Decimals,
+			To:                 otherAddr,
+			Value:              400,
+			Fee:                10,
+			Return:             true,
+		})
+		relayerBalance, err := instances[0].lcli.Balance(ctx, addrStr)
+		require.NoError(err)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.Transfer{To: addr2, Value: 100_000},
+				&actions.BridgeRelease{Message: msg},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlkWithContext(instances[0], &block.Context{PChainHeight: 1})(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		balance, err := instances[0].lcli.Balance(ctx, otherStr)
+		require.NoError(err)
+		require.Equal(uint64(400), balance)
+		balance, err = instances[0].lcli.Balance(ctx, addrStr)
+		require.NoError(err)
+		require.Equal(relayerBalance-100_000-results[0].Fee+10, balance) // relay fee
+		locked, err = instances[0].lcli.Locked(ctx, bridgeChainID)
+		require.NoError(err)
+		require.Equal(uint64(600), locked)
+
+		// Reject replay
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeRelease{Message: msg}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlkWithContext(instances[0], &block.Context{PChainHeight: 1})(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "already consumed")
+		balance, err = instances[0].lcli.Balance(ctx, otherStr)
+		require.NoError(err)
+		require.Equal(uint64(400), balance)
+		locked, err = instances[0].lcli.Locked(ctx, bridgeChainID)
+		require.NoError(err)
+		require.Equal(uint64(600), locked)
+	})
+
+	ginkgo.It("indexes blocks and transactions", func() {
+		ctx := context.Background()
+		priv, err := ed25519.GeneratePrivateKey()
+		require.NoError(err)
+		ifactory := auth.NewED25519Factory(priv)
+		iaddr := auth.NewED25519Address(priv.PublicKey())
+
+		parser, err := instances[0].lcli.Parser(ctx)
+		require.NoError(err)
+		submit, fundTx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{
+				To:    iaddr,
+				Value: 100_000 + 2*actions.NameFeePerPeriod,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.RegisterName{
+				Name:    []byte("indexed"),
+				Periods: 1,
+			}},
+			ifactory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Blocks are indexed asynchronously after they are accepted
+		var (
+			itx       *chain.Transaction
+			result    *chain.Result
+			height    uint64
+			timestamp int64
+		)
+		require.NoError(rpc.Wait(ctx, func(ctx context.Context) (bool, error) {
+			itx, result, height, timestamp, err = instances[0].cli.GetIndexedTx(ctx, parser, tx.ID())
+			if err != nil && strings.Contains(err.Error(), rpc.ErrTxMissing.Error()) {
+				return false, nil
+			}
+			return err == nil, err
+		}))
+		require.Equal(tx.ID(), itx.ID())
+		require.True(result.Success)
+		require.Equal(results[0].Fee, result.Fee)
+		require.Positive(timestamp)
+
+		blk, blkResults, _, err := instances[0].cli.GetIndexedBlock(ctx, parser, height)
+		require.NoError(err)
+		require.Equal(height, blk.Hght)
+		require.Equal(timestamp, blk.Tmstmp)
+		require.Len(blk.Txs, 1)
+		require.Equal(tx.ID(), blk.Txs[0].ID())
+		require.Len(blkResults, 1)
+		_, _, _, err = instances[0].cli.GetIndexedBlock(ctx, parser, height+1)
+		require.ErrorContains(err, rpc.ErrBlockMissing.Error())
+
+		// Transactions are indexed by recipient and actor (oldest first)
+		txIDs, next, err := instances[0].cli.GetTxsByAddress(ctx, iaddr, nil, 1)
+		require.NoError(err)
+		require.Equal([]ids.ID{fundTx.ID()}, txIDs)
+		require.NotNil(next)
+		txIDs, next, err = instances[0].cli.GetTxsByAddress(ctx, iaddr, next, 1)
+		require.NoError(err)
+		require.Equal([]ids.ID{tx.ID()}, txIDs)
+		require.Nil(next)
+
+		txIDs, _, err = instances[0].cli.GetTxsByActionType(ctx, lconsts.RegisterNameID, nil, 0)
+		require.NoError(err)
+		require.Contains(txIDs, tx.ID())
+		require.NotContains(txIDs, fundTx.ID())
+
+		txIDs, next, err = instances[0].cli.GetTxsByKey(ctx, storage.NameIndexKey([]byte("indexed")), nil, 0)
+		require.NoError(err)
+		require.Equal([]ids.ID{tx.ID()}, txIDs)
+		require.Nil(next)
+	})
 })
 
+func bridgeMessage(transfer *bridge.Transfer) *warp.Message {
+	require := require.New(ginkgo.GinkgoT())
+
+	unsignedMsg, err := warp.NewUnsignedMessage(networkID, bridgeChainID, transfer.Bytes())
+	require.NoError(err)
+	signers := set.NewBits(0)
+	signature := &warp.BitSetSignature{Signers: signers.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(bls.Sign(bridgeSK, unsignedMsg.Bytes())))
+	msg, err := warp.NewMessage(unsignedMsg, signature)
+	require.NoError(err)
+	return msg
+}
+
 func expectBlk(i instance) func(bool) []*chain.Result {
+	return expectBlkWithContext(i, nil)
+}
+
+// expectBlkWithContext builds a block at the P-Chain height of [bctx] (required
+// to include incoming warp messages).
+func expectBlkWithContext(i instance, bctx *block.Context) func(bool) []*chain.Result {
 	require := require.New(ginkgo.GinkgoT())
 
 	ctx := context.TODO()
@@ -910,11 +2121,21 @@ func expectBlk(i instance) func(bool) []*chain.Result {
 	// manually ack ready sig as in engine
 	<-i.toEngine
 
-	blk, err := i.vm.BuildBlock(ctx)
-	require.NoError(err)
-	require.NotNil(blk)
-
-	require.NoError(blk.Verify(ctx))
+	var (
+		blk snowman.Block
+		err error
+	)
+	if bctx != nil {
+		blk, err = i.vm.BuildBlockWithContext(ctx, bctx)
+		require.NoError(err)
+		require.NotNil(blk)
+		require.NoError(blk.(*chain.StatelessBlock).VerifyWithContext(ctx, bctx))
+	} else {
+		blk, err = i.vm.BuildBlock(ctx)
+		require.NoError(err)
+		require.NotNil(blk)
+		require.NoError(blk.Verify(ctx))
+	}
 	require.Equal(blk.Status(), choices.Processing)
 
 	err = i.vm.SetPreference(ctx, blk.ID())
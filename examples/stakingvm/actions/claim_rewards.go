@@ -0,0 +1,117 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	sconsts "github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+var _ chain.Action = (*ClaimRewards)(nil)
+
+// ClaimRewards pays the rewards the actor earned on [NodeID] for every epoch
+// that is over. If the actor registered [NodeID], these are the rewards of its
+// stake and the delegation fees it earned. Otherwise, they are the rewards of
+// its delegation (minus the delegation fee).
+//
+// The only output is the amount paid.
+type ClaimRewards struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+func (*ClaimRewards) GetTypeID() uint8 {
+	return sconsts.ClaimRewardsID
+}
+
+func (c *ClaimRewards) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(actor)):              state.All,
+		string(storage.ValidatorKey(c.NodeID)):         state.Read | state.Write,
+		string(storage.DelegationKey(c.NodeID, actor)): state.Read | state.Write,
+	}
+}
+
+func (*ClaimRewards) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks, storage.ValidatorChunks, storage.DelegationChunks}
+}
+
+func (c *ClaimRewards) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	sr, err := stakingRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := storage.GetValidator(ctx, mu, c.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrOutputValidatorNotFound
+	}
+	epoch := Epoch(sr, timestamp)
+	var reward uint64
+	if v.Owner == actor {
+		reward, err = claimValidator(ctx, sr, mu, v, epoch)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		d, err := storage.GetDelegation(ctx, mu, c.NodeID, actor)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			return nil, ErrOutputDelegationNotFound
+		}
+		reward, err = claimDelegation(ctx, sr, mu, v, d, actor, epoch)
+		if err != nil {
+			return nil, err
+		}
+		if err := storage.SetDelegation(ctx, mu, c.NodeID, actor, d); err != nil {
+			return nil, err
+		}
+	}
+	if err := putValidator(ctx, mu, c.NodeID, v); err != nil {
+		return nil, err
+	}
+	return rewardOutput(reward), nil
+}
+
+func (*ClaimRewards) ComputeUnits(chain.Rules) uint64 {
+	return StakingComputeUnits
+}
+
+func (*ClaimRewards) Size() int {
+	return ids.NodeIDLen
+}
+
+func (c *ClaimRewards) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(c.NodeID.Bytes())
+}
+
+func UnmarshalClaimRewards(p *codec.Packer) (chain.Action, error) {
+	var claim ClaimRewards
+	nodeID := claim.NodeID[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ids.NodeIDLen, &nodeID)
+	return &claim, p.Err()
+}
+
+func (*ClaimRewards) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
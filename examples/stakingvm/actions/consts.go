@@ -0,0 +1,14 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+const (
+	TransferComputeUnits = 1
+	StakingComputeUnits  = 1
+	// Slashes verify two signatures
+	SlashComputeUnits = 5
+
+	// BasisPoints is 100% (of a delegation fee, reward rate, or slash rate)
+	BasisPoints = 10_000
+)
@@ -0,0 +1,124 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	sconsts "github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+var _ chain.Action = (*Delegate)(nil)
+
+// Delegate stakes [Amount] on the active validator [NodeID]. Like the stake of
+// validators, it earns rewards from the epoch after the one it was delegated
+// in. An actor can only have one delegation on each validator.
+type Delegate struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Amount uint64     `json:"amount"`
+}
+
+func (*Delegate) GetTypeID() uint8 {
+	return sconsts.DelegateID
+}
+
+func (d *Delegate) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(actor)):              state.Read | state.Write,
+		string(storage.ValidatorKey(d.NodeID)):         state.Read | state.Write,
+		string(storage.DelegationKey(d.NodeID, actor)): state.All,
+	}
+}
+
+func (*Delegate) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks, storage.ValidatorChunks, storage.DelegationChunks}
+}
+
+func (d *Delegate) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	sr, err := stakingRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	if d.Amount < sr.GetMinDelegatorStake() {
+		return nil, ErrOutputStakeTooLow
+	}
+	v, err := storage.GetValidator(ctx, mu, d.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrOutputValidatorNotFound
+	}
+	if v.End != 0 {
+		return nil, ErrOutputValidatorNotActive
+	}
+	if v.Owner == actor {
+		return nil, ErrOutputSelfDelegation
+	}
+	delegation, err := storage.GetDelegation(ctx, mu, d.NodeID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if delegation != nil {
+		return nil, ErrOutputDelegationExists
+	}
+	if err := storage.SubBalance(ctx, mu, actor, d.Amount); err != nil {
+		return nil, err
+	}
+	v.Delegated, err = smath.Add64(v.Delegated, d.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetValidator(ctx, mu, d.NodeID, v); err != nil {
+		return nil, err
+	}
+	start := Epoch(sr, timestamp) + 1
+	return nil, storage.SetDelegation(ctx, mu, d.NodeID, actor, &storage.Delegation{
+		Amount:    d.Amount,
+		Start:     start,
+		LastClaim: start,
+	})
+}
+
+func (*Delegate) ComputeUnits(chain.Rules) uint64 {
+	return StakingComputeUnits
+}
+
+func (*Delegate) Size() int {
+	return ids.NodeIDLen + consts.Uint64Len
+}
+
+func (d *Delegate) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(d.NodeID.Bytes())
+	p.PackUint64(d.Amount)
+}
+
+func UnmarshalDelegate(p *codec.Packer) (chain.Action, error) {
+	var delegate Delegate
+	nodeID := delegate.NodeID[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ids.NodeIDLen, &nodeID)
+	delegate.Amount = p.UnpackUint64(true)
+	return &delegate, p.Err()
+}
+
+func (*Delegate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	sconsts "github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+var _ chain.Action = (*ExitValidator)(nil)
+
+// ExitValidator returns the stake of [NodeID] (and its rewards) to the actor
+// that registered it. The stake (and the stake of delegators) stops earning
+// rewards in the current epoch, so delegators should undelegate.
+type ExitValidator struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+func (*ExitValidator) GetTypeID() uint8 {
+	return sconsts.ExitValidatorID
+}
+
+func (e *ExitValidator) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(actor)):      state.All,
+		string(storage.ValidatorKey(e.NodeID)): state.Read | state.Write,
+	}
+}
+
+func (*ExitValidator) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks, storage.ValidatorChunks}
+}
+
+func (e *ExitValidator) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	sr, err := stakingRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := storage.GetValidator(ctx, mu, e.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrOutputValidatorNotFound
+	}
+	if v.Owner != actor {
+		return nil, ErrOutputWrongValidatorOwner
+	}
+	if v.Stake == 0 {
+		return nil, ErrOutputValidatorExited
+	}
+	epoch := Epoch(sr, timestamp)
+	if v.End == 0 {
+		v.End = epoch
+	}
+	reward, err := claimValidator(ctx, sr, mu, v, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, actor, v.Stake, true); err != nil {
+		return nil, err
+	}
+	v.Stake = 0
+	if err := putValidator(ctx, mu, e.NodeID, v); err != nil {
+		return nil, err
+	}
+	return rewardOutput(reward), nil
+}
+
+func (*ExitValidator) ComputeUnits(chain.Rules) uint64 {
+	return StakingComputeUnits
+}
+
+func (*ExitValidator) Size() int {
+	return ids.NodeIDLen
+}
+
+func (e *ExitValidator) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(e.NodeID.Bytes())
+}
+
+func UnmarshalExitValidator(p *codec.Packer) (chain.Action, error) {
+	var exit ExitValidator
+	nodeID := exit.NodeID[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ids.NodeIDLen, &nodeID)
+	return &exit, p.Err()
+}
+
+func (*ExitValidator) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
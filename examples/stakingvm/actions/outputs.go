@@ -0,0 +1,24 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import "errors"
+
+var (
+	ErrStakingRulesMissing        = errors.New("rules do not implement staking rules")
+	ErrOutputValueZero            = errors.New("value is zero")
+	ErrOutputStakeTooLow          = errors.New("stake is below the minimum")
+	ErrOutputInvalidDelegationFee = errors.New("delegation fee is larger than 100%")
+	ErrOutputValidatorExists      = errors.New("validator is already registered")
+	ErrOutputValidatorNotFound    = errors.New("validator is not registered")
+	ErrOutputValidatorNotActive   = errors.New("validator is not active")
+	ErrOutputValidatorExited      = errors.New("validator already exited")
+	ErrOutputWrongValidatorOwner  = errors.New("wrong validator owner")
+	ErrOutputSelfDelegation       = errors.New("validator can't delegate to itself")
+	ErrOutputDelegationExists     = errors.New("delegation already exists")
+	ErrOutputDelegationNotFound   = errors.New("delegation not found")
+	ErrOutputAlreadySlashed       = errors.New("validator is already slashed")
+	ErrOutputNoEquivocation       = errors.New("attestations are for the same block")
+	ErrOutputInvalidAttestation   = errors.New("invalid attestation signature")
+)
@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	sconsts "github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+var _ chain.Action = (*RegisterValidator)(nil)
+
+// RegisterValidator stakes [Stake] on [NodeID]. The stake earns rewards from
+// the epoch after the one it was registered in.
+//
+// Registration is not checked against the validator set of the subnet, so
+// anyone can stake on any node (and delegators must trust the actor that
+// registered it).
+type RegisterValidator struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// SigningKey signs the attestations of the validator (see
+	// [AttestationMessage])
+	SigningKey ed25519.PublicKey `json:"signingKey"`
+	Stake      uint64            `json:"stake"`
+	// DelegationFee is the share (in basis points) of the rewards of delegators
+	// paid to the actor
+	DelegationFee uint16 `json:"delegationFee"`
+}
+
+func (*RegisterValidator) GetTypeID() uint8 {
+	return sconsts.RegisterValidatorID
+}
+
+func (r *RegisterValidator) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(actor)):      state.Read | state.Write,
+		string(storage.ValidatorKey(r.NodeID)): state.All,
+	}
+}
+
+func (*RegisterValidator) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks, storage.ValidatorChunks}
+}
+
+func (r *RegisterValidator) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	sr, err := stakingRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	if r.Stake < sr.GetMinValidatorStake() {
+		return nil, ErrOutputStakeTooLow
+	}
+	if r.DelegationFee > BasisPoints {
+		return nil, ErrOutputInvalidDelegationFee
+	}
+	v, err := storage.GetValidator(ctx, mu, r.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		return nil, ErrOutputValidatorExists
+	}
+	if err := storage.SubBalance(ctx, mu, actor, r.Stake); err != nil {
+		return nil, err
+	}
+	start := Epoch(sr, timestamp) + 1
+	return nil, storage.SetValidator(ctx, mu, r.NodeID, &storage.Validator{
+		Owner:         actor,
+		SigningKey:    r.SigningKey,
+		Stake:         r.Stake,
+		DelegationFee: r.DelegationFee,
+		Start:         start,
+		LastClaim:     start,
+	})
+}
+
+func (*RegisterValidator) ComputeUnits(chain.Rules) uint64 {
+	return StakingComputeUnits
+}
+
+func (*RegisterValidator) Size() int {
+	return ids.NodeIDLen + ed25519.PublicKeyLen + consts.Uint64Len + consts.IntLen
+}
+
+func (r *RegisterValidator) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(r.NodeID.Bytes())
+	p.PackFixedBytes(r.SigningKey[:])
+	p.PackUint64(r.Stake)
+	p.PackInt(int(r.DelegationFee))
+}
+
+func UnmarshalRegisterValidator(p *codec.Packer) (chain.Action, error) {
+	var register RegisterValidator
+	nodeID := register.NodeID[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ids.NodeIDLen, &nodeID)
+	signingKey := register.SigningKey[:]
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &signingKey)
+	register.Stake = p.UnpackUint64(true)
+	delegationFee := p.UnpackInt(false)
+	if delegationFee > BasisPoints {
+		return nil, ErrOutputInvalidDelegationFee
+	}
+	register.DelegationFee = uint16(delegationFee)
+	return &register, p.Err()
+}
+
+func (*RegisterValidator) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
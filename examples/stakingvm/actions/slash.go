@@ -0,0 +1,129 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	sconsts "github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+var _ chain.Action = (*Slash)(nil)
+
+// Slash proves that the validator [NodeID] attested to two different blocks
+// ([First] and [Second]) at [Height]. Anyone can submit it.
+//
+// The slash rate of the stake of the validator is burned (and the slash rate
+// of each delegation is burned when it is returned). The validator stops
+// earning rewards in the current epoch and can't be delegated to anymore.
+type Slash struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Height uint64     `json:"height"`
+
+	First           ids.ID            `json:"first"`
+	FirstSignature  ed25519.Signature `json:"firstSignature"`
+	Second          ids.ID            `json:"second"`
+	SecondSignature ed25519.Signature `json:"secondSignature"`
+}
+
+func (*Slash) GetTypeID() uint8 {
+	return sconsts.SlashID
+}
+
+func (s *Slash) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.ValidatorKey(s.NodeID)): state.Read | state.Write,
+	}
+}
+
+func (*Slash) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ValidatorChunks}
+}
+
+func (s *Slash) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	_ codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	sr, err := stakingRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	if s.First == s.Second {
+		return nil, ErrOutputNoEquivocation
+	}
+	v, err := storage.GetValidator(ctx, mu, s.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrOutputValidatorNotFound
+	}
+	if v.Slashed {
+		return nil, ErrOutputAlreadySlashed
+	}
+	chainID := rules.ChainID()
+	if !ed25519.Verify(AttestationMessage(chainID, s.Height, s.First), v.SigningKey, s.FirstSignature) ||
+		!ed25519.Verify(AttestationMessage(chainID, s.Height, s.Second), v.SigningKey, s.SecondSignature) {
+		return nil, ErrOutputInvalidAttestation
+	}
+	penalty, err := mulBasisPoints(v.Stake, sr.GetSlashRate())
+	if err != nil {
+		return nil, err
+	}
+	v.Stake -= penalty
+	v.Slashed = true
+	if v.End == 0 {
+		v.End = Epoch(sr, timestamp)
+	}
+	return nil, putValidator(ctx, mu, s.NodeID, v)
+}
+
+func (*Slash) ComputeUnits(chain.Rules) uint64 {
+	return SlashComputeUnits
+}
+
+func (*Slash) Size() int {
+	return ids.NodeIDLen + consts.Uint64Len + (ids.IDLen+ed25519.SignatureLen)*2
+}
+
+func (s *Slash) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(s.NodeID.Bytes())
+	p.PackUint64(s.Height)
+	p.PackID(s.First)
+	p.PackFixedBytes(s.FirstSignature[:])
+	p.PackID(s.Second)
+	p.PackFixedBytes(s.SecondSignature[:])
+}
+
+func UnmarshalSlash(p *codec.Packer) (chain.Action, error) {
+	var slash Slash
+	nodeID := slash.NodeID[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ids.NodeIDLen, &nodeID)
+	slash.Height = p.UnpackUint64(false)
+	p.UnpackID(true, &slash.First)
+	firstSignature := slash.FirstSignature[:]
+	p.UnpackFixedBytes(ed25519.SignatureLen, &firstSignature)
+	p.UnpackID(true, &slash.Second)
+	secondSignature := slash.SecondSignature[:]
+	p.UnpackFixedBytes(ed25519.SignatureLen, &secondSignature)
+	return &slash, p.Err()
+}
+
+func (*Slash) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
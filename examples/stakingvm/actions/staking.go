@@ -0,0 +1,153 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// StakingRules are the staking parameters of the chain (implemented by
+// [genesis.Rules]).
+type StakingRules interface {
+	// GetEpochDuration is the duration (in ms) of an epoch. Stake earns rewards
+	// for every epoch it is staked for in full.
+	GetEpochDuration() int64
+	GetMinValidatorStake() uint64
+	GetMinDelegatorStake() uint64
+	// GetRewardRate is the reward (in basis points of stake) earned every epoch
+	GetRewardRate() uint64
+	// GetSlashRate is the share (in basis points) of the stake of a validator
+	// (and its delegators) burned when it is slashed
+	GetSlashRate() uint64
+}
+
+func stakingRules(r chain.Rules) (StakingRules, error) {
+	sr, ok := r.(StakingRules)
+	if !ok {
+		return nil, ErrStakingRulesMissing
+	}
+	return sr, nil
+}
+
+// Epoch returns the epoch of a block with [timestamp].
+func Epoch(r StakingRules, timestamp int64) uint64 {
+	return uint64(timestamp / r.GetEpochDuration())
+}
+
+// Reward returns the rewards of [amount] staked for every epoch in [from, end)
+// that was over before [current] (no epoch ends the stake if [end] is 0).
+func Reward(r StakingRules, amount uint64, from uint64, end uint64, current uint64) (uint64, error) {
+	to := current
+	if end != 0 && end < to {
+		to = end
+	}
+	if to <= from {
+		return 0, nil
+	}
+	// The reward of each epoch is rounded down
+	reward, err := mulBasisPoints(amount, r.GetRewardRate())
+	if err != nil {
+		return 0, err
+	}
+	return smath.Mul64(reward, to-from)
+}
+
+// mulBasisPoints returns [bps] basis points of [amount] (rounded down).
+func mulBasisPoints(amount uint64, bps uint64) (uint64, error) {
+	whole, err := smath.Mul64(amount/BasisPoints, bps)
+	if err != nil {
+		return 0, err
+	}
+	return smath.Add64(whole, amount%BasisPoints*bps/BasisPoints)
+}
+
+// AttestationMessage is the message signed with the signing key of a
+// validator when it attests to [blockID] at [height] on [chainID]. Signing
+// two different blocks at the same height is evidence for [Slash].
+func AttestationMessage(chainID ids.ID, height uint64, blockID ids.ID) []byte {
+	msg := make([]byte, 0, ids.IDLen*2+consts.Uint64Len)
+	msg = append(msg, chainID[:]...)
+	msg = binary.BigEndian.AppendUint64(msg, height)
+	return append(msg, blockID[:]...)
+}
+
+// claimValidator pays the rewards of the stake of [v] up to [epoch] (and the
+// fees it earned from delegators) to its owner.
+func claimValidator(
+	ctx context.Context,
+	r StakingRules,
+	mu state.Mutable,
+	v *storage.Validator,
+	epoch uint64,
+) (uint64, error) {
+	reward, err := Reward(r, v.Stake, v.LastClaim, v.End, epoch)
+	if err != nil {
+		return 0, err
+	}
+	reward, err = smath.Add64(reward, v.Commission)
+	if err != nil {
+		return 0, err
+	}
+	v.Commission = 0
+	v.LastClaim = max(v.LastClaim, epoch)
+	if reward == 0 {
+		return 0, nil
+	}
+	return reward, storage.AddBalance(ctx, mu, v.Owner, reward, true)
+}
+
+// claimDelegation pays the rewards of [d] up to [epoch] (minus the delegation
+// fee of [v]) to [delegator].
+func claimDelegation(
+	ctx context.Context,
+	r StakingRules,
+	mu state.Mutable,
+	v *storage.Validator,
+	d *storage.Delegation,
+	delegator codec.Address,
+	epoch uint64,
+) (uint64, error) {
+	reward, err := Reward(r, d.Amount, d.LastClaim, v.End, epoch)
+	if err != nil {
+		return 0, err
+	}
+	fee, err := mulBasisPoints(reward, uint64(v.DelegationFee))
+	if err != nil {
+		return 0, err
+	}
+	v.Commission, err = smath.Add64(v.Commission, fee)
+	if err != nil {
+		return 0, err
+	}
+	d.LastClaim = max(d.LastClaim, epoch)
+	reward -= fee
+	if reward == 0 {
+		return 0, nil
+	}
+	return reward, storage.AddBalance(ctx, mu, delegator, reward, true)
+}
+
+// putValidator stores [v] (or deletes it once nothing is staked on it and
+// all of its rewards are claimed).
+func putValidator(ctx context.Context, mu state.Mutable, nodeID ids.NodeID, v *storage.Validator) error {
+	if v.Stake == 0 && v.Delegated == 0 && v.Commission == 0 {
+		return storage.DeleteValidator(ctx, mu, nodeID)
+	}
+	return storage.SetValidator(ctx, mu, nodeID, v)
+}
+
+func rewardOutput(reward uint64) [][]byte {
+	return [][]byte{binary.BigEndian.AppendUint64(nil, reward)}
+}
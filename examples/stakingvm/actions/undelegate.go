@@ -0,0 +1,124 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	sconsts "github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+var _ chain.Action = (*Undelegate)(nil)
+
+// Undelegate returns the delegation of the actor on [NodeID] (and its
+// rewards). If the validator was slashed, the slash rate of the delegation is
+// burned.
+type Undelegate struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+func (*Undelegate) GetTypeID() uint8 {
+	return sconsts.UndelegateID
+}
+
+func (u *Undelegate) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.BalanceKey(actor)):              state.All,
+		string(storage.ValidatorKey(u.NodeID)):         state.Read | state.Write,
+		string(storage.DelegationKey(u.NodeID, actor)): state.Read | state.Write,
+	}
+}
+
+func (*Undelegate) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.BalanceChunks, storage.ValidatorChunks, storage.DelegationChunks}
+}
+
+func (u *Undelegate) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	sr, err := stakingRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	d, err := storage.GetDelegation(ctx, mu, u.NodeID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrOutputDelegationNotFound
+	}
+	v, err := storage.GetValidator(ctx, mu, u.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		// Validators are only deleted once all delegations are returned
+		return nil, ErrOutputValidatorNotFound
+	}
+	reward, err := claimDelegation(ctx, sr, mu, v, d, actor, Epoch(sr, timestamp))
+	if err != nil {
+		return nil, err
+	}
+	amount := d.Amount
+	if v.Slashed {
+		penalty, err := mulBasisPoints(amount, sr.GetSlashRate())
+		if err != nil {
+			return nil, err
+		}
+		amount -= penalty
+	}
+	if amount > 0 {
+		if err := storage.AddBalance(ctx, mu, actor, amount, true); err != nil {
+			return nil, err
+		}
+	}
+	v.Delegated, err = smath.Sub(v.Delegated, d.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteDelegation(ctx, mu, u.NodeID, actor); err != nil {
+		return nil, err
+	}
+	if err := putValidator(ctx, mu, u.NodeID, v); err != nil {
+		return nil, err
+	}
+	return rewardOutput(reward), nil
+}
+
+func (*Undelegate) ComputeUnits(chain.Rules) uint64 {
+	return StakingComputeUnits
+}
+
+func (*Undelegate) Size() int {
+	return ids.NodeIDLen
+}
+
+func (u *Undelegate) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(u.NodeID.Bytes())
+}
+
+func UnmarshalUndelegate(p *codec.Packer) (chain.Action, error) {
+	var undelegate Undelegate
+	nodeID := undelegate.NodeID[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ids.NodeIDLen, &nodeID)
+	return &undelegate, p.Err()
+}
+
+func (*Undelegate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
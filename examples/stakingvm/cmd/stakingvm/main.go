@@ -0,0 +1,50 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/ulimit"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/examples/stakingvm/cmd/stakingvm/version"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/controller"
+)
+
+var rootCmd = &cobra.Command{
+	Use:        "stakingvm",
+	Short:      "StakingVM agent",
+	SuggestFor: []string{"stakingvm"},
+	RunE:       runFunc,
+}
+
+func init() {
+	cobra.EnablePrefixMatching = true
+}
+
+func init() {
+	rootCmd.AddCommand(
+		version.NewCommand(),
+	)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "stakingvm failed %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func runFunc(*cobra.Command, []string) error {
+	if err := ulimit.Set(ulimit.DefaultFDLimit, logging.NoLog{}); err != nil {
+		return fmt.Errorf("%w: failed to set fd limit correctly", err)
+	}
+	return rpcchainvm.Serve(context.TODO(), controller.New())
+}
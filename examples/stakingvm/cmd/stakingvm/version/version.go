@@ -0,0 +1,32 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/version"
+)
+
+func init() {
+	cobra.EnablePrefixMatching = true
+}
+
+// NewCommand implements "stakingvm version" command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Prints out the verson",
+		RunE:  versionFunc,
+	}
+	return cmd
+}
+
+func versionFunc(*cobra.Command, []string) error {
+	fmt.Printf("%s@%s (%s)\n", consts.Name, version.Version, consts.ID)
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	hconfig "github.com/ava-labs/hypersdk/config"
+)
+
+// EnvPrefix starts the environment variables that override the [Config] of
+// a node (like STAKINGVM_LOG_LEVEL for "logLevel").
+const EnvPrefix = "STAKINGVM"
+
+type Config struct {
+	StoreTransactions bool          `json:"storeTransactions"`
+	TestMode          bool          `json:"testMode"` // makes gossip/building manual
+	LogLevel          logging.Level `json:"logLevel"`
+}
+
+func New(b []byte) (*Config, error) {
+	c := &Config{
+		StoreTransactions: true,
+		LogLevel:          logging.Info,
+	}
+
+	if err := hconfig.Load(b, c, EnvPrefix); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consts
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+)
+
+const (
+	HRP      = "staking"
+	Name     = "stakingvm"
+	Symbol   = "STK"
+	Decimals = 9
+)
+
+var ID ids.ID
+
+// AddressFormat encodes and parses the addresses of the VM
+var AddressFormat = address.MustNew(HRP, auth.Types())
+
+func init() {
+	b := make([]byte, ids.IDLen)
+	copy(b, []byte(Name))
+	vmID, err := ids.ToID(b)
+	if err != nil {
+		panic(err)
+	}
+	ID = vmID
+}
+
+// Instantiate registry here so it can be imported by any package. We set these
+// values in [controller/registry].
+var (
+	ActionRegistry *codec.TypeParser[chain.Action]
+	AuthRegistry   *codec.TypeParser[chain.Auth]
+)
@@ -0,0 +1,15 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package consts
+
+const (
+	// Action TypeIDs
+	TransferID          uint8 = 0
+	RegisterValidatorID uint8 = 1
+	ExitValidatorID     uint8 = 2
+	DelegateID          uint8 = 3
+	UndelegateID        uint8 = 4
+	ClaimRewardsID      uint8 = 5
+	SlashID             uint8 = 6
+)
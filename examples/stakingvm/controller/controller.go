@@ -0,0 +1,167 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/builder"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/config"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/rpc"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/version"
+	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/pebble"
+	"github.com/ava-labs/hypersdk/vm"
+
+	ametrics "github.com/ava-labs/avalanchego/api/metrics"
+	hrpc "github.com/ava-labs/hypersdk/rpc"
+	hstorage "github.com/ava-labs/hypersdk/storage"
+)
+
+var _ vm.Controller = (*Controller)(nil)
+
+type Controller struct {
+	inner *vm.VM
+
+	snowCtx      *snow.Context
+	genesis      *genesis.Genesis
+	config       *config.Config
+	stateManager *storage.StateManager
+
+	db database.Database
+}
+
+func New() *vm.VM {
+	return vm.New(&Controller{}, version.Version)
+}
+
+func (c *Controller) Initialize(
+	inner *vm.VM,
+	snowCtx *snow.Context,
+	gatherer ametrics.MultiGatherer,
+	genesisBytes []byte,
+	upgradeBytes []byte, // subnets to allow for AWM
+	configBytes []byte,
+) (
+	vm.Genesis,
+	builder.Builder,
+	gossiper.Gossiper,
+	vm.Handlers,
+	chain.ActionRegistry,
+	chain.AuthRegistry,
+	map[uint8]vm.AuthEngine,
+	error,
+) {
+	c.inner = inner
+	c.snowCtx = snowCtx
+	c.stateManager = &storage.StateManager{}
+
+	var err error
+	// Load config and genesis
+	c.config, err = config.New(configBytes)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+
+	c.snowCtx.Log.SetLevel(c.config.LogLevel)
+	snowCtx.Log.Info("initialized config", zap.Any("contents", c.config))
+
+	c.genesis, err = genesis.New(genesisBytes, upgradeBytes)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf(
+			"unable to read genesis: %w",
+			err,
+		)
+	}
+	snowCtx.Log.Info("loaded genesis", zap.Any("genesis", c.genesis))
+
+	c.db, err = hstorage.New(pebble.NewDefaultConfig(), snowCtx.ChainDataDir, "db", gatherer)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+
+	// Create handlers
+	//
+	// hypersdk handler are initiatlized automatically, you just need to
+	// initialize custom handlers here.
+	apis := map[string]http.Handler{}
+	jsonRPCHandler, err := hrpc.NewJSONRPCHandler(
+		consts.Name,
+		rpc.NewJSONRPCServer(c),
+	)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+	apis[rpc.JSONRPCEndpoint] = jsonRPCHandler
+
+	// Create builder and gossiper
+	var (
+		build  builder.Builder
+		gossip gossiper.Gossiper
+	)
+	if c.config.TestMode {
+		c.inner.Logger().Info("running build and gossip in test mode")
+		build = builder.NewManual(inner)
+		gossip = gossiper.NewManual(inner)
+	} else {
+		build = builder.NewTime(inner)
+		gcfg := gossiper.DefaultProposerConfig()
+		gossip, err = gossiper.NewProposer(inner, gcfg)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, err
+		}
+	}
+	return c.genesis, build, gossip, apis, consts.ActionRegistry, consts.AuthRegistry, auth.Engines(), nil
+}
+
+func (c *Controller) Rules(t int64) chain.Rules {
+	// TODO: extend with [UpgradeBytes]
+	return c.genesis.Rules(t, c.snowCtx.NetworkID, c.snowCtx.ChainID)
+}
+
+func (c *Controller) StateManager() chain.StateManager {
+	return c.stateManager
+}
+
+func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) error {
+	batch := c.db.NewBatch()
+	defer batch.Reset()
+
+	results := blk.Results()
+	for i, tx := range blk.Txs {
+		result := results[i]
+		if c.config.StoreTransactions {
+			err := storage.StoreTransaction(
+				ctx,
+				batch,
+				tx.ID(),
+				blk.GetTimestamp(),
+				result.Success,
+				result.Units,
+				result.Fee,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Write()
+}
+
+func (*Controller) Shutdown(context.Context) error {
+	// Do not close any databases provided during initialization. The VM will
+	// close any databases your provided.
+	return nil
+}
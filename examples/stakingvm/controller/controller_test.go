@@ -0,0 +1,230 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/actions"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/vm/vmtest"
+)
+
+type account struct {
+	factory chain.AuthFactory
+	addr    codec.Address
+}
+
+func newAccount(t *testing.T) *account {
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	return &account{auth.NewED25519Factory(priv), auth.NewED25519Address(priv.PublicKey())}
+}
+
+func newHarness(t *testing.T, gen *genesis.Genesis, balance uint64, accounts ...*account) *vmtest.Harness {
+	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	gen.MinBlockGap = 0
+	for _, acct := range accounts {
+		gen.CustomAllocation = append(gen.CustomAllocation, &genesis.CustomAllocation{
+			Address: consts.AddressFormat.Encode(acct.addr),
+			Balance: balance,
+		})
+	}
+	genesisBytes, err := json.Marshal(gen)
+	require.NoError(t, err)
+	return vmtest.New(t, New(), vmtest.Config{
+		Genesis:   genesisBytes,
+		VMConfig:  []byte(`{"config":{"testMode":true}}`),
+		NetworkID: 1,
+	})
+}
+
+// produce builds and accepts a block with a transaction of [actions] sent by
+// [acct] and returns its result and timestamp.
+func produce(ctx context.Context, h *vmtest.Harness, acct *account, actions ...chain.Action) (*chain.Result, int64) {
+	h.Submit(ctx, h.GenerateTx(actions, acct.factory))
+	blk := h.BuildBlock(ctx)
+	results := h.AcceptBlock(ctx, blk)
+	return results[0], blk.GetTimestamp()
+}
+
+func TestStaking(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	owner, delegator := newAccount(t), newAccount(t)
+	gen := genesis.Default()
+	gen.MinValidatorStake = 1_000
+	gen.MinDelegatorStake = 100
+	h := newHarness(t, gen, 1_000_000, owner, delegator)
+
+	signingKey, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	nodeID := ids.GenerateTestNodeID()
+	result, _ := produce(ctx, h, owner, &actions.RegisterValidator{
+		NodeID:        nodeID,
+		SigningKey:    signingKey.PublicKey(),
+		Stake:         999,
+		DelegationFee: 1_000,
+	})
+	h.RequireFailure(result, actions.ErrOutputStakeTooLow)
+	result, _ = produce(ctx, h, owner, &actions.RegisterValidator{
+		NodeID:        nodeID,
+		SigningKey:    signingKey.PublicKey(),
+		Stake:         10_000,
+		DelegationFee: 1_000,
+	})
+	h.RequireSuccess([]*chain.Result{result})
+	result, _ = produce(ctx, h, delegator, &actions.RegisterValidator{
+		NodeID:     nodeID,
+		SigningKey: signingKey.PublicKey(),
+		Stake:      10_000,
+	})
+	h.RequireFailure(result, actions.ErrOutputValidatorExists)
+
+	// Validators can't delegate to themselves (and delegators only delegate
+	// once on each validator)
+	result, _ = produce(ctx, h, owner, &actions.Delegate{NodeID: nodeID, Amount: 1_000})
+	h.RequireFailure(result, actions.ErrOutputSelfDelegation)
+	result, _ = produce(ctx, h, delegator, &actions.Delegate{NodeID: nodeID, Amount: 1_000})
+	h.RequireSuccess([]*chain.Result{result})
+	result, _ = produce(ctx, h, delegator, &actions.Delegate{NodeID: nodeID, Amount: 2_000})
+	h.RequireFailure(result, actions.ErrOutputDelegationExists)
+	v, err := storage.GetValidatorFromState(ctx, h.VM().ReadState, nodeID)
+	require.NoError(err)
+	require.Equal(owner.addr, v.Owner)
+	require.Equal(uint64(10_000), v.Stake)
+	require.Equal(uint64(1_000), v.Delegated)
+	require.False(v.Slashed)
+
+	// Only attestations of two different blocks at the same height signed by
+	// the signing key of the validator slash it
+	var (
+		height      = uint64(10)
+		first       = ids.GenerateTestID()
+		second      = ids.GenerateTestID()
+		chainID     = h.VM().ChainID()
+		firstSig    = ed25519.Sign(actions.AttestationMessage(chainID, height, first), signingKey)
+		secondSig   = ed25519.Sign(actions.AttestationMessage(chainID, height, second), signingKey)
+		wrongHeight = ed25519.Sign(actions.AttestationMessage(chainID, height+1, second), signingKey)
+		firstAgain  = ed25519.Sign(actions.AttestationMessage(chainID, height+1, first), signingKey)
+	)
+	result, _ = produce(ctx, h, delegator, &actions.Slash{NodeID: nodeID, Height: height, First: first, FirstSignature: firstSig, Second: first, SecondSignature: firstSig})
+	h.RequireFailure(result, actions.ErrOutputNoEquivocation)
+	result, _ = produce(ctx, h, delegator, &actions.Slash{NodeID: nodeID, Height: height, First: first, FirstSignature: firstSig, Second: second, SecondSignature: wrongHeight})
+	h.RequireFailure(result, actions.ErrOutputInvalidAttestation)
+	result, _ = produce(ctx, h, delegator, &actions.Slash{NodeID: nodeID, Height: height, First: first, FirstSignature: firstSig, Second: second, SecondSignature: secondSig})
+	h.RequireSuccess([]*chain.Result{result})
+	result, _ = produce(ctx, h, delegator, &actions.Slash{NodeID: nodeID, Height: height + 1, First: first, FirstSignature: firstAgain, Second: second, SecondSignature: wrongHeight})
+	h.RequireFailure(result, actions.ErrOutputAlreadySlashed)
+	v, err = storage.GetValidatorFromState(ctx, h.VM().ReadState, nodeID)
+	require.NoError(err)
+	require.Equal(uint64(9_000), v.Stake)
+	require.True(v.Slashed)
+	require.NotZero(v.End)
+
+	// Slashed validators can't be delegated to and delegators lose the slash
+	// rate of their delegation
+	result, _ = produce(ctx, h, delegator, &actions.Delegate{NodeID: nodeID, Amount: 500})
+	h.RequireFailure(result, actions.ErrOutputValidatorNotActive)
+	before, err := storage.GetBalanceFromState(ctx, h.VM().ReadState, delegator.addr)
+	require.NoError(err)
+	result, _ = produce(ctx, h, delegator, &actions.Undelegate{NodeID: nodeID})
+	h.RequireSuccess([]*chain.Result{result})
+	after, err := storage.GetBalanceFromState(ctx, h.VM().ReadState, delegator.addr)
+	require.NoError(err)
+	require.Equal(before+900-result.Fee, after)
+	d, err := storage.GetDelegationFromState(ctx, h.VM().ReadState, nodeID, delegator.addr)
+	require.NoError(err)
+	require.Nil(d)
+
+	// Only the owner can exit and the validator is deleted once nothing is
+	// staked on it
+	result, _ = produce(ctx, h, delegator, &actions.ExitValidator{NodeID: nodeID})
+	h.RequireFailure(result, actions.ErrOutputWrongValidatorOwner)
+	before, err = storage.GetBalanceFromState(ctx, h.VM().ReadState, owner.addr)
+	require.NoError(err)
+	result, _ = produce(ctx, h, owner, &actions.ExitValidator{NodeID: nodeID})
+	h.RequireSuccess([]*chain.Result{result})
+	after, err = storage.GetBalanceFromState(ctx, h.VM().ReadState, owner.addr)
+	require.NoError(err)
+	require.Equal(before+9_000-result.Fee, after)
+	h.RequireValue(ctx, storage.ValidatorKey(nodeID), nil)
+}
+
+func TestRewards(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	owner, delegator := newAccount(t), newAccount(t)
+	gen := genesis.Default()
+	gen.EpochDuration = 50
+	gen.MinValidatorStake = 1_000
+	gen.MinDelegatorStake = 100
+	gen.RewardRate = 100 // 1%
+	h := newHarness(t, gen, 10_000_000, owner, delegator)
+	r := h.VM().Rules(0).(actions.StakingRules)
+	reward := func(result *chain.Result, i int) uint64 {
+		require.True(result.Success, string(result.Error))
+		return binary.BigEndian.Uint64(result.Outputs[i][0])
+	}
+
+	nodeID := ids.GenerateTestNodeID()
+	result, registered := produce(ctx, h, owner, &actions.RegisterValidator{
+		NodeID:        nodeID,
+		Stake:         1_000_000,
+		DelegationFee: 1_000, // 10%
+	})
+	h.RequireSuccess([]*chain.Result{result})
+	result, delegated := produce(ctx, h, delegator, &actions.Delegate{NodeID: nodeID, Amount: 100_000})
+	h.RequireSuccess([]*chain.Result{result})
+
+	// Stake only earns rewards for the epochs that are over
+	time.Sleep(time.Duration(3*gen.EpochDuration) * time.Millisecond)
+	result, claimed := produce(ctx, h, delegator, &actions.ClaimRewards{NodeID: nodeID})
+	delegationEpochs := actions.Epoch(r, claimed) - (actions.Epoch(r, delegated) + 1)
+	require.Positive(delegationEpochs)
+	require.Equal(900*delegationEpochs, reward(result, 0))
+
+	// Rewards are only paid once for each epoch
+	result, ownerClaimed := produce(ctx, h, owner, &actions.ClaimRewards{NodeID: nodeID}, &actions.ClaimRewards{NodeID: nodeID})
+	validatorEpochs := actions.Epoch(r, ownerClaimed) - (actions.Epoch(r, registered) + 1)
+	require.Equal(10_000*validatorEpochs+100*delegationEpochs, reward(result, 0))
+	require.Zero(reward(result, 1))
+
+	// Delegations stop earning rewards once the validator exits
+	time.Sleep(time.Duration(2*gen.EpochDuration) * time.Millisecond)
+	result, exited := produce(ctx, h, owner, &actions.ExitValidator{NodeID: nodeID})
+	h.RequireSuccess([]*chain.Result{result})
+	time.Sleep(time.Duration(2*gen.EpochDuration) * time.Millisecond)
+	result, _ = produce(ctx, h, delegator, &actions.Undelegate{NodeID: nodeID})
+	delegationEpochs = actions.Epoch(r, exited) - actions.Epoch(r, claimed)
+	require.Positive(delegationEpochs)
+	require.Equal(900*delegationEpochs, reward(result, 0))
+
+	// The validator is deleted once its owner claims the fees of the last
+	// delegation
+	v, err := storage.GetValidatorFromState(ctx, h.VM().ReadState, nodeID)
+	require.NoError(err)
+	require.Equal(uint64(0), v.Stake)
+	require.Equal(uint64(0), v.Delegated)
+	require.Equal(100*delegationEpochs, v.Commission)
+	result, _ = produce(ctx, h, owner, &actions.ClaimRewards{NodeID: nodeID})
+	require.Equal(100*delegationEpochs, reward(result, 0))
+	h.RequireValue(ctx, storage.ValidatorKey(nodeID), nil)
+}
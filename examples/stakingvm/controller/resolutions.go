@@ -0,0 +1,58 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+func (c *Controller) Genesis() *genesis.Genesis {
+	return c.genesis
+}
+
+func (c *Controller) Logger() logging.Logger {
+	return c.inner.Logger()
+}
+
+func (c *Controller) Tracer() trace.Tracer {
+	return c.inner.Tracer()
+}
+
+func (c *Controller) GetTransaction(
+	ctx context.Context,
+	txID ids.ID,
+) (bool, int64, bool, fees.Dimensions, uint64, error) {
+	return storage.GetTransaction(ctx, c.db, txID)
+}
+
+func (c *Controller) GetBalanceFromState(
+	ctx context.Context,
+	acct codec.Address,
+) (uint64, error) {
+	return storage.GetBalanceFromState(ctx, c.inner.ReadState, acct)
+}
+
+func (c *Controller) GetValidatorFromState(
+	ctx context.Context,
+	nodeID ids.NodeID,
+) (*storage.Validator, error) {
+	return storage.GetValidatorFromState(ctx, c.inner.ReadState, nodeID)
+}
+
+func (c *Controller) GetDelegationFromState(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	delegator codec.Address,
+) (*storage.Delegation, error) {
+	return storage.GetDelegationFromState(ctx, c.inner.ReadState, nodeID, delegator)
+}
@@ -0,0 +1,11 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import "errors"
+
+var (
+	ErrInvalidEpochDuration = errors.New("epoch duration must be positive")
+	ErrInvalidSlashRate     = errors.New("slash rate is larger than 100%")
+)
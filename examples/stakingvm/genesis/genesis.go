@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/vm"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	hconsts "github.com/ava-labs/hypersdk/consts"
+)
+
+var _ vm.Genesis = (*Genesis)(nil)
+
+const maxSlashRate = 10_000 // 100%
+
+type CustomAllocation struct {
+	Address string `json:"address"` // bech32 address
+	Balance uint64 `json:"balance"`
+}
+
+type Genesis struct {
+	// State Parameters
+	StateBranchFactor merkledb.BranchFactor `json:"stateBranchFactor"`
+
+	// Chain Parameters
+	MinBlockGap             int64 `json:"minBlockGap"`             // ms
+	MinEmptyBlockGap        int64 `json:"minEmptyBlockGap"`        // ms
+	MaxEmptyBlockGap        int64 `json:"maxEmptyBlockGap"`        // ms
+	BlockTimestampTolerance int64 `json:"blockTimestampTolerance"` // ms
+
+	// Chain Fee Parameters
+	MinUnitPrice               fees.Dimensions `json:"minUnitPrice"`
+	UnitPriceChangeDenominator fees.Dimensions `json:"unitPriceChangeDenominator"`
+	WindowTargetUnits          fees.Dimensions `json:"windowTargetUnits"` // 10s
+	MaxBlockUnits              fees.Dimensions `json:"maxBlockUnits"`     // must be possible to reach before block too large
+
+	// Tx Parameters
+	ValidityWindow      int64 `json:"validityWindow"` // ms
+	MaxActionsPerTx     uint8 `json:"maxActionsPerTx"`
+	MaxOutputsPerAction uint8 `json:"maxOutputsPerAction"`
+
+	// Tx Fee Parameters
+	BaseComputeUnits          uint64 `json:"baseUnits"`
+	StorageKeyReadUnits       uint64 `json:"storageKeyReadUnits"`
+	StorageValueReadUnits     uint64 `json:"storageValueReadUnits"` // per chunk
+	StorageKeyAllocateUnits   uint64 `json:"storageKeyAllocateUnits"`
+	StorageValueAllocateUnits uint64 `json:"storageValueAllocateUnits"` // per chunk
+	StorageKeyWriteUnits      uint64 `json:"storageKeyWriteUnits"`
+	StorageValueWriteUnits    uint64 `json:"storageValueWriteUnits"` // per chunk
+
+	// Staking Parameters
+	EpochDuration     int64  `json:"epochDuration"` // ms
+	MinValidatorStake uint64 `json:"minValidatorStake"`
+	MinDelegatorStake uint64 `json:"minDelegatorStake"`
+	RewardRate        uint64 `json:"rewardRate"` // basis points of stake per epoch
+	SlashRate         uint64 `json:"slashRate"`  // basis points of stake
+
+	// Allocates
+	CustomAllocation []*CustomAllocation `json:"customAllocation"`
+}
+
+func Default() *Genesis {
+	return &Genesis{
+		// State Parameters
+		StateBranchFactor: merkledb.BranchFactor16,
+
+		// Chain Parameters
+		MinBlockGap:             100,
+		MinEmptyBlockGap:        750,
+		MaxEmptyBlockGap:        750,
+		BlockTimestampTolerance: 1_000,
+
+		// Chain Fee Parameters
+		MinUnitPrice:               fees.Dimensions{100, 100, 100, 100, 100},
+		UnitPriceChangeDenominator: fees.Dimensions{48, 48, 48, 48, 48},
+		WindowTargetUnits:          fees.Dimensions{20_000_000, 1_000, 1_000, 1_000, 1_000},
+		MaxBlockUnits:              fees.Dimensions{1_800_000, 2_000, 2_000, 2_000, 2_000},
+
+		// Tx Parameters
+		ValidityWindow:      60 * hconsts.MillisecondsPerSecond, // ms
+		MaxActionsPerTx:     16,
+		MaxOutputsPerAction: 1,
+
+		// Tx Fee Compute Parameters
+		BaseComputeUnits: 1,
+
+		// Tx Fee Storage Parameters
+		//
+		// TODO: tune this
+		StorageKeyReadUnits:       5,
+		StorageValueReadUnits:     2,
+		StorageKeyAllocateUnits:   20,
+		StorageValueAllocateUnits: 5,
+		StorageKeyWriteUnits:      10,
+		StorageValueWriteUnits:    3,
+
+		// Staking Parameters
+		EpochDuration:     60 * 60 * hconsts.MillisecondsPerSecond, // 1h
+		MinValidatorStake: 2_000_000_000_000,                       // 2k STK
+		MinDelegatorStake: 25_000_000_000,                          // 25 STK
+		RewardRate:        1,                                       // 0.01% every epoch
+		SlashRate:         1_000,                                   // 10%
+	}
+}
+
+func New(b []byte, _ []byte /* upgradeBytes */) (*Genesis, error) {
+	g := Default()
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, g); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(b), err)
+		}
+	}
+	if g.EpochDuration <= 0 {
+		return nil, ErrInvalidEpochDuration
+	}
+	if g.SlashRate > maxSlashRate {
+		return nil, ErrInvalidSlashRate
+	}
+	return g, nil
+}
+
+func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutable) error {
+	ctx, span := tracer.Start(ctx, "Genesis.Load")
+	defer span.End()
+
+	if err := g.StateBranchFactor.Valid(); err != nil {
+		return err
+	}
+
+	supply := uint64(0)
+	for _, alloc := range g.CustomAllocation {
+		addr, err := consts.AddressFormat.Parse(alloc.Address)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, alloc.Address)
+		}
+		supply, err = smath.Add64(supply, alloc.Balance)
+		if err != nil {
+			return err
+		}
+		if err := storage.SetBalance(ctx, mu, addr, alloc.Balance); err != nil {
+			return fmt.Errorf("%w: addr=%s, bal=%d", err, alloc.Address, alloc.Balance)
+		}
+	}
+	return nil
+}
+
+func (g *Genesis) GetStateBranchFactor() merkledb.BranchFactor {
+	return g.StateBranchFactor
+}
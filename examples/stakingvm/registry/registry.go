@@ -0,0 +1,38 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package registry
+
+import (
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/actions"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+// Setup types
+func init() {
+	consts.ActionRegistry = codec.NewTypeParser[chain.Action]()
+	consts.AuthRegistry = codec.NewTypeParser[chain.Auth]()
+
+	errs := &wrappers.Errs{}
+	errs.Add(
+		// When registering new actions, ALWAYS make sure to append at the end.
+		consts.ActionRegistry.Register((&actions.Transfer{}).GetTypeID(), actions.UnmarshalTransfer),
+		consts.ActionRegistry.Register((&actions.RegisterValidator{}).GetTypeID(), actions.UnmarshalRegisterValidator),
+		consts.ActionRegistry.Register((&actions.ExitValidator{}).GetTypeID(), actions.UnmarshalExitValidator),
+		consts.ActionRegistry.Register((&actions.Delegate{}).GetTypeID(), actions.UnmarshalDelegate),
+		consts.ActionRegistry.Register((&actions.Undelegate{}).GetTypeID(), actions.UnmarshalUndelegate),
+		consts.ActionRegistry.Register((&actions.ClaimRewards{}).GetTypeID(), actions.UnmarshalClaimRewards),
+		consts.ActionRegistry.Register((&actions.Slash{}).GetTypeID(), actions.UnmarshalSlash),
+
+		// When registering new auth, ALWAYS make sure to append at the end.
+		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519),
+	)
+	if errs.Errored() {
+		panic(errs.Err)
+	}
+}
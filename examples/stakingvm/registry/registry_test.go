@@ -0,0 +1,65 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/actions"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+	"github.com/ava-labs/hypersdk/fuzz"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+)
+
+// seedActions returns a valid instance of each action of the stakingvm.
+func seedActions() []chain.Action {
+	var (
+		to     = codec.CreateAddress(auth.ED25519ID, ids.GenerateTestID())
+		nodeID = ids.GenerateTestNodeID()
+	)
+	return []chain.Action{
+		&actions.Transfer{To: to, Value: 10},
+		&actions.RegisterValidator{NodeID: nodeID, SigningKey: ed25519.PublicKey{1}, Stake: 10, DelegationFee: 100},
+		&actions.ExitValidator{NodeID: nodeID},
+		&actions.Delegate{NodeID: nodeID, Amount: 10},
+		&actions.Undelegate{NodeID: nodeID},
+		&actions.ClaimRewards{NodeID: nodeID},
+		&actions.Slash{NodeID: nodeID, Height: 1, First: ids.GenerateTestID(), Second: ids.GenerateTestID()},
+	}
+}
+
+func FuzzUnmarshalAction(f *testing.F) {
+	for _, action := range seedActions() {
+		fuzz.Seed(f, fuzz.Encode(action))
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckObject(t, raw, consts.ActionRegistry)
+	})
+}
+
+func FuzzUnmarshalTx(f *testing.F) {
+	require := require.New(f)
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	base := &chain.Base{
+		Timestamp: hconsts.MillisecondsPerSecond,
+		ChainID:   ids.GenerateTestID(),
+		MaxFee:    1_000,
+	}
+	tx, err := chain.NewTx(base, seedActions()).Sign(auth.NewED25519Factory(priv), consts.ActionRegistry, consts.AuthRegistry)
+	require.NoError(err)
+	fuzz.Seed(f, tx.Bytes())
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckTx(t, raw, consts.ActionRegistry, consts.AuthRegistry)
+	})
+}
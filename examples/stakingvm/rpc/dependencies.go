@@ -0,0 +1,25 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+type Controller interface {
+	Genesis() *genesis.Genesis
+	Tracer() trace.Tracer
+	GetTransaction(context.Context, ids.ID) (bool, int64, bool, fees.Dimensions, uint64, error)
+	GetBalanceFromState(context.Context, codec.Address) (uint64, error)
+	GetValidatorFromState(context.Context, ids.NodeID) (*storage.Validator, error)
+	GetDelegationFromState(context.Context, ids.NodeID, codec.Address) (*storage.Delegation, error)
+}
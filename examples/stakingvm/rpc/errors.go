@@ -0,0 +1,12 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import "errors"
+
+var (
+	ErrTxNotFound         = errors.New("tx not found")
+	ErrValidatorNotFound  = errors.New("validator not found")
+	ErrDelegationNotFound = errors.New("delegation not found")
+)
@@ -0,0 +1,201 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	_ "github.com/ava-labs/hypersdk/examples/stakingvm/registry" // ensure registry populated
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/storage"
+	"github.com/ava-labs/hypersdk/requester"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+type JSONRPCClient struct {
+	requester *requester.EndpointRequester
+
+	networkID uint32
+	chainID   ids.ID
+	g         *genesis.Genesis
+}
+
+// New creates a new client object.
+func NewJSONRPCClient(uri string, networkID uint32, chainID ids.ID) *JSONRPCClient {
+	uri = strings.TrimSuffix(uri, "/")
+	uri += JSONRPCEndpoint
+	req := requester.New(uri, consts.Name)
+	return &JSONRPCClient{req, networkID, chainID, nil}
+}
+
+func (cli *JSONRPCClient) Genesis(ctx context.Context) (*genesis.Genesis, error) {
+	if cli.g != nil {
+		return cli.g, nil
+	}
+
+	resp := new(GenesisReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"genesis",
+		nil,
+		resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cli.g = resp.Genesis
+	return resp.Genesis, nil
+}
+
+func (cli *JSONRPCClient) Tx(ctx context.Context, id ids.ID) (bool, bool, int64, uint64, error) {
+	resp := new(TxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"tx",
+		&TxArgs{TxID: id},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrTxNotFound.Error()):
+		return false, false, -1, 0, nil
+	case err != nil:
+		return false, false, -1, 0, err
+	}
+	return true, resp.Success, resp.Timestamp, resp.Fee, nil
+}
+
+func (cli *JSONRPCClient) Balance(ctx context.Context, addr string) (uint64, error) {
+	resp := new(BalanceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"balance",
+		&BalanceArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Amount, err
+}
+
+// Validator returns the validator registered on [nodeID] (or false if there is
+// none).
+func (cli *JSONRPCClient) Validator(ctx context.Context, nodeID ids.NodeID) (bool, *ValidatorReply, error) {
+	resp := new(ValidatorReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"validator",
+		&ValidatorArgs{NodeID: nodeID},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrValidatorNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+// Delegation returns the delegation of [delegator] on [nodeID] (or false if
+// there is none).
+func (cli *JSONRPCClient) Delegation(ctx context.Context, nodeID ids.NodeID, delegator string) (bool, *DelegationReply, error) {
+	resp := new(DelegationReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"delegation",
+		&DelegationArgs{NodeID: nodeID, Delegator: delegator},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrDelegationNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *JSONRPCClient) WaitForBalance(
+	ctx context.Context,
+	addr string,
+	min uint64,
+) error {
+	return rpc.Wait(ctx, func(ctx context.Context) (bool, error) {
+		balance, err := cli.Balance(ctx, addr)
+		if err != nil {
+			return false, err
+		}
+		shouldExit := balance >= min
+		if !shouldExit {
+			utils.Outf(
+				"{{yellow}}waiting for %s balance: %s{{/}}\n",
+				utils.FormatBalance(min, consts.Decimals),
+				addr,
+			)
+		}
+		return shouldExit, nil
+	})
+}
+
+func (cli *JSONRPCClient) WaitForTransaction(ctx context.Context, txID ids.ID) (bool, uint64, error) {
+	var success bool
+	var fee uint64
+	if err := rpc.Wait(ctx, func(ctx context.Context) (bool, error) {
+		found, isuccess, _, ifee, err := cli.Tx(ctx, txID)
+		if err != nil {
+			return false, err
+		}
+		success = isuccess
+		fee = ifee
+		return found, nil
+	}); err != nil {
+		return false, 0, err
+	}
+	return success, fee, nil
+}
+
+var _ chain.Parser = (*Parser)(nil)
+
+type Parser struct {
+	networkID uint32
+	chainID   ids.ID
+	genesis   *genesis.Genesis
+}
+
+func (p *Parser) ChainID() ids.ID {
+	return p.chainID
+}
+
+func (p *Parser) Rules(t int64) chain.Rules {
+	return p.genesis.Rules(t, p.networkID, p.chainID)
+}
+
+func (*Parser) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return consts.ActionRegistry, consts.AuthRegistry
+}
+
+func (*Parser) StateManager() chain.StateManager {
+	return &storage.StateManager{}
+}
+
+func (cli *JSONRPCClient) Parser(ctx context.Context) (chain.Parser, error) {
+	g, err := cli.Genesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Parser{cli.networkID, cli.chainID, g}, nil
+}
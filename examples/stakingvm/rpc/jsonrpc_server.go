@@ -0,0 +1,160 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+	"github.com/ava-labs/hypersdk/examples/stakingvm/genesis"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+type JSONRPCServer struct {
+	c Controller
+}
+
+func NewJSONRPCServer(c Controller) *JSONRPCServer {
+	return &JSONRPCServer{c}
+}
+
+type GenesisReply struct {
+	Genesis *genesis.Genesis `json:"genesis"`
+}
+
+func (j *JSONRPCServer) Genesis(_ *http.Request, _ *struct{}, reply *GenesisReply) (err error) {
+	reply.Genesis = j.c.Genesis()
+	return nil
+}
+
+type TxArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type TxReply struct {
+	Timestamp int64           `json:"timestamp"`
+	Success   bool            `json:"success"`
+	Units     fees.Dimensions `json:"units"`
+	Fee       uint64          `json:"fee"`
+}
+
+func (j *JSONRPCServer) Tx(req *http.Request, args *TxArgs, reply *TxReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Tx")
+	defer span.End()
+
+	found, t, success, units, fee, err := j.c.GetTransaction(ctx, args.TxID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrTxNotFound
+	}
+	reply.Timestamp = t
+	reply.Success = success
+	reply.Units = units
+	reply.Fee = fee
+	return nil
+}
+
+type BalanceArgs struct {
+	Address string `json:"address"`
+}
+
+type BalanceReply struct {
+	Amount uint64 `json:"amount"`
+}
+
+func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *BalanceReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Balance")
+	defer span.End()
+
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	balance, err := j.c.GetBalanceFromState(ctx, addr)
+	if err != nil {
+		return err
+	}
+	reply.Amount = balance
+	return err
+}
+
+type ValidatorArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+type ValidatorReply struct {
+	Owner         string            `json:"owner"`
+	SigningKey    ed25519.PublicKey `json:"signingKey"`
+	Stake         uint64            `json:"stake"`
+	Delegated     uint64            `json:"delegated"`
+	DelegationFee uint16            `json:"delegationFee"`
+	Start         uint64            `json:"start"`
+	End           uint64            `json:"end"`
+	LastClaim     uint64            `json:"lastClaim"`
+	Commission    uint64            `json:"commission"`
+	Slashed       bool              `json:"slashed"`
+}
+
+// Validator returns the validator registered on [NodeID] (until its stake,
+// delegations, and rewards are all returned).
+func (j *JSONRPCServer) Validator(req *http.Request, args *ValidatorArgs, reply *ValidatorReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Validator")
+	defer span.End()
+
+	v, err := j.c.GetValidatorFromState(ctx, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return ErrValidatorNotFound
+	}
+	reply.Owner = consts.AddressFormat.Encode(v.Owner)
+	reply.SigningKey = v.SigningKey
+	reply.Stake = v.Stake
+	reply.Delegated = v.Delegated
+	reply.DelegationFee = v.DelegationFee
+	reply.Start = v.Start
+	reply.End = v.End
+	reply.LastClaim = v.LastClaim
+	reply.Commission = v.Commission
+	reply.Slashed = v.Slashed
+	return nil
+}
+
+type DelegationArgs struct {
+	NodeID    ids.NodeID `json:"nodeID"`
+	Delegator string     `json:"delegator"`
+}
+
+type DelegationReply struct {
+	Amount    uint64 `json:"amount"`
+	Start     uint64 `json:"start"`
+	LastClaim uint64 `json:"lastClaim"`
+}
+
+func (j *JSONRPCServer) Delegation(req *http.Request, args *DelegationArgs, reply *DelegationReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Delegation")
+	defer span.End()
+
+	delegator, err := consts.AddressFormat.Parse(args.Delegator)
+	if err != nil {
+		return err
+	}
+	d, err := j.c.GetDelegationFromState(ctx, args.NodeID, delegator)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return ErrDelegationNotFound
+	}
+	reply.Amount = d.Amount
+	reply.Start = d.Start
+	reply.LastClaim = d.LastClaim
+	return nil
+}
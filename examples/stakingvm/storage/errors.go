@@ -0,0 +1,12 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import "errors"
+
+var (
+	ErrInvalidBalance    = errors.New("invalid balance")
+	ErrInvalidValidator  = errors.New("invalid validator")
+	ErrInvalidDelegation = errors.New("invalid delegation")
+)
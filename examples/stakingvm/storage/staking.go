@@ -0,0 +1,177 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	validatorSize = codec.AddressLen + ed25519.PublicKeyLen + consts.Uint64Len*2 +
+		consts.IntLen + consts.Uint64Len*4 + consts.BoolLen
+	delegationSize = consts.Uint64Len * 3
+)
+
+// Validator is registered by [Owner] to stake on [ids.NodeID].
+//
+// Stake earns rewards for every epoch in [Start, End) (or up to the current
+// epoch while [End] is 0).
+type Validator struct {
+	Owner      codec.Address     `json:"owner"`
+	SigningKey ed25519.PublicKey `json:"signingKey"`
+
+	Stake     uint64 `json:"stake"`
+	Delegated uint64 `json:"delegated"`
+	// DelegationFee is the share (in basis points) of the rewards of
+	// delegators paid to [Owner]
+	DelegationFee uint16 `json:"delegationFee"`
+
+	Start      uint64 `json:"start"`
+	End        uint64 `json:"end"`
+	LastClaim  uint64 `json:"lastClaim"`
+	Commission uint64 `json:"commission"`
+	Slashed    bool   `json:"slashed"`
+}
+
+// Delegation is the stake of a delegator on a [Validator].
+type Delegation struct {
+	Amount    uint64 `json:"amount"`
+	Start     uint64 `json:"start"`
+	LastClaim uint64 `json:"lastClaim"`
+}
+
+// [validatorPrefix] + [nodeID]
+func ValidatorKey(nodeID ids.NodeID) []byte {
+	k := make([]byte, 1+ids.NodeIDLen+consts.Uint16Len)
+	k[0] = validatorPrefix
+	copy(k[1:], nodeID.Bytes())
+	return keys.EncodeChunks(k[:1+ids.NodeIDLen], ValidatorChunks)
+}
+
+// [delegationPrefix] + [nodeID] + [delegator]
+func DelegationKey(nodeID ids.NodeID, delegator codec.Address) []byte {
+	k := make([]byte, 1+ids.NodeIDLen+codec.AddressLen+consts.Uint16Len)
+	k[0] = delegationPrefix
+	copy(k[1:], nodeID.Bytes())
+	copy(k[1+ids.NodeIDLen:], delegator[:])
+	return keys.EncodeChunks(k[:1+ids.NodeIDLen+codec.AddressLen], DelegationChunks)
+}
+
+// GetValidator returns the validator staking on [nodeID] (or nil if there is
+// none).
+func GetValidator(ctx context.Context, im state.Immutable, nodeID ids.NodeID) (*Validator, error) {
+	return innerGetValidator(im.GetValue(ctx, ValidatorKey(nodeID)))
+}
+
+// Used to serve RPC queries
+func GetValidatorFromState(ctx context.Context, f ReadState, nodeID ids.NodeID) (*Validator, error) {
+	values, errs := f(ctx, [][]byte{ValidatorKey(nodeID)})
+	return innerGetValidator(values[0], errs[0])
+}
+
+func innerGetValidator(v []byte, err error) (*Validator, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var (
+		p         = codec.NewReader(v, validatorSize)
+		validator Validator
+	)
+	p.UnpackAddress(&validator.Owner)
+	signingKey := validator.SigningKey[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &signingKey)
+	validator.Stake = p.UnpackUint64(false)
+	validator.Delegated = p.UnpackUint64(false)
+	validator.DelegationFee = uint16(p.UnpackInt(false))
+	validator.Start = p.UnpackUint64(false)
+	validator.End = p.UnpackUint64(false)
+	validator.LastClaim = p.UnpackUint64(false)
+	validator.Commission = p.UnpackUint64(false)
+	validator.Slashed = p.UnpackBool()
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValidator, err)
+	}
+	return &validator, nil
+}
+
+func SetValidator(ctx context.Context, mu state.Mutable, nodeID ids.NodeID, validator *Validator) error {
+	p := codec.NewWriter(validatorSize, validatorSize)
+	p.PackAddress(validator.Owner)
+	p.PackFixedBytes(validator.SigningKey[:])
+	p.PackUint64(validator.Stake)
+	p.PackUint64(validator.Delegated)
+	p.PackInt(int(validator.DelegationFee))
+	p.PackUint64(validator.Start)
+	p.PackUint64(validator.End)
+	p.PackUint64(validator.LastClaim)
+	p.PackUint64(validator.Commission)
+	p.PackBool(validator.Slashed)
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ValidatorKey(nodeID), p.Bytes())
+}
+
+func DeleteValidator(ctx context.Context, mu state.Mutable, nodeID ids.NodeID) error {
+	return mu.Remove(ctx, ValidatorKey(nodeID))
+}
+
+// GetDelegation returns the delegation of [delegator] on [nodeID] (or nil if
+// there is none).
+func GetDelegation(ctx context.Context, im state.Immutable, nodeID ids.NodeID, delegator codec.Address) (*Delegation, error) {
+	return innerGetDelegation(im.GetValue(ctx, DelegationKey(nodeID, delegator)))
+}
+
+// Used to serve RPC queries
+func GetDelegationFromState(ctx context.Context, f ReadState, nodeID ids.NodeID, delegator codec.Address) (*Delegation, error) {
+	values, errs := f(ctx, [][]byte{DelegationKey(nodeID, delegator)})
+	return innerGetDelegation(values[0], errs[0])
+}
+
+func innerGetDelegation(v []byte, err error) (*Delegation, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(v) != delegationSize {
+		return nil, fmt.Errorf("%w: invalid length %d", ErrInvalidDelegation, len(v))
+	}
+	p := codec.NewReader(v, delegationSize)
+	return &Delegation{
+		Amount:    p.UnpackUint64(false),
+		Start:     p.UnpackUint64(false),
+		LastClaim: p.UnpackUint64(false),
+	}, p.Err()
+}
+
+func SetDelegation(ctx context.Context, mu state.Mutable, nodeID ids.NodeID, delegator codec.Address, delegation *Delegation) error {
+	p := codec.NewWriter(delegationSize, delegationSize)
+	p.PackUint64(delegation.Amount)
+	p.PackUint64(delegation.Start)
+	p.PackUint64(delegation.LastClaim)
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, DelegationKey(nodeID, delegator), p.Bytes())
+}
+
+func DeleteDelegation(ctx context.Context, mu state.Mutable, nodeID ids.NodeID, delegator codec.Address) error {
+	return mu.Remove(ctx, DelegationKey(nodeID, delegator))
+}
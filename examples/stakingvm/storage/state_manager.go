@@ -0,0 +1,65 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ (chain.StateManager) = (*StateManager)(nil)
+
+type StateManager struct{}
+
+func (*StateManager) HeightKey() []byte {
+	return HeightKey()
+}
+
+func (*StateManager) TimestampKey() []byte {
+	return TimestampKey()
+}
+
+func (*StateManager) FeeKey() []byte {
+	return FeeKey()
+}
+
+var _ (chain.ReservedKeyManager) = (*StateManager)(nil)
+
+func (*StateManager) ReservedPrefixes() [][]byte {
+	return ReservedPrefixes()
+}
+
+func (*StateManager) SponsorStateKeys(addr codec.Address) state.Keys {
+	return state.Keys{
+		string(BalanceKey(addr)): state.Read | state.Write,
+	}
+}
+
+func (*StateManager) CanDeduct(
+	ctx context.Context,
+	addr codec.Address,
+	im state.Immutable,
+	amount uint64,
+) error {
+	bal, err := GetBalance(ctx, im, addr)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return ErrInvalidBalance
+	}
+	return nil
+}
+
+func (*StateManager) Deduct(
+	ctx context.Context,
+	addr codec.Address,
+	mu state.Mutable,
+	amount uint64,
+) error {
+	return SubBalance(ctx, mu, addr, amount)
+}
@@ -0,0 +1,271 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	sconsts "github.com/ava-labs/hypersdk/examples/stakingvm/consts"
+)
+
+type ReadState func(context.Context, [][]byte) ([][]byte, []error)
+
+// Metadata
+// 0x0/ (tx)
+//   -> [txID] => timestamp|success|units|fee
+//
+// State
+// / (height) => store in root
+//   -> [heightPrefix] => height
+// 0x0/ (balance)
+//   -> [owner] => balance
+// 0x1/ (hypersdk-height)
+// 0x2/ (hypersdk-timestamp)
+// 0x3/ (hypersdk-fee)
+// 0x4/ (validators)
+//   -> [nodeID] => owner|signingKey|stake|delegated|delegationFee|start|end|lastClaim|commission|slashed
+// 0x5/ (delegations)
+//   -> [nodeID|delegator] => amount|start|lastClaim
+
+const (
+	// metaDB
+	txPrefix = 0x0
+
+	// stateDB
+	balancePrefix    = 0x0
+	heightPrefix     = 0x1
+	timestampPrefix  = 0x2
+	feePrefix        = 0x3
+	validatorPrefix  = 0x4
+	delegationPrefix = 0x5
+)
+
+const (
+	BalanceChunks    uint16 = 1
+	ValidatorChunks  uint16 = 2
+	DelegationChunks uint16 = 1
+)
+
+var (
+	failureByte  = byte(0x0)
+	successByte  = byte(0x1)
+	heightKey    = []byte{heightPrefix}
+	timestampKey = []byte{timestampPrefix}
+	feeKey       = []byte{feePrefix}
+)
+
+// [txPrefix] + [txID]
+func TxKey(id ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen)
+	k[0] = txPrefix
+	copy(k[1:], id[:])
+	return
+}
+
+func StoreTransaction(
+	_ context.Context,
+	db database.KeyValueWriter,
+	id ids.ID,
+	t int64,
+	success bool,
+	units fees.Dimensions,
+	fee uint64,
+) error {
+	k := TxKey(id)
+	v := make([]byte, consts.Uint64Len+1+fees.DimensionsLen+consts.Uint64Len)
+	binary.BigEndian.PutUint64(v, uint64(t))
+	if success {
+		v[consts.Uint64Len] = successByte
+	} else {
+		v[consts.Uint64Len] = failureByte
+	}
+	copy(v[consts.Uint64Len+1:], units.Bytes())
+	binary.BigEndian.PutUint64(v[consts.Uint64Len+1+fees.DimensionsLen:], fee)
+	return db.Put(k, v)
+}
+
+func GetTransaction(
+	_ context.Context,
+	db database.KeyValueReader,
+	id ids.ID,
+) (bool, int64, bool, fees.Dimensions, uint64, error) {
+	k := TxKey(id)
+	v, err := db.Get(k)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, false, fees.Dimensions{}, 0, nil
+	}
+	if err != nil {
+		return false, 0, false, fees.Dimensions{}, 0, err
+	}
+	t := int64(binary.BigEndian.Uint64(v))
+	success := true
+	if v[consts.Uint64Len] == failureByte {
+		success = false
+	}
+	d, err := fees.UnpackDimensions(v[consts.Uint64Len+1 : consts.Uint64Len+1+fees.DimensionsLen])
+	if err != nil {
+		return false, 0, false, fees.Dimensions{}, 0, err
+	}
+	fee := binary.BigEndian.Uint64(v[consts.Uint64Len+1+fees.DimensionsLen:])
+	return true, t, success, d, fee, nil
+}
+
+// [balancePrefix] + [address]
+func BalanceKey(addr codec.Address) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.Uint16Len)
+	k[0] = balancePrefix
+	copy(k[1:], addr[:])
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen:], BalanceChunks)
+	return
+}
+
+// If locked is 0, then account does not exist
+func GetBalance(
+	ctx context.Context,
+	im state.Immutable,
+	addr codec.Address,
+) (uint64, error) {
+	_, bal, _, err := getBalance(ctx, im, addr)
+	return bal, err
+}
+
+func getBalance(
+	ctx context.Context,
+	im state.Immutable,
+	addr codec.Address,
+) ([]byte, uint64, bool, error) {
+	k := BalanceKey(addr)
+	bal, exists, err := innerGetBalance(im.GetValue(ctx, k))
+	return k, bal, exists, err
+}
+
+// Used to serve RPC queries
+func GetBalanceFromState(
+	ctx context.Context,
+	f ReadState,
+	addr codec.Address,
+) (uint64, error) {
+	k := BalanceKey(addr)
+	values, errs := f(ctx, [][]byte{k})
+	bal, _, err := innerGetBalance(values[0], errs[0])
+	return bal, err
+}
+
+func innerGetBalance(
+	v []byte,
+	err error,
+) (uint64, bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return binary.BigEndian.Uint64(v), true, nil
+}
+
+func SetBalance(
+	ctx context.Context,
+	mu state.Mutable,
+	addr codec.Address,
+	balance uint64,
+) error {
+	k := BalanceKey(addr)
+	return setBalance(ctx, mu, k, balance)
+}
+
+func setBalance(
+	ctx context.Context,
+	mu state.Mutable,
+	key []byte,
+	balance uint64,
+) error {
+	return mu.Insert(ctx, key, binary.BigEndian.AppendUint64(nil, balance))
+}
+
+func AddBalance(
+	ctx context.Context,
+	mu state.Mutable,
+	addr codec.Address,
+	amount uint64,
+	create bool,
+) error {
+	key, bal, exists, err := getBalance(ctx, mu, addr)
+	if err != nil {
+		return err
+	}
+	// Don't add balance if account doesn't exist. This
+	// can be useful when processing fee refunds.
+	if !exists && !create {
+		return nil
+	}
+	nbal, err := smath.Add64(bal, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not add balance (bal=%d, addr=%v, amount=%d)",
+			ErrInvalidBalance,
+			bal,
+			sconsts.AddressFormat.Encode(addr),
+			amount,
+		)
+	}
+	return setBalance(ctx, mu, key, nbal)
+}
+
+func SubBalance(
+	ctx context.Context,
+	mu state.Mutable,
+	addr codec.Address,
+	amount uint64,
+) error {
+	key, bal, _, err := getBalance(ctx, mu, addr)
+	if err != nil {
+		return err
+	}
+	nbal, err := smath.Sub(bal, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not subtract balance (bal=%d, addr=%v, amount=%d)",
+			ErrInvalidBalance,
+			bal,
+			sconsts.AddressFormat.Encode(addr),
+			amount,
+		)
+	}
+	if nbal == 0 {
+		// If there is no balance left, we should delete the record instead of
+		// setting it to 0.
+		return mu.Remove(ctx, key)
+	}
+	return setBalance(ctx, mu, key, nbal)
+}
+
+func HeightKey() (k []byte) {
+	return heightKey
+}
+
+func TimestampKey() (k []byte) {
+	return timestampKey
+}
+
+func FeeKey() (k []byte) {
+	return feeKey
+}
+
+// ReservedPrefixes are the prefixes of the keys that actions can't touch (the chain metadata).
+func ReservedPrefixes() [][]byte {
+	return [][]byte{{heightPrefix}, {timestampPrefix}, {feePrefix}}
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ chain.Action = (*AddLiquidity)(nil)
+
+type AddLiquidity struct {
+	// [AssetA] and [AssetB] identify the pool to deposit into.
+	AssetA ids.ID `json:"assetA"`
+	AssetB ids.ID `json:"assetB"`
+
+	// [MaxA] and [MaxB] are the most of each asset the actor is willing to
+	// deposit. Only the amounts that match the current ratio of the reserves
+	// are taken.
+	MaxA uint64 `json:"maxA"`
+	MaxB uint64 `json:"maxB"`
+
+	// [MinShares] is the fewest shares the actor is willing to receive.
+	MinShares uint64 `json:"minShares"`
+}
+
+func (*AddLiquidity) GetTypeID() uint8 {
+	return addLiquidityID
+}
+
+func (a *AddLiquidity) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	keys := state.Keys{
+		string(storage.BalanceKey(actor, a.AssetA)):          state.Read | state.Write,
+		string(storage.BalanceKey(actor, a.AssetB)):          state.Read | state.Write,
+		string(storage.PoolKey(a.AssetA, a.AssetB)):          state.Read | state.Write,
+		string(storage.SharesKey(actor, a.AssetA, a.AssetB)): state.All,
+	}
+	addControlKeys(keys, a.AssetA, actor)
+	return addControlKeys(keys, a.AssetB, actor)
+}
+
+func (a *AddLiquidity) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{storage.BalanceChunks, storage.BalanceChunks, storage.PoolChunks, storage.SharesChunks}
+	chunks = append(chunks, controlKeysMaxChunks(a.AssetA, 1)...)
+	return append(chunks, controlKeysMaxChunks(a.AssetB, 1)...)
+}
+
+func (a *AddLiquidity) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if err := checkPoolAssets(a.AssetA, a.AssetB); err != nil {
+		return nil, err
+	}
+	if a.MaxA == 0 || a.MaxB == 0 {
+		return nil, ErrOutputValueZero
+	}
+	if err := checkControls(ctx, mu, a.AssetA, actor); err != nil {
+		return nil, err
+	}
+	if err := checkControls(ctx, mu, a.AssetB, actor); err != nil {
+		return nil, err
+	}
+	exists, reserveA, reserveB, totalShares, err := storage.GetPool(ctx, mu, a.AssetA, a.AssetB)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputPoolMissing
+	}
+
+	// Deposit as much as possible at the current ratio of the reserves
+	amountA, amountB := a.MaxA, a.MaxB
+	optimalB, err := mulDiv(a.MaxA, reserveB, reserveA)
+	if err != nil {
+		return nil, err
+	}
+	if optimalB <= a.MaxB {
+		amountB = optimalB
+	} else {
+		amountA, err = mulDiv(a.MaxB, reserveA, reserveB)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sharesA, err := mulDiv(amountA, totalShares, reserveA)
+	if err != nil {
+		return nil, err
+	}
+	sharesB, err := mulDiv(amountB, totalShares, reserveB)
+	if err != nil {
+		return nil, err
+	}
+	shares := min(sharesA, sharesB)
+	if shares == 0 {
+		return nil, ErrOutputSharesZero
+	}
+	if shares < a.MinShares {
+		return nil, ErrOutputSlippage
+	}
+
+	if err := storage.SubBalance(ctx, mu, actor, a.AssetA, amountA); err != nil {
+		return nil, err
+	}
+	if err := storage.SubBalance(ctx, mu, actor, a.AssetB, amountB); err != nil {
+		return nil, err
+	}
+	nreserveA, err := smath.Add64(reserveA, amountA)
+	if err != nil {
+		return nil, err
+	}
+	nreserveB, err := smath.Add64(reserveB, amountB)
+	if err != nil {
+		return nil, err
+	}
+	ntotalShares, err := smath.Add64(totalShares, shares)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetPool(ctx, mu, a.AssetA, a.AssetB, nreserveA, nreserveB, ntotalShares); err != nil {
+		return nil, err
+	}
+	if err := storage.AddShares(ctx, mu, actor, a.AssetA, a.AssetB, shares); err != nil {
+		return nil, err
+	}
+	lr := &LiquidityResult{AmountA: amountA, AmountB: amountB, Shares: shares}
+	output, err := lr.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{output}, nil
+}
+
+func (*AddLiquidity) ComputeUnits(chain.Rules) uint64 {
+	return AddLiquidityComputeUnits
+}
+
+func (*AddLiquidity) Size() int {
+	return ids.IDLen*2 + consts.Uint64Len*3
+}
+
+func (a *AddLiquidity) Marshal(p *codec.Packer) {
+	p.PackID(a.AssetA)
+	p.PackID(a.AssetB)
+	p.PackUint64(a.MaxA)
+	p.PackUint64(a.MaxB)
+	p.PackUint64(a.MinShares)
+}
+
+func UnmarshalAddLiquidity(p *codec.Packer) (chain.Action, error) {
+	var add AddLiquidity
+	p.UnpackID(false, &add.AssetA) // empty ID is the native asset
+	p.UnpackID(false, &add.AssetB) // empty ID is the native asset
+	add.MaxA = p.UnpackUint64(true)
+	add.MaxB = p.UnpackUint64(true)
+	add.MinShares = p.UnpackUint64(false)
+	return &add, p.Err()
+}
+
+func (*AddLiquidity) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// AirdropLeaf returns the leaf of an airdrop tree that allows [recipient] to
+// claim [amount] using the claim at [index].
+//
+// Each leaf must have a unique [index] within a tree because it is used to
+// mark the claim as paid.
+func AirdropLeaf(index uint64, recipient codec.Address, amount uint64) ids.ID {
+	b := make([]byte, 0, consts.Uint64Len+codec.AddressLen+consts.Uint64Len)
+	b = binary.BigEndian.AppendUint64(b, index)
+	b = append(b, recipient[:]...)
+	b = binary.BigEndian.AppendUint64(b, amount)
+	return utils.ToID(b)
+}
+
+// AirdropExpired returns true if an airdrop with [expiry] can no longer be
+// claimed (and can be reclaimed by its owner) at [timestamp].
+func AirdropExpired(expiry int64, timestamp int64) bool {
+	return timestamp > expiry
+}
+
+// hashAirdropNodes sorts the children of a node before hashing them so that a
+// proof does not need to encode which side each sibling is on.
+func hashAirdropNodes(a ids.ID, b ids.ID) ids.ID {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return utils.ToID(append(a[:], b[:]...))
+}
+
+// AirdropRoot returns the root of the tree containing [leaves]. A node without
+// a sibling is carried up to the next level unchanged.
+func AirdropRoot(leaves []ids.ID) ids.ID {
+	if len(leaves) == 0 {
+		return ids.Empty
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = nextAirdropLevel(level)
+	}
+	return level[0]
+}
+
+// AirdropProof returns the siblings needed to prove that the leaf at [index] of
+// [leaves] is included in [AirdropRoot].
+func AirdropProof(leaves []ids.ID, index int) []ids.ID {
+	proof := []ids.ID{}
+	level := leaves
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		level = nextAirdropLevel(level)
+		index /= 2
+	}
+	return proof
+}
+
+// VerifyAirdropProof returns true if [proof] shows that [leaf] is included in
+// the tree with [root].
+func VerifyAirdropProof(root ids.ID, leaf ids.ID, proof []ids.ID) bool {
+	node := leaf
+	for _, sibling := range proof {
+		node = hashAirdropNodes(node, sibling)
+	}
+	return node == root
+}
+
+func nextAirdropLevel(level []ids.ID) []ids.ID {
+	next := make([]ids.ID, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+			continue
+		}
+		next = append(next, hashAirdropNodes(level[i], level[i+1]))
+	}
+	return next
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.RecipientsAction = (*Approve)(nil)
+
+type Approve struct {
+	// Spender is allowed to move up to [Value] of [Asset] on behalf of the
+	// actor using [TransferFrom].
+	Spender codec.Address `json:"spender"`
+
+	// Asset that [Spender] is allowed to move.
+	Asset ids.ID `json:"asset"`
+
+	// Value replaces any existing allowance. Approving a [Value] of 0 revokes
+	// the allowance.
+	Value uint64 `json:"value"`
+}
+
+func (*Approve) GetTypeID() uint8 {
+	return approveID
+}
+
+func (a *Approve) Recipients() []codec.Address {
+	return []codec.Address{a.Spender}
+}
+
+func (a *Approve) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AllowanceKey(actor, a.Spender, a.Asset)): state.All,
+	}
+}
+
+func (*Approve) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AllowanceChunks}
+}
+
+func (a *Approve) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if err := storage.SetAllowance(ctx, mu, actor, a.Spender, a.Asset, a.Value); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*Approve) ComputeUnits(chain.Rules) uint64 {
+	return ApproveComputeUnits
+}
+
+func (*Approve) Size() int {
+	return codec.AddressLen + ids.IDLen + consts.Uint64Len
+}
+
+func (a *Approve) Marshal(p *codec.Packer) {
+	p.PackAddress(a.Spender)
+	p.PackID(a.Asset)
+	p.PackUint64(a.Value)
+}
+
+func UnmarshalApprove(p *codec.Packer) (chain.Action, error) {
+	var approve Approve
+	p.UnpackAddress(&approve.Spender)
+	p.UnpackID(false, &approve.Asset) // empty ID is the native asset
+	approve.Value = p.UnpackUint64(false)
+	return &approve, p.Err()
+}
+
+func (*Approve) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
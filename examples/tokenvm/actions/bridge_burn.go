@@ -0,0 +1,148 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/bridge"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ chain.RecipientsAction = (*BridgeBurn)(nil)
+
+// BridgeBurn burns a wrapped asset minted by [BridgeMint] and sends the
+// underlying funds back to the chain they were locked on.
+type BridgeBurn struct {
+	// Asset is the wrapped asset to burn.
+	Asset ids.ID `json:"asset"`
+
+	// To is the recipient of the released funds on the chain the asset was
+	// bridged from.
+	To codec.Address `json:"to"`
+
+	Value uint64 `json:"value"`
+
+	// Fee is burned with [Value] and rewards whoever delivers the transfer on
+	// the chain the asset was bridged from.
+	Fee uint64 `json:"fee"`
+}
+
+func (*BridgeBurn) GetTypeID() uint8 {
+	return bridgeBurnID
+}
+
+func (b *BridgeBurn) Recipients() []codec.Address {
+	return []codec.Address{b.To}
+}
+
+func (b *BridgeBurn) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(b.Asset)):          state.Read | state.Write,
+		string(storage.BridgedKey(b.Asset)):        state.Read,
+		string(storage.BalanceKey(actor, b.Asset)): state.Read | state.Write,
+		string(storage.BurnedKey(b.Asset)):         state.All,
+	}
+}
+
+func (*BridgeBurn) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AssetChunks, storage.BridgedChunks, storage.BalanceChunks, storage.BurnedChunks}
+}
+
+// Execute returns the ID of the warp message that must be delivered to the
+// chain the asset was bridged from.
+func (b *BridgeBurn) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	actionID ids.ID,
+) ([][]byte, error) {
+	if b.Value == 0 {
+		return nil, ErrOutputValueZero
+	}
+	bridged, sourceChainID, originAsset, err := storage.GetBridged(ctx, mu, b.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if !bridged {
+		return nil, ErrOutputAssetNotBridged
+	}
+	total, err := smath.Add64(b.Value, b.Fee)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SubBalance(ctx, mu, actor, b.Asset, total); err != nil {
+		return nil, err
+	}
+	_, symbol, decimals, metadata, uri, supply, owner, err := storage.GetAsset(ctx, mu, b.Asset)
+	if err != nil {
+		return nil, err
+	}
+	newSupply, err := smath.Sub(supply, total)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetAsset(ctx, mu, b.Asset, symbol, decimals, metadata, uri, newSupply, owner); err != nil {
+		return nil, err
+	}
+	if err := storage.AddBurned(ctx, mu, b.Asset, total); err != nil {
+		return nil, err
+	}
+	transfer := &bridge.Transfer{
+		ActionID:           actionID,
+		DestinationChainID: sourceChainID,
+		Asset:              originAsset,
+		Symbol:             symbol,
+		Decimals:           decimals,
+		To:                 b.To,
+		Value:              b.Value,
+		Fee:                b.Fee,
+		Return:             true,
+	}
+	msg, err := chain.SendWarpMessage(ctx, transfer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	msgID := msg.ID()
+	return [][]byte{msgID[:]}, nil
+}
+
+func (*BridgeBurn) ComputeUnits(chain.Rules) uint64 {
+	return BridgeBurnComputeUnits
+}
+
+func (*BridgeBurn) Size() int {
+	return ids.IDLen + codec.AddressLen + consts.Uint64Len*2
+}
+
+func (b *BridgeBurn) Marshal(p *codec.Packer) {
+	p.PackID(b.Asset)
+	p.PackAddress(b.To)
+	p.PackUint64(b.Value)
+	p.PackUint64(b.Fee)
+}
+
+func UnmarshalBridgeBurn(p *codec.Packer) (chain.Action, error) {
+	var burn BridgeBurn
+	p.UnpackID(true, &burn.Asset)
+	p.UnpackAddress(&burn.To)
+	burn.Value = p.UnpackUint64(true)
+	burn.Fee = p.UnpackUint64(false)
+	return &burn, p.Err()
+}
+
+func (*BridgeBurn) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/bridge"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ chain.WarpAction       = (*BridgeMint)(nil)
+	_ chain.RecipientsAction = (*BridgeMint)(nil)
+)
+
+// BridgeMint delivers a [bridge.Transfer] sent to this chain and mints the
+// wrapped asset of the transferred asset to its recipient. Anyone (usually a
+// relayer) can deliver it and receives the [bridge.Transfer.Fee] of the
+// transfer (in the wrapped asset).
+type BridgeMint struct {
+	// Message is the signed transfer sent by the chain the asset was locked
+	// on.
+	Message *warp.Message `json:"message"`
+}
+
+func (*BridgeMint) GetTypeID() uint8 {
+	return bridgeMintID
+}
+
+func (b *BridgeMint) WarpMessage() *warp.Message {
+	return b.Message
+}
+
+func (b *BridgeMint) Recipients() []codec.Address {
+	transfer, err := bridge.UnmarshalTransfer(b.Message.Payload)
+	if err != nil {
+		return nil
+	}
+	return []codec.Address{transfer.To}
+}
+
+func (b *BridgeMint) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	// Invalid payloads are rejected by [Execute]
+	transfer, err := bridge.UnmarshalTransfer(b.Message.Payload)
+	if err != nil {
+		return state.Keys{}
+	}
+	asset := bridge.WrappedAssetID(b.Message.SourceChainID, transfer.Asset)
+	return state.Keys{
+		string(storage.AssetKey(asset)):                state.All,
+		string(storage.BridgedKey(asset)):              state.All,
+		string(storage.BalanceKey(transfer.To, asset)): state.All,
+		string(storage.BalanceKey(actor, asset)):       state.All,
+	}
+}
+
+func (*BridgeMint) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AssetChunks, storage.BridgedChunks, storage.BalanceChunks, storage.BalanceChunks, storage.WarpMessageChunks}
+}
+
+// Execute returns the ID of the wrapped asset.
+func (b *BridgeMint) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	transfer, err := bridge.ParseTransfer(&b.Message.UnsignedMessage, r.ChainID())
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Return {
+		return nil, ErrOutputReturnNotSupported
+	}
+	asset := bridge.WrappedAssetID(b.Message.SourceChainID, transfer.Asset)
+	exists, symbol, decimals, metadata, uri, supply, owner, err := storage.GetAsset(ctx, mu, asset)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		// Nobody can mint a wrapped asset directly because it has no owner
+		symbol = transfer.Symbol
+		decimals = transfer.Decimals
+		if decimals > MaxDecimals {
+			return nil, ErrOutputDecimalsTooLarge
+		}
+		metadata = []byte(b.Message.SourceChainID.String())
+		owner = codec.EmptyAddress
+		if err := storage.SetBridged(ctx, mu, asset, b.Message.SourceChainID, transfer.Asset); err != nil {
+			return nil, err
+		}
+	}
+	total, err := transfer.Total()
+	if err != nil {
+		return nil, err
+	}
+	newSupply, err := smath.Add64(supply, total)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetAsset(ctx, mu, asset, symbol, decimals, metadata, uri, newSupply, owner); err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, transfer.To, asset, transfer.Value, true); err != nil {
+		return nil, err
+	}
+	if transfer.Fee > 0 {
+		if err := storage.AddBalance(ctx, mu, actor, asset, transfer.Fee, true); err != nil {
+			return nil, err
+		}
+	}
+	return [][]byte{asset[:]}, nil
+}
+
+func (*BridgeMint) ComputeUnits(chain.Rules) uint64 {
+	return BridgeMintComputeUnits
+}
+
+func (b *BridgeMint) Size() int {
+	return codec.BytesLen(b.Message.Bytes())
+}
+
+func (b *BridgeMint) Marshal(p *codec.Packer) {
+	p.PackBytes(b.Message.Bytes())
+}
+
+func UnmarshalBridgeMint(p *codec.Packer) (chain.Action, error) {
+	var msgBytes []byte
+	p.UnpackBytes(MaxWarpMessageSize, true, &msgBytes)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	msg, err := warp.ParseMessage(msgBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeMint{Message: msg}, nil
+}
+
+func (*BridgeMint) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -23,7 +23,7 @@ type BurnAsset struct {
 	// Asset is the [ActionID] that created the asset.
 	Asset ids.ID `json:"asset"`
 
-	// Number of assets to mint to [To].
+	// Number of assets to burn from the actor.
 	Value uint64 `json:"value"`
 }
 
@@ -35,11 +35,12 @@ func (b *BurnAsset) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
 	return state.Keys{
 		string(storage.AssetKey(b.Asset)):          state.Read | state.Write,
 		string(storage.BalanceKey(actor, b.Asset)): state.Read | state.Write,
+		string(storage.BurnedKey(b.Asset)):         state.All,
 	}
 }
 
 func (*BurnAsset) StateKeysMaxChunks() []uint16 {
-	return []uint16{storage.AssetChunks, storage.BalanceChunks}
+	return []uint16{storage.AssetChunks, storage.BalanceChunks, storage.BurnedChunks}
 }
 
 func (b *BurnAsset) Execute(
@@ -56,7 +57,7 @@ func (b *BurnAsset) Execute(
 	if err := storage.SubBalance(ctx, mu, actor, b.Asset, b.Value); err != nil {
 		return nil, err
 	}
-	exists, symbol, decimals, metadata, supply, owner, err := storage.GetAsset(ctx, mu, b.Asset)
+	exists, symbol, decimals, metadata, uri, supply, owner, err := storage.GetAsset(ctx, mu, b.Asset)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +68,10 @@ func (b *BurnAsset) Execute(
 	if err != nil {
 		return nil, err
 	}
-	if err := storage.SetAsset(ctx, mu, b.Asset, symbol, decimals, metadata, newSupply, owner); err != nil {
+	if err := storage.SetAsset(ctx, mu, b.Asset, symbol, decimals, metadata, uri, newSupply, owner); err != nil {
+		return nil, err
+	}
+	if err := storage.AddBurned(ctx, mu, b.Asset, b.Value); err != nil {
 		return nil, err
 	}
 	return nil, nil
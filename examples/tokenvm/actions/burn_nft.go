@@ -0,0 +1,106 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ chain.Action = (*BurnNFT)(nil)
+
+type BurnNFT struct {
+	// Collection is the [ActionID] that created the collection.
+	Collection ids.ID `json:"collection"`
+
+	// ID of the NFT in [Collection].
+	ID uint64 `json:"id"`
+}
+
+func (*BurnNFT) GetTypeID() uint8 {
+	return burnNFTID
+}
+
+func (b *BurnNFT) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.CollectionKey(b.Collection)): state.Read | state.Write,
+		string(storage.NFTKey(b.Collection, b.ID)):  state.Read | state.Write,
+	}
+}
+
+func (*BurnNFT) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.CollectionChunks, storage.NFTChunks}
+}
+
+func (b *BurnNFT) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, _, owner, err := storage.GetNFT(ctx, mu, b.Collection, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputNFTMissing
+	}
+	if owner != actor {
+		return nil, ErrOutputWrongOwner
+	}
+	exists, name, metadata, supply, collectionOwner, err := storage.GetCollection(ctx, mu, b.Collection)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputCollectionMissing
+	}
+	newSupply, err := smath.Sub(supply, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetCollection(ctx, mu, b.Collection, name, metadata, newSupply, collectionOwner); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteNFT(ctx, mu, b.Collection, b.ID); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*BurnNFT) ComputeUnits(chain.Rules) uint64 {
+	return BurnNFTComputeUnits
+}
+
+func (*BurnNFT) Size() int {
+	return ids.IDLen + consts.Uint64Len
+}
+
+func (b *BurnNFT) Marshal(p *codec.Packer) {
+	p.PackID(b.Collection)
+	p.PackUint64(b.ID)
+}
+
+func UnmarshalBurnNFT(p *codec.Packer) (chain.Action, error) {
+	var burn BurnNFT
+	p.UnpackID(true, &burn.Collection)
+	burn.ID = p.UnpackUint64(false)
+	return &burn, p.Err()
+}
+
+func (*BurnNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.RecipientsAction = (*CancelStream)(nil)
+
+// CancelStream deletes a stream. Anything released but unclaimed is paid to
+// the payee and the rest is refunded to the payer.
+type CancelStream struct {
+	// Stream is the ActionID that created the stream.
+	Stream ids.ID `json:"stream"`
+
+	// Asset and Payee of [Stream]. We need to provide these to populate
+	// [StateKeys].
+	Asset ids.ID        `json:"asset"`
+	Payee codec.Address `json:"payee"`
+}
+
+func (*CancelStream) GetTypeID() uint8 {
+	return cancelStreamID
+}
+
+func (c *CancelStream) Recipients() []codec.Address {
+	return []codec.Address{c.Payee}
+}
+
+func (c *CancelStream) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return addControlKeys(state.Keys{
+		string(storage.StreamKey(c.Stream)):          state.Read | state.Write,
+		string(storage.BalanceKey(actor, c.Asset)):   state.All,
+		string(storage.BalanceKey(c.Payee, c.Asset)): state.All,
+	}, c.Asset, actor, c.Payee)
+}
+
+func (c *CancelStream) StateKeysMaxChunks() []uint16 {
+	return append(
+		[]uint16{storage.StreamChunks, storage.BalanceChunks, storage.BalanceChunks},
+		controlKeysMaxChunks(c.Asset, 2)...,
+	)
+}
+
+func (c *CancelStream) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, stream, err := storage.GetStream(ctx, mu, c.Stream)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputStreamMissing
+	}
+	if stream.Payer != actor {
+		return nil, ErrOutputUnauthorized
+	}
+	if stream.Asset != c.Asset {
+		return nil, ErrOutputWrongAsset
+	}
+	if stream.Payee != c.Payee {
+		return nil, ErrOutputWrongDestination
+	}
+	if err := checkControls(ctx, mu, c.Asset, actor, c.Payee); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteStream(ctx, mu, c.Stream); err != nil {
+		return nil, err
+	}
+
+	// The payee keeps everything released before the cancellation.
+	sr := &StreamResult{
+		Paid:     StreamClaimable(stream, timestamp),
+		Refunded: stream.Total - StreamVested(stream, timestamp),
+	}
+	if sr.Paid > 0 {
+		if err := storage.AddBalance(ctx, mu, c.Payee, c.Asset, sr.Paid, true); err != nil {
+			return nil, err
+		}
+	}
+	if sr.Refunded > 0 {
+		if err := storage.AddBalance(ctx, mu, actor, c.Asset, sr.Refunded, true); err != nil {
+			return nil, err
+		}
+	}
+	output, err := sr.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{output}, nil
+}
+
+func (*CancelStream) ComputeUnits(chain.Rules) uint64 {
+	return CancelStreamComputeUnits
+}
+
+func (*CancelStream) Size() int {
+	return ids.IDLen*2 + codec.AddressLen
+}
+
+func (c *CancelStream) Marshal(p *codec.Packer) {
+	p.PackID(c.Stream)
+	p.PackID(c.Asset)
+	p.PackAddress(c.Payee)
+}
+
+func UnmarshalCancelStream(p *codec.Packer) (chain.Action, error) {
+	var cancel CancelStream
+	p.UnpackID(true, &cancel.Stream)
+	p.UnpackID(false, &cancel.Asset) // empty ID is the native asset
+	p.UnpackAddress(&cancel.Payee)
+	return &cancel, p.Err()
+}
+
+func (*CancelStream) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*ClaimAirdrop)(nil)
+
+type ClaimAirdrop struct {
+	// Airdrop is the ActionID that created the airdrop.
+	Airdrop ids.ID `json:"airdrop"`
+
+	// Asset is the asset of [Airdrop]. We need to provide this to populate
+	// [StateKeys].
+	Asset ids.ID `json:"asset"`
+
+	// Index and Amount are the contents of the [AirdropLeaf] of the actor.
+	Index  uint64 `json:"index"`
+	Amount uint64 `json:"amount"`
+
+	// Proof is the list of siblings from the leaf of the actor to the root.
+	Proof []ids.ID `json:"proof"`
+}
+
+func (*ClaimAirdrop) GetTypeID() uint8 {
+	return claimAirdropID
+}
+
+func (c *ClaimAirdrop) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return addControlKeys(state.Keys{
+		string(storage.AirdropKey(c.Airdrop)):               state.Read | state.Write,
+		string(storage.AirdropClaimKey(c.Airdrop, c.Index)): state.Read | state.Allocate | state.Write,
+		string(storage.BalanceKey(actor, c.Asset)):          state.All,
+	}, c.Asset, actor)
+}
+
+func (c *ClaimAirdrop) StateKeysMaxChunks() []uint16 {
+	return append(
+		[]uint16{storage.AirdropChunks, storage.AirdropClaimChunks, storage.BalanceChunks},
+		controlKeysMaxChunks(c.Asset, 1)...,
+	)
+}
+
+func (c *ClaimAirdrop) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, airdrop, err := storage.GetAirdrop(ctx, mu, c.Airdrop)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputAirdropMissing
+	}
+	if airdrop.Asset != c.Asset {
+		return nil, ErrOutputWrongAsset
+	}
+	if AirdropExpired(airdrop.Expiry, timestamp) {
+		return nil, ErrOutputAirdropExpired
+	}
+	claimed, err := storage.GetAirdropClaimed(ctx, mu, c.Airdrop, c.Index)
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, ErrOutputAlreadyClaimed
+	}
+	if !VerifyAirdropProof(airdrop.Root, AirdropLeaf(c.Index, actor, c.Amount), c.Proof) {
+		return nil, ErrOutputInvalidProof
+	}
+	if c.Amount > airdrop.Remaining {
+		// This can only happen if the escrow was funded with less than the sum
+		// of all claims in the tree.
+		return nil, ErrOutputAirdropExhausted
+	}
+	if err := checkControls(ctx, mu, c.Asset, actor); err != nil {
+		return nil, err
+	}
+	if err := storage.SetAirdropClaimed(ctx, mu, c.Airdrop, c.Index); err != nil {
+		return nil, err
+	}
+	airdrop.Remaining -= c.Amount
+	if airdrop.Remaining == 0 {
+		err = storage.DeleteAirdrop(ctx, mu, c.Airdrop)
+	} else {
+		err = storage.SetAirdrop(ctx, mu, c.Airdrop, airdrop)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, actor, c.Asset, c.Amount, true); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*ClaimAirdrop) ComputeUnits(chain.Rules) uint64 {
+	return ClaimAirdropComputeUnits
+}
+
+func (c *ClaimAirdrop) Size() int {
+	return ids.IDLen*2 + consts.Uint64Len*2 + consts.IntLen + ids.IDLen*len(c.Proof)
+}
+
+func (c *ClaimAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(c.Airdrop)
+	p.PackID(c.Asset)
+	p.PackUint64(c.Index)
+	p.PackUint64(c.Amount)
+	p.PackInt(len(c.Proof))
+	for _, sibling := range c.Proof {
+		p.PackID(sibling)
+	}
+}
+
+func UnmarshalClaimAirdrop(p *codec.Packer) (chain.Action, error) {
+	var claim ClaimAirdrop
+	p.UnpackID(true, &claim.Airdrop)
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	claim.Index = p.UnpackUint64(false)
+	claim.Amount = p.UnpackUint64(true)
+	siblings := p.UnpackInt(false) // a tree with one leaf has an empty proof
+	if siblings > MaxAirdropProofLength {
+		return nil, ErrOutputProofTooLarge
+	}
+	claim.Proof = make([]ids.ID, siblings)
+	for i := range claim.Proof {
+		p.UnpackID(true, &claim.Proof[i])
+	}
+	return &claim, p.Err()
+}
+
+func (*ClaimAirdrop) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*ClaimStream)(nil)
+
+type ClaimStream struct {
+	// Stream is the ActionID that created the stream.
+	Stream ids.ID `json:"stream"`
+
+	// Asset is the asset of [Stream]. We need to provide this to populate
+	// [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (*ClaimStream) GetTypeID() uint8 {
+	return claimStreamID
+}
+
+func (c *ClaimStream) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return addControlKeys(state.Keys{
+		string(storage.StreamKey(c.Stream)):        state.Read | state.Write,
+		string(storage.BalanceKey(actor, c.Asset)): state.All,
+	}, c.Asset, actor)
+}
+
+func (c *ClaimStream) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{storage.StreamChunks, storage.BalanceChunks}, controlKeysMaxChunks(c.Asset, 1)...)
+}
+
+func (c *ClaimStream) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, stream, err := storage.GetStream(ctx, mu, c.Stream)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputStreamMissing
+	}
+	if stream.Payee != actor {
+		return nil, ErrOutputUnauthorized
+	}
+	if stream.Asset != c.Asset {
+		return nil, ErrOutputWrongAsset
+	}
+	claimable := StreamClaimable(stream, timestamp)
+	if claimable == 0 {
+		return nil, ErrOutputNothingToClaim
+	}
+	if err := checkControls(ctx, mu, c.Asset, actor); err != nil {
+		return nil, err
+	}
+	stream.Claimed += claimable
+	if stream.Claimed == stream.Total {
+		// If there is nothing left to release, we should delete the stream
+		// instead of storing it.
+		err = storage.DeleteStream(ctx, mu, c.Stream)
+	} else {
+		err = storage.SetStream(ctx, mu, c.Stream, stream)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, actor, c.Asset, claimable, true); err != nil {
+		return nil, err
+	}
+	sr := &StreamResult{Paid: claimable}
+	output, err := sr.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{output}, nil
+}
+
+func (*ClaimStream) ComputeUnits(chain.Rules) uint64 {
+	return ClaimStreamComputeUnits
+}
+
+func (*ClaimStream) Size() int {
+	return ids.IDLen * 2
+}
+
+func (c *ClaimStream) Marshal(p *codec.Packer) {
+	p.PackID(c.Stream)
+	p.PackID(c.Asset)
+}
+
+func UnmarshalClaimStream(p *codec.Packer) (chain.Action, error) {
+	var claim ClaimStream
+	p.UnpackID(true, &claim.Stream)
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	return &claim, p.Err()
+}
+
+func (*ClaimStream) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
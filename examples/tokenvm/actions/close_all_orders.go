@@ -0,0 +1,142 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*CloseAllOrders)(nil)
+
+type CloseAllOrders struct {
+	// [Orders] are the OrderIDs you wish to close.
+	//
+	// Orders that no longer exist (because they were filled or closed since
+	// the transaction was created) are skipped.
+	Orders []ids.ID `json:"orders"`
+
+	// [Out] are the assets locked up in each of [Orders]. We need to provide
+	// these to populate [StateKeys].
+	Out []ids.ID `json:"out"`
+}
+
+func (*CloseAllOrders) GetTypeID() uint8 {
+	return closeAllOrdersID
+}
+
+func (c *CloseAllOrders) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	keys := make(state.Keys, len(c.Orders)+len(c.Out))
+	for _, order := range c.Orders {
+		keys.Add(string(storage.OrderKey(order)), state.Read|state.Write)
+	}
+	for _, out := range c.Out {
+		keys.Add(string(storage.BalanceKey(actor, out)), state.Read|state.Write)
+	}
+	return keys
+}
+
+func (c *CloseAllOrders) StateKeysMaxChunks() []uint16 {
+	chunks := make([]uint16, 0, len(c.Orders)+len(c.Out))
+	for range c.Orders {
+		chunks = append(chunks, storage.OrderChunks)
+	}
+	for range c.Out {
+		chunks = append(chunks, storage.BalanceChunks)
+	}
+	return chunks
+}
+
+func (c *CloseAllOrders) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if len(c.Orders) == 0 {
+		return nil, ErrOutputNoOrders
+	}
+	if len(c.Orders) > MaxCloseAllOrders {
+		return nil, ErrOutputTooManyOrders
+	}
+	if len(c.Orders) != len(c.Out) {
+		return nil, ErrOutputOrdersMisaligned
+	}
+	for i, order := range c.Orders {
+		exists, _, _, out, _, remaining, owner, _, err := storage.GetOrder(ctx, mu, order)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		if owner != actor {
+			return nil, ErrOutputUnauthorized
+		}
+		if out != c.Out[i] {
+			return nil, ErrOutputWrongOut
+		}
+		if err := storage.DeleteOrder(ctx, mu, order); err != nil {
+			return nil, err
+		}
+		if err := storage.AddBalance(ctx, mu, actor, out, remaining, true); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (c *CloseAllOrders) ComputeUnits(chain.Rules) uint64 {
+	return CloseOrderComputeUnits * uint64(len(c.Orders))
+}
+
+func (c *CloseAllOrders) Size() int {
+	return consts.IntLen*2 + ids.IDLen*(len(c.Orders)+len(c.Out))
+}
+
+func (c *CloseAllOrders) Marshal(p *codec.Packer) {
+	p.PackInt(len(c.Orders))
+	for _, order := range c.Orders {
+		p.PackID(order)
+	}
+	p.PackInt(len(c.Out))
+	for _, out := range c.Out {
+		p.PackID(out)
+	}
+}
+
+func UnmarshalCloseAllOrders(p *codec.Packer) (chain.Action, error) {
+	var cl CloseAllOrders
+	orders := p.UnpackInt(true)
+	if orders > MaxCloseAllOrders {
+		return nil, ErrOutputTooManyOrders
+	}
+	cl.Orders = make([]ids.ID, orders)
+	for i := range cl.Orders {
+		p.UnpackID(true, &cl.Orders[i])
+	}
+	outs := p.UnpackInt(true)
+	if outs != orders {
+		return nil, ErrOutputOrdersMisaligned
+	}
+	cl.Out = make([]ids.ID, outs)
+	for i := range cl.Out {
+		p.UnpackID(false, &cl.Out[i]) // empty ID is the native asset
+	}
+	return &cl, p.Err()
+}
+
+func (*CloseAllOrders) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
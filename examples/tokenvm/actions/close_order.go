@@ -48,7 +48,7 @@ func (c *CloseOrder) Execute(
 	actor codec.Address,
 	_ ids.ID,
 ) ([][]byte, error) {
-	exists, _, _, out, _, remaining, owner, err := storage.GetOrder(ctx, mu, c.Order)
+	exists, _, _, out, _, remaining, owner, _, err := storage.GetOrder(ctx, mu, c.Order)
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,40 @@ const (
 	burnAssetID   uint8 = 0
 	closeOrderID  uint8 = 1
 	createAssetID uint8 = 2
-	exportAssetID uint8 = 3
-	importAssetID uint8 = 4
+	bridgeBurnID  uint8 = 3
+	bridgeMintID  uint8 = 4
 	createOrderID uint8 = 5
 	fillOrderID   uint8 = 6
 	mintAssetID   uint8 = 7
 	transferID    uint8 = 8
+
+	createCollectionID uint8 = 9
+	mintNFTID          uint8 = 10
+	transferNFTID      uint8 = 11
+	burnNFTID          uint8 = 12
+
+	closeAllOrdersID uint8 = 13
+
+	createPoolID      uint8 = 14
+	addLiquidityID    uint8 = 15
+	removeLiquidityID uint8 = 16
+	swapID            uint8 = 17
+
+	freezeAccountID uint8 = 18
+	pauseAssetID    uint8 = 19
+
+	approveID      uint8 = 20
+	transferFromID uint8 = 21
+
+	transferManyID uint8 = 22
+
+	createStreamID uint8 = 23
+	claimStreamID  uint8 = 24
+	cancelStreamID uint8 = 25
+
+	createAirdropID  uint8 = 26
+	claimAirdropID   uint8 = 27
+	reclaimAirdropID uint8 = 28
 )
 
 const (
@@ -26,8 +54,57 @@ const (
 	MintAssetComputeUnits   = 2
 	TransferComputeUnits    = 1
 
+	CreateCollectionComputeUnits = 10
+	MintNFTComputeUnits          = 5
+	TransferNFTComputeUnits      = 1
+	BurnNFTComputeUnits          = 2
+
+	CreatePoolComputeUnits      = 10
+	AddLiquidityComputeUnits    = 5
+	RemoveLiquidityComputeUnits = 5
+	SwapComputeUnits            = 5
+
+	FreezeAccountComputeUnits = 2
+	PauseAssetComputeUnits    = 2
+
+	ApproveComputeUnits      = 1
+	TransferFromComputeUnits = 2
+
+	CreateStreamComputeUnits = 5
+	ClaimStreamComputeUnits  = 2
+	CancelStreamComputeUnits = 2
+
+	CreateAirdropComputeUnits  = 5
+	ClaimAirdropComputeUnits   = 5
+	ReclaimAirdropComputeUnits = 2
+
+	BridgeBurnComputeUnits = 2
+	// Mints verify the aggregate signature of a warp message
+	BridgeMintComputeUnits = 10
+
 	MaxSymbolSize   = 8
 	MaxMemoSize     = 256
 	MaxMetadataSize = 256
+	MaxURISize      = 256
 	MaxDecimals     = 9
+
+	MaxCollectionNameSize = 32
+
+	// Royalties are denominated in basis points of the proceeds of a
+	// [FillOrder].
+	MaxRoyaltyBasisPoints = 10_000
+
+	MaxCloseAllOrders = 16
+
+	MaxTransferManyRecipients = 32
+
+	// Proofs of this length can be produced for trees with up to 2^32 leaves.
+	MaxAirdropProofLength = 32
+
+	MaxWarpMessageSize = 2048
+
+	// A fee of [SwapFeeNumerator]/[SwapFeeDenominator] of the input to a
+	// [Swap] is retained by the pool.
+	SwapFeeNumerator   = 3
+	SwapFeeDenominator = 1000
 )
@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// The native asset has no owner, so it can never be paused or frozen and its
+// controls are never read.
+
+// addControlKeys adds the keys read by [checkControls] to [keys].
+func addControlKeys(keys state.Keys, asset ids.ID, accounts ...codec.Address) state.Keys {
+	if asset == ids.Empty {
+		return keys
+	}
+	keys.Add(string(storage.PausedKey(asset)), state.Read)
+	for _, account := range accounts {
+		keys.Add(string(storage.FrozenKey(asset, account)), state.Read)
+	}
+	return keys
+}
+
+// controlKeysMaxChunks returns the chunks of the keys added by
+// [addControlKeys].
+func controlKeysMaxChunks(asset ids.ID, accounts int) []uint16 {
+	if asset == ids.Empty {
+		return nil
+	}
+	chunks := make([]uint16, 0, 1+accounts)
+	chunks = append(chunks, storage.PausedChunks)
+	for i := 0; i < accounts; i++ {
+		chunks = append(chunks, storage.FrozenChunks)
+	}
+	return chunks
+}
+
+// checkControls returns an error if [asset] is paused or if any of
+// [accounts] are frozen.
+func checkControls(ctx context.Context, im state.Immutable, asset ids.ID, accounts ...codec.Address) error {
+	if asset == ids.Empty {
+		return nil
+	}
+	paused, err := storage.GetPaused(ctx, im, asset)
+	if err != nil {
+		return err
+	}
+	if paused {
+		return ErrOutputAssetPaused
+	}
+	for _, account := range accounts {
+		frozen, err := storage.GetFrozen(ctx, im, asset, account)
+		if err != nil {
+			return err
+		}
+		if frozen {
+			return ErrOutputAccountFrozen
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*CreateAirdrop)(nil)
+
+type CreateAirdrop struct {
+	// Asset that is distributed by the airdrop.
+	Asset ids.ID `json:"asset"`
+
+	// Root of the tree of [AirdropLeaf]s that can be claimed.
+	Root ids.ID `json:"root"`
+
+	// Total is deducted from the actor and escrowed until it is claimed. It
+	// should be the sum of all claims in the tree.
+	Total uint64 `json:"total"`
+
+	// Expiry is the timestamp (in milliseconds) after which claims are no
+	// longer accepted and the actor can reclaim whatever remains with
+	// [ReclaimAirdrop].
+	Expiry int64 `json:"expiry"`
+}
+
+func (*CreateAirdrop) GetTypeID() uint8 {
+	return createAirdropID
+}
+
+func (c *CreateAirdrop) StateKeys(actor codec.Address, actionID ids.ID) state.Keys {
+	return addControlKeys(state.Keys{
+		string(storage.BalanceKey(actor, c.Asset)): state.Read | state.Write,
+		string(storage.AirdropKey(actionID)):       state.Allocate | state.Write,
+	}, c.Asset, actor)
+}
+
+func (c *CreateAirdrop) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{storage.BalanceChunks, storage.AirdropChunks}, controlKeysMaxChunks(c.Asset, 1)...)
+}
+
+func (c *CreateAirdrop) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	actionID ids.ID,
+) ([][]byte, error) {
+	if c.Total == 0 {
+		return nil, ErrOutputValueZero
+	}
+	if c.Expiry <= timestamp {
+		return nil, ErrOutputExpiryInPast
+	}
+	if err := checkControls(ctx, mu, c.Asset, actor); err != nil {
+		return nil, err
+	}
+	if err := storage.SubBalance(ctx, mu, actor, c.Asset, c.Total); err != nil {
+		return nil, err
+	}
+	if err := storage.SetAirdrop(ctx, mu, actionID, &storage.Airdrop{
+		Asset:     c.Asset,
+		Root:      c.Root,
+		Owner:     actor,
+		Remaining: c.Total,
+		Expiry:    c.Expiry,
+	}); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*CreateAirdrop) ComputeUnits(chain.Rules) uint64 {
+	return CreateAirdropComputeUnits
+}
+
+func (*CreateAirdrop) Size() int {
+	return ids.IDLen*2 + consts.Uint64Len + consts.Int64Len
+}
+
+func (c *CreateAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackID(c.Root)
+	p.PackUint64(c.Total)
+	p.PackInt64(c.Expiry)
+}
+
+func UnmarshalCreateAirdrop(p *codec.Packer) (chain.Action, error) {
+	var create CreateAirdrop
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	p.UnpackID(true, &create.Root)
+	create.Total = p.UnpackUint64(true)
+	create.Expiry = p.UnpackInt64(true)
+	return &create, p.Err()
+}
+
+func (*CreateAirdrop) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -21,19 +21,35 @@ type CreateAsset struct {
 	Symbol   []byte `json:"symbol"`
 	Decimals uint8  `json:"decimals"`
 	Metadata []byte `json:"metadata"`
+
+	// URI optionally points to off-chain metadata about the asset.
+	URI []byte `json:"uri"`
+
+	// RoyaltyBasisPoints of the proceeds from every [FillOrder] or [Swap]
+	// that sells the asset are paid to [RoyaltyRecipient] instead of the
+	// seller (or pool). [RoyaltyRecipient] must be set if there is a royalty.
+	RoyaltyBasisPoints uint16        `json:"royaltyBasisPoints"`
+	RoyaltyRecipient   codec.Address `json:"royaltyRecipient"`
 }
 
 func (*CreateAsset) GetTypeID() uint8 {
 	return createAssetID
 }
 
-func (*CreateAsset) StateKeys(_ codec.Address, actionID ids.ID) state.Keys {
-	return state.Keys{
+func (c *CreateAsset) StateKeys(_ codec.Address, actionID ids.ID) state.Keys {
+	keys := state.Keys{
 		string(storage.AssetKey(actionID)): state.Allocate | state.Write,
 	}
+	if c.RoyaltyBasisPoints > 0 {
+		keys[string(storage.RoyaltyKey(actionID))] = state.Allocate | state.Write
+	}
+	return keys
 }
 
-func (*CreateAsset) StateKeysMaxChunks() []uint16 {
+func (c *CreateAsset) StateKeysMaxChunks() []uint16 {
+	if c.RoyaltyBasisPoints > 0 {
+		return []uint16{storage.AssetChunks, storage.RoyaltyChunks}
+	}
 	return []uint16{storage.AssetChunks}
 }
 
@@ -60,11 +76,25 @@ func (c *CreateAsset) Execute(
 	if len(c.Metadata) > MaxMetadataSize {
 		return nil, ErrOutputMetadataTooLarge
 	}
+	if len(c.URI) > MaxURISize {
+		return nil, ErrOutputURITooLarge
+	}
+	if c.RoyaltyBasisPoints > MaxRoyaltyBasisPoints {
+		return nil, ErrOutputRoyaltyTooLarge
+	}
+	if c.RoyaltyBasisPoints > 0 && c.RoyaltyRecipient == codec.EmptyAddress {
+		return nil, ErrOutputRoyaltyRecipient
+	}
 	// It should only be possible to overwrite an existing asset if there is
 	// a hash collision.
-	if err := storage.SetAsset(ctx, mu, actionID, c.Symbol, c.Decimals, c.Metadata, 0, actor); err != nil {
+	if err := storage.SetAsset(ctx, mu, actionID, c.Symbol, c.Decimals, c.Metadata, c.URI, 0, actor); err != nil {
 		return nil, err
 	}
+	if c.RoyaltyBasisPoints > 0 {
+		if err := storage.SetRoyalty(ctx, mu, actionID, c.RoyaltyBasisPoints, c.RoyaltyRecipient); err != nil {
+			return nil, err
+		}
+	}
 	return nil, nil
 }
 
@@ -74,13 +104,22 @@ func (*CreateAsset) ComputeUnits(chain.Rules) uint64 {
 
 func (c *CreateAsset) Size() int {
 	// TODO: add small bytes (smaller int prefix)
-	return codec.BytesLen(c.Symbol) + consts.Uint8Len + codec.BytesLen(c.Metadata)
+	size := codec.BytesLen(c.Symbol) + consts.Uint8Len + codec.BytesLen(c.Metadata) + codec.BytesLen(c.URI) + consts.IntLen
+	if c.RoyaltyBasisPoints > 0 {
+		size += codec.AddressLen
+	}
+	return size
 }
 
 func (c *CreateAsset) Marshal(p *codec.Packer) {
 	p.PackBytes(c.Symbol)
 	p.PackByte(c.Decimals)
 	p.PackBytes(c.Metadata)
+	p.PackBytes(c.URI)
+	p.PackInt(int(c.RoyaltyBasisPoints))
+	if c.RoyaltyBasisPoints > 0 {
+		p.PackAddress(c.RoyaltyRecipient)
+	}
 }
 
 func UnmarshalCreateAsset(p *codec.Packer) (chain.Action, error) {
@@ -88,6 +127,18 @@ func UnmarshalCreateAsset(p *codec.Packer) (chain.Action, error) {
 	p.UnpackBytes(MaxSymbolSize, true, &create.Symbol)
 	create.Decimals = p.UnpackByte()
 	p.UnpackBytes(MaxMetadataSize, true, &create.Metadata)
+	p.UnpackBytes(MaxURISize, false, &create.URI)
+	royaltyBasisPoints := p.UnpackInt(false)
+	if royaltyBasisPoints > MaxRoyaltyBasisPoints {
+		return nil, ErrOutputRoyaltyTooLarge
+	}
+	create.RoyaltyBasisPoints = uint16(royaltyBasisPoints)
+	if create.RoyaltyBasisPoints > 0 {
+		p.UnpackAddress(&create.RoyaltyRecipient)
+		if create.RoyaltyRecipient == codec.EmptyAddress {
+			return nil, ErrOutputRoyaltyRecipient
+		}
+	}
 	return &create, p.Err()
 }
 
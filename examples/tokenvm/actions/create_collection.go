@@ -0,0 +1,89 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*CreateCollection)(nil)
+
+type CreateCollection struct {
+	Name     []byte `json:"name"`
+	Metadata []byte `json:"metadata"`
+}
+
+func (*CreateCollection) GetTypeID() uint8 {
+	return createCollectionID
+}
+
+func (*CreateCollection) StateKeys(_ codec.Address, actionID ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.CollectionKey(actionID)): state.Allocate | state.Write,
+	}
+}
+
+func (*CreateCollection) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.CollectionChunks}
+}
+
+func (c *CreateCollection) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	actionID ids.ID,
+) ([][]byte, error) {
+	if len(c.Name) == 0 {
+		return nil, ErrOutputNameEmpty
+	}
+	if len(c.Name) > MaxCollectionNameSize {
+		return nil, ErrOutputNameTooLarge
+	}
+	if len(c.Metadata) == 0 {
+		return nil, ErrOutputMetadataEmpty
+	}
+	if len(c.Metadata) > MaxMetadataSize {
+		return nil, ErrOutputMetadataTooLarge
+	}
+	// It should only be possible to overwrite an existing collection if there is
+	// a hash collision.
+	if err := storage.SetCollection(ctx, mu, actionID, c.Name, c.Metadata, 0, actor); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*CreateCollection) ComputeUnits(chain.Rules) uint64 {
+	return CreateCollectionComputeUnits
+}
+
+func (c *CreateCollection) Size() int {
+	return codec.BytesLen(c.Name) + codec.BytesLen(c.Metadata)
+}
+
+func (c *CreateCollection) Marshal(p *codec.Packer) {
+	p.PackBytes(c.Name)
+	p.PackBytes(c.Metadata)
+}
+
+func UnmarshalCreateCollection(p *codec.Packer) (chain.Action, error) {
+	var create CreateCollection
+	p.UnpackBytes(MaxCollectionNameSize, true, &create.Name)
+	p.UnpackBytes(MaxMetadataSize, true, &create.Metadata)
+	return &create, p.Err()
+}
+
+func (*CreateCollection) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -38,12 +38,18 @@ type CreateOrder struct {
 	// [Supply] is the initial amount of [In] that the actor is locking up.
 	Supply uint64 `json:"supply"`
 
+	// [Expiry] is the timestamp (in milliseconds) after which the order can no
+	// longer be filled. If 0, the order never expires.
+	Expiry int64 `json:"expiry"`
+
 	// Notes:
 	// * Users are allowed to have any number of orders for the same [In]-[Out] pair.
 	// * Using [InTick] and [OutTick] blocks ensures we avoid any odd rounding
 	//	 errors.
 	// * Users can fill orders with any multiple of [InTick] and will get
 	//   refunded any unused assets.
+	// * Expired orders are not removed from state until they are closed by
+	//   the owner or reclaimed by an attempted fill.
 }
 
 func (*CreateOrder) GetTypeID() uint8 {
@@ -65,7 +71,7 @@ func (c *CreateOrder) Execute(
 	ctx context.Context,
 	_ chain.Rules,
 	mu state.Mutable,
-	_ int64,
+	timestamp int64,
 	actor codec.Address,
 	actionID ids.ID,
 ) ([][]byte, error) {
@@ -84,10 +90,13 @@ func (c *CreateOrder) Execute(
 	if c.Supply%c.OutTick != 0 {
 		return nil, ErrOutputSupplyMisaligned
 	}
+	if c.Expiry != 0 && c.Expiry <= timestamp {
+		return nil, ErrOutputExpiryInPast
+	}
 	if err := storage.SubBalance(ctx, mu, actor, c.Out, c.Supply); err != nil {
 		return nil, err
 	}
-	if err := storage.SetOrder(ctx, mu, actionID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, actor); err != nil {
+	if err := storage.SetOrder(ctx, mu, actionID, c.In, c.InTick, c.Out, c.OutTick, c.Supply, actor, c.Expiry); err != nil {
 		return nil, err
 	}
 	return nil, nil
@@ -98,7 +107,7 @@ func (*CreateOrder) ComputeUnits(chain.Rules) uint64 {
 }
 
 func (*CreateOrder) Size() int {
-	return ids.IDLen*2 + consts.Uint64Len*3
+	return ids.IDLen*2 + consts.Uint64Len*3 + consts.Int64Len
 }
 
 func (c *CreateOrder) Marshal(p *codec.Packer) {
@@ -107,6 +116,7 @@ func (c *CreateOrder) Marshal(p *codec.Packer) {
 	p.PackID(c.Out)
 	p.PackUint64(c.OutTick)
 	p.PackUint64(c.Supply)
+	p.PackInt64(c.Expiry)
 }
 
 func UnmarshalCreateOrder(p *codec.Packer) (chain.Action, error) {
@@ -116,6 +126,7 @@ func UnmarshalCreateOrder(p *codec.Packer) (chain.Action, error) {
 	p.UnpackID(false, &create.Out) // empty ID is the native asset
 	create.OutTick = p.UnpackUint64(true)
 	create.Supply = p.UnpackUint64(true)
+	create.Expiry = p.UnpackInt64(false) // if 0, never expires
 	return &create, p.Err()
 }
 
@@ -124,6 +135,12 @@ func (*CreateOrder) ValidRange(chain.Rules) (int64, int64) {
 	return -1, -1
 }
 
+// OrderExpired returns true if an order with [expiry] can no longer be filled
+// at [timestamp].
+func OrderExpired(expiry int64, timestamp int64) bool {
+	return expiry != 0 && timestamp > expiry
+}
+
 func PairID(in, out ids.ID) string {
 	return fmt.Sprintf("%s-%s", in.String(), out.String())
 }
@@ -0,0 +1,129 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*CreatePool)(nil)
+
+type CreatePool struct {
+	// [AssetA] and [AssetB] are the assets traded by the pool.
+	//
+	// [AssetA] must sort before [AssetB] (see [PoolAssets]).
+	AssetA ids.ID `json:"assetA"`
+	AssetB ids.ID `json:"assetB"`
+
+	// [AmountA] and [AmountB] are the initial reserves deposited by the
+	// actor. Their ratio sets the initial price of the pool.
+	AmountA uint64 `json:"amountA"`
+	AmountB uint64 `json:"amountB"`
+}
+
+func (*CreatePool) GetTypeID() uint8 {
+	return createPoolID
+}
+
+func (c *CreatePool) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	keys := state.Keys{
+		string(storage.BalanceKey(actor, c.AssetA)):          state.Read | state.Write,
+		string(storage.BalanceKey(actor, c.AssetB)):          state.Read | state.Write,
+		string(storage.PoolKey(c.AssetA, c.AssetB)):          state.Allocate | state.Write,
+		string(storage.SharesKey(actor, c.AssetA, c.AssetB)): state.Allocate | state.Write,
+	}
+	addControlKeys(keys, c.AssetA, actor)
+	return addControlKeys(keys, c.AssetB, actor)
+}
+
+func (c *CreatePool) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{storage.BalanceChunks, storage.BalanceChunks, storage.PoolChunks, storage.SharesChunks}
+	chunks = append(chunks, controlKeysMaxChunks(c.AssetA, 1)...)
+	return append(chunks, controlKeysMaxChunks(c.AssetB, 1)...)
+}
+
+func (c *CreatePool) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if err := checkPoolAssets(c.AssetA, c.AssetB); err != nil {
+		return nil, err
+	}
+	if c.AmountA == 0 || c.AmountB == 0 {
+		return nil, ErrOutputValueZero
+	}
+	if err := checkControls(ctx, mu, c.AssetA, actor); err != nil {
+		return nil, err
+	}
+	if err := checkControls(ctx, mu, c.AssetB, actor); err != nil {
+		return nil, err
+	}
+	exists, _, _, _, err := storage.GetPool(ctx, mu, c.AssetA, c.AssetB)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrOutputPoolAlreadyExists
+	}
+	if err := storage.SubBalance(ctx, mu, actor, c.AssetA, c.AmountA); err != nil {
+		return nil, err
+	}
+	if err := storage.SubBalance(ctx, mu, actor, c.AssetB, c.AmountB); err != nil {
+		return nil, err
+	}
+	shares := initialShares(c.AmountA, c.AmountB)
+	if err := storage.SetPool(ctx, mu, c.AssetA, c.AssetB, c.AmountA, c.AmountB, shares); err != nil {
+		return nil, err
+	}
+	if err := storage.AddShares(ctx, mu, actor, c.AssetA, c.AssetB, shares); err != nil {
+		return nil, err
+	}
+	lr := &LiquidityResult{AmountA: c.AmountA, AmountB: c.AmountB, Shares: shares}
+	output, err := lr.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{output}, nil
+}
+
+func (*CreatePool) ComputeUnits(chain.Rules) uint64 {
+	return CreatePoolComputeUnits
+}
+
+func (*CreatePool) Size() int {
+	return ids.IDLen*2 + consts.Uint64Len*2
+}
+
+func (c *CreatePool) Marshal(p *codec.Packer) {
+	p.PackID(c.AssetA)
+	p.PackID(c.AssetB)
+	p.PackUint64(c.AmountA)
+	p.PackUint64(c.AmountB)
+}
+
+func UnmarshalCreatePool(p *codec.Packer) (chain.Action, error) {
+	var create CreatePool
+	p.UnpackID(false, &create.AssetA) // empty ID is the native asset
+	p.UnpackID(false, &create.AssetB) // empty ID is the native asset
+	create.AmountA = p.UnpackUint64(true)
+	create.AmountB = p.UnpackUint64(true)
+	return &create, p.Err()
+}
+
+func (*CreatePool) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
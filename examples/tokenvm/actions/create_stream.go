@@ -0,0 +1,142 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.RecipientsAction = (*CreateStream)(nil)
+
+type CreateStream struct {
+	// Payee can claim released funds from the stream.
+	Payee codec.Address `json:"payee"`
+
+	// Asset that is streamed to [Payee].
+	Asset ids.ID `json:"asset"`
+
+	// Rate is the number of units of [Asset] released per second.
+	Rate uint64 `json:"rate"`
+
+	// Total is deducted from the actor when the stream is created and is the
+	// maximum amount that can ever be released to [Payee].
+	Total uint64 `json:"total"`
+
+	// Start is the timestamp (in milliseconds) that funds begin to be released.
+	// If 0, the stream starts at the time of the block that includes it.
+	Start int64 `json:"start"`
+
+	// Cliff is the timestamp (in milliseconds) before which nothing can be
+	// claimed. Funds released before [Cliff] become claimable at [Cliff].
+	// If 0, the cliff is [Start].
+	Cliff int64 `json:"cliff"`
+}
+
+func (*CreateStream) GetTypeID() uint8 {
+	return createStreamID
+}
+
+func (c *CreateStream) Recipients() []codec.Address {
+	return []codec.Address{c.Payee}
+}
+
+func (c *CreateStream) StateKeys(actor codec.Address, actionID ids.ID) state.Keys {
+	return addControlKeys(state.Keys{
+		string(storage.BalanceKey(actor, c.Asset)): state.Read | state.Write,
+		string(storage.StreamKey(actionID)):        state.Allocate | state.Write,
+	}, c.Asset, actor, c.Payee)
+}
+
+func (c *CreateStream) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{storage.BalanceChunks, storage.StreamChunks}, controlKeysMaxChunks(c.Asset, 2)...)
+}
+
+func (c *CreateStream) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	actionID ids.ID,
+) ([][]byte, error) {
+	if c.Rate == 0 {
+		return nil, ErrOutputRateZero
+	}
+	if c.Total == 0 {
+		return nil, ErrOutputValueZero
+	}
+	start := c.Start
+	if start == 0 {
+		start = timestamp
+	}
+	if start < timestamp {
+		return nil, ErrOutputStartInPast
+	}
+	cliff := c.Cliff
+	if cliff == 0 {
+		cliff = start
+	}
+	if cliff < start {
+		return nil, ErrOutputCliffBeforeStart
+	}
+	if err := checkControls(ctx, mu, c.Asset, actor, c.Payee); err != nil {
+		return nil, err
+	}
+	if err := storage.SubBalance(ctx, mu, actor, c.Asset, c.Total); err != nil {
+		return nil, err
+	}
+	if err := storage.SetStream(ctx, mu, actionID, &storage.Stream{
+		Asset: c.Asset,
+		Payer: actor,
+		Payee: c.Payee,
+		Rate:  c.Rate,
+		Start: start,
+		Cliff: cliff,
+		Total: c.Total,
+	}); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*CreateStream) ComputeUnits(chain.Rules) uint64 {
+	return CreateStreamComputeUnits
+}
+
+func (*CreateStream) Size() int {
+	return codec.AddressLen + ids.IDLen + consts.Uint64Len*2 + consts.Int64Len*2
+}
+
+func (c *CreateStream) Marshal(p *codec.Packer) {
+	p.PackAddress(c.Payee)
+	p.PackID(c.Asset)
+	p.PackUint64(c.Rate)
+	p.PackUint64(c.Total)
+	p.PackInt64(c.Start)
+	p.PackInt64(c.Cliff)
+}
+
+func UnmarshalCreateStream(p *codec.Packer) (chain.Action, error) {
+	var create CreateStream
+	p.UnpackAddress(&create.Payee)
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	create.Rate = p.UnpackUint64(true)
+	create.Total = p.UnpackUint64(true)
+	create.Start = p.UnpackInt64(false)
+	create.Cliff = p.UnpackInt64(false)
+	return &create, p.Err()
+}
+
+func (*CreateStream) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
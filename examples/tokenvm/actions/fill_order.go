@@ -17,7 +17,7 @@ import (
 	smath "github.com/ava-labs/avalanchego/utils/math"
 )
 
-var _ chain.Action = (*FillOrder)(nil)
+var _ chain.RecipientsAction = (*FillOrder)(nil)
 
 type FillOrder struct {
 	// [Order] is the OrderID you wish to close.
@@ -37,34 +37,57 @@ type FillOrder struct {
 
 	// [Value] is the max amount of [In] that will be swapped for [Out].
 	Value uint64 `json:"value"`
+
+	// [Royalty] is the royalty recipient of [Out], if it has one. We need to
+	// provide this to populate [StateKeys].
+	Royalty codec.Address `json:"royalty"`
 }
 
 func (*FillOrder) GetTypeID() uint8 {
 	return fillOrderID
 }
 
+func (f *FillOrder) Recipients() []codec.Address {
+	if f.Royalty == codec.EmptyAddress {
+		return []codec.Address{f.Owner}
+	}
+	return []codec.Address{f.Owner, f.Royalty}
+}
+
 func (f *FillOrder) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
-	return state.Keys{
-		string(storage.OrderKey(f.Order)):         state.Read | state.Write,
-		string(storage.BalanceKey(f.Owner, f.In)): state.All,
-		string(storage.BalanceKey(actor, f.In)):   state.Read | state.Write,
-		string(storage.BalanceKey(actor, f.Out)):  state.All,
+	keys := state.Keys{
+		string(storage.OrderKey(f.Order)):          state.Read | state.Write,
+		string(storage.BalanceKey(f.Owner, f.In)):  state.All,
+		string(storage.BalanceKey(f.Owner, f.Out)): state.All, // refunded if expired
+		string(storage.BalanceKey(actor, f.In)):    state.Read | state.Write,
+		string(storage.BalanceKey(actor, f.Out)):   state.All,
+		string(storage.RoyaltyKey(f.Out)):          state.Read,
+	}
+	if f.Royalty != codec.EmptyAddress {
+		keys[string(storage.BalanceKey(f.Royalty, f.In))] = state.All
 	}
+	addControlKeys(keys, f.In, actor, f.Owner)
+	return addControlKeys(keys, f.Out, actor, f.Owner)
 }
 
-func (*FillOrder) StateKeysMaxChunks() []uint16 {
-	return []uint16{storage.OrderChunks, storage.BalanceChunks, storage.BalanceChunks, storage.BalanceChunks}
+func (f *FillOrder) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{storage.OrderChunks, storage.BalanceChunks, storage.BalanceChunks, storage.BalanceChunks, storage.BalanceChunks, storage.RoyaltyChunks}
+	if f.Royalty != codec.EmptyAddress {
+		chunks = append(chunks, storage.BalanceChunks)
+	}
+	chunks = append(chunks, controlKeysMaxChunks(f.In, 2)...)
+	return append(chunks, controlKeysMaxChunks(f.Out, 2)...)
 }
 
 func (f *FillOrder) Execute(
 	ctx context.Context,
 	_ chain.Rules,
 	mu state.Mutable,
-	_ int64,
+	timestamp int64,
 	actor codec.Address,
 	_ ids.ID,
 ) ([][]byte, error) {
-	exists, in, inTick, out, outTick, remaining, owner, err := storage.GetOrder(ctx, mu, f.Order)
+	exists, in, inTick, out, outTick, remaining, owner, expiry, err := storage.GetOrder(ctx, mu, f.Order)
 	if err != nil {
 		return nil, err
 	}
@@ -80,55 +103,51 @@ func (f *FillOrder) Execute(
 	if out != f.Out {
 		return nil, ErrOutputWrongOut
 	}
-	if f.Value == 0 {
-		// This should be guarded via [Unmarshal] but we check anyways.
-		return nil, ErrOutputValueZero
+	if OrderExpired(expiry, timestamp) {
+		// Return the remaining supply of an expired order to its owner instead
+		// of failing so that its state is reclaimed.
+		if err := storage.DeleteOrder(ctx, mu, f.Order); err != nil {
+			return nil, err
+		}
+		if err := storage.AddBalance(ctx, mu, owner, out, remaining, true); err != nil {
+			return nil, err
+		}
+		or := &OrderResult{}
+		output, err := or.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{output}, nil
 	}
-	if f.Value%inTick != 0 {
-		return nil, ErrOutputValueMisaligned
+	if err := checkControls(ctx, mu, in, actor, owner); err != nil {
+		return nil, err
 	}
-	// Determine amount of [Out] counterparty will receive if the trade is
-	// successful.
-	outputAmount, err := smath.Mul64(outTick, f.Value/inTick)
+	if err := checkControls(ctx, mu, out, actor, owner); err != nil {
+		return nil, err
+	}
+	inputAmount, outputAmount, err := FillAmounts(inTick, outTick, remaining, f.Value)
 	if err != nil {
 		return nil, err
 	}
-	if outputAmount == 0 {
-		// This should never happen because [f.Value] > 0
-		return nil, ErrOutputInsufficientOutput
+	royalty, err := royaltyAmount(ctx, mu, out, f.Royalty, inputAmount)
+	if err != nil {
+		return nil, err
 	}
 	var (
-		inputAmount    = f.Value
-		shouldDelete   = false
-		orderRemaining uint64
-	)
-	switch {
-	case outputAmount > remaining:
-		// Calculate correct input given remaining supply
-		//
-		// This may happen if 2 people try to trade the same order at once.
-		blocksOver := (outputAmount - remaining) / outTick
-		inputAmount -= blocksOver * inTick
-
-		// If the [outputAmount] is greater than remaining, take what is left.
-		outputAmount = remaining
-		shouldDelete = true
-	case outputAmount == remaining:
-		// If the [outputAmount] is equal to remaining, take all of it.
-		shouldDelete = true
-	default:
+		shouldDelete   = outputAmount == remaining
 		orderRemaining = remaining - outputAmount
-	}
-	if inputAmount == 0 {
-		// Don't allow free trades (can happen due to refund rounding)
-		return nil, err
-	}
+	)
 	if err := storage.SubBalance(ctx, mu, actor, f.In, inputAmount); err != nil {
 		return nil, err
 	}
-	if err := storage.AddBalance(ctx, mu, f.Owner, f.In, inputAmount, true); err != nil {
+	if err := storage.AddBalance(ctx, mu, f.Owner, f.In, inputAmount-royalty, true); err != nil {
 		return nil, err
 	}
+	if royalty > 0 {
+		if err := storage.AddBalance(ctx, mu, f.Royalty, f.In, royalty, true); err != nil {
+			return nil, err
+		}
+	}
 	if err := storage.AddBalance(ctx, mu, actor, f.Out, outputAmount, true); err != nil {
 		return nil, err
 	}
@@ -137,11 +156,11 @@ func (f *FillOrder) Execute(
 			return nil, err
 		}
 	} else {
-		if err := storage.SetOrder(ctx, mu, f.Order, in, inTick, out, outTick, orderRemaining, owner); err != nil {
+		if err := storage.SetOrder(ctx, mu, f.Order, in, inTick, out, outTick, orderRemaining, owner, expiry); err != nil {
 			return nil, err
 		}
 	}
-	or := &OrderResult{In: inputAmount, Out: outputAmount, Remaining: orderRemaining}
+	or := &OrderResult{In: inputAmount, Out: outputAmount, Remaining: orderRemaining, Royalty: royalty}
 	output, err := or.Marshal()
 	if err != nil {
 		return nil, err
@@ -149,12 +168,80 @@ func (f *FillOrder) Execute(
 	return [][]byte{output}, nil
 }
 
+// royaltyAmount returns the portion of [proceeds] from selling [asset] that
+// must be paid to [recipient] instead of the seller.
+func royaltyAmount(
+	ctx context.Context,
+	im state.Immutable,
+	asset ids.ID,
+	recipient codec.Address,
+	proceeds uint64,
+) (uint64, error) {
+	basisPoints, royaltyRecipient, err := storage.GetRoyalty(ctx, im, asset)
+	if err != nil {
+		return 0, err
+	}
+	if basisPoints == 0 {
+		return 0, nil
+	}
+	if royaltyRecipient != recipient {
+		return 0, ErrOutputWrongRoyalty
+	}
+	return mulDiv(proceeds, uint64(basisPoints), MaxRoyaltyBasisPoints)
+}
+
+// FillAmounts returns the amount of In spent and Out received when filling an
+// order with [inTick], [outTick], and [remaining] using up to [value] of In.
+//
+// This is the only place fill amounts are computed so that anything matching
+// orders off-chain (like the orderbook) produces the same result as
+// [FillOrder].
+func FillAmounts(inTick uint64, outTick uint64, remaining uint64, value uint64) (uint64, uint64, error) {
+	if value == 0 {
+		// This should be guarded via [Unmarshal] but we check anyways.
+		return 0, 0, ErrOutputValueZero
+	}
+	if value%inTick != 0 {
+		return 0, 0, ErrOutputValueMisaligned
+	}
+	// Determine amount of [Out] counterparty will receive if the trade is
+	// successful.
+	outputAmount, err := smath.Mul64(outTick, value/inTick)
+	if err != nil {
+		return 0, 0, err
+	}
+	if outputAmount == 0 {
+		// This should never happen because [value] > 0
+		return 0, 0, ErrOutputInsufficientOutput
+	}
+	inputAmount := value
+	if outputAmount > remaining {
+		// Calculate correct input given remaining supply
+		//
+		// This may happen if 2 people try to trade the same order at once.
+		blocksOver := (outputAmount - remaining) / outTick
+		inputAmount -= blocksOver * inTick
+
+		// If the [outputAmount] is greater than remaining, take what is left.
+		outputAmount = remaining
+	}
+	if inputAmount == 0 {
+		// Don't allow free trades (can happen due to refund rounding)
+		return 0, 0, ErrOutputInsufficientInput
+	}
+	return inputAmount, outputAmount, nil
+}
+
 func (*FillOrder) ComputeUnits(chain.Rules) uint64 {
 	return FillOrderComputeUnits
 }
 
-func (*FillOrder) Size() int {
-	return ids.IDLen*3 + codec.AddressLen + consts.Uint64Len
+func (f *FillOrder) Size() int {
+	size := ids.IDLen*3 + codec.AddressLen + consts.Uint64Len + consts.BoolLen
+	if f.Royalty != codec.EmptyAddress {
+		size += codec.AddressLen
+	}
+	return size
 }
 
 func (f *FillOrder) Marshal(p *codec.Packer) {
@@ -163,6 +250,11 @@ func (f *FillOrder) Marshal(p *codec.Packer) {
 	p.PackID(f.In)
 	p.PackID(f.Out)
 	p.PackUint64(f.Value)
+	hasRoyalty := f.Royalty != codec.EmptyAddress
+	p.PackBool(hasRoyalty)
+	if hasRoyalty {
+		p.PackAddress(f.Royalty)
+	}
 }
 
 func UnmarshalFillOrder(p *codec.Packer) (chain.Action, error) {
@@ -172,6 +264,9 @@ func UnmarshalFillOrder(p *codec.Packer) (chain.Action, error) {
 	p.UnpackID(false, &fill.In)  // empty ID is the native asset
 	p.UnpackID(false, &fill.Out) // empty ID is the native asset
 	fill.Value = p.UnpackUint64(true)
+	if p.UnpackBool() {
+		p.UnpackAddress(&fill.Royalty)
+	}
 	return &fill, p.Err()
 }
 
@@ -182,25 +277,32 @@ func (*FillOrder) ValidRange(chain.Rules) (int64, int64) {
 
 // OrderResult is a custom successful response output that provides information
 // about a successful trade.
+//
+// If the filled order was expired, [In], [Out], and [Remaining] are all 0.
+// [Royalty] is the portion of [In] paid to the royalty recipient of the
+// order's Out asset instead of the owner.
 type OrderResult struct {
 	In        uint64 `json:"in"`
 	Out       uint64 `json:"out"`
 	Remaining uint64 `json:"remaining"`
+	Royalty   uint64 `json:"royalty"`
 }
 
 func UnmarshalOrderResult(b []byte) (*OrderResult, error) {
-	p := codec.NewReader(b, consts.Uint64Len*3)
+	p := codec.NewReader(b, consts.Uint64Len*4)
 	var result OrderResult
-	result.In = p.UnpackUint64(true)
-	result.Out = p.UnpackUint64(true)
+	result.In = p.UnpackUint64(false)        // if 0, expired
+	result.Out = p.UnpackUint64(false)       // if 0, expired
 	result.Remaining = p.UnpackUint64(false) // if 0, deleted
+	result.Royalty = p.UnpackUint64(false)
 	return &result, p.Err()
 }
 
 func (o *OrderResult) Marshal() ([]byte, error) {
-	p := codec.NewWriter(consts.Uint64Len*3, consts.Uint64Len*3)
+	p := codec.NewWriter(consts.Uint64Len*4, consts.Uint64Len*4)
 	p.PackUint64(o.In)
 	p.PackUint64(o.Out)
 	p.PackUint64(o.Remaining)
+	p.PackUint64(o.Royalty)
 	return p.Bytes(), p.Err()
 }
@@ -0,0 +1,100 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.RecipientsAction = (*FreezeAccount)(nil)
+
+type FreezeAccount struct {
+	// Asset to freeze [Account] for. Only the owner of the asset can modify
+	// this.
+	Asset ids.ID `json:"asset"`
+
+	// Account to freeze or unfreeze.
+	Account codec.Address `json:"account"`
+
+	// Frozen is whether [Account] can send or receive [Asset].
+	Frozen bool `json:"frozen"`
+}
+
+func (*FreezeAccount) GetTypeID() uint8 {
+	return freezeAccountID
+}
+
+func (f *FreezeAccount) Recipients() []codec.Address {
+	return []codec.Address{f.Account}
+}
+
+func (f *FreezeAccount) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(f.Asset)):             state.Read,
+		string(storage.FrozenKey(f.Asset, f.Account)): state.All,
+	}
+}
+
+func (*FreezeAccount) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AssetChunks, storage.FrozenChunks}
+}
+
+func (f *FreezeAccount) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, _, _, _, _, _, owner, err := storage.GetAsset(ctx, mu, f.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputAssetMissing
+	}
+	if owner != actor {
+		return nil, ErrOutputWrongOwner
+	}
+	if err := storage.SetFrozen(ctx, mu, f.Asset, f.Account, f.Frozen); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*FreezeAccount) ComputeUnits(chain.Rules) uint64 {
+	return FreezeAccountComputeUnits
+}
+
+func (*FreezeAccount) Size() int {
+	return ids.IDLen + codec.AddressLen + consts.BoolLen
+}
+
+func (f *FreezeAccount) Marshal(p *codec.Packer) {
+	p.PackID(f.Asset)
+	p.PackAddress(f.Account)
+	p.PackBool(f.Frozen)
+}
+
+func UnmarshalFreezeAccount(p *codec.Packer) (chain.Action, error) {
+	var freeze FreezeAccount
+	p.UnpackID(true, &freeze.Asset) // native asset cannot be frozen
+	p.UnpackAddress(&freeze.Account)
+	freeze.Frozen = p.UnpackBool()
+	return &freeze, p.Err()
+}
+
+func (*FreezeAccount) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -17,7 +17,7 @@ import (
 	smath "github.com/ava-labs/avalanchego/utils/math"
 )
 
-var _ chain.Action = (*MintAsset)(nil)
+var _ chain.RecipientsAction = (*MintAsset)(nil)
 
 type MintAsset struct {
 	// To is the recipient of the [Value].
@@ -37,6 +37,10 @@ func (*MintAsset) GetTypeID() uint8 {
 	return mintAssetID
 }
 
+func (m *MintAsset) Recipients() []codec.Address {
+	return []codec.Address{m.To}
+}
+
 func (m *MintAsset) StateKeys(codec.Address, ids.ID) state.Keys {
 	return state.Keys{
 		string(storage.AssetKey(m.Asset)):         state.Read | state.Write,
@@ -62,7 +66,7 @@ func (m *MintAsset) Execute(
 	if m.Value == 0 {
 		return nil, ErrOutputValueZero
 	}
-	exists, symbol, decimals, metadata, supply, owner, err := storage.GetAsset(ctx, mu, m.Asset)
+	exists, symbol, decimals, metadata, uri, supply, owner, err := storage.GetAsset(ctx, mu, m.Asset)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +80,7 @@ func (m *MintAsset) Execute(
 	if err != nil {
 		return nil, err
 	}
-	if err := storage.SetAsset(ctx, mu, m.Asset, symbol, decimals, metadata, newSupply, actor); err != nil {
+	if err := storage.SetAsset(ctx, mu, m.Asset, symbol, decimals, metadata, uri, newSupply, actor); err != nil {
 		return nil, err
 	}
 	if err := storage.AddBalance(ctx, mu, m.To, m.Asset, m.Value, true); err != nil {
@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ chain.RecipientsAction = (*MintNFT)(nil)
+
+type MintNFT struct {
+	// To is the owner of the minted NFT.
+	To codec.Address `json:"to"`
+
+	// Collection is the [ActionID] that created the collection.
+	Collection ids.ID `json:"collection"`
+
+	// ID of the NFT in [Collection]. It must not already be
+	// in use.
+	ID uint64 `json:"id"`
+
+	// Metadata of the NFT (usually a URI).
+	Metadata []byte `json:"metadata"`
+}
+
+func (*MintNFT) GetTypeID() uint8 {
+	return mintNFTID
+}
+
+func (m *MintNFT) Recipients() []codec.Address {
+	return []codec.Address{m.To}
+}
+
+func (m *MintNFT) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.CollectionKey(m.Collection)): state.Read | state.Write,
+		string(storage.NFTKey(m.Collection, m.ID)):  state.All,
+	}
+}
+
+func (*MintNFT) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.CollectionChunks, storage.NFTChunks}
+}
+
+func (m *MintNFT) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if len(m.Metadata) == 0 {
+		return nil, ErrOutputMetadataEmpty
+	}
+	if len(m.Metadata) > MaxMetadataSize {
+		return nil, ErrOutputMetadataTooLarge
+	}
+	exists, name, metadata, supply, owner, err := storage.GetCollection(ctx, mu, m.Collection)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputCollectionMissing
+	}
+	if owner != actor {
+		return nil, ErrOutputWrongOwner
+	}
+	exists, _, _, err = storage.GetNFT(ctx, mu, m.Collection, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrOutputNFTAlreadyExists
+	}
+	newSupply, err := smath.Add64(supply, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.SetCollection(ctx, mu, m.Collection, name, metadata, newSupply, owner); err != nil {
+		return nil, err
+	}
+	if err := storage.SetNFT(ctx, mu, m.Collection, m.ID, m.Metadata, m.To); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*MintNFT) ComputeUnits(chain.Rules) uint64 {
+	return MintNFTComputeUnits
+}
+
+func (m *MintNFT) Size() int {
+	return codec.AddressLen + ids.IDLen + consts.Uint64Len + codec.BytesLen(m.Metadata)
+}
+
+func (m *MintNFT) Marshal(p *codec.Packer) {
+	p.PackAddress(m.To)
+	p.PackID(m.Collection)
+	p.PackUint64(m.ID)
+	p.PackBytes(m.Metadata)
+}
+
+func UnmarshalMintNFT(p *codec.Packer) (chain.Action, error) {
+	var mint MintNFT
+	p.UnpackAddress(&mint.To)
+	p.UnpackID(true, &mint.Collection)
+	mint.ID = p.UnpackUint64(false)
+	p.UnpackBytes(MaxMetadataSize, true, &mint.Metadata)
+	return &mint, p.Err()
+}
+
+func (*MintNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
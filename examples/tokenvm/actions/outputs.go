@@ -34,4 +34,42 @@ var (
 	ErrOutputWrongDestination   = errors.New("wrong destination")
 	ErrOutputMustFill           = errors.New("must fill request")
 	ErrOutputInvalidDestination = errors.New("invalid destination")
+	ErrOutputNameEmpty          = errors.New("name is empty")
+	ErrOutputNameTooLarge       = errors.New("name is too large")
+	ErrOutputCollectionMissing  = errors.New("collection missing")
+	ErrOutputNFTMissing         = errors.New("nft missing")
+	ErrOutputNFTAlreadyExists   = errors.New("nft already exists")
+	ErrOutputExpiryInPast       = errors.New("expiry is in the past")
+	ErrOutputNoOrders           = errors.New("no orders provided")
+	ErrOutputTooManyOrders      = errors.New("too many orders")
+	ErrOutputOrdersMisaligned   = errors.New("orders and out assets are misaligned")
+	ErrOutputPoolUnordered      = errors.New("pool assets are not ordered")
+	ErrOutputPoolAlreadyExists  = errors.New("pool already exists")
+	ErrOutputPoolMissing        = errors.New("pool missing")
+	ErrOutputSharesZero         = errors.New("shares are zero")
+	ErrOutputSlippage           = errors.New("slippage limit exceeded")
+	ErrOutputURITooLarge        = errors.New("uri is too large")
+	ErrOutputAssetPaused        = errors.New("asset is paused")
+	ErrOutputAccountFrozen      = errors.New("account is frozen")
+	ErrOutputNoRecipients       = errors.New("no recipients provided")
+	ErrOutputTooManyRecipients  = errors.New("too many recipients")
+	ErrOutputValuesMisaligned   = errors.New("recipients and values are misaligned")
+	ErrOutputRateZero           = errors.New("rate is zero")
+	ErrOutputStartInPast        = errors.New("start is in the past")
+	ErrOutputCliffBeforeStart   = errors.New("cliff is before start")
+	ErrOutputStreamMissing      = errors.New("stream is missing")
+	ErrOutputWrongAsset         = errors.New("wrong asset")
+	ErrOutputNothingToClaim     = errors.New("nothing to claim")
+	ErrOutputRoyaltyTooLarge    = errors.New("royalty is too large")
+	ErrOutputWrongRoyalty       = errors.New("wrong royalty recipient")
+	ErrOutputRoyaltyRecipient   = errors.New("royalty recipient is empty")
+	ErrOutputAirdropMissing     = errors.New("airdrop is missing")
+	ErrOutputAlreadyClaimed     = errors.New("already claimed")
+	ErrOutputInvalidProof       = errors.New("invalid proof")
+	ErrOutputAirdropExhausted   = errors.New("airdrop is exhausted")
+	ErrOutputAirdropExpired     = errors.New("airdrop is expired")
+	ErrOutputAirdropNotExpired  = errors.New("airdrop is not expired")
+	ErrOutputProofTooLarge      = errors.New("proof is too large")
+	ErrOutputAssetNotBridged    = errors.New("asset was not minted by the bridge")
+	ErrOutputReturnNotSupported = errors.New("tokenvm assets cannot be returned")
 )
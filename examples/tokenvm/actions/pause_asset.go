@@ -0,0 +1,91 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*PauseAsset)(nil)
+
+type PauseAsset struct {
+	// Asset to pause or unpause. Only the owner of the asset can modify this.
+	Asset ids.ID `json:"asset"`
+
+	// Paused is whether [Asset] can be transferred or traded. While paused,
+	// it can still be minted and burned by the owner.
+	Paused bool `json:"paused"`
+}
+
+func (*PauseAsset) GetTypeID() uint8 {
+	return pauseAssetID
+}
+
+func (pa *PauseAsset) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.AssetKey(pa.Asset)):  state.Read,
+		string(storage.PausedKey(pa.Asset)): state.All,
+	}
+}
+
+func (*PauseAsset) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.AssetChunks, storage.PausedChunks}
+}
+
+func (pa *PauseAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, _, _, _, _, _, owner, err := storage.GetAsset(ctx, mu, pa.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputAssetMissing
+	}
+	if owner != actor {
+		return nil, ErrOutputWrongOwner
+	}
+	if err := storage.SetPaused(ctx, mu, pa.Asset, pa.Paused); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*PauseAsset) ComputeUnits(chain.Rules) uint64 {
+	return PauseAssetComputeUnits
+}
+
+func (*PauseAsset) Size() int {
+	return ids.IDLen + consts.BoolLen
+}
+
+func (pa *PauseAsset) Marshal(p *codec.Packer) {
+	p.PackID(pa.Asset)
+	p.PackBool(pa.Paused)
+}
+
+func UnmarshalPauseAsset(p *codec.Packer) (chain.Action, error) {
+	var pause PauseAsset
+	p.UnpackID(true, &pause.Asset) // native asset cannot be paused
+	pause.Paused = p.UnpackBool()
+	return &pause, p.Err()
+}
+
+func (*PauseAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
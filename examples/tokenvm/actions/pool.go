@@ -0,0 +1,108 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// PoolAssets returns [a] and [b] in the order they are stored in a pool and
+// whether they were swapped.
+func PoolAssets(a ids.ID, b ids.ID) (ids.ID, ids.ID, bool) {
+	if a.Compare(b) > 0 {
+		return b, a, true
+	}
+	return a, b, false
+}
+
+func checkPoolAssets(assetA ids.ID, assetB ids.ID) error {
+	switch c := assetA.Compare(assetB); {
+	case c == 0:
+		return ErrOutputSameInOut
+	case c > 0:
+		return ErrOutputPoolUnordered
+	default:
+		return nil
+	}
+}
+
+// mulDiv returns floor(a*b/c) without overflowing on the intermediate product.
+func mulDiv(a uint64, b uint64, c uint64) (uint64, error) {
+	r := new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+	r.Div(r, new(big.Int).SetUint64(c))
+	if !r.IsUint64() {
+		return 0, smath.ErrOverflow
+	}
+	return r.Uint64(), nil
+}
+
+// initialShares returns the shares minted when a pool is created, which is the
+// geometric mean of the deposited reserves.
+func initialShares(amountA uint64, amountB uint64) uint64 {
+	r := new(big.Int).Mul(new(big.Int).SetUint64(amountA), new(big.Int).SetUint64(amountB))
+	return r.Sqrt(r).Uint64()
+}
+
+// SwapOutput returns the amount of the out asset received for [value] of the
+// in asset, after the swap fee, such that the product of the reserves does
+// not decrease.
+func SwapOutput(value uint64, reserveIn uint64, reserveOut uint64) uint64 {
+	in := new(big.Int).SetUint64(value)
+	in.Mul(in, big.NewInt(SwapFeeDenominator-SwapFeeNumerator))
+	num := new(big.Int).Mul(in, new(big.Int).SetUint64(reserveOut))
+	den := new(big.Int).Mul(new(big.Int).SetUint64(reserveIn), big.NewInt(SwapFeeDenominator))
+	den.Add(den, in)
+	return num.Div(num, den).Uint64()
+}
+
+// SwapInput returns the smallest amount of the in asset for which [SwapOutput]
+// is at least [value].
+func SwapInput(value uint64, reserveIn uint64, reserveOut uint64) (uint64, error) {
+	if value >= reserveOut {
+		return 0, ErrOutputInsufficientOutput
+	}
+	num := new(big.Int).Mul(new(big.Int).SetUint64(reserveIn), new(big.Int).SetUint64(value))
+	num.Mul(num, big.NewInt(SwapFeeDenominator))
+	den := new(big.Int).SetUint64(reserveOut - value)
+	den.Mul(den, big.NewInt(SwapFeeDenominator-SwapFeeNumerator))
+	num.Add(num, den)
+	num.Sub(num, big.NewInt(1))
+	in := num.Div(num, den)
+	if !in.IsUint64() {
+		return 0, smath.ErrOverflow
+	}
+	return in.Uint64(), nil
+}
+
+// LiquidityResult is a custom successful response output that provides
+// information about a change in pool liquidity.
+type LiquidityResult struct {
+	AmountA uint64 `json:"amountA"`
+	AmountB uint64 `json:"amountB"`
+	Shares  uint64 `json:"shares"`
+}
+
+func UnmarshalLiquidityResult(b []byte) (*LiquidityResult, error) {
+	p := codec.NewReader(b, consts.Uint64Len*3)
+	var result LiquidityResult
+	result.AmountA = p.UnpackUint64(true)
+	result.AmountB = p.UnpackUint64(true)
+	result.Shares = p.UnpackUint64(true)
+	return &result, p.Err()
+}
+
+func (l *LiquidityResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len*3, consts.Uint64Len*3)
+	p.PackUint64(l.AmountA)
+	p.PackUint64(l.AmountB)
+	p.PackUint64(l.Shares)
+	return p.Bytes(), p.Err()
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/consts"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+func TestSwapInput(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      uint64
+		reserveIn  uint64
+		reserveOut uint64
+		in         uint64
+		err        error
+	}{
+		{
+			name:       "balanced pool",
+			value:      100,
+			reserveIn:  10_000,
+			reserveOut: 10_000,
+			in:         102, // 101.31 rounded up
+		},
+		{
+			name:       "unbalanced pool",
+			value:      100,
+			reserveIn:  1_000,
+			reserveOut: 100_000,
+			in:         2, // 1.01 rounded up
+		},
+		{
+			name:       "exact input",
+			value:      997,
+			reserveIn:  1_000,
+			reserveOut: 1_997,
+			in:         1_000,
+		},
+		{
+			name:       "smallest output",
+			value:      1,
+			reserveIn:  1,
+			reserveOut: 2,
+			in:         2, // 1.003 rounded up
+		},
+		{
+			name:       "zero output",
+			value:      0,
+			reserveIn:  1_000,
+			reserveOut: 1_000,
+			in:         0,
+		},
+		{
+			name:       "whole reserve",
+			value:      1_000,
+			reserveIn:  1_000,
+			reserveOut: 1_000,
+			err:        ErrOutputInsufficientOutput,
+		},
+		{
+			name:       "more than reserve",
+			value:      1_001,
+			reserveIn:  1_000,
+			reserveOut: 1_000,
+			err:        ErrOutputInsufficientOutput,
+		},
+		{
+			name:       "empty pool",
+			value:      1,
+			reserveIn:  0,
+			reserveOut: 0,
+			err:        ErrOutputInsufficientOutput,
+		},
+		{
+			name:       "overflow",
+			value:      consts.MaxUint64 - 1,
+			reserveIn:  consts.MaxUint64,
+			reserveOut: consts.MaxUint64,
+			err:        smath.ErrOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			in, err := SwapInput(tt.value, tt.reserveIn, tt.reserveOut)
+			require.ErrorIs(err, tt.err)
+			if tt.err != nil {
+				return
+			}
+			require.Equal(tt.in, in)
+
+			// [in] is the smallest input that buys [value]
+			require.GreaterOrEqual(SwapOutput(in, tt.reserveIn, tt.reserveOut), tt.value)
+			if in > 0 {
+				require.Less(SwapOutput(in-1, tt.reserveIn, tt.reserveOut), tt.value)
+			}
+		})
+	}
+}
+
+func TestSwapInputRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	// Buying the output of a swap never costs more than its input (the
+	// product of the reserves doesn't decrease either way)
+	for _, reserves := range [][2]uint64{{1_000, 1_000}, {1_000, 1_000_000}, {1_000_000, 1_000}, {7, 13}} {
+		for _, value := range []uint64{1, 10, 100, 999} {
+			out := SwapOutput(value, reserves[0], reserves[1])
+			if out == 0 {
+				continue
+			}
+			in, err := SwapInput(out, reserves[0], reserves[1])
+			require.NoError(err)
+			require.LessOrEqual(in, value)
+			require.GreaterOrEqual(
+				(reserves[0]+in)*(reserves[1]-out),
+				reserves[0]*reserves[1],
+			)
+		}
+	}
+}
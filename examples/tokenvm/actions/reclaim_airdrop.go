@@ -0,0 +1,104 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*ReclaimAirdrop)(nil)
+
+// ReclaimAirdrop deletes an expired airdrop and refunds whatever was not
+// claimed to its owner.
+type ReclaimAirdrop struct {
+	// Airdrop is the ActionID that created the airdrop.
+	Airdrop ids.ID `json:"airdrop"`
+
+	// Asset is the asset of [Airdrop]. We need to provide this to populate
+	// [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (*ReclaimAirdrop) GetTypeID() uint8 {
+	return reclaimAirdropID
+}
+
+func (r *ReclaimAirdrop) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return addControlKeys(state.Keys{
+		string(storage.AirdropKey(r.Airdrop)):      state.Read | state.Write,
+		string(storage.BalanceKey(actor, r.Asset)): state.All,
+	}, r.Asset, actor)
+}
+
+func (r *ReclaimAirdrop) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{storage.AirdropChunks, storage.BalanceChunks}, controlKeysMaxChunks(r.Asset, 1)...)
+}
+
+func (r *ReclaimAirdrop) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	exists, airdrop, err := storage.GetAirdrop(ctx, mu, r.Airdrop)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputAirdropMissing
+	}
+	if airdrop.Owner != actor {
+		return nil, ErrOutputWrongOwner
+	}
+	if airdrop.Asset != r.Asset {
+		return nil, ErrOutputWrongAsset
+	}
+	if !AirdropExpired(airdrop.Expiry, timestamp) {
+		return nil, ErrOutputAirdropNotExpired
+	}
+	if err := checkControls(ctx, mu, r.Asset, actor); err != nil {
+		return nil, err
+	}
+	if err := storage.DeleteAirdrop(ctx, mu, r.Airdrop); err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, actor, r.Asset, airdrop.Remaining, true); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*ReclaimAirdrop) ComputeUnits(chain.Rules) uint64 {
+	return ReclaimAirdropComputeUnits
+}
+
+func (*ReclaimAirdrop) Size() int {
+	return ids.IDLen * 2
+}
+
+func (r *ReclaimAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(r.Airdrop)
+	p.PackID(r.Asset)
+}
+
+func UnmarshalReclaimAirdrop(p *codec.Packer) (chain.Action, error) {
+	var reclaim ReclaimAirdrop
+	p.UnpackID(true, &reclaim.Airdrop)
+	p.UnpackID(false, &reclaim.Asset) // empty ID is the native asset
+	return &reclaim, p.Err()
+}
+
+func (*ReclaimAirdrop) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.Action = (*RemoveLiquidity)(nil)
+
+type RemoveLiquidity struct {
+	// [AssetA] and [AssetB] identify the pool to withdraw from.
+	AssetA ids.ID `json:"assetA"`
+	AssetB ids.ID `json:"assetB"`
+
+	// [Shares] is the number of the actor's shares to redeem.
+	Shares uint64 `json:"shares"`
+
+	// [MinA] and [MinB] are the fewest of each asset the actor is willing to
+	// receive.
+	MinA uint64 `json:"minA"`
+	MinB uint64 `json:"minB"`
+}
+
+func (*RemoveLiquidity) GetTypeID() uint8 {
+	return removeLiquidityID
+}
+
+func (r *RemoveLiquidity) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	keys := state.Keys{
+		string(storage.BalanceKey(actor, r.AssetA)):          state.All,
+		string(storage.BalanceKey(actor, r.AssetB)):          state.All,
+		string(storage.PoolKey(r.AssetA, r.AssetB)):          state.Read | state.Write,
+		string(storage.SharesKey(actor, r.AssetA, r.AssetB)): state.Read | state.Write,
+	}
+	addControlKeys(keys, r.AssetA, actor)
+	return addControlKeys(keys, r.AssetB, actor)
+}
+
+func (r *RemoveLiquidity) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{storage.BalanceChunks, storage.BalanceChunks, storage.PoolChunks, storage.SharesChunks}
+	chunks = append(chunks, controlKeysMaxChunks(r.AssetA, 1)...)
+	return append(chunks, controlKeysMaxChunks(r.AssetB, 1)...)
+}
+
+func (r *RemoveLiquidity) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if err := checkPoolAssets(r.AssetA, r.AssetB); err != nil {
+		return nil, err
+	}
+	if r.Shares == 0 {
+		return nil, ErrOutputSharesZero
+	}
+	if err := checkControls(ctx, mu, r.AssetA, actor); err != nil {
+		return nil, err
+	}
+	if err := checkControls(ctx, mu, r.AssetB, actor); err != nil {
+		return nil, err
+	}
+	exists, reserveA, reserveB, totalShares, err := storage.GetPool(ctx, mu, r.AssetA, r.AssetB)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputPoolMissing
+	}
+	if err := storage.SubShares(ctx, mu, actor, r.AssetA, r.AssetB, r.Shares); err != nil {
+		return nil, err
+	}
+
+	// The actor can't hold more than [totalShares], so this can't underflow
+	amountA, err := mulDiv(r.Shares, reserveA, totalShares)
+	if err != nil {
+		return nil, err
+	}
+	amountB, err := mulDiv(r.Shares, reserveB, totalShares)
+	if err != nil {
+		return nil, err
+	}
+	if amountA < r.MinA || amountB < r.MinB {
+		return nil, ErrOutputSlippage
+	}
+	if r.Shares == totalShares {
+		// Remove the pool once all liquidity is withdrawn so that it can be
+		// recreated at a new price.
+		if err := storage.DeletePool(ctx, mu, r.AssetA, r.AssetB); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := storage.SetPool(ctx, mu, r.AssetA, r.AssetB, reserveA-amountA, reserveB-amountB, totalShares-r.Shares); err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.AddBalance(ctx, mu, actor, r.AssetA, amountA, true); err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, actor, r.AssetB, amountB, true); err != nil {
+		return nil, err
+	}
+	lr := &LiquidityResult{AmountA: amountA, AmountB: amountB, Shares: r.Shares}
+	output, err := lr.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{output}, nil
+}
+
+func (*RemoveLiquidity) ComputeUnits(chain.Rules) uint64 {
+	return RemoveLiquidityComputeUnits
+}
+
+func (*RemoveLiquidity) Size() int {
+	return ids.IDLen*2 + consts.Uint64Len*3
+}
+
+func (r *RemoveLiquidity) Marshal(p *codec.Packer) {
+	p.PackID(r.AssetA)
+	p.PackID(r.AssetB)
+	p.PackUint64(r.Shares)
+	p.PackUint64(r.MinA)
+	p.PackUint64(r.MinB)
+}
+
+func UnmarshalRemoveLiquidity(p *codec.Packer) (chain.Action, error) {
+	var remove RemoveLiquidity
+	p.UnpackID(false, &remove.AssetA) // empty ID is the native asset
+	p.UnpackID(false, &remove.AssetB) // empty ID is the native asset
+	remove.Shares = p.UnpackUint64(true)
+	remove.MinA = p.UnpackUint64(false)
+	remove.MinB = p.UnpackUint64(false)
+	return &remove, p.Err()
+}
+
+func (*RemoveLiquidity) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// StreamVested returns the amount of [s] that has been released to the payee
+// (whether or not it has been claimed) by [timestamp].
+//
+// A vesting schedule is a stream whose [Rate] releases [Total] over the
+// vesting period.
+func StreamVested(s *storage.Stream, timestamp int64) uint64 {
+	if timestamp < s.Cliff || timestamp <= s.Start {
+		return 0
+	}
+	elapsed := uint64(timestamp-s.Start) / consts.MillisecondsPerSecond
+	vested, err := smath.Mul64(s.Rate, elapsed)
+	if err != nil {
+		return s.Total
+	}
+	return min(vested, s.Total)
+}
+
+// StreamClaimable returns the amount of [s] that the payee can claim at
+// [timestamp].
+func StreamClaimable(s *storage.Stream, timestamp int64) uint64 {
+	return StreamVested(s, timestamp) - s.Claimed
+}
+
+// StreamResult is a custom successful response output that provides
+// information about funds released from a stream.
+type StreamResult struct {
+	// Paid is the amount sent to the payee.
+	Paid uint64 `json:"paid"`
+
+	// Refunded is the amount returned to the payer when a stream is
+	// cancelled.
+	Refunded uint64 `json:"refunded"`
+}
+
+func UnmarshalStreamResult(b []byte) (*StreamResult, error) {
+	p := codec.NewReader(b, consts.Uint64Len*2)
+	var result StreamResult
+	result.Paid = p.UnpackUint64(false)
+	result.Refunded = p.UnpackUint64(false)
+	return &result, p.Err()
+}
+
+func (s *StreamResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len*2, consts.Uint64Len*2)
+	p.PackUint64(s.Paid)
+	p.PackUint64(s.Refunded)
+	return p.Bytes(), p.Err()
+}
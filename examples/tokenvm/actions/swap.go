@@ -0,0 +1,219 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ chain.RecipientsAction = (*Swap)(nil)
+
+type Swap struct {
+	// [In] is the asset sent to the pool.
+	In ids.ID `json:"in"`
+
+	// [Out] is the asset received from the pool.
+	Out ids.ID `json:"out"`
+
+	// [Value] is the amount of [In] to swap.
+	Value uint64 `json:"value"`
+
+	// [MinOut] is the least amount of [Out] the actor is willing to receive.
+	MinOut uint64 `json:"minOut"`
+
+	// [Royalty] is the royalty recipient of [Out], if it has one. Like
+	// [FillOrder], the royalty is paid from [Value] (and only the rest is sent
+	// to the pool).
+	Royalty codec.Address `json:"royalty"`
+}
+
+func (*Swap) GetTypeID() uint8 {
+	return swapID
+}
+
+func (s *Swap) Recipients() []codec.Address {
+	if s.Royalty == codec.EmptyAddress {
+		return nil
+	}
+	return []codec.Address{s.Royalty}
+}
+
+func (s *Swap) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	assetA, assetB, _ := PoolAssets(s.In, s.Out)
+	keys := state.Keys{
+		string(storage.BalanceKey(actor, s.In)):  state.Read | state.Write,
+		string(storage.BalanceKey(actor, s.Out)): state.All,
+		string(storage.PoolKey(assetA, assetB)):  state.Read | state.Write,
+		string(storage.RoyaltyKey(s.Out)):        state.Read,
+	}
+	if s.Royalty != codec.EmptyAddress {
+		keys[string(storage.BalanceKey(s.Royalty, s.In))] = state.All
+	}
+	addControlKeys(keys, s.In, actor)
+	return addControlKeys(keys, s.Out, actor)
+}
+
+func (s *Swap) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{storage.BalanceChunks, storage.BalanceChunks, storage.PoolChunks, storage.RoyaltyChunks}
+	if s.Royalty != codec.EmptyAddress {
+		chunks = append(chunks, storage.BalanceChunks)
+	}
+	chunks = append(chunks, controlKeysMaxChunks(s.In, 1)...)
+	return append(chunks, controlKeysMaxChunks(s.Out, 1)...)
+}
+
+func (s *Swap) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if s.In == s.Out {
+		return nil, ErrOutputSameInOut
+	}
+	if s.Value == 0 {
+		// This should be guarded via [Unmarshal] but we check anyways.
+		return nil, ErrOutputValueZero
+	}
+	if err := checkControls(ctx, mu, s.In, actor); err != nil {
+		return nil, err
+	}
+	if err := checkControls(ctx, mu, s.Out, actor); err != nil {
+		return nil, err
+	}
+	assetA, assetB, reversed := PoolAssets(s.In, s.Out)
+	exists, reserveA, reserveB, shares, err := storage.GetPool(ctx, mu, assetA, assetB)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputPoolMissing
+	}
+	reserveIn, reserveOut := reserveA, reserveB
+	if reversed {
+		reserveIn, reserveOut = reserveB, reserveA
+	}
+	royalty, err := royaltyAmount(ctx, mu, s.Out, s.Royalty, s.Value)
+	if err != nil {
+		return nil, err
+	}
+	in := s.Value - royalty
+	out := SwapOutput(in, reserveIn, reserveOut)
+	if out == 0 {
+		return nil, ErrOutputInsufficientOutput
+	}
+	if out < s.MinOut {
+		return nil, ErrOutputSlippage
+	}
+	nreserveIn, err := smath.Add64(reserveIn, in)
+	if err != nil {
+		return nil, err
+	}
+	nreserveOut := reserveOut - out // [SwapOutput] is always less than [reserveOut]
+	nreserveA, nreserveB := nreserveIn, nreserveOut
+	if reversed {
+		nreserveA, nreserveB = nreserveOut, nreserveIn
+	}
+	if err := storage.SubBalance(ctx, mu, actor, s.In, s.Value); err != nil {
+		return nil, err
+	}
+	if royalty > 0 {
+		if err := storage.AddBalance(ctx, mu, s.Royalty, s.In, royalty, true); err != nil {
+			return nil, err
+		}
+	}
+	if err := storage.AddBalance(ctx, mu, actor, s.Out, out, true); err != nil {
+		return nil, err
+	}
+	if err := storage.SetPool(ctx, mu, assetA, assetB, nreserveA, nreserveB, shares); err != nil {
+		return nil, err
+	}
+	sr := &SwapResult{In: s.Value, Out: out, Royalty: royalty}
+	output, err := sr.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{output}, nil
+}
+
+func (*Swap) ComputeUnits(chain.Rules) uint64 {
+	return SwapComputeUnits
+}
+
+func (s *Swap) Size() int {
+	size := ids.IDLen*2 + consts.Uint64Len*2 + consts.BoolLen
+	if s.Royalty != codec.EmptyAddress {
+		size += codec.AddressLen
+	}
+	return size
+}
+
+func (s *Swap) Marshal(p *codec.Packer) {
+	p.PackID(s.In)
+	p.PackID(s.Out)
+	p.PackUint64(s.Value)
+	p.PackUint64(s.MinOut)
+	hasRoyalty := s.Royalty != codec.EmptyAddress
+	p.PackBool(hasRoyalty)
+	if hasRoyalty {
+		p.PackAddress(s.Royalty)
+	}
+}
+
+func UnmarshalSwap(p *codec.Packer) (chain.Action, error) {
+	var swap Swap
+	p.UnpackID(false, &swap.In)  // empty ID is the native asset
+	p.UnpackID(false, &swap.Out) // empty ID is the native asset
+	swap.Value = p.UnpackUint64(true)
+	swap.MinOut = p.UnpackUint64(false)
+	if p.UnpackBool() {
+		p.UnpackAddress(&swap.Royalty)
+	}
+	return &swap, p.Err()
+}
+
+func (*Swap) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// SwapResult is a custom successful response output that provides information
+// about a successful swap.
+//
+// [Royalty] is the portion of [In] paid to the royalty recipient of the Out
+// asset instead of the pool.
+type SwapResult struct {
+	In      uint64 `json:"in"`
+	Out     uint64 `json:"out"`
+	Royalty uint64 `json:"royalty"`
+}
+
+func UnmarshalSwapResult(b []byte) (*SwapResult, error) {
+	p := codec.NewReader(b, consts.Uint64Len*3)
+	var result SwapResult
+	result.In = p.UnpackUint64(true)
+	result.Out = p.UnpackUint64(true)
+	result.Royalty = p.UnpackUint64(false)
+	return &result, p.Err()
+}
+
+func (s *SwapResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len*3, consts.Uint64Len*3)
+	p.PackUint64(s.In)
+	p.PackUint64(s.Out)
+	p.PackUint64(s.Royalty)
+	return p.Bytes(), p.Err()
+}
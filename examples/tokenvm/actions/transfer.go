@@ -15,7 +15,7 @@ import (
 	"github.com/ava-labs/hypersdk/state"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var _ chain.RecipientsAction = (*Transfer)(nil)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -38,15 +38,19 @@ func (*Transfer) GetTypeID() uint8 {
 	return transferID
 }
 
+func (t *Transfer) Recipients() []codec.Address {
+	return []codec.Address{t.To}
+}
+
 func (t *Transfer) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
-	return state.Keys{
+	return addControlKeys(state.Keys{
 		string(storage.BalanceKey(actor, t.Asset)): state.Read | state.Write,
 		string(storage.BalanceKey(t.To, t.Asset)):  state.All,
-	}
+	}, t.Asset, actor, t.To)
 }
 
-func (*Transfer) StateKeysMaxChunks() []uint16 {
-	return []uint16{storage.BalanceChunks, storage.BalanceChunks}
+func (t *Transfer) StateKeysMaxChunks() []uint16 {
+	return append([]uint16{storage.BalanceChunks, storage.BalanceChunks}, controlKeysMaxChunks(t.Asset, 2)...)
 }
 
 func (t *Transfer) Execute(
@@ -63,6 +67,9 @@ func (t *Transfer) Execute(
 	if len(t.Memo) > MaxMemoSize {
 		return nil, ErrOutputMemoTooLarge
 	}
+	if err := checkControls(ctx, mu, t.Asset, actor, t.To); err != nil {
+		return nil, err
+	}
 	if err := storage.SubBalance(ctx, mu, actor, t.Asset, t.Value); err != nil {
 		return nil, err
 	}
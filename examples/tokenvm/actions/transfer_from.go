@@ -0,0 +1,119 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.RecipientsAction = (*TransferFrom)(nil)
+
+type TransferFrom struct {
+	// From is the account that approved the actor to spend [Asset].
+	From codec.Address `json:"from"`
+
+	// To is the recipient of the [Value].
+	To codec.Address `json:"to"`
+
+	// Asset to transfer to [To].
+	Asset ids.ID `json:"asset"`
+
+	// Amount are transferred to [To] and deducted from the allowance of the
+	// actor.
+	Value uint64 `json:"value"`
+
+	// Optional message to accompany transaction.
+	Memo []byte `json:"memo"`
+}
+
+func (*TransferFrom) GetTypeID() uint8 {
+	return transferFromID
+}
+
+func (t *TransferFrom) Recipients() []codec.Address {
+	return []codec.Address{t.From, t.To}
+}
+
+func (t *TransferFrom) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	return addControlKeys(state.Keys{
+		string(storage.AllowanceKey(t.From, actor, t.Asset)): state.Read | state.Write,
+		string(storage.BalanceKey(t.From, t.Asset)):          state.Read | state.Write,
+		string(storage.BalanceKey(t.To, t.Asset)):            state.All,
+	}, t.Asset, t.From, t.To)
+}
+
+func (t *TransferFrom) StateKeysMaxChunks() []uint16 {
+	return append(
+		[]uint16{storage.AllowanceChunks, storage.BalanceChunks, storage.BalanceChunks},
+		controlKeysMaxChunks(t.Asset, 2)...,
+	)
+}
+
+func (t *TransferFrom) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if t.Value == 0 {
+		return nil, ErrOutputValueZero
+	}
+	if len(t.Memo) > MaxMemoSize {
+		return nil, ErrOutputMemoTooLarge
+	}
+	if err := checkControls(ctx, mu, t.Asset, t.From, t.To); err != nil {
+		return nil, err
+	}
+	if err := storage.SubAllowance(ctx, mu, t.From, actor, t.Asset, t.Value); err != nil {
+		return nil, err
+	}
+	if err := storage.SubBalance(ctx, mu, t.From, t.Asset, t.Value); err != nil {
+		return nil, err
+	}
+	if err := storage.AddBalance(ctx, mu, t.To, t.Asset, t.Value, true); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*TransferFrom) ComputeUnits(chain.Rules) uint64 {
+	return TransferFromComputeUnits
+}
+
+func (t *TransferFrom) Size() int {
+	return codec.AddressLen*2 + ids.IDLen + consts.Uint64Len + codec.BytesLen(t.Memo)
+}
+
+func (t *TransferFrom) Marshal(p *codec.Packer) {
+	p.PackAddress(t.From)
+	p.PackAddress(t.To)
+	p.PackID(t.Asset)
+	p.PackUint64(t.Value)
+	p.PackBytes(t.Memo)
+}
+
+func UnmarshalTransferFrom(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferFrom
+	p.UnpackAddress(&transfer.From)
+	p.UnpackAddress(&transfer.To)
+	p.UnpackID(false, &transfer.Asset) // empty ID is the native asset
+	transfer.Value = p.UnpackUint64(true)
+	p.UnpackBytes(MaxMemoSize, false, &transfer.Memo)
+	return &transfer, p.Err()
+}
+
+func (*TransferFrom) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
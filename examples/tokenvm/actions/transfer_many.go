@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var _ chain.RecipientsAction = (*TransferMany)(nil)
+
+type TransferMany struct {
+	// Asset to transfer to each of [To].
+	Asset ids.ID `json:"asset"`
+
+	// To are the recipients of [Values]. A recipient may appear more than
+	// once.
+	To []codec.Address `json:"to"`
+
+	// Values[i] is transferred to To[i].
+	Values []uint64 `json:"values"`
+
+	// Optional message to accompany transaction.
+	Memo []byte `json:"memo"`
+}
+
+func (*TransferMany) GetTypeID() uint8 {
+	return transferManyID
+}
+
+func (t *TransferMany) Recipients() []codec.Address {
+	return t.To
+}
+
+func (t *TransferMany) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	keys := make(state.Keys, 1+len(t.To))
+	keys.Add(string(storage.BalanceKey(actor, t.Asset)), state.Read|state.Write)
+	for _, to := range t.To {
+		keys.Add(string(storage.BalanceKey(to, t.Asset)), state.All)
+	}
+	return addControlKeys(keys, t.Asset, append([]codec.Address{actor}, t.To...)...)
+}
+
+func (t *TransferMany) StateKeysMaxChunks() []uint16 {
+	chunks := make([]uint16, 0, 1+len(t.To))
+	chunks = append(chunks, storage.BalanceChunks)
+	for range t.To {
+		chunks = append(chunks, storage.BalanceChunks)
+	}
+	return append(chunks, controlKeysMaxChunks(t.Asset, 1+len(t.To))...)
+}
+
+func (t *TransferMany) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if len(t.To) == 0 {
+		return nil, ErrOutputNoRecipients
+	}
+	if len(t.To) > MaxTransferManyRecipients {
+		return nil, ErrOutputTooManyRecipients
+	}
+	if len(t.To) != len(t.Values) {
+		return nil, ErrOutputValuesMisaligned
+	}
+	if len(t.Memo) > MaxMemoSize {
+		return nil, ErrOutputMemoTooLarge
+	}
+	if err := checkControls(ctx, mu, t.Asset, append([]codec.Address{actor}, t.To...)...); err != nil {
+		return nil, err
+	}
+
+	// Debit the total from the sender before crediting any recipient so that
+	// an insufficient balance fails the action without partial effects.
+	var total uint64
+	for _, value := range t.Values {
+		if value == 0 {
+			return nil, ErrOutputValueZero
+		}
+		ntotal, err := smath.Add64(total, value)
+		if err != nil {
+			return nil, err
+		}
+		total = ntotal
+	}
+	if err := storage.SubBalance(ctx, mu, actor, t.Asset, total); err != nil {
+		return nil, err
+	}
+	for i, to := range t.To {
+		if err := storage.AddBalance(ctx, mu, to, t.Asset, t.Values[i], true); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (t *TransferMany) ComputeUnits(chain.Rules) uint64 {
+	return TransferComputeUnits * uint64(len(t.To))
+}
+
+func (t *TransferMany) Size() int {
+	return ids.IDLen +
+		consts.IntLen + codec.AddressLen*len(t.To) +
+		consts.IntLen + consts.Uint64Len*len(t.Values) +
+		codec.BytesLen(t.Memo)
+}
+
+func (t *TransferMany) Marshal(p *codec.Packer) {
+	p.PackID(t.Asset)
+	p.PackInt(len(t.To))
+	for _, to := range t.To {
+		p.PackAddress(to)
+	}
+	p.PackInt(len(t.Values))
+	for _, value := range t.Values {
+		p.PackUint64(value)
+	}
+	p.PackBytes(t.Memo)
+}
+
+func UnmarshalTransferMany(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferMany
+	p.UnpackID(false, &transfer.Asset) // empty ID is the native asset
+	recipients := p.UnpackInt(true)
+	if recipients > MaxTransferManyRecipients {
+		return nil, ErrOutputTooManyRecipients
+	}
+	transfer.To = make([]codec.Address, recipients)
+	for i := range transfer.To {
+		p.UnpackAddress(&transfer.To[i])
+	}
+	values := p.UnpackInt(true)
+	if values != recipients {
+		return nil, ErrOutputValuesMisaligned
+	}
+	transfer.Values = make([]uint64, values)
+	for i := range transfer.Values {
+		transfer.Values[i] = p.UnpackUint64(true)
+	}
+	p.UnpackBytes(MaxMemoSize, false, &transfer.Memo)
+	return &transfer, p.Err()
+}
+
+func (*TransferMany) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
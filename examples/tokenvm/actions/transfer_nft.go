@@ -0,0 +1,106 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ chain.RecipientsAction = (*TransferNFT)(nil)
+
+type TransferNFT struct {
+	// To is the new owner of the NFT.
+	To codec.Address `json:"to"`
+
+	// Collection is the [ActionID] that created the collection.
+	Collection ids.ID `json:"collection"`
+
+	// ID of the NFT in [Collection].
+	ID uint64 `json:"id"`
+
+	// Optional message to accompany transaction.
+	Memo []byte `json:"memo"`
+}
+
+func (*TransferNFT) GetTypeID() uint8 {
+	return transferNFTID
+}
+
+func (t *TransferNFT) Recipients() []codec.Address {
+	return []codec.Address{t.To}
+}
+
+func (t *TransferNFT) StateKeys(codec.Address, ids.ID) state.Keys {
+	return state.Keys{
+		string(storage.NFTKey(t.Collection, t.ID)): state.Read | state.Write,
+	}
+}
+
+func (*TransferNFT) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.NFTChunks}
+}
+
+func (t *TransferNFT) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if len(t.Memo) > MaxMemoSize {
+		return nil, ErrOutputMemoTooLarge
+	}
+	exists, metadata, owner, err := storage.GetNFT(ctx, mu, t.Collection, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrOutputNFTMissing
+	}
+	if owner != actor {
+		return nil, ErrOutputWrongOwner
+	}
+	if err := storage.SetNFT(ctx, mu, t.Collection, t.ID, metadata, t.To); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*TransferNFT) ComputeUnits(chain.Rules) uint64 {
+	return TransferNFTComputeUnits
+}
+
+func (t *TransferNFT) Size() int {
+	return codec.AddressLen + ids.IDLen + consts.Uint64Len + codec.BytesLen(t.Memo)
+}
+
+func (t *TransferNFT) Marshal(p *codec.Packer) {
+	p.PackAddress(t.To)
+	p.PackID(t.Collection)
+	p.PackUint64(t.ID)
+	p.PackBytes(t.Memo)
+}
+
+func UnmarshalTransferNFT(p *codec.Packer) (chain.Action, error) {
+	var transfer TransferNFT
+	p.UnpackAddress(&transfer.To)
+	p.UnpackID(true, &transfer.Collection)
+	transfer.ID = p.UnpackUint64(false)
+	p.UnpackBytes(MaxMemoSize, false, &transfer.Memo)
+	return &transfer, p.Err()
+}
+
+func (*TransferNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
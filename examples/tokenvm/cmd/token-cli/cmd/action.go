@@ -6,12 +6,12 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/chain"
-	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/utils"
@@ -68,7 +68,7 @@ var fundFaucetCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		addr, err := codec.ParseAddressBech32(tconsts.HRP, faucetAddress)
+		addr, err := tconsts.AddressFormat.Parse(faucetAddress)
 		if err != nil {
 			return err
 		}
@@ -204,7 +204,7 @@ var mintAssetCmd = &cobra.Command{
 			utils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
 		}
-		if owner != codec.MustAddressBech32(tconsts.HRP, priv.Address) {
+		if owner != tconsts.AddressFormat.Encode(priv.Address) {
 			utils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", owner, assetID)
 			utils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
@@ -245,6 +245,60 @@ var mintAssetCmd = &cobra.Command{
 	},
 }
 
+var bridgeBurnCmd = &cobra.Command{
+	Use: "bridge-burn",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select bridged token to burn
+		assetID, err := handler.Root().PromptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		_, decimals, balance, _, err := handler.GetAssetInfo(ctx, tcli, priv.Address, assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient on the chain the token was bridged from
+		recipient, err := handler.Root().PromptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := handler.Root().PromptAmount("amount", decimals, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select reward of the relayer
+		fee, err := handler.Root().PromptAmount("relay fee", decimals, balance-amount, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		_, err = sendAndWait(ctx, []chain.Action{&actions.BridgeBurn{
+			Asset: assetID,
+			To:    recipient,
+			Value: amount,
+			Fee:   fee,
+		}}, cli, scli, tcli, factory)
+		return err
+	},
+}
+
 var closeOrderCmd = &cobra.Command{
 	Use: "close-order",
 	RunE: func(*cobra.Command, []string) error {
@@ -281,6 +335,51 @@ var closeOrderCmd = &cobra.Command{
 	},
 }
 
+var closeAllOrdersCmd = &cobra.Command{
+	Use: "close-all-orders",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		// View open orders
+		orders, err := tcli.OwnerOrders(ctx, tconsts.AddressFormat.Encode(priv.Address))
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			utils.Outf("{{red}}no open orders{{/}}\n")
+			utils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if len(orders) > actions.MaxCloseAllOrders {
+			utils.Outf("{{yellow}}closing first %d of %d open orders{{/}}\n", actions.MaxCloseAllOrders, len(orders))
+			orders = orders[:actions.MaxCloseAllOrders]
+		}
+		action := &actions.CloseAllOrders{
+			Orders: make([]ids.ID, len(orders)),
+			Out:    make([]ids.ID, len(orders)),
+		}
+		for i, order := range orders {
+			utils.Outf("{{yellow}}orderID:{{/}} %s {{yellow}}out assetID:{{/}} %s\n", order.ID, order.OutAsset)
+			action.Orders[i] = order.ID
+			action.Out[i] = order.OutAsset
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		_, err = sendAndWait(ctx, []chain.Action{action}, cli, scli, tcli, factory)
+		return err
+	},
+}
+
 var createOrderCmd = &cobra.Command{
 	Use: "create-order",
 	RunE: func(*cobra.Command, []string) error {
@@ -352,6 +451,20 @@ var createOrderCmd = &cobra.Command{
 			return err
 		}
 
+		// Select expiry
+		var expiry int64
+		expires, err := handler.Root().PromptBool("expire order")
+		if err != nil {
+			return err
+		}
+		if expires {
+			seconds, err := handler.Root().PromptInt("expiry (seconds from now)", consts.MaxInt)
+			if err != nil {
+				return err
+			}
+			expiry = time.Now().Add(time.Duration(seconds) * time.Second).UnixMilli()
+		}
+
 		// Confirm action
 		cont, err := handler.Root().PromptContinue()
 		if !cont || err != nil {
@@ -365,6 +478,7 @@ var createOrderCmd = &cobra.Command{
 			Out:     outAssetID,
 			OutTick: outTick,
 			Supply:  supply,
+			Expiry:  expiry,
 		}}, cli, scli, tcli, factory)
 		if err != nil {
 			return err
@@ -479,16 +593,21 @@ var fillOrderCmd = &cobra.Command{
 			return err
 		}
 
-		owner, err := codec.ParseAddressBech32(tconsts.HRP, order.Owner)
+		owner, err := tconsts.AddressFormat.Parse(order.Owner)
+		if err != nil {
+			return err
+		}
+		royalty, err := tcli.RoyaltyRecipient(ctx, outAssetID)
 		if err != nil {
 			return err
 		}
 		_, err = sendAndWait(ctx, []chain.Action{&actions.FillOrder{
-			Order: order.ID,
-			Owner: owner,
-			In:    inAssetID,
-			Out:   outAssetID,
-			Value: value,
+			Order:   order.ID,
+			Owner:   owner,
+			In:      inAssetID,
+			Out:     outAssetID,
+			Value:   value,
+			Royalty: royalty,
 		}}, cli, scli, tcli, factory)
 		return err
 	},
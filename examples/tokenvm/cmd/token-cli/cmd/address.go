@@ -0,0 +1,88 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var addressCmd = &cobra.Command{
+	Use: "address",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+var addAddressCmd = &cobra.Command{
+	Use: "add [label] [address]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		addr, err := handler.Root().ResolveAddress(args[1])
+		if err != nil {
+			return err
+		}
+		if err := handler.Root().StoreLabel(args[0], addr); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}labeled address:{{/}} %s\n", handler.Root().FormatAddress(addr))
+		return nil
+	},
+}
+
+var removeAddressCmd = &cobra.Command{
+	Use: "remove [label]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		if err := handler.Root().DeleteLabel(args[0]); err != nil {
+			return err
+		}
+		utils.Outf("{{green}}removed label:{{/}} %s\n", args[0])
+		return nil
+	},
+}
+
+var listAddressCmd = &cobra.Command{
+	Use: "list",
+	RunE: func(*cobra.Command, []string) error {
+		return handler.Root().PrintAddressBook()
+	},
+}
+
+var importAddressCmd = &cobra.Command{
+	Use: "import [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return handler.Root().ImportAddressBook(args[0])
+	},
+}
+
+var exportAddressCmd = &cobra.Command{
+	Use: "export [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return handler.Root().ExportAddressBook(args[0])
+	},
+}
@@ -6,11 +6,14 @@ package cmd
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 
 	trpc "github.com/ava-labs/hypersdk/examples/tokenvm/rpc"
 )
@@ -63,11 +66,72 @@ var chainInfoCmd = &cobra.Command{
 	},
 }
 
+var unitProfileChainCmd = &cobra.Command{
+	Use: "unit-profile [start] [end]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		start, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		end, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		return handler.Root().PrintUnitProfile(start, end)
+	},
+}
+
+// actionTypes maps the names accepted by "chain watch --action" to action
+// type IDs.
+var actionTypes = map[string]uint8{
+	"transfer":          (&actions.Transfer{}).GetTypeID(),
+	"create-asset":      (&actions.CreateAsset{}).GetTypeID(),
+	"mint-asset":        (&actions.MintAsset{}).GetTypeID(),
+	"burn-asset":        (&actions.BurnAsset{}).GetTypeID(),
+	"create-order":      (&actions.CreateOrder{}).GetTypeID(),
+	"fill-order":        (&actions.FillOrder{}).GetTypeID(),
+	"close-order":       (&actions.CloseOrder{}).GetTypeID(),
+	"create-collection": (&actions.CreateCollection{}).GetTypeID(),
+	"mint-nft":          (&actions.MintNFT{}).GetTypeID(),
+	"transfer-nft":      (&actions.TransferNFT{}).GetTypeID(),
+	"burn-nft":          (&actions.BurnNFT{}).GetTypeID(),
+	"close-all-orders":  (&actions.CloseAllOrders{}).GetTypeID(),
+	"create-pool":       (&actions.CreatePool{}).GetTypeID(),
+	"add-liquidity":     (&actions.AddLiquidity{}).GetTypeID(),
+	"remove-liquidity":  (&actions.RemoveLiquidity{}).GetTypeID(),
+	"swap":              (&actions.Swap{}).GetTypeID(),
+	"freeze-account":    (&actions.FreezeAccount{}).GetTypeID(),
+	"pause-asset":       (&actions.PauseAsset{}).GetTypeID(),
+	"approve":           (&actions.Approve{}).GetTypeID(),
+	"transfer-from":     (&actions.TransferFrom{}).GetTypeID(),
+	"transfer-many":     (&actions.TransferMany{}).GetTypeID(),
+	"create-stream":     (&actions.CreateStream{}).GetTypeID(),
+	"claim-stream":      (&actions.ClaimStream{}).GetTypeID(),
+	"cancel-stream":     (&actions.CancelStream{}).GetTypeID(),
+	"create-airdrop":    (&actions.CreateAirdrop{}).GetTypeID(),
+	"claim-airdrop":     (&actions.ClaimAirdrop{}).GetTypeID(),
+	"reclaim-airdrop":   (&actions.ReclaimAirdrop{}).GetTypeID(),
+}
+
 var watchChainCmd = &cobra.Command{
 	Use: "watch",
 	RunE: func(_ *cobra.Command, args []string) error {
+		types, err := cli.ParseActionTypes(filterActions, actionTypes)
+		if err != nil {
+			return err
+		}
+		filter, err := handler.Root().ParseTxFilter(filterAddresses, types, filterMinFee, filterFailedOnly)
+		if err != nil {
+			return err
+		}
 		var cli *trpc.JSONRPCClient
-		return handler.Root().WatchChain(hideTxs, func(uri string, networkID uint32, chainID ids.ID) (chain.Parser, error) {
+		return handler.Root().WatchChain(hideTxs, filter, func(uri string, networkID uint32, chainID ids.ID) (chain.Parser, error) {
 			cli = trpc.NewJSONRPCClient(uri, networkID, chainID)
 			return cli.Parser(context.TODO())
 		}, func(tx *chain.Transaction, result *chain.Result) {
@@ -79,3 +143,16 @@ var watchChainCmd = &cobra.Command{
 		})
 	},
 }
+
+var dashboardChainCmd = &cobra.Command{
+	Use: "dashboard",
+	RunE: func(*cobra.Command, []string) error {
+		var cli *trpc.JSONRPCClient
+		return handler.Root().Dashboard(watchAddresses, func(uri string, networkID uint32, chainID ids.ID) (chain.Parser, error) {
+			cli = trpc.NewJSONRPCClient(uri, networkID, chainID)
+			return cli.Parser(context.TODO())
+		}, func(ctx context.Context, _ string, _ uint32, _ ids.ID, addr string) (uint64, error) {
+			return cli.Balance(ctx, addr, ids.Empty)
+		})
+	},
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/devnet"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
+)
+
+var devnetCmd = &cobra.Command{
+	Use: "devnet",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+func devnetGenesis(funded []codec.Address) ([]byte, error) {
+	g := genesis.Default()
+	g.CustomAllocation = make([]*genesis.CustomAllocation, len(funded))
+	for i, addr := range funded {
+		g.CustomAllocation[i] = &genesis.CustomAllocation{
+			Address: consts.AddressFormat.Encode(addr),
+			Balance: devnetBalance,
+		}
+	}
+	return json.Marshal(g)
+}
+
+var startDevnetCmd = &cobra.Command{
+	Use:   "start [options]",
+	Short: "Starts a local network and imports its chain and funded keys",
+	RunE: func(*cobra.Command, []string) error {
+		cfg := devnet.DefaultConfig()
+		cfg.RunnerEndpoint = devnetRunnerEndpoint
+		cfg.RunnerPath = devnetRunnerPath
+		cfg.ExecPath = devnetExecPath
+		cfg.PluginDir = devnetPluginDir
+		cfg.VMName = consts.Name
+		cfg.Genesis = devnetGenesis
+		cfg.Validators = devnetValidators
+		cfg.InitialValidators = initialValidators
+		cfg.StakeInterval = devnetStakeInterval
+		cfg.FundedKeys = devnetFundedKeys
+		cfg.LogLevel = devnetLogLevel
+		if len(devnetChainConfig) > 0 {
+			b, err := os.ReadFile(devnetChainConfig)
+			if err != nil {
+				return err
+			}
+			cfg.ChainConfig = b
+		}
+		if len(devnetSubnetConfig) > 0 {
+			b, err := os.ReadFile(devnetSubnetConfig)
+			if err != nil {
+				return err
+			}
+			cfg.SubnetConfig = b
+		}
+		return handler.Root().RunDevnet(cfg)
+	},
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/auth"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli"
@@ -64,10 +65,7 @@ func (*Handler) GetAssetInfo(
 	if !checkBalance {
 		return symbol, decimals, 0, sourceChainID, nil
 	}
-	saddr, err := codec.AddressBech32(consts.HRP, addr)
-	if err != nil {
-		return nil, 0, 0, ids.Empty, err
-	}
+	saddr := consts.AddressFormat.Encode(addr)
 	balance, err := cli.Balance(ctx, saddr, assetID)
 	if err != nil {
 		return nil, 0, 0, ids.Empty, err
@@ -121,18 +119,29 @@ func (h *Handler) DefaultActor() (
 		), nil
 }
 
+var _ cli.AuditController = (*Controller)(nil)
+
 type Controller struct {
 	databasePath string
+	auditPath    string
 }
 
-func NewController(databasePath string) *Controller {
-	return &Controller{databasePath}
+func NewController(databasePath string, auditPath string) *Controller {
+	return &Controller{databasePath, auditPath}
 }
 
 func (c *Controller) DatabasePath() string {
 	return c.databasePath
 }
 
+// AuditConfig enables the audit log if an audit path was provided.
+func (c *Controller) AuditConfig() audit.Config {
+	config := audit.NewDefaultConfig()
+	config.Enabled = len(c.auditPath) > 0
+	config.Directory = c.auditPath
+	return config
+}
+
 func (*Controller) Symbol() string {
 	return consts.Symbol
 }
@@ -142,9 +151,9 @@ func (*Controller) Decimals() uint8 {
 }
 
 func (*Controller) Address(addr codec.Address) string {
-	return codec.MustAddressBech32(consts.HRP, addr)
+	return consts.AddressFormat.Encode(addr)
 }
 
 func (*Controller) ParseAddress(address string) (codec.Address, error) {
-	return codec.ParseAddressBech32(consts.HRP, address)
+	return consts.AddressFormat.Parse(address)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/hd"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/challenge"
 	"github.com/ava-labs/hypersdk/utils"
 
@@ -49,7 +50,7 @@ var genKeyCmd = &cobra.Command{
 		}
 		utils.Outf(
 			"{{green}}created address:{{/}} %s",
-			codec.MustAddressBech32(tconsts.HRP, priv.Address),
+			tconsts.AddressFormat.Encode(priv.Address),
 		)
 		return nil
 	},
@@ -64,7 +65,7 @@ var importKeyCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(_ *cobra.Command, args []string) error {
-		p, err := utils.LoadBytes(args[0], ed25519.PrivateKeyLen)
+		p, err := handler.Root().LoadKeyFile(args[0], ed25519.PrivateKeyLen)
 		if err != nil {
 			return err
 		}
@@ -81,12 +82,72 @@ var importKeyCmd = &cobra.Command{
 		}
 		utils.Outf(
 			"{{green}}imported address:{{/}} %s",
-			codec.MustAddressBech32(tconsts.HRP, priv.Address),
+			tconsts.AddressFormat.Encode(priv.Address),
 		)
 		return nil
 	},
 }
 
+// storeMnemonicAccount stores the ed25519 key of [mnemonicAccount] derived
+// from [mnemonic] and makes it the default key.
+func storeMnemonicAccount(mnemonic string) error {
+	p, err := hd.DeriveED25519Account(mnemonic, "", mnemonicAccount)
+	if err != nil {
+		return err
+	}
+	priv := &cli.PrivateKey{
+		Address: auth.NewED25519Address(p.PublicKey()),
+		Bytes:   p[:],
+	}
+	if err := handler.h.StoreKey(priv); err != nil {
+		return err
+	}
+	if err := handler.h.StoreDefaultKey(priv.Address); err != nil {
+		return err
+	}
+	utils.Outf(
+		"{{green}}stored account %d:{{/}} %s\n",
+		mnemonicAccount,
+		tconsts.AddressFormat.Encode(priv.Address),
+	)
+	return nil
+}
+
+var generateMnemonicKeyCmd = &cobra.Command{
+	Use: "generate-mnemonic",
+	RunE: func(*cobra.Command, []string) error {
+		mnemonic, err := handler.Root().GenerateMnemonic()
+		if err != nil {
+			return err
+		}
+		return storeMnemonicAccount(mnemonic)
+	},
+}
+
+var importMnemonicKeyCmd = &cobra.Command{
+	Use: "import-mnemonic",
+	RunE: func(*cobra.Command, []string) error {
+		mnemonic, err := handler.Root().PromptMnemonic("mnemonic")
+		if err != nil {
+			return err
+		}
+		return storeMnemonicAccount(mnemonic)
+	},
+}
+
+var exportKeyCmd = &cobra.Command{
+	Use: "export [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return handler.Root().ExportKey(args[0])
+	},
+}
+
 func lookupSetKeyBalance(choice int, address string, uri string, networkID uint32, chainID ids.ID) error {
 	// TODO: just load once
 	cli := trpc.NewJSONRPCClient(uri, networkID, chainID)
@@ -156,7 +217,7 @@ var faucetKeyCmd = &cobra.Command{
 		start := time.Now()
 		solution, attempts := challenge.Search(salt, difficulty, numCores)
 		utils.Outf("{{cyan}}found solution (attempts=%d, t=%s):{{/}} %x\n", attempts, time.Since(start), solution)
-		txID, amount, err := fcli.SolveChallenge(ctx, codec.MustAddressBech32(tconsts.HRP, priv.Address), salt, solution)
+		txID, amount, err := fcli.SolveChallenge(ctx, tconsts.AddressFormat.Encode(priv.Address), salt, solution)
 		if err != nil {
 			return err
 		}
@@ -156,3 +156,11 @@ var generatePrometheusCmd = &cobra.Command{
 		})
 	},
 }
+
+var aggregatePrometheusCmd = &cobra.Command{
+	Use:   "aggregate",
+	Short: "Serves chain-wide metrics aggregated from all nodes of a chain",
+	RunE: func(*cobra.Command, []string) error {
+		return handler.Root().AggregateMetrics(aggregateListen, aggregateInterval)
+	},
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/cli"
-	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
@@ -65,7 +64,7 @@ func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result
 			"%s {{yellow}}%s{{/}} {{yellow}}actor:{{/}} %s {{yellow}}error:{{/}} [%s] {{yellow}}fee (max %.2f%%):{{/}} %s %s {{yellow}}consumed:{{/}} [%s]\n",
 			"❌",
 			tx.ID(),
-			codec.MustAddressBech32(tconsts.HRP, actor),
+			handler.Root().FormatAddress(actor),
 			result.Error,
 			float64(result.Fee)/float64(tx.Base.MaxFee)*100,
 			utils.FormatBalance(result.Fee, tconsts.Decimals),
@@ -88,7 +87,7 @@ func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result
 				return
 			}
 			amountStr := utils.FormatBalance(action.Value, decimals)
-			summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+			summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, handler.Root().FormatAddress(action.To))
 		case *actions.BurnAsset:
 			summaryStr = fmt.Sprintf("%d %s -> 🔥", action.Value, action.Asset)
 		case *actions.Transfer:
@@ -98,7 +97,7 @@ func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result
 				return
 			}
 			amountStr := utils.FormatBalance(action.Value, decimals)
-			summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+			summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, symbol, handler.Root().FormatAddress(action.To))
 			if len(action.Memo) > 0 {
 				summaryStr += fmt.Sprintf(" (memo: %s)", action.Memo)
 			}
@@ -119,6 +118,10 @@ func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result
 			summaryStr = fmt.Sprintf("%s %s -> %s %s (supply: %s %s)", inTickStr, inSymbol, outTickStr, outSymbol, supplyStr, outSymbol)
 		case *actions.FillOrder:
 			or, _ := actions.UnmarshalOrderResult(result.Outputs[i][0])
+			if or.In == 0 {
+				summaryStr = fmt.Sprintf("orderID: %s (expired)", action.Order)
+				break
+			}
 			_, inSymbol, inDecimals, _, _, _, err := c.Asset(context.TODO(), action.In, true)
 			if err != nil {
 				utils.Outf("{{red}}could not fetch asset info:{{/}} %v", err)
@@ -138,12 +141,14 @@ func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result
 			)
 		case *actions.CloseOrder:
 			summaryStr = fmt.Sprintf("orderID: %s", action.Order)
+		case *actions.CloseAllOrders:
+			summaryStr = fmt.Sprintf("orders: %d", len(action.Orders))
 		}
 		utils.Outf(
 			"%s {{yellow}}%s{{/}} {{yellow}}actor:{{/}} %s {{yellow}}summary (%s):{{/}} [%s] {{yellow}}fee (max %.2f%%):{{/}} %s %s {{yellow}}consumed:{{/}} [%s]\n",
 			"✅",
 			tx.ID(),
-			codec.MustAddressBech32(tconsts.HRP, actor),
+			handler.Root().FormatAddress(actor),
 			reflect.TypeOf(act),
 			summaryStr,
 			float64(result.Fee)/float64(tx.Base.MaxFee)*100,
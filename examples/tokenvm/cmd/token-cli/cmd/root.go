@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ava-labs/hypersdk/cli"
+	"github.com/ava-labs/hypersdk/devnet"
 	"github.com/ava-labs/hypersdk/utils"
 )
 
@@ -23,19 +24,41 @@ var (
 	handler *Handler
 
 	dbPath                string
+	auditPath             string
 	genesisFile           string
 	minBlockGap           int64
 	minUnitPrice          []string
 	maxBlockUnits         []string
 	windowTargetUnits     []string
 	hideTxs               bool
+	watchAddresses        []string
+	filterAddresses       []string
+	filterActions         []string
+	filterMinFee          string
+	filterFailedOnly      bool
 	checkAllChains        bool
+	mnemonicAccount       uint32
+	workloadFile          string
 	prometheusBaseURI     string
 	prometheusOpenBrowser bool
 	prometheusFile        string
 	prometheusData        string
 	startPrometheus       bool
 	numCores              int
+	aggregateListen       string
+	aggregateInterval     time.Duration
+	devnetRunnerEndpoint  string
+	devnetRunnerPath      string
+	devnetExecPath        string
+	devnetPluginDir       string
+	devnetValidators      int
+	initialValidators     int
+	devnetStakeInterval   time.Duration
+	devnetFundedKeys      int
+	devnetBalance         uint64
+	devnetChainConfig     string
+	devnetSubnetConfig    string
+	devnetLogLevel        string
 
 	rootCmd = &cobra.Command{
 		Use:        "token-cli",
@@ -49,10 +72,12 @@ func init() {
 	rootCmd.AddCommand(
 		genesisCmd,
 		keyCmd,
+		addressCmd,
 		chainCmd,
 		actionCmd,
 		spamCmd,
 		prometheusCmd,
+		devnetCmd,
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&dbPath,
@@ -60,9 +85,15 @@ func init() {
 		defaultDatabase,
 		"path to database (will create it missing)",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&auditPath,
+		"audit-log",
+		"",
+		"directory of the audit log recording the use of stored keys (disabled if empty)",
+	)
 	rootCmd.PersistentPreRunE = func(*cobra.Command, []string) error {
 		utils.Outf("{{yellow}}database:{{/}} %s\n", dbPath)
-		controller := NewController(dbPath)
+		controller := NewController(dbPath, auditPath)
 		root, err := cli.New(controller)
 		if err != nil {
 			return err
@@ -123,14 +154,34 @@ func init() {
 		4,
 		"number of cores to use when searching for faucet solutions",
 	)
+	for _, cmd := range []*cobra.Command{generateMnemonicKeyCmd, importMnemonicKeyCmd} {
+		cmd.PersistentFlags().Uint32Var(
+			&mnemonicAccount,
+			"account",
+			0,
+			"index of the account to derive from the mnemonic",
+		)
+	}
 	keyCmd.AddCommand(
 		genKeyCmd,
 		importKeyCmd,
+		generateMnemonicKeyCmd,
+		importMnemonicKeyCmd,
+		exportKeyCmd,
 		setKeyCmd,
 		balanceKeyCmd,
 		faucetKeyCmd,
 	)
 
+	// address
+	addressCmd.AddCommand(
+		addAddressCmd,
+		removeAddressCmd,
+		listAddressCmd,
+		importAddressCmd,
+		exportAddressCmd,
+	)
+
 	// chain
 	watchChainCmd.PersistentFlags().BoolVar(
 		&hideTxs,
@@ -138,13 +189,45 @@ func init() {
 		false,
 		"hide txs",
 	)
+	watchChainCmd.PersistentFlags().StringSliceVar(
+		&filterAddresses,
+		"address",
+		[]string{},
+		"only show txs sent by or referencing these addresses",
+	)
+	watchChainCmd.PersistentFlags().StringSliceVar(
+		&filterActions,
+		"action",
+		[]string{},
+		"only show txs containing these action types",
+	)
+	watchChainCmd.PersistentFlags().StringVar(
+		&filterMinFee,
+		"min-fee",
+		"",
+		"only show txs that paid at least this fee",
+	)
+	watchChainCmd.PersistentFlags().BoolVar(
+		&filterFailedOnly,
+		"failed-only",
+		false,
+		"only show failed txs",
+	)
+	dashboardChainCmd.PersistentFlags().StringSliceVar(
+		&watchAddresses,
+		"watch",
+		[]string{},
+		"addresses to show balances of (defaults to all stored keys)",
+	)
 	chainCmd.AddCommand(
 		importChainCmd,
 		importANRChainCmd,
 		importAvalancheOpsChainCmd,
 		setChainCmd,
 		chainInfoCmd,
+		unitProfileChainCmd,
 		watchChainCmd,
+		dashboardChainCmd,
 	)
 
 	// actions
@@ -156,13 +239,21 @@ func init() {
 		createAssetCmd,
 		mintAssetCmd,
 		// burnAssetCmd,
+		bridgeBurnCmd,
 
 		createOrderCmd,
 		fillOrderCmd,
 		closeOrderCmd,
+		closeAllOrdersCmd,
 	)
 
 	// spam
+	runSpamCmd.PersistentFlags().StringVar(
+		&workloadFile,
+		"workload",
+		"",
+		"path to a workload config (skips prompts and reports latency on exit)",
+	)
 	spamCmd.AddCommand(
 		runSpamCmd,
 	)
@@ -198,8 +289,98 @@ func init() {
 		true,
 		"start local prometheus server",
 	)
+	aggregatePrometheusCmd.PersistentFlags().StringVar(
+		&aggregateListen,
+		"listen",
+		"127.0.0.1:9095",
+		"address to serve aggregated metrics on",
+	)
+	aggregatePrometheusCmd.PersistentFlags().DurationVar(
+		&aggregateInterval,
+		"interval",
+		5*time.Second,
+		"how often to scrape each node",
+	)
 	prometheusCmd.AddCommand(
 		generatePrometheusCmd,
+		aggregatePrometheusCmd,
+	)
+
+	// devnet
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetRunnerEndpoint,
+		"runner-endpoint",
+		devnet.DefaultRunnerEndpoint,
+		"avalanche-network-runner gRPC endpoint",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetRunnerPath,
+		"runner-path",
+		"",
+		"avalanche-network-runner binary to start a server with (uses a running server if empty)",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetExecPath,
+		"avalanchego-path",
+		"",
+		"avalanchego binary",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetPluginDir,
+		"plugin-dir",
+		"",
+		"directory containing the vm plugin",
+	)
+	startDevnetCmd.PersistentFlags().IntVar(
+		&devnetValidators,
+		"validators",
+		devnet.DefaultValidators,
+		"number of validators",
+	)
+	startDevnetCmd.PersistentFlags().IntVar(
+		&initialValidators,
+		"initial-validators",
+		0,
+		"number of validators when the chain is created (defaults to all)",
+	)
+	startDevnetCmd.PersistentFlags().DurationVar(
+		&devnetStakeInterval,
+		"stake-interval",
+		0,
+		"delay between adding each of the remaining validators",
+	)
+	startDevnetCmd.PersistentFlags().IntVar(
+		&devnetFundedKeys,
+		"funded-keys",
+		1,
+		"number of keys to fund in genesis",
+	)
+	startDevnetCmd.PersistentFlags().Uint64Var(
+		&devnetBalance,
+		"balance",
+		10_000_000_000_000_000_000,
+		"genesis balance of each funded key",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetChainConfig,
+		"chain-config",
+		"",
+		"chain config file path",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetSubnetConfig,
+		"subnet-config",
+		"",
+		"subnet config file path",
+	)
+	startDevnetCmd.PersistentFlags().StringVar(
+		&devnetLogLevel,
+		"log-level",
+		devnet.DefaultLogLevel,
+		"avalanchego log level",
+	)
+	devnetCmd.AddCommand(
+		startDevnetCmd,
 	)
 }
 
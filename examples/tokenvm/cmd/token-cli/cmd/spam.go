@@ -91,6 +91,14 @@ var spamCmd = &cobra.Command{
 var runSpamCmd = &cobra.Command{
 	Use: "run",
 	RunE: func(*cobra.Command, []string) error {
-		return handler.Root().Spam(&SpamHelper{})
+		var workload *cli.Workload
+		if len(workloadFile) > 0 {
+			var err error
+			workload, err = cli.LoadWorkload(workloadFile)
+			if err != nil {
+				return err
+			}
+		}
+		return handler.Root().Spam(&SpamHelper{}, workload)
 	},
 }
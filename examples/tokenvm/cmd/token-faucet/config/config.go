@@ -32,5 +32,5 @@ func (c *Config) Address() codec.Address {
 }
 
 func (c *Config) AddressBech32() string {
-	return codec.MustAddressBech32(consts.HRP, c.Address())
+	return consts.AddressFormat.Encode(c.Address())
 }
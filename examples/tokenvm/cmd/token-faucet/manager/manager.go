@@ -173,7 +173,7 @@ func (m *Manager) SolveChallenge(ctx context.Context, solver codec.Address, salt
 	m.log.Info("fauceted funds",
 		zap.Stringer("txID", txID),
 		zap.String("max fee", utils.FormatBalance(maxFee, consts.Decimals)),
-		zap.String("destination", codec.MustAddressBech32(consts.HRP, solver)),
+		zap.String("destination", consts.AddressFormat.Encode(solver)),
 		zap.String("amount", utils.FormatBalance(m.config.Amount, consts.Decimals)),
 	)
 	m.solutions.Add(solutionID)
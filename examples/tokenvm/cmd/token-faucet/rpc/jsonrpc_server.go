@@ -8,7 +8,6 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 
-	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 )
 
@@ -29,7 +28,7 @@ func (j *JSONRPCServer) FaucetAddress(req *http.Request, _ *struct{}, reply *Fau
 	if err != nil {
 		return err
 	}
-	reply.Address = codec.MustAddressBech32(consts.HRP, addr)
+	reply.Address = consts.AddressFormat.Encode(addr)
 	return nil
 }
 
@@ -60,7 +59,7 @@ type SolveChallengeReply struct {
 }
 
 func (j *JSONRPCServer) SolveChallenge(req *http.Request, args *SolveChallengeArgs, reply *SolveChallengeReply) error {
-	addr, err := codec.ParseAddressBech32(consts.HRP, args.Address)
+	addr, err := consts.AddressFormat.Parse(args.Address)
 	if err != nil {
 		return err
 	}
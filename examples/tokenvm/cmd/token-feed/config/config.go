@@ -28,7 +28,7 @@ func (c *Config) RecipientAddress() (codec.Address, error) {
 	if c.recipientAddr != codec.EmptyAddress {
 		return c.recipientAddr, nil
 	}
-	addr, err := codec.ParseAddressBech32(consts.HRP, c.Recipient)
+	addr, err := consts.AddressFormat.Parse(c.Recipient)
 	if err == nil {
 		c.recipientAddr = addr
 	}
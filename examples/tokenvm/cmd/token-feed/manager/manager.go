@@ -146,7 +146,7 @@ func (m *Manager) Run(ctx context.Context) error {
 					}
 					result := results[i]
 					from := tx.Auth.Actor()
-					fromStr := codec.MustAddressBech32(consts.HRP, from)
+					fromStr := consts.AddressFormat.Encode(from)
 					if !result.Success {
 						m.log.Info("incoming message failed on-chain", zap.String("from", fromStr), zap.String("memo", string(action.Memo)), zap.Uint64("payment", action.Value), zap.Uint64("required", m.feeAmount))
 						continue
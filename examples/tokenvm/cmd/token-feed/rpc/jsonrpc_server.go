@@ -6,7 +6,6 @@ package rpc
 import (
 	"net/http"
 
-	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/cmd/token-feed/manager"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 )
@@ -29,7 +28,7 @@ func (j *JSONRPCServer) FeedInfo(req *http.Request, _ *struct{}, reply *FeedInfo
 	if err != nil {
 		return err
 	}
-	reply.Address = codec.MustAddressBech32(consts.HRP, addr)
+	reply.Address = consts.AddressFormat.Encode(addr)
 	reply.Fee = fee
 	return nil
 }
@@ -134,7 +134,7 @@ func (b *Backend) Start(ctx context.Context) error {
 	b.priv = key
 	b.factory = auth.NewED25519Factory(b.priv)
 	b.addr = auth.NewED25519Address(b.priv.PublicKey())
-	b.addrStr = codec.MustAddressBech32(tconsts.HRP, b.addr)
+	b.addrStr = tconsts.AddressFormat.Encode(b.addr)
 	if err := b.AddAddressBook("Me", b.addrStr); err != nil {
 		return err
 	}
@@ -233,13 +233,13 @@ func (b *Backend) collectBlocks() {
 						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
 						Success:   result.Success,
 						Timestamp: blk.Tmstmp,
-						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Actor:     tconsts.AddressFormat.Encode(actor),
 						Type:      "Transfer",
 						Units:     hcli.ParseDimensions(result.Units),
 						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
 					}
 					if result.Success {
-						txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+						txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, tconsts.AddressFormat.Encode(action.To))
 						if len(action.Memo) > 0 {
 							txInfo.Summary += fmt.Sprintf(" (memo: %s)", action.Memo)
 						}
@@ -287,7 +287,7 @@ func (b *Backend) collectBlocks() {
 						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
 						Success:   result.Success,
 						Timestamp: blk.Tmstmp,
-						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Actor:     tconsts.AddressFormat.Encode(actor),
 						Type:      "CreateAsset",
 						Units:     hcli.ParseDimensions(result.Units),
 						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
@@ -316,13 +316,13 @@ func (b *Backend) collectBlocks() {
 						Timestamp: blk.Tmstmp,
 						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
 						Success:   result.Success,
-						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Actor:     tconsts.AddressFormat.Encode(actor),
 						Type:      "Mint",
 						Units:     hcli.ParseDimensions(result.Units),
 						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
 					}
 					if result.Success {
-						txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, codec.MustAddressBech32(tconsts.HRP, action.To))
+						txInfo.Summary = fmt.Sprintf("%s %s -> %s", hutils.FormatBalance(action.Value, decimals), symbol, tconsts.AddressFormat.Encode(action.To))
 					} else {
 						txInfo.Summary = string(result.Error)
 					}
@@ -373,7 +373,7 @@ func (b *Backend) collectBlocks() {
 						Timestamp: blk.Tmstmp,
 						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
 						Success:   result.Success,
-						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Actor:     tconsts.AddressFormat.Encode(actor),
 						Type:      "CreateOrder",
 						Units:     hcli.ParseDimensions(result.Units),
 						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
@@ -414,7 +414,7 @@ func (b *Backend) collectBlocks() {
 						Timestamp: blk.Tmstmp,
 						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
 						Success:   result.Success,
-						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Actor:     tconsts.AddressFormat.Encode(actor),
 						Type:      "FillOrder",
 						Units:     hcli.ParseDimensions(result.Units),
 						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
@@ -456,7 +456,7 @@ func (b *Backend) collectBlocks() {
 						Timestamp: blk.Tmstmp,
 						Size:      fmt.Sprintf("%.2fKB", float64(tx.Size())/units.KiB),
 						Success:   result.Success,
-						Actor:     codec.MustAddressBech32(tconsts.HRP, actor),
+						Actor:     tconsts.AddressFormat.Encode(actor),
 						Type:      "CloseOrder",
 						Units:     hcli.ParseDimensions(result.Units),
 						Fee:       fmt.Sprintf("%s %s", hutils.FormatBalance(result.Fee, tconsts.Decimals), tconsts.Symbol),
@@ -526,7 +526,7 @@ func (b *Backend) collectBlocks() {
 		}
 		b.currentStat.Transactions += bi.Txs
 		for _, tx := range blk.Txs {
-			b.currentStat.Accounts.Add(codec.MustAddressBech32(tconsts.HRP, tx.Auth.Sponsor()))
+			b.currentStat.Accounts.Add(tconsts.AddressFormat.Encode(tx.Auth.Sponsor()))
 		}
 		b.currentStat.Prices = prices
 		snow := time.Now().Unix()
@@ -682,7 +682,7 @@ func (b *Backend) MintAsset(asset string, address string, amount string) error {
 	if err != nil {
 		return err
 	}
-	to, err := codec.ParseAddressBech32(tconsts.HRP, address)
+	to, err := tconsts.AddressFormat.Parse(address)
 	if err != nil {
 		return err
 	}
@@ -737,7 +737,7 @@ func (b *Backend) Transfer(asset string, address string, amount string, memo str
 	if err != nil {
 		return err
 	}
-	to, err := codec.ParseAddressBech32(tconsts.HRP, address)
+	to, err := tconsts.AddressFormat.Parse(address)
 	if err != nil {
 		return err
 	}
@@ -1159,7 +1159,7 @@ func (b *Backend) FillOrder(orderID string, orderOwner string, assetIn string, i
 	if err != nil {
 		return err
 	}
-	owner, err := codec.ParseAddressBech32(tconsts.HRP, orderOwner)
+	owner, err := tconsts.AddressFormat.Parse(orderOwner)
 	if err != nil {
 		return err
 	}
@@ -1197,13 +1197,19 @@ func (b *Backend) FillOrder(orderID string, orderOwner string, assetIn string, i
 		return fmt.Errorf("fill amount is not aligned (must be multiple of %s %s)", inTick, inSymbol)
 	}
 
+	royalty, err := b.tcli.RoyaltyRecipient(b.ctx, outID)
+	if err != nil {
+		return err
+	}
+
 	// Generate transaction
 	_, tx, maxFee, err := b.cli.GenerateTransaction(b.ctx, b.parser, []chain.Action{&actions.FillOrder{
-		Order: oID,
-		Owner: owner,
-		In:    inID,
-		Out:   outID,
-		Value: inAmount,
+		Order:   oID,
+		Owner:   owner,
+		In:      inID,
+		Out:     outID,
+		Value:   inAmount,
+		Royalty: royalty,
 	}}, b.factory)
 	if err != nil {
 		return fmt.Errorf("%w: unable to generate transaction", err)
@@ -1373,7 +1379,7 @@ func (b *Backend) Message(message string, url string) error {
 	if err != nil {
 		return err
 	}
-	recipientAddr, err := codec.ParseAddressBech32(tconsts.HRP, recipient)
+	recipientAddr, err := tconsts.AddressFormat.Parse(recipient)
 	if err != nil {
 		return err
 	}
@@ -129,7 +129,7 @@ func (s *Storage) GetTransactions() ([]*TransactionInfo, error) {
 }
 
 func (s *Storage) StoreAddress(address string, nickname string) error {
-	addr, err := codec.ParseAddressBech32(tconsts.HRP, address)
+	addr, err := tconsts.AddressFormat.Parse(address)
 	if err != nil {
 		return err
 	}
@@ -147,7 +147,7 @@ func (s *Storage) GetAddresses() ([]*AddressInfo, error) {
 	for iter.Next() {
 		address := codec.Address(iter.Key()[1:])
 		nickname := string(iter.Value())
-		addresses = append(addresses, &AddressInfo{nickname, codec.MustAddressBech32(tconsts.HRP, address), fmt.Sprintf("%s [%s..%s]", nickname, address[:len(tconsts.HRP)+3], address[len(address)-3:])})
+		addresses = append(addresses, &AddressInfo{nickname, tconsts.AddressFormat.Encode(address), fmt.Sprintf("%s [%s..%s]", nickname, address[:len(tconsts.HRP)+3], address[len(address)-3:])})
 	}
 	return addresses, iter.Error()
 }
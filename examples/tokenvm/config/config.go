@@ -4,25 +4,29 @@
 package config
 
 import (
-	"encoding/json"
-
 	"github.com/ava-labs/avalanchego/utils/logging"
 
 	"github.com/ava-labs/hypersdk/gossiper"
+
+	hconfig "github.com/ava-labs/hypersdk/config"
 )
 
+// EnvPrefix starts the environment variables that override the [Config] of
+// a node (like TOKENVM_LOG_LEVEL for "logLevel").
+const EnvPrefix = "TOKENVM"
+
 type Config struct {
 	// Gossip
-	GossipMaxSize       int   `json:"gossipMaxSize"`
-	GossipProposerDiff  int   `json:"gossipProposerDiff"`
-	GossipProposerDepth int   `json:"gossipProposerDepth"`
-	NoGossipBuilderDiff int   `json:"noGossipBuilderDiff"`
-	VerifyTimeout       int64 `json:"verifyTimeout"`
+	GossipMaxSize       int   `json:"gossipMaxSize" min:"1"`
+	GossipProposerDiff  int   `json:"gossipProposerDiff" min:"0"`
+	GossipProposerDepth int   `json:"gossipProposerDepth" min:"1"`
+	NoGossipBuilderDiff int   `json:"noGossipBuilderDiff" min:"0"`
+	VerifyTimeout       int64 `json:"verifyTimeout" min:"0"`
 
 	// Order Book
 	//
 	// This is denoted as <asset 1>-<asset 2>
-	MaxOrdersPerPair int      `json:"maxOrdersPerPair"`
+	MaxOrdersPerPair int      `json:"maxOrdersPerPair" min:"1"`
 	TrackedPairs     []string `json:"trackedPairs"` // which asset ID pairs we care about
 
 	// Misc
@@ -44,11 +48,8 @@ func New(b []byte) (*Config, error) {
 		MaxOrdersPerPair:    1024,
 	}
 
-	if len(b) > 0 {
-		if err := json.Unmarshal(b, c); err != nil {
-			return nil, err
-		}
+	if err := hconfig.Load(b, c, EnvPrefix); err != nil {
+		return nil, err
 	}
-
 	return c, nil
 }
@@ -6,8 +6,11 @@ package consts
 import (
 	"github.com/ava-labs/avalanchego/ids"
 
+	"github.com/ava-labs/hypersdk/auth"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+	"github.com/ava-labs/hypersdk/governance"
 )
 
 const (
@@ -19,6 +22,9 @@ const (
 
 var ID ids.ID
 
+// AddressFormat encodes and parses the addresses of the VM
+var AddressFormat = address.MustNew(HRP, auth.Types())
+
 func init() {
 	b := make([]byte, ids.IDLen)
 	copy(b, []byte(Name))
@@ -34,4 +40,5 @@ func init() {
 var (
 	ActionRegistry *codec.TypeParser[chain.Action]
 	AuthRegistry   *codec.TypeParser[chain.Auth]
+	Governance     *governance.Module
 )
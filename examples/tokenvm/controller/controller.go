@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/snow"
@@ -24,6 +26,7 @@ import (
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/version"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/governance"
 	"github.com/ava-labs/hypersdk/pebble"
 	"github.com/ava-labs/hypersdk/vm"
 
@@ -47,6 +50,9 @@ type Controller struct {
 	db database.Database
 
 	orderBook *orderbook.OrderBook
+
+	governanceLock   sync.Mutex
+	governanceLoaded atomic.Bool
 }
 
 func New() *vm.VM {
@@ -72,7 +78,6 @@ func (c *Controller) Initialize(
 ) {
 	c.inner = inner
 	c.snowCtx = snowCtx
-	c.stateManager = &StateManager{}
 
 	// Instantiate metrics
 	var err error
@@ -98,6 +103,10 @@ func (c *Controller) Initialize(
 		)
 	}
 	snowCtx.Log.Info("loaded genesis", zap.Any("genesis", c.genesis))
+	c.stateManager = &StateManager{
+		feeAsset:     c.genesis.FeeAsset,
+		feeAssetRate: c.genesis.FeeAssetRate,
+	}
 
 	// Create DBs
 	c.db, err = hstorage.New(pebble.NewDefaultConfig(), snowCtx.ChainDataDir, "db", gatherer)
@@ -149,7 +158,32 @@ func (c *Controller) Initialize(
 
 func (c *Controller) Rules(t int64) chain.Rules {
 	// TODO: extend with [UpgradeBytes]
-	return c.genesis.Rules(t, c.snowCtx.NetworkID, c.snowCtx.ChainID)
+	if !c.governanceLoaded.Load() {
+		if err := c.loadGovernance(context.TODO()); err != nil {
+			c.inner.Logger().Warn("unable to load governance changes", zap.Error(err))
+		}
+	}
+	return consts.Governance.Rules(c.genesis.Rules(t, c.snowCtx.NetworkID, c.snowCtx.ChainID), t)
+}
+
+// loadGovernance loads the changes executed by governance proposals once
+// state is available (it isn't during [Initialize] or state sync).
+func (c *Controller) loadGovernance(ctx context.Context) error {
+	c.governanceLock.Lock()
+	defer c.governanceLock.Unlock()
+
+	if !c.inner.StateReady() {
+		return nil
+	}
+	stateDB, err := c.inner.State()
+	if err != nil {
+		return err
+	}
+	if err := consts.Governance.Load(ctx, stateDB); err != nil {
+		return err
+	}
+	c.governanceLoaded.Store(true)
+	return nil
 }
 
 func (c *Controller) StateManager() chain.StateManager {
@@ -180,19 +214,13 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 		if result.Success {
 			for i, act := range tx.Actions {
 				switch action := act.(type) {
-				case *actions.CreateAsset:
-					c.metrics.createAsset.Inc()
-				case *actions.MintAsset:
-					c.metrics.mintAsset.Inc()
-				case *actions.BurnAsset:
-					c.metrics.burnAsset.Inc()
-				case *actions.Transfer:
-					c.metrics.transfer.Inc()
 				case *actions.CreateOrder:
-					c.metrics.createOrder.Inc()
-					c.orderBook.Add(chain.CreateActionID(tx.ID(), uint8(i)), tx.Auth.Actor(), action)
+					orderID := chain.CreateActionID(tx.ID(), uint8(i))
+					c.orderBook.Add(orderID, tx.Auth.Actor(), action)
+					if err := storage.StoreOrder(ctx, batch, tx.Auth.Actor(), orderID); err != nil {
+						return err
+					}
 				case *actions.FillOrder:
-					c.metrics.fillOrder.Inc()
 					outputs := result.Outputs[i]
 					for _, output := range outputs {
 						orderResult, err := actions.UnmarshalOrderResult(output)
@@ -200,19 +228,51 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 							// This should never happen
 							return err
 						}
+						if orderResult.Royalty > 0 {
+							c.metrics.royaltyFill.Inc()
+						}
 						if orderResult.Remaining == 0 {
 							c.orderBook.Remove(action.Order)
+							if err := storage.DeleteStoredOrder(ctx, batch, action.Owner, action.Order); err != nil {
+								return err
+							}
 							continue
 						}
 						c.orderBook.UpdateRemaining(action.Order, orderResult.Remaining)
 					}
 				case *actions.CloseOrder:
-					c.metrics.closeOrder.Inc()
 					c.orderBook.Remove(action.Order)
+					if err := storage.DeleteStoredOrder(ctx, batch, tx.Auth.Actor(), action.Order); err != nil {
+						return err
+					}
+				case *actions.CloseAllOrders:
+					for _, order := range action.Orders {
+						c.orderBook.Remove(order)
+						if err := storage.DeleteStoredOrder(ctx, batch, tx.Auth.Actor(), order); err != nil {
+							return err
+						}
+					}
+				case *actions.MintNFT:
+					if err := storage.StoreNFT(ctx, batch, action.Collection, action.ID, action.To); err != nil {
+						return err
+					}
+				case *actions.TransferNFT:
+					if err := storage.StoreNFTTransfer(ctx, batch, action.Collection, action.ID, tx.Auth.Actor(), action.To); err != nil {
+						return err
+					}
+				case *actions.BurnNFT:
+					if err := storage.DeleteStoredNFT(ctx, batch, action.Collection, action.ID, tx.Auth.Actor()); err != nil {
+						return err
+					}
+				case *governance.Execute:
+					if err := c.loadGovernance(ctx); err != nil {
+						return err
+					}
 				}
 			}
 		}
 	}
+	c.orderBook.Expire(blk.GetTimestamp())
 	return batch.Write()
 }
 
@@ -12,84 +12,24 @@ import (
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
 )
 
+// Executed actions are counted by the VM, so only metrics that depend on
+// the outcome of an action are recorded here.
 type metrics struct {
-	createAsset prometheus.Counter
-	mintAsset   prometheus.Counter
-	burnAsset   prometheus.Counter
-
-	transfer prometheus.Counter
-
-	createOrder prometheus.Counter
-	fillOrder   prometheus.Counter
-	closeOrder  prometheus.Counter
-
-	importAsset prometheus.Counter
-	exportAsset prometheus.Counter
+	royaltyFill prometheus.Counter
 }
 
 func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 	m := &metrics{
-		createAsset: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "create_asset",
-			Help:      "number of create asset actions",
-		}),
-		mintAsset: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "mint_asset",
-			Help:      "number of mint asset actions",
-		}),
-		burnAsset: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "burn_asset",
-			Help:      "number of burn asset actions",
-		}),
-		transfer: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "transfer",
-			Help:      "number of transfer actions",
-		}),
-		createOrder: prometheus.NewCounter(prometheus.CounterOpts{
+		royaltyFill: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "actions",
-			Name:      "create_order",
-			Help:      "number of create order actions",
-		}),
-		fillOrder: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "fill_order",
-			Help:      "number of fill order actions",
-		}),
-		closeOrder: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "close_order",
-			Help:      "number of close order actions",
-		}),
-		importAsset: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "import_asset",
-			Help:      "number of import asset actions",
-		}),
-		exportAsset: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: "actions",
-			Name:      "export_asset",
-			Help:      "number of export asset actions",
+			Name:      "royalty_fill",
+			Help:      "number of fill order actions that paid a royalty",
 		}),
 	}
 	r := prometheus.NewRegistry()
 	errs := wrappers.Errs{}
 	errs.Add(
-		r.Register(m.createAsset),
-		r.Register(m.mintAsset),
-		r.Register(m.burnAsset),
-
-		r.Register(m.transfer),
-
-		r.Register(m.createOrder),
-		r.Register(m.fillOrder),
-		r.Register(m.closeOrder),
-
-		r.Register(m.importAsset),
-		r.Register(m.exportAsset),
+		r.Register(m.royaltyFill),
 		gatherer.Register(consts.Name, r),
 	)
 	return m, errs.Err
@@ -39,7 +39,7 @@ func (c *Controller) GetTransaction(
 func (c *Controller) GetAssetFromState(
 	ctx context.Context,
 	asset ids.ID,
-) (bool, []byte, uint8, []byte, uint64, codec.Address, error) {
+) (bool, []byte, uint8, []byte, []byte, uint64, codec.Address, error) {
 	return storage.GetAssetFromState(ctx, c.inner.ReadState, asset)
 }
 
@@ -51,10 +51,47 @@ func (c *Controller) GetBalanceFromState(
 	return storage.GetBalanceFromState(ctx, c.inner.ReadState, addr, asset)
 }
 
+func (c *Controller) GetPausedFromState(
+	ctx context.Context,
+	asset ids.ID,
+) (bool, error) {
+	return storage.GetPausedFromState(ctx, c.inner.ReadState, asset)
+}
+
+func (c *Controller) GetRoyaltyFromState(
+	ctx context.Context,
+	asset ids.ID,
+) (uint16, codec.Address, error) {
+	return storage.GetRoyaltyFromState(ctx, c.inner.ReadState, asset)
+}
+
+func (c *Controller) GetFrozenFromState(
+	ctx context.Context,
+	asset ids.ID,
+	addr codec.Address,
+) (bool, error) {
+	return storage.GetFrozenFromState(ctx, c.inner.ReadState, asset, addr)
+}
+
+func (c *Controller) GetBurnedFromState(
+	ctx context.Context,
+	asset ids.ID,
+) (uint64, error) {
+	return storage.GetBurnedFromState(ctx, c.inner.ReadState, asset)
+}
+
 func (c *Controller) Orders(pair string, limit int) []*orderbook.Order {
 	return c.orderBook.Orders(pair, limit)
 }
 
+func (c *Controller) Depth(pair string, offset int, limit int) ([]*orderbook.Level, int) {
+	return c.orderBook.Depth(pair, offset, limit)
+}
+
+func (c *Controller) Match(pair string, value uint64) []*orderbook.Fill {
+	return c.orderBook.Match(pair, value)
+}
+
 func (c *Controller) GetOrderFromState(
 	ctx context.Context,
 	orderID ids.ID,
@@ -66,7 +103,99 @@ func (c *Controller) GetOrderFromState(
 	uint64, // outTick
 	uint64, // remaining
 	codec.Address, // owner
+	int64, // expiry
 	error,
 ) {
 	return storage.GetOrderFromState(ctx, c.inner.ReadState, orderID)
 }
+
+func (c *Controller) GetCollectionFromState(
+	ctx context.Context,
+	collection ids.ID,
+) (bool, []byte, []byte, uint64, codec.Address, error) {
+	return storage.GetCollectionFromState(ctx, c.inner.ReadState, collection)
+}
+
+func (c *Controller) GetNFTFromState(
+	ctx context.Context,
+	collection ids.ID,
+	nftID uint64,
+) (bool, []byte, codec.Address, error) {
+	return storage.GetNFTFromState(ctx, c.inner.ReadState, collection, nftID)
+}
+
+func (c *Controller) GetCollectionNFTs(
+	ctx context.Context,
+	collection ids.ID,
+	limit int,
+) ([]uint64, error) {
+	return storage.GetCollectionNFTs(ctx, c.db, collection, limit)
+}
+
+func (c *Controller) GetOwnerNFTs(
+	ctx context.Context,
+	owner codec.Address,
+	limit int,
+) ([]*storage.NFT, error) {
+	return storage.GetOwnerNFTs(ctx, c.db, owner, limit)
+}
+
+func (c *Controller) GetOwnerOrders(
+	ctx context.Context,
+	owner codec.Address,
+	limit int,
+) ([]ids.ID, error) {
+	return storage.GetOwnerOrders(ctx, c.db, owner, limit)
+}
+
+func (c *Controller) GetPoolFromState(
+	ctx context.Context,
+	assetA ids.ID,
+	assetB ids.ID,
+) (bool, uint64, uint64, uint64, error) {
+	return storage.GetPoolFromState(ctx, c.inner.ReadState, assetA, assetB)
+}
+
+func (c *Controller) GetSharesFromState(
+	ctx context.Context,
+	owner codec.Address,
+	assetA ids.ID,
+	assetB ids.ID,
+) (uint64, error) {
+	return storage.GetSharesFromState(ctx, c.inner.ReadState, owner, assetA, assetB)
+}
+
+func (c *Controller) GetAllowanceFromState(
+	ctx context.Context,
+	owner codec.Address,
+	spender codec.Address,
+	asset ids.ID,
+) (uint64, error) {
+	return storage.GetAllowanceFromState(ctx, c.inner.ReadState, owner, spender, asset)
+}
+
+func (c *Controller) GetStreamFromState(
+	ctx context.Context,
+	stream ids.ID,
+) (bool, *storage.Stream, error) {
+	return storage.GetStreamFromState(ctx, c.inner.ReadState, stream)
+}
+
+func (c *Controller) GetTimestampFromState(ctx context.Context) (int64, error) {
+	return storage.GetTimestampFromState(ctx, c.inner.ReadState)
+}
+
+func (c *Controller) GetAirdropFromState(
+	ctx context.Context,
+	airdrop ids.ID,
+) (bool, *storage.Airdrop, error) {
+	return storage.GetAirdropFromState(ctx, c.inner.ReadState, airdrop)
+}
+
+func (c *Controller) GetAirdropClaimedFromState(
+	ctx context.Context,
+	airdrop ids.ID,
+	index uint64,
+) (bool, error) {
+	return storage.GetAirdropClaimedFromState(ctx, c.inner.ReadState, airdrop, index)
+}
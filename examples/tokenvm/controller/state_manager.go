@@ -5,18 +5,30 @@ package controller
 
 import (
 	"context"
+	"math/bits"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 var _ (chain.StateManager) = (*StateManager)(nil)
 
-type StateManager struct{}
+// StateManager deducts fees from the native balance of sponsors or, if
+// [feeAsset] is set and that balance is not sufficient, from their balance of
+// [feeAsset] (see [genesis.Genesis.FeeAsset]).
+type StateManager struct {
+	feeAsset     ids.ID
+	feeAssetRate uint64
+}
 
 func (*StateManager) HeightKey() []byte {
 	return storage.HeightKey()
@@ -30,19 +42,116 @@ func (*StateManager) FeeKey() []byte {
 	return storage.FeeKey()
 }
 
-func (*StateManager) SponsorStateKeys(addr codec.Address) state.Keys {
-	return state.Keys{
+var _ (chain.ReservedKeyManager) = (*StateManager)(nil)
+
+func (*StateManager) ReservedPrefixes() [][]byte {
+	return storage.ReservedPrefixes()
+}
+
+// SponsorStateKeys must be kept in sync with
+// [genesis.Rules.GetSponsorStateKeysMaxChunks].
+func (s *StateManager) SponsorStateKeys(addr codec.Address) state.Keys {
+	keys := state.Keys{
 		string(storage.BalanceKey(addr, ids.Empty)): state.Read | state.Write,
 	}
+	if s.feeAsset == ids.Empty {
+		return keys
+	}
+	keys.Add(string(storage.BalanceKey(addr, s.feeAsset)), state.Read|state.Write)
+	if s.feeAssetRate > 0 {
+		keys.Add(string(storage.AssetKey(s.feeAsset)), state.Read|state.Write)
+		keys.Add(string(storage.BurnedKey(s.feeAsset)), state.All)
+	} else {
+		assetA, assetB, _ := actions.PoolAssets(ids.Empty, s.feeAsset)
+		keys.Add(string(storage.PoolKey(assetA, assetB)), state.Read|state.Write)
+	}
+	return keys
 }
 
-func (*StateManager) CanDeduct(
+// payInFeeAsset returns whether [addr] must pay a fee of [amount] in
+// [feeAsset] (fees are never split between assets).
+func (s *StateManager) payInFeeAsset(
 	ctx context.Context,
 	addr codec.Address,
 	im state.Immutable,
 	amount uint64,
-) error {
+) (bool, error) {
+	if s.feeAsset == ids.Empty {
+		return false, nil
+	}
 	bal, err := storage.GetBalance(ctx, im, addr, ids.Empty)
+	if err != nil {
+		return false, err
+	}
+	return bal < amount, nil
+}
+
+// feeAssetCost returns the amount of [feeAsset] that pays a fee of [amount]
+// (rounded up) and, when the fee is swapped, the reserves of the fee pool
+// after the swap.
+func (s *StateManager) feeAssetCost(
+	ctx context.Context,
+	im state.Immutable,
+	amount uint64,
+) (uint64, uint64, uint64, uint64, error) {
+	if s.feeAssetRate > 0 {
+		hi, lo := bits.Mul64(amount, s.feeAssetRate)
+		if hi >= genesis.FeeAssetRateDenominator {
+			return 0, 0, 0, 0, smath.ErrOverflow
+		}
+		cost, rem := bits.Div64(hi, lo, genesis.FeeAssetRateDenominator)
+		if rem == 0 {
+			return cost, 0, 0, 0, nil
+		}
+		cost, err := smath.Add64(cost, 1)
+		return cost, 0, 0, 0, err
+	}
+	assetA, assetB, reversed := actions.PoolAssets(s.feeAsset, ids.Empty)
+	exists, reserveA, reserveB, shares, err := storage.GetPool(ctx, im, assetA, assetB)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if !exists {
+		return 0, 0, 0, 0, actions.ErrOutputPoolMissing
+	}
+	reserveIn, reserveOut := reserveA, reserveB
+	if reversed {
+		reserveIn, reserveOut = reserveB, reserveA
+	}
+	cost, err := actions.SwapInput(amount, reserveIn, reserveOut)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	nreserveIn, err := smath.Add64(reserveIn, cost)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	nreserveA, nreserveB := nreserveIn, reserveOut-amount
+	if reversed {
+		nreserveA, nreserveB = nreserveB, nreserveA
+	}
+	return cost, nreserveA, nreserveB, shares, nil
+}
+
+func (s *StateManager) CanDeduct(
+	ctx context.Context,
+	addr codec.Address,
+	im state.Immutable,
+	amount uint64,
+) error {
+	alt, err := s.payInFeeAsset(ctx, addr, im, amount)
+	if err != nil {
+		return err
+	}
+	asset := ids.Empty
+	if alt {
+		asset = s.feeAsset
+		amount, _, _, _, err = s.feeAssetCost(ctx, im, amount)
+		if err != nil {
+			return err
+		}
+	}
+	bal, err := storage.GetBalance(ctx, im, addr, asset)
 	if err != nil {
 		return err
 	}
@@ -52,11 +161,76 @@ func (*StateManager) CanDeduct(
 	return nil
 }
 
-func (*StateManager) Deduct(
+// Deduct burns the fees paid in [feeAsset]. When the fee is swapped, the
+// native asset bought from the pool is burned instead.
+func (s *StateManager) Deduct(
 	ctx context.Context,
 	addr codec.Address,
 	mu state.Mutable,
 	amount uint64,
 ) error {
-	return storage.SubBalance(ctx, mu, addr, ids.Empty, amount)
+	alt, err := s.payInFeeAsset(ctx, addr, mu, amount)
+	if err != nil {
+		return err
+	}
+	if !alt {
+		return storage.SubBalance(ctx, mu, addr, ids.Empty, amount)
+	}
+	cost, reserveA, reserveB, shares, err := s.feeAssetCost(ctx, mu, amount)
+	if err != nil {
+		return err
+	}
+	if err := storage.SubBalance(ctx, mu, addr, s.feeAsset, cost); err != nil {
+		return err
+	}
+	if s.feeAssetRate == 0 {
+		assetA, assetB, _ := actions.PoolAssets(s.feeAsset, ids.Empty)
+		return storage.SetPool(ctx, mu, assetA, assetB, reserveA, reserveB, shares)
+	}
+	exists, symbol, decimals, metadata, uri, supply, owner, err := storage.GetAsset(ctx, mu, s.feeAsset)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return actions.ErrOutputAssetMissing
+	}
+	newSupply, err := smath.Sub(supply, cost)
+	if err != nil {
+		return err
+	}
+	if err := storage.SetAsset(ctx, mu, s.feeAsset, symbol, decimals, metadata, uri, newSupply, owner); err != nil {
+		return err
+	}
+	return storage.AddBurned(ctx, mu, s.feeAsset, cost)
+}
+
+// Incoming warp messages must be signed by [WarpQuorumNum]/[WarpQuorumDen] of
+// the stake of their source subnet.
+const (
+	WarpQuorumNum = 67
+	WarpQuorumDen = 100
+)
+
+var _ (chain.WarpManager) = (*StateManager)(nil)
+
+func (*StateManager) WarpQuorum(chain.Rules, *warp.UnsignedMessage) (uint64, uint64, error) {
+	return WarpQuorumNum, WarpQuorumDen, nil
+}
+
+func (*StateManager) WarpStateKeys(msg *warp.UnsignedMessage) state.Keys {
+	return state.Keys{
+		string(storage.WarpMessageKey(msg.ID())): state.All,
+	}
+}
+
+// ApplyWarpMessage ensures every message is only delivered once.
+func (*StateManager) ApplyWarpMessage(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	_ codec.Address,
+	msg *warp.UnsignedMessage,
+) error {
+	return storage.ConsumeWarpMessage(ctx, mu, msg.ID())
 }
@@ -0,0 +1,308 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const testFeeAssetSupply = 1_000_000
+
+var testFeeAsset = ids.ID{1}
+
+// testFeeState is the state read and written by the [StateManager] when
+// [addr] pays a fee.
+type testFeeState struct {
+	native   uint64 // balance of [addr]
+	feeAsset uint64 // balance of [addr]
+
+	// The reserves of the fee pool (which exists if either is non-zero)
+	poolNative   uint64
+	poolFeeAsset uint64
+
+	// Only read when [StateManager.feeAssetRate] is set
+	supply uint64
+	burned uint64
+}
+
+// newTestFeeView returns a view of [fs] scoped to the sponsor state keys of
+// [addr].
+func newTestFeeView(t *testing.T, s *StateManager, addr codec.Address, fs *testFeeState) state.Mutable {
+	require := require.New(t)
+	ctx := context.Background()
+
+	keys := s.SponsorStateKeys(addr)
+	all := make(state.Keys, len(keys))
+	for k := range keys {
+		all.Add(k, state.All)
+	}
+	ts := tstate.New(len(keys))
+	setup := ts.NewView(all, map[string][]byte{})
+	if fs.native > 0 {
+		require.NoError(storage.SetBalance(ctx, setup, addr, ids.Empty, fs.native))
+	}
+	if fs.feeAsset > 0 {
+		require.NoError(storage.SetBalance(ctx, setup, addr, s.feeAsset, fs.feeAsset))
+	}
+	if s.feeAssetRate > 0 {
+		require.NoError(storage.SetAsset(ctx, setup, s.feeAsset, []byte("FEE"), 9, []byte("fee"), []byte("uri"), fs.supply, codec.EmptyAddress))
+		if fs.burned > 0 {
+			require.NoError(storage.AddBurned(ctx, setup, s.feeAsset, fs.burned))
+		}
+	} else if fs.poolNative > 0 || fs.poolFeeAsset > 0 {
+		reserveA, reserveB := fs.poolNative, fs.poolFeeAsset
+		assetA, assetB, reversed := actions.PoolAssets(ids.Empty, s.feeAsset)
+		if reversed {
+			reserveA, reserveB = reserveB, reserveA
+		}
+		require.NoError(storage.SetPool(ctx, setup, assetA, assetB, reserveA, reserveB, 1_000))
+	}
+	setup.Commit()
+	return ts.NewView(keys, map[string][]byte{})
+}
+
+// readTestFeeState returns the state of [mu] written by [newTestFeeView].
+func readTestFeeState(t *testing.T, s *StateManager, addr codec.Address, mu state.Mutable) *testFeeState {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		fs  testFeeState
+		err error
+	)
+	fs.native, err = storage.GetBalance(ctx, mu, addr, ids.Empty)
+	require.NoError(err)
+	if s.feeAsset == ids.Empty {
+		return &fs
+	}
+	fs.feeAsset, err = storage.GetBalance(ctx, mu, addr, s.feeAsset)
+	require.NoError(err)
+	if s.feeAssetRate > 0 {
+		_, _, _, _, _, fs.supply, _, err = storage.GetAsset(ctx, mu, s.feeAsset)
+		require.NoError(err)
+		fs.burned, err = storage.GetBurned(ctx, mu, s.feeAsset)
+		require.NoError(err)
+		return &fs
+	}
+	assetA, assetB, reversed := actions.PoolAssets(ids.Empty, s.feeAsset)
+	_, fs.poolNative, fs.poolFeeAsset, _, err = storage.GetPool(ctx, mu, assetA, assetB)
+	require.NoError(err)
+	if reversed {
+		fs.poolNative, fs.poolFeeAsset = fs.poolFeeAsset, fs.poolNative
+	}
+	return &fs
+}
+
+func TestFeeAssetCost(t *testing.T) {
+	addr := codec.CreateAddress(0, ids.GenerateTestID())
+	tests := []struct {
+		name     string
+		rate     uint64
+		state    *testFeeState
+		amount   uint64
+		cost     uint64
+		reserves [2]uint64 // native and fee asset reserves after the swap
+		err      error
+	}{
+		{
+			name:   "rate",
+			rate:   2 * genesis.FeeAssetRateDenominator,
+			state:  &testFeeState{supply: testFeeAssetSupply},
+			amount: 10,
+			cost:   20,
+		},
+		{
+			name:   "rate rounds up",
+			rate:   genesis.FeeAssetRateDenominator / 3,
+			state:  &testFeeState{supply: testFeeAssetSupply},
+			amount: 10,
+			cost:   4, // 3.33 rounded up
+		},
+		{
+			name:   "rate below denominator",
+			rate:   1,
+			state:  &testFeeState{supply: testFeeAssetSupply},
+			amount: 1,
+			cost:   1,
+		},
+		{
+			name:   "rate overflow",
+			rate:   genesis.FeeAssetRateDenominator + 1,
+			state:  &testFeeState{supply: testFeeAssetSupply},
+			amount: consts.MaxUint64,
+			err:    smath.ErrOverflow,
+		},
+		{
+			name:     "swap",
+			state:    &testFeeState{poolNative: 10_000, poolFeeAsset: 10_000},
+			amount:   100,
+			cost:     102,
+			reserves: [2]uint64{9_900, 10_102},
+		},
+		{
+			name:     "swap with scarce native asset",
+			state:    &testFeeState{poolNative: 1_000, poolFeeAsset: 100_000},
+			amount:   500,
+			cost:     100_301,
+			reserves: [2]uint64{500, 200_301},
+		},
+		{
+			name:   "swap of whole reserve",
+			state:  &testFeeState{poolNative: 100, poolFeeAsset: 10_000},
+			amount: 100,
+			err:    actions.ErrOutputInsufficientOutput,
+		},
+		{
+			name:   "missing pool",
+			state:  &testFeeState{},
+			amount: 100,
+			err:    actions.ErrOutputPoolMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			s := &StateManager{feeAsset: testFeeAsset, feeAssetRate: tt.rate}
+			mu := newTestFeeView(t, s, addr, tt.state)
+			cost, reserveA, reserveB, _, err := s.feeAssetCost(context.Background(), mu, tt.amount)
+			require.ErrorIs(err, tt.err)
+			if tt.err != nil {
+				return
+			}
+			require.Equal(tt.cost, cost)
+			if tt.rate > 0 {
+				return
+			}
+			if _, _, reversed := actions.PoolAssets(ids.Empty, s.feeAsset); reversed {
+				reserveA, reserveB = reserveB, reserveA
+			}
+			require.Equal(tt.reserves, [2]uint64{reserveA, reserveB})
+		})
+	}
+}
+
+func TestDeduct(t *testing.T) {
+	addr := codec.CreateAddress(0, ids.GenerateTestID())
+	tests := []struct {
+		name     string
+		feeAsset ids.ID
+		rate     uint64
+		state    *testFeeState
+		amount   uint64
+		alt      bool
+		expected *testFeeState // nil if the fee can't be paid
+		err      error
+	}{
+		{
+			name:     "native",
+			state:    &testFeeState{native: 100},
+			amount:   40,
+			expected: &testFeeState{native: 60},
+		},
+		{
+			name:     "whole native balance",
+			state:    &testFeeState{native: 100},
+			amount:   100,
+			expected: &testFeeState{},
+		},
+		{
+			name:   "insufficient native balance",
+			state:  &testFeeState{native: 10},
+			amount: 40,
+			err:    storage.ErrInvalidBalance,
+		},
+		{
+			name:     "native preferred over fee asset",
+			feeAsset: testFeeAsset,
+			state:    &testFeeState{native: 100, feeAsset: 100, poolNative: 10_000, poolFeeAsset: 10_000},
+			amount:   40,
+			expected: &testFeeState{native: 60, feeAsset: 100, poolNative: 10_000, poolFeeAsset: 10_000},
+		},
+		{
+			name:     "fee asset at rate",
+			feeAsset: testFeeAsset,
+			rate:     2 * genesis.FeeAssetRateDenominator,
+			state:    &testFeeState{native: 10, feeAsset: 100, supply: testFeeAssetSupply, burned: 5},
+			amount:   20,
+			alt:      true,
+			expected: &testFeeState{native: 10, feeAsset: 60, supply: testFeeAssetSupply - 40, burned: 45},
+		},
+		{
+			name:     "insufficient fee asset at rate",
+			feeAsset: testFeeAsset,
+			rate:     2 * genesis.FeeAssetRateDenominator,
+			state:    &testFeeState{native: 10, feeAsset: 30, supply: testFeeAssetSupply},
+			amount:   20,
+			alt:      true,
+			err:      storage.ErrInvalidBalance,
+		},
+		{
+			name:     "fee asset swapped",
+			feeAsset: testFeeAsset,
+			state:    &testFeeState{native: 10, feeAsset: 1_000, poolNative: 10_000, poolFeeAsset: 10_000},
+			amount:   100,
+			alt:      true,
+			expected: &testFeeState{native: 10, feeAsset: 898, poolNative: 9_900, poolFeeAsset: 10_102},
+		},
+		{
+			name:     "insufficient fee asset to swap",
+			feeAsset: testFeeAsset,
+			state:    &testFeeState{native: 10, feeAsset: 101, poolNative: 10_000, poolFeeAsset: 10_000},
+			amount:   100,
+			alt:      true,
+			err:      storage.ErrInvalidBalance,
+		},
+		{
+			name:     "insufficient pool liquidity",
+			feeAsset: testFeeAsset,
+			state:    &testFeeState{native: 10, feeAsset: 1_000_000, poolNative: 100, poolFeeAsset: 10_000},
+			amount:   100,
+			alt:      true,
+			err:      actions.ErrOutputInsufficientOutput,
+		},
+		{
+			name:     "missing pool",
+			feeAsset: testFeeAsset,
+			state:    &testFeeState{native: 10, feeAsset: 1_000},
+			amount:   100,
+			alt:      true,
+			err:      actions.ErrOutputPoolMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			s := &StateManager{feeAsset: tt.feeAsset, feeAssetRate: tt.rate}
+			mu := newTestFeeView(t, s, addr, tt.state)
+			alt, err := s.payInFeeAsset(ctx, addr, mu, tt.amount)
+			require.NoError(err)
+			require.Equal(tt.alt, alt)
+
+			// [CanDeduct] succeeds if and only if [Deduct] does
+			require.ErrorIs(s.CanDeduct(ctx, addr, mu, tt.amount), tt.err)
+			require.ErrorIs(s.Deduct(ctx, addr, mu, tt.amount), tt.err)
+			if tt.err != nil {
+				return
+			}
+			require.Equal(tt.expected, readTestFeeState(t, s, addr, mu))
+		})
+	}
+}
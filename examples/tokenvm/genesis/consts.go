@@ -5,4 +5,7 @@ package genesis
 
 const (
 	StateLockupField = "state_lockup"
+
+	// FeeAssetRateDenominator is the denominator of [Genesis.FeeAssetRate].
+	FeeAssetRateDenominator = 1_000_000
 )
@@ -35,8 +35,10 @@ type Genesis struct {
 	StateBranchFactor merkledb.BranchFactor `json:"stateBranchFactor"`
 
 	// Chain Parameters
-	MinBlockGap      int64 `json:"minBlockGap"`      // ms
-	MinEmptyBlockGap int64 `json:"minEmptyBlockGap"` // ms
+	MinBlockGap             int64 `json:"minBlockGap"`             // ms
+	MinEmptyBlockGap        int64 `json:"minEmptyBlockGap"`        // ms
+	MaxEmptyBlockGap        int64 `json:"maxEmptyBlockGap"`        // ms
+	BlockTimestampTolerance int64 `json:"blockTimestampTolerance"` // ms
 
 	// Chain Fee Parameters
 	MinUnitPrice               fees.Dimensions `json:"minUnitPrice"`
@@ -58,6 +60,16 @@ type Genesis struct {
 	StorageKeyWriteUnits      uint64 `json:"storageKeyWriteUnits"`
 	StorageValueWriteUnits    uint64 `json:"storageValueWriteUnits"` // per chunk
 
+	// Alternative Fee Parameters
+	//
+	// Sponsors that can't cover a fee with their native balance pay it in
+	// [FeeAsset] (disabled if empty). If [FeeAssetRate] is set, it is the
+	// amount of [FeeAsset] burned per [FeeAssetRateDenominator] units of the
+	// native asset. Otherwise, the fee is swapped through the pool of
+	// [FeeAsset] and the native asset.
+	FeeAsset     ids.ID `json:"feeAsset"`
+	FeeAssetRate uint64 `json:"feeAssetRate"`
+
 	// Allocates
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
 }
@@ -68,8 +80,10 @@ func Default() *Genesis {
 		StateBranchFactor: merkledb.BranchFactor16,
 
 		// Chain Parameters
-		MinBlockGap:      100,
-		MinEmptyBlockGap: 750,
+		MinBlockGap:             100,
+		MinEmptyBlockGap:        750,
+		MaxEmptyBlockGap:        750,
+		BlockTimestampTolerance: 1_000,
 
 		// Chain Fee Parameters
 		MinUnitPrice:               fees.Dimensions{100, 100, 100, 100, 100},
@@ -117,7 +131,7 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutabl
 
 	supply := uint64(0)
 	for _, alloc := range g.CustomAllocation {
-		pk, err := codec.ParseAddressBech32(consts.HRP, alloc.Address)
+		pk, err := consts.AddressFormat.Parse(alloc.Address)
 		if err != nil {
 			return err
 		}
@@ -136,6 +150,7 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, mu state.Mutabl
 		[]byte(consts.Symbol),
 		consts.Decimals,
 		[]byte(consts.Name),
+		nil,
 		supply,
 		codec.EmptyAddress,
 	)
@@ -41,6 +41,14 @@ func (r *Rules) GetMinEmptyBlockGap() int64 {
 	return r.g.MinEmptyBlockGap
 }
 
+func (r *Rules) GetMaxEmptyBlockGap() int64 {
+	return r.g.MaxEmptyBlockGap
+}
+
+func (r *Rules) GetBlockTimestampTolerance() int64 {
+	return r.g.BlockTimestampTolerance
+}
+
 func (r *Rules) GetValidityWindow() int64 {
 	return r.g.ValidityWindow
 }
@@ -61,8 +69,15 @@ func (r *Rules) GetBaseComputeUnits() uint64 {
 	return r.g.BaseComputeUnits
 }
 
-func (*Rules) GetSponsorStateKeysMaxChunks() []uint16 {
-	return []uint16{storage.BalanceChunks}
+func (r *Rules) GetSponsorStateKeysMaxChunks() []uint16 {
+	switch {
+	case r.g.FeeAsset == ids.Empty:
+		return []uint16{storage.BalanceChunks}
+	case r.g.FeeAssetRate > 0:
+		return []uint16{storage.BalanceChunks, storage.BalanceChunks, storage.AssetChunks, storage.BurnedChunks}
+	default:
+		return []uint16{storage.BalanceChunks, storage.BalanceChunks, storage.PoolChunks}
+	}
 }
 
 func (r *Rules) GetStorageKeyReadUnits() uint64 {
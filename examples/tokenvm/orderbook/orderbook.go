@@ -4,15 +4,18 @@
 package orderbook
 
 import (
+	"math/bits"
+	"slices"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/eheap"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
-	"github.com/ava-labs/hypersdk/heap"
 )
 
 const allPairs = "*"
@@ -25,19 +28,129 @@ type Order struct {
 	OutAsset  ids.ID `json:"outAsset"`
 	OutTick   uint64 `json:"outTick"`
 	Remaining uint64 `json:"remaining"`
+	Expiry    int64  `json:"expiry"`
 
 	owner codec.Address
 }
 
+// expiringOrder is the [eheap.Item] of an order that expires.
+type expiringOrder struct {
+	order *Order
+}
+
+func (e *expiringOrder) ID() ids.ID { return e.order.ID }
+
+func (e *expiringOrder) Expiry() int64 { return e.order.Expiry }
+
+// Level is the aggregate of all tracked orders in a pair at the same
+// [Price] (InTick/OutTick).
+type Level struct {
+	Price  float64 `json:"price"`
+	Size   uint64  `json:"size"` // sum of [Order.Remaining]
+	Orders int     `json:"orders"`
+}
+
+// Fill is a [actions.FillOrder] that should be issued to trade against an
+// order.
+type Fill struct {
+	Order *Order `json:"order"`
+	In    uint64 `json:"in"`  // [actions.FillOrder.Value]
+	Out   uint64 `json:"out"` // amount of [Order.OutAsset] received
+}
+
+// level holds all orders in a pair with the same price, in the order they
+// were accepted.
+type level struct {
+	inTick  uint64
+	outTick uint64
+	orders  []*Order
+}
+
+// book holds the orders of a pair in price-time priority: the lowest price
+// (the least In paid for each unit of Out) first and, within a price, the
+// oldest order first.
+type book struct {
+	levels []*level
+}
+
+// comparePrices returns -1 if [aIn]/[aOut] is less than [bIn]/[bOut], 1 if
+// it is greater, and 0 if they are equal.
+//
+// Prices are compared with exact integer math so that orders at the same
+// price are never split across levels by floating point rounding.
+func comparePrices(aIn, aOut, bIn, bOut uint64) int {
+	ahi, alo := bits.Mul64(aIn, bOut)
+	bhi, blo := bits.Mul64(bIn, aOut)
+	switch {
+	case ahi < bhi || (ahi == bhi && alo < blo):
+		return -1
+	case ahi == bhi && alo == blo:
+		return 0
+	default:
+		return 1
+	}
+}
+
+func (b *book) len() int {
+	l := 0
+	for _, lvl := range b.levels {
+		l += len(lvl.orders)
+	}
+	return l
+}
+
+func (b *book) add(order *Order) {
+	i, found := slices.BinarySearchFunc(b.levels, order, func(lvl *level, o *Order) int {
+		return comparePrices(lvl.inTick, lvl.outTick, o.InTick, o.OutTick)
+	})
+	if !found {
+		b.levels = slices.Insert(b.levels, i, &level{inTick: order.InTick, outTick: order.OutTick})
+	}
+	b.levels[i].orders = append(b.levels[i].orders, order)
+}
+
+// removeWorst removes the newest order at the worst price.
+func (b *book) removeWorst() *Order {
+	if len(b.levels) == 0 {
+		return nil
+	}
+	i := len(b.levels) - 1
+	lvl := b.levels[i]
+	order := lvl.orders[len(lvl.orders)-1]
+	lvl.orders = lvl.orders[:len(lvl.orders)-1]
+	if len(lvl.orders) == 0 {
+		b.levels = slices.Delete(b.levels, i, i+1)
+	}
+	return order
+}
+
+// remove deletes all orders that match [f] and returns them.
+func (b *book) remove(f func(*Order) bool) []*Order {
+	removed := []*Order{}
+	b.levels = slices.DeleteFunc(b.levels, func(lvl *level) bool {
+		lvl.orders = slices.DeleteFunc(lvl.orders, func(o *Order) bool {
+			if f(o) {
+				removed = append(removed, o)
+				return true
+			}
+			return false
+		})
+		return len(lvl.orders) == 0
+	})
+	return removed
+}
+
 type OrderBook struct {
 	c Controller
 
 	// Fee required to create an order should be high enough to prevent too many
-	// dust orders from filling the heap.
+	// dust orders from filling the book.
 	//
 	// TODO: Allow operator to specify min creation supply per pair to be tracked
-	orders           map[string]*heap.Heap[*Order, float64]
+	orders           map[string]*book
 	orderToPair      map[ids.ID]string // needed to delete from [CloseOrder] actions
+	orderToEntry     map[ids.ID]*Order
+	expiries         *eheap.ExpiryHeap[*expiringOrder] // orders that expire, soonest first
 	maxOrdersPerPair int
 	l                sync.RWMutex
 
@@ -45,15 +158,14 @@ type OrderBook struct {
 }
 
 func New(c Controller, trackedPairs []string, maxOrdersPerPair int) *OrderBook {
-	m := map[string]*heap.Heap[*Order, float64]{}
+	m := map[string]*book{}
 	trackAll := false
 	if len(trackedPairs) == 1 && trackedPairs[0] == allPairs {
 		trackAll = true
 		c.Logger().Info("tracking all order books")
 	} else {
 		for _, pair := range trackedPairs {
-			// We use a max heap so we return the best rates in order.
-			m[pair] = heap.New[*Order, float64](maxOrdersPerPair+1, true)
+			m[pair] = &book{}
 			c.Logger().Info("tracking order book", zap.String("pair", pair))
 		}
 	}
@@ -61,48 +173,54 @@ func New(c Controller, trackedPairs []string, maxOrdersPerPair int) *OrderBook {
 		c:                c,
 		orders:           m,
 		orderToPair:      map[ids.ID]string{},
+		orderToEntry:     map[ids.ID]*Order{},
+		expiries:         eheap.New[*expiringOrder](0),
 		maxOrdersPerPair: maxOrdersPerPair,
 		trackAll:         trackAll,
 	}
 }
 
+// Add must be called with orders in the order they are accepted so that
+// orders at the same price are filled first-in, first-out.
 func (o *OrderBook) Add(actionID ids.ID, actor codec.Address, action *actions.CreateOrder) {
 	pair := actions.PairID(action.In, action.Out)
 	order := &Order{
 		actionID,
-		codec.MustAddressBech32(consts.HRP, actor),
+		consts.AddressFormat.Encode(actor),
 		action.In,
 		action.InTick,
 		action.Out,
 		action.OutTick,
 		action.Supply,
+		action.Expiry,
 		actor,
 	}
 
 	o.l.Lock()
 	defer o.l.Unlock()
-	h, ok := o.orders[pair]
+	b, ok := o.orders[pair]
 	switch {
 	case !ok && !o.trackAll:
 		return
 	case !ok && o.trackAll:
 		o.c.Logger().Info("tracking order book", zap.String("pair", pair))
-		h = heap.New[*Order, float64](o.maxOrdersPerPair+1, true)
-		o.orders[pair] = h
-	}
-	h.Push(&heap.Entry[*Order, float64]{
-		ID:    order.ID,
-		Val:   float64(order.InTick) / float64(order.OutTick),
-		Item:  order,
-		Index: h.Len(),
-	})
+		b = &book{}
+		o.orders[pair] = b
+	}
+	b.add(order)
 	o.orderToPair[order.ID] = pair
+	o.orderToEntry[order.ID] = order
+	if order.Expiry != 0 {
+		o.expiries.Add(&expiringOrder{order})
+	}
 
 	// Remove worst order if we are above the max we
 	// track per pair
-	if l := h.Len(); l > o.maxOrdersPerPair {
-		e := h.Remove(l - 1)
-		delete(o.orderToPair, e.ID)
+	if b.len() > o.maxOrdersPerPair {
+		worst := b.removeWorst()
+		delete(o.orderToPair, worst.ID)
+		delete(o.orderToEntry, worst.ID)
+		o.expiries.Remove(worst.ID)
 	}
 }
 
@@ -115,57 +233,153 @@ func (o *OrderBook) Remove(id ids.ID) {
 		return
 	}
 	delete(o.orderToPair, id)
-	h, ok := o.orders[pair]
-	if !ok {
-		// This should never happen
-		return
-	}
-	entry, ok := h.Get(id) // O(log 1)
+	delete(o.orderToEntry, id)
+	o.expiries.Remove(id)
+	b, ok := o.orders[pair]
 	if !ok {
 		// This should never happen
 		return
 	}
-	h.Remove(entry.Index) // O(log N)
+	b.remove(func(order *Order) bool { return order.ID == id })
 }
 
+// UpdateRemaining sets the remaining supply of a partially filled order. The
+// order keeps its time priority.
 func (o *OrderBook) UpdateRemaining(id ids.ID, remaining uint64) {
 	o.l.Lock()
 	defer o.l.Unlock()
 
-	pair, ok := o.orderToPair[id]
+	order, ok := o.orderToEntry[id]
 	if !ok {
 		return
 	}
-	h, ok := o.orders[pair]
-	if !ok {
-		// This should never happen
+	order.Remaining = remaining
+}
+
+// Expire removes all tracked orders that can no longer be filled
+// at [timestamp].
+func (o *OrderBook) Expire(timestamp int64) {
+	o.l.Lock()
+	defer o.l.Unlock()
+
+	// Orders expire once [timestamp] is greater than their expiry (see
+	// [actions.OrderExpired])
+	expired := o.expiries.SetMin(timestamp)
+	if len(expired) == 0 {
 		return
 	}
-	entry, ok := h.Get(id)
-	if !ok {
-		// This should never happen
-		return
+	pairs := map[string]set.Set[ids.ID]{}
+	for _, e := range expired {
+		id := e.order.ID
+		pair := o.orderToPair[id]
+		orders := pairs[pair]
+		orders.Add(id)
+		pairs[pair] = orders
+		delete(o.orderToPair, id)
+		delete(o.orderToEntry, id)
+	}
+	for pair, orders := range pairs {
+		b, ok := o.orders[pair]
+		if !ok {
+			// This should never happen
+			continue
+		}
+		b.remove(func(order *Order) bool { return orders.Contains(order.ID) })
 	}
-	entry.Item.Remaining = remaining
 }
 
+// Orders returns up to [limit] orders of [pair] in price-time priority.
 func (o *OrderBook) Orders(pair string, limit int) []*Order {
 	o.l.RLock()
 	defer o.l.RUnlock()
 
-	h, ok := o.orders[pair]
+	b, ok := o.orders[pair]
 	if !ok {
 		// Clients often prefer an empty slice instead of null
 		return []*Order{}
 	}
-	items := h.Items()
-	arrLen := len(items)
-	if limit < arrLen {
-		arrLen = limit
-	}
-	orders := make([]*Order, arrLen)
-	for i := 0; i < arrLen; i++ {
-		orders[i] = items[i].Item
+	orders := make([]*Order, 0, min(limit, b.len()))
+	for _, lvl := range b.levels {
+		for _, order := range lvl.orders {
+			if len(orders) == limit {
+				return orders
+			}
+			orders = append(orders, order)
+		}
 	}
 	return orders
 }
+
+// Depth returns up to [limit] price levels of [pair], best price first,
+// starting at [offset]. If there are more levels, it also returns the offset
+// of the next page (otherwise 0).
+func (o *OrderBook) Depth(pair string, offset int, limit int) ([]*Level, int) {
+	o.l.RLock()
+	defer o.l.RUnlock()
+
+	b, ok := o.orders[pair]
+	if !ok || offset >= len(b.levels) {
+		// Clients often prefer an empty slice instead of null
+		return []*Level{}, 0
+	}
+	end := min(offset+limit, len(b.levels))
+	levels := make([]*Level, 0, end-offset)
+	for _, lvl := range b.levels[offset:end] {
+		level := &Level{
+			Price:  float64(lvl.inTick) / float64(lvl.outTick),
+			Orders: len(lvl.orders),
+		}
+		for _, order := range lvl.orders {
+			level.Size += order.Remaining
+		}
+		levels = append(levels, level)
+	}
+	next := 0
+	if end < len(b.levels) {
+		next = end
+	}
+	return levels, next
+}
+
+// Match returns the fills that spend up to [value] of the In asset of
+// [pair] against tracked orders in price-time priority.
+//
+// Fill amounts are computed with [actions.FillAmounts], so each [Fill] will
+// trade exactly [Fill.In] for [Fill.Out] on-chain unless the order is
+// modified before the fill is accepted.
+func (o *OrderBook) Match(pair string, value uint64) []*Fill {
+	o.l.RLock()
+	defer o.l.RUnlock()
+
+	fills := []*Fill{}
+	b, ok := o.orders[pair]
+	if !ok {
+		return fills
+	}
+	for _, lvl := range b.levels {
+		for _, order := range lvl.orders {
+			if value == 0 {
+				return fills
+			}
+			if value < order.InTick {
+				continue
+			}
+			// Don't spend more than is needed to take the rest of the order
+			aligned := value - value%order.InTick
+			blocks := order.Remaining / order.OutTick
+			if order.Remaining%order.OutTick != 0 {
+				blocks++
+			}
+			if hi, needed := bits.Mul64(blocks, order.InTick); hi == 0 && needed < aligned {
+				aligned = needed
+			}
+			in, out, err := actions.FillAmounts(order.InTick, order.OutTick, order.Remaining, aligned)
+			if err != nil {
+				continue
+			}
+			fills = append(fills, &Fill{Order: order, In: in, Out: out})
+			value -= in
+		}
+	}
+	return fills
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
+	"github.com/ava-labs/hypersdk/governance"
 )
 
 // Setup types
@@ -18,6 +20,36 @@ func init() {
 	consts.ActionRegistry = codec.NewTypeParser[chain.Action]()
 	consts.AuthRegistry = codec.NewTypeParser[chain.Auth]()
 
+	// Native balances vote on changes to the fee and block parameters
+	governanceModule, err := governance.New(governance.Config{
+		StatePrefix: storage.GovernancePrefix,
+		Params: []governance.Param{
+			governance.MinBlockGap,
+			governance.MinEmptyBlockGap,
+			governance.MaxActionsPerTx,
+			governance.MaxOutputsPerAction,
+			governance.BaseComputeUnits,
+			governance.StorageKeyReadUnits,
+			governance.StorageValueReadUnits,
+			governance.StorageKeyAllocateUnits,
+			governance.StorageValueAllocateUnits,
+			governance.StorageKeyWriteUnits,
+			governance.StorageValueWriteUnits,
+			governance.MaxEmptyBlockGap,
+			governance.BlockTimestampTolerance,
+		},
+		ProposalThreshold: 10_000 * 1e9,        // 10k TKN
+		VotingPeriod:      60 * 60 * 1000,      // 1h
+		Quorum:            1_000_000 * 1e9,     // 1M TKN
+		ExecutionDelay:    10 * 60 * 1000,      // 10m
+		ExecutionWindow:   24 * 60 * 60 * 1000, // 24h
+		ActivationDelay:   5 * 60 * 1000,       // 5m
+	}, storage.GovernanceWeigher{})
+	if err != nil {
+		panic(err)
+	}
+	consts.Governance = governanceModule
+
 	errs := &wrappers.Errs{}
 	errs.Add(
 		// When registering new actions, ALWAYS make sure to append at the end.
@@ -31,6 +63,33 @@ func init() {
 		consts.ActionRegistry.Register((&actions.FillOrder{}).GetTypeID(), actions.UnmarshalFillOrder),
 		consts.ActionRegistry.Register((&actions.CloseOrder{}).GetTypeID(), actions.UnmarshalCloseOrder),
 
+		consts.ActionRegistry.Register((&actions.CreateCollection{}).GetTypeID(), actions.UnmarshalCreateCollection),
+		consts.ActionRegistry.Register((&actions.MintNFT{}).GetTypeID(), actions.UnmarshalMintNFT),
+		consts.ActionRegistry.Register((&actions.TransferNFT{}).GetTypeID(), actions.UnmarshalTransferNFT),
+		consts.ActionRegistry.Register((&actions.BurnNFT{}).GetTypeID(), actions.UnmarshalBurnNFT),
+
+		consts.ActionRegistry.Register((&actions.CloseAllOrders{}).GetTypeID(), actions.UnmarshalCloseAllOrders),
+
+		consts.ActionRegistry.Register((&actions.CreatePool{}).GetTypeID(), actions.UnmarshalCreatePool),
+		consts.ActionRegistry.Register((&actions.AddLiquidity{}).GetTypeID(), actions.UnmarshalAddLiquidity),
+		consts.ActionRegistry.Register((&actions.RemoveLiquidity{}).GetTypeID(), actions.UnmarshalRemoveLiquidity),
+		consts.ActionRegistry.Register((&actions.Swap{}).GetTypeID(), actions.UnmarshalSwap),
+
+		consts.ActionRegistry.Register((&actions.FreezeAccount{}).GetTypeID(), actions.UnmarshalFreezeAccount),
+		consts.ActionRegistry.Register((&actions.PauseAsset{}).GetTypeID(), actions.UnmarshalPauseAsset),
+		consts.ActionRegistry.Register((&actions.Approve{}).GetTypeID(), actions.UnmarshalApprove),
+		consts.ActionRegistry.Register((&actions.TransferFrom{}).GetTypeID(), actions.UnmarshalTransferFrom),
+		consts.ActionRegistry.Register((&actions.TransferMany{}).GetTypeID(), actions.UnmarshalTransferMany),
+		consts.ActionRegistry.Register((&actions.CreateStream{}).GetTypeID(), actions.UnmarshalCreateStream),
+		consts.ActionRegistry.Register((&actions.ClaimStream{}).GetTypeID(), actions.UnmarshalClaimStream),
+		consts.ActionRegistry.Register((&actions.CancelStream{}).GetTypeID(), actions.UnmarshalCancelStream),
+		consts.ActionRegistry.Register((&actions.CreateAirdrop{}).GetTypeID(), actions.UnmarshalCreateAirdrop),
+		consts.ActionRegistry.Register((&actions.ClaimAirdrop{}).GetTypeID(), actions.UnmarshalClaimAirdrop),
+		consts.ActionRegistry.Register((&actions.ReclaimAirdrop{}).GetTypeID(), actions.UnmarshalReclaimAirdrop),
+		consts.ActionRegistry.Register((&actions.BridgeBurn{}).GetTypeID(), actions.UnmarshalBridgeBurn),
+		consts.ActionRegistry.Register((&actions.BridgeMint{}).GetTypeID(), actions.UnmarshalBridgeMint),
+		consts.Governance.Register(consts.ActionRegistry),
+
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519),
 	)
@@ -0,0 +1,81 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+	"github.com/ava-labs/hypersdk/fuzz"
+
+	hconsts "github.com/ava-labs/hypersdk/consts"
+)
+
+// seedActions returns a valid instance of some of the actions of the tokenvm
+// (the fuzzer reaches the others from their type IDs).
+func seedActions() []chain.Action {
+	var (
+		to    = codec.CreateAddress(auth.ED25519ID, ids.GenerateTestID())
+		asset = ids.GenerateTestID()
+	)
+	return []chain.Action{
+		&actions.Transfer{To: to, Asset: asset, Value: 10, Memo: []byte("memo")},
+		&actions.CreateAsset{
+			Symbol:             []byte("TKN"),
+			Decimals:           9,
+			Metadata:           []byte("token"),
+			URI:                []byte("uri"),
+			RoyaltyBasisPoints: 100,
+			RoyaltyRecipient:   to,
+		},
+		&actions.CreateOrder{In: asset, InTick: 1, Out: ids.GenerateTestID(), OutTick: 2, Supply: 10, Expiry: 1_000},
+		&actions.TransferMany{Asset: asset, To: []codec.Address{to, to}, Values: []uint64{1, 2}},
+		&actions.Swap{In: asset, Out: ids.GenerateTestID(), Value: 10, MinOut: 1},
+		&actions.CreateAirdrop{Asset: asset, Root: ids.GenerateTestID(), Total: 10, Expiry: 1_000},
+	}
+}
+
+func FuzzUnmarshalAction(f *testing.F) {
+	for _, action := range seedActions() {
+		fuzz.Seed(f, fuzz.Encode(action))
+	}
+	for id := 0; id <= 255; id++ {
+		if _, ok := consts.ActionRegistry.LookupIndex(uint8(id)); ok {
+			f.Add([]byte{uint8(id)})
+		}
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckObject(t, raw, consts.ActionRegistry)
+	})
+}
+
+func FuzzUnmarshalTx(f *testing.F) {
+	require := require.New(f)
+
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	base := &chain.Base{
+		Timestamp: hconsts.MillisecondsPerSecond,
+		ChainID:   ids.GenerateTestID(),
+		MaxFee:    1_000,
+	}
+	tx, err := chain.NewTx(base, seedActions()).Sign(
+		auth.NewED25519Factory(priv),
+		consts.ActionRegistry,
+		consts.AuthRegistry,
+	)
+	require.NoError(err)
+	fuzz.Seed(f, tx.Bytes())
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckTx(t, raw, consts.ActionRegistry, consts.AuthRegistry)
+	})
+}
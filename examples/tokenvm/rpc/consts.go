@@ -7,4 +7,6 @@ const (
 	JSONRPCEndpoint = "/tokenapi"
 
 	ordersToSend = 128
+	levelsToSend = 128
+	nftsToSend   = 1024
 )
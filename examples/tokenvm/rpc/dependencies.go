@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/fees"
 )
 
@@ -19,9 +20,15 @@ type Controller interface {
 	Genesis() *genesis.Genesis
 	Tracer() trace.Tracer
 	GetTransaction(context.Context, ids.ID) (bool, int64, bool, fees.Dimensions, uint64, error)
-	GetAssetFromState(context.Context, ids.ID) (bool, []byte, uint8, []byte, uint64, codec.Address, error)
+	GetAssetFromState(context.Context, ids.ID) (bool, []byte, uint8, []byte, []byte, uint64, codec.Address, error)
 	GetBalanceFromState(context.Context, codec.Address, ids.ID) (uint64, error)
+	GetPausedFromState(context.Context, ids.ID) (bool, error)
+	GetRoyaltyFromState(context.Context, ids.ID) (uint16, codec.Address, error)
+	GetFrozenFromState(context.Context, ids.ID, codec.Address) (bool, error)
+	GetBurnedFromState(context.Context, ids.ID) (uint64, error)
 	Orders(pair string, limit int) []*orderbook.Order
+	Depth(pair string, offset int, limit int) ([]*orderbook.Level, int)
+	Match(pair string, value uint64) []*orderbook.Fill
 	GetOrderFromState(context.Context, ids.ID) (
 		bool, // exists
 		ids.ID, // in
@@ -30,6 +37,19 @@ type Controller interface {
 		uint64, // outTick
 		uint64, // remaining
 		codec.Address, // owner
+		int64, // expiry
 		error,
 	)
+	GetCollectionFromState(context.Context, ids.ID) (bool, []byte, []byte, uint64, codec.Address, error)
+	GetNFTFromState(context.Context, ids.ID, uint64) (bool, []byte, codec.Address, error)
+	GetCollectionNFTs(context.Context, ids.ID, int) ([]uint64, error)
+	GetOwnerNFTs(context.Context, codec.Address, int) ([]*storage.NFT, error)
+	GetOwnerOrders(context.Context, codec.Address, int) ([]ids.ID, error)
+	GetPoolFromState(context.Context, ids.ID, ids.ID) (bool, uint64, uint64, uint64, error)
+	GetSharesFromState(context.Context, codec.Address, ids.ID, ids.ID) (uint64, error)
+	GetAllowanceFromState(context.Context, codec.Address, codec.Address, ids.ID) (uint64, error)
+	GetStreamFromState(context.Context, ids.ID) (bool, *storage.Stream, error)
+	GetTimestampFromState(context.Context) (int64, error)
+	GetAirdropFromState(context.Context, ids.ID) (bool, *storage.Airdrop, error)
+	GetAirdropClaimedFromState(context.Context, ids.ID, uint64) (bool, error)
 }
@@ -9,4 +9,14 @@ var (
 	ErrTxNotFound    = errors.New("tx not found")
 	ErrAssetNotFound = errors.New("asset not found")
 	ErrOrderNotFound = errors.New("order not found")
+
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrNFTNotFound        = errors.New("nft not found")
+
+	ErrPoolNotFound   = errors.New("pool not found")
+	ErrStreamNotFound = errors.New("stream not found")
+
+	ErrAirdropNotFound = errors.New("airdrop not found")
+
+	ErrInvalidOffset = errors.New("invalid offset")
 )
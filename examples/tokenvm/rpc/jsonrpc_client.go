@@ -14,9 +14,11 @@ import (
 	_ "github.com/ava-labs/hypersdk/examples/tokenvm/registry" // ensure registry populated
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/requester"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/utils"
@@ -117,6 +119,81 @@ func (cli *JSONRPCClient) Asset(
 	return true, resp.Symbol, resp.Decimals, resp.Metadata, resp.Supply, resp.Owner, nil
 }
 
+// AssetInfo returns the latest information about [asset], including its
+// URI and whether it is paused. Unlike [Asset], the result is never cached.
+func (cli *JSONRPCClient) AssetInfo(ctx context.Context, asset ids.ID) (bool, *AssetReply, error) {
+	resp := new(AssetReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"asset",
+		&AssetArgs{
+			Asset: asset,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrAssetNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+// RoyaltyRecipient returns the address that must be provided to
+// [actions.FillOrder] when selling [asset], which is empty if the asset has
+// no royalty.
+func (cli *JSONRPCClient) RoyaltyRecipient(ctx context.Context, asset ids.ID) (codec.Address, error) {
+	if asset == ids.Empty {
+		return codec.EmptyAddress, nil
+	}
+	exists, info, err := cli.AssetInfo(ctx, asset)
+	if err != nil {
+		return codec.EmptyAddress, err
+	}
+	if !exists || len(info.RoyaltyRecipient) == 0 {
+		return codec.EmptyAddress, nil
+	}
+	return consts.AddressFormat.Parse(info.RoyaltyRecipient)
+}
+
+func (cli *JSONRPCClient) AssetSupply(ctx context.Context, asset ids.ID) (bool, *AssetSupplyReply, error) {
+	resp := new(AssetSupplyReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"assetSupply",
+		&AssetArgs{
+			Asset: asset,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrAssetNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *JSONRPCClient) Frozen(ctx context.Context, addr string, asset ids.ID) (bool, error) {
+	resp := new(FrozenReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"frozen",
+		&FrozenArgs{
+			Address: addr,
+			Asset:   asset,
+		},
+		resp,
+	)
+	return resp.Frozen, err
+}
+
 func (cli *JSONRPCClient) Balance(ctx context.Context, addr string, asset ids.ID) (uint64, error) {
 	resp := new(BalanceReply)
 	err := cli.requester.SendRequest(
@@ -144,6 +221,40 @@ func (cli *JSONRPCClient) Orders(ctx context.Context, pair string) ([]*orderbook
 	return resp.Orders, err
 }
 
+func (cli *JSONRPCClient) Depth(
+	ctx context.Context,
+	pair string,
+	offset int,
+	levels int,
+) ([]*orderbook.Level, int, error) {
+	resp := new(DepthReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"depth",
+		&DepthArgs{
+			Pair:   pair,
+			Offset: offset,
+			Levels: levels,
+		},
+		resp,
+	)
+	return resp.Levels, resp.Next, err
+}
+
+func (cli *JSONRPCClient) Match(ctx context.Context, pair string, value uint64) ([]*orderbook.Fill, error) {
+	resp := new(MatchReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"match",
+		&MatchArgs{
+			Pair:  pair,
+			Value: value,
+		},
+		resp,
+	)
+	return resp.Fills, err
+}
+
 func (cli *JSONRPCClient) GetOrder(ctx context.Context, orderID ids.ID) (*orderbook.Order, error) {
 	resp := new(GetOrderReply)
 	err := cli.requester.SendRequest(
@@ -157,6 +268,223 @@ func (cli *JSONRPCClient) GetOrder(ctx context.Context, orderID ids.ID) (*orderb
 	return resp.Order, err
 }
 
+func (cli *JSONRPCClient) OwnerOrders(ctx context.Context, addr string) ([]*orderbook.Order, error) {
+	resp := new(OwnerOrdersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"ownerOrders",
+		&OwnerOrdersArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Orders, err
+}
+
+func (cli *JSONRPCClient) Collection(
+	ctx context.Context,
+	collection ids.ID,
+) (bool, []byte, []byte, uint64, string, error) {
+	resp := new(CollectionReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"collection",
+		&CollectionArgs{
+			Collection: collection,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrCollectionNotFound.Error()):
+		return false, nil, nil, 0, "", nil
+	case err != nil:
+		return false, nil, nil, 0, "", err
+	}
+	return true, resp.Name, resp.Metadata, resp.Supply, resp.Owner, nil
+}
+
+func (cli *JSONRPCClient) GetNFT(
+	ctx context.Context,
+	collection ids.ID,
+	nftID uint64,
+) (bool, []byte, string, error) {
+	resp := new(GetNFTReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getNFT",
+		&GetNFTArgs{
+			Collection: collection,
+			ID:         nftID,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrNFTNotFound.Error()):
+		return false, nil, "", nil
+	case err != nil:
+		return false, nil, "", err
+	}
+	return true, resp.Metadata, resp.Owner, nil
+}
+
+func (cli *JSONRPCClient) CollectionNFTs(ctx context.Context, collection ids.ID) ([]uint64, error) {
+	resp := new(CollectionNFTsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"collectionNFTs",
+		&CollectionArgs{
+			Collection: collection,
+		},
+		resp,
+	)
+	return resp.IDs, err
+}
+
+func (cli *JSONRPCClient) OwnerNFTs(ctx context.Context, addr string) ([]*storage.NFT, error) {
+	resp := new(OwnerNFTsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"ownerNFTs",
+		&OwnerNFTsArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.NFTs, err
+}
+
+// Pool returns whether the pool between [assetA] and [assetB] exists and, if
+// so, its reserves (in the order the assets are stored) and total shares.
+func (cli *JSONRPCClient) Pool(
+	ctx context.Context,
+	assetA ids.ID,
+	assetB ids.ID,
+) (bool, *PoolReply, error) {
+	resp := new(PoolReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"pool",
+		&PoolArgs{
+			AssetA: assetA,
+			AssetB: assetB,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrPoolNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *JSONRPCClient) Stream(ctx context.Context, stream ids.ID) (bool, *StreamReply, error) {
+	resp := new(StreamReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"stream",
+		&StreamArgs{
+			Stream: stream,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrStreamNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *JSONRPCClient) Airdrop(ctx context.Context, airdrop ids.ID) (bool, *AirdropReply, error) {
+	resp := new(AirdropReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"airdrop",
+		&AirdropArgs{
+			Airdrop: airdrop,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrAirdropNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *JSONRPCClient) AirdropClaimed(ctx context.Context, airdrop ids.ID, index uint64) (bool, error) {
+	resp := new(AirdropClaimedReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"airdropClaimed",
+		&AirdropClaimedArgs{
+			Airdrop: airdrop,
+			Index:   index,
+		},
+		resp,
+	)
+	return resp.Claimed, err
+}
+
+func (cli *JSONRPCClient) SpotPrice(ctx context.Context, in ids.ID, out ids.ID) (float64, error) {
+	resp := new(SpotPriceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"spotPrice",
+		&SpotPriceArgs{
+			In:  in,
+			Out: out,
+		},
+		resp,
+	)
+	return resp.Price, err
+}
+
+func (cli *JSONRPCClient) Shares(ctx context.Context, addr string, assetA ids.ID, assetB ids.ID) (uint64, error) {
+	resp := new(SharesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"shares",
+		&SharesArgs{
+			Address: addr,
+			AssetA:  assetA,
+			AssetB:  assetB,
+		},
+		resp,
+	)
+	return resp.Shares, err
+}
+
+func (cli *JSONRPCClient) Allowance(ctx context.Context, owner string, spender string, asset ids.ID) (uint64, error) {
+	resp := new(AllowanceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"allowance",
+		&AllowanceArgs{
+			Owner:   owner,
+			Spender: spender,
+			Asset:   asset,
+		},
+		resp,
+	)
+	return resp.Amount, err
+}
+
 func (cli *JSONRPCClient) WaitForBalance(
 	ctx context.Context,
 	addr string,
@@ -8,10 +8,11 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 
-	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/actions"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/consts"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/genesis"
 	"github.com/ava-labs/hypersdk/examples/tokenvm/orderbook"
+	"github.com/ava-labs/hypersdk/examples/tokenvm/storage"
 	"github.com/ava-labs/hypersdk/fees"
 )
 
@@ -71,27 +72,100 @@ type AssetReply struct {
 	Metadata []byte `json:"metadata"`
 	Supply   uint64 `json:"supply"`
 	Owner    string `json:"owner"`
+	URI      []byte `json:"uri"`
+	Paused   bool   `json:"paused"`
+
+	// RoyaltyRecipient is empty if the asset has no royalty.
+	RoyaltyBasisPoints uint16 `json:"royaltyBasisPoints"`
+	RoyaltyRecipient   string `json:"royaltyRecipient"`
 }
 
 func (j *JSONRPCServer) Asset(req *http.Request, args *AssetArgs, reply *AssetReply) error {
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Asset")
 	defer span.End()
 
-	exists, symbol, decimals, metadata, supply, owner, err := j.c.GetAssetFromState(ctx, args.Asset)
+	exists, symbol, decimals, metadata, uri, supply, owner, err := j.c.GetAssetFromState(ctx, args.Asset)
 	if err != nil {
 		return err
 	}
 	if !exists {
 		return ErrAssetNotFound
 	}
+	paused, err := j.c.GetPausedFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	royaltyBasisPoints, royaltyRecipient, err := j.c.GetRoyaltyFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
 	reply.Symbol = symbol
 	reply.Decimals = decimals
 	reply.Metadata = metadata
 	reply.Supply = supply
-	reply.Owner = codec.MustAddressBech32(consts.HRP, owner)
+	reply.Owner = consts.AddressFormat.Encode(owner)
+	reply.URI = uri
+	reply.Paused = paused
+	if royaltyBasisPoints > 0 {
+		reply.RoyaltyBasisPoints = royaltyBasisPoints
+		reply.RoyaltyRecipient = consts.AddressFormat.Encode(royaltyRecipient)
+	}
 	return err
 }
 
+type AssetSupplyReply struct {
+	// Supply is the circulating supply of the asset.
+	Supply uint64 `json:"supply"`
+	Burned uint64 `json:"burned"`
+	Minted uint64 `json:"minted"`
+}
+
+func (j *JSONRPCServer) AssetSupply(req *http.Request, args *AssetArgs, reply *AssetSupplyReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.AssetSupply")
+	defer span.End()
+
+	exists, _, _, _, _, supply, _, err := j.c.GetAssetFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAssetNotFound
+	}
+	burned, err := j.c.GetBurnedFromState(ctx, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Supply = supply
+	reply.Burned = burned
+	reply.Minted = supply + burned
+	return nil
+}
+
+type FrozenArgs struct {
+	Address string `json:"address"`
+	Asset   ids.ID `json:"asset"`
+}
+
+type FrozenReply struct {
+	Frozen bool `json:"frozen"`
+}
+
+func (j *JSONRPCServer) Frozen(req *http.Request, args *FrozenArgs, reply *FrozenReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Frozen")
+	defer span.End()
+
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	frozen, err := j.c.GetFrozenFromState(ctx, args.Asset, addr)
+	if err != nil {
+		return err
+	}
+	reply.Frozen = frozen
+	return nil
+}
+
 type BalanceArgs struct {
 	Address string `json:"address"`
 	Asset   ids.ID `json:"asset"`
@@ -105,7 +179,7 @@ func (j *JSONRPCServer) Balance(req *http.Request, args *BalanceArgs, reply *Bal
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Balance")
 	defer span.End()
 
-	addr, err := codec.ParseAddressBech32(consts.HRP, args.Address)
+	addr, err := consts.AddressFormat.Parse(args.Address)
 	if err != nil {
 		return err
 	}
@@ -133,6 +207,61 @@ func (j *JSONRPCServer) Orders(req *http.Request, args *OrdersArgs, reply *Order
 	return nil
 }
 
+type DepthArgs struct {
+	Pair string `json:"pair"`
+
+	// Offset is the number of price levels to skip.
+	Offset int `json:"offset"`
+
+	// Levels is the maximum number of price levels to return. If 0 or
+	// larger than the server maximum, the server maximum is used.
+	Levels int `json:"levels"`
+}
+
+type DepthReply struct {
+	Levels []*orderbook.Level `json:"levels"`
+
+	// Next is the [Offset] of the next page, or 0 if there are no more
+	// levels.
+	Next int `json:"next"`
+}
+
+// Depth returns the tracked orders of [Pair] aggregated by price, best price
+// first.
+func (j *JSONRPCServer) Depth(req *http.Request, args *DepthArgs, reply *DepthReply) error {
+	_, span := j.c.Tracer().Start(req.Context(), "Server.Depth")
+	defer span.End()
+
+	if args.Offset < 0 {
+		return ErrInvalidOffset
+	}
+	levels := args.Levels
+	if levels <= 0 || levels > levelsToSend {
+		levels = levelsToSend
+	}
+	reply.Levels, reply.Next = j.c.Depth(args.Pair, args.Offset, levels)
+	return nil
+}
+
+type MatchArgs struct {
+	Pair  string `json:"pair"`
+	Value uint64 `json:"value"`
+}
+
+type MatchReply struct {
+	Fills []*orderbook.Fill `json:"fills"`
+}
+
+// Match returns the [actions.FillOrder] amounts that spend up to [Value] of
+// the In asset of [Pair] against the best tracked orders.
+func (j *JSONRPCServer) Match(req *http.Request, args *MatchArgs, reply *MatchReply) error {
+	_, span := j.c.Tracer().Start(req.Context(), "Server.Match")
+	defer span.End()
+
+	reply.Fills = j.c.Match(args.Pair, args.Value)
+	return nil
+}
+
 type GetOrderArgs struct {
 	OrderID ids.ID `json:"orderID"`
 }
@@ -145,7 +274,7 @@ func (j *JSONRPCServer) GetOrder(req *http.Request, args *GetOrderArgs, reply *G
 	ctx, span := j.c.Tracer().Start(req.Context(), "Server.GetOrder")
 	defer span.End()
 
-	exists, in, inTick, out, outTick, remaining, owner, err := j.c.GetOrderFromState(ctx, args.OrderID)
+	exists, in, inTick, out, outTick, remaining, owner, expiry, err := j.c.GetOrderFromState(ctx, args.OrderID)
 	if err != nil {
 		return err
 	}
@@ -154,12 +283,379 @@ func (j *JSONRPCServer) GetOrder(req *http.Request, args *GetOrderArgs, reply *G
 	}
 	reply.Order = &orderbook.Order{
 		ID:        args.OrderID,
-		Owner:     codec.MustAddressBech32(consts.HRP, owner),
+		Owner:     consts.AddressFormat.Encode(owner),
 		InAsset:   in,
 		InTick:    inTick,
 		OutAsset:  out,
 		OutTick:   outTick,
 		Remaining: remaining,
+		Expiry:    expiry,
+	}
+	return nil
+}
+
+type OwnerOrdersArgs struct {
+	Address string `json:"address"`
+}
+
+type OwnerOrdersReply struct {
+	Orders []*orderbook.Order `json:"orders"`
+}
+
+// OwnerOrders returns the open orders created by [Address], including those
+// that have expired but have not yet been reclaimed.
+func (j *JSONRPCServer) OwnerOrders(req *http.Request, args *OwnerOrdersArgs, reply *OwnerOrdersReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.OwnerOrders")
+	defer span.End()
+
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	orderIDs, err := j.c.GetOwnerOrders(ctx, addr, ordersToSend)
+	if err != nil {
+		return err
+	}
+	reply.Orders = make([]*orderbook.Order, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		exists, in, inTick, out, outTick, remaining, _, expiry, err := j.c.GetOrderFromState(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// Order was removed after the index was read
+			continue
+		}
+		reply.Orders = append(reply.Orders, &orderbook.Order{
+			ID:        orderID,
+			Owner:     args.Address,
+			InAsset:   in,
+			InTick:    inTick,
+			OutAsset:  out,
+			OutTick:   outTick,
+			Remaining: remaining,
+			Expiry:    expiry,
+		})
+	}
+	return nil
+}
+
+type CollectionArgs struct {
+	Collection ids.ID `json:"collection"`
+}
+
+type CollectionReply struct {
+	Name     []byte `json:"name"`
+	Metadata []byte `json:"metadata"`
+	Supply   uint64 `json:"supply"`
+	Owner    string `json:"owner"`
+}
+
+func (j *JSONRPCServer) Collection(req *http.Request, args *CollectionArgs, reply *CollectionReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Collection")
+	defer span.End()
+
+	exists, name, metadata, supply, owner, err := j.c.GetCollectionFromState(ctx, args.Collection)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrCollectionNotFound
+	}
+	reply.Name = name
+	reply.Metadata = metadata
+	reply.Supply = supply
+	reply.Owner = consts.AddressFormat.Encode(owner)
+	return nil
+}
+
+type GetNFTArgs struct {
+	Collection ids.ID `json:"collection"`
+	ID         uint64 `json:"id"`
+}
+
+type GetNFTReply struct {
+	Metadata []byte `json:"metadata"`
+	Owner    string `json:"owner"`
+}
+
+func (j *JSONRPCServer) GetNFT(req *http.Request, args *GetNFTArgs, reply *GetNFTReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.GetNFT")
+	defer span.End()
+
+	exists, metadata, owner, err := j.c.GetNFTFromState(ctx, args.Collection, args.ID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNFTNotFound
+	}
+	reply.Metadata = metadata
+	reply.Owner = consts.AddressFormat.Encode(owner)
+	return nil
+}
+
+type CollectionNFTsReply struct {
+	IDs []uint64 `json:"ids"`
+}
+
+func (j *JSONRPCServer) CollectionNFTs(req *http.Request, args *CollectionArgs, reply *CollectionNFTsReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.CollectionNFTs")
+	defer span.End()
+
+	nfts, err := j.c.GetCollectionNFTs(ctx, args.Collection, nftsToSend)
+	if err != nil {
+		return err
+	}
+	reply.IDs = nfts
+	return nil
+}
+
+type OwnerNFTsArgs struct {
+	Address string `json:"address"`
+}
+
+type OwnerNFTsReply struct {
+	NFTs []*storage.NFT `json:"nfts"`
+}
+
+func (j *JSONRPCServer) OwnerNFTs(req *http.Request, args *OwnerNFTsArgs, reply *OwnerNFTsReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.OwnerNFTs")
+	defer span.End()
+
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	nfts, err := j.c.GetOwnerNFTs(ctx, addr, nftsToSend)
+	if err != nil {
+		return err
+	}
+	reply.NFTs = nfts
+	return nil
+}
+
+type PoolArgs struct {
+	AssetA ids.ID `json:"assetA"`
+	AssetB ids.ID `json:"assetB"`
+}
+
+type PoolReply struct {
+	AssetA   ids.ID `json:"assetA"`
+	AssetB   ids.ID `json:"assetB"`
+	ReserveA uint64 `json:"reserveA"`
+	ReserveB uint64 `json:"reserveB"`
+	Shares   uint64 `json:"shares"`
+}
+
+// Pool returns the reserves of the pool between [AssetA] and [AssetB]. The
+// reply lists the assets in the order they are stored (which may differ from
+// the order provided).
+func (j *JSONRPCServer) Pool(req *http.Request, args *PoolArgs, reply *PoolReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Pool")
+	defer span.End()
+
+	assetA, assetB, _ := actions.PoolAssets(args.AssetA, args.AssetB)
+	exists, reserveA, reserveB, shares, err := j.c.GetPoolFromState(ctx, assetA, assetB)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrPoolNotFound
+	}
+	reply.AssetA = assetA
+	reply.AssetB = assetB
+	reply.ReserveA = reserveA
+	reply.ReserveB = reserveB
+	reply.Shares = shares
+	return nil
+}
+
+type AllowanceArgs struct {
+	Owner   string `json:"owner"`
+	Spender string `json:"spender"`
+	Asset   ids.ID `json:"asset"`
+}
+
+type AllowanceReply struct {
+	Amount uint64 `json:"amount"`
+}
+
+func (j *JSONRPCServer) Allowance(req *http.Request, args *AllowanceArgs, reply *AllowanceReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Allowance")
+	defer span.End()
+
+	owner, err := consts.AddressFormat.Parse(args.Owner)
+	if err != nil {
+		return err
+	}
+	spender, err := consts.AddressFormat.Parse(args.Spender)
+	if err != nil {
+		return err
+	}
+	amount, err := j.c.GetAllowanceFromState(ctx, owner, spender, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Amount = amount
+	return nil
+}
+
+type SpotPriceArgs struct {
+	In  ids.ID `json:"in"`
+	Out ids.ID `json:"out"`
+}
+
+type SpotPriceReply struct {
+	Price float64 `json:"price"`
+}
+
+// SpotPrice returns the amount of [Out] received per unit of [In] for an
+// infinitesimally small swap (ignoring fees).
+func (j *JSONRPCServer) SpotPrice(req *http.Request, args *SpotPriceArgs, reply *SpotPriceReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.SpotPrice")
+	defer span.End()
+
+	assetA, assetB, reversed := actions.PoolAssets(args.In, args.Out)
+	exists, reserveA, reserveB, _, err := j.c.GetPoolFromState(ctx, assetA, assetB)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrPoolNotFound
+	}
+	if reversed {
+		reply.Price = float64(reserveA) / float64(reserveB)
+	} else {
+		reply.Price = float64(reserveB) / float64(reserveA)
+	}
+	return nil
+}
+
+type SharesArgs struct {
+	Address string `json:"address"`
+	AssetA  ids.ID `json:"assetA"`
+	AssetB  ids.ID `json:"assetB"`
+}
+
+type SharesReply struct {
+	Shares uint64 `json:"shares"`
+}
+
+func (j *JSONRPCServer) Shares(req *http.Request, args *SharesArgs, reply *SharesReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Shares")
+	defer span.End()
+
+	addr, err := consts.AddressFormat.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	assetA, assetB, _ := actions.PoolAssets(args.AssetA, args.AssetB)
+	shares, err := j.c.GetSharesFromState(ctx, addr, assetA, assetB)
+	if err != nil {
+		return err
+	}
+	reply.Shares = shares
+	return nil
+}
+
+type StreamArgs struct {
+	Stream ids.ID `json:"stream"`
+}
+
+type StreamReply struct {
+	Asset     ids.ID `json:"asset"`
+	Payer     string `json:"payer"`
+	Payee     string `json:"payee"`
+	Rate      uint64 `json:"rate"`
+	Start     int64  `json:"start"`
+	Cliff     int64  `json:"cliff"`
+	Total     uint64 `json:"total"`
+	Claimed   uint64 `json:"claimed"`
+	Claimable uint64 `json:"claimable"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Stream returns the state of [Stream] and the amount the payee could claim
+// as of the last accepted block ([Timestamp]).
+func (j *JSONRPCServer) Stream(req *http.Request, args *StreamArgs, reply *StreamReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Stream")
+	defer span.End()
+
+	exists, stream, err := j.c.GetStreamFromState(ctx, args.Stream)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrStreamNotFound
+	}
+	timestamp, err := j.c.GetTimestampFromState(ctx)
+	if err != nil {
+		return err
+	}
+	reply.Asset = stream.Asset
+	reply.Payer = consts.AddressFormat.Encode(stream.Payer)
+	reply.Payee = consts.AddressFormat.Encode(stream.Payee)
+	reply.Rate = stream.Rate
+	reply.Start = stream.Start
+	reply.Cliff = stream.Cliff
+	reply.Total = stream.Total
+	reply.Claimed = stream.Claimed
+	reply.Claimable = actions.StreamClaimable(stream, timestamp)
+	reply.Timestamp = timestamp
+	return nil
+}
+
+type AirdropArgs struct {
+	Airdrop ids.ID `json:"airdrop"`
+}
+
+type AirdropReply struct {
+	Asset     ids.ID `json:"asset"`
+	Root      ids.ID `json:"root"`
+	Owner     string `json:"owner"`
+	Remaining uint64 `json:"remaining"`
+	Expiry    int64  `json:"expiry"`
+}
+
+// Airdrop returns the state of [Airdrop]. Airdrops are removed once all
+// escrowed funds are claimed or reclaimed by the owner.
+func (j *JSONRPCServer) Airdrop(req *http.Request, args *AirdropArgs, reply *AirdropReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Airdrop")
+	defer span.End()
+
+	exists, airdrop, err := j.c.GetAirdropFromState(ctx, args.Airdrop)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAirdropNotFound
+	}
+	reply.Asset = airdrop.Asset
+	reply.Root = airdrop.Root
+	reply.Owner = consts.AddressFormat.Encode(airdrop.Owner)
+	reply.Remaining = airdrop.Remaining
+	reply.Expiry = airdrop.Expiry
+	return nil
+}
+
+type AirdropClaimedArgs struct {
+	Airdrop ids.ID `json:"airdrop"`
+	Index   uint64 `json:"index"`
+}
+
+type AirdropClaimedReply struct {
+	Claimed bool `json:"claimed"`
+}
+
+func (j *JSONRPCServer) AirdropClaimed(req *http.Request, args *AirdropClaimedArgs, reply *AirdropClaimedReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.AirdropClaimed")
+	defer span.End()
+
+	claimed, err := j.c.GetAirdropClaimedFromState(ctx, args.Airdrop, args.Index)
+	if err != nil {
+		return err
 	}
+	reply.Claimed = claimed
 	return nil
 }
@@ -134,17 +134,23 @@ cat "${DEPLOY_ARTIFACT_PREFIX}/tokenvm-genesis.json"
 
 cat <<EOF > "${DEPLOY_ARTIFACT_PREFIX}/tokenvm-chain-config.json"
 {
-  "logLevel": "info",
   "mempoolSize": 10000000,
-  "mempoolExemptPayers":["token1rvzhmceq997zntgvravfagsks6w0ryud3rylh4cdvayry0dl97nsjzf3yp"],
   "streamingBacklogSize": 10000000,
   "authVerificationCores": 4,
   "rootGenerationCores": 4,
   "transactionExecutionCores": 4,
-  "storeTransactions": false,
   "verifyAuth": true,
-  "trackedPairs":["*"],
-  "continuousProfilerDir":"/data/tokenvm-profiles"
+  "continuousProfilerConfig": {
+    "enabled": true,
+    "dir": "/data/tokenvm-profiles",
+    "freq": 900000000000,
+    "maxNumFiles": 5
+  },
+  "config": {
+    "logLevel": "info",
+    "storeTransactions": false,
+    "trackedPairs": ["*"]
+  }
 }
 EOF
 cat "${DEPLOY_ARTIFACT_PREFIX}/tokenvm-chain-config.json"
@@ -14,7 +14,7 @@ source ../../scripts/constants.sh
 # shellcheck source=/scripts/common/utils.sh
 source ../../scripts/common/utils.sh
 
-# to run E2E tests (terminates cluster afterwards)
+# to run E2E tests (on a devnet launched by the tests)
 # MODE=test ./scripts/run.sh
 VERSION=v1.11.8
 MAX_UINT64=18446744073709551615
@@ -154,17 +154,23 @@ cat <<EOF > "${TMPDIR}"/tokenvm.config
 {
   "mempoolSize": 10000000,
   "mempoolSponsorSize": 10000000,
-  "mempoolExemptSponsors":["${ADDRESS}"],
   "authVerificationCores": 2,
   "rootGenerationCores": 2,
   "transactionExecutionCores": 2,
   "verifyAuth": true,
-  "storeTransactions": ${STORE_TXS},
   "streamingBacklogSize": 10000000,
-  "trackedPairs":["*"],
-  "logLevel": "${LOG_LEVEL}",
-  "continuousProfilerDir":"${TMPDIR}/tokenvm-e2e-profiles/*",
-  "stateSyncServerDelay": ${STATESYNC_DELAY}
+  "continuousProfilerConfig": {
+    "enabled": true,
+    "dir": "${TMPDIR}/tokenvm-e2e-profiles",
+    "freq": 900000000000,
+    "maxNumFiles": 5
+  },
+  "stateSyncServerDelay": ${STATESYNC_DELAY},
+  "config": {
+    "storeTransactions": ${STORE_TXS},
+    "trackedPairs": ["*"],
+    "logLevel": "${LOG_LEVEL}"
+  }
 }
 EOF
 mkdir -p "${TMPDIR}"/tokenvm-e2e-profiles
@@ -185,13 +191,6 @@ EOF
 ############################
 
 ############################
-echo "building e2e.test"
-
-prepare_ginkgo
-
-ACK_GINKGO_RC=true ginkgo build ./tests/e2e
-./tests/e2e/e2e.test --help
-
 #################################
 # download avalanche-network-runner
 # https://github.com/ava-labs/avalanche-network-runner
@@ -220,10 +219,6 @@ $BIN server \
 --grpc-gateway-port=":12353" &
 
 ############################
-# By default, it runs all e2e test cases!
-# Use "--ginkgo.skip" to skip tests.
-# Use "--ginkgo.focus" to select tests.
-
 KEEPALIVE=false
 function cleanup() {
   if [[ ${KEEPALIVE} = true ]]; then
@@ -241,25 +236,28 @@ function cleanup() {
 }
 trap cleanup EXIT
 
-echo "running e2e tests"
-./tests/e2e/e2e.test \
---ginkgo.v \
---network-runner-log-level verbo \
---avalanchego-log-level "${AGO_LOG_LEVEL}" \
---avalanchego-log-display-level "${AGO_LOG_DISPLAY_LEVEL}" \
---network-runner-grpc-endpoint="0.0.0.0:12352" \
---network-runner-grpc-gateway-endpoint="0.0.0.0:12353" \
+if [[ ${MODE} != "run" ]]; then
+  # The e2e tests launch their own devnet with the server above. Use
+  # "-run" to select checks (like "-run TestE2e/state-sync").
+  echo "running e2e tests"
+  AVALANCHEGO_PATH="${AVALANCHEGO_PATH}" \
+  AVALANCHEGO_PLUGIN_DIR="${AVALANCHEGO_PLUGIN_DIR}" \
+  ANR_ENDPOINT="0.0.0.0:12352" \
+  go test -v -count=1 -timeout=30m ./tests/e2e
+  exit
+fi
+
+echo "launching cluster"
+$BIN control start \
+--endpoint="0.0.0.0:12352" \
+--number-of-nodes=5 \
 --avalanchego-path="${AVALANCHEGO_PATH}" \
---avalanchego-plugin-dir="${AVALANCHEGO_PLUGIN_DIR}" \
---vm-genesis-path="${TMPDIR}"/tokenvm.genesis \
---vm-config-path="${TMPDIR}"/tokenvm.config \
---subnet-config-path="${TMPDIR}"/tokenvm.subnet \
---output-path="${TMPDIR}"/avalanchego-"${VERSION}"/output.yaml \
---mode="${MODE}"
+--plugin-dir="${AVALANCHEGO_PLUGIN_DIR}" \
+--global-node-config="{\"log-level\":\"${AGO_LOG_LEVEL}\",\"log-display-level\":\"${AGO_LOG_DISPLAY_LEVEL}\",\"proposervm-use-current-height\":true,\"http-host\":\"\",\"http-allowed-origins\":\"*\",\"http-allowed-hosts\":\"*\"}" \
+--blockchain-specs="[{\"vm_name\":\"tokenvm\",\"genesis\":\"${TMPDIR}/tokenvm.genesis\",\"chain_config\":\"${TMPDIR}/tokenvm.config\",\"subnet_spec\":{\"subnet_config\":\"${TMPDIR}/tokenvm.subnet\"}}]"
+$BIN control wait-for-healthy --endpoint="0.0.0.0:12352"
 
 ############################
-if [[ ${MODE} == "run" ]]; then
-  echo "cluster is ready!"
-  # We made it past initialization and should avoid shutting down the network
-  KEEPALIVE=true
-fi
+echo "cluster is ready!"
+# We made it past initialization and should avoid shutting down the network
+KEEPALIVE=true
@@ -0,0 +1,120 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const airdropLen = ids.IDLen*2 + codec.AddressLen + consts.Uint64Len + consts.Int64Len
+
+// Airdrop escrows [Remaining] of [Asset] that can be claimed by anyone who
+// can prove their claim is included in the Merkle tree with [Root]. Once
+// [Expiry] has passed, [Owner] can reclaim whatever is left.
+type Airdrop struct {
+	Asset     ids.ID        `json:"asset"`
+	Root      ids.ID        `json:"root"`
+	Owner     codec.Address `json:"owner"`
+	Remaining uint64        `json:"remaining"`
+	Expiry    int64         `json:"expiry"`
+}
+
+// [airdropPrefix] + [actionID]
+func AirdropKey(actionID ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = airdropPrefix
+	copy(k[1:], actionID[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], AirdropChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetAirdropFromState(ctx context.Context, f ReadState, airdrop ids.ID) (bool, *Airdrop, error) {
+	values, errs := f(ctx, [][]byte{AirdropKey(airdrop)})
+	return innerGetAirdrop(values[0], errs[0])
+}
+
+func GetAirdrop(ctx context.Context, im state.Immutable, airdrop ids.ID) (bool, *Airdrop, error) {
+	k := AirdropKey(airdrop)
+	return innerGetAirdrop(im.GetValue(ctx, k))
+}
+
+func innerGetAirdrop(v []byte, err error) (bool, *Airdrop, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	a := &Airdrop{}
+	copy(a.Asset[:], v[:ids.IDLen])
+	copy(a.Root[:], v[ids.IDLen:])
+	copy(a.Owner[:], v[ids.IDLen*2:])
+	a.Remaining = binary.BigEndian.Uint64(v[ids.IDLen*2+codec.AddressLen:])
+	a.Expiry = int64(binary.BigEndian.Uint64(v[ids.IDLen*2+codec.AddressLen+consts.Uint64Len:]))
+	return true, a, nil
+}
+
+func SetAirdrop(ctx context.Context, mu state.Mutable, airdrop ids.ID, a *Airdrop) error {
+	k := AirdropKey(airdrop)
+	v := make([]byte, 0, airdropLen)
+	v = append(v, a.Asset[:]...)
+	v = append(v, a.Root[:]...)
+	v = append(v, a.Owner[:]...)
+	v = binary.BigEndian.AppendUint64(v, a.Remaining)
+	v = binary.BigEndian.AppendUint64(v, uint64(a.Expiry))
+	return mu.Insert(ctx, k, v)
+}
+
+func DeleteAirdrop(ctx context.Context, mu state.Mutable, airdrop ids.ID) error {
+	k := AirdropKey(airdrop)
+	return mu.Remove(ctx, k)
+}
+
+// [airdropClaimPrefix] + [airdrop] + [index]
+//
+// Claims are kept after an airdrop is exhausted, so that a claim can never be
+// paid twice.
+func AirdropClaimKey(airdrop ids.ID, index uint64) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint64Len+consts.Uint16Len)
+	k[0] = airdropClaimPrefix
+	copy(k[1:], airdrop[:])
+	binary.BigEndian.PutUint64(k[1+ids.IDLen:], index)
+	binary.BigEndian.PutUint16(k[1+ids.IDLen+consts.Uint64Len:], AirdropClaimChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetAirdropClaimedFromState(ctx context.Context, f ReadState, airdrop ids.ID, index uint64) (bool, error) {
+	_, errs := f(ctx, [][]byte{AirdropClaimKey(airdrop, index)})
+	return innerGetAirdropClaimed(errs[0])
+}
+
+func GetAirdropClaimed(ctx context.Context, im state.Immutable, airdrop ids.ID, index uint64) (bool, error) {
+	_, err := im.GetValue(ctx, AirdropClaimKey(airdrop, index))
+	return innerGetAirdropClaimed(err)
+}
+
+func innerGetAirdropClaimed(err error) (bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func SetAirdropClaimed(ctx context.Context, mu state.Mutable, airdrop ids.ID, index uint64) error {
+	return mu.Insert(ctx, AirdropClaimKey(airdrop, index), []byte{successByte})
+}
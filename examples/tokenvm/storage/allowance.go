@@ -0,0 +1,109 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+)
+
+// [allowancePrefix] + [owner] + [spender] + [asset]
+func AllowanceKey(owner codec.Address, spender codec.Address, asset ids.ID) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen*2+ids.IDLen+consts.Uint16Len)
+	k[0] = allowancePrefix
+	copy(k[1:], owner[:])
+	copy(k[1+codec.AddressLen:], spender[:])
+	copy(k[1+codec.AddressLen*2:], asset[:])
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen*2+ids.IDLen:], AllowanceChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetAllowanceFromState(
+	ctx context.Context,
+	f ReadState,
+	owner codec.Address,
+	spender codec.Address,
+	asset ids.ID,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{AllowanceKey(owner, spender, asset)})
+	return innerGetAllowance(values[0], errs[0])
+}
+
+func GetAllowance(
+	ctx context.Context,
+	im state.Immutable,
+	owner codec.Address,
+	spender codec.Address,
+	asset ids.ID,
+) (uint64, error) {
+	k := AllowanceKey(owner, spender, asset)
+	return innerGetAllowance(im.GetValue(ctx, k))
+}
+
+func innerGetAllowance(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+// SetAllowance overwrites the amount of [asset] that [spender] may move on
+// behalf of [owner].
+func SetAllowance(
+	ctx context.Context,
+	mu state.Mutable,
+	owner codec.Address,
+	spender codec.Address,
+	asset ids.ID,
+	amount uint64,
+) error {
+	k := AllowanceKey(owner, spender, asset)
+	if amount == 0 {
+		return mu.Remove(ctx, k)
+	}
+	return mu.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, amount))
+}
+
+func SubAllowance(
+	ctx context.Context,
+	mu state.Mutable,
+	owner codec.Address,
+	spender codec.Address,
+	asset ids.ID,
+	amount uint64,
+) error {
+	allowance, err := GetAllowance(ctx, mu, owner, spender, asset)
+	if err != nil {
+		return err
+	}
+	nallowance, err := smath.Sub(allowance, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not subtract allowance (asset=%s, allowance=%d, owner=%v, spender=%v, amount=%d)",
+			ErrInvalidAllowance,
+			asset,
+			allowance,
+			tconsts.AddressFormat.Encode(owner),
+			tconsts.AddressFormat.Encode(spender),
+			amount,
+		)
+	}
+	return SetAllowance(ctx, mu, owner, spender, asset, nallowance)
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// Assets minted by the bridge record the chain and asset they wrap so that
+// burning them can send the funds back.
+
+// [bridgedPrefix] + [asset]
+func BridgedKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = bridgedPrefix
+	copy(k[1:], asset[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], BridgedChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetBridgedFromState(ctx context.Context, f ReadState, asset ids.ID) (bool, ids.ID, ids.ID, error) {
+	values, errs := f(ctx, [][]byte{BridgedKey(asset)})
+	return innerGetBridged(values[0], errs[0])
+}
+
+// GetBridged returns the source chain and the origin asset of [asset] if it
+// was minted by the bridge.
+func GetBridged(ctx context.Context, im state.Immutable, asset ids.ID) (bool, ids.ID, ids.ID, error) {
+	return innerGetBridged(im.GetValue(ctx, BridgedKey(asset)))
+}
+
+func innerGetBridged(v []byte, err error) (bool, ids.ID, ids.ID, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, ids.Empty, nil
+	}
+	if err != nil {
+		return false, ids.Empty, ids.Empty, err
+	}
+	return true, ids.ID(v[:ids.IDLen]), ids.ID(v[ids.IDLen:]), nil
+}
+
+func SetBridged(ctx context.Context, mu state.Mutable, asset ids.ID, sourceChainID ids.ID, originAsset ids.ID) error {
+	v := make([]byte, 0, ids.IDLen*2)
+	v = append(v, sourceChainID[:]...)
+	v = append(v, originAsset[:]...)
+	return mu.Insert(ctx, BridgedKey(asset), v)
+}
+
+// [warpPrefix] + [msgID]
+func WarpMessageKey(msgID ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = warpPrefix
+	copy(k[1:], msgID[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], WarpMessageChunks)
+	return
+}
+
+// ConsumeWarpMessage records that [msgID] was delivered and errors if it was
+// delivered before.
+func ConsumeWarpMessage(ctx context.Context, mu state.Mutable, msgID ids.ID) error {
+	k := WarpMessageKey(msgID)
+	_, err := mu.GetValue(ctx, k)
+	if err == nil {
+		return ErrWarpMessageConsumed
+	}
+	if !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	return mu.Insert(ctx, k, controlSet)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// Assets can be paused (preventing all movement) and accounts can be frozen
+// (preventing movement into or out of them) by the asset owner. Both controls
+// are stored as the presence of a key.
+var controlSet = []byte{1}
+
+// [pausedPrefix] + [asset]
+func PausedKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = pausedPrefix
+	copy(k[1:], asset[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], PausedChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetPausedFromState(ctx context.Context, f ReadState, asset ids.ID) (bool, error) {
+	values, errs := f(ctx, [][]byte{PausedKey(asset)})
+	return innerGetControl(values[0], errs[0])
+}
+
+func GetPaused(ctx context.Context, im state.Immutable, asset ids.ID) (bool, error) {
+	return innerGetControl(im.GetValue(ctx, PausedKey(asset)))
+}
+
+func SetPaused(ctx context.Context, mu state.Mutable, asset ids.ID, paused bool) error {
+	return setControl(ctx, mu, PausedKey(asset), paused)
+}
+
+// [frozenPrefix] + [asset] + [addr]
+func FrozenKey(asset ids.ID, addr codec.Address) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+codec.AddressLen+consts.Uint16Len)
+	k[0] = frozenPrefix
+	copy(k[1:], asset[:])
+	copy(k[1+ids.IDLen:], addr[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen+codec.AddressLen:], FrozenChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetFrozenFromState(ctx context.Context, f ReadState, asset ids.ID, addr codec.Address) (bool, error) {
+	values, errs := f(ctx, [][]byte{FrozenKey(asset, addr)})
+	return innerGetControl(values[0], errs[0])
+}
+
+func GetFrozen(ctx context.Context, im state.Immutable, asset ids.ID, addr codec.Address) (bool, error) {
+	return innerGetControl(im.GetValue(ctx, FrozenKey(asset, addr)))
+}
+
+func SetFrozen(ctx context.Context, mu state.Mutable, asset ids.ID, addr codec.Address, frozen bool) error {
+	return setControl(ctx, mu, FrozenKey(asset, addr), frozen)
+}
+
+func innerGetControl(_ []byte, err error) (bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func setControl(ctx context.Context, mu state.Mutable, k []byte, set bool) error {
+	if !set {
+		return mu.Remove(ctx, k)
+	}
+	return mu.Insert(ctx, k, controlSet)
+}
@@ -5,4 +5,10 @@ package storage
 
 import "errors"
 
-var ErrInvalidBalance = errors.New("invalid balance")
+var (
+	ErrInvalidBalance   = errors.New("invalid balance")
+	ErrInvalidShares    = errors.New("invalid shares")
+	ErrInvalidAllowance = errors.New("invalid allowance")
+
+	ErrWarpMessageConsumed = errors.New("warp message already consumed")
+)
@@ -0,0 +1,38 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/governance"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// GovernancePrefix is the prefix of every key written by governance actions.
+const GovernancePrefix byte = governancePrefix
+
+var _ governance.Weigher = (*GovernanceWeigher)(nil)
+
+// GovernanceWeigher weights votes by the native balance of the voter.
+//
+// Balances can be transferred during the voting period, so the same funds can
+// vote more than once. This is acceptable for an example VM but production VMs
+// should weight votes by balances that are locked until voting is over.
+type GovernanceWeigher struct{}
+
+func (GovernanceWeigher) WeightStateKeys(addr codec.Address) state.Keys {
+	return state.Keys{string(BalanceKey(addr, ids.Empty)): state.Read}
+}
+
+func (GovernanceWeigher) WeightStateKeysMaxChunks() []uint16 {
+	return []uint16{BalanceChunks}
+}
+
+func (GovernanceWeigher) Weight(ctx context.Context, im state.Immutable, addr codec.Address) (uint64, error) {
+	return GetBalance(ctx, im, addr, ids.Empty)
+}
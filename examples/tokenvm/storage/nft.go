@@ -0,0 +1,266 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// [collectionPrefix] + [collection]
+func CollectionKey(collection ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = collectionPrefix
+	copy(k[1:], collection[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], CollectionChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetCollectionFromState(
+	ctx context.Context,
+	f ReadState,
+	collection ids.ID,
+) (bool, []byte, []byte, uint64, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{CollectionKey(collection)})
+	return innerGetCollection(values[0], errs[0])
+}
+
+func GetCollection(
+	ctx context.Context,
+	im state.Immutable,
+	collection ids.ID,
+) (bool, []byte, []byte, uint64, codec.Address, error) {
+	k := CollectionKey(collection)
+	return innerGetCollection(im.GetValue(ctx, k))
+}
+
+func innerGetCollection(
+	v []byte,
+	err error,
+) (bool, []byte, []byte, uint64, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil, nil, 0, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return false, nil, nil, 0, codec.EmptyAddress, err
+	}
+	nameLen := binary.BigEndian.Uint16(v)
+	name := v[consts.Uint16Len : consts.Uint16Len+nameLen]
+	metadataLen := binary.BigEndian.Uint16(v[consts.Uint16Len+nameLen:])
+	metadata := v[consts.Uint16Len+nameLen+consts.Uint16Len : consts.Uint16Len+nameLen+consts.Uint16Len+metadataLen]
+	supply := binary.BigEndian.Uint64(v[consts.Uint16Len+nameLen+consts.Uint16Len+metadataLen:])
+	var owner codec.Address
+	copy(owner[:], v[consts.Uint16Len+nameLen+consts.Uint16Len+metadataLen+consts.Uint64Len:])
+	return true, name, metadata, supply, owner, nil
+}
+
+func SetCollection(
+	ctx context.Context,
+	mu state.Mutable,
+	collection ids.ID,
+	name []byte,
+	metadata []byte,
+	supply uint64,
+	owner codec.Address,
+) error {
+	k := CollectionKey(collection)
+	nameLen := len(name)
+	metadataLen := len(metadata)
+	v := make([]byte, consts.Uint16Len+nameLen+consts.Uint16Len+metadataLen+consts.Uint64Len+codec.AddressLen)
+	binary.BigEndian.PutUint16(v, uint16(nameLen))
+	copy(v[consts.Uint16Len:], name)
+	binary.BigEndian.PutUint16(v[consts.Uint16Len+nameLen:], uint16(metadataLen))
+	copy(v[consts.Uint16Len+nameLen+consts.Uint16Len:], metadata)
+	binary.BigEndian.PutUint64(v[consts.Uint16Len+nameLen+consts.Uint16Len+metadataLen:], supply)
+	copy(v[consts.Uint16Len+nameLen+consts.Uint16Len+metadataLen+consts.Uint64Len:], owner[:])
+	return mu.Insert(ctx, k, v)
+}
+
+// [nftPrefix] + [collection] + [nftID]
+func NFTKey(collection ids.ID, nftID uint64) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint64Len+consts.Uint16Len)
+	k[0] = nftPrefix
+	copy(k[1:], collection[:])
+	binary.BigEndian.PutUint64(k[1+ids.IDLen:], nftID)
+	binary.BigEndian.PutUint16(k[1+ids.IDLen+consts.Uint64Len:], NFTChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetNFTFromState(
+	ctx context.Context,
+	f ReadState,
+	collection ids.ID,
+	nftID uint64,
+) (bool, []byte, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{NFTKey(collection, nftID)})
+	return innerGetNFT(values[0], errs[0])
+}
+
+func GetNFT(
+	ctx context.Context,
+	im state.Immutable,
+	collection ids.ID,
+	nftID uint64,
+) (bool, []byte, codec.Address, error) {
+	k := NFTKey(collection, nftID)
+	return innerGetNFT(im.GetValue(ctx, k))
+}
+
+func innerGetNFT(v []byte, err error) (bool, []byte, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return false, nil, codec.EmptyAddress, err
+	}
+	metadataLen := binary.BigEndian.Uint16(v)
+	metadata := v[consts.Uint16Len : consts.Uint16Len+metadataLen]
+	var owner codec.Address
+	copy(owner[:], v[consts.Uint16Len+metadataLen:])
+	return true, metadata, owner, nil
+}
+
+func SetNFT(
+	ctx context.Context,
+	mu state.Mutable,
+	collection ids.ID,
+	nftID uint64,
+	metadata []byte,
+	owner codec.Address,
+) error {
+	k := NFTKey(collection, nftID)
+	metadataLen := len(metadata)
+	v := make([]byte, consts.Uint16Len+metadataLen+codec.AddressLen)
+	binary.BigEndian.PutUint16(v, uint16(metadataLen))
+	copy(v[consts.Uint16Len:], metadata)
+	copy(v[consts.Uint16Len+metadataLen:], owner[:])
+	return mu.Insert(ctx, k, v)
+}
+
+func DeleteNFT(ctx context.Context, mu state.Mutable, collection ids.ID, nftID uint64) error {
+	k := NFTKey(collection, nftID)
+	return mu.Remove(ctx, k)
+}
+
+// NFT is the identifier of a unique token.
+type NFT struct {
+	Collection ids.ID `json:"collection"`
+	ID         uint64 `json:"id"`
+}
+
+// [collectionNFTsPrefix] + [collection] + [nftID]
+func collectionNFTKey(collection ids.ID, nftID uint64) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint64Len)
+	k[0] = collectionNFTsPrefix
+	copy(k[1:], collection[:])
+	binary.BigEndian.PutUint64(k[1+ids.IDLen:], nftID)
+	return
+}
+
+// [ownerNFTsPrefix] + [owner] + [collection] + [nftID]
+func ownerNFTKey(owner codec.Address, collection ids.ID, nftID uint64) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+ids.IDLen+consts.Uint64Len)
+	k[0] = ownerNFTsPrefix
+	copy(k[1:], owner[:])
+	copy(k[1+codec.AddressLen:], collection[:])
+	binary.BigEndian.PutUint64(k[1+codec.AddressLen+ids.IDLen:], nftID)
+	return
+}
+
+// StoreNFT indexes a newly minted NFT so that it can be enumerated by
+// collection and by owner.
+func StoreNFT(
+	_ context.Context,
+	db database.KeyValueWriter,
+	collection ids.ID,
+	nftID uint64,
+	owner codec.Address,
+) error {
+	if err := db.Put(collectionNFTKey(collection, nftID), nil); err != nil {
+		return err
+	}
+	return db.Put(ownerNFTKey(owner, collection, nftID), nil)
+}
+
+// StoreNFTTransfer updates the owner index of an NFT.
+func StoreNFTTransfer(
+	_ context.Context,
+	db database.KeyValueWriterDeleter,
+	collection ids.ID,
+	nftID uint64,
+	from codec.Address,
+	to codec.Address,
+) error {
+	if err := db.Delete(ownerNFTKey(from, collection, nftID)); err != nil {
+		return err
+	}
+	return db.Put(ownerNFTKey(to, collection, nftID), nil)
+}
+
+// DeleteStoredNFT removes a burned NFT from all indexes.
+func DeleteStoredNFT(
+	_ context.Context,
+	db database.KeyValueDeleter,
+	collection ids.ID,
+	nftID uint64,
+	owner codec.Address,
+) error {
+	if err := db.Delete(collectionNFTKey(collection, nftID)); err != nil {
+		return err
+	}
+	return db.Delete(ownerNFTKey(owner, collection, nftID))
+}
+
+// GetCollectionNFTs returns the IDs of up to [limit] NFTs in [collection].
+func GetCollectionNFTs(
+	_ context.Context,
+	db database.Iteratee,
+	collection ids.ID,
+	limit int,
+) ([]uint64, error) {
+	prefix := make([]byte, 1+ids.IDLen)
+	prefix[0] = collectionNFTsPrefix
+	copy(prefix[1:], collection[:])
+	iter := db.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	nfts := []uint64{}
+	for len(nfts) < limit && iter.Next() {
+		nfts = append(nfts, binary.BigEndian.Uint64(iter.Key()[len(prefix):]))
+	}
+	return nfts, iter.Error()
+}
+
+// GetOwnerNFTs returns up to [limit] NFTs owned by [owner].
+func GetOwnerNFTs(
+	_ context.Context,
+	db database.Iteratee,
+	owner codec.Address,
+	limit int,
+) ([]*NFT, error) {
+	prefix := make([]byte, 1+codec.AddressLen)
+	prefix[0] = ownerNFTsPrefix
+	copy(prefix[1:], owner[:])
+	iter := db.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	nfts := []*NFT{}
+	for len(nfts) < limit && iter.Next() {
+		k := iter.Key()[len(prefix):]
+		nft := &NFT{ID: binary.BigEndian.Uint64(k[ids.IDLen:])}
+		copy(nft.Collection[:], k[:ids.IDLen])
+		nfts = append(nfts, nft)
+	}
+	return nfts, iter.Error()
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	tconsts "github.com/ava-labs/hypersdk/examples/tokenvm/consts"
+)
+
+// [poolPrefix] + [assetA] + [assetB]
+//
+// [assetA] must sort before [assetB].
+func PoolKey(assetA ids.ID, assetB ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen*2+consts.Uint16Len)
+	k[0] = poolPrefix
+	copy(k[1:], assetA[:])
+	copy(k[1+ids.IDLen:], assetB[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen*2:], PoolChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetPoolFromState(
+	ctx context.Context,
+	f ReadState,
+	assetA ids.ID,
+	assetB ids.ID,
+) (
+	bool, // exists
+	uint64, // reserveA
+	uint64, // reserveB
+	uint64, // shares
+	error,
+) {
+	values, errs := f(ctx, [][]byte{PoolKey(assetA, assetB)})
+	return innerGetPool(values[0], errs[0])
+}
+
+func GetPool(
+	ctx context.Context,
+	im state.Immutable,
+	assetA ids.ID,
+	assetB ids.ID,
+) (
+	bool, // exists
+	uint64, // reserveA
+	uint64, // reserveB
+	uint64, // shares
+	error,
+) {
+	k := PoolKey(assetA, assetB)
+	return innerGetPool(im.GetValue(ctx, k))
+}
+
+func innerGetPool(v []byte, err error) (
+	bool, // exists
+	uint64, // reserveA
+	uint64, // reserveB
+	uint64, // shares
+	error,
+) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, 0, 0, 0, err
+	}
+	reserveA := binary.BigEndian.Uint64(v)
+	reserveB := binary.BigEndian.Uint64(v[consts.Uint64Len:])
+	shares := binary.BigEndian.Uint64(v[consts.Uint64Len*2:])
+	return true, reserveA, reserveB, shares, nil
+}
+
+func SetPool(
+	ctx context.Context,
+	mu state.Mutable,
+	assetA ids.ID,
+	assetB ids.ID,
+	reserveA uint64,
+	reserveB uint64,
+	shares uint64,
+) error {
+	k := PoolKey(assetA, assetB)
+	v := make([]byte, consts.Uint64Len*3)
+	binary.BigEndian.PutUint64(v, reserveA)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], reserveB)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len*2:], shares)
+	return mu.Insert(ctx, k, v)
+}
+
+func DeletePool(ctx context.Context, mu state.Mutable, assetA ids.ID, assetB ids.ID) error {
+	k := PoolKey(assetA, assetB)
+	return mu.Remove(ctx, k)
+}
+
+// [sharesPrefix] + [owner] + [assetA] + [assetB]
+func SharesKey(owner codec.Address, assetA ids.ID, assetB ids.ID) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+ids.IDLen*2+consts.Uint16Len)
+	k[0] = sharesPrefix
+	copy(k[1:], owner[:])
+	copy(k[1+codec.AddressLen:], assetA[:])
+	copy(k[1+codec.AddressLen+ids.IDLen:], assetB[:])
+	binary.BigEndian.PutUint16(k[1+codec.AddressLen+ids.IDLen*2:], SharesChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetSharesFromState(
+	ctx context.Context,
+	f ReadState,
+	owner codec.Address,
+	assetA ids.ID,
+	assetB ids.ID,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{SharesKey(owner, assetA, assetB)})
+	return innerGetShares(values[0], errs[0])
+}
+
+func GetShares(
+	ctx context.Context,
+	im state.Immutable,
+	owner codec.Address,
+	assetA ids.ID,
+	assetB ids.ID,
+) (uint64, error) {
+	k := SharesKey(owner, assetA, assetB)
+	return innerGetShares(im.GetValue(ctx, k))
+}
+
+func innerGetShares(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func AddShares(
+	ctx context.Context,
+	mu state.Mutable,
+	owner codec.Address,
+	assetA ids.ID,
+	assetB ids.ID,
+	amount uint64,
+) error {
+	shares, err := GetShares(ctx, mu, owner, assetA, assetB)
+	if err != nil {
+		return err
+	}
+	nshares, err := smath.Add64(shares, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not add shares (pool=%s-%s, shares=%d, addr=%v, amount=%d)",
+			ErrInvalidShares,
+			assetA,
+			assetB,
+			shares,
+			tconsts.AddressFormat.Encode(owner),
+			amount,
+		)
+	}
+	k := SharesKey(owner, assetA, assetB)
+	return mu.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, nshares))
+}
+
+func SubShares(
+	ctx context.Context,
+	mu state.Mutable,
+	owner codec.Address,
+	assetA ids.ID,
+	assetB ids.ID,
+	amount uint64,
+) error {
+	shares, err := GetShares(ctx, mu, owner, assetA, assetB)
+	if err != nil {
+		return err
+	}
+	nshares, err := smath.Sub(shares, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not subtract shares (pool=%s-%s, shares=%d, addr=%v, amount=%d)",
+			ErrInvalidShares,
+			assetA,
+			assetB,
+			shares,
+			tconsts.AddressFormat.Encode(owner),
+			amount,
+		)
+	}
+	k := SharesKey(owner, assetA, assetB)
+	if nshares == 0 {
+		// If there are no shares left, we should delete the record instead of
+		// setting it to 0.
+		return mu.Remove(ctx, k)
+	}
+	return mu.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, nshares))
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// [royaltyPrefix] + [asset]
+func RoyaltyKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = royaltyPrefix
+	copy(k[1:], asset[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], RoyaltyChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetRoyaltyFromState(ctx context.Context, f ReadState, asset ids.ID) (uint16, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{RoyaltyKey(asset)})
+	return innerGetRoyalty(values[0], errs[0])
+}
+
+// GetRoyalty returns the basis points of the proceeds from selling [asset]
+// that are paid to the returned recipient. Assets without a royalty return
+// 0 basis points.
+func GetRoyalty(ctx context.Context, im state.Immutable, asset ids.ID) (uint16, codec.Address, error) {
+	return innerGetRoyalty(im.GetValue(ctx, RoyaltyKey(asset)))
+}
+
+func innerGetRoyalty(v []byte, err error) (uint16, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return 0, codec.EmptyAddress, err
+	}
+	var recipient codec.Address
+	copy(recipient[:], v[consts.Uint16Len:])
+	return binary.BigEndian.Uint16(v), recipient, nil
+}
+
+func SetRoyalty(
+	ctx context.Context,
+	mu state.Mutable,
+	asset ids.ID,
+	basisPoints uint16,
+	recipient codec.Address,
+) error {
+	v := make([]byte, consts.Uint16Len+codec.AddressLen)
+	binary.BigEndian.PutUint16(v, basisPoints)
+	copy(v[consts.Uint16Len:], recipient[:])
+	return mu.Insert(ctx, RoyaltyKey(asset), v)
+}
@@ -28,35 +28,103 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // Metadata
 // 0x0/ (tx)
 //   -> [txID] => timestamp
+// 0x1/ (collection nfts)
+//   -> [collection|nftID] => nil
+// 0x2/ (owner nfts)
+//   -> [owner|collection|nftID] => nil
+// 0x3/ (owner orders)
+//   -> [owner|orderID] => nil
 //
 // State
 // 0x0/ (balance)
 //   -> [owner|asset] => balance
 // 0x1/ (assets)
-//   -> [asset] => metadataLen|metadata|supply|owner
+//   -> [asset] => symbolLen|symbol|decimals|metadataLen|metadata|supply|owner|uriLen|uri
 // 0x2/ (orders)
-//   -> [txID] => in|out|rate|remaining|owner
+//   -> [txID] => in|out|rate|remaining|owner|expiry
 // 0x3/ (hypersdk-height)
 // 0x4/ (hypersdk-timestamp)
 // 0x5/ (hypersdk-fee)
+// 0x6/ (collections)
+//   -> [collection] => nameLen|name|metadataLen|metadata|supply|owner
+// 0x7/ (nfts)
+//   -> [collection|nftID] => metadataLen|metadata|owner
+// 0x8/ (pools)
+//   -> [assetA|assetB] => reserveA|reserveB|shares
+// 0x9/ (pool shares)
+//   -> [owner|assetA|assetB] => shares
+// 0xa/ (paused assets)
+//   -> [asset] => 1
+// 0xb/ (frozen accounts)
+//   -> [asset|owner] => 1
+// 0xc/ (allowances)
+//   -> [owner|spender|asset] => allowance
+// 0xd/ (streams)
+//   -> [actionID] => asset|payer|payee|rate|start|cliff|total|claimed
+// 0xe/ (burned supply)
+//   -> [asset] => burned
+// 0xf/ (royalties)
+//   -> [asset] => basisPoints|recipient
+// 0x10/ (airdrops)
+//   -> [actionID] => asset|root|owner|remaining
+// 0x11/ (airdrop claims)
+//   -> [airdrop|index] => 1
+// 0x12/ (bridged assets)
+//   -> [asset] => sourceChainID|originAsset
+// 0x13/ (consumed warp messages)
+//   -> [msgID] => 1
+// 0x14/ (governance)
+//   -> proposals, votes, and executed changes (see hypersdk/governance)
 
 const (
 	// Indexes
-	txPrefix = 0x0
+	txPrefix             = 0x0
+	collectionNFTsPrefix = 0x1
+	ownerNFTsPrefix      = 0x2
+	ownerOrdersPrefix    = 0x3
 
 	// Active state
-	balancePrefix   = 0x0
-	assetPrefix     = 0x1
-	orderPrefix     = 0x2
-	heightPrefix    = 0x3
-	timestampPrefix = 0x4
-	feePrefix       = 0x5
+	balancePrefix      = 0x0
+	assetPrefix        = 0x1
+	orderPrefix        = 0x2
+	heightPrefix       = 0x3
+	timestampPrefix    = 0x4
+	feePrefix          = 0x5
+	collectionPrefix   = 0x6
+	nftPrefix          = 0x7
+	poolPrefix         = 0x8
+	sharesPrefix       = 0x9
+	pausedPrefix       = 0xa
+	frozenPrefix       = 0xb
+	allowancePrefix    = 0xc
+	streamPrefix       = 0xd
+	burnedPrefix       = 0xe
+	royaltyPrefix      = 0xf
+	airdropPrefix      = 0x10
+	airdropClaimPrefix = 0x11
+	bridgedPrefix      = 0x12
+	warpPrefix         = 0x13
+	governancePrefix   = 0x14
 )
 
 const (
-	BalanceChunks uint16 = 1
-	AssetChunks   uint16 = 5
-	OrderChunks   uint16 = 2
+	BalanceChunks      uint16 = 1
+	AssetChunks        uint16 = 9
+	OrderChunks        uint16 = 3
+	CollectionChunks   uint16 = 6
+	NFTChunks          uint16 = 5
+	PoolChunks         uint16 = 1
+	SharesChunks       uint16 = 1
+	PausedChunks       uint16 = 1
+	FrozenChunks       uint16 = 1
+	AllowanceChunks    uint16 = 1
+	StreamChunks       uint16 = 3
+	BurnedChunks       uint16 = 1
+	RoyaltyChunks      uint16 = 1
+	AirdropChunks      uint16 = 2
+	AirdropClaimChunks uint16 = 1
+	BridgedChunks      uint16 = 2
+	WarpMessageChunks  uint16 = 1
 )
 
 var (
@@ -242,7 +310,7 @@ func AddBalance(
 			ErrInvalidBalance,
 			asset,
 			bal,
-			codec.MustAddressBech32(tconsts.HRP, addr),
+			tconsts.AddressFormat.Encode(addr),
 			amount,
 		)
 	}
@@ -267,7 +335,7 @@ func SubBalance(
 			ErrInvalidBalance,
 			asset,
 			bal,
-			codec.MustAddressBech32(tconsts.HRP, addr),
+			tconsts.AddressFormat.Encode(addr),
 			amount,
 		)
 	}
@@ -293,7 +361,7 @@ func GetAssetFromState(
 	ctx context.Context,
 	f ReadState,
 	asset ids.ID,
-) (bool, []byte, uint8, []byte, uint64, codec.Address, error) {
+) (bool, []byte, uint8, []byte, []byte, uint64, codec.Address, error) {
 	values, errs := f(ctx, [][]byte{AssetKey(asset)})
 	return innerGetAsset(values[0], errs[0])
 }
@@ -302,7 +370,7 @@ func GetAsset(
 	ctx context.Context,
 	im state.Immutable,
 	asset ids.ID,
-) (bool, []byte, uint8, []byte, uint64, codec.Address, error) {
+) (bool, []byte, uint8, []byte, []byte, uint64, codec.Address, error) {
 	k := AssetKey(asset)
 	return innerGetAsset(im.GetValue(ctx, k))
 }
@@ -310,12 +378,12 @@ func GetAsset(
 func innerGetAsset(
 	v []byte,
 	err error,
-) (bool, []byte, uint8, []byte, uint64, codec.Address, error) {
+) (bool, []byte, uint8, []byte, []byte, uint64, codec.Address, error) {
 	if errors.Is(err, database.ErrNotFound) {
-		return false, nil, 0, nil, 0, codec.EmptyAddress, nil
+		return false, nil, 0, nil, nil, 0, codec.EmptyAddress, nil
 	}
 	if err != nil {
-		return false, nil, 0, nil, 0, codec.EmptyAddress, err
+		return false, nil, 0, nil, nil, 0, codec.EmptyAddress, err
 	}
 	symbolLen := binary.BigEndian.Uint16(v)
 	symbol := v[consts.Uint16Len : consts.Uint16Len+symbolLen]
@@ -325,7 +393,9 @@ func innerGetAsset(
 	supply := binary.BigEndian.Uint64(v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen:])
 	var addr codec.Address
 	copy(addr[:], v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len:])
-	return true, symbol, decimals, metadata, supply, addr, nil
+	uriLen := binary.BigEndian.Uint16(v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len+codec.AddressLen:])
+	uri := v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len+codec.AddressLen+consts.Uint16Len:][:uriLen]
+	return true, symbol, decimals, metadata, uri, supply, addr, nil
 }
 
 func SetAsset(
@@ -335,13 +405,15 @@ func SetAsset(
 	symbol []byte,
 	decimals uint8,
 	metadata []byte,
+	uri []byte,
 	supply uint64,
 	owner codec.Address,
 ) error {
 	k := AssetKey(asset)
 	symbolLen := len(symbol)
 	metadataLen := len(metadata)
-	v := make([]byte, consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len+codec.AddressLen)
+	uriLen := len(uri)
+	v := make([]byte, consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len+codec.AddressLen+consts.Uint16Len+uriLen)
 	binary.BigEndian.PutUint16(v, uint16(symbolLen))
 	copy(v[consts.Uint16Len:], symbol)
 	v[consts.Uint16Len+symbolLen] = decimals
@@ -349,6 +421,8 @@ func SetAsset(
 	copy(v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len:], metadata)
 	binary.BigEndian.PutUint64(v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen:], supply)
 	copy(v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len:], owner[:])
+	binary.BigEndian.PutUint16(v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len+codec.AddressLen:], uint16(uriLen))
+	copy(v[consts.Uint16Len+symbolLen+consts.Uint8Len+consts.Uint16Len+metadataLen+consts.Uint64Len+codec.AddressLen+consts.Uint16Len:], uri)
 	return mu.Insert(ctx, k, v)
 }
 
@@ -376,15 +450,17 @@ func SetOrder(
 	outTick uint64,
 	supply uint64,
 	owner codec.Address,
+	expiry int64,
 ) error {
 	k := OrderKey(actionID)
-	v := make([]byte, ids.IDLen*2+consts.Uint64Len*3+codec.AddressLen)
+	v := make([]byte, ids.IDLen*2+consts.Uint64Len*3+codec.AddressLen+consts.Int64Len)
 	copy(v, in[:])
 	binary.BigEndian.PutUint64(v[ids.IDLen:], inTick)
 	copy(v[ids.IDLen+consts.Uint64Len:], out[:])
 	binary.BigEndian.PutUint64(v[ids.IDLen*2+consts.Uint64Len:], outTick)
 	binary.BigEndian.PutUint64(v[ids.IDLen*2+consts.Uint64Len*2:], supply)
 	copy(v[ids.IDLen*2+consts.Uint64Len*3:], owner[:])
+	binary.BigEndian.PutUint64(v[ids.IDLen*2+consts.Uint64Len*3+codec.AddressLen:], uint64(expiry))
 	return mu.Insert(ctx, k, v)
 }
 
@@ -400,6 +476,7 @@ func GetOrder(
 	uint64, // outTick
 	uint64, // remaining
 	codec.Address, // owner
+	int64, // expiry
 	error,
 ) {
 	k := OrderKey(order)
@@ -420,6 +497,7 @@ func GetOrderFromState(
 	uint64, // outTick
 	uint64, // remaining
 	codec.Address, // owner
+	int64, // expiry
 	error,
 ) {
 	values, errs := f(ctx, [][]byte{OrderKey(order)})
@@ -434,13 +512,14 @@ func innerGetOrder(v []byte, err error) (
 	uint64, // outTick
 	uint64, // remaining
 	codec.Address, // owner
+	int64, // expiry
 	error,
 ) {
 	if errors.Is(err, database.ErrNotFound) {
-		return false, ids.Empty, 0, ids.Empty, 0, 0, codec.EmptyAddress, nil
+		return false, ids.Empty, 0, ids.Empty, 0, 0, codec.EmptyAddress, 0, nil
 	}
 	if err != nil {
-		return false, ids.Empty, 0, ids.Empty, 0, 0, codec.EmptyAddress, err
+		return false, ids.Empty, 0, ids.Empty, 0, 0, codec.EmptyAddress, 0, err
 	}
 	var in ids.ID
 	copy(in[:], v[:ids.IDLen])
@@ -451,7 +530,8 @@ func innerGetOrder(v []byte, err error) (
 	supply := binary.BigEndian.Uint64(v[ids.IDLen*2+consts.Uint64Len*2:])
 	var owner codec.Address
 	copy(owner[:], v[ids.IDLen*2+consts.Uint64Len*3:])
-	return true, in, inTick, out, outTick, supply, owner, nil
+	expiry := int64(binary.BigEndian.Uint64(v[ids.IDLen*2+consts.Uint64Len*3+codec.AddressLen:]))
+	return true, in, inTick, out, outTick, supply, owner, expiry, nil
 }
 
 func DeleteOrder(ctx context.Context, mu state.Mutable, order ids.ID) error {
@@ -459,6 +539,59 @@ func DeleteOrder(ctx context.Context, mu state.Mutable, order ids.ID) error {
 	return mu.Remove(ctx, k)
 }
 
+// [ownerOrdersPrefix] + [owner] + [orderID]
+func ownerOrderKey(owner codec.Address, order ids.ID) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+ids.IDLen)
+	k[0] = ownerOrdersPrefix
+	copy(k[1:], owner[:])
+	copy(k[1+codec.AddressLen:], order[:])
+	return
+}
+
+// StoreOrder indexes a newly created order so that it can be enumerated by
+// owner.
+func StoreOrder(
+	_ context.Context,
+	db database.KeyValueWriter,
+	owner codec.Address,
+	order ids.ID,
+) error {
+	return db.Put(ownerOrderKey(owner, order), nil)
+}
+
+// DeleteStoredOrder removes a closed or filled order from the owner index.
+func DeleteStoredOrder(
+	_ context.Context,
+	db database.KeyValueDeleter,
+	owner codec.Address,
+	order ids.ID,
+) error {
+	return db.Delete(ownerOrderKey(owner, order))
+}
+
+// GetOwnerOrders returns the IDs of up to [limit] open orders created by
+// [owner].
+func GetOwnerOrders(
+	_ context.Context,
+	db database.Iteratee,
+	owner codec.Address,
+	limit int,
+) ([]ids.ID, error) {
+	prefix := make([]byte, 1+codec.AddressLen)
+	prefix[0] = ownerOrdersPrefix
+	copy(prefix[1:], owner[:])
+	iter := db.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	orders := []ids.ID{}
+	for len(orders) < limit && iter.Next() {
+		var order ids.ID
+		copy(order[:], iter.Key()[len(prefix):])
+		orders = append(orders, order)
+	}
+	return orders, iter.Error()
+}
+
 func HeightKey() (k []byte) {
 	return heightKey
 }
@@ -470,3 +603,8 @@ func TimestampKey() (k []byte) {
 func FeeKey() (k []byte) {
 	return feeKey
 }
+
+// ReservedPrefixes are the prefixes of the keys that actions can't touch (the chain metadata and delivered warp messages).
+func ReservedPrefixes() [][]byte {
+	return [][]byte{{heightPrefix}, {timestampPrefix}, {feePrefix}, {warpPrefix}}
+}
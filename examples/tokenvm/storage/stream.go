@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const streamLen = ids.IDLen + codec.AddressLen*2 + consts.Uint64Len + consts.Int64Len*2 + consts.Uint64Len*2
+
+// Stream releases [Total] of [Asset] from [Payer] to [Payee] at [Rate] units
+// per second, starting at [Start]. Nothing can be claimed before [Cliff].
+type Stream struct {
+	Asset   ids.ID        `json:"asset"`
+	Payer   codec.Address `json:"payer"`
+	Payee   codec.Address `json:"payee"`
+	Rate    uint64        `json:"rate"`
+	Start   int64         `json:"start"`
+	Cliff   int64         `json:"cliff"`
+	Total   uint64        `json:"total"`
+	Claimed uint64        `json:"claimed"`
+}
+
+// [streamPrefix] + [actionID]
+func StreamKey(actionID ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = streamPrefix
+	copy(k[1:], actionID[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], StreamChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetStreamFromState(ctx context.Context, f ReadState, stream ids.ID) (bool, *Stream, error) {
+	values, errs := f(ctx, [][]byte{StreamKey(stream)})
+	return innerGetStream(values[0], errs[0])
+}
+
+func GetStream(ctx context.Context, im state.Immutable, stream ids.ID) (bool, *Stream, error) {
+	k := StreamKey(stream)
+	return innerGetStream(im.GetValue(ctx, k))
+}
+
+func innerGetStream(v []byte, err error) (bool, *Stream, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	s := &Stream{}
+	copy(s.Asset[:], v[:ids.IDLen])
+	copy(s.Payer[:], v[ids.IDLen:])
+	copy(s.Payee[:], v[ids.IDLen+codec.AddressLen:])
+	o := ids.IDLen + codec.AddressLen*2
+	s.Rate = binary.BigEndian.Uint64(v[o:])
+	s.Start = int64(binary.BigEndian.Uint64(v[o+consts.Uint64Len:]))
+	s.Cliff = int64(binary.BigEndian.Uint64(v[o+consts.Uint64Len+consts.Int64Len:]))
+	s.Total = binary.BigEndian.Uint64(v[o+consts.Uint64Len+consts.Int64Len*2:])
+	s.Claimed = binary.BigEndian.Uint64(v[o+consts.Uint64Len*2+consts.Int64Len*2:])
+	return true, s, nil
+}
+
+func SetStream(ctx context.Context, mu state.Mutable, stream ids.ID, s *Stream) error {
+	k := StreamKey(stream)
+	v := make([]byte, 0, streamLen)
+	v = append(v, s.Asset[:]...)
+	v = append(v, s.Payer[:]...)
+	v = append(v, s.Payee[:]...)
+	v = binary.BigEndian.AppendUint64(v, s.Rate)
+	v = binary.BigEndian.AppendUint64(v, uint64(s.Start))
+	v = binary.BigEndian.AppendUint64(v, uint64(s.Cliff))
+	v = binary.BigEndian.AppendUint64(v, s.Total)
+	v = binary.BigEndian.AppendUint64(v, s.Claimed)
+	return mu.Insert(ctx, k, v)
+}
+
+func DeleteStream(ctx context.Context, mu state.Mutable, stream ids.ID) error {
+	k := StreamKey(stream)
+	return mu.Remove(ctx, k)
+}
+
+// GetTimestampFromState returns the timestamp of the last accepted block.
+func GetTimestampFromState(ctx context.Context, f ReadState) (int64, error) {
+	values, errs := f(ctx, [][]byte{chain.TimestampKey(TimestampKey())})
+	if errs[0] != nil {
+		return 0, errs[0]
+	}
+	return int64(binary.BigEndian.Uint64(values[0])), nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// The supply stored with an asset is its circulating supply. The total
+// amount ever burned is tracked separately so that the amount minted can be
+// derived without replaying history.
+
+// [burnedPrefix] + [asset]
+func BurnedKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+ids.IDLen+consts.Uint16Len)
+	k[0] = burnedPrefix
+	copy(k[1:], asset[:])
+	binary.BigEndian.PutUint16(k[1+ids.IDLen:], BurnedChunks)
+	return
+}
+
+// Used to serve RPC queries
+func GetBurnedFromState(ctx context.Context, f ReadState, asset ids.ID) (uint64, error) {
+	values, errs := f(ctx, [][]byte{BurnedKey(asset)})
+	return innerGetBurned(values[0], errs[0])
+}
+
+func GetBurned(ctx context.Context, im state.Immutable, asset ids.ID) (uint64, error) {
+	return innerGetBurned(im.GetValue(ctx, BurnedKey(asset)))
+}
+
+func innerGetBurned(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func AddBurned(ctx context.Context, mu state.Mutable, asset ids.ID, amount uint64) error {
+	burned, err := GetBurned(ctx, mu, asset)
+	if err != nil {
+		return err
+	}
+	nburned, err := smath.Add64(burned, amount)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, BurnedKey(asset), binary.BigEndian.AppendUint64(nil, nburned))
+}
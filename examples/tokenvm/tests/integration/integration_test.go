@@ -16,20 +16,24 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/fatih/color"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/hypersdk/bridge"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
@@ -95,6 +99,10 @@ var (
 
 	networkID uint32
 	gen       *genesis.Genesis
+
+	// single validator subnet that bridged tokens are sent from
+	bridgeChainID ids.ID
+	bridgeSK      *bls.SecretKey
 )
 
 func init() {
@@ -149,7 +157,7 @@ var _ = ginkgo.BeforeSuite(func() {
 	require.NoError(err)
 	factory = auth.NewED25519Factory(priv)
 	rsender = auth.NewED25519Address(priv.PublicKey())
-	sender = codec.MustAddressBech32(tconsts.HRP, rsender)
+	sender = tconsts.AddressFormat.Encode(rsender)
 	log.Debug(
 		"generated key",
 		zap.String("addr", sender),
@@ -160,7 +168,7 @@ var _ = ginkgo.BeforeSuite(func() {
 	require.NoError(err)
 	factory2 = auth.NewED25519Factory(priv2)
 	rsender2 = auth.NewED25519Address(priv2.PublicKey())
-	sender2 = codec.MustAddressBech32(tconsts.HRP, rsender2)
+	sender2 = tconsts.AddressFormat.Encode(rsender2)
 	log.Debug(
 		"generated key",
 		zap.String("addr", sender2),
@@ -171,7 +179,7 @@ var _ = ginkgo.BeforeSuite(func() {
 	require.NoError(err)
 	factory3 = auth.NewED25519Factory(priv3)
 	rsender3 = auth.NewED25519Address(priv3.PublicKey())
-	sender3 = codec.MustAddressBech32(tconsts.HRP, rsender3)
+	sender3 = tconsts.AddressFormat.Encode(rsender3)
 	log.Debug(
 		"generated key",
 		zap.String("addr", sender3),
@@ -196,6 +204,8 @@ var _ = ginkgo.BeforeSuite(func() {
 
 	gen = genesis.Default()
 	gen.MinUnitPrice = fees.Dimensions{1, 1, 1, 1, 1}
+	// Keep unit prices at their minimum (the fees asserted below assume it)
+	gen.WindowTargetUnits = fees.Dimensions{1_000_000_000, 1_000_000_000, 1_000_000_000, 1_000_000_000, 1_000_000_000}
 	gen.MinBlockGap = 0
 	gen.CustomAllocation = []*genesis.CustomAllocation{
 		{
@@ -210,6 +220,35 @@ var _ = ginkgo.BeforeSuite(func() {
 	subnetID := ids.GenerateTestID()
 	chainID := ids.GenerateTestID()
 
+	bridgeSubnetID := ids.GenerateTestID()
+	bridgeChainID = ids.GenerateTestID()
+	bridgeSK, err = bls.NewSecretKey()
+	require.NoError(err)
+	vdrState := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 1, nil
+		},
+		GetSubnetIDF: func(_ context.Context, chainID ids.ID) (ids.ID, error) {
+			if chainID != bridgeChainID {
+				return ids.Empty, database.ErrNotFound
+			}
+			return bridgeSubnetID, nil
+		},
+		GetValidatorSetF: func(_ context.Context, _ uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			if subnetID != bridgeSubnetID {
+				return nil, database.ErrNotFound
+			}
+			nodeID := ids.GenerateTestNodeID()
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(bridgeSK),
+					Weight:    1,
+				},
+			}, nil
+		},
+	}
+
 	app := &appSender{}
 	for i := range instances {
 		nodeID := ids.GenerateTestNodeID()
@@ -228,7 +267,8 @@ var _ = ginkgo.BeforeSuite(func() {
 			ChainDataDir:   dname,
 			Metrics:        metrics.NewPrefixGatherer(),
 			PublicKey:      bls.PublicFromSecretKey(sk),
-			ValidatorState: &validators.TestState{},
+			WarpSigner:     warp.NewSigner(sk, networkID, chainID),
+			ValidatorState: vdrState,
 		}
 
 		toEngine := make(chan common.Message, 1)
@@ -300,7 +340,7 @@ var _ = ginkgo.BeforeSuite(func() {
 		require.Equal(decimals, uint8(tconsts.Decimals))
 		require.Equal(string(metadata), tconsts.Name)
 		require.Equal(supply, csupply)
-		require.Equal(owner, codec.MustAddressBech32(tconsts.HRP, codec.EmptyAddress))
+		require.Equal(owner, tconsts.AddressFormat.Encode(codec.EmptyAddress))
 	}
 	blocks = []snowman.Block{}
 
@@ -358,8 +398,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 	// read: 2 keys reads
 	// allocate: 1 key created with 1 chunk
 	// write: 2 keys modified
-	transferTxUnits := fees.Dimensions{224, 7, 14, 50, 26}
-	transferTxFee := uint64(321)
+	transferTxUnits := fees.Dimensions{232, 7, 14, 50, 26}
+	transferTxFee := uint64(329)
 
 	ginkgo.It("get currently accepted block ID", func() {
 		for _, inst := range instances {
@@ -473,7 +513,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		ginkgo.By("ensure balance is updated", func() {
 			balance, err := instances[1].tcli.Balance(context.Background(), sender, ids.Empty)
 			require.NoError(err)
-			require.Equal(balance, uint64(9_899_679))
+			require.Equal(balance, uint64(9_899_671))
 			balance2, err := instances[1].tcli.Balance(context.Background(), sender2, ids.Empty)
 			require.NoError(err)
 			require.Equal(balance2, uint64(100_000))
@@ -1065,6 +1105,13 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		require.Equal(metadata, asset1)
 		require.Equal(supply, uint64(10))
 		require.Equal(owner, sender)
+
+		exists, assetSupply, err := instances[0].tcli.AssetSupply(context.TODO(), asset1ID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(uint64(10), assetSupply.Supply)
+		require.Equal(uint64(5), assetSupply.Burned)
+		require.Equal(uint64(15), assetSupply.Minted)
 	})
 
 	ginkgo.It("burn missing asset", func() {
@@ -1097,6 +1144,11 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		require.Equal(metadata, asset1)
 		require.Equal(supply, uint64(10))
 		require.Equal(owner, sender)
+
+		exists, assetSupply, err := instances[0].tcli.AssetSupply(context.TODO(), asset1ID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(uint64(5), assetSupply.Burned)
 	})
 
 	ginkgo.It("rejects empty mint", func() {
@@ -1424,7 +1476,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		require.NoError(err)
 		require.Len(orders, 1)
 		order := orders[0]
-		owner, err := codec.ParseAddressBech32(tconsts.HRP, order.Owner)
+		owner, err := tconsts.AddressFormat.Parse(order.Owner)
 		require.NoError(err)
 		parser, err := instances[0].tcli.Parser(context.Background())
 		require.NoError(err)
@@ -1456,7 +1508,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		require.NoError(err)
 		require.Len(orders, 1)
 		order := orders[0]
-		owner, err := codec.ParseAddressBech32(tconsts.HRP, order.Owner)
+		owner, err := tconsts.AddressFormat.Parse(order.Owner)
 		require.NoError(err)
 		parser, err := instances[0].tcli.Parser(context.Background())
 		require.NoError(err)
@@ -1488,7 +1540,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		require.NoError(err)
 		require.Len(orders, 1)
 		order := orders[0]
-		owner, err := codec.ParseAddressBech32(tconsts.HRP, order.Owner)
+		owner, err := tconsts.AddressFormat.Parse(order.Owner)
 		require.NoError(err)
 		parser, err := instances[0].tcli.Parser(context.Background())
 		require.NoError(err)
@@ -1645,7 +1697,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		require.NoError(err)
 		require.Len(orders, 1)
 		order := orders[0]
-		owner, err := codec.ParseAddressBech32(tconsts.HRP, order.Owner)
+		owner, err := tconsts.AddressFormat.Parse(order.Owner)
 		require.NoError(err)
 		parser, err := instances[0].tcli.Parser(context.Background())
 		require.NoError(err)
@@ -2055,23 +2107,1753 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		require.False(result.Success)
 		require.Contains(string(result.Error), "value is misaligned")
 	})
-})
 
-func expectBlk(i instance) func(bool) []*chain.Result {
-	require := require.New(ginkgo.GinkgoT())
+	ginkgo.It("create, mint, transfer, and burn nfts", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
 
-	ctx := context.TODO()
+		// Create collection and mint
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateCollection{
+				Name:     []byte("apes"),
+				Metadata: []byte("bored"),
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		collectionID := chain.CreateActionID(tx.ID(), 0)
 
-	// manually signal ready
-	require.NoError(i.vm.Builder().Force(ctx))
-	// manually ack ready sig as in engine
-	<-i.toEngine
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintNFT{To: rsender, Collection: collectionID, ID: 1, Metadata: []byte("ipfs://1")},
+				&actions.MintNFT{To: rsender, Collection: collectionID, ID: 2, Metadata: []byte("ipfs://2")},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
 
-	blk, err := i.vm.BuildBlock(ctx)
-	require.NoError(err)
-	require.NotNil(blk)
+		exists, name, _, supply, owner, err := instances[0].tcli.Collection(ctx, collectionID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal([]byte("apes"), name)
+		require.Equal(uint64(2), supply)
+		require.Equal(sender, owner)
+		nfts, err := instances[0].tcli.CollectionNFTs(ctx, collectionID)
+		require.NoError(err)
+		require.Equal([]uint64{1, 2}, nfts)
+
+		// Reject duplicate mint
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.MintNFT{To: rsender, Collection: collectionID, ID: 1, Metadata: []byte("ipfs://1")}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "nft already exists")
+
+		// Transfer and burn
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.TransferNFT{To: rsender2, Collection: collectionID, ID: 1},
+				&actions.BurnNFT{Collection: collectionID, ID: 2},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		exists, metadata, owner, err := instances[0].tcli.GetNFT(ctx, collectionID, 1)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal([]byte("ipfs://1"), metadata)
+		require.Equal(sender2, owner)
+		exists, _, _, err = instances[0].tcli.GetNFT(ctx, collectionID, 2)
+		require.NoError(err)
+		require.False(exists)
+		owned, err := instances[0].tcli.OwnerNFTs(ctx, sender2)
+		require.NoError(err)
+		require.Len(owned, 1)
+		require.Equal(collectionID, owned[0].Collection)
+		require.Equal(uint64(1), owned[0].ID)
+		owned, err = instances[0].tcli.OwnerNFTs(ctx, sender)
+		require.NoError(err)
+		require.Empty(owned)
+		nfts, err = instances[0].tcli.CollectionNFTs(ctx, collectionID)
+		require.NoError(err)
+		require.Equal([]uint64{1}, nfts)
+
+		// Only the owner can transfer
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.TransferNFT{To: rsender, Collection: collectionID, ID: 1}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "wrong owner")
+	})
+
+	ginkgo.It("expire and close all orders", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// Create and mint asset
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:   []byte("EXP"),
+				Decimals: 0,
+				Metadata: []byte("expiring"),
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		assetID := chain.CreateActionID(tx.ID(), 0)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.MintAsset{To: rsender, Asset: assetID, Value: 100}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Reject expiry in the past
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateOrder{
+				In:      ids.Empty,
+				InTick:  1,
+				Out:     assetID,
+				OutTick: 1,
+				Supply:  10,
+				Expiry:  1,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "expiry is in the past")
+
+		// Create expiring and non-expiring orders
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.CreateOrder{
+					In:      ids.Empty,
+					InTick:  1,
+					Out:     assetID,
+					OutTick: 1,
+					Supply:  10,
+					Expiry:  time.Now().Add(time.Second).UnixMilli(),
+				},
+				&actions.CreateOrder{
+					In:      ids.Empty,
+					InTick:  1,
+					Out:     assetID,
+					OutTick: 1,
+					Supply:  20,
+				},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		expiringID := chain.CreateActionID(tx.ID(), 0)
+		openID := chain.CreateActionID(tx.ID(), 1)
+		orders, err := instances[0].tcli.Orders(ctx, actions.PairID(ids.Empty, assetID))
+		require.NoError(err)
+		require.Len(orders, 2)
+
+		// Fill of expired order returns supply to owner
+		time.Sleep(2 * time.Second)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.FillOrder{
+				Order: expiringID,
+				Owner: rsender,
+				In:    ids.Empty,
+				Out:   assetID,
+				Value: 1,
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		or, err := actions.UnmarshalOrderResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Zero(or.In)
+		require.Zero(or.Out)
+		require.Zero(or.Remaining)
+		balance, err := instances[0].tcli.Balance(ctx, sender, assetID)
+		require.NoError(err)
+		require.Equal(uint64(80), balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, assetID)
+		require.NoError(err)
+		require.Zero(balance)
+		_, err = instances[0].tcli.GetOrder(ctx, expiringID)
+		require.ErrorContains(err, "order not found")
+		orders, err = instances[0].tcli.Orders(ctx, actions.PairID(ids.Empty, assetID))
+		require.NoError(err)
+		require.Len(orders, 1)
+		require.Equal(openID, orders[0].ID)
+
+		// Close all remaining orders (missing orders are skipped)
+		owned, err := instances[0].tcli.OwnerOrders(ctx, sender)
+		require.NoError(err)
+		ownedIDs := make([]ids.ID, 0, len(owned))
+		for _, order := range owned {
+			ownedIDs = append(ownedIDs, order.ID)
+		}
+		require.Contains(ownedIDs, openID)
+		require.NotContains(ownedIDs, expiringID)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CloseAllOrders{
+				Orders: []ids.ID{openID, expiringID},
+				Out:    []ids.ID{assetID, assetID},
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		balance, err = instances[0].tcli.Balance(ctx, sender, assetID)
+		require.NoError(err)
+		require.Equal(uint64(100), balance)
+		orders, err = instances[0].tcli.Orders(ctx, actions.PairID(ids.Empty, assetID))
+		require.NoError(err)
+		require.Empty(orders)
+		owned, err = instances[0].tcli.OwnerOrders(ctx, sender)
+		require.NoError(err)
+		for _, order := range owned {
+			require.NotEqual(openID, order.ID)
+		}
+	})
+
+	ginkgo.It("create pool, swap, and add and remove liquidity", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// Create and mint assets
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.CreateAsset{Symbol: []byte("AMMX"), Decimals: 0, Metadata: []byte("x")},
+				&actions.CreateAsset{Symbol: []byte("AMMY"), Decimals: 0, Metadata: []byte("y")},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		assetA, assetB, _ := actions.PoolAssets(chain.CreateActionID(tx.ID(), 0), chain.CreateActionID(tx.ID(), 1))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: assetA, Value: 1_000_000},
+				&actions.MintAsset{To: rsender, Asset: assetB, Value: 1_000_000},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Reject unordered pool
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreatePool{AssetA: assetB, AssetB: assetA, AmountA: 40_000, AmountB: 10_000}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "pool assets are not ordered")
+
+		// Create pool
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreatePool{AssetA: assetA, AssetB: assetB, AmountA: 10_000, AmountB: 40_000}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		lr, err := actions.UnmarshalLiquidityResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal(uint64(20_000), lr.Shares)
+
+		exists, pool, err := instances[0].tcli.Pool(ctx, assetB, assetA)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(assetA, pool.AssetA)
+		require.Equal(uint64(10_000), pool.ReserveA)
+		require.Equal(uint64(40_000), pool.ReserveB)
+		require.Equal(uint64(20_000), pool.Shares)
+		price, err := instances[0].tcli.SpotPrice(ctx, assetA, assetB)
+		require.NoError(err)
+		require.Equal(4.0, price)
+		price, err = instances[0].tcli.SpotPrice(ctx, assetB, assetA)
+		require.NoError(err)
+		require.Equal(0.25, price)
+
+		// Swap respects slippage limit
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Swap{In: assetA, Out: assetB, Value: 1_000, MinOut: 4_000}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "slippage limit exceeded")
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Swap{In: assetA, Out: assetB, Value: 1_000, MinOut: 3_000}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		sr, err := actions.UnmarshalSwapResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal(uint64(1_000), sr.In)
+		require.Equal(uint64(3_626), sr.Out)
+		_, pool, err = instances[0].tcli.Pool(ctx, assetA, assetB)
+		require.NoError(err)
+		require.Equal(uint64(11_000), pool.ReserveA)
+		require.Equal(uint64(36_374), pool.ReserveB)
+
+		// Add liquidity at the current ratio
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.AddLiquidity{AssetA: assetA, AssetB: assetB, MaxA: 1_100, MaxB: 10_000}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		lr, err = actions.UnmarshalLiquidityResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal(uint64(1_100), lr.AmountA)
+		require.Equal(uint64(3_637), lr.AmountB)
+		require.Equal(uint64(1_999), lr.Shares)
+		shares, err := instances[0].tcli.Shares(ctx, sender, assetA, assetB)
+		require.NoError(err)
+		require.Equal(uint64(21_999), shares)
+
+		// Remove all liquidity
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.RemoveLiquidity{AssetA: assetA, AssetB: assetB, Shares: shares}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		lr, err = actions.UnmarshalLiquidityResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal(uint64(12_100), lr.AmountA)
+		require.Equal(uint64(40_011), lr.AmountB)
+
+		exists, _, err = instances[0].tcli.Pool(ctx, assetA, assetB)
+		require.NoError(err)
+		require.False(exists)
+		shares, err = instances[0].tcli.Shares(ctx, sender, assetA, assetB)
+		require.NoError(err)
+		require.Zero(shares)
+		balance, err := instances[0].tcli.Balance(ctx, sender, assetA)
+		require.NoError(err)
+		require.Equal(uint64(1_000_000), balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender, assetB)
+		require.NoError(err)
+		require.Equal(uint64(1_000_000), balance)
+	})
 
-	require.NoError(blk.Verify(ctx))
+	ginkgo.It("pause and freeze asset", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// Create asset with URI
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:   []byte("CTRL"),
+				Decimals: 0,
+				Metadata: []byte("controlled"),
+				URI:      []byte("ipfs://ctrl"),
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		asset := chain.CreateActionID(tx.ID(), 0)
+
+		exists, info, err := instances[0].tcli.AssetInfo(ctx, asset)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal([]byte("ipfs://ctrl"), info.URI)
+		require.False(info.Paused)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.MintAsset{To: rsender, Asset: asset, Value: 1_000}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Non-owner cannot pause
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.PauseAsset{Asset: asset, Paused: true}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "wrong owner")
+
+		// Paused asset cannot be transferred
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.PauseAsset{Asset: asset, Paused: true}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		exists, info, err = instances[0].tcli.AssetInfo(ctx, asset)
+		require.NoError(err)
+		require.True(exists)
+		require.True(info.Paused)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{To: rsender2, Asset: asset, Value: 5}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "asset is paused")
+
+		// Frozen account cannot receive
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.PauseAsset{Asset: asset, Paused: false},
+				&actions.FreezeAccount{Asset: asset, Account: rsender2, Frozen: true},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		frozen, err := instances[0].tcli.Frozen(ctx, sender2, asset)
+		require.NoError(err)
+		require.True(frozen)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Transfer{To: rsender2, Asset: asset, Value: 10}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "account is frozen")
+
+		// Unfreeze account
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.FreezeAccount{Asset: asset, Account: rsender2, Frozen: false},
+				&actions.Transfer{To: rsender2, Asset: asset, Value: 10},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		frozen, err = instances[0].tcli.Frozen(ctx, sender2, asset)
+		require.NoError(err)
+		require.False(frozen)
+		balance, err := instances[0].tcli.Balance(ctx, sender2, asset)
+		require.NoError(err)
+		require.Equal(uint64(10), balance)
+	})
+
+	ginkgo.It("pause and freeze pooled asset", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// [expect] submits [actions] and checks the result (identical
+		// transactions in the same validity window would be rejected)
+		expect := func(errMsg string, actions ...chain.Action) {
+			submit, _, _, err := instances[0].cli.GenerateTransaction(ctx, parser, actions, factory)
+			require.NoError(err)
+			require.NoError(submit(ctx))
+			results := expectBlk(instances[0])(false)
+			require.Len(results, 1)
+			if len(errMsg) == 0 {
+				require.True(results[0].Success, string(results[0].Error))
+				return
+			}
+			require.False(results[0].Success)
+			require.Contains(string(results[0].Error), errMsg)
+		}
+
+		// Create and mint assets
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.CreateAsset{Symbol: []byte("CTLX"), Decimals: 0, Metadata: []byte("x")},
+				&actions.CreateAsset{Symbol: []byte("CTLY"), Decimals: 0, Metadata: []byte("y")},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		controlled := chain.CreateActionID(tx.ID(), 0)
+		assetA, assetB, _ := actions.PoolAssets(controlled, chain.CreateActionID(tx.ID(), 1))
+		expect("",
+			&actions.MintAsset{To: rsender, Asset: assetA, Value: 1_000_000},
+			&actions.MintAsset{To: rsender, Asset: assetB, Value: 1_000_000},
+		)
+
+		expect("", &actions.CreatePool{AssetA: assetA, AssetB: assetB, AmountA: 10_000, AmountB: 10_000})
+
+		// Pools of a paused asset can't be created or used (controls are
+		// checked before the pool is read)
+		expect("", &actions.PauseAsset{Asset: controlled, Paused: true})
+		expect("asset is paused", &actions.CreatePool{AssetA: assetA, AssetB: assetB, AmountA: 20_000, AmountB: 20_000})
+		expect("asset is paused", &actions.Swap{In: assetA, Out: assetB, Value: 100})
+		expect("asset is paused", &actions.Swap{In: assetB, Out: assetA, Value: 100})
+		expect("asset is paused", &actions.AddLiquidity{AssetA: assetA, AssetB: assetB, MaxA: 100, MaxB: 100})
+		expect("asset is paused", &actions.RemoveLiquidity{AssetA: assetA, AssetB: assetB, Shares: 100})
+
+		// Frozen accounts can't use pools of the asset
+		expect("",
+			&actions.PauseAsset{Asset: controlled, Paused: false},
+			&actions.FreezeAccount{Asset: controlled, Account: rsender, Frozen: true},
+		)
+		expect("account is frozen", &actions.Swap{In: assetA, Out: assetB, Value: 101})
+		expect("account is frozen", &actions.Swap{In: assetB, Out: assetA, Value: 101})
+		expect("account is frozen", &actions.AddLiquidity{AssetA: assetA, AssetB: assetB, MaxA: 101, MaxB: 101})
+		expect("account is frozen", &actions.RemoveLiquidity{AssetA: assetA, AssetB: assetB, Shares: 101})
+
+		// Reserves are unchanged
+		exists, pool, err := instances[0].tcli.Pool(ctx, assetA, assetB)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(uint64(10_000), pool.ReserveA)
+		require.Equal(uint64(10_000), pool.ReserveB)
+		expect("",
+			&actions.FreezeAccount{Asset: controlled, Account: rsender, Frozen: false},
+			&actions.Swap{In: assetA, Out: assetB, Value: 100},
+		)
+	})
+
+	ginkgo.It("approve and transfer from", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// Create, mint, and approve
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{Symbol: []byte("ALW"), Decimals: 0, Metadata: []byte("allowance")}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		asset := chain.CreateActionID(tx.ID(), 0)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: asset, Value: 1_000},
+				&actions.Approve{Spender: rsender2, Asset: asset, Value: 100},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		allowance, err := instances[0].tcli.Allowance(ctx, sender, sender2, asset)
+		require.NoError(err)
+		require.Equal(uint64(100), allowance)
+
+		// Spend part of the allowance
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.TransferFrom{From: rsender, To: rsender2, Asset: asset, Value: 60}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		allowance, err = instances[0].tcli.Allowance(ctx, sender, sender2, asset)
+		require.NoError(err)
+		require.Equal(uint64(40), allowance)
+		balance, err := instances[0].tcli.Balance(ctx, sender, asset)
+		require.NoError(err)
+		require.Equal(uint64(940), balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, asset)
+		require.NoError(err)
+		require.Equal(uint64(60), balance)
+
+		// Exceed the allowance
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.TransferFrom{From: rsender, To: rsender2, Asset: asset, Value: 50}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "invalid allowance")
+
+		// Revoke the allowance
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Approve{Spender: rsender2, Asset: asset, Value: 0}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		allowance, err = instances[0].tcli.Allowance(ctx, sender, sender2, asset)
+		require.NoError(err)
+		require.Zero(allowance)
+	})
+
+	ginkgo.It("transfer to many recipients", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{Symbol: []byte("DROP"), Decimals: 0, Metadata: []byte("airdrop")}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		asset := chain.CreateActionID(tx.ID(), 0)
+
+		// Insufficient balance fails without partial transfers
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: asset, Value: 100},
+				&actions.TransferMany{
+					Asset:  asset,
+					To:     []codec.Address{rsender2, rsender3},
+					Values: []uint64{60, 50},
+				},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "invalid balance")
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: asset, Value: 100},
+				&actions.TransferMany{
+					Asset:  asset,
+					To:     []codec.Address{rsender2, rsender3, rsender2},
+					Values: []uint64{10, 20, 30},
+				},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		balance, err := instances[0].tcli.Balance(ctx, sender, asset)
+		require.NoError(err)
+		require.Equal(uint64(40), balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, asset)
+		require.NoError(err)
+		require.Equal(uint64(40), balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender3, asset)
+		require.NoError(err)
+		require.Equal(uint64(20), balance)
+	})
+
+	ginkgo.It("create, claim, and cancel stream", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{Symbol: []byte("VEST"), Decimals: 0, Metadata: []byte("vesting")}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		asset := chain.CreateActionID(tx.ID(), 0)
+
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: asset, Value: 1_000},
+				&actions.CreateStream{Payee: rsender2, Asset: asset, Rate: 100, Total: 500},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		streamID := chain.CreateActionID(tx.ID(), 1)
+
+		exists, stream, err := instances[0].tcli.Stream(ctx, streamID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(sender, stream.Payer)
+		require.Equal(sender2, stream.Payee)
+		require.Equal(uint64(500), stream.Total)
+		require.Zero(stream.Claimed)
+		balance, err := instances[0].tcli.Balance(ctx, sender, asset)
+		require.NoError(err)
+		require.Equal(uint64(500), balance)
+
+		// Only the payee can claim
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimStream{Stream: streamID, Asset: asset}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "unauthorized")
+
+		// Wait for some funds to be released
+		time.Sleep(1500 * time.Millisecond)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimStream{Stream: streamID, Asset: asset}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		claim, err := actions.UnmarshalStreamResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.GreaterOrEqual(claim.Paid, uint64(100))
+		require.Less(claim.Paid, uint64(500))
+		require.Zero(claim.Refunded)
+
+		// Cancel the stream
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CancelStream{Stream: streamID, Asset: asset, Payee: rsender2}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		cancel, err := actions.UnmarshalStreamResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal(uint64(500), claim.Paid+cancel.Paid+cancel.Refunded)
+
+		exists, _, err = instances[0].tcli.Stream(ctx, streamID)
+		require.NoError(err)
+		require.False(exists)
+		balance, err = instances[0].tcli.Balance(ctx, sender, asset)
+		require.NoError(err)
+		require.Equal(500+cancel.Refunded, balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, asset)
+		require.NoError(err)
+		require.Equal(claim.Paid+cancel.Paid, balance)
+	})
+
+	ginkgo.It("aggregate and match order book in price-time priority", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.CreateAsset{Symbol: []byte("DPTX"), Decimals: 0, Metadata: []byte("x")},
+				&actions.CreateAsset{Symbol: []byte("DPTY"), Decimals: 0, Metadata: []byte("y")},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		in := chain.CreateActionID(tx.ID(), 0)
+		out := chain.CreateActionID(tx.ID(), 1)
+
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: out, Value: 100},
+				&actions.CreateOrder{In: in, InTick: 1, Out: out, OutTick: 2, Supply: 4},
+				&actions.CreateOrder{In: in, InTick: 2, Out: out, OutTick: 4, Supply: 8},
+				&actions.CreateOrder{In: in, InTick: 1, Out: out, OutTick: 1, Supply: 2},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		pair := actions.PairID(in, out)
+		levels, next, err := instances[0].tcli.Depth(ctx, pair, 0, 0)
+		require.NoError(err)
+		require.Zero(next)
+		require.Len(levels, 2)
+
+		// Paginate one level at a time
+		levels, next, err = instances[0].tcli.Depth(ctx, pair, 0, 1)
+		require.NoError(err)
+		require.Equal(1, next)
+		require.Len(levels, 1)
+		require.Equal(0.5, levels[0].Price)
+		require.Equal(uint64(12), levels[0].Size)
+		require.Equal(2, levels[0].Orders)
+		levels, next, err = instances[0].tcli.Depth(ctx, pair, next, 1)
+		require.NoError(err)
+		require.Zero(next)
+		require.Len(levels, 1)
+		require.Equal(1.0, levels[0].Price)
+		require.Equal(uint64(2), levels[0].Size)
+		require.Equal(1, levels[0].Orders)
+
+		levels, next, err = instances[0].tcli.Depth(ctx, pair, 2, 1)
+		require.NoError(err)
+		require.Zero(next)
+		require.Empty(levels)
+
+		// Orders are listed best price first and FIFO within a price
+		orders, err := instances[0].tcli.Orders(ctx, pair)
+		require.NoError(err)
+		require.Len(orders, 3)
+		require.Equal(chain.CreateActionID(tx.ID(), 1), orders[0].ID)
+		require.Equal(chain.CreateActionID(tx.ID(), 2), orders[1].ID)
+		require.Equal(chain.CreateActionID(tx.ID(), 3), orders[2].ID)
+
+		fills, err := instances[0].tcli.Match(ctx, pair, 5)
+		require.NoError(err)
+		require.Len(fills, 3)
+		require.Equal(orders[0].ID, fills[0].Order.ID)
+		require.Equal(uint64(2), fills[0].In)
+		require.Equal(uint64(4), fills[0].Out)
+		require.Equal(orders[1].ID, fills[1].Order.ID)
+		require.Equal(uint64(2), fills[1].In)
+		require.Equal(uint64(4), fills[1].Out)
+		require.Equal(orders[2].ID, fills[2].Order.ID)
+		require.Equal(uint64(1), fills[2].In)
+		require.Equal(uint64(1), fills[2].Out)
+
+		// Fills execute exactly as matched
+		fillActions := []chain.Action{&actions.MintAsset{To: rsender2, Asset: in, Value: 5}}
+		for _, fill := range fills {
+			fillActions = append(fillActions, &actions.FillOrder{
+				Order: fill.Order.ID,
+				Owner: rsender,
+				In:    in,
+				Out:   out,
+				Value: fill.In,
+			})
+		}
+		submit, _, _, err = instances[0].cli.GenerateTransaction(ctx, parser, fillActions[:1], factory)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(ctx, parser, fillActions[1:], factory2)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		for i, fill := range fills {
+			or, err := actions.UnmarshalOrderResult(results[0].Outputs[i][0])
+			require.NoError(err)
+			require.Equal(fill.In, or.In)
+			require.Equal(fill.Out, or.Out)
+		}
+
+		orders, err = instances[0].tcli.Orders(ctx, pair)
+		require.NoError(err)
+		require.Len(orders, 2)
+		require.Equal(chain.CreateActionID(tx.ID(), 2), orders[0].ID)
+		require.Equal(uint64(4), orders[0].Remaining)
+		require.Equal(chain.CreateActionID(tx.ID(), 3), orders[1].ID)
+		require.Equal(uint64(1), orders[1].Remaining)
+	})
+
+	ginkgo.It("pays royalties on order fills", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// Reject royalty larger than proceeds
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:             []byte("ROY"),
+				Decimals:           0,
+				Metadata:           []byte("royalty"),
+				RoyaltyBasisPoints: actions.MaxRoyaltyBasisPoints + 1,
+				RoyaltyRecipient:   rsender3,
+			}},
+			factory,
+		)
+		require.ErrorContains(err, "royalty is too large")
+
+		// Create and mint asset with royalty
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:             []byte("ROY"),
+				Decimals:           0,
+				Metadata:           []byte("royalty"),
+				RoyaltyBasisPoints: 250,
+				RoyaltyRecipient:   rsender3,
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		assetID := chain.CreateActionID(tx.ID(), 0)
+		exists, info, err := instances[0].tcli.AssetInfo(ctx, assetID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(uint16(250), info.RoyaltyBasisPoints)
+		require.Equal(sender3, info.RoyaltyRecipient)
+		royalty, err := instances[0].tcli.RoyaltyRecipient(ctx, assetID)
+		require.NoError(err)
+		require.Equal(rsender3, royalty)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: assetID, Value: 100},
+				&actions.CreateOrder{
+					In:      ids.Empty,
+					InTick:  100,
+					Out:     assetID,
+					OutTick: 1,
+					Supply:  10,
+				},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		orders, err := instances[0].tcli.Orders(ctx, actions.PairID(ids.Empty, assetID))
+		require.NoError(err)
+		require.Len(orders, 1)
+		orderID := orders[0].ID
+
+		// Reject fill without royalty recipient
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.FillOrder{
+				Order: orderID,
+				Owner: rsender,
+				In:    ids.Empty,
+				Out:   assetID,
+				Value: 400,
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "wrong royalty recipient")
+
+		// Split proceeds with royalty recipient
+		royaltyBalance, err := instances[0].tcli.Balance(ctx, sender3, ids.Empty)
+		require.NoError(err)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.FillOrder{
+				Order:   orderID,
+				Owner:   rsender,
+				In:      ids.Empty,
+				Out:     assetID,
+				Value:   400,
+				Royalty: rsender3,
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		or, err := actions.UnmarshalOrderResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal(uint64(400), or.In)
+		require.Equal(uint64(4), or.Out)
+		require.Equal(uint64(6), or.Remaining)
+		require.Equal(uint64(10), or.Royalty)
+		balance, err := instances[0].tcli.Balance(ctx, sender3, ids.Empty)
+		require.NoError(err)
+		require.Equal(royaltyBalance+10, balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, assetID)
+		require.NoError(err)
+		require.Equal(uint64(4), balance)
+
+		// Swaps that buy the asset from a pool also pay royalties
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreatePool{AssetA: ids.Empty, AssetB: assetID, AmountA: 10_000, AmountB: 50}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Swap{In: ids.Empty, Out: assetID, Value: 1_000}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "wrong royalty recipient")
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.Swap{In: ids.Empty, Out: assetID, Value: 1_000, Royalty: rsender3}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		sr, err := actions.UnmarshalSwapResult(results[0].Outputs[0][0])
+		require.NoError(err)
+		require.Equal(uint64(1_000), sr.In)
+		require.Equal(uint64(25), sr.Royalty)
+		require.Equal(actions.SwapOutput(975, 10_000, 50), sr.Out)
+		balance, err = instances[0].tcli.Balance(ctx, sender3, ids.Empty)
+		require.NoError(err)
+		require.Equal(royaltyBalance+10+25, balance)
+		exists, pool, err := instances[0].tcli.Pool(ctx, ids.Empty, assetID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(uint64(10_975), pool.ReserveA)
+		require.Equal(50-sr.Out, pool.ReserveB)
+
+		// Royalties can't be paid to the empty address
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:             []byte("ROY"),
+				Decimals:           0,
+				Metadata:           []byte("royalty"),
+				RoyaltyBasisPoints: 250,
+			}},
+			factory,
+		)
+		require.ErrorContains(err, "royalty recipient is empty")
+	})
+
+	ginkgo.It("create and claim airdrop", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// Create and mint asset
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:   []byte("AIR"),
+				Decimals: 0,
+				Metadata: []byte("airdrop"),
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		assetID := chain.CreateActionID(tx.ID(), 0)
+
+		// Fund airdrop
+		leaves := []ids.ID{
+			actions.AirdropLeaf(0, rsender2, 30),
+			actions.AirdropLeaf(1, rsender3, 20),
+			actions.AirdropLeaf(2, rsender2, 5),
+		}
+		root := actions.AirdropRoot(leaves)
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: assetID, Value: 100},
+				&actions.CreateAirdrop{
+					Asset:  assetID,
+					Root:   root,
+					Total:  55,
+					Expiry: time.Now().Add(time.Hour).UnixMilli(),
+				},
+				&actions.Transfer{To: rsender3, Value: 100_000}, // pay fees of claims
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		airdropID := chain.CreateActionID(tx.ID(), 1)
+		exists, airdrop, err := instances[0].tcli.Airdrop(ctx, airdropID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(assetID, airdrop.Asset)
+		require.Equal(root, airdrop.Root)
+		require.Equal(sender, airdrop.Owner)
+		require.Equal(uint64(55), airdrop.Remaining)
+		balance, err := instances[0].tcli.Balance(ctx, sender, assetID)
+		require.NoError(err)
+		require.Equal(uint64(45), balance)
+
+		// Reject claim of another recipient
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimAirdrop{
+				Airdrop: airdropID,
+				Asset:   assetID,
+				Index:   0,
+				Amount:  30,
+				Proof:   actions.AirdropProof(leaves, 0),
+			}},
+			factory3,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "invalid proof")
+
+		// Claim
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimAirdrop{
+				Airdrop: airdropID,
+				Asset:   assetID,
+				Index:   0,
+				Amount:  30,
+				Proof:   actions.AirdropProof(leaves, 0),
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, assetID)
+		require.NoError(err)
+		require.Equal(uint64(30), balance)
+		claimed, err := instances[0].tcli.AirdropClaimed(ctx, airdropID, 0)
+		require.NoError(err)
+		require.True(claimed)
+		claimed, err = instances[0].tcli.AirdropClaimed(ctx, airdropID, 1)
+		require.NoError(err)
+		require.False(claimed)
+
+		// Reject second claim
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimAirdrop{
+				Airdrop: airdropID,
+				Asset:   assetID,
+				Index:   0,
+				Amount:  31,
+				Proof:   actions.AirdropProof(leaves, 0),
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "already claimed")
+
+		// Claim remaining leaves
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimAirdrop{
+				Airdrop: airdropID,
+				Asset:   assetID,
+				Index:   1,
+				Amount:  20,
+				Proof:   actions.AirdropProof(leaves, 1),
+			}},
+			factory3,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimAirdrop{
+				Airdrop: airdropID,
+				Asset:   assetID,
+				Index:   2,
+				Amount:  5,
+				Proof:   actions.AirdropProof(leaves, 2),
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 2)
+		require.True(results[0].Success)
+		require.True(results[1].Success)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, assetID)
+		require.NoError(err)
+		require.Equal(uint64(35), balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender3, assetID)
+		require.NoError(err)
+		require.Equal(uint64(20), balance)
+
+		// Exhausted airdrop is removed
+		exists, _, err = instances[0].tcli.Airdrop(ctx, airdropID)
+		require.NoError(err)
+		require.False(exists)
+	})
+
+	ginkgo.It("reclaim expired airdrop", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+
+		// Create and mint asset
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.CreateAsset{
+				Symbol:   []byte("RAIR"),
+				Decimals: 0,
+				Metadata: []byte("reclaimed airdrop"),
+			}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		assetID := chain.CreateActionID(tx.ID(), 0)
+
+		// Reject expiry in the past
+		leaves := []ids.ID{
+			actions.AirdropLeaf(0, rsender2, 30),
+			actions.AirdropLeaf(1, rsender3, 20),
+		}
+		root := actions.AirdropRoot(leaves)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: assetID, Value: 100},
+				&actions.CreateAirdrop{Asset: assetID, Root: root, Total: 50, Expiry: 1},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "expiry is in the past")
+
+		// Fund airdrop
+		expiry := time.Now().Add(3 * time.Second)
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.MintAsset{To: rsender, Asset: assetID, Value: 100},
+				&actions.CreateAirdrop{Asset: assetID, Root: root, Total: 50, Expiry: expiry.UnixMilli()},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		airdropID := chain.CreateActionID(tx.ID(), 1)
+		exists, airdrop, err := instances[0].tcli.Airdrop(ctx, airdropID)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(expiry.UnixMilli(), airdrop.Expiry)
+
+		// Claim before expiry
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimAirdrop{
+				Airdrop: airdropID,
+				Asset:   assetID,
+				Index:   0,
+				Amount:  30,
+				Proof:   actions.AirdropProof(leaves, 0),
+			}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+
+		// Reject reclaim before expiry
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ReclaimAirdrop{Airdrop: airdropID, Asset: assetID}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "airdrop is not expired")
+
+		// Reject claim and reclaim by anyone but the owner after expiry
+		time.Sleep(time.Until(expiry) + time.Second)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ClaimAirdrop{
+				Airdrop: airdropID,
+				Asset:   assetID,
+				Index:   1,
+				Amount:  20,
+				Proof:   actions.AirdropProof(leaves, 1),
+			}},
+			factory3,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "airdrop is expired")
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ReclaimAirdrop{Airdrop: airdropID, Asset: assetID}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "wrong owner")
+
+		// Reclaim returns what was not claimed to the owner
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.ReclaimAirdrop{Airdrop: airdropID, Asset: assetID}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		balance, err := instances[0].tcli.Balance(ctx, sender, assetID)
+		require.NoError(err)
+		require.Equal(uint64(70), balance)
+		exists, _, err = instances[0].tcli.Airdrop(ctx, airdropID)
+		require.NoError(err)
+		require.False(exists)
+	})
+
+	ginkgo.It("mint and burn bridged tokens", func() {
+		ctx := context.Background()
+		parser, err := instances[0].tcli.Parser(ctx)
+		require.NoError(err)
+		assetID := bridge.WrappedAssetID(bridgeChainID, ids.Empty)
+
+		// Reject transfers to another chain
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeMint{Message: bridgeMessage(&bridge.Transfer{
+				ActionID:           ids.GenerateTestID(),
+				DestinationChainID: ids.GenerateTestID(),
+				Symbol:             []byte("MVM"),
+				Decimals:           9,
+				To:                 rsender2,
+				Value:              1_000,
+			})}},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results := expectBlkWithContext(instances[0], &block.Context{PChainHeight: 1})(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "not addressed to this chain")
+
+		// Mint (and fund fees of the recipient)
+		msg := bridgeMessage(&bridge.Transfer{
+			ActionID:           ids.GenerateTestID(),
+			DestinationChainID: instances[0].chainID,
+			Symbol:             []byte("MVM"),
+			Decimals:           9,
+			To:                 rsender2,
+			Value:              1_000,
+			Fee:                10,
+		})
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{
+				&actions.Transfer{To: rsender2, Value: 100_000},
+				&actions.BridgeMint{Message: msg},
+			},
+			factory,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlkWithContext(instances[0], &block.Context{PChainHeight: 1})(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		require.Equal(assetID[:], results[0].Outputs[1][0])
+		balance, err := instances[0].tcli.Balance(ctx, sender2, assetID)
+		require.NoError(err)
+		require.Equal(uint64(1_000), balance)
+		balance, err = instances[0].tcli.Balance(ctx, sender, assetID)
+		require.NoError(err)
+		require.Equal(uint64(10), balance) // relay fee
+		exists, symbol, decimals, metadata, supply, owner, err := instances[0].tcli.Asset(ctx, assetID, false)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal([]byte("MVM"), symbol)
+		require.Equal(uint8(9), decimals)
+		require.Equal(bridgeChainID.String(), string(metadata))
+		require.Equal(uint64(1_010), supply)
+		require.Equal(tconsts.AddressFormat.Encode(codec.EmptyAddress), owner)
+
+		// Reject replay
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeMint{Message: msg}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlkWithContext(instances[0], &block.Context{PChainHeight: 1})(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "already consumed")
+		balance, err = instances[0].tcli.Balance(ctx, sender2, assetID)
+		require.NoError(err)
+		require.Equal(uint64(1_000), balance)
+
+		// Reject burn of an asset that was not bridged
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeBurn{Asset: asset1ID, To: rsender3, Value: 1}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.False(results[0].Success)
+		require.Contains(string(results[0].Error), "not minted by the bridge")
+
+		// Burn
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			ctx,
+			parser,
+			[]chain.Action{&actions.BridgeBurn{Asset: assetID, To: rsender3, Value: 400, Fee: 5}},
+			factory2,
+		)
+		require.NoError(err)
+		require.NoError(submit(ctx))
+		results = expectBlk(instances[0])(false)
+		require.Len(results, 1)
+		require.True(results[0].Success)
+		require.Len(results[0].WarpMessages, 1)
+		out := results[0].WarpMessages[0]
+		msgID := out.ID()
+		require.Equal(msgID[:], results[0].Outputs[0][0])
+		require.Equal(instances[0].chainID, out.SourceChainID)
+		transfer, err := bridge.ParseTransfer(out, bridgeChainID)
+		require.NoError(err)
+		require.True(transfer.Return)
+		require.Equal(ids.Empty, transfer.Asset)
+		require.Equal(rsender3, transfer.To)
+		require.Equal(uint64(400), transfer.Value)
+		require.Equal(uint64(5), transfer.Fee)
+		balance, err = instances[0].tcli.Balance(ctx, sender2, assetID)
+		require.NoError(err)
+		require.Equal(uint64(595), balance)
+		_, _, _, _, supply, _, err = instances[0].tcli.Asset(ctx, assetID, false)
+		require.NoError(err)
+		require.Equal(uint64(605), supply)
+	})
+})
+
+func bridgeMessage(transfer *bridge.Transfer) *warp.Message {
+	require := require.New(ginkgo.GinkgoT())
+
+	unsignedMsg, err := warp.NewUnsignedMessage(networkID, bridgeChainID, transfer.Bytes())
+	require.NoError(err)
+	signers := set.NewBits(0)
+	signature := &warp.BitSetSignature{Signers: signers.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(bls.Sign(bridgeSK, unsignedMsg.Bytes())))
+	msg, err := warp.NewMessage(unsignedMsg, signature)
+	require.NoError(err)
+	return msg
+}
+
+func expectBlk(i instance) func(bool) []*chain.Result {
+	return expectBlkWithContext(i, nil)
+}
+
+// expectBlkWithContext builds a block at the P-Chain height of [bctx] (required
+// to include incoming warp messages).
+func expectBlkWithContext(i instance, bctx *block.Context) func(bool) []*chain.Result {
+	require := require.New(ginkgo.GinkgoT())
+
+	ctx := context.TODO()
+
+	// manually signal ready
+	require.NoError(i.vm.Builder().Force(ctx))
+	// manually ack ready sig as in engine
+	<-i.toEngine
+
+	var (
+		blk snowman.Block
+		err error
+	)
+	if bctx != nil {
+		blk, err = i.vm.BuildBlockWithContext(ctx, bctx)
+		require.NoError(err)
+		require.NotNil(blk)
+		require.NoError(blk.(*chain.StatelessBlock).VerifyWithContext(ctx, bctx))
+	} else {
+		blk, err = i.vm.BuildBlock(ctx)
+		require.NoError(err)
+		require.NotNil(blk)
+		require.NoError(blk.Verify(ctx))
+	}
 	require.Equal(blk.Status(), choices.Processing)
 
 	err = i.vm.SetPreference(ctx, blk.ID())
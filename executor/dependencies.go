@@ -3,7 +3,20 @@
 
 package executor
 
+import "time"
+
 type Metrics interface {
 	RecordBlocked()
 	RecordExecutable()
+	// RecordQueueLatency is called with the time an executable task waited
+	// for a worker.
+	RecordQueueLatency(time.Duration)
+}
+
+type OptimisticMetrics interface {
+	RecordSpeculative()
+	RecordConflict()
+	// RecordQueueLatency is called with the time a task waited for a worker
+	// to speculate on it.
+	RecordQueueLatency(time.Duration)
 }
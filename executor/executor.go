@@ -6,6 +6,7 @@ package executor
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/set"
 
@@ -63,13 +64,17 @@ func (e *Executor) work() {
 		if !ok {
 			return
 		}
+		if e.metrics != nil {
+			e.metrics.RecordQueueLatency(time.Since(t.queued))
+		}
 		e.runTask(t)
 	}
 }
 
 type task struct {
-	id int
-	f  func() error
+	id     int
+	f      func() error
+	queued time.Time // when [task] became executable (only set with metrics)
 	// reading are the tasks that this task is using non-exclusively.
 	reading map[int]*task
 
@@ -88,6 +93,14 @@ type task struct {
 	dependencies atomic.Int64
 }
 
+// enqueue passes [t] to the workers.
+func (e *Executor) enqueue(t *task) {
+	if e.metrics != nil {
+		t.queued = time.Now()
+	}
+	e.executable <- t
+}
+
 func (e *Executor) runTask(t *task) {
 	// No matter what happens, we need to clear our dependencies
 	// to ensure we can exit.
@@ -107,7 +120,7 @@ func (e *Executor) runTask(t *task) {
 			if bt.dependencies.Add(-1) > 0 {
 				continue
 			}
-			e.executable <- bt
+			e.enqueue(bt)
 		}
 		t.blocked = nil // free memory
 		t.executed = true
@@ -216,7 +229,7 @@ func (e *Executor) Run(keys state.Keys, f func() error) {
 	}
 
 	// Mark task for execution if we aren't waiting on any other tasks
-	e.executable <- t
+	e.enqueue(t)
 	if e.metrics != nil {
 		e.metrics.RecordExecutable()
 	}
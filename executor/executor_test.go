@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -21,6 +22,31 @@ const (
 	maxDependencies = 100_000_000
 )
 
+type testMetrics struct {
+	l          sync.Mutex
+	blocked    int
+	executable int
+	latencies  int
+}
+
+func (m *testMetrics) RecordBlocked() {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.blocked++
+}
+
+func (m *testMetrics) RecordExecutable() {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.executable++
+}
+
+func (m *testMetrics) RecordQueueLatency(time.Duration) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.latencies++
+}
+
 func generateNumbers(start int) []int {
 	array := make([]int, 9999)
 	for i := 0; i < 9999; i++ {
@@ -919,3 +945,28 @@ func TestLargeRandomReadsAndWrites(t *testing.T) {
 	require.NoError(e.Wait())
 	require.Len(completed, numTxs)
 }
+
+func TestExecutorMetrics(t *testing.T) {
+	var (
+		require     = require.New(t)
+		conflictKey = ids.GenerateTestID().String()
+		metrics     = &testMetrics{}
+		e           = New(10, 4, maxDependencies, metrics)
+		start       = make(chan struct{})
+	)
+	for i := 0; i < 10; i++ {
+		s := make(state.Keys, 1)
+		s.Add(conflictKey, state.Read|state.Write)
+		e.Run(s, func() error {
+			<-start
+			return nil
+		})
+	}
+	close(start)
+	require.NoError(e.Wait())
+
+	// Tasks that were blocked report their latency once they are executable
+	require.Equal(1, metrics.executable)
+	require.Equal(9, metrics.blocked)
+	require.Equal(10, metrics.latencies)
+}
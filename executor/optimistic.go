@@ -0,0 +1,169 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/hypersdk/state"
+
+	uatomic "go.uber.org/atomic"
+)
+
+// Optimistic executes all tasks concurrently against a snapshot
+// and then resolves conflicts deterministically.
+//
+// Unlike [Executor], Optimistic does not wait for conflicting tasks
+// to finish before starting a task. Instead, every task is speculatively
+// executed as soon as a worker is available and, once all speculative
+// executions are complete, tasks are committed in the order they were
+// queued. If a task touched a key that was written by any task queued before
+// it, its speculative result is discarded and the task is re-executed on top
+// of the committed state.
+//
+// This approach performs well when few tasks conflict (re-execution is
+// sequential) and poorly when most tasks conflict.
+type Optimistic struct {
+	metrics OptimisticMetrics
+
+	workers     sync.WaitGroup
+	speculative chan *optimisticTask
+
+	outstanding sync.WaitGroup
+	tasks       []*optimisticTask
+
+	err uatomic.Error
+}
+
+type optimisticTask struct {
+	keys      state.Keys
+	speculate func() (func(), error)
+	execute   func() error
+	queued    time.Time // only set with metrics
+
+	commit func()
+	err    error
+}
+
+// NewOptimistic creates a new [Optimistic] executor.
+func NewOptimistic(items, concurrency int, metrics OptimisticMetrics) *Optimistic {
+	o := &Optimistic{
+		metrics:     metrics,
+		speculative: make(chan *optimisticTask, items),
+		tasks:       make([]*optimisticTask, 0, items),
+	}
+	o.workers.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go o.work()
+	}
+	return o
+}
+
+func (o *Optimistic) work() {
+	defer o.workers.Done()
+
+	for {
+		t, ok := <-o.speculative
+		if !ok {
+			return
+		}
+		if o.metrics != nil {
+			o.metrics.RecordQueueLatency(time.Since(t.queued))
+		}
+
+		// We skip speculation once stopped but still mark the
+		// task as done to ensure [Wait] can exit.
+		if o.err.Load() == nil {
+			t.commit, t.err = t.speculate()
+		}
+		o.outstanding.Done()
+	}
+}
+
+// Run enqueues [speculate] to be executed against a snapshot that does
+// not contain the changes of any other task.
+//
+// [speculate] must not modify any state shared with other tasks. Rather, it
+// should return a function that applies its changes, which will only be called
+// if the task does not conflict with any task queued before it. If a conflict is
+// detected, [execute] is called instead (in order) after all tasks queued before
+// it have been committed.
+//
+// [keys] must be a full enumeration of all keys touched by the task and is used
+// to detect conflicts. Two tasks only conflict if at least one of them may write
+// to a key that both touch.
+//
+// Run is not safe to call concurrently.
+func (o *Optimistic) Run(keys state.Keys, speculate func() (func(), error), execute func() error) {
+	t := &optimisticTask{
+		keys:      keys,
+		speculate: speculate,
+		execute:   execute,
+	}
+	if o.metrics != nil {
+		t.queued = time.Now()
+	}
+	o.tasks = append(o.tasks, t)
+	o.outstanding.Add(1)
+	o.speculative <- t
+	if o.metrics != nil {
+		o.metrics.RecordSpeculative()
+	}
+}
+
+func (o *Optimistic) Stop() {
+	o.err.CompareAndSwap(nil, ErrStopped)
+}
+
+// Wait blocks until all speculative executions are complete and then commits
+// (or re-executes) each task in the order it was queued.
+//
+// If a task without conflicts returned an error during speculation, that
+// error is returned (this is the same error that would have been returned if
+// the task were executed conservatively). If a re-executed task returns an
+// error, that error is returned and no further tasks are committed.
+//
+// You should not call [Run] after [Wait] is called.
+func (o *Optimistic) Wait() error {
+	o.outstanding.Wait()
+	close(o.speculative)
+	o.workers.Wait()
+	if err := o.err.Load(); err != nil {
+		return err
+	}
+
+	written := make(map[string]struct{}, len(o.tasks))
+	for _, t := range o.tasks {
+		conflict := false
+		for k := range t.keys {
+			if _, ok := written[k]; ok {
+				conflict = true
+				break
+			}
+		}
+		switch {
+		case conflict:
+			if o.metrics != nil {
+				o.metrics.RecordConflict()
+			}
+			if err := t.execute(); err != nil {
+				return err
+			}
+		case t.err != nil:
+			return t.err
+		default:
+			t.commit()
+		}
+
+		// We conservatively mark all keys that may be written by a task
+		// as written (regardless of whether they actually were).
+		for k, v := range t.keys {
+			if v.Has(state.Allocate) || v.Has(state.Write) {
+				written[k] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+type testOptimisticMetrics struct {
+	l           sync.Mutex
+	speculative int
+	conflicts   int
+	latencies   int
+}
+
+func (m *testOptimisticMetrics) RecordSpeculative() {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.speculative++
+}
+
+func (m *testOptimisticMetrics) RecordConflict() {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.conflicts++
+}
+
+func (m *testOptimisticMetrics) RecordQueueLatency(time.Duration) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.latencies++
+}
+
+func TestOptimisticNoConflicts(t *testing.T) {
+	var (
+		require   = require.New(t)
+		metrics   = &testOptimisticMetrics{}
+		o         = NewOptimistic(100, 4, metrics)
+		committed = make([]int, 0, 100)
+	)
+	for i := 0; i < 100; i++ {
+		s := make(state.Keys)
+		s.Add(ids.GenerateTestID().String(), state.Read|state.Write)
+		ti := i
+		o.Run(s, func() (func(), error) {
+			return func() { committed = append(committed, ti) }, nil
+		}, func() error {
+			require.FailNow("should not re-execute")
+			return nil
+		})
+	}
+	require.NoError(o.Wait())
+	require.Len(committed, 100)
+	for i := 0; i < 100; i++ {
+		require.Equal(i, committed[i])
+	}
+	require.Equal(100, metrics.speculative)
+	require.Zero(metrics.conflicts)
+	require.Equal(100, metrics.latencies)
+}
+
+func TestOptimisticConflicts(t *testing.T) {
+	var (
+		require     = require.New(t)
+		conflictKey = ids.GenerateTestID().String()
+		metrics     = &testOptimisticMetrics{}
+		o           = NewOptimistic(100, 4, metrics)
+		order       = make([]int, 0, 100)
+		reexecuted  = make([]int, 0, 10)
+	)
+	for i := 0; i < 100; i++ {
+		s := make(state.Keys)
+		s.Add(ids.GenerateTestID().String(), state.Read|state.Write)
+		if i%10 == 0 {
+			s.Add(conflictKey, state.Read|state.Write)
+		}
+		ti := i
+		o.Run(s, func() (func(), error) {
+			return func() { order = append(order, ti) }, nil
+		}, func() error {
+			order = append(order, ti)
+			reexecuted = append(reexecuted, ti)
+			return nil
+		})
+	}
+	require.NoError(o.Wait())
+	require.Len(order, 100)
+	for i := 0; i < 100; i++ {
+		require.Equal(i, order[i])
+	}
+
+	// The first task touching the conflict key can be committed
+	require.Equal([]int{10, 20, 30, 40, 50, 60, 70, 80, 90}, reexecuted)
+	require.Equal(9, metrics.conflicts)
+}
+
+func TestOptimisticReadsDoNotConflict(t *testing.T) {
+	var (
+		require = require.New(t)
+		readKey = ids.GenerateTestID().String()
+		o       = NewOptimistic(100, 4, nil)
+	)
+	for i := 0; i < 100; i++ {
+		s := make(state.Keys)
+		s.Add(readKey, state.Read)
+		o.Run(s, func() (func(), error) {
+			return func() {}, nil
+		}, func() error {
+			require.FailNow("should not re-execute")
+			return nil
+		})
+	}
+	require.NoError(o.Wait())
+}
+
+func TestOptimisticSpeculativeError(t *testing.T) {
+	var (
+		require     = require.New(t)
+		conflictKey = ids.GenerateTestID().String()
+		errTest     = errors.New("test")
+		o           = NewOptimistic(10, 4, nil)
+		executed    = false
+	)
+
+	// The speculative error of a conflicting task is ignored
+	for i := 0; i < 2; i++ {
+		s := make(state.Keys)
+		s.Add(conflictKey, state.Read|state.Write)
+		ti := i
+		o.Run(s, func() (func(), error) {
+			if ti == 1 {
+				return nil, errTest
+			}
+			return func() {}, nil
+		}, func() error {
+			executed = true
+			return nil
+		})
+	}
+	require.NoError(o.Wait())
+	require.True(executed)
+
+	// The speculative error of a task without conflicts is returned
+	o = NewOptimistic(10, 4, nil)
+	s := make(state.Keys)
+	s.Add(conflictKey, state.Read|state.Write)
+	o.Run(s, func() (func(), error) {
+		return nil, errTest
+	}, func() error {
+		require.FailNow("should not re-execute")
+		return nil
+	})
+	require.ErrorIs(o.Wait(), errTest)
+}
+
+func TestOptimisticStop(t *testing.T) {
+	var (
+		require = require.New(t)
+		o       = NewOptimistic(10, 4, nil)
+	)
+	o.Stop()
+	s := make(state.Keys)
+	s.Add(ids.GenerateTestID().String(), state.Read|state.Write)
+	o.Run(s, func() (func(), error) {
+		return func() {}, nil
+	}, func() error {
+		return nil
+	})
+	require.ErrorIs(o.Wait(), ErrStopped)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package explorer
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+var (
+	addressType   = reflect.TypeOf(codec.Address{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// decodeAction returns the fields of [action] as they are encoded to JSON,
+// with each [codec.Address] formatted by [c] (instead of as an array of
+// bytes).
+func decodeAction(c Controller, action chain.Action) any {
+	return decodeValue(c, reflect.ValueOf(action))
+}
+
+func decodeValue(c Controller, v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == addressType {
+		return c.Address(v.Interface().(codec.Address))
+	}
+	if v.Type().Implements(marshalerType) {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return decodeValue(c, v.Elem())
+	case reflect.Struct:
+		fields := map[string]any{}
+		decodeFields(c, v, fields)
+		return fields
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Byte slices are base64-encoded (and byte arrays are encoded
+			// as arrays of numbers)
+			return v.Interface()
+		}
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		values := make([]any, v.Len())
+		for i := range values {
+			values[i] = decodeValue(c, v.Index(i))
+		}
+		return values
+	default:
+		return v.Interface()
+	}
+}
+
+// decodeFields adds the exported fields of the struct [v] to [fields] under
+// their JSON names. Like [json.Marshal], the fields of embedded structs are
+// promoted and fields tagged with "-" are skipped.
+func decodeFields(c Controller, v reflect.Value, fields map[string]any) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && len(name) == 0 && f.Type.Kind() == reflect.Struct {
+			decodeFields(c, v.Field(i), fields)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		fields[name] = decodeValue(c, v.Field(i))
+	}
+}
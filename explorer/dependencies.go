@@ -0,0 +1,58 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package explorer
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ Client = (*rpc.JSONRPCClient)(nil)
+
+// Client fetches chain data from a node running the indexer (see
+// [rpc.JSONRPCClient]).
+type Client interface {
+	Accepted(ctx context.Context) (ids.ID, uint64, int64, error)
+	GetIndexedBlock(
+		ctx context.Context,
+		parser chain.Parser,
+		height uint64,
+	) (*chain.StatefulBlock, []*chain.Result, fees.Dimensions, error)
+	GetIndexedBlockHeight(ctx context.Context, blkID ids.ID) (uint64, error)
+	GetIndexedTx(
+		ctx context.Context,
+		parser chain.Parser,
+		txID ids.ID,
+	) (*chain.Transaction, *chain.Result, uint64, int64, error)
+	GetTxsByAddress(
+		ctx context.Context,
+		addr codec.Address,
+		cursor []byte,
+		limit int,
+	) ([]ids.ID, []byte, error)
+}
+
+// Controller adapts [Server] to the actions and state of a hypervm.
+type Controller interface {
+	chain.Parser
+
+	ParseAddress(address string) (codec.Address, error)
+	Address(addr codec.Address) string
+
+	// ActionName returns the name of the actions of [typeID] (which are
+	// decoded from their JSON encoding).
+	ActionName(typeID uint8) string
+
+	// Assets returns the assets of the hypervm (the native asset first).
+	Assets(ctx context.Context) ([]*Asset, error)
+	// Balances returns the non-zero balances of [addr] in the last accepted
+	// block.
+	Balances(ctx context.Context, addr codec.Address) ([]*Balance, error)
+}
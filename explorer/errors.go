@@ -0,0 +1,12 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package explorer
+
+import "errors"
+
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrNotFound       = errors.New("not found")
+	ErrUnavailable    = errors.New("node unavailable")
+)
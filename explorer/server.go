@@ -0,0 +1,303 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package explorer implements the backend of a block explorer for any
+// hypersdk chain, so each hypervm doesn't need a bespoke explorer service.
+//
+// [Server] reads blocks and transactions from a node that runs the indexer,
+// decodes their actions from their JSON encoding, and serves them (and the
+// accounts and assets of the hypervm, provided by a [Controller]) over a
+// REST API that can be mounted on any [http.ServeMux].
+package explorer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const (
+	BlocksPath   = "/blocks/"
+	TxsPath      = "/txs/"
+	AccountsPath = "/accounts/"
+	AssetsPath   = "/assets"
+	SearchPath   = "/search"
+
+	// LatestBlock can be requested instead of a height or block ID.
+	LatestBlock = "latest"
+
+	DefaultTxsLimit = 25
+	MaxTxsLimit     = 100
+)
+
+type handler func(ctx context.Context, id string, query url.Values) (any, error)
+
+// Server serves the explorer API of the chain a [Client] is connected to
+// (relative to where it is mounted):
+//   - GET /blocks/{height|id|latest}
+//   - GET /txs/{id}
+//   - GET /accounts/{address}?cursor=&limit=
+//   - GET /assets and /assets/{id}
+//   - GET /search?q=
+type Server struct {
+	c   Controller
+	cli Client
+
+	handlers map[string]handler
+}
+
+func NewServer(c Controller, cli Client) *Server {
+	s := &Server{c: c, cli: cli}
+	s.handlers = map[string]handler{
+		BlocksPath:   s.block,
+		TxsPath:      s.tx,
+		AccountsPath: s.account,
+		AssetsPath:   s.assets,
+		SearchPath:   s.search,
+	}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, id := r.URL.Path, ""
+	if i := strings.LastIndexByte(path, '/'); i > 0 {
+		path, id = path[:i+1], path[i+1:]
+	}
+	if path == AssetsPath+"/" {
+		path = AssetsPath
+	}
+	h, ok := s.handlers[path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	resp, err := h(r.Context(), id, r.URL.Query())
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(status(err))
+		resp = &ErrorResponse{Error: err.Error()}
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func status(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
+
+// clientError converts an error returned by the [Client] to an error
+// wrapping [ErrNotFound] or [ErrUnavailable].
+func clientError(err error) error {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, rpc.ErrBlockMissing.Error()),
+		strings.Contains(msg, rpc.ErrTxMissing.Error()):
+		return fmt.Errorf("%w: %s", ErrNotFound, msg)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnavailable, msg)
+	}
+}
+
+// height returns the height of the block identified by [id].
+func (s *Server) height(ctx context.Context, id string) (uint64, error) {
+	if id == LatestBlock {
+		_, height, _, err := s.cli.Accepted(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", ErrUnavailable, err)
+		}
+		return height, nil
+	}
+	if height, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return height, nil
+	}
+	blkID, err := ids.FromString(id)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a height or block ID", ErrInvalidRequest, id)
+	}
+	height, err := s.cli.GetIndexedBlockHeight(ctx, blkID)
+	if err != nil {
+		return 0, clientError(err)
+	}
+	return height, nil
+}
+
+func (s *Server) block(ctx context.Context, id string, _ url.Values) (any, error) {
+	height, err := s.height(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	blk, results, prices, err := s.cli.GetIndexedBlock(ctx, s.c, height)
+	if err != nil {
+		return nil, clientError(err)
+	}
+	blkID, err := blk.ID()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+	b := &Block{
+		ID:         blkID,
+		Parent:     blk.Prnt,
+		Height:     blk.Hght,
+		Timestamp:  blk.Tmstmp,
+		UnitPrices: prices,
+		Txs:        make([]*Tx, len(blk.Txs)),
+	}
+	for i, tx := range blk.Txs {
+		b.Txs[i] = s.transaction(tx, results[i], blk.Hght, blk.Tmstmp)
+	}
+	return b, nil
+}
+
+func (s *Server) tx(ctx context.Context, id string, _ url.Values) (any, error) {
+	txID, err := ids.FromString(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+	}
+	tx, result, height, timestamp, err := s.cli.GetIndexedTx(ctx, s.c, txID)
+	if err != nil {
+		return nil, clientError(err)
+	}
+	return s.transaction(tx, result, height, timestamp), nil
+}
+
+// transaction converts [tx], which produced [result] in the block at
+// [height], to a [Tx] with decoded actions.
+func (s *Server) transaction(tx *chain.Transaction, result *chain.Result, height uint64, timestamp int64) *Tx {
+	t := &Tx{
+		ID:        tx.ID(),
+		Height:    height,
+		Timestamp: timestamp,
+		Actor:     s.c.Address(tx.Auth.Actor()),
+		Sponsor:   s.c.Address(tx.Sponsor()),
+		MaxFee:    tx.Base.MaxFee,
+		Actions:   make([]*Action, len(tx.Actions)),
+		Success:   result.Success,
+		Error:     string(result.Error),
+		Units:     result.Units,
+		Fee:       result.Fee,
+	}
+	for i, action := range tx.Actions {
+		a := &Action{
+			TypeID: action.GetTypeID(),
+			Type:   s.c.ActionName(action.GetTypeID()),
+			Fields: decodeAction(s.c, action),
+		}
+		if i < len(result.Outputs) {
+			a.Outputs = result.Outputs[i]
+		}
+		t.Actions[i] = a
+	}
+	return t
+}
+
+func (s *Server) account(ctx context.Context, id string, query url.Values) (any, error) {
+	addr, err := s.c.ParseAddress(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+	}
+	var cursor []byte
+	if c := query.Get("cursor"); len(c) > 0 {
+		cursor, err = hex.DecodeString(c)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid cursor: %s", ErrInvalidRequest, err)
+		}
+	}
+	limit := DefaultTxsLimit
+	if l := query.Get("limit"); len(l) > 0 {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 || limit > MaxTxsLimit {
+			return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidRequest, MaxTxsLimit)
+		}
+	}
+	balances, err := s.c.Balances(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+	txIDs, next, err := s.cli.GetTxsByAddress(ctx, addr, cursor, limit)
+	if err != nil {
+		return nil, clientError(err)
+	}
+	return &Account{
+		Address:  s.c.Address(addr),
+		Balances: balances,
+		TxIDs:    txIDs,
+		Next:     hex.EncodeToString(next),
+	}, nil
+}
+
+func (s *Server) assets(ctx context.Context, id string, _ url.Values) (any, error) {
+	assets, err := s.c.Assets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+	if len(id) == 0 {
+		return assets, nil
+	}
+	for _, asset := range assets {
+		if asset.ID == id {
+			return asset, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: asset %s", ErrNotFound, id)
+}
+
+// search returns the blocks, transactions, accounts, and assets that [q]
+// refers to (a height, block ID, transaction ID, address, or asset ID or
+// symbol).
+func (s *Server) search(ctx context.Context, _ string, query url.Values) (any, error) {
+	q := strings.TrimSpace(query.Get("q"))
+	if len(q) == 0 {
+		return nil, fmt.Errorf("%w: missing query", ErrInvalidRequest)
+	}
+	results := []*SearchResult{}
+	if height, err := strconv.ParseUint(q, 10, 64); err == nil {
+		_, accepted, _, err := s.cli.Accepted(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnavailable, err)
+		}
+		if height <= accepted {
+			results = append(results, &SearchResult{Type: BlockResult, ID: q})
+		}
+	}
+	if id, err := ids.FromString(q); err == nil {
+		if _, err := s.cli.GetIndexedBlockHeight(ctx, id); err == nil {
+			results = append(results, &SearchResult{Type: BlockResult, ID: q})
+		}
+		if _, _, _, _, err := s.cli.GetIndexedTx(ctx, s.c, id); err == nil {
+			results = append(results, &SearchResult{Type: TxResult, ID: q})
+		}
+	}
+	if addr, err := s.c.ParseAddress(q); err == nil {
+		results = append(results, &SearchResult{Type: AccountResult, ID: s.c.Address(addr)})
+	}
+	assets, err := s.c.Assets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+	for _, asset := range assets {
+		if asset.ID == q || strings.EqualFold(asset.Symbol, q) {
+			results = append(results, &SearchResult{Type: AssetResult, ID: asset.ID})
+		}
+	}
+	return &SearchResponse{Results: results}, nil
+}
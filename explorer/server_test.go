@@ -0,0 +1,288 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package explorer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var (
+	testAddresses = address.MustNew("test", nil)
+	testAsset     = &Asset{ID: "native", Symbol: "TEST", Decimals: 9}
+)
+
+type testTransfer struct {
+	chain.Action `json:"-"`
+
+	To    codec.Address `json:"to"`
+	Value uint64        `json:"value"`
+	Memo  []byte        `json:"memo"`
+}
+
+func (*testTransfer) GetTypeID() uint8 { return 0 }
+
+func (t *testTransfer) Size() int {
+	return codec.AddressLen + consts.Uint64Len + codec.BytesLen(t.Memo)
+}
+
+func (t *testTransfer) Marshal(p *codec.Packer) {
+	p.PackAddress(t.To)
+	p.PackUint64(t.Value)
+	p.PackBytes(t.Memo)
+}
+
+func unmarshalTestTransfer(p *codec.Packer) (chain.Action, error) {
+	var t testTransfer
+	p.UnpackAddress(&t.To)
+	t.Value = p.UnpackUint64(true)
+	p.UnpackBytes(-1, false, &t.Memo)
+	return &t, p.Err()
+}
+
+type testController struct {
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+	balances       map[codec.Address]uint64
+}
+
+func newTestController(t *testing.T) *testController {
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(t, actionRegistry.Register((&testTransfer{}).GetTypeID(), unmarshalTestTransfer))
+	require.NoError(t, authRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519))
+	return &testController{
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+		balances:       map[codec.Address]uint64{},
+	}
+}
+
+func (*testController) Rules(int64) chain.Rules { return nil }
+
+func (c *testController) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return c.actionRegistry, c.authRegistry
+}
+
+func (*testController) ParseAddress(s string) (codec.Address, error) {
+	return testAddresses.Parse(s)
+}
+
+func (*testController) Address(addr codec.Address) string {
+	return testAddresses.Encode(addr)
+}
+
+func (*testController) ActionName(uint8) string { return "transfer" }
+
+func (*testController) Assets(context.Context) ([]*Asset, error) {
+	return []*Asset{testAsset}, nil
+}
+
+func (c *testController) Balances(_ context.Context, addr codec.Address) ([]*Balance, error) {
+	balances := []*Balance{}
+	if balance := c.balances[addr]; balance > 0 {
+		balances = append(balances, &Balance{Asset: testAsset.ID, Amount: balance})
+	}
+	return balances, nil
+}
+
+type testClient struct {
+	blocks  []*chain.StatefulBlock
+	results [][]*chain.Result
+}
+
+func (c *testClient) Accepted(context.Context) (ids.ID, uint64, int64, error) {
+	blk := c.blocks[len(c.blocks)-1]
+	blkID, err := blk.ID()
+	return blkID, blk.Hght, blk.Tmstmp, err
+}
+
+func (c *testClient) GetIndexedBlock(
+	_ context.Context,
+	_ chain.Parser,
+	height uint64,
+) (*chain.StatefulBlock, []*chain.Result, fees.Dimensions, error) {
+	if height == 0 || height > uint64(len(c.blocks)) {
+		return nil, nil, fees.Dimensions{}, rpc.ErrBlockMissing
+	}
+	return c.blocks[height-1], c.results[height-1], fees.Dimensions{1, 1, 1, 1, 1}, nil
+}
+
+func (c *testClient) GetIndexedBlockHeight(_ context.Context, blkID ids.ID) (uint64, error) {
+	for _, blk := range c.blocks {
+		if id, _ := blk.ID(); id == blkID {
+			return blk.Hght, nil
+		}
+	}
+	return 0, rpc.ErrBlockMissing
+}
+
+func (c *testClient) GetIndexedTx(
+	_ context.Context,
+	_ chain.Parser,
+	txID ids.ID,
+) (*chain.Transaction, *chain.Result, uint64, int64, error) {
+	for i, blk := range c.blocks {
+		for j, tx := range blk.Txs {
+			if tx.ID() == txID {
+				return tx, c.results[i][j], blk.Hght, blk.Tmstmp, nil
+			}
+		}
+	}
+	return nil, nil, 0, 0, rpc.ErrTxMissing
+}
+
+func (c *testClient) GetTxsByAddress(
+	_ context.Context,
+	addr codec.Address,
+	cursor []byte,
+	limit int,
+) ([]ids.ID, []byte, error) {
+	txIDs := []ids.ID{}
+	for _, blk := range c.blocks {
+		for _, tx := range blk.Txs {
+			if tx.Auth.Actor() == addr || tx.Actions[0].(*testTransfer).To == addr {
+				txIDs = append(txIDs, tx.ID())
+			}
+		}
+	}
+	start := 0
+	if len(cursor) > 0 {
+		start = int(cursor[0])
+	}
+	if start+limit >= len(txIDs) {
+		return txIDs[start:], nil, nil
+	}
+	return txIDs[start : start+limit], []byte{byte(start + limit)}, nil
+}
+
+func get[T any](t *testing.T, s *Server, path string, code int) *T {
+	require := require.New(t)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(code, w.Code, w.Body.String())
+	resp := new(T)
+	require.NoError(json.Unmarshal(w.Body.Bytes(), resp))
+	return resp
+}
+
+func TestServer(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestController(t)
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	sender := auth.NewED25519Address(priv.PublicKey())
+	recipient := codec.CreateAddress(0, ids.GenerateTestID())
+	c.balances[recipient] = 20
+
+	actionRegistry, authRegistry := c.Registry()
+	newTx := func(value uint64) *chain.Transaction {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: 1_000, ChainID: ids.GenerateTestID(), MaxFee: 100},
+			[]chain.Action{&testTransfer{To: recipient, Value: value, Memo: []byte("hi")}},
+		).Sign(auth.NewED25519Factory(priv), actionRegistry, authRegistry)
+		require.NoError(err)
+		return tx
+	}
+	tx0, tx1 := newTx(10), newTx(10)
+	cli := &testClient{
+		blocks: []*chain.StatefulBlock{
+			{Prnt: ids.GenerateTestID(), Tmstmp: 1_000, Hght: 1, Txs: []*chain.Transaction{tx0}},
+			{Tmstmp: 2_000, Hght: 2, Txs: []*chain.Transaction{tx1}},
+		},
+		results: [][]*chain.Result{
+			{{Success: true, Fee: 5, Outputs: [][][]byte{{[]byte("out")}}}},
+			{{Success: false, Error: []byte("insufficient balance"), Fee: 5}},
+		},
+	}
+	blk1ID, err := cli.blocks[0].ID()
+	require.NoError(err)
+	cli.blocks[1].Prnt = blk1ID
+	blk2ID, err := cli.blocks[1].ID()
+	require.NoError(err)
+	s := NewServer(c, cli)
+
+	// Blocks can be fetched by height or ID
+	latest := get[Block](t, s, BlocksPath+LatestBlock, http.StatusOK)
+	require.Equal(blk2ID, latest.ID)
+	require.Equal(blk1ID, latest.Parent)
+	blk := get[Block](t, s, BlocksPath+blk1ID.String(), http.StatusOK)
+	require.Equal(uint64(1), blk.Height)
+	require.Equal(fees.Dimensions{1, 1, 1, 1, 1}, blk.UnitPrices)
+	require.Len(blk.Txs, 1)
+	require.Equal(tx0.ID(), blk.Txs[0].ID)
+	require.Equal(get[Block](t, s, BlocksPath+"1", http.StatusOK), blk)
+	get[ErrorResponse](t, s, BlocksPath+"3", http.StatusNotFound)
+	get[ErrorResponse](t, s, BlocksPath+ids.GenerateTestID().String(), http.StatusNotFound)
+	get[ErrorResponse](t, s, BlocksPath+"invalid", http.StatusBadRequest)
+
+	// Actions are decoded with formatted addresses
+	tx := get[Tx](t, s, TxsPath+tx0.ID().String(), http.StatusOK)
+	require.Equal(testAddresses.Encode(sender), tx.Actor)
+	require.True(tx.Success)
+	require.Equal(uint64(5), tx.Fee)
+	require.Len(tx.Actions, 1)
+	require.Equal("transfer", tx.Actions[0].Type)
+	require.Equal(map[string]any{
+		"to":    testAddresses.Encode(recipient),
+		"value": float64(10),
+		"memo":  "aGk=",
+	}, tx.Actions[0].Fields)
+	require.Equal([][]byte{[]byte("out")}, tx.Actions[0].Outputs)
+	tx = get[Tx](t, s, TxsPath+tx1.ID().String(), http.StatusOK)
+	require.False(tx.Success)
+	require.Equal("insufficient balance", tx.Error)
+	get[ErrorResponse](t, s, TxsPath+ids.GenerateTestID().String(), http.StatusNotFound)
+
+	// The transactions of accounts are paged
+	account := get[Account](t, s, AccountsPath+testAddresses.Encode(recipient)+"?limit=1", http.StatusOK)
+	require.Equal([]*Balance{{Asset: testAsset.ID, Amount: 20}}, account.Balances)
+	require.Equal([]ids.ID{tx0.ID()}, account.TxIDs)
+	require.NotEmpty(account.Next)
+	account = get[Account](t, s, AccountsPath+testAddresses.Encode(recipient)+"?limit=1&cursor="+account.Next, http.StatusOK)
+	require.Equal([]ids.ID{tx1.ID()}, account.TxIDs)
+	require.Empty(account.Next)
+	get[ErrorResponse](t, s, AccountsPath+"invalid", http.StatusBadRequest)
+	get[ErrorResponse](t, s, AccountsPath+testAddresses.Encode(recipient)+"?limit=0", http.StatusBadRequest)
+
+	// Assets
+	require.Equal(&[]*Asset{testAsset}, get[[]*Asset](t, s, AssetsPath, http.StatusOK))
+	require.Equal(testAsset, get[Asset](t, s, AssetsPath+"/"+testAsset.ID, http.StatusOK))
+	get[ErrorResponse](t, s, AssetsPath+"/other", http.StatusNotFound)
+
+	// Search
+	search := func(q string) []*SearchResult {
+		return get[SearchResponse](t, s, SearchPath+"?q="+q, http.StatusOK).Results
+	}
+	require.Equal([]*SearchResult{{Type: BlockResult, ID: "2"}}, search("2"))
+	require.Empty(search("3"))
+	require.Equal([]*SearchResult{{Type: BlockResult, ID: blk1ID.String()}}, search(blk1ID.String()))
+	require.Equal([]*SearchResult{{Type: TxResult, ID: tx1.ID().String()}}, search(tx1.ID().String()))
+	require.Equal([]*SearchResult{{Type: AccountResult, ID: testAddresses.Encode(sender)}}, search(testAddresses.Encode(sender)))
+	require.Equal([]*SearchResult{{Type: AssetResult, ID: testAsset.ID}}, search("test"))
+	get[ErrorResponse](t, s, SearchPath, http.StatusBadRequest)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, TxsPath+tx0.ID().String(), nil))
+	require.Equal(http.StatusMethodNotAllowed, w.Code)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	require.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package explorer
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+type Block struct {
+	ID        ids.ID `json:"id"`
+	Parent    ids.ID `json:"parent"`
+	Height    uint64 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	// UnitPrices are the prices the block was built with
+	UnitPrices fees.Dimensions `json:"unitPrices"`
+	Txs        []*Tx           `json:"txs"`
+}
+
+type Tx struct {
+	ID        ids.ID          `json:"id"`
+	Height    uint64          `json:"height"`
+	Timestamp int64           `json:"timestamp"`
+	Actor     string          `json:"actor"`
+	Sponsor   string          `json:"sponsor"`
+	MaxFee    uint64          `json:"maxFee"`
+	Actions   []*Action       `json:"actions"`
+	Success   bool            `json:"success"`
+	Error     string          `json:"error,omitempty"`
+	Units     fees.Dimensions `json:"units"`
+	Fee       uint64          `json:"fee"`
+}
+
+// Action is an action decoded from its JSON encoding, with the addresses it
+// references formatted by the [Controller].
+type Action struct {
+	TypeID  uint8    `json:"typeID"`
+	Type    string   `json:"type"`
+	Fields  any      `json:"fields"`
+	Outputs [][]byte `json:"outputs"`
+}
+
+type Account struct {
+	Address  string     `json:"address"`
+	Balances []*Balance `json:"balances"`
+	TxIDs    []ids.ID   `json:"txIDs"`
+	// Next is the (hex-encoded) cursor of the next page of [TxIDs] (empty
+	// if there are no more transactions)
+	Next string `json:"next,omitempty"`
+}
+
+type Asset struct {
+	ID       string `json:"id"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+	Metadata string `json:"metadata,omitempty"`
+}
+
+type Balance struct {
+	Asset  string `json:"asset"`
+	Amount uint64 `json:"amount"`
+}
+
+const (
+	BlockResult   = "block"
+	TxResult      = "tx"
+	AccountResult = "account"
+	AssetResult   = "asset"
+)
+
+// SearchResult identifies the object a search query refers to, which can be
+// fetched from the endpoint of its [Type].
+type SearchResult struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type SearchResponse struct {
+	Results []*SearchResult `json:"results"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package export writes accepted blocks and their results into flat files
+// (bundles) so that downstream pipelines can process (or re-index) the chain
+// without querying a node.
+//
+// Each bundle contains the blocks in the range [start, start+BlocksPerFile)
+// (blocks that were never indexed, like the ones accepted while the node was
+// state syncing, are missing). A bundle starts with [Magic] followed by a
+// version byte and the records of its blocks (in height order). Every record
+// is a hypersdk.export.Block (see export.proto) prefixed by its length as a
+// 4-byte big-endian integer.
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+const (
+	Magic   = "hblk"
+	Version = 1
+
+	// FileExtension is the extension of bundle names (see [FileName])
+	FileExtension = ".hblk"
+
+	maxRecordSize = 64 * units.MiB
+)
+
+var (
+	ErrInvalidBlocksPerFile = errors.New("blocks per file must be positive")
+	ErrNoDestination        = errors.New("no directory or url")
+	ErrMultipleDestinations = errors.New("only one of directory or url can be set")
+	ErrInvalidHeader        = errors.New("invalid bundle header")
+	ErrRecordTooLarge       = errors.New("record too large")
+	ErrInvalidRecord        = errors.New("invalid record")
+)
+
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// BlocksPerFile is the number of heights covered by each bundle
+	BlocksPerFile uint64 `json:"blocksPerFile"`
+
+	// Directory is the local directory bundles are written to
+	Directory string `json:"directory"`
+
+	// URL is the prefix of the object store URLs bundles are uploaded to with
+	// PUT requests (like "https://storage.googleapis.com/<bucket>/<prefix>")
+	URL string `json:"url"`
+
+	// AuthToken is sent as a bearer token with uploads (if not empty)
+	AuthToken string `json:"authToken"`
+
+	// Timeout bounds how long an upload can take
+	Timeout time.Duration `json:"timeout"`
+
+	// RetryDelay is how long to wait before writing again after a failure
+	RetryDelay time.Duration `json:"retryDelay"`
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Enabled:       false,
+		BlocksPerFile: 100,
+		Timeout:       time.Minute,
+		RetryDelay:    time.Second,
+	}
+}
+
+// FileName returns the name of the bundle that starts at [start].
+func FileName(start uint64) string {
+	return fmt.Sprintf("%010d%s", start, FileExtension)
+}
+
+// Record is an exported block.
+type Record struct {
+	ID        ids.ID
+	Parent    ids.ID
+	Height    uint64
+	Timestamp int64
+
+	// Block can be parsed with [chain.UnmarshalBlock]
+	Block []byte
+	// Results can be parsed with [chain.UnmarshalResults]
+	Results    []byte
+	UnitPrices fees.Dimensions
+}
+
+// NewRecord returns the [Record] of [blk], which produced [results] and was
+// executed with [prices].
+func NewRecord(blk *chain.StatefulBlock, results []*chain.Result, prices fees.Dimensions) (*Record, error) {
+	b, err := blk.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	blkID, err := blk.ID()
+	if err != nil {
+		return nil, err
+	}
+	mresults, err := chain.MarshalResults(results)
+	if err != nil {
+		return nil, err
+	}
+	return &Record{
+		ID:         blkID,
+		Parent:     blk.Prnt,
+		Height:     blk.Hght,
+		Timestamp:  blk.Tmstmp,
+		Block:      b,
+		Results:    mresults,
+		UnitPrices: prices,
+	}, nil
+}
+
+// Marshal encodes [r] as a hypersdk.export.Block.
+func (r *Record) Marshal() []byte {
+	var p []byte
+	p = appendBytes(p, 1, r.ID[:])
+	p = appendBytes(p, 2, r.Parent[:])
+	p = appendVarint(p, 3, r.Height)
+	p = appendVarint(p, 4, uint64(r.Timestamp))
+	p = appendBytes(p, 5, r.Block)
+	p = appendBytes(p, 6, r.Results)
+	packed := make([]byte, 0, fees.FeeDimensions*protowire.SizeVarint(0))
+	for _, v := range r.UnitPrices {
+		packed = protowire.AppendVarint(packed, v)
+	}
+	p = protowire.AppendTag(p, 7, protowire.BytesType)
+	return protowire.AppendBytes(p, packed)
+}
+
+// appendVarint omits zero values like proto3 does for scalar fields.
+func appendVarint(p []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return p
+	}
+	p = protowire.AppendTag(p, num, protowire.VarintType)
+	return protowire.AppendVarint(p, v)
+}
+
+func appendBytes(p []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return p
+	}
+	p = protowire.AppendTag(p, num, protowire.BytesType)
+	return protowire.AppendBytes(p, v)
+}
+
+// UnmarshalRecord decodes a hypersdk.export.Block. Unknown fields are
+// skipped.
+func UnmarshalRecord(b []byte) (*Record, error) {
+	var r Record
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, protowire.ParseError(n))
+		}
+		b = b[n:]
+		var err error
+		switch {
+		case typ == protowire.VarintType && (num == 3 || num == 4):
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			if num == 3 {
+				r.Height = v
+			} else {
+				r.Timestamp = int64(v)
+			}
+		case typ == protowire.BytesType && num != 3 && num != 4 && num <= 7:
+			var v []byte
+			v, n = protowire.ConsumeBytes(b)
+			if n >= 0 {
+				err = r.setBytes(num, v)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, protowire.ParseError(n))
+		}
+		if err != nil {
+			return nil, err
+		}
+		b = b[n:]
+	}
+	return &r, nil
+}
+
+func (r *Record) setBytes(num protowire.Number, v []byte) error {
+	switch num {
+	case 1, 2:
+		if len(v) != ids.IDLen {
+			return fmt.Errorf("%w: field %d has %d bytes", ErrInvalidRecord, num, len(v))
+		}
+		if num == 1 {
+			copy(r.ID[:], v)
+		} else {
+			copy(r.Parent[:], v)
+		}
+	case 5:
+		r.Block = v
+	case 6:
+		r.Results = v
+	case 7:
+		for i := 0; len(v) > 0; i++ {
+			if i == fees.FeeDimensions {
+				return fmt.Errorf("%w: too many unit prices", ErrInvalidRecord)
+			}
+			price, n := protowire.ConsumeVarint(v)
+			if n < 0 {
+				return fmt.Errorf("%w: %w", ErrInvalidRecord, protowire.ParseError(n))
+			}
+			r.UnitPrices[i] = price
+			v = v[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBundle encodes [records] as a bundle.
+func MarshalBundle(records []*Record) []byte {
+	b := append([]byte(Magic), Version)
+	for _, r := range records {
+		v := r.Marshal()
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		b = append(b, v...)
+	}
+	return b
+}
+
+// ReadBundle calls [f] with each record of the bundle read from [r] (in
+// order) until [f] returns an error.
+func ReadBundle(r io.Reader, f func(*Record) error) error {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(Magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if string(header[:len(Magic)]) != Magic || header[len(Magic)] != Version {
+		return ErrInvalidHeader
+	}
+	size := make([]byte, consts.Uint32Len)
+	for {
+		if _, err := io.ReadFull(br, size); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		l := binary.BigEndian.Uint32(size)
+		if l > maxRecordSize {
+			return fmt.Errorf("%w: %d bytes", ErrRecordTooLarge, l)
+		}
+		v := make([]byte, l)
+		if _, err := io.ReadFull(br, v); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		record, err := UnmarshalRecord(v)
+		if err != nil {
+			return err
+		}
+		if err := f(record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	records := []*Record{
+		{
+			ID:         ids.GenerateTestID(),
+			Parent:     ids.GenerateTestID(),
+			Height:     10,
+			Timestamp:  1_000,
+			Block:      []byte("block 10"),
+			Results:    []byte("results 10"),
+			UnitPrices: fees.Dimensions{1, 2, 3, 4, 5},
+		},
+		// Zero values are omitted from the encoding
+		{ID: ids.GenerateTestID(), Height: 11},
+	}
+	b := MarshalBundle(records)
+	require.Equal([]byte(Magic), b[:len(Magic)])
+
+	read := []*Record{}
+	require.NoError(ReadBundle(bytes.NewReader(b), func(r *Record) error {
+		read = append(read, r)
+		return nil
+	}))
+	require.Len(read, 2)
+	require.Equal(records[0], read[0])
+	require.Equal(records[1].ID, read[1].ID)
+	require.Equal(records[1].Height, read[1].Height)
+	require.Empty(read[1].Block)
+
+	// Unknown fields are skipped
+	extra := append(records[0].Marshal(), appendBytes(nil, 20, []byte("new field"))...)
+	r, err := UnmarshalRecord(extra)
+	require.NoError(err)
+	require.Equal(records[0], r)
+
+	// Truncated bundles are rejected
+	require.ErrorIs(ReadBundle(bytes.NewReader(b[:len(b)-1]), func(*Record) error { return nil }), ErrInvalidRecord)
+	require.ErrorIs(ReadBundle(bytes.NewReader([]byte("hblx\x01")), func(*Record) error { return nil }), ErrInvalidHeader)
+	require.ErrorIs(ReadBundle(bytes.NewReader(nil), func(*Record) error { return nil }), ErrInvalidHeader)
+}
+
+func TestNew(t *testing.T) {
+	require := require.New(t)
+
+	cfg := NewDefaultConfig()
+	_, err := New(cfg)
+	require.ErrorIs(err, ErrNoDestination)
+
+	cfg.Directory = t.TempDir()
+	cfg.URL = "http://localhost"
+	_, err = New(cfg)
+	require.ErrorIs(err, ErrMultipleDestinations)
+
+	cfg.URL = ""
+	cfg.BlocksPerFile = 0
+	_, err = New(cfg)
+	require.ErrorIs(err, ErrInvalidBlocksPerFile)
+}
+
+func TestLocalStore(t *testing.T) {
+	require := require.New(t)
+
+	dir := filepath.Join(t.TempDir(), "bundles")
+	s, err := NewLocalStore(dir)
+	require.NoError(err)
+
+	name := FileName(100)
+	require.Equal("0000000100.hblk", name)
+	ctx := context.Background()
+	require.NoError(s.Put(ctx, name, []byte("first")))
+	require.NoError(s.Put(ctx, name, []byte("second")))
+
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(err)
+	require.Equal([]byte("second"), b)
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(entries, 1)
+}
+
+func TestHTTPStore(t *testing.T) {
+	require := require.New(t)
+
+	uploads := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		uploads[r.URL.Path] = b
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	s := NewHTTPStore(server.URL+"/bucket/", "secret", NewDefaultConfig().Timeout)
+	require.NoError(s.Put(ctx, FileName(0), []byte("bundle")))
+	require.Equal([]byte("bundle"), uploads["/bucket/0000000000.hblk"])
+
+	s = NewHTTPStore(server.URL+"/bucket", "", NewDefaultConfig().Timeout)
+	require.ErrorIs(s.Put(ctx, FileName(0), []byte("bundle")), ErrUploadStatus)
+}
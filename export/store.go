@@ -0,0 +1,113 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrUploadStatus = errors.New("unexpected upload status")
+
+// Store persists bundles. Writing a bundle that already exists replaces it,
+// so bundles can be written again after a failure.
+type Store interface {
+	Put(ctx context.Context, name string, data []byte) error
+}
+
+// New returns the [Store] that writes to [cfg.Directory] or [cfg.URL].
+func New(cfg Config) (Store, error) {
+	if cfg.BlocksPerFile == 0 {
+		return nil, ErrInvalidBlocksPerFile
+	}
+	switch {
+	case len(cfg.Directory) > 0 && len(cfg.URL) > 0:
+		return nil, ErrMultipleDestinations
+	case len(cfg.Directory) > 0:
+		return NewLocalStore(cfg.Directory)
+	case len(cfg.URL) > 0:
+		return NewHTTPStore(cfg.URL, cfg.AuthToken, cfg.Timeout), nil
+	default:
+		return nil, ErrNoDestination
+	}
+}
+
+// LocalStore writes bundles to a directory. Bundles are written to a
+// temporary file that is renamed once it is synced, so readers never observe
+// partial bundles.
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+func (l *LocalStore) Put(_ context.Context, name string, data []byte) error {
+	tmp := filepath.Join(l.dir, name+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(l.dir, name))
+}
+
+// HTTPStore uploads bundles to an object store with PUT requests (which most
+// object stores, like Google Cloud Storage, accept with a bearer token).
+type HTTPStore struct {
+	url   string
+	token string
+	cli   *http.Client
+}
+
+func NewHTTPStore(url string, token string, timeout time.Duration) *HTTPStore {
+	return &HTTPStore{
+		url:   strings.TrimSuffix(url, "/"),
+		token: token,
+		cli:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (h *HTTPStore) Put(ctx context.Context, name string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, h.url+"/"+name, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if len(h.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+	resp, err := h.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", ErrUploadStatus, resp.StatusCode)
+	}
+	return nil
+}
@@ -5,4 +5,7 @@ package fees
 
 import "errors"
 
-var ErrWrongDimensionSize = errors.New("wrong dimensions size")
+var (
+	ErrWrongDimensionSize = errors.New("wrong dimensions size")
+	ErrInvalidFeeRules    = errors.New("window target units and unit price change denominator must be positive")
+)
@@ -6,6 +6,7 @@ package fees
 import (
 	"encoding/binary"
 	"fmt"
+	"math/bits"
 	"strconv"
 	"sync"
 
@@ -85,7 +86,7 @@ func (f *Manager) LastConsumed(d Dimension) uint64 {
 }
 
 func (f *Manager) lastConsumed(d Dimension) uint64 {
-	start := consts.IntLen + dimensionStateLen*d + consts.Uint64Len + window.WindowSliceSize
+	start := consts.Int64Len + dimensionStateLen*d + consts.Uint64Len + window.WindowSliceSize
 	return binary.BigEndian.Uint64(f.raw[start : start+consts.Uint64Len])
 }
 
@@ -220,6 +221,19 @@ func (f *Manager) UnitsConsumed() Dimensions {
 	return d
 }
 
+// computeNextPriceWindow rolls the window of a dimension forward [since]
+// seconds (adding the units consumed by the parent block) and moves the unit
+// price towards the price at which [target] units are consumed per window.
+//
+// Like the base fee of EIP-1559, the adjustment is proportional to the distance
+// between the units consumed in the window and [target]:
+//
+//	delta = price * min(|total - target|, target) / target / changeDenom
+//
+// so the price of a dimension changes by at most 1/[changeDenom] per block (and
+// by at least 1 if the window is not at its target). If no block was produced
+// for more than a window, the decrease is applied once for every window that
+// elapsed. The price never drops below [minPrice].
 func computeNextPriceWindow(
 	previous window.Window,
 	previousConsumed uint64,
@@ -229,6 +243,9 @@ func computeNextPriceWindow(
 	minPrice uint64,
 	since int64, /* seconds */
 ) (uint64, window.Window, error) {
+	if target == 0 || changeDenom == 0 {
+		return 0, window.Window{}, ErrInvalidFeeRules
+	}
 	newRollupWindow, err := window.Roll(previous, since)
 	if err != nil {
 		return 0, window.Window{}, err
@@ -244,38 +261,27 @@ func computeNextPriceWindow(
 	total := window.Sum(newRollupWindow)
 
 	nextPrice := previousPrice
-	if total > target {
-		// If the parent block used more units than its target, the baseFee should increase.
-		delta := total - target
-		x := previousPrice * delta
-		y := x / target
-		baseDelta := y / changeDenom
-		if baseDelta < 1 {
-			baseDelta = 1
-		}
-		n, over := math.Add64(nextPrice, baseDelta)
+	switch {
+	case total > target:
+		// If the window used more units than its target, the price should increase.
+		n, over := math.Add64(nextPrice, priceDelta(previousPrice, total-target, target, changeDenom))
 		if over != nil {
 			nextPrice = consts.MaxUint64
 		} else {
 			nextPrice = n
 		}
-	} else if total < target {
-		// Otherwise if the parent block used less units than its target, the baseFee should decrease.
-		delta := target - total
-		x := previousPrice * delta
-		y := x / target
-		baseDelta := y / changeDenom
-		if baseDelta < 1 {
-			baseDelta = 1
-		}
+	case total < target:
+		// Otherwise if the window used less units than its target, the price should decrease.
+		baseDelta := priceDelta(previousPrice, target-total, target, changeDenom)
 
-		// If [roll] is greater than [rollupWindow], apply the state transition to the base fee to account
-		// for the interval during which no blocks were produced.
-		// We use roll/rollupWindow, so that the transition is applied for every [rollupWindow] seconds
-		// that has elapsed between the parent and this block.
+		// If [since] is greater than [window.WindowSize], apply the state transition to the price
+		// to account for the interval during which no blocks were produced.
 		if since > window.WindowSize {
-			// Note: roll/rollupWindow must be greater than 1 since we've checked that roll > rollupWindow
-			baseDelta *= uint64(since / window.WindowSize)
+			n, over := math.Mul64(baseDelta, uint64(since/window.WindowSize))
+			if over != nil {
+				n = consts.MaxUint64
+			}
+			baseDelta = n
 		}
 		n, under := math.Sub(nextPrice, baseDelta)
 		if under != nil {
@@ -290,6 +296,16 @@ func computeNextPriceWindow(
 	return nextPrice, newRollupWindow, nil
 }
 
+// priceDelta returns price * min(diff, target) / target / changeDenom (at
+// least 1).
+func priceDelta(price uint64, diff uint64, target uint64, changeDenom uint64) uint64 {
+	// [bits.Div64] panics if the quotient overflows, which can't happen because
+	// [diff] is at most [target].
+	hi, lo := bits.Mul64(price, min(diff, target))
+	delta, _ := bits.Div64(hi, lo, target)
+	return max(delta/changeDenom, 1)
+}
+
 func Add(a, b Dimensions) (Dimensions, error) {
 	d := Dimensions{}
 	for i := Dimension(0); i < FeeDimensions; i++ {
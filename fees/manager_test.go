@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/window"
+)
+
+func TestComputeNextPriceWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		consumed uint64
+		price    uint64
+		since    int64
+		want     uint64
+	}{
+		{name: "at target", consumed: 1_000, price: 4_800, since: 1, want: 4_800},
+		{name: "above target", consumed: 1_500, price: 4_800, since: 1, want: 4_850},
+		// The increase is bounded by 1/denominator of the price
+		{name: "far above target", consumed: 1_000_000, price: 4_800, since: 1, want: 4_900},
+		{name: "large price", consumed: 1_000_000, price: consts.MaxUint64 - 1, since: 1, want: consts.MaxUint64},
+		{name: "below target", consumed: 500, price: 4_800, since: 1, want: 4_750},
+		{name: "at least 1", consumed: 999, price: 4_800, since: 1, want: 4_799},
+		// The decrease is applied for each window without blocks
+		{name: "empty windows", consumed: 0, price: 4_800, since: 3 * window.WindowSize, want: 4_500},
+		{name: "min price", consumed: 0, price: 101, since: 1, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			price, w, err := computeNextPriceWindow(window.Window{}, tt.consumed, tt.price, 1_000, 48, 100, tt.since)
+			require.NoError(err)
+			require.Equal(tt.want, price)
+			if tt.since < window.WindowSize {
+				require.Equal(tt.consumed, window.Sum(w))
+			}
+		})
+	}
+}
+
+func TestComputeNextInvalidRules(t *testing.T) {
+	require := require.New(t)
+
+	_, _, err := computeNextPriceWindow(window.Window{}, 0, 100, 0, 48, 100, 1)
+	require.ErrorIs(err, ErrInvalidFeeRules)
+	_, _, err = computeNextPriceWindow(window.Window{}, 0, 100, 1_000, 0, 100, 1)
+	require.ErrorIs(err, ErrInvalidFeeRules)
+}
+
+func TestManagerLastConsumed(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager(nil)
+	m.SetUnitPrice(Compute, 10)
+	m.SetLastConsumed(Compute, 25)
+	require.Equal(uint64(10), m.UnitPrice(Compute))
+	require.Equal(uint64(25), m.LastConsumed(Compute))
+	require.Equal(Dimensions{0, 25, 0, 0, 0}, m.UnitsConsumed())
+}
@@ -0,0 +1,12 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fetcher
+
+import "time"
+
+type Metrics interface {
+	// RecordQueueLatency is called with the time a key waited for a worker
+	// to fetch it.
+	RecordQueueLatency(time.Duration)
+}
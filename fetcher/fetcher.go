@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -20,7 +21,8 @@ import (
 // a value is only fetched from data once. Subsequent
 // requests can be retrieved from cache.
 type Fetcher struct {
-	im state.Immutable
+	im      state.Immutable
+	metrics Metrics
 
 	l    sync.RWMutex
 	keys map[string]*key
@@ -42,8 +44,9 @@ type tx struct {
 }
 
 type task struct {
-	ctx context.Context
-	key string
+	ctx    context.Context
+	key    string
+	queued time.Time // only set with metrics
 }
 
 type key struct {
@@ -57,10 +60,11 @@ type data struct {
 	chunks uint16
 }
 
-// New creates a new [Fetcher]
-func New(im state.Immutable, txs, concurrency int) *Fetcher {
+// New creates a new [Fetcher]. [metrics] may be nil.
+func New(im state.Immutable, txs, concurrency int, metrics Metrics) *Fetcher {
 	f := &Fetcher{
-		im: im,
+		im:      im,
+		metrics: metrics,
 
 		keys: make(map[string]*key, txs*2),
 		txs:  make(map[ids.ID]*tx, txs),
@@ -85,6 +89,9 @@ func (f *Fetcher) runWorker() {
 			if !ok {
 				return
 			}
+			if f.metrics != nil {
+				f.metrics.RecordQueueLatency(time.Since(t.queued))
+			}
 
 			v, err := f.im.GetValue(t.ctx, []byte(t.key))
 			if errors.Is(err, database.ErrNotFound) {
@@ -181,6 +188,9 @@ func (f *Fetcher) Fetch(ctx context.Context, txID ids.ID, stateKeys state.Keys)
 
 	// Send fetch tasks to the workers or exit
 	for _, t := range tasks {
+		if f.metrics != nil {
+			t.queued = time.Now()
+		}
 		select {
 		case f.tasks <- t:
 		case <-f.stop:
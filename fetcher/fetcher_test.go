@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -42,11 +43,22 @@ func (db *testDB) GetValue(_ context.Context, key []byte) (value []byte, err err
 	return val, nil
 }
 
+type testMetrics struct {
+	l         sync.Mutex
+	latencies int
+}
+
+func (m *testMetrics) RecordQueueLatency(time.Duration) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.latencies++
+}
+
 func TestFetchDifferentKeys(t *testing.T) {
 	var (
 		require = require.New(t)
 		numTxs  = 100
-		f       = New(newTestDB(), numTxs, 4)
+		f       = New(newTestDB(), numTxs, 4, nil)
 		ctx     = context.TODO()
 		wg      sync.WaitGroup
 
@@ -88,7 +100,7 @@ func TestFetchSameKeys(t *testing.T) {
 	var (
 		require = require.New(t)
 		numTxs  = 100
-		f       = New(newTestDB(), numTxs, 4)
+		f       = New(newTestDB(), numTxs, 4, nil)
 		ctx     = context.TODO()
 		wg      sync.WaitGroup
 
@@ -127,7 +139,7 @@ func TestFetchSameKeysSlow(t *testing.T) {
 	var (
 		require = require.New(t)
 		numTxs  = 1000 // More txns trying to fetch same key
-		f       = New(newTestDB(), numTxs, 4)
+		f       = New(newTestDB(), numTxs, 4, nil)
 		ctx     = context.TODO()
 		wg      sync.WaitGroup
 
@@ -174,7 +186,7 @@ func TestFetcherStop(t *testing.T) {
 	var (
 		require = require.New(t)
 		numTxs  = 100
-		f       = New(newTestDB(), numTxs, 10)
+		f       = New(newTestDB(), numTxs, 10, nil)
 		ctx     = context.TODO()
 		wg      sync.WaitGroup
 
@@ -215,3 +227,27 @@ func TestFetcherStop(t *testing.T) {
 	require.Equal(ErrStopped, f.Wait())
 	require.Less(len(cache), 100)
 }
+
+func TestFetchMetrics(t *testing.T) {
+	var (
+		require = require.New(t)
+		numTxs  = 10
+		metrics = &testMetrics{}
+		f       = New(newTestDB(), numTxs, 4, metrics)
+		ctx     = context.TODO()
+	)
+	for i := 0; i < numTxs; i++ {
+		stateKeys := make(state.Keys, numTxs)
+		for k := 0; k < numTxs; k++ {
+			stateKeys.Add(keyBase+strconv.Itoa(k), state.Read)
+		}
+		txID := ids.GenerateTestID()
+		require.NoError(f.Fetch(ctx, txID, stateKeys))
+		_, err := f.Get(txID)
+		require.NoError(err)
+	}
+	require.NoError(f.Wait())
+
+	// Each key is only queued (and fetched) once
+	require.Equal(numTxs, metrics.latencies)
+}
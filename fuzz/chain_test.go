@@ -0,0 +1,154 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fuzz_test
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/crypto/secp256r1"
+	"github.com/ava-labs/hypersdk/fuzz"
+)
+
+// testAction is a minimal action, so the fuzzers of the hypersdk exercise the
+// encoding of transactions and blocks instead of that of any hypervm.
+type testAction struct {
+	chain.Action
+
+	to    codec.Address
+	value uint64
+	memo  []byte
+}
+
+func (*testAction) GetTypeID() uint8 { return 0 }
+
+func (a *testAction) Size() int {
+	return codec.AddressLen + consts.Uint64Len + codec.BytesLen(a.memo)
+}
+
+func (a *testAction) Marshal(p *codec.Packer) {
+	p.PackAddress(a.to)
+	p.PackUint64(a.value)
+	p.PackBytes(a.memo)
+}
+
+func unmarshalTestAction(p *codec.Packer) (chain.Action, error) {
+	var a testAction
+	p.UnpackAddress(&a.to)
+	a.value = p.UnpackUint64(true)
+	p.UnpackBytes(256, false, &a.memo)
+	return &a, p.Err()
+}
+
+// parser parses the blocks of a chain with [testAction] and the auths of the
+// hypersdk.
+type parser struct {
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+}
+
+func newParser(t testing.TB) *parser {
+	require := require.New(t)
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(authRegistry.Register(auth.ED25519ID, auth.UnmarshalED25519))
+	require.NoError(authRegistry.Register(auth.SECP256R1ID, auth.UnmarshalSECP256R1))
+	require.NoError(authRegistry.Register(auth.BLSID, auth.UnmarshalBLS))
+	return &parser{actionRegistry: actionRegistry, authRegistry: authRegistry}
+}
+
+func (*parser) Rules(int64) chain.Rules { return nil }
+
+func (p *parser) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return p.actionRegistry, p.authRegistry
+}
+
+// seedTxs signs a transaction with each of the auths of the hypersdk.
+func seedTxs(t testing.TB, p *parser) []*chain.Transaction {
+	require := require.New(t)
+
+	edPriv, err := ed25519.GeneratePrivateKey()
+	require.NoError(err)
+	rPriv, err := secp256r1.GeneratePrivateKey()
+	require.NoError(err)
+	factories := []chain.AuthFactory{
+		auth.NewED25519Factory(edPriv),
+		auth.NewSECP256R1Factory(rPriv),
+	}
+
+	txs := make([]*chain.Transaction, 0, len(factories))
+	for i, factory := range factories {
+		base := &chain.Base{
+			Timestamp: int64(i+1) * consts.MillisecondsPerSecond,
+			ChainID:   ids.GenerateTestID(),
+			MaxFee:    1_000,
+			Tip:       uint64(i),
+		}
+		actions := []chain.Action{
+			&testAction{to: codec.CreateAddress(0, ids.GenerateTestID()), value: 10},
+			&testAction{to: codec.CreateAddress(1, ids.GenerateTestID()), value: 20, memo: []byte("memo")},
+		}
+		tx, err := chain.NewTx(base, actions).Sign(factory, p.actionRegistry, p.authRegistry)
+		require.NoError(err)
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func FuzzUnmarshalTx(f *testing.F) {
+	p := newParser(f)
+	for _, tx := range seedTxs(f, p) {
+		fuzz.Seed(f, tx.Bytes())
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckTx(t, raw, p.actionRegistry, p.authRegistry)
+	})
+}
+
+func FuzzUnmarshalBlock(f *testing.F) {
+	require := require.New(f)
+
+	p := newParser(f)
+	txs := seedTxs(f, p)
+	var (
+		proposer = codec.CreateAddress(0, ids.GenerateTestID())
+		empty    = codec.EmptyAddress
+	)
+	blks := []*chain.StatefulBlock{
+		{Prnt: ids.GenerateTestID(), Tmstmp: 1, Hght: 1, Txs: []*chain.Transaction{}},
+		{Prnt: ids.GenerateTestID(), Tmstmp: 2, Hght: 2, Txs: txs, StateRoot: ids.GenerateTestID()},
+		{Prnt: ids.GenerateTestID(), Tmstmp: 3, Hght: 3, Txs: txs, Proposer: &proposer},
+		{Prnt: ids.GenerateTestID(), Tmstmp: 4, Hght: 4, Txs: []*chain.Transaction{}, Proposer: &empty},
+	}
+	for _, blk := range blks {
+		raw, err := blk.Marshal()
+		require.NoError(err)
+		fuzz.Seed(f, raw)
+
+		// Append chunk certificates to the encoding (blocks can only be
+		// marshaled with certificates if their chunks are attached)
+		cert := &chain.ChunkCertificate{
+			Chunk:     ids.GenerateTestID(),
+			Signature: &warp.BitSetSignature{Signers: []byte{0x1}},
+		}
+		w := codec.NewWriter(consts.IntLen+cert.Size(), consts.NetworkSizeLimit)
+		w.PackInt(1)
+		cert.Marshal(w)
+		require.NoError(w.Err())
+		fuzz.Seed(f, append(raw, w.Bytes()...))
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		fuzz.CheckBlock(t, raw, p)
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fuzz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// Encode returns the type ID of [obj] followed by its encoding (the input
+// expected by [CheckObject]).
+func Encode(obj chain.Object) []byte {
+	p := codec.NewWriter(consts.ByteLen+obj.Size(), consts.NetworkSizeLimit)
+	p.PackByte(obj.GetTypeID())
+	obj.Marshal(p)
+	return p.Bytes()
+}
+
+// CheckObject unmarshals [raw] (the type ID of the object followed by its
+// encoding) with [registry] and, if it is valid, requires that marshaling the
+// object returns [raw] and that its size is the length of its encoding.
+func CheckObject[T chain.Object](t testing.TB, raw []byte, registry *codec.TypeParser[T]) {
+	require := require.New(t)
+
+	if len(raw) == 0 {
+		return
+	}
+	unmarshal, ok := registry.LookupIndex(raw[0])
+	if !ok {
+		return
+	}
+	p := codec.NewReader(raw[1:], consts.NetworkSizeLimit)
+	obj, err := unmarshal(p)
+	if err != nil || p.Err() != nil || !p.Empty() {
+		return
+	}
+	require.Equal(raw[0], obj.GetTypeID())
+	w := codec.NewWriter(obj.Size(), consts.NetworkSizeLimit)
+	obj.Marshal(w)
+	require.NoError(w.Err())
+	require.Equal(raw[1:], w.Bytes(), "non-canonical encoding")
+	require.Equal(len(raw)-1, obj.Size())
+}
+
+// CheckTx unmarshals a transaction from [raw] and, if it is valid, requires
+// that the transaction is the prefix of [raw] it consumed and that encoding
+// its fields again reproduces it.
+func CheckTx(
+	t testing.TB,
+	raw []byte,
+	actionRegistry chain.ActionRegistry,
+	authRegistry chain.AuthRegistry,
+) {
+	require := require.New(t)
+
+	p := codec.NewReader(raw, consts.NetworkSizeLimit)
+	tx, err := chain.UnmarshalTx(p, actionRegistry, authRegistry)
+	if err != nil {
+		return
+	}
+	require.Equal(raw[:p.Offset()], tx.Bytes())
+	requireCanonicalTx(t, tx)
+}
+
+// CheckBlock unmarshals a block from [raw] and, if it is valid, requires that
+// marshaling the block (and each of its transactions) returns [raw].
+func CheckBlock(t testing.TB, raw []byte, parser chain.Parser) {
+	require := require.New(t)
+
+	blk, err := chain.UnmarshalBlock(raw, parser)
+	if err != nil {
+		return
+	}
+	for _, tx := range blk.Txs {
+		requireCanonicalTx(t, tx)
+	}
+
+	// Parsed blocks don't have the chunks of their certificates attached, so
+	// the certificates are marshaled separately
+	certs := blk.Chunks
+	blk.Chunks = nil
+	encoded, err := blk.Marshal()
+	require.NoError(err)
+	if len(certs) > 0 {
+		w := codec.NewWriter(consts.IntLen+codec.CummSize(certs), consts.NetworkSizeLimit)
+		w.PackInt(len(certs))
+		for _, cert := range certs {
+			cert.Marshal(w)
+		}
+		require.NoError(w.Err())
+		encoded = append(encoded, w.Bytes()...)
+	}
+	require.Equal(raw, encoded, "non-canonical encoding")
+}
+
+// requireCanonicalTx encodes the fields of [tx] (instead of the bytes it was
+// parsed from) and requires that they match the bytes of [tx].
+func requireCanonicalTx(t testing.TB, tx *chain.Transaction) {
+	require := require.New(t)
+
+	fields := chain.NewTx(tx.Base, tx.Actions)
+	fields.Auth = tx.Auth
+	w := codec.NewWriter(tx.Size(), consts.NetworkSizeLimit)
+	require.NoError(fields.Marshal(w))
+	require.Equal(tx.Bytes(), w.Bytes(), "non-canonical encoding")
+}
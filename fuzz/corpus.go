@@ -0,0 +1,115 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package fuzz manages the seed corpora of fuzz targets that take a single
+// []byte and provides the differential checks shared by the fuzz targets of
+// the hypersdk and of hypervms.
+package fuzz
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// header is the first line of every corpus entry written by `go test -fuzz`.
+const header = "go test fuzz v1\n"
+
+var ErrInvalidEntry = errors.New("invalid corpus entry")
+
+// Marshal encodes [input] as a corpus entry.
+func Marshal(input []byte) []byte {
+	return []byte(fmt.Sprintf("%s[]byte(%q)\n", header, input))
+}
+
+// Unmarshal decodes a corpus entry encoded by [Marshal] (or `go test -fuzz`).
+func Unmarshal(entry []byte) ([]byte, error) {
+	value, ok := bytes.CutPrefix(entry, []byte(header))
+	if !ok {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidEntry)
+	}
+	value = bytes.TrimSpace(value)
+	value, ok = bytes.CutPrefix(value, []byte("[]byte("))
+	if !ok {
+		return nil, fmt.Errorf("%w: not a []byte", ErrInvalidEntry)
+	}
+	value, ok = bytes.CutSuffix(value, []byte(")"))
+	if !ok {
+		return nil, fmt.Errorf("%w: not a []byte", ErrInvalidEntry)
+	}
+	input, err := strconv.Unquote(string(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+	}
+	return []byte(input), nil
+}
+
+// Dir returns the corpus directory of [target] in the package in [root], which
+// `go test` loads automatically.
+func Dir(root string, target string) string {
+	return filepath.Join(root, "testdata", "fuzz", target)
+}
+
+// Write adds [inputs] to the corpus in [dir]. Entries are named by their hash,
+// so writing an input that is already in the corpus is a no-op.
+func Write(dir string, inputs ...[]byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, input := range inputs {
+		entry := Marshal(input)
+		h := sha256.Sum256(entry)
+		name := hex.EncodeToString(h[:])[:16]
+		if err := os.WriteFile(filepath.Join(dir, name), entry, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read returns the inputs of the corpus in [dir] (which may not exist).
+func Read(dir string) ([][]byte, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([][]byte, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		entry, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		input, err := Unmarshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, file.Name())
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// Seed adds [inputs] (usually the encodings of valid objects) to the seed
+// corpus of [f], along with the truncations of each input (which exercise
+// the handling of short reads).
+func Seed(f *testing.F, inputs ...[]byte) {
+	for _, input := range inputs {
+		f.Add(input)
+		for _, n := range []int{0, 1, len(input) / 2, len(input) - 1} {
+			if n >= 0 && n < len(input) {
+				f.Add(input[:n])
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fuzz
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorpus(t *testing.T) {
+	require := require.New(t)
+
+	dir := Dir(t.TempDir(), "FuzzTarget")
+	inputs, err := Read(dir)
+	require.NoError(err)
+	require.Empty(inputs)
+
+	written := [][]byte{{}, []byte("hello"), {0x0, 0xff, '"', '\\', '\n'}}
+	require.NoError(Write(dir, written...))
+	require.NoError(Write(dir, written[1])) // already in the corpus
+	inputs, err = Read(dir)
+	require.NoError(err)
+	require.ElementsMatch(written, inputs)
+
+	for _, input := range written {
+		decoded, err := Unmarshal(Marshal(input))
+		require.NoError(err)
+		require.Equal(input, decoded)
+	}
+
+	// Entries written by `go test -fuzz`
+	decoded, err := Unmarshal([]byte("go test fuzz v1\n[]byte(\"\\x00a\")\n"))
+	require.NoError(err)
+	require.Equal([]byte{0x0, 'a'}, decoded)
+
+	require.NoError(os.WriteFile(filepath.Join(dir, "invalid"), []byte("string(\"a\")"), 0o600))
+	_, err = Read(dir)
+	require.ErrorIs(err, ErrInvalidEntry)
+	_, err = Unmarshal([]byte("go test fuzz v1\nstring(\"a\")\n"))
+	require.ErrorIs(err, ErrInvalidEntry)
+}
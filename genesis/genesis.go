@@ -0,0 +1,223 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package genesis builds the genesis of a VM from a [Spec] (instead of
+// editing its JSON by hand): the default genesis of the VM with some of its
+// fields overridden, allocations read from a CSV or JSON file, and custom
+// state entries.
+//
+// VMs implement [Controller] (and optionally [StateController]) for their
+// genesis type, so the spec is applied and the result validated by the VM.
+package genesis
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+var (
+	ErrUnknownField          = errors.New("unknown genesis field")
+	ErrDuplicateAllocation   = errors.New("duplicate allocation")
+	ErrInvalidAllocation     = errors.New("invalid allocation")
+	ErrUnknownFormat         = errors.New("unknown allocations format")
+	ErrStateNotSupported     = errors.New("genesis state entries not supported")
+	ErrDuplicateStateEntry   = errors.New("duplicate state entry")
+	ErrUnexpectedCSVFieldNum = errors.New("unexpected number of csv fields")
+)
+
+// Allocation is a balance of the native asset of a VM at genesis.
+type Allocation struct {
+	Address string `json:"address"`
+	Balance uint64 `json:"balance"`
+}
+
+// StateEntry is a key-value pair written to state at genesis.
+type StateEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Spec describes a genesis.
+type Spec struct {
+	// Rules overrides the fields of the default genesis of the VM (by their
+	// JSON name), like "minBlockGap" or "maxBlockUnits".
+	Rules map[string]json.RawMessage `json:"rules"`
+	// Allocations is the path of a CSV or JSON file of [Allocation]s. If it
+	// is relative, it is relative to the spec file.
+	Allocations string `json:"allocations"`
+	// State is written to state at genesis (if the VM implements
+	// [StateController]).
+	State []*StateEntry `json:"state"`
+}
+
+// Controller builds the genesis [G] of a VM.
+type Controller[G any] interface {
+	// Default returns a new default genesis.
+	Default() G
+	// Allocate adds [allocations] to [g].
+	Allocate(g G, allocations []*Allocation) error
+	// Verify returns an error if [g] is invalid.
+	Verify(g G) error
+	// Rules returns the rules of a chain created with [g].
+	Rules(g G) chain.Rules
+}
+
+// StateController is an optional extension of [Controller] that adds custom
+// state entries to the genesis (the VM decides which keys can be set).
+type StateController[G any] interface {
+	SetState(g G, entries []*StateEntry) error
+}
+
+// LoadSpec reads the [Spec] at [path].
+func LoadSpec(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	var spec Spec
+	if err := d.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("unable to parse spec: %w", err)
+	}
+	if len(spec.Allocations) > 0 && !filepath.IsAbs(spec.Allocations) {
+		spec.Allocations = filepath.Join(filepath.Dir(path), spec.Allocations)
+	}
+	return &spec, nil
+}
+
+// Build returns the genesis described by [spec], after it was verified by
+// [c].
+func Build[G any](c Controller[G], spec *Spec) (G, error) {
+	var empty G
+	g := c.Default()
+	if err := override(g, spec.Rules); err != nil {
+		return empty, err
+	}
+	if len(spec.Allocations) > 0 {
+		allocations, err := ReadAllocations(spec.Allocations)
+		if err != nil {
+			return empty, err
+		}
+		if err := c.Allocate(g, allocations); err != nil {
+			return empty, err
+		}
+	}
+	if len(spec.State) > 0 {
+		sc, ok := c.(StateController[G])
+		if !ok {
+			return empty, ErrStateNotSupported
+		}
+		keys := make(map[string]struct{}, len(spec.State))
+		for _, entry := range spec.State {
+			if _, ok := keys[string(entry.Key)]; ok {
+				return empty, fmt.Errorf("%w: %x", ErrDuplicateStateEntry, entry.Key)
+			}
+			keys[string(entry.Key)] = struct{}{}
+		}
+		if err := sc.SetState(g, spec.State); err != nil {
+			return empty, err
+		}
+	}
+	if err := c.Verify(g); err != nil {
+		return empty, err
+	}
+	return g, nil
+}
+
+// override sets the fields of [g] named by [fields]. Unlike unmarshalling
+// [fields] into [g], fields that [g] doesn't have are rejected.
+func override(g any, fields map[string]json.RawMessage) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	var current map[string]json.RawMessage
+	if err := json.Unmarshal(b, &current); err != nil {
+		return err
+	}
+	for name := range fields {
+		if _, ok := current[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownField, name)
+		}
+	}
+	b, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, g)
+}
+
+// ReadAllocations reads the allocations in the CSV (.csv) or JSON (.json)
+// file at [path]. No address can be allocated to more than once.
+func ReadAllocations(path string) ([]*Allocation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var allocations []*Allocation
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		allocations, err = ParseCSVAllocations(f)
+	case ".json":
+		err = json.NewDecoder(f).Decode(&allocations)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, ext)
+	}
+	if err != nil {
+		return nil, err
+	}
+	addresses := make(map[string]struct{}, len(allocations))
+	for _, alloc := range allocations {
+		if _, ok := addresses[alloc.Address]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateAllocation, alloc.Address)
+		}
+		addresses[alloc.Address] = struct{}{}
+	}
+	return allocations, nil
+}
+
+// ParseCSVAllocations parses allocations formatted as "address,balance"
+// records. The first record is skipped if it is a header (its balance is not
+// a number).
+func ParseCSVAllocations(r io.Reader) ([]*Allocation, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+	allocations := []*Allocation{}
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return allocations, nil
+		}
+		if errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: line %d", ErrUnexpectedCSVFieldNum, line)
+		}
+		if err != nil {
+			return nil, err
+		}
+		balance, err := strconv.ParseUint(record[1], 10, 64)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidAllocation, line, err)
+		}
+		allocations = append(allocations, &Allocation{Address: record[0], Balance: balance})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+var errNoAllocations = errors.New("no allocations")
+
+type testGenesis struct {
+	MinBlockGap int64             `json:"minBlockGap"`
+	Units       [2]uint64         `json:"units"`
+	Allocations []*Allocation     `json:"allocations"`
+	State       map[string][]byte `json:"-"`
+}
+
+type testController struct{}
+
+func (testController) Default() *testGenesis {
+	return &testGenesis{MinBlockGap: 100, Units: [2]uint64{1, 2}, State: map[string][]byte{}}
+}
+
+func (testController) Allocate(g *testGenesis, allocations []*Allocation) error {
+	g.Allocations = append(g.Allocations, allocations...)
+	return nil
+}
+
+func (testController) Verify(g *testGenesis) error {
+	if len(g.Allocations) == 0 {
+		return errNoAllocations
+	}
+	return nil
+}
+
+func (testController) Rules(*testGenesis) chain.Rules {
+	return nil
+}
+
+type testStateController struct {
+	testController
+}
+
+func (testStateController) SetState(g *testGenesis, entries []*StateEntry) error {
+	for _, entry := range entries {
+		g.State[string(entry.Key)] = entry.Value
+	}
+	return nil
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestBuild(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	writeFile(t, dir, "allocations.csv", "address,balance\naddr1,10\n addr2, 20\n")
+	path := writeFile(t, dir, "spec.json", `{
+		"rules": {"units": [3, 4]},
+		"allocations": "allocations.csv",
+		"state": [{"key": "AQI=", "value": "Aw=="}]
+	}`)
+	spec, err := LoadSpec(path)
+	require.NoError(err)
+	require.Equal(filepath.Join(dir, "allocations.csv"), spec.Allocations)
+
+	g, err := Build[*testGenesis](testStateController{}, spec)
+	require.NoError(err)
+	require.Equal(int64(100), g.MinBlockGap)
+	require.Equal([2]uint64{3, 4}, g.Units)
+	require.Equal([]*Allocation{{"addr1", 10}, {"addr2", 20}}, g.Allocations)
+	require.Equal(map[string][]byte{"\x01\x02": {3}}, g.State)
+
+	// State entries require a [StateController]
+	_, err = Build[*testGenesis](testController{}, spec)
+	require.ErrorIs(err, ErrStateNotSupported)
+	spec.State = append(spec.State, &StateEntry{Key: []byte{1, 2}})
+	_, err = Build[*testGenesis](testStateController{}, spec)
+	require.ErrorIs(err, ErrDuplicateStateEntry)
+
+	// The genesis is verified by the controller
+	_, err = Build[*testGenesis](testController{}, &Spec{})
+	require.ErrorIs(err, errNoAllocations)
+
+	// Only fields of the genesis can be overridden
+	_, err = Build[*testGenesis](testController{}, &Spec{Rules: map[string]json.RawMessage{"minBlockGapp": json.RawMessage("1")}})
+	require.ErrorIs(err, ErrUnknownField)
+}
+
+func TestReadAllocations(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	allocations, err := ReadAllocations(writeFile(t, dir, "a.json", `[{"address": "addr1", "balance": 1}]`))
+	require.NoError(err)
+	require.Equal([]*Allocation{{"addr1", 1}}, allocations)
+
+	_, err = ReadAllocations(writeFile(t, dir, "b.json", `[{"address": "addr1", "balance": 1}, {"address": "addr1", "balance": 2}]`))
+	require.ErrorIs(err, ErrDuplicateAllocation)
+	_, err = ReadAllocations(writeFile(t, dir, "c.txt", ""))
+	require.ErrorIs(err, ErrUnknownFormat)
+
+	_, err = ParseCSVAllocations(strings.NewReader("addr1,1\naddr2,two\n"))
+	require.ErrorIs(err, ErrInvalidAllocation)
+	_, err = ParseCSVAllocations(strings.NewReader("addr1,1\naddr2\n"))
+	require.ErrorIs(err, ErrUnexpectedCSVFieldNum)
+}
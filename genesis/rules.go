@@ -0,0 +1,39 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import "github.com/ava-labs/hypersdk/chain"
+
+// Param is a value of the [chain.Rules] derived from a genesis.
+type Param struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// Describe returns the values of [r] (in the order they are declared by
+// [chain.Rules]), so the chain configuration derived from a genesis can be
+// reviewed before it is used.
+func Describe(r chain.Rules) []*Param {
+	return []*Param{
+		{"minBlockGap", r.GetMinBlockGap()},
+		{"minEmptyBlockGap", r.GetMinEmptyBlockGap()},
+		{"maxEmptyBlockGap", r.GetMaxEmptyBlockGap()},
+		{"blockTimestampTolerance", r.GetBlockTimestampTolerance()},
+		{"validityWindow", r.GetValidityWindow()},
+		{"maxActionsPerTx", r.GetMaxActionsPerTx()},
+		{"maxOutputsPerAction", r.GetMaxOutputsPerAction()},
+		{"minUnitPrice", r.GetMinUnitPrice()},
+		{"unitPriceChangeDenominator", r.GetUnitPriceChangeDenominator()},
+		{"windowTargetUnits", r.GetWindowTargetUnits()},
+		{"maxBlockUnits", r.GetMaxBlockUnits()},
+		{"baseComputeUnits", r.GetBaseComputeUnits()},
+		{"sponsorStateKeysMaxChunks", r.GetSponsorStateKeysMaxChunks()},
+		{"storageKeyReadUnits", r.GetStorageKeyReadUnits()},
+		{"storageValueReadUnits", r.GetStorageValueReadUnits()},
+		{"storageKeyAllocateUnits", r.GetStorageKeyAllocateUnits()},
+		{"storageValueAllocateUnits", r.GetStorageValueAllocateUnits()},
+		{"storageKeyWriteUnits", r.GetStorageKeyWriteUnits()},
+		{"storageValueWriteUnits", r.GetStorageValueWriteUnits()},
+	}
+}
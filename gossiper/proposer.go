@@ -177,9 +177,9 @@ func (g *Proposer) HandleAppGossip(ctx context.Context, nodeID ids.NodeID, msg [
 	// Add incoming transactions to our caches to prevent useless gossip and perform
 	// batch signature verification.
 	//
-	// We rely on AppGossipConcurrency to regulate concurrency here, so we don't create
-	// a separate pool of workers for this verification.
-	job, err := workers.NewSerial().NewJob(len(txs))
+	// Verification shares the workers of block verification but is preempted
+	// by it when the workers are saturated.
+	job, err := g.vm.AuthVerifiers().NewPriorityJob(workers.Background, len(txs))
 	if err != nil {
 		g.vm.Logger().Warn(
 			"unable to spawn new worker",
@@ -0,0 +1,431 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+
+	smath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	ProposeComputeUnits = 5
+	VoteComputeUnits    = 5
+	QueueComputeUnits   = 1
+	ExecuteComputeUnits = 5
+)
+
+var (
+	_ chain.Action = (*Propose)(nil)
+	_ chain.Action = (*Vote)(nil)
+	_ chain.Action = (*Queue)(nil)
+	_ chain.Action = (*Execute)(nil)
+)
+
+// withWeightStateKeys adds the keys read by the [Weigher] to [stateKeys].
+func (m *Module) withWeightStateKeys(actor codec.Address, stateKeys state.Keys) state.Keys {
+	for k, permissions := range m.weigher.WeightStateKeys(actor) {
+		stateKeys.Add(k, permissions)
+	}
+	return stateKeys
+}
+
+// Propose creates a proposal (whose ID is the ID of the action) to set
+// [Param] to [Value].
+type Propose struct {
+	Param Param  `json:"param"`
+	Value uint64 `json:"value"`
+
+	m *Module
+}
+
+// Propose returns an action that proposes to set [param] to [value].
+func (m *Module) Propose(param Param, value uint64) *Propose {
+	return &Propose{Param: param, Value: value, m: m}
+}
+
+func (*Propose) GetTypeID() uint8 {
+	return ProposeID
+}
+
+func (a *Propose) StateKeys(actor codec.Address, actionID ids.ID) state.Keys {
+	if a.m == nil {
+		return state.Keys{}
+	}
+	return a.m.withWeightStateKeys(actor, state.Keys{
+		string(ProposalKey(a.m.config.StatePrefix, actionID)): state.Allocate | state.Write,
+	})
+}
+
+func (a *Propose) StateKeysMaxChunks() []uint16 {
+	if a.m == nil {
+		return []uint16{ProposalChunks}
+	}
+	return append(a.m.weigher.WeightStateKeysMaxChunks(), ProposalChunks)
+}
+
+func (a *Propose) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	actionID ids.ID,
+) ([][]byte, error) {
+	if a.m == nil {
+		return nil, ErrNotRegistered
+	}
+	if !a.m.params.Contains(a.Param) {
+		return nil, fmt.Errorf("%w: %s", ErrParamNotGoverned, a.Param)
+	}
+	if info := params[a.Param]; a.Value < info.min || a.Value > info.max {
+		return nil, fmt.Errorf("%w: %s must be in [%d, %d]", ErrInvalidValue, a.Param, info.min, info.max)
+	}
+	weight, err := a.m.weigher.Weight(ctx, mu, actor)
+	if err != nil {
+		return nil, err
+	}
+	if weight < a.m.config.ProposalThreshold {
+		return nil, fmt.Errorf("%w: %d < %d", ErrInsufficientWeight, weight, a.m.config.ProposalThreshold)
+	}
+	if err := setProposal(ctx, mu, a.m.config.StatePrefix, actionID, &Proposal{
+		Proposer: actor,
+		Param:    a.Param,
+		Value:    a.Value,
+		End:      timestamp + a.m.config.VotingPeriod,
+		Status:   Active,
+	}); err != nil {
+		return nil, err
+	}
+	return [][]byte{actionID[:]}, nil
+}
+
+func (*Propose) ComputeUnits(chain.Rules) uint64 {
+	return ProposeComputeUnits
+}
+
+func (*Propose) Size() int {
+	return consts.Uint8Len + consts.Uint64Len
+}
+
+func (a *Propose) Marshal(p *codec.Packer) {
+	p.PackByte(uint8(a.Param))
+	p.PackUint64(a.Value)
+}
+
+func unmarshalPropose(m *Module, p *codec.Packer) (chain.Action, error) {
+	propose := Propose{m: m}
+	propose.Param = Param(p.UnpackByte())
+	propose.Value = p.UnpackUint64(false)
+	return &propose, p.Err()
+}
+
+func (*Propose) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// Vote adds the weight of the actor in favor of [Proposal] (if [Support]) or
+// against it. Each address can only vote once on a proposal.
+type Vote struct {
+	Proposal ids.ID `json:"proposal"`
+	Support  bool   `json:"support"`
+
+	m *Module
+}
+
+// Vote returns an action that votes for (or against) [proposal].
+func (m *Module) Vote(proposal ids.ID, support bool) *Vote {
+	return &Vote{Proposal: proposal, Support: support, m: m}
+}
+
+func (*Vote) GetTypeID() uint8 {
+	return VoteID
+}
+
+func (a *Vote) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	if a.m == nil {
+		return state.Keys{}
+	}
+	return a.m.withWeightStateKeys(actor, state.Keys{
+		string(ProposalKey(a.m.config.StatePrefix, a.Proposal)):    state.Read | state.Write,
+		string(VoteKey(a.m.config.StatePrefix, a.Proposal, actor)): state.All,
+	})
+}
+
+func (a *Vote) StateKeysMaxChunks() []uint16 {
+	if a.m == nil {
+		return []uint16{ProposalChunks, VoteChunks}
+	}
+	return append(a.m.weigher.WeightStateKeysMaxChunks(), ProposalChunks, VoteChunks)
+}
+
+func (a *Vote) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if a.m == nil {
+		return nil, ErrNotRegistered
+	}
+	prefix := a.m.config.StatePrefix
+	proposal, err := GetProposal(ctx, mu, prefix, a.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != Active || timestamp > proposal.End {
+		return nil, ErrVotingClosed
+	}
+	voted, err := hasVoted(ctx, mu, prefix, a.Proposal, actor)
+	if err != nil {
+		return nil, err
+	}
+	if voted {
+		return nil, ErrAlreadyVoted
+	}
+	weight, err := a.m.weigher.Weight(ctx, mu, actor)
+	if err != nil {
+		return nil, err
+	}
+	if weight == 0 {
+		return nil, ErrInsufficientWeight
+	}
+	if a.Support {
+		proposal.Yes, err = smath.Add64(proposal.Yes, weight)
+	} else {
+		proposal.No, err = smath.Add64(proposal.No, weight)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := setVote(ctx, mu, prefix, a.Proposal, actor, a.Support, weight); err != nil {
+		return nil, err
+	}
+	return nil, setProposal(ctx, mu, prefix, a.Proposal, proposal)
+}
+
+func (*Vote) ComputeUnits(chain.Rules) uint64 {
+	return VoteComputeUnits
+}
+
+func (*Vote) Size() int {
+	return ids.IDLen + consts.BoolLen
+}
+
+func (a *Vote) Marshal(p *codec.Packer) {
+	p.PackID(a.Proposal)
+	p.PackBool(a.Support)
+}
+
+func unmarshalVote(m *Module, p *codec.Packer) (chain.Action, error) {
+	vote := Vote{m: m}
+	p.UnpackID(true, &vote.Proposal)
+	vote.Support = p.UnpackBool()
+	return &vote, p.Err()
+}
+
+func (*Vote) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// Queue queues [Proposal] for execution once voting is over (if it passed).
+// Anyone can queue a proposal.
+type Queue struct {
+	Proposal ids.ID `json:"proposal"`
+
+	m *Module
+}
+
+// Queue returns an action that queues [proposal].
+func (m *Module) Queue(proposal ids.ID) *Queue {
+	return &Queue{Proposal: proposal, m: m}
+}
+
+func (*Queue) GetTypeID() uint8 {
+	return QueueID
+}
+
+func (a *Queue) StateKeys(codec.Address, ids.ID) state.Keys {
+	if a.m == nil {
+		return state.Keys{}
+	}
+	return state.Keys{
+		string(ProposalKey(a.m.config.StatePrefix, a.Proposal)): state.Read | state.Write,
+	}
+}
+
+func (*Queue) StateKeysMaxChunks() []uint16 {
+	return []uint16{ProposalChunks}
+}
+
+func (a *Queue) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	_ codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if a.m == nil {
+		return nil, ErrNotRegistered
+	}
+	prefix := a.m.config.StatePrefix
+	proposal, err := GetProposal(ctx, mu, prefix, a.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != Active {
+		return nil, ErrProposalNotActive
+	}
+	if timestamp <= proposal.End {
+		return nil, ErrVotingOpen
+	}
+	if proposal.Yes < a.m.config.Quorum || proposal.Yes <= proposal.No {
+		return nil, fmt.Errorf("%w: yes=%d no=%d quorum=%d", ErrProposalNotPassed, proposal.Yes, proposal.No, a.m.config.Quorum)
+	}
+	proposal.Status = Queued
+	proposal.ETA = timestamp + a.m.config.ExecutionDelay
+	return nil, setProposal(ctx, mu, prefix, a.Proposal, proposal)
+}
+
+func (*Queue) ComputeUnits(chain.Rules) uint64 {
+	return QueueComputeUnits
+}
+
+func (*Queue) Size() int {
+	return ids.IDLen
+}
+
+func (a *Queue) Marshal(p *codec.Packer) {
+	p.PackID(a.Proposal)
+}
+
+func unmarshalQueue(m *Module, p *codec.Packer) (chain.Action, error) {
+	queue := Queue{m: m}
+	p.UnpackID(true, &queue.Proposal)
+	return &queue, p.Err()
+}
+
+func (*Queue) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// Execute applies the change of the queued [Proposal], which takes effect
+// [Config.ActivationDelay] later. Anyone can execute a proposal.
+type Execute struct {
+	Proposal ids.ID `json:"proposal"`
+
+	m *Module
+}
+
+// Execute returns an action that executes [proposal].
+func (m *Module) Execute(proposal ids.ID) *Execute {
+	return &Execute{Proposal: proposal, m: m}
+}
+
+func (*Execute) GetTypeID() uint8 {
+	return ExecuteID
+}
+
+func (a *Execute) StateKeys(codec.Address, ids.ID) state.Keys {
+	if a.m == nil {
+		return state.Keys{}
+	}
+	return state.Keys{
+		string(ProposalKey(a.m.config.StatePrefix, a.Proposal)): state.Read | state.Write,
+		string(OverridesKey(a.m.config.StatePrefix)):            state.All,
+	}
+}
+
+func (*Execute) StateKeysMaxChunks() []uint16 {
+	return []uint16{ProposalChunks, OverridesChunks}
+}
+
+func (a *Execute) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	_ codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if a.m == nil {
+		return nil, ErrNotRegistered
+	}
+	prefix := a.m.config.StatePrefix
+	proposal, err := GetProposal(ctx, mu, prefix, a.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != Queued {
+		return nil, ErrProposalNotQueued
+	}
+	if timestamp < proposal.ETA {
+		return nil, ErrExecutionDelayPending
+	}
+	if timestamp > proposal.ETA+a.m.config.ExecutionWindow {
+		return nil, ErrProposalExpired
+	}
+	overrides, err := getOverrides(ctx, mu, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// The previous value is read from state (instead of [r], which reads the
+	// changes loaded by the node) so that every node records the same value.
+	// If [Param] was never changed, [r] returns the value of the VM.
+	o := &Override{
+		Value:      proposal.Value,
+		Activation: timestamp + a.m.config.ActivationDelay,
+		Previous:   params[proposal.Param].get(r),
+	}
+	if prev, ok := overrides[proposal.Param]; ok {
+		o.Previous = prev.Previous
+		if timestamp >= prev.Activation {
+			o.Previous = prev.Value
+		}
+	}
+	overrides[proposal.Param] = o
+	if err := setOverrides(ctx, mu, prefix, overrides); err != nil {
+		return nil, err
+	}
+	proposal.Status = Executed
+	return nil, setProposal(ctx, mu, prefix, a.Proposal, proposal)
+}
+
+func (*Execute) ComputeUnits(chain.Rules) uint64 {
+	return ExecuteComputeUnits
+}
+
+func (*Execute) Size() int {
+	return ids.IDLen
+}
+
+func (a *Execute) Marshal(p *codec.Packer) {
+	p.PackID(a.Proposal)
+}
+
+func unmarshalExecute(m *Module, p *codec.Packer) (chain.Action, error) {
+	execute := Execute{m: m}
+	p.UnpackID(true, &execute.Proposal)
+	return &execute, p.Err()
+}
+
+func (*Execute) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package governance lets the holders of voting weight (as defined by the VM)
+// change the parameters of a chain exposed through [chain.Rules] with
+// on-chain proposals.
+//
+// A proposal goes through the following stages, each triggered by an action:
+//
+//  1. [Propose] creates a proposal to set a [Param] to a new value. The
+//     proposer must have at least [Config.ProposalThreshold] weight.
+//  2. [Vote] records the weight of the actor for or against the proposal until
+//     [Config.VotingPeriod] has passed.
+//  3. [Queue] queues a proposal that received at least [Config.Quorum] weight
+//     in favor (and more in favor than against) once voting is over.
+//  4. [Execute] applies a queued proposal once [Config.ExecutionDelay] has
+//     passed (and before [Config.ExecutionWindow] has passed after that).
+//
+// Executed changes are recorded in state and take effect
+// [Config.ActivationDelay] after the block that executed them. The [Rules]
+// returned by [Module.Rules] only read changes loaded with [Module.Load], so
+// VMs must call it once their state is ready and whenever they accept a block
+// that executed a proposal. [Config.ActivationDelay] must be much larger than
+// the time it takes to accept a block, so every validator loads a change
+// before it verifies any block it applies to.
+package governance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+// Note: Registry will error during initialization if a duplicate ID is
+// assigned. VMs must not register their own actions with these IDs.
+const (
+	ProposeID uint8 = 0xf0
+	VoteID    uint8 = 0xf1
+	QueueID   uint8 = 0xf2
+	ExecuteID uint8 = 0xf3
+)
+
+var (
+	ErrNotRegistered         = errors.New("governance action was not parsed by a registered module")
+	ErrInvalidConfig         = errors.New("invalid governance config")
+	ErrParamNotGoverned      = errors.New("param can't be changed by governance")
+	ErrInvalidValue          = errors.New("invalid param value")
+	ErrInsufficientWeight    = errors.New("insufficient weight")
+	ErrProposalNotFound      = errors.New("proposal not found")
+	ErrVotingClosed          = errors.New("voting is closed")
+	ErrVotingOpen            = errors.New("voting is still open")
+	ErrAlreadyVoted          = errors.New("already voted")
+	ErrProposalNotPassed     = errors.New("proposal did not pass")
+	ErrProposalNotQueued     = errors.New("proposal is not queued")
+	ErrProposalNotActive     = errors.New("proposal is not active")
+	ErrExecutionDelayPending = errors.New("execution delay has not passed")
+	ErrProposalExpired       = errors.New("proposal expired")
+	ErrInvalidOverrides      = errors.New("invalid overrides")
+)
+
+// Weigher determines the voting weight of an address (like its balance of some
+// token or the stake of a validator it controls).
+//
+// Weight is read when a vote is cast, so a weigher that counts balances that
+// can be moved during the voting period lets the same funds vote more than
+// once (from different addresses). Weighers should only count balances that
+// can't be moved until voting is over if this matters.
+type Weigher interface {
+	// WeightStateKeys are the keys read by [Weight] (formatted like the keys
+	// of [chain.Action.StateKeys]).
+	WeightStateKeys(addr codec.Address) state.Keys
+	WeightStateKeysMaxChunks() []uint16
+
+	// Weight returns the voting weight of [addr].
+	Weight(ctx context.Context, im state.Immutable, addr codec.Address) (uint64, error)
+}
+
+type Config struct {
+	// StatePrefix is the first byte of every key written by governance
+	// actions (which no other key of the VM may start with)
+	StatePrefix byte `json:"statePrefix"`
+
+	// Params are the parameters proposals can change
+	Params []Param `json:"params"`
+
+	// ProposalThreshold is the weight an actor needs to make a proposal
+	ProposalThreshold uint64 `json:"proposalThreshold"`
+	// VotingPeriod is how long (in ms) a proposal can be voted on
+	VotingPeriod int64 `json:"votingPeriod"`
+	// Quorum is the weight that must vote in favor of a proposal for it to
+	// pass
+	Quorum uint64 `json:"quorum"`
+
+	// ExecutionDelay is how long (in ms) a proposal is queued before it can be
+	// executed and ExecutionWindow is how long (in ms) it can be executed after
+	// that
+	ExecutionDelay  int64 `json:"executionDelay"`
+	ExecutionWindow int64 `json:"executionWindow"`
+	// ActivationDelay is how long (in ms) after its execution a change takes
+	// effect
+	ActivationDelay int64 `json:"activationDelay"`
+}
+
+// Module provides the governance actions and the [Rules] overridden by the
+// proposals they executed.
+type Module struct {
+	config  Config
+	weigher Weigher
+	params  set.Set[Param]
+
+	overrides atomic.Pointer[map[Param]*Override]
+}
+
+func New(config Config, weigher Weigher) (*Module, error) {
+	if config.VotingPeriod <= 0 || config.ExecutionDelay < 0 || config.ExecutionWindow <= 0 || config.ActivationDelay <= 0 {
+		return nil, fmt.Errorf("%w: periods must be positive", ErrInvalidConfig)
+	}
+	if config.Quorum == 0 {
+		return nil, fmt.Errorf("%w: quorum must be positive", ErrInvalidConfig)
+	}
+	m := &Module{
+		config:  config,
+		weigher: weigher,
+		params:  set.NewSet[Param](len(config.Params)),
+	}
+	for _, param := range config.Params {
+		if _, ok := params[param]; !ok {
+			return nil, fmt.Errorf("%w: unknown param %d", ErrInvalidConfig, param)
+		}
+		m.params.Add(param)
+	}
+	m.overrides.Store(&map[Param]*Override{})
+	return m, nil
+}
+
+// Register adds the governance actions to [registry]. Only actions parsed by
+// [registry] (or created by the constructors of [m]) can be executed.
+func (m *Module) Register(registry chain.ActionRegistry) error {
+	r := (*codec.TypeParser[chain.Action])(registry)
+	for _, action := range []struct {
+		typeID    uint8
+		unmarshal func(*Module, *codec.Packer) (chain.Action, error)
+	}{
+		{ProposeID, unmarshalPropose},
+		{VoteID, unmarshalVote},
+		{QueueID, unmarshalQueue},
+		{ExecuteID, unmarshalExecute},
+	} {
+		unmarshal := action.unmarshal
+		if err := r.Register(action.typeID, func(p *codec.Packer) (chain.Action, error) {
+			return unmarshal(m, p)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load replaces the changes applied by the [Rules] of [m] with the changes
+// executed in [im].
+func (m *Module) Load(ctx context.Context, im state.Immutable) error {
+	overrides, err := getOverrides(ctx, im, m.config.StatePrefix)
+	if err != nil {
+		return err
+	}
+	m.overrides.Store(&overrides)
+	return nil
+}
+
+// Overrides returns the changes applied by the [Rules] of [m].
+func (m *Module) Overrides() map[Param]*Override {
+	return *m.overrides.Load()
+}
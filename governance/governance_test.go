@@ -0,0 +1,207 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+type testState map[string][]byte
+
+func (s testState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s testState) Insert(_ context.Context, key []byte, value []byte) error {
+	s[string(key)] = value
+	return nil
+}
+
+func (s testState) Remove(_ context.Context, key []byte) error {
+	delete(s, string(key))
+	return nil
+}
+
+// testWeigher assigns fixed weights (without reading state).
+type testWeigher map[codec.Address]uint64
+
+func (testWeigher) WeightStateKeys(codec.Address) state.Keys { return state.Keys{} }
+
+func (testWeigher) WeightStateKeysMaxChunks() []uint16 { return nil }
+
+func (w testWeigher) Weight(_ context.Context, _ state.Immutable, addr codec.Address) (uint64, error) {
+	return w[addr], nil
+}
+
+func TestGovernance(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	var (
+		alice = codec.CreateAddress(0, ids.GenerateTestID())
+		bob   = codec.CreateAddress(0, ids.GenerateTestID())
+		carol = codec.CreateAddress(0, ids.GenerateTestID())
+	)
+	m, err := New(Config{
+		StatePrefix:       0xff,
+		Params:            []Param{MaxActionsPerTx},
+		ProposalThreshold: 10,
+		VotingPeriod:      100,
+		Quorum:            50,
+		ExecutionDelay:    20,
+		ExecutionWindow:   30,
+		ActivationDelay:   40,
+	}, testWeigher{alice: 10, bob: 40, carol: 30})
+	require.NoError(err)
+	base := chain.NewMockRules(ctrl)
+	base.EXPECT().GetMaxActionsPerTx().Return(uint8(1)).AnyTimes()
+	base.EXPECT().GetMaxOutputsPerAction().Return(uint8(1)).AnyTimes()
+
+	// Actions are executed as parsed by the registry
+	registry := codec.NewTypeParser[chain.Action]()
+	require.NoError(m.Register(registry))
+	parse := func(a chain.Action) chain.Action {
+		p := codec.NewWriter(a.Size(), a.Size())
+		a.Marshal(p)
+		require.NoError(p.Err())
+		unmarshal, ok := registry.LookupIndex(a.GetTypeID())
+		require.True(ok)
+		parsed, err := unmarshal(codec.NewReader(p.Bytes(), a.Size()))
+		require.NoError(err)
+		require.Equal(a, parsed)
+		return parsed
+	}
+	s := testState{}
+	execute := func(a chain.Action, timestamp int64, actor codec.Address, actionID ids.ID) ([][]byte, error) {
+		return parse(a).Execute(ctx, m.Rules(base, timestamp), s, timestamp, actor, actionID)
+	}
+
+	// Only governed params can be changed (to valid values) by actors with
+	// enough weight
+	_, err = execute(m.Propose(MaxOutputsPerAction, 2), 0, alice, ids.GenerateTestID())
+	require.ErrorIs(err, ErrParamNotGoverned)
+	_, err = execute(m.Propose(MaxActionsPerTx, 256), 0, alice, ids.GenerateTestID())
+	require.ErrorIs(err, ErrInvalidValue)
+	_, err = execute(m.Propose(MaxActionsPerTx, 2), 0, codec.CreateAddress(0, ids.GenerateTestID()), ids.GenerateTestID())
+	require.ErrorIs(err, ErrInsufficientWeight)
+	proposalID := ids.GenerateTestID()
+	outputs, err := execute(m.Propose(MaxActionsPerTx, 2), 0, alice, proposalID)
+	require.NoError(err)
+	require.Equal([][]byte{proposalID[:]}, outputs)
+
+	// Votes are counted once per address until the voting period is over
+	_, err = execute(m.Vote(proposalID, true), 10, bob, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Vote(proposalID, false), 20, bob, ids.GenerateTestID())
+	require.ErrorIs(err, ErrAlreadyVoted)
+	_, err = execute(m.Vote(proposalID, false), 100, carol, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Vote(proposalID, true), 101, alice, ids.GenerateTestID())
+	require.ErrorIs(err, ErrVotingClosed)
+	proposal, err := GetProposal(ctx, s, 0xff, proposalID)
+	require.NoError(err)
+	require.Equal(&Proposal{Proposer: alice, Param: MaxActionsPerTx, Value: 2, End: 100, Yes: 40, No: 30}, proposal)
+
+	// 40 yes < 50 quorum
+	_, err = execute(m.Queue(proposalID), 101, carol, ids.GenerateTestID())
+	require.ErrorIs(err, ErrProposalNotPassed)
+
+	// A proposal that reaches the quorum is executed after the execution
+	// delay and applies once it is loaded and activated
+	proposalID = ids.GenerateTestID()
+	_, err = execute(m.Propose(MaxActionsPerTx, 2), 200, alice, proposalID)
+	require.NoError(err)
+	_, err = execute(m.Vote(proposalID, true), 210, bob, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Vote(proposalID, true), 220, alice, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Queue(proposalID), 300, carol, ids.GenerateTestID())
+	require.ErrorIs(err, ErrVotingOpen)
+	_, err = execute(m.Queue(proposalID), 301, carol, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Execute(proposalID), 320, carol, ids.GenerateTestID())
+	require.ErrorIs(err, ErrExecutionDelayPending)
+	_, err = execute(m.Execute(proposalID), 321, carol, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Execute(proposalID), 322, carol, ids.GenerateTestID())
+	require.ErrorIs(err, ErrProposalNotQueued)
+
+	require.Equal(uint8(1), m.Rules(base, 400).GetMaxActionsPerTx())
+	require.NoError(m.Load(ctx, s))
+	require.Equal(map[Param]*Override{MaxActionsPerTx: {Value: 2, Activation: 361, Previous: 1}}, m.Overrides())
+	require.Equal(uint8(1), m.Rules(base, 360).GetMaxActionsPerTx())
+	require.Equal(uint8(2), m.Rules(base, 361).GetMaxActionsPerTx())
+	require.Equal(uint8(1), m.Rules(base, 361).GetMaxOutputsPerAction())
+
+	// Queued proposals expire if they aren't executed during the execution
+	// window
+	proposalID = ids.GenerateTestID()
+	_, err = execute(m.Propose(MaxActionsPerTx, 3), 400, alice, proposalID)
+	require.NoError(err)
+	_, err = execute(m.Vote(proposalID, true), 410, bob, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Vote(proposalID, true), 420, carol, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Queue(proposalID), 501, alice, ids.GenerateTestID())
+	require.NoError(err)
+	_, err = execute(m.Execute(proposalID), 552, alice, ids.GenerateTestID())
+	require.ErrorIs(err, ErrProposalExpired)
+
+	_, err = execute(m.Execute(ids.GenerateTestID()), 552, alice, ids.GenerateTestID())
+	require.ErrorIs(err, ErrProposalNotFound)
+}
+
+func TestExecuteBeforeActivation(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	alice := codec.CreateAddress(0, ids.GenerateTestID())
+	m, err := New(Config{
+		Params:          []Param{BaseComputeUnits},
+		VotingPeriod:    1,
+		Quorum:          1,
+		ExecutionWindow: 1,
+		ActivationDelay: 100,
+	}, testWeigher{alice: 1})
+	require.NoError(err)
+	base := chain.NewMockRules(ctrl)
+	base.EXPECT().GetBaseComputeUnits().Return(uint64(1)).AnyTimes()
+
+	s := testState{}
+	change := func(value uint64, timestamp int64) {
+		proposalID := ids.GenerateTestID()
+		for _, a := range []chain.Action{m.Propose(BaseComputeUnits, value), m.Vote(proposalID, true), m.Queue(proposalID), m.Execute(proposalID)} {
+			_, err := a.Execute(ctx, m.Rules(base, timestamp), s, timestamp, alice, proposalID)
+			require.NoError(err)
+			timestamp++
+		}
+		require.NoError(m.Load(ctx, s))
+	}
+
+	// A change executed before the previous one is activated keeps the value
+	// the previous change replaced until it is activated
+	change(2, 0)
+	change(3, 10)
+	require.Equal(map[Param]*Override{BaseComputeUnits: {Value: 3, Activation: 113, Previous: 1}}, m.Overrides())
+	change(4, 200)
+	require.Equal(map[Param]*Override{BaseComputeUnits: {Value: 4, Activation: 303, Previous: 3}}, m.Overrides())
+	require.Equal(uint64(3), m.Rules(base, 302).GetBaseComputeUnits())
+	require.Equal(uint64(4), m.Rules(base, 303).GetBaseComputeUnits())
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"math"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+var _ chain.Rules = (*Rules)(nil)
+
+// Param is a parameter of [chain.Rules] that proposals can change.
+type Param uint8
+
+const (
+	MinBlockGap Param = iota
+	MinEmptyBlockGap
+	MaxActionsPerTx
+	MaxOutputsPerAction
+	BaseComputeUnits
+	StorageKeyReadUnits
+	StorageValueReadUnits
+	StorageKeyAllocateUnits
+	StorageValueAllocateUnits
+	StorageKeyWriteUnits
+	StorageValueWriteUnits
+	MaxEmptyBlockGap
+	BlockTimestampTolerance
+)
+
+type paramInfo struct {
+	name string
+	// Values must be in [min, max]
+	min, max uint64
+	get      func(chain.Rules) uint64
+}
+
+var params = map[Param]paramInfo{
+	MinBlockGap:               {"minBlockGap", 0, math.MaxInt64, func(r chain.Rules) uint64 { return uint64(r.GetMinBlockGap()) }},
+	MinEmptyBlockGap:          {"minEmptyBlockGap", 0, math.MaxInt64, func(r chain.Rules) uint64 { return uint64(r.GetMinEmptyBlockGap()) }},
+	MaxActionsPerTx:           {"maxActionsPerTx", 1, math.MaxUint8, func(r chain.Rules) uint64 { return uint64(r.GetMaxActionsPerTx()) }},
+	MaxOutputsPerAction:       {"maxOutputsPerAction", 1, math.MaxUint8, func(r chain.Rules) uint64 { return uint64(r.GetMaxOutputsPerAction()) }},
+	BaseComputeUnits:          {"baseComputeUnits", 0, math.MaxUint64, chain.Rules.GetBaseComputeUnits},
+	StorageKeyReadUnits:       {"storageKeyReadUnits", 0, math.MaxUint64, chain.Rules.GetStorageKeyReadUnits},
+	StorageValueReadUnits:     {"storageValueReadUnits", 0, math.MaxUint64, chain.Rules.GetStorageValueReadUnits},
+	StorageKeyAllocateUnits:   {"storageKeyAllocateUnits", 0, math.MaxUint64, chain.Rules.GetStorageKeyAllocateUnits},
+	StorageValueAllocateUnits: {"storageValueAllocateUnits", 0, math.MaxUint64, chain.Rules.GetStorageValueAllocateUnits},
+	StorageKeyWriteUnits:      {"storageKeyWriteUnits", 0, math.MaxUint64, chain.Rules.GetStorageKeyWriteUnits},
+	StorageValueWriteUnits:    {"storageValueWriteUnits", 0, math.MaxUint64, chain.Rules.GetStorageValueWriteUnits},
+	MaxEmptyBlockGap:          {"maxEmptyBlockGap", 0, math.MaxInt64, func(r chain.Rules) uint64 { return uint64(r.GetMaxEmptyBlockGap()) }},
+	BlockTimestampTolerance:   {"blockTimestampTolerance", 0, math.MaxInt64, func(r chain.Rules) uint64 { return uint64(r.GetBlockTimestampTolerance()) }},
+}
+
+func (p Param) String() string {
+	if info, ok := params[p]; ok {
+		return info.name
+	}
+	return "unknown"
+}
+
+// Override is a change of a [Param] executed by a proposal.
+type Override struct {
+	Value uint64 `json:"value"`
+	// Activation is the timestamp of the first block the change applies to
+	// ([Previous] applies to earlier blocks)
+	Activation int64  `json:"activation"`
+	Previous   uint64 `json:"previous"`
+}
+
+// Rules are the [chain.Rules] of a VM with the changes executed by proposals.
+//
+// Rules only implement [chain.Rules], so VMs whose rules implement optional
+// extensions (like [chain.FeeDiscountRules]) must embed [Rules] in a type that
+// implements them.
+type Rules struct {
+	chain.Rules
+
+	overrides map[Param]*Override
+	t         int64
+}
+
+// Rules returns the rules of the block with timestamp [t] (with [r] being the
+// rules of the VM without any change).
+func (m *Module) Rules(r chain.Rules, t int64) *Rules {
+	return &Rules{r, m.Overrides(), t}
+}
+
+func (r *Rules) value(param Param, base uint64) uint64 {
+	o, ok := r.overrides[param]
+	switch {
+	case !ok:
+		return base
+	case r.t >= o.Activation:
+		return o.Value
+	default:
+		return o.Previous
+	}
+}
+
+func (r *Rules) GetMinBlockGap() int64 {
+	return int64(r.value(MinBlockGap, uint64(r.Rules.GetMinBlockGap())))
+}
+
+func (r *Rules) GetMinEmptyBlockGap() int64 {
+	return int64(r.value(MinEmptyBlockGap, uint64(r.Rules.GetMinEmptyBlockGap())))
+}
+
+func (r *Rules) GetMaxEmptyBlockGap() int64 {
+	return int64(r.value(MaxEmptyBlockGap, uint64(r.Rules.GetMaxEmptyBlockGap())))
+}
+
+func (r *Rules) GetBlockTimestampTolerance() int64 {
+	return int64(r.value(BlockTimestampTolerance, uint64(r.Rules.GetBlockTimestampTolerance())))
+}
+
+func (r *Rules) GetMaxActionsPerTx() uint8 {
+	return uint8(r.value(MaxActionsPerTx, uint64(r.Rules.GetMaxActionsPerTx())))
+}
+
+func (r *Rules) GetMaxOutputsPerAction() uint8 {
+	return uint8(r.value(MaxOutputsPerAction, uint64(r.Rules.GetMaxOutputsPerAction())))
+}
+
+func (r *Rules) GetBaseComputeUnits() uint64 {
+	return r.value(BaseComputeUnits, r.Rules.GetBaseComputeUnits())
+}
+
+func (r *Rules) GetStorageKeyReadUnits() uint64 {
+	return r.value(StorageKeyReadUnits, r.Rules.GetStorageKeyReadUnits())
+}
+
+func (r *Rules) GetStorageValueReadUnits() uint64 {
+	return r.value(StorageValueReadUnits, r.Rules.GetStorageValueReadUnits())
+}
+
+func (r *Rules) GetStorageKeyAllocateUnits() uint64 {
+	return r.value(StorageKeyAllocateUnits, r.Rules.GetStorageKeyAllocateUnits())
+}
+
+func (r *Rules) GetStorageValueAllocateUnits() uint64 {
+	return r.value(StorageValueAllocateUnits, r.Rules.GetStorageValueAllocateUnits())
+}
+
+func (r *Rules) GetStorageKeyWriteUnits() uint64 {
+	return r.value(StorageKeyWriteUnits, r.Rules.GetStorageKeyWriteUnits())
+}
+
+func (r *Rules) GetStorageValueWriteUnits() uint64 {
+	return r.value(StorageValueWriteUnits, r.Rules.GetStorageValueWriteUnits())
+}
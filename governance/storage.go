@@ -0,0 +1,194 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package governance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	proposalPrefix  = 0x0
+	votePrefix      = 0x1
+	overridesPrefix = 0x2
+
+	ProposalChunks  uint16 = 2
+	VoteChunks      uint16 = 1
+	OverridesChunks uint16 = 5
+
+	proposalSize = codec.AddressLen + consts.Uint8Len + consts.Uint64Len*4 + consts.Uint8Len + consts.Int64Len
+	voteSize     = consts.BoolLen + consts.Uint64Len
+	overrideSize = consts.Uint8Len + consts.Uint64Len*2 + consts.Int64Len
+)
+
+// Status is the stage of a [Proposal].
+type Status uint8
+
+const (
+	// Active proposals can be voted on (until [Proposal.End]) and queued
+	// once voting is over (if they passed)
+	Active Status = iota
+	Queued
+	Executed
+)
+
+// Proposal is a change of [Param] to [Value] proposed by [Proposer].
+type Proposal struct {
+	Proposer codec.Address `json:"proposer"`
+	Param    Param         `json:"param"`
+	Value    uint64        `json:"value"`
+
+	// End is the timestamp of the last block that can include a vote
+	End int64  `json:"end"`
+	Yes uint64 `json:"yes"`
+	No  uint64 `json:"no"`
+
+	Status Status `json:"status"`
+	// ETA is the timestamp after which a queued proposal can be executed
+	ETA int64 `json:"eta"`
+}
+
+// [statePrefix] + [proposalPrefix] + [proposalID]
+func ProposalKey(statePrefix byte, proposalID ids.ID) []byte {
+	k := make([]byte, 2+ids.IDLen+consts.Uint16Len)
+	k[0] = statePrefix
+	k[1] = proposalPrefix
+	copy(k[2:], proposalID[:])
+	return keys.EncodeChunks(k[:2+ids.IDLen], ProposalChunks)
+}
+
+// [statePrefix] + [votePrefix] + [proposalID] + [voter]
+func VoteKey(statePrefix byte, proposalID ids.ID, voter codec.Address) []byte {
+	k := make([]byte, 2+ids.IDLen+codec.AddressLen+consts.Uint16Len)
+	k[0] = statePrefix
+	k[1] = votePrefix
+	copy(k[2:], proposalID[:])
+	copy(k[2+ids.IDLen:], voter[:])
+	return keys.EncodeChunks(k[:2+ids.IDLen+codec.AddressLen], VoteChunks)
+}
+
+// [statePrefix] + [overridesPrefix]
+func OverridesKey(statePrefix byte) []byte {
+	return keys.EncodeChunks([]byte{statePrefix, overridesPrefix}, OverridesChunks)
+}
+
+// GetProposal returns the proposal [proposalID] (or [ErrProposalNotFound]).
+func GetProposal(ctx context.Context, im state.Immutable, statePrefix byte, proposalID ids.ID) (*Proposal, error) {
+	v, err := im.GetValue(ctx, ProposalKey(statePrefix, proposalID))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, ErrProposalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalProposal(v)
+}
+
+func setProposal(ctx context.Context, mu state.Mutable, statePrefix byte, proposalID ids.ID, proposal *Proposal) error {
+	p := codec.NewWriter(proposalSize, proposalSize)
+	p.PackAddress(proposal.Proposer)
+	p.PackByte(uint8(proposal.Param))
+	p.PackUint64(proposal.Value)
+	p.PackInt64(proposal.End)
+	p.PackUint64(proposal.Yes)
+	p.PackUint64(proposal.No)
+	p.PackByte(uint8(proposal.Status))
+	p.PackInt64(proposal.ETA)
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ProposalKey(statePrefix, proposalID), p.Bytes())
+}
+
+func unmarshalProposal(v []byte) (*Proposal, error) {
+	var (
+		p        = codec.NewReader(v, proposalSize)
+		proposal Proposal
+	)
+	p.UnpackAddress(&proposal.Proposer)
+	proposal.Param = Param(p.UnpackByte())
+	proposal.Value = p.UnpackUint64(false)
+	proposal.End = p.UnpackInt64(true)
+	proposal.Yes = p.UnpackUint64(false)
+	proposal.No = p.UnpackUint64(false)
+	proposal.Status = Status(p.UnpackByte())
+	proposal.ETA = p.UnpackInt64(false)
+	return &proposal, p.Err()
+}
+
+func hasVoted(ctx context.Context, im state.Immutable, statePrefix byte, proposalID ids.ID, voter codec.Address) (bool, error) {
+	_, err := im.GetValue(ctx, VoteKey(statePrefix, proposalID, voter))
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func setVote(ctx context.Context, mu state.Mutable, statePrefix byte, proposalID ids.ID, voter codec.Address, support bool, weight uint64) error {
+	p := codec.NewWriter(voteSize, voteSize)
+	p.PackBool(support)
+	p.PackUint64(weight)
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, VoteKey(statePrefix, proposalID, voter), p.Bytes())
+}
+
+func getOverrides(ctx context.Context, im state.Immutable, statePrefix byte) (map[Param]*Override, error) {
+	v, err := im.GetValue(ctx, OverridesKey(statePrefix))
+	if errors.Is(err, database.ErrNotFound) {
+		return map[Param]*Override{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := codec.NewReader(v, len(v))
+	count := int(p.UnpackByte())
+	if count > len(params) {
+		return nil, fmt.Errorf("%w: %d params", ErrInvalidOverrides, count)
+	}
+	overrides := make(map[Param]*Override, count)
+	for i := 0; i < count; i++ {
+		param := Param(p.UnpackByte())
+		overrides[param] = &Override{
+			Value:      p.UnpackUint64(false),
+			Activation: p.UnpackInt64(false),
+			Previous:   p.UnpackUint64(false),
+		}
+	}
+	if !p.Empty() {
+		return nil, fmt.Errorf("%w: remaining=%d", ErrInvalidOverrides, len(v)-p.Offset())
+	}
+	return overrides, p.Err()
+}
+
+func setOverrides(ctx context.Context, mu state.Mutable, statePrefix byte, overrides map[Param]*Override) error {
+	size := consts.Uint8Len + len(overrides)*overrideSize
+	p := codec.NewWriter(size, size)
+	p.PackByte(uint8(len(overrides)))
+	// Params are encoded in order, so every node writes the same value
+	for param := Param(0); int(param) < len(params); param++ {
+		o, ok := overrides[param]
+		if !ok {
+			continue
+		}
+		p.PackByte(uint8(param))
+		p.PackUint64(o.Value)
+		p.PackInt64(o.Activation)
+		p.PackUint64(o.Previous)
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, OverridesKey(statePrefix), p.Bytes())
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package light
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// Block is a block whose ID has been computed from its bytes. Once any
+// block is trusted (either because its ID was obtained from a trusted
+// source or attested to by a trusted validator set), the blocks around it
+// can be verified by following parent links.
+//
+// Blocks do not commit to the results of their transactions. To prove
+// the outcome of a transaction, verify its inclusion with [Transaction]
+// and then verify the state it modified against the StateRoot of a
+// descendant block (the StateRoot of a block is the root of the state
+// after executing its parent).
+type Block struct {
+	*chain.StatefulBlock
+
+	id ids.ID
+}
+
+// ParseBlock parses [raw] and computes its ID. Parsing does not
+// imply the block is trusted.
+func ParseBlock(raw []byte, parser chain.Parser) (*Block, error) {
+	blk, err := chain.UnmarshalBlock(raw, parser)
+	if err != nil {
+		return nil, err
+	}
+	return &Block{StatefulBlock: blk, id: utils.ToID(raw)}, nil
+}
+
+func (b *Block) ID() ids.ID {
+	return b.id
+}
+
+// VerifyChild parses [raw] and ensures it is the child of [b].
+func (b *Block) VerifyChild(raw []byte, parser chain.Parser) (*Block, error) {
+	child, err := ParseBlock(raw, parser)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyLink(b, child); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
+
+// VerifyParent parses [raw] and ensures it is the parent of [b].
+func (b *Block) VerifyParent(raw []byte, parser chain.Parser) (*Block, error) {
+	parent, err := ParseBlock(raw, parser)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyLink(parent, b); err != nil {
+		return nil, err
+	}
+	return parent, nil
+}
+
+// VerifyDescendants verifies that [raw] is a sequence of blocks
+// where the first block is the child of [b].
+func (b *Block) VerifyDescendants(raw [][]byte, parser chain.Parser) ([]*Block, error) {
+	var (
+		blks   = make([]*Block, 0, len(raw))
+		parent = b
+	)
+	for i, r := range raw {
+		child, err := parent.VerifyChild(r, parser)
+		if err != nil {
+			return nil, fmt.Errorf("%w: block %d", err, i)
+		}
+		blks = append(blks, child)
+		parent = child
+	}
+	return blks, nil
+}
+
+// Transaction returns the transaction with [txID] in [b].
+func (b *Block) Transaction(txID ids.ID) (*chain.Transaction, error) {
+	for _, tx := range b.Txs {
+		if tx.ID() == txID {
+			return tx, nil
+		}
+	}
+	return nil, ErrMissingTransaction
+}
+
+func verifyLink(parent *Block, child *Block) error {
+	if child.Prnt != parent.id {
+		return fmt.Errorf("%w: expected=%s found=%s", ErrUnexpectedParent, parent.id, child.Prnt)
+	}
+	if child.Hght != parent.Hght+1 {
+		return fmt.Errorf("%w: expected=%d found=%d", ErrUnexpectedHeight, parent.Hght+1, child.Hght)
+	}
+	if child.Tmstmp < parent.Tmstmp {
+		return ErrTimestampDecreased
+	}
+	return nil
+}
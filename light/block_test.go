@@ -0,0 +1,72 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package light
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+type testParser struct{}
+
+func (*testParser) Rules(int64) chain.Rules { return nil }
+
+func (*testParser) Registry() (chain.ActionRegistry, chain.AuthRegistry) { return nil, nil }
+
+func newTestBlock(t *testing.T, parent ids.ID, height uint64, timestamp int64) []byte {
+	blk := &chain.StatefulBlock{
+		Prnt:      parent,
+		Hght:      height,
+		Tmstmp:    timestamp,
+		Txs:       []*chain.Transaction{},
+		StateRoot: ids.GenerateTestID(),
+	}
+	raw, err := blk.Marshal()
+	require.NoError(t, err)
+	return raw
+}
+
+func TestVerifyDescendants(t *testing.T) {
+	var (
+		require = require.New(t)
+		parser  = &testParser{}
+	)
+	trustedRaw := newTestBlock(t, ids.GenerateTestID(), 10, 100)
+	trusted, err := ParseBlock(trustedRaw, parser)
+	require.NoError(err)
+
+	// Build a chain of valid descendants
+	raw := [][]byte{}
+	parent := trusted.ID()
+	for i := 0; i < 5; i++ {
+		r := newTestBlock(t, parent, uint64(11+i), int64(101+i))
+		blk, err := ParseBlock(r, parser)
+		require.NoError(err)
+		raw = append(raw, r)
+		parent = blk.ID()
+	}
+	blks, err := trusted.VerifyDescendants(raw, parser)
+	require.NoError(err)
+	require.Len(blks, 5)
+	require.Equal(parent, blks[4].ID())
+
+	// Verify backwards
+	p, err := blks[0].VerifyParent(trustedRaw, parser)
+	require.NoError(err)
+	require.Equal(trusted.ID(), p.ID())
+	_, err = blks[1].VerifyParent(trustedRaw, parser)
+	require.ErrorIs(err, ErrUnexpectedParent)
+
+	// Invalid links
+	_, err = trusted.VerifyChild(newTestBlock(t, ids.GenerateTestID(), 11, 101), parser)
+	require.ErrorIs(err, ErrUnexpectedParent)
+	_, err = trusted.VerifyChild(newTestBlock(t, trusted.ID(), 12, 101), parser)
+	require.ErrorIs(err, ErrUnexpectedHeight)
+	_, err = trusted.VerifyChild(newTestBlock(t, trusted.ID(), 11, 99), parser)
+	require.ErrorIs(err, ErrTimestampDecreased)
+}
@@ -0,0 +1,17 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package light
+
+import "errors"
+
+var (
+	ErrUnexpectedParent   = errors.New("unexpected parent")
+	ErrUnexpectedHeight   = errors.New("unexpected height")
+	ErrTimestampDecreased = errors.New("timestamp decreased")
+	ErrMissingTransaction = errors.New("transaction not in block")
+	ErrUnexpectedKey      = errors.New("unexpected key")
+	ErrInvalidBranch      = errors.New("invalid branch factor")
+	ErrWrongSourceChain   = errors.New("wrong source chain")
+	ErrNoValidators       = errors.New("no validators")
+)
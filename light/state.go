@@ -0,0 +1,60 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package light
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+)
+
+// StateVerifier verifies proofs generated by the state of a chain
+// using [branchFactor] (returned by the Genesis of the VM).
+type StateVerifier struct {
+	tokenSize int
+}
+
+func NewStateVerifier(branchFactor merkledb.BranchFactor) (*StateVerifier, error) {
+	tokenSize, ok := merkledb.BranchFactorToTokenSize[branchFactor]
+	if !ok {
+		return nil, ErrInvalidBranch
+	}
+	return &StateVerifier{tokenSize: tokenSize}, nil
+}
+
+// VerifyValue verifies that [proof] proves the value of [key] against
+// [root] and returns it. If [key] is not in state, [maybe.Nothing] is
+// returned.
+func (s *StateVerifier) VerifyValue(
+	ctx context.Context,
+	root ids.ID,
+	key []byte,
+	proof *merkledb.Proof,
+) (maybe.Maybe[[]byte], error) {
+	if !bytes.Equal(proof.Key.Bytes(), key) {
+		return maybe.Nothing[[]byte](), ErrUnexpectedKey
+	}
+	if err := proof.Verify(ctx, root, s.tokenSize, merkledb.DefaultHasher); err != nil {
+		return maybe.Nothing[[]byte](), err
+	}
+	return proof.Value, nil
+}
+
+// VerifyRange verifies that [proof] proves all key-values in [start, end]
+// (up to the number of key-values included in [proof]) against [root].
+func (s *StateVerifier) VerifyRange(
+	ctx context.Context,
+	root ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	proof *merkledb.RangeProof,
+) ([]merkledb.KeyValue, error) {
+	if err := proof.Verify(ctx, start, end, root, s.tokenSize, merkledb.DefaultHasher); err != nil {
+		return nil, err
+	}
+	return proof.KeyValues, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package light
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateVerifier(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctx     = context.TODO()
+	)
+	db, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		BranchFactor:              merkledb.BranchFactor16,
+		RootGenConcurrency:        1,
+		HistoryLength:             1,
+		ValueNodeCacheSize:        1024,
+		IntermediateNodeCacheSize: 1024,
+		Reg:                       prometheus.NewRegistry(),
+		Tracer:                    trace.Noop,
+	})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+	require.NoError(db.Put([]byte("b"), []byte("2")))
+	root, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	s, err := NewStateVerifier(merkledb.BranchFactor16)
+	require.NoError(err)
+
+	// Inclusion
+	proof, err := db.GetProof(ctx, []byte("a"))
+	require.NoError(err)
+	v, err := s.VerifyValue(ctx, root, []byte("a"), proof)
+	require.NoError(err)
+	require.Equal([]byte("1"), v.Value())
+	_, err = s.VerifyValue(ctx, root, []byte("b"), proof)
+	require.ErrorIs(err, ErrUnexpectedKey)
+	_, err = s.VerifyValue(ctx, ids.GenerateTestID(), []byte("a"), proof)
+	require.ErrorIs(err, merkledb.ErrInvalidProof)
+
+	// Exclusion
+	proof, err = db.GetProof(ctx, []byte("c"))
+	require.NoError(err)
+	v, err = s.VerifyValue(ctx, root, []byte("c"), proof)
+	require.NoError(err)
+	require.True(v.IsNothing())
+
+	// Range
+	rproof, err := db.GetRangeProof(ctx, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	kvs, err := s.VerifyRange(ctx, root, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), rproof)
+	require.NoError(err)
+	require.Len(kvs, 2)
+
+	_, err = NewStateVerifier(merkledb.BranchFactor(3))
+	require.ErrorIs(err, ErrInvalidBranch)
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package light
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+// ValidatorSet is a trusted set of validators for a chain (usually
+// obtained from a trusted P-Chain height).
+type ValidatorSet struct {
+	networkID uint32
+	chainID   ids.ID
+
+	validators  []*warp.Validator
+	totalWeight uint64
+}
+
+// NewValidatorSet creates a [ValidatorSet] for [chainID] from the
+// validators of its subnet (as returned by the P-Chain).
+func NewValidatorSet(
+	networkID uint32,
+	chainID ids.ID,
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+) (*ValidatorSet, error) {
+	canonical, totalWeight, err := warp.FlattenValidatorSet(vdrs)
+	if err != nil {
+		return nil, err
+	}
+	if len(canonical) == 0 {
+		return nil, ErrNoValidators
+	}
+	return &ValidatorSet{
+		networkID:   networkID,
+		chainID:     chainID,
+		validators:  canonical,
+		totalWeight: totalWeight,
+	}, nil
+}
+
+// Verify ensures [msg] was sent by the chain of [v] and is signed by at
+// least [quorumNum]/[quorumDen] of its stake.
+func (v *ValidatorSet) Verify(msg *warp.Message, quorumNum uint64, quorumDen uint64) error {
+	if msg.NetworkID != v.networkID {
+		return warp.ErrWrongNetworkID
+	}
+	if msg.SourceChainID != v.chainID {
+		return fmt.Errorf("%w: expected=%s found=%s", ErrWrongSourceChain, v.chainID, msg.SourceChainID)
+	}
+	sig, ok := msg.Signature.(*warp.BitSetSignature)
+	if !ok {
+		return warp.ErrInvalidSignature
+	}
+
+	// Ensure the signers have sufficient weight
+	signerIndices := set.BitsFromBytes(sig.Signers)
+	if len(signerIndices.Bytes()) != len(sig.Signers) {
+		return warp.ErrInvalidBitSet
+	}
+	signers, err := warp.FilterValidators(signerIndices, v.validators)
+	if err != nil {
+		return err
+	}
+	sigWeight, err := warp.SumWeight(signers)
+	if err != nil {
+		return err
+	}
+	if err := warp.VerifyWeight(sigWeight, v.totalWeight, quorumNum, quorumDen); err != nil {
+		return err
+	}
+
+	// Verify the aggregate signature
+	aggSig, err := bls.SignatureFromBytes(sig.Signature[:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", warp.ErrParseSignature, err)
+	}
+	aggPubKey, err := warp.AggregatePublicKeys(signers)
+	if err != nil {
+		return err
+	}
+	if !bls.Verify(aggPubKey, aggSig, msg.UnsignedMessage.Bytes()) {
+		return warp.ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyBlock verifies that [msg] attests to the ID of an accepted block
+// and returns it. Any [Block] with this ID can then be trusted.
+func (v *ValidatorSet) VerifyBlock(msg *warp.Message, quorumNum uint64, quorumDen uint64) (ids.ID, error) {
+	if err := v.Verify(msg, quorumNum, quorumDen); err != nil {
+		return ids.Empty, err
+	}
+	hash, err := payload.ParseHash(msg.Payload)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return hash.Hash, nil
+}
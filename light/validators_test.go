@@ -0,0 +1,227 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package light
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/stretchr/testify/require"
+)
+
+const testNetworkID = 1
+
+// testValidators are the validators of a [ValidatorSet] (in canonical order)
+// and their secret keys.
+type testValidators struct {
+	set *ValidatorSet
+	sks []*bls.SecretKey
+}
+
+func newTestValidators(t *testing.T, chainID ids.ID, weights ...uint64) *testValidators {
+	require := require.New(t)
+
+	var (
+		vdrs = map[ids.NodeID]*validators.GetValidatorOutput{}
+		sks  = []*bls.SecretKey{}
+	)
+	for _, weight := range weights {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		nodeID := ids.GenerateTestNodeID()
+		vdrs[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    weight,
+		}
+		sks = append(sks, sk)
+	}
+	vdrSet, err := NewValidatorSet(testNetworkID, chainID, vdrs)
+	require.NoError(err)
+
+	// Order the secret keys like the canonical validators
+	canonical := make([]*bls.SecretKey, len(vdrSet.validators))
+	for i, vdr := range vdrSet.validators {
+		for _, sk := range sks {
+			if bytes.Equal(bls.PublicKeyToUncompressedBytes(bls.PublicFromSecretKey(sk)), vdr.PublicKeyBytes) {
+				canonical[i] = sk
+			}
+		}
+	}
+	return &testValidators{set: vdrSet, sks: canonical}
+}
+
+// sign returns [unsigned] signed by the validators at [signers] (which may
+// include indices outside of the set, signed with a random key).
+func (v *testValidators) sign(t *testing.T, unsigned *warp.UnsignedMessage, signers ...int) *warp.Message {
+	require := require.New(t)
+
+	var (
+		bits = set.NewBits()
+		sigs = []*bls.Signature{}
+	)
+	for _, i := range signers {
+		bits.Add(i)
+		sk := (*bls.SecretKey)(nil)
+		if i < len(v.sks) {
+			sk = v.sks[i]
+		} else {
+			var err error
+			sk, err = bls.NewSecretKey()
+			require.NoError(err)
+		}
+		sigs = append(sigs, bls.Sign(sk, unsigned.Bytes()))
+	}
+	aggSig, err := bls.AggregateSignatures(sigs)
+	require.NoError(err)
+	sig := &warp.BitSetSignature{Signers: bits.Bytes()}
+	copy(sig.Signature[:], bls.SignatureToBytes(aggSig))
+	msg, err := warp.NewMessage(unsigned, sig)
+	require.NoError(err)
+	return msg
+}
+
+func newTestBlockMessage(t *testing.T, chainID ids.ID, blkID ids.ID) *warp.UnsignedMessage {
+	require := require.New(t)
+
+	hash, err := payload.NewHash(blkID)
+	require.NoError(err)
+	unsigned, err := warp.NewUnsignedMessage(testNetworkID, chainID, hash.Bytes())
+	require.NoError(err)
+	return unsigned
+}
+
+func TestNewValidatorSet(t *testing.T) {
+	_, err := NewValidatorSet(testNetworkID, ids.GenerateTestID(), nil)
+	require.ErrorIs(t, err, ErrNoValidators)
+}
+
+func TestValidatorSetVerify(t *testing.T) {
+	var (
+		chainID = ids.GenerateTestID()
+		blkID   = ids.GenerateTestID()
+		vdrs    = newTestValidators(t, chainID, 1, 1, 1)
+		msg     = newTestBlockMessage(t, chainID, blkID)
+	)
+	tests := []struct {
+		name string
+		msg  *warp.Message
+		err  error
+	}{
+		{
+			name: "quorum",
+			msg:  vdrs.sign(t, msg, 0, 1),
+		},
+		{
+			name: "all validators",
+			msg:  vdrs.sign(t, msg, 0, 1, 2),
+		},
+		{
+			name: "insufficient weight",
+			msg:  vdrs.sign(t, msg, 2),
+			err:  warp.ErrInsufficientWeight,
+		},
+		{
+			name: "no signers",
+			msg: func() *warp.Message {
+				msg, err := warp.NewMessage(msg, &warp.BitSetSignature{})
+				require.NoError(t, err)
+				return msg
+			}(),
+			err: warp.ErrInsufficientWeight,
+		},
+		{
+			name: "unknown signer",
+			msg:  vdrs.sign(t, msg, 0, 1, 3),
+			err:  warp.ErrUnknownValidator,
+		},
+		{
+			name: "bad signature",
+			msg: func() *warp.Message {
+				// Claims to be signed by validator 1 (but isn't)
+				signed := vdrs.sign(t, msg, 0, 2)
+				sig := signed.Signature.(*warp.BitSetSignature)
+				bits := set.BitsFromBytes(sig.Signers)
+				bits.Add(1)
+				sig.Signers = bits.Bytes()
+				return signed
+			}(),
+			err: warp.ErrInvalidSignature,
+		},
+		{
+			name: "signature of another message",
+			msg: func() *warp.Message {
+				signed := vdrs.sign(t, newTestBlockMessage(t, chainID, ids.GenerateTestID()), 0, 1)
+				signed, err := warp.NewMessage(msg, signed.Signature)
+				require.NoError(t, err)
+				return signed
+			}(),
+			err: warp.ErrInvalidSignature,
+		},
+		{
+			name: "wrong source chain",
+			msg:  vdrs.sign(t, newTestBlockMessage(t, ids.GenerateTestID(), blkID), 0, 1),
+			err:  ErrWrongSourceChain,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.ErrorIs(vdrs.set.Verify(tt.msg, 2, 3), tt.err)
+			verified, err := vdrs.set.VerifyBlock(tt.msg, 2, 3)
+			require.ErrorIs(err, tt.err)
+			if tt.err == nil {
+				require.Equal(blkID, verified)
+			}
+		})
+	}
+}
+
+func TestValidatorSetVerifyWeight(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	vdrs := newTestValidators(t, chainID, 1, 1, 10)
+	msg := newTestBlockMessage(t, chainID, ids.GenerateTestID())
+
+	// Quorum is computed from stake (not the number of signers)
+	heavy := 0
+	for i, vdr := range vdrs.set.validators {
+		if vdr.Weight == 10 {
+			heavy = i
+		}
+	}
+	require.NoError(vdrs.set.Verify(vdrs.sign(t, msg, heavy), 2, 3))
+	light := []int{}
+	for i := range vdrs.set.validators {
+		if i != heavy {
+			light = append(light, i)
+		}
+	}
+	require.ErrorIs(vdrs.set.Verify(vdrs.sign(t, msg, light...), 2, 3), warp.ErrInsufficientWeight)
+}
+
+func TestValidatorSetVerifyBlockPayload(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	vdrs := newTestValidators(t, chainID, 1)
+
+	// Messages that don't contain a hash can't attest to a block
+	addressed, err := payload.NewAddressedCall(nil, []byte("payload"))
+	require.NoError(err)
+	unsigned, err := warp.NewUnsignedMessage(testNetworkID, chainID, addressed.Bytes())
+	require.NoError(err)
+	msg := vdrs.sign(t, unsigned, 0)
+	require.NoError(vdrs.set.Verify(msg, 1, 1))
+	_, err = vdrs.set.VerifyBlock(msg, 1, 1)
+	require.Error(err) // wrong payload type
+}
@@ -44,9 +44,13 @@ type Mempool[T Item] struct {
 	// [Sponsor]
 	owned map[codec.Address]int
 
+	// streamLock is held from [StartStreaming] until [FinishStreaming], so
+	// only one stream is active at a time. It must always be acquired before
+	// [mu] (never while holding it).
+	//
 	// streamedItems have been removed from the mempool during streaming
 	// and should not be re-added by calls to [Add].
-	streamLock        sync.Mutex // should never be needed
+	streamLock        sync.Mutex
 	streamedItems     set.Set[ids.ID]
 	nextStream        []T
 	nextStreamFetched bool
@@ -97,6 +101,21 @@ func (m *Mempool[T]) Has(ctx context.Context, itemID ids.ID) bool {
 	return m.eh.Has(itemID)
 }
 
+// Get returns the item [itemID] if it is in [m]
+func (m *Mempool[T]) Get(ctx context.Context, itemID ids.ID) (T, bool) {
+	_, span := m.tracer.Start(ctx, "Mempool.Get")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	elem, ok := m.eh.Get(itemID)
+	if !ok {
+		return *new(T), false
+	}
+	return elem.Value(), true
+}
+
 // Add pushes all new items from [items] to m. Does not add a item if
 // the item sponsor is not exempt and their items in the mempool exceed m.maxSponsorSize.
 // If the size of m exceeds m.maxSize, Add pops the lowest value item
@@ -207,6 +226,44 @@ func (m *Mempool[T]) Remove(ctx context.Context, items []T) {
 	}
 }
 
+// Prioritize moves the items of [itemIDs] that are in m to the front of m
+// (in the order of [itemIDs]), so they are the next items popped or streamed.
+// It returns the number of items moved.
+func (m *Mempool[T]) Prioritize(ctx context.Context, itemIDs []ids.ID) int {
+	_, span := m.tracer.Start(ctx, "Mempool.Prioritize")
+	defer span.End()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	moved := 0
+	for i := len(itemIDs) - 1; i >= 0; i-- {
+		elem, ok := m.eh.Remove(itemIDs[i])
+		if !ok {
+			continue
+		}
+		item := m.queue.Remove(elem)
+		m.eh.Add(m.queue.PushFront(item))
+		moved++
+	}
+	return moved
+}
+
+// Items returns all items in m (from highest to lowest valued).
+func (m *Mempool[T]) Items(ctx context.Context) []T {
+	_, span := m.tracer.Start(ctx, "Mempool.Items")
+	defer span.End()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	items := make([]T, 0, m.queue.Size())
+	for elem := m.queue.First(); elem != nil; elem = elem.Next() {
+		items = append(items, elem.Value())
+	}
+	return items
+}
+
 // Len returns the number of items in m.
 func (m *Mempool[T]) Len(ctx context.Context) int {
 	_, span := m.tracer.Start(ctx, "Mempool.Len")
@@ -290,10 +347,13 @@ func (m *Mempool[T]) Top(
 // best txs to build without holding the lock during the duration of the build
 // process. Streaming in batches allows for various state prefetching operations.
 func (m *Mempool[T]) StartStreaming(_ context.Context) {
+	// Waiting for [streamLock] while holding [mu] would prevent the active
+	// stream from calling [FinishStreaming].
+	m.streamLock.Lock()
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.streamLock.Lock()
 	m.streamedItems = set.NewSet[ids.ID](maxPrealloc)
 }
 
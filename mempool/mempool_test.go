@@ -6,6 +6,7 @@ package mempool
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/stretchr/testify/require"
@@ -159,6 +160,26 @@ func TestMempoolRemoveTxs(t *testing.T) {
 	require.Equal(0, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
 
+func TestMempoolGet(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := New[*TestItem](tracer, 3, 20)
+	item := GenerateTestItem(testSponsor, 10)
+	txm.Add(ctx, []*TestItem{item})
+	got, ok := txm.Get(ctx, item.ID())
+	require.True(ok)
+	require.Equal(item, got)
+
+	// Items that aren't in the mempool (or were removed) are not found
+	_, ok = txm.Get(ctx, GenerateTestItem(testSponsor, 10).ID())
+	require.False(ok)
+	txm.Remove(ctx, []*TestItem{item})
+	_, ok = txm.Get(ctx, item.ID())
+	require.False(ok)
+}
+
 func TestMempoolSetMinTimestamp(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -188,3 +209,66 @@ func TestMempoolSetMinTimestamp(t *testing.T) {
 	// Mempool has same length
 	require.Equal(5, txm.Len(ctx), "Mempool has incorrect number of txs.")
 }
+
+func TestMempoolConcurrentStreaming(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	txm := New[*TestItem](tracer, 3, 16)
+	txm.Add(ctx, []*TestItem{GenerateTestItem(testSponsor, 100), GenerateTestItem(testSponsor, 200)})
+
+	txm.StartStreaming(ctx)
+	require.Len(txm.Stream(ctx, 1), 1)
+
+	// A second stream waits for the first one to finish (without preventing
+	// it from finishing)
+	started := make(chan struct{})
+	go func() {
+		txm.StartStreaming(ctx)
+		close(started)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	finished := make(chan struct{})
+	go func() {
+		txm.FinishStreaming(ctx, nil)
+		close(finished)
+	}()
+	for _, ch := range []chan struct{}{finished, started} {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			require.FailNow("streams deadlocked")
+		}
+	}
+	require.Len(txm.Stream(ctx, 1), 1)
+	require.Zero(txm.FinishStreaming(ctx, nil))
+}
+
+func TestMempoolPrioritize(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+
+	txm := New[*TestItem](tracer, 20, 20)
+	items := make([]*TestItem, 5)
+	for i := range items {
+		items[i] = GenerateTestItem(testSponsor, int64(i))
+	}
+	txm.Add(ctx, items)
+
+	// Unknown items are skipped
+	moved := txm.Prioritize(ctx, []ids.ID{items[3].ID(), ids.GenerateTestID(), items[1].ID()})
+	require.Equal(2, moved)
+	require.Equal([]*TestItem{items[3], items[1], items[0], items[2], items[4]}, txm.Items(ctx))
+	require.Equal(5, txm.Len(ctx))
+	require.Equal(10, txm.Size(ctx))
+
+	// Prioritized items can still be removed
+	txm.Remove(ctx, []*TestItem{items[3]})
+	next, ok := txm.PopNext(ctx)
+	require.True(ok)
+	require.Equal(items[1], next)
+	require.Len(txm.SetMinTimestamp(ctx, 3), 2)
+	require.Equal([]*TestItem{items[4]}, txm.Items(ctx))
+}
@@ -31,6 +31,13 @@ type metrics struct {
 	zombieTableCount   prometheus.Gauge
 	obsoleteWALSize    prometheus.Gauge
 	obsoleteWALCount   prometheus.Gauge
+
+	compactionDebt    prometheus.Gauge
+	readAmplification prometheus.Gauge
+	memTableSize      prometheus.Gauge
+	blockCacheSize    prometheus.Gauge
+	blockCacheHits    prometheus.Gauge
+	blockCacheMisses  prometheus.Gauge
 }
 
 func newMetrics() (*prometheus.Registry, *metrics, error) {
@@ -104,6 +111,36 @@ func newMetrics() (*prometheus.Registry, *metrics, error) {
 			Name:      "obsolete_wal_count",
 			Help:      "number of WAL files no longer needed by the db",
 		}),
+		compactionDebt: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pebble",
+			Name:      "compaction_debt",
+			Help:      "estimated number of bytes that need to be compacted for the db to reach a stable state",
+		}),
+		readAmplification: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pebble",
+			Name:      "read_amplification",
+			Help:      "number of sublevels that may need to be read for a point lookup",
+		}),
+		memTableSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pebble",
+			Name:      "memtable_size",
+			Help:      "number of bytes allocated by memtables and large batches",
+		}),
+		blockCacheSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pebble",
+			Name:      "block_cache_size",
+			Help:      "number of bytes in use by the block cache",
+		}),
+		blockCacheHits: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pebble",
+			Name:      "block_cache_hits",
+			Help:      "number of block cache hits",
+		}),
+		blockCacheMisses: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pebble",
+			Name:      "block_cache_misses",
+			Help:      "number of block cache misses",
+		}),
 	}
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -117,6 +154,12 @@ func newMetrics() (*prometheus.Registry, *metrics, error) {
 		r.Register(m.zombieTableCount),
 		r.Register(m.obsoleteWALSize),
 		r.Register(m.obsoleteWALCount),
+		r.Register(m.compactionDebt),
+		r.Register(m.readAmplification),
+		r.Register(m.memTableSize),
+		r.Register(m.blockCacheSize),
+		r.Register(m.blockCacheHits),
+		r.Register(m.blockCacheMisses),
 	)
 	return r, m, errs.Err
 }
@@ -158,6 +201,12 @@ func (db *Database) collectMetrics() {
 			db.metrics.zombieTableCount.Set(float64(metrics.Table.ZombieCount))
 			db.metrics.obsoleteWALSize.Set(float64(metrics.WAL.ObsoletePhysicalSize))
 			db.metrics.obsoleteWALCount.Set(float64(metrics.WAL.ObsoleteFiles))
+			db.metrics.compactionDebt.Set(float64(metrics.Compact.EstimatedDebt))
+			db.metrics.readAmplification.Set(float64(metrics.ReadAmp()))
+			db.metrics.memTableSize.Set(float64(metrics.MemTable.Size))
+			db.metrics.blockCacheSize.Set(float64(metrics.BlockCache.Size))
+			db.metrics.blockCacheHits.Set(float64(metrics.BlockCache.Hits))
+			db.metrics.blockCacheMisses.Set(float64(metrics.BlockCache.Misses))
 		case <-db.closing:
 			return
 		}
@@ -38,12 +38,13 @@ type Database struct {
 }
 
 type Config struct {
-	CacheSize                   int // B
-	BytesPerSync                int // B
-	MemTableStopWritesThreshold int // num tables
-	MemTableSize                int // B
-	MaxOpenFiles                int
-	ConcurrentCompactions       func() int
+	CacheSize                   int `json:"cacheSize"`                   // B
+	BytesPerSync                int `json:"bytesPerSync"`                // B
+	MemTableStopWritesThreshold int `json:"memTableStopWritesThreshold"` // num tables
+	MemTableSize                int `json:"memTableSize"`                // B
+	MaxOpenFiles                int `json:"maxOpenFiles"`
+	ConcurrentCompactions       int `json:"concurrentCompactions"`
+	BloomFilterBits             int `json:"bloomFilterBits"` // bits per key (0 disables bloom filters)
 }
 
 func NewDefaultConfig() Config {
@@ -53,7 +54,8 @@ func NewDefaultConfig() Config {
 		MemTableStopWritesThreshold: 8,
 		MemTableSize:                16 * 1024 * 1024,
 		MaxOpenFiles:                4_096,
-		ConcurrentCompactions:       func() int { return 1 },
+		ConcurrentCompactions:       1,
+		BloomFilterBits:             10,
 	}
 }
 
@@ -67,7 +69,7 @@ func New(file string, cfg Config) (database.Database, *prometheus.Registry, erro
 		MemTableStopWritesThreshold: cfg.MemTableStopWritesThreshold,
 		MemTableSize:                uint64(cfg.MemTableSize),
 		MaxOpenFiles:                cfg.MaxOpenFiles,
-		MaxConcurrentCompactions:    cfg.ConcurrentCompactions, // TODO: may want to tweak this?
+		MaxConcurrentCompactions:    func() int { return cfg.ConcurrentCompactions },
 		Levels:                      make([]pebble.LevelOptions, 7),
 		// TODO: add support for adding a custom logger
 
@@ -84,8 +86,10 @@ func New(file string, cfg Config) (database.Database, *prometheus.Registry, erro
 		l := &opts.Levels[i]
 		l.BlockSize = 64 * 1024
 		l.IndexBlockSize = 256 * 1024
-		l.FilterPolicy = bloom.FilterPolicy(10)
-		l.FilterType = pebble.TableFilter
+		if cfg.BloomFilterBits > 0 {
+			l.FilterPolicy = bloom.FilterPolicy(cfg.BloomFilterBits)
+			l.FilterType = pebble.TableFilter
+		}
 		if i > 0 {
 			l.TargetFileSize = opts.Levels[i-1].TargetFileSize * 2
 		}
@@ -0,0 +1,314 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+const testDriverName = "hypersdk-postgres-test"
+
+var errTestWrite = errors.New("write failed")
+
+type testExec struct {
+	query string
+	args  []driver.Value
+}
+
+// testDriver records the statements of committed transactions.
+type testDriver struct {
+	l         sync.Mutex
+	committed []testExec
+	cursor    *int64
+	fail      bool
+}
+
+var testDB = &testDriver{}
+
+func init() {
+	sql.Register(testDriverName, testDB)
+}
+
+func (d *testDriver) reset() {
+	d.l.Lock()
+	defer d.l.Unlock()
+	d.committed = nil
+	d.cursor = nil
+	d.fail = false
+}
+
+func (d *testDriver) setFail(fail bool) {
+	d.l.Lock()
+	defer d.l.Unlock()
+	d.fail = fail
+}
+
+func (d *testDriver) execs(query string) []testExec {
+	d.l.Lock()
+	defer d.l.Unlock()
+	execs := []testExec{}
+	for _, exec := range d.committed {
+		if exec.query == query {
+			execs = append(execs, exec)
+		}
+	}
+	return execs
+}
+
+func (d *testDriver) Open(string) (driver.Conn, error) {
+	return &testConn{d: d}, nil
+}
+
+type testConn struct {
+	d       *testDriver
+	pending []testExec
+	inTx    bool
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{c: c, query: query}, nil
+}
+
+func (*testConn) Close() error { return nil }
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	c.inTx = true
+	c.pending = nil
+	return c, nil
+}
+
+func (c *testConn) Commit() error {
+	c.d.l.Lock()
+	defer c.d.l.Unlock()
+	for _, exec := range c.pending {
+		if exec.query == updateCursor {
+			height := exec.args[0].(int64)
+			c.d.cursor = &height
+		}
+	}
+	c.d.committed = append(c.d.committed, c.pending...)
+	c.inTx = false
+	c.pending = nil
+	return nil
+}
+
+func (c *testConn) Rollback() error {
+	c.inTx = false
+	c.pending = nil
+	return nil
+}
+
+type testStmt struct {
+	c     *testConn
+	query string
+}
+
+func (*testStmt) Close() error { return nil }
+
+func (*testStmt) NumInput() int { return -1 }
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.d.l.Lock()
+	fail := s.c.d.fail
+	s.c.d.l.Unlock()
+	if fail && s.query == insertAction {
+		return nil, errTestWrite
+	}
+	exec := testExec{query: s.query, args: args}
+	if !s.c.inTx {
+		s.c.pending = []testExec{exec}
+		return driver.RowsAffected(1), s.c.Commit()
+	}
+	s.c.pending = append(s.c.pending, exec)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *testStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.c.d.l.Lock()
+	defer s.c.d.l.Unlock()
+	rows := &testRows{}
+	if s.query == selectCursor && s.c.d.cursor != nil {
+		rows.values = []int64{*s.c.d.cursor}
+	}
+	return rows, nil
+}
+
+type testRows struct {
+	values []int64
+}
+
+func (*testRows) Columns() []string { return []string{"height"} }
+
+func (*testRows) Close() error { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+	return nil
+}
+
+type testAction struct {
+	chain.Action `json:"-"`
+
+	Value uint64 `json:"value"`
+}
+
+func (*testAction) GetTypeID() uint8 { return 3 }
+
+func (*testAction) Size() int { return consts.Uint64Len }
+
+func (a *testAction) Marshal(p *codec.Packer) { p.PackUint64(a.Value) }
+
+func unmarshalTestAction(p *codec.Packer) (chain.Action, error) {
+	return &testAction{Value: p.UnpackUint64(false)}, p.Err()
+}
+
+// testAuth authorizes transactions of [actor] without a signature.
+type testAuth struct {
+	chain.Auth
+
+	actor codec.Address
+}
+
+func (*testAuth) GetTypeID() uint8 { return 0 }
+
+func (*testAuth) Size() int { return codec.AddressLen }
+
+func (a *testAuth) Marshal(p *codec.Packer) { p.PackAddress(a.actor) }
+
+func (a *testAuth) Actor() codec.Address { return a.actor }
+
+func (a *testAuth) Sponsor() codec.Address { return a.actor }
+
+func unmarshalTestAuth(p *codec.Packer) (chain.Auth, error) {
+	a := &testAuth{}
+	p.UnpackAddress(&a.actor)
+	return a, p.Err()
+}
+
+type testAuthFactory struct {
+	chain.AuthFactory
+
+	actor codec.Address
+}
+
+func (f *testAuthFactory) Sign([]byte) (chain.Auth, error) {
+	return &testAuth{actor: f.actor}, nil
+}
+
+func newTestTx(t *testing.T) *chain.Transaction {
+	require := require.New(t)
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+
+	tx := chain.NewTx(
+		&chain.Base{Timestamp: 1_000, ChainID: ids.GenerateTestID(), MaxFee: 1 << 63},
+		[]chain.Action{&testAction{Value: 10}, &testAction{Value: 20}},
+	)
+	factory := &testAuthFactory{actor: codec.CreateAddress(0, ids.GenerateTestID())}
+	tx, err := tx.Sign(factory, actionRegistry, authRegistry)
+	require.NoError(err)
+	return tx
+}
+
+func TestWriteBlock(t *testing.T) {
+	require := require.New(t)
+
+	testDB.reset()
+	cfg := NewDefaultConfig()
+	cfg.Driver = testDriverName
+	_, err := Open(cfg)
+	require.ErrorIs(err, ErrNoDSN)
+	cfg.DSN = "test"
+	db, err := Open(cfg)
+	require.NoError(err)
+	defer db.Close()
+
+	ctx := context.Background()
+	require.NoError(db.Migrate(ctx))
+	require.Len(testDB.execs(Schema), 1)
+	_, ok, err := db.Cursor(ctx)
+	require.NoError(err)
+	require.False(ok)
+
+	tx := newTestTx(t)
+	blk := &chain.StatefulBlock{
+		Prnt:      ids.GenerateTestID(),
+		Tmstmp:    2_000,
+		Hght:      5,
+		Txs:       []*chain.Transaction{tx},
+		StateRoot: ids.GenerateTestID(),
+	}
+	results := []*chain.Result{{
+		Success: true,
+		Outputs: [][][]byte{{[]byte("output")}, nil},
+		Units:   fees.Dimensions{1, 2, 3, 4, 5},
+		Fee:     15,
+	}}
+	prices := fees.Dimensions{6, 7, 8, 9, 10}
+
+	// Blocks are not partially written
+	testDB.setFail(true)
+	require.ErrorIs(db.WriteBlock(ctx, blk, results, prices), errTestWrite)
+	require.Empty(testDB.execs(insertBlock))
+	_, ok, err = db.Cursor(ctx)
+	require.NoError(err)
+	require.False(ok)
+
+	testDB.setFail(false)
+	require.NoError(db.WriteBlock(ctx, blk, results, prices))
+	cursor, ok, err := db.Cursor(ctx)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(uint64(5), cursor)
+
+	blocks := testDB.execs(insertBlock)
+	require.Len(blocks, 1)
+	blkID, err := blk.ID()
+	require.NoError(err)
+	require.Equal(blkID[:], blocks[0].args[1])
+	require.Equal("{6,7,8,9,10}", blocks[0].args[6])
+
+	txs := testDB.execs(insertTx)
+	require.Len(txs, 1)
+	txID := tx.ID()
+	require.Equal(txID[:], txs[0].args[0])
+	require.Equal("9223372036854775808", txs[0].args[4])
+	require.Equal(true, txs[0].args[9])
+	require.Equal("{1,2,3,4,5}", txs[0].args[11])
+	require.Equal("15", txs[0].args[12])
+
+	actions := testDB.execs(insertAction)
+	require.Len(actions, 2)
+	require.Equal(int64(3), actions[0].args[2])
+	require.JSONEq(`{"value":10}`, actions[0].args[3].(string))
+	outputs, err := json.Marshal([][]byte{[]byte("output")})
+	require.NoError(err)
+	require.JSONEq(string(outputs), actions[0].args[4].(string))
+	require.JSONEq(`{"value":20}`, actions[1].args[3].(string))
+	require.JSONEq("null", actions[1].args[4].(string))
+
+	require.ErrorIs(db.WriteBlock(ctx, blk, nil, prices), chain.ErrInvalidObject)
+}
@@ -21,4 +21,7 @@ const (
 	MaxWriteMessageSize = 16 * units.MiB
 	MaxMessageWait      = 50 * time.Millisecond
 	MaxPendingMessages  = 1024
+	MaxTopicNameLen     = 64
+	MaxSubscriptions    = 32
+	ReplayBufferSize    = 16 * units.MiB
 )
@@ -12,4 +12,11 @@ var (
 	ErrInvalidCommand       = errors.New("invalid command")
 	ErrMessageTooLarge      = errors.New("message too large")
 	ErrClosed               = errors.New("closed")
+	ErrInvalidTopicName     = errors.New("invalid topic name")
+	ErrDuplicateTopic       = errors.New("duplicate topic")
+	ErrUnknownTopic         = errors.New("unknown topic")
+	ErrNotSubscribed        = errors.New("not subscribed")
+	ErrSubscriptionLimit    = errors.New("subscription limit exceeded")
+	ErrReplayUnavailable    = errors.New("replay unavailable")
+	ErrReplayIncomplete     = errors.New("replay incomplete")
 )
@@ -32,6 +32,9 @@ type ServerConfig struct {
 	PongWait time.Duration
 	// Send pings to peer with this period. Must be less than pongWait.
 	PingPeriod time.Duration
+	// Maximum size in bytes of the recent events of each topic buffered for
+	// subscribers that resume after reconnecting (0 disables resumption).
+	ReplayBufferSize int
 }
 
 func NewDefaultServerConfig() *ServerConfig {
@@ -45,6 +48,7 @@ func NewDefaultServerConfig() *ServerConfig {
 		WriteWait:           WriteWait,
 		PongWait:            PongWait,
 		PingPeriod:          (9 * PongWait) / 10,
+		ReplayBufferSize:    ReplayBufferSize,
 	}
 }
 
@@ -57,6 +61,7 @@ type Server struct {
 	callback Callback
 	upgrader *websocket.Upgrader
 	conns    *Connections
+	topics   *Topics
 }
 
 // New returns a new Server instance. The callback function [f] is called
@@ -132,6 +137,9 @@ func (s *Server) addConnection(conn *Connection) {
 // removeConnection removes [conn] from the servers connection set.
 func (s *Server) removeConnection(conn *Connection) {
 	s.conns.Remove(conn)
+	if s.topics != nil {
+		s.topics.remove(conn)
+	}
 }
 
 func (s *Server) Connections() *Connections {
@@ -0,0 +1,312 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/maps"
+)
+
+const topicLabel = "topic"
+
+// Filter selects the events of a topic that are sent to a subscription.
+type Filter interface {
+	Match(event any) bool
+}
+
+// FilterParser parses the filter of a subscription from the params sent by
+// the subscriber. It may return a nil [Filter] to match every event.
+type FilterParser func(params []byte) (Filter, error)
+
+type topic struct {
+	parse FilterParser
+	subs  map[*Connection]Filter
+
+	// seq is the sequence number of the last event published to the topic
+	seq uint64
+	// replay holds the most recent events of the topic, oldest first, so
+	// reconnecting subscribers can resume from the last event they received
+	replay     []*replayEvent
+	replaySize int
+}
+
+type replayEvent struct {
+	event any
+	msg   []byte
+}
+
+type topicMetrics struct {
+	subscriptions *prometheus.GaugeVec
+	events        *prometheus.CounterVec
+	sent          *prometheus.CounterVec
+	dropped       *prometheus.CounterVec
+	replayed      *prometheus.CounterVec
+}
+
+// Topics tracks the subscriptions of the connections of a [Server] to named
+// topics. Events published to a topic are only sent to the connections
+// subscribed to it whose filter matches them.
+type Topics struct {
+	s       *Server
+	metrics *topicMetrics
+
+	l      sync.RWMutex
+	topics map[string]*topic
+}
+
+// NewTopics returns the topics of [s] and the registry of their metrics. It
+// must be called before [s] accepts connections, as subscriptions are removed
+// when their connection is closed.
+func NewTopics(s *Server) (*Topics, *prometheus.Registry, error) {
+	r := prometheus.NewRegistry()
+	m := &topicMetrics{
+		subscriptions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "topic",
+			Name:      "subscriptions",
+			Help:      "number of connections subscribed to the topic",
+		}, []string{topicLabel}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "topic",
+			Name:      "events",
+			Help:      "number of events published to the topic",
+		}, []string{topicLabel}),
+		sent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "topic",
+			Name:      "sent",
+			Help:      "number of events sent to subscriptions of the topic",
+		}, []string{topicLabel}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "topic",
+			Name:      "dropped",
+			Help:      "number of events not sent to subscriptions of the topic due to too many pending messages",
+		}, []string{topicLabel}),
+		replayed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "topic",
+			Name:      "replayed",
+			Help:      "number of buffered events sent to resumed subscriptions of the topic",
+		}, []string{topicLabel}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		r.Register(m.subscriptions),
+		r.Register(m.events),
+		r.Register(m.sent),
+		r.Register(m.dropped),
+		r.Register(m.replayed),
+	)
+	if errs.Errored() {
+		return nil, nil, errs.Err
+	}
+	t := &Topics{
+		s:       s,
+		metrics: m,
+		topics:  map[string]*topic{},
+	}
+	s.topics = t
+	return t, r, nil
+}
+
+// Register adds the topic [name], whose subscriptions are filtered by the
+// filters returned by [parse].
+func (t *Topics) Register(name string, parse FilterParser) error {
+	if len(name) == 0 || len(name) > MaxTopicNameLen {
+		return fmt.Errorf("%w: %q", ErrInvalidTopicName, name)
+	}
+
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if _, ok := t.topics[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateTopic, name)
+	}
+	t.topics[name] = &topic{parse: parse, subs: map[*Connection]Filter{}}
+	t.metrics.subscriptions.WithLabelValues(name).Set(0)
+	return nil
+}
+
+// Names returns the registered topics, sorted.
+func (t *Topics) Names() []string {
+	t.l.RLock()
+	defer t.l.RUnlock()
+
+	names := maps.Keys(t.topics)
+	slices.Sort(names)
+	return names
+}
+
+// Subscribe subscribes [c] to the events of [name] matched by the filter
+// parsed from [params] and returns the sequence number of the last event
+// published to [name]. Subscribing to a topic again replaces the previous
+// filter.
+//
+// If [after] is not 0, the subscription resumes after the event [after]:
+// the matching events published since then are sent to [c] before Subscribe
+// returns. If some of them are no longer buffered (see
+// [ServerConfig.ReplayBufferSize]), [c] is not subscribed and
+// [ErrReplayUnavailable] is returned. If some of them can't be sent to [c],
+// any subscription of [c] to [name] is removed and [ErrReplayIncomplete] is
+// returned.
+func (t *Topics) Subscribe(c *Connection, name string, params []byte, after uint64) (uint64, error) {
+	t.l.RLock()
+	tp, ok := t.topics[name]
+	t.l.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownTopic, name)
+	}
+	filter, err := tp.parse(params)
+	if err != nil {
+		return 0, err
+	}
+
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	_, subscribed := tp.subs[c]
+	if !subscribed && t.subscriptions(c) >= MaxSubscriptions {
+		return 0, ErrSubscriptionLimit
+	}
+	var replay []*replayEvent
+	if after > 0 {
+		// [tp.replay] holds the events after [oldest]
+		oldest := tp.seq - uint64(len(tp.replay))
+		if after < oldest || after > tp.seq {
+			return 0, fmt.Errorf("%w: %s after %d (buffered %d-%d)", ErrReplayUnavailable, name, after, oldest+1, tp.seq)
+		}
+		replay = tp.replay[after-oldest:]
+	}
+	for _, e := range replay {
+		if filter != nil && !filter.Match(e.event) {
+			continue
+		}
+		if !c.Send(e.msg) {
+			// Resuming with a gap would silently lose events, so the
+			// subscription is closed instead (the subscriber may resume again
+			// after the last event it received)
+			t.metrics.dropped.WithLabelValues(name).Inc()
+			if subscribed {
+				delete(tp.subs, c)
+				t.metrics.subscriptions.WithLabelValues(name).Dec()
+			}
+			return 0, fmt.Errorf("%w: %s after %d", ErrReplayIncomplete, name, after)
+		}
+		t.metrics.replayed.WithLabelValues(name).Inc()
+	}
+	if !subscribed {
+		t.metrics.subscriptions.WithLabelValues(name).Inc()
+	}
+	tp.subs[c] = filter
+	return tp.seq, nil
+}
+
+// Unsubscribe removes the subscription of [c] to [name].
+func (t *Topics) Unsubscribe(c *Connection, name string) error {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	tp, ok := t.topics[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTopic, name)
+	}
+	if _, ok := tp.subs[c]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotSubscribed, name)
+	}
+	delete(tp.subs, c)
+	t.metrics.subscriptions.WithLabelValues(name).Dec()
+	return nil
+}
+
+// HasSubscribers returns true if any connection is subscribed to [name].
+func (t *Topics) HasSubscribers(name string) bool {
+	t.l.RLock()
+	defer t.l.RUnlock()
+
+	tp, ok := t.topics[name]
+	return ok && len(tp.subs) > 0
+}
+
+// Active returns true if events published to [name] are sent to subscribers
+// or buffered for replay.
+func (t *Topics) Active(name string) bool {
+	return t.s.config.ReplayBufferSize > 0 || t.HasSubscribers(name)
+}
+
+// Publish sends [event] to the subscriptions of [name] that match it. The
+// message sent is returned by [pack] with the sequence number of [event]. It
+// is only called (once) if a subscription matches or if events are buffered
+// for replay.
+func (t *Topics) Publish(name string, event any, pack func(seq uint64) ([]byte, error)) error {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	tp, ok := t.topics[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTopic, name)
+	}
+	tp.seq++
+	t.metrics.events.WithLabelValues(name).Inc()
+	var msg []byte
+	if maxSize := t.s.config.ReplayBufferSize; maxSize > 0 {
+		var err error
+		msg, err = pack(tp.seq)
+		if err != nil {
+			return err
+		}
+		tp.replay = append(tp.replay, &replayEvent{event: event, msg: msg})
+		tp.replaySize += len(msg)
+		for tp.replaySize > maxSize {
+			tp.replaySize -= len(tp.replay[0].msg)
+			tp.replay[0] = nil
+			tp.replay = tp.replay[1:]
+		}
+	}
+	for c, filter := range tp.subs {
+		if filter != nil && !filter.Match(event) {
+			continue
+		}
+		if msg == nil {
+			var err error
+			msg, err = pack(tp.seq)
+			if err != nil {
+				return err
+			}
+		}
+		if !c.Send(msg) {
+			t.metrics.dropped.WithLabelValues(name).Inc()
+			t.s.log.Verbo("dropping topic message due to too many pending messages")
+			continue
+		}
+		t.metrics.sent.WithLabelValues(name).Inc()
+	}
+	return nil
+}
+
+// subscriptions returns the number of topics [c] is subscribed to. The caller
+// must hold [t.l].
+func (t *Topics) subscriptions(c *Connection) int {
+	count := 0
+	for _, tp := range t.topics {
+		if _, ok := tp.subs[c]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// remove drops the subscriptions of the closed connection [c].
+func (t *Topics) remove(c *Connection) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	for name, tp := range t.topics {
+		if _, ok := tp.subs[c]; ok {
+			delete(tp.subs, c)
+			t.metrics.subscriptions.WithLabelValues(name).Dec()
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// prefixFilter matches events that start with its prefix.
+type prefixFilter []byte
+
+func (f prefixFilter) Match(event any) bool {
+	return bytes.HasPrefix(event.([]byte), f)
+}
+
+func parsePrefixFilter(params []byte) (Filter, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	return prefixFilter(params), nil
+}
+
+func newTestConnection(s *Server) *Connection {
+	c := &Connection{
+		s:  s,
+		mb: NewMessageBuffer(logging.NoLog{}, MaxPendingMessages, MaxWriteMessageSize, time.Millisecond),
+	}
+	c.active.Store(true)
+	s.conns.Add(c)
+	return c
+}
+
+// received returns the messages sent to [c] before [timeout].
+func received(t *testing.T, c *Connection, timeout time.Duration) [][]byte {
+	msgs := [][]byte{}
+	for {
+		select {
+		case batch := <-c.mb.Queue:
+			batchMsgs, err := ParseBatchMessage(MaxWriteMessageSize, batch)
+			require.NoError(t, err)
+			msgs = append(msgs, batchMsgs...)
+		case <-time.After(timeout):
+			return msgs
+		}
+	}
+}
+
+func subscribe(topics *Topics, c *Connection, name string, params []byte) error {
+	_, err := topics.Subscribe(c, name, params, 0)
+	return err
+}
+
+func metricValue(t *testing.T, c prometheus.Collector) float64 {
+	var m dto.Metric
+	switch c := c.(type) {
+	case prometheus.Gauge:
+		require.NoError(t, c.Write(&m))
+		return m.GetGauge().GetValue()
+	case prometheus.Counter:
+		require.NoError(t, c.Write(&m))
+		return m.GetCounter().GetValue()
+	}
+	require.FailNow(t, "unexpected collector")
+	return 0
+}
+
+func TestTopics(t *testing.T) {
+	require := require.New(t)
+
+	cfg := NewDefaultServerConfig()
+	cfg.ReplayBufferSize = 0
+	s := New(logging.NoLog{}, cfg, nil)
+	topics, _, err := NewTopics(s)
+	require.NoError(err)
+	require.NoError(topics.Register("a", parsePrefixFilter))
+	require.NoError(topics.Register("b", parsePrefixFilter))
+	require.ErrorIs(topics.Register("a", parsePrefixFilter), ErrDuplicateTopic)
+	require.ErrorIs(topics.Register("", parsePrefixFilter), ErrInvalidTopicName)
+	require.Equal([]string{"a", "b"}, topics.Names())
+
+	all := newTestConnection(s)
+	filtered := newTestConnection(s)
+	other := newTestConnection(s)
+	require.NoError(subscribe(topics, all, "a", nil))
+	require.NoError(subscribe(topics, filtered, "a", []byte("x")))
+	require.NoError(subscribe(topics, other, "b", nil))
+	require.ErrorIs(subscribe(topics, all, "c", nil), ErrUnknownTopic)
+	require.Equal(2.0, metricValue(t, topics.metrics.subscriptions.WithLabelValues("a")))
+	require.False(topics.HasSubscribers("c"))
+
+	// Events are only sent to the matching subscriptions of their topic, and
+	// only packed if a subscription matches
+	packed := 0
+	publish := func(name string, event []byte) {
+		require.NoError(topics.Publish(name, event, func(uint64) ([]byte, error) {
+			packed++
+			return event, nil
+		}))
+	}
+	publish("a", []byte("x1"))
+	publish("a", []byte("y1"))
+	require.Equal(2, packed)
+	require.Equal([][]byte{[]byte("x1"), []byte("y1")}, received(t, all, 50*time.Millisecond))
+	require.Equal([][]byte{[]byte("x1")}, received(t, filtered, 50*time.Millisecond))
+	require.Empty(received(t, other, 50*time.Millisecond))
+	require.Equal(2.0, metricValue(t, topics.metrics.events.WithLabelValues("a")))
+	require.Equal(3.0, metricValue(t, topics.metrics.sent.WithLabelValues("a")))
+
+	// Subscribing again replaces the filter
+	require.NoError(subscribe(topics, filtered, "a", []byte("y")))
+	require.Equal(2.0, metricValue(t, topics.metrics.subscriptions.WithLabelValues("a")))
+	publish("a", []byte("x2"))
+	publish("a", []byte("y2"))
+	require.Equal([][]byte{[]byte("y2")}, received(t, filtered, 50*time.Millisecond))
+
+	require.NoError(topics.Unsubscribe(all, "a"))
+	require.ErrorIs(topics.Unsubscribe(all, "a"), ErrNotSubscribed)
+	require.ErrorIs(topics.Unsubscribe(all, "c"), ErrUnknownTopic)
+	require.Equal(1.0, metricValue(t, topics.metrics.subscriptions.WithLabelValues("a")))
+
+	// Closed connections are unsubscribed from every topic
+	s.removeConnection(other)
+	require.False(topics.HasSubscribers("b"))
+	require.Zero(metricValue(t, topics.metrics.subscriptions.WithLabelValues("b")))
+}
+
+func TestTopicsSubscriptionLimit(t *testing.T) {
+	require := require.New(t)
+
+	s := New(logging.NoLog{}, NewDefaultServerConfig(), nil)
+	topics, _, err := NewTopics(s)
+	require.NoError(err)
+	c := newTestConnection(s)
+	for i := 0; i <= MaxSubscriptions; i++ {
+		require.NoError(topics.Register(string(rune('A'+i)), parsePrefixFilter))
+	}
+	for _, name := range topics.Names()[:MaxSubscriptions] {
+		require.NoError(subscribe(topics, c, name, nil))
+	}
+	// Limits only apply to new subscriptions
+	require.NoError(subscribe(topics, c, topics.Names()[0], []byte("x")))
+	require.ErrorIs(subscribe(topics, c, topics.Names()[MaxSubscriptions], nil), ErrSubscriptionLimit)
+}
+
+func TestTopicsReplay(t *testing.T) {
+	require := require.New(t)
+
+	cfg := NewDefaultServerConfig()
+	cfg.ReplayBufferSize = 6
+	s := New(logging.NoLog{}, cfg, nil)
+	topics, _, err := NewTopics(s)
+	require.NoError(err)
+	require.NoError(topics.Register("a", parsePrefixFilter))
+
+	// Events are buffered without subscribers, up to [ReplayBufferSize] bytes
+	for _, event := range []string{"x1", "y2", "x3", "y4", "x5"} {
+		require.NoError(topics.Publish("a", []byte(event), func(uint64) ([]byte, error) {
+			return []byte(event), nil
+		}))
+	}
+
+	c := newTestConnection(s)
+	seq, err := topics.Subscribe(c, "a", []byte("x"), 2)
+	require.NoError(err)
+	require.Equal(uint64(5), seq)
+	require.Equal([][]byte{[]byte("x3"), []byte("x5")}, received(t, c, 50*time.Millisecond))
+	require.Equal(2.0, metricValue(t, topics.metrics.replayed.WithLabelValues("a")))
+
+	// Resuming from the last event replays nothing
+	seq, err = topics.Subscribe(c, "a", nil, 5)
+	require.NoError(err)
+	require.Equal(uint64(5), seq)
+	require.Empty(received(t, c, 50*time.Millisecond))
+
+	// Events no longer buffered (or not yet published) can't be replayed
+	other := newTestConnection(s)
+	_, err = topics.Subscribe(other, "a", nil, 1)
+	require.ErrorIs(err, ErrReplayUnavailable)
+	_, err = topics.Subscribe(other, "a", nil, 6)
+	require.ErrorIs(err, ErrReplayUnavailable)
+	require.Equal(1.0, metricValue(t, topics.metrics.subscriptions.WithLabelValues("a")))
+}
+
+func TestTopicsReplayIncomplete(t *testing.T) {
+	require := require.New(t)
+
+	cfg := NewDefaultServerConfig()
+	cfg.ReplayBufferSize = 6
+	s := New(logging.NoLog{}, cfg, nil)
+	topics, _, err := NewTopics(s)
+	require.NoError(err)
+	require.NoError(topics.Register("a", parsePrefixFilter))
+	for _, event := range []string{"x1", "y2", "x3"} {
+		require.NoError(topics.Publish("a", []byte(event), func(uint64) ([]byte, error) {
+			return []byte(event), nil
+		}))
+	}
+
+	// A subscription can't resume if the events it missed can't be sent...
+	c := newTestConnection(s)
+	require.NoError(subscribe(topics, c, "a", nil))
+	require.Equal(1.0, metricValue(t, topics.metrics.subscriptions.WithLabelValues("a")))
+	c.active.Store(false)
+	_, err = topics.Subscribe(c, "a", nil, 1)
+	require.ErrorIs(err, ErrReplayIncomplete)
+	require.Equal(1.0, metricValue(t, topics.metrics.dropped.WithLabelValues("a")))
+
+	// ...and is closed rather than left with a gap
+	require.False(topics.HasSubscribers("a"))
+	require.Zero(metricValue(t, topics.metrics.subscriptions.WithLabelValues("a")))
+	require.ErrorIs(topics.Unsubscribe(c, "a"), ErrNotSubscribed)
+
+	// Resuming succeeds if none of the missed events match the filter (as
+	// nothing needs to be sent)
+	seq, err := topics.Subscribe(c, "a", []byte("z"), 1)
+	require.NoError(err)
+	require.Equal(uint64(3), seq)
+	require.True(topics.HasSubscribers("a"))
+}
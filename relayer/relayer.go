@@ -0,0 +1,405 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package relayer delivers the warp messages sent by a HyperSDK chain to
+// another HyperSDK chain. It watches the source chain for accepted messages,
+// aggregates the signatures collected by the source validators, and submits a
+// transaction built by the destination VM that carries the signed message.
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const (
+	DefaultQuorumNum   = 67
+	DefaultQuorumDen   = 100
+	DefaultFeeBuffer   = 10 // percent
+	DefaultRetryDelay  = 2 * time.Second
+	DefaultMaxAttempts = 30
+
+	pendingMessages = 1024
+)
+
+var (
+	ErrMissingSourceURI      = errors.New("missing source uri")
+	ErrMissingDestinationURI = errors.New("missing destination uri")
+	ErrMissingParser         = errors.New("missing parser")
+	ErrMissingAuthFactory    = errors.New("missing auth factory")
+	ErrMissingDeliver        = errors.New("missing deliver function")
+	ErrInvalidQuorum         = errors.New("invalid quorum")
+	ErrInvalidMaxAttempts    = errors.New("invalid max attempts")
+	ErrFeeTooHigh            = errors.New("fee exceeds max fee")
+	ErrDeliveryFailed        = errors.New("delivery transaction failed")
+	ErrNoActions             = errors.New("no delivery actions")
+)
+
+// DeliverFunc returns the actions that deliver [msg] on the destination
+// chain (usually a single action implementing [chain.WarpAction]).
+type DeliverFunc func(msg *warp.Message) ([]chain.Action, error)
+
+// FilterFunc returns true if [msg] should be relayed to the chain
+// [destinationChainID].
+type FilterFunc func(destinationChainID ids.ID, msg *warp.UnsignedMessage) bool
+
+type Config struct {
+	// SourceURI and DestinationURI are the chain endpoints of nodes of each
+	// chain (<node uri>/ext/bc/<chainID>).
+	SourceURI      string
+	DestinationURI string
+
+	SourceParser      chain.Parser
+	DestinationParser chain.Parser
+
+	// AuthFactory signs (and pays for) delivery transactions.
+	AuthFactory chain.AuthFactory
+	Deliver     DeliverFunc
+	// Filter selects the messages to relay (all messages if nil).
+	Filter FilterFunc
+
+	// QuorumNum/QuorumDen is the fraction of the stake of the source subnet
+	// that must have signed a message before it is delivered.
+	QuorumNum uint64
+	QuorumDen uint64
+
+	// FeeBuffer is the percentage added to the estimated fee of a delivery
+	// transaction to tolerate unit price increases before it is included.
+	// Fees above MaxFee (if not 0) are never paid.
+	FeeBuffer uint64
+	MaxFee    uint64
+
+	// Aggregation and submission are each retried [MaxAttempts] times,
+	// [RetryDelay] apart.
+	RetryDelay  time.Duration
+	MaxAttempts int
+
+	Log logging.Logger
+}
+
+// DefaultConfig returns a [Config] that must be completed with the chains to
+// relay between, their parsers, an auth factory, and a deliver function.
+func DefaultConfig() *Config {
+	return &Config{
+		QuorumNum:   DefaultQuorumNum,
+		QuorumDen:   DefaultQuorumDen,
+		FeeBuffer:   DefaultFeeBuffer,
+		RetryDelay:  DefaultRetryDelay,
+		MaxAttempts: DefaultMaxAttempts,
+		Log:         logging.NoLog{},
+	}
+}
+
+func (c *Config) verify() error {
+	switch {
+	case len(c.SourceURI) == 0:
+		return ErrMissingSourceURI
+	case len(c.DestinationURI) == 0:
+		return ErrMissingDestinationURI
+	case c.SourceParser == nil || c.DestinationParser == nil:
+		return ErrMissingParser
+	case c.AuthFactory == nil:
+		return ErrMissingAuthFactory
+	case c.Deliver == nil:
+		return ErrMissingDeliver
+	case c.QuorumDen == 0 || c.QuorumNum > c.QuorumDen:
+		return fmt.Errorf("%w: %d/%d", ErrInvalidQuorum, c.QuorumNum, c.QuorumDen)
+	case c.MaxAttempts <= 0:
+		return fmt.Errorf("%w: %d", ErrInvalidMaxAttempts, c.MaxAttempts)
+	}
+	if c.Log == nil {
+		c.Log = logging.NoLog{}
+	}
+	return nil
+}
+
+// Relayer delivers messages from the source chain of its [Config] to the
+// destination chain.
+type Relayer struct {
+	cfg *Config
+
+	source      *rpc.JSONRPCClient
+	destination *rpc.JSONRPCClient
+
+	// delivered is only accessed by the delivery loop of [Run]
+	delivered set.Set[ids.ID]
+}
+
+func New(cfg *Config) (*Relayer, error) {
+	if err := cfg.verify(); err != nil {
+		return nil, err
+	}
+	return &Relayer{
+		cfg:         cfg,
+		source:      rpc.NewJSONRPCClient(cfg.SourceURI),
+		destination: rpc.NewJSONRPCClient(cfg.DestinationURI),
+		delivered:   set.Set[ids.ID]{},
+	}, nil
+}
+
+// Run relays every message accepted on the source chain until [ctx] is done.
+// Messages are delivered one at a time in the order they were accepted and a
+// message that can't be delivered is logged and skipped.
+func (r *Relayer) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	_, _, destinationChainID, err := r.destination.Network(ctx)
+	if err != nil {
+		return err
+	}
+	source, err := rpc.NewWebSocketClient(
+		r.cfg.SourceURI,
+		rpc.DefaultHandshakeTimeout,
+		pubsub.MaxPendingMessages,
+		pubsub.MaxReadMessageSize,
+	)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	if err := source.RegisterBlocks(); err != nil {
+		return err
+	}
+
+	msgs := make(chan ids.ID, pendingMessages)
+	deliveryDone := make(chan struct{})
+	go func() {
+		defer close(deliveryDone)
+		for msgID := range msgs {
+			txID, err := r.Relay(ctx, msgID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				r.cfg.Log.Warn("unable to relay warp message",
+					zap.Stringer("msgID", msgID),
+					zap.Error(err),
+				)
+				continue
+			}
+			r.cfg.Log.Info("relayed warp message",
+				zap.Stringer("msgID", msgID),
+				zap.Stringer("txID", txID),
+			)
+		}
+	}()
+	defer func() {
+		close(msgs)
+		<-deliveryDone
+	}()
+
+	for {
+		blk, results, _, err := source.ListenBlock(ctx, r.cfg.SourceParser)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, result := range results {
+			for _, msg := range result.WarpMessages {
+				if r.cfg.Filter != nil && !r.cfg.Filter(destinationChainID, msg) {
+					continue
+				}
+				r.cfg.Log.Debug("found warp message",
+					zap.Stringer("msgID", msg.ID()),
+					zap.Uint64("height", blk.Hght),
+				)
+				select {
+				case msgs <- msg.ID():
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// sleep waits for [RetryDelay] and returns false if [ctx] is done first.
+func (r *Relayer) sleep(ctx context.Context) bool {
+	t := time.NewTimer(r.cfg.RetryDelay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Relay delivers the message [msgID] sent by the source chain and returns the
+// ID of the delivery transaction. Messages already delivered by [r] are not
+// delivered again.
+func (r *Relayer) Relay(ctx context.Context, msgID ids.ID) (ids.ID, error) {
+	if r.delivered.Contains(msgID) {
+		return ids.Empty, nil
+	}
+	msg, err := r.aggregate(ctx, msgID)
+	if err != nil {
+		return ids.Empty, err
+	}
+	actions, err := r.cfg.Deliver(msg)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if len(actions) == 0 {
+		return ids.Empty, ErrNoActions
+	}
+	txID, err := r.submit(ctx, actions)
+	if err != nil {
+		return ids.Empty, err
+	}
+	r.delivered.Add(msgID)
+	return txID, nil
+}
+
+// aggregate waits until enough validators of the source subnet have signed
+// [msgID]. Signatures are collected by the source validators after the block
+// including the message is accepted, so they may not be available right away.
+func (r *Relayer) aggregate(ctx context.Context, msgID ids.ID) (*warp.Message, error) {
+	var err error
+	for attempt := 0; attempt < r.cfg.MaxAttempts; attempt++ {
+		if attempt > 0 && !r.sleep(ctx) {
+			return nil, ctx.Err()
+		}
+		var (
+			msg      *warp.Message
+			progress *rpc.WarpProgress
+		)
+		msg, progress, err = r.source.GetAggregateWarpSignature(
+			ctx,
+			msgID,
+			r.cfg.QuorumNum,
+			r.cfg.QuorumDen,
+		)
+		if err != nil {
+			r.cfg.Log.Debug("unable to aggregate warp signatures",
+				zap.Stringer("msgID", msgID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if msg != nil {
+			return msg, nil
+		}
+		err = warp.ErrInsufficientWeight
+		r.cfg.Log.Debug("waiting for warp signatures",
+			zap.Stringer("msgID", msgID),
+			zap.Int("signers", progress.Signers),
+			zap.Int("validators", progress.Validators),
+			zap.Uint64("signedWeight", progress.SignedWeight),
+			zap.Uint64("totalWeight", progress.TotalWeight),
+		)
+	}
+	return nil, fmt.Errorf("unable to aggregate signatures after %d attempts: %w", r.cfg.MaxAttempts, err)
+}
+
+// fee returns the fee to pay for [actions] at the current unit prices of the
+// destination chain.
+func (r *Relayer) fee(ctx context.Context, actions []chain.Action) (uint64, error) {
+	unitPrices, err := r.destination.UnitPrices(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+	rules := r.cfg.DestinationParser.Rules(time.Now().UnixMilli())
+	units, err := chain.EstimateUnits(rules, actions, r.cfg.AuthFactory)
+	if err != nil {
+		return 0, err
+	}
+	fee, err := fees.MulSum(unitPrices, units)
+	if err != nil {
+		return 0, err
+	}
+	fee += fee * r.cfg.FeeBuffer / 100
+	if r.cfg.MaxFee > 0 && fee > r.cfg.MaxFee {
+		return 0, fmt.Errorf("%w: fee=%d max=%d", ErrFeeTooHigh, fee, r.cfg.MaxFee)
+	}
+	return fee, nil
+}
+
+// submit issues a transaction with [actions] on the destination chain and
+// waits for it to be accepted. Transactions that are dropped (for example,
+// because unit prices increased past their max fee) are re-issued with a new
+// fee.
+func (r *Relayer) submit(ctx context.Context, actions []chain.Action) (ids.ID, error) {
+	destination, err := rpc.NewWebSocketClient(
+		r.cfg.DestinationURI,
+		rpc.DefaultHandshakeTimeout,
+		pubsub.MaxPendingMessages,
+		pubsub.MaxReadMessageSize,
+	)
+	if err != nil {
+		return ids.Empty, err
+	}
+	defer destination.Close()
+
+	for attempt := 0; attempt < r.cfg.MaxAttempts; attempt++ {
+		if attempt > 0 && !r.sleep(ctx) {
+			return ids.Empty, ctx.Err()
+		}
+		var fee uint64
+		fee, err = r.fee(ctx, actions)
+		if err != nil {
+			r.cfg.Log.Debug("unable to compute delivery fee", zap.Error(err))
+			continue
+		}
+		var tx *chain.Transaction
+		_, tx, err = r.destination.GenerateTransactionManual(r.cfg.DestinationParser, actions, r.cfg.AuthFactory, fee)
+		if err != nil {
+			return ids.Empty, err
+		}
+		if err = destination.RegisterTx(tx); err != nil {
+			return ids.Empty, err
+		}
+		var result *chain.Result
+		result, err = listenTx(ctx, destination, tx.ID())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ids.Empty, ctx.Err()
+			}
+			r.cfg.Log.Debug("delivery transaction dropped",
+				zap.Stringer("txID", tx.ID()),
+				zap.Uint64("fee", fee),
+				zap.Error(err),
+			)
+			continue
+		}
+		if !result.Success {
+			// Execution is deterministic, so retrying would fail again
+			return tx.ID(), fmt.Errorf("%w: %s", ErrDeliveryFailed, result.Error)
+		}
+		return tx.ID(), nil
+	}
+	return ids.Empty, fmt.Errorf("unable to submit delivery after %d attempts: %w", r.cfg.MaxAttempts, err)
+}
+
+// listenTx waits for the result of [txID], which was registered with [ws].
+func listenTx(ctx context.Context, ws *rpc.WebSocketClient, txID ids.ID) (*chain.Result, error) {
+	for {
+		id, txErr, result, err := ws.ListenTx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if id != txID {
+			continue
+		}
+		if txErr != nil {
+			return nil, txErr
+		}
+		return result, nil
+	}
+}
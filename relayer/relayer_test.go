@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+type testParser struct {
+	chain.Parser
+}
+
+type testAuthFactory struct {
+	chain.AuthFactory
+}
+
+func testConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.SourceURI = "http://127.0.0.1:9650/ext/bc/source"
+	cfg.DestinationURI = "http://127.0.0.1:9650/ext/bc/destination"
+	cfg.SourceParser = &testParser{}
+	cfg.DestinationParser = &testParser{}
+	cfg.AuthFactory = &testAuthFactory{}
+	cfg.Deliver = func(*warp.Message) ([]chain.Action, error) { return nil, nil }
+	return cfg
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "missing source uri",
+			modify: func(c *Config) { c.SourceURI = "" },
+			err:    ErrMissingSourceURI,
+		},
+		{
+			name:   "missing destination uri",
+			modify: func(c *Config) { c.DestinationURI = "" },
+			err:    ErrMissingDestinationURI,
+		},
+		{
+			name:   "missing parser",
+			modify: func(c *Config) { c.DestinationParser = nil },
+			err:    ErrMissingParser,
+		},
+		{
+			name:   "missing auth factory",
+			modify: func(c *Config) { c.AuthFactory = nil },
+			err:    ErrMissingAuthFactory,
+		},
+		{
+			name:   "missing deliver",
+			modify: func(c *Config) { c.Deliver = nil },
+			err:    ErrMissingDeliver,
+		},
+		{
+			name:   "zero quorum denominator",
+			modify: func(c *Config) { c.QuorumDen = 0 },
+			err:    ErrInvalidQuorum,
+		},
+		{
+			name:   "quorum above 1",
+			modify: func(c *Config) { c.QuorumNum = c.QuorumDen + 1 },
+			err:    ErrInvalidQuorum,
+		},
+		{
+			name:   "no attempts",
+			modify: func(c *Config) { c.MaxAttempts = 0 },
+			err:    ErrInvalidMaxAttempts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			tt.modify(cfg)
+			require.ErrorIs(t, cfg.verify(), tt.err)
+		})
+	}
+}
+
+func TestConfigVerifyDefaults(t *testing.T) {
+	require := require.New(t)
+
+	cfg := testConfig()
+	cfg.Log = nil
+	require.NoError(cfg.verify())
+	require.NotNil(cfg.Log)
+}
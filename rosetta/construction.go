@@ -0,0 +1,296 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rosetta
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	CurveEdwards25519    = "edwards25519"
+	SignatureTypeEd25519 = "ed25519"
+)
+
+// preprocessOptions are returned by /construction/preprocess and passed to
+// /construction/metadata.
+type preprocessOptions struct {
+	Actor string          `json:"actor"`
+	Units fees.Dimensions `json:"units"`
+}
+
+// constructionMetadata is returned by /construction/metadata and passed to
+// /construction/payloads.
+type constructionMetadata struct {
+	ChainID ids.ID `json:"chainId"`
+	MaxFee  string `json:"maxFee"`
+}
+
+func toMap(v any) (map[string]any, *Error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	return m, nil
+}
+
+func fromMap(m map[string]any, v any) *Error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return ErrInvalidRequest.wrap(err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return ErrInvalidRequest.wrap(err)
+	}
+	return nil
+}
+
+func (s *Server) constructionDerive(_ context.Context, r *ConstructionDeriveRequest) (any, *Error) {
+	pk, rerr := parsePublicKey(r.PublicKey)
+	if rerr != nil {
+		return nil, rerr
+	}
+	addr := auth.NewED25519Address(pk)
+	return &ConstructionDeriveResponse{AccountIdentifier: &AccountIdentifier{Address: s.c.Address(addr)}}, nil
+}
+
+func parsePublicKey(pk *PublicKey) (ed25519.PublicKey, *Error) {
+	if pk == nil || pk.CurveType != CurveEdwards25519 {
+		return ed25519.EmptyPublicKey, ErrInvalidPublicKey.wrapf("curve must be %s", CurveEdwards25519)
+	}
+	b, err := codec.LoadHex(pk.HexBytes, ed25519.PublicKeyLen)
+	if err != nil {
+		return ed25519.EmptyPublicKey, ErrInvalidPublicKey.wrap(err)
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// actions returns the actions that perform [ops] and their actor, which must
+// be an [auth.ED25519] address.
+func (s *Server) actions(ops []*Operation) (codec.Address, []chain.Action, *Error) {
+	if len(ops) == 0 {
+		return codec.EmptyAddress, nil, ErrInvalidOperations.wrapf("no operations")
+	}
+	for _, op := range ops {
+		if op.Type == FeeOperation {
+			return codec.EmptyAddress, nil, ErrInvalidOperations.wrapf("fees are set by /construction/metadata")
+		}
+	}
+	actor, actions, err := s.c.Actions(ops)
+	if err != nil {
+		return codec.EmptyAddress, nil, ErrInvalidOperations.wrap(err)
+	}
+	if actor[0] != auth.ED25519ID {
+		return codec.EmptyAddress, nil, ErrUnsupported.wrapf("actor must be an ed25519 address")
+	}
+	return actor, actions, nil
+}
+
+func (s *Server) constructionPreprocess(_ context.Context, r *ConstructionPreprocessRequest) (any, *Error) {
+	actor, actions, rerr := s.actions(r.Operations)
+	if rerr != nil {
+		return nil, rerr
+	}
+	// The units of the auth only depend on its type, so any key can be used to
+	// estimate them.
+	units, err := chain.EstimateUnits(s.c.Rules(time.Now().UnixMilli()), actions, &auth.ED25519Factory{})
+	if err != nil {
+		return nil, ErrInvalidOperations.wrap(err)
+	}
+	options, rerr := toMap(&preprocessOptions{Actor: s.c.Address(actor), Units: units})
+	if rerr != nil {
+		return nil, rerr
+	}
+	return &ConstructionPreprocessResponse{
+		Options:            options,
+		RequiredPublicKeys: []*AccountIdentifier{{Address: s.c.Address(actor)}},
+	}, nil
+}
+
+func (s *Server) constructionMetadata(ctx context.Context, r *ConstructionMetadataRequest) (any, *Error) {
+	var options preprocessOptions
+	if rerr := fromMap(r.Options, &options); rerr != nil {
+		return nil, rerr
+	}
+	_, _, chainID, err := s.cli.Network(ctx)
+	if err != nil {
+		return nil, ErrUnavailable.wrap(err)
+	}
+	unitPrices, err := s.cli.UnitPrices(ctx, false)
+	if err != nil {
+		return nil, ErrUnavailable.wrap(err)
+	}
+	maxFee, err := fees.MulSum(unitPrices, options.Units)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	metadata, rerr := toMap(&constructionMetadata{ChainID: chainID, MaxFee: strconv.FormatUint(maxFee, 10)})
+	if rerr != nil {
+		return nil, rerr
+	}
+	return &ConstructionMetadataResponse{
+		Metadata:     metadata,
+		SuggestedFee: []*Amount{s.amount(maxFee, false)},
+	}, nil
+}
+
+// constructionPayloads returns the digest of the transaction prefixed by its
+// actor, which is needed to parse its operations before it is signed.
+func (s *Server) constructionPayloads(_ context.Context, r *ConstructionPayloadsRequest) (any, *Error) {
+	actor, actions, rerr := s.actions(r.Operations)
+	if rerr != nil {
+		return nil, rerr
+	}
+	var metadata constructionMetadata
+	if rerr := fromMap(r.Metadata, &metadata); rerr != nil {
+		return nil, rerr
+	}
+	maxFee, err := strconv.ParseUint(metadata.MaxFee, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	now := time.Now().UnixMilli()
+	base := &chain.Base{
+		Timestamp: utils.UnixRMilli(now, s.c.Rules(now).GetValidityWindow()),
+		ChainID:   metadata.ChainID,
+		MaxFee:    maxFee,
+	}
+	digest, err := chain.NewTx(base, actions).Digest()
+	if err != nil {
+		return nil, ErrInvalidOperations.wrap(err)
+	}
+	return &ConstructionPayloadsResponse{
+		UnsignedTransaction: codec.ToHex(append(actor[:], digest...)),
+		Payloads: []*SigningPayload{{
+			AccountIdentifier: &AccountIdentifier{Address: s.c.Address(actor)},
+			HexBytes:          codec.ToHex(digest),
+			SignatureType:     SignatureTypeEd25519,
+		}},
+	}, nil
+}
+
+// parseUnsignedTx parses a transaction returned by /construction/payloads.
+func (s *Server) parseUnsignedTx(unsignedTx string) (codec.Address, *chain.Transaction, *Error) {
+	b, err := codec.LoadHex(unsignedTx, -1)
+	if err != nil || len(b) < codec.AddressLen {
+		return codec.EmptyAddress, nil, ErrInvalidTransaction.wrapf("invalid unsigned transaction")
+	}
+	tx, rerr := s.unmarshalTx(b[codec.AddressLen:], false)
+	return codec.Address(b[:codec.AddressLen]), tx, rerr
+}
+
+// presignedFactory authorizes a transaction with a signature produced by the
+// client.
+type presignedFactory struct {
+	auth chain.Auth
+}
+
+func (f *presignedFactory) Sign([]byte) (chain.Auth, error) {
+	return f.auth, nil
+}
+
+func (*presignedFactory) MaxUnits() (uint64, uint64) {
+	return (&auth.ED25519Factory{}).MaxUnits()
+}
+
+func (s *Server) constructionCombine(_ context.Context, r *ConstructionCombineRequest) (any, *Error) {
+	actor, tx, rerr := s.parseUnsignedTx(r.UnsignedTransaction)
+	if rerr != nil {
+		return nil, rerr
+	}
+	if len(r.Signatures) != 1 || r.Signatures[0].SignatureType != SignatureTypeEd25519 {
+		return nil, ErrInvalidSignature.wrapf("expected an %s signature", SignatureTypeEd25519)
+	}
+	pk, rerr := parsePublicKey(r.Signatures[0].PublicKey)
+	if rerr != nil {
+		return nil, rerr
+	}
+	if auth.NewED25519Address(pk) != actor {
+		return nil, ErrInvalidSignature.wrapf("public key is not the key of %s", s.c.Address(actor))
+	}
+	sig, err := codec.LoadHex(r.Signatures[0].HexBytes, ed25519.SignatureLen)
+	if err != nil {
+		return nil, ErrInvalidSignature.wrap(err)
+	}
+	digest, err := tx.Digest()
+	if err != nil {
+		return nil, ErrInvalidTransaction.wrap(err)
+	}
+	if !ed25519.Verify(digest, pk, ed25519.Signature(sig)) {
+		return nil, ErrInvalidSignature
+	}
+	actionRegistry, authRegistry := s.c.Registry()
+	tx, err = tx.Sign(
+		&presignedFactory{auth: &auth.ED25519{Signer: pk, Signature: ed25519.Signature(sig)}},
+		actionRegistry,
+		authRegistry,
+	)
+	if err != nil {
+		return nil, ErrInvalidTransaction.wrap(err)
+	}
+	return &ConstructionCombineResponse{SignedTransaction: codec.ToHex(tx.Bytes())}, nil
+}
+
+func (s *Server) constructionParse(_ context.Context, r *ConstructionParseRequest) (any, *Error) {
+	var (
+		actor codec.Address
+		tx    *chain.Transaction
+		rerr  *Error
+	)
+	if r.Signed {
+		tx, rerr = s.parseTx(r.Transaction)
+		if tx != nil {
+			actor = tx.Auth.Actor()
+		}
+	} else {
+		actor, tx, rerr = s.parseUnsignedTx(r.Transaction)
+	}
+	if rerr != nil {
+		return nil, rerr
+	}
+	ops, rerr := s.operations(actor, tx.Actions)
+	if rerr != nil {
+		return nil, rerr
+	}
+	resp := &ConstructionParseResponse{Operations: ops, AccountIdentifierSigners: []*AccountIdentifier{}}
+	if r.Signed {
+		resp.AccountIdentifierSigners = append(resp.AccountIdentifierSigners, &AccountIdentifier{Address: s.c.Address(actor)})
+	}
+	return resp, nil
+}
+
+func (s *Server) constructionHash(_ context.Context, r *ConstructionHashRequest) (any, *Error) {
+	tx, rerr := s.parseTx(r.SignedTransaction)
+	if rerr != nil {
+		return nil, rerr
+	}
+	return &TransactionIdentifierResponse{TransactionIdentifier: &TransactionIdentifier{Hash: tx.ID().String()}}, nil
+}
+
+func (s *Server) constructionSubmit(ctx context.Context, r *ConstructionSubmitRequest) (any, *Error) {
+	tx, rerr := s.parseTx(r.SignedTransaction)
+	if rerr != nil {
+		return nil, rerr
+	}
+	txID, err := s.cli.SubmitTx(ctx, tx.Bytes())
+	if err != nil {
+		return nil, ErrSubmitFailed.wrap(err)
+	}
+	return &TransactionIdentifierResponse{TransactionIdentifier: &TransactionIdentifier{Hash: txID.String()}}, nil
+}
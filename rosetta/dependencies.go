@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rosetta
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ Client = (*rpc.JSONRPCClient)(nil)
+
+// Client fetches chain data from a node running the indexer (see
+// [rpc.JSONRPCClient]).
+type Client interface {
+	Network(ctx context.Context) (uint32, ids.ID, ids.ID, error)
+	Accepted(ctx context.Context) (ids.ID, uint64, int64, error)
+	GetIndexedBlock(
+		ctx context.Context,
+		parser chain.Parser,
+		height uint64,
+	) (*chain.StatefulBlock, []*chain.Result, fees.Dimensions, error)
+	GetIndexedBlockHeight(ctx context.Context, blkID ids.ID) (uint64, error)
+	GetIndexedTx(
+		ctx context.Context,
+		parser chain.Parser,
+		txID ids.ID,
+	) (*chain.Transaction, *chain.Result, uint64, int64, error)
+	UnitPrices(ctx context.Context, useCache bool) (fees.Dimensions, error)
+	SubmitTx(ctx context.Context, tx []byte) (ids.ID, error)
+}
+
+// Controller adapts [Server] to the actions and state of a hypervm.
+type Controller interface {
+	chain.Parser
+
+	// Blockchain is the name of the hypervm in [NetworkIdentifier]s (the
+	// network is the ID of the chain).
+	Blockchain() string
+	// Currency is the native asset that fees are paid in.
+	Currency() *Currency
+
+	ParseAddress(address string) (codec.Address, error)
+	Address(addr codec.Address) string
+
+	// OperationTypes lists the types of the operations returned by
+	// [Operations] (excluding [FeeOperation]).
+	OperationTypes() []string
+	// Operations returns the balance changes caused by [action] if it is
+	// executed successfully by [actor]. The identifiers and statuses of the
+	// operations are assigned by [Server].
+	Operations(actor codec.Address, action chain.Action) ([]*Operation, error)
+	// Actions returns the actions that perform [ops] (which were returned by
+	// [Operations]) and the actor that must sign them.
+	Actions(ops []*Operation) (codec.Address, []chain.Action, error)
+
+	// Balance returns the balance of [addr] after the block at [height] was
+	// accepted.
+	Balance(ctx context.Context, addr codec.Address, height uint64) (uint64, error)
+	// GenesisOperations returns the balances allocated by the genesis.
+	GenesisOperations(ctx context.Context) ([]*Operation, error)
+}
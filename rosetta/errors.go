@@ -0,0 +1,59 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rosetta
+
+import "fmt"
+
+// Error is returned (with status code 500) by every endpoint that fails.
+type Error struct {
+	Code      int32          `json:"code"`
+	Message   string         `json:"message"`
+	Retriable bool           `json:"retriable"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// wrap returns a copy of [e] that includes [err] in its details.
+func (e *Error) wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Details = map[string]any{"error": err.Error()}
+	return &wrapped
+}
+
+func (e *Error) wrapf(format string, args ...any) *Error {
+	return e.wrap(fmt.Errorf(format, args...))
+}
+
+var (
+	ErrInvalidRequest     = &Error{Code: 1, Message: "invalid request"}
+	ErrUnknownNetwork     = &Error{Code: 2, Message: "unknown network"}
+	ErrUnavailable        = &Error{Code: 3, Message: "node unavailable", Retriable: true}
+	ErrBlockMissing       = &Error{Code: 4, Message: "block missing", Retriable: true}
+	ErrTxMissing          = &Error{Code: 5, Message: "transaction missing"}
+	ErrInvalidAddress     = &Error{Code: 6, Message: "invalid address"}
+	ErrInvalidOperations  = &Error{Code: 7, Message: "invalid operations"}
+	ErrInvalidPublicKey   = &Error{Code: 8, Message: "invalid public key"}
+	ErrInvalidSignature   = &Error{Code: 9, Message: "invalid signature"}
+	ErrInvalidTransaction = &Error{Code: 10, Message: "invalid transaction"}
+	ErrSubmitFailed       = &Error{Code: 11, Message: "transaction rejected"}
+	ErrUnsupported        = &Error{Code: 12, Message: "unsupported"}
+
+	errs = []*Error{
+		ErrInvalidRequest,
+		ErrUnknownNetwork,
+		ErrUnavailable,
+		ErrBlockMissing,
+		ErrTxMissing,
+		ErrInvalidAddress,
+		ErrInvalidOperations,
+		ErrInvalidPublicKey,
+		ErrInvalidSignature,
+		ErrInvalidTransaction,
+		ErrSubmitFailed,
+		ErrUnsupported,
+	}
+)
@@ -0,0 +1,522 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package rosetta implements the Rosetta Data and Construction APIs
+// (https://docs.cdp.coinbase.com/mesh/docs/api-reference) for any hypersdk
+// chain, so exchanges can integrate it with their existing tooling.
+//
+// [Server] reads chain data from a node that runs the indexer and uses a
+// [Controller] to convert the actions of the hypervm to and from Rosetta
+// operations. Transactions must be authorized by [auth.ED25519].
+package rosetta
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const (
+	RosettaVersion = "1.4.13"
+
+	// FeeOperation debits the fee of a transaction from its sponsor.
+	FeeOperation = "FEE"
+
+	StatusSuccess = "SUCCESS"
+	StatusFailure = "FAILURE"
+
+	maxRequestSize = 4 * units.MiB
+)
+
+type handler func(context.Context, []byte) (any, *Error)
+
+// Server serves the Rosetta API of the chain a [Client] is connected to.
+type Server struct {
+	c           Controller
+	cli         Client
+	nodeVersion string
+	handlers    map[string]handler
+
+	l           sync.Mutex
+	network     *NetworkIdentifier
+	genesisHash string
+}
+
+func NewServer(c Controller, cli Client, nodeVersion string) *Server {
+	s := &Server{c: c, cli: cli, nodeVersion: nodeVersion}
+	s.handlers = map[string]handler{
+		"/network/list":                  s.networkList,
+		"/network/status":                handle(s, s.networkStatus),
+		"/network/options":               handle(s, s.networkOptions),
+		"/block":                         handle(s, s.block),
+		"/block/transaction":             handle(s, s.blockTransaction),
+		"/account/balance":               handle(s, s.accountBalance),
+		"/mempool":                       handle(s, s.mempool),
+		"/mempool/transaction":           handle(s, s.mempoolTransaction),
+		"/construction/derive":           handle(s, s.constructionDerive),
+		"/construction/preprocess":       handle(s, s.constructionPreprocess),
+		"/construction/metadata":         handle(s, s.constructionMetadata),
+		"/construction/payloads":         handle(s, s.constructionPayloads),
+		"/construction/combine":          handle(s, s.constructionCombine),
+		"/construction/parse":            handle(s, s.constructionParse),
+		"/construction/hash":             handle(s, s.constructionHash),
+		"/construction/submit":           handle(s, s.constructionSubmit),
+		"/call":                          unsupported,
+		"/events/blocks":                 unsupported,
+		"/search/transactions":           unsupported,
+		"/account/coins":                 unsupported,
+		"/construction/preprocess/batch": unsupported,
+	}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h, ok := s.handlers[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	var (
+		resp any
+		rerr *Error
+	)
+	if err != nil {
+		rerr = ErrInvalidRequest.wrap(err)
+	} else {
+		resp, rerr = h(r.Context(), body)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if rerr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		resp = rerr
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handle decodes requests for [f] after checking that they are sent to the
+// network of [s].
+func handle[T any](s *Server, f func(context.Context, *T) (any, *Error)) handler {
+	return func(ctx context.Context, body []byte) (any, *Error) {
+		var r NetworkRequest
+		if err := json.Unmarshal(body, &r); err != nil {
+			return nil, ErrInvalidRequest.wrap(err)
+		}
+		network, rerr := s.networkIdentifier(ctx)
+		if rerr != nil {
+			return nil, rerr
+		}
+		if r.NetworkIdentifier == nil || *r.NetworkIdentifier != *network {
+			return nil, ErrUnknownNetwork
+		}
+		req := new(T)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, ErrInvalidRequest.wrap(err)
+		}
+		return f(ctx, req)
+	}
+}
+
+func unsupported(context.Context, []byte) (any, *Error) {
+	return nil, ErrUnsupported
+}
+
+func (s *Server) networkIdentifier(ctx context.Context) (*NetworkIdentifier, *Error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if s.network == nil {
+		_, _, chainID, err := s.cli.Network(ctx)
+		if err != nil {
+			return nil, ErrUnavailable.wrap(err)
+		}
+		s.network = &NetworkIdentifier{Blockchain: s.c.Blockchain(), Network: chainID.String()}
+	}
+	return s.network, nil
+}
+
+// genesisIdentifier returns the identifier of the genesis block, which is the
+// parent of the first block (the genesis block is not indexed).
+func (s *Server) genesisIdentifier(ctx context.Context) (*BlockIdentifier, *Error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if len(s.genesisHash) == 0 {
+		blk, _, _, err := s.cli.GetIndexedBlock(ctx, s.c, 1)
+		if err != nil {
+			return nil, clientError(err)
+		}
+		s.genesisHash = blk.Prnt.String()
+	}
+	return &BlockIdentifier{Index: 0, Hash: s.genesisHash}, nil
+}
+
+// clientError converts an error returned by the [Client] to an [Error].
+func clientError(err error) *Error {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, rpc.ErrBlockMissing.Error()):
+		return ErrBlockMissing.wrap(err)
+	case strings.Contains(msg, rpc.ErrTxMissing.Error()):
+		return ErrTxMissing.wrap(err)
+	default:
+		return ErrUnavailable.wrap(err)
+	}
+}
+
+func (s *Server) networkList(ctx context.Context, _ []byte) (any, *Error) {
+	network, rerr := s.networkIdentifier(ctx)
+	if rerr != nil {
+		return nil, rerr
+	}
+	return &NetworkListResponse{NetworkIdentifiers: []*NetworkIdentifier{network}}, nil
+}
+
+func (s *Server) networkStatus(ctx context.Context, _ *NetworkRequest) (any, *Error) {
+	genesis, rerr := s.genesisIdentifier(ctx)
+	if rerr != nil {
+		return nil, rerr
+	}
+	blkID, height, timestamp, err := s.cli.Accepted(ctx)
+	if err != nil {
+		return nil, ErrUnavailable.wrap(err)
+	}
+	return &NetworkStatusResponse{
+		CurrentBlockIdentifier: &BlockIdentifier{Index: int64(height), Hash: blkID.String()},
+		CurrentBlockTimestamp:  timestamp,
+		GenesisBlockIdentifier: genesis,
+		Peers:                  []*Peer{},
+	}, nil
+}
+
+func (s *Server) networkOptions(context.Context, *NetworkRequest) (any, *Error) {
+	return &NetworkOptionsResponse{
+		Version: &Version{RosettaVersion: RosettaVersion, NodeVersion: s.nodeVersion},
+		Allow: &Allow{
+			OperationStatuses: []*OperationStatus{
+				{Status: StatusSuccess, Successful: true},
+				{Status: StatusFailure, Successful: false},
+			},
+			OperationTypes:          append(slices.Clone(s.c.OperationTypes()), FeeOperation),
+			Errors:                  errs,
+			HistoricalBalanceLookup: true,
+		},
+	}, nil
+}
+
+// height returns the height of the block identified by [id] (the last
+// accepted block if [id] is empty).
+func (s *Server) height(ctx context.Context, id *PartialBlockIdentifier) (uint64, *Error) {
+	switch {
+	case id != nil && id.Index != nil:
+		if *id.Index < 0 {
+			return 0, ErrInvalidRequest.wrapf("invalid index %d", *id.Index)
+		}
+		return uint64(*id.Index), nil
+	case id != nil && id.Hash != nil:
+		blkID, err := ids.FromString(*id.Hash)
+		if err != nil {
+			return 0, ErrInvalidRequest.wrap(err)
+		}
+		genesis, rerr := s.genesisIdentifier(ctx)
+		if rerr != nil {
+			return 0, rerr
+		}
+		if *id.Hash == genesis.Hash {
+			return 0, nil
+		}
+		height, err := s.cli.GetIndexedBlockHeight(ctx, blkID)
+		if err != nil {
+			return 0, clientError(err)
+		}
+		return height, nil
+	default:
+		_, height, _, err := s.cli.Accepted(ctx)
+		if err != nil {
+			return 0, ErrUnavailable.wrap(err)
+		}
+		return height, nil
+	}
+}
+
+func (s *Server) block(ctx context.Context, r *BlockRequest) (any, *Error) {
+	height, rerr := s.height(ctx, r.BlockIdentifier)
+	if rerr != nil {
+		return nil, rerr
+	}
+	genesis, rerr := s.genesisIdentifier(ctx)
+	if rerr != nil {
+		return nil, rerr
+	}
+	var blk *Block
+	if height == 0 {
+		ops, err := s.c.GenesisOperations(ctx)
+		if err != nil {
+			return nil, ErrUnavailable.wrap(err)
+		}
+		setIdentifiers(ops, 0, nil)
+		for _, op := range ops {
+			op.Status = status(true)
+		}
+		blk = &Block{
+			BlockIdentifier:       genesis,
+			ParentBlockIdentifier: genesis,
+			Transactions: []*Transaction{{
+				TransactionIdentifier: &TransactionIdentifier{Hash: genesis.Hash},
+				Operations:            ops,
+			}},
+		}
+	} else {
+		sblk, results, _, err := s.cli.GetIndexedBlock(ctx, s.c, height)
+		if err != nil {
+			return nil, clientError(err)
+		}
+		blkID, err := sblk.ID()
+		if err != nil {
+			return nil, ErrInvalidTransaction.wrap(err)
+		}
+		blk = &Block{
+			BlockIdentifier:       &BlockIdentifier{Index: int64(height), Hash: blkID.String()},
+			ParentBlockIdentifier: &BlockIdentifier{Index: int64(height) - 1, Hash: sblk.Prnt.String()},
+			Timestamp:             sblk.Tmstmp,
+			Transactions:          make([]*Transaction, len(sblk.Txs)),
+		}
+		for i, tx := range sblk.Txs {
+			blk.Transactions[i], rerr = s.transaction(tx, results[i])
+			if rerr != nil {
+				return nil, rerr
+			}
+		}
+	}
+	if r.BlockIdentifier != nil && r.BlockIdentifier.Hash != nil && *r.BlockIdentifier.Hash != blk.BlockIdentifier.Hash {
+		return nil, ErrBlockMissing.wrapf("block %d is %s", height, blk.BlockIdentifier.Hash)
+	}
+	return &BlockResponse{Block: blk}, nil
+}
+
+func (s *Server) blockTransaction(ctx context.Context, r *BlockTransactionRequest) (any, *Error) {
+	if r.TransactionIdentifier == nil || r.BlockIdentifier == nil {
+		return nil, ErrInvalidRequest
+	}
+	txID, err := ids.FromString(r.TransactionIdentifier.Hash)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	tx, result, height, _, err := s.cli.GetIndexedTx(ctx, s.c, txID)
+	if err != nil {
+		return nil, clientError(err)
+	}
+	if int64(height) != r.BlockIdentifier.Index {
+		return nil, ErrTxMissing.wrapf("%s is in block %d", txID, height)
+	}
+	t, rerr := s.transaction(tx, result)
+	if rerr != nil {
+		return nil, rerr
+	}
+	return &BlockTransactionResponse{Transaction: t}, nil
+}
+
+// transaction converts [tx], which produced [result], to a Rosetta
+// transaction. The operations of the actions of failed transactions are
+// included with the [StatusFailure] status.
+func (s *Server) transaction(tx *chain.Transaction, result *chain.Result) (*Transaction, *Error) {
+	ops, rerr := s.operations(tx.Auth.Actor(), tx.Actions)
+	if rerr != nil {
+		return nil, rerr
+	}
+	for _, op := range ops {
+		op.Status = status(result.Success)
+	}
+	if result.Fee > 0 {
+		ops = append(ops, &Operation{
+			OperationIdentifier: &OperationIdentifier{Index: int64(len(ops))},
+			Type:                FeeOperation,
+			Status:              status(true),
+			Account:             &AccountIdentifier{Address: s.c.Address(tx.Sponsor())},
+			Amount:              s.amount(result.Fee, true),
+		})
+	}
+	t := &Transaction{
+		TransactionIdentifier: &TransactionIdentifier{Hash: tx.ID().String()},
+		Operations:            ops,
+	}
+	if !result.Success {
+		t.Metadata = map[string]any{"error": string(result.Error)}
+	}
+	return t, nil
+}
+
+// operations returns the operations of [actions] (executed by [actor]) with
+// their identifiers. Each operation is related to the first operation of its
+// action.
+func (s *Server) operations(actor codec.Address, actions []chain.Action) ([]*Operation, *Error) {
+	ops := []*Operation{}
+	for _, action := range actions {
+		aops, err := s.c.Operations(actor, action)
+		if err != nil {
+			return nil, ErrInvalidTransaction.wrap(err)
+		}
+		first := int64(len(ops))
+		setIdentifiers(aops, first, &OperationIdentifier{Index: first})
+		ops = append(ops, aops...)
+	}
+	return ops, nil
+}
+
+func setIdentifiers(ops []*Operation, start int64, related *OperationIdentifier) {
+	for i, op := range ops {
+		op.OperationIdentifier = &OperationIdentifier{Index: start + int64(i)}
+		op.RelatedOperations = nil
+		if i > 0 && related != nil {
+			op.RelatedOperations = []*OperationIdentifier{related}
+		}
+	}
+}
+
+func status(success bool) *string {
+	s := StatusFailure
+	if success {
+		s = StatusSuccess
+	}
+	return &s
+}
+
+func (s *Server) amount(value uint64, debit bool) *Amount {
+	return NewAmount(value, debit, s.c.Currency())
+}
+
+// NewAmount returns the [Amount] that credits (or debits) [value].
+func NewAmount(value uint64, debit bool, currency *Currency) *Amount {
+	v := strconv.FormatUint(value, 10)
+	if debit && value > 0 {
+		v = "-" + v
+	}
+	return &Amount{Value: v, Currency: currency}
+}
+
+// ParseAmount returns the value of [amount] and whether it is debited.
+func ParseAmount(amount *Amount, currency *Currency) (uint64, bool, error) {
+	if amount == nil || amount.Currency == nil || *amount.Currency != *currency {
+		return 0, false, ErrInvalidOperations.wrapf("amount must be in %s", currency.Symbol)
+	}
+	v, debit := strings.CutPrefix(amount.Value, "-")
+	value, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, false, ErrInvalidOperations.wrap(err)
+	}
+	return value, debit, nil
+}
+
+// blockIdentifier returns the full identifier of the block identified by [id].
+func (s *Server) blockIdentifier(ctx context.Context, id *PartialBlockIdentifier) (*BlockIdentifier, *Error) {
+	height, rerr := s.height(ctx, id)
+	if rerr != nil {
+		return nil, rerr
+	}
+	if height == 0 {
+		return s.genesisIdentifier(ctx)
+	}
+	blk, _, _, err := s.cli.GetIndexedBlock(ctx, s.c, height)
+	if err != nil {
+		return nil, clientError(err)
+	}
+	blkID, err := blk.ID()
+	if err != nil {
+		return nil, ErrInvalidTransaction.wrap(err)
+	}
+	return &BlockIdentifier{Index: int64(height), Hash: blkID.String()}, nil
+}
+
+func (s *Server) accountBalance(ctx context.Context, r *AccountBalanceRequest) (any, *Error) {
+	if r.AccountIdentifier == nil {
+		return nil, ErrInvalidRequest
+	}
+	addr, err := s.c.ParseAddress(r.AccountIdentifier.Address)
+	if err != nil {
+		return nil, ErrInvalidAddress.wrap(err)
+	}
+	var (
+		height uint64
+		blkID  ids.ID
+	)
+	if r.BlockIdentifier == nil || (r.BlockIdentifier.Index == nil && r.BlockIdentifier.Hash == nil) {
+		blkID, height, _, err = s.cli.Accepted(ctx)
+		if err != nil {
+			return nil, ErrUnavailable.wrap(err)
+		}
+	} else {
+		id, rerr := s.blockIdentifier(ctx, r.BlockIdentifier)
+		if rerr != nil {
+			return nil, rerr
+		}
+		height = uint64(id.Index)
+		blkID, err = ids.FromString(id.Hash)
+		if err != nil {
+			return nil, ErrInvalidTransaction.wrap(err)
+		}
+	}
+	balance, err := s.c.Balance(ctx, addr, height)
+	if err != nil {
+		return nil, ErrUnavailable.wrap(err)
+	}
+	return &AccountBalanceResponse{
+		BlockIdentifier: &BlockIdentifier{Index: int64(height), Hash: blkID.String()},
+		Balances:        []*Amount{s.amount(balance, false)},
+	}, nil
+}
+
+// mempool returns no transactions because nodes do not expose the contents of
+// their mempool.
+func (*Server) mempool(context.Context, *NetworkRequest) (any, *Error) {
+	return &MempoolResponse{TransactionIdentifiers: []*TransactionIdentifier{}}, nil
+}
+
+func (*Server) mempoolTransaction(context.Context, *MempoolTransactionRequest) (any, *Error) {
+	return nil, ErrTxMissing
+}
+
+// parseTx parses a signed transaction encoded by the construction endpoints.
+func (s *Server) parseTx(hexTx string) (*chain.Transaction, *Error) {
+	b, err := codec.LoadHex(hexTx, -1)
+	if err != nil {
+		return nil, ErrInvalidTransaction.wrap(err)
+	}
+	return s.unmarshalTx(b, true)
+}
+
+func (s *Server) unmarshalTx(b []byte, signed bool) (*chain.Transaction, *Error) {
+	actionRegistry, authRegistry := s.c.Registry()
+	p := codec.NewReader(b, consts.NetworkSizeLimit)
+	var (
+		tx  *chain.Transaction
+		err error
+	)
+	if signed {
+		tx, err = chain.UnmarshalTx(p, actionRegistry, authRegistry)
+	} else {
+		tx, err = chain.UnmarshalUnsignedTx(p, actionRegistry)
+	}
+	if err == nil && !p.Empty() {
+		err = chain.ErrInvalidObject
+	}
+	if err != nil {
+		return nil, ErrInvalidTransaction.wrap(err)
+	}
+	return tx, nil
+}
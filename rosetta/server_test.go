@@ -0,0 +1,492 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rosetta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/auth"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const testTransferType = "TRANSFER"
+
+var (
+	testChainID  = ids.GenerateTestID()
+	testCurrency = &Currency{Symbol: "TEST", Decimals: 9}
+	testNetwork  = &NetworkIdentifier{Blockchain: "testvm", Network: testChainID.String()}
+
+	testAddresses = address.MustNew("test", nil)
+
+	errTestUnsupported = errors.New("unsupported operations")
+)
+
+type testTransfer struct {
+	chain.Action `json:"-"`
+
+	To    codec.Address
+	Value uint64
+}
+
+func (*testTransfer) GetTypeID() uint8 { return 0 }
+
+func (*testTransfer) Size() int { return codec.AddressLen + consts.Uint64Len }
+
+func (*testTransfer) ComputeUnits(chain.Rules) uint64 { return 1 }
+
+func (*testTransfer) StateKeysMaxChunks() []uint16 { return []uint16{1, 1} }
+
+func (t *testTransfer) Marshal(p *codec.Packer) {
+	p.PackAddress(t.To)
+	p.PackUint64(t.Value)
+}
+
+func unmarshalTestTransfer(p *codec.Packer) (chain.Action, error) {
+	var t testTransfer
+	p.UnpackAddress(&t.To)
+	t.Value = p.UnpackUint64(true)
+	return &t, p.Err()
+}
+
+type testRules struct {
+	chain.Rules
+}
+
+func (*testRules) GetValidityWindow() int64 { return 60_000 }
+
+func (*testRules) GetBaseComputeUnits() uint64 { return 1 }
+
+func (*testRules) GetSponsorStateKeysMaxChunks() []uint16 { return []uint16{1} }
+
+func (*testRules) GetStorageKeyReadUnits() uint64 { return 1 }
+
+func (*testRules) GetStorageValueReadUnits() uint64 { return 1 }
+
+func (*testRules) GetStorageKeyAllocateUnits() uint64 { return 1 }
+
+func (*testRules) GetStorageValueAllocateUnits() uint64 { return 1 }
+
+func (*testRules) GetStorageKeyWriteUnits() uint64 { return 1 }
+
+func (*testRules) GetStorageValueWriteUnits() uint64 { return 1 }
+
+type testController struct {
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+	balances       map[codec.Address]uint64
+}
+
+func newTestController(t *testing.T) *testController {
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(t, actionRegistry.Register((&testTransfer{}).GetTypeID(), unmarshalTestTransfer))
+	require.NoError(t, authRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519))
+	return &testController{
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+		balances:       map[codec.Address]uint64{},
+	}
+}
+
+func (*testController) ChainID() ids.ID { return testChainID }
+
+func (*testController) Rules(int64) chain.Rules { return &testRules{} }
+
+func (c *testController) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return c.actionRegistry, c.authRegistry
+}
+
+func (*testController) StateManager() chain.StateManager { return nil }
+
+func (*testController) Blockchain() string { return testNetwork.Blockchain }
+
+func (*testController) Currency() *Currency { return testCurrency }
+
+func (*testController) ParseAddress(s string) (codec.Address, error) {
+	return testAddresses.Parse(s)
+}
+
+func (*testController) Address(addr codec.Address) string {
+	return testAddresses.Encode(addr)
+}
+
+func (*testController) OperationTypes() []string { return []string{testTransferType} }
+
+func (c *testController) Operations(actor codec.Address, action chain.Action) ([]*Operation, error) {
+	transfer := action.(*testTransfer)
+	return []*Operation{
+		{Type: testTransferType, Account: &AccountIdentifier{Address: c.Address(actor)}, Amount: NewAmount(transfer.Value, true, testCurrency)},
+		{Type: testTransferType, Account: &AccountIdentifier{Address: c.Address(transfer.To)}, Amount: NewAmount(transfer.Value, false, testCurrency)},
+	}, nil
+}
+
+func (c *testController) Actions(ops []*Operation) (codec.Address, []chain.Action, error) {
+	if len(ops) != 2 {
+		return codec.EmptyAddress, nil, errTestUnsupported
+	}
+	from, err := c.ParseAddress(ops[0].Account.Address)
+	if err != nil {
+		return codec.EmptyAddress, nil, err
+	}
+	to, err := c.ParseAddress(ops[1].Account.Address)
+	if err != nil {
+		return codec.EmptyAddress, nil, err
+	}
+	value, debit, err := ParseAmount(ops[1].Amount, testCurrency)
+	if err != nil || debit {
+		return codec.EmptyAddress, nil, errTestUnsupported
+	}
+	return from, []chain.Action{&testTransfer{To: to, Value: value}}, nil
+}
+
+func (c *testController) Balance(_ context.Context, addr codec.Address, _ uint64) (uint64, error) {
+	return c.balances[addr], nil
+}
+
+func (c *testController) GenesisOperations(context.Context) ([]*Operation, error) {
+	ops := []*Operation{}
+	for addr, balance := range c.balances {
+		ops = append(ops, &Operation{
+			Type:    testTransferType,
+			Account: &AccountIdentifier{Address: c.Address(addr)},
+			Amount:  NewAmount(balance, false, testCurrency),
+		})
+	}
+	return ops, nil
+}
+
+type testClient struct {
+	blocks    []*chain.StatefulBlock
+	results   [][]*chain.Result
+	submitted [][]byte
+}
+
+func (*testClient) Network(context.Context) (uint32, ids.ID, ids.ID, error) {
+	return 1, ids.Empty, testChainID, nil
+}
+
+func (c *testClient) Accepted(context.Context) (ids.ID, uint64, int64, error) {
+	blk := c.blocks[len(c.blocks)-1]
+	blkID, err := blk.ID()
+	return blkID, blk.Hght, blk.Tmstmp, err
+}
+
+func (c *testClient) GetIndexedBlock(
+	_ context.Context,
+	_ chain.Parser,
+	height uint64,
+) (*chain.StatefulBlock, []*chain.Result, fees.Dimensions, error) {
+	if height == 0 || height > uint64(len(c.blocks)) {
+		return nil, nil, fees.Dimensions{}, rpc.ErrBlockMissing
+	}
+	return c.blocks[height-1], c.results[height-1], fees.Dimensions{}, nil
+}
+
+func (c *testClient) GetIndexedBlockHeight(_ context.Context, blkID ids.ID) (uint64, error) {
+	for _, blk := range c.blocks {
+		if id, _ := blk.ID(); id == blkID {
+			return blk.Hght, nil
+		}
+	}
+	return 0, rpc.ErrBlockMissing
+}
+
+func (c *testClient) GetIndexedTx(
+	_ context.Context,
+	_ chain.Parser,
+	txID ids.ID,
+) (*chain.Transaction, *chain.Result, uint64, int64, error) {
+	for i, blk := range c.blocks {
+		for j, tx := range blk.Txs {
+			if tx.ID() == txID {
+				return tx, c.results[i][j], blk.Hght, blk.Tmstmp, nil
+			}
+		}
+	}
+	return nil, nil, 0, 0, rpc.ErrTxMissing
+}
+
+func (*testClient) UnitPrices(context.Context, bool) (fees.Dimensions, error) {
+	return fees.Dimensions{1, 1, 1, 1, 1}, nil
+}
+
+func (c *testClient) SubmitTx(_ context.Context, tx []byte) (ids.ID, error) {
+	c.submitted = append(c.submitted, tx)
+	return ids.ID{}, nil
+}
+
+func post[T any](t *testing.T, s *Server, path string, req any) (*T, *Error) {
+	require := require.New(t)
+	body, err := json.Marshal(req)
+	require.NoError(err)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		require.Equal(http.StatusInternalServerError, w.Code)
+		var rerr Error
+		require.NoError(json.Unmarshal(w.Body.Bytes(), &rerr))
+		return nil, &rerr
+	}
+	resp := new(T)
+	require.NoError(json.Unmarshal(w.Body.Bytes(), resp))
+	return resp, nil
+}
+
+func newTestKey(t *testing.T) (ed25519.PrivateKey, codec.Address) {
+	priv, err := ed25519.GeneratePrivateKey()
+	require.NoError(t, err)
+	return priv, auth.NewED25519Address(priv.PublicKey())
+}
+
+func TestDataAPI(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestController(t)
+	priv, sender := newTestKey(t)
+	_, recipient := newTestKey(t)
+	c.balances[sender] = 1_000
+
+	actionRegistry, authRegistry := c.Registry()
+	tx, err := chain.NewTx(
+		&chain.Base{Timestamp: 1_000, ChainID: testChainID, MaxFee: 100},
+		[]chain.Action{&testTransfer{To: recipient, Value: 10}},
+	).Sign(auth.NewED25519Factory(priv), actionRegistry, authRegistry)
+	require.NoError(err)
+
+	genesisID := ids.GenerateTestID()
+	cli := &testClient{
+		blocks: []*chain.StatefulBlock{
+			{Prnt: genesisID, Tmstmp: 1_000, Hght: 1},
+			{Tmstmp: 2_000, Hght: 2, Txs: []*chain.Transaction{tx}},
+		},
+		results: [][]*chain.Result{{}, {{Success: true, Fee: 5}}},
+	}
+	blk1ID, err := cli.blocks[0].ID()
+	require.NoError(err)
+	cli.blocks[1].Prnt = blk1ID
+	blk2ID, err := cli.blocks[1].ID()
+	require.NoError(err)
+	s := NewServer(c, cli, "v0.0.1")
+
+	list, rerr := post[NetworkListResponse](t, s, "/network/list", struct{}{})
+	require.Nil(rerr)
+	require.Equal([]*NetworkIdentifier{testNetwork}, list.NetworkIdentifiers)
+
+	_, rerr = post[NetworkStatusResponse](t, s, "/network/status", &NetworkRequest{
+		NetworkIdentifier: &NetworkIdentifier{Blockchain: "othervm", Network: testChainID.String()},
+	})
+	require.Equal(ErrUnknownNetwork.Code, rerr.Code)
+
+	status, rerr := post[NetworkStatusResponse](t, s, "/network/status", &NetworkRequest{NetworkIdentifier: testNetwork})
+	require.Nil(rerr)
+	require.Equal(&BlockIdentifier{Index: 0, Hash: genesisID.String()}, status.GenesisBlockIdentifier)
+	require.Equal(&BlockIdentifier{Index: 2, Hash: blk2ID.String()}, status.CurrentBlockIdentifier)
+
+	options, rerr := post[NetworkOptionsResponse](t, s, "/network/options", &NetworkRequest{NetworkIdentifier: testNetwork})
+	require.Nil(rerr)
+	require.Equal([]string{testTransferType, FeeOperation}, options.Allow.OperationTypes)
+
+	// The genesis block contains the allocations
+	index := int64(0)
+	genesis, rerr := post[BlockResponse](t, s, "/block", &BlockRequest{
+		NetworkIdentifier: testNetwork,
+		BlockIdentifier:   &PartialBlockIdentifier{Index: &index},
+	})
+	require.Nil(rerr)
+	require.Len(genesis.Block.Transactions, 1)
+	require.Len(genesis.Block.Transactions[0].Operations, 1)
+	require.Equal("1000", genesis.Block.Transactions[0].Operations[0].Amount.Value)
+
+	hash := blk2ID.String()
+	blk, rerr := post[BlockResponse](t, s, "/block", &BlockRequest{
+		NetworkIdentifier: testNetwork,
+		BlockIdentifier:   &PartialBlockIdentifier{Hash: &hash},
+	})
+	require.Nil(rerr)
+	require.Equal(&BlockIdentifier{Index: 1, Hash: blk1ID.String()}, blk.Block.ParentBlockIdentifier)
+	require.Len(blk.Block.Transactions, 1)
+	ops := blk.Block.Transactions[0].Operations
+	require.Len(ops, 3)
+	require.Equal("-10", ops[0].Amount.Value)
+	require.Equal("10", ops[1].Amount.Value)
+	require.Equal([]*OperationIdentifier{{Index: 0}}, ops[1].RelatedOperations)
+	require.Equal(FeeOperation, ops[2].Type)
+	require.Equal("-5", ops[2].Amount.Value)
+	require.Equal(StatusSuccess, *ops[2].Status)
+
+	btx, rerr := post[BlockTransactionResponse](t, s, "/block/transaction", &BlockTransactionRequest{
+		NetworkIdentifier:     testNetwork,
+		BlockIdentifier:       &BlockIdentifier{Index: 2, Hash: hash},
+		TransactionIdentifier: &TransactionIdentifier{Hash: tx.ID().String()},
+	})
+	require.Nil(rerr)
+	require.Equal(blk.Block.Transactions[0], btx.Transaction)
+
+	missing := int64(3)
+	_, rerr = post[BlockResponse](t, s, "/block", &BlockRequest{
+		NetworkIdentifier: testNetwork,
+		BlockIdentifier:   &PartialBlockIdentifier{Index: &missing},
+	})
+	require.Equal(ErrBlockMissing.Code, rerr.Code)
+	require.True(rerr.Retriable)
+
+	balance, rerr := post[AccountBalanceResponse](t, s, "/account/balance", &AccountBalanceRequest{
+		NetworkIdentifier: testNetwork,
+		AccountIdentifier: &AccountIdentifier{Address: c.Address(sender)},
+		BlockIdentifier:   &PartialBlockIdentifier{Index: &index},
+	})
+	require.Nil(rerr)
+	require.Equal(status.GenesisBlockIdentifier, balance.BlockIdentifier)
+	require.Equal("1000", balance.Balances[0].Value)
+}
+
+func TestConstructionAPI(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestController(t)
+	cli := &testClient{
+		blocks:  []*chain.StatefulBlock{{Prnt: ids.GenerateTestID(), Hght: 1}},
+		results: [][]*chain.Result{{}},
+	}
+	s := NewServer(c, cli, "v0.0.1")
+	priv, sender := newTestKey(t)
+	_, recipient := newTestKey(t)
+	pub := priv.PublicKey()
+	pk := &PublicKey{HexBytes: codec.ToHex(pub[:]), CurveType: CurveEdwards25519}
+
+	derive, rerr := post[ConstructionDeriveResponse](t, s, "/construction/derive", &ConstructionDeriveRequest{
+		NetworkIdentifier: testNetwork,
+		PublicKey:         pk,
+	})
+	require.Nil(rerr)
+	require.Equal(c.Address(sender), derive.AccountIdentifier.Address)
+
+	ops := []*Operation{
+		{
+			OperationIdentifier: &OperationIdentifier{Index: 0},
+			Type:                testTransferType,
+			Account:             &AccountIdentifier{Address: c.Address(sender)},
+			Amount:              NewAmount(10, true, testCurrency),
+		},
+		{
+			OperationIdentifier: &OperationIdentifier{Index: 1},
+			RelatedOperations:   []*OperationIdentifier{{Index: 0}},
+			Type:                testTransferType,
+			Account:             &AccountIdentifier{Address: c.Address(recipient)},
+			Amount:              NewAmount(10, false, testCurrency),
+		},
+	}
+	_, rerr = post[ConstructionPreprocessResponse](t, s, "/construction/preprocess", &ConstructionPreprocessRequest{
+		NetworkIdentifier: testNetwork,
+		Operations:        append(ops[:2:2], &Operation{Type: FeeOperation}),
+	})
+	require.Equal(ErrInvalidOperations.Code, rerr.Code)
+
+	preprocess, rerr := post[ConstructionPreprocessResponse](t, s, "/construction/preprocess", &ConstructionPreprocessRequest{
+		NetworkIdentifier: testNetwork,
+		Operations:        ops,
+	})
+	require.Nil(rerr)
+	require.Equal([]*AccountIdentifier{{Address: c.Address(sender)}}, preprocess.RequiredPublicKeys)
+
+	metadata, rerr := post[ConstructionMetadataResponse](t, s, "/construction/metadata", &ConstructionMetadataRequest{
+		NetworkIdentifier: testNetwork,
+		Options:           preprocess.Options,
+	})
+	require.Nil(rerr)
+	require.Len(metadata.SuggestedFee, 1)
+
+	payloads, rerr := post[ConstructionPayloadsResponse](t, s, "/construction/payloads", &ConstructionPayloadsRequest{
+		NetworkIdentifier: testNetwork,
+		Operations:        ops,
+		Metadata:          metadata.Metadata,
+	})
+	require.Nil(rerr)
+	require.Len(payloads.Payloads, 1)
+
+	unsigned, rerr := post[ConstructionParseResponse](t, s, "/construction/parse", &ConstructionParseRequest{
+		NetworkIdentifier: testNetwork,
+		Transaction:       payloads.UnsignedTransaction,
+	})
+	require.Nil(rerr)
+	require.Equal(ops, unsigned.Operations)
+	require.Empty(unsigned.AccountIdentifierSigners)
+
+	digest, err := codec.LoadHex(payloads.Payloads[0].HexBytes, -1)
+	require.NoError(err)
+	sig := ed25519.Sign(digest, priv)
+	signature := &Signature{
+		SigningPayload: payloads.Payloads[0],
+		PublicKey:      pk,
+		SignatureType:  SignatureTypeEd25519,
+		HexBytes:       codec.ToHex(sig[:]),
+	}
+
+	// Signatures of other keys are rejected
+	otherPriv, _ := newTestKey(t)
+	otherSig := ed25519.Sign(digest, otherPriv)
+	_, rerr = post[ConstructionCombineResponse](t, s, "/construction/combine", &ConstructionCombineRequest{
+		NetworkIdentifier:   testNetwork,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures: []*Signature{{
+			SigningPayload: payloads.Payloads[0],
+			PublicKey:      pk,
+			SignatureType:  SignatureTypeEd25519,
+			HexBytes:       codec.ToHex(otherSig[:]),
+		}},
+	})
+	require.Equal(ErrInvalidSignature.Code, rerr.Code)
+
+	combine, rerr := post[ConstructionCombineResponse](t, s, "/construction/combine", &ConstructionCombineRequest{
+		NetworkIdentifier:   testNetwork,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures:          []*Signature{signature},
+	})
+	require.Nil(rerr)
+
+	signed, rerr := post[ConstructionParseResponse](t, s, "/construction/parse", &ConstructionParseRequest{
+		NetworkIdentifier: testNetwork,
+		Signed:            true,
+		Transaction:       combine.SignedTransaction,
+	})
+	require.Nil(rerr)
+	require.Equal(ops, signed.Operations)
+	require.Equal([]*AccountIdentifier{{Address: c.Address(sender)}}, signed.AccountIdentifierSigners)
+
+	txBytes, err := codec.LoadHex(combine.SignedTransaction, -1)
+	require.NoError(err)
+	actionRegistry, authRegistry := c.Registry()
+	tx, err := chain.UnmarshalTx(codec.NewReader(txBytes, consts.NetworkSizeLimit), actionRegistry, authRegistry)
+	require.NoError(err)
+	require.NoError(tx.Auth.Verify(context.Background(), digest))
+
+	hash, rerr := post[TransactionIdentifierResponse](t, s, "/construction/hash", &ConstructionHashRequest{
+		NetworkIdentifier: testNetwork,
+		SignedTransaction: combine.SignedTransaction,
+	})
+	require.Nil(rerr)
+	require.Equal(tx.ID().String(), hash.TransactionIdentifier.Hash)
+
+	_, rerr = post[TransactionIdentifierResponse](t, s, "/construction/submit", &ConstructionSubmitRequest{
+		NetworkIdentifier: testNetwork,
+		SignedTransaction: combine.SignedTransaction,
+	})
+	require.Nil(rerr)
+	require.Equal([][]byte{txBytes}, cli.submitted)
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rosetta
+
+// The types below are the subset of the Rosetta models
+// (https://github.com/coinbase/mesh-specifications) used by [Server].
+
+type NetworkIdentifier struct {
+	Blockchain string `json:"blockchain"`
+	Network    string `json:"network"`
+}
+
+type BlockIdentifier struct {
+	Index int64  `json:"index"`
+	Hash  string `json:"hash"`
+}
+
+type PartialBlockIdentifier struct {
+	Index *int64  `json:"index,omitempty"`
+	Hash  *string `json:"hash,omitempty"`
+}
+
+type TransactionIdentifier struct {
+	Hash string `json:"hash"`
+}
+
+type AccountIdentifier struct {
+	Address string `json:"address"`
+}
+
+type Currency struct {
+	Symbol   string `json:"symbol"`
+	Decimals int32  `json:"decimals"`
+}
+
+type Amount struct {
+	Value    string    `json:"value"`
+	Currency *Currency `json:"currency"`
+}
+
+type OperationIdentifier struct {
+	Index int64 `json:"index"`
+}
+
+type Operation struct {
+	OperationIdentifier *OperationIdentifier   `json:"operation_identifier"`
+	RelatedOperations   []*OperationIdentifier `json:"related_operations,omitempty"`
+	Type                string                 `json:"type"`
+	Status              *string                `json:"status,omitempty"`
+	Account             *AccountIdentifier     `json:"account,omitempty"`
+	Amount              *Amount                `json:"amount,omitempty"`
+	Metadata            map[string]any         `json:"metadata,omitempty"`
+}
+
+type Transaction struct {
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+	Operations            []*Operation           `json:"operations"`
+	Metadata              map[string]any         `json:"metadata,omitempty"`
+}
+
+type Block struct {
+	BlockIdentifier       *BlockIdentifier `json:"block_identifier"`
+	ParentBlockIdentifier *BlockIdentifier `json:"parent_block_identifier"`
+	Timestamp             int64            `json:"timestamp"`
+	Transactions          []*Transaction   `json:"transactions"`
+}
+
+type PublicKey struct {
+	HexBytes  string `json:"hex_bytes"`
+	CurveType string `json:"curve_type"`
+}
+
+type SigningPayload struct {
+	AccountIdentifier *AccountIdentifier `json:"account_identifier"`
+	HexBytes          string             `json:"hex_bytes"`
+	SignatureType     string             `json:"signature_type"`
+}
+
+type Signature struct {
+	SigningPayload *SigningPayload `json:"signing_payload"`
+	PublicKey      *PublicKey      `json:"public_key"`
+	SignatureType  string          `json:"signature_type"`
+	HexBytes       string          `json:"hex_bytes"`
+}
+
+type OperationStatus struct {
+	Status     string `json:"status"`
+	Successful bool   `json:"successful"`
+}
+
+type Version struct {
+	RosettaVersion string `json:"rosetta_version"`
+	NodeVersion    string `json:"node_version"`
+}
+
+type Allow struct {
+	OperationStatuses       []*OperationStatus `json:"operation_statuses"`
+	OperationTypes          []string           `json:"operation_types"`
+	Errors                  []*Error           `json:"errors"`
+	HistoricalBalanceLookup bool               `json:"historical_balance_lookup"`
+	MempoolCoins            bool               `json:"mempool_coins"`
+}
+
+type Peer struct {
+	PeerID string `json:"peer_id"`
+}
+
+type NetworkRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+}
+
+type NetworkListResponse struct {
+	NetworkIdentifiers []*NetworkIdentifier `json:"network_identifiers"`
+}
+
+type NetworkStatusResponse struct {
+	CurrentBlockIdentifier *BlockIdentifier `json:"current_block_identifier"`
+	CurrentBlockTimestamp  int64            `json:"current_block_timestamp"`
+	GenesisBlockIdentifier *BlockIdentifier `json:"genesis_block_identifier"`
+	Peers                  []*Peer          `json:"peers"`
+}
+
+type NetworkOptionsResponse struct {
+	Version *Version `json:"version"`
+	Allow   *Allow   `json:"allow"`
+}
+
+type BlockRequest struct {
+	NetworkIdentifier *NetworkIdentifier      `json:"network_identifier"`
+	BlockIdentifier   *PartialBlockIdentifier `json:"block_identifier"`
+}
+
+type BlockResponse struct {
+	Block *Block `json:"block"`
+}
+
+type BlockTransactionRequest struct {
+	NetworkIdentifier     *NetworkIdentifier     `json:"network_identifier"`
+	BlockIdentifier       *BlockIdentifier       `json:"block_identifier"`
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+}
+
+type BlockTransactionResponse struct {
+	Transaction *Transaction `json:"transaction"`
+}
+
+type AccountBalanceRequest struct {
+	NetworkIdentifier *NetworkIdentifier      `json:"network_identifier"`
+	AccountIdentifier *AccountIdentifier      `json:"account_identifier"`
+	BlockIdentifier   *PartialBlockIdentifier `json:"block_identifier,omitempty"`
+}
+
+type AccountBalanceResponse struct {
+	BlockIdentifier *BlockIdentifier `json:"block_identifier"`
+	Balances        []*Amount        `json:"balances"`
+}
+
+type MempoolResponse struct {
+	TransactionIdentifiers []*TransactionIdentifier `json:"transaction_identifiers"`
+}
+
+type MempoolTransactionRequest struct {
+	NetworkIdentifier     *NetworkIdentifier     `json:"network_identifier"`
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+}
+
+type ConstructionDeriveRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	PublicKey         *PublicKey         `json:"public_key"`
+}
+
+type ConstructionDeriveResponse struct {
+	AccountIdentifier *AccountIdentifier `json:"account_identifier"`
+}
+
+type ConstructionPreprocessRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	Operations        []*Operation       `json:"operations"`
+}
+
+type ConstructionPreprocessResponse struct {
+	Options            map[string]any       `json:"options"`
+	RequiredPublicKeys []*AccountIdentifier `json:"required_public_keys"`
+}
+
+type ConstructionMetadataRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	Options           map[string]any     `json:"options"`
+}
+
+type ConstructionMetadataResponse struct {
+	Metadata     map[string]any `json:"metadata"`
+	SuggestedFee []*Amount      `json:"suggested_fee"`
+}
+
+type ConstructionPayloadsRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	Operations        []*Operation       `json:"operations"`
+	Metadata          map[string]any     `json:"metadata"`
+}
+
+type ConstructionPayloadsResponse struct {
+	UnsignedTransaction string            `json:"unsigned_transaction"`
+	Payloads            []*SigningPayload `json:"payloads"`
+}
+
+type ConstructionCombineRequest struct {
+	NetworkIdentifier   *NetworkIdentifier `json:"network_identifier"`
+	UnsignedTransaction string             `json:"unsigned_transaction"`
+	Signatures          []*Signature       `json:"signatures"`
+}
+
+type ConstructionCombineResponse struct {
+	SignedTransaction string `json:"signed_transaction"`
+}
+
+type ConstructionParseRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	Signed            bool               `json:"signed"`
+	Transaction       string             `json:"transaction"`
+}
+
+type ConstructionParseResponse struct {
+	Operations               []*Operation         `json:"operations"`
+	AccountIdentifierSigners []*AccountIdentifier `json:"account_identifier_signers"`
+}
+
+type ConstructionHashRequest struct {
+	NetworkIdentifier *NetworkIdentifier `json:"network_identifier"`
+	SignedTransaction string             `json:"signed_transaction"`
+}
+
+type ConstructionSubmitRequest = ConstructionHashRequest
+
+type TransactionIdentifierResponse struct {
+	TransactionIdentifier *TransactionIdentifier `json:"transaction_identifier"`
+}
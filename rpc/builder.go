@@ -0,0 +1,278 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/pubsub"
+)
+
+// Modes of the messages sent over [BuilderEndpoint].
+const (
+	// BuilderPendingMode messages are sent by the node for each change of
+	// its mempool (packed by [PackPendingTxMessage])
+	BuilderPendingMode byte = 0
+	// BuilderResultMode messages are sent by the node in response to each
+	// proposal (packed by [PackProposalResult])
+	BuilderResultMode byte = 1
+	// BuilderSnapshotMode messages are sent by the builder to receive every
+	// transaction in the mempool (as [PendingAdmitted] events)
+	BuilderSnapshotMode byte = 2
+	// BuilderProposalMode messages are sent by the builder to propose the
+	// transactions of the next block (packed by [PackProposal])
+	BuilderProposalMode byte = 3
+)
+
+// Proposal is an ordered list of transactions (in the mempool of the node)
+// proposed by an external builder for the child of [Parent].
+//
+// The node builds its next block with the transactions of the proposal
+// first (if the block is built on [Parent]), and each of them is verified
+// like any other transaction from the mempool. Each proposal replaces the
+// previous one.
+type Proposal struct {
+	Parent ids.ID   `json:"parent"`
+	TxIDs  []ids.ID `json:"txIDs"`
+}
+
+func PackProposal(p *Proposal) ([]byte, error) {
+	w := codec.NewWriter(ids.IDLen+consts.IntLen+len(p.TxIDs)*ids.IDLen, consts.NetworkSizeLimit)
+	w.PackID(p.Parent)
+	w.PackInt(len(p.TxIDs))
+	for _, txID := range p.TxIDs {
+		w.PackID(txID)
+	}
+	return w.Bytes(), w.Err()
+}
+
+// UnpackProposal returns the [Proposal] packed by [PackProposal]. It
+// returns [ErrInvalidProposal] if the proposal is empty, has more than
+// [maxTxs] transactions, or includes a transaction more than once.
+func UnpackProposal(msg []byte, maxTxs int) (*Proposal, error) {
+	var (
+		p     = codec.NewReader(msg, consts.NetworkSizeLimit)
+		prop  Proposal
+		count int
+	)
+	p.UnpackID(true, &prop.Parent)
+	count = p.UnpackInt(false)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if count == 0 || count > maxTxs {
+		return nil, fmt.Errorf("%w: %d txs (max=%d)", ErrInvalidProposal, count, maxTxs)
+	}
+	prop.TxIDs = make([]ids.ID, 0, count)
+	seen := set.NewSet[ids.ID](count)
+	for i := 0; i < count; i++ {
+		var txID ids.ID
+		p.UnpackID(true, &txID)
+		if seen.Contains(txID) {
+			return nil, fmt.Errorf("%w: duplicate tx %s", ErrInvalidProposal, txID)
+		}
+		seen.Add(txID)
+		prop.TxIDs = append(prop.TxIDs, txID)
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &prop, p.Err()
+}
+
+// ProposalResult is the response of the node to a [Proposal].
+type ProposalResult struct {
+	Parent ids.ID `json:"parent"`
+	// Known is the number of proposed transactions in the mempool of the
+	// node (the others are ignored)
+	Known int `json:"known"`
+	// Error is set if the proposal was rejected
+	Error string `json:"error"`
+}
+
+func PackProposalResult(r *ProposalResult) ([]byte, error) {
+	p := codec.NewWriter(ids.IDLen+consts.IntLen+codec.StringLen(r.Error), consts.NetworkSizeLimit)
+	p.PackID(r.Parent)
+	p.PackInt(r.Known)
+	p.PackString(r.Error)
+	return p.Bytes(), p.Err()
+}
+
+func UnpackProposalResult(msg []byte) (*ProposalResult, error) {
+	var (
+		p = codec.NewReader(msg, consts.NetworkSizeLimit)
+		r ProposalResult
+	)
+	p.UnpackID(false, &r.Parent)
+	r.Known = p.UnpackInt(false)
+	r.Error = p.UnpackString(false)
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &r, p.Err()
+}
+
+// BuilderClient is the connection of an external builder to
+// [BuilderEndpoint].
+type BuilderClient struct {
+	cl   sync.Once
+	conn *websocket.Conn
+
+	mb           *pubsub.MessageBuffer
+	writeStopped chan struct{}
+	readStopped  chan struct{}
+
+	pendingTxs     chan []byte
+	pendingResults chan []byte
+
+	err  error
+	errl sync.Once
+}
+
+// NewBuilderClient dials into the builder endpoint of the node at [uri],
+// authenticated with [token].
+func NewBuilderClient(uri string, token string, handshakeTimeout time.Duration, pending int, maxSize int) (*BuilderClient, error) {
+	uri = strings.ReplaceAll(uri, "http://", "ws://")
+	uri = strings.ReplaceAll(uri, "https://", "wss://")
+	if !strings.HasPrefix(uri, "ws") { // fallback to default usage
+		uri = "ws://" + uri
+	}
+	uri = strings.TrimSuffix(uri, "/")
+	uri += BuilderEndpoint
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: handshakeTimeout,
+	}
+	conn, resp, err := dialer.Dial(uri, http.Header{"Authorization": []string{"Bearer " + token}})
+	if resp != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, ErrUnauthorized
+		}
+		return nil, err
+	}
+	bc := &BuilderClient{
+		conn:           conn,
+		mb:             pubsub.NewMessageBuffer(&logging.NoLog{}, pending, maxSize, pubsub.MaxMessageWait),
+		readStopped:    make(chan struct{}),
+		writeStopped:   make(chan struct{}),
+		pendingTxs:     make(chan []byte, pending),
+		pendingResults: make(chan []byte, pending),
+	}
+	go func() {
+		defer close(bc.readStopped)
+		for {
+			_, msgBatch, err := conn.ReadMessage()
+			if err != nil {
+				bc.errl.Do(func() {
+					bc.err = err
+				})
+				return
+			}
+			msgs, err := pubsub.ParseBatchMessage(pubsub.MaxWriteMessageSize, msgBatch)
+			if err != nil {
+				continue
+			}
+			for _, msg := range msgs {
+				if len(msg) == 0 {
+					continue
+				}
+				switch msg[0] {
+				case BuilderPendingMode:
+					bc.pendingTxs <- msg[1:]
+				case BuilderResultMode:
+					bc.pendingResults <- msg[1:]
+				}
+			}
+		}
+	}()
+	go func() {
+		defer close(bc.writeStopped)
+		for {
+			select {
+			case msg, ok := <-bc.mb.Queue:
+				if !ok {
+					return
+				}
+				if err := bc.conn.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+					bc.errl.Do(func() {
+						bc.err = err
+					})
+					_ = bc.conn.Close()
+					return
+				}
+			case <-bc.readStopped:
+				_ = bc.mb.Close()
+				return
+			}
+		}
+	}()
+	return bc, nil
+}
+
+// RequestSnapshot requests every transaction in the mempool of the node
+// (sent as [PendingAdmitted] events).
+func (c *BuilderClient) RequestSnapshot() error {
+	return c.mb.Send([]byte{BuilderSnapshotMode})
+}
+
+// Propose proposes the transactions of the child of [parent] (in order).
+func (c *BuilderClient) Propose(parent ids.ID, txIDs []ids.ID) error {
+	msg, err := PackProposal(&Proposal{Parent: parent, TxIDs: txIDs})
+	if err != nil {
+		return err
+	}
+	return c.mb.Send(append([]byte{BuilderProposalMode}, msg...))
+}
+
+// ListenPending listens for changes of the mempool of the node (like
+// [PendingAdmitted]).
+func (c *BuilderClient) ListenPending(ctx context.Context, parser chain.Parser) (byte, *chain.Transaction, error) {
+	select {
+	case msg := <-c.pendingTxs:
+		return UnpackPendingTxMessage(msg, parser)
+	case <-c.readStopped:
+		return 0, nil, c.err
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	}
+}
+
+// ListenResult listens for the responses of the node to proposals.
+func (c *BuilderClient) ListenResult(ctx context.Context) (*ProposalResult, error) {
+	select {
+	case msg := <-c.pendingResults:
+		return UnpackProposalResult(msg)
+	case <-c.readStopped:
+		return nil, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *BuilderClient) Close() error {
+	var err error
+	c.cl.Do(func() {
+		// Flush all unwritten messages before we close the connection
+		_ = c.mb.Close()
+		<-c.writeStopped
+		err = c.conn.Close()
+	})
+	return err
+}
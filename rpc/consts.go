@@ -6,9 +6,12 @@ package rpc
 import "time"
 
 const (
-	Name              = "hypersdk"
-	JSONRPCEndpoint   = "/coreapi"
-	WebSocketEndpoint = "/corews"
+	Name               = "hypersdk"
+	JSONRPCEndpoint    = "/coreapi"
+	WebSocketEndpoint  = "/corews"
+	EthEndpoint        = "/eth"
+	BuilderEndpoint    = "/corebuilder"
+	CheckpointEndpoint = "/corecheckpoint"
 
 	DefaultHandshakeTimeout = 10 * time.Second
 )
@@ -10,8 +10,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/fees"
 )
 
@@ -28,9 +31,120 @@ type VM interface {
 		txs []*chain.Transaction,
 	) (errs []error)
 	LastAcceptedBlock() *chain.StatelessBlock
+	Mempool() chain.Mempool
 	UnitPrices(context.Context) (fees.Dimensions, error)
+	Rules(int64) chain.Rules
 	CurrentValidators(
 		context.Context,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{})
+	// ValidatorSet returns the P-Chain height of the current validator set
+	// and its validators.
+	ValidatorSet(context.Context) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// ProposerWindows returns the P-Chain height the proposers are sampled at,
+	// the height of the next block, and the first [windows] proposers of each
+	// of the [heights] blocks starting at that height.
+	ProposerWindows(ctx context.Context, heights int, windows int) (uint64, uint64, [][]ids.NodeID, error)
+	// ProposerSchedule returns the first [slots] slots of the child of the
+	// preferred block.
+	ProposerSchedule(ctx context.Context, slots int) (*ProposerSchedule, error)
 	GetVerifyAuth() bool
+	GetWarpMessage(msgID ids.ID) (*warp.UnsignedMessage, error)
+	// GetFeeSplit returns how the base fees of the accepted block at [height]
+	// were split (if the block is still stored and was executed).
+	GetFeeSplit(height uint64) (*chain.FeeSplit, error)
+	GetWarpSignatures(msgID ids.ID) ([]*chain.WarpSignature, error)
+	RequestWarpSignatures(ctx context.Context, msg *warp.UnsignedMessage) error
+	// Indexer returns nil if the node doesn't index accepted blocks
+	Indexer() Indexer
+	// Webhooks returns nil if the node doesn't send webhook notifications
+	Webhooks() Webhooks
+	// FeeHistory returns nil if the node doesn't keep a fee history
+	FeeHistory() FeeHistory
+	// TxStatuses returns nil if the node doesn't track transactions
+	TxStatuses() TxStatuses
+	// StateDiffs returns nil if the node doesn't keep state diffs
+	StateDiffs() StateDiffs
+	// Invariants returns nil if the hypervm doesn't register invariants
+	Invariants() Invariants
+	// TxTracer returns nil if the node doesn't trace transactions
+	TxTracer() TxTracer
+	// UnitProfiler returns nil if the node doesn't profile units
+	UnitProfiler() UnitProfiler
+	// AuditLog returns nil if the node doesn't record an audit log
+	AuditLog() *audit.Logger
+}
+
+// EthVM is the [VM] served by [EthServer].
+type EthVM interface {
+	VM
+	chain.Parser
+
+	// NativeBalance returns the balance of [addr] in the native asset of the
+	// hypervm (or [ErrNativeBalanceUnsupported] if it doesn't expose one).
+	NativeBalance(ctx context.Context, addr codec.Address) (uint64, error)
+}
+
+// Indexer serves the blocks and transactions indexed by the node. Lookups of
+// missing entries return [database.ErrNotFound].
+type Indexer interface {
+	GetBlock(height uint64) ([]byte, error)
+	GetBlockHeight(blkID ids.ID) (uint64, error)
+	GetTx(txID ids.ID) (height uint64, timestamp int64, tx []byte, result []byte, err error)
+	GetTxsByAddress(addr codec.Address, cursor []byte, limit int) ([]ids.ID, []byte, error)
+	GetTxsByActionType(typeID uint8, cursor []byte, limit int) ([]ids.ID, []byte, error)
+	GetTxsByKey(key []byte, cursor []byte, limit int) ([]ids.ID, []byte, error)
+}
+
+// FeeHistory serves the fees of the most recently accepted blocks.
+type FeeHistory interface {
+	// Blocks returns the fees of (up to) the last [count] accepted blocks,
+	// oldest first, including the fees paid at each of [percentiles].
+	Blocks(count int, percentiles []float64) []*BlockFees
+}
+
+// UnitProfiler serves where the units consumed by the most recently accepted
+// blocks were spent.
+type UnitProfiler interface {
+	// Profile aggregates the units consumed by the profiled blocks with
+	// heights in [start, end] (or returns [ErrUnitProfileMissing] if none are
+	// profiled).
+	Profile(start uint64, end uint64) (*UnitProfile, error)
+}
+
+// StateDiffs serves the changes the most recently accepted blocks made to
+// state.
+type StateDiffs interface {
+	// Get returns the changes of the accepted block at [height] (or
+	// [database.ErrNotFound] if they aren't kept).
+	Get(height uint64) (*StateDiff, error)
+}
+
+// Webhooks manages the webhooks that are notified when watched addresses send
+// or receive transactions.
+type Webhooks interface {
+	// Authorized returns true if [token] can manage webhooks.
+	Authorized(token string) bool
+	// Register returns the ID of the new webhook and the secret its payloads
+	// are signed with.
+	Register(url string, addresses []codec.Address) (ids.ID, []byte, error)
+	Unregister(id ids.ID) error
+}
+
+// Invariants checks the invariants registered by the hypervm against the
+// accepted state.
+type Invariants interface {
+	// Authorized returns true if [token] can check invariants.
+	Authorized(token string) bool
+	// Check returns the height of the accepted state that was checked and the
+	// invariants it violates.
+	Check(ctx context.Context) (uint64, []*InvariantViolation, error)
+}
+
+// TxTracer re-executes accepted transactions to debug them.
+type TxTracer interface {
+	// Authorized returns true if [token] can trace transactions.
+	Authorized(token string) bool
+	// Trace re-executes the accepted transaction [txID] and returns every
+	// state access it made (or [ErrTxMissing] if it isn't indexed).
+	Trace(ctx context.Context, txID ids.ID) (*chain.TxTrace, error)
 }
@@ -9,4 +9,46 @@ var (
 	ErrClosed         = errors.New("closed")
 	ErrExpired        = errors.New("expired")
 	ErrMessageMissing = errors.New("message missing")
+	ErrInvalidQuorum  = errors.New("invalid quorum")
+
+	ErrIndexerDisabled = errors.New("indexer disabled")
+	ErrBlockMissing    = errors.New("block missing")
+	ErrTxMissing       = errors.New("tx missing")
+
+	ErrWebhooksDisabled = errors.New("webhooks disabled")
+	ErrUnauthorized     = errors.New("unauthorized")
+
+	ErrInvariantsDisabled = errors.New("invariants disabled")
+
+	ErrTxTracingDisabled = errors.New("tx tracing disabled")
+
+	ErrNativeBalanceUnsupported = errors.New("native balance unsupported")
+
+	ErrFeeHistoryDisabled = errors.New("fee history disabled")
+	ErrInvalidBlockCount  = errors.New("invalid block count")
+	ErrInvalidPercentiles = errors.New("invalid percentiles")
+
+	ErrTooManyFilterAddresses = errors.New("too many filter addresses")
+
+	ErrTxStatusesDisabled = errors.New("tx statuses disabled")
+	ErrInvalidTxState     = errors.New("invalid tx state")
+
+	ErrInvalidHeightCount = errors.New("invalid height count")
+	ErrInvalidWindowCount = errors.New("invalid window count")
+	ErrInvalidSlotCount   = errors.New("invalid slot count")
+
+	ErrInvalidProposal = errors.New("invalid proposal")
+
+	ErrFeeSplitMissing = errors.New("fee split missing")
+
+	ErrStateDiffsDisabled = errors.New("state diffs disabled")
+	ErrStateDiffMissing   = errors.New("state diff missing")
+
+	ErrUnitProfilingDisabled = errors.New("unit profiling disabled")
+	ErrUnitProfileMissing    = errors.New("unit profile missing")
+	ErrInvalidHeightRange    = errors.New("invalid height range")
+
+	ErrUnexpectedParams   = errors.New("unexpected params")
+	ErrInvalidTopicKind   = errors.New("invalid topic message kind")
+	ErrTopicCommandFailed = errors.New("topic command failed")
 )
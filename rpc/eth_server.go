@@ -0,0 +1,344 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	ethVersion        = "2.0"
+	ethMaxRequestSize = 4 * units.MiB
+	ethMaxBatchSize   = 100
+
+	// JSON-RPC 2.0 error codes
+	ethParseError     = -32700
+	ethInvalidRequest = -32600
+	ethMethodNotFound = -32601
+	ethInvalidParams  = -32602
+	ethServerError    = -32000
+
+	ethLogsBloomLen = 256
+)
+
+var (
+	errEthInvalidParams    = errors.New("invalid params")
+	errEthUnsupportedBlock = errors.New("only the latest block is supported")
+)
+
+// EthChainID is the Ethereum chain ID reported for [chainID]: the first 6
+// bytes of [chainID] interpreted as a big-endian integer (which fits in the
+// integers wallets can represent).
+func EthChainID(chainID ids.ID) uint64 {
+	var b [consts.Uint64Len]byte
+	copy(b[2:], chainID[:6])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+type ethRequest struct {
+	Version string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type ethError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type ethResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *ethError       `json:"error,omitempty"`
+}
+
+// EthReceipt is the receipt returned by eth_getTransactionReceipt. Fees are
+// reported as the gas used by a transaction (with a gas price of 1).
+type EthReceipt struct {
+	TransactionHash   string    `json:"transactionHash"`
+	TransactionIndex  string    `json:"transactionIndex"`
+	BlockHash         string    `json:"blockHash"`
+	BlockNumber       string    `json:"blockNumber"`
+	From              string    `json:"from"`
+	To                *string   `json:"to"`
+	CumulativeGasUsed string    `json:"cumulativeGasUsed"`
+	GasUsed           string    `json:"gasUsed"`
+	EffectiveGasPrice string    `json:"effectiveGasPrice"`
+	ContractAddress   *string   `json:"contractAddress"`
+	Logs              []*string `json:"logs"`
+	LogsBloom         string    `json:"logsBloom"`
+	Status            string    `json:"status"`
+	Type              string    `json:"type"`
+}
+
+type ethMethod func(ctx context.Context, params []json.RawMessage) (any, error)
+
+// EthServer translates a subset of the Ethereum JSON-RPC API into hypersdk
+// calls so that generic wallet and monitoring tooling can query a node:
+//
+//   - eth_chainId returns [EthChainID]
+//   - eth_blockNumber returns the height of the last accepted block
+//   - eth_getBalance returns the balance of a hex-encoded [codec.Address] in
+//     the native asset of the hypervm (see [EthVM.NativeBalance])
+//   - eth_sendRawTransaction submits a hex-encoded signed hypersdk transaction
+//     and returns its ID
+//   - eth_getTransactionReceipt returns the [EthReceipt] of an indexed
+//     transaction
+//
+// Because accepted blocks are final, every block tag refers to the last
+// accepted block.
+type EthServer struct {
+	vm      EthVM
+	methods map[string]ethMethod
+}
+
+func NewEthServer(vm EthVM) *EthServer {
+	s := &EthServer{vm: vm}
+	s.methods = map[string]ethMethod{
+		"eth_chainId":               s.chainID,
+		"eth_blockNumber":           s.blockNumber,
+		"eth_getBalance":            s.getBalance,
+		"eth_sendRawTransaction":    s.sendRawTransaction,
+		"eth_getTransactionReceipt": s.getTransactionReceipt,
+	}
+	return s
+}
+
+func (s *EthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, span := s.vm.Tracer().Start(r.Context(), "EthServer.ServeHTTP")
+	defer span.End()
+
+	w.Header().Set("Content-Type", "application/json")
+	body, err := io.ReadAll(io.LimitReader(r.Body, ethMaxRequestSize))
+	if err != nil {
+		_ = json.NewEncoder(w).Encode(ethFailure(nil, ethParseError, err))
+		return
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '[' {
+		var req ethRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			_ = json.NewEncoder(w).Encode(ethFailure(nil, ethParseError, err))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s.handle(ctx, &req))
+		return
+	}
+	var reqs []*ethRequest
+	if err := json.Unmarshal(body, &reqs); err != nil {
+		_ = json.NewEncoder(w).Encode(ethFailure(nil, ethParseError, err))
+		return
+	}
+	if len(reqs) == 0 || len(reqs) > ethMaxBatchSize {
+		_ = json.NewEncoder(w).Encode(ethFailure(nil, ethInvalidRequest, fmt.Errorf("batches must contain 1-%d requests", ethMaxBatchSize)))
+		return
+	}
+	resps := make([]*ethResponse, len(reqs))
+	for i, req := range reqs {
+		resps[i] = s.handle(ctx, req)
+	}
+	_ = json.NewEncoder(w).Encode(resps)
+}
+
+func ethFailure(id json.RawMessage, code int, err error) *ethResponse {
+	return &ethResponse{Version: ethVersion, ID: id, Error: &ethError{Code: code, Message: err.Error()}}
+}
+
+func (s *EthServer) handle(ctx context.Context, req *ethRequest) *ethResponse {
+	if req.Version != ethVersion {
+		return ethFailure(req.ID, ethInvalidRequest, fmt.Errorf("jsonrpc must be %q", ethVersion))
+	}
+	method, ok := s.methods[req.Method]
+	if !ok {
+		return ethFailure(req.ID, ethMethodNotFound, fmt.Errorf("method %s not supported", req.Method))
+	}
+	result, err := method(ctx, req.Params)
+	switch {
+	case errors.Is(err, errEthInvalidParams), errors.Is(err, errEthUnsupportedBlock):
+		return ethFailure(req.ID, ethInvalidParams, err)
+	case err != nil:
+		s.vm.Logger().Debug("eth request failed",
+			zap.String("method", req.Method),
+			zap.Error(err),
+		)
+		return ethFailure(req.ID, ethServerError, err)
+	}
+	if result == nil {
+		// Missing objects are returned as null
+		return &ethResponse{Version: ethVersion, ID: req.ID, Result: json.RawMessage("null")}
+	}
+	return &ethResponse{Version: ethVersion, ID: req.ID, Result: result}
+}
+
+func ethQuantity(v uint64) string {
+	return "0x" + strconv.FormatUint(v, 16)
+}
+
+func ethData(b []byte) string {
+	return "0x" + codec.ToHex(b)
+}
+
+// ethParam unmarshals the string parameter at index [i] and decodes it as
+// hex data.
+func ethParam(params []json.RawMessage, i int) ([]byte, error) {
+	if len(params) <= i {
+		return nil, fmt.Errorf("%w: missing param %d", errEthInvalidParams, i)
+	}
+	var s string
+	if err := json.Unmarshal(params[i], &s); err != nil {
+		return nil, fmt.Errorf("%w: %w", errEthInvalidParams, err)
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("%w: param %d must be 0x-prefixed", errEthInvalidParams, i)
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errEthInvalidParams, err)
+	}
+	return b, nil
+}
+
+func (s *EthServer) chainID(context.Context, []json.RawMessage) (any, error) {
+	return ethQuantity(EthChainID(s.vm.ChainID())), nil
+}
+
+func (s *EthServer) blockNumber(context.Context, []json.RawMessage) (any, error) {
+	return ethQuantity(s.vm.LastAcceptedBlock().Hght), nil
+}
+
+func (s *EthServer) getBalance(ctx context.Context, params []json.RawMessage) (any, error) {
+	b, err := ethParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := codec.ToAddress(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errEthInvalidParams, err)
+	}
+	if len(params) > 1 {
+		var tag string
+		if err := json.Unmarshal(params[1], &tag); err != nil {
+			return nil, fmt.Errorf("%w: %w", errEthInvalidParams, err)
+		}
+		switch tag {
+		case "latest", "pending", "safe", "finalized":
+		default:
+			return nil, errEthUnsupportedBlock
+		}
+	}
+	balance, err := s.vm.NativeBalance(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	return ethQuantity(balance), nil
+}
+
+func (s *EthServer) sendRawTransaction(ctx context.Context, params []json.RawMessage) (any, error) {
+	b, err := ethParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	actionRegistry, authRegistry := s.vm.Registry()
+	p := codec.NewReader(b, consts.NetworkSizeLimit)
+	tx, err := chain.UnmarshalTx(p, actionRegistry, authRegistry)
+	if err == nil && !p.Empty() {
+		err = chain.ErrInvalidObject
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errEthInvalidParams, err)
+	}
+	if err := s.vm.Submit(ctx, true, []*chain.Transaction{tx})[0]; err != nil {
+		return nil, err
+	}
+	txID := tx.ID()
+	return ethData(txID[:]), nil
+}
+
+func (s *EthServer) getTransactionReceipt(_ context.Context, params []json.RawMessage) (any, error) {
+	b, err := ethParam(params, 0)
+	if err != nil {
+		return nil, err
+	}
+	txID, err := ids.ToID(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errEthInvalidParams, err)
+	}
+	indexer := s.vm.Indexer()
+	if indexer == nil {
+		return nil, ErrIndexerDisabled
+	}
+	height, _, _, _, err := indexer.GetTx(txID)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	msg, err := indexer.GetBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	blk, results, _, err := UnpackBlockMessage(msg, s.vm)
+	if err != nil {
+		return nil, err
+	}
+	blkID, err := blk.ID()
+	if err != nil {
+		return nil, err
+	}
+	var cumulativeFee uint64
+	for i, tx := range blk.Txs {
+		result := results[i]
+		cumulativeFee += result.Fee
+		if tx.ID() != txID {
+			continue
+		}
+		status := uint64(0)
+		if result.Success {
+			status = 1
+		}
+		actor := tx.Auth.Actor()
+		return &EthReceipt{
+			TransactionHash:   ethData(txID[:]),
+			TransactionIndex:  ethQuantity(uint64(i)),
+			BlockHash:         ethData(blkID[:]),
+			BlockNumber:       ethQuantity(height),
+			From:              ethData(actor[:]),
+			CumulativeGasUsed: ethQuantity(cumulativeFee),
+			GasUsed:           ethQuantity(result.Fee),
+			EffectiveGasPrice: ethQuantity(1),
+			Logs:              []*string{},
+			LogsBloom:         ethData(make([]byte, ethLogsBloomLen)),
+			Status:            ethQuantity(status),
+			Type:              ethQuantity(0),
+		}, nil
+	}
+	// Should never happen because transactions are indexed with their block
+	return nil, fmt.Errorf("%w: %s not in block %d", ErrTxMissing, txID, height)
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+type testAction struct {
+	chain.Action
+
+	value uint64
+}
+
+func (*testAction) GetTypeID() uint8 { return 0 }
+
+func (*testAction) Size() int { return consts.Uint64Len }
+
+func (a *testAction) Marshal(p *codec.Packer) { p.PackUint64(a.value) }
+
+func unmarshalTestAction(p *codec.Packer) (chain.Action, error) {
+	return &testAction{value: p.UnpackUint64(false)}, p.Err()
+}
+
+// testAuth authorizes transactions of [actor] without a signature.
+type testAuth struct {
+	chain.Auth
+
+	actor codec.Address
+}
+
+func (*testAuth) GetTypeID() uint8 { return 0 }
+
+func (*testAuth) Size() int { return codec.AddressLen }
+
+func (a *testAuth) Marshal(p *codec.Packer) { p.PackAddress(a.actor) }
+
+func (a *testAuth) Actor() codec.Address { return a.actor }
+
+func (a *testAuth) Sponsor() codec.Address { return a.actor }
+
+func unmarshalTestAuth(p *codec.Packer) (chain.Auth, error) {
+	var auth testAuth
+	p.UnpackAddress(&auth.actor)
+	return &auth, p.Err()
+}
+
+type testAuthFactory struct {
+	chain.AuthFactory
+
+	actor codec.Address
+}
+
+func (f *testAuthFactory) Sign([]byte) (chain.Auth, error) {
+	return &testAuth{actor: f.actor}, nil
+}
+
+type testIndexer struct {
+	Indexer
+
+	blocks map[uint64][]byte
+	txs    map[ids.ID]uint64
+}
+
+func (i *testIndexer) GetBlock(height uint64) ([]byte, error) {
+	blk, ok := i.blocks[height]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return blk, nil
+}
+
+func (i *testIndexer) GetTx(txID ids.ID) (uint64, int64, []byte, []byte, error) {
+	height, ok := i.txs[txID]
+	if !ok {
+		return 0, 0, nil, nil, database.ErrNotFound
+	}
+	return height, 0, nil, nil, nil
+}
+
+type testEthVM struct {
+	EthVM
+
+	chainID        ids.ID
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+	lastAccepted   *chain.StatelessBlock
+	balances       map[codec.Address]uint64
+	indexer        Indexer
+	submitted      []*chain.Transaction
+}
+
+func newTestEthVM(t *testing.T) *testEthVM {
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(t, actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(t, authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+	return &testEthVM{
+		chainID:        ids.GenerateTestID(),
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+		balances:       map[codec.Address]uint64{},
+	}
+}
+
+func (vm *testEthVM) ChainID() ids.ID { return vm.chainID }
+
+func (*testEthVM) Tracer() trace.Tracer { return trace.Noop }
+
+func (*testEthVM) Logger() logging.Logger { return logging.NoLog{} }
+
+func (vm *testEthVM) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return vm.actionRegistry, vm.authRegistry
+}
+
+func (vm *testEthVM) LastAcceptedBlock() *chain.StatelessBlock { return vm.lastAccepted }
+
+func (vm *testEthVM) Indexer() Indexer { return vm.indexer }
+
+func (vm *testEthVM) NativeBalance(_ context.Context, addr codec.Address) (uint64, error) {
+	return vm.balances[addr], nil
+}
+
+func (vm *testEthVM) Submit(_ context.Context, _ bool, txs []*chain.Transaction) []error {
+	vm.submitted = append(vm.submitted, txs...)
+	return make([]error, len(txs))
+}
+
+func (vm *testEthVM) newTx(t *testing.T, actor codec.Address, value uint64) *chain.Transaction {
+	tx, err := chain.NewTx(
+		&chain.Base{Timestamp: 1_000, ChainID: vm.chainID, MaxFee: 100},
+		[]chain.Action{&testAction{value: value}},
+	).Sign(&testAuthFactory{actor: actor}, vm.actionRegistry, vm.authRegistry)
+	require.NoError(t, err)
+	return tx
+}
+
+func ethCall(t *testing.T, s *EthServer, body string) *ethResponse {
+	require := require.New(t)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, EthEndpoint, bytes.NewBufferString(body)))
+	require.Equal(http.StatusOK, w.Code)
+	var resp ethResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	return &resp
+}
+
+func TestEthServer(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestEthVM(t)
+	vm.lastAccepted = &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Hght: 26}}
+	addr := codec.CreateAddress(0, ids.GenerateTestID())
+	vm.balances[addr] = 4_096
+	s := NewEthServer(vm)
+
+	resp := ethCall(t, s, `{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)
+	require.Nil(resp.Error)
+	require.Equal(ethQuantity(EthChainID(vm.chainID)), resp.Result)
+	require.Equal(json.RawMessage("1"), resp.ID)
+
+	resp = ethCall(t, s, `{"jsonrpc":"2.0","id":2,"method":"eth_blockNumber"}`)
+	require.Nil(resp.Error)
+	require.Equal("0x1a", resp.Result)
+
+	resp = ethCall(t, s, `{"jsonrpc":"2.0","id":3,"method":"eth_getBalance","params":["`+ethData(addr[:])+`","latest"]}`)
+	require.Nil(resp.Error)
+	require.Equal("0x1000", resp.Result)
+
+	resp = ethCall(t, s, `{"jsonrpc":"2.0","id":4,"method":"eth_getBalance","params":["`+ethData(addr[:])+`","0x1"]}`)
+	require.Equal(ethInvalidParams, resp.Error.Code)
+
+	resp = ethCall(t, s, `{"jsonrpc":"2.0","id":5,"method":"eth_getBalance","params":["0x1234"]}`)
+	require.Equal(ethInvalidParams, resp.Error.Code)
+
+	resp = ethCall(t, s, `{"jsonrpc":"2.0","id":6,"method":"eth_call","params":[]}`)
+	require.Equal(ethMethodNotFound, resp.Error.Code)
+
+	// Requests in a batch are answered in order
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, EthEndpoint, bytes.NewBufferString(
+		`[{"jsonrpc":"2.0","id":7,"method":"eth_blockNumber"},{"jsonrpc":"1.0","id":8,"method":"eth_blockNumber"}]`,
+	)))
+	var resps []*ethResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &resps))
+	require.Len(resps, 2)
+	require.Equal("0x1a", resps[0].Result)
+	require.Equal(ethInvalidRequest, resps[1].Error.Code)
+}
+
+func TestEthServerTransactions(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestEthVM(t)
+	s := NewEthServer(vm)
+	actor := codec.CreateAddress(0, ids.GenerateTestID())
+	tx := vm.newTx(t, actor, 1)
+	txID := tx.ID()
+
+	resp := ethCall(t, s, `{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["`+ethData(tx.Bytes())+`"]}`)
+	require.Nil(resp.Error)
+	require.Equal(ethData(txID[:]), resp.Result)
+	require.Len(vm.submitted, 1)
+	require.Equal(txID, vm.submitted[0].ID())
+
+	resp = ethCall(t, s, `{"jsonrpc":"2.0","id":2,"method":"eth_sendRawTransaction","params":["0x00"]}`)
+	require.Equal(ethInvalidParams, resp.Error.Code)
+
+	receipt := `{"jsonrpc":"2.0","id":3,"method":"eth_getTransactionReceipt","params":["` + ethData(txID[:]) + `"]}`
+	resp = ethCall(t, s, receipt)
+	require.Equal(ethServerError, resp.Error.Code)
+	require.Equal(ErrIndexerDisabled.Error(), resp.Error.Message)
+
+	// Index a block that includes [tx] after another transaction
+	other := vm.newTx(t, actor, 2)
+	blk := &chain.StatefulBlock{Hght: 3, Txs: []*chain.Transaction{other, tx}}
+	blkBytes, err := blk.Marshal()
+	require.NoError(err)
+	results, err := chain.MarshalResults([]*chain.Result{{Success: true, Fee: 7}, {Success: false, Fee: 5}})
+	require.NoError(err)
+	p := codec.NewWriter(0, consts.MaxInt)
+	p.PackBytes(blkBytes)
+	p.PackBytes(results)
+	p.PackFixedBytes(fees.Dimensions{}.Bytes())
+	require.NoError(p.Err())
+	indexer := &testIndexer{blocks: map[uint64][]byte{3: p.Bytes()}, txs: map[ids.ID]uint64{}}
+	vm.indexer = indexer
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, EthEndpoint, bytes.NewBufferString(receipt)))
+	require.JSONEq(`{"jsonrpc":"2.0","id":3,"result":null}`, w.Body.String())
+
+	indexer.txs[txID] = 3
+	resp = ethCall(t, s, receipt)
+	require.Nil(resp.Error)
+	b, err := json.Marshal(resp.Result)
+	require.NoError(err)
+	var r EthReceipt
+	require.NoError(json.Unmarshal(b, &r))
+	blkID, err := blk.ID()
+	require.NoError(err)
+	require.Equal(ethData(txID[:]), r.TransactionHash)
+	require.Equal("0x1", r.TransactionIndex)
+	require.Equal(ethData(blkID[:]), r.BlockHash)
+	require.Equal("0x3", r.BlockNumber)
+	require.Equal(ethData(actor[:]), r.From)
+	require.Equal("0x5", r.GasUsed)
+	require.Equal("0xc", r.CumulativeGasUsed)
+	require.Equal("0x0", r.Status)
+}
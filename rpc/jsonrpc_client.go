@@ -10,8 +10,13 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/requester"
 	"github.com/ava-labs/hypersdk/utils"
@@ -82,6 +87,19 @@ func (cli *JSONRPCClient) Accepted(ctx context.Context) (ids.ID, uint64, int64,
 	return resp.BlockID, resp.Height, resp.Timestamp, err
 }
 
+// Mempool returns the number of transactions in the mempool and their total
+// size (in bytes).
+func (cli *JSONRPCClient) Mempool(ctx context.Context) (int, int, error) {
+	resp := new(MempoolReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"mempool",
+		nil,
+		resp,
+	)
+	return resp.Txs, resp.Size, err
+}
+
 func (cli *JSONRPCClient) UnitPrices(ctx context.Context, useCache bool) (fees.Dimensions, error) {
 	if useCache && time.Since(cli.lastUnitPrices) < unitPricesCacheRefresh {
 		return cli.unitPrices, nil
@@ -104,6 +122,126 @@ func (cli *JSONRPCClient) UnitPrices(ctx context.Context, useCache bool) (fees.D
 	return resp.UnitPrices, nil
 }
 
+// FeeParameters returns the unit prices of the last accepted block and the
+// rules that determine how they change.
+func (cli *JSONRPCClient) FeeParameters(ctx context.Context) (*FeeParametersReply, error) {
+	resp := new(FeeParametersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"feeParameters",
+		nil,
+		resp,
+	)
+	return resp, err
+}
+
+// FeeHistory returns the fees of (up to) the last [blocks] accepted blocks,
+// including the fees paid at each of [percentiles].
+func (cli *JSONRPCClient) FeeHistory(
+	ctx context.Context,
+	blocks int,
+	percentiles []float64,
+) ([]*BlockFees, error) {
+	resp := new(FeeHistoryReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"feeHistory",
+		&FeeHistoryArgs{Blocks: blocks, Percentiles: percentiles},
+		resp,
+	)
+	return resp.Blocks, err
+}
+
+// FeeSplit returns how the base fees collected by the accepted block at
+// [height] were split between its proposer and burning them.
+func (cli *JSONRPCClient) FeeSplit(ctx context.Context, height uint64) (*chain.FeeSplit, error) {
+	resp := new(FeeSplitReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"feeSplit",
+		&FeeSplitArgs{Height: height},
+		resp,
+	)
+	return resp.Split, err
+}
+
+// TxStatus returns the last known status of [txID] (if the node has seen
+// it).
+func (cli *JSONRPCClient) TxStatus(ctx context.Context, txID ids.ID) (*TxStatus, error) {
+	resp := new(TxStatusReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"txStatus",
+		&TxStatusArgs{TxID: txID},
+		resp,
+	)
+	return resp.Status, err
+}
+
+// StateDiff returns the changes the accepted block at [height] made to state.
+func (cli *JSONRPCClient) StateDiff(ctx context.Context, height uint64) (*StateDiff, error) {
+	resp := new(StateDiffReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"stateDiff",
+		&StateDiffArgs{Height: height},
+		resp,
+	)
+	return resp.Diff, err
+}
+
+// UnitProfile returns where the units consumed by the accepted blocks with
+// heights in [start, end] were spent.
+func (cli *JSONRPCClient) UnitProfile(ctx context.Context, start uint64, end uint64) (*UnitProfile, error) {
+	resp := new(UnitProfileReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"unitProfile",
+		&UnitProfileArgs{StartHeight: start, EndHeight: end},
+		resp,
+	)
+	return resp.Profile, err
+}
+
+// Validators returns the current validators of the subnet (sorted by node
+// ID) and the P-Chain height they are defined at.
+func (cli *JSONRPCClient) Validators(ctx context.Context) (*ValidatorsReply, error) {
+	resp := new(ValidatorsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"validators",
+		nil,
+		resp,
+	)
+	return resp, err
+}
+
+// ProposerWindows returns the first [windows] expected proposers of each of
+// the next [heights] blocks.
+func (cli *JSONRPCClient) ProposerWindows(ctx context.Context, heights int, windows int) (*ProposerWindowsReply, error) {
+	resp := new(ProposerWindowsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"proposerWindows",
+		&ProposerWindowsArgs{Heights: heights, Windows: windows},
+		resp,
+	)
+	return resp, err
+}
+
+// ProposerSchedule returns the first [slots] slots of the child of the
+// preferred block of the node.
+func (cli *JSONRPCClient) ProposerSchedule(ctx context.Context, slots int) (*ProposerSchedule, error) {
+	resp := new(ProposerSchedule)
+	err := cli.requester.SendRequest(
+		ctx,
+		"proposerSchedule",
+		&ProposerScheduleArgs{Slots: slots},
+		resp,
+	)
+	return resp, err
+}
+
 func (cli *JSONRPCClient) SubmitTx(ctx context.Context, d []byte) (ids.ID, error) {
 	resp := new(SubmitTxReply)
 	err := cli.requester.SendRequest(
@@ -115,6 +253,124 @@ func (cli *JSONRPCClient) SubmitTx(ctx context.Context, d []byte) (ids.ID, error
 	return resp.TxID, err
 }
 
+// GetWarpSignatures returns an outgoing warp message, the signatures of it
+// collected by the node, and the current validators of the subnet.
+func (cli *JSONRPCClient) GetWarpSignatures(
+	ctx context.Context,
+	msgID ids.ID,
+) (*warp.UnsignedMessage, map[ids.NodeID]*validators.GetValidatorOutput, []*chain.WarpSignature, error) {
+	resp := new(GetWarpSignaturesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getWarpSignatures",
+		&GetWarpSignaturesArgs{MessageID: msgID},
+		resp,
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	msg, err := warp.ParseUnsignedMessage(resp.Message)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(resp.Validators))
+	for _, vdr := range resp.Validators {
+		pk, err := bls.PublicKeyFromCompressedBytes(vdr.PublicKey)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		vdrs[vdr.NodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.NodeID,
+			PublicKey: pk,
+			Weight:    vdr.Weight,
+		}
+	}
+	return msg, vdrs, resp.Signatures, nil
+}
+
+// GenerateAggregateWarpSignature aggregates the signatures collected by the
+// node for [msgID] into a message signed by at least [quorumNum]/[quorumDen]
+// of the stake of the current validators. It also returns the weight of the
+// signers and the total weight of the validators.
+func (cli *JSONRPCClient) GenerateAggregateWarpSignature(
+	ctx context.Context,
+	msgID ids.ID,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, uint64, uint64, error) {
+	unsignedMsg, vdrs, signatures, err := cli.GetWarpSignatures(ctx, msgID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	msg, progress, err := AggregateWarpSignatures(unsignedMsg, vdrs, signatures, quorumNum, quorumDen)
+	if progress == nil {
+		return nil, 0, 0, err
+	}
+	return msg, progress.SignedWeight, progress.TotalWeight, err
+}
+
+// GetAggregateWarpSignature asks the node to aggregate the signatures it has
+// collected for [msgID]. If they are not signed by [quorumNum]/[quorumDen] of
+// the stake of the current validators yet, it returns a nil message and the
+// progress made so far (and the node requests the missing signatures again).
+func (cli *JSONRPCClient) GetAggregateWarpSignature(
+	ctx context.Context,
+	msgID ids.ID,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, *WarpProgress, error) {
+	resp := new(GetAggregateWarpSignatureReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getAggregateWarpSignature",
+		&GetAggregateWarpSignatureArgs{
+			MessageID: msgID,
+			QuorumNum: quorumNum,
+			QuorumDen: quorumDen,
+		},
+		resp,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Message) == 0 {
+		return nil, resp.Progress, nil
+	}
+	msg, err := warp.ParseMessage(resp.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, resp.Progress, nil
+}
+
+// WaitForAggregateWarpSignature polls [GetAggregateWarpSignature] until
+// [msgID] is signed by [quorumNum]/[quorumDen] of the stake of the current
+// validators. [onProgress] (if not nil) is called with the progress of each
+// attempt.
+func (cli *JSONRPCClient) WaitForAggregateWarpSignature(
+	ctx context.Context,
+	msgID ids.ID,
+	quorumNum uint64,
+	quorumDen uint64,
+	onProgress func(*WarpProgress),
+) (*warp.Message, error) {
+	var msg *warp.Message
+	if err := Wait(ctx, func(ctx context.Context) (bool, error) {
+		signed, progress, err := cli.GetAggregateWarpSignature(ctx, msgID, quorumNum, quorumDen)
+		if err != nil {
+			return false, err
+		}
+		if onProgress != nil {
+			onProgress(progress)
+		}
+		msg = signed
+		return msg != nil, nil
+	}); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
 type Modifier interface {
 	Base(*chain.Base)
 }
@@ -154,23 +410,9 @@ func (cli *JSONRPCClient) GenerateTransactionManual(
 	maxFee uint64,
 	modifiers ...Modifier,
 ) (func(context.Context) error, *chain.Transaction, error) {
-	// Construct transaction
-	now := time.Now().UnixMilli()
-	rules := parser.Rules(now)
-	base := &chain.Base{
-		Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
-		ChainID:   rules.ChainID(),
-		MaxFee:    maxFee,
-	}
-
-	// Modify gathered data
-	for _, m := range modifiers {
-		m.Base(base)
-	}
-
 	// Build transaction
 	actionRegistry, authRegistry := parser.Registry()
-	tx := chain.NewTx(base, actions)
+	tx := chain.NewTx(newBase(parser, maxFee, modifiers...), actions)
 	tx, err := tx.Sign(authFactory, actionRegistry, authRegistry)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w: failed to sign transaction", err)
@@ -183,6 +425,49 @@ func (cli *JSONRPCClient) GenerateTransactionManual(
 	}, tx, nil
 }
 
+// GenerateUnsignedTransaction constructs a transaction that is signed later
+// (possibly on another machine) with [chain.Transaction.Sign].
+//
+// [authFactory] is only used to estimate the units of the transaction, so it
+// only needs to be of the same type as the factory that will sign it.
+func (cli *JSONRPCClient) GenerateUnsignedTransaction(
+	ctx context.Context,
+	parser chain.Parser,
+	actions []chain.Action,
+	authFactory chain.AuthFactory,
+	modifiers ...Modifier,
+) (*chain.Transaction, error) {
+	unitPrices, err := cli.UnitPrices(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	units, err := chain.EstimateUnits(parser.Rules(time.Now().UnixMilli()), actions, authFactory)
+	if err != nil {
+		return nil, err
+	}
+	maxFee, err := fees.MulSum(unitPrices, units)
+	if err != nil {
+		return nil, err
+	}
+	return chain.NewTx(newBase(parser, maxFee, modifiers...), actions), nil
+}
+
+func newBase(parser chain.Parser, maxFee uint64, modifiers ...Modifier) *chain.Base {
+	now := time.Now().UnixMilli()
+	rules := parser.Rules(now)
+	base := &chain.Base{
+		Timestamp: utils.UnixRMilli(now, rules.GetValidityWindow()),
+		ChainID:   rules.ChainID(),
+		MaxFee:    maxFee,
+	}
+
+	// Modify gathered data
+	for _, m := range modifiers {
+		m.Base(base)
+	}
+	return base
+}
+
 func Wait(ctx context.Context, check func(ctx context.Context) (bool, error)) error {
 	for ctx.Err() == nil {
 		exit, err := check(ctx)
@@ -196,3 +481,184 @@ func Wait(ctx context.Context, check func(ctx context.Context) (bool, error)) er
 	}
 	return ctx.Err()
 }
+
+// GetIndexedBlock returns the accepted block at [height], its results, and the
+// unit prices it was built with (requires the node to run the indexer).
+func (cli *JSONRPCClient) GetIndexedBlock(
+	ctx context.Context,
+	parser chain.Parser,
+	height uint64,
+) (*chain.StatefulBlock, []*chain.Result, fees.Dimensions, error) {
+	resp := new(GetIndexedBlockReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getIndexedBlock",
+		&GetIndexedBlockArgs{Height: height},
+		resp,
+	)
+	if err != nil {
+		return nil, nil, fees.Dimensions{}, err
+	}
+	return UnpackBlockMessage(resp.Block, parser)
+}
+
+// GetIndexedBlockHeight returns the height of the accepted block [blkID]
+// (requires the node to run the indexer).
+func (cli *JSONRPCClient) GetIndexedBlockHeight(ctx context.Context, blkID ids.ID) (uint64, error) {
+	resp := new(GetIndexedBlockHeightReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getIndexedBlockHeight",
+		&GetIndexedBlockHeightArgs{BlockID: blkID},
+		resp,
+	)
+	return resp.Height, err
+}
+
+// GetIndexedTx returns the accepted transaction [txID], its result, and the
+// height and timestamp of the block that included it (requires the node to
+// run the indexer).
+func (cli *JSONRPCClient) GetIndexedTx(
+	ctx context.Context,
+	parser chain.Parser,
+	txID ids.ID,
+) (*chain.Transaction, *chain.Result, uint64, int64, error) {
+	resp := new(GetIndexedTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getIndexedTx",
+		&GetIndexedTxArgs{TxID: txID},
+		resp,
+	)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	actionRegistry, authRegistry := parser.Registry()
+	tx, err := chain.UnmarshalTx(codec.NewReader(resp.Tx, consts.NetworkSizeLimit), actionRegistry, authRegistry)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	p := codec.NewReader(resp.Result, consts.MaxInt)
+	result, err := chain.UnmarshalResult(p)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	if !p.Empty() {
+		return nil, nil, 0, 0, chain.ErrInvalidObject
+	}
+	return tx, result, resp.Height, resp.Timestamp, nil
+}
+
+// GetTxsByAddress returns up to [limit] IDs of the accepted transactions that
+// reference [addr] (oldest first), starting at [cursor]. The returned cursor
+// can be used to fetch the next page (nil if there are no more transactions).
+func (cli *JSONRPCClient) GetTxsByAddress(
+	ctx context.Context,
+	addr codec.Address,
+	cursor []byte,
+	limit int,
+) ([]ids.ID, []byte, error) {
+	resp := new(IndexedTxsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getTxsByAddress",
+		&GetTxsByAddressArgs{Address: addr, Cursor: cursor, Limit: limit},
+		resp,
+	)
+	return resp.TxIDs, resp.Next, err
+}
+
+// GetTxsByActionType is like [GetTxsByAddress] for transactions that contain
+// an action of [typeID].
+func (cli *JSONRPCClient) GetTxsByActionType(
+	ctx context.Context,
+	typeID uint8,
+	cursor []byte,
+	limit int,
+) ([]ids.ID, []byte, error) {
+	resp := new(IndexedTxsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getTxsByActionType",
+		&GetTxsByActionTypeArgs{TypeID: typeID, Cursor: cursor, Limit: limit},
+		resp,
+	)
+	return resp.TxIDs, resp.Next, err
+}
+
+// GetTxsByKey is like [GetTxsByAddress] for transactions indexed by a
+// hypervm-defined [key].
+func (cli *JSONRPCClient) GetTxsByKey(
+	ctx context.Context,
+	key []byte,
+	cursor []byte,
+	limit int,
+) ([]ids.ID, []byte, error) {
+	resp := new(IndexedTxsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"getTxsByKey",
+		&GetTxsByKeyArgs{Key: key, Cursor: cursor, Limit: limit},
+		resp,
+	)
+	return resp.TxIDs, resp.Next, err
+}
+
+// RegisterWebhook registers [url] to be notified when any of [addresses] sends
+// or receives a transaction and returns the ID of the webhook and the secret
+// its payloads are signed with. [token] is the webhook auth token of the node.
+func (cli *JSONRPCClient) RegisterWebhook(
+	ctx context.Context,
+	token string,
+	url string,
+	addresses []codec.Address,
+) (ids.ID, []byte, error) {
+	resp := new(RegisterWebhookReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"registerWebhook",
+		&RegisterWebhookArgs{URL: url, Addresses: addresses},
+		resp,
+		requester.WithHeader("Authorization", "Bearer "+token),
+	)
+	return resp.ID, resp.Secret, err
+}
+
+func (cli *JSONRPCClient) UnregisterWebhook(ctx context.Context, token string, id ids.ID) error {
+	return cli.requester.SendRequest(
+		ctx,
+		"unregisterWebhook",
+		&UnregisterWebhookArgs{ID: id},
+		new(struct{}),
+		requester.WithHeader("Authorization", "Bearer "+token),
+	)
+}
+
+// CheckInvariants returns the height of the most recently accepted state of
+// the node and the invariants it violates. [token] is the invariant auth token
+// of the node.
+func (cli *JSONRPCClient) CheckInvariants(ctx context.Context, token string) (uint64, []*InvariantViolation, error) {
+	resp := new(CheckInvariantsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"checkInvariants",
+		nil,
+		resp,
+		requester.WithHeader("Authorization", "Bearer "+token),
+	)
+	return resp.Height, resp.Violations, err
+}
+
+// TraceTx re-executes the accepted transaction [txID] and returns every state
+// access it made. [token] is the tracing auth token of the node.
+func (cli *JSONRPCClient) TraceTx(ctx context.Context, token string, txID ids.ID) (*chain.TxTrace, error) {
+	resp := new(TraceTxReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"traceTx",
+		&TraceTxArgs{TxID: txID},
+		resp,
+		requester.WithHeader("Authorization", "Bearer "+token),
+	)
+	return resp.Trace, err
+}
@@ -7,9 +7,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
@@ -99,6 +108,27 @@ func (j *JSONRPCServer) LastAccepted(_ *http.Request, _ *struct{}, reply *LastAc
 	return nil
 }
 
+type MempoolReply struct {
+	Txs  int `json:"txs"`
+	Size int `json:"size"`
+}
+
+// Mempool returns the number of transactions in the mempool and their total
+// size (in bytes).
+func (j *JSONRPCServer) Mempool(
+	req *http.Request,
+	_ *struct{},
+	reply *MempoolReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.Mempool")
+	defer span.End()
+
+	mempool := j.vm.Mempool()
+	reply.Txs = mempool.Len(ctx)
+	reply.Size = mempool.Size(ctx)
+	return nil
+}
+
 type UnitPricesReply struct {
 	UnitPrices fees.Dimensions `json:"unitPrices"`
 }
@@ -118,3 +148,812 @@ func (j *JSONRPCServer) UnitPrices(
 	reply.UnitPrices = unitPrices
 	return nil
 }
+
+type FeeParametersReply struct {
+	// UnitPrices are the base fee (per unit) of each dimension as of the last
+	// accepted block.
+	UnitPrices                 fees.Dimensions `json:"unitPrices"`
+	MinUnitPrice               fees.Dimensions `json:"minUnitPrice"`
+	UnitPriceChangeDenominator fees.Dimensions `json:"unitPriceChangeDenominator"`
+	WindowTargetUnits          fees.Dimensions `json:"windowTargetUnits"`
+	MaxBlockUnits              fees.Dimensions `json:"maxBlockUnits"`
+}
+
+// FeeParameters returns the unit prices of the last accepted block and the
+// [chain.Rules] that determine how they change.
+func (j *JSONRPCServer) FeeParameters(
+	req *http.Request,
+	_ *struct{},
+	reply *FeeParametersReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.FeeParameters")
+	defer span.End()
+
+	unitPrices, err := j.vm.UnitPrices(ctx)
+	if err != nil {
+		return err
+	}
+	r := j.vm.Rules(time.Now().UnixMilli())
+	reply.UnitPrices = unitPrices
+	reply.MinUnitPrice = r.GetMinUnitPrice()
+	reply.UnitPriceChangeDenominator = r.GetUnitPriceChangeDenominator()
+	reply.WindowTargetUnits = r.GetWindowTargetUnits()
+	reply.MaxBlockUnits = r.GetMaxBlockUnits()
+	return nil
+}
+
+// maxFeePercentiles is the maximum number of percentiles in a [FeeHistory]
+// request.
+const maxFeePercentiles = 100
+
+type FeeHistoryArgs struct {
+	Blocks int `json:"blocks"`
+	// Percentiles (0-100, increasing) of the fees paid in each block
+	Percentiles []float64 `json:"percentiles"`
+}
+
+// BlockFees are the fees of an accepted block.
+type BlockFees struct {
+	Height     uint64          `json:"height"`
+	Timestamp  int64           `json:"timestamp"`
+	UnitPrices fees.Dimensions `json:"unitPrices"`
+	// UnitsConsumed and Utilization (the fraction of [chain.Rules.GetMaxBlockUnits]
+	// consumed) are per dimension
+	UnitsConsumed fees.Dimensions             `json:"unitsConsumed"`
+	Utilization   [fees.FeeDimensions]float64 `json:"utilization"`
+	// FeePercentiles are the fees paid at each requested percentile (0 if
+	// the block has no transactions)
+	FeePercentiles []uint64 `json:"feePercentiles"`
+}
+
+type FeeHistoryReply struct {
+	Blocks []*BlockFees `json:"blocks"`
+}
+
+// FeeHistory returns the unit prices, utilization, and fees paid of (up to)
+// the last [FeeHistoryArgs.Blocks] accepted blocks, oldest first.
+func (j *JSONRPCServer) FeeHistory(req *http.Request, args *FeeHistoryArgs, reply *FeeHistoryReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.FeeHistory")
+	defer span.End()
+
+	history := j.vm.FeeHistory()
+	if history == nil {
+		return ErrFeeHistoryDisabled
+	}
+	if args.Blocks <= 0 {
+		return ErrInvalidBlockCount
+	}
+	if len(args.Percentiles) > maxFeePercentiles {
+		return fmt.Errorf("%w: more than %d", ErrInvalidPercentiles, maxFeePercentiles)
+	}
+	for i, p := range args.Percentiles {
+		// Also rejects NaN
+		if !(p >= 0 && p <= 100) || (i > 0 && p < args.Percentiles[i-1]) {
+			return fmt.Errorf("%w: %v", ErrInvalidPercentiles, args.Percentiles)
+		}
+	}
+	reply.Blocks = history.Blocks(args.Blocks, args.Percentiles)
+	return nil
+}
+
+type FeeSplitArgs struct {
+	Height uint64 `json:"height"`
+}
+
+type FeeSplitReply struct {
+	Split *chain.FeeSplit `json:"split"`
+}
+
+// FeeSplit returns how the base fees collected by an accepted block were
+// split between its proposer and burning them (see
+// [chain.ProposerRewardRules]).
+func (j *JSONRPCServer) FeeSplit(req *http.Request, args *FeeSplitArgs, reply *FeeSplitReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.FeeSplit")
+	defer span.End()
+
+	split, err := j.vm.GetFeeSplit(args.Height)
+	if errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("%w: %d", ErrFeeSplitMissing, args.Height)
+	}
+	if err != nil {
+		return err
+	}
+	reply.Split = split
+	return nil
+}
+
+// Validator is a validator of the subnet. [PublicKey] is empty if it didn't
+// register a BLS key.
+type Validator struct {
+	NodeID    ids.NodeID `json:"nodeId"`
+	PublicKey []byte     `json:"publicKey"`
+	Weight    uint64     `json:"weight"`
+}
+
+type ValidatorsReply struct {
+	PChainHeight uint64       `json:"pChainHeight"`
+	TotalWeight  uint64       `json:"totalWeight"`
+	Validators   []*Validator `json:"validators"`
+}
+
+// Validators returns the current validators of the subnet (sorted by node
+// ID) and the P-Chain height they are defined at.
+func (j *JSONRPCServer) Validators(req *http.Request, _ *struct{}, reply *ValidatorsReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.Validators")
+	defer span.End()
+
+	pHeight, vdrs, err := j.vm.ValidatorSet(ctx)
+	if err != nil {
+		return err
+	}
+	reply.PChainHeight = pHeight
+	reply.Validators = make([]*Validator, 0, len(vdrs))
+	for nodeID, vdr := range vdrs {
+		v := &Validator{NodeID: nodeID, Weight: vdr.Weight}
+		if vdr.PublicKey != nil {
+			v.PublicKey = bls.PublicKeyToCompressedBytes(vdr.PublicKey)
+		}
+		totalWeight, err := smath.Add64(reply.TotalWeight, vdr.Weight)
+		if err != nil {
+			return err
+		}
+		reply.TotalWeight = totalWeight
+		reply.Validators = append(reply.Validators, v)
+	}
+	slices.SortFunc(reply.Validators, func(a, b *Validator) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return nil
+}
+
+// MaxProposerHeights is the maximum number of heights in a [ProposerWindows]
+// request.
+const MaxProposerHeights = 32
+
+type ProposerWindowsArgs struct {
+	Heights int `json:"heights"`
+	// Windows is the number of proposers returned for each height (at most
+	// [proposer.MaxBuildWindows])
+	Windows int `json:"windows"`
+}
+
+// ProposerWindow lists the validators that can propose the block at
+// [Height], in order. The proposer at index i can propose it once
+// [ProposerWindowsReply.WindowDuration] * i has elapsed since the timestamp
+// of its parent (and anyone can after the last window).
+type ProposerWindow struct {
+	Height    uint64       `json:"height"`
+	Proposers []ids.NodeID `json:"proposers"`
+}
+
+type ProposerWindowsReply struct {
+	PChainHeight   uint64            `json:"pChainHeight"`
+	WindowDuration time.Duration     `json:"windowDuration"`
+	Windows        []*ProposerWindow `json:"windows"`
+}
+
+// ProposerWindows returns the expected proposers of the blocks following the
+// preferred block of the node (assuming the P-Chain height of the current
+// validator set).
+func (j *JSONRPCServer) ProposerWindows(req *http.Request, args *ProposerWindowsArgs, reply *ProposerWindowsReply) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.ProposerWindows")
+	defer span.End()
+
+	if args.Heights <= 0 || args.Heights > MaxProposerHeights {
+		return fmt.Errorf("%w: must be between 1 and %d", ErrInvalidHeightCount, MaxProposerHeights)
+	}
+	if args.Windows <= 0 || args.Windows > proposer.MaxBuildWindows {
+		return fmt.Errorf("%w: must be between 1 and %d", ErrInvalidWindowCount, proposer.MaxBuildWindows)
+	}
+	pHeight, height, schedule, err := j.vm.ProposerWindows(ctx, args.Heights, args.Windows)
+	if err != nil {
+		return err
+	}
+	reply.PChainHeight = pHeight
+	reply.WindowDuration = proposer.WindowDuration
+	reply.Windows = make([]*ProposerWindow, len(schedule))
+	for i, proposers := range schedule {
+		reply.Windows[i] = &ProposerWindow{Height: height + uint64(i), Proposers: proposers}
+	}
+	return nil
+}
+
+type ProposerScheduleArgs struct {
+	// Slots is the number of slots returned (at most
+	// [proposer.MaxBuildWindows])
+	Slots int `json:"slots"`
+}
+
+// ProposerSchedule returns when validators become able to propose the child
+// of the preferred block of the node (so submissions can be sent to the
+// validators that will propose soon).
+func (j *JSONRPCServer) ProposerSchedule(req *http.Request, args *ProposerScheduleArgs, reply *ProposerSchedule) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.ProposerSchedule")
+	defer span.End()
+
+	if args.Slots <= 0 || args.Slots > proposer.MaxBuildWindows {
+		return fmt.Errorf("%w: must be between 1 and %d", ErrInvalidSlotCount, proposer.MaxBuildWindows)
+	}
+	schedule, err := j.vm.ProposerSchedule(ctx, args.Slots)
+	if err != nil {
+		return err
+	}
+	*reply = *schedule
+	return nil
+}
+
+type GetWarpSignaturesArgs struct {
+	MessageID ids.ID `json:"messageId"`
+}
+
+type WarpValidator = Validator
+
+type GetWarpSignaturesReply struct {
+	Validators []*WarpValidator       `json:"validators"`
+	Message    []byte                 `json:"message"`
+	Signatures []*chain.WarpSignature `json:"signatures"`
+}
+
+// GetWarpSignatures returns an outgoing warp message, the signatures of it
+// collected by the node, and the current validators of the subnet (which can
+// be used to aggregate the signatures).
+func (j *JSONRPCServer) GetWarpSignatures(
+	req *http.Request,
+	args *GetWarpSignaturesArgs,
+	reply *GetWarpSignaturesReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetWarpSignatures")
+	defer span.End()
+
+	msg, err := j.vm.GetWarpMessage(args.MessageID)
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrMessageMissing
+	}
+	if err != nil {
+		return err
+	}
+	signatures, err := j.vm.GetWarpSignatures(args.MessageID)
+	if err != nil {
+		return err
+	}
+
+	// Only return validators that registered a BLS key
+	vdrs, _ := j.vm.CurrentValidators(ctx)
+	validators := make([]*WarpValidator, 0, len(vdrs))
+	for nodeID, vdr := range vdrs {
+		if vdr.PublicKey == nil {
+			continue
+		}
+		validators = append(validators, &WarpValidator{
+			NodeID:    nodeID,
+			PublicKey: bls.PublicKeyToCompressedBytes(vdr.PublicKey),
+			Weight:    vdr.Weight,
+		})
+	}
+	reply.Validators = validators
+	reply.Message = msg.Bytes()
+	reply.Signatures = signatures
+	return nil
+}
+
+type GetAggregateWarpSignatureArgs struct {
+	MessageID ids.ID `json:"messageId"`
+	QuorumNum uint64 `json:"quorumNum"`
+	QuorumDen uint64 `json:"quorumDen"`
+}
+
+type GetAggregateWarpSignatureReply struct {
+	// Message is empty until the quorum is reached
+	Message  []byte        `json:"message"`
+	Progress *WarpProgress `json:"progress"`
+}
+
+// GetAggregateWarpSignature aggregates the signatures of an outgoing warp
+// message collected by the node. If they don't reach the requested quorum of
+// the stake of the current validators, it returns the progress made so far and
+// requests the missing signatures again.
+func (j *JSONRPCServer) GetAggregateWarpSignature(
+	req *http.Request,
+	args *GetAggregateWarpSignatureArgs,
+	reply *GetAggregateWarpSignatureReply,
+) error {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetAggregateWarpSignature")
+	defer span.End()
+
+	if args.QuorumDen == 0 || args.QuorumNum > args.QuorumDen {
+		return fmt.Errorf("%w: %d/%d", ErrInvalidQuorum, args.QuorumNum, args.QuorumDen)
+	}
+	unsignedMsg, err := j.vm.GetWarpMessage(args.MessageID)
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrMessageMissing
+	}
+	if err != nil {
+		return err
+	}
+	signatures, err := j.vm.GetWarpSignatures(args.MessageID)
+	if err != nil {
+		return err
+	}
+	vdrs, _ := j.vm.CurrentValidators(ctx)
+	msg, progress, err := AggregateWarpSignatures(unsignedMsg, vdrs, signatures, args.QuorumNum, args.QuorumDen)
+	switch {
+	case errors.Is(err, warp.ErrInsufficientWeight):
+		reply.Progress = progress
+		return j.vm.RequestWarpSignatures(ctx, unsignedMsg)
+	case err != nil:
+		return err
+	}
+	reply.Message = msg.Bytes()
+	reply.Progress = progress
+	return nil
+}
+
+// indexedTxsToSend is the maximum number of transaction IDs returned by a
+// single indexer query.
+const indexedTxsToSend = 256
+
+type GetIndexedBlockArgs struct {
+	Height uint64 `json:"height"`
+}
+
+type GetIndexedBlockReply struct {
+	// Block is packed with its results and unit prices (see
+	// [UnpackBlockMessage])
+	Block []byte `json:"block"`
+}
+
+// GetIndexedBlock returns an accepted block by height, even if it was pruned
+// from the chain.
+func (j *JSONRPCServer) GetIndexedBlock(req *http.Request, args *GetIndexedBlockArgs, reply *GetIndexedBlockReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetIndexedBlock")
+	defer span.End()
+
+	indexer := j.vm.Indexer()
+	if indexer == nil {
+		return ErrIndexerDisabled
+	}
+	blk, err := indexer.GetBlock(args.Height)
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrBlockMissing
+	}
+	if err != nil {
+		return err
+	}
+	reply.Block = blk
+	return nil
+}
+
+type GetIndexedBlockHeightArgs struct {
+	BlockID ids.ID `json:"blockId"`
+}
+
+type GetIndexedBlockHeightReply struct {
+	Height uint64 `json:"height"`
+}
+
+// GetIndexedBlockHeight returns the height of an accepted block by ID.
+func (j *JSONRPCServer) GetIndexedBlockHeight(
+	req *http.Request,
+	args *GetIndexedBlockHeightArgs,
+	reply *GetIndexedBlockHeightReply,
+) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetIndexedBlockHeight")
+	defer span.End()
+
+	indexer := j.vm.Indexer()
+	if indexer == nil {
+		return ErrIndexerDisabled
+	}
+	height, err := indexer.GetBlockHeight(args.BlockID)
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrBlockMissing
+	}
+	if err != nil {
+		return err
+	}
+	reply.Height = height
+	return nil
+}
+
+type TxStatusArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type TxStatusReply struct {
+	Status *TxStatus `json:"status"`
+}
+
+// TxStatus returns the last known status of a transaction seen by the node.
+func (j *JSONRPCServer) TxStatus(req *http.Request, args *TxStatusArgs, reply *TxStatusReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.TxStatus")
+	defer span.End()
+
+	statuses := j.vm.TxStatuses()
+	if statuses == nil {
+		return ErrTxStatusesDisabled
+	}
+	status, ok := statuses.Get(args.TxID)
+	if !ok {
+		return ErrTxMissing
+	}
+	reply.Status = status
+	return nil
+}
+
+type StateDiffArgs struct {
+	Height uint64 `json:"height"`
+}
+
+// StateDiff is the set of changes an accepted block made to state.
+type StateDiff struct {
+	Height  uint64               `json:"height"`
+	BlockID ids.ID               `json:"blockId"`
+	Changes []*chain.StateChange `json:"changes"`
+}
+
+type StateDiffReply struct {
+	Diff *StateDiff `json:"diff"`
+}
+
+// StateDiff returns the changes an accepted block made to state, so a copy of
+// state can be kept in sync by applying the diff of each block in order.
+func (j *JSONRPCServer) StateDiff(req *http.Request, args *StateDiffArgs, reply *StateDiffReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.StateDiff")
+	defer span.End()
+
+	diffs := j.vm.StateDiffs()
+	if diffs == nil {
+		return ErrStateDiffsDisabled
+	}
+	diff, err := diffs.Get(args.Height)
+	if errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("%w: %d", ErrStateDiffMissing, args.Height)
+	}
+	if err != nil {
+		return err
+	}
+	reply.Diff = diff
+	return nil
+}
+
+type UnitProfileArgs struct {
+	StartHeight uint64 `json:"startHeight"`
+	EndHeight   uint64 `json:"endHeight"`
+}
+
+// UnitProfile is where the units consumed by the transactions of a range of
+// accepted blocks were spent.
+type UnitProfile struct {
+	// StartHeight and EndHeight are the heights of the first and last
+	// profiled blocks in the range
+	StartHeight uint64 `json:"startHeight"`
+	EndHeight   uint64 `json:"endHeight"`
+	Blocks      int    `json:"blocks"`
+	Txs         int    `json:"txs"`
+
+	// Units are the units consumed in each dimension
+	Units      fees.Dimensions   `json:"units"`
+	Actions    []*ActionUnits    `json:"actions"`
+	Namespaces []*NamespaceUnits `json:"namespaces"`
+}
+
+// ActionUnits are the units consumed by the transactions that included an
+// action type. The units of a transaction are split evenly between its
+// actions.
+type ActionUnits struct {
+	Name   string          `json:"name"`
+	TypeID uint8           `json:"typeId"`
+	Count  uint64          `json:"count"`
+	Units  fees.Dimensions `json:"units"`
+}
+
+// NamespaceUnits are the storage units charged for the state keys in a
+// namespace (the first byte of a key) declared by transactions.
+type NamespaceUnits struct {
+	Namespace string `json:"namespace"`
+	Keys      uint64 `json:"keys"`
+	Read      uint64 `json:"read"`
+	Allocate  uint64 `json:"allocate"`
+	Write     uint64 `json:"write"`
+}
+
+type UnitProfileReply struct {
+	Profile *UnitProfile `json:"profile"`
+}
+
+// UnitProfile aggregates where the units consumed by the accepted blocks with
+// heights in [UnitProfileArgs.StartHeight, UnitProfileArgs.EndHeight] were
+// spent (by action type, fee dimension, and state key namespace).
+func (j *JSONRPCServer) UnitProfile(req *http.Request, args *UnitProfileArgs, reply *UnitProfileReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.UnitProfile")
+	defer span.End()
+
+	profiler := j.vm.UnitProfiler()
+	if profiler == nil {
+		return ErrUnitProfilingDisabled
+	}
+	if args.StartHeight > args.EndHeight {
+		return fmt.Errorf("%w: start=%d end=%d", ErrInvalidHeightRange, args.StartHeight, args.EndHeight)
+	}
+	profile, err := profiler.Profile(args.StartHeight, args.EndHeight)
+	if err != nil {
+		return err
+	}
+	reply.Profile = profile
+	return nil
+}
+
+type GetIndexedTxArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type GetIndexedTxReply struct {
+	Height    uint64 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Tx        []byte `json:"tx"`
+	Result    []byte `json:"result"`
+}
+
+// GetIndexedTx returns an accepted transaction, its result, and the block
+// that included it.
+func (j *JSONRPCServer) GetIndexedTx(req *http.Request, args *GetIndexedTxArgs, reply *GetIndexedTxReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetIndexedTx")
+	defer span.End()
+
+	indexer := j.vm.Indexer()
+	if indexer == nil {
+		return ErrIndexerDisabled
+	}
+	height, timestamp, tx, result, err := indexer.GetTx(args.TxID)
+	if errors.Is(err, database.ErrNotFound) {
+		return ErrTxMissing
+	}
+	if err != nil {
+		return err
+	}
+	reply.Height = height
+	reply.Timestamp = timestamp
+	reply.Tx = tx
+	reply.Result = result
+	return nil
+}
+
+type IndexedTxsReply struct {
+	TxIDs []ids.ID `json:"txIds"`
+	// Next is the cursor of the next page (empty if there are no more
+	// transactions)
+	Next []byte `json:"next"`
+}
+
+type GetTxsByAddressArgs struct {
+	Address codec.Address `json:"address"`
+	Cursor  []byte        `json:"cursor"`
+	Limit   int           `json:"limit"`
+}
+
+// GetTxsByAddress returns the IDs of the accepted transactions that
+// reference an address (oldest first).
+func (j *JSONRPCServer) GetTxsByAddress(req *http.Request, args *GetTxsByAddressArgs, reply *IndexedTxsReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetTxsByAddress")
+	defer span.End()
+
+	indexer := j.vm.Indexer()
+	if indexer == nil {
+		return ErrIndexerDisabled
+	}
+	txIDs, next, err := indexer.GetTxsByAddress(args.Address, args.Cursor, indexedTxsLimit(args.Limit))
+	if err != nil {
+		return err
+	}
+	reply.TxIDs = txIDs
+	reply.Next = next
+	return nil
+}
+
+type GetTxsByActionTypeArgs struct {
+	TypeID uint8  `json:"typeId"`
+	Cursor []byte `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+// GetTxsByActionType returns the IDs of the accepted transactions that
+// contain an action of a given type (oldest first).
+func (j *JSONRPCServer) GetTxsByActionType(req *http.Request, args *GetTxsByActionTypeArgs, reply *IndexedTxsReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetTxsByActionType")
+	defer span.End()
+
+	indexer := j.vm.Indexer()
+	if indexer == nil {
+		return ErrIndexerDisabled
+	}
+	txIDs, next, err := indexer.GetTxsByActionType(args.TypeID, args.Cursor, indexedTxsLimit(args.Limit))
+	if err != nil {
+		return err
+	}
+	reply.TxIDs = txIDs
+	reply.Next = next
+	return nil
+}
+
+type GetTxsByKeyArgs struct {
+	Key    []byte `json:"key"`
+	Cursor []byte `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+// GetTxsByKey returns the IDs of the accepted transactions indexed by a
+// hypervm-defined key (oldest first).
+func (j *JSONRPCServer) GetTxsByKey(req *http.Request, args *GetTxsByKeyArgs, reply *IndexedTxsReply) error {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.GetTxsByKey")
+	defer span.End()
+
+	indexer := j.vm.Indexer()
+	if indexer == nil {
+		return ErrIndexerDisabled
+	}
+	txIDs, next, err := indexer.GetTxsByKey(args.Key, args.Cursor, indexedTxsLimit(args.Limit))
+	if err != nil {
+		return err
+	}
+	reply.TxIDs = txIDs
+	reply.Next = next
+	return nil
+}
+
+func indexedTxsLimit(limit int) int {
+	if limit <= 0 || limit > indexedTxsToSend {
+		return indexedTxsToSend
+	}
+	return limit
+}
+
+type RegisterWebhookArgs struct {
+	URL       string          `json:"url"`
+	Addresses []codec.Address `json:"addresses"`
+}
+
+type RegisterWebhookReply struct {
+	ID ids.ID `json:"id"`
+	// Secret is the HMAC-SHA256 key that payloads are signed with
+	Secret []byte `json:"secret"`
+}
+
+// RegisterWebhook sends signed notifications to a URL whenever any of the
+// provided addresses sends or receives a transaction. Requests must include
+// the webhook auth token of the node as a bearer token.
+func (j *JSONRPCServer) RegisterWebhook(req *http.Request, args *RegisterWebhookArgs, reply *RegisterWebhookReply) (err error) {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.RegisterWebhook")
+	defer span.End()
+	defer func() {
+		j.audit(req, "registerWebhook", err, map[string]any{
+			"id":        reply.ID,
+			"url":       args.URL,
+			"addresses": len(args.Addresses),
+		})
+	}()
+
+	webhooks, err := j.authorizedWebhooks(req)
+	if err != nil {
+		return err
+	}
+	id, secret, err := webhooks.Register(args.URL, args.Addresses)
+	if err != nil {
+		return err
+	}
+	reply.ID = id
+	reply.Secret = secret
+	return nil
+}
+
+type UnregisterWebhookArgs struct {
+	ID ids.ID `json:"id"`
+}
+
+// UnregisterWebhook stops the notifications of a webhook.
+func (j *JSONRPCServer) UnregisterWebhook(req *http.Request, args *UnregisterWebhookArgs, _ *struct{}) (err error) {
+	_, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.UnregisterWebhook")
+	defer span.End()
+	defer func() {
+		j.audit(req, "unregisterWebhook", err, map[string]any{"id": args.ID})
+	}()
+
+	webhooks, err := j.authorizedWebhooks(req)
+	if err != nil {
+		return err
+	}
+	return webhooks.Unregister(args.ID)
+}
+
+func (j *JSONRPCServer) authorizedWebhooks(req *http.Request) (Webhooks, error) {
+	webhooks := j.vm.Webhooks()
+	if webhooks == nil {
+		return nil, ErrWebhooksDisabled
+	}
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || !webhooks.Authorized(token) {
+		return nil, ErrUnauthorized
+	}
+	return webhooks, nil
+}
+
+// audit records a call to the admin method [method] by [req] (including
+// unauthorized ones) that returned [err].
+func (j *JSONRPCServer) audit(req *http.Request, method string, err error, fields map[string]any) {
+	j.vm.AuditLog().Record(audit.AdminCategory, method, req.RemoteAddr, err, fields)
+}
+
+type InvariantViolation struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+type CheckInvariantsReply struct {
+	Height     uint64                `json:"height"`
+	Violations []*InvariantViolation `json:"violations"`
+}
+
+// CheckInvariants checks the invariants registered by the hypervm against the
+// most recently accepted state. Requests must include the invariant auth token
+// of the node as a bearer token.
+func (j *JSONRPCServer) CheckInvariants(req *http.Request, _ *struct{}, reply *CheckInvariantsReply) (err error) {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.CheckInvariants")
+	defer span.End()
+	defer func() {
+		j.audit(req, "checkInvariants", err, map[string]any{
+			"height":     reply.Height,
+			"violations": len(reply.Violations),
+		})
+	}()
+
+	invariants := j.vm.Invariants()
+	if invariants == nil {
+		return ErrInvariantsDisabled
+	}
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || !invariants.Authorized(token) {
+		return ErrUnauthorized
+	}
+	height, violations, err := invariants.Check(ctx)
+	if err != nil {
+		return err
+	}
+	reply.Height = height
+	reply.Violations = violations
+	return nil
+}
+
+type TraceTxArgs struct {
+	TxID ids.ID `json:"txId"`
+}
+
+type TraceTxReply struct {
+	Trace *chain.TxTrace `json:"trace"`
+}
+
+// TraceTx re-executes an accepted transaction and returns every state access
+// it made, the units it was charged, and its result. Requests must include the
+// tracing auth token of the node as a bearer token.
+func (j *JSONRPCServer) TraceTx(req *http.Request, args *TraceTxArgs, reply *TraceTxReply) (err error) {
+	ctx, span := j.vm.Tracer().Start(req.Context(), "JSONRPCServer.TraceTx")
+	defer span.End()
+	defer func() {
+		j.audit(req, "traceTx", err, map[string]any{
+			"txID": args.TxID.String(),
+		})
+	}()
+
+	tracer := j.vm.TxTracer()
+	if tracer == nil {
+		return ErrTxTracingDisabled
+	}
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || !tracer.Authorized(token) {
+		return ErrUnauthorized
+	}
+	trace, err := tracer.Trace(ctx, args.TxID)
+	if err != nil {
+		return err
+	}
+	reply.Trace = trace
+	return nil
+}
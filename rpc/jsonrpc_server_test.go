@@ -0,0 +1,126 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+	"github.com/stretchr/testify/require"
+)
+
+type testValidatorVM struct {
+	VM
+
+	pHeight    uint64
+	validators map[ids.NodeID]*validators.GetValidatorOutput
+	height     uint64
+}
+
+func (*testValidatorVM) Tracer() trace.Tracer { return trace.Noop }
+
+func (vm *testValidatorVM) ValidatorSet(context.Context) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return vm.pHeight, vm.validators, nil
+}
+
+func (vm *testValidatorVM) ProposerWindows(_ context.Context, heights int, windows int) (uint64, uint64, [][]ids.NodeID, error) {
+	nodeIDs := make([]ids.NodeID, 0, len(vm.validators))
+	for nodeID := range vm.validators {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	schedule := make([][]ids.NodeID, heights)
+	for i := range schedule {
+		schedule[i] = nodeIDs[:min(windows, len(nodeIDs))]
+	}
+	return vm.pHeight, vm.height + 1, schedule, nil
+}
+
+func (vm *testValidatorVM) ProposerSchedule(ctx context.Context, slots int) (*ProposerSchedule, error) {
+	pHeight, height, schedule, err := vm.ProposerWindows(ctx, 1, slots)
+	if err != nil {
+		return nil, err
+	}
+	s := &ProposerSchedule{PChainHeight: pHeight, Height: height, ParentTimestamp: 1_000}
+	for i, nodeID := range schedule[0] {
+		s.Slots = append(s.Slots, &ProposerSlot{
+			Start:    s.ParentTimestamp + int64(i)*proposer.WindowDuration.Milliseconds(),
+			Proposer: nodeID,
+		})
+	}
+	return s, nil
+}
+
+func TestJSONRPCServerValidators(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	vm := &testValidatorVM{
+		pHeight:    10,
+		validators: map[ids.NodeID]*validators.GetValidatorOutput{},
+		height:     5,
+	}
+	for i := 0; i < 3; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		vm.validators[nodeID] = &validators.GetValidatorOutput{NodeID: nodeID, Weight: uint64(i + 1)}
+	}
+	vm.validators[ids.EmptyNodeID] = &validators.GetValidatorOutput{PublicKey: bls.PublicFromSecretKey(sk), Weight: 4}
+	s := NewJSONRPCServer(vm)
+	req := httptest.NewRequest(http.MethodPost, JSONRPCEndpoint, nil)
+
+	var validatorsReply ValidatorsReply
+	require.NoError(s.Validators(req, nil, &validatorsReply))
+	require.Equal(uint64(10), validatorsReply.PChainHeight)
+	require.Equal(uint64(10), validatorsReply.TotalWeight)
+	require.Len(validatorsReply.Validators, 4)
+	require.Equal(&Validator{
+		NodeID:    ids.EmptyNodeID,
+		PublicKey: bls.PublicKeyToCompressedBytes(bls.PublicFromSecretKey(sk)),
+		Weight:    4,
+	}, validatorsReply.Validators[0])
+	for i, v := range validatorsReply.Validators[1:] {
+		require.Empty(v.PublicKey)
+		require.Negative(validatorsReply.Validators[i].NodeID.Compare(v.NodeID))
+	}
+
+	var windowsReply ProposerWindowsReply
+	require.NoError(s.ProposerWindows(req, &ProposerWindowsArgs{Heights: 2, Windows: 3}, &windowsReply))
+	require.Equal(uint64(10), windowsReply.PChainHeight)
+	require.Equal(proposer.WindowDuration, windowsReply.WindowDuration)
+	require.Len(windowsReply.Windows, 2)
+	for i, w := range windowsReply.Windows {
+		require.Equal(uint64(6+i), w.Height)
+		require.Len(w.Proposers, 3)
+	}
+	require.ErrorIs(s.ProposerWindows(req, &ProposerWindowsArgs{Heights: MaxProposerHeights + 1, Windows: 1}, &windowsReply), ErrInvalidHeightCount)
+	require.ErrorIs(s.ProposerWindows(req, &ProposerWindowsArgs{Heights: 1}, &windowsReply), ErrInvalidWindowCount)
+
+	var schedule ProposerSchedule
+	require.NoError(s.ProposerSchedule(req, &ProposerScheduleArgs{Slots: 2}, &schedule))
+	require.Equal(uint64(10), schedule.PChainHeight)
+	require.Equal(uint64(6), schedule.Height)
+	require.Len(schedule.Slots, 2)
+	first, second := schedule.Slots[0].Proposer, schedule.Slots[1].Proposer
+	start, ok := schedule.Start(second)
+	require.True(ok)
+	require.Equal(schedule.ParentTimestamp+proposer.WindowDuration.Milliseconds(), start)
+	require.True(schedule.Eligible(first, schedule.ParentTimestamp))
+	require.False(schedule.Eligible(second, start-1))
+	require.True(schedule.Eligible(second, start))
+
+	// Anyone can propose once all slots have passed
+	other := ids.GenerateTestNodeID()
+	_, ok = schedule.Start(other)
+	require.False(ok)
+	require.False(schedule.Eligible(other, start))
+	require.True(schedule.Eligible(other, schedule.ParentTimestamp+proposer.MaxBuildDelay.Milliseconds()))
+	require.ErrorIs(s.ProposerSchedule(req, &ProposerScheduleArgs{Slots: proposer.MaxBuildWindows + 1}, &schedule), ErrInvalidSlotCount)
+}
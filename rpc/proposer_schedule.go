@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+// ProposerSlot is the window (of [proposer.WindowDuration]) starting at
+// [Start] in which [Proposer] becomes able to propose the next block.
+type ProposerSlot struct {
+	// Start is the time (in milliseconds) the slot opens
+	Start    int64      `json:"start"`
+	Proposer ids.NodeID `json:"proposer"`
+}
+
+// ProposerSchedule lists the slots in which validators become able to
+// propose the child of the preferred block of the node (at [Height]).
+//
+// A validator remains able to propose the block once its slot has opened,
+// and anyone can propose it once [proposer.MaxBuildWindows] slots have
+// passed.
+type ProposerSchedule struct {
+	PChainHeight uint64 `json:"pChainHeight"`
+	Height       uint64 `json:"height"`
+	// ParentTimestamp is the timestamp of the parent of the block (the
+	// preferred block), when its first slot opens
+	ParentTimestamp int64           `json:"parentTimestamp"`
+	Slots           []*ProposerSlot `json:"slots"`
+}
+
+// Start returns when the first slot of [nodeID] opens (or false if it has
+// none of the slots in the schedule).
+func (s *ProposerSchedule) Start(nodeID ids.NodeID) (int64, bool) {
+	for _, slot := range s.Slots {
+		if slot.Proposer == nodeID {
+			return slot.Start, true
+		}
+	}
+	return 0, false
+}
+
+// Eligible returns true if [nodeID] can propose the block at [t] (in
+// milliseconds). Only the proposers of the slots in the schedule are known,
+// so the result may be a false negative if it doesn't have
+// [proposer.MaxBuildWindows] slots.
+func (s *ProposerSchedule) Eligible(nodeID ids.NodeID, t int64) bool {
+	if t >= s.ParentTimestamp+proposer.MaxBuildDelay.Milliseconds() {
+		return true
+	}
+	start, ok := s.Start(nodeID)
+	return ok && t >= start
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/pubsub"
+)
+
+// MaxTxStatusSubscriptions is the maximum number of transactions a single
+// [TxStatusTopic] subscription can follow.
+const MaxTxStatusSubscriptions = 256
+
+// TxState is the stage a transaction seen by the node has reached.
+//
+// Transactions start [TxPending] (once admitted to the mempool) and become
+// [TxIncluded] when a processing block includes them (and [TxPending] again
+// if that block is rejected). Once a block that includes them is accepted,
+// they are [TxAccepted] (or [TxFailed] if they were executed unsuccessfully).
+// Transactions that are not included in an accepted block before their
+// expiry are [TxExpired].
+type TxState uint8
+
+const (
+	TxPending TxState = iota
+	TxIncluded
+	TxAccepted
+	TxFailed
+	TxExpired
+)
+
+var txStateNames = [...]string{
+	TxPending:  "pending",
+	TxIncluded: "included",
+	TxAccepted: "accepted",
+	TxFailed:   "failed",
+	TxExpired:  "expired",
+}
+
+func (s TxState) String() string {
+	if int(s) < len(txStateNames) {
+		return txStateNames[s]
+	}
+	return fmt.Sprintf("unknown(%d)", s)
+}
+
+// Final returns true if the transaction can no longer change state.
+func (s TxState) Final() bool {
+	return s >= TxAccepted
+}
+
+func (s TxState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *TxState) UnmarshalText(text []byte) error {
+	for state, name := range txStateNames {
+		if name == string(text) {
+			*s = TxState(state)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidTxState, text)
+}
+
+// TxStatus is the last known [TxState] of a transaction.
+type TxStatus struct {
+	TxID  ids.ID  `json:"txId"`
+	State TxState `json:"state"`
+	// Expiry is the expiry of the transaction
+	Expiry int64 `json:"expiry"`
+	// Height is the height of the block that included the transaction (0 if
+	// it is [TxPending] or [TxExpired])
+	Height uint64 `json:"height"`
+	// Updated is the time (in milliseconds) the transaction reached [State]
+	Updated int64 `json:"updated"`
+	// Error is the error of a [TxFailed] transaction
+	Error []byte `json:"error"`
+}
+
+// TxStatuses serves the statuses of the transactions seen by the node. Only
+// a bounded number of transactions are remembered once they reach a final
+// state.
+type TxStatuses interface {
+	Get(txID ids.ID) (*TxStatus, bool)
+}
+
+func PackTxStatusMessage(s *TxStatus) ([]byte, error) {
+	size := ids.IDLen + consts.ByteLen + consts.Int64Len + consts.Uint64Len + consts.Int64Len + codec.BytesLen(s.Error)
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackID(s.TxID)
+	p.PackByte(uint8(s.State))
+	p.PackInt64(s.Expiry)
+	p.PackUint64(s.Height)
+	p.PackInt64(s.Updated)
+	p.PackBytes(s.Error)
+	return p.Bytes(), p.Err()
+}
+
+func UnpackTxStatusMessage(msg []byte) (*TxStatus, error) {
+	var (
+		p = codec.NewReader(msg, consts.MaxInt)
+		s TxStatus
+	)
+	p.UnpackID(true, &s.TxID)
+	s.State = TxState(p.UnpackByte())
+	s.Expiry = p.UnpackInt64(false)
+	s.Height = p.UnpackUint64(false)
+	s.Updated = p.UnpackInt64(false)
+	p.UnpackBytes(-1, false, &s.Error)
+	if len(s.Error) == 0 {
+		s.Error = nil
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &s, p.Err()
+}
+
+// PublishTxStatus publishes [s] to the [TxStatusTopic] subscriptions of its
+// transaction.
+func (w *WebSocketServer) PublishTxStatus(s *TxStatus) error {
+	if !w.topics.Active(TxStatusTopic) {
+		return nil
+	}
+	return w.topics.Publish(TxStatusTopic, s, func(seq uint64) ([]byte, error) {
+		bytes, err := PackTxStatusMessage(s)
+		if err != nil {
+			return nil, err
+		}
+		return packTopicEvent(TxStatusTopic, seq, bytes)
+	})
+}
+
+// txStatusTopicFilter is the [pubsub.Filter] of a [TxStatusTopic]
+// subscription. Its events are [*TxStatus]es.
+type txStatusTopicFilter struct {
+	txIDs set.Set[ids.ID]
+}
+
+func (f *txStatusTopicFilter) Match(event any) bool {
+	return f.txIDs.Contains(event.(*TxStatus).TxID)
+}
+
+func parseTxStatusFilter(params []byte) (pubsub.Filter, error) {
+	if len(params) == 0 || len(params)%ids.IDLen != 0 {
+		return nil, fmt.Errorf("%w: expected tx IDs", ErrUnexpectedParams)
+	}
+	if len(params)/ids.IDLen > MaxTxStatusSubscriptions {
+		return nil, fmt.Errorf("%w: more than %d tx IDs", ErrUnexpectedParams, MaxTxStatusSubscriptions)
+	}
+	f := &txStatusTopicFilter{txIDs: set.NewSet[ids.ID](len(params) / ids.IDLen)}
+	for i := 0; i < len(params); i += ids.IDLen {
+		f.txIDs.Add(ids.ID(params[i : i+ids.IDLen]))
+	}
+	return f, nil
+}
+
+// PackTxStatusRequest packs the params of a [TxStatusTopic] subscription to
+// the statuses of [txIDs].
+func PackTxStatusRequest(txIDs []ids.ID) []byte {
+	params := make([]byte, 0, len(txIDs)*ids.IDLen)
+	for _, txID := range txIDs {
+		params = append(params, txID[:]...)
+	}
+	return params
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// WarpProgress describes how much of the stake of a subnet has signed a warp
+// message.
+type WarpProgress struct {
+	Signers      int    `json:"signers"`
+	Validators   int    `json:"validators"`
+	SignedWeight uint64 `json:"signedWeight"`
+	TotalWeight  uint64 `json:"totalWeight"`
+}
+
+// AggregateWarpSignatures aggregates [signatures] of [unsignedMsg] into a
+// message signed by at least [quorumNum]/[quorumDen] of the weight of [vdrs].
+// If the quorum is not reached, it returns [warp.ErrInsufficientWeight] and the
+// progress made so far.
+func AggregateWarpSignatures(
+	unsignedMsg *warp.UnsignedMessage,
+	vdrs map[ids.NodeID]*validators.GetValidatorOutput,
+	signatures []*chain.WarpSignature,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, *WarpProgress, error) {
+	canonical, totalWeight, err := warp.FlattenValidatorSet(vdrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	signaturesByKey := make(map[string][]byte, len(signatures))
+	for _, signature := range signatures {
+		signaturesByKey[string(signature.PublicKey)] = signature.Signature
+	}
+
+	// Validators that are not in the canonical set can't be included in the
+	// aggregate signature
+	var (
+		signers       = set.NewBits()
+		blsSignatures = []*bls.Signature{}
+		progress      = &WarpProgress{
+			Validators:  len(canonical),
+			TotalWeight: totalWeight,
+		}
+	)
+	for i, vdr := range canonical {
+		raw, ok := signaturesByKey[string(bls.PublicKeyToCompressedBytes(vdr.PublicKey))]
+		if !ok {
+			continue
+		}
+		signature, err := bls.SignatureFromBytes(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		signers.Add(i)
+		blsSignatures = append(blsSignatures, signature)
+		progress.Signers++
+		progress.SignedWeight += vdr.Weight
+	}
+	if err := warp.VerifyWeight(progress.SignedWeight, totalWeight, quorumNum, quorumDen); err != nil {
+		return nil, progress, err
+	}
+	aggSignature, err := bls.AggregateSignatures(blsSignatures)
+	if err != nil {
+		return nil, nil, err
+	}
+	bitSetSignature := &warp.BitSetSignature{Signers: signers.Bytes()}
+	copy(bitSetSignature.Signature[:], bls.SignatureToBytes(aggSignature))
+	msg, err := warp.NewMessage(unsignedMsg, bitSetSignature)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, progress, nil
+}
@@ -5,6 +5,7 @@ package rpc
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"sync"
@@ -28,8 +29,14 @@ type WebSocketClient struct {
 	writeStopped chan struct{}
 	readStopped  chan struct{}
 
-	pendingBlocks chan []byte
-	pendingTxs    chan []byte
+	pendingBlocks      chan []byte
+	pendingTxs         chan []byte
+	pendingFilteredTxs chan []byte
+	pendingPreconfs    chan []byte
+	pendingTopics      chan []byte
+
+	seqL sync.Mutex
+	seqs map[string]uint64
 
 	startedClose bool
 	closed       bool
@@ -58,12 +65,16 @@ func NewWebSocketClient(uri string, handshakeTimeout time.Duration, pending int,
 	}
 	resp.Body.Close()
 	wc := &WebSocketClient{
-		conn:          conn,
-		mb:            pubsub.NewMessageBuffer(&logging.NoLog{}, pending, maxSize, pubsub.MaxMessageWait),
-		readStopped:   make(chan struct{}),
-		writeStopped:  make(chan struct{}),
-		pendingBlocks: make(chan []byte, pending),
-		pendingTxs:    make(chan []byte, pending),
+		conn:               conn,
+		mb:                 pubsub.NewMessageBuffer(&logging.NoLog{}, pending, maxSize, pubsub.MaxMessageWait),
+		readStopped:        make(chan struct{}),
+		writeStopped:       make(chan struct{}),
+		pendingBlocks:      make(chan []byte, pending),
+		pendingTxs:         make(chan []byte, pending),
+		pendingFilteredTxs: make(chan []byte, pending),
+		pendingPreconfs:    make(chan []byte, pending),
+		pendingTopics:      make(chan []byte, pending),
+		seqs:               map[string]uint64{},
 	}
 	go func() {
 		defer close(wc.readStopped)
@@ -91,6 +102,12 @@ func NewWebSocketClient(uri string, handshakeTimeout time.Duration, pending int,
 					wc.pendingBlocks <- tmsg
 				case TxMode:
 					wc.pendingTxs <- tmsg
+				case FilteredTxMode:
+					wc.pendingFilteredTxs <- tmsg
+				case PreconfMode:
+					wc.pendingPreconfs <- tmsg
+				case TopicMode:
+					wc.pendingTopics <- tmsg
 				default:
 					utils.Outf("{{orange}}unexpected message mode:{{/}} %x\n", msg[0])
 					continue
@@ -178,6 +195,128 @@ func (c *WebSocketClient) ListenTx(ctx context.Context) (ids.ID, error, *chain.R
 	}
 }
 
+// RegisterFilteredTxs subscribes to accepted transactions that match
+// [filter]. The filter is applied by the server, so only matching
+// transactions are sent over the connection.
+func (c *WebSocketClient) RegisterFilteredTxs(filter *TxFilter) error {
+	if c.closed {
+		return ErrClosed
+	}
+	msg, err := PackFilteredTxsRequest(filter)
+	if err != nil {
+		return err
+	}
+	return c.mb.Send(append([]byte{FilteredTxMode}, msg...))
+}
+
+// ListenFilteredTxs listens for transactions matching the filter passed to
+// [RegisterFilteredTxs]. It returns the height of the block the transactions
+// were accepted in (blocks without any matching transactions are skipped).
+func (c *WebSocketClient) ListenFilteredTxs(
+	ctx context.Context,
+	parser chain.Parser,
+) (uint64, []*chain.Transaction, []*chain.Result, error) {
+	select {
+	case msg := <-c.pendingFilteredTxs:
+		return UnpackFilteredTxsMessage(msg, parser)
+	case <-c.readStopped:
+		return 0, nil, nil, c.err
+	case <-ctx.Done():
+		return 0, nil, nil, ctx.Err()
+	}
+}
+
+// RegisterPreconfs subscribes to the pre-confirmations of transactions
+// included in processing blocks (see [Preconfirmation]).
+func (c *WebSocketClient) RegisterPreconfs() error {
+	if c.closed {
+		return ErrClosed
+	}
+	return c.mb.Send([]byte{PreconfMode})
+}
+
+// ListenPreconf listens for pre-confirmations. They are sent as soon as a
+// block is verified, before the result of its transactions is final.
+func (c *WebSocketClient) ListenPreconf(ctx context.Context) (*Preconfirmation, error) {
+	select {
+	case msg := <-c.pendingPreconfs:
+		return UnpackPreconfMessage(msg)
+	case <-c.readStopped:
+		return nil, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Subscribe subscribes to the events of [topic] matched by the filter
+// described by [params] (see [BlocksTopic], [TxsTopic], [PendingTxsTopic],
+// and [TxStatusTopic]). The server confirms the subscription with a
+// [SubscribeKind] message.
+func (c *WebSocketClient) Subscribe(topic string, params []byte) error {
+	return c.sendTopicCommand(&TopicMessage{Kind: SubscribeKind, Topic: topic, Payload: params})
+}
+
+// Resume subscribes to [topic] like [Subscribe], starting after the event
+// [seq] (like one returned by [LastSeq] before reconnecting). The events
+// published since then are sent before the acknowledgement, or the command is
+// rejected (and any subscription to [topic] is closed) if the server no longer
+// buffers all of them or can't send them.
+func (c *WebSocketClient) Resume(topic string, params []byte, seq uint64) error {
+	return c.sendTopicCommand(&TopicMessage{Kind: SubscribeKind, Topic: topic, Seq: seq, Payload: params})
+}
+
+// LastSeq returns the sequence number of the last event of [topic] returned
+// by [ListenTopic] (or 0 if there was none).
+func (c *WebSocketClient) LastSeq(topic string) uint64 {
+	c.seqL.Lock()
+	defer c.seqL.Unlock()
+
+	return c.seqs[topic]
+}
+
+// Unsubscribe removes the subscription to [topic]. The server confirms it
+// with an [UnsubscribeKind] message.
+func (c *WebSocketClient) Unsubscribe(topic string) error {
+	return c.sendTopicCommand(&TopicMessage{Kind: UnsubscribeKind, Topic: topic})
+}
+
+func (c *WebSocketClient) sendTopicCommand(m *TopicMessage) error {
+	if c.closed {
+		return ErrClosed
+	}
+	msg, err := PackTopicMessage(m)
+	if err != nil {
+		return err
+	}
+	return c.mb.Send(append([]byte{TopicMode}, msg...))
+}
+
+// ListenTopic listens for the events of subscribed topics and the replies to
+// subscription commands. A rejected command is returned as an [ErrorKind]
+// message with an error wrapping [ErrTopicCommandFailed].
+func (c *WebSocketClient) ListenTopic(ctx context.Context) (*TopicMessage, error) {
+	select {
+	case msg := <-c.pendingTopics:
+		m, err := UnpackTopicMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		switch m.Kind {
+		case ErrorKind:
+			return m, fmt.Errorf("%w: %s", ErrTopicCommandFailed, m.Payload)
+		case EventKind:
+			c.seqL.Lock()
+			c.seqs[m.Topic] = m.Seq
+			c.seqL.Unlock()
+		}
+		return m, nil
+	case <-c.readStopped:
+		return nil, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // Close closes [c]'s connection to the decision rpc server.
 func (c *WebSocketClient) Close() error {
 	var err error
@@ -0,0 +1,108 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"slices"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// MaxFilterAddresses is the maximum number of addresses a [TxFilter] can
+// match against.
+const MaxFilterAddresses = 32
+
+// TxFilter selects which accepted transactions are streamed to a
+// subscriber. Empty fields match all transactions and all populated fields
+// must match for a transaction to be sent.
+type TxFilter struct {
+	// Addresses matches transactions sent by (or sponsored by) any of
+	// these addresses or with an action that affects any of them (see
+	// [chain.RecipientsAction]).
+	Addresses []codec.Address
+	// ActionTypes matches transactions that contain an action with any of
+	// these type IDs.
+	ActionTypes []uint8
+	// MinFee matches transactions that paid at least this fee.
+	MinFee uint64
+	// FailedOnly matches transactions that failed during execution.
+	FailedOnly bool
+}
+
+// Match returns true if [tx], which produced [result], satisfies [f].
+func (f *TxFilter) Match(tx *chain.Transaction, result *chain.Result) bool {
+	if f.FailedOnly && result.Success {
+		return false
+	}
+	if result.Fee < f.MinFee {
+		return false
+	}
+	return f.matchTx(tx)
+}
+
+// MatchPending returns true if [tx], which has not been executed yet,
+// satisfies [f]. [f.MinFee] is compared to the max fee of [tx] (the fee it
+// pays is only known once executed) and [f.FailedOnly] is ignored.
+func (f *TxFilter) MatchPending(tx *chain.Transaction) bool {
+	if tx.Base.MaxFee < f.MinFee {
+		return false
+	}
+	return f.matchTx(tx)
+}
+
+// matchTx returns true if [tx] satisfies [f.ActionTypes] and [f.Addresses].
+func (f *TxFilter) matchTx(tx *chain.Transaction) bool {
+	if len(f.ActionTypes) > 0 && !slices.ContainsFunc(tx.Actions, func(action chain.Action) bool {
+		return slices.Contains(f.ActionTypes, action.GetTypeID())
+	}) {
+		return false
+	}
+	if len(f.Addresses) > 0 && !slices.ContainsFunc(f.Addresses, func(addr codec.Address) bool {
+		return addr == tx.Auth.Actor() || addr == tx.Sponsor() || actionsAffect(tx.Actions, addr)
+	}) {
+		return false
+	}
+	return true
+}
+
+// actionsAffect returns true if any of [actions] lists [addr] as a recipient.
+func actionsAffect(actions []chain.Action, addr codec.Address) bool {
+	return slices.ContainsFunc(actions, func(action chain.Action) bool {
+		ra, ok := action.(chain.RecipientsAction)
+		return ok && slices.Contains(ra.Recipients(), addr)
+	})
+}
+
+func (f *TxFilter) Size() int {
+	return consts.IntLen + len(f.Addresses)*codec.AddressLen +
+		codec.BytesLen(f.ActionTypes) + consts.Uint64Len + consts.BoolLen
+}
+
+func (f *TxFilter) Marshal(p *codec.Packer) {
+	p.PackInt(len(f.Addresses))
+	for _, addr := range f.Addresses {
+		p.PackAddress(addr)
+	}
+	p.PackBytes(f.ActionTypes)
+	p.PackUint64(f.MinFee)
+	p.PackBool(f.FailedOnly)
+}
+
+func UnmarshalTxFilter(p *codec.Packer) (*TxFilter, error) {
+	var f TxFilter
+	count := p.UnpackInt(false)
+	if count > MaxFilterAddresses {
+		return nil, ErrTooManyFilterAddresses
+	}
+	f.Addresses = make([]codec.Address, count)
+	for i := range f.Addresses {
+		p.UnpackAddress(&f.Addresses[i])
+	}
+	p.UnpackBytes(int(consts.MaxUint8)+1, false, &f.ActionTypes)
+	f.MinFee = p.UnpackUint64(false)
+	f.FailedOnly = p.UnpackBool()
+	return &f, p.Err()
+}
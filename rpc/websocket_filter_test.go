@@ -0,0 +1,153 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// testTransferAction sends to [to] and carries an arbitrary [memo].
+type testTransferAction struct {
+	chain.Action
+
+	to   codec.Address
+	memo []byte
+}
+
+func (*testTransferAction) GetTypeID() uint8 { return 1 }
+
+func (a *testTransferAction) Size() int { return codec.AddressLen + codec.BytesLen(a.memo) }
+
+func (a *testTransferAction) Marshal(p *codec.Packer) {
+	p.PackAddress(a.to)
+	p.PackBytes(a.memo)
+}
+
+func (a *testTransferAction) Recipients() []codec.Address { return []codec.Address{a.to} }
+
+func unmarshalTestTransferAction(p *codec.Packer) (chain.Action, error) {
+	var a testTransferAction
+	p.UnpackAddress(&a.to)
+	p.UnpackBytes(consts.NetworkSizeLimit, false, &a.memo)
+	return &a, p.Err()
+}
+
+func TestTxFilterMatch(t *testing.T) {
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(t, actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(t, actionRegistry.Register((&testTransferAction{}).GetTypeID(), unmarshalTestTransferAction))
+	require.NoError(t, authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+
+	var (
+		actor     = codec.CreateAddress(0, ids.GenerateTestID())
+		recipient = codec.CreateAddress(0, ids.GenerateTestID())
+		other     = codec.CreateAddress(0, ids.GenerateTestID())
+	)
+	newTx := func(actions ...chain.Action) *chain.Transaction {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: 1_000, ChainID: ids.GenerateTestID(), MaxFee: 100},
+			actions,
+		).Sign(&testAuthFactory{actor: actor}, actionRegistry, authRegistry)
+		require.NoError(t, err)
+		return tx
+	}
+	var (
+		transfer = newTx(&testTransferAction{to: recipient})
+		// [other] is contained in the bytes of the transaction but is not
+		// affected by it
+		memo = newTx(&testTransferAction{to: recipient, memo: other[:]})
+	)
+	tests := []struct {
+		name    string
+		filter  *TxFilter
+		tx      *chain.Transaction
+		result  *chain.Result
+		matches bool
+	}{
+		{
+			name:    "empty filter",
+			filter:  &TxFilter{},
+			tx:      transfer,
+			result:  &chain.Result{Success: true},
+			matches: true,
+		},
+		{
+			name:    "actor",
+			filter:  &TxFilter{Addresses: []codec.Address{actor}},
+			tx:      transfer,
+			result:  &chain.Result{Success: true},
+			matches: true,
+		},
+		{
+			name:    "recipient",
+			filter:  &TxFilter{Addresses: []codec.Address{recipient}},
+			tx:      transfer,
+			result:  &chain.Result{Success: true},
+			matches: true,
+		},
+		{
+			name:   "unrelated address",
+			filter: &TxFilter{Addresses: []codec.Address{other}},
+			tx:     transfer,
+			result: &chain.Result{Success: true},
+		},
+		{
+			name:   "address contained in transaction bytes",
+			filter: &TxFilter{Addresses: []codec.Address{other}},
+			tx:     memo,
+			result: &chain.Result{Success: true},
+		},
+		{
+			name:   "action without recipients",
+			filter: &TxFilter{Addresses: []codec.Address{other}},
+			tx:     newTx(&testAction{value: 1}),
+			result: &chain.Result{Success: true},
+		},
+		{
+			name:    "action type",
+			filter:  &TxFilter{ActionTypes: []uint8{(&testTransferAction{}).GetTypeID()}},
+			tx:      transfer,
+			result:  &chain.Result{Success: true},
+			matches: true,
+		},
+		{
+			name:   "other action type",
+			filter: &TxFilter{ActionTypes: []uint8{(&testAction{}).GetTypeID()}},
+			tx:     transfer,
+			result: &chain.Result{Success: true},
+		},
+		{
+			name:   "fee below min",
+			filter: &TxFilter{MinFee: 10},
+			tx:     transfer,
+			result: &chain.Result{Success: true, Fee: 9},
+		},
+		{
+			name:   "successful tx with failed only",
+			filter: &TxFilter{FailedOnly: true},
+			tx:     transfer,
+			result: &chain.Result{Success: true},
+		},
+		{
+			name:    "failed tx with failed only",
+			filter:  &TxFilter{FailedOnly: true, Addresses: []codec.Address{recipient}},
+			tx:      transfer,
+			result:  &chain.Result{},
+			matches: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.matches, tt.filter.Match(tt.tx, tt.result))
+		})
+	}
+}
@@ -15,8 +15,11 @@ import (
 )
 
 const (
-	BlockMode byte = 0
-	TxMode    byte = 1
+	BlockMode      byte = 0
+	TxMode         byte = 1
+	FilteredTxMode byte = 2
+	PreconfMode    byte = 3
+	TopicMode      byte = 4
 )
 
 func PackBlockMessage(b *chain.StatelessBlock) ([]byte, error) {
@@ -30,6 +33,12 @@ func PackBlockMessage(b *chain.StatelessBlock) ([]byte, error) {
 	}
 	p.PackBytes(mresults)
 	p.PackFixedBytes(b.FeeManager().UnitPrices().Bytes())
+
+	// The block only encodes the certificates of its chunks, so the chunks are
+	// included for subscribers to see all of its transactions
+	for _, chunk := range b.GetChunks() {
+		p.PackBytes(chunk.Bytes())
+	}
 	return p.Bytes(), p.Err()
 }
 
@@ -56,6 +65,23 @@ func UnpackBlockMessage(
 	if err != nil {
 		return nil, nil, fees.Dimensions{}, err
 	}
+	if len(blk.Chunks) > 0 {
+		chunks := make([]*chain.Chunk, len(blk.Chunks))
+		for i := range chunks {
+			var chunkMsg []byte
+			p.UnpackBytes(-1, true, &chunkMsg)
+			if err := p.Err(); err != nil {
+				return nil, nil, fees.Dimensions{}, err
+			}
+			chunks[i], err = chain.UnmarshalChunk(chunkMsg, parser)
+			if err != nil {
+				return nil, nil, fees.Dimensions{}, err
+			}
+		}
+		if err := blk.AttachChunks(chunks); err != nil {
+			return nil, nil, fees.Dimensions{}, err
+		}
+	}
 	if !p.Empty() {
 		return nil, nil, fees.Dimensions{}, chain.ErrInvalidObject
 	}
@@ -106,3 +132,166 @@ func UnpackTxMessage(msg []byte) (ids.ID, error, *chain.Result, error) {
 	}
 	return txID, nil, result, p.Err()
 }
+
+func PackFilteredTxsRequest(filter *TxFilter) ([]byte, error) {
+	p := codec.NewWriter(filter.Size(), consts.NetworkSizeLimit)
+	filter.Marshal(p)
+	return p.Bytes(), p.Err()
+}
+
+func UnpackFilteredTxsRequest(msg []byte) (*TxFilter, error) {
+	p := codec.NewReader(msg, consts.NetworkSizeLimit)
+	filter, err := UnmarshalTxFilter(p)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return filter, p.Err()
+}
+
+// PackFilteredTxsMessage packs the transactions at [indices] in [b] (and
+// their results) with the height of [b].
+func PackFilteredTxsMessage(b *chain.StatelessBlock, indices []int) ([]byte, error) {
+	results := b.Results()
+	size := consts.Uint64Len + consts.IntLen
+	for _, i := range indices {
+		size += b.Txs[i].Size() + results[i].Size()
+	}
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackUint64(b.Hght)
+	p.PackInt(len(indices))
+	for _, i := range indices {
+		if err := b.Txs[i].Marshal(p); err != nil {
+			return nil, err
+		}
+		if err := results[i].Marshal(p); err != nil {
+			return nil, err
+		}
+	}
+	return p.Bytes(), p.Err()
+}
+
+// UnpackFilteredTxsMessage returns the height, transactions, and results
+// packed by [PackFilteredTxsMessage].
+func UnpackFilteredTxsMessage(
+	msg []byte,
+	parser chain.Parser,
+) (uint64, []*chain.Transaction, []*chain.Result, error) {
+	var (
+		p                            = codec.NewReader(msg, consts.MaxInt)
+		actionRegistry, authRegistry = parser.Registry()
+		height                       = p.UnpackUint64(false)
+		count                        = p.UnpackInt(false)
+		txs                          = make([]*chain.Transaction, 0, min(count, int(consts.MaxUint16)))
+		results                      = make([]*chain.Result, 0, min(count, int(consts.MaxUint16)))
+	)
+	for i := 0; i < count; i++ {
+		tx, err := chain.UnmarshalTx(p, actionRegistry, authRegistry)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		result, err := chain.UnmarshalResult(p)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		txs = append(txs, tx)
+		results = append(results, result)
+	}
+	if !p.Empty() {
+		return 0, nil, nil, chain.ErrInvalidObject
+	}
+	return height, txs, results, p.Err()
+}
+
+// Preconfirmation reports that transactions are included in the processing
+// block [BlockID] or, if [Rejected], that they are no longer included in any
+// processing block (after [BlockID] was rejected).
+//
+// Transactions included in a processing block may still not be accepted, so
+// the final status of a transaction is only sent to its tx listeners.
+type Preconfirmation struct {
+	BlockID  ids.ID
+	Height   uint64
+	Rejected bool
+	TxIDs    []ids.ID
+}
+
+func PackPreconfMessage(pc *Preconfirmation) ([]byte, error) {
+	size := ids.IDLen + consts.Uint64Len + consts.BoolLen + consts.IntLen + len(pc.TxIDs)*ids.IDLen
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackID(pc.BlockID)
+	p.PackUint64(pc.Height)
+	p.PackBool(pc.Rejected)
+	p.PackInt(len(pc.TxIDs))
+	for _, txID := range pc.TxIDs {
+		p.PackID(txID)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func UnpackPreconfMessage(msg []byte) (*Preconfirmation, error) {
+	var (
+		p  = codec.NewReader(msg, consts.MaxInt)
+		pc Preconfirmation
+	)
+	p.UnpackID(true, &pc.BlockID)
+	pc.Height = p.UnpackUint64(false)
+	pc.Rejected = p.UnpackBool()
+	count := p.UnpackInt(true)
+	pc.TxIDs = make([]ids.ID, 0, min(count, len(msg)/ids.IDLen))
+	for i := 0; i < count; i++ {
+		var txID ids.ID
+		p.UnpackID(true, &txID)
+		pc.TxIDs = append(pc.TxIDs, txID)
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &pc, p.Err()
+}
+
+// Kinds of the events of [PendingTxsTopic].
+const (
+	// PendingAdmitted is sent when a transaction is added to the mempool.
+	PendingAdmitted byte = 0
+	// PendingEvicted is sent when a transaction is dropped from the mempool
+	// because it is no longer admitted by the node.
+	PendingEvicted byte = 1
+	// PendingExpired is sent when a transaction is dropped from the mempool
+	// because it expired before being included in a block.
+	PendingExpired byte = 2
+	// PendingIncluded is sent when a transaction is dropped from the mempool
+	// because a processing block includes it (only to external builders, see
+	// [BuilderEndpoint]).
+	PendingIncluded byte = 3
+)
+
+// PackPendingTxMessage packs the event [kind] of [tx] (see [PendingTxsTopic]).
+func PackPendingTxMessage(kind byte, tx *chain.Transaction) ([]byte, error) {
+	p := codec.NewWriter(consts.ByteLen+tx.Size(), consts.MaxInt)
+	p.PackByte(kind)
+	if err := tx.Marshal(p); err != nil {
+		return nil, err
+	}
+	return p.Bytes(), p.Err()
+}
+
+// UnpackPendingTxMessage returns the kind and transaction packed by
+// [PackPendingTxMessage].
+func UnpackPendingTxMessage(msg []byte, parser chain.Parser) (byte, *chain.Transaction, error) {
+	var (
+		p                            = codec.NewReader(msg, consts.MaxInt)
+		actionRegistry, authRegistry = parser.Registry()
+		kind                         = p.UnpackByte()
+	)
+	tx, err := chain.UnmarshalTx(p, actionRegistry, authRegistry)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !p.Empty() {
+		return 0, nil, chain.ErrInvalidObject
+	}
+	return kind, tx, p.Err()
+}
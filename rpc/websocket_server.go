@@ -9,6 +9,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/chain"
@@ -21,25 +23,172 @@ import (
 type WebSocketServer struct {
 	logger logging.Logger
 	s      *pubsub.Server
+	topics *pubsub.Topics
 
 	blockListeners *pubsub.Connections
 
+	filterL           sync.Mutex
+	filteredListeners map[*pubsub.Connection]*TxFilter
+
 	txL         sync.Mutex
 	txListeners map[ids.ID]*pubsub.Connections
 	expiringTxs *emap.EMap[*chain.Transaction] // ensures all tx listeners are eventually responded to
+
+	preconfL         sync.Mutex
+	preconfListeners *pubsub.Connections
+	processingTxs    map[ids.ID]set.Set[ids.ID] // txID -> processing blocks that include it
 }
 
-func NewWebSocketServer(vm VM, maxPendingMessages int) (*WebSocketServer, *pubsub.Server) {
+// NewWebSocketServer returns the WebSocket server of [vm], the pubsub server
+// that serves it, and the registry of the metrics of its topics. Up to
+// [replayBufferSize] bytes of the recent events of each topic are kept for
+// subscribers that resume after reconnecting.
+func NewWebSocketServer(vm VM, maxPendingMessages int, replayBufferSize int) (*WebSocketServer, *pubsub.Server, *prometheus.Registry, error) {
 	w := &WebSocketServer{
-		logger:         vm.Logger(),
-		blockListeners: pubsub.NewConnections(),
-		txListeners:    map[ids.ID]*pubsub.Connections{},
-		expiringTxs:    emap.NewEMap[*chain.Transaction](),
+		logger:            vm.Logger(),
+		blockListeners:    pubsub.NewConnections(),
+		filteredListeners: map[*pubsub.Connection]*TxFilter{},
+		txListeners:       map[ids.ID]*pubsub.Connections{},
+		expiringTxs:       emap.NewEMap[*chain.Transaction](),
+		preconfListeners:  pubsub.NewConnections(),
+		processingTxs:     map[ids.ID]set.Set[ids.ID]{},
 	}
 	cfg := pubsub.NewDefaultServerConfig()
 	cfg.MaxPendingMessages = maxPendingMessages
+	cfg.ReplayBufferSize = replayBufferSize
 	w.s = pubsub.New(w.logger, cfg, w.MessageCallback(vm))
-	return w, w.s
+	topics, registry, err := pubsub.NewTopics(w.s)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	w.topics = topics
+	if err := w.RegisterTopic(BlocksTopic, parseNoParams); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := w.RegisterTopic(TxsTopic, parseTxFilter); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := w.RegisterTopic(PendingTxsTopic, parsePendingTxFilter); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := w.RegisterTopic(TxStatusTopic, parseTxStatusFilter); err != nil {
+		return nil, nil, nil, err
+	}
+	return w, w.s, registry, nil
+}
+
+// RegisterTopic adds the topic [name], whose subscriptions are filtered by
+// the filters returned by [parse], to the topics clients can subscribe to.
+func (w *WebSocketServer) RegisterTopic(name string, parse pubsub.FilterParser) error {
+	return w.topics.Register(name, parse)
+}
+
+// PublishTopic sends [payload] to the subscriptions of [name] whose filter
+// matches [event].
+func (w *WebSocketServer) PublishTopic(name string, event any, payload []byte) error {
+	return w.topics.Publish(name, event, func(seq uint64) ([]byte, error) {
+		return packTopicEvent(name, seq, payload)
+	})
+}
+
+func packTopicEvent(name string, seq uint64, payload []byte) ([]byte, error) {
+	bytes, err := PackTopicMessage(&TopicMessage{Kind: EventKind, Topic: name, Seq: seq, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{TopicMode}, bytes...), nil
+}
+
+// handleTopicCommand applies the subscription command [msg] of [c] and
+// replies with its result.
+func (w *WebSocketServer) handleTopicCommand(msg []byte, c *pubsub.Connection) {
+	m, err := UnpackTopicMessage(msg)
+	if err != nil {
+		w.logger.Error("failed to unmarshal topic command",
+			zap.Int("len", len(msg)),
+			zap.Error(err),
+		)
+		return
+	}
+	var seq uint64
+	switch m.Kind {
+	case SubscribeKind:
+		seq, err = w.topics.Subscribe(c, m.Topic, m.Payload, m.Seq)
+	case UnsubscribeKind:
+		err = w.topics.Unsubscribe(c, m.Topic)
+	default:
+		err = ErrInvalidTopicKind
+	}
+	reply := &TopicMessage{Kind: m.Kind, Topic: m.Topic, Seq: seq}
+	if err != nil {
+		w.logger.Debug("rejected topic command",
+			zap.Uint8("kind", m.Kind),
+			zap.String("topic", m.Topic),
+			zap.Error(err),
+		)
+		reply = &TopicMessage{Kind: ErrorKind, Topic: m.Topic, Payload: []byte(err.Error())}
+	}
+	bytes, err := PackTopicMessage(reply)
+	if err != nil {
+		w.logger.Error("failed to pack topic reply", zap.Error(err))
+		return
+	}
+	if !c.Send(append([]byte{TopicMode}, bytes...)) {
+		w.logger.Verbo("dropping topic reply due to too many pending messages")
+	}
+}
+
+// publishTopics publishes [b] and its transactions to the built-in topics.
+func (w *WebSocketServer) publishTopics(b *chain.StatelessBlock) error {
+	if w.topics.Active(BlocksTopic) {
+		// [b] is not the event, as it would be retained for replay (with its
+		// view)
+		if err := w.topics.Publish(BlocksTopic, nil, func(seq uint64) ([]byte, error) {
+			bytes, err := PackBlockMessage(b)
+			if err != nil {
+				return nil, err
+			}
+			return packTopicEvent(BlocksTopic, seq, bytes)
+		}); err != nil {
+			return err
+		}
+	}
+	if !w.topics.Active(TxsTopic) {
+		return nil
+	}
+	results := b.Results()
+	for i, tx := range b.Txs {
+		if err := w.topics.Publish(TxsTopic, &txEvent{tx, results[i]}, func(seq uint64) ([]byte, error) {
+			bytes, err := PackFilteredTxsMessage(b, []int{i})
+			if err != nil {
+				return nil, err
+			}
+			return packTopicEvent(TxsTopic, seq, bytes)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PublishPendingTxs publishes the event [kind] (like [PendingAdmitted]) of
+// each of [txs] to [PendingTxsTopic].
+func (w *WebSocketServer) PublishPendingTxs(kind byte, txs []*chain.Transaction) error {
+	if !w.topics.Active(PendingTxsTopic) {
+		return nil
+	}
+	for _, tx := range txs {
+		if err := w.topics.Publish(PendingTxsTopic, tx, func(seq uint64) ([]byte, error) {
+			bytes, err := PackPendingTxMessage(kind, tx)
+			if err != nil {
+				return nil, err
+			}
+			return packTopicEvent(PendingTxsTopic, seq, bytes)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Note: no need to have a tx listener removal, this will happen when all
@@ -57,6 +206,16 @@ func (w *WebSocketServer) AddTxListener(tx *chain.Transaction, c *pubsub.Connect
 	w.expiringTxs.Add([]*chain.Transaction{tx})
 }
 
+// AddFilteredListener streams accepted transactions that match [filter] to
+// [c]. Each connection can have at most one filter, so calling this again
+// replaces any previous filter.
+func (w *WebSocketServer) AddFilteredListener(filter *TxFilter, c *pubsub.Connection) {
+	w.filterL.Lock()
+	defer w.filterL.Unlock()
+
+	w.filteredListeners[c] = filter
+}
+
 // If never possible for a tx to enter mempool, call this
 func (w *WebSocketServer) RemoveTx(txID ids.ID, err error) error {
 	w.txL.Lock()
@@ -96,7 +255,85 @@ func (w *WebSocketServer) SetMinTx(t int64) error {
 	return nil
 }
 
+// VerifyBlock notifies pre-confirmation listeners that the transactions of
+// [b] are included in a processing block.
+func (w *WebSocketServer) VerifyBlock(b *chain.StatelessBlock) error {
+	w.preconfL.Lock()
+	defer w.preconfL.Unlock()
+
+	// Transactions are only tracked while there are listeners, so rollbacks
+	// are only published for transactions that were pre-confirmed
+	if w.preconfListeners.Len() == 0 || len(b.Txs) == 0 {
+		return nil
+	}
+	blkID := b.ID()
+	txIDs := make([]ids.ID, len(b.Txs))
+	for i, tx := range b.Txs {
+		txID := tx.ID()
+		txIDs[i] = txID
+		blks, ok := w.processingTxs[txID]
+		if !ok {
+			blks = set.NewSet[ids.ID](1)
+			w.processingTxs[txID] = blks
+		}
+		blks.Add(blkID)
+	}
+	return w.publishPreconf(&Preconfirmation{BlockID: blkID, Height: b.Hght, TxIDs: txIDs})
+}
+
+// RejectBlock notifies pre-confirmation listeners of the transactions of [b]
+// that are no longer included in any processing block.
+func (w *WebSocketServer) RejectBlock(b *chain.StatelessBlock) error {
+	w.preconfL.Lock()
+	defer w.preconfL.Unlock()
+
+	blkID := b.ID()
+	txIDs := []ids.ID{}
+	for _, tx := range b.Txs {
+		txID := tx.ID()
+		blks, ok := w.processingTxs[txID]
+		if !ok || !blks.Contains(blkID) {
+			// Not pre-confirmed or already accepted in a sibling of [b]
+			continue
+		}
+		blks.Remove(blkID)
+		if blks.Len() == 0 {
+			delete(w.processingTxs, txID)
+			txIDs = append(txIDs, txID)
+		}
+	}
+	if len(txIDs) == 0 {
+		return nil
+	}
+	return w.publishPreconf(&Preconfirmation{BlockID: blkID, Height: b.Hght, Rejected: true, TxIDs: txIDs})
+}
+
+// publishPreconf sends [p] to the pre-confirmation listeners. The caller must
+// hold [w.preconfL].
+func (w *WebSocketServer) publishPreconf(p *Preconfirmation) error {
+	if w.preconfListeners.Len() == 0 {
+		return nil
+	}
+	bytes, err := PackPreconfMessage(p)
+	if err != nil {
+		return err
+	}
+	inactiveConnection := w.s.Publish(append([]byte{PreconfMode}, bytes...), w.preconfListeners)
+	for _, conn := range inactiveConnection {
+		w.preconfListeners.Remove(conn)
+	}
+	return nil
+}
+
 func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
+	// Accepted transactions are reported to tx listeners, so they are never
+	// rolled back when the siblings of [b] are rejected
+	w.preconfL.Lock()
+	for _, tx := range b.Txs {
+		delete(w.processingTxs, tx.ID())
+	}
+	w.preconfL.Unlock()
+
 	if w.blockListeners.Len() > 0 {
 		bytes, err := PackBlockMessage(b)
 		if err != nil {
@@ -108,6 +345,13 @@ func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
 		}
 	}
 
+	if err := w.publishFiltered(b); err != nil {
+		return err
+	}
+	if err := w.publishTopics(b); err != nil {
+		return err
+	}
+
 	w.txL.Lock()
 	defer w.txL.Unlock()
 	results := b.Results()
@@ -129,6 +373,36 @@ func (w *WebSocketServer) AcceptBlock(b *chain.StatelessBlock) error {
 	return nil
 }
 
+func (w *WebSocketServer) publishFiltered(b *chain.StatelessBlock) error {
+	w.filterL.Lock()
+	defer w.filterL.Unlock()
+
+	results := b.Results()
+	for c, filter := range w.filteredListeners {
+		if !w.s.Connections().Has(c) {
+			delete(w.filteredListeners, c)
+			continue
+		}
+		indices := []int{}
+		for i, tx := range b.Txs {
+			if filter.Match(tx, results[i]) {
+				indices = append(indices, i)
+			}
+		}
+		if len(indices) == 0 {
+			continue
+		}
+		bytes, err := PackFilteredTxsMessage(b, indices)
+		if err != nil {
+			return err
+		}
+		if !c.Send(append([]byte{FilteredTxMode}, bytes...)) {
+			w.logger.Verbo("dropping filtered txs message due to too many pending messages")
+		}
+	}
+	return nil
+}
+
 func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 	// Assumes controller is initialized before this is called
 	var (
@@ -194,6 +468,22 @@ func (w *WebSocketServer) MessageCallback(vm VM) pubsub.Callback {
 				return
 			}
 			log.Debug("submitted tx", zap.Stringer("id", txID))
+		case FilteredTxMode:
+			filter, err := UnpackFilteredTxsRequest(msgBytes[1:])
+			if err != nil {
+				log.Error("failed to unmarshal tx filter",
+					zap.Int("len", len(msgBytes)),
+					zap.Error(err),
+				)
+				return
+			}
+			w.AddFilteredListener(filter, c)
+			log.Debug("added filtered tx listener")
+		case PreconfMode:
+			w.preconfListeners.Add(c)
+			log.Debug("added pre-confirmation listener")
+		case TopicMode:
+			w.handleTopicCommand(msgBytes[1:], c)
 		default:
 			log.Error("unexpected message type",
 				zap.Int("len", len(msgBytes)),
@@ -0,0 +1,335 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/pubsub"
+)
+
+// testChainVM parses blocks below its last accepted block, which are not
+// executed.
+type testChainVM struct {
+	chain.VM
+}
+
+func (*testChainVM) Tracer() trace.Tracer { return trace.Noop }
+
+func (*testChainVM) Rules(int64) chain.Rules { return &testChainRules{} }
+
+func (*testChainVM) LastAcceptedBlock() *chain.StatelessBlock {
+	return &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Hght: consts.MaxUint64}}
+}
+
+type testChainRules struct {
+	chain.Rules
+}
+
+func (*testChainRules) GetBlockTimestampTolerance() int64 { return 1_000 }
+
+func newTestBlock(t *testing.T, parent ids.ID, txs ...*chain.Transaction) *chain.StatelessBlock {
+	blk, err := chain.ParseStatefulBlock(
+		context.Background(),
+		&chain.StatefulBlock{Prnt: parent, Hght: 1, Txs: txs},
+		nil,
+		choices.Processing,
+		&testChainVM{},
+	)
+	require.NoError(t, err)
+	return blk
+}
+
+func TestWebSocketPreconfirmations(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestEthVM(t)
+	w, pubsubServer, _, err := NewWebSocketServer(vm, 1_024, 0)
+	require.NoError(err)
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketEndpoint, pubsubServer)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cli, err := NewWebSocketClient(server.URL, DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	require.NoError(err)
+	defer cli.Close()
+	require.NoError(cli.RegisterPreconfs())
+	require.Eventually(func() bool {
+		w.preconfL.Lock()
+		defer w.preconfL.Unlock()
+		return w.preconfListeners.Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	actor := codec.CreateAddress(0, ids.GenerateTestID())
+	tx1 := vm.newTx(t, actor, 1)
+	tx2 := vm.newTx(t, actor, 2)
+	parent := ids.GenerateTestID()
+	blk1 := newTestBlock(t, parent, tx1)
+	blk2 := newTestBlock(t, parent, tx1, tx2)
+	require.NotEqual(blk1.ID(), blk2.ID())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(w.VerifyBlock(blk1))
+	require.NoError(w.VerifyBlock(blk2))
+	pc, err := cli.ListenPreconf(ctx)
+	require.NoError(err)
+	require.Equal(&Preconfirmation{BlockID: blk1.ID(), Height: 1, TxIDs: []ids.ID{tx1.ID()}}, pc)
+	pc, err = cli.ListenPreconf(ctx)
+	require.NoError(err)
+	require.Equal(&Preconfirmation{BlockID: blk2.ID(), Height: 1, TxIDs: []ids.ID{tx1.ID(), tx2.ID()}}, pc)
+
+	// Only the transactions that were not accepted in the sibling of the
+	// rejected block are rolled back
+	require.NoError(w.AcceptBlock(blk1))
+	require.NoError(w.RejectBlock(blk2))
+	pc, err = cli.ListenPreconf(ctx)
+	require.NoError(err)
+	require.Equal(&Preconfirmation{BlockID: blk2.ID(), Height: 1, Rejected: true, TxIDs: []ids.ID{tx2.ID()}}, pc)
+	require.Empty(w.processingTxs)
+
+	// Rejecting a block twice does not roll back its transactions again
+	require.NoError(w.RejectBlock(blk2))
+	select {
+	case <-cli.pendingPreconfs:
+		require.FailNow("unexpected pre-confirmation")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWebSocketTopics(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestEthVM(t)
+	w, pubsubServer, _, err := NewWebSocketServer(vm, 1_024, 0)
+	require.NoError(err)
+	require.NoError(w.RegisterTopic("custom", func([]byte) (pubsub.Filter, error) { return nil, nil }))
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketEndpoint, pubsubServer)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cli, err := NewWebSocketClient(server.URL, DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	require.NoError(err)
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Commands are acknowledged once applied
+	require.NoError(cli.Subscribe(BlocksTopic, nil))
+	m, err := cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(&TopicMessage{Kind: SubscribeKind, Topic: BlocksTopic}, m)
+	filter, err := PackFilteredTxsRequest(&TxFilter{FailedOnly: true})
+	require.NoError(err)
+	require.NoError(cli.Subscribe(TxsTopic, filter))
+	m, err = cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(&TopicMessage{Kind: SubscribeKind, Topic: TxsTopic}, m)
+
+	// Invalid commands are rejected
+	require.NoError(cli.Subscribe("missing", nil))
+	m, err = cli.ListenTopic(ctx)
+	require.ErrorIs(err, ErrTopicCommandFailed)
+	require.Equal(ErrorKind, m.Kind)
+	require.Equal("missing", m.Topic)
+	require.NoError(cli.Subscribe(BlocksTopic, []byte{1}))
+	_, err = cli.ListenTopic(ctx)
+	require.ErrorIs(err, ErrTopicCommandFailed)
+	require.NoError(cli.Unsubscribe("custom"))
+	_, err = cli.ListenTopic(ctx)
+	require.ErrorIs(err, ErrTopicCommandFailed)
+
+	// Controller topics are published with [PublishTopic]
+	require.NoError(cli.Subscribe("custom", nil))
+	m, err = cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(&TopicMessage{Kind: SubscribeKind, Topic: "custom"}, m)
+	require.NoError(w.PublishTopic("custom", nil, []byte("event")))
+	m, err = cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(&TopicMessage{Kind: EventKind, Topic: "custom", Seq: 1, Payload: []byte("event")}, m)
+
+	require.NoError(cli.Unsubscribe(BlocksTopic))
+	m, err = cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(&TopicMessage{Kind: UnsubscribeKind, Topic: BlocksTopic}, m)
+
+	// Blocks without transactions send nothing to tx subscribers
+	require.NoError(w.AcceptBlock(newTestBlock(t, ids.GenerateTestID())))
+	select {
+	case <-cli.pendingTopics:
+		require.FailNow("unexpected topic message")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWebSocketTopicResume(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestEthVM(t)
+	w, pubsubServer, _, err := NewWebSocketServer(vm, 1_024, pubsub.ReplayBufferSize)
+	require.NoError(err)
+	require.NoError(w.RegisterTopic("custom", func([]byte) (pubsub.Filter, error) { return nil, nil }))
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketEndpoint, pubsubServer)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cli, err := NewWebSocketClient(server.URL, DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	require.NoError(err)
+	require.NoError(cli.Subscribe("custom", nil))
+	_, err = cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.NoError(w.PublishTopic("custom", nil, []byte("1")))
+	m, err := cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(uint64(1), m.Seq)
+	last := cli.LastSeq("custom")
+	require.Equal(uint64(1), last)
+	require.NoError(cli.Close())
+
+	// Events published while disconnected are replayed before the
+	// acknowledgement of the resumed subscription
+	require.NoError(w.PublishTopic("custom", nil, []byte("2")))
+	require.NoError(w.PublishTopic("custom", nil, []byte("3")))
+	cli, err = NewWebSocketClient(server.URL, DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	require.NoError(err)
+	defer cli.Close()
+	require.NoError(cli.Resume("custom", nil, last))
+	for _, expected := range []*TopicMessage{
+		{Kind: EventKind, Topic: "custom", Seq: 2, Payload: []byte("2")},
+		{Kind: EventKind, Topic: "custom", Seq: 3, Payload: []byte("3")},
+		{Kind: SubscribeKind, Topic: "custom", Seq: 3},
+	} {
+		m, err := cli.ListenTopic(ctx)
+		require.NoError(err)
+		require.Equal(expected, m)
+	}
+	require.Equal(uint64(3), cli.LastSeq("custom"))
+
+	// Resuming after events the server never published is rejected
+	require.NoError(cli.Resume("custom", nil, 4))
+	_, err = cli.ListenTopic(ctx)
+	require.ErrorIs(err, ErrTopicCommandFailed)
+}
+
+func TestWebSocketPendingTxs(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestEthVM(t)
+	w, pubsubServer, _, err := NewWebSocketServer(vm, 1_024, 0)
+	require.NoError(err)
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketEndpoint, pubsubServer)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cli, err := NewWebSocketClient(server.URL, DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	require.NoError(err)
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Pending transactions have not been executed
+	filter, err := PackFilteredTxsRequest(&TxFilter{FailedOnly: true})
+	require.NoError(err)
+	require.NoError(cli.Subscribe(PendingTxsTopic, filter))
+	_, err = cli.ListenTopic(ctx)
+	require.ErrorIs(err, ErrTopicCommandFailed)
+
+	actor := codec.CreateAddress(0, ids.GenerateTestID())
+	filter, err = PackFilteredTxsRequest(&TxFilter{Addresses: []codec.Address{actor}})
+	require.NoError(err)
+	require.NoError(cli.Subscribe(PendingTxsTopic, filter))
+	m, err := cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(&TopicMessage{Kind: SubscribeKind, Topic: PendingTxsTopic}, m)
+
+	// Only the transactions of [actor] are sent
+	tx1 := vm.newTx(t, actor, 1)
+	tx2 := vm.newTx(t, codec.CreateAddress(0, ids.GenerateTestID()), 2)
+	require.NoError(w.PublishPendingTxs(PendingAdmitted, []*chain.Transaction{tx1, tx2}))
+	require.NoError(w.PublishPendingTxs(PendingExpired, []*chain.Transaction{tx2, tx1}))
+	for _, expected := range []struct {
+		kind byte
+		seq  uint64
+	}{
+		{PendingAdmitted, 1},
+		{PendingExpired, 4},
+	} {
+		m, err := cli.ListenTopic(ctx)
+		require.NoError(err)
+		require.Equal(EventKind, m.Kind)
+		require.Equal(expected.seq, m.Seq)
+		kind, tx, err := UnpackPendingTxMessage(m.Payload, vm)
+		require.NoError(err)
+		require.Equal(expected.kind, kind)
+		require.Equal(tx1.ID(), tx.ID())
+	}
+}
+
+func TestWebSocketTxStatus(t *testing.T) {
+	require := require.New(t)
+
+	vm := newTestEthVM(t)
+	w, pubsubServer, _, err := NewWebSocketServer(vm, 1_024, 0)
+	require.NoError(err)
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketEndpoint, pubsubServer)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cli, err := NewWebSocketClient(server.URL, DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+	require.NoError(err)
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Subscriptions must follow at least one transaction
+	require.NoError(cli.Subscribe(TxStatusTopic, nil))
+	_, err = cli.ListenTopic(ctx)
+	require.ErrorIs(err, ErrTopicCommandFailed)
+
+	txID := ids.GenerateTestID()
+	require.NoError(cli.Subscribe(TxStatusTopic, PackTxStatusRequest([]ids.ID{txID})))
+	m, err := cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(SubscribeKind, m.Kind)
+
+	// Only the statuses of followed transactions are sent
+	require.NoError(w.PublishTxStatus(&TxStatus{TxID: ids.GenerateTestID(), State: TxPending}))
+	expected := &TxStatus{TxID: txID, State: TxFailed, Expiry: 10, Height: 2, Updated: 5, Error: []byte("failed")}
+	require.NoError(w.PublishTxStatus(expected))
+	m, err = cli.ListenTopic(ctx)
+	require.NoError(err)
+	require.Equal(uint64(2), m.Seq)
+	status, err := UnpackTxStatusMessage(m.Payload)
+	require.NoError(err)
+	require.Equal(expected, status)
+
+	// States are encoded by name in JSON
+	b, err := json.Marshal(status)
+	require.NoError(err)
+	require.Contains(string(b), `"state":"failed"`)
+	var decoded TxStatus
+	require.NoError(json.Unmarshal(b, &decoded))
+	require.Equal(expected, &decoded)
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/pubsub"
+)
+
+// Topics served by every [WebSocketServer]. Controllers can register more
+// (see [WebSocketServer.RegisterTopic]).
+const (
+	// BlocksTopic streams accepted blocks (packed by [PackBlockMessage]). It
+	// takes no params.
+	BlocksTopic = "blocks"
+	// TxsTopic streams accepted transactions, one per event (packed by
+	// [PackFilteredTxsMessage]). Its params are an optional [TxFilter] (packed
+	// by [PackFilteredTxsRequest]).
+	TxsTopic = "txs"
+	// PendingTxsTopic streams transactions as they are added to the mempool
+	// of the node and removed from it without being included in a block, one
+	// per event (packed by [PackPendingTxMessage]). Its params are an
+	// optional [TxFilter] (see [TxFilter.MatchPending]).
+	PendingTxsTopic = "pendingTxs"
+	// TxStatusTopic streams the changes of the [TxStatus] of transactions
+	// seen by the node (packed by [PackTxStatusMessage]). Its params are the
+	// IDs of the transactions to follow (packed by [PackTxStatusRequest]).
+	TxStatusTopic = "txStatus"
+)
+
+// Kinds of [TopicMessage]. Clients send [SubscribeKind] and
+// [UnsubscribeKind] commands, which the server echoes back once applied or
+// answers with an [ErrorKind] message.
+//
+// Events are numbered by topic. A subscription resumes after the event [Seq]
+// of its [SubscribeKind] command (if not 0), so a reconnecting client
+// receives the events it missed (that are still buffered by the server)
+// before the acknowledgement of its subscription.
+const (
+	SubscribeKind   byte = 0
+	UnsubscribeKind byte = 1
+	ErrorKind       byte = 2
+	EventKind       byte = 3
+)
+
+// TopicMessage is a message of the topic subscription protocol, sent with
+// [TopicMode].
+type TopicMessage struct {
+	Kind  byte
+	Topic string
+	// Seq is the sequence number of the event of an [EventKind] message, the
+	// event a [SubscribeKind] command resumes after, or the last event
+	// published to the topic in the acknowledgement of a [SubscribeKind]
+	// command.
+	Seq uint64
+	// Payload is the params of a [SubscribeKind] command, the error of an
+	// [ErrorKind] message, or the event of an [EventKind] message.
+	Payload []byte
+}
+
+func PackTopicMessage(m *TopicMessage) ([]byte, error) {
+	size := consts.ByteLen + codec.StringLen(m.Topic) + consts.Uint64Len + codec.BytesLen(m.Payload)
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackByte(m.Kind)
+	p.PackString(m.Topic)
+	p.PackUint64(m.Seq)
+	p.PackBytes(m.Payload)
+	return p.Bytes(), p.Err()
+}
+
+func UnpackTopicMessage(msg []byte) (*TopicMessage, error) {
+	var (
+		p = codec.NewReader(msg, consts.MaxInt)
+		m TopicMessage
+	)
+	m.Kind = p.UnpackByte()
+	m.Topic = p.UnpackString(true)
+	m.Seq = p.UnpackUint64(false)
+	p.UnpackBytes(-1, false, &m.Payload)
+	if len(m.Payload) == 0 {
+		m.Payload = nil
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &m, p.Err()
+}
+
+// txEvent is an event of [TxsTopic].
+type txEvent struct {
+	tx     *chain.Transaction
+	result *chain.Result
+}
+
+// txTopicFilter is the [pubsub.Filter] of a [TxsTopic] subscription.
+type txTopicFilter struct {
+	filter *TxFilter
+}
+
+func (f *txTopicFilter) Match(event any) bool {
+	e := event.(*txEvent)
+	return f.filter.Match(e.tx, e.result)
+}
+
+// pendingTxTopicFilter is the [pubsub.Filter] of a [PendingTxsTopic]
+// subscription. Its events are [*chain.Transaction]s.
+type pendingTxTopicFilter struct {
+	filter *TxFilter
+}
+
+func (f *pendingTxTopicFilter) Match(event any) bool {
+	return f.filter.MatchPending(event.(*chain.Transaction))
+}
+
+func parseNoParams(params []byte) (pubsub.Filter, error) {
+	if len(params) > 0 {
+		return nil, ErrUnexpectedParams
+	}
+	return nil, nil
+}
+
+func parseTxFilter(params []byte) (pubsub.Filter, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	filter, err := UnpackFilteredTxsRequest(params)
+	if err != nil {
+		return nil, err
+	}
+	return &txTopicFilter{filter}, nil
+}
+
+func parsePendingTxFilter(params []byte) (pubsub.Filter, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	filter, err := UnpackFilteredTxsRequest(params)
+	if err != nil {
+		return nil, err
+	}
+	if filter.FailedOnly {
+		// Pending transactions have not been executed
+		return nil, fmt.Errorf("%w: failed only", ErrUnexpectedParams)
+	}
+	return &pendingTxTopicFilter{filter}, nil
+}
@@ -61,3 +61,6 @@ update_avalanchego_run_version "$PWD/examples/morpheusvm/scripts/run.sh" "$VERSI
 # Update AvalancheGo version in examples/tokenvm
 update_avalanchego_mod_version "$PWD/examples/tokenvm" "$VERSION"
 update_avalanchego_run_version "$PWD/examples/tokenvm/scripts/run.sh" "$VERSION"  
+
+# Update AvalancheGo version in examples/stakingvm
+update_avalanchego_mod_version "$PWD/examples/stakingvm" "$VERSION"
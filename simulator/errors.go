@@ -0,0 +1,11 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import "errors"
+
+var (
+	ErrNegativeAdvance  = errors.New("clock cannot move backwards")
+	ErrNondeterministic = errors.New("parallel execution diverged from sequential execution")
+)
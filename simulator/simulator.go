@@ -0,0 +1,520 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator executes sequences of transactions against the chain logic
+// of a hypervm (without a VM, consensus, or a network) with a virtual clock and
+// seeded randomness, so that the same workload always produces the same
+// results and state roots. This makes it possible to write regression tests for
+// changes to fee algorithms, actions, or the executor.
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/executor"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/tstate"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+type Config struct {
+	Genesis      vm.Genesis
+	Parser       chain.Parser
+	StateManager chain.StateManager
+
+	// Seed seeds the randomness available to workloads (see [Simulator.Rand]).
+	Seed int64
+	// Start is the time (in ms) of the genesis block (and the initial time of
+	// the virtual clock).
+	Start int64
+	// ExecutionCores is the concurrency of the executor that re-executes each
+	// block after it is built (defaults to 4).
+	ExecutionCores int
+}
+
+// Block is the outcome of a block produced by the [Simulator].
+type Block struct {
+	Height    uint64
+	Timestamp int64
+
+	// Txs are the transactions included in the block (with one result each).
+	// Transactions that could not be included (because they were invalid,
+	// could not pay fees, repeated an earlier transaction, or did not fit in the
+	// block) are in [Dropped].
+	Txs     []*chain.Transaction
+	Results []*chain.Result
+	Dropped []*chain.Transaction
+
+	UnitPrices    fees.Dimensions
+	UnitsConsumed fees.Dimensions
+
+	// StateRoot is the root of the state after the block is executed.
+	StateRoot ids.ID
+}
+
+// Simulator produces blocks from the transactions given to it. Blocks are
+// built like the transactions were taken from the mempool in order and each
+// block is re-executed with the [executor.Executor] (like blocks are verified)
+// to ensure that both executions agree.
+//
+// Incoming warp messages are not verified and expired keys are not swept from
+// state (if the [chain.StateManager] is a [chain.RentManager]).
+type Simulator struct {
+	config Config
+	rand   *rand.Rand
+	db     merkledb.MerkleDB
+
+	now       int64
+	height    uint64
+	timestamp int64
+	fees      []byte
+
+	// seen maps the ID of each included transaction to its expiry
+	seen map[ids.ID]int64
+}
+
+// New creates a [Simulator] with the state of [config.Genesis].
+func New(ctx context.Context, config Config) (*Simulator, error) {
+	if config.ExecutionCores <= 0 {
+		config.ExecutionCores = 4
+	}
+	db, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		BranchFactor:                config.Genesis.GetStateBranchFactor(),
+		RootGenConcurrency:          1,
+		HistoryLength:               1,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.KiB,
+		IntermediateWriteBatchSize:  units.KiB,
+		Reg:                         prometheus.NewRegistry(),
+		TraceLevel:                  merkledb.InfoTrace,
+		Tracer:                      trace.Noop,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sps := state.NewSimpleMutable(db)
+	if err := config.Genesis.Load(ctx, trace.Noop, sps); err != nil {
+		return nil, fmt.Errorf("%w: could not load genesis", err)
+	}
+	feeManager := fees.NewManager(nil)
+	minUnitPrice := config.Parser.Rules(config.Start).GetMinUnitPrice()
+	for i := fees.Dimension(0); i < fees.FeeDimensions; i++ {
+		feeManager.SetUnitPrice(i, minUnitPrice[i])
+	}
+	s := &Simulator{
+		config:    config,
+		rand:      rand.New(rand.NewSource(config.Seed)), //nolint:gosec
+		db:        db,
+		now:       config.Start,
+		timestamp: config.Start,
+		fees:      feeManager.Bytes(),
+		seen:      map[ids.ID]int64{},
+	}
+	if err := s.insertMetadata(ctx, sps); err != nil {
+		return nil, err
+	}
+	if err := sps.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rand returns the randomness of the simulation (seeded by [Config.Seed]).
+// Workloads must only use this source of randomness to be reproducible.
+func (s *Simulator) Rand() *rand.Rand {
+	return s.rand
+}
+
+// Now returns the time (in ms) of the virtual clock, which is the timestamp of
+// the next block produced.
+func (s *Simulator) Now() int64 {
+	return s.now
+}
+
+// Advance moves the virtual clock forward by [ms].
+func (s *Simulator) Advance(ms int64) error {
+	if ms < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeAdvance, ms)
+	}
+	s.now += ms
+	return nil
+}
+
+// Height returns the height of the last block produced (0 is genesis).
+func (s *Simulator) Height() uint64 {
+	return s.height
+}
+
+// Rules returns the rules of the next block produced.
+func (s *Simulator) Rules() chain.Rules {
+	return s.config.Parser.Rules(s.now)
+}
+
+// State returns the state after the last block produced.
+func (s *Simulator) State() state.Immutable {
+	return s.db
+}
+
+// StateRoot returns the root of the state after the last block produced.
+func (s *Simulator) StateRoot(ctx context.Context) (ids.ID, error) {
+	return s.db.GetMerkleRoot(ctx)
+}
+
+// UnitPrices returns the unit prices of the next block produced (if it is
+// produced at [Now]).
+func (s *Simulator) UnitPrices() (fees.Dimensions, error) {
+	feeManager, err := fees.NewManager(s.fees).ComputeNext(s.now, s.Rules())
+	if err != nil {
+		return fees.Dimensions{}, err
+	}
+	return feeManager.UnitPrices(), nil
+}
+
+// GenerateKey returns an ED25519 private key derived from [Rand].
+func (s *Simulator) GenerateKey() (ed25519.PrivateKey, error) {
+	seed := make([]byte, ed25519.PrivateKeySeedLen)
+	_, _ = s.rand.Read(seed)
+	return ed25519.PrivateKeyFromSeed(seed)
+}
+
+// GenerateTx signs a transaction of [actions] that is valid for the next
+// block (with the max fee suggested at the current unit prices).
+func (s *Simulator) GenerateTx(actions []chain.Action, authFactory chain.AuthFactory) (*chain.Transaction, error) {
+	r := s.Rules()
+	unitPrices, err := s.UnitPrices()
+	if err != nil {
+		return nil, err
+	}
+	units, err := chain.EstimateUnits(r, actions, authFactory)
+	if err != nil {
+		return nil, err
+	}
+	maxFee, err := fees.MulSum(unitPrices, units)
+	if err != nil {
+		return nil, err
+	}
+	base := &chain.Base{
+		Timestamp: utils.UnixRMilli(s.now, r.GetValidityWindow()),
+		ChainID:   r.ChainID(),
+		MaxFee:    maxFee,
+	}
+	actionRegistry, authRegistry := s.config.Parser.Registry()
+	return chain.NewTx(base, actions).Sign(authFactory, actionRegistry, authRegistry)
+}
+
+// Produce builds a block at [Now] from [txs] (in order) and executes it.
+func (s *Simulator) Produce(ctx context.Context, txs []*chain.Transaction) (*Block, error) {
+	r := s.Rules()
+	if s.now < s.timestamp+r.GetMinBlockGap() {
+		return nil, fmt.Errorf("%w: allowed in %d ms", chain.ErrTimestampTooEarly, s.timestamp+r.GetMinBlockGap()-s.now)
+	}
+	parentFeeManager := fees.NewManager(s.fees)
+	feeManager, err := parentFeeManager.ComputeNext(s.now, r)
+	if err != nil {
+		return nil, err
+	}
+	blk := &Block{
+		Height:     s.height + 1,
+		Timestamp:  s.now,
+		Txs:        []*chain.Transaction{},
+		Results:    []*chain.Result{},
+		Dropped:    []*chain.Transaction{},
+		UnitPrices: feeManager.UnitPrices(),
+	}
+
+	// Build the block
+	var (
+		ts            = tstate.New(len(txs) * 2)
+		discounted    int
+		maxDiscounted int
+		included      = map[ids.ID]struct{}{}
+	)
+	dr, discounts := r.(chain.FeeDiscountRules)
+	if discounts {
+		maxDiscounted = dr.GetMaxDiscountedTxs()
+	}
+	for _, tx := range txs {
+		if _, ok := s.seen[tx.ID()]; ok {
+			blk.Dropped = append(blk.Dropped, tx)
+			continue
+		}
+		if _, ok := included[tx.ID()]; ok {
+			blk.Dropped = append(blk.Dropped, tx)
+			continue
+		}
+		discount := discounts && dr.FeeDiscount(tx) > 0
+		if discount && discounted == maxDiscounted {
+			blk.Dropped = append(blk.Dropped, tx)
+			continue
+		}
+		result, tsv, err := s.execute(ctx, r, ts, feeManager, tx)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			blk.Dropped = append(blk.Dropped, tx)
+			continue
+		}
+		if ok, _ := feeManager.Consume(result.Units, r.GetMaxBlockUnits()); !ok {
+			blk.Dropped = append(blk.Dropped, tx)
+			continue
+		}
+		if discount {
+			discounted++
+		}
+		tsv.Commit()
+		included[tx.ID()] = struct{}{}
+		blk.Txs = append(blk.Txs, tx)
+		blk.Results = append(blk.Results, result)
+	}
+	if len(blk.Txs) == 0 && s.now < s.timestamp+r.GetMinEmptyBlockGap() {
+		return nil, fmt.Errorf("%w: allowed in %d ms", chain.ErrNoTxs, s.timestamp+r.GetMinEmptyBlockGap()-s.now)
+	}
+	blk.UnitsConsumed = feeManager.UnitsConsumed()
+	view, err := s.export(ctx, ts, parentFeeManager, feeManager, blk)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(ctx, r, parentFeeManager, blk, view); err != nil {
+		return nil, err
+	}
+
+	// Accept the block
+	if err := view.CommitToDB(ctx); err != nil {
+		return nil, err
+	}
+	root, err := s.db.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blk.StateRoot = root
+	s.height = blk.Height
+	s.timestamp = blk.Timestamp
+	s.fees = feeManager.Bytes()
+	for id, expiry := range s.seen {
+		if expiry < s.timestamp {
+			delete(s.seen, id)
+		}
+	}
+	for _, tx := range blk.Txs {
+		s.seen[tx.ID()] = tx.Expiry()
+	}
+	return blk, nil
+}
+
+// execute runs [tx] on a new view of [ts] (which is not committed). If [tx]
+// can't be included in a block, it returns a nil [chain.Result].
+func (s *Simulator) execute(
+	ctx context.Context,
+	r chain.Rules,
+	ts *tstate.TState,
+	feeManager *fees.Manager,
+	tx *chain.Transaction,
+) (*chain.Result, *tstate.TStateView, error) {
+	sm := s.config.StateManager
+	stateKeys, err := tx.StateKeys(sm)
+	if errors.Is(err, tstate.ErrReservedKey) {
+		// An action that declares a reserved key is a bug in the VM (not in
+		// the workload)
+		return nil, nil, fmt.Errorf("tx %s: %w", tx.ID(), err)
+	}
+	if err != nil {
+		return nil, nil, nil
+	}
+	digest, err := tx.Digest()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := tx.Auth.Verify(ctx, digest); err != nil {
+		return nil, nil, nil
+	}
+	storage, err := s.fetch(ctx, stateKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	tsv := ts.NewView(stateKeys, storage)
+	if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, s.now); err != nil {
+		return nil, nil, nil
+	}
+	result, err := tx.Execute(ctx, feeManager, sm, r, tsv, s.now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, tsv, nil
+}
+
+// fetch reads [stateKeys] from the state after the last block produced.
+func (s *Simulator) fetch(ctx context.Context, stateKeys state.Keys) (map[string][]byte, error) {
+	storage := make(map[string][]byte, len(stateKeys))
+	for k := range stateKeys {
+		v, err := s.db.GetValue(ctx, []byte(k))
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := keys.NumChunks(v); !ok {
+			return nil, chain.ErrInvalidKeyValue
+		}
+		storage[k] = v
+	}
+	return storage, nil
+}
+
+// export records the metadata of [blk] in [ts] and returns a view of the state
+// after [blk].
+func (s *Simulator) export(
+	ctx context.Context,
+	ts *tstate.TState,
+	parentFeeManager *fees.Manager,
+	feeManager *fees.Manager,
+	blk *Block,
+) (merkledb.View, error) {
+	sm := s.config.StateManager
+	var (
+		heightKey    = string(chain.HeightKey(sm.HeightKey()))
+		timestampKey = string(chain.TimestampKey(sm.TimestampKey()))
+		feeKey       = string(chain.FeeKey(sm.FeeKey()))
+	)
+	metadata := make(state.Keys)
+	metadata.Add(heightKey, state.Write)
+	metadata.Add(timestampKey, state.Write)
+	metadata.Add(feeKey, state.Write)
+	tsv := ts.NewView(metadata, map[string][]byte{
+		heightKey:    binary.BigEndian.AppendUint64(nil, s.height),
+		timestampKey: binary.BigEndian.AppendUint64(nil, uint64(s.timestamp)),
+		feeKey:       parentFeeManager.Bytes(),
+	})
+	if err := tsv.Insert(ctx, []byte(heightKey), binary.BigEndian.AppendUint64(nil, blk.Height)); err != nil {
+		return nil, err
+	}
+	if err := tsv.Insert(ctx, []byte(timestampKey), binary.BigEndian.AppendUint64(nil, uint64(blk.Timestamp))); err != nil {
+		return nil, err
+	}
+	if err := tsv.Insert(ctx, []byte(feeKey), feeManager.Bytes()); err != nil {
+		return nil, err
+	}
+	tsv.Commit()
+	return ts.ExportMerkleDBView(ctx, trace.Noop, s.db)
+}
+
+// verify re-executes the transactions of [blk] with the [executor.Executor]
+// and ensures that the results and the resulting state match those of the
+// sequential execution that built [blk] (which resulted in [view]).
+func (s *Simulator) verify(
+	ctx context.Context,
+	r chain.Rules,
+	parentFeeManager *fees.Manager,
+	blk *Block,
+	view merkledb.View,
+) error {
+	feeManager, err := parentFeeManager.ComputeNext(blk.Timestamp, r)
+	if err != nil {
+		return err
+	}
+	var (
+		sm      = s.config.StateManager
+		ts      = tstate.New(len(blk.Txs) * 2)
+		results = make([]*chain.Result, len(blk.Txs))
+		e       = executor.New(len(blk.Txs), s.config.ExecutionCores, chain.MaxKeyDependencies, nil)
+	)
+	for li, ltx := range blk.Txs {
+		i := li
+		tx := ltx
+
+		stateKeys, err := tx.StateKeys(sm)
+		if err != nil {
+			e.Stop()
+			return err
+		}
+		units, err := tx.Units(sm, r)
+		if err != nil {
+			e.Stop()
+			return err
+		}
+		if ok, d := feeManager.Consume(units, r.GetMaxBlockUnits()); !ok {
+			e.Stop()
+			return fmt.Errorf("%w: %d too large", chain.ErrInvalidUnitsConsumed, d)
+		}
+		e.Run(stateKeys, func() error {
+			storage, err := s.fetch(ctx, stateKeys)
+			if err != nil {
+				return err
+			}
+			tsv := ts.NewView(stateKeys, storage)
+			if err := tx.PreExecute(ctx, feeManager, sm, r, tsv, blk.Timestamp); err != nil {
+				return err
+			}
+			result, err := tx.Execute(ctx, feeManager, sm, r, tsv, blk.Timestamp)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+			tsv.Commit()
+			return nil
+		})
+	}
+	if err := e.Wait(); err != nil {
+		return fmt.Errorf("%w: %w", ErrNondeterministic, err)
+	}
+	expected, err := chain.MarshalResults(blk.Results)
+	if err != nil {
+		return err
+	}
+	actual, err := chain.MarshalResults(results)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expected, actual) {
+		return fmt.Errorf("%w: results", ErrNondeterministic)
+	}
+	verified, err := s.export(ctx, ts, parentFeeManager, feeManager, blk)
+	if err != nil {
+		return err
+	}
+	expectedRoot, err := view.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	actualRoot, err := verified.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if expectedRoot != actualRoot {
+		return fmt.Errorf("%w: expected=%s found=%s", ErrNondeterministic, expectedRoot, actualRoot)
+	}
+	return nil
+}
+
+// insertMetadata records the height, timestamp, and fees of the last block
+// produced in [mu].
+func (s *Simulator) insertMetadata(ctx context.Context, mu state.Mutable) error {
+	sm := s.config.StateManager
+	if err := mu.Insert(ctx, chain.HeightKey(sm.HeightKey()), binary.BigEndian.AppendUint64(nil, s.height)); err != nil {
+		return err
+	}
+	if err := mu.Insert(ctx, chain.TimestampKey(sm.TimestampKey()), binary.BigEndian.AppendUint64(nil, uint64(s.timestamp))); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, chain.FeeKey(sm.FeeKey()), s.fees)
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+// Step is the production of a block from [Txs] after the virtual clock is
+// advanced by [Advance] (in ms).
+type Step struct {
+	Advance int64
+	Txs     []*chain.Transaction
+}
+
+// Workload generates the steps of a simulation. Next returns nil once the
+// workload is done.
+type Workload interface {
+	Next(ctx context.Context, s *Simulator) (*Step, error)
+}
+
+// WorkloadFunc adapts a function to a [Workload].
+type WorkloadFunc func(ctx context.Context, s *Simulator) (*Step, error)
+
+func (f WorkloadFunc) Next(ctx context.Context, s *Simulator) (*Step, error) {
+	return f(ctx, s)
+}
+
+// Script is a [Workload] that generates each of its steps (in order). Each
+// function is called once all of the blocks before it are produced, so it can
+// sign transactions with [Simulator.GenerateTx] and read state.
+func Script(steps ...func(ctx context.Context, s *Simulator) (*Step, error)) Workload {
+	next := 0
+	return WorkloadFunc(func(ctx context.Context, s *Simulator) (*Step, error) {
+		if next == len(steps) {
+			return nil, nil
+		}
+		step := steps[next]
+		next++
+		return step(ctx, s)
+	})
+}
+
+// Random is a [Workload] of [blocks] steps that each advance the virtual clock
+// by a gap chosen uniformly from [minGap, maxGap] and include the transactions
+// returned by [generate].
+//
+// [generate] should only use [Simulator.Rand] as a source of randomness for the
+// simulation to be reproducible.
+func Random(
+	blocks int,
+	minGap int64,
+	maxGap int64,
+	generate func(ctx context.Context, s *Simulator) ([]*chain.Transaction, error),
+) Workload {
+	produced := 0
+	return WorkloadFunc(func(ctx context.Context, s *Simulator) (*Step, error) {
+		if produced == blocks {
+			return nil, nil
+		}
+		produced++
+		gap := minGap
+		if maxGap > minGap {
+			gap += s.Rand().Int63n(maxGap - minGap + 1)
+		}
+		// Transactions are generated at the time they are included
+		if err := s.Advance(gap); err != nil {
+			return nil, err
+		}
+		txs, err := generate(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		return &Step{Txs: txs}, nil
+	})
+}
+
+// Run produces a block for each step of [w] (until it is done) and returns the
+// blocks produced.
+func (s *Simulator) Run(ctx context.Context, w Workload) ([]*Block, error) {
+	blks := []*Block{}
+	for {
+		step, err := w.Next(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		if step == nil {
+			return blks, nil
+		}
+		if err := s.Advance(step.Advance); err != nil {
+			return nil, err
+		}
+		blk, err := s.Produce(ctx, step.Txs)
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, blk)
+	}
+}
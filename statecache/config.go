@@ -0,0 +1,61 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statecache
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+type Config struct {
+	// Size is the number of bytes that can be used to cache
+	// keys that are not part of any namespace in [Namespaces].
+	Size int `json:"size"`
+
+	// Namespaces maps a hex-encoded key prefix to the number of bytes
+	// that can be used to cache keys with that prefix. If a key matches
+	// multiple prefixes, the longest prefix is used.
+	//
+	// This makes it possible to prevent a single class of keys
+	// (like balances) from evicting all others.
+	Namespaces map[string]int `json:"namespaces"`
+}
+
+func NewDefaultConfig() Config {
+	return Config{
+		Size:       0, // disabled
+		Namespaces: map[string]int{},
+	}
+}
+
+// Enabled returns true if any bytes are allocated to the cache.
+func (c Config) Enabled() bool {
+	if c.Size > 0 {
+		return true
+	}
+	for _, size := range c.Namespaces {
+		if size > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Config) namespaces() (map[string]int, error) {
+	namespaces := make(map[string]int, len(c.Namespaces))
+	for prefix, size := range c.Namespaces {
+		rawPrefix, err := hex.DecodeString(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid namespace %s", err, prefix)
+		}
+		if len(rawPrefix) == 0 {
+			return nil, fmt.Errorf("%w: empty namespace", ErrInvalidNamespace)
+		}
+		if size < 0 {
+			return nil, fmt.Errorf("%w: namespace %s has negative size", ErrInvalidNamespace, prefix)
+		}
+		namespaces[string(rawPrefix)] = size
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,8 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statecache
+
+import "errors"
+
+var ErrInvalidNamespace = errors.New("invalid namespace")
@@ -0,0 +1,59 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statecache
+
+import (
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespaceLabel = "namespace"
+
+type metrics struct {
+	hits      *prometheus.CounterVec
+	misses    *prometheus.CounterVec
+	evictions *prometheus.CounterVec
+	flushes   prometheus.Counter
+	size      *prometheus.GaugeVec
+}
+
+func newMetrics() (*prometheus.Registry, *metrics, error) {
+	r := prometheus.NewRegistry()
+	m := &metrics{
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "statecache",
+			Name:      "hits",
+			Help:      "number of state reads served from the cache",
+		}, []string{namespaceLabel}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "statecache",
+			Name:      "misses",
+			Help:      "number of state reads that were not in the cache",
+		}, []string{namespaceLabel}),
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "statecache",
+			Name:      "evictions",
+			Help:      "number of items evicted from the cache to make room for new items",
+		}, []string{namespaceLabel}),
+		flushes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "statecache",
+			Name:      "flushes",
+			Help:      "number of times the entire cache was cleared",
+		}),
+		size: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "statecache",
+			Name:      "size",
+			Help:      "number of bytes stored in the cache",
+		}, []string{namespaceLabel}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		r.Register(m.hits),
+		r.Register(m.misses),
+		r.Register(m.evictions),
+		r.Register(m.flushes),
+		r.Register(m.size),
+	)
+	return r, m, errs.Err
+}
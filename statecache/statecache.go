@@ -0,0 +1,297 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statecache
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/cache"
+)
+
+const (
+	defaultNamespace = "default"
+
+	// entryOverhead is a rough estimate of the memory used to track
+	// a single item in the cache (excluding the key and value).
+	entryOverhead = 64
+)
+
+var (
+	_ merkledb.MerkleDB = (*DB)(nil)
+	_ merkledb.View     = (*view)(nil)
+	_ database.Batch    = (*batch)(nil)
+)
+
+type entry struct {
+	v      []byte
+	exists bool
+}
+
+type partition struct {
+	prefix string
+	name   string
+	items  *cache.SizedLRU[string, *entry]
+
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+	evictions prometheus.Counter
+	size      prometheus.Gauge
+}
+
+// DB wraps a [merkledb.MerkleDB] with a cache of recently read values. The
+// cache is partitioned by key prefix (namespace) and each partition is
+// bounded by the number of bytes it may use.
+//
+// Changes committed through any [merkledb.View] created from [DB] (or its
+// descendants) are written through to the cache. Any other modification
+// of the underlying database (like committing range proofs during state
+// sync) clears the cache.
+type DB struct {
+	merkledb.MerkleDB
+
+	// commitLock ensures that a value read from the underlying database
+	// that is stale by the time it is cached is never inserted.
+	commitLock sync.RWMutex
+
+	// partitions are sorted by descending prefix length
+	partitions []*partition
+	metrics    *metrics
+}
+
+// New wraps [db] with a cache configured by [cfg].
+func New(db merkledb.MerkleDB, cfg Config) (*DB, *prometheus.Registry, error) {
+	registry, metrics, err := newMetrics()
+	if err != nil {
+		return nil, nil, err
+	}
+	namespaces, err := cfg.namespaces()
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &DB{
+		MerkleDB:   db,
+		partitions: make([]*partition, 0, len(namespaces)+1),
+		metrics:    metrics,
+	}
+	for prefix, size := range namespaces {
+		c.partitions = append(c.partitions, c.newPartition(prefix, hex.EncodeToString([]byte(prefix)), size))
+	}
+	sort.Slice(c.partitions, func(i, j int) bool {
+		return len(c.partitions[i].prefix) > len(c.partitions[j].prefix)
+	})
+	c.partitions = append(c.partitions, c.newPartition("", defaultNamespace, cfg.Size))
+	return c, registry, nil
+}
+
+func (c *DB) newPartition(prefix string, name string, size int) *partition {
+	return &partition{
+		prefix: prefix,
+		name:   name,
+		items: cache.NewSizedLRU[string, *entry](size, func(k string, e *entry) int {
+			return len(k) + len(e.v) + entryOverhead
+		}),
+		hits:      c.metrics.hits.WithLabelValues(name),
+		misses:    c.metrics.misses.WithLabelValues(name),
+		evictions: c.metrics.evictions.WithLabelValues(name),
+		size:      c.metrics.size.WithLabelValues(name),
+	}
+}
+
+func (c *DB) partition(key string) *partition {
+	for _, p := range c.partitions {
+		if strings.HasPrefix(key, p.prefix) {
+			return p
+		}
+	}
+	// Unreachable because the default partition matches all keys
+	return c.partitions[len(c.partitions)-1]
+}
+
+// put inserts a value into the cache.
+//
+// Assumes [commitLock] is held.
+func (c *DB) put(key string, v []byte, exists bool) {
+	p := c.partition(key)
+	evicted := p.items.Put(key, &entry{slices.Clone(v), exists})
+	p.evictions.Add(float64(evicted))
+	p.size.Set(float64(p.items.Size()))
+}
+
+// evict removes a key from the cache.
+//
+// Assumes [commitLock] is held.
+func (c *DB) evict(key string) {
+	p := c.partition(key)
+	p.items.Evict(key)
+	p.size.Set(float64(p.items.Size()))
+}
+
+// flush clears all partitions.
+//
+// Assumes [commitLock] is held.
+func (c *DB) flush() {
+	for _, p := range c.partitions {
+		p.items.Flush()
+		p.size.Set(0)
+	}
+	c.metrics.flushes.Inc()
+}
+
+// apply writes all [changes] committed to the underlying database
+// to the cache.
+//
+// Assumes [commitLock] is held.
+func (c *DB) apply(changes merkledb.ViewChanges) {
+	for _, op := range changes.BatchOps {
+		c.put(string(op.Key), op.Value, !op.Delete)
+	}
+	for k, v := range changes.MapOps {
+		c.put(k, v.Value(), v.HasValue())
+	}
+}
+
+func (c *DB) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	c.commitLock.RLock()
+	defer c.commitLock.RUnlock()
+
+	k := string(key)
+	p := c.partition(k)
+	if e, ok := p.items.Get(k); ok {
+		p.hits.Inc()
+		if !e.exists {
+			return nil, database.ErrNotFound
+		}
+		return slices.Clone(e.v), nil
+	}
+	p.misses.Inc()
+	v, err := c.MerkleDB.GetValue(ctx, key)
+	switch {
+	case err == nil:
+		c.put(k, v, true)
+	case errors.Is(err, database.ErrNotFound):
+		c.put(k, nil, false)
+	}
+	return v, err
+}
+
+func (c *DB) GetValues(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+	var (
+		values = make([][]byte, len(keys))
+		errs   = make([]error, len(keys))
+	)
+	for i, key := range keys {
+		values[i], errs[i] = c.GetValue(ctx, key)
+	}
+	return values, errs
+}
+
+func (c *DB) Get(key []byte) ([]byte, error) {
+	return c.GetValue(context.TODO(), key)
+}
+
+func (c *DB) Put(key []byte, value []byte) error {
+	c.commitLock.Lock()
+	defer c.commitLock.Unlock()
+
+	c.evict(string(key))
+	return c.MerkleDB.Put(key, value)
+}
+
+func (c *DB) Delete(key []byte) error {
+	c.commitLock.Lock()
+	defer c.commitLock.Unlock()
+
+	c.evict(string(key))
+	return c.MerkleDB.Delete(key)
+}
+
+func (c *DB) NewBatch() database.Batch {
+	return &batch{c.MerkleDB.NewBatch(), c}
+}
+
+func (c *DB) Clear() error {
+	c.commitLock.Lock()
+	defer c.commitLock.Unlock()
+
+	c.flush()
+	return c.MerkleDB.Clear()
+}
+
+func (c *DB) CommitRangeProof(ctx context.Context, start, end maybe.Maybe[[]byte], proof *merkledb.RangeProof) error {
+	c.commitLock.Lock()
+	defer c.commitLock.Unlock()
+
+	c.flush()
+	return c.MerkleDB.CommitRangeProof(ctx, start, end, proof)
+}
+
+func (c *DB) CommitChangeProof(ctx context.Context, proof *merkledb.ChangeProof) error {
+	c.commitLock.Lock()
+	defer c.commitLock.Unlock()
+
+	c.flush()
+	return c.MerkleDB.CommitChangeProof(ctx, proof)
+}
+
+func (c *DB) NewView(ctx context.Context, changes merkledb.ViewChanges) (merkledb.View, error) {
+	v, err := c.MerkleDB.NewView(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+	return &view{v, c, changes}, nil
+}
+
+type view struct {
+	merkledb.View
+
+	db      *DB
+	changes merkledb.ViewChanges
+}
+
+func (v *view) NewView(ctx context.Context, changes merkledb.ViewChanges) (merkledb.View, error) {
+	child, err := v.View.NewView(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+	return &view{child, v.db, changes}, nil
+}
+
+func (v *view) CommitToDB(ctx context.Context) error {
+	v.db.commitLock.Lock()
+	defer v.db.commitLock.Unlock()
+
+	if err := v.View.CommitToDB(ctx); err != nil {
+		// We don't know what was written, so we assume nothing
+		// in the cache is valid.
+		v.db.flush()
+		return err
+	}
+	v.db.apply(v.changes)
+	return nil
+}
+
+type batch struct {
+	database.Batch
+
+	db *DB
+}
+
+func (b *batch) Write() error {
+	b.db.commitLock.Lock()
+	defer b.db.commitLock.Unlock()
+
+	b.db.flush()
+	return b.Batch.Write()
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statecache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T, cfg Config) *DB {
+	require := require.New(t)
+	db, err := merkledb.New(context.TODO(), memdb.New(), merkledb.Config{
+		BranchFactor:              merkledb.BranchFactor16,
+		RootGenConcurrency:        1,
+		HistoryLength:             1,
+		ValueNodeCacheSize:        1024,
+		IntermediateNodeCacheSize: 1024,
+		Reg:                       prometheus.NewRegistry(),
+		Tracer:                    trace.Noop,
+	})
+	require.NoError(err)
+	c, _, err := New(db, cfg)
+	require.NoError(err)
+	return c
+}
+
+func TestCacheWriteThrough(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctx     = context.TODO()
+		c       = newTestDB(t, Config{Size: 1024})
+	)
+
+	// Cache missing key
+	_, err := c.GetValue(ctx, []byte("a"))
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Equal(1, c.partition("a").items.Len())
+
+	// Commit key through nested views
+	v1, err := c.NewView(ctx, merkledb.ViewChanges{
+		MapOps: map[string]maybe.Maybe[[]byte]{"a": maybe.Some([]byte("1"))},
+	})
+	require.NoError(err)
+	v2, err := v1.NewView(ctx, merkledb.ViewChanges{
+		MapOps: map[string]maybe.Maybe[[]byte]{"a": maybe.Some([]byte("2"))},
+	})
+	require.NoError(err)
+	require.NoError(v1.CommitToDB(ctx))
+	v, err := c.GetValue(ctx, []byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("1"), v)
+	require.NoError(v2.CommitToDB(ctx))
+	v, err = c.GetValue(ctx, []byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("2"), v)
+
+	// Delete key
+	v3, err := c.NewView(ctx, merkledb.ViewChanges{
+		BatchOps: []database.BatchOp{{Key: []byte("a"), Delete: true}},
+	})
+	require.NoError(err)
+	require.NoError(v3.CommitToDB(ctx))
+	_, err = c.GetValue(ctx, []byte("a"))
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestCacheNamespaces(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctx     = context.TODO()
+		c       = newTestDB(t, Config{
+			Size: 0,
+			Namespaces: map[string]int{
+				"00":   entryOverhead + 2,
+				"0001": 2 * (entryOverhead + 3),
+			},
+		})
+	)
+	require.Equal("0001", c.partition("\x00\x01\x02").name)
+	require.Equal("00", c.partition("\x00\x02").name)
+	require.Equal(defaultNamespace, c.partition("\x01").name)
+
+	// Default partition has no capacity
+	_, err := c.GetValue(ctx, []byte("\x01"))
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Zero(c.partition("\x01").items.Len())
+
+	// Partitions evict independently
+	for _, k := range []string{"\x00\x01\x02", "\x00\x01\x03", "\x00\x02"} {
+		_, err := c.GetValue(ctx, []byte(k))
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+	require.Equal(2, c.partition("\x00\x01").items.Len())
+	require.Equal(1, c.partition("\x00\x02").items.Len())
+	_, err = c.GetValue(ctx, []byte("\x00\x03"))
+	require.ErrorIs(err, database.ErrNotFound)
+	_, ok := c.partition("\x00").items.Get("\x00\x02")
+	require.False(ok)
+	require.Equal(2, c.partition("\x00\x01").items.Len())
+}
+
+func TestCacheInvalidNamespace(t *testing.T) {
+	require := require.New(t)
+	db, err := merkledb.New(context.TODO(), memdb.New(), merkledb.Config{
+		BranchFactor: merkledb.BranchFactor16,
+		Reg:          prometheus.NewRegistry(),
+		Tracer:       trace.Noop,
+	})
+	require.NoError(err)
+	_, _, err = New(db, Config{Namespaces: map[string]int{"": 10}})
+	require.ErrorIs(err, ErrInvalidNamespace)
+}
@@ -10,4 +10,5 @@ var (
 	ErrInvalidKeyOrPermission = errors.New("invalid key or key permission")
 	ErrInvalidKeyValue        = errors.New("invalid key or value")
 	ErrAllocationDisabled     = errors.New("allocation disabled")
+	ErrReservedKey            = errors.New("reserved key")
 )
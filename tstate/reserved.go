@@ -0,0 +1,61 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tstate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var _ state.Mutable = (*ReservedView)(nil)
+
+// ReservedView wraps a [state.Mutable] to reject any read or write of a key
+// starting with one of [prefixes] (like the keys of the fee state or of
+// delivered warp messages), even if the key is in scope.
+//
+// This prevents a buggy action from corrupting state that is only managed by
+// the VM.
+type ReservedView struct {
+	mu       state.Mutable
+	prefixes [][]byte
+}
+
+func NewReservedView(mu state.Mutable, prefixes [][]byte) *ReservedView {
+	return &ReservedView{mu: mu, prefixes: prefixes}
+}
+
+// CheckReserved returns [ErrReservedKey] if [key] starts with any of
+// [prefixes].
+func CheckReserved(key []byte, prefixes [][]byte) error {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return fmt.Errorf("%w: %x (prefix %x)", ErrReservedKey, key, prefix)
+		}
+	}
+	return nil
+}
+
+func (r *ReservedView) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	if err := CheckReserved(key, r.prefixes); err != nil {
+		return nil, err
+	}
+	return r.mu.GetValue(ctx, key)
+}
+
+func (r *ReservedView) Insert(ctx context.Context, key []byte, value []byte) error {
+	if err := CheckReserved(key, r.prefixes); err != nil {
+		return err
+	}
+	return r.mu.Insert(ctx, key, value)
+}
+
+func (r *ReservedView) Remove(ctx context.Context, key []byte) error {
+	if err := CheckReserved(key, r.prefixes); err != nil {
+		return err
+	}
+	return r.mu.Remove(ctx, key)
+}
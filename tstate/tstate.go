@@ -5,6 +5,7 @@ package tstate
 
 import (
 	"context"
+	"maps"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/trace"
@@ -52,6 +53,15 @@ func (ts *TState) PendingChanges() int {
 	return len(ts.changedKeys)
 }
 
+// ChangedKeys returns the new value of each key changed in ts (or nothing
+// if the key was deleted). The values must not be modified.
+func (ts *TState) ChangedKeys() map[string]maybe.Maybe[[]byte] {
+	ts.l.RLock()
+	defer ts.l.RUnlock()
+
+	return maps.Clone(ts.changedKeys)
+}
+
 // OpIndex returns the number of operations done on ts.
 func (ts *TState) OpIndex() int {
 	ts.l.RLock()
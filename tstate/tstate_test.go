@@ -74,6 +74,28 @@ func TestScope(t *testing.T) {
 	require.ErrorIs(ErrInvalidKeyOrPermission, tsv.Remove(ctx, testKey))
 }
 
+func TestReservedView(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+
+	// Reserved keys are rejected even if they are in scope
+	tsv := ts.NewView(state.Keys{key1str: state.All, key2str: state.All}, map[string][]byte{key1str: testVal})
+	rv := NewReservedView(tsv, [][]byte{[]byte("key2")})
+	val, err := rv.GetValue(ctx, key1)
+	require.NoError(err)
+	require.Equal(testVal, val)
+	require.NoError(rv.Insert(ctx, key1, []byte("value2")))
+	_, err = rv.GetValue(ctx, key2)
+	require.ErrorIs(err, ErrReservedKey)
+	require.ErrorIs(rv.Insert(ctx, key2, testVal), ErrReservedKey)
+	require.ErrorIs(rv.Remove(ctx, key2), ErrReservedKey)
+	require.Equal(1, tsv.PendingChanges())
+
+	// The underlying view is unrestricted
+	require.NoError(tsv.Insert(ctx, key2, testVal))
+}
+
 func TestGetValue(t *testing.T) {
 	require := require.New(t)
 	ctx := context.TODO()
@@ -103,6 +125,64 @@ func TestDeleteCommitGet(t *testing.T) {
 	require.Nil(val)
 }
 
+func TestSnapshotView(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+
+	// Delete value
+	tsv := ts.NewView(state.Keys{string(testKey): state.Read | state.Write}, map[string][]byte{string(testKey): testVal})
+	require.NoError(tsv.Remove(ctx, testKey))
+	tsv.Commit()
+
+	// Snapshot ignores committed changes
+	tsv = ts.NewSnapshotView(state.Keys{string(testKey): state.Read | state.Write}, map[string][]byte{string(testKey): testVal})
+	val, err := tsv.GetValue(ctx, testKey)
+	require.NoError(err)
+	require.Equal(testVal, val)
+
+	// Snapshot commits to parent
+	newVal := []byte("newVal")
+	require.NoError(tsv.Insert(ctx, testKey, newVal))
+	tsv.Commit()
+	tsv = ts.NewView(state.Keys{string(testKey): state.Read | state.Write}, map[string][]byte{string(testKey): testVal})
+	val, err = tsv.GetValue(ctx, testKey)
+	require.NoError(err)
+	require.Equal(newVal, val)
+}
+
+func TestTrace(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+	ts := New(10)
+
+	type access struct {
+		t     AccessType
+		key   string
+		value []byte
+		err   error
+	}
+	accesses := []access{}
+	tsv := ts.NewView(state.Keys{key1str: state.Read, key2str: state.All}, map[string][]byte{key1str: testVal})
+	tsv.Trace(func(t AccessType, key []byte, value []byte, err error) {
+		accesses = append(accesses, access{t, string(key), value, err})
+	})
+	_, err := tsv.GetValue(ctx, key1)
+	require.NoError(err)
+	_, err = tsv.GetValue(ctx, key2)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.ErrorIs(tsv.Insert(ctx, key1, testVal), ErrInvalidKeyOrPermission)
+	require.NoError(tsv.Insert(ctx, key2, testVal))
+	require.NoError(tsv.Remove(ctx, key2))
+	require.Equal([]access{
+		{ReadAccess, key1str, testVal, nil},
+		{ReadAccess, key2str, nil, database.ErrNotFound},
+		{WriteAccess, key1str, testVal, ErrInvalidKeyOrPermission},
+		{WriteAccess, key2str, testVal, nil},
+		{RemoveAccess, key2str, nil, nil},
+	}, accesses)
+}
+
 func TestGetValueNoStorage(t *testing.T) {
 	require := require.New(t)
 	ctx := context.TODO()
@@ -32,6 +32,15 @@ type op struct {
 	pastWrites    *uint16
 }
 
+// AccessType is the kind of a state access traced by [TStateView.Trace].
+type AccessType string
+
+const (
+	ReadAccess   AccessType = "read"
+	WriteAccess  AccessType = "write"
+	RemoveAccess AccessType = "remove"
+)
+
 type TStateView struct {
 	ts                 *TState
 	pendingChangedKeys map[string]maybe.Maybe[[]byte]
@@ -50,6 +59,12 @@ type TStateView struct {
 	// Store which keys are modified and how large their values were.
 	allocates map[string]uint16
 	writes    map[string]uint16
+
+	// snapshot views ignore changes committed to [ts]
+	snapshot bool
+
+	// trace is called with each access of state (if set)
+	trace func(t AccessType, key []byte, value []byte, err error)
 }
 
 func (ts *TState) NewView(scope state.Keys, storage map[string][]byte) *TStateView {
@@ -67,6 +82,18 @@ func (ts *TState) NewView(scope state.Keys, storage map[string][]byte) *TStateVi
 	}
 }
 
+// NewSnapshotView returns a view that only reads from [storage] (ignoring any
+// changes already committed to [ts]) but still commits its changes to [ts].
+//
+// This is used to speculatively execute transactions concurrently. It is up to the
+// caller to ensure that no other view that modified a key in [scope] was committed
+// to [ts] before calling [Commit].
+func (ts *TState) NewSnapshotView(scope state.Keys, storage map[string][]byte) *TStateView {
+	tsv := ts.NewView(scope, storage)
+	tsv.snapshot = true
+	return tsv
+}
+
 // Rollback restores the TState to the ts.op[restorePoint] operation.
 func (ts *TStateView) Rollback(_ context.Context, restorePoint int) {
 	for i := len(ts.ops) - 1; i >= restorePoint; i-- {
@@ -126,6 +153,15 @@ func (ts *TStateView) Rollback(_ context.Context, restorePoint int) {
 	ts.ops = ts.ops[:restorePoint]
 }
 
+// Trace calls [f] with every read, write, and removal of a key made with ts
+// (including those that fail), in order. Reads of keys that don't exist
+// return [database.ErrNotFound].
+//
+// This is used to debug the execution of transactions.
+func (ts *TStateView) Trace(f func(t AccessType, key []byte, value []byte, err error)) {
+	ts.trace = f
+}
+
 // OpIndex returns the number of operations done on ts.
 func (ts *TStateView) OpIndex() int {
 	return len(ts.ops)
@@ -151,7 +187,10 @@ func (ts *TStateView) checkScope(_ context.Context, k []byte, perm state.Permiss
 // GetValue returns the value associated from tempStorage with the
 // associated [key]. If [key] does not exist in scope, or is not read/rw, or if it is not found
 // in storage an error is returned.
-func (ts *TStateView) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+func (ts *TStateView) GetValue(ctx context.Context, key []byte) (v []byte, err error) {
+	if ts.trace != nil {
+		defer func() { ts.trace(ReadAccess, key, v, err) }()
+	}
 	// Getting a value requires a Read permission, so we pass state.Read
 	if !ts.checkScope(ctx, key, state.Read) {
 		return nil, ErrInvalidKeyOrPermission
@@ -171,7 +210,7 @@ func (ts *TStateView) getValue(ctx context.Context, key string) ([]byte, bool) {
 		}
 		return v.Value(), true
 	}
-	if v, changed, exists := ts.ts.getChangedValue(ctx, key); changed {
+	if v, changed, exists := ts.getParentChangedValue(ctx, key); changed {
 		return v, exists
 	}
 	if v, ok := ts.scopeStorage[key]; ok {
@@ -183,7 +222,7 @@ func (ts *TStateView) getValue(ctx context.Context, key string) ([]byte, bool) {
 // isUnchanged determines if a [key] is unchanged from the parent view (or
 // scope if the parent is unchanged).
 func (ts *TStateView) isUnchanged(ctx context.Context, key string, nval []byte, nexists bool) bool {
-	if v, changed, exists := ts.ts.getChangedValue(ctx, key); changed {
+	if v, changed, exists := ts.getParentChangedValue(ctx, key); changed {
 		return !exists && !nexists || exists && nexists && bytes.Equal(v, nval)
 	}
 	if v, ok := ts.scopeStorage[key]; ok {
@@ -192,9 +231,21 @@ func (ts *TStateView) isUnchanged(ctx context.Context, key string, nval []byte,
 	return !nexists
 }
 
+// getParentChangedValue returns the value of [key] in the parent [TState]
+// (unless this is a snapshot view).
+func (ts *TStateView) getParentChangedValue(ctx context.Context, key string) ([]byte, bool, bool) {
+	if ts.snapshot {
+		return nil, false, false
+	}
+	return ts.ts.getChangedValue(ctx, key)
+}
+
 // Insert allocates and writes (or just writes) a new key to [tstate]. If this
 // action returns the value of [key] to the parent view, it reverts any pending changes.
-func (ts *TStateView) Insert(ctx context.Context, key []byte, value []byte) error {
+func (ts *TStateView) Insert(ctx context.Context, key []byte, value []byte) (err error) {
+	if ts.trace != nil {
+		defer func() { ts.trace(WriteAccess, key, value, err) }()
+	}
 	// Inserting requires a Write Permissions, so we pass state.Write
 	if !ts.checkScope(ctx, key, state.Write) {
 		return ErrInvalidKeyOrPermission
@@ -245,7 +296,10 @@ func (ts *TStateView) Insert(ctx context.Context, key []byte, value []byte) erro
 
 // Remove deletes a key from [tstate]. If this action returns the
 // value of [key] to the parent view, it reverts any pending changes.
-func (ts *TStateView) Remove(ctx context.Context, key []byte) error {
+func (ts *TStateView) Remove(ctx context.Context, key []byte) (err error) {
+	if ts.trace != nil {
+		defer func() { ts.trace(RemoveAccess, key, nil, err) }()
+	}
 	// Removing requires writing & deleting that key, so we pass state.Write
 	if !ts.checkScope(ctx, key, state.Write) {
 		return ErrInvalidKeyOrPermission
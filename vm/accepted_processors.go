@@ -0,0 +1,272 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+// Priorities of the built-in [AcceptedProcessor]s. Processors run in
+// increasing order of priority, and processors with the same priority run in
+// order of registration (after the built-in processors), so a [Controller]
+// can run its processors before, between, or after the built-in ones.
+const (
+	// The [Controller] and warp signatures
+	PriorityController = 0
+	// The indexer and the components that read it (postgres, block export,
+	// and the event sink) and state diffs
+	PriorityIndexer = 100
+	// Webhooks, WebSocket subscribers, and transaction statuses
+	PriorityNotify = 200
+	// Price metrics, the fee history, and the unit profiler
+	PriorityMetrics = 300
+)
+
+// AcceptedProcessor processes each block after it is accepted (once it has
+// been executed). Processors run one block at a time, in order of height, on
+// the same goroutine.
+type AcceptedProcessor struct {
+	Name     string
+	Priority int
+
+	// Critical processors stop the node if they fail (because what they
+	// didn't process can't be recovered). Failures of other processors (and
+	// their panics) are logged and counted, and don't prevent later
+	// processors from running.
+	Critical bool
+
+	Process func(ctx context.Context, blk *chain.StatelessBlock) error
+}
+
+// RegisterAcceptedProcessor adds [p] to the processors run for each accepted
+// block. Processors must be registered before [VM.Initialize] returns (usually
+// by [Controller.Initialize]).
+func (vm *VM) RegisterAcceptedProcessor(p *AcceptedProcessor) error {
+	if vm.acceptedSealed {
+		return fmt.Errorf("%w: %s", ErrProcessorsSealed, p.Name)
+	}
+	for _, registered := range vm.acceptedProcessors {
+		if registered.Name == p.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateProcessor, p.Name)
+		}
+	}
+	vm.acceptedProcessors = append(vm.acceptedProcessors, p)
+	return nil
+}
+
+// sealAcceptedProcessors adds the built-in processors of the components that
+// are enabled to the registered processors and sorts them. No processors can
+// be registered after it is called.
+func (vm *VM) sealAcceptedProcessors() error {
+	builtins := vm.builtinProcessors()
+	for _, p := range vm.acceptedProcessors {
+		for _, builtin := range builtins {
+			if builtin.Name == p.Name {
+				return fmt.Errorf("%w: %s", ErrDuplicateProcessor, p.Name)
+			}
+		}
+	}
+	vm.acceptedProcessors = sortProcessors(builtins, vm.acceptedProcessors)
+	vm.acceptedSealed = true
+	return nil
+}
+
+func sortProcessors(builtins []*AcceptedProcessor, registered []*AcceptedProcessor) []*AcceptedProcessor {
+	processors := make([]*AcceptedProcessor, 0, len(builtins)+len(registered))
+	processors = append(processors, builtins...)
+	processors = append(processors, registered...)
+	sort.SliceStable(processors, func(i, j int) bool {
+		return processors[i].Priority < processors[j].Priority
+	})
+	return processors
+}
+
+func (vm *VM) builtinProcessors() []*AcceptedProcessor {
+	processors := []*AcceptedProcessor{
+		{
+			Name:     "controller",
+			Priority: PriorityController,
+			Critical: true,
+			Process:  vm.c.Accepted,
+		},
+		{
+			// Sign outgoing warp messages
+			Name:     "warp",
+			Priority: PriorityController,
+			Critical: true,
+			Process:  vm.warpCollector.Accepted,
+		},
+	}
+	if vm.indexer != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "indexer",
+			Priority: PriorityIndexer,
+			Critical: true,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				if err := vm.indexer.Accept(b); err != nil {
+					return err
+				}
+				vm.cacheTxs(b)
+				return nil
+			},
+		})
+	}
+	if vm.postgresWriter != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "postgres",
+			Priority: PriorityIndexer,
+			Process: func(context.Context, *chain.StatelessBlock) error {
+				vm.postgresWriter.Accepted()
+				return nil
+			},
+		})
+	}
+	if vm.blockExporter != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "export",
+			Priority: PriorityIndexer,
+			Process: func(context.Context, *chain.StatelessBlock) error {
+				vm.blockExporter.Accepted()
+				return nil
+			},
+		})
+	}
+	if vm.stateDiffs != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "state_diffs",
+			Priority: PriorityIndexer,
+			Critical: true,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				return vm.stateDiffs.Accepted(b)
+			},
+		})
+	}
+	if vm.eventStreamer != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "events",
+			Priority: PriorityIndexer,
+			Critical: true,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				return vm.eventStreamer.Accepted(b)
+			},
+		})
+	}
+	if vm.webhooks != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "webhooks",
+			Priority: PriorityNotify,
+			Critical: true,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				return vm.webhooks.Accepted(b)
+			},
+		})
+	}
+	processors = append(processors,
+		&AcceptedProcessor{
+			// TODO: consider removing this (unused and requires an extra iteration)
+			Name:     "auth_cache",
+			Priority: PriorityNotify,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				for _, tx := range b.Txs {
+					// Only cache auth for accepted blocks to prevent cache manipulation from RPC submissions
+					vm.cacheAuth(tx.Auth)
+				}
+				return nil
+			},
+		},
+		&AcceptedProcessor{
+			Name:     "websocket",
+			Priority: PriorityNotify,
+			Critical: true,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				if err := vm.webSocketServer.AcceptBlock(b); err != nil {
+					return err
+				}
+				// Must clear accepted txs before [SetMinTx] or else we will errnoueously
+				// send [ErrExpired] messages.
+				return vm.webSocketServer.SetMinTx(b.Tmstmp)
+			},
+		},
+	)
+	if vm.txStatuses != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "tx_statuses",
+			Priority: PriorityNotify,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				vm.txStatuses.Accepted(b)
+				return nil
+			},
+		})
+	}
+	processors = append(processors, &AcceptedProcessor{
+		Name:     "prices",
+		Priority: PriorityMetrics,
+		Process: func(_ context.Context, b *chain.StatelessBlock) error {
+			feeManager := b.FeeManager()
+			vm.metrics.bandwidthPrice.Set(float64(feeManager.UnitPrice(fees.Bandwidth)))
+			vm.metrics.computePrice.Set(float64(feeManager.UnitPrice(fees.Compute)))
+			vm.metrics.storageReadPrice.Set(float64(feeManager.UnitPrice(fees.StorageRead)))
+			vm.metrics.storageAllocatePrice.Set(float64(feeManager.UnitPrice(fees.StorageAllocate)))
+			vm.metrics.storageWritePrice.Set(float64(feeManager.UnitPrice(fees.StorageWrite)))
+			return nil
+		},
+	})
+	if vm.feeHistory != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "fee_history",
+			Priority: PriorityMetrics,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				vm.feeHistory.Accepted(b, vm.Rules(b.Tmstmp).GetMaxBlockUnits())
+				return nil
+			},
+		})
+	}
+	if vm.unitProfiler != nil {
+		processors = append(processors, &AcceptedProcessor{
+			Name:     "unit_profiler",
+			Priority: PriorityMetrics,
+			Process: func(_ context.Context, b *chain.StatelessBlock) error {
+				return vm.unitProfiler.Accepted(b, vm.StateManager(), vm.Rules(b.Tmstmp))
+			},
+		})
+	}
+	return processors
+}
+
+// runAcceptedProcessors runs each [AcceptedProcessor] on [b].
+func (vm *VM) runAcceptedProcessors(ctx context.Context, b *chain.StatelessBlock) {
+	for _, p := range vm.acceptedProcessors {
+		err := runProcessor(ctx, p, b)
+		if err == nil {
+			continue
+		}
+		if p.Critical {
+			vm.Fatal("accepted processing failed", zap.String("processor", p.Name), zap.Error(err))
+		}
+		vm.metrics.processorFailures.WithLabelValues(p.Name).Inc()
+		vm.snowCtx.Log.Warn("accepted processing failed",
+			zap.String("processor", p.Name),
+			zap.Uint64("height", b.Hght),
+			zap.Error(err),
+		)
+	}
+}
+
+func runProcessor(ctx context.Context, p *AcceptedProcessor, b *chain.StatelessBlock) (err error) {
+	if !p.Critical {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%w: %v", ErrProcessorPanicked, r)
+			}
+		}()
+	}
+	return p.Process(ctx, b)
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func TestAcceptedProcessors(t *testing.T) {
+	require := require.New(t)
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		metrics: m,
+	}
+	order := []string{}
+	processor := func(name string, priority int, err error) *AcceptedProcessor {
+		return &AcceptedProcessor{
+			Name:     name,
+			Priority: priority,
+			Process: func(context.Context, *chain.StatelessBlock) error {
+				order = append(order, name)
+				return err
+			},
+		}
+	}
+
+	require.NoError(vm.RegisterAcceptedProcessor(processor("after", PriorityMetrics+1, nil)))
+	require.NoError(vm.RegisterAcceptedProcessor(processor("failing", PriorityNotify, errors.New("failed"))))
+	panicking := processor("panicking", PriorityIndexer, nil)
+	panicking.Process = func(context.Context, *chain.StatelessBlock) error {
+		order = append(order, "panicking")
+		panic("oops")
+	}
+	require.NoError(vm.RegisterAcceptedProcessor(panicking))
+	require.ErrorIs(vm.RegisterAcceptedProcessor(processor("after", 0, nil)), ErrDuplicateProcessor)
+
+	// Registered processors run after built-in processors of the same priority
+	builtins := []*AcceptedProcessor{
+		processor("controller", PriorityController, nil),
+		processor("indexer", PriorityIndexer, nil),
+		processor("websocket", PriorityNotify, nil),
+	}
+	vm.acceptedProcessors = sortProcessors(builtins, vm.acceptedProcessors)
+	vm.acceptedSealed = true
+	require.ErrorIs(vm.RegisterAcceptedProcessor(processor("late", 0, nil)), ErrProcessorsSealed)
+
+	// Failures of non-critical processors don't stop later processors
+	vm.runAcceptedProcessors(context.Background(), &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Hght: 1}})
+	require.Equal([]string{"controller", "indexer", "panicking", "websocket", "failing", "after"}, order)
+	require.Equal(float64(1), testutil.ToFloat64(m.processorFailures.WithLabelValues("failing")))
+	require.Equal(float64(1), testutil.ToFloat64(m.processorFailures.WithLabelValues("panicking")))
+	require.Equal(float64(0), testutil.ToFloat64(m.processorFailures.WithLabelValues("after")))
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+const (
+	actionSucceeded = "success"
+	actionFailed    = "failure"
+	actionReverted  = "reverted"
+)
+
+var dimensionNames = [fees.FeeDimensions]string{
+	fees.Bandwidth:       "bandwidth",
+	fees.Compute:         "compute",
+	fees.StorageRead:     "storage_read",
+	fees.StorageAllocate: "storage_allocate",
+	fees.StorageWrite:    "storage_write",
+}
+
+// actionMetrics records the execution of each type of action in accepted
+// blocks (so controllers don't need to count their actions themselves).
+//
+// Actions are labeled by the name of their type (in snake case, so
+// [*actions.TransferMultiple] is "transfer_multiple").
+type actionMetrics struct {
+	executed *prometheus.CounterVec
+	units    *prometheus.HistogramVec
+
+	// names caches the label of each action type ID. It is only accessed
+	// by [record], which is called once for each accepted block (in order).
+	names [256]string
+}
+
+func newActionMetrics() *actionMetrics {
+	return &actionMetrics{
+		executed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "executed",
+			Help:      "number of accepted actions by type and result (reverted actions succeeded before a later action in their tx failed)",
+		}, []string{"action", "result"}),
+		units: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "actions",
+			Name:      "units",
+			Help:      "units consumed by accepted actions by type and dimension (the units of a tx are split evenly between its actions)",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 12),
+		}, []string{"action", "dimension"}),
+	}
+}
+
+func (m *actionMetrics) register(r *prometheus.Registry) []error {
+	return []error{r.Register(m.executed), r.Register(m.units)}
+}
+
+// record observes the actions of [blk].
+func (m *actionMetrics) record(blk *chain.StatelessBlock) {
+	results := blk.Results()
+	for i, tx := range blk.Txs {
+		result := results[i]
+
+		// Actions are executed in order until one fails (and all outputs
+		// are discarded if any action fails)
+		failed := len(tx.Actions)
+		if !result.Success {
+			failed = len(result.Outputs)
+		}
+		for j, action := range tx.Actions {
+			name := m.name(action)
+			switch {
+			case result.Success:
+				m.executed.WithLabelValues(name, actionSucceeded).Inc()
+			case j < failed:
+				m.executed.WithLabelValues(name, actionReverted).Inc()
+			case j == failed:
+				m.executed.WithLabelValues(name, actionFailed).Inc()
+			}
+			for d, units := range result.Units {
+				m.units.WithLabelValues(name, dimensionNames[d]).Observe(float64(units) / float64(len(tx.Actions)))
+			}
+		}
+	}
+}
+
+func (m *actionMetrics) name(action chain.Action) string {
+	id := action.GetTypeID()
+	if name := m.names[id]; len(name) > 0 {
+		return name
+	}
+	name := actionName(action)
+	m.names[id] = name
+	return name
+}
+
+// actionName returns the name of the type of [action] in snake case (or its
+// type ID if the type is unnamed).
+func actionName(action chain.Action) string {
+	t := reflect.TypeOf(action)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if name := snakeCase(t.Name()); len(name) > 0 {
+		return name
+	}
+	return strconv.Itoa(int(action.GetTypeID()))
+}
+
+// snakeCase converts a Go identifier (like "TransferNFT") to snake case
+// ("transfer_nft").
+func snakeCase(s string) string {
+	var sb strings.Builder
+	rs := []rune(s)
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(rs[i-1]) || (i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
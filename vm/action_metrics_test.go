@@ -0,0 +1,20 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeCase(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("transfer", snakeCase("Transfer"))
+	require.Equal("transfer_multiple", snakeCase("TransferMultiple"))
+	require.Equal("transfer_nft", snakeCase("TransferNFT"))
+	require.Equal("nft_transfer", snakeCase("NFTTransfer"))
+	require.Equal("close_all_orders", snakeCase("CloseAllOrders"))
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/hypersdk/admission"
+	"github.com/ava-labs/hypersdk/audit"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// AdmissionConfig filters the transactions the node adds to its mempool and
+// includes in the blocks it builds (blocks built by other validators are not
+// affected).
+type AdmissionConfig struct {
+	// PolicyFile is the path of the JSON policy file (see [admission.File]).
+	// Admission is only filtered by the [AdmissionController] if empty.
+	PolicyFile string `json:"policyFile"`
+	// ReloadInterval is how often the policy file is checked for changes (or
+	// never if 0)
+	ReloadInterval time.Duration `json:"reloadInterval"`
+}
+
+// AdmissionController is an optional extension of [Controller] that rejects
+// transactions (in addition to the policy file of the node) before they are
+// added to the mempool or included in a built block.
+type AdmissionController interface {
+	// AdmitTx returns an error if [tx] should not be admitted.
+	AdmitTx(ctx context.Context, tx *chain.Transaction) error
+}
+
+func (vm *VM) newAdmissionWatcher() (*admission.Watcher, error) {
+	path := vm.config.AdmissionConfig.PolicyFile
+	return admission.NewWatcher(vm.snowCtx.Log, path, vm.config.AdmissionConfig.ReloadInterval, func(digest [sha256.Size]byte, err error) {
+		vm.audit.Record(audit.ConfigCategory, "loadAdmissionPolicy", "", err, map[string]any{
+			"path":   path,
+			"sha256": hex.EncodeToString(digest[:]),
+		})
+	})
+}
+
+// AdmitTx returns an error if [tx] is rejected by the admission policy of the
+// node or by the [AdmissionController].
+func (vm *VM) AdmitTx(ctx context.Context, tx *chain.Transaction) error {
+	if err := vm.admitTx(ctx, tx); err != nil {
+		vm.metrics.txsNotAdmitted.Inc()
+		return fmt.Errorf("%w: %w", ErrTxNotAdmitted, err)
+	}
+	return nil
+}
+
+func (vm *VM) admitTx(ctx context.Context, tx *chain.Transaction) error {
+	if vm.admission != nil {
+		var recipients func(*chain.Transaction) []codec.Address
+		if ic, ok := vm.c.(IndexController); ok {
+			recipients = ic.TxAddresses
+		}
+		if err := vm.admission.Policy().Check(tx, recipients); err != nil {
+			return err
+		}
+	}
+	if ac, ok := vm.c.(AdmissionController); ok {
+		return ac.AdmitTx(ctx, tx)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/admission"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+)
+
+var errHookRejected = errors.New("rejected by hook")
+
+// admissionController rejects the transactions with [rejected] actions.
+type admissionController struct {
+	Controller
+
+	rejected uint64
+}
+
+func (c *admissionController) AdmitTx(_ context.Context, tx *chain.Transaction) error {
+	if tx.Actions[0].(*testAction).value == c.rejected {
+		return errHookRejected
+	}
+	return nil
+}
+
+func TestAdmitTx(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+	newTx := func(sponsor codec.Address, value uint64) *chain.Transaction {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: 1_000, ChainID: ids.GenerateTestID(), MaxFee: 100},
+			[]chain.Action{&testAction{value: value}},
+		).Sign(&testAuthFactory{actor: sponsor}, actionRegistry, authRegistry)
+		require.NoError(err)
+		return tx
+	}
+
+	denied := codec.CreateAddress(0, ids.GenerateTestID())
+	allowed := codec.CreateAddress(0, ids.GenerateTestID())
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(os.WriteFile(path, []byte(`{"denySponsors":["`+address.MustNew("test", nil).Encode(denied)+`"]}`), 0o600))
+
+	_, metrics, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{
+		c:       &admissionController{rejected: 1},
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		config:  NewConfig(),
+		metrics: metrics,
+	}
+	vm.config.AdmissionConfig = AdmissionConfig{PolicyFile: path}
+	vm.admission, err = vm.newAdmissionWatcher()
+	require.NoError(err)
+	defer vm.admission.Close()
+
+	// Transactions must be admitted by both the policy file and the hook
+	require.NoError(vm.AdmitTx(ctx, newTx(allowed, 0)))
+	err = vm.AdmitTx(ctx, newTx(denied, 0))
+	require.ErrorIs(err, ErrTxNotAdmitted)
+	require.ErrorIs(err, admission.ErrSponsorDenied)
+	err = vm.AdmitTx(ctx, newTx(allowed, 1))
+	require.ErrorIs(err, ErrTxNotAdmitted)
+	require.ErrorIs(err, errHookRejected)
+}
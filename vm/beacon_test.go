@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+func TestBeacon(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	var (
+		chainID  = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		proposer = ids.GenerateTestNodeID()
+		other    = ids.GenerateTestNodeID()
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	otherSK, err := bls.NewSecretKey()
+	require.NoError(err)
+	vdrState := &validators.TestState{
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) { return subnetID, nil },
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				proposer: {NodeID: proposer, PublicKey: bls.PublicFromSecretKey(sk), Weight: 1},
+				other:    {NodeID: other, Weight: 1},
+			}, nil
+		},
+	}
+	rules := chain.NewMockRules(ctrl)
+	rules.EXPECT().NetworkID().Return(uint32(1)).AnyTimes()
+	rules.EXPECT().ChainID().Return(chainID).AnyTimes()
+	vm := &VM{snowCtx: &snow.Context{
+		NetworkID:  1,
+		ChainID:    chainID,
+		NodeID:     proposer,
+		WarpSigner: warp.NewSigner(sk, 1, chainID),
+	}}
+
+	parent := ids.GenerateTestID()
+	beacon, err := chain.NewBeacon(rules, vm.NodeID(), parent, vm.Sign)
+	require.NoError(err)
+	require.NoError(beacon.Verify(ctx, rules, vdrState, 10, parent))
+
+	// The signature is unique to the parent
+	require.ErrorIs(beacon.Verify(ctx, rules, vdrState, 10, ids.GenerateTestID()), chain.ErrInvalidBeacon)
+	again, err := chain.NewBeacon(rules, vm.NodeID(), parent, vm.Sign)
+	require.NoError(err)
+	require.Equal(beacon.Randomness(), again.Randomness())
+
+	// The signature must be from the validator it claims to be from (with a
+	// registered BLS key)
+	forged := &chain.Beacon{Proposer: other, Signature: beacon.Signature}
+	require.ErrorIs(forged.Verify(ctx, rules, vdrState, 10, parent), chain.ErrInvalidBeacon)
+	unknown, err := chain.NewBeacon(rules, ids.GenerateTestNodeID(), parent, warp.NewSigner(otherSK, 1, chainID).Sign)
+	require.NoError(err)
+	require.ErrorIs(unknown.Verify(ctx, rules, vdrState, 10, parent), chain.ErrInvalidBeacon)
+
+	// Beacons are encoded at the end of blocks
+	blk := &chain.StatefulBlock{Prnt: parent, Tmstmp: 1, Hght: 1, Txs: []*chain.Transaction{}, Beacon: beacon}
+	b, err := blk.Marshal()
+	require.NoError(err)
+	parsed, err := chain.UnmarshalBlock(b, &testParser{})
+	require.NoError(err)
+	require.Equal(beacon, parsed.Beacon)
+	require.Empty(parsed.Chunks)
+	blk.Beacon = nil
+	b, err = blk.Marshal()
+	require.NoError(err)
+	parsed, err = chain.UnmarshalBlock(b, &testParser{})
+	require.NoError(err)
+	require.Nil(parsed.Beacon)
+
+	// Randomness can only be read during execution
+	_, err = chain.Randomness(ctx)
+	require.ErrorIs(err, chain.ErrNoRandomness)
+}
+
+type testParser struct{}
+
+func (*testParser) Rules(int64) chain.Rules { return nil }
+
+func (*testParser) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return codec.NewTypeParser[chain.Action](), codec.NewTypeParser[chain.Auth]()
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/export"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+// BlockExporter writes the blocks stored by the [Indexer] into bundles (see
+// [export]).
+//
+// A bundle is only written once a block above its range was indexed, so every
+// bundle is complete when it is written. The start of the next bundle is
+// stored in the vmDB after each write, so bundles that were not written
+// before a restart (or while the store was unreachable) are written later.
+type BlockExporter struct {
+	vm     *VM
+	config export.Config
+	store  export.Store
+
+	notify chan struct{}
+	done   chan struct{}
+}
+
+func NewBlockExporter(vm *VM, config export.Config, store export.Store) *BlockExporter {
+	return &BlockExporter{
+		vm:     vm,
+		config: config,
+		store:  store,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// Accepted wakes up the exporter after a block was indexed.
+func (e *BlockExporter) Accepted() {
+	select {
+	case e.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Cursor returns the start of the next bundle to write.
+func (e *BlockExporter) Cursor() (uint64, error) {
+	v, err := e.vm.vmDB.Get(exportCursor)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func (e *BlockExporter) Run() {
+	defer close(e.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-e.vm.stop
+		cancel()
+	}()
+
+	for {
+		err := e.export(ctx)
+		if err == nil {
+			select {
+			case <-e.notify:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		e.vm.Logger().Warn("unable to export blocks", zap.Error(err))
+		select {
+		case <-time.After(e.config.RetryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// export writes bundles until the indexed blocks do not complete the next
+// one.
+func (e *BlockExporter) export(ctx context.Context) error {
+	start, err := e.Cursor()
+	if err != nil {
+		return err
+	}
+	for {
+		first, _, ok, err := e.vm.indexer.NextBlock(start)
+		if err != nil || !ok {
+			return err
+		}
+		if first >= start+e.config.BlocksPerFile {
+			// Skip the bundles of blocks that were never indexed
+			start = first - first%e.config.BlocksPerFile
+			continue
+		}
+		end := start + e.config.BlocksPerFile
+		if _, _, ok, err := e.vm.indexer.NextBlock(end - 1); err != nil || !ok {
+			return err
+		}
+
+		records := []*export.Record{}
+		next := first
+		for {
+			height, msg, ok, err := e.vm.indexer.NextBlock(next)
+			if err != nil {
+				return err
+			}
+			if !ok || height >= end {
+				break
+			}
+			blk, results, prices, err := rpc.UnpackBlockMessage(msg, e.vm)
+			if err != nil {
+				return err
+			}
+			record, err := export.NewRecord(blk, results, prices)
+			if err != nil {
+				return err
+			}
+			records = append(records, record)
+			next = height + 1
+		}
+		if err := e.store.Put(ctx, export.FileName(start), export.MarshalBundle(records)); err != nil {
+			return err
+		}
+		e.vm.metrics.exportHeight.Set(float64(records[len(records)-1].Height))
+		if err := e.vm.vmDB.Put(exportCursor, binary.BigEndian.AppendUint64(nil, end)); err != nil {
+			return err
+		}
+		start = end
+	}
+}
+
+// Done waits for [Run] to exit.
+func (e *BlockExporter) Done() {
+	<-e.done
+}
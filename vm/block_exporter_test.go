@@ -0,0 +1,98 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/export"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+var errStoreDown = errors.New("store down")
+
+type testStore struct {
+	fail    bool
+	bundles map[string][]byte
+}
+
+func (s *testStore) Put(_ context.Context, name string, data []byte) error {
+	if s.fail {
+		return errStoreDown
+	}
+	s.bundles[name] = data
+	return nil
+}
+
+func indexTestBlock(t *testing.T, indexer *Indexer, height uint64) {
+	b, err := (&chain.StatefulBlock{Hght: height, Tmstmp: int64(height)}).Marshal()
+	require.NoError(t, err)
+	results, err := chain.MarshalResults(nil)
+	require.NoError(t, err)
+	p := codec.NewWriter(0, consts.MaxInt)
+	p.PackBytes(b)
+	p.PackBytes(results)
+	p.PackFixedBytes(fees.Dimensions{}.Bytes())
+	require.NoError(t, p.Err())
+	require.NoError(t, indexer.db.Put(indexBlockKey(height), p.Bytes()))
+}
+
+func readTestBundle(t *testing.T, b []byte) []uint64 {
+	heights := []uint64{}
+	require.NoError(t, export.ReadBundle(bytes.NewReader(b), func(r *export.Record) error {
+		heights = append(heights, r.Height)
+		return nil
+	}))
+	return heights
+}
+
+func TestBlockExporterBundles(t *testing.T) {
+	require := require.New(t)
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	indexer := &Indexer{db: memdb.New()}
+	vm := &VM{vmDB: memdb.New(), metrics: m, indexer: indexer}
+	store := &testStore{fail: true, bundles: map[string][]byte{}}
+	cfg := export.NewDefaultConfig()
+	cfg.BlocksPerFile = 4
+	e := NewBlockExporter(vm, cfg, store)
+
+	// Blocks before the first indexed block (accepted while state syncing) are
+	// skipped
+	for height := uint64(9); height <= 16; height++ {
+		indexTestBlock(t, indexer, height)
+	}
+	ctx := context.Background()
+	require.ErrorIs(e.export(ctx), errStoreDown)
+	cursor, err := e.Cursor()
+	require.NoError(err)
+	require.Zero(cursor)
+
+	// The bundle of [16, 20) is not complete
+	store.fail = false
+	require.NoError(e.export(ctx))
+	require.Len(store.bundles, 2)
+	require.Equal([]uint64{9, 10, 11}, readTestBundle(t, store.bundles[export.FileName(8)]))
+	require.Equal([]uint64{12, 13, 14, 15}, readTestBundle(t, store.bundles[export.FileName(12)]))
+	cursor, err = e.Cursor()
+	require.NoError(err)
+	require.Equal(uint64(16), cursor)
+
+	for height := uint64(17); height <= 20; height++ {
+		indexTestBlock(t, indexer, height)
+	}
+	require.NoError(e.export(ctx))
+	require.Len(store.bundles, 3)
+	require.Equal([]uint64{16, 17, 18, 19}, readTestBundle(t, store.bundles[export.FileName(16)]))
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"math"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/hypersdk/cache"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const (
+	acceptedBlocksCache       = "accepted_blocks"
+	acceptedBlockHeightsCache = "accepted_block_heights"
+	parsedTxsCache            = "parsed_txs"
+	resultsCache              = "results"
+)
+
+var _ rpc.Indexer = (*cachedIndexer)(nil)
+
+// CacheLimit bounds the number of entries in a cache and the total size of
+// those entries.
+type CacheLimit struct {
+	Entries int `json:"entries" min:"0"` // 0 disables the cache
+	Bytes   int `json:"bytes" min:"0"`   // 0 doesn't limit the size of the entries
+}
+
+// CacheConfig sizes the in-memory caches of recently accepted data.
+//
+// Nodes that serve a lot of RPC traffic benefit from large caches, whereas
+// validators rarely look up anything older than the blocks they are
+// processing.
+type CacheConfig struct {
+	// AcceptedBlocks holds recently accepted blocks (so they aren't read from
+	// disk when served to peers).
+	AcceptedBlocks CacheLimit `json:"acceptedBlocks"`
+
+	// ParsedTxs and Results hold the transactions (and their results) of
+	// recently accepted blocks. They are used to serve indexed transactions
+	// without reading them from the [Indexer] (so they are only populated if
+	// [Config.IndexerEnabled] is set).
+	ParsedTxs CacheLimit `json:"parsedTxs"`
+	Results   CacheLimit `json:"results"`
+}
+
+func NewDefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		AcceptedBlocks: CacheLimit{Entries: 128, Bytes: 256 * units.MiB}, // 128 blocks at 2MB
+		ParsedTxs:      CacheLimit{Entries: 8_192},
+		Results:        CacheLimit{Entries: 8_192},
+	}
+}
+
+// lruCache is a [cache.SizedLRU] bounded by a [CacheLimit] that records its
+// hits and misses.
+type lruCache[K comparable, V any] struct {
+	lru    *cache.SizedLRU[K, V] // nil if disabled
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+func newLRUCache[K comparable, V any](
+	name string,
+	limit CacheLimit,
+	size func(K, V) int,
+	metrics *Metrics,
+) *lruCache[K, V] {
+	c := &lruCache[K, V]{
+		hits:   metrics.cacheHits.WithLabelValues(name),
+		misses: metrics.cacheMisses.WithLabelValues(name),
+	}
+	if limit.Entries > 0 {
+		maxSize := limit.Bytes
+		if maxSize == 0 {
+			maxSize = math.MaxInt
+		}
+		c.lru = cache.NewLimitedLRU(limit.Entries, maxSize, size)
+	}
+	return c
+}
+
+func (c *lruCache[K, V]) Put(key K, val V) {
+	if c.lru == nil {
+		return
+	}
+	c.lru.Put(key, val)
+}
+
+func (c *lruCache[K, V]) Get(key K) (V, bool) {
+	if c.lru == nil {
+		c.misses.Inc()
+		var v V
+		return v, false
+	}
+	v, ok := c.lru.Get(key)
+	if ok {
+		c.hits.Inc()
+	} else {
+		c.misses.Inc()
+	}
+	return v, ok
+}
+
+// acceptedResult is the [chain.Result] of an accepted transaction and the
+// block that included it.
+type acceptedResult struct {
+	height    uint64
+	timestamp int64
+	result    *chain.Result
+}
+
+// cachedIndexer serves the transactions of recently accepted blocks from
+// [VM.parsedTxs] and [VM.results] (and everything else from the [Indexer]).
+type cachedIndexer struct {
+	*Indexer
+
+	vm *VM
+}
+
+func (c *cachedIndexer) GetTx(txID ids.ID) (uint64, int64, []byte, []byte, error) {
+	// Results are checked first because they are needed to serve the
+	// transaction (and are smaller)
+	r, ok := c.vm.results.Get(txID)
+	if !ok {
+		return c.Indexer.GetTx(txID)
+	}
+	tx, ok := c.vm.parsedTxs.Get(txID)
+	if !ok {
+		return c.Indexer.GetTx(txID)
+	}
+	p := codec.NewWriter(r.result.Size(), consts.MaxInt)
+	if err := r.result.Marshal(p); err != nil {
+		return 0, 0, nil, nil, err
+	}
+	return r.height, r.timestamp, tx.Bytes(), p.Bytes(), nil
+}
+
+// cacheTxs adds the transactions of [blk] (which must have been processed)
+// to [VM.parsedTxs] and [VM.results].
+func (vm *VM) cacheTxs(blk *chain.StatelessBlock) {
+	results := blk.Results()
+	for i, tx := range blk.Txs {
+		vm.parsedTxs.Put(tx.ID(), tx)
+		vm.results.Put(tx.ID(), &acceptedResult{
+			height:    blk.Hght,
+			timestamp: blk.Tmstmp,
+			result:    results[i],
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache(t *testing.T) {
+	require := require.New(t)
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	size := func(_ int, v []byte) int { return len(v) }
+
+	// Entries are evicted when there are too many of them
+	c := newLRUCache("entries", CacheLimit{Entries: 2}, size, m)
+	c.Put(1, []byte{1})
+	c.Put(2, []byte{2})
+	c.Put(3, []byte{3})
+	_, ok := c.Get(1)
+	require.False(ok)
+	v, ok := c.Get(3)
+	require.True(ok)
+	require.Equal([]byte{3}, v)
+	require.Equal(float64(1), testutil.ToFloat64(m.cacheHits.WithLabelValues("entries")))
+	require.Equal(float64(1), testutil.ToFloat64(m.cacheMisses.WithLabelValues("entries")))
+
+	// ...or when they are too large
+	c = newLRUCache("bytes", CacheLimit{Entries: 10, Bytes: 4}, size, m)
+	c.Put(1, []byte{1, 1})
+	c.Put(2, []byte{2, 2})
+	c.Put(3, []byte{3})
+	_, ok = c.Get(1)
+	require.False(ok)
+	_, ok = c.Get(2)
+	require.True(ok)
+
+	// Caches without entries are disabled
+	c = newLRUCache("disabled", CacheLimit{Bytes: 4}, size, m)
+	c.Put(1, []byte{1})
+	_, ok = c.Get(1)
+	require.False(ok)
+	require.Equal(float64(1), testutil.ToFloat64(m.cacheMisses.WithLabelValues("disabled")))
+}
@@ -0,0 +1,377 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/checkpoint"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+const (
+	// checkpointBatchSize is the number of keys of a checkpoint committed to
+	// the state at once
+	checkpointBatchSize = 4_096
+	// checkpointPageSize is the number of keys read from the state at once
+	// when publishing a checkpoint
+	checkpointPageSize = 2_048
+)
+
+type CheckpointConfig struct {
+	// Path is the checkpoint to initialize the node from (instead of
+	// genesis) if it has not accepted any block yet
+	Path string `json:"path"`
+	// TrustedPublishers are the hex-encoded ED25519 public keys that can
+	// sign the checkpoint at [Path]
+	TrustedPublishers []string `json:"trustedPublishers"`
+
+	// PublisherEnabled serves checkpoints of the last accepted block at
+	// [rpc.CheckpointEndpoint]
+	PublisherEnabled bool `json:"publisherEnabled"`
+	// AuthToken must be provided as a bearer token to fetch checkpoints
+	AuthToken string `json:"authToken"`
+	// SigningKey is the hex-encoded ED25519 private key published
+	// checkpoints are signed with
+	SigningKey string `json:"signingKey"`
+}
+
+func parsePrivateKey(s string) (ed25519.PrivateKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return ed25519.EmptyPrivateKey, err
+	}
+	if len(b) != ed25519.PrivateKeyLen {
+		return ed25519.EmptyPrivateKey, fmt.Errorf("%w: expected %d bytes", ErrInvalidCheckpointKey, ed25519.PrivateKeyLen)
+	}
+	return ed25519.PrivateKey(b), nil
+}
+
+func parsePublicKey(s string) (ed25519.PublicKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return ed25519.EmptyPublicKey, err
+	}
+	if len(b) != ed25519.PublicKeyLen {
+		return ed25519.EmptyPublicKey, fmt.Errorf("%w: expected %d bytes", ErrInvalidCheckpointKey, ed25519.PublicKeyLen)
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// CheckpointPublisher serves signed checkpoints of the last accepted block,
+// which operators can distribute to initialize new nodes with
+// [CheckpointConfig.Path].
+type CheckpointPublisher struct {
+	vm     *VM
+	config CheckpointConfig
+	key    ed25519.PrivateKey
+}
+
+func NewCheckpointPublisher(vm *VM, config CheckpointConfig) (*CheckpointPublisher, error) {
+	if len(config.AuthToken) == 0 {
+		return nil, fmt.Errorf("%w: checkpoints can not be fetched", ErrMissingAuthToken)
+	}
+	key, err := parsePrivateKey(config.SigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse checkpoint signing key: %w", err)
+	}
+	return &CheckpointPublisher{vm: vm, config: config, key: key}, nil
+}
+
+func (p *CheckpointPublisher) Authorized(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(p.config.AuthToken)) == 1
+}
+
+// ServeHTTP writes a checkpoint of the last accepted block to authorized
+// requests.
+func (p *CheckpointPublisher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !p.Authorized(token) {
+		http.Error(w, rpc.ErrUnauthorized.Error(), http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	h, err := p.header(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := p.write(r.Context(), w, h); err != nil {
+		// The status was already sent, so the incomplete checkpoint is only
+		// rejected when it is read
+		p.vm.snowCtx.Log.Warn("unable to write checkpoint", zap.Error(err))
+		return
+	}
+	p.vm.metrics.checkpointsPublished.Inc()
+}
+
+// header returns the signed [checkpoint.Header] of the last accepted block,
+// which includes the blocks in its validity window.
+func (p *CheckpointPublisher) header(ctx context.Context) (*checkpoint.Header, error) {
+	last := p.vm.LastAcceptedBlock()
+	if last.Hght == 0 {
+		return nil, ErrNotReady
+	}
+	validityWindow := p.vm.Rules(last.Tmstmp).GetValidityWindow()
+	blks := [][]byte{last.Bytes()}
+	for blk := last; blk.Hght > 1 && last.Tmstmp-blk.Tmstmp <= validityWindow; {
+		parent, err := p.vm.GetStatelessBlock(ctx, blk.Prnt)
+		if err != nil {
+			return nil, err
+		}
+		blks = append(blks, parent.Bytes())
+		blk = parent
+	}
+	for i, j := 0, len(blks)-1; i < j; i, j = i+1, j-1 {
+		blks[i], blks[j] = blks[j], blks[i]
+	}
+	h := &checkpoint.Header{Blocks: blks}
+	if err := h.Sign(p.vm.snowCtx.ChainID, p.key); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// write writes the checkpoint of [h] to [w]. Its state is read at the root of
+// the checkpoint block, which must still be in the state history.
+func (p *CheckpointPublisher) write(ctx context.Context, w io.Writer, h *checkpoint.Header) error {
+	blk, err := chain.UnmarshalBlock(h.Blocks[len(h.Blocks)-1], p.vm)
+	if err != nil {
+		return err
+	}
+	cw, err := checkpoint.NewWriter(w, h)
+	if err != nil {
+		return err
+	}
+	start := maybe.Nothing[[]byte]()
+	for {
+		proof, err := p.vm.stateDB.GetRangeProofAtRoot(ctx, blk.StateRoot, start, maybe.Nothing[[]byte](), checkpointPageSize)
+		if errors.Is(err, merkledb.ErrEmptyProof) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, kv := range proof.KeyValues {
+			if err := cw.Put(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+		if len(proof.KeyValues) < checkpointPageSize {
+			break
+		}
+		// The next page starts right after the last key of this one
+		start = maybe.Some(append(slices.Clone(proof.KeyValues[len(proof.KeyValues)-1].Key), 0))
+	}
+	return cw.Flush()
+}
+
+// loadCheckpoint initializes the state and last accepted block of the node
+// from the checkpoint at [CheckpointConfig.Path] (instead of genesis).
+//
+// Like after state sync, the last accepted block is not executed until its
+// first child is verified (the state of the checkpoint is the state it was
+// built on).
+func (vm *VM) loadCheckpoint(ctx context.Context) error {
+	config := vm.config.CheckpointConfig
+	trusted := make([]ed25519.PublicKey, 0, len(config.TrustedPublishers))
+	for _, s := range config.TrustedPublishers {
+		pk, err := parsePublicKey(s)
+		if err != nil {
+			return fmt.Errorf("unable to parse trusted publisher: %w", err)
+		}
+		trusted = append(trusted, pk)
+	}
+	if len(trusted) == 0 {
+		return ErrNoTrustedPublishers
+	}
+
+	// A checkpoint can't be loaded on top of existing state (which is left
+	// behind if loading a checkpoint fails)
+	root, err := vm.stateDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if root != ids.Empty {
+		return ErrStateNotEmpty
+	}
+	genesisBlk, err := vm.newGenesisBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Like blocks received while state syncing, blocks after genesis are
+	// parsed as if they will be executed (so their signatures are verified
+	// when the last one is).
+	vm.lastAccepted = genesisBlk
+
+	f, err := os.Open(config.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// If loading is interrupted after blocks are stored, the node will state
+	// sync from its peers when it restarts.
+	if err := vm.PutDiskIsSyncing(true); err != nil {
+		return err
+	}
+	var (
+		blks []*chain.StatelessBlock
+		ops  = make([]database.BatchOp, 0, checkpointBatchSize)
+		keys int
+	)
+	commit := func() error {
+		view, err := vm.stateDB.NewView(ctx, merkledb.ViewChanges{BatchOps: ops, ConsumeBytes: true})
+		if err != nil {
+			return err
+		}
+		if err := view.CommitToDB(ctx); err != nil {
+			return err
+		}
+		keys += len(ops)
+		ops = make([]database.BatchOp, 0, checkpointBatchSize)
+		return nil
+	}
+	if err := checkpoint.Read(f, func(h *checkpoint.Header) error {
+		if err := h.Verify(vm.snowCtx.ChainID, trusted); err != nil {
+			return err
+		}
+		blks, err = vm.parseCheckpointBlocks(ctx, h.Blocks, genesisBlk)
+		return err
+	}, func(key []byte, value []byte) error {
+		ops = append(ops, database.BatchOp{Key: key, Value: value})
+		if len(ops) < checkpointBatchSize {
+			return nil
+		}
+		return commit()
+	}); err != nil {
+		return fmt.Errorf("unable to read checkpoint: %w", err)
+	}
+	if err := commit(); err != nil {
+		return err
+	}
+	last := blks[len(blks)-1]
+	root, err = vm.stateDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if root != last.StateRoot {
+		return fmt.Errorf("%w: checkpoint=%s expected=%s", ErrUnexpectedStateRoot, root, last.StateRoot)
+	}
+
+	// Store the blocks so their transactions are used for replay protection
+	vm.genesisBlk = genesisBlk
+	for _, blk := range append([]*chain.StatelessBlock{genesisBlk}, blks...) {
+		if err := vm.UpdateLastAccepted(ctx, blk); err != nil {
+			return err
+		}
+	}
+	if err := vm.PutDiskIsSyncing(false); err != nil {
+		return err
+	}
+	vm.preferred = last.ID() // [UpdateLastAccepted] set [lastAccepted]
+	vm.snowCtx.Log.Info("initialized vm from checkpoint",
+		zap.Stringer("block", last.ID()),
+		zap.Uint64("height", last.Hght),
+		zap.Stringer("root", root),
+		zap.Int("keys", keys),
+		zap.Int("blocks", len(blks)),
+	)
+	return nil
+}
+
+// parseCheckpointBlocks parses [raw] and ensures each block is the child of
+// the one before it (and the first is the child of [genesisBlk] if it is at
+// height 1).
+func (vm *VM) parseCheckpointBlocks(
+	ctx context.Context,
+	raw [][]byte,
+	genesisBlk *chain.StatelessBlock,
+) ([]*chain.StatelessBlock, error) {
+	blks := make([]*chain.StatelessBlock, 0, len(raw))
+	parent := genesisBlk
+	for i, b := range raw {
+		sblk, err := chain.UnmarshalBlock(b, vm)
+		if err != nil {
+			return nil, err
+		}
+		if len(sblk.Chunks) > 0 {
+			return nil, ErrCheckpointChunks
+		}
+		blk, err := chain.ParseStatefulBlock(ctx, sblk, b, choices.Accepted, vm)
+		if err != nil {
+			return nil, err
+		}
+		if blk.Hght == 0 {
+			return nil, fmt.Errorf("%w: checkpoint includes genesis", ErrInvalidCheckpointBlocks)
+		}
+		if (i > 0 || blk.Hght == 1) && (blk.Prnt != parent.ID() || blk.Hght != parent.Hght+1) {
+			return nil, fmt.Errorf("%w: block %d is not the child of block %d", ErrInvalidCheckpointBlocks, blk.Hght, parent.Hght)
+		}
+		blks = append(blks, blk)
+		parent = blk
+	}
+	return blks, nil
+}
+
+// newGenesisBlock returns the genesis block of the chain without storing the
+// genesis allocation in the state.
+func (vm *VM) newGenesisBlock(ctx context.Context) (*chain.StatelessBlock, error) {
+	db, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		BranchFactor:                vm.genesis.GetStateBranchFactor(),
+		RootGenConcurrency:          uint(vm.config.RootGenerationCores),
+		HistoryLength:               1,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.MiB,
+		IntermediateWriteBatchSize:  units.MiB,
+		Reg:                         prometheus.NewRegistry(),
+		TraceLevel:                  merkledb.InfoTrace,
+		Tracer:                      vm.tracer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sps := state.NewSimpleMutable(db)
+	if err := vm.genesis.Load(ctx, vm.tracer, sps); err != nil {
+		return nil, err
+	}
+	if err := sps.Commit(ctx); err != nil {
+		return nil, err
+	}
+	root, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return chain.ParseStatefulBlock(ctx, chain.NewGenesisBlock(root), nil, choices.Accepted, vm)
+}
@@ -0,0 +1,886 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const (
+	chunkSignRequest  = 0x0 // expiry, txIDs -> signature
+	chunkFetchRequest = 0x1 // chunkID -> chunk
+	txFetchRequest    = 0x2 // txIDs -> txs
+
+	// maxProducerChunks is the number of chunks of each producer that are
+	// stored until they are included or expire
+	maxProducerChunks = 64
+
+	// maxChunkSize leaves room for the request type in a network message
+	maxChunkSize = consts.NetworkSizeLimit - consts.ByteLen
+
+	maxChunkFetchAttempts = 8
+
+	// chunkRetention is how long chunks that were not included are kept
+	// after they expire, so peers verifying blocks that include them can
+	// still fetch them
+	chunkRetention = 30 * time.Second
+)
+
+type ChunkConfig struct {
+	// Enabled produces chunks from the mempool and includes certified chunks
+	// in built blocks. Validators sign and serve the chunks of their peers
+	// regardless.
+	Enabled bool `json:"enabled"`
+	// BuildInterval is how often a chunk is produced (if the mempool is not
+	// empty)
+	BuildInterval time.Duration `json:"buildInterval" min:"1ms"`
+	MaxTxs        int           `json:"maxTxs" min:"1"`
+	// Expiry is how long after it is produced a chunk can be included (must
+	// be less than the validity window)
+	Expiry time.Duration `json:"expiry" min:"1ms"`
+	// FetchTimeout is how long to wait for a peer to return a missing chunk
+	FetchTimeout time.Duration `json:"fetchTimeout" min:"1ms"`
+}
+
+// pendingChunk is a chunk that has not been included by an accepted block.
+type pendingChunk struct {
+	chunk *chain.Chunk
+	cert  *chain.ChunkCertificate
+
+	// Only set for the chunks produced by this node
+	msg        *warp.UnsignedMessage
+	vdrs       map[ids.NodeID]*validators.GetValidatorOutput
+	signatures []*chain.WarpSignature
+	restored   bool
+}
+
+// chunkRequest is a request for the signature of a validator over a chunk
+// (if [response] is nil) or for a chunk (or its transactions).
+type chunkRequest struct {
+	chunkID   ids.ID
+	nodeID    ids.NodeID
+	publicKey *bls.PublicKey
+	response  chan []byte
+}
+
+// txFetch is a transaction requested from a peer. Concurrent fetches of the
+// same transaction wait for the first request instead of sending another one.
+type txFetch struct {
+	done chan struct{}
+	tx   *chain.Transaction
+}
+
+// ChunkManager disseminates the transactions of the mempool in chunks ahead of
+// block proposal (see [chain.Chunk]).
+//
+// Every [ChunkConfig.BuildInterval], a validator packs the transactions of its
+// mempool into a chunk and sends the IDs of its transactions to the other
+// validators, which rebuild it (fetching the transactions they don't have
+// from the producer), store it, and return their signature over its ID. Once a quorum of the stake signed the
+// chunk, its certificate is gossiped to the validators and can be included by
+// the next block. Validators that do not store a chunk (or that restarted)
+// fetch it from their peers in the background when they parse a block that
+// includes it, which can only be verified once the chunk is fetched.
+type ChunkManager struct {
+	vm        *VM
+	config    ChunkConfig
+	appSender common.AppSender
+
+	l         sync.Mutex
+	chunks    map[ids.ID]*pendingChunk
+	producers map[ids.NodeID]int
+	requestID uint32
+	requests  map[uint32]*chunkRequest
+
+	// txs indexes the transactions of the chunks produced by this node (which
+	// are no longer in the mempool)
+	txs     map[ids.ID]*chain.Transaction
+	fetches map[ids.ID]*txFetch
+
+	// chunkFetches are the chunks being fetched in the background
+	chunkFetches set.Set[ids.ID]
+
+	done chan struct{}
+}
+
+func NewChunkManager(vm *VM, config ChunkConfig, appSender common.AppSender) *ChunkManager {
+	return &ChunkManager{
+		vm:        vm,
+		config:    config,
+		appSender: appSender,
+		chunks:    map[ids.ID]*pendingChunk{},
+		producers: map[ids.NodeID]int{},
+		requests:  map[uint32]*chunkRequest{},
+		txs:       map[ids.ID]*chain.Transaction{},
+		fetches:   map[ids.ID]*txFetch{},
+
+		chunkFetches: set.Set[ids.ID]{},
+		done:         make(chan struct{}),
+	}
+}
+
+func (c *ChunkManager) Run() {
+	defer close(c.done)
+
+	t := time.NewTicker(c.config.BuildInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-c.vm.stop:
+			return
+		}
+		ctx := context.Background()
+		c.expire(ctx, time.Now().UnixMilli())
+		if !c.config.Enabled || !c.vm.isReady() {
+			continue
+		}
+		if err := c.produce(ctx); err != nil {
+			c.vm.Logger().Warn("unable to produce chunk", zap.Error(err))
+		}
+	}
+}
+
+// produce packs the best transactions of the mempool into a chunk and asks
+// the current validators to sign it.
+func (c *ChunkManager) produce(ctx context.Context) error {
+	nodeID := c.vm.snowCtx.NodeID
+	vdrs, _ := c.vm.CurrentValidators(ctx)
+	if _, ok := vdrs[nodeID]; !ok {
+		return nil
+	}
+	var (
+		txs     = []*chain.Transaction{}
+		evicted = []*chain.Transaction{}
+		size    = ids.NodeIDLen + consts.Int64Len + consts.IntLen
+	)
+	for len(txs) < c.config.MaxTxs {
+		tx, ok := c.vm.mempool.PopNext(ctx)
+		if !ok {
+			break
+		}
+		// Drop transactions no longer admitted by a reloaded policy
+		if err := c.vm.AdmitTx(ctx, tx); err != nil {
+			evicted = append(evicted, tx)
+			continue
+		}
+		if size+tx.Size() > maxChunkSize {
+			c.vm.mempool.Add(ctx, []*chain.Transaction{tx})
+			break
+		}
+		txs = append(txs, tx)
+		size += tx.Size()
+	}
+	c.vm.publishPending(rpc.PendingEvicted, evicted)
+	if len(txs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	chunk, err := chain.NewChunk(nodeID, now.Add(c.config.Expiry).UnixMilli(), txs)
+	if err != nil {
+		c.vm.mempool.Add(ctx, txs)
+		return err
+	}
+	r := c.vm.Rules(now.UnixMilli())
+	msg, err := chain.NewChunkMessage(r.NetworkID(), r.ChainID(), chunk.ID())
+	if err != nil {
+		c.vm.mempool.Add(ctx, txs)
+		return err
+	}
+	signature, err := c.vm.snowCtx.WarpSigner.Sign(msg)
+	if err != nil {
+		c.vm.mempool.Add(ctx, txs)
+		return err
+	}
+	pc := &pendingChunk{
+		chunk:      chunk,
+		msg:        msg,
+		vdrs:       vdrs,
+		signatures: []*chain.WarpSignature{{PublicKey: c.vm.pkBytes, Signature: signature}},
+	}
+	c.l.Lock()
+	c.add(pc)
+	c.l.Unlock()
+	c.vm.metrics.chunksProduced.Inc()
+
+	// The signature of this node may be enough to reach the quorum
+	c.aggregate(ctx, pc)
+	p := codec.NewWriter(consts.ByteLen+consts.Int64Len+consts.IntLen+len(txs)*ids.IDLen, consts.NetworkSizeLimit)
+	p.PackByte(chunkSignRequest)
+	p.PackInt64(chunk.Expiry)
+	p.PackInt(len(txs))
+	for _, tx := range txs {
+		p.PackID(tx.ID())
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	request := p.Bytes()
+	for vdrID, vdr := range vdrs {
+		if vdrID == nodeID || vdr.PublicKey == nil {
+			continue
+		}
+		_ = c.request(ctx, &chunkRequest{
+			chunkID:   chunk.ID(),
+			nodeID:    vdrID,
+			publicKey: vdr.PublicKey,
+		}, request)
+	}
+	return nil
+}
+
+// aggregate creates the certificate of [pc] (and shares it with the
+// validators) once enough signatures were collected.
+func (c *ChunkManager) aggregate(ctx context.Context, pc *pendingChunk) {
+	c.l.Lock()
+	if pc.cert != nil {
+		c.l.Unlock()
+		return
+	}
+	signatures := slices.Clone(pc.signatures)
+	c.l.Unlock()
+
+	msg, _, err := rpc.AggregateWarpSignatures(pc.msg, pc.vdrs, signatures, chain.ChunkQuorumNum, chain.ChunkQuorumDen)
+	if err != nil {
+		if !errors.Is(err, warp.ErrInsufficientWeight) {
+			c.vm.Logger().Warn("unable to aggregate chunk signatures", zap.Stringer("chunkID", pc.chunk.ID()), zap.Error(err))
+		}
+		return
+	}
+	signature, ok := msg.Signature.(*warp.BitSetSignature)
+	if !ok {
+		return
+	}
+	cert := &chain.ChunkCertificate{Chunk: pc.chunk.ID(), Signature: signature}
+	c.l.Lock()
+	if pc.cert != nil {
+		c.l.Unlock()
+		return
+	}
+	pc.cert = cert
+	c.l.Unlock()
+	c.vm.metrics.chunksCertified.Inc()
+	c.vm.builder.Queue(ctx)
+
+	p := codec.NewWriter(cert.Size(), consts.NetworkSizeLimit)
+	cert.Marshal(p)
+	if err := p.Err(); err != nil {
+		c.vm.Logger().Warn("unable to marshal chunk certificate", zap.Error(err))
+		return
+	}
+	recipients := set.NewSet[ids.NodeID](len(pc.vdrs))
+	for nodeID := range pc.vdrs {
+		if nodeID != c.vm.snowCtx.NodeID {
+			recipients.Add(nodeID)
+		}
+	}
+	if err := c.appSender.SendAppGossip(ctx, common.SendConfig{NodeIDs: recipients}, p.Bytes()); err != nil {
+		c.vm.Logger().Warn("unable to gossip chunk certificate", zap.Stringer("chunkID", cert.Chunk), zap.Error(err))
+	}
+}
+
+// add tracks [pc] until it is included or expires. The caller must hold [c.l].
+func (c *ChunkManager) add(pc *pendingChunk) {
+	chunkID := pc.chunk.ID()
+	if _, ok := c.chunks[chunkID]; ok {
+		return
+	}
+	c.chunks[chunkID] = pc
+	c.producers[pc.chunk.Producer]++
+	if pc.msg != nil {
+		for _, tx := range pc.chunk.Txs {
+			c.txs[tx.ID()] = tx
+		}
+	}
+}
+
+// remove stops tracking [chunkID]. The caller must hold [c.l].
+func (c *ChunkManager) remove(chunkID ids.ID) {
+	pc, ok := c.chunks[chunkID]
+	if !ok {
+		return
+	}
+	delete(c.chunks, chunkID)
+	if pc.msg != nil {
+		for _, tx := range pc.chunk.Txs {
+			delete(c.txs, tx.ID())
+		}
+	}
+	producer := pc.chunk.Producer
+	c.producers[producer]--
+	if c.producers[producer] <= 0 {
+		delete(c.producers, producer)
+	}
+}
+
+// expire returns the transactions of the chunks produced by this node that
+// expired before they were included to the mempool and stops tracking chunks
+// once [chunkRetention] has passed.
+func (c *ChunkManager) expire(ctx context.Context, now int64) {
+	restorable := []*chain.Transaction{}
+	c.l.Lock()
+	for chunkID, pc := range c.chunks {
+		if pc.chunk.Expiry >= now {
+			continue
+		}
+		if pc.msg != nil && !pc.restored {
+			restorable = append(restorable, pc.chunk.Txs...)
+			pc.restored = true
+		}
+		if pc.chunk.Expiry+chunkRetention.Milliseconds() < now {
+			c.remove(chunkID)
+		}
+	}
+	c.l.Unlock()
+	if len(restorable) > 0 {
+		c.vm.mempool.Add(ctx, restorable)
+	}
+}
+
+// Accepted stops tracking the chunks included by [blk], which are stored on
+// disk with it.
+func (c *ChunkManager) Accepted(blk *chain.StatelessBlock) {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	for _, chunk := range blk.GetChunks() {
+		c.remove(chunk.ID())
+	}
+}
+
+// CertifiedChunks returns the certified chunks that have not expired at [t]
+// (the chunks that expire first are returned first).
+func (c *ChunkManager) CertifiedChunks(t int64) ([]*chain.ChunkCertificate, []*chain.Chunk) {
+	if !c.config.Enabled {
+		return nil, nil
+	}
+	c.l.Lock()
+	certified := []*pendingChunk{}
+	for _, pc := range c.chunks {
+		if pc.cert != nil && pc.chunk.Expiry >= t {
+			certified = append(certified, pc)
+		}
+	}
+	c.l.Unlock()
+
+	slices.SortFunc(certified, func(a, b *pendingChunk) int {
+		return cmp.Compare(a.chunk.Expiry, b.chunk.Expiry)
+	})
+	certs := make([]*chain.ChunkCertificate, len(certified))
+	chunks := make([]*chain.Chunk, len(certified))
+	for i, pc := range certified {
+		certs[i] = pc.cert
+		chunks[i] = pc.chunk
+	}
+	return certs, chunks
+}
+
+// getChunk returns the chunk [chunkID] if it is stored by this node.
+func (c *ChunkManager) getChunk(chunkID ids.ID) (*chain.Chunk, error) {
+	c.l.Lock()
+	pc, ok := c.chunks[chunkID]
+	c.l.Unlock()
+	if ok {
+		return pc.chunk, nil
+	}
+	return c.vm.GetDiskChunk(chunkID)
+}
+
+// GetChunks returns the chunks certified by [certs] if they are all stored by
+// this node. Otherwise, it fetches the missing chunks from its peers in the
+// background and returns [chain.ErrChunksNotAvailable].
+func (c *ChunkManager) GetChunks(_ context.Context, certs []*chain.ChunkCertificate) ([]*chain.Chunk, error) {
+	var (
+		chunks  = make([]*chain.Chunk, len(certs))
+		missing = []ids.ID{}
+	)
+	for i, cert := range certs {
+		chunk, err := c.getChunk(cert.Chunk)
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			missing = append(missing, cert.Chunk)
+		case err != nil:
+			return nil, err
+		}
+		chunks[i] = chunk
+	}
+	if len(missing) == 0 {
+		return chunks, nil
+	}
+	for _, chunkID := range missing {
+		c.fetchAsync(chunkID)
+	}
+	return nil, fmt.Errorf("%w: %d of %d chunks are being fetched", chain.ErrChunksNotAvailable, len(missing), len(certs))
+}
+
+// fetchAsync fetches [chunkID] from the current validators unless it is
+// already being fetched.
+func (c *ChunkManager) fetchAsync(chunkID ids.ID) {
+	c.l.Lock()
+	if c.chunkFetches.Contains(chunkID) {
+		c.l.Unlock()
+		return
+	}
+	c.chunkFetches.Add(chunkID)
+	c.l.Unlock()
+
+	go func() {
+		defer func() {
+			c.l.Lock()
+			c.chunkFetches.Remove(chunkID)
+			c.l.Unlock()
+		}()
+		if _, err := c.fetch(context.Background(), chunkID, nil); err != nil {
+			c.vm.Logger().Debug("unable to fetch chunk", zap.Stringer("chunkID", chunkID), zap.Error(err))
+		}
+	}()
+}
+
+// fetch requests [chunkID] from [preferred] and then from the current
+// validators until one of them returns it.
+func (c *ChunkManager) fetch(ctx context.Context, chunkID ids.ID, preferred []ids.NodeID) (*chain.Chunk, error) {
+	nodeIDs := slices.Clone(preferred)
+	vdrs, _ := c.vm.CurrentValidators(ctx)
+	for nodeID := range vdrs {
+		if nodeID != c.vm.snowCtx.NodeID && !slices.Contains(preferred, nodeID) {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	request := append([]byte{chunkFetchRequest}, chunkID[:]...)
+	for i, nodeID := range nodeIDs {
+		if i == maxChunkFetchAttempts {
+			break
+		}
+		response := make(chan []byte, 1)
+		if err := c.request(ctx, &chunkRequest{chunkID: chunkID, nodeID: nodeID, response: response}, request); err != nil {
+			continue
+		}
+		var raw []byte
+		select {
+		case raw = <-response:
+		case <-time.After(c.config.FetchTimeout):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		chunk, err := chain.UnmarshalChunk(raw, c.vm)
+		if err != nil || chunk.ID() != chunkID {
+			c.vm.Logger().Debug("received invalid chunk", zap.Stringer("chunkID", chunkID), zap.Stringer("nodeID", nodeID))
+			continue
+		}
+		c.l.Lock()
+		c.add(&pendingChunk{chunk: chunk})
+		c.l.Unlock()
+		return chunk, nil
+	}
+	return nil, fmt.Errorf("%w: %s", chain.ErrChunksNotAvailable, chunkID)
+}
+
+func (c *ChunkManager) request(ctx context.Context, req *chunkRequest, msg []byte) error {
+	c.l.Lock()
+	requestID := c.requestID
+	c.requestID++
+	c.requests[requestID] = req
+	c.l.Unlock()
+
+	if err := c.appSender.SendAppRequest(ctx, set.Of(req.nodeID), requestID, msg); err != nil {
+		c.vm.Logger().Warn("unable to send chunk request",
+			zap.Stringer("chunkID", req.chunkID),
+			zap.Stringer("nodeID", req.nodeID),
+			zap.Error(err),
+		)
+		c.takeRequest(req.nodeID, requestID)
+		return err
+	}
+	return nil
+}
+
+func (c *ChunkManager) takeRequest(nodeID ids.NodeID, requestID uint32) *chunkRequest {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	req, ok := c.requests[requestID]
+	if !ok || req.nodeID != nodeID {
+		return nil
+	}
+	delete(c.requests, requestID)
+	return req
+}
+
+// verifyChunk ensures [chunk] only contains transactions that can be included
+// before it expires.
+func (c *ChunkManager) verifyChunk(ctx context.Context, chunk *chain.Chunk) error {
+	now := time.Now().UnixMilli()
+	r := c.vm.Rules(now)
+	if chunk.Expiry < now || chunk.Expiry > now+r.GetValidityWindow() {
+		return fmt.Errorf("%w: %d", ErrInvalidChunkExpiry, chunk.Expiry)
+	}
+	for _, tx := range chunk.Txs {
+		if err := tx.Base.Execute(r.ChainID(), r, now); err != nil {
+			return fmt.Errorf("%w: tx %s", err, tx.ID())
+		}
+		if !c.vm.config.VerifyAuth {
+			continue
+		}
+		digest, err := tx.Digest()
+		if err != nil {
+			return err
+		}
+		if err := tx.Auth.Verify(ctx, digest); err != nil {
+			return fmt.Errorf("%w: tx %s", err, tx.ID())
+		}
+	}
+	return nil
+}
+
+// sign returns the signature of this node over the chunk produced by [nodeID]
+// that expires at [expiry] and contains [txIDs] if it is valid (which is then
+// stored until it is included or expires).
+func (c *ChunkManager) sign(ctx context.Context, nodeID ids.NodeID, expiry int64, txIDs []ids.ID) ([]byte, error) {
+	if len(txIDs) == 0 {
+		return nil, chain.ErrNoTxs
+	}
+	vdrs, _ := c.vm.CurrentValidators(ctx)
+	if _, ok := vdrs[nodeID]; !ok {
+		return nil, ErrNotValidator
+	}
+	txs, err := c.fetchTxs(ctx, nodeID, txIDs)
+	if err != nil {
+		return nil, err
+	}
+	chunk, err := chain.NewChunk(nodeID, expiry, txs)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyChunk(ctx, chunk); err != nil {
+		return nil, err
+	}
+	c.l.Lock()
+	if _, ok := c.chunks[chunk.ID()]; !ok && c.producers[nodeID] >= maxProducerChunks {
+		c.l.Unlock()
+		return nil, ErrTooManyChunks
+	}
+	c.add(&pendingChunk{chunk: chunk})
+	c.l.Unlock()
+
+	r := c.vm.Rules(time.Now().UnixMilli())
+	msg, err := chain.NewChunkMessage(r.NetworkID(), r.ChainID(), chunk.ID())
+	if err != nil {
+		return nil, err
+	}
+	return c.vm.snowCtx.WarpSigner.Sign(msg)
+}
+
+// getTx returns the transaction [txID] if it is in the mempool (or in a chunk
+// produced by this node).
+func (c *ChunkManager) getTx(ctx context.Context, txID ids.ID) (*chain.Transaction, bool) {
+	c.l.Lock()
+	tx, ok := c.txs[txID]
+	c.l.Unlock()
+	if ok {
+		return tx, true
+	}
+	return c.vm.mempool.Get(ctx, txID)
+}
+
+// fetchTxs returns [txIDs], requesting the transactions that are not stored
+// by this node from [nodeID].
+func (c *ChunkManager) fetchTxs(ctx context.Context, nodeID ids.NodeID, txIDs []ids.ID) ([]*chain.Transaction, error) {
+	var (
+		txs     = make([]*chain.Transaction, len(txIDs))
+		waiting = map[int]*txFetch{}
+		missing = []ids.ID{}
+	)
+	for i, txID := range txIDs {
+		if tx, ok := c.getTx(ctx, txID); ok {
+			txs[i] = tx
+			continue
+		}
+		c.l.Lock()
+		f, ok := c.fetches[txID]
+		if !ok {
+			f = &txFetch{done: make(chan struct{})}
+			c.fetches[txID] = f
+			missing = append(missing, txID)
+		}
+		c.l.Unlock()
+		waiting[i] = f
+	}
+	if len(missing) > 0 {
+		c.requestTxs(ctx, nodeID, missing)
+	}
+
+	timeout := time.NewTimer(c.config.FetchTimeout)
+	defer timeout.Stop()
+	for i, f := range waiting {
+		select {
+		case <-f.done:
+		case <-timeout.C:
+			return nil, fmt.Errorf("%w: timeout", ErrTxsNotAvailable)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if f.tx == nil {
+			return nil, fmt.Errorf("%w: %s", ErrTxsNotAvailable, txIDs[i])
+		}
+		txs[i] = f.tx
+	}
+	return txs, nil
+}
+
+// requestTxs requests [txIDs] from [nodeID] and completes their fetches once
+// it responds (or times out).
+func (c *ChunkManager) requestTxs(ctx context.Context, nodeID ids.NodeID, txIDs []ids.ID) {
+	found := map[ids.ID]*chain.Transaction{}
+	defer func() {
+		c.l.Lock()
+		for _, txID := range txIDs {
+			f := c.fetches[txID]
+			delete(c.fetches, txID)
+			f.tx = found[txID]
+			close(f.done)
+		}
+		c.l.Unlock()
+	}()
+
+	p := codec.NewWriter(consts.ByteLen+consts.IntLen+len(txIDs)*ids.IDLen, consts.NetworkSizeLimit)
+	p.PackByte(txFetchRequest)
+	p.PackInt(len(txIDs))
+	for _, txID := range txIDs {
+		p.PackID(txID)
+	}
+	if p.Err() != nil {
+		return
+	}
+	response := make(chan []byte, 1)
+	if err := c.request(ctx, &chunkRequest{nodeID: nodeID, response: response}, p.Bytes()); err != nil {
+		return
+	}
+	var raw []byte
+	select {
+	case raw = <-response:
+	case <-time.After(c.config.FetchTimeout):
+	case <-ctx.Done():
+	}
+	if len(raw) == 0 {
+		return
+	}
+	actionRegistry, authRegistry := c.vm.Registry()
+	_, txs, err := chain.UnmarshalTxs(raw, len(txIDs), actionRegistry, authRegistry)
+	if err != nil {
+		c.vm.Logger().Debug("received invalid txs", zap.Stringer("nodeID", nodeID), zap.Error(err))
+		return
+	}
+	for _, tx := range txs {
+		found[tx.ID()] = tx
+	}
+}
+
+// unpackTxIDs parses the IDs of the transactions requested by a peer.
+func unpackTxIDs(p *codec.Packer) ([]ids.ID, error) {
+	count := p.UnpackInt(true)
+	txIDs := []ids.ID{} // don't preallocate all to avoid DoS
+	for i := 0; i < count; i++ {
+		var txID ids.ID
+		p.UnpackID(true, &txID)
+		txIDs = append(txIDs, txID)
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return txIDs, p.Err()
+}
+
+// AppRequest responds with the signature of this node over the chunk
+// provided (or with the chunk or transactions requested). An empty response
+// is returned if the chunk is invalid (or unknown).
+func (c *ChunkManager) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	request []byte,
+) error {
+	if len(request) == 0 {
+		return nil
+	}
+	var response []byte
+	switch request[0] {
+	case chunkSignRequest:
+		p := codec.NewReader(request[1:], consts.NetworkSizeLimit)
+		expiry := p.UnpackInt64(true)
+		txIDs, err := unpackTxIDs(p)
+		if err != nil {
+			c.vm.Logger().Debug("invalid chunk request", zap.Stringer("nodeID", nodeID), zap.Error(err))
+			return nil
+		}
+		// Missing transactions are fetched from [nodeID], so the response is
+		// sent once they are received
+		go func() {
+			signature, err := c.sign(context.Background(), nodeID, expiry, txIDs)
+			if err != nil {
+				c.vm.Logger().Debug("not signing chunk", zap.Stringer("nodeID", nodeID), zap.Error(err))
+			}
+			if err := c.appSender.SendAppResponse(context.Background(), nodeID, requestID, signature); err != nil {
+				c.vm.Logger().Warn("unable to send chunk signature", zap.Stringer("nodeID", nodeID), zap.Error(err))
+			}
+		}()
+		return nil
+	case chunkFetchRequest:
+		chunkID, err := ids.ToID(request[1:])
+		if err != nil {
+			c.vm.Logger().Debug("invalid chunk request", zap.Stringer("nodeID", nodeID), zap.Error(err))
+			return nil
+		}
+		chunk, err := c.getChunk(chunkID)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			c.vm.Logger().Warn("unable to get chunk", zap.Stringer("chunkID", chunkID), zap.Error(err))
+			return nil
+		}
+		if chunk != nil {
+			response = chunk.Bytes()
+		}
+	case txFetchRequest:
+		txIDs, err := unpackTxIDs(codec.NewReader(request[1:], consts.NetworkSizeLimit))
+		if err != nil {
+			c.vm.Logger().Debug("invalid tx request", zap.Stringer("nodeID", nodeID), zap.Error(err))
+			return nil
+		}
+		txs := []*chain.Transaction{}
+		for _, txID := range txIDs {
+			if tx, ok := c.getTx(ctx, txID); ok {
+				txs = append(txs, tx)
+			}
+		}
+		if len(txs) > 0 {
+			response, err = chain.MarshalTxs(txs)
+			if err != nil {
+				c.vm.Logger().Warn("unable to marshal txs", zap.Error(err))
+				return nil
+			}
+		}
+	default:
+		c.vm.Logger().Debug("unknown chunk request", zap.Stringer("nodeID", nodeID), zap.Uint8("type", request[0]))
+		return nil
+	}
+	return c.appSender.SendAppResponse(ctx, nodeID, requestID, response)
+}
+
+func (c *ChunkManager) AppRequestFailed(nodeID ids.NodeID, requestID uint32) {
+	if req := c.takeRequest(nodeID, requestID); req != nil && req.response != nil {
+		req.response <- nil
+	}
+}
+
+// AppResponse passes a requested chunk to its fetcher or adds the signature
+// of [nodeID] to a chunk produced by this node (if it is valid).
+func (c *ChunkManager) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) {
+	req := c.takeRequest(nodeID, requestID)
+	if req == nil {
+		return
+	}
+	if req.response != nil {
+		req.response <- response
+		return
+	}
+	if len(response) == 0 {
+		return
+	}
+	c.l.Lock()
+	pc, ok := c.chunks[req.chunkID]
+	c.l.Unlock()
+	if !ok || pc.msg == nil {
+		return
+	}
+	signature, err := bls.SignatureFromBytes(response)
+	if err != nil || !bls.Verify(req.publicKey, signature, pc.msg.Bytes()) {
+		c.vm.Logger().Warn("received invalid chunk signature",
+			zap.Stringer("chunkID", req.chunkID),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return
+	}
+	c.l.Lock()
+	pc.signatures = append(pc.signatures, &chain.WarpSignature{
+		PublicKey: bls.PublicKeyToCompressedBytes(req.publicKey),
+		Signature: response,
+	})
+	c.l.Unlock()
+	c.aggregate(context.Background(), pc)
+}
+
+// AppGossip stores a certificate gossiped by the producer of its chunk
+// (fetching the chunk from [nodeID] if this node did not sign it).
+func (c *ChunkManager) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) {
+	if !c.config.Enabled {
+		return
+	}
+	p := codec.NewReader(msg, consts.NetworkSizeLimit)
+	cert, err := chain.UnmarshalChunkCertificate(p)
+	if err != nil || !p.Empty() {
+		c.vm.Logger().Debug("invalid chunk certificate", zap.Stringer("nodeID", nodeID))
+		return
+	}
+	c.l.Lock()
+	pc, ok := c.chunks[cert.Chunk]
+	certified := ok && pc.cert != nil
+	c.l.Unlock()
+	if certified {
+		return
+	}
+	pChainHeight, err := c.vm.snowCtx.ValidatorState.GetCurrentHeight(ctx)
+	if err != nil {
+		c.vm.Logger().Warn("unable to get P-Chain height", zap.Error(err))
+		return
+	}
+	if err := cert.Verify(ctx, c.vm.Rules(time.Now().UnixMilli()), c.vm.ValidatorState(), pChainHeight); err != nil {
+		c.vm.Logger().Debug("invalid chunk certificate", zap.Stringer("nodeID", nodeID), zap.Error(err))
+		return
+	}
+	if ok {
+		c.l.Lock()
+		pc.cert = cert
+		c.l.Unlock()
+		c.vm.builder.Queue(ctx)
+		return
+	}
+	go func() {
+		if _, err := c.fetch(context.Background(), cert.Chunk, []ids.NodeID{nodeID}); err != nil {
+			c.vm.Logger().Debug("unable to fetch certified chunk", zap.Stringer("chunkID", cert.Chunk), zap.Error(err))
+			return
+		}
+		c.l.Lock()
+		if pc, ok := c.chunks[cert.Chunk]; ok {
+			pc.cert = cert
+		}
+		c.l.Unlock()
+		c.vm.builder.Queue(context.Background())
+	}()
+}
+
+// Done waits for [Run] to exit.
+func (c *ChunkManager) Done() {
+	<-c.done
+}
@@ -0,0 +1,323 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/hypersdk/builder"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/mempool"
+)
+
+type testAction struct {
+	chain.Action
+
+	value uint64
+}
+
+func (*testAction) GetTypeID() uint8 { return 0 }
+
+func (*testAction) Size() int { return consts.Uint64Len }
+
+func (a *testAction) Marshal(p *codec.Packer) { p.PackUint64(a.value) }
+
+func unmarshalTestAction(p *codec.Packer) (chain.Action, error) {
+	return &testAction{value: p.UnpackUint64(false)}, p.Err()
+}
+
+// testAuth authorizes transactions of [actor] without a signature.
+type testAuth struct {
+	chain.Auth
+
+	actor codec.Address
+}
+
+func (*testAuth) GetTypeID() uint8 { return 0 }
+
+func (*testAuth) Size() int { return codec.AddressLen }
+
+func (a *testAuth) Marshal(p *codec.Packer) { p.PackAddress(a.actor) }
+
+func (a *testAuth) Actor() codec.Address { return a.actor }
+
+func (a *testAuth) Sponsor() codec.Address { return a.actor }
+
+func unmarshalTestAuth(p *codec.Packer) (chain.Auth, error) {
+	var auth testAuth
+	p.UnpackAddress(&auth.actor)
+	return &auth, p.Err()
+}
+
+type testAuthFactory struct {
+	chain.AuthFactory
+
+	actor codec.Address
+}
+
+func (f *testAuthFactory) Sign([]byte) (chain.Auth, error) {
+	return &testAuth{actor: f.actor}, nil
+}
+
+// testChunkNetwork delivers the messages of the [ChunkManager]s of its nodes
+// synchronously (gossip is recorded instead).
+type testChunkNetwork struct {
+	managers map[ids.NodeID]*ChunkManager
+
+	l      sync.Mutex
+	gossip [][]byte
+}
+
+func (n *testChunkNetwork) gossiped() [][]byte {
+	n.l.Lock()
+	defer n.l.Unlock()
+
+	return slices.Clone(n.gossip)
+}
+
+type testChunkSender struct {
+	common.AppSender
+
+	nodeID  ids.NodeID
+	network *testChunkNetwork
+}
+
+func (s *testChunkSender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, msg []byte) error {
+	for nodeID := range nodeIDs {
+		if err := s.network.managers[nodeID].AppRequest(ctx, s.nodeID, requestID, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *testChunkSender) SendAppResponse(_ context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error {
+	s.network.managers[nodeID].AppResponse(s.nodeID, requestID, msg)
+	return nil
+}
+
+func (s *testChunkSender) SendAppGossip(_ context.Context, _ common.SendConfig, msg []byte) error {
+	s.network.l.Lock()
+	defer s.network.l.Unlock()
+
+	s.network.gossip = append(s.network.gossip, msg)
+	return nil
+}
+
+func newTestChunkManager(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	network *testChunkNetwork,
+	vdrState validators.State,
+	nodeID ids.NodeID,
+	sk *bls.SecretKey,
+) *ChunkManager {
+	require := require.New(t)
+
+	rules := chain.NewMockRules(ctrl)
+	rules.EXPECT().NetworkID().Return(uint32(1)).AnyTimes()
+	rules.EXPECT().ChainID().Return(testChunkChainID).AnyTimes()
+	rules.EXPECT().GetValidityWindow().Return(int64(60_000)).AnyTimes()
+	rules.EXPECT().GetBlockTimestampTolerance().Return(int64(10_000)).AnyTimes()
+	controller := NewMockController(ctrl)
+	controller.EXPECT().Rules(gomock.Any()).Return(rules).AnyTimes()
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{
+		snowCtx: &snow.Context{
+			NetworkID:      1,
+			ChainID:        testChunkChainID,
+			NodeID:         nodeID,
+			Log:            logging.NoLog{},
+			WarpSigner:     warp.NewSigner(sk, 1, testChunkChainID),
+			ValidatorState: vdrState,
+		},
+		pkBytes:        bls.PublicKeyToCompressedBytes(bls.PublicFromSecretKey(sk)),
+		config:         NewConfig(),
+		vmDB:           memdb.New(),
+		metrics:        m,
+		tracer:         trace.Noop,
+		mempool:        mempool.New[*chain.Transaction](trace.Noop, 100, 32),
+		c:              controller,
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+	}
+	vm.config.VerifyAuth = false
+	vm.builder = builder.NewManual(vm)
+	vm.proposerMonitor = NewProposerMonitor(vm)
+
+	config := vm.config.ChunkConfig
+	config.Enabled = true
+	c := NewChunkManager(vm, config, &testChunkSender{nodeID: nodeID, network: network})
+	network.managers[nodeID] = c
+	return c
+}
+
+var testChunkChainID = ids.GenerateTestID()
+
+func TestChunkManagerCertify(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	// The producer did not register its BLS key, so the signature of the
+	// other validator is required to reach the quorum
+	var (
+		nodeIDs = []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+		sks     = make([]*bls.SecretKey, len(nodeIDs))
+		vdrs    = map[ids.NodeID]*validators.GetValidatorOutput{}
+	)
+	for i, nodeID := range nodeIDs {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		sks[i] = sk
+		vdrs[nodeID] = &validators.GetValidatorOutput{NodeID: nodeID, PublicKey: bls.PublicFromSecretKey(sk), Weight: 3}
+	}
+	vdrs[nodeIDs[0]].PublicKey = nil
+	vdrs[nodeIDs[0]].Weight = 1
+	subnetID := ids.GenerateTestID()
+	vdrState := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) { return 10, nil },
+		GetSubnetIDF:      func(context.Context, ids.ID) (ids.ID, error) { return subnetID, nil },
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrs, nil
+		},
+	}
+	network := &testChunkNetwork{managers: map[ids.NodeID]*ChunkManager{}}
+	managers := make([]*ChunkManager, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		managers[i] = newTestChunkManager(t, ctrl, network, vdrState, nodeID, sks[i])
+	}
+	producer := managers[0]
+
+	timestamp := (time.Now().UnixMilli()/consts.MillisecondsPerSecond + 30) * consts.MillisecondsPerSecond
+	var txs []*chain.Transaction
+	for i := uint64(0); i < 2; i++ {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: timestamp, ChainID: testChunkChainID, MaxFee: 100},
+			[]chain.Action{&testAction{value: i}},
+		).Sign(&testAuthFactory{actor: codec.CreateAddress(0, ids.GenerateTestID())}, producer.vm.actionRegistry, producer.vm.authRegistry)
+		require.NoError(err)
+		txs = append(txs, tx)
+	}
+	producer.vm.mempool.Add(ctx, txs)
+	require.NoError(producer.produce(ctx))
+	require.Zero(producer.vm.mempool.Len(ctx))
+
+	// The other validator fetches the transactions of the chunk from the
+	// producer and its signature certifies the chunk
+	require.Eventually(func() bool {
+		return len(network.gossiped()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	now := time.Now().UnixMilli()
+	certs, chunks := producer.CertifiedChunks(now)
+	require.Len(certs, 1)
+	require.Len(chunks[0].Txs, 2)
+	require.Equal(nodeIDs[0], chunks[0].Producer)
+	require.NoError(certs[0].Verify(ctx, producer.vm.Rules(now), vdrState, 10))
+
+	// Signers only include the chunk once they receive its certificate
+	signer := managers[1]
+	certified, _ := signer.CertifiedChunks(now)
+	require.Empty(certified)
+	signer.AppGossip(ctx, nodeIDs[0], network.gossiped()[0])
+	certified, _ = signer.CertifiedChunks(now)
+	require.Equal(certs, certified)
+
+	// Validators only sign the chunks of validators
+	txIDs := []ids.ID{txs[0].ID(), txs[1].ID()}
+	_, err := signer.sign(ctx, ids.GenerateTestNodeID(), chunks[0].Expiry, txIDs)
+	require.ErrorIs(err, ErrNotValidator)
+
+	// Transactions that the producer does not have can't be fetched
+	_, err = signer.sign(ctx, nodeIDs[0], chunks[0].Expiry, []ids.ID{ids.GenerateTestID()})
+	require.ErrorIs(err, ErrTxsNotAvailable)
+	require.Empty(signer.fetches)
+
+	// Nodes that did not sign the chunk fetch it from the validators in the
+	// background
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	fetcher := newTestChunkManager(t, ctrl, network, vdrState, ids.GenerateTestNodeID(), sk)
+	_, err = fetcher.GetChunks(ctx, certs)
+	require.ErrorIs(err, chain.ErrChunksNotAvailable)
+	var fetched []*chain.Chunk
+	require.Eventually(func() bool {
+		fetched, err = fetcher.GetChunks(ctx, certs)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(chunks[0].ID(), fetched[0].ID())
+
+	// Blocks only encode the certificates of their chunks
+	blk := &chain.StatefulBlock{Prnt: ids.GenerateTestID(), Tmstmp: now, Hght: 1, Chunks: certs}
+	_, err = blk.Marshal()
+	require.ErrorIs(err, chain.ErrChunksNotAttached)
+	require.NoError(blk.AttachChunks(fetched))
+	require.Len(blk.Txs, 2)
+	raw, err := blk.Marshal()
+	require.NoError(err)
+	parsed, err := chain.UnmarshalBlock(raw, fetcher.vm)
+	require.NoError(err)
+	require.Empty(parsed.Txs)
+	require.Len(parsed.Chunks, 1)
+	require.Equal(certs[0].Chunk, parsed.Chunks[0].Chunk)
+	require.Equal(certs[0].Signature, parsed.Chunks[0].Signature)
+	require.ErrorIs(parsed.AttachChunks(nil), chain.ErrChunkMismatch)
+
+	// Parsing a processing block does not wait for its chunks to be fetched,
+	// while the chunks of accepted blocks must be stored
+	sk, err = bls.NewSecretKey()
+	require.NoError(err)
+	parser := newTestChunkManager(t, ctrl, network, vdrState, ids.GenerateTestNodeID(), sk)
+	parser.vm.lastAccepted = &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{}}
+	_, err = chain.ParseBlock(ctx, raw, choices.Accepted, parser.vm)
+	require.ErrorIs(err, chain.ErrChunksNotAvailable)
+	parsedBlk, err := chain.ParseBlock(ctx, raw, choices.Processing, parser.vm)
+	require.NoError(err)
+	require.Nil(parsedBlk.GetChunks())
+	require.Empty(parsedBlk.Txs)
+	require.Eventually(func() bool {
+		_, err := parser.GetChunks(ctx, certs)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The transactions of chunks that expire are returned to the mempool and
+	// the chunks are dropped once they stop being served
+	expiry := chunks[0].Expiry
+	producer.expire(ctx, expiry+1)
+	require.Equal(2, producer.vm.mempool.Len(ctx))
+	certified, _ = producer.CertifiedChunks(expiry + 1)
+	require.Empty(certified)
+	producer.expire(ctx, expiry+chunkRetention.Milliseconds()+1)
+	_, err = producer.getChunk(chunks[0].ID())
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Equal(2, producer.vm.mempool.Len(ctx))
+}
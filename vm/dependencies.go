@@ -13,10 +13,18 @@ import (
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/events"
+	"github.com/ava-labs/hypersdk/export"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/pebble"
+	"github.com/ava-labs/hypersdk/postgres"
+	"github.com/ava-labs/hypersdk/pubsub"
 	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/statecache"
 	"github.com/ava-labs/hypersdk/trace"
 
 	avametrics "github.com/ava-labs/avalanchego/api/metrics"
@@ -25,33 +33,66 @@ import (
 
 type Handlers map[string]http.Handler
 
+// ConfigEnvPrefix starts the environment variables that override the
+// [Config] of a node (like HYPERSDK_MEMPOOL_SIZE for "mempoolSize").
+const ConfigEnvPrefix = "HYPERSDK"
+
 type Config struct {
-	TraceConfig                      trace.Config    `json:"traceConfig"`
-	MempoolSize                      int             `json:"mempoolSize"`
-	AuthVerificationCores            int             `json:"authVerificationCores"`
-	VerifyAuth                       bool            `json:"verifyAuth"`
-	RootGenerationCores              int             `json:"rootGenerationCores"`
-	TransactionExecutionCores        int             `json:"transactionExecutionCores"`
-	StateFetchConcurrency            int             `json:"stateFetchConcurrency"`
-	MempoolSponsorSize               int             `json:"mempoolSponsorSize"`
-	StreamingBacklogSize             int             `json:"streamingBacklogSize"`
-	StateHistoryLength               int             `json:"stateHistoryLength"`               // how many roots back of data to keep to serve state queries
-	IntermediateNodeCacheSize        int             `json:"intermediateNodeCacheSize"`        // how many bytes to keep in intermediate cache
-	StateIntermediateWriteBufferSize int             `json:"stateIntermediateWriteBufferSize"` // how many bytes to keep unwritten in intermediate cache
-	StateIntermediateWriteBatchSize  int             `json:"stateIntermediateWriteBatchSize"`  // how many bytes to write from intermediate cache at once
-	ValueNodeCacheSize               int             `json:"valueNodeCacheSize"`               // how many bytes to keep in value cache
-	AcceptorSize                     int             `json:"acceptorSize"`                     // how far back we can fall in processing accepted blocks
-	StateSyncParallelism             int             `json:"stateSyncParallelism"`
-	StateSyncMinBlocks               uint64          `json:"stateSyncMinBlocks"`
-	StateSyncServerDelay             time.Duration   `json:"stateSyncServerDelay"`
-	ParsedBlockCacheSize             int             `json:"parsedBlockCacheSize"`
-	AcceptedBlockWindow              int             `json:"acceptedBlockWindow"`
-	AcceptedBlockWindowCache         int             `json:"acceptedBlockWindowCache"`
-	ContinuousProfilerConfig         profiler.Config `json:"continuousProfilerConfig"`
-	TargetBuildDuration              time.Duration   `json:"targetBuildDuration"`
-	ProcessingBuildSkip              int             `json:"processingBuildSkip"`
-	TargetGossipDuration             time.Duration   `json:"targetGossipDuration"`
-	BlockCompactionFrequency         int             `json:"blockCompactionFrequency"`
+	TraceConfig                      trace.Config           `json:"traceConfig"`
+	MempoolSize                      int                    `json:"mempoolSize" min:"1"`
+	AuthVerificationCores            int                    `json:"authVerificationCores" min:"1"`
+	AuthVerificationMaxCores         int                    `json:"authVerificationMaxCores"` // if greater than [AuthVerificationCores], workers are added (up to this) while verification jobs wait
+	VerifyAuth                       bool                   `json:"verifyAuth"`
+	RootGenerationCores              int                    `json:"rootGenerationCores" min:"1"` // fixed once the state database is opened
+	TransactionExecutionCores        int                    `json:"transactionExecutionCores" min:"1"`
+	TransactionExecutionMaxCores     int                    `json:"transactionExecutionMaxCores"` // if greater than [TransactionExecutionCores], blocks are executed with more workers (up to this) while transactions wait
+	OptimisticExecution              bool                   `json:"optimisticExecution"`          // speculatively execute all transactions and re-execute conflicts
+	StateFetchConcurrency            int                    `json:"stateFetchConcurrency" min:"1"`
+	StateFetchMaxConcurrency         int                    `json:"stateFetchMaxConcurrency"` // if greater than [StateFetchConcurrency], state is fetched with more workers (up to this) while keys wait
+	StatePrefetch                    bool                   `json:"statePrefetch"`            // load state keys of parsed blocks before verification
+	MempoolSponsorSize               int                    `json:"mempoolSponsorSize" min:"0"`
+	StreamingBacklogSize             int                    `json:"streamingBacklogSize" min:"0"`
+	StreamingReplaySize              int                    `json:"streamingReplaySize" min:"0"`      // how many bytes of recent events to keep per WebSocket topic for resuming subscribers
+	StateHistoryLength               int                    `json:"stateHistoryLength" min:"1"`       // how many roots back of data to keep to serve state queries
+	IntermediateNodeCacheSize        int                    `json:"intermediateNodeCacheSize"`        // how many bytes to keep in intermediate cache
+	StateIntermediateWriteBufferSize int                    `json:"stateIntermediateWriteBufferSize"` // how many bytes to keep unwritten in intermediate cache
+	StateIntermediateWriteBatchSize  int                    `json:"stateIntermediateWriteBatchSize"`  // how many bytes to write from intermediate cache at once
+	ValueNodeCacheSize               int                    `json:"valueNodeCacheSize"`               // how many bytes to keep in value cache
+	StateCacheConfig                 statecache.Config      `json:"stateCacheConfig"`                 // how many bytes to keep in the partitioned state cache
+	PebbleConfig                     pebble.Config          `json:"pebbleConfig"`                     // overrides of the default pebble options for the block and state databases
+	MemoryDB                         bool                   `json:"memoryDB"`                         // keep the block, state, and index databases in memory (used for testing)
+	AcceptorSize                     int                    `json:"acceptorSize" min:"0"`             // how far back we can fall in processing accepted blocks
+	FeeHistorySize                   int                    `json:"feeHistorySize" min:"0"`           // how many accepted blocks to serve fees of (0 to disable)
+	SeenConfig                       SeenConfig             `json:"seenConfig"`                       // how accepted transactions are tracked for replay protection
+	TxStatusSize                     int                    `json:"txStatusSize" min:"0"`             // how many finalized transactions to remember the status of (0 to disable tracking)
+	StateDiffSize                    int                    `json:"stateDiffSize" min:"0"`            // how many accepted blocks to serve the state changes of (0 to disable)
+	UnitProfileSize                  int                    `json:"unitProfileSize" min:"0"`          // how many accepted blocks to profile the units of (0 to disable)
+	StateSyncParallelism             int                    `json:"stateSyncParallelism" min:"1"`
+	StateSyncMinBlocks               uint64                 `json:"stateSyncMinBlocks"`
+	StateSyncServerDelay             time.Duration          `json:"stateSyncServerDelay" min:"0s"`
+	ParsedBlockCacheSize             int                    `json:"parsedBlockCacheSize" min:"1"`
+	AcceptedBlockWindow              int                    `json:"acceptedBlockWindow" min:"1"`
+	CacheConfig                      CacheConfig            `json:"cacheConfig"` // how many (and how many bytes of) recently accepted blocks, txs, and results to keep in memory
+	ContinuousProfilerConfig         profiler.Config        `json:"continuousProfilerConfig"`
+	TargetBuildDuration              time.Duration          `json:"targetBuildDuration" min:"0s"`
+	ProcessingBuildSkip              int                    `json:"processingBuildSkip" min:"0"`
+	TargetGossipDuration             time.Duration          `json:"targetGossipDuration" min:"0s"`
+	BlockCompactionFrequency         int                    `json:"blockCompactionFrequency" min:"1"`
+	ProposerAddress                  string                 `json:"proposerAddress"`        // credited with the proposer rewards of the blocks built by the node (see [chain.ProposerRewardRules])
+	IndexerEnabled                   bool                   `json:"indexerEnabled"`         // index accepted blocks and transactions (see [Indexer])
+	EventSinkConfig                  events.Config          `json:"eventSinkConfig"`        // publish accepted blocks and transactions to NATS JetStream
+	PostgresConfig                   postgres.Config        `json:"postgresConfig"`         // write indexed blocks and transactions to PostgreSQL (requires [IndexerEnabled])
+	WebhookConfig                    WebhookConfig          `json:"webhookConfig"`          // notify registered webhooks of the activity of watched addresses
+	EthRPCEnabled                    bool                   `json:"ethRPCEnabled"`          // serve a subset of the Ethereum JSON-RPC API (see [rpc.EthServer])
+	ExportConfig                     export.Config          `json:"exportConfig"`           // write indexed blocks into flat files (requires [IndexerEnabled])
+	ChunkConfig                      ChunkConfig            `json:"chunkConfig"`            // disseminate transactions in chunks ahead of block proposal
+	DirectSubmissionConfig           DirectSubmissionConfig `json:"directSubmissionConfig"` // accept transactions from registered submitters over AppRequests
+	InvariantConfig                  InvariantConfig        `json:"invariantConfig"`        // check the invariants registered by the Controller (see [InvariantController])
+	TxTraceConfig                    TxTraceConfig          `json:"txTraceConfig"`          // re-execute accepted transactions to debug them (requires [IndexerEnabled])
+	AuditConfig                      audit.Config           `json:"auditConfig"`            // record admin RPC calls, mempool evictions, and the applied config in an audit log
+	AdmissionConfig                  AdmissionConfig        `json:"admissionConfig"`        // reject transactions from the mempool and built blocks with a local policy file
+	ExternalBuilderConfig            ExternalBuilderConfig  `json:"externalBuilderConfig"`  // mirror the mempool to external builders and order built blocks as they propose
+	CheckpointConfig                 CheckpointConfig       `json:"checkpointConfig"`       // initialize new nodes from (and publish) signed checkpoints
 	// Config is defined by the Controller
 	Config map[string]any `json:"config"`
 }
@@ -64,25 +105,67 @@ func NewConfig() Config {
 		VerifyAuth:                       true,
 		RootGenerationCores:              1,
 		TransactionExecutionCores:        1,
+		OptimisticExecution:              false,
 		StateFetchConcurrency:            1,
+		StatePrefetch:                    true,
 		MempoolSponsorSize:               32,
+		StreamingReplaySize:              pubsub.ReplayBufferSize,
 		StateHistoryLength:               256,
 		IntermediateNodeCacheSize:        4 * units.GiB,
 		StateIntermediateWriteBufferSize: 32 * units.MiB,
 		StateIntermediateWriteBatchSize:  4 * units.MiB,
 		ValueNodeCacheSize:               2 * units.GiB,
+		StateCacheConfig:                 statecache.NewDefaultConfig(),
+		PebbleConfig:                     pebble.NewDefaultConfig(),
 		AcceptorSize:                     64,
+		FeeHistorySize:                   128,
+		SeenConfig:                       SeenConfig{},
+		TxStatusSize:                     16_384,
+		StateDiffSize:                    0,
+		UnitProfileSize:                  0,
 		StateSyncParallelism:             4,
 		StateSyncMinBlocks:               768, // set to max int for archive nodes to ensure no skips
 		StateSyncServerDelay:             0,   // used for testing
 		ParsedBlockCacheSize:             128,
 		AcceptedBlockWindow:              50_000, // ~3.5hr with 250ms block time (100GB at 2MB)
-		AcceptedBlockWindowCache:         128,    // 256MB at 2MB blocks
+		CacheConfig:                      NewDefaultCacheConfig(),
 		ContinuousProfilerConfig:         profiler.Config{Enabled: false},
 		TargetBuildDuration:              100 * time.Millisecond,
 		ProcessingBuildSkip:              16,
 		TargetGossipDuration:             20 * time.Millisecond,
 		BlockCompactionFrequency:         32, // 64 MB of deletion if 2 MB blocks
+		IndexerEnabled:                   false,
+		EventSinkConfig:                  events.NewDefaultConfig(),
+		PostgresConfig:                   postgres.NewDefaultConfig(),
+		EthRPCEnabled:                    false,
+		ExportConfig:                     export.NewDefaultConfig(),
+		AuditConfig:                      audit.NewDefaultConfig(),
+		AdmissionConfig:                  AdmissionConfig{ReloadInterval: 5 * time.Second},
+		ChunkConfig: ChunkConfig{
+			Enabled:       false,
+			BuildInterval: 100 * time.Millisecond,
+			MaxTxs:        2_048,
+			Expiry:        10 * time.Second,
+			FetchTimeout:  2 * time.Second,
+		},
+		DirectSubmissionConfig: DirectSubmissionConfig{
+			Forward:       false,
+			ProposerDiff:  4,
+			ProposerDepth: 1,
+		},
+		WebhookConfig: WebhookConfig{
+			Enabled:        false,
+			Timeout:        10 * time.Second,
+			MaxAttempts:    8,
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Minute,
+			QueueSize:      16_384,
+			Workers:        8,
+		},
+		ExternalBuilderConfig: ExternalBuilderConfig{
+			Enabled:        false,
+			MaxProposalTxs: 16_384,
+		},
 	}
 }
 
@@ -125,6 +208,10 @@ type Controller interface {
 
 	// Anything that the VM wishes to store outside of state or blocks must be
 	// recorded here
+	//
+	// Accepted is the first (critical) [AcceptedProcessor]. Independent
+	// components should register their own processors with
+	// [VM.RegisterAcceptedProcessor] instead of being called from here.
 	Accepted(ctx context.Context, blk *chain.StatelessBlock) error
 
 	// Shutdown should be used by the [Controller] to terminate any async
@@ -132,3 +219,17 @@ type Controller interface {
 	// `vm.Shutdown` is called.
 	Shutdown(context.Context) error
 }
+
+// BalanceController is an optional extension of [Controller] that exposes
+// balances in the native asset of the hypervm (used by eth_getBalance).
+type BalanceController interface {
+	GetBalanceFromState(ctx context.Context, addr codec.Address) (uint64, error)
+}
+
+// TopicController is an optional extension of [Controller] that registers
+// topics clients can subscribe to over the WebSocket API, with the parser of
+// the filters of their subscriptions. Events are published to them with
+// [VM.PublishTopic].
+type TopicController interface {
+	Topics() map[string]pubsub.FilterParser
+}
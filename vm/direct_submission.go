@@ -0,0 +1,258 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// initialDirectCapacity is the number of transactions allocated when parsing
+// a direct submission (to avoid DoS)
+const initialDirectCapacity = 256
+
+type DirectSubmissionConfig struct {
+	// Forward sends the transactions submitted to this node to the next
+	// proposers (which must have registered this node as a submitter)
+	Forward       bool `json:"forward"`
+	ProposerDiff  int  `json:"proposerDiff"`
+	ProposerDepth int  `json:"proposerDepth"`
+	// Submitters are the nodes allowed to submit transactions to this node
+	Submitters []DirectSubmitter `json:"submitters"`
+}
+
+// DirectSubmitter is a node (usually run by an RPC provider or wallet) that
+// is allowed to submit [TxsPerSecond] transactions to this node (in bursts of
+// at most [TxsPerSecond]).
+type DirectSubmitter struct {
+	NodeID       ids.NodeID `json:"nodeID"`
+	TxsPerSecond int        `json:"txsPerSecond"`
+}
+
+// quota is a token bucket that refills at [rate] tokens per second.
+type quota struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// take returns how many of [n] tokens are available at [now] and removes them.
+func (q *quota) take(now time.Time, n int) int {
+	q.tokens = min(q.rate, q.tokens+now.Sub(q.last).Seconds()*q.rate)
+	q.last = now
+	taken := min(n, int(q.tokens))
+	q.tokens -= float64(taken)
+	return taken
+}
+
+// DirectSubmission accepts transactions from registered submitters over
+// AppRequests and, if [DirectSubmissionConfig.Forward] is set, submits the
+// transactions received by this node to the next proposers the same way.
+//
+// Transactions submitted directly skip the public gossip of the submitter, so
+// they reach proposers with a single hop. Peers are authenticated by the
+// network layer, so submitters are registered by [ids.NodeID].
+type DirectSubmission struct {
+	vm        *VM
+	config    DirectSubmissionConfig
+	appSender common.AppSender
+
+	l         sync.Mutex
+	quotas    map[ids.NodeID]*quota
+	requestID uint32
+	requests  map[uint32]ids.NodeID
+}
+
+func NewDirectSubmission(vm *VM, config DirectSubmissionConfig, appSender common.AppSender) (*DirectSubmission, error) {
+	quotas := make(map[ids.NodeID]*quota, len(config.Submitters))
+	for _, submitter := range config.Submitters {
+		if submitter.TxsPerSecond <= 0 {
+			return nil, ErrInvalidSubmitterQuota
+		}
+		if _, ok := quotas[submitter.NodeID]; ok {
+			return nil, ErrDuplicateSubmitter
+		}
+		rate := float64(submitter.TxsPerSecond)
+		quotas[submitter.NodeID] = &quota{rate: rate, tokens: rate, last: time.Now()}
+	}
+	return &DirectSubmission{
+		vm:        vm,
+		config:    config,
+		appSender: appSender,
+		quotas:    quotas,
+		requests:  map[uint32]ids.NodeID{},
+	}, nil
+}
+
+// Forward sends [txs] to the next proposers. It is a no-op unless
+// [DirectSubmissionConfig.Forward] is set.
+func (d *DirectSubmission) Forward(ctx context.Context, txs []*chain.Transaction) {
+	if !d.config.Forward || len(txs) == 0 {
+		return
+	}
+	msg, err := chain.MarshalTxs(txs)
+	if err != nil {
+		d.vm.Logger().Warn("unable to marshal direct submission", zap.Error(err))
+		return
+	}
+	proposers, err := d.vm.proposerMonitor.Proposers(ctx, d.config.ProposerDiff, d.config.ProposerDepth)
+	if err != nil {
+		d.vm.Logger().Warn("unable to fetch proposers", zap.Error(err))
+		return
+	}
+	for proposer := range proposers {
+		if proposer == d.vm.snowCtx.NodeID {
+			continue
+		}
+		d.l.Lock()
+		requestID := d.requestID
+		d.requestID++
+		d.requests[requestID] = proposer
+		d.l.Unlock()
+		if err := d.appSender.SendAppRequest(ctx, set.Of(proposer), requestID, msg); err != nil {
+			d.vm.Logger().Warn("unable to submit txs directly", zap.Stringer("nodeID", proposer), zap.Error(err))
+			d.takeRequest(proposer, requestID)
+			continue
+		}
+		d.vm.metrics.directTxsSent.Add(float64(len(txs)))
+	}
+}
+
+func (d *DirectSubmission) takeRequest(nodeID ids.NodeID, requestID uint32) bool {
+	d.l.Lock()
+	defer d.l.Unlock()
+
+	requester, ok := d.requests[requestID]
+	if !ok || requester != nodeID {
+		return false
+	}
+	delete(d.requests, requestID)
+	return true
+}
+
+// AppRequest submits the transactions of a registered submitter that fit in
+// its quota to the mempool and responds with the outcome of each transaction
+// (an empty response is sent to nodes that are not registered).
+func (d *DirectSubmission) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	request []byte,
+) error {
+	d.l.Lock()
+	q, ok := d.quotas[nodeID]
+	d.l.Unlock()
+	if !ok {
+		d.vm.Logger().Debug("dropping direct submission from unregistered node", zap.Stringer("nodeID", nodeID))
+		return d.appSender.SendAppResponse(ctx, nodeID, requestID, nil)
+	}
+	actionRegistry, authRegistry := d.vm.Registry()
+	_, txs, err := chain.UnmarshalTxs(request, initialDirectCapacity, actionRegistry, authRegistry)
+	if err != nil {
+		d.vm.Logger().Debug("received invalid direct submission", zap.Stringer("nodeID", nodeID), zap.Error(err))
+		return d.appSender.SendAppResponse(ctx, nodeID, requestID, nil)
+	}
+	d.l.Lock()
+	allowed := q.take(time.Now(), len(txs))
+	d.l.Unlock()
+	d.vm.metrics.directTxsReceived.Add(float64(len(txs)))
+	d.vm.metrics.directTxsThrottled.Add(float64(len(txs) - allowed))
+
+	errs := make([]error, len(txs))
+	if allowed > 0 {
+		submitErrs := d.vm.submit(ctx, true, txs[:allowed])
+		for i := 0; i < allowed; i++ {
+			if len(submitErrs) != allowed {
+				// [submit] fails all transactions with a single error
+				errs[i] = submitErrs[0]
+				continue
+			}
+			errs[i] = submitErrs[i]
+		}
+	}
+	for i := allowed; i < len(txs); i++ {
+		errs[i] = ErrQuotaExceeded
+	}
+	response, err := packDirectResponse(errs)
+	if err != nil {
+		return err
+	}
+	return d.appSender.SendAppResponse(ctx, nodeID, requestID, response)
+}
+
+func (d *DirectSubmission) AppRequestFailed(nodeID ids.NodeID, requestID uint32) {
+	d.takeRequest(nodeID, requestID)
+}
+
+// AppResponse records the transactions a proposer did not add to its
+// mempool.
+func (d *DirectSubmission) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) {
+	if !d.takeRequest(nodeID, requestID) {
+		return
+	}
+	if len(response) == 0 {
+		d.vm.Logger().Warn("direct submission not accepted (is this node a registered submitter?)", zap.Stringer("nodeID", nodeID))
+		return
+	}
+	errs, err := unpackDirectResponse(response)
+	if err != nil {
+		d.vm.Logger().Debug("received invalid direct submission response", zap.Stringer("nodeID", nodeID), zap.Error(err))
+		return
+	}
+	var rejected int
+	for _, err := range errs {
+		if len(err) > 0 {
+			rejected++
+		}
+	}
+	if rejected > 0 {
+		d.vm.Logger().Debug("txs rejected by proposer",
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("rejected", rejected),
+			zap.Strings("errors", errs),
+		)
+	}
+}
+
+// packDirectResponse encodes an error string for each transaction (empty if
+// it was added to the mempool).
+func packDirectResponse(errs []error) ([]byte, error) {
+	msgs := make([]string, len(errs))
+	size := consts.IntLen
+	for i, err := range errs {
+		if err != nil {
+			msgs[i] = err.Error()
+		}
+		size += codec.StringLen(msgs[i])
+	}
+	p := codec.NewWriter(size, consts.NetworkSizeLimit)
+	p.PackInt(len(msgs))
+	for _, msg := range msgs {
+		p.PackString(msg)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func unpackDirectResponse(response []byte) ([]string, error) {
+	p := codec.NewReader(response, consts.NetworkSizeLimit)
+	count := p.UnpackInt(false)
+	errs := make([]string, 0, min(count, initialDirectCapacity))
+	for i := 0; i < count; i++ {
+		errs = append(errs, p.UnpackString(false))
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return errs, p.Err()
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+type testResponseSender struct {
+	common.AppSender
+
+	responses map[uint32][]byte
+}
+
+func (s *testResponseSender) SendAppResponse(_ context.Context, _ ids.NodeID, requestID uint32, msg []byte) error {
+	s.responses[requestID] = msg
+	return nil
+}
+
+func TestQuota(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	q := &quota{rate: 4, tokens: 4, last: now}
+	require.Equal(4, q.take(now, 6))
+	require.Zero(q.take(now, 1))
+
+	// Tokens refill at [rate] and never exceed a burst of [rate]
+	require.Equal(2, q.take(now.Add(500*time.Millisecond), 3))
+	require.Equal(4, q.take(now.Add(time.Hour), 5))
+}
+
+func TestDirectSubmission(t *testing.T) {
+	require := require.New(t)
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{
+		snowCtx:        &snow.Context{Log: logging.NoLog{}},
+		tracer:         trace.Noop,
+		metrics:        m,
+		ready:          make(chan struct{}),
+		actionRegistry: actionRegistry,
+		authRegistry:   authRegistry,
+	}
+
+	submitter := ids.GenerateTestNodeID()
+	_, err = NewDirectSubmission(vm, DirectSubmissionConfig{Submitters: []DirectSubmitter{{NodeID: submitter}}}, nil)
+	require.ErrorIs(err, ErrInvalidSubmitterQuota)
+	_, err = NewDirectSubmission(vm, DirectSubmissionConfig{Submitters: []DirectSubmitter{
+		{NodeID: submitter, TxsPerSecond: 1},
+		{NodeID: submitter, TxsPerSecond: 2},
+	}}, nil)
+	require.ErrorIs(err, ErrDuplicateSubmitter)
+
+	sender := &testResponseSender{responses: map[uint32][]byte{}}
+	d, err := NewDirectSubmission(vm, DirectSubmissionConfig{Submitters: []DirectSubmitter{{NodeID: submitter, TxsPerSecond: 1}}}, sender)
+	require.NoError(err)
+
+	txs := []*chain.Transaction{}
+	for i := uint64(0); i < 2; i++ {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: 1_000, ChainID: ids.GenerateTestID(), MaxFee: 100},
+			[]chain.Action{&testAction{value: i}},
+		).Sign(&testAuthFactory{actor: codec.CreateAddress(0, ids.GenerateTestID())}, actionRegistry, authRegistry)
+		require.NoError(err)
+		txs = append(txs, tx)
+	}
+	request, err := chain.MarshalTxs(txs)
+	require.NoError(err)
+
+	// Nodes that are not registered are not allowed to submit
+	ctx := context.Background()
+	require.NoError(d.AppRequest(ctx, ids.GenerateTestNodeID(), 0, request))
+	require.Empty(sender.responses[0])
+
+	// Transactions beyond the quota are rejected (the VM is not ready, so the
+	// others are not added to the mempool either)
+	require.NoError(d.AppRequest(ctx, submitter, 1, request))
+	errs, err := unpackDirectResponse(sender.responses[1])
+	require.NoError(err)
+	require.Equal([]string{ErrNotReady.Error(), ErrQuotaExceeded.Error()}, errs)
+}
@@ -8,11 +8,37 @@ import (
 )
 
 var (
-	ErrNotAdded            = errors.New("not added")
-	ErrDropped             = errors.New("dropped")
-	ErrNotReady            = errors.New("not ready")
-	ErrStateMissing        = errors.New("state missing")
-	ErrStateSyncing        = errors.New("state still syncing")
-	ErrUnexpectedStateRoot = errors.New("unexpected state root")
-	ErrTooManyProcessing   = errors.New("too many processing")
+	ErrNotAdded                = errors.New("not added")
+	ErrDropped                 = errors.New("dropped")
+	ErrNotReady                = errors.New("not ready")
+	ErrStateMissing            = errors.New("state missing")
+	ErrStateSyncing            = errors.New("state still syncing")
+	ErrUnexpectedStateRoot     = errors.New("unexpected state root")
+	ErrTooManyProcessing       = errors.New("too many processing")
+	ErrHeightNotAccepted       = errors.New("height not accepted")
+	ErrIndexKeyTooLarge        = errors.New("index key too large")
+	ErrIndexerRequired         = errors.New("indexer required")
+	ErrMissingAuthToken        = errors.New("missing auth token")
+	ErrInvalidWebhookURL       = errors.New("invalid webhook url")
+	ErrNoWebhookAddresses      = errors.New("no webhook addresses")
+	ErrTooManyAddresses        = errors.New("too many addresses")
+	ErrWebhookMissing          = errors.New("webhook missing")
+	ErrWebhookStatus           = errors.New("unexpected webhook status")
+	ErrInvalidBackoff          = errors.New("invalid backoff")
+	ErrNotValidator            = errors.New("not a validator")
+	ErrInvalidChunkExpiry      = errors.New("invalid chunk expiry")
+	ErrTooManyChunks           = errors.New("too many pending chunks")
+	ErrTxsNotAvailable         = errors.New("txs not available")
+	ErrInvalidSubmitterQuota   = errors.New("submitter quota must be positive")
+	ErrDuplicateSubmitter      = errors.New("duplicate submitter")
+	ErrQuotaExceeded           = errors.New("submitter quota exceeded")
+	ErrTxNotAdmitted           = errors.New("tx not admitted")
+	ErrProcessorsSealed        = errors.New("accepted processors can't be registered after initialization")
+	ErrDuplicateProcessor      = errors.New("duplicate accepted processor")
+	ErrProcessorPanicked       = errors.New("accepted processor panicked")
+	ErrInvalidCheckpointKey    = errors.New("invalid checkpoint key")
+	ErrNoTrustedPublishers     = errors.New("no trusted checkpoint publishers")
+	ErrStateNotEmpty           = errors.New("state is not empty")
+	ErrCheckpointChunks        = errors.New("checkpoints of blocks with chunks are not supported")
+	ErrInvalidCheckpointBlocks = errors.New("invalid checkpoint blocks")
 )
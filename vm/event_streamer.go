@@ -0,0 +1,185 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/events"
+)
+
+// EventStreamer publishes the events of accepted blocks to an [events.Sink].
+//
+// The events of each block are stored in the vmDB when the block is accepted
+// and are only deleted (advancing the cursor) once the sink acknowledged them,
+// so events that were not published before a restart or a broker outage are
+// published later in the order their blocks were accepted.
+type EventStreamer struct {
+	vm     *VM
+	config events.Config
+	sink   events.Sink
+
+	notify chan struct{}
+	done   chan struct{}
+}
+
+func NewEventStreamer(vm *VM, config events.Config, sink events.Sink) *EventStreamer {
+	return &EventStreamer{
+		vm:     vm,
+		config: config,
+		sink:   sink,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// Accepted stores the events of [blk] until they are published.
+func (e *EventStreamer) Accepted(blk *chain.StatelessBlock) error {
+	msgs, err := events.NewMessages(blk, e.config.TopicPrefix)
+	if err != nil {
+		return err
+	}
+	if e.config.StateDiffs {
+		msg, err := events.NewStateDiffMessage(blk, e.config.TopicPrefix)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := e.store(blk.Hght, msgs); err != nil {
+		return err
+	}
+	select {
+	case e.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (e *EventStreamer) store(height uint64, msgs []*events.Message) error {
+	size := consts.IntLen
+	for _, msg := range msgs {
+		size += codec.StringLen(msg.Topic) + codec.BytesLen(msg.Key) + codec.BytesLen(msg.Value)
+	}
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackInt(len(msgs))
+	for _, msg := range msgs {
+		p.PackString(msg.Topic)
+		p.PackBytes(msg.Key)
+		p.PackBytes(msg.Value)
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return e.vm.vmDB.Put(PrefixEventKey(height), p.Bytes())
+}
+
+// Cursor returns the height of the last block whose events were published.
+func (e *EventStreamer) Cursor() (uint64, bool, error) {
+	v, err := e.vm.vmDB.Get(eventCursor)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return binary.BigEndian.Uint64(v), true, nil
+}
+
+func (e *EventStreamer) Run() {
+	defer close(e.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-e.vm.stop
+		cancel()
+	}()
+
+	if height, ok, err := e.Cursor(); err == nil && ok {
+		e.vm.metrics.eventsPublishedHeight.Set(float64(height))
+		e.vm.Logger().Info("resuming event publishing", zap.Uint64("cursor", height))
+	}
+	for {
+		err := e.publish(ctx)
+		if err == nil {
+			select {
+			case <-e.notify:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		e.vm.Logger().Warn("unable to publish events", zap.Error(err))
+		select {
+		case <-time.After(e.config.RetryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// publish sends stored events to the sink (oldest first) until none are left.
+func (e *EventStreamer) publish(ctx context.Context) error {
+	for {
+		iter := e.vm.vmDB.NewIteratorWithPrefix([]byte{eventPrefix})
+		ok := iter.Next()
+		k, v := slices.Clone(iter.Key()), slices.Clone(iter.Value())
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		p := codec.NewReader(v, consts.MaxInt)
+		msgs := make([]*events.Message, p.UnpackInt(false))
+		for i := range msgs {
+			msg := &events.Message{Topic: p.UnpackString(true)}
+			p.UnpackBytes(-1, false, &msg.Key)
+			p.UnpackBytes(-1, false, &msg.Value)
+			msgs[i] = msg
+		}
+		if err := p.Err(); err != nil {
+			return err
+		}
+		if err := e.sink.Publish(ctx, msgs); err != nil {
+			return err
+		}
+
+		batch := e.vm.vmDB.NewBatch()
+		if err := batch.Delete(k); err != nil {
+			return err
+		}
+		if err := batch.Put(eventCursor, k[1:]); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		e.vm.metrics.eventsPublished.Add(float64(len(msgs)))
+		e.vm.metrics.eventsPublishedHeight.Set(float64(binary.BigEndian.Uint64(k[1:])))
+	}
+}
+
+// Done waits for [Run] to exit and closes the sink.
+func (e *EventStreamer) Done() error {
+	<-e.done
+	return e.sink.Close()
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/events"
+)
+
+var errBrokerDown = errors.New("broker down")
+
+type testSink struct {
+	fail      bool
+	published [][]*events.Message
+}
+
+func (s *testSink) Publish(_ context.Context, msgs []*events.Message) error {
+	if s.fail {
+		return errBrokerDown
+	}
+	s.published = append(s.published, msgs)
+	return nil
+}
+
+func (*testSink) Close() error { return nil }
+
+func TestEventStreamerResume(t *testing.T) {
+	require := require.New(t)
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{vmDB: memdb.New(), metrics: m}
+	sink := &testSink{fail: true}
+	e := NewEventStreamer(vm, events.NewDefaultConfig(), sink)
+
+	blk1 := []*events.Message{
+		{Topic: "hypersdk.blocks", Key: []byte{1}, Value: []byte("block 1")},
+		{Topic: "hypersdk.txs", Key: []byte{2}, Value: []byte("tx 1")},
+	}
+	blk2 := []*events.Message{
+		{Topic: "hypersdk.blocks", Key: []byte{3}, Value: []byte("block 2")},
+	}
+	require.NoError(e.store(2, blk2))
+	require.NoError(e.store(1, blk1))
+
+	// Events are kept until the sink acknowledges them
+	ctx := context.Background()
+	require.ErrorIs(e.publish(ctx), errBrokerDown)
+	_, ok, err := e.Cursor()
+	require.NoError(err)
+	require.False(ok)
+
+	sink.fail = false
+	require.NoError(e.publish(ctx))
+	require.Equal([][]*events.Message{blk1, blk2}, sink.published)
+	cursor, ok, err := e.Cursor()
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(uint64(2), cursor)
+
+	// Published events are not sent again
+	sink.published = nil
+	require.NoError(e.publish(ctx))
+	require.Empty(sink.published)
+}
@@ -0,0 +1,167 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+type ExternalBuilderConfig struct {
+	Enabled bool `json:"enabled"`
+	// AuthToken must be provided as a bearer token to connect to
+	// [rpc.BuilderEndpoint]
+	AuthToken string `json:"authToken"`
+	// MaxProposalTxs is the maximum number of transactions in a proposal
+	MaxProposalTxs int `json:"maxProposalTxs" min:"1"`
+}
+
+// ExternalBuilder mirrors the mempool of the node to builders running in
+// other processes (over [rpc.BuilderEndpoint]) and orders the transactions
+// of the blocks built by the node as they propose.
+//
+// Proposals only change which transactions are considered first when
+// building a block: each proposed transaction is taken from the mempool and
+// verified like any other, so a builder can't get invalid transactions
+// into a block.
+type ExternalBuilder struct {
+	vm     *VM
+	config ExternalBuilderConfig
+	s      *pubsub.Server
+
+	l        sync.Mutex
+	proposal *rpc.Proposal
+}
+
+func NewExternalBuilder(vm *VM, config ExternalBuilderConfig) (*ExternalBuilder, error) {
+	if len(config.AuthToken) == 0 {
+		return nil, fmt.Errorf("%w: external builders can not connect", ErrMissingAuthToken)
+	}
+	b := &ExternalBuilder{
+		vm:     vm,
+		config: config,
+	}
+	b.s = pubsub.New(vm.snowCtx.Log, pubsub.NewDefaultServerConfig(), b.callback)
+	return b, nil
+}
+
+func (b *ExternalBuilder) Authorized(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(b.config.AuthToken)) == 1
+}
+
+// ServeHTTP upgrades authorized requests to a builder connection.
+func (b *ExternalBuilder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !b.Authorized(token) {
+		http.Error(w, rpc.ErrUnauthorized.Error(), http.StatusUnauthorized)
+		return
+	}
+	b.s.ServeHTTP(w, r)
+}
+
+// Publish sends the event [kind] of each of [txs] to the connected
+// builders.
+func (b *ExternalBuilder) Publish(kind byte, txs []*chain.Transaction) {
+	if len(txs) == 0 || b.s.Connections().Len() == 0 {
+		return
+	}
+	for _, tx := range txs {
+		msg, err := rpc.PackPendingTxMessage(kind, tx)
+		if err != nil {
+			b.vm.snowCtx.Log.Warn("unable to pack pending tx", zap.Error(err))
+			continue
+		}
+		b.s.Publish(append([]byte{rpc.BuilderPendingMode}, msg...), b.s.Connections())
+	}
+}
+
+func (b *ExternalBuilder) callback(msg []byte, c *pubsub.Connection) {
+	if len(msg) == 0 {
+		return
+	}
+	ctx := context.Background()
+	switch msg[0] {
+	case rpc.BuilderSnapshotMode:
+		for _, tx := range b.vm.mempool.Items(ctx) {
+			pmsg, err := rpc.PackPendingTxMessage(rpc.PendingAdmitted, tx)
+			if err != nil {
+				b.vm.snowCtx.Log.Warn("unable to pack pending tx", zap.Error(err))
+				continue
+			}
+			if !c.Send(append([]byte{rpc.BuilderPendingMode}, pmsg...)) {
+				b.vm.snowCtx.Log.Debug("unable to send mempool snapshot to builder")
+				return
+			}
+		}
+	case rpc.BuilderProposalMode:
+		result := b.propose(ctx, msg[1:])
+		rmsg, err := rpc.PackProposalResult(result)
+		if err != nil {
+			b.vm.snowCtx.Log.Warn("unable to pack proposal result", zap.Error(err))
+			return
+		}
+		c.Send(append([]byte{rpc.BuilderResultMode}, rmsg...))
+	default:
+		b.vm.snowCtx.Log.Debug("unexpected builder message mode", zap.Uint8("mode", msg[0]))
+	}
+}
+
+// propose stores the proposal packed in [msg] (replacing the previous one) if
+// it is valid.
+func (b *ExternalBuilder) propose(ctx context.Context, msg []byte) *rpc.ProposalResult {
+	proposal, err := rpc.UnpackProposal(msg, b.config.MaxProposalTxs)
+	if err != nil {
+		return &rpc.ProposalResult{Error: err.Error()}
+	}
+	result := &rpc.ProposalResult{Parent: proposal.Parent}
+	if _, err := b.vm.GetStatelessBlock(ctx, proposal.Parent); err != nil {
+		result.Error = fmt.Errorf("%w: unknown parent %s", rpc.ErrInvalidProposal, proposal.Parent).Error()
+		return result
+	}
+	for _, txID := range proposal.TxIDs {
+		if b.vm.mempool.Has(ctx, txID) {
+			result.Known++
+		}
+	}
+	b.vm.metrics.builderProposals.Inc()
+
+	b.l.Lock()
+	b.proposal = proposal
+	b.l.Unlock()
+	return result
+}
+
+// Prioritize moves the transactions of the proposal for the child of
+// [parent] (if there is one) to the front of the mempool before a block is
+// built on [parent]. A proposal is only used once.
+func (b *ExternalBuilder) Prioritize(ctx context.Context, parent ids.ID) {
+	b.l.Lock()
+	proposal := b.proposal
+	if proposal == nil || proposal.Parent != parent {
+		b.l.Unlock()
+		return
+	}
+	b.proposal = nil
+	b.l.Unlock()
+
+	moved := b.vm.mempool.Prioritize(ctx, proposal.TxIDs)
+	b.vm.metrics.builderProposalsUsed.Inc()
+	b.vm.metrics.builderProposalTxs.Add(float64(moved))
+	b.vm.snowCtx.Log.Debug("using external proposal",
+		zap.Stringer("parent", parent),
+		zap.Int("proposed", len(proposal.TxIDs)),
+		zap.Int("prioritized", moved),
+	)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/mempool"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/trace"
+)
+
+type testBuilderParser struct {
+	actionRegistry chain.ActionRegistry
+	authRegistry   chain.AuthRegistry
+}
+
+func (*testBuilderParser) Rules(int64) chain.Rules {
+	return nil
+}
+
+func (p *testBuilderParser) Registry() (chain.ActionRegistry, chain.AuthRegistry) {
+	return p.actionRegistry, p.authRegistry
+}
+
+func TestExternalBuilder(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+	parser := &testBuilderParser{actionRegistry: actionRegistry, authRegistry: authRegistry}
+	sponsor := codec.CreateAddress(0, ids.GenerateTestID())
+	newTx := func(value uint64) *chain.Transaction {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: 1_000, ChainID: ids.GenerateTestID(), MaxFee: 100},
+			[]chain.Action{&testAction{value: value}},
+		).Sign(&testAuthFactory{actor: sponsor}, actionRegistry, authRegistry)
+		require.NoError(err)
+		return tx
+	}
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	parent := ids.GenerateTestID()
+	vm := &VM{
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		metrics: m,
+		tracer:  tracer,
+		vmDB:    memdb.New(),
+		mempool: mempool.New[*chain.Transaction](tracer, 100, 32),
+
+		acceptedBlocksByID: newLRUCache(acceptedBlocksCache, CacheLimit{Entries: 1}, func(ids.ID, *chain.StatelessBlock) int { return 1 }, m),
+		verifiedBlocks:     map[ids.ID]*chain.StatelessBlock{parent: {StatefulBlock: &chain.StatefulBlock{Hght: 1}}},
+		genesisBlk:         &chain.StatelessBlock{},
+		lastAccepted:       &chain.StatelessBlock{},
+	}
+	_, err = NewExternalBuilder(vm, ExternalBuilderConfig{Enabled: true})
+	require.ErrorIs(err, ErrMissingAuthToken)
+	b, err := NewExternalBuilder(vm, ExternalBuilderConfig{Enabled: true, AuthToken: "token", MaxProposalTxs: 3})
+	require.NoError(err)
+	server := httptest.NewServer(b)
+	defer server.Close()
+
+	_, err = rpc.NewBuilderClient(server.URL, "wrong", time.Second, 16, pubsub.MaxWriteMessageSize)
+	require.ErrorIs(err, rpc.ErrUnauthorized)
+	cli, err := rpc.NewBuilderClient(server.URL, "token", time.Second, 16, pubsub.MaxWriteMessageSize)
+	require.NoError(err)
+	defer cli.Close()
+
+	// The builder receives the mempool and its changes
+	tx0, tx1, tx2 := newTx(0), newTx(1), newTx(2)
+	vm.mempool.Add(ctx, []*chain.Transaction{tx0, tx1, tx2})
+	require.NoError(cli.RequestSnapshot())
+	for _, tx := range []*chain.Transaction{tx0, tx1, tx2} {
+		kind, received, err := cli.ListenPending(ctx, parser)
+		require.NoError(err)
+		require.Equal(rpc.PendingAdmitted, kind)
+		require.Equal(tx.ID(), received.ID())
+	}
+	b.Publish(rpc.PendingIncluded, []*chain.Transaction{tx0})
+	kind, received, err := cli.ListenPending(ctx, parser)
+	require.NoError(err)
+	require.Equal(rpc.PendingIncluded, kind)
+	require.Equal(tx0.ID(), received.ID())
+
+	// Invalid proposals are rejected
+	require.NoError(cli.Propose(parent, []ids.ID{tx2.ID(), tx1.ID(), tx2.ID()}))
+	result, err := cli.ListenResult(ctx)
+	require.NoError(err)
+	require.Contains(result.Error, rpc.ErrInvalidProposal.Error())
+	require.NoError(cli.Propose(ids.GenerateTestID(), []ids.ID{tx2.ID()}))
+	result, err = cli.ListenResult(ctx)
+	require.NoError(err)
+	require.Contains(result.Error, rpc.ErrInvalidProposal.Error())
+
+	// Unknown transactions are ignored
+	require.NoError(cli.Propose(parent, []ids.ID{tx2.ID(), ids.GenerateTestID(), tx1.ID()}))
+	result, err = cli.ListenResult(ctx)
+	require.NoError(err)
+	require.Empty(result.Error)
+	require.Equal(parent, result.Parent)
+	require.Equal(2, result.Known)
+	require.Equal(float64(1), testutil.ToFloat64(m.builderProposals))
+
+	// Proposals are only used for blocks built on their parent (once)
+	b.Prioritize(ctx, ids.GenerateTestID())
+	require.Equal([]*chain.Transaction{tx0, tx1, tx2}, vm.mempool.Items(ctx))
+	b.Prioritize(ctx, parent)
+	require.Equal([]*chain.Transaction{tx2, tx1, tx0}, vm.mempool.Items(ctx))
+	require.Equal(float64(1), testutil.ToFloat64(m.builderProposalsUsed))
+	require.Equal(float64(2), testutil.ToFloat64(m.builderProposalTxs))
+	vm.mempool.Prioritize(ctx, []ids.ID{tx0.ID()})
+	b.Prioritize(ctx, parent)
+	require.Equal([]*chain.Transaction{tx0, tx2, tx1}, vm.mempool.Items(ctx))
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ rpc.FeeHistory = (*FeeHistory)(nil)
+
+type blockFees struct {
+	height        uint64
+	timestamp     int64
+	unitPrices    fees.Dimensions
+	unitsConsumed fees.Dimensions
+	utilization   [fees.FeeDimensions]float64
+	fees          []uint64 // sorted
+}
+
+// FeeHistory keeps the fees of the last [size] blocks accepted by this node in
+// memory (so it starts empty whenever the node restarts).
+type FeeHistory struct {
+	size int
+
+	l      sync.RWMutex
+	blocks []*blockFees // oldest first
+}
+
+func NewFeeHistory(size int) *FeeHistory {
+	return &FeeHistory{
+		size:   size,
+		blocks: make([]*blockFees, 0, size),
+	}
+}
+
+// Accepted records the fees of [b], which must have been processed.
+func (h *FeeHistory) Accepted(b *chain.StatelessBlock, maxUnits fees.Dimensions) {
+	h.record(b.Hght, b.Tmstmp, b.FeeManager(), maxUnits, b.Results())
+}
+
+func (h *FeeHistory) record(
+	height uint64,
+	timestamp int64,
+	feeManager *fees.Manager,
+	maxUnits fees.Dimensions,
+	results []*chain.Result,
+) {
+	entry := &blockFees{
+		height:        height,
+		timestamp:     timestamp,
+		unitPrices:    feeManager.UnitPrices(),
+		unitsConsumed: feeManager.UnitsConsumed(),
+		fees:          make([]uint64, len(results)),
+	}
+	for i := fees.Dimension(0); i < fees.FeeDimensions; i++ {
+		if maxUnits[i] > 0 {
+			entry.utilization[i] = float64(entry.unitsConsumed[i]) / float64(maxUnits[i])
+		}
+	}
+	for i, result := range results {
+		entry.fees[i] = result.Fee
+	}
+	slices.Sort(entry.fees)
+
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	if len(h.blocks) == h.size {
+		copy(h.blocks, h.blocks[1:])
+		h.blocks = h.blocks[:h.size-1]
+	}
+	h.blocks = append(h.blocks, entry)
+}
+
+func (h *FeeHistory) Blocks(count int, percentiles []float64) []*rpc.BlockFees {
+	h.l.RLock()
+	defer h.l.RUnlock()
+
+	start := max(len(h.blocks)-count, 0)
+	blocks := make([]*rpc.BlockFees, 0, len(h.blocks)-start)
+	for _, entry := range h.blocks[start:] {
+		feePercentiles := make([]uint64, len(percentiles))
+		for i, p := range percentiles {
+			feePercentiles[i] = percentile(entry.fees, p)
+		}
+		blocks = append(blocks, &rpc.BlockFees{
+			Height:         entry.height,
+			Timestamp:      entry.timestamp,
+			UnitPrices:     entry.unitPrices,
+			UnitsConsumed:  entry.unitsConsumed,
+			Utilization:    entry.utilization,
+			FeePercentiles: feePercentiles,
+		})
+	}
+	return blocks
+}
+
+// percentile returns the nearest-rank [p]th percentile of [sorted].
+func percentile(sorted []uint64, p float64) uint64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
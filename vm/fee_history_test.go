@@ -0,0 +1,52 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+)
+
+func TestFeeHistory(t *testing.T) {
+	require := require.New(t)
+
+	h := NewFeeHistory(2)
+	maxUnits := fees.Dimensions{100, 100, 100, 0, 100}
+	for height := uint64(1); height <= 3; height++ {
+		feeManager := fees.NewManager(nil)
+		feeManager.SetUnitPrice(fees.Bandwidth, height)
+		feeManager.SetLastConsumed(fees.Compute, 25)
+		var results []*chain.Result
+		for fee := uint64(1); fee <= 10*height; fee++ {
+			// Fees are sorted before computing percentiles
+			results = append(results, &chain.Result{Fee: 10*height + 1 - fee})
+		}
+		h.record(height, int64(height), feeManager, maxUnits, results)
+	}
+
+	// Only the last [size] blocks are kept
+	blocks := h.Blocks(5, []float64{0, 50, 90, 100})
+	require.Len(blocks, 2)
+	require.Equal(uint64(2), blocks[0].Height)
+	require.Equal(uint64(3), blocks[1].Height)
+	require.Equal(uint64(3), blocks[1].UnitPrices[fees.Bandwidth])
+	require.Equal(fees.Dimensions{0, 25, 0, 0, 0}, blocks[1].UnitsConsumed)
+	require.Equal([fees.FeeDimensions]float64{0, 0.25, 0, 0, 0}, blocks[1].Utilization)
+	require.Equal([]uint64{1, 10, 18, 20}, blocks[0].FeePercentiles)
+	require.Equal([]uint64{1, 15, 27, 30}, blocks[1].FeePercentiles)
+
+	blocks = h.Blocks(1, nil)
+	require.Len(blocks, 1)
+	require.Equal(uint64(3), blocks[0].Height)
+	require.Empty(blocks[0].FeePercentiles)
+
+	// Blocks without transactions report fees of 0
+	h.record(4, 4, fees.NewManager(nil), maxUnits, nil)
+	blocks = h.Blocks(1, []float64{50})
+	require.Equal([]uint64{0}, blocks[0].FeePercentiles)
+}
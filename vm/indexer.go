@@ -0,0 +1,264 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+const (
+	indexBlockPrefix   = 0x0 // height -> block message (see [rpc.PackBlockMessage])
+	indexTxPrefix      = 0x1 // txID -> height|timestamp|txLen|tx|result
+	indexAddressPrefix = 0x2 // address|height|txIndex -> txID
+	indexActionPrefix  = 0x3 // actionType|height|txIndex -> txID
+	indexKeyPrefix     = 0x4 // keyLen|key|height|txIndex -> txID
+	indexBlockIDPrefix = 0x5 // blockID -> height
+
+	indexPositionLen = consts.Uint64Len + consts.Uint32Len
+)
+
+var _ rpc.Indexer = (*Indexer)(nil)
+
+// IndexController is an optional extension of [Controller] that adds
+// hypervm-specific entries to the [Indexer].
+type IndexController interface {
+	// TxAddresses returns the addresses referenced by the actions of [tx]
+	// (like transfer recipients). Transactions are always indexed by their
+	// actor and sponsor.
+	TxAddresses(tx *chain.Transaction) []codec.Address
+
+	// TxIndexKeys returns arbitrary keys that [tx] (which produced [result])
+	// should be indexed by. Keys defined by different hypervm features should
+	// be namespaced to avoid collisions.
+	TxIndexKeys(tx *chain.Transaction, result *chain.Result) [][]byte
+}
+
+// Indexer stores every processed block and its transactions (pruned blocks
+// included) and indexes transactions by address, action type, and any keys
+// defined by the [IndexController].
+//
+// Blocks that are accepted while the node is state syncing are never
+// processed, so they are not indexed.
+type Indexer struct {
+	db database.Database
+	c  IndexController
+}
+
+// NewIndexer creates an [Indexer] that stores its entries in [db]. [c] is
+// used to index hypervm-specific entries if it implements [IndexController].
+func NewIndexer(db database.Database, c Controller) *Indexer {
+	ic, _ := c.(IndexController)
+	return &Indexer{db: db, c: ic}
+}
+
+func indexPosition(k []byte, height uint64, txIndex int) []byte {
+	k = binary.BigEndian.AppendUint64(k, height)
+	return binary.BigEndian.AppendUint32(k, uint32(txIndex))
+}
+
+func indexBlockKey(height uint64) []byte {
+	k := make([]byte, 1, 1+consts.Uint64Len)
+	k[0] = indexBlockPrefix
+	return binary.BigEndian.AppendUint64(k, height)
+}
+
+func indexBlockIDKey(blkID ids.ID) []byte {
+	k := make([]byte, 1+ids.IDLen)
+	k[0] = indexBlockIDPrefix
+	copy(k[1:], blkID[:])
+	return k
+}
+
+func indexTxKey(txID ids.ID) []byte {
+	k := make([]byte, 1+ids.IDLen)
+	k[0] = indexTxPrefix
+	copy(k[1:], txID[:])
+	return k
+}
+
+func indexAddressPrefixKey(addr codec.Address) []byte {
+	k := make([]byte, 1+codec.AddressLen, 1+codec.AddressLen+indexPositionLen)
+	k[0] = indexAddressPrefix
+	copy(k[1:], addr[:])
+	return k
+}
+
+func indexActionPrefixKey(typeID uint8) []byte {
+	return append(make([]byte, 0, 1+consts.Uint8Len+indexPositionLen), indexActionPrefix, typeID)
+}
+
+func indexKeyPrefixKey(key []byte) []byte {
+	k := make([]byte, 1, 1+consts.Uint16Len+len(key)+indexPositionLen)
+	k[0] = indexKeyPrefix
+	k = binary.BigEndian.AppendUint16(k, uint16(len(key)))
+	return append(k, key...)
+}
+
+// Accept indexes [blk], which must have been processed.
+func (i *Indexer) Accept(blk *chain.StatelessBlock) error {
+	msg, err := rpc.PackBlockMessage(blk)
+	if err != nil {
+		return err
+	}
+	batch := i.db.NewBatch()
+	if err := batch.Put(indexBlockKey(blk.Hght), msg); err != nil {
+		return err
+	}
+	if err := batch.Put(indexBlockIDKey(blk.ID()), binary.BigEndian.AppendUint64(nil, blk.Hght)); err != nil {
+		return err
+	}
+	results := blk.Results()
+	for j, tx := range blk.Txs {
+		if err := i.indexTx(batch, blk.Hght, blk.Tmstmp, j, tx, results[j]); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func (i *Indexer) indexTx(
+	batch database.Batch,
+	height uint64,
+	timestamp int64,
+	txIndex int,
+	tx *chain.Transaction,
+	result *chain.Result,
+) error {
+	txID := tx.ID()
+	txBytes := tx.Bytes()
+	p := codec.NewWriter(result.Size(), consts.MaxInt)
+	if err := result.Marshal(p); err != nil {
+		return err
+	}
+	resultBytes := p.Bytes()
+	v := make([]byte, 0, consts.Uint64Len+consts.Int64Len+consts.Uint32Len+len(txBytes)+len(resultBytes))
+	v = binary.BigEndian.AppendUint64(v, height)
+	v = binary.BigEndian.AppendUint64(v, uint64(timestamp))
+	v = binary.BigEndian.AppendUint32(v, uint32(len(txBytes)))
+	v = append(v, txBytes...)
+	v = append(v, resultBytes...)
+	if err := batch.Put(indexTxKey(txID), v); err != nil {
+		return err
+	}
+
+	// Index each address and action type only once per transaction
+	addrs := set.Of(tx.Auth.Actor(), tx.Sponsor())
+	if i.c != nil {
+		addrs.Add(i.c.TxAddresses(tx)...)
+	}
+	for addr := range addrs {
+		if err := batch.Put(indexPosition(indexAddressPrefixKey(addr), height, txIndex), txID[:]); err != nil {
+			return err
+		}
+	}
+	actionTypes := set.NewSet[uint8](len(tx.Actions))
+	for _, action := range tx.Actions {
+		actionTypes.Add(action.GetTypeID())
+	}
+	for typeID := range actionTypes {
+		if err := batch.Put(indexPosition(indexActionPrefixKey(typeID), height, txIndex), txID[:]); err != nil {
+			return err
+		}
+	}
+	if i.c == nil {
+		return nil
+	}
+	for _, key := range i.c.TxIndexKeys(tx, result) {
+		if len(key) > int(consts.MaxUint16) {
+			return ErrIndexKeyTooLarge
+		}
+		if err := batch.Put(indexPosition(indexKeyPrefixKey(key), height, txIndex), txID[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBlock returns the block at [height] packed with its results (see
+// [rpc.UnpackBlockMessage]).
+func (i *Indexer) GetBlock(height uint64) ([]byte, error) {
+	return i.db.Get(indexBlockKey(height))
+}
+
+// GetBlockHeight returns the height of the indexed block [blkID].
+func (i *Indexer) GetBlockHeight(blkID ids.ID) (uint64, error) {
+	v, err := i.db.Get(indexBlockIDKey(blkID))
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+// NextBlock returns the first indexed block at or above [height] (see
+// [GetBlock]), if any.
+func (i *Indexer) NextBlock(height uint64) (uint64, []byte, bool, error) {
+	iter := i.db.NewIteratorWithStartAndPrefix(indexBlockKey(height), []byte{indexBlockPrefix})
+	defer iter.Release()
+
+	if !iter.Next() {
+		return 0, nil, false, iter.Error()
+	}
+	return binary.BigEndian.Uint64(iter.Key()[1:]), slices.Clone(iter.Value()), true, nil
+}
+
+// GetTx returns the height and timestamp of the block that included [txID],
+// the transaction, and its result.
+func (i *Indexer) GetTx(txID ids.ID) (uint64, int64, []byte, []byte, error) {
+	v, err := i.db.Get(indexTxKey(txID))
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+	height := binary.BigEndian.Uint64(v)
+	timestamp := int64(binary.BigEndian.Uint64(v[consts.Uint64Len:]))
+	v = v[consts.Uint64Len+consts.Int64Len:]
+	txLen := binary.BigEndian.Uint32(v)
+	v = v[consts.Uint32Len:]
+	return height, timestamp, v[:txLen], v[txLen:], nil
+}
+
+// GetTxsByAddress returns up to [limit] IDs of the transactions that reference
+// [addr] (oldest first), starting at [cursor]. If there are more transactions,
+// it also returns the cursor of the next one.
+func (i *Indexer) GetTxsByAddress(addr codec.Address, cursor []byte, limit int) ([]ids.ID, []byte, error) {
+	return i.iterate(indexAddressPrefixKey(addr), cursor, limit)
+}
+
+// GetTxsByActionType is like [GetTxsByAddress] for transactions that contain
+// an action of [typeID].
+func (i *Indexer) GetTxsByActionType(typeID uint8, cursor []byte, limit int) ([]ids.ID, []byte, error) {
+	return i.iterate(indexActionPrefixKey(typeID), cursor, limit)
+}
+
+// GetTxsByKey is like [GetTxsByAddress] for transactions indexed by [key] (see
+// [IndexController]).
+func (i *Indexer) GetTxsByKey(key []byte, cursor []byte, limit int) ([]ids.ID, []byte, error) {
+	if len(key) > int(consts.MaxUint16) {
+		return nil, nil, ErrIndexKeyTooLarge
+	}
+	return i.iterate(indexKeyPrefixKey(key), cursor, limit)
+}
+
+func (i *Indexer) iterate(prefix []byte, cursor []byte, limit int) ([]ids.ID, []byte, error) {
+	iter := i.db.NewIteratorWithStartAndPrefix(append(prefix, cursor...), prefix)
+	defer iter.Release()
+
+	txIDs := []ids.ID{}
+	for iter.Next() {
+		if len(txIDs) == limit {
+			return txIDs, slices.Clone(iter.Key()[len(prefix):]), iter.Error()
+		}
+		txIDs = append(txIDs, ids.ID(iter.Value()))
+	}
+	return txIDs, nil, iter.Error()
+}
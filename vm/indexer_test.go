@@ -0,0 +1,78 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+func TestIndexerPagination(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	indexer := &Indexer{db: db}
+	key := []byte("key")
+	txIDs := []ids.ID{}
+	for i := 0; i < 5; i++ {
+		txID := ids.GenerateTestID()
+		txIDs = append(txIDs, txID)
+		require.NoError(db.Put(indexPosition(indexKeyPrefixKey(key), uint64(i/2), i%2), txID[:]))
+	}
+
+	// Keys sharing a prefix with [key] are not returned
+	require.NoError(db.Put(indexPosition(indexKeyPrefixKey([]byte("keys")), 0, 0), ids.Empty[:]))
+
+	page, next, err := indexer.GetTxsByKey(key, nil, 2)
+	require.NoError(err)
+	require.Equal(txIDs[:2], page)
+	page, next, err = indexer.GetTxsByKey(key, next, 2)
+	require.NoError(err)
+	require.Equal(txIDs[2:4], page)
+	page, next, err = indexer.GetTxsByKey(key, next, 2)
+	require.NoError(err)
+	require.Equal(txIDs[4:], page)
+	require.Nil(next)
+
+	page, next, err = indexer.GetTxsByKey([]byte("missing"), nil, 2)
+	require.NoError(err)
+	require.Empty(page)
+	require.Nil(next)
+
+	_, _, err = indexer.GetTxsByKey(make([]byte, int(consts.MaxUint16)+1), nil, 2)
+	require.ErrorIs(err, ErrIndexKeyTooLarge)
+
+	_, _, _, _, err = indexer.GetTx(txIDs[0])
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestIndexerNextBlock(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	indexer := &Indexer{db: db}
+	require.NoError(db.Put(indexBlockKey(3), []byte("block 3")))
+	require.NoError(db.Put(indexBlockKey(7), []byte("block 7")))
+
+	// Heights that were not indexed are skipped
+	height, blk, ok, err := indexer.NextBlock(0)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(uint64(3), height)
+	require.Equal([]byte("block 3"), blk)
+	height, blk, ok, err = indexer.NextBlock(4)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(uint64(7), height)
+	require.Equal([]byte("block 7"), blk)
+	_, _, ok, err = indexer.NextBlock(8)
+	require.NoError(err)
+	require.False(ok)
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ rpc.Invariants = (*InvariantChecker)(nil)
+
+// Invariant is a property of the state that must hold after every accepted
+// block (like the sum of all balances equaling the minted supply).
+type Invariant struct {
+	Name string
+	// Check returns an error if [db] violates the invariant. [db] must not be
+	// modified.
+	Check func(ctx context.Context, db merkledb.Trie) error
+}
+
+// InvariantController is an optional extension of [Controller] that
+// registers the invariants of the hypervm.
+type InvariantController interface {
+	Invariants() []*Invariant
+}
+
+type InvariantConfig struct {
+	// CheckAccepted checks every invariant after each accepted block. Blocks
+	// are not processed until the checks complete, so this should only be
+	// enabled on test and dev networks.
+	CheckAccepted bool `json:"checkAccepted"`
+	// HaltOnViolation stops the node when an accepted block violates an
+	// invariant (instead of logging an error)
+	HaltOnViolation bool `json:"haltOnViolation"`
+	// AuthToken must be provided as a bearer token to check invariants over
+	// RPC (checks are not served if empty)
+	AuthToken string `json:"authToken"`
+}
+
+// InvariantChecker checks the [Invariant]s registered by the [Controller]
+// against the accepted state.
+type InvariantChecker struct {
+	vm         *VM
+	config     InvariantConfig
+	invariants []*Invariant
+}
+
+func NewInvariantChecker(vm *VM, config InvariantConfig, invariants []*Invariant) *InvariantChecker {
+	return &InvariantChecker{
+		vm:         vm,
+		config:     config,
+		invariants: invariants,
+	}
+}
+
+func (ic *InvariantChecker) Authorized(token string) bool {
+	if len(ic.config.AuthToken) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(ic.config.AuthToken)) == 1
+}
+
+// check returns the invariants violated by [db]. Errors caused by [db] being
+// invalidated are returned instead of reported as violations.
+func (ic *InvariantChecker) check(ctx context.Context, db merkledb.Trie) ([]*rpc.InvariantViolation, error) {
+	violations := []*rpc.InvariantViolation{}
+	for _, invariant := range ic.invariants {
+		err := invariant.Check(ctx, db)
+		switch {
+		case err == nil:
+		case errors.Is(err, merkledb.ErrInvalid), ctx.Err() != nil:
+			return nil, err
+		default:
+			violations = append(violations, &rpc.InvariantViolation{
+				Name:  invariant.Name,
+				Error: err.Error(),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// Accepted checks the state after [b] if [InvariantConfig.CheckAccepted] is
+// set. It must be called before any other block is committed.
+func (ic *InvariantChecker) Accepted(ctx context.Context, b *chain.StatelessBlock) {
+	if !ic.config.CheckAccepted {
+		return
+	}
+	violations, err := ic.check(ctx, ic.vm.stateDB)
+	if err != nil {
+		ic.vm.Fatal("unable to check invariants", zap.Uint64("height", b.Hght), zap.Error(err))
+	}
+	for _, violation := range violations {
+		ic.vm.metrics.invariantViolations.Inc()
+		fields := []zap.Field{
+			zap.String("invariant", violation.Name),
+			zap.Uint64("height", b.Hght),
+			zap.Stringer("blkID", b.ID()),
+			zap.String("error", violation.Error),
+		}
+		if ic.config.HaltOnViolation {
+			ic.vm.Fatal("invariant violated", fields...)
+		}
+		ic.vm.snowCtx.Log.Error("invariant violated", fields...)
+	}
+}
+
+// Check checks the most recently accepted state and returns its height. The
+// check is retried if a block is accepted while it runs.
+func (ic *InvariantChecker) Check(ctx context.Context) (uint64, []*rpc.InvariantViolation, error) {
+	db, err := ic.vm.State()
+	if err != nil {
+		return 0, nil, err
+	}
+	return ic.checkLatest(ctx, db, chain.HeightKey(ic.vm.StateManager().HeightKey()))
+}
+
+func (ic *InvariantChecker) checkLatest(
+	ctx context.Context,
+	db merkledb.MerkleDB,
+	heightKey []byte,
+) (uint64, []*rpc.InvariantViolation, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+		// A view is invalidated as soon as another block is committed, so a
+		// check that completes without errors saw a single state.
+		view, err := db.NewView(ctx, merkledb.ViewChanges{})
+		if err != nil {
+			return 0, nil, err
+		}
+		violations, err := ic.check(ctx, view)
+		if errors.Is(err, merkledb.ErrInvalid) {
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		height, err := view.GetValue(ctx, heightKey)
+		if errors.Is(err, merkledb.ErrInvalid) {
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		return binary.BigEndian.Uint64(height), violations, nil
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/trace"
+)
+
+var (
+	errNegativeSupply = errors.New("negative supply")
+
+	invariantHeightKey = []byte("height")
+	invariantSupplyKey = []byte("supply")
+)
+
+func TestInvariantChecker(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	tracer, err := trace.New(&trace.Config{Enabled: false})
+	require.NoError(err)
+	db, err := merkledb.New(ctx, memdb.New(), merkledb.Config{
+		BranchFactor:                merkledb.BranchFactor16,
+		RootGenConcurrency:          1,
+		HistoryLength:               100,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.KiB,
+		IntermediateWriteBatchSize:  units.KiB,
+		Tracer:                      tracer,
+	})
+	require.NoError(err)
+	accept := func(height uint64, supply byte) {
+		view, err := db.NewView(ctx, merkledb.ViewChanges{BatchOps: []database.BatchOp{
+			{Key: invariantHeightKey, Value: binary.BigEndian.AppendUint64(nil, height)},
+			{Key: invariantSupplyKey, Value: []byte{supply}},
+		}})
+		require.NoError(err)
+		require.NoError(view.CommitToDB(ctx))
+	}
+	accept(1, 1)
+
+	// A block is accepted while the first check runs
+	var checks int
+	ic := NewInvariantChecker(nil, InvariantConfig{AuthToken: "token"}, []*Invariant{
+		{
+			Name: "supply",
+			Check: func(ctx context.Context, db merkledb.Trie) error {
+				checks++
+				if checks == 1 {
+					accept(2, 0)
+				}
+				supply, err := db.GetValue(ctx, invariantSupplyKey)
+				if err != nil {
+					return err
+				}
+				if supply[0] == 0 {
+					return errNegativeSupply
+				}
+				return nil
+			},
+		},
+		{
+			Name: "noop",
+			Check: func(context.Context, merkledb.Trie) error {
+				return nil
+			},
+		},
+	})
+	require.True(ic.Authorized("token"))
+	require.False(ic.Authorized("other"))
+	require.False(NewInvariantChecker(nil, InvariantConfig{}, nil).Authorized(""))
+
+	// The check is retried against the state of the new block
+	height, violations, err := ic.checkLatest(ctx, db, invariantHeightKey)
+	require.NoError(err)
+	require.Equal(2, checks)
+	require.Equal(uint64(2), height)
+	require.Len(violations, 1)
+	require.Equal("supply", violations[0].Name)
+	require.Equal(errNegativeSupply.Error(), violations[0].Error)
+
+	accept(3, 1)
+	height, violations, err = ic.checkLatest(ctx, db, invariantHeightKey)
+	require.NoError(err)
+	require.Equal(uint64(3), height)
+	require.Empty(violations)
+
+	// Checks stop once the context is canceled
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = ic.checkLatest(cctx, db, invariantHeightKey)
+	require.ErrorIs(err, context.Canceled)
+}
@@ -4,16 +4,28 @@
 package vm
 
 import (
+	"time"
+
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/hypersdk/executor"
+	"github.com/ava-labs/hypersdk/fetcher"
+	"github.com/ava-labs/hypersdk/workers"
+)
+
+var (
+	_ executor.Metrics           = (*executorMetrics)(nil)
+	_ executor.OptimisticMetrics = (*optimisticExecutorMetrics)(nil)
+	_ fetcher.Metrics            = (*fetcherMetrics)(nil)
+	_ workers.Metrics            = (*authVerifierMetrics)(nil)
 )
 
 type executorMetrics struct {
 	blocked    prometheus.Counter
 	executable prometheus.Counter
+	tuner      *workers.Tuner // set if the execution pool is adaptive
 }
 
 func (em *executorMetrics) RecordBlocked() {
@@ -24,9 +36,64 @@ func (em *executorMetrics) RecordExecutable() {
 	em.executable.Inc()
 }
 
+func (em *executorMetrics) RecordQueueLatency(latency time.Duration) {
+	if em.tuner != nil {
+		em.tuner.Observe(latency)
+	}
+}
+
+type optimisticExecutorMetrics struct {
+	speculative prometheus.Counter
+	conflicts   prometheus.Counter
+	tuner       *workers.Tuner // set if the execution pool is adaptive
+}
+
+func (om *optimisticExecutorMetrics) RecordSpeculative() {
+	om.speculative.Inc()
+}
+
+func (om *optimisticExecutorMetrics) RecordConflict() {
+	om.conflicts.Inc()
+}
+
+func (om *optimisticExecutorMetrics) RecordQueueLatency(latency time.Duration) {
+	if om.tuner != nil {
+		om.tuner.Observe(latency)
+	}
+}
+
+type fetcherMetrics struct {
+	tuner *workers.Tuner // set if the state fetch pool is adaptive
+}
+
+func (fm *fetcherMetrics) RecordQueueLatency(latency time.Duration) {
+	if fm.tuner != nil {
+		fm.tuner.Observe(latency)
+	}
+}
+
+type authVerifierMetrics struct {
+	queueDepth *prometheus.GaugeVec
+	workers    prometheus.Gauge
+}
+
+func (am *authVerifierMetrics) RecordQueueDepth(priority workers.Priority, depth int) {
+	am.queueDepth.WithLabelValues(priority.String()).Set(float64(depth))
+}
+
+func (am *authVerifierMetrics) RecordWorkers(count int) {
+	am.workers.Set(float64(count))
+}
+
 type Metrics struct {
 	txsSubmitted             prometheus.Counter // includes gossip
+	txsNotAdmitted           prometheus.Counter
 	txsReceived              prometheus.Counter
+	chunksProduced           prometheus.Counter
+	chunksCertified          prometheus.Counter
+	directTxsSent            prometheus.Counter
+	directTxsReceived        prometheus.Counter
+	directTxsThrottled       prometheus.Counter
 	seenTxsReceived          prometheus.Counter
 	txsGossiped              prometheus.Counter
 	txsVerified              prometheus.Counter
@@ -36,6 +103,7 @@ type Metrics struct {
 	buildCapped              prometheus.Counter
 	emptyBlockBuilt          prometheus.Counter
 	clearedMempool           prometheus.Counter
+	rentSwept                prometheus.Counter
 	deletedBlocks            prometheus.Counter
 	blocksFromDisk           prometheus.Counter
 	blocksHeightsFromDisk    prometheus.Counter
@@ -43,12 +111,37 @@ type Metrics struct {
 	executorBuildExecutable  prometheus.Counter
 	executorVerifyBlocked    prometheus.Counter
 	executorVerifyExecutable prometheus.Counter
+	executorSpeculative      prometheus.Counter
+	executorConflicts        prometheus.Counter
+	eventsPublished          prometheus.Counter
+	webhooksDelivered        prometheus.Counter
+	webhooksFailed           prometheus.Counter
+	webhooksDropped          prometheus.Counter
+	invariantViolations      prometheus.Counter
 	mempoolSize              prometheus.Gauge
 	bandwidthPrice           prometheus.Gauge
 	computePrice             prometheus.Gauge
 	storageReadPrice         prometheus.Gauge
 	storageAllocatePrice     prometheus.Gauge
 	storageWritePrice        prometheus.Gauge
+	eventsPublishedHeight    prometheus.Gauge
+	postgresHeight           prometheus.Gauge
+	exportHeight             prometheus.Gauge
+	authVerifierQueueDepth   *prometheus.GaugeVec
+	cacheHits                *prometheus.CounterVec
+	cacheMisses              *prometheus.CounterVec
+	seenTxs                  prometheus.Gauge
+	seenBuckets              prometheus.Gauge
+	seenEvicted              prometheus.Counter
+	seenRepeats              prometheus.Counter
+	processorFailures        *prometheus.CounterVec
+	builderProposals         prometheus.Counter
+	builderProposalsUsed     prometheus.Counter
+	builderProposalTxs       prometheus.Counter
+	checkpointsPublished     prometheus.Counter
+	authVerifierWorkers      prometheus.Gauge
+	executorWorkers          prometheus.Gauge
+	stateFetcherWorkers      prometheus.Gauge
 	rootCalculated           metric.Averager
 	waitRoot                 metric.Averager
 	waitSignatures           metric.Averager
@@ -57,9 +150,16 @@ type Metrics struct {
 	blockVerify              metric.Averager
 	blockAccept              metric.Averager
 	blockProcess             metric.Averager
+	executeConservative      metric.Averager
+	executeOptimistic        metric.Averager
+	statePrefetch            metric.Averager
+	actions                  *actionMetrics
 
-	executorBuildRecorder  executor.Metrics
-	executorVerifyRecorder executor.Metrics
+	executorBuildRecorder      *executorMetrics
+	executorVerifyRecorder     *executorMetrics
+	executorOptimisticRecorder *optimisticExecutorMetrics
+	stateFetchRecorder         *fetcherMetrics
+	authVerifierRecorder       workers.Metrics
 }
 
 func newMetrics() (*prometheus.Registry, *Metrics, error) {
@@ -129,6 +229,30 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	executeConservative, err := metric.NewAverager(
+		"chain_execute_conservative",
+		"time spent executing transactions with the conservative executor in verify",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	executeOptimistic, err := metric.NewAverager(
+		"chain_execute_optimistic",
+		"time spent executing transactions with the optimistic executor in verify",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	statePrefetch, err := metric.NewAverager(
+		"chain_state_prefetch",
+		"time spent prefetching state for parsed blocks",
+		r,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	m := &Metrics{
 		txsSubmitted: prometheus.NewCounter(prometheus.CounterOpts{
@@ -136,6 +260,36 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "txs_submitted",
 			Help:      "number of txs submitted to vm",
 		}),
+		txsNotAdmitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "txs_not_admitted",
+			Help:      "number of txs rejected by the admission policy when submitted or building",
+		}),
+		chunksProduced: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "chunks_produced",
+			Help:      "number of chunks produced",
+		}),
+		chunksCertified: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "chunks_certified",
+			Help:      "number of produced chunks that were certified",
+		}),
+		directTxsSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "direct_txs_sent",
+			Help:      "number of txs submitted directly to proposers",
+		}),
+		directTxsReceived: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "direct_txs_received",
+			Help:      "number of txs received from registered submitters",
+		}),
+		directTxsThrottled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "direct_txs_throttled",
+			Help:      "number of txs from registered submitters that exceeded their quota",
+		}),
 		txsReceived: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "txs_received",
@@ -186,6 +340,11 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "cleared_mempool",
 			Help:      "number of times cleared mempool while building",
 		}),
+		rentSwept: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "rent_swept",
+			Help:      "number of expired keys removed from state",
+		}),
 		deletedBlocks: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: "vm",
 			Name:      "deleted_blocks",
@@ -221,6 +380,41 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "executor_verify_executable",
 			Help:      "executor tasks executable during verify",
 		}),
+		executorSpeculative: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "executor_speculative",
+			Help:      "executor tasks speculatively executed during verify",
+		}),
+		executorConflicts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "chain",
+			Name:      "executor_conflicts",
+			Help:      "executor tasks re-executed because of conflicts during verify",
+		}),
+		eventsPublished: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "events_published",
+			Help:      "number of block and transaction events published to the event sink",
+		}),
+		webhooksDelivered: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "webhooks_delivered",
+			Help:      "number of webhook notifications delivered",
+		}),
+		webhooksFailed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "webhooks_failed",
+			Help:      "number of webhook notifications abandoned after all delivery attempts failed",
+		}),
+		webhooksDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "webhooks_dropped",
+			Help:      "number of webhook notifications dropped because the delivery queue was full",
+		}),
+		invariantViolations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "invariant_violations",
+			Help:      "number of invariants violated by accepted blocks",
+		}),
 		mempoolSize: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: "chain",
 			Name:      "mempool_size",
@@ -251,6 +445,96 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 			Name:      "storage_modify_price",
 			Help:      "unit price of storage modifications",
 		}),
+		eventsPublishedHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "events_published_height",
+			Help:      "height of the last block published to the event sink",
+		}),
+		postgresHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "postgres_height",
+			Help:      "height of the last block written to postgres",
+		}),
+		exportHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "export_height",
+			Help:      "height of the last block exported to bundles",
+		}),
+		authVerifierQueueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "auth_verifier_queue_depth",
+			Help:      "number of signature verification jobs waiting for the workers by priority",
+		}, []string{"priority"}),
+		seenTxs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "seen_txs",
+			Help:      "number of accepted txs tracked for replay protection",
+		}),
+		seenBuckets: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "seen_buckets",
+			Help:      "number of expiry buckets of the txs tracked for replay protection",
+		}),
+		seenEvicted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "seen_evicted",
+			Help:      "number of txs no longer tracked for replay protection because they expired",
+		}),
+		seenRepeats: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "seen_repeats",
+			Help:      "number of txs found to repeat an accepted tx",
+		}),
+		processorFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "accepted_processor_failures",
+			Help:      "number of accepted blocks a non-critical processor failed to process",
+		}, []string{"processor"}),
+		builderProposals: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "builder_proposals",
+			Help:      "number of valid proposals received from external builders",
+		}),
+		builderProposalsUsed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "builder_proposals_used",
+			Help:      "number of external proposals used to build blocks",
+		}),
+		builderProposalTxs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "builder_proposal_txs",
+			Help:      "number of proposed txs prioritized when building blocks",
+		}),
+		checkpointsPublished: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "checkpoints_published",
+			Help:      "number of checkpoints served to operators",
+		}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "cache_hits",
+			Help:      "number of lookups served by each cache of recently accepted data",
+		}, []string{"cache"}),
+		cacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vm",
+			Name:      "cache_misses",
+			Help:      "number of lookups missed by each cache of recently accepted data",
+		}, []string{"cache"}),
+		authVerifierWorkers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "auth_verifier_workers",
+			Help:      "number of signature verification workers",
+		}),
+		executorWorkers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "executor_workers",
+			Help:      "number of workers that execute the transactions of a block",
+		}),
+		stateFetcherWorkers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vm",
+			Name:      "state_fetcher_workers",
+			Help:      "number of workers that fetch the state of a block",
+		}),
 		rootCalculated: rootCalculated,
 		waitRoot:       waitRoot,
 		waitSignatures: waitSignatures,
@@ -259,14 +543,28 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		blockVerify:    blockVerify,
 		blockAccept:    blockAccept,
 		blockProcess:   blockProcess,
+
+		executeConservative: executeConservative,
+		executeOptimistic:   executeOptimistic,
+		statePrefetch:       statePrefetch,
+		actions:             newActionMetrics(),
 	}
 	m.executorBuildRecorder = &executorMetrics{blocked: m.executorBuildBlocked, executable: m.executorBuildExecutable}
 	m.executorVerifyRecorder = &executorMetrics{blocked: m.executorVerifyBlocked, executable: m.executorVerifyExecutable}
+	m.executorOptimisticRecorder = &optimisticExecutorMetrics{speculative: m.executorSpeculative, conflicts: m.executorConflicts}
+	m.stateFetchRecorder = &fetcherMetrics{}
+	m.authVerifierRecorder = &authVerifierMetrics{queueDepth: m.authVerifierQueueDepth, workers: m.authVerifierWorkers}
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		r.Register(m.txsSubmitted),
+		r.Register(m.txsNotAdmitted),
 		r.Register(m.txsReceived),
+		r.Register(m.chunksProduced),
+		r.Register(m.chunksCertified),
+		r.Register(m.directTxsSent),
+		r.Register(m.directTxsReceived),
+		r.Register(m.directTxsThrottled),
 		r.Register(m.seenTxsReceived),
 		r.Register(m.txsGossiped),
 		r.Register(m.txsVerified),
@@ -277,6 +575,7 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		r.Register(m.buildCapped),
 		r.Register(m.emptyBlockBuilt),
 		r.Register(m.clearedMempool),
+		r.Register(m.rentSwept),
 		r.Register(m.deletedBlocks),
 		r.Register(m.blocksFromDisk),
 		r.Register(m.blocksHeightsFromDisk),
@@ -284,11 +583,37 @@ func newMetrics() (*prometheus.Registry, *Metrics, error) {
 		r.Register(m.executorBuildExecutable),
 		r.Register(m.executorVerifyBlocked),
 		r.Register(m.executorVerifyExecutable),
+		r.Register(m.executorSpeculative),
+		r.Register(m.executorConflicts),
+		r.Register(m.eventsPublished),
+		r.Register(m.webhooksDelivered),
+		r.Register(m.webhooksFailed),
+		r.Register(m.webhooksDropped),
+		r.Register(m.invariantViolations),
 		r.Register(m.bandwidthPrice),
 		r.Register(m.computePrice),
 		r.Register(m.storageReadPrice),
 		r.Register(m.storageAllocatePrice),
 		r.Register(m.storageWritePrice),
+		r.Register(m.eventsPublishedHeight),
+		r.Register(m.postgresHeight),
+		r.Register(m.exportHeight),
+		r.Register(m.authVerifierQueueDepth),
+		r.Register(m.authVerifierWorkers),
+		r.Register(m.executorWorkers),
+		r.Register(m.stateFetcherWorkers),
+		r.Register(m.seenTxs),
+		r.Register(m.seenBuckets),
+		r.Register(m.seenEvicted),
+		r.Register(m.seenRepeats),
+		r.Register(m.processorFailures),
+		r.Register(m.builderProposals),
+		r.Register(m.builderProposalsUsed),
+		r.Register(m.builderProposalTxs),
+		r.Register(m.checkpointsPublished),
+		r.Register(m.cacheHits),
+		r.Register(m.cacheMisses),
 	)
+	errs.Add(m.actions.register(r)...)
 	return r, m, errs.Err
 }
@@ -0,0 +1,86 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+type DirectSubmissionHandler struct {
+	vm *VM
+}
+
+func NewDirectSubmissionHandler(vm *VM) *DirectSubmissionHandler {
+	return &DirectSubmissionHandler{vm}
+}
+
+func (*DirectSubmissionHandler) Connected(context.Context, ids.NodeID, *version.Application) error {
+	return nil
+}
+
+func (*DirectSubmissionHandler) Disconnected(context.Context, ids.NodeID) error {
+	return nil
+}
+
+func (*DirectSubmissionHandler) AppGossip(context.Context, ids.NodeID, []byte) error {
+	return nil
+}
+
+func (d *DirectSubmissionHandler) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	_ time.Time,
+	request []byte,
+) error {
+	if d.vm.directSubmission == nil {
+		return nil
+	}
+	return d.vm.directSubmission.AppRequest(ctx, nodeID, requestID, request)
+}
+
+func (d *DirectSubmissionHandler) AppRequestFailed(
+	_ context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+) error {
+	if d.vm.directSubmission != nil {
+		d.vm.directSubmission.AppRequestFailed(nodeID, requestID)
+	}
+	return nil
+}
+
+func (d *DirectSubmissionHandler) AppResponse(
+	_ context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	response []byte,
+) error {
+	if d.vm.directSubmission != nil {
+		d.vm.directSubmission.AppResponse(nodeID, requestID, response)
+	}
+	return nil
+}
+
+func (*DirectSubmissionHandler) CrossChainAppRequest(
+	context.Context,
+	ids.ID,
+	uint32,
+	time.Time,
+	[]byte,
+) error {
+	return nil
+}
+
+func (*DirectSubmissionHandler) CrossChainAppRequestFailed(context.Context, ids.ID, uint32) error {
+	return nil
+}
+
+func (*DirectSubmissionHandler) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
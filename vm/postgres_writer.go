@@ -0,0 +1,128 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/postgres"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+// PostgresWriter copies the blocks stored by the [Indexer] into a
+// [postgres.DB].
+//
+// Blocks are written in order starting after the cursor stored in the
+// database (or from the first indexed block if the database is empty), so
+// blocks accepted while the database was unreachable (or before the writer was
+// enabled) are backfilled.
+type PostgresWriter struct {
+	vm     *VM
+	config postgres.Config
+	db     *postgres.DB
+
+	notify chan struct{}
+	done   chan struct{}
+}
+
+func NewPostgresWriter(vm *VM, config postgres.Config, db *postgres.DB) *PostgresWriter {
+	return &PostgresWriter{
+		vm:     vm,
+		config: config,
+		db:     db,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// Accepted wakes up the writer after a block was indexed.
+func (p *PostgresWriter) Accepted() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (p *PostgresWriter) Run() {
+	defer close(p.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.vm.stop
+		cancel()
+	}()
+
+	migrated := false
+	for {
+		var err error
+		if !migrated {
+			err = p.db.Migrate(ctx)
+			migrated = err == nil
+		}
+		if migrated {
+			err = p.write(ctx)
+		}
+		if err == nil {
+			select {
+			case <-p.notify:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if migrated {
+			p.vm.Logger().Warn("unable to write to postgres", zap.Error(err))
+		} else {
+			p.vm.Logger().Warn("unable to migrate postgres", zap.Error(err))
+		}
+		select {
+		case <-time.After(p.config.RetryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// write copies the indexed blocks after the cursor until none are left.
+func (p *PostgresWriter) write(ctx context.Context) error {
+	height, ok, err := p.db.Cursor(ctx)
+	if err != nil {
+		return err
+	}
+	next := uint64(0)
+	if ok {
+		next = height + 1
+	}
+	for {
+		height, msg, ok, err := p.vm.indexer.NextBlock(next)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		blk, results, prices, err := rpc.UnpackBlockMessage(msg, p.vm)
+		if err != nil {
+			return err
+		}
+		if err := p.db.WriteBlock(ctx, blk, results, prices); err != nil {
+			return err
+		}
+		p.vm.metrics.postgresHeight.Set(float64(height))
+		next = height + 1
+	}
+}
+
+// Done waits for [Run] to exit and closes the database.
+func (p *PostgresWriter) Done() error {
+	<-p.done
+	return p.db.Close()
+}
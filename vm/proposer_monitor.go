@@ -16,6 +16,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/rpc"
 )
 
 const (
@@ -112,17 +114,9 @@ func (p *ProposerMonitor) Proposers(
 	proposersToGossip := set.NewSet[ids.NodeID](diff * depth)
 	udepth := uint64(depth)
 	for i := uint64(1); i <= uint64(diff); i++ {
-		height := preferredBlk.Hght + i
-		key := fmt.Sprintf("%d-%d", height, p.currentPHeight)
-		var proposers []ids.NodeID
-		if v, ok := p.proposerCache.Get(key); ok {
-			proposers = v
-		} else {
-			proposers, err = p.proposer.Proposers(ctx, height, p.currentPHeight, diff)
-			if err != nil {
-				return nil, err
-			}
-			p.proposerCache.Put(key, proposers)
+		proposers, err := p.proposers(ctx, preferredBlk.Hght+i, p.currentPHeight, diff)
+		if err != nil {
+			return nil, err
 		}
 		arrLen := min(udepth, uint64(len(proposers)))
 		proposersToGossip.Add(proposers[:arrLen]...)
@@ -130,6 +124,79 @@ func (p *ProposerMonitor) Proposers(
 	return proposersToGossip, nil
 }
 
+// Windows returns the P-Chain height the proposers are sampled at, the height
+// of the child of the preferred block, and the first [windows] proposers that
+// can build each of the [heights] blocks starting at that height (the
+// proposer at index i can build a block [proposer.WindowDuration] * i after
+// the timestamp of its parent).
+func (p *ProposerMonitor) Windows(
+	ctx context.Context,
+	heights int,
+	windows int,
+) (uint64, uint64, [][]ids.NodeID, error) {
+	if err := p.refresh(ctx); err != nil {
+		return 0, 0, nil, err
+	}
+	preferredBlk, err := p.vm.GetStatelessBlock(ctx, p.vm.preferred)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	pHeight := p.currentPHeight
+	schedule := make([][]ids.NodeID, heights)
+	for i := range schedule {
+		schedule[i], err = p.proposers(ctx, preferredBlk.Hght+uint64(i)+1, pHeight, windows)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+	}
+	return pHeight, preferredBlk.Hght + 1, schedule, nil
+}
+
+// Schedule returns the first [slots] slots of the child of the preferred
+// block (the proposer of slot i can build it [proposer.WindowDuration] * i
+// after the timestamp of the preferred block).
+func (p *ProposerMonitor) Schedule(ctx context.Context, slots int) (*rpc.ProposerSchedule, error) {
+	if err := p.refresh(ctx); err != nil {
+		return nil, err
+	}
+	preferredBlk, err := p.vm.GetStatelessBlock(ctx, p.vm.preferred)
+	if err != nil {
+		return nil, err
+	}
+	schedule := &rpc.ProposerSchedule{
+		PChainHeight:    p.currentPHeight,
+		Height:          preferredBlk.Hght + 1,
+		ParentTimestamp: preferredBlk.Tmstmp,
+	}
+	proposers, err := p.proposers(ctx, schedule.Height, schedule.PChainHeight, slots)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Slots = make([]*rpc.ProposerSlot, len(proposers))
+	for i, nodeID := range proposers {
+		schedule.Slots[i] = &rpc.ProposerSlot{
+			Start:    preferredBlk.Tmstmp + int64(i)*proposer.WindowDuration.Milliseconds(),
+			Proposer: nodeID,
+		}
+	}
+	return schedule, nil
+}
+
+// proposers returns the first [windows] proposers of the block at [height]
+// when sampled at [pHeight].
+func (p *ProposerMonitor) proposers(ctx context.Context, height uint64, pHeight uint64, windows int) ([]ids.NodeID, error) {
+	key := fmt.Sprintf("%d-%d-%d", height, pHeight, windows)
+	if v, ok := p.proposerCache.Get(key); ok {
+		return v, nil
+	}
+	proposers, err := p.proposer.Proposers(ctx, height, pHeight, windows)
+	if err != nil {
+		return nil, err
+	}
+	p.proposerCache.Put(key, proposers)
+	return proposers, nil
+}
+
 func (p *ProposerMonitor) Validators(
 	ctx context.Context,
 ) (map[ids.NodeID]*validators.GetValidatorOutput, map[string]struct{}) {
@@ -138,3 +205,14 @@ func (p *ProposerMonitor) Validators(
 	}
 	return p.validators, p.validatorPublicKeys
 }
+
+// ValidatorSet returns the P-Chain height of the current validator set and
+// its validators.
+func (p *ProposerMonitor) ValidatorSet(
+	ctx context.Context,
+) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	if err := p.refresh(ctx); err != nil {
+		return 0, nil, err
+	}
+	return p.currentPHeight, p.validators, nil
+}
@@ -5,6 +5,7 @@ package vm
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -14,23 +15,29 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/executor"
 	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/fetcher"
 	"github.com/ava-labs/hypersdk/gossiper"
+	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/workers"
 )
 
 var (
-	_ chain.VM              = (*VM)(nil)
-	_ gossiper.VM           = (*VM)(nil)
-	_ builder.VM            = (*VM)(nil)
-	_ block.ChainVM         = (*VM)(nil)
-	_ block.StateSyncableVM = (*VM)(nil)
+	_ chain.VM                           = (*VM)(nil)
+	_ gossiper.VM                        = (*VM)(nil)
+	_ builder.VM                         = (*VM)(nil)
+	_ block.ChainVM                      = (*VM)(nil)
+	_ block.BuildBlockWithContextChainVM = (*VM)(nil)
+	_ block.StateSyncableVM              = (*VM)(nil)
 )
 
 func (vm *VM) ChainID() ids.ID {
@@ -70,6 +77,9 @@ func (vm *VM) Rules(t int64) chain.Rules {
 }
 
 func (vm *VM) LastAcceptedBlock() *chain.StatelessBlock {
+	vm.acceptedL.RLock()
+	defer vm.acceptedL.RUnlock()
+
 	return vm.lastAccepted
 }
 
@@ -93,7 +103,10 @@ func (vm *VM) IsRepeat(ctx context.Context, txs []*chain.Transaction, marker set
 	_, span := vm.tracer.Start(ctx, "VM.IsRepeat")
 	defer span.End()
 
-	return vm.seen.Contains(txs, marker, stop)
+	repeats := marker.Len()
+	marker = vm.seen.Contains(txs, marker, stop)
+	vm.metrics.seenRepeats.Add(float64(marker.Len() - repeats))
+	return marker
 }
 
 func (vm *VM) Verified(ctx context.Context, b *chain.StatelessBlock) {
@@ -105,9 +118,18 @@ func (vm *VM) Verified(ctx context.Context, b *chain.StatelessBlock) {
 	vm.verifiedBlocks[b.ID()] = b
 	vm.verifiedL.Unlock()
 	vm.parsedBlocks.Evict(b.ID())
+	if vm.externalBuilder != nil {
+		vm.externalBuilder.Publish(rpc.PendingIncluded, vm.inMempool(ctx, b.Txs))
+	}
 	vm.mempool.Remove(ctx, b.Txs)
 	vm.gossiper.BlockVerified(b.Tmstmp)
 	vm.checkActivity(ctx)
+	if err := vm.webSocketServer.VerifyBlock(b); err != nil {
+		vm.snowCtx.Log.Warn("unable to send pre-confirmations", zap.Error(err))
+	}
+	if vm.txStatuses != nil {
+		vm.txStatuses.Included(b)
+	}
 
 	if b.Processed() {
 		fm := b.FeeManager()
@@ -143,6 +165,15 @@ func (vm *VM) Rejected(ctx context.Context, b *chain.StatelessBlock) {
 	delete(vm.verifiedBlocks, b.ID())
 	vm.verifiedL.Unlock()
 	vm.mempool.Add(ctx, b.Txs)
+	if vm.externalBuilder != nil {
+		vm.externalBuilder.Publish(rpc.PendingAdmitted, vm.inMempool(ctx, b.Txs))
+	}
+	if err := vm.webSocketServer.RejectBlock(b); err != nil {
+		vm.snowCtx.Log.Warn("unable to send pre-confirmation rollbacks", zap.Error(err))
+	}
+	if vm.txStatuses != nil {
+		vm.txStatuses.Rejected(b)
+	}
 
 	// Ensure children of block are cleared, they may never be
 	// verified
@@ -165,34 +196,7 @@ func (vm *VM) processAcceptedBlock(b *chain.StatelessBlock) {
 		return
 	}
 
-	// Update controller
-	if err := vm.c.Accepted(context.TODO(), b); err != nil {
-		vm.Fatal("accepted processing failed", zap.Error(err))
-	}
-
-	// TODO: consider removing this (unused and requires an extra iteration)
-	for _, tx := range b.Txs {
-		// Only cache auth for accepted blocks to prevent cache manipulation from RPC submissions
-		vm.cacheAuth(tx.Auth)
-	}
-
-	// Update server
-	if err := vm.webSocketServer.AcceptBlock(b); err != nil {
-		vm.Fatal("unable to accept block in websocket server", zap.Error(err))
-	}
-	// Must clear accepted txs before [SetMinTx] or else we will errnoueously
-	// send [ErrExpired] messages.
-	if err := vm.webSocketServer.SetMinTx(b.Tmstmp); err != nil {
-		vm.Fatal("unable to set min tx in websocket server", zap.Error(err))
-	}
-
-	// Update price metrics
-	feeManager := b.FeeManager()
-	vm.metrics.bandwidthPrice.Set(float64(feeManager.UnitPrice(fees.Bandwidth)))
-	vm.metrics.computePrice.Set(float64(feeManager.UnitPrice(fees.Compute)))
-	vm.metrics.storageReadPrice.Set(float64(feeManager.UnitPrice(fees.StorageRead)))
-	vm.metrics.storageAllocatePrice.Set(float64(feeManager.UnitPrice(fees.StorageAllocate)))
-	vm.metrics.storageWritePrice.Set(float64(feeManager.UnitPrice(fees.StorageWrite)))
+	vm.runAcceptedProcessors(context.TODO(), b)
 }
 
 func (vm *VM) processAcceptedBlocks() {
@@ -216,16 +220,45 @@ func (vm *VM) processAcceptedBlocks() {
 	}
 }
 
+func (vm *VM) GetChunks(ctx context.Context, certs []*chain.ChunkCertificate) ([]*chain.Chunk, error) {
+	if vm.chunkManager != nil {
+		return vm.chunkManager.GetChunks(ctx, certs)
+	}
+
+	// Blocks parsed during initialization are accepted, so their chunks are
+	// on disk
+	chunks := make([]*chain.Chunk, len(certs))
+	for i, cert := range certs {
+		chunk, err := vm.GetDiskChunk(cert.Chunk)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", chain.ErrChunksNotAvailable, cert.Chunk)
+		}
+		chunks[i] = chunk
+	}
+	return chunks, nil
+}
+
+func (vm *VM) CertifiedChunks(_ context.Context, t int64) ([]*chain.ChunkCertificate, []*chain.Chunk) {
+	return vm.chunkManager.CertifiedChunks(t)
+}
+
 func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 	ctx, span := vm.tracer.Start(ctx, "VM.Accepted")
 	defer span.End()
 
 	vm.metrics.txsAccepted.Add(float64(len(b.Txs)))
+	if b.Processed() {
+		// Blocks accepted during state sync are not executed
+		vm.metrics.actions.record(b)
+	}
 
 	// Update accepted blocks on-disk and caches
-	if err := vm.UpdateLastAccepted(b); err != nil {
+	if err := vm.UpdateLastAccepted(ctx, b); err != nil {
 		vm.Fatal("unable to update last accepted", zap.Error(err))
 	}
+	if len(b.Chunks) > 0 {
+		vm.chunkManager.Accepted(b)
+	}
 
 	// Remove from verified caches
 	//
@@ -243,6 +276,7 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 	evicted := vm.seen.SetMin(blkTime)
 	vm.Logger().Debug("txs evicted from seen", zap.Int("len", len(evicted)))
 	vm.seen.Add(b.Txs)
+	vm.recordSeen(len(evicted))
 
 	// Verify if emap is now sufficient (we need a consecutive run of blocks with
 	// timestamps of at least [ValidityWindow] for this to occur).
@@ -272,6 +306,23 @@ func (vm *VM) Accepted(ctx context.Context, b *chain.StatelessBlock) {
 	// transactions instead of the mempool because we won't need to iterate
 	// through as many transactions.
 	removed := vm.mempool.SetMinTimestamp(ctx, blkTime)
+	vm.publishPending(rpc.PendingExpired, removed)
+	if vm.audit != nil && len(removed) > 0 {
+		txIDs := make([]ids.ID, len(removed))
+		for i, tx := range removed {
+			txIDs[i] = tx.ID()
+		}
+		vm.audit.Record(audit.MempoolCategory, "expire", "", nil, map[string]any{
+			"height": b.Hght,
+			"txIDs":  txIDs,
+		})
+	}
+
+	// Blocks are only committed to state once processed and no other block can
+	// be committed until we return
+	if vm.invariants != nil && b.Processed() {
+		vm.invariants.Accepted(ctx, b)
+	}
 
 	// Enqueue block for processing
 	vm.acceptedQueue <- b
@@ -302,10 +353,38 @@ func (vm *VM) CurrentValidators(
 	return vm.proposerMonitor.Validators(ctx)
 }
 
+func (vm *VM) ValidatorSet(
+	ctx context.Context,
+) (uint64, map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return vm.proposerMonitor.ValidatorSet(ctx)
+}
+
+func (vm *VM) ProposerWindows(ctx context.Context, heights int, windows int) (uint64, uint64, [][]ids.NodeID, error) {
+	return vm.proposerMonitor.Windows(ctx, heights, windows)
+}
+
+func (vm *VM) ProposerSchedule(ctx context.Context, slots int) (*rpc.ProposerSchedule, error) {
+	return vm.proposerMonitor.Schedule(ctx, slots)
+}
+
+// RequestWarpSignatures asks the current validators that have not signed
+// [msg] yet for their signature.
+func (vm *VM) RequestWarpSignatures(ctx context.Context, msg *warp.UnsignedMessage) error {
+	return vm.warpCollector.RequestMissing(ctx, msg)
+}
+
 func (vm *VM) NodeID() ids.NodeID {
 	return vm.snowCtx.NodeID
 }
 
+func (vm *VM) ProposerAddress() codec.Address {
+	return vm.proposerAddress
+}
+
+func (vm *VM) Sign(msg *warp.UnsignedMessage) ([]byte, error) {
+	return vm.snowCtx.WarpSigner.Sign(msg)
+}
+
 func (vm *VM) PreferredBlock(ctx context.Context) (*chain.StatelessBlock, error) {
 	return vm.GetStatelessBlock(ctx, vm.preferred)
 }
@@ -370,6 +449,18 @@ func (vm *VM) RecordWaitSignatures(t time.Duration) {
 	vm.metrics.waitSignatures.Observe(float64(t))
 }
 
+func (vm *VM) RecordExecuteConservative(t time.Duration) {
+	vm.metrics.executeConservative.Observe(float64(t))
+}
+
+func (vm *VM) RecordExecuteOptimistic(t time.Duration) {
+	vm.metrics.executeOptimistic.Observe(float64(t))
+}
+
+func (vm *VM) RecordStatePrefetch(t time.Duration) {
+	vm.metrics.statePrefetch.Observe(float64(t))
+}
+
 func (vm *VM) RecordStateChanges(c int) {
 	vm.metrics.stateChanges.Add(float64(c))
 }
@@ -378,6 +469,106 @@ func (vm *VM) RecordStateOperations(c int) {
 	vm.metrics.stateOperations.Add(float64(c))
 }
 
+func (vm *VM) Indexer() rpc.Indexer {
+	if vm.indexer == nil {
+		return nil
+	}
+	return &cachedIndexer{Indexer: vm.indexer, vm: vm}
+}
+
+func (vm *VM) TxStatuses() rpc.TxStatuses {
+	if vm.txStatuses == nil {
+		return nil
+	}
+	return vm.txStatuses
+}
+
+func (vm *VM) StateDiffs() rpc.StateDiffs {
+	if vm.stateDiffs == nil {
+		return nil
+	}
+	return vm.stateDiffs
+}
+
+func (vm *VM) UnitProfiler() rpc.UnitProfiler {
+	if vm.unitProfiler == nil {
+		return nil
+	}
+	return vm.unitProfiler
+}
+
+func (vm *VM) FeeHistory() rpc.FeeHistory {
+	if vm.feeHistory == nil {
+		return nil
+	}
+	return vm.feeHistory
+}
+
+func (vm *VM) Webhooks() rpc.Webhooks {
+	if vm.webhooks == nil {
+		return nil
+	}
+	return vm.webhooks
+}
+
+// AuditLog returns nil if the audit log is disabled.
+func (vm *VM) AuditLog() *audit.Logger {
+	return vm.audit
+}
+
+func (vm *VM) Invariants() rpc.Invariants {
+	if vm.invariants == nil {
+		return nil
+	}
+	return vm.invariants
+}
+
+func (vm *VM) TxTracer() rpc.TxTracer {
+	if vm.txTracer == nil {
+		return nil
+	}
+	return vm.txTracer
+}
+
+// PublishTopic sends [payload] to the WebSocket subscriptions of the topic
+// [name] (registered by a [TopicController]) whose filter matches [event].
+func (vm *VM) PublishTopic(name string, event any, payload []byte) error {
+	return vm.webSocketServer.PublishTopic(name, event, payload)
+}
+
+// publishPending publishes the event [kind] of [txs] to the subscribers of
+// pending transactions.
+func (vm *VM) publishPending(kind byte, txs []*chain.Transaction) {
+	if len(txs) == 0 {
+		return
+	}
+	if err := vm.webSocketServer.PublishPendingTxs(kind, txs); err != nil {
+		vm.snowCtx.Log.Warn("unable to publish pending txs", zap.Error(err))
+	}
+	if vm.externalBuilder != nil {
+		vm.externalBuilder.Publish(kind, txs)
+	}
+}
+
+// inMempool returns the transactions of [txs] in the mempool.
+func (vm *VM) inMempool(ctx context.Context, txs []*chain.Transaction) []*chain.Transaction {
+	pending := make([]*chain.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if vm.mempool.Has(ctx, tx.ID()) {
+			pending = append(pending, tx)
+		}
+	}
+	return pending
+}
+
+func (vm *VM) NativeBalance(ctx context.Context, addr codec.Address) (uint64, error) {
+	bc, ok := vm.c.(BalanceController)
+	if !ok {
+		return 0, rpc.ErrNativeBalanceUnsupported
+	}
+	return bc.GetBalanceFromState(ctx, addr)
+}
+
 func (vm *VM) GetVerifyAuth() bool {
 	return vm.config.VerifyAuth
 }
@@ -438,6 +629,10 @@ func (vm *VM) RecordClearedMempool() {
 	vm.metrics.clearedMempool.Inc()
 }
 
+func (vm *VM) RecordRentSwept(c int) {
+	vm.metrics.rentSwept.Add(float64(c))
+}
+
 func (vm *VM) UnitPrices(context.Context) (fees.Dimensions, error) {
 	v, err := vm.stateDB.Get(chain.FeeKey(vm.StateManager().FeeKey()))
 	if err != nil {
@@ -447,13 +642,27 @@ func (vm *VM) UnitPrices(context.Context) (fees.Dimensions, error) {
 }
 
 func (vm *VM) GetTransactionExecutionCores() int {
+	if vm.executionTuner != nil {
+		return vm.executionTuner.Size()
+	}
 	return vm.config.TransactionExecutionCores
 }
 
+func (vm *VM) GetOptimisticExecution() bool {
+	return vm.config.OptimisticExecution
+}
+
 func (vm *VM) GetStateFetchConcurrency() int {
+	if vm.stateFetchTuner != nil {
+		return vm.stateFetchTuner.Size()
+	}
 	return vm.config.StateFetchConcurrency
 }
 
+func (vm *VM) GetStatePrefetch() bool {
+	return vm.config.StatePrefetch
+}
+
 func (vm *VM) GetExecutorBuildRecorder() executor.Metrics {
 	return vm.metrics.executorBuildRecorder
 }
@@ -461,3 +670,11 @@ func (vm *VM) GetExecutorBuildRecorder() executor.Metrics {
 func (vm *VM) GetExecutorVerifyRecorder() executor.Metrics {
 	return vm.metrics.executorVerifyRecorder
 }
+
+func (vm *VM) GetExecutorOptimisticRecorder() executor.OptimisticMetrics {
+	return vm.metrics.executorOptimisticRecorder
+}
+
+func (vm *VM) GetStateFetchRecorder() fetcher.Metrics {
+	return vm.metrics.stateFetchRecorder
+}
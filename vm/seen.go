@@ -0,0 +1,56 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/emap"
+)
+
+// SeenConfig tunes how the transactions of accepted blocks are tracked for
+// replay protection (until they expire).
+//
+// Chains with long validity windows and high throughput track a lot of
+// transactions: grouping their expiries into buckets reduces the number of
+// buckets to track (at the cost of keeping each transaction up to
+// [BucketInterval] longer) and preallocating space avoids repeatedly growing
+// the set of transactions while it fills up.
+type SeenConfig struct {
+	BucketInterval time.Duration `json:"bucketInterval" min:"0s"` // 0 to keep a bucket per expiry
+	Capacity       int           `json:"capacity" min:"0"`        // a warning is logged if more transactions are tracked
+}
+
+func newSeen(cfg SeenConfig) *emap.EMap[*chain.Transaction] {
+	var policy emap.Policy = emap.ExactPolicy{}
+	if interval := cfg.BucketInterval.Milliseconds(); interval > 1 {
+		policy = emap.IntervalPolicy{Interval: interval}
+	}
+	return emap.NewEMapWithCapacity[*chain.Transaction](policy, nil, cfg.Capacity)
+}
+
+// recordSeen updates the metrics of [vm.seen] after [evicted] transactions
+// were removed from it.
+func (vm *VM) recordSeen(evicted int) {
+	size := vm.seen.Len()
+	vm.metrics.seenTxs.Set(float64(size))
+	vm.metrics.seenBuckets.Set(float64(vm.seen.Buckets()))
+	vm.metrics.seenEvicted.Add(float64(evicted))
+
+	capacity := vm.config.SeenConfig.Capacity
+	if capacity == 0 {
+		return
+	}
+	exceeded := size > capacity
+	if exceeded && !vm.seenExceeded {
+		vm.Logger().Warn("seen transactions exceeded capacity",
+			zap.Int("size", size),
+			zap.Int("capacity", capacity),
+		)
+	}
+	vm.seenExceeded = exceeded
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/trace"
+)
+
+func TestSeenMetrics(t *testing.T) {
+	require := require.New(t)
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+	txs := []*chain.Transaction{}
+	for _, expiry := range []int64{1_000, 2_000, 3_000} {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: expiry, ChainID: ids.GenerateTestID(), MaxFee: 100},
+			[]chain.Action{&testAction{}},
+		).Sign(&testAuthFactory{actor: codec.CreateAddress(0, ids.GenerateTestID())}, actionRegistry, authRegistry)
+		require.NoError(err)
+		txs = append(txs, tx)
+	}
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	tracer, _ := trace.New(&trace.Config{Enabled: false})
+	vm := &VM{
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		config:  NewConfig(),
+		metrics: m,
+		tracer:  tracer,
+	}
+	vm.config.SeenConfig = SeenConfig{BucketInterval: 2 * time.Second, Capacity: 2}
+	vm.seen = newSeen(vm.config.SeenConfig)
+
+	// Expiries are grouped into buckets of [BucketInterval]
+	vm.seen.Add(txs)
+	vm.recordSeen(0)
+	require.Equal(float64(3), testutil.ToFloat64(m.seenTxs))
+	require.Equal(float64(2), testutil.ToFloat64(m.seenBuckets))
+	require.True(vm.seenExceeded)
+
+	marker := vm.IsRepeat(context.Background(), txs[:2], set.NewBits(), false)
+	require.Equal(2, marker.Len())
+	require.Equal(float64(2), testutil.ToFloat64(m.seenRepeats))
+
+	vm.recordSeen(len(vm.seen.SetMin(2_001)))
+	require.Equal(float64(1), testutil.ToFloat64(m.seenTxs))
+	require.Equal(float64(2), testutil.ToFloat64(m.seenEvicted))
+	require.False(vm.seenExceeded)
+}
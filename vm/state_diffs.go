@@ -0,0 +1,75 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ rpc.StateDiffs = (*StateDiffs)(nil)
+
+// StateDiffs stores the changes the last [size] accepted blocks made to
+// state in the vmDB, so mirrors can keep a copy of state without executing
+// transactions (and catch up after a restart).
+//
+// Blocks accepted while state syncing were not executed, so their changes are
+// never stored.
+type StateDiffs struct {
+	db   database.Database
+	size uint64
+}
+
+func NewStateDiffs(db database.Database, size int) *StateDiffs {
+	return &StateDiffs{
+		db:   db,
+		size: uint64(size),
+	}
+}
+
+// Accepted stores the changes of [b] (which must have been processed) and
+// deletes those of the block [size] blocks before it.
+func (s *StateDiffs) Accepted(b *chain.StatelessBlock) error {
+	changes, err := chain.MarshalStateChanges(b.StateChanges())
+	if err != nil {
+		return err
+	}
+	blkID := b.ID()
+	v := make([]byte, 0, ids.IDLen+len(changes))
+	v = append(v, blkID[:]...)
+	v = append(v, changes...)
+
+	batch := s.db.NewBatch()
+	if err := batch.Put(PrefixStateDiffKey(b.Hght), v); err != nil {
+		return err
+	}
+	if b.Hght >= s.size {
+		if err := batch.Delete(PrefixStateDiffKey(b.Hght - s.size)); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func (s *StateDiffs) Get(height uint64) (*rpc.StateDiff, error) {
+	v, err := s.db.Get(PrefixStateDiffKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(v) < ids.IDLen {
+		return nil, chain.ErrInvalidObject
+	}
+	changes, err := chain.UnmarshalStateChanges(v[ids.IDLen:])
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.StateDiff{
+		Height:  height,
+		BlockID: ids.ID(v[:ids.IDLen]),
+		Changes: changes,
+	}, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+func TestStateDiffs(t *testing.T) {
+	require := require.New(t)
+
+	diffs := NewStateDiffs(memdb.New(), 2)
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(diffs.Accepted(&chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Hght: height}}))
+	}
+
+	// Only the diffs of the last 2 blocks are kept
+	_, err := diffs.Get(1)
+	require.ErrorIs(err, database.ErrNotFound)
+	for height := uint64(2); height <= 3; height++ {
+		diff, err := diffs.Get(height)
+		require.NoError(err)
+		require.Equal(height, diff.Height)
+		require.Empty(diff.Changes)
+	}
+	_, err = diffs.Get(4)
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -9,11 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/hypersdk/chain"
@@ -33,11 +35,21 @@ const (
 	blockPrefix         = 0x0 // TODO: move to flat files (https://github.com/ava-labs/hypersdk/issues/553)
 	blockIDHeightPrefix = 0x1 // ID -> Height
 	blockHeightIDPrefix = 0x2 // Height -> ID (don't always need full block from disk)
+	warpMessagePrefix   = 0x3 // msgID -> unsigned message
+	warpSignaturePrefix = 0x4 // msgID|publicKey -> signature
+	eventPrefix         = 0x5 // height -> events not yet published
+	webhookPrefix       = 0x6 // webhookID -> url|secret|addresses
+	chunkPrefix         = 0x7 // chunkID -> chunk of an accepted block
+	chunkHeightPrefix   = 0x8 // height -> IDs of the chunks of the block
+	stateDiffPrefix     = 0x9 // height -> blockID|changes made to state
+	feeSplitPrefix      = 0xa // height -> split of the fees of the block
 )
 
 var (
 	isSyncing    = []byte("is_syncing")
 	lastAccepted = []byte("last_accepted")
+	eventCursor  = []byte("event_cursor")  // height of the last published events
+	exportCursor = []byte("export_cursor") // start of the next exported bundle
 )
 
 func PrefixBlockKey(height uint64) []byte {
@@ -61,6 +73,63 @@ func PrefixBlockHeightIDKey(height uint64) []byte {
 	return k
 }
 
+func PrefixWarpMessageKey(msgID ids.ID) []byte {
+	k := make([]byte, 1+ids.IDLen)
+	k[0] = warpMessagePrefix
+	copy(k[1:], msgID[:])
+	return k
+}
+
+func PrefixEventKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = eventPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+func PrefixWebhookKey(id ids.ID) []byte {
+	k := make([]byte, 1+ids.IDLen)
+	k[0] = webhookPrefix
+	copy(k[1:], id[:])
+	return k
+}
+
+func PrefixWarpSignatureKey(msgID ids.ID, publicKey []byte) []byte {
+	k := make([]byte, 1+ids.IDLen+len(publicKey))
+	k[0] = warpSignaturePrefix
+	copy(k[1:], msgID[:])
+	copy(k[1+ids.IDLen:], publicKey)
+	return k
+}
+
+func PrefixChunkKey(chunkID ids.ID) []byte {
+	k := make([]byte, 1+ids.IDLen)
+	k[0] = chunkPrefix
+	copy(k[1:], chunkID[:])
+	return k
+}
+
+func PrefixChunkHeightKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = chunkHeightPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+func PrefixStateDiffKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = stateDiffPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
+func PrefixFeeSplitKey(height uint64) []byte {
+	k := make([]byte, 1+consts.Uint64Len)
+	k[0] = feeSplitPrefix
+	binary.BigEndian.PutUint64(k[1:], height)
+	return k
+}
+
 func (vm *VM) HasGenesis() (bool, error) {
 	return vm.HasDiskBlock(0)
 }
@@ -93,6 +162,23 @@ func (vm *VM) shouldComapct(expiryHeight uint64) bool {
 	return expiryHeight%uint64(vm.config.BlockCompactionFrequency) == uint64(compactionOffset)
 }
 
+// setLastAccepted sets [lastAccepted] to [blk] and records the current root of
+// [stateDB] as the state it produced.
+//
+// Blocks are accepted one at a time (and committed to [stateDB] before they are
+// marked accepted), so the root read here is the root produced by [blk].
+func (vm *VM) setLastAccepted(ctx context.Context, blk *chain.StatelessBlock) error {
+	root, err := vm.stateDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	vm.acceptedL.Lock()
+	defer vm.acceptedL.Unlock()
+
+	vm.lastAccepted, vm.lastAcceptedRoot = blk, root
+	return nil
+}
+
 // UpdateLastAccepted updates the [lastAccepted] index, stores [blk] on-disk,
 // adds [blk] to the [acceptedCache], and deletes any expired blocks from
 // disk.
@@ -102,7 +188,7 @@ func (vm *VM) shouldComapct(expiryHeight uint64) bool {
 //
 // We store blocks by height because it doesn't cause nearly as much
 // compaction as storing blocks randomly on-disk (when using [block.ID]).
-func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
+func (vm *VM) UpdateLastAccepted(ctx context.Context, blk *chain.StatelessBlock) error {
 	batch := vm.vmDB.NewBatch()
 	bigEndianHeight := binary.BigEndian.AppendUint64(nil, blk.Height())
 	if err := batch.Put(lastAccepted, bigEndianHeight); err != nil {
@@ -118,6 +204,30 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 	if err := batch.Put(PrefixBlockHeightIDKey(blk.Height()), blkID[:]); err != nil {
 		return err
 	}
+	if chunks := blk.GetChunks(); len(chunks) > 0 {
+		chunkIDs := make([]byte, 0, len(chunks)*ids.IDLen)
+		for _, chunk := range chunks {
+			chunkID := chunk.ID()
+			if err := batch.Put(PrefixChunkKey(chunkID), chunk.Bytes()); err != nil {
+				return err
+			}
+			chunkIDs = append(chunkIDs, chunkID[:]...)
+		}
+		if err := batch.Put(PrefixChunkHeightKey(blk.Height()), chunkIDs); err != nil {
+			return err
+		}
+	}
+	// The fee split is only known if the block was executed (blocks accepted
+	// while state syncing are not)
+	if feeSplit := blk.FeeSplit(); feeSplit != nil {
+		v, err := feeSplit.Marshal()
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(PrefixFeeSplitKey(blk.Height()), v); err != nil {
+			return err
+		}
+	}
 	expiryHeight := blk.Height() - uint64(vm.config.AcceptedBlockWindow)
 	var expired bool
 	if expiryHeight > 0 && expiryHeight < blk.Height() { // ensure we don't free genesis
@@ -135,6 +245,12 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 		if err := batch.Delete(PrefixBlockHeightIDKey(expiryHeight)); err != nil {
 			return err
 		}
+		if err := vm.deleteDiskChunks(batch, expiryHeight); err != nil {
+			return err
+		}
+		if err := batch.Delete(PrefixFeeSplitKey(expiryHeight)); err != nil {
+			return err
+		}
 		expired = true
 		vm.metrics.deletedBlocks.Inc()
 		vm.Logger().Info("deleted block", zap.Uint64("height", expiryHeight))
@@ -142,7 +258,9 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 	if err := batch.Write(); err != nil {
 		return fmt.Errorf("%w: unable to update last accepted", err)
 	}
-	vm.lastAccepted = blk
+	if err := vm.setLastAccepted(ctx, blk); err != nil {
+		return err
+	}
 	vm.acceptedBlocksByID.Put(blk.ID(), blk)
 	vm.acceptedBlocksByHeight.Put(blk.Height(), blk.ID())
 	if expired && vm.shouldComapct(expiryHeight) {
@@ -158,6 +276,32 @@ func (vm *VM) UpdateLastAccepted(blk *chain.StatelessBlock) error {
 	return nil
 }
 
+// deleteDiskChunks deletes the chunks of the block at [height] (if any).
+func (vm *VM) deleteDiskChunks(batch database.Batch, height uint64) error {
+	chunkIDs, err := vm.vmDB.Get(PrefixChunkHeightKey(height))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for i := 0; i+ids.IDLen <= len(chunkIDs); i += ids.IDLen {
+		if err := batch.Delete(PrefixChunkKey(ids.ID(chunkIDs[i : i+ids.IDLen]))); err != nil {
+			return err
+		}
+	}
+	return batch.Delete(PrefixChunkHeightKey(height))
+}
+
+// GetDiskChunk returns the chunk [chunkID] included by an accepted block.
+func (vm *VM) GetDiskChunk(chunkID ids.ID) (*chain.Chunk, error) {
+	b, err := vm.vmDB.Get(PrefixChunkKey(chunkID))
+	if err != nil {
+		return nil, err
+	}
+	return chain.UnmarshalChunk(b, vm)
+}
+
 func (vm *VM) GetDiskBlock(ctx context.Context, height uint64) (*chain.StatelessBlock, error) {
 	b, err := vm.vmDB.Get(PrefixBlockKey(height))
 	if err != nil {
@@ -166,6 +310,16 @@ func (vm *VM) GetDiskBlock(ctx context.Context, height uint64) (*chain.Stateless
 	return chain.ParseBlock(ctx, b, choices.Accepted, vm)
 }
 
+// GetFeeSplit returns how the base fees collected by the accepted block at
+// [height] were split between its proposer and burning them.
+func (vm *VM) GetFeeSplit(height uint64) (*chain.FeeSplit, error) {
+	b, err := vm.vmDB.Get(PrefixFeeSplitKey(height))
+	if err != nil {
+		return nil, err
+	}
+	return chain.UnmarshalFeeSplit(b)
+}
+
 func (vm *VM) HasDiskBlock(height uint64) (bool, error) {
 	return vm.vmDB.Has(PrefixBlockKey(height))
 }
@@ -211,3 +365,44 @@ func (vm *VM) PutDiskIsSyncing(v bool) error {
 	}
 	return vm.vmDB.Put(isSyncing, []byte{0x0})
 }
+
+// StoreWarpMessage persists an outgoing warp message so that its signatures
+// can be served after it is no longer in an accepted block in memory.
+func (vm *VM) StoreWarpMessage(msg *warp.UnsignedMessage) error {
+	return vm.vmDB.Put(PrefixWarpMessageKey(msg.ID()), msg.Bytes())
+}
+
+func (vm *VM) GetWarpMessage(msgID ids.ID) (*warp.UnsignedMessage, error) {
+	v, err := vm.vmDB.Get(PrefixWarpMessageKey(msgID))
+	if err != nil {
+		return nil, err
+	}
+	return warp.ParseUnsignedMessage(v)
+}
+
+// StoreWarpSignature persists a verified signature of [msgID] by the validator
+// with [publicKey] (in compressed form).
+func (vm *VM) StoreWarpSignature(msgID ids.ID, publicKey []byte, signature []byte) error {
+	return vm.vmDB.Put(PrefixWarpSignatureKey(msgID, publicKey), signature)
+}
+
+func (vm *VM) GetWarpSignature(msgID ids.ID, publicKey []byte) ([]byte, error) {
+	return vm.vmDB.Get(PrefixWarpSignatureKey(msgID, publicKey))
+}
+
+// GetWarpSignatures returns all signatures collected for [msgID].
+func (vm *VM) GetWarpSignatures(msgID ids.ID) ([]*chain.WarpSignature, error) {
+	prefix := PrefixWarpMessageKey(msgID)
+	prefix[0] = warpSignaturePrefix
+	iter := vm.vmDB.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	signatures := []*chain.WarpSignature{}
+	for iter.Next() {
+		signatures = append(signatures, &chain.WarpSignature{
+			PublicKey: slices.Clone(iter.Key()[len(prefix):]),
+			Signature: slices.Clone(iter.Value()),
+		})
+	}
+	return signatures, iter.Error()
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/cache"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/emap"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ rpc.TxStatuses = (*TxStatuses)(nil)
+
+// TxStatuses tracks the [rpc.TxStatus] of every transaction the node admits
+// to its mempool or sees in a processing block.
+//
+// Transactions that have not reached a final state are tracked until they
+// expire, and the last [size] transactions that reached a final state are
+// remembered after that.
+type TxStatuses struct {
+	l        sync.Mutex
+	active   map[ids.ID]*rpc.TxStatus
+	expiring *emap.EMap[*chain.Transaction]
+	final    *cache.FIFO[ids.ID, *rpc.TxStatus]
+
+	// publish is called with a copy of each new status (while [l] is held, so
+	// the statuses of a transaction are published in order)
+	publish func(*rpc.TxStatus)
+}
+
+// NewTxStatuses creates a [TxStatuses] that remembers the last [size]
+// transactions that reached a final state and calls [publish] with each
+// status change.
+func NewTxStatuses(size int, publish func(*rpc.TxStatus)) (*TxStatuses, error) {
+	final, err := cache.NewFIFO[ids.ID, *rpc.TxStatus](size)
+	if err != nil {
+		return nil, err
+	}
+	return &TxStatuses{
+		active:   map[ids.ID]*rpc.TxStatus{},
+		expiring: emap.NewEMap[*chain.Transaction](),
+		final:    final,
+		publish:  publish,
+	}, nil
+}
+
+// Get returns the last known status of [txID].
+func (t *TxStatuses) Get(txID ids.ID) (*rpc.TxStatus, bool) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if s, ok := t.active[txID]; ok {
+		c := *s
+		return &c, true
+	}
+	if s, ok := t.final.Get(txID); ok {
+		c := *s
+		return &c, true
+	}
+	return nil, false
+}
+
+// Pending records that [txs] were admitted to the mempool. Transactions that
+// are already tracked are ignored.
+func (t *TxStatuses) Pending(txs []*chain.Transaction) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	for _, tx := range txs {
+		t.track(tx, rpc.TxPending, 0)
+	}
+}
+
+// Included records that the transactions of [blk] are included in a
+// processing block.
+func (t *TxStatuses) Included(blk *chain.StatelessBlock) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	for _, tx := range blk.Txs {
+		t.track(tx, rpc.TxIncluded, blk.Hght)
+	}
+}
+
+// Rejected records that the transactions of [blk] are pending again (unless
+// another processing block at a different height includes them).
+func (t *TxStatuses) Rejected(blk *chain.StatelessBlock) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	for _, tx := range blk.Txs {
+		s, ok := t.active[tx.ID()]
+		if !ok || s.State != rpc.TxIncluded || s.Height != blk.Hght {
+			continue
+		}
+		t.update(s, rpc.TxPending, 0, nil)
+	}
+}
+
+// Accepted records the results of the transactions of [blk] (which must have
+// been processed) and expires the transactions that can no longer be
+// included after it.
+func (t *TxStatuses) Accepted(blk *chain.StatelessBlock) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	results := blk.Results()
+	for i, tx := range blk.Txs {
+		s := t.track(tx, rpc.TxIncluded, blk.Hght)
+		if s == nil {
+			continue
+		}
+		if results[i].Success {
+			t.update(s, rpc.TxAccepted, blk.Hght, nil)
+		} else {
+			t.update(s, rpc.TxFailed, blk.Hght, results[i].Error)
+		}
+	}
+	for _, txID := range t.expiring.SetMin(blk.Tmstmp) {
+		if s, ok := t.active[txID]; ok {
+			t.update(s, rpc.TxExpired, 0, nil)
+		}
+	}
+}
+
+// track starts tracking [tx] (unless it is already tracked) and moves it to
+// [state]. It returns nil if [tx] already reached a final state.
+func (t *TxStatuses) track(tx *chain.Transaction, state rpc.TxState, height uint64) *rpc.TxStatus {
+	txID := tx.ID()
+	if _, ok := t.final.Get(txID); ok {
+		return nil
+	}
+	s, ok := t.active[txID]
+	if !ok {
+		s = &rpc.TxStatus{TxID: txID, State: state, Expiry: tx.Expiry(), Height: height, Updated: time.Now().UnixMilli()}
+		t.active[txID] = s
+		t.expiring.Add([]*chain.Transaction{tx})
+		t.notify(s)
+		return s
+	}
+	if state == rpc.TxIncluded && (s.State != state || s.Height != height) {
+		t.update(s, state, height, nil)
+	}
+	return s
+}
+
+// update moves [s] to [state] (and to [final] if [state] is final).
+func (t *TxStatuses) update(s *rpc.TxStatus, state rpc.TxState, height uint64, err []byte) {
+	s.State = state
+	s.Height = height
+	s.Updated = time.Now().UnixMilli()
+	s.Error = err
+	if state.Final() {
+		delete(t.active, s.TxID)
+		t.final.Put(s.TxID, s)
+	}
+	t.notify(s)
+}
+
+func (t *TxStatuses) notify(s *rpc.TxStatus) {
+	c := *s
+	t.publish(&c)
+}
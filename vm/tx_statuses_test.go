@@ -0,0 +1,81 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+func TestTxStatuses(t *testing.T) {
+	require := require.New(t)
+
+	actionRegistry := codec.NewTypeParser[chain.Action]()
+	authRegistry := codec.NewTypeParser[chain.Auth]()
+	require.NoError(actionRegistry.Register((&testAction{}).GetTypeID(), unmarshalTestAction))
+	require.NoError(authRegistry.Register((&testAuth{}).GetTypeID(), unmarshalTestAuth))
+	newTx := func(expiry int64) *chain.Transaction {
+		tx, err := chain.NewTx(
+			&chain.Base{Timestamp: expiry, ChainID: ids.GenerateTestID(), MaxFee: 100},
+			[]chain.Action{&testAction{}},
+		).Sign(&testAuthFactory{actor: codec.CreateAddress(0, ids.GenerateTestID())}, actionRegistry, authRegistry)
+		require.NoError(err)
+		return tx
+	}
+	newBlock := func(height uint64, timestamp int64, txs ...*chain.Transaction) *chain.StatelessBlock {
+		return &chain.StatelessBlock{StatefulBlock: &chain.StatefulBlock{Hght: height, Tmstmp: timestamp, Txs: txs}}
+	}
+
+	published := []rpc.TxState{}
+	statuses, err := NewTxStatuses(1, func(s *rpc.TxStatus) {
+		published = append(published, s.State)
+	})
+	require.NoError(err)
+
+	tx := newTx(1_000)
+	_, ok := statuses.Get(tx.ID())
+	require.False(ok)
+	statuses.Pending([]*chain.Transaction{tx})
+	s, ok := statuses.Get(tx.ID())
+	require.True(ok)
+	require.Equal(rpc.TxPending, s.State)
+	require.Equal(int64(1_000), s.Expiry)
+
+	// Transactions are pending again if the block that includes them is
+	// rejected
+	blk := newBlock(1, 500, tx)
+	statuses.Included(blk)
+	s, _ = statuses.Get(tx.ID())
+	require.Equal(rpc.TxIncluded, s.State)
+	require.Equal(uint64(1), s.Height)
+	statuses.Rejected(blk)
+	s, _ = statuses.Get(tx.ID())
+	require.Equal(rpc.TxPending, s.State)
+	require.Zero(s.Height)
+
+	// Transactions that are not included before their expiry expire
+	statuses.Accepted(newBlock(1, 1_001))
+	s, _ = statuses.Get(tx.ID())
+	require.Equal(rpc.TxExpired, s.State)
+	require.Equal([]rpc.TxState{rpc.TxPending, rpc.TxIncluded, rpc.TxPending, rpc.TxExpired}, published)
+
+	// Expired transactions are not tracked again
+	statuses.Pending([]*chain.Transaction{tx})
+	require.Len(published, 4)
+
+	// Only the last [size] final statuses are remembered
+	other := newTx(2_000)
+	statuses.Pending([]*chain.Transaction{other})
+	statuses.Accepted(newBlock(2, 2_001))
+	_, ok = statuses.Get(tx.ID())
+	require.False(ok)
+	s, _ = statuses.Get(other.ID())
+	require.Equal(rpc.TxExpired, s.State)
+}
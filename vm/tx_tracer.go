@@ -0,0 +1,104 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/state"
+)
+
+var (
+	_ rpc.TxTracer    = (*TxTracer)(nil)
+	_ state.Immutable = (*historicalState)(nil)
+)
+
+type TxTraceConfig struct {
+	// AuthToken must be provided as a bearer token to trace transactions over
+	// RPC (traces are not served if empty). Tracing requires [IndexerEnabled].
+	AuthToken string `json:"authToken"`
+}
+
+// TxTracer re-executes accepted transactions on top of the state their block
+// was built on, so it can only trace transactions accepted in the last
+// [StateHistoryLength] blocks.
+type TxTracer struct {
+	vm     *VM
+	config TxTraceConfig
+}
+
+func NewTxTracer(vm *VM, config TxTraceConfig) *TxTracer {
+	return &TxTracer{
+		vm:     vm,
+		config: config,
+	}
+}
+
+func (t *TxTracer) Authorized(token string) bool {
+	if len(t.config.AuthToken) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(t.config.AuthToken)) == 1
+}
+
+func (t *TxTracer) Trace(ctx context.Context, txID ids.ID) (*chain.TxTrace, error) {
+	indexer := t.vm.Indexer()
+	if indexer == nil {
+		return nil, rpc.ErrIndexerDisabled
+	}
+	height, _, _, _, err := indexer.GetTx(txID)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, rpc.ErrTxMissing
+	}
+	if err != nil {
+		return nil, err
+	}
+	blk, err := t.vm.GetDiskBlock(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load block %d: %w", height, err)
+	}
+	index := -1
+	for i, tx := range blk.Txs {
+		if tx.ID() == txID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("%w: not in block %d", rpc.ErrTxMissing, height)
+	}
+	return blk.TraceTx(ctx, &historicalState{db: t.vm.stateDB, root: blk.StateRoot}, index)
+}
+
+// historicalState reads the state at [root] one key at a time. [root] must be
+// one of the last [StateHistoryLength] roots of [db].
+type historicalState struct {
+	db   merkledb.MerkleDB
+	root ids.ID
+}
+
+func (h *historicalState) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	proof, err := h.db.GetRangeProofAtRoot(ctx, h.root, maybe.Some(key), maybe.Some(key), 1)
+	if errors.Is(err, merkledb.ErrInsufficientHistory) {
+		return nil, fmt.Errorf("%w: state %s is no longer kept", err, h.root)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.KeyValues) == 0 || !bytes.Equal(proof.KeyValues[0].Key, key) {
+		return nil, database.ErrNotFound
+	}
+	return proof.KeyValues[0].Value, nil
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+var _ rpc.UnitProfiler = (*UnitProfiler)(nil)
+
+type actionProfile struct {
+	name  string
+	count uint64
+	units fees.Dimensions
+}
+
+type namespaceProfile struct {
+	keys     uint64
+	read     uint64
+	allocate uint64
+	write    uint64
+}
+
+type blockProfile struct {
+	height     uint64
+	txs        int
+	units      fees.Dimensions
+	actions    map[uint8]*actionProfile
+	namespaces map[byte]*namespaceProfile
+}
+
+// UnitProfiler keeps where the units consumed by the last [size] blocks
+// accepted by this node were spent in memory (so it starts empty whenever the
+// node restarts).
+type UnitProfiler struct {
+	size int
+
+	// names caches the name of each action type ID. It is only accessed by
+	// [record], which is called once for each accepted block (in order).
+	names [256]string
+
+	l      sync.RWMutex
+	blocks []*blockProfile // oldest first
+}
+
+func NewUnitProfiler(size int) *UnitProfiler {
+	return &UnitProfiler{
+		size:   size,
+		blocks: make([]*blockProfile, 0, size),
+	}
+}
+
+// Accepted profiles [b], which must have been processed.
+func (p *UnitProfiler) Accepted(b *chain.StatelessBlock, sm chain.StateManager, r chain.Rules) error {
+	breakdowns := make([]*chain.UnitBreakdown, len(b.Txs))
+	for i, tx := range b.Txs {
+		breakdown, err := chain.NewUnitBreakdown(tx, sm, r)
+		if err != nil {
+			return err
+		}
+		breakdowns[i] = breakdown
+	}
+	return p.record(b.Hght, b.Txs, b.Results(), breakdowns)
+}
+
+func (p *UnitProfiler) record(
+	height uint64,
+	txs []*chain.Transaction,
+	results []*chain.Result,
+	breakdowns []*chain.UnitBreakdown,
+) error {
+	entry := &blockProfile{
+		height:     height,
+		txs:        len(txs),
+		actions:    map[uint8]*actionProfile{},
+		namespaces: map[byte]*namespaceProfile{},
+	}
+	for i, tx := range txs {
+		units := results[i].Units
+		total, err := fees.Add(entry.units, units)
+		if err != nil {
+			return err
+		}
+		entry.units = total
+
+		// The units of a transaction are split evenly between its actions
+		// (with any remainder attributed to the first one)
+		n := uint64(len(tx.Actions))
+		for j, action := range tx.Actions {
+			typeID := action.GetTypeID()
+			ap, ok := entry.actions[typeID]
+			if !ok {
+				ap = &actionProfile{name: p.name(action)}
+				entry.actions[typeID] = ap
+			}
+			ap.count++
+			for d := range units {
+				share := units[d] / n
+				if j == 0 {
+					share += units[d] % n
+				}
+				ap.units[d] += share
+			}
+		}
+		for _, k := range breakdowns[i].Keys {
+			np, ok := entry.namespaces[k.Key[0]]
+			if !ok {
+				np = &namespaceProfile{}
+				entry.namespaces[k.Key[0]] = np
+			}
+			np.keys++
+			np.read += k.Read
+			np.allocate += k.Allocate
+			np.write += k.Write
+		}
+	}
+
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	if len(p.blocks) == p.size {
+		copy(p.blocks, p.blocks[1:])
+		p.blocks = p.blocks[:p.size-1]
+	}
+	p.blocks = append(p.blocks, entry)
+	return nil
+}
+
+func (p *UnitProfiler) name(action chain.Action) string {
+	id := action.GetTypeID()
+	if name := p.names[id]; len(name) > 0 {
+		return name
+	}
+	name := actionName(action)
+	p.names[id] = name
+	return name
+}
+
+func (p *UnitProfiler) Profile(start uint64, end uint64) (*rpc.UnitProfile, error) {
+	p.l.RLock()
+	defer p.l.RUnlock()
+
+	var (
+		profile    = &rpc.UnitProfile{}
+		actions    = map[uint8]*rpc.ActionUnits{}
+		namespaces = map[byte]*rpc.NamespaceUnits{}
+	)
+	for _, entry := range p.blocks {
+		if entry.height < start || entry.height > end {
+			continue
+		}
+		if profile.Blocks == 0 {
+			profile.StartHeight = entry.height
+		}
+		profile.EndHeight = entry.height
+		profile.Blocks++
+		profile.Txs += entry.txs
+		units, err := fees.Add(profile.Units, entry.units)
+		if err != nil {
+			return nil, err
+		}
+		profile.Units = units
+		for typeID, ap := range entry.actions {
+			au, ok := actions[typeID]
+			if !ok {
+				au = &rpc.ActionUnits{Name: ap.name, TypeID: typeID}
+				actions[typeID] = au
+			}
+			au.Count += ap.count
+			units, err := fees.Add(au.Units, ap.units)
+			if err != nil {
+				return nil, err
+			}
+			au.Units = units
+		}
+		for prefix, np := range entry.namespaces {
+			nu, ok := namespaces[prefix]
+			if !ok {
+				nu = &rpc.NamespaceUnits{Namespace: fmt.Sprintf("0x%02x", prefix)}
+				namespaces[prefix] = nu
+			}
+			nu.Keys += np.keys
+			nu.Read += np.read
+			nu.Allocate += np.allocate
+			nu.Write += np.write
+		}
+	}
+	if profile.Blocks == 0 {
+		return nil, fmt.Errorf("%w: no profiled blocks in %d-%d", rpc.ErrUnitProfileMissing, start, end)
+	}
+	profile.Actions = make([]*rpc.ActionUnits, 0, len(actions))
+	for _, au := range actions {
+		profile.Actions = append(profile.Actions, au)
+	}
+	sort.Slice(profile.Actions, func(i, j int) bool {
+		return profile.Actions[i].TypeID < profile.Actions[j].TypeID
+	})
+	profile.Namespaces = make([]*rpc.NamespaceUnits, 0, len(namespaces))
+	for _, nu := range namespaces {
+		profile.Namespaces = append(profile.Namespaces, nu)
+	}
+	sort.Slice(profile.Namespaces, func(i, j int) bool {
+		return profile.Namespaces[i].Namespace < profile.Namespaces[j].Namespace
+	})
+	return profile, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/rpc"
+)
+
+type otherTestAction struct {
+	testAction
+}
+
+func (*otherTestAction) GetTypeID() uint8 { return 1 }
+
+func TestUnitProfiler(t *testing.T) {
+	require := require.New(t)
+
+	p := NewUnitProfiler(2)
+	txs := []*chain.Transaction{
+		chain.NewTx(&chain.Base{}, []chain.Action{&testAction{}}),
+		chain.NewTx(&chain.Base{}, []chain.Action{&testAction{}, &otherTestAction{}}),
+	}
+	results := []*chain.Result{
+		{Units: fees.Dimensions{10, 20, 30, 40, 50}},
+		{Units: fees.Dimensions{5, 5, 5, 5, 5}},
+	}
+	breakdowns := []*chain.UnitBreakdown{
+		{Keys: []*chain.KeyUnits{{Key: []byte{0, 1}, Read: 1, Allocate: 2, Write: 3}}},
+		{Keys: []*chain.KeyUnits{{Key: []byte{0, 2}, Read: 1, Allocate: 2, Write: 3}, {Key: []byte{1}, Read: 4}}},
+	}
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(p.record(height, txs, results, breakdowns))
+	}
+
+	// Only the last 2 blocks are kept
+	_, err := p.Profile(0, 1)
+	require.ErrorIs(err, rpc.ErrUnitProfileMissing)
+	profile, err := p.Profile(0, 10)
+	require.NoError(err)
+	require.Equal(uint64(2), profile.StartHeight)
+	require.Equal(uint64(3), profile.EndHeight)
+	require.Equal(2, profile.Blocks)
+	require.Equal(4, profile.Txs)
+	require.Equal(fees.Dimensions{30, 50, 70, 90, 110}, profile.Units)
+
+	// The units of a tx are split between its actions
+	require.Equal([]*rpc.ActionUnits{
+		{Name: "test_action", TypeID: 0, Count: 4, Units: fees.Dimensions{26, 46, 66, 86, 106}},
+		{Name: "other_test_action", TypeID: 1, Count: 2, Units: fees.Dimensions{4, 4, 4, 4, 4}},
+	}, profile.Actions)
+	require.Equal([]*rpc.NamespaceUnits{
+		{Namespace: "0x00", Keys: 4, Read: 4, Allocate: 8, Write: 12},
+		{Namespace: "0x01", Keys: 2, Read: 8},
+	}, profile.Namespaces)
+
+	profile, err = p.Profile(3, 3)
+	require.NoError(err)
+	require.Equal(1, profile.Blocks)
+	require.Equal(fees.Dimensions{15, 25, 35, 45, 55}, profile.Units)
+}
@@ -4,22 +4,30 @@
 package vm
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
@@ -27,17 +35,25 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/hypersdk/admission"
+	"github.com/ava-labs/hypersdk/audit"
 	"github.com/ava-labs/hypersdk/builder"
-	"github.com/ava-labs/hypersdk/cache"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/codec/address"
+	"github.com/ava-labs/hypersdk/config"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/emap"
+	"github.com/ava-labs/hypersdk/events"
+	"github.com/ava-labs/hypersdk/export"
 	"github.com/ava-labs/hypersdk/fees"
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/mempool"
 	"github.com/ava-labs/hypersdk/network"
-	"github.com/ava-labs/hypersdk/pebble"
+	"github.com/ava-labs/hypersdk/postgres"
 	"github.com/ava-labs/hypersdk/rpc"
 	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/statecache"
 	"github.com/ava-labs/hypersdk/storage"
 	"github.com/ava-labs/hypersdk/trace"
 	"github.com/ava-labs/hypersdk/utils"
@@ -52,7 +68,9 @@ import (
 const (
 	blockDB   = "blockdb"
 	stateDB   = "statedb"
+	indexDB   = "indexdb"
 	vmDataDir = "vm"
+	auditDir  = "audit"
 )
 
 type VM struct {
@@ -80,6 +98,7 @@ type VM struct {
 
 	// track all accepted but still valid txs (replay protection)
 	seen                   *emap.EMap[*chain.Transaction]
+	seenExceeded           bool // only accessed while accepting blocks
 	startSeenTime          int64
 	seenValidityWindowOnce sync.Once
 	seenValidityWindow     chan struct{}
@@ -92,15 +111,22 @@ type VM struct {
 	verifiedL      sync.RWMutex
 	verifiedBlocks map[ids.ID]*chain.StatelessBlock
 
-	// We store the last [AcceptedBlockWindowCache] blocks in memory
-	// to avoid reading blocks from disk.
-	acceptedBlocksByID     *cache.FIFO[ids.ID, *chain.StatelessBlock]
-	acceptedBlocksByHeight *cache.FIFO[uint64, ids.ID]
+	// We store recently accepted blocks (and their transactions) in memory
+	// to avoid reading them from disk (see [CacheConfig]).
+	acceptedBlocksByID     *lruCache[ids.ID, *chain.StatelessBlock]
+	acceptedBlocksByHeight *lruCache[uint64, ids.ID]
+	parsedTxs              *lruCache[ids.ID, *chain.Transaction]
+	results                *lruCache[ids.ID, *acceptedResult]
 
 	// Accepted block queue
 	acceptedQueue chan *chain.StatelessBlock
 	acceptorDone  chan struct{}
 
+	// acceptedProcessors are registered during initialization (and only read
+	// by the acceptor after that)
+	acceptedProcessors []*AcceptedProcessor
+	acceptedSealed     bool
+
 	// Transactions that streaming users are currently subscribed to
 	webSocketServer *rpc.WebSocketServer
 
@@ -108,11 +134,23 @@ type VM struct {
 	// with limited parallelism
 	authVerifiers workers.Workers
 
+	// executionTuner and stateFetchTuner size the pools created for each
+	// block (nil if those pools have a fixed size)
+	executionTuner  *workers.Tuner
+	stateFetchTuner *workers.Tuner
+
 	bootstrapped avautils.Atomic[bool]
 	genesisBlk   *chain.StatelessBlock
 	preferred    ids.ID
-	lastAccepted *chain.StatelessBlock
-	toEngine     chan<- common.Message
+
+	// [lastAccepted] and the root of the state it produced are updated
+	// together under [acceptedL] so they can be read as a consistent snapshot
+	// (blocks are committed to [stateDB] before [lastAccepted] is updated).
+	acceptedL        sync.RWMutex
+	lastAccepted     *chain.StatelessBlock
+	lastAcceptedRoot ids.ID
+
+	toEngine chan<- common.Message
 
 	// State Sync client and AppRequest handlers
 	stateSyncClient        *stateSyncerClient
@@ -122,6 +160,57 @@ type VM struct {
 	// Network manager routes p2p messages to pre-registered handlers
 	networkManager *network.Manager
 
+	// Signs and collects signatures of outgoing warp messages
+	warpCollector *WarpCollector
+
+	// Disseminates and certifies chunks of transactions
+	chunkManager *ChunkManager
+
+	// Accepts (and forwards) transactions submitted directly to proposers
+	directSubmission *DirectSubmission
+
+	// Indexes accepted blocks (nil if [Config.IndexerEnabled] is false)
+	indexer *Indexer
+
+	// Publishes the events of accepted blocks (nil if the event sink is
+	// disabled)
+	eventStreamer *EventStreamer
+
+	// Writes indexed blocks to PostgreSQL (nil if disabled)
+	postgresWriter *PostgresWriter
+	blockExporter  *BlockExporter
+
+	// Notifies webhooks of the activity of watched addresses (nil if
+	// disabled)
+	webhooks *WebhookNotifier
+
+	// Serves the fees of recently accepted blocks (nil if disabled)
+	feeHistory *FeeHistory
+	txStatuses *TxStatuses
+	stateDiffs *StateDiffs
+	// Profiles the units of recently accepted blocks (nil if disabled)
+	unitProfiler *UnitProfiler
+
+	// Checks the invariants registered by the Controller (nil if there are
+	// none)
+	invariants *InvariantChecker
+
+	// Re-executes accepted transactions to debug them (nil if disabled)
+	txTracer *TxTracer
+
+	// Records security-relevant events (nil if disabled)
+	audit *audit.Logger
+
+	// Holds the admission policy of the node (nil if there is no policy file)
+	admission *admission.Watcher
+
+	// Mirrors the mempool to external builders (nil if disabled)
+	externalBuilder *ExternalBuilder
+
+	// Credited with the proposer rewards of the blocks built by the node
+	// (empty if they are burned)
+	proposerAddress codec.Address
+
 	metrics  *Metrics
 	profiler profiler.ContinuousProfiler
 
@@ -154,8 +243,6 @@ func (vm *VM) Initialize(
 	// This will be overwritten when we accept the first block (in state sync) or
 	// backfill existing blocks (during normal bootstrapping).
 	vm.startSeenTime = -1
-	// Init seen for tracking transactions that have been accepted on-chain
-	vm.seen = emap.NewEMap[*chain.Transaction]()
 	vm.seenValidityWindow = make(chan struct{})
 	vm.ready = make(chan struct{})
 	vm.stop = make(chan struct{})
@@ -171,17 +258,104 @@ func (vm *VM) Initialize(
 	vm.proposerMonitor = NewProposerMonitor(vm)
 	vm.networkManager = network.NewManager(vm.snowCtx.Log, vm.snowCtx.NodeID, appSender)
 
-	pebbleConfig := pebble.NewDefaultConfig()
-	vm.vmDB, err = storage.New(pebbleConfig, vm.snowCtx.ChainDataDir, blockDB, vm.snowCtx.Metrics)
+	if err := config.Load(configBytes, &vm.config, ConfigEnvPrefix); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	// Init seen for tracking transactions that have been accepted on-chain
+	vm.seen = newSeen(vm.config.SeenConfig)
+	if vm.config.AuditConfig.Enabled {
+		if len(vm.config.AuditConfig.Directory) == 0 {
+			vm.config.AuditConfig.Directory = filepath.Join(vm.snowCtx.ChainDataDir, auditDir)
+		}
+		vm.audit, err = audit.New(vm.snowCtx.Log, vm.config.AuditConfig)
+		if err != nil {
+			return fmt.Errorf("unable to open audit log: %w", err)
+		}
+		// The config may include secrets (like auth tokens), so only its
+		// digest is recorded
+		digest := sha256.Sum256(configBytes)
+		vm.audit.Record(audit.ConfigCategory, "load", "", nil, map[string]any{
+			"sha256": hex.EncodeToString(digest[:]),
+		})
+	}
+
+	vm.vmDB, err = vm.newDB(blockDB)
 	if err != nil {
 		return err
 	}
 
-	vm.rawStateDB, err = storage.New(pebbleConfig, vm.snowCtx.ChainDataDir, stateDB, vm.snowCtx.Metrics)
+	if len(vm.config.AdmissionConfig.PolicyFile) > 0 {
+		vm.admission, err = vm.newAdmissionWatcher()
+		if err != nil {
+			return fmt.Errorf("unable to load admission policy: %w", err)
+		}
+	}
+
+	vm.rawStateDB, err = vm.newDB(stateDB)
 	if err != nil {
 		return err
 	}
 
+	if vm.config.IndexerEnabled {
+		db, err := vm.newDB(indexDB)
+		if err != nil {
+			return err
+		}
+		vm.indexer = NewIndexer(db, vm.c)
+	}
+
+	if vm.config.EventSinkConfig.Enabled {
+		sink, err := events.New(vm.config.EventSinkConfig)
+		if err != nil {
+			return fmt.Errorf("unable to create event sink: %w", err)
+		}
+		vm.eventStreamer = NewEventStreamer(vm, vm.config.EventSinkConfig, sink)
+	}
+
+	if vm.config.PostgresConfig.Enabled {
+		if vm.indexer == nil {
+			return fmt.Errorf("%w: postgres writer reads indexed blocks", ErrIndexerRequired)
+		}
+		db, err := postgres.Open(vm.config.PostgresConfig)
+		if err != nil {
+			return fmt.Errorf("unable to open postgres: %w", err)
+		}
+		vm.postgresWriter = NewPostgresWriter(vm, vm.config.PostgresConfig, db)
+	}
+
+	if vm.config.ExportConfig.Enabled {
+		if vm.indexer == nil {
+			return fmt.Errorf("%w: block exporter reads indexed blocks", ErrIndexerRequired)
+		}
+		store, err := export.New(vm.config.ExportConfig)
+		if err != nil {
+			return fmt.Errorf("unable to create export store: %w", err)
+		}
+		vm.blockExporter = NewBlockExporter(vm, vm.config.ExportConfig, store)
+	}
+
+	if vm.config.WebhookConfig.Enabled {
+		vm.webhooks, err = NewWebhookNotifier(vm, vm.config.WebhookConfig)
+		if err != nil {
+			return fmt.Errorf("unable to load webhooks: %w", err)
+		}
+	}
+	if vm.config.FeeHistorySize > 0 {
+		vm.feeHistory = NewFeeHistory(vm.config.FeeHistorySize)
+	}
+	if vm.config.StateDiffSize > 0 {
+		vm.stateDiffs = NewStateDiffs(vm.vmDB, vm.config.StateDiffSize)
+	}
+	if vm.config.UnitProfileSize > 0 {
+		vm.unitProfiler = NewUnitProfiler(vm.config.UnitProfileSize)
+	}
+	if len(vm.config.ProposerAddress) > 0 {
+		_, vm.proposerAddress, err = address.Decode(vm.config.ProposerAddress)
+		if err != nil {
+			return fmt.Errorf("unable to parse proposer address: %w", err)
+		}
+	}
+
 	// TODO do not expose entire context to the Controller
 	//
 	// Note: does not copy the consensus lock but this is safe because the
@@ -205,10 +379,6 @@ func (vm *VM) Initialize(
 		ChainDataDir:   filepath.Join(vm.snowCtx.ChainDataDir, vmDataDir),
 	}
 
-	if err := json.Unmarshal(configBytes, &vm.config); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
-	}
-
 	controllerConfigBytes, err := json.Marshal(vm.config.Config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal controller config: %w", err)
@@ -226,6 +396,14 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return fmt.Errorf("implementation initialization failed: %w", err)
 	}
+	if ic, ok := vm.c.(InvariantController); ok {
+		if invariants := ic.Invariants(); len(invariants) > 0 {
+			vm.invariants = NewInvariantChecker(vm, vm.config.InvariantConfig, invariants)
+		}
+	}
+	if len(vm.config.TxTraceConfig.AuthToken) > 0 {
+		vm.txTracer = NewTxTracer(vm, vm.config.TxTraceConfig)
+	}
 
 	// Setup tracer
 	vm.tracer, err = trace.New(&vm.config.TraceConfig)
@@ -263,26 +441,74 @@ func (vm *VM) Initialize(
 	if err := vm.snowCtx.Metrics.Register("state", merkleRegistry); err != nil {
 		return err
 	}
+	if cfg := vm.config.StateCacheConfig; cfg.Enabled() {
+		cachedStateDB, cacheRegistry, err := statecache.New(vm.stateDB, cfg)
+		if err != nil {
+			return err
+		}
+		if err := vm.snowCtx.Metrics.Register("statecache", cacheRegistry); err != nil {
+			return err
+		}
+		vm.stateDB = cachedStateDB
+	}
 
 	// Setup worker cluster for verifying signatures
 	//
-	// If [parallelism] is odd, we assign the extra
-	// core to signature verification.
-	vm.authVerifiers = workers.NewParallel(vm.config.AuthVerificationCores, 100) // TODO: make job backlog a const
+	// Gossip is verified by background jobs, so it never delays the
+	// verification of blocks.
+	if vm.config.AuthVerificationMaxCores > vm.config.AuthVerificationCores {
+		vm.authVerifiers, err = workers.NewAdaptive(&workers.AdaptiveConfig{
+			MinWorkers:    vm.config.AuthVerificationCores,
+			MaxWorkers:    vm.config.AuthVerificationMaxCores,
+			TargetLatency: workers.DefaultTargetLatency,
+			Interval:      workers.DefaultTuneInterval,
+		}, 100, vm.metrics.authVerifierRecorder) // TODO: make job backlog a const
+		if err != nil {
+			return fmt.Errorf("unable to create auth verifiers: %w", err)
+		}
+	} else {
+		vm.authVerifiers = workers.NewParallelWithMetrics(vm.config.AuthVerificationCores, 100, vm.metrics.authVerifierRecorder)
+	}
+
+	// Setup tuners for the pools that execute (and fetch the state of) each
+	// block
+	//
+	// These pools only live as long as it takes to process a block, so they
+	// can't be resized while they run. Instead, each block reads the size of
+	// its pools from a tuner that is fed the queue latency of all blocks.
+	vm.executionTuner, err = newTuner(vm.config.TransactionExecutionCores, vm.config.TransactionExecutionMaxCores)
+	if err != nil {
+		return fmt.Errorf("unable to create execution tuner: %w", err)
+	}
+	vm.metrics.executorBuildRecorder.tuner = vm.executionTuner
+	vm.metrics.executorVerifyRecorder.tuner = vm.executionTuner
+	vm.metrics.executorOptimisticRecorder.tuner = vm.executionTuner
+	vm.stateFetchTuner, err = newTuner(vm.config.StateFetchConcurrency, vm.config.StateFetchMaxConcurrency)
+	if err != nil {
+		return fmt.Errorf("unable to create state fetch tuner: %w", err)
+	}
+	vm.metrics.stateFetchRecorder.tuner = vm.stateFetchTuner
+	vm.metrics.executorWorkers.Set(float64(vm.GetTransactionExecutionCores()))
+	vm.metrics.stateFetcherWorkers.Set(float64(vm.GetStateFetchConcurrency()))
 
 	// Init channels before initializing other structs
 	vm.toEngine = toEngine
 
 	vm.parsedBlocks = &avacache.LRU[ids.ID, *chain.StatelessBlock]{Size: vm.config.ParsedBlockCacheSize}
 	vm.verifiedBlocks = make(map[ids.ID]*chain.StatelessBlock)
-	vm.acceptedBlocksByID, err = cache.NewFIFO[ids.ID, *chain.StatelessBlock](vm.config.AcceptedBlockWindowCache)
-	if err != nil {
-		return err
-	}
-	vm.acceptedBlocksByHeight, err = cache.NewFIFO[uint64, ids.ID](vm.config.AcceptedBlockWindowCache)
-	if err != nil {
-		return err
-	}
+	cacheConfig := vm.config.CacheConfig
+	vm.acceptedBlocksByID = newLRUCache(acceptedBlocksCache, cacheConfig.AcceptedBlocks, func(_ ids.ID, blk *chain.StatelessBlock) int {
+		return len(blk.Bytes())
+	}, vm.metrics)
+	vm.acceptedBlocksByHeight = newLRUCache(acceptedBlockHeightsCache, CacheLimit{Entries: cacheConfig.AcceptedBlocks.Entries}, func(uint64, ids.ID) int {
+		return consts.Uint64Len + ids.IDLen
+	}, vm.metrics)
+	vm.parsedTxs = newLRUCache(parsedTxsCache, cacheConfig.ParsedTxs, func(_ ids.ID, tx *chain.Transaction) int {
+		return tx.Size()
+	}, vm.metrics)
+	vm.results = newLRUCache(resultsCache, cacheConfig.Results, func(_ ids.ID, r *acceptedResult) int {
+		return r.result.Size()
+	}, vm.metrics)
 	vm.acceptedQueue = make(chan *chain.StatelessBlock, vm.config.AcceptorSize)
 	vm.acceptorDone = make(chan struct{})
 
@@ -311,7 +537,11 @@ func (vm *VM) Initialize(
 			snowCtx.Log.Error("could not get last accepted block", zap.Error(err))
 			return err
 		}
-		vm.preferred, vm.lastAccepted = blk.ID(), blk
+		vm.preferred = blk.ID()
+		if err := vm.setLastAccepted(ctx, blk); err != nil {
+			snowCtx.Log.Error("could not set last accepted", zap.Error(err))
+			return err
+		}
 		if err := vm.loadAcceptedBlocks(ctx); err != nil {
 			snowCtx.Log.Error("could not load accepted blocks from disk", zap.Error(err))
 			return err
@@ -319,6 +549,11 @@ func (vm *VM) Initialize(
 		// It is not guaranteed that the last accepted state on-disk matches the post-execution
 		// result of the last accepted block.
 		snowCtx.Log.Info("initialized vm from last accepted", zap.Stringer("block", blk.ID()))
+	} else if len(vm.config.CheckpointConfig.Path) > 0 {
+		if err := vm.loadCheckpoint(ctx); err != nil {
+			snowCtx.Log.Error("could not initialize vm from checkpoint", zap.Error(err))
+			return err
+		}
 	} else {
 		// Set balances and compute genesis root
 		sps := state.NewSimpleMutable(vm.stateDB)
@@ -380,12 +615,12 @@ func (vm *VM) Initialize(
 
 		// Update last accepted and preferred block
 		vm.genesisBlk = genesisBlk
-		if err := vm.UpdateLastAccepted(genesisBlk); err != nil {
+		if err := vm.UpdateLastAccepted(ctx, genesisBlk); err != nil {
 			snowCtx.Log.Error("could not set genesis block as last accepted", zap.Error(err))
 			return err
 		}
 		gBlkID := genesisBlk.ID()
-		vm.preferred, vm.lastAccepted = gBlkID, genesisBlk
+		vm.preferred = gBlkID
 		snowCtx.Log.Info("initialized vm from genesis",
 			zap.Stringer("block", gBlkID),
 			zap.Stringer("pre-execution root", genesisBlk.StateRoot),
@@ -393,6 +628,21 @@ func (vm *VM) Initialize(
 		)
 	}
 	go vm.processAcceptedBlocks()
+	if vm.executionTuner != nil || vm.stateFetchTuner != nil {
+		go vm.tuneWorkers()
+	}
+	if vm.eventStreamer != nil {
+		go vm.eventStreamer.Run()
+	}
+	if vm.postgresWriter != nil {
+		go vm.postgresWriter.Run()
+	}
+	if vm.blockExporter != nil {
+		go vm.blockExporter.Run()
+	}
+	if vm.webhooks != nil {
+		vm.webhooks.Run()
+	}
 
 	// Setup state syncing
 	stateSyncHandler, stateSyncSender := vm.networkManager.Register()
@@ -420,9 +670,30 @@ func (vm *VM) Initialize(
 	gossipHandler, gossipSender := vm.networkManager.Register()
 	vm.networkManager.SetHandler(gossipHandler, NewTxGossipHandler(vm))
 
+	// Setup warp signature collection
+	warpHandler, warpSender := vm.networkManager.Register()
+	vm.warpCollector = NewWarpCollector(vm, warpSender)
+	vm.networkManager.SetHandler(warpHandler, NewWarpHandler(vm))
+
+	// Setup chunk dissemination
+	chunkHandler, chunkSender := vm.networkManager.Register()
+	vm.chunkManager = NewChunkManager(vm, vm.config.ChunkConfig, chunkSender)
+	vm.networkManager.SetHandler(chunkHandler, NewChunkHandler(vm))
+
+	// Setup direct transaction submission
+	directHandler, directSender := vm.networkManager.Register()
+	if cfg := vm.config.DirectSubmissionConfig; cfg.Forward || len(cfg.Submitters) > 0 {
+		vm.directSubmission, err = NewDirectSubmission(vm, cfg, directSender)
+		if err != nil {
+			return fmt.Errorf("unable to create direct submission: %w", err)
+		}
+	}
+	vm.networkManager.SetHandler(directHandler, NewDirectSubmissionHandler(vm))
+
 	// Startup block builder and gossiper
 	go vm.builder.Run()
 	go vm.gossiper.Run(gossipSender)
+	go vm.chunkManager.Run()
 
 	// Wait until VM is ready and then send a state sync message to engine
 	go vm.markReady()
@@ -439,10 +710,59 @@ func (vm *VM) Initialize(
 	if _, ok := vm.handlers[rpc.WebSocketEndpoint]; ok {
 		return fmt.Errorf("duplicate WebSocket handler found: %s", rpc.WebSocketEndpoint)
 	}
-	webSocketServer, pubsubServer := rpc.NewWebSocketServer(vm, vm.config.StreamingBacklogSize)
+	webSocketServer, pubsubServer, topicRegistry, err := rpc.NewWebSocketServer(vm, vm.config.StreamingBacklogSize, vm.config.StreamingReplaySize)
+	if err != nil {
+		return fmt.Errorf("unable to create WebSocket server: %w", err)
+	}
+	if err := vm.snowCtx.Metrics.Register("pubsub", topicRegistry); err != nil {
+		return err
+	}
+	if tc, ok := vm.c.(TopicController); ok {
+		for name, parse := range tc.Topics() {
+			if err := webSocketServer.RegisterTopic(name, parse); err != nil {
+				return fmt.Errorf("unable to register topic: %w", err)
+			}
+		}
+	}
 	vm.webSocketServer = webSocketServer
 	vm.handlers[rpc.WebSocketEndpoint] = pubsubServer
-	return nil
+	if vm.config.TxStatusSize > 0 {
+		vm.txStatuses, err = NewTxStatuses(vm.config.TxStatusSize, func(s *rpc.TxStatus) {
+			if err := webSocketServer.PublishTxStatus(s); err != nil {
+				vm.snowCtx.Log.Warn("unable to publish tx status", zap.Error(err))
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if vm.config.EthRPCEnabled {
+		if _, ok := vm.handlers[rpc.EthEndpoint]; ok {
+			return fmt.Errorf("duplicate Eth handler found: %s", rpc.EthEndpoint)
+		}
+		vm.handlers[rpc.EthEndpoint] = rpc.NewEthServer(vm)
+	}
+	if vm.config.ExternalBuilderConfig.Enabled {
+		if _, ok := vm.handlers[rpc.BuilderEndpoint]; ok {
+			return fmt.Errorf("duplicate builder handler found: %s", rpc.BuilderEndpoint)
+		}
+		vm.externalBuilder, err = NewExternalBuilder(vm, vm.config.ExternalBuilderConfig)
+		if err != nil {
+			return fmt.Errorf("unable to create external builder: %w", err)
+		}
+		vm.handlers[rpc.BuilderEndpoint] = vm.externalBuilder
+	}
+	if vm.config.CheckpointConfig.PublisherEnabled {
+		if _, ok := vm.handlers[rpc.CheckpointEndpoint]; ok {
+			return fmt.Errorf("duplicate checkpoint handler found: %s", rpc.CheckpointEndpoint)
+		}
+		publisher, err := NewCheckpointPublisher(vm, vm.config.CheckpointConfig)
+		if err != nil {
+			return fmt.Errorf("unable to create checkpoint publisher: %w", err)
+		}
+		vm.handlers[rpc.CheckpointEndpoint] = publisher
+	}
+	return vm.sealAcceptedProcessors()
 }
 
 func (vm *VM) checkActivity(ctx context.Context) {
@@ -450,6 +770,40 @@ func (vm *VM) checkActivity(ctx context.Context) {
 	vm.builder.Queue(ctx)
 }
 
+// newTuner returns a tuner that sizes a pool between [minWorkers] and
+// [maxWorkers] (or nil if the pool has a fixed size of [minWorkers]).
+func newTuner(minWorkers, maxWorkers int) (*workers.Tuner, error) {
+	if maxWorkers <= minWorkers {
+		return nil, nil
+	}
+	return workers.NewTuner(&workers.AdaptiveConfig{
+		MinWorkers:    minWorkers,
+		MaxWorkers:    maxWorkers,
+		TargetLatency: workers.DefaultTargetLatency,
+		Interval:      workers.DefaultTuneInterval,
+	})
+}
+
+// tuneWorkers resizes the pools created for each block every
+// [workers.DefaultTuneInterval] until the VM is shut down.
+func (vm *VM) tuneWorkers() {
+	t := time.NewTicker(workers.DefaultTuneInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-vm.stop:
+			return
+		}
+		if vm.executionTuner != nil {
+			vm.metrics.executorWorkers.Set(float64(vm.executionTuner.Tune()))
+		}
+		if vm.stateFetchTuner != nil {
+			vm.metrics.stateFetcherWorkers.Set(float64(vm.stateFetchTuner.Tune()))
+		}
+	}
+}
+
 func (vm *VM) markReady() {
 	// Wait for state syncing to complete
 	select {
@@ -498,8 +852,99 @@ func (vm *VM) ReadState(ctx context.Context, keys [][]byte) ([][]byte, []error)
 	if !vm.isReady() {
 		return utils.Repeat[[]byte](nil, len(keys)), utils.Repeat(ErrNotReady, len(keys))
 	}
-	// Atomic read to ensure consistency
-	return vm.stateDB.GetValues(ctx, keys)
+	rm, ok := vm.StateManager().(chain.RentManager)
+	if !ok {
+		// Atomic read to ensure consistency
+		return vm.stateDB.GetValues(ctx, keys)
+	}
+
+	// Read the timestamp of the state with [keys], so expiry is checked
+	// against the block that produced the values
+	values, errs := vm.stateDB.GetValues(ctx, append(slices.Clip(keys), vm.timestampKey()))
+	return stripRent(rm, keys, values, errs)
+}
+
+func (vm *VM) timestampKey() []byte {
+	return chain.TimestampKey(vm.StateManager().TimestampKey())
+}
+
+// stripRent removes the rent prefix from the values of rented [keys] (see
+// [chain.RentManager]). The last of [values] must be the timestamp of the
+// state they were read from.
+func stripRent(rm chain.RentManager, keys [][]byte, values [][]byte, errs []error) ([][]byte, []error) {
+	if err := errs[len(keys)]; err != nil {
+		return utils.Repeat[[]byte](nil, len(keys)), utils.Repeat(err, len(keys))
+	}
+	timestamp := int64(binary.BigEndian.Uint64(values[len(keys)]))
+	values, errs = values[:len(keys)], errs[:len(keys)]
+	chain.StripRentValues(rm, keys, values, errs, timestamp)
+	return values, errs
+}
+
+// ReadStateAt returns the values of [keys] in the state produced by the
+// accepted block at [height]. The last accepted block and its root are read
+// as one snapshot, so a block accepted concurrently is never served as the
+// state of its parent.
+//
+// Historical state is served from the roots retained by [merkledb], so only
+// the last [StateHistoryLength] roots can be read. Older heights return
+// [merkledb.ErrInsufficientHistory].
+func (vm *VM) ReadStateAt(ctx context.Context, height uint64, keys [][]byte) ([][]byte, []error) {
+	if !vm.isReady() {
+		return utils.Repeat[[]byte](nil, len(keys)), utils.Repeat(ErrNotReady, len(keys))
+	}
+	root, err := vm.stateRootAt(ctx, height)
+	if err != nil {
+		return utils.Repeat[[]byte](nil, len(keys)), utils.Repeat(err, len(keys))
+	}
+	rm, rented := vm.StateManager().(chain.RentManager)
+	if rented {
+		keys = append(slices.Clip(keys), vm.timestampKey())
+	}
+	values := make([][]byte, len(keys))
+	errs := make([]error, len(keys))
+	for i, key := range keys {
+		values[i], errs[i] = vm.getValueAtRoot(ctx, root, key)
+	}
+	if rented {
+		return stripRent(rm, keys[:len(keys)-1], values, errs)
+	}
+	return values, errs
+}
+
+// stateRootAt returns the root of the state after the accepted block at
+// [height] was executed.
+func (vm *VM) stateRootAt(ctx context.Context, height uint64) (ids.ID, error) {
+	vm.acceptedL.RLock()
+	lastAccepted, lastAcceptedRoot := vm.lastAccepted, vm.lastAcceptedRoot
+	vm.acceptedL.RUnlock()
+	switch {
+	case height > lastAccepted.Hght:
+		return ids.Empty, fmt.Errorf("%w: height=%d last accepted=%d", ErrHeightNotAccepted, height, lastAccepted.Hght)
+	case height == lastAccepted.Hght:
+		return lastAcceptedRoot, nil
+	}
+
+	// Blocks include the root of the state produced by their parent
+	child, err := vm.GetDiskBlock(ctx, height+1)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return child.StateRoot, nil
+}
+
+func (vm *VM) getValueAtRoot(ctx context.Context, root ids.ID, key []byte) ([]byte, error) {
+	proof, err := vm.stateDB.GetRangeProofAtRoot(ctx, root, maybe.Some(key), maybe.Some(key), 1)
+	if errors.Is(err, merkledb.ErrEmptyProof) {
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.KeyValues) == 0 || !bytes.Equal(proof.KeyValues[0].Key, key) {
+		return nil, database.ErrNotFound
+	}
+	return proof.KeyValues[0].Value, nil
 }
 
 func (vm *VM) SetState(_ context.Context, state snow.State) error {
@@ -590,10 +1035,33 @@ func (vm *VM) Shutdown(ctx context.Context) error {
 	// Process remaining accepted blocks before shutdown
 	close(vm.acceptedQueue)
 	<-vm.acceptorDone
+	if vm.eventStreamer != nil {
+		if err := vm.eventStreamer.Done(); err != nil {
+			return err
+		}
+	}
+	if vm.postgresWriter != nil {
+		if err := vm.postgresWriter.Done(); err != nil {
+			return err
+		}
+	}
+	if vm.blockExporter != nil {
+		vm.blockExporter.Done()
+	}
+	if vm.webhooks != nil {
+		vm.webhooks.Done()
+	}
+	if vm.admission != nil {
+		vm.admission.Close()
+	}
+	if err := vm.audit.Close(); err != nil {
+		return err
+	}
 
 	// Shutdown other async VM mechanisms
 	vm.builder.Done()
 	vm.gossiper.Done()
+	vm.chunkManager.Done()
 	vm.authVerifiers.Stop()
 	if vm.profiler != nil {
 		vm.profiler.Shutdown()
@@ -615,6 +1083,11 @@ func (vm *VM) Shutdown(ctx context.Context) error {
 	if err := vm.stateDB.Close(); err != nil {
 		return err
 	}
+	if vm.indexer != nil {
+		if err := vm.indexer.db.Close(); err != nil {
+			return err
+		}
+	}
 	return vm.rawStateDB.Close()
 }
 
@@ -740,6 +1213,15 @@ func (vm *VM) ParseBlock(ctx context.Context, source []byte) (snowman.Block, err
 
 // implements "block.ChainVM"
 func (vm *VM) BuildBlock(ctx context.Context) (snowman.Block, error) {
+	return vm.buildBlock(ctx, nil)
+}
+
+// implements "block.BuildBlockWithContextChainVM"
+func (vm *VM) BuildBlockWithContext(ctx context.Context, bctx *block.Context) (snowman.Block, error) {
+	return vm.buildBlock(ctx, bctx)
+}
+
+func (vm *VM) buildBlock(ctx context.Context, bctx *block.Context) (snowman.Block, error) {
 	start := time.Now()
 	defer func() {
 		vm.metrics.blockBuild.Observe(float64(time.Since(start)))
@@ -777,7 +1259,10 @@ func (vm *VM) BuildBlock(ctx context.Context) (snowman.Block, error) {
 		vm.snowCtx.Log.Warn("unable to get preferred block", zap.Error(err))
 		return nil, err
 	}
-	blk, err := chain.BuildBlock(ctx, vm, preferredBlk)
+	if vm.externalBuilder != nil {
+		vm.externalBuilder.Prioritize(ctx, preferredBlk.ID())
+	}
+	blk, err := chain.BuildBlock(ctx, vm, preferredBlk, bctx)
 	if err != nil {
 		// This is a DEBUG log because BuildBlock may fail before
 		// the min build gap (especially when there are no transactions).
@@ -792,6 +1277,27 @@ func (vm *VM) Submit(
 	ctx context.Context,
 	verifyAuth bool,
 	txs []*chain.Transaction,
+) (errs []error) {
+	errs = vm.submit(ctx, verifyAuth, txs)
+	if vm.directSubmission == nil || len(errs) != len(txs) {
+		return errs
+	}
+	added := make([]*chain.Transaction, 0, len(txs))
+	for i, err := range errs {
+		if err == nil {
+			added = append(added, txs[i])
+		}
+	}
+	vm.directSubmission.Forward(ctx, added)
+	return errs
+}
+
+// submit adds the valid [txs] to the mempool. Transactions submitted directly
+// by registered submitters are not forwarded again (see [DirectSubmission]).
+func (vm *VM) submit(
+	ctx context.Context,
+	verifyAuth bool,
+	txs []*chain.Transaction,
 ) (errs []error) {
 	ctx, span := vm.tracer.Start(ctx, "VM.Submit")
 	defer span.End()
@@ -850,6 +1356,11 @@ func (vm *VM) Submit(
 			continue
 		}
 
+		if err := vm.AdmitTx(ctx, tx); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
 		// Ensure state keys are valid
 		_, err := tx.StateKeys(vm.c.StateManager())
 		if err != nil {
@@ -877,6 +1388,22 @@ func (vm *VM) Submit(
 			}
 		}
 
+		// Verify incoming warp messages at the current P-Chain height
+		//
+		// They are verified again at the P-Chain height of the block that
+		// includes them.
+		if len(tx.WarpMessages()) > 0 {
+			pChainHeight, err := vm.snowCtx.ValidatorState.GetCurrentHeight(ctx)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if err := tx.VerifyWarpMessages(ctx, vm.c.StateManager(), r, vm.snowCtx.ValidatorState, pChainHeight); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
 		// PreExecute does not make any changes to state
 		//
 		// This may fail if the state we are utilizing is invalidated (if a trie
@@ -894,6 +1421,19 @@ func (vm *VM) Submit(
 		validTxs = append(validTxs, tx)
 	}
 	vm.mempool.Add(ctx, validTxs)
+
+	// Transactions are not added if the mempool is full (or if their sponsor
+	// has too many pending transactions)
+	admitted := make([]*chain.Transaction, 0, len(validTxs))
+	for _, tx := range validTxs {
+		if vm.mempool.Has(ctx, tx.ID()) {
+			admitted = append(admitted, tx)
+		}
+	}
+	vm.publishPending(rpc.PendingAdmitted, admitted)
+	if vm.txStatuses != nil {
+		vm.txStatuses.Pending(admitted)
+	}
 	vm.checkActivity(ctx)
 	vm.metrics.mempoolSize.Set(float64(vm.mempool.Len(ctx)))
 	return errs
@@ -1104,11 +1644,15 @@ func (vm *VM) backfillSeenTransactions() {
 		zap.Uint64("start", oldest),
 		zap.Uint64("finish", vm.lastAccepted.Hght),
 	)
+	vm.recordSeen(0)
 }
 
 func (vm *VM) loadAcceptedBlocks(ctx context.Context) error {
 	start := uint64(0)
-	lookback := uint64(vm.config.AcceptedBlockWindowCache) - 1 // include latest
+	if vm.config.CacheConfig.AcceptedBlocks.Entries == 0 {
+		return nil
+	}
+	lookback := uint64(vm.config.CacheConfig.AcceptedBlocks.Entries) - 1 // include latest
 	if vm.lastAccepted.Hght > lookback {
 		start = vm.lastAccepted.Hght - lookback
 	}
@@ -1128,6 +1672,15 @@ func (vm *VM) loadAcceptedBlocks(ctx context.Context) error {
 	return nil
 }
 
+// newDB opens the database of [namespace] in the chain data directory (or in
+// memory if [Config.MemoryDB] is set).
+func (vm *VM) newDB(namespace string) (database.Database, error) {
+	if vm.config.MemoryDB {
+		return memdb.New(), nil
+	}
+	return storage.New(vm.config.PebbleConfig, vm.snowCtx.ChainDataDir, namespace, vm.snowCtx.Metrics)
+}
+
 // Fatal logs the provided message and then panics to force an exit.
 //
 // While we could attempt a graceful shutdown, it is not clear that
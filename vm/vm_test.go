@@ -8,14 +8,17 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
-	"github.com/ava-labs/hypersdk/cache"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/emap"
 	"github.com/ava-labs/hypersdk/mempool"
@@ -36,14 +39,31 @@ func TestBlockCache(t *testing.T) {
 	}
 	blkID := blk.ID()
 
+	// Init metrics (called in [Accepted])
+	reg, m, err := newMetrics()
+	require.NoError(err)
+
 	tracer, _ := trace.New(&trace.Config{Enabled: false})
-	bByID, _ := cache.NewFIFO[ids.ID, *chain.StatelessBlock](3)
-	bByHeight, _ := cache.NewFIFO[uint64, ids.ID](3)
+	stateDB, err := merkledb.New(context.TODO(), memdb.New(), merkledb.Config{
+		BranchFactor:                merkledb.BranchFactor16,
+		RootGenConcurrency:          1,
+		HistoryLength:               1,
+		ValueNodeCacheSize:          units.MiB,
+		IntermediateNodeCacheSize:   units.MiB,
+		IntermediateWriteBufferSize: units.KiB,
+		IntermediateWriteBatchSize:  units.KiB,
+		Tracer:                      tracer,
+	})
+	require.NoError(err)
+	limit := CacheLimit{Entries: 3}
+	bByID := newLRUCache(acceptedBlocksCache, limit, func(ids.ID, *chain.StatelessBlock) int { return 1 }, m)
+	bByHeight := newLRUCache(acceptedBlockHeightsCache, limit, func(uint64, ids.ID) int { return 1 }, m)
 	controller := NewMockController(ctrl)
 	vm := VM{
 		snowCtx: &snow.Context{Log: logging.NoLog{}, Metrics: metrics.NewPrefixGatherer()},
 		config:  NewConfig(),
 		vmDB:    memdb.New(),
+		stateDB: stateDB,
 
 		tracer:                 tracer,
 		acceptedBlocksByID:     bByID,
@@ -54,12 +74,8 @@ func TestBlockCache(t *testing.T) {
 		mempool:        mempool.New[*chain.Transaction](tracer, 100, 32),
 		acceptedQueue:  make(chan *chain.StatelessBlock, 1024), // don't block on queue
 		c:              controller,
+		metrics:        m,
 	}
-
-	// Init metrics (called in [Accepted])
-	reg, m, err := newMetrics()
-	require.NoError(err)
-	vm.metrics = m
 	require.NoError(vm.snowCtx.Metrics.Register("hypersdk", reg))
 
 	// put the block into the cache "vm.blocks"
@@ -76,3 +92,37 @@ func TestBlockCache(t *testing.T) {
 	require.NoError(err)
 	require.Equal(blk, blk2)
 }
+
+func TestWarpStorage(t *testing.T) {
+	require := require.New(t)
+
+	vm := VM{vmDB: memdb.New()}
+	msg, err := warp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+	other, err := warp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte("other"))
+	require.NoError(err)
+
+	_, err = vm.GetWarpMessage(msg.ID())
+	require.ErrorIs(err, database.ErrNotFound)
+	require.NoError(vm.StoreWarpMessage(msg))
+	stored, err := vm.GetWarpMessage(msg.ID())
+	require.NoError(err)
+	require.Equal(msg.Bytes(), stored.Bytes())
+
+	// Signatures of other messages are not returned
+	signatures := []*chain.WarpSignature{
+		{PublicKey: []byte{1, 2, 3}, Signature: []byte{4}},
+		{PublicKey: []byte{5, 6, 7}, Signature: []byte{8}},
+	}
+	for _, signature := range signatures {
+		require.NoError(vm.StoreWarpSignature(msg.ID(), signature.PublicKey, signature.Signature))
+	}
+	require.NoError(vm.StoreWarpSignature(other.ID(), []byte{9}, []byte{10}))
+
+	found, err := vm.GetWarpSignatures(msg.ID())
+	require.NoError(err)
+	require.Equal(signatures, found)
+	signature, err := vm.GetWarpSignature(msg.ID(), []byte{5, 6, 7})
+	require.NoError(err)
+	require.Equal([]byte{8}, signature)
+}
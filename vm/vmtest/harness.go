@@ -0,0 +1,301 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package vmtest runs a [vm.VM] in-process, with its blocks and state in
+// memory, so the controllers of hypervms can be tested without starting a
+// network.
+package vmtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/fees"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/vm"
+)
+
+// readyTimeout is how long [New] waits for the VM to be ready.
+const readyTimeout = 5 * time.Second
+
+type Config struct {
+	// Genesis, Upgrade, and VMConfig are passed to [vm.VM.Initialize]
+	// ([vm.Config.MemoryDB] is always set). Controllers should be configured
+	// to use [builder.Manual] so blocks are only built by the [Harness].
+	Genesis  []byte
+	Upgrade  []byte
+	VMConfig []byte
+
+	NetworkID uint32
+	// ChainID defaults to a random ID (harnesses that verify the blocks of
+	// each other must share it)
+	ChainID ids.ID
+	// ValidatorState defaults to a subnet where this node is the only
+	// validator
+	ValidatorState validators.State
+}
+
+// Harness drives a single [vm.VM]: tests submit transactions, force blocks to
+// be built and accepted, and assert on the resulting state and results.
+type Harness struct {
+	t        testing.TB
+	vm       *vm.VM
+	toEngine chan common.Message
+	metrics  metrics.MultiGatherer
+}
+
+// New initializes [v] (as returned by the constructor of a controller) and
+// marks it ready. The VM is shut down when the test completes.
+func New(t testing.TB, v *vm.VM, config Config) *Harness {
+	require := require.New(t)
+
+	vmConfig := map[string]any{}
+	if len(config.VMConfig) > 0 {
+		require.NoError(json.Unmarshal(config.VMConfig, &vmConfig))
+	}
+	vmConfig["memoryDB"] = true
+	configBytes, err := json.Marshal(vmConfig)
+	require.NoError(err)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	var (
+		nodeID   = ids.GenerateTestNodeID()
+		subnetID = ids.GenerateTestID()
+		chainID  = config.ChainID
+	)
+	if chainID == ids.Empty {
+		chainID = ids.GenerateTestID()
+	}
+	vdrState := config.ValidatorState
+	if vdrState == nil {
+		vdrState = &validators.TestState{
+			GetCurrentHeightF: func(context.Context) (uint64, error) {
+				return 0, nil
+			},
+			GetSubnetIDF: func(_ context.Context, id ids.ID) (ids.ID, error) {
+				if id != chainID {
+					return ids.Empty, database.ErrNotFound
+				}
+				return subnetID, nil
+			},
+			GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+				return map[ids.NodeID]*validators.GetValidatorOutput{
+					nodeID: {NodeID: nodeID, PublicKey: bls.PublicFromSecretKey(sk), Weight: 1},
+				}, nil
+			},
+		}
+	}
+	snowCtx := &snow.Context{
+		NetworkID:      config.NetworkID,
+		SubnetID:       subnetID,
+		ChainID:        chainID,
+		NodeID:         nodeID,
+		Log:            logging.NoLog{},
+		ChainDataDir:   t.TempDir(),
+		Metrics:        metrics.NewPrefixGatherer(),
+		PublicKey:      bls.PublicFromSecretKey(sk),
+		WarpSigner:     warp.NewSigner(sk, config.NetworkID, chainID),
+		ValidatorState: vdrState,
+	}
+
+	toEngine := make(chan common.Message, 1)
+	require.NoError(v.Initialize(
+		context.Background(),
+		snowCtx,
+		nil,
+		config.Genesis,
+		config.Upgrade,
+		configBytes,
+		toEngine,
+		nil,
+		&appSender{},
+	))
+	t.Cleanup(func() {
+		require.NoError(v.Shutdown(context.Background()))
+	})
+	v.ForceReady()
+	require.Eventually(func() bool {
+		_, err := v.HealthCheck(context.Background())
+		return err == nil
+	}, readyTimeout, 10*time.Millisecond)
+	return &Harness{t: t, vm: v, toEngine: toEngine, metrics: snowCtx.Metrics}
+}
+
+// Metrics gathers the metrics registered by the VM (and its controller).
+func (h *Harness) Metrics() prometheus.Gatherer {
+	return h.metrics
+}
+
+// VM returns the VM driven by the harness (which also serves as the
+// [chain.Parser] and [rpc.VM] of the chain).
+func (h *Harness) VM() *vm.VM {
+	return h.vm
+}
+
+// GenerateTx signs a transaction of [actions] that is valid for the next block
+// (with the max fee suggested at the current unit prices).
+func (h *Harness) GenerateTx(actions []chain.Action, authFactory chain.AuthFactory) *chain.Transaction {
+	require := require.New(h.t)
+
+	now := time.Now().UnixMilli()
+	r := h.vm.Rules(now)
+	unitPrices, err := h.vm.UnitPrices(context.Background())
+	require.NoError(err)
+	units, err := chain.EstimateUnits(r, actions, authFactory)
+	require.NoError(err)
+	maxFee, err := fees.MulSum(unitPrices, units)
+	require.NoError(err)
+	base := &chain.Base{
+		Timestamp: utils.UnixRMilli(now, r.GetValidityWindow()),
+		ChainID:   r.ChainID(),
+		MaxFee:    maxFee,
+	}
+	actionRegistry, authRegistry := h.vm.Registry()
+	tx, err := chain.NewTx(base, actions).Sign(authFactory, actionRegistry, authRegistry)
+	require.NoError(err)
+	return tx
+}
+
+// Submit adds [txs] to the mempool and fails the test if any is rejected.
+func (h *Harness) Submit(ctx context.Context, txs ...*chain.Transaction) {
+	require := require.New(h.t)
+
+	for _, err := range h.vm.Submit(ctx, true, txs) {
+		require.NoError(err)
+	}
+}
+
+// BuildBlock builds a block from the mempool, verifies it, and prefers it.
+func (h *Harness) BuildBlock(ctx context.Context) *chain.StatelessBlock {
+	require := require.New(h.t)
+
+	blk, err := h.vm.BuildBlock(ctx)
+	require.NoError(err)
+	// The block was built without waiting for the builder to notify the
+	// (missing) engine
+	select {
+	case <-h.toEngine:
+	default:
+	}
+	return h.verify(ctx, blk.(*chain.StatelessBlock))
+}
+
+// VerifyBlock parses [blkBytes] (built by a harness of the same chain),
+// verifies it, and prefers it.
+func (h *Harness) VerifyBlock(ctx context.Context, blkBytes []byte) *chain.StatelessBlock {
+	require := require.New(h.t)
+
+	blk, err := h.vm.ParseBlock(ctx, blkBytes)
+	require.NoError(err)
+	return h.verify(ctx, blk.(*chain.StatelessBlock))
+}
+
+func (h *Harness) verify(ctx context.Context, blk *chain.StatelessBlock) *chain.StatelessBlock {
+	require := require.New(h.t)
+
+	require.NoError(blk.Verify(ctx))
+	require.Equal(choices.Processing, blk.Status())
+	require.NoError(h.vm.SetPreference(ctx, blk.ID()))
+	return blk
+}
+
+// AcceptBlock accepts [blk] and returns its results (one per transaction).
+func (h *Harness) AcceptBlock(ctx context.Context, blk *chain.StatelessBlock) []*chain.Result {
+	require := require.New(h.t)
+
+	require.NoError(blk.Accept(ctx))
+	require.Equal(choices.Accepted, blk.Status())
+	lastAccepted, err := h.vm.LastAccepted(ctx)
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAccepted)
+	return blk.Results()
+}
+
+// ProduceBlock builds and accepts a block from the mempool and returns its
+// results.
+func (h *Harness) ProduceBlock(ctx context.Context) []*chain.Result {
+	return h.AcceptBlock(ctx, h.BuildBlock(ctx))
+}
+
+// RequireSuccess fails the test if any of [results] is not successful.
+func (h *Harness) RequireSuccess(results []*chain.Result) {
+	for i, result := range results {
+		require.True(h.t, result.Success, "tx %d failed: %s", i, result.Error)
+	}
+}
+
+// RequireFailure fails the test unless [result] failed with [err].
+func (h *Harness) RequireFailure(result *chain.Result, err error) {
+	require := require.New(h.t)
+
+	require.False(result.Success)
+	require.Contains(string(result.Error), err.Error())
+}
+
+// RequireValue fails the test unless [key] is set to [value] in the state of
+// the last accepted block (or missing if [value] is nil).
+func (h *Harness) RequireValue(ctx context.Context, key []byte, value []byte) {
+	require := require.New(h.t)
+
+	values, errs := h.vm.ReadState(ctx, [][]byte{key})
+	if value == nil {
+		require.ErrorIs(errs[0], database.ErrNotFound)
+		return
+	}
+	require.NoError(errs[0])
+	require.Equal(value, values[0])
+}
+
+var errNoPeers = errors.New("harness has no peers")
+
+var _ common.AppSender = (*appSender)(nil)
+
+// appSender drops gossip (the harness has no peers).
+type appSender struct{}
+
+func (*appSender) SendAppGossip(context.Context, common.SendConfig, []byte) error {
+	return nil
+}
+
+func (*appSender) SendAppRequest(context.Context, set.Set[ids.NodeID], uint32, []byte) error {
+	return errNoPeers
+}
+
+func (*appSender) SendAppError(context.Context, ids.NodeID, uint32, int32, string) error {
+	return nil
+}
+
+func (*appSender) SendAppResponse(context.Context, ids.NodeID, uint32, []byte) error {
+	return nil
+}
+
+func (*appSender) SendCrossChainAppRequest(context.Context, ids.ID, uint32, []byte) error {
+	return errNoPeers
+}
+
+func (*appSender) SendCrossChainAppResponse(context.Context, ids.ID, uint32, []byte) error {
+	return nil
+}
+
+func (*appSender) SendCrossChainAppError(context.Context, ids.ID, uint32, int32, string) error {
+	return nil
+}
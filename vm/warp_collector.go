@@ -0,0 +1,232 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+)
+
+const (
+	warpRetryDelay = 2 * time.Second
+	maxWarpRetries = 10
+)
+
+// warpRequest is a request for the signature of a validator over an outgoing
+// warp message.
+type warpRequest struct {
+	msg       *warp.UnsignedMessage
+	nodeID    ids.NodeID
+	publicKey *bls.PublicKey
+	attempts  int
+}
+
+// WarpCollector signs the outgoing warp messages of accepted blocks and
+// collects the signatures of the other validators of the subnet. Peers may not
+// have accepted a block by the time they are asked for their signature, so
+// requests are retried until a signature is returned.
+type WarpCollector struct {
+	vm        *VM
+	appSender common.AppSender
+
+	l         sync.Mutex
+	requestID uint32
+	requests  map[uint32]*warpRequest
+}
+
+func NewWarpCollector(vm *VM, appSender common.AppSender) *WarpCollector {
+	return &WarpCollector{
+		vm:        vm,
+		appSender: appSender,
+		requests:  map[uint32]*warpRequest{},
+	}
+}
+
+// Accepted signs and stores the warp messages sent by the transactions of
+// [blk] and requests the signatures of the current validators.
+func (w *WarpCollector) Accepted(ctx context.Context, blk *chain.StatelessBlock) error {
+	msgs := []*warp.UnsignedMessage{}
+	for _, result := range blk.Results() {
+		msgs = append(msgs, result.WarpMessages...)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	vdrs, _ := w.vm.CurrentValidators(ctx)
+	for _, msg := range msgs {
+		if err := w.vm.StoreWarpMessage(msg); err != nil {
+			return err
+		}
+		signature, err := w.vm.snowCtx.WarpSigner.Sign(msg)
+		if err != nil {
+			return err
+		}
+		if err := w.vm.StoreWarpSignature(msg.ID(), w.vm.pkBytes, signature); err != nil {
+			return err
+		}
+		for nodeID, vdr := range vdrs {
+			if nodeID == w.vm.snowCtx.NodeID || vdr.PublicKey == nil {
+				continue
+			}
+			w.request(ctx, &warpRequest{
+				msg:       msg,
+				nodeID:    nodeID,
+				publicKey: vdr.PublicKey,
+			})
+		}
+	}
+	return nil
+}
+
+// RequestMissing requests the signatures over [msg] of the current validators
+// whose signature has not been collected and that are not being asked for it
+// already.
+func (w *WarpCollector) RequestMissing(ctx context.Context, msg *warp.UnsignedMessage) error {
+	msgID := msg.ID()
+	signatures, err := w.vm.GetWarpSignatures(msgID)
+	if err != nil {
+		return err
+	}
+	signed := set.NewSet[string](len(signatures))
+	for _, signature := range signatures {
+		signed.Add(string(signature.PublicKey))
+	}
+	w.l.Lock()
+	pending := set.Set[ids.NodeID]{}
+	for _, req := range w.requests {
+		if req.msg.ID() == msgID {
+			pending.Add(req.nodeID)
+		}
+	}
+	w.l.Unlock()
+
+	vdrs, _ := w.vm.CurrentValidators(ctx)
+	for nodeID, vdr := range vdrs {
+		if nodeID == w.vm.snowCtx.NodeID || vdr.PublicKey == nil || pending.Contains(nodeID) {
+			continue
+		}
+		if signed.Contains(string(bls.PublicKeyToCompressedBytes(vdr.PublicKey))) {
+			continue
+		}
+		w.request(ctx, &warpRequest{
+			msg:       msg,
+			nodeID:    nodeID,
+			publicKey: vdr.PublicKey,
+		})
+	}
+	return nil
+}
+
+func (w *WarpCollector) request(ctx context.Context, req *warpRequest) {
+	w.l.Lock()
+	requestID := w.requestID
+	w.requestID++
+	w.requests[requestID] = req
+	w.l.Unlock()
+
+	msgID := req.msg.ID()
+	if err := w.appSender.SendAppRequest(ctx, set.Of(req.nodeID), requestID, msgID[:]); err != nil {
+		w.vm.Logger().Warn("unable to request warp signature",
+			zap.Stringer("msgID", msgID),
+			zap.Stringer("nodeID", req.nodeID),
+			zap.Error(err),
+		)
+		w.l.Lock()
+		delete(w.requests, requestID)
+		w.l.Unlock()
+	}
+}
+
+func (w *WarpCollector) retry(req *warpRequest) {
+	req.attempts++
+	if req.attempts >= maxWarpRetries {
+		w.vm.Logger().Debug("giving up on warp signature",
+			zap.Stringer("msgID", req.msg.ID()),
+			zap.Stringer("nodeID", req.nodeID),
+		)
+		return
+	}
+	go func() {
+		select {
+		case <-time.After(warpRetryDelay):
+			w.request(context.Background(), req)
+		case <-w.vm.stop:
+		}
+	}()
+}
+
+func (w *WarpCollector) takeRequest(nodeID ids.NodeID, requestID uint32) *warpRequest {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	req, ok := w.requests[requestID]
+	if !ok || req.nodeID != nodeID {
+		return nil
+	}
+	delete(w.requests, requestID)
+	return req
+}
+
+// AppRequest responds with the signature of this node over the requested
+// message (or an empty response if it has not signed it yet).
+func (w *WarpCollector) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	request []byte,
+) error {
+	msgID, err := ids.ToID(request)
+	if err != nil {
+		w.vm.Logger().Debug("invalid warp signature request", zap.Stringer("nodeID", nodeID), zap.Error(err))
+		return nil
+	}
+	signature, err := w.vm.GetWarpSignature(msgID, w.vm.pkBytes)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		w.vm.Logger().Warn("unable to get warp signature", zap.Stringer("msgID", msgID), zap.Error(err))
+		return nil
+	}
+	return w.appSender.SendAppResponse(ctx, nodeID, requestID, signature)
+}
+
+func (w *WarpCollector) AppRequestFailed(nodeID ids.NodeID, requestID uint32) {
+	if req := w.takeRequest(nodeID, requestID); req != nil {
+		w.retry(req)
+	}
+}
+
+// AppResponse stores the signature of [nodeID] if it is valid.
+func (w *WarpCollector) AppResponse(nodeID ids.NodeID, requestID uint32, response []byte) {
+	req := w.takeRequest(nodeID, requestID)
+	if req == nil {
+		return
+	}
+	if len(response) == 0 {
+		w.retry(req)
+		return
+	}
+	msgID := req.msg.ID()
+	signature, err := bls.SignatureFromBytes(response)
+	if err != nil || !bls.Verify(req.publicKey, signature, req.msg.Bytes()) {
+		w.vm.Logger().Warn("received invalid warp signature",
+			zap.Stringer("msgID", msgID),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return
+	}
+	if err := w.vm.StoreWarpSignature(msgID, bls.PublicKeyToCompressedBytes(req.publicKey), response); err != nil {
+		w.vm.Logger().Warn("unable to store warp signature", zap.Stringer("msgID", msgID), zap.Error(err))
+	}
+}
@@ -0,0 +1,409 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/events"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	// WebhookSignatureHeader is the hex-encoded HMAC-SHA256 of the payload,
+	// keyed by the secret returned when the webhook was registered
+	WebhookSignatureHeader = "X-Hypersdk-Signature"
+	// WebhookDeliveryHeader identifies a notification across retries, so
+	// receivers can ignore duplicates
+	WebhookDeliveryHeader = "X-Hypersdk-Delivery"
+
+	maxWebhookAddresses = 1024
+	webhookSecretLen    = 32
+)
+
+var _ rpc.Webhooks = (*WebhookNotifier)(nil)
+
+type WebhookConfig struct {
+	Enabled bool `json:"enabled"`
+	// AuthToken must be provided as a bearer token to (un)register webhooks
+	AuthToken string `json:"authToken"`
+	// Timeout of each delivery attempt
+	Timeout time.Duration `json:"timeout" min:"1ms"`
+	// MaxAttempts is the number of times a notification is sent before it is
+	// abandoned
+	MaxAttempts int `json:"maxAttempts" min:"1"`
+	// The delay between attempts starts at [InitialBackoff] and doubles after
+	// every failure (up to [MaxBackoff])
+	InitialBackoff time.Duration `json:"initialBackoff" min:"0s"`
+	MaxBackoff     time.Duration `json:"maxBackoff" min:"0s"`
+	// QueueSize is the number of notifications waiting to be delivered after
+	// which new notifications are dropped
+	QueueSize int `json:"queueSize" min:"1"`
+	Workers   int `json:"workers" min:"1"`
+}
+
+// Verify returns an error if the backoff of [c] can't be applied.
+func (c *WebhookConfig) Verify() error {
+	if c.InitialBackoff > c.MaxBackoff {
+		return fmt.Errorf("%w: initialBackoff %s exceeds maxBackoff %s", ErrInvalidBackoff, c.InitialBackoff, c.MaxBackoff)
+	}
+	return nil
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook for every accepted
+// transaction that involves any of its addresses.
+type WebhookPayload struct {
+	WebhookID ids.ID `json:"webhookId"`
+	// Sent are the watched addresses that sent (or sponsored) the transaction
+	Sent [][]byte `json:"sent"`
+	// Received are the watched addresses referenced by its actions (like a
+	// transfer recipient)
+	Received       [][]byte   `json:"received"`
+	BlockTimestamp int64      `json:"blockTimestamp"`
+	Tx             *events.Tx `json:"tx"`
+}
+
+type webhook struct {
+	id        ids.ID
+	url       string
+	secret    []byte
+	addresses []codec.Address
+}
+
+type webhookDelivery struct {
+	hook *webhook
+	id   ids.ID
+	body []byte
+}
+
+// WebhookNotifier POSTs a signed [WebhookPayload] to the registered webhooks
+// watching the addresses of every accepted transaction.
+//
+// Webhooks are persisted in the vmDB but notifications are only kept in
+// memory, so notifications that were not delivered before the node shuts down
+// are not retried.
+type WebhookNotifier struct {
+	vm     *VM
+	config WebhookConfig
+	cli    *http.Client
+	c      IndexController
+
+	l       sync.RWMutex
+	hooks   map[ids.ID]*webhook
+	watched map[codec.Address]set.Set[ids.ID]
+
+	deliveries chan *webhookDelivery
+	done       sync.WaitGroup
+}
+
+// NewWebhookNotifier loads the webhooks stored in the vmDB. The recipients of
+// transactions are provided by the [Controller] if it implements
+// [IndexController], otherwise any address contained in a transaction is
+// considered a recipient.
+func NewWebhookNotifier(vm *VM, config WebhookConfig) (*WebhookNotifier, error) {
+	if len(config.AuthToken) == 0 {
+		return nil, fmt.Errorf("%w: webhooks can not be registered", ErrMissingAuthToken)
+	}
+	ic, _ := vm.c.(IndexController)
+	w := &WebhookNotifier{
+		vm:         vm,
+		config:     config,
+		cli:        &http.Client{Timeout: config.Timeout},
+		c:          ic,
+		hooks:      map[ids.ID]*webhook{},
+		watched:    map[codec.Address]set.Set[ids.ID]{},
+		deliveries: make(chan *webhookDelivery, config.QueueSize),
+	}
+	iter := vm.vmDB.NewIteratorWithPrefix([]byte{webhookPrefix})
+	defer iter.Release()
+	for iter.Next() {
+		hook, err := unmarshalWebhook(ids.ID(iter.Key()[1:]), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		w.add(hook)
+	}
+	return w, iter.Error()
+}
+
+func (h *webhook) marshal() ([]byte, error) {
+	size := codec.StringLen(h.url) + codec.BytesLen(h.secret) + consts.IntLen + len(h.addresses)*codec.AddressLen
+	p := codec.NewWriter(size, consts.MaxInt)
+	p.PackString(h.url)
+	p.PackBytes(h.secret)
+	p.PackInt(len(h.addresses))
+	for _, addr := range h.addresses {
+		p.PackAddress(addr)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func unmarshalWebhook(id ids.ID, v []byte) (*webhook, error) {
+	p := codec.NewReader(v, consts.MaxInt)
+	h := &webhook{id: id, url: p.UnpackString(true)}
+	p.UnpackBytes(webhookSecretLen, true, &h.secret)
+	h.addresses = make([]codec.Address, p.UnpackInt(true))
+	for i := range h.addresses {
+		p.UnpackAddress(&h.addresses[i])
+	}
+	return h, p.Err()
+}
+
+func (w *WebhookNotifier) add(hook *webhook) {
+	w.hooks[hook.id] = hook
+	for _, addr := range hook.addresses {
+		hooks := w.watched[addr]
+		hooks.Add(hook.id)
+		w.watched[addr] = hooks
+	}
+}
+
+func (w *WebhookNotifier) Authorized(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(w.config.AuthToken)) == 1
+}
+
+func (w *WebhookNotifier) Register(rawURL string, addresses []codec.Address) (ids.ID, []byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return ids.Empty, nil, fmt.Errorf("%w: %s", ErrInvalidWebhookURL, rawURL)
+	}
+	switch {
+	case len(addresses) == 0:
+		return ids.Empty, nil, ErrNoWebhookAddresses
+	case len(addresses) > maxWebhookAddresses:
+		return ids.Empty, nil, fmt.Errorf("%w: max=%d", ErrTooManyAddresses, maxWebhookAddresses)
+	}
+
+	var id ids.ID
+	if _, err := rand.Read(id[:]); err != nil {
+		return ids.Empty, nil, err
+	}
+	secret := make([]byte, webhookSecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return ids.Empty, nil, err
+	}
+	hook := &webhook{
+		id:        id,
+		url:       rawURL,
+		secret:    secret,
+		addresses: set.Of(addresses...).List(),
+	}
+	v, err := hook.marshal()
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+
+	w.l.Lock()
+	defer w.l.Unlock()
+	if err := w.vm.vmDB.Put(PrefixWebhookKey(id), v); err != nil {
+		return ids.Empty, nil, err
+	}
+	w.add(hook)
+	w.vm.Logger().Info("registered webhook", zap.Stringer("id", id), zap.Int("addresses", len(hook.addresses)))
+	return id, secret, nil
+}
+
+func (w *WebhookNotifier) Unregister(id ids.ID) error {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	hook, ok := w.hooks[id]
+	if !ok {
+		return ErrWebhookMissing
+	}
+	if err := w.vm.vmDB.Delete(PrefixWebhookKey(id)); err != nil {
+		return err
+	}
+	delete(w.hooks, id)
+	for _, addr := range hook.addresses {
+		hooks := w.watched[addr]
+		hooks.Remove(id)
+		if hooks.Len() == 0 {
+			delete(w.watched, addr)
+		}
+	}
+	w.vm.Logger().Info("unregistered webhook", zap.Stringer("id", id))
+	return nil
+}
+
+// Accepted queues the notifications of the transactions in [blk]. It never
+// blocks: notifications are dropped if the queue is full.
+func (w *WebhookNotifier) Accepted(blk *chain.StatelessBlock) error {
+	w.l.RLock()
+	defer w.l.RUnlock()
+
+	if len(w.watched) == 0 {
+		return nil
+	}
+	_, txs := events.NewEvents(blk)
+	for i, tx := range blk.Txs {
+		payloads := map[ids.ID]*WebhookPayload{}
+		payload := func(id ids.ID) *WebhookPayload {
+			p, ok := payloads[id]
+			if !ok {
+				p = &WebhookPayload{WebhookID: id, BlockTimestamp: blk.Tmstmp, Tx: txs[i]}
+				payloads[id] = p
+			}
+			return p
+		}
+		senders := set.Of(tx.Auth.Actor(), tx.Sponsor())
+		for addr := range senders {
+			for id := range w.watched[addr] {
+				p := payload(id)
+				p.Sent = append(p.Sent, addr[:])
+			}
+		}
+		for _, addr := range w.recipients(tx) {
+			for id := range w.watched[addr] {
+				p := payload(id)
+				p.Received = append(p.Received, addr[:])
+			}
+		}
+		for id, p := range payloads {
+			if err := w.enqueue(w.hooks[id], tx.ID(), p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *WebhookNotifier) recipients(tx *chain.Transaction) []codec.Address {
+	if w.c != nil {
+		return set.Of(w.c.TxAddresses(tx)...).List()
+	}
+	// Actions pack addresses as fixed bytes, so any watched address
+	// referenced by an action is contained in the transaction bytes.
+	addrs := []codec.Address{}
+	for addr := range w.watched {
+		if bytes.Contains(tx.Bytes(), addr[:]) && addr != tx.Auth.Actor() && addr != tx.Sponsor() {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func (w *WebhookNotifier) enqueue(hook *webhook, txID ids.ID, payload *WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	d := &webhookDelivery{
+		hook: hook,
+		id:   utils.ToID(append(hook.id[:], txID[:]...)),
+		body: body,
+	}
+	select {
+	case w.deliveries <- d:
+	default:
+		w.vm.metrics.webhooksDropped.Inc()
+		w.vm.Logger().Warn("dropping webhook notification", zap.Stringer("webhook", hook.id), zap.Stringer("tx", txID))
+	}
+	return nil
+}
+
+// Run delivers queued notifications until the VM is stopped.
+func (w *WebhookNotifier) Run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-w.vm.stop
+		cancel()
+	}()
+
+	w.done.Add(w.config.Workers)
+	for i := 0; i < w.config.Workers; i++ {
+		go func() {
+			defer w.done.Done()
+			for {
+				select {
+				case d := <-w.deliveries:
+					w.deliver(ctx, d)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+func (w *WebhookNotifier) deliver(ctx context.Context, d *webhookDelivery) {
+	backoff := w.config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := w.send(ctx, d)
+		if err == nil {
+			w.vm.metrics.webhooksDelivered.Inc()
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if attempt == w.config.MaxAttempts {
+			w.vm.metrics.webhooksFailed.Inc()
+			w.vm.Logger().Warn("unable to deliver webhook notification",
+				zap.Stringer("webhook", d.hook.id),
+				zap.Stringer("delivery", d.id),
+				zap.Int("attempts", attempt),
+				zap.Error(err),
+			)
+			return
+		}
+		w.vm.Logger().Debug("retrying webhook notification",
+			zap.Stringer("webhook", d.hook.id),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(2*backoff, w.config.MaxBackoff)
+	}
+}
+
+func (w *WebhookNotifier) send(ctx context.Context, d *webhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.hook.url, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, d.hook.secret)
+	_, _ = mac.Write(d.body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set(WebhookDeliveryHeader, d.id.String())
+	resp, err := w.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %d", ErrWebhookStatus, resp.StatusCode)
+	}
+	return nil
+}
+
+// Done waits for in-flight deliveries to stop after the VM is stopped.
+func (w *WebhookNotifier) Done() {
+	w.done.Wait()
+}
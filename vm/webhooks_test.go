@@ -0,0 +1,146 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vm
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/events"
+)
+
+func newTestWebhookNotifier(t *testing.T, vm *VM) *WebhookNotifier {
+	config := NewConfig().WebhookConfig
+	config.AuthToken = "token"
+	config.InitialBackoff = time.Millisecond
+	config.MaxAttempts = 3
+	w, err := NewWebhookNotifier(vm, config)
+	require.NoError(t, err)
+	return w
+}
+
+func TestWebhookRegistration(t *testing.T) {
+	require := require.New(t)
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{vmDB: memdb.New(), metrics: m, snowCtx: &snow.Context{Log: logging.NoLog{}}}
+	_, err = NewWebhookNotifier(vm, NewConfig().WebhookConfig)
+	require.ErrorIs(err, ErrMissingAuthToken)
+	w := newTestWebhookNotifier(t, vm)
+	require.True(w.Authorized("token"))
+	require.False(w.Authorized("tokens"))
+	require.False(w.Authorized(""))
+
+	addr := codec.CreateAddress(0, ids.GenerateTestID())
+	_, _, err = w.Register("ftp://localhost/hook", []codec.Address{addr})
+	require.ErrorIs(err, ErrInvalidWebhookURL)
+	_, _, err = w.Register("http://localhost/hook", nil)
+	require.ErrorIs(err, ErrNoWebhookAddresses)
+	_, _, err = w.Register("http://localhost/hook", make([]codec.Address, maxWebhookAddresses+1))
+	require.ErrorIs(err, ErrTooManyAddresses)
+
+	id1, secret, err := w.Register("http://localhost/hook1", []codec.Address{addr, addr})
+	require.NoError(err)
+	require.Len(secret, webhookSecretLen)
+	id2, _, err := w.Register("http://localhost/hook2", []codec.Address{addr})
+	require.NoError(err)
+
+	// Webhooks are loaded after a restart
+	w = newTestWebhookNotifier(t, vm)
+	require.Len(w.hooks, 2)
+	require.Equal(secret, w.hooks[id1].secret)
+	require.Equal([]codec.Address{addr}, w.hooks[id1].addresses)
+	require.Len(w.watched[addr], 2)
+
+	require.NoError(w.Unregister(id1))
+	require.ErrorIs(w.Unregister(id1), ErrWebhookMissing)
+	w = newTestWebhookNotifier(t, vm)
+	require.Len(w.hooks, 1)
+	require.Contains(w.watched[addr], id2)
+	require.NoError(w.Unregister(id2))
+	require.Empty(w.watched)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l          sync.Mutex
+		attempts   int
+		deliveries = make(chan *http.Request, 1)
+		bodies     = make(chan []byte, 1)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		l.Lock()
+		attempts++
+		attempt := attempts
+		l.Unlock()
+
+		// Failed deliveries are retried
+		if attempt == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		require.NoError(err)
+		deliveries <- req
+		bodies <- body
+	}))
+	defer server.Close()
+
+	_, m, err := newMetrics()
+	require.NoError(err)
+	vm := &VM{
+		vmDB:    memdb.New(),
+		metrics: m,
+		snowCtx: &snow.Context{Log: logging.NoLog{}},
+		stop:    make(chan struct{}),
+	}
+	w := newTestWebhookNotifier(t, vm)
+	addr := codec.CreateAddress(0, ids.GenerateTestID())
+	id, secret, err := w.Register(server.URL, []codec.Address{addr})
+	require.NoError(err)
+	w.Run()
+
+	txID := ids.GenerateTestID()
+	require.NoError(w.enqueue(w.hooks[id], txID, &WebhookPayload{
+		WebhookID: id,
+		Received:  [][]byte{addr[:]},
+		Tx:        &events.Tx{ID: txID},
+	}))
+	req := <-deliveries
+	body := <-bodies
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	require.Equal(hex.EncodeToString(mac.Sum(nil)), req.Header.Get(WebhookSignatureHeader))
+	require.NotEmpty(req.Header.Get(WebhookDeliveryHeader))
+
+	var payload WebhookPayload
+	require.NoError(json.Unmarshal(body, &payload))
+	require.Equal(id, payload.WebhookID)
+	require.Equal([][]byte{addr[:]}, payload.Received)
+	require.Equal(txID, payload.Tx.ID)
+
+	close(vm.stop)
+	w.Done()
+	l.Lock()
+	require.Equal(2, attempts)
+	l.Unlock()
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package workers
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTargetLatency = 5 * time.Millisecond
+	DefaultTuneInterval  = time.Second
+)
+
+// AdaptiveConfig bounds the workers of a pool created by [NewAdaptive] (or
+// sized by a [Tuner]).
+type AdaptiveConfig struct {
+	// The pool starts with [MinWorkers] and never has more than [MaxWorkers]
+	// (or GOMAXPROCS, if lower).
+	MinWorkers int
+	MaxWorkers int
+	// A worker is added when the jobs started during an [Interval] waited
+	// longer than [TargetLatency] on average in the queue, and removed when
+	// they waited less than half of it.
+	TargetLatency time.Duration
+	Interval      time.Duration
+}
+
+func (c *AdaptiveConfig) verify() error {
+	if c.MinWorkers <= 0 || c.MaxWorkers < c.MinWorkers ||
+		c.TargetLatency <= 0 || c.Interval <= 0 {
+		return ErrInvalidConfig
+	}
+	return nil
+}
+
+// Tuner tracks the size of a pool, tuned (within the bounds of its config) to
+// the queue latency reported with [Observe] and the cores available to the
+// process.
+//
+// Pools that only live as long as a single task (like the executor of a
+// block) can't be resized while they run, so they read their size from a
+// [Tuner] when they are created.
+type Tuner struct {
+	config *AdaptiveConfig
+
+	l      sync.Mutex
+	size   int
+	waited time.Duration
+	jobs   int
+}
+
+// NewTuner returns a [Tuner] with [config.MinWorkers]. It is up to the caller
+// to call [Tune] every [config.Interval].
+func NewTuner(config *AdaptiveConfig) (*Tuner, error) {
+	if err := config.verify(); err != nil {
+		return nil, err
+	}
+	return &Tuner{config: config, size: config.MinWorkers}, nil
+}
+
+// Size returns the number of workers a pool should have.
+func (t *Tuner) Size() int {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	return t.size
+}
+
+// Observe records that a job waited [latency] in the queue before a worker
+// started it.
+func (t *Tuner) Observe(latency time.Duration) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	t.waited += latency
+	t.jobs++
+}
+
+// Tune adds or removes (at most) one worker based on the average latency
+// observed since it was last called and returns the new size.
+func (t *Tuner) Tune() int {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	var latency time.Duration
+	if t.jobs > 0 {
+		latency = t.waited / time.Duration(t.jobs)
+	}
+	t.waited, t.jobs = 0, 0
+
+	maxWorkers := max(min(t.config.MaxWorkers, runtime.GOMAXPROCS(0)), t.config.MinWorkers)
+	switch {
+	case t.size > maxWorkers || (t.size > t.config.MinWorkers && latency < t.config.TargetLatency/2):
+		t.size--
+	case t.size < maxWorkers && latency > t.config.TargetLatency:
+		t.size++
+	}
+	return t.size
+}
+
+// NewAdaptive returns a pool of workers whose size is tuned (within the
+// bounds of [config]) to the latency of its queue. The number of workers of
+// a job is set when it is created.
+func NewAdaptive(config *AdaptiveConfig, maxJobs int, metrics Metrics) (Workers, error) {
+	tuner, err := NewTuner(config)
+	if err != nil {
+		return nil, err
+	}
+	w := NewParallelWithMetrics(config.MinWorkers, maxJobs, metrics).(*ParallelWorkers)
+	w.tuner = tuner
+	w.stopTuner = make(chan struct{})
+	w.stoppedTuner = make(chan struct{})
+	go w.tune(config.Interval)
+	return w, nil
+}
+
+// tune resizes w every [interval] until [Stop] is called.
+func (w *ParallelWorkers) tune(interval time.Duration) {
+	defer close(w.stoppedTuner)
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-w.stopTuner:
+			return
+		}
+		size := w.tuner.Tune()
+		w.lock.RLock()
+		count := w.count
+		w.lock.RUnlock()
+		switch {
+		case count > size:
+			// Workers only retire once they finish their current task
+			select {
+			case w.retireWorker <- struct{}{}:
+			case <-w.stopTuner:
+				return
+			}
+			count--
+		case count < size:
+			w.startWorker()
+			count++
+		default:
+			continue
+		}
+		w.lock.Lock()
+		w.count = count
+		w.lock.Unlock()
+		w.recordWorkers(count)
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package workers
+
+type Metrics interface {
+	// RecordQueueDepth records the number of jobs of [priority] waiting to
+	// start.
+	RecordQueueDepth(priority Priority, depth int)
+	// RecordWorkers records the number of workers of the pool.
+	RecordWorkers(count int)
+}
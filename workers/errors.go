@@ -5,4 +5,7 @@ package workers
 
 import "errors"
 
-var ErrShutdown = errors.New("workers shutdown")
+var (
+	ErrShutdown      = errors.New("workers shutdown")
+	ErrInvalidConfig = errors.New("invalid config")
+)
@@ -5,6 +5,7 @@ package workers
 
 import (
 	"sync"
+	"time"
 )
 
 var (
@@ -17,23 +18,39 @@ var (
 // Limit number of concurrent goroutines with resetable error
 // Ensure minimal overhead for parallel ops
 type ParallelWorkers struct {
-	count int               // Number of workers in the pool
-	queue chan *ParallelJob // A channel for passing jobs
+	count           int               // Number of workers in the pool (requires lock)
+	queue           chan *ParallelJob // A channel for passing critical jobs
+	backgroundQueue chan *ParallelJob // A channel for passing background jobs
+	metrics         Metrics
 
 	// tracking state
 	lock              sync.RWMutex
 	shouldShutdown    bool
 	triggeredShutdown bool
 
-	// single job execution
-	err   error // requires lock
-	sg    sync.WaitGroup
-	tasks chan func() error
+	// task execution
+	tasks chan *task
+
+	// sizes the pool (only set by [NewAdaptive])
+	tuner *Tuner
+
+	// number of jobs of each [Priority] that were created but not started
+	// (including those waiting for room in a full queue)
+	depthL sync.Mutex
+	depths [Background + 1]int
 
 	// shutdown coordination
 	ackShutdown    chan struct{}
+	retireWorker   chan struct{}
 	stopWorkers    chan struct{}
 	stoppedWorkers chan struct{}
+	stopTuner      chan struct{}
+	stoppedTuner   chan struct{}
+}
+
+type task struct {
+	j *ParallelJob
+	f func() error
 }
 
 // Goroutines allocate a minimum of 2KB of memory, we can save this by reusing
@@ -43,12 +60,22 @@ type ParallelWorkers struct {
 // Current size: https://github.com/golang/go/blob/fa463cc96d797c218be4e218723f83be47e814c8/src/runtime/stack.go#L74-L75
 // Backstory: https://medium.com/a-journey-with-go/go-how-does-the-goroutine-stack-size-evolve-447fc02085e5
 func NewParallel(workers int, maxJobs int) Workers {
+	return NewParallelWithMetrics(workers, maxJobs, nil)
+}
+
+// NewParallelWithMetrics is [NewParallel] with the queue depth of each
+// [Priority] recorded by [metrics]. [maxJobs] jobs of each priority can be
+// queued.
+func NewParallelWithMetrics(workers int, maxJobs int, metrics Metrics) Workers {
 	w := &ParallelWorkers{
-		count: workers,
-		queue: make(chan *ParallelJob, maxJobs),
+		count:           workers,
+		queue:           make(chan *ParallelJob, maxJobs),
+		backgroundQueue: make(chan *ParallelJob, maxJobs),
+		metrics:         metrics,
 
-		tasks:          make(chan func() error),
+		tasks:          make(chan *task),
 		ackShutdown:    make(chan struct{}),
+		retireWorker:   make(chan struct{}),
 		stopWorkers:    make(chan struct{}),
 		stoppedWorkers: make(chan struct{}),
 	}
@@ -56,34 +83,45 @@ func NewParallel(workers int, maxJobs int) Workers {
 	for i := 0; i < workers; i++ {
 		w.startWorker()
 	}
+	w.recordWorkers(workers)
 	return w
 }
 
-// processQueue starts a new goroutine that listens to the queue channel for jobs.
-// It assigns that jobs' tasks to w until shouldShutdown is set.
+// processQueue starts a new goroutine that listens to the queue channels for
+// jobs. It assigns that jobs' tasks to w until shouldShutdown is set.
+//
+// Critical jobs always start before background jobs and preempt the
+// background job being processed (which only resumes once no critical job is
+// queued).
 func (w *ParallelWorkers) processQueue() {
 	go func() {
-		for j := range w.queue {
-			// Don't do work if should shutdown
-			w.lock.Lock()
-			shouldShutdown := w.shouldShutdown
-			w.lock.Unlock()
-			if shouldShutdown {
-				j.result <- ErrShutdown
-				continue
-			}
-			// Process tasks
-			for t := range j.tasks {
-				w.sg.Add(1)
-				w.tasks <- t
+		critical, background := w.queue, w.backgroundQueue
+		for critical != nil || background != nil {
+			var (
+				j  *ParallelJob
+				ok bool
+			)
+			select {
+			case j, ok = <-critical:
+				if !ok {
+					critical = nil
+					continue
+				}
+			default:
+				select {
+				case j, ok = <-critical:
+					if !ok {
+						critical = nil
+						continue
+					}
+				case j, ok = <-background:
+					if !ok {
+						background = nil
+						continue
+					}
+				}
 			}
-			w.sg.Wait()
-			// Send result to queue and reset err
-			w.lock.Lock()
-			close(j.completed)
-			j.result <- w.err
-			w.err = nil
-			w.lock.Unlock()
+			w.run(j, &critical)
 		}
 		// Ensure stop returns
 		w.lock.Lock()
@@ -95,6 +133,101 @@ func (w *ParallelWorkers) processQueue() {
 	}()
 }
 
+// run assigns the tasks of [j] to w and sends its result once they are
+// completed. While a background job waits for tasks or workers, critical jobs
+// received from [critical] are run first (and [critical] is set to nil once
+// it is closed).
+func (w *ParallelWorkers) run(j *ParallelJob, critical *chan *ParallelJob) {
+	w.recordQueueDepth(j.priority, -1)
+
+	// Don't do work if should shutdown
+	w.lock.Lock()
+	shouldShutdown := w.shouldShutdown
+	w.lock.Unlock()
+	if w.tuner != nil {
+		w.tuner.Observe(time.Since(j.queued))
+	}
+	if shouldShutdown {
+		j.result <- ErrShutdown
+		return
+	}
+	preempting := func() chan *ParallelJob {
+		if j.priority == Critical {
+			return nil
+		}
+		return *critical
+	}
+	preempt := func(cj *ParallelJob, ok bool) {
+		if !ok {
+			*critical = nil
+			return
+		}
+		w.run(cj, critical)
+	}
+	// runQueued runs the critical jobs that are already queued, as select
+	// picks a random case when both a task and a critical job are ready
+	runQueued := func() {
+		for {
+			select {
+			case cj, ok := <-preempting():
+				preempt(cj, ok)
+			default:
+				return
+			}
+		}
+	}
+	send := func(t *task) {
+		for {
+			runQueued()
+			select {
+			case w.tasks <- t:
+				return
+			case cj, ok := <-preempting():
+				preempt(cj, ok)
+			}
+		}
+	}
+
+	// Process tasks
+	tasks := j.tasks
+	for tasks != nil {
+		runQueued()
+		select {
+		case f, ok := <-tasks:
+			if !ok {
+				tasks = nil
+				continue
+			}
+			j.wg.Add(1)
+			send(&task{j: j, f: f})
+		case cj, ok := <-preempting():
+			preempt(cj, ok)
+		}
+	}
+	if j.priority == Critical {
+		j.wg.Wait()
+	} else {
+		done := make(chan struct{})
+		go func() {
+			j.wg.Wait()
+			close(done)
+		}()
+		for done != nil {
+			runQueued()
+			select {
+			case <-done:
+				done = nil
+			case cj, ok := <-preempting():
+				preempt(cj, ok)
+			}
+		}
+	}
+
+	// Send result to queue
+	close(j.completed)
+	j.result <- j.error()
+}
+
 // startWorker starts a new goroutine that listens to two channels.
 // The stopWorkers channel signals the worker to stop processing tasks.
 // The tasks channel attempts to process a job.
@@ -105,52 +238,98 @@ func (w *ParallelWorkers) startWorker() {
 			case <-w.stopWorkers:
 				w.stoppedWorkers <- struct{}{}
 				return
-			case j := <-w.tasks:
+			case <-w.retireWorker:
+				return
+			case t := <-w.tasks:
 				// Check if we should even do the work
-				w.lock.RLock()
-				err := w.err
-				w.lock.RUnlock()
-				if err != nil {
-					w.sg.Done()
-					return
+				if t.j.error() != nil {
+					t.j.wg.Done()
+					continue
 				}
 				// Attempt to process the job
-				if err := j(); err != nil {
-					w.lock.Lock()
-					if w.err == nil {
-						w.err = err
-					}
-					w.lock.Unlock()
+				if err := t.f(); err != nil {
+					t.j.setError(err)
 				}
-				w.sg.Done()
+				t.j.wg.Done()
 			}
 		}
 	}()
 }
 
+func (w *ParallelWorkers) recordWorkers(count int) {
+	if w.metrics == nil {
+		return
+	}
+	w.metrics.RecordWorkers(count)
+}
+
+// recordQueueDepth adds [delta] to the number of jobs of [priority] that
+// have not started and records it.
+func (w *ParallelWorkers) recordQueueDepth(priority Priority, delta int) {
+	if w.metrics == nil {
+		return
+	}
+	w.depthL.Lock()
+	defer w.depthL.Unlock()
+
+	w.depths[priority] += delta
+	w.metrics.RecordQueueDepth(priority, w.depths[priority])
+}
+
 // Stop stops the worker pool by setting shouldShutdown, closing the
 // queue and waiting for all workers to complete.
 func (w *ParallelWorkers) Stop() {
 	w.lock.Lock()
 	w.shouldShutdown = true
 	w.lock.Unlock()
+	if w.stopTuner != nil {
+		close(w.stopTuner)
+		<-w.stoppedTuner
+	}
 	close(w.queue)
+	close(w.backgroundQueue)
 
 	// Wait for scheduler to return
 	<-w.ackShutdown
 	close(w.stopWorkers)
 
 	// Wait for all workers to return
-	for i := 0; i < w.count; i++ {
+	w.lock.RLock()
+	count := w.count
+	w.lock.RUnlock()
+	for i := 0; i < count; i++ {
 		<-w.stoppedWorkers
 	}
 }
 
 type ParallelJob struct {
 	count     int
+	priority  Priority
+	queued    time.Time
 	tasks     chan func() error
 	completed chan struct{}
 	result    chan error
+
+	// execution of tasks
+	wg   sync.WaitGroup
+	errL sync.RWMutex
+	err  error
+}
+
+func (j *ParallelJob) error() error {
+	j.errL.RLock()
+	defer j.errL.RUnlock()
+
+	return j.err
+}
+
+func (j *ParallelJob) setError(err error) {
+	j.errL.Lock()
+	defer j.errL.Unlock()
+
+	if j.err == nil {
+		j.err = err
+	}
 }
 
 // Go adds [f] to the j's task channel.
@@ -183,25 +362,41 @@ func (j *ParallelJob) Workers() int {
 	return j.count
 }
 
-// NewJob creates a new job and adds it to the workers' queue. [taskBacklog]
-// specifies the maximum number of tasks that can be added to the created job
-// channel.
+// NewJob creates a new [Critical] job and adds it to the workers' queue.
+// [taskBacklog] specifies the maximum number of tasks that can be added to
+// the created job channel.
 //
 // If you don't want to block, make sure taskBacklog is greater than all
 // possible tasks you'll add.
 func (w *ParallelWorkers) NewJob(taskBacklog int) (Job, error) {
+	return w.NewPriorityJob(Critical, taskBacklog)
+}
+
+// NewPriorityJob creates a new job of [priority] and adds it to the workers'
+// queue of [priority] (see [NewJob]).
+func (w *ParallelWorkers) NewPriorityJob(priority Priority, taskBacklog int) (Job, error) {
 	w.lock.Lock()
 	shouldShutdown := w.shouldShutdown
+	count := w.count
 	w.lock.Unlock()
 	if shouldShutdown {
 		return nil, ErrShutdown
 	}
 	j := &ParallelJob{
-		count:     w.count,
+		count:     count,
+		priority:  priority,
+		queued:    time.Now(),
 		tasks:     make(chan func() error, taskBacklog),
 		completed: make(chan struct{}),
 		result:    make(chan error, 1),
 	}
-	w.queue <- j
+	// The depth is recorded before the job is queued, as queuing blocks while
+	// the queue is full
+	w.recordQueueDepth(priority, 1)
+	if priority == Critical {
+		w.queue <- j
+	} else {
+		w.backgroundQueue <- j
+	}
 	return j, nil
 }
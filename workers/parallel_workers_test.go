@@ -268,3 +268,231 @@ func TestNewJobShutdown(t *testing.T) {
 	require.ErrorIs(ErrShutdown, err, "NewJob returned no error")
 	require.Nil(job, "NewJob returned a not nil job pointer.")
 }
+
+type testMetrics struct {
+	l       sync.Mutex
+	depths  map[Priority]int
+	workers int
+}
+
+func (m *testMetrics) RecordQueueDepth(priority Priority, depth int) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	m.depths[priority] = max(m.depths[priority], depth)
+}
+
+func (m *testMetrics) RecordWorkers(count int) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	m.workers = count
+}
+
+func (m *testMetrics) getWorkers() int {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	return m.workers
+}
+
+func TestWorkerPriority(t *testing.T) {
+	require := require.New(t)
+	metrics := &testMetrics{depths: map[Priority]int{}}
+	w := NewParallelWithMetrics(1, 10, metrics)
+	defer w.Stop()
+
+	var (
+		orderLock sync.Mutex
+		order     []string
+		started   = make(chan struct{})
+		release   = make(chan struct{})
+	)
+	record := func(name string) func() error {
+		return func() error {
+			orderLock.Lock()
+			defer orderLock.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	// Saturate the only worker with a background job
+	background, err := w.NewPriorityJob(Background, 3)
+	require.NoError(err)
+	background.Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	background.Go(record("background"))
+	background.Go(record("background"))
+	background.Done(nil)
+	<-started
+
+	// Background jobs wait for the current one while critical jobs run before
+	// its remaining tasks
+	queued, err := w.NewPriorityJob(Background, 1)
+	require.NoError(err)
+	queued.Go(record("queued"))
+	queued.Done(nil)
+	critical, err := w.NewJob(1)
+	require.NoError(err)
+	critical.Go(record("critical"))
+	critical.Done(nil)
+	close(release)
+	require.NoError(critical.Wait())
+	require.NoError(background.Wait())
+	require.NoError(queued.Wait())
+	require.Equal([]string{"critical", "background", "background", "queued"}, order)
+
+	metrics.l.Lock()
+	defer metrics.l.Unlock()
+	require.Equal(1, metrics.depths[Background])
+}
+
+func TestWorkerQueueDepth(t *testing.T) {
+	require := require.New(t)
+	metrics := &testMetrics{depths: map[Priority]int{}}
+	w := NewParallelWithMetrics(1, 1, metrics)
+	defer w.Stop()
+
+	// Saturate the only worker
+	started, release := make(chan struct{}), make(chan struct{})
+	running, err := w.NewJob(1)
+	require.NoError(err)
+	running.Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	running.Done(nil)
+	<-started
+
+	// Jobs waiting for room in the full queue are part of its depth
+	jobs := make(chan Job, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			j, err := w.NewJob(1)
+			if err == nil {
+				j.Done(nil)
+			}
+			jobs <- j
+		}()
+	}
+	require.Eventually(func() bool {
+		metrics.l.Lock()
+		defer metrics.l.Unlock()
+		return metrics.depths[Critical] == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	close(release)
+	require.NoError(running.Wait())
+	for i := 0; i < 2; i++ {
+		j := <-jobs
+		require.NotNil(j)
+		require.NoError(j.Wait())
+	}
+}
+
+func TestWorkerPriorityError(t *testing.T) {
+	require := require.New(t)
+	w := NewParallel(2, 10)
+	defer w.Stop()
+
+	// Errors are tracked by job
+	errTest := errors.New("test")
+	background, err := w.NewPriorityJob(Background, 1)
+	require.NoError(err)
+	background.Go(func() error { return errTest })
+	background.Done(nil)
+	critical, err := w.NewJob(1)
+	require.NoError(err)
+	critical.Go(func() error { return nil })
+	critical.Done(nil)
+	require.ErrorIs(background.Wait(), errTest)
+	require.NoError(critical.Wait())
+}
+
+func TestAdaptiveWorkers(t *testing.T) {
+	require := require.New(t)
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	_, err := NewAdaptive(&AdaptiveConfig{MinWorkers: 2, MaxWorkers: 1, TargetLatency: time.Millisecond, Interval: time.Millisecond}, 10, nil)
+	require.ErrorIs(err, ErrInvalidConfig)
+
+	metrics := &testMetrics{depths: map[Priority]int{}}
+	w, err := NewAdaptive(&AdaptiveConfig{
+		MinWorkers:    1,
+		MaxWorkers:    8,
+		TargetLatency: time.Millisecond,
+		Interval:      10 * time.Millisecond,
+	}, 100, metrics)
+	require.NoError(err)
+	require.Equal(1, metrics.getWorkers())
+
+	// Workers are added while jobs wait in the queue (up to GOMAXPROCS)
+	ctx, cancel := context.WithCancel(context.Background())
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		for ctx.Err() == nil {
+			j, err := w.NewJob(1)
+			if err != nil {
+				return
+			}
+			j.Go(func() error {
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			})
+			j.Done(nil)
+		}
+	}()
+	require.Eventually(func() bool { return metrics.getWorkers() == 4 }, 5*time.Second, 10*time.Millisecond)
+	j, err := w.NewJob(1)
+	require.NoError(err)
+	require.Equal(4, j.Workers())
+	j.Done(nil)
+
+	// Idle workers are removed
+	cancel()
+	<-loaded
+	require.Eventually(func() bool { return metrics.getWorkers() == 1 }, 5*time.Second, 10*time.Millisecond)
+	w.Stop()
+}
+
+func TestTuner(t *testing.T) {
+	require := require.New(t)
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	_, err := NewTuner(&AdaptiveConfig{MinWorkers: 0, MaxWorkers: 1, TargetLatency: time.Millisecond, Interval: time.Millisecond})
+	require.ErrorIs(err, ErrInvalidConfig)
+
+	tuner, err := NewTuner(&AdaptiveConfig{
+		MinWorkers:    1,
+		MaxWorkers:    8,
+		TargetLatency: 10 * time.Millisecond,
+		Interval:      time.Second,
+	})
+	require.NoError(err)
+	require.Equal(1, tuner.Size())
+
+	// A worker is added each time jobs wait longer than the target (up to
+	// GOMAXPROCS)
+	for _, size := range []int{2, 3, 4, 4} {
+		tuner.Observe(5 * time.Millisecond)
+		tuner.Observe(25 * time.Millisecond)
+		require.Equal(size, tuner.Tune())
+		require.Equal(size, tuner.Size())
+	}
+
+	// The size is kept while the latency is close to the target
+	tuner.Observe(8 * time.Millisecond)
+	require.Equal(4, tuner.Tune())
+
+	// A worker is removed each time jobs don't wait (or there are none)
+	tuner.Observe(time.Millisecond)
+	require.Equal(3, tuner.Tune())
+	for _, size := range []int{2, 1, 1} {
+		require.Equal(size, tuner.Tune())
+	}
+}
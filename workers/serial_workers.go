@@ -25,6 +25,10 @@ func (*SerialWorkers) NewJob(_ int) (Job, error) {
 	return &SerialJob{}, nil
 }
 
+func (w *SerialWorkers) NewPriorityJob(_ Priority, backlog int) (Job, error) {
+	return w.NewJob(backlog)
+}
+
 func (*SerialWorkers) Stop() {}
 
 func (j *SerialJob) Go(f func() error) {
@@ -3,8 +3,33 @@
 
 package workers
 
+// Priority is the class of a job. When the workers are saturated, jobs of a
+// higher priority run before (and preempt) jobs of a lower priority.
+type Priority uint8
+
+const (
+	// Critical jobs are required by consensus (like the verification of the
+	// signatures of a block).
+	Critical Priority = iota
+	// Background jobs can be delayed (like the verification of gossip).
+	Background
+)
+
+func (p Priority) String() string {
+	switch p {
+	case Critical:
+		return "critical"
+	case Background:
+		return "background"
+	default:
+		return "unknown"
+	}
+}
+
 type Workers interface {
+	// NewJob creates a new [Critical] job.
 	NewJob(backlog int) (Job, error)
+	NewPriorityJob(priority Priority, backlog int) (Job, error)
 	Stop()
 }
 
@@ -0,0 +1,143 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package actions lets any hypervm deploy and execute programs (see
+// [runtime]) with generic actions, so app logic can be deployed without
+// shipping a new VM binary.
+//
+// [DeployProgram] stores the bytes of a program under a key derived from
+// their hash (so each program is only stored once) and creates an account
+// for it (which holds the state and balance of the program).
+//
+// [CallProgram] calls a function of a program with a fuel limit. The fee of
+// the call is determined by this limit (not the fuel actually consumed),
+// which is converted to compute units at [Config.FuelPerComputeUnit].
+// Programs can't touch state that isn't declared by the action (like any
+// other action), so callers must list the programs that may be loaded and
+// the keys of their state that may be accessed during the call.
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/programs/runtime"
+)
+
+// Note: Registry will error during initialization if a duplicate ID is
+// assigned. VMs must not register their own actions with these IDs.
+const (
+	CallProgramID   uint8 = 0xe0
+	DeployProgramID uint8 = 0xe1
+)
+
+var (
+	ErrNotRegistered      = errors.New("program action was not parsed by a registered module")
+	ErrInvalidConfig      = errors.New("invalid programs config")
+	ErrUnknownAccount     = errors.New("account has no program")
+	ErrUnknownProgram     = errors.New("unknown program")
+	ErrAccountExists      = errors.New("account already exists")
+	ErrProgramTooLarge    = errors.New("program is too large")
+	ErrInvalidProgram     = errors.New("invalid program")
+	ErrFuelZero           = errors.New("fuel is zero")
+	ErrFuelTooLarge       = errors.New("fuel is too large")
+	ErrFunctionEmpty      = errors.New("function is empty")
+	ErrTooManyAccesses    = errors.New("too many accesses")
+	ErrTooManyKeys        = errors.New("too many keys")
+	ErrKeyTooLarge        = errors.New("key is too large")
+	ErrProgramNotDeclared = errors.New("program not declared")
+)
+
+// Balances moves the native balance of the VM on behalf of programs (when
+// value is attached to a call or a program sends its balance).
+type Balances interface {
+	// BalanceStateKeys are the keys read and written by [GetBalance] and
+	// [TransferBalance] for [addr] (formatted like the keys of
+	// [chain.Action.StateKeys]).
+	BalanceStateKeys(addr codec.Address) state.Keys
+	BalanceStateKeysMaxChunks() []uint16
+
+	GetBalance(ctx context.Context, im state.Immutable, addr codec.Address) (uint64, error)
+	TransferBalance(ctx context.Context, mu state.Mutable, from codec.Address, to codec.Address, amount uint64) error
+}
+
+type Config struct {
+	// StatePrefix is the first byte of every key written by program actions
+	// (which no other key of the VM may start with)
+	StatePrefix byte `json:"statePrefix"`
+
+	// FuelPerComputeUnit is the amount of fuel paid for by each compute
+	// unit of a [CallProgram]
+	FuelPerComputeUnit uint64 `json:"fuelPerComputeUnit"`
+	// MaxFuel is the maximum amount of fuel a single [CallProgram] can use
+	MaxFuel uint64 `json:"maxFuel"`
+
+	// MaxProgramChunks is the maximum number of chunks of the bytes of a
+	// program and MaxValueChunks is the maximum number of chunks of a value
+	// stored by a program
+	MaxProgramChunks uint16 `json:"maxProgramChunks"`
+	MaxValueChunks   uint16 `json:"maxValueChunks"`
+
+	// MaxAccesses is the maximum number of programs a [CallProgram] can
+	// declare and MaxKeys is the maximum number of keys (of all of them)
+	MaxAccesses int `json:"maxAccesses"`
+	MaxKeys     int `json:"maxKeys"`
+	// MaxKeySize is the maximum size of a key used by a program
+	MaxKeySize int `json:"maxKeySize"`
+}
+
+// Module provides the program actions.
+type Module struct {
+	config   Config
+	balances Balances
+
+	// The runtime keeps per-call state that isn't safe for concurrent use,
+	// so calls are executed one at a time.
+	l       sync.Mutex
+	runtime *runtime.WasmRuntime
+}
+
+func New(config Config, runtimeConfig *runtime.Config, log logging.Logger, balances Balances) (*Module, error) {
+	if config.FuelPerComputeUnit == 0 || config.MaxFuel == 0 {
+		return nil, fmt.Errorf("%w: fuel limits must be positive", ErrInvalidConfig)
+	}
+	if config.MaxProgramChunks == 0 || config.MaxValueChunks == 0 {
+		return nil, fmt.Errorf("%w: chunk limits must be positive", ErrInvalidConfig)
+	}
+	if config.MaxAccesses <= 0 || config.MaxKeys < 0 || config.MaxKeySize <= 0 {
+		return nil, fmt.Errorf("%w: access limits must be positive", ErrInvalidConfig)
+	}
+	return &Module{
+		config:   config,
+		balances: balances,
+		runtime:  runtime.NewRuntime(runtimeConfig, log),
+	}, nil
+}
+
+// Register adds the program actions to [registry]. Only actions parsed by
+// [registry] (or created by the constructors of [m]) can be executed.
+func (m *Module) Register(registry chain.ActionRegistry) error {
+	r := (*codec.TypeParser[chain.Action])(registry)
+	for _, action := range []struct {
+		typeID    uint8
+		unmarshal func(*Module, *codec.Packer) (chain.Action, error)
+	}{
+		{CallProgramID, unmarshalCallProgram},
+		{DeployProgramID, unmarshalDeployProgram},
+	} {
+		unmarshal := action.unmarshal
+		if err := r.Register(action.typeID, func(p *codec.Packer) (chain.Action, error) {
+			return unmarshal(m, p)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
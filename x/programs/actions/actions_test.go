@@ -0,0 +1,194 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/bytecodealliance/wasmtime-go/v14"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/x/programs/runtime"
+)
+
+const testProgram = `
+(module
+  (import "program" "set_call_result" (func $set_call_result (param i32 i32)))
+  (import "context" "actor" (func $actor (result i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 16) "hello")
+  (global $next (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $len i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (global.get $next))
+    (global.set $next (i32.add (global.get $next) (local.get $len)))
+    (local.get $ptr))
+  (func (export "hello") (param i32)
+    (call $set_call_result (i32.const 16) (i32.const 5)))
+  (func (export "actor") (param i32)
+    (call $set_call_result (call $actor) (i32.const 33)))
+  (func (export "spin") (param i32)
+    (loop $l (br $l)))
+  (func (export "grow") (param i32)
+    (if (i32.eq (memory.grow (i32.const 512)) (i32.const -1))
+      (then unreachable))))
+`
+
+type testState map[string][]byte
+
+func (s testState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s testState) Insert(_ context.Context, key []byte, value []byte) error {
+	s[string(key)] = value
+	return nil
+}
+
+func (s testState) Remove(_ context.Context, key []byte) error {
+	delete(s, string(key))
+	return nil
+}
+
+// testBalances keeps balances in memory (without reading state).
+type testBalances map[codec.Address]uint64
+
+func (testBalances) BalanceStateKeys(codec.Address) state.Keys { return state.Keys{} }
+
+func (testBalances) BalanceStateKeysMaxChunks() []uint16 { return nil }
+
+func (b testBalances) GetBalance(_ context.Context, _ state.Immutable, addr codec.Address) (uint64, error) {
+	return b[addr], nil
+}
+
+func (b testBalances) TransferBalance(_ context.Context, _ state.Mutable, from codec.Address, to codec.Address, amount uint64) error {
+	if b[from] < amount {
+		return errors.New("insufficient balance")
+	}
+	b[from] -= amount
+	b[to] += amount
+	return nil
+}
+
+func newTestModule(t *testing.T, balances testBalances) *Module {
+	m, err := New(Config{
+		StatePrefix:        0xfe,
+		FuelPerComputeUnit: 1_000,
+		MaxFuel:            1_000_000,
+		MaxProgramChunks:   64,
+		MaxValueChunks:     4,
+		MaxAccesses:        4,
+		MaxKeys:            16,
+		MaxKeySize:         64,
+	}, runtime.NewConfig(), logging.NoLog{}, balances)
+	require.NoError(t, err)
+	return m
+}
+
+func TestCallProgram(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		actor    = codec.CreateAddress(0, ids.GenerateTestID())
+		balances = testBalances{actor: 10}
+		m        = newTestModule(t, balances)
+		mu       = testState{}
+	)
+	// Actions are executed as parsed by the registry
+	registry := codec.NewTypeParser[chain.Action]()
+	require.NoError(m.Register(registry))
+	parse := func(a chain.Action) (chain.Action, error) {
+		p := codec.NewWriter(a.Size(), a.Size())
+		a.Marshal(p)
+		require.NoError(p.Err())
+		unmarshal, ok := registry.LookupIndex(a.GetTypeID())
+		require.True(ok)
+		return unmarshal(codec.NewReader(p.Bytes(), a.Size()))
+	}
+
+	program, err := wasmtime.Wat2Wasm(testProgram)
+	require.NoError(err)
+	programID := utils.ToID(program)
+	account := ProgramAccount(programID, nil)
+
+	// Programs are stored once and can have many accounts
+	deploy, err := parse(m.DeployProgram(program, nil))
+	require.NoError(err)
+	require.Contains(deploy.StateKeys(actor, ids.Empty), string(ProgramKey(0xfe, programID, 64)))
+	outputs, err := deploy.Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	require.NoError(err)
+	require.Equal([][]byte{account[:]}, outputs)
+	_, err = deploy.Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	require.ErrorIs(err, ErrAccountExists)
+	deploy, err = parse(m.DeployProgram(program, []byte{1}))
+	require.NoError(err)
+	_, err = deploy.Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	require.NoError(err)
+	_, err = m.DeployProgram([]byte{1}, nil).Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	require.ErrorIs(err, ErrInvalidProgram)
+
+	access, err := m.Access(ctx, mu, account)
+	require.NoError(err)
+	accesses := []*Access{access}
+
+	call, err := parse(m.CallProgram(account, "hello", nil, 4, 100_000, accesses, nil))
+	require.NoError(err)
+	require.Equal(uint64(CallProgramComputeUnits+100), call.ComputeUnits(nil))
+	require.Equal(uint64(100_000), call.(chain.FueledAction).MaxFuel())
+	stateKeys := call.StateKeys(actor, ids.Empty)
+	require.Contains(stateKeys, string(AccountKey(0xfe, account)))
+	require.Contains(stateKeys, string(ProgramKey(0xfe, programID, 64)))
+	outputs, err = call.Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	require.NoError(err)
+	require.Equal([][]byte{[]byte("hello")}, outputs)
+
+	// Programs can read the context of the call
+	call, err = parse(m.CallProgram(account, "actor", nil, 0, 100_000, accesses, nil))
+	require.NoError(err)
+	outputs, err = call.Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	require.NoError(err)
+	require.Equal([][]byte{actor[:]}, outputs)
+	require.Equal(uint64(6), balances[actor])
+	require.Equal(uint64(4), balances[account])
+
+	// Execution stops once the fuel of the call is consumed
+	call, err = parse(m.CallProgram(account, "spin", nil, 0, 100_000, accesses, nil))
+	require.NoError(err)
+	_, err = call.Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	code, ok := runtime.ExtractProgramCallErrorCode(err)
+	require.True(ok)
+	require.Equal(runtime.OutOfFuel, code)
+
+	// Memory can't grow past the limit of the runtime
+	call, err = parse(m.CallProgram(account, "grow", nil, 0, 100_000, accesses, nil))
+	require.NoError(err)
+	_, err = call.Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	code, ok = runtime.ExtractProgramCallErrorCode(err)
+	require.True(ok)
+	require.Equal(runtime.CallPanicked, code)
+
+	// Calls must be within the limits of the module
+	_, err = parse(m.CallProgram(account, "hello", nil, 0, 1_000_001, accesses, nil))
+	require.ErrorIs(err, ErrFuelTooLarge)
+	_, err = parse(m.CallProgram(account, "hello", nil, 0, 100_000, nil, nil))
+	require.ErrorIs(err, ErrProgramNotDeclared)
+	_, err = m.CallProgram(account, "hello", nil, 0, 100_000, []*Access{
+		{Account: account, Program: programID, Keys: [][]byte{make([]byte, 65)}},
+	}, nil).Execute(ctx, nil, mu, 0, actor, ids.Empty)
+	require.ErrorIs(err, ErrKeyTooLarge)
+}
@@ -0,0 +1,308 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/x/programs/runtime"
+)
+
+const CallProgramComputeUnits = 5
+
+var _ chain.FueledAction = (*CallProgram)(nil)
+
+// Access declares a program that may be loaded during a [CallProgram] and
+// the keys of its state that may be accessed.
+type Access struct {
+	// Account is the address of the program
+	Account codec.Address `json:"account"`
+	// Program is the ID of the program of [Account]
+	Program ids.ID `json:"program"`
+	// Keys are the keys (as used by the program) of the state of [Account]
+	// that may be read or written
+	Keys [][]byte `json:"keys"`
+}
+
+func (a *Access) size() int {
+	size := codec.AddressLen + ids.IDLen + consts.IntLen
+	for _, key := range a.Keys {
+		size += codec.BytesLen(key)
+	}
+	return size
+}
+
+// CallProgram calls [Function] of the program of [Program] with [Params]
+// (after transferring [Value] from the actor to [Program]). The output of the
+// action is the result of the call.
+type CallProgram struct {
+	Program  codec.Address `json:"program"`
+	Function string        `json:"function"`
+	Params   []byte        `json:"params"`
+	Value    uint64        `json:"value"`
+
+	// Fuel is the maximum amount of fuel the call (including the calls it
+	// makes to other programs) can consume. The call is paid for as if all
+	// of it is consumed.
+	Fuel uint64 `json:"fuel"`
+
+	// Accesses must include [Program] and any program it may call
+	Accesses []*Access `json:"accesses"`
+	// Balances are the addresses (other than the actor and the accounts of
+	// [Accesses]) whose balance may be read or changed
+	Balances []codec.Address `json:"balances"`
+
+	m *Module
+}
+
+// CallProgram returns an action that calls [function] of [program].
+func (m *Module) CallProgram(
+	program codec.Address,
+	function string,
+	params []byte,
+	value uint64,
+	fuel uint64,
+	accesses []*Access,
+	balances []codec.Address,
+) *CallProgram {
+	return &CallProgram{
+		Program:  program,
+		Function: function,
+		Params:   params,
+		Value:    value,
+		Fuel:     fuel,
+		Accesses: accesses,
+		Balances: balances,
+		m:        m,
+	}
+}
+
+func (*CallProgram) GetTypeID() uint8 {
+	return CallProgramID
+}
+
+func (c *CallProgram) StateKeys(actor codec.Address, _ ids.ID) state.Keys {
+	if c.m == nil {
+		return state.Keys{}
+	}
+	var (
+		prefix    = c.m.config.StatePrefix
+		stateKeys = state.Keys{}
+	)
+	addBalance := func(addr codec.Address) {
+		for k, permissions := range c.m.balances.BalanceStateKeys(addr) {
+			stateKeys.Add(k, permissions)
+		}
+	}
+	addBalance(actor)
+	for _, access := range c.Accesses {
+		stateKeys.Add(string(AccountKey(prefix, access.Account)), state.Read)
+		stateKeys.Add(string(ProgramKey(prefix, access.Program, c.m.config.MaxProgramChunks)), state.Read)
+		for _, key := range access.Keys {
+			stateKeys.Add(string(ValueKey(prefix, access.Account, key, c.m.config.MaxValueChunks)), state.All)
+		}
+		addBalance(access.Account)
+	}
+	for _, addr := range c.Balances {
+		addBalance(addr)
+	}
+	return stateKeys
+}
+
+func (c *CallProgram) StateKeysMaxChunks() []uint16 {
+	if c.m == nil {
+		return nil
+	}
+	balanceChunks := c.m.balances.BalanceStateKeysMaxChunks()
+	chunks := append([]uint16{}, balanceChunks...)
+	for _, access := range c.Accesses {
+		chunks = append(chunks, AccountChunks, c.m.config.MaxProgramChunks)
+		for range access.Keys {
+			chunks = append(chunks, c.m.config.MaxValueChunks)
+		}
+		chunks = append(chunks, balanceChunks...)
+	}
+	for range c.Balances {
+		chunks = append(chunks, balanceChunks...)
+	}
+	return chunks
+}
+
+func (c *CallProgram) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor codec.Address,
+	actionID ids.ID,
+) ([][]byte, error) {
+	if c.m == nil {
+		return nil, ErrNotRegistered
+	}
+	if err := c.m.verifyCall(c); err != nil {
+		return nil, err
+	}
+	// The height is only known when executing a block (not when simulating
+	// a call)
+	height, _ := chain.Height(ctx)
+	callInfo := &runtime.CallInfo{
+		State:        &stateManager{m: c.m, mu: mu},
+		Actor:        actor,
+		FunctionName: c.Function,
+		Program:      c.Program,
+		Params:       c.Params,
+		Fuel:         c.Fuel,
+		Height:       height,
+		Timestamp:    uint64(timestamp),
+		ActionID:     actionID,
+		Value:        c.Value,
+	}
+
+	c.m.l.Lock()
+	defer c.m.l.Unlock()
+
+	result, err := c.m.runtime.CallProgram(ctx, callInfo)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{result}, nil
+}
+
+func (c *CallProgram) MaxFuel() uint64 {
+	return c.Fuel
+}
+
+func (c *CallProgram) ComputeUnits(chain.Rules) uint64 {
+	if c.m == nil {
+		return CallProgramComputeUnits
+	}
+	// [Fuel] is at most [Config.MaxFuel], so this can't overflow
+	return CallProgramComputeUnits + (c.Fuel+c.m.config.FuelPerComputeUnit-1)/c.m.config.FuelPerComputeUnit
+}
+
+func (c *CallProgram) Size() int {
+	size := codec.AddressLen +
+		codec.StringLen(c.Function) +
+		codec.BytesLen(c.Params) +
+		consts.Uint64Len*2 +
+		consts.IntLen + consts.IntLen + codec.AddressLen*len(c.Balances)
+	for _, access := range c.Accesses {
+		size += access.size()
+	}
+	return size
+}
+
+func (c *CallProgram) Marshal(p *codec.Packer) {
+	p.PackAddress(c.Program)
+	p.PackString(c.Function)
+	p.PackBytes(c.Params)
+	p.PackUint64(c.Value)
+	p.PackUint64(c.Fuel)
+	p.PackInt(len(c.Accesses))
+	for _, access := range c.Accesses {
+		p.PackAddress(access.Account)
+		p.PackID(access.Program)
+		p.PackInt(len(access.Keys))
+		for _, key := range access.Keys {
+			p.PackBytes(key)
+		}
+	}
+	p.PackInt(len(c.Balances))
+	for _, addr := range c.Balances {
+		p.PackAddress(addr)
+	}
+}
+
+func unmarshalCallProgram(m *Module, p *codec.Packer) (chain.Action, error) {
+	call := CallProgram{m: m}
+	p.UnpackAddress(&call.Program)
+	call.Function = p.UnpackString(true)
+	p.UnpackBytes(-1, false, &call.Params)
+	call.Value = p.UnpackUint64(false)
+	call.Fuel = p.UnpackUint64(true)
+	accesses := p.UnpackInt(false)
+	if accesses > m.config.MaxAccesses {
+		return nil, ErrTooManyAccesses
+	}
+	call.Accesses = make([]*Access, accesses)
+	var totalKeys int
+	for i := range call.Accesses {
+		access := &Access{}
+		p.UnpackAddress(&access.Account)
+		p.UnpackID(true, &access.Program)
+		keys := p.UnpackInt(false)
+		totalKeys += keys
+		if totalKeys > m.config.MaxKeys {
+			return nil, ErrTooManyKeys
+		}
+		access.Keys = make([][]byte, keys)
+		for j := range access.Keys {
+			p.UnpackBytes(m.config.MaxKeySize, false, &access.Keys[j])
+		}
+		call.Accesses[i] = access
+	}
+	balances := p.UnpackInt(false)
+	if balances > m.config.MaxAccesses {
+		return nil, ErrTooManyAccesses
+	}
+	call.Balances = make([]codec.Address, balances)
+	for i := range call.Balances {
+		p.UnpackAddress(&call.Balances[i])
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if err := m.verifyCall(&call); err != nil {
+		return nil, err
+	}
+	return &call, nil
+}
+
+// verifyCall checks [c] against the limits of [m].
+func (m *Module) verifyCall(c *CallProgram) error {
+	if len(c.Function) == 0 {
+		return ErrFunctionEmpty
+	}
+	if c.Fuel == 0 {
+		return ErrFuelZero
+	}
+	if c.Fuel > m.config.MaxFuel {
+		return fmt.Errorf("%w: %d > %d", ErrFuelTooLarge, c.Fuel, m.config.MaxFuel)
+	}
+	if len(c.Accesses) > m.config.MaxAccesses || len(c.Balances) > m.config.MaxAccesses {
+		return ErrTooManyAccesses
+	}
+	var (
+		totalKeys int
+		declared  bool
+	)
+	for _, access := range c.Accesses {
+		declared = declared || access.Account == c.Program
+		for _, key := range access.Keys {
+			if len(key) > m.config.MaxKeySize {
+				return ErrKeyTooLarge
+			}
+		}
+		totalKeys += len(access.Keys)
+	}
+	if totalKeys > m.config.MaxKeys {
+		return ErrTooManyKeys
+	}
+	if !declared {
+		return ErrProgramNotDeclared
+	}
+	return nil
+}
+
+func (*CallProgram) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
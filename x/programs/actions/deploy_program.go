@@ -0,0 +1,153 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	DeployProgramComputeUnits = 10
+
+	// Compiling a program takes time proportional to its size, so each
+	// [deployProgramBytesPerUnit] of the program cost an extra compute unit.
+	deployProgramBytesPerUnit = units.KiB
+
+	MaxCreationDataSize = 256
+)
+
+var _ chain.Action = (*DeployProgram)(nil)
+
+// DeployProgram stores [Program] (if it isn't already stored) and creates the
+// account [ProgramAccount] of its ID and [CreationData]. The output of the
+// action is the address of the account.
+type DeployProgram struct {
+	Program      []byte `json:"program"`
+	CreationData []byte `json:"creationData"`
+
+	m *Module
+}
+
+// DeployProgram returns an action that deploys [program].
+func (m *Module) DeployProgram(program []byte, creationData []byte) *DeployProgram {
+	return &DeployProgram{Program: program, CreationData: creationData, m: m}
+}
+
+func (*DeployProgram) GetTypeID() uint8 {
+	return DeployProgramID
+}
+
+func (d *DeployProgram) StateKeys(codec.Address, ids.ID) state.Keys {
+	if d.m == nil {
+		return state.Keys{}
+	}
+	programID := utils.ToID(d.Program)
+	return state.Keys{
+		string(ProgramKey(d.m.config.StatePrefix, programID, d.m.config.MaxProgramChunks)):    state.Allocate | state.Write,
+		string(AccountKey(d.m.config.StatePrefix, ProgramAccount(programID, d.CreationData))): state.Allocate | state.Write,
+	}
+}
+
+func (d *DeployProgram) StateKeysMaxChunks() []uint16 {
+	if d.m == nil {
+		return []uint16{AccountChunks}
+	}
+	return []uint16{d.m.config.MaxProgramChunks, AccountChunks}
+}
+
+func (d *DeployProgram) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	_ codec.Address,
+	_ ids.ID,
+) ([][]byte, error) {
+	if d.m == nil {
+		return nil, ErrNotRegistered
+	}
+	if err := d.m.verifyDeploy(d); err != nil {
+		return nil, err
+	}
+	programID := utils.ToID(d.Program)
+	account := ProgramAccount(programID, d.CreationData)
+	_, err := d.m.GetAccountProgram(ctx, mu, account)
+	switch {
+	case err == nil:
+		return nil, ErrAccountExists
+	case !errors.Is(err, ErrUnknownAccount):
+		return nil, err
+	}
+	_, err = mu.GetValue(ctx, ProgramKey(d.m.config.StatePrefix, programID, d.m.config.MaxProgramChunks))
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		if err := d.m.runtime.ValidateProgram(d.Program); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProgram, err)
+		}
+		if _, err := d.m.SetProgram(ctx, mu, d.Program); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	}
+	if err := d.m.SetAccountProgram(ctx, mu, account, programID); err != nil {
+		return nil, err
+	}
+	return [][]byte{account[:]}, nil
+}
+
+func (d *DeployProgram) ComputeUnits(chain.Rules) uint64 {
+	return DeployProgramComputeUnits + uint64(len(d.Program)/deployProgramBytesPerUnit)
+}
+
+func (d *DeployProgram) Size() int {
+	return codec.BytesLen(d.Program) + codec.BytesLen(d.CreationData)
+}
+
+func (d *DeployProgram) Marshal(p *codec.Packer) {
+	p.PackBytes(d.Program)
+	p.PackBytes(d.CreationData)
+}
+
+func unmarshalDeployProgram(m *Module, p *codec.Packer) (chain.Action, error) {
+	deploy := DeployProgram{m: m}
+	p.UnpackBytes(-1, true, &deploy.Program)
+	p.UnpackBytes(MaxCreationDataSize, false, &deploy.CreationData)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if err := m.verifyDeploy(&deploy); err != nil {
+		return nil, err
+	}
+	return &deploy, nil
+}
+
+// verifyDeploy checks [d] against the limits of [m].
+func (m *Module) verifyDeploy(d *DeployProgram) error {
+	if len(d.CreationData) > MaxCreationDataSize {
+		return fmt.Errorf("%w: creation data", ErrInvalidProgram)
+	}
+	chunks, ok := keys.NumChunks(d.Program)
+	if !ok || chunks > m.config.MaxProgramChunks {
+		return ErrProgramTooLarge
+	}
+	return nil
+}
+
+func (*DeployProgram) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/keys"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/x/programs/runtime"
+)
+
+const (
+	programPrefix = 0x0
+	accountPrefix = 0x1
+	valuePrefix   = 0x2
+
+	// AccountTypeID is the first byte of the addresses of accounts created by
+	// programs (which can't collide with the addresses of any auth)
+	AccountTypeID uint8 = 0xe0
+
+	AccountChunks uint16 = 1
+)
+
+// [statePrefix] + [programPrefix] + [programID]
+func ProgramKey(statePrefix byte, programID ids.ID, maxChunks uint16) []byte {
+	k := make([]byte, 2+ids.IDLen+consts.Uint16Len)
+	k[0] = statePrefix
+	k[1] = programPrefix
+	copy(k[2:], programID[:])
+	return keys.EncodeChunks(k[:2+ids.IDLen], maxChunks)
+}
+
+// [statePrefix] + [accountPrefix] + [account]
+func AccountKey(statePrefix byte, account codec.Address) []byte {
+	k := make([]byte, 2+codec.AddressLen+consts.Uint16Len)
+	k[0] = statePrefix
+	k[1] = accountPrefix
+	copy(k[2:], account[:])
+	return keys.EncodeChunks(k[:2+codec.AddressLen], AccountChunks)
+}
+
+// [statePrefix] + [valuePrefix] + [account] + [key]
+func ValueKey(statePrefix byte, account codec.Address, key []byte, maxChunks uint16) []byte {
+	k := make([]byte, 2+codec.AddressLen+len(key), 2+codec.AddressLen+len(key)+consts.Uint16Len)
+	k[0] = statePrefix
+	k[1] = valuePrefix
+	copy(k[2:], account[:])
+	copy(k[2+codec.AddressLen:], key)
+	return keys.EncodeChunks(k, maxChunks)
+}
+
+// ProgramAccount returns the address of the account created for [programID]
+// with [creationData] (by [DeployProgram] or by a program).
+func ProgramAccount(programID ids.ID, creationData []byte) codec.Address {
+	return codec.CreateAddress(AccountTypeID, utils.ToID(append(programID[:], creationData...)))
+}
+
+// Access returns the [Access] that declares [keys] of the state of
+// [account] (and its program as stored in [im]).
+func (m *Module) Access(ctx context.Context, im state.Immutable, account codec.Address, keys ...[]byte) (*Access, error) {
+	programID, err := m.GetAccountProgram(ctx, im, account)
+	if err != nil {
+		return nil, err
+	}
+	return &Access{Account: account, Program: programID, Keys: keys}, nil
+}
+
+// GetProgram returns the bytes of [programID] (or [ErrUnknownProgram]).
+func (m *Module) GetProgram(ctx context.Context, im state.Immutable, programID ids.ID) ([]byte, error) {
+	v, err := im.GetValue(ctx, ProgramKey(m.config.StatePrefix, programID, m.config.MaxProgramChunks))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, ErrUnknownProgram
+	}
+	return v, err
+}
+
+// SetProgram stores [program] and returns its ID (the hash of its bytes).
+func (m *Module) SetProgram(ctx context.Context, mu state.Mutable, program []byte) (ids.ID, error) {
+	programID := utils.ToID(program)
+	return programID, mu.Insert(ctx, ProgramKey(m.config.StatePrefix, programID, m.config.MaxProgramChunks), program)
+}
+
+// GetAccountProgram returns the ID of the program of [account] (or
+// [ErrUnknownAccount]).
+func (m *Module) GetAccountProgram(ctx context.Context, im state.Immutable, account codec.Address) (ids.ID, error) {
+	v, err := im.GetValue(ctx, AccountKey(m.config.StatePrefix, account))
+	if errors.Is(err, database.ErrNotFound) {
+		return ids.Empty, ErrUnknownAccount
+	}
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(v)
+}
+
+// SetAccountProgram makes [programID] the program of [account].
+func (m *Module) SetAccountProgram(ctx context.Context, mu state.Mutable, account codec.Address, programID ids.ID) error {
+	return mu.Insert(ctx, AccountKey(m.config.StatePrefix, account), programID[:])
+}
+
+var _ runtime.StateManager = (*stateManager)(nil)
+
+// stateManager is the view of [mu] used by the programs executed by a
+// [CallProgram].
+type stateManager struct {
+	m  *Module
+	mu state.Mutable
+}
+
+func (s *stateManager) GetBalance(ctx context.Context, address codec.Address) (uint64, error) {
+	return s.m.balances.GetBalance(ctx, s.mu, address)
+}
+
+func (s *stateManager) TransferBalance(ctx context.Context, from codec.Address, to codec.Address, amount uint64) error {
+	return s.m.balances.TransferBalance(ctx, s.mu, from, to, amount)
+}
+
+func (s *stateManager) GetProgramState(address codec.Address) state.Mutable {
+	return &programState{m: s.m, mu: s.mu, account: address}
+}
+
+func (s *stateManager) GetAccountProgram(ctx context.Context, account codec.Address) (ids.ID, error) {
+	return s.m.GetAccountProgram(ctx, s.mu, account)
+}
+
+func (s *stateManager) GetProgramBytes(ctx context.Context, programID ids.ID) ([]byte, error) {
+	return s.m.GetProgram(ctx, s.mu, programID)
+}
+
+func (s *stateManager) NewAccountWithProgram(ctx context.Context, programID ids.ID, accountCreationData []byte) (codec.Address, error) {
+	account := ProgramAccount(programID, accountCreationData)
+	return account, s.m.SetAccountProgram(ctx, s.mu, account, programID)
+}
+
+func (s *stateManager) SetAccountProgram(ctx context.Context, account codec.Address, programID ids.ID) error {
+	return s.m.SetAccountProgram(ctx, s.mu, account, programID)
+}
+
+var _ state.Mutable = (*programState)(nil)
+
+// programState stores the values of a program under [ValueKey].
+type programState struct {
+	m       *Module
+	mu      state.Mutable
+	account codec.Address
+}
+
+func (p *programState) key(key []byte) ([]byte, error) {
+	if len(key) > p.m.config.MaxKeySize {
+		return nil, ErrKeyTooLarge
+	}
+	return ValueKey(p.m.config.StatePrefix, p.account, key, p.m.config.MaxValueChunks), nil
+}
+
+func (p *programState) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	k, err := p.key(key)
+	if err != nil {
+		return nil, err
+	}
+	return p.mu.GetValue(ctx, k)
+}
+
+func (p *programState) Insert(ctx context.Context, key []byte, value []byte) error {
+	k, err := p.key(key)
+	if err != nil {
+		return err
+	}
+	return p.mu.Insert(ctx, k, value)
+}
+
+func (p *programState) Remove(ctx context.Context, key []byte) error {
+	k, err := p.key(key)
+	if err != nil {
+		return err
+	}
+	return p.mu.Remove(ctx, k)
+}
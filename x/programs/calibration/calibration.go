@@ -0,0 +1,207 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package calibration measures how long programs take to execute per unit of
+// fuel on the current machine, so the fuel limits of a VM can be derived from
+// the time it may spend executing programs.
+//
+// Fuel is charged by wasmtime for each instruction, by [runtime.Config] for
+// each page of memory, and by the runtime for each host call. The workload
+// with the highest cost per fuel bounds how long any call with the same fuel
+// can take, so [Report.FuelFor] is used to set:
+//
+//   - the fuel paid for by each compute unit of a call
+//     ([actions.Config.FuelPerComputeUnit]), from the time budgeted for a
+//     compute unit
+//   - the fuel of a single call and of all calls in a block
+//     ([chain.FuelRules]), from the time budgeted for verifying a block
+//
+// If a workload is much more expensive per fuel than the others, the fuel
+// cost of what it stresses should be raised (with
+// [runtime.Config.MemoryPageCost] or [runtime.WasmRuntime.SetFuelCost])
+// instead of lowering the fuel of every call.
+package calibration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/ava-labs/hypersdk/x/programs/runtime"
+)
+
+var ErrNoFuelConsumed = errors.New("no fuel consumed")
+
+// Workload is a function of a program that is called repeatedly to measure
+// its cost.
+type Workload struct {
+	Name     string
+	Program  []byte
+	Function string
+	Params   []byte
+	// Fuel is the fuel given to each call
+	Fuel uint64
+}
+
+// Measurement is the result of calling a [Workload].
+type Measurement struct {
+	Workload string        `json:"workload"`
+	Calls    int           `json:"calls"`
+	Fuel     uint64        `json:"fuel"`
+	Duration time.Duration `json:"duration"`
+}
+
+// NanosPerFuel is the average time it took to consume each unit of fuel.
+func (m *Measurement) NanosPerFuel() float64 {
+	if m.Fuel == 0 {
+		return 0
+	}
+	return float64(m.Duration.Nanoseconds()) / float64(m.Fuel)
+}
+
+// Measure calls [w] [calls] times with [r] and returns the total fuel
+// consumed and time spent. Calls that run out of fuel are expected (and
+// consume all of their fuel).
+func Measure(ctx context.Context, r *runtime.WasmRuntime, w *Workload, calls int) (*Measurement, error) {
+	programID := utils.ToID(w.Program)
+	program := codec.CreateAddress(0, programID)
+	sm := &stateManager{
+		programID: programID,
+		program:   w.Program,
+		values:    memoryState{},
+	}
+	m := &Measurement{Workload: w.Name, Calls: calls}
+	for i := 0; i < calls; i++ {
+		callInfo := &runtime.CallInfo{
+			State:        sm,
+			Program:      program,
+			FunctionName: w.Function,
+			Params:       w.Params,
+			Fuel:         w.Fuel,
+		}
+		start := time.Now()
+		_, err := r.CallProgram(ctx, callInfo)
+		m.Duration += time.Since(start)
+		if code, ok := runtime.ExtractProgramCallErrorCode(err); err != nil && (!ok || code != runtime.OutOfFuel) {
+			return nil, fmt.Errorf("%s: %w", w.Name, err)
+		}
+		m.Fuel += w.Fuel - callInfo.RemainingFuel()
+	}
+	if m.Fuel == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoFuelConsumed, w.Name)
+	}
+	return m, nil
+}
+
+// Report is the result of calibrating a runtime.
+type Report struct {
+	Measurements []*Measurement `json:"measurements"`
+}
+
+// Calibrate measures [workloads] on a new runtime created with [cfg]. The
+// first call of each workload compiles its program and is not measured.
+func Calibrate(ctx context.Context, cfg *runtime.Config, workloads []*Workload, calls int) (*Report, error) {
+	r := runtime.NewRuntime(cfg, logging.NoLog{})
+	report := &Report{}
+	for _, w := range workloads {
+		if _, err := Measure(ctx, r, w, 1); err != nil {
+			return nil, err
+		}
+		m, err := Measure(ctx, r, w, calls)
+		if err != nil {
+			return nil, err
+		}
+		report.Measurements = append(report.Measurements, m)
+	}
+	return report, nil
+}
+
+// Slowest returns the measurement with the highest cost per fuel.
+func (r *Report) Slowest() *Measurement {
+	var slowest *Measurement
+	for _, m := range r.Measurements {
+		if slowest == nil || m.NanosPerFuel() > slowest.NanosPerFuel() {
+			slowest = m
+		}
+	}
+	return slowest
+}
+
+// FuelFor returns the fuel that can be consumed in [d] by the slowest
+// workload (at least 1).
+func (r *Report) FuelFor(d time.Duration) uint64 {
+	slowest := r.Slowest()
+	if slowest == nil {
+		return 0
+	}
+	return max(uint64(float64(d.Nanoseconds())/slowest.NanosPerFuel()), 1)
+}
+
+var _ runtime.StateManager = (*stateManager)(nil)
+
+// stateManager serves a single program (at every address) and keeps values
+// in memory. Balances are always empty.
+type stateManager struct {
+	programID ids.ID
+	program   []byte
+	values    memoryState
+}
+
+func (*stateManager) GetBalance(context.Context, codec.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (*stateManager) TransferBalance(context.Context, codec.Address, codec.Address, uint64) error {
+	return errors.New("balances are not supported")
+}
+
+func (s *stateManager) GetProgramState(codec.Address) state.Mutable {
+	return s.values
+}
+
+func (s *stateManager) GetAccountProgram(context.Context, codec.Address) (ids.ID, error) {
+	return s.programID, nil
+}
+
+func (s *stateManager) GetProgramBytes(_ context.Context, programID ids.ID) ([]byte, error) {
+	if programID != s.programID {
+		return nil, database.ErrNotFound
+	}
+	return s.program, nil
+}
+
+func (s *stateManager) NewAccountWithProgram(_ context.Context, programID ids.ID, creationData []byte) (codec.Address, error) {
+	return codec.CreateAddress(0, utils.ToID(append(programID[:], creationData...))), nil
+}
+
+func (*stateManager) SetAccountProgram(context.Context, codec.Address, ids.ID) error {
+	return nil
+}
+
+type memoryState map[string][]byte
+
+func (s memoryState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s memoryState) Insert(_ context.Context, key []byte, value []byte) error {
+	s[string(key)] = value
+	return nil
+}
+
+func (s memoryState) Remove(_ context.Context, key []byte) error {
+	delete(s, string(key))
+	return nil
+}
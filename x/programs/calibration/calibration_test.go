@@ -0,0 +1,61 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package calibration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/hypersdk/x/programs/runtime"
+)
+
+func TestCalibrate(t *testing.T) {
+	require := require.New(t)
+
+	workloads, err := DefaultWorkloads(100_000)
+	require.NoError(err)
+	report, err := Calibrate(context.Background(), runtime.NewConfig(), workloads, 2)
+	require.NoError(err)
+	require.Len(report.Measurements, len(workloads))
+	for _, m := range report.Measurements {
+		require.Equal(2, m.Calls)
+		require.Positive(m.NanosPerFuel())
+		if m.Workload != "grow" {
+			// Workloads run until they are out of fuel
+			require.Equal(uint64(200_000), m.Fuel)
+		}
+	}
+	slowest := report.Slowest()
+	require.NotNil(slowest)
+	require.Equal(uint64(1), report.FuelFor(0))
+	require.Greater(report.FuelFor(time.Second), report.FuelFor(time.Millisecond))
+}
+
+func TestMeasureMemoryPageCost(t *testing.T) {
+	require := require.New(t)
+
+	workloads, err := DefaultWorkloads(100_000)
+	require.NoError(err)
+	grow := workloads[2]
+	require.Equal("grow", grow.Name)
+
+	// Growing memory until the call runs out of fuel
+	cfg, err := runtime.NewConfigBuilder().WithMemoryPageCost(10_000).Build()
+	require.NoError(err)
+	m, err := Measure(context.Background(), runtime.NewRuntime(cfg, logging.NoLog{}), grow, 1)
+	require.NoError(err)
+	require.Equal(uint64(100_000), m.Fuel)
+
+	// Growing memory until the limit of the runtime
+	grow.Fuel = 1_000_000
+	cfg, err = runtime.NewConfigBuilder().WithMemoryPageCost(1).Build()
+	require.NoError(err)
+	m, err = Measure(context.Background(), runtime.NewRuntime(cfg, logging.NoLog{}), grow, 1)
+	require.NoError(err)
+	require.Less(m.Fuel, uint64(1_000_000))
+}
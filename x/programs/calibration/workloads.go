@@ -0,0 +1,78 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package calibration
+
+import "github.com/bytecodealliance/wasmtime-go/v14"
+
+// Each workload loops until the call runs out of fuel (or memory), so the
+// fuel consumed by a call is the fuel it is given. [alloc] always returns the
+// same scratch space because outputs of host functions are never read.
+const (
+	arithmeticWorkload = `
+(module
+  (memory (export "memory") 1)
+  (func (export "alloc") (param i32) (result i32) (i32.const 1024))
+  (func (export "run") (param i32)
+    (local $x i64)
+    (loop $l
+      (local.set $x (i64.add (i64.mul (local.get $x) (i64.const 6364136223846793005)) (i64.const 1442695040888963407)))
+      (br $l))))
+`
+
+	memoryWorkload = `
+(module
+  (memory (export "memory") 16)
+  (func (export "alloc") (param i32) (result i32) (i32.const 1024))
+  (func (export "run") (param i32)
+    (local $ptr i32)
+    (loop $l
+      (i64.store (local.get $ptr) (i64.add (i64.load (local.get $ptr)) (i64.const 1)))
+      (local.set $ptr (i32.and (i32.add (local.get $ptr) (i32.const 4104)) (i32.const 1048568)))
+      (br $l))))
+`
+
+	// Each page is charged when [height] is called after growing memory
+	growWorkload = `
+(module
+  (import "context" "height" (func $height (result i32)))
+  (memory (export "memory") 1)
+  (func (export "alloc") (param i32) (result i32) (i32.const 1024))
+  (func (export "run") (param i32)
+    (loop $l
+      (if (i32.eq (memory.grow (i32.const 1)) (i32.const -1))
+        (then return))
+      (drop (call $height))
+      (br $l))))
+`
+
+	hostWorkload = `
+(module
+  (import "context" "actor" (func $actor (result i32)))
+  (memory (export "memory") 1)
+  (func (export "alloc") (param i32) (result i32) (i32.const 1024))
+  (func (export "run") (param i32)
+    (loop $l
+      (drop (call $actor))
+      (br $l))))
+`
+)
+
+// DefaultWorkloads returns workloads that stress instruction execution,
+// memory access, memory growth, and host calls. Each is called with [fuel].
+func DefaultWorkloads(fuel uint64) ([]*Workload, error) {
+	workloads := []*Workload{
+		{Name: "arithmetic", Function: "run", Fuel: fuel},
+		{Name: "memory", Function: "run", Fuel: fuel},
+		{Name: "grow", Function: "run", Fuel: fuel},
+		{Name: "host", Function: "run", Fuel: fuel},
+	}
+	for i, wat := range []string{arithmeticWorkload, memoryWorkload, growWorkload, hostWorkload} {
+		program, err := wasmtime.Wat2Wasm(wat)
+		if err != nil {
+			return nil, err
+		}
+		workloads[i].Program = program
+	}
+	return workloads, nil
+}
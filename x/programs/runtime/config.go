@@ -18,7 +18,9 @@ const (
 )
 
 var (
-	DefaultMaxWasmStack         = 256 * units.MiB // 256 MiB
+	DefaultMaxWasmStack         = 256 * units.MiB       // 256 MiB
+	DefaultMaxMemory            = int64(16 * units.MiB) // 16 MiB
+	DefaultMemoryPageCost       = uint64(1_000)
 	DefaultSIMD                 = false
 	DefaultEnableReferenceTypes = false
 	DefaultEnableBulkMemory     = false
@@ -43,6 +45,8 @@ func NewConfig() *Config {
 	return &Config{
 		wasmConfig:       DefaultWasmtimeConfig(),
 		ProgramCacheSize: defaultProgramCacheSize,
+		MaxMemory:        DefaultMaxMemory,
+		MemoryPageCost:   DefaultMemoryPageCost,
 	}
 }
 
@@ -54,6 +58,16 @@ type Config struct {
 	CompileStrategy CompileStrategy `json:"compileStrategy,omitempty" yaml:"compile_strategy,omitempty"`
 
 	ProgramCacheSize int
+
+	// MaxMemory is the maximum size, in bytes, of the linear memory of each
+	// program instance. Growing memory past this limit fails (and traps
+	// if the program doesn't handle the failure).
+	MaxMemory int64
+
+	// MemoryPageCost is the fuel charged for each page (64 KiB) of linear
+	// memory of a program instance (including its initial memory). Growth is
+	// charged before each host call and when the call returns.
+	MemoryPageCost uint64
 }
 
 // Get returns the underlying wasmtime config.
@@ -195,6 +209,8 @@ func NewConfigBuilder() *ConfigBuilder {
 		EnableWasmReferenceTypes: DefaultEnableReferenceTypes,
 		EnableWasmSIMD:           DefaultSIMD,
 		MaxWasmStack:             DefaultMaxWasmStack,
+		MaxMemory:                DefaultMaxMemory,
+		MemoryPageCost:           DefaultMemoryPageCost,
 		ProfilingStrategy:        DefaultProfilingStrategy,
 		EnableDefaultCache:       false,
 	}
@@ -237,6 +253,14 @@ type ConfigBuilder struct {
 	// Note that this setting is not interpreted with 100% precision.
 	// This is 256 MiB by default.
 	MaxWasmStack int `json:"maxWasmStack,omitempty" yaml:"max_wasm_stack,omitempty"`
+	// MaxMemory configures the maximum size, in bytes, of the linear memory
+	// of each program instance.
+	// This is 16 MiB by default.
+	MaxMemory int64 `json:"maxMemory,omitempty" yaml:"max_memory,omitempty"`
+	// MemoryPageCost configures the fuel charged for each page (64 KiB) of
+	// linear memory of each program instance.
+	// This is 1000 by default.
+	MemoryPageCost uint64 `json:"memoryPageCost,omitempty" yaml:"memory_page_cost,omitempty"`
 	// ProfilingStrategy decides what sort of profiling to enable, if any.
 	// Default is `wasmtime.ProfilingStrategyNone`.
 	ProfilingStrategy wasmtime.ProfilingStrategy
@@ -251,6 +275,24 @@ func (c *ConfigBuilder) WithMaxWasmStack(max int) *ConfigBuilder {
 	return c
 }
 
+// WithMaxMemory defines the maximum size of the linear memory of each
+// program instance.
+//
+// Default is 16 MiB.
+func (c *ConfigBuilder) WithMaxMemory(max int64) *ConfigBuilder {
+	c.MaxMemory = max
+	return c
+}
+
+// WithMemoryPageCost defines the fuel charged for each page of linear memory
+// of each program instance.
+//
+// Default is 1000.
+func (c *ConfigBuilder) WithMemoryPageCost(cost uint64) *ConfigBuilder {
+	c.MemoryPageCost = cost
+	return c
+}
+
 // WithMultiValue enables modules that can return multiple values.
 // ref. https://github.com/webassembly/multi-value
 //
@@ -315,6 +357,8 @@ func (c *ConfigBuilder) Build() (*Config, error) {
 	cfg.SetWasmSIMD(c.EnableWasmSIMD)
 	cfg.SetMaxWasmStack(c.MaxWasmStack)
 	cfg.SetProfiler(c.ProfilingStrategy)
+	cfg.MaxMemory = c.MaxMemory
+	cfg.MemoryPageCost = c.MemoryPageCost
 	if c.EnableDefaultCache {
 		if err := cfg.CacheConfigLoadDefault(); err != nil {
 			return nil, err
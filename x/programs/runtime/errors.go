@@ -9,6 +9,11 @@ import (
 	"github.com/bytecodealliance/wasmtime-go/v14"
 )
 
+// ErrOutOfFuel is returned when a call can't pay for the memory of its
+// program or for a host call (running out of fuel while executing
+// instructions traps instead).
+var ErrOutOfFuel = errors.New("out of fuel")
+
 func convertToTrap(err error) *wasmtime.Trap {
 	if err == nil {
 		return nil
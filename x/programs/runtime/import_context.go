@@ -0,0 +1,38 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package runtime
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+const contextCost = 1000
+
+// NewContextModule provides the identity of the caller and the program and
+// the context of the block executing the call (which are also serialized
+// before the params of each call).
+func NewContextModule() *ImportModule {
+	return &ImportModule{
+		Name: "context",
+		HostFunctions: map[string]HostFunction{
+			"actor": {FuelCost: contextCost, Function: FunctionNoInput[codec.Address](func(callInfo *CallInfo) (codec.Address, error) {
+				return callInfo.Actor, nil
+			})},
+			"program": {FuelCost: contextCost, Function: FunctionNoInput[codec.Address](func(callInfo *CallInfo) (codec.Address, error) {
+				return callInfo.Program, nil
+			})},
+			"height": {FuelCost: contextCost, Function: FunctionNoInput[uint64](func(callInfo *CallInfo) (uint64, error) {
+				return callInfo.Height, nil
+			})},
+			"timestamp": {FuelCost: contextCost, Function: FunctionNoInput[uint64](func(callInfo *CallInfo) (uint64, error) {
+				return callInfo.Timestamp, nil
+			})},
+			"action_id": {FuelCost: contextCost, Function: FunctionNoInput[ids.ID](func(callInfo *CallInfo) (ids.ID, error) {
+				return callInfo.ActionID, nil
+			})},
+		},
+	}
+}
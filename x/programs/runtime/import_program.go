@@ -44,9 +44,16 @@ type deployProgramInput struct {
 }
 
 func ExtractProgramCallErrorCode(err error) (ProgramCallErrorCode, bool) {
+	if errors.Is(err, ErrOutOfFuel) {
+		return OutOfFuel, true
+	}
 	var trap *wasmtime.Trap
 	if errors.As(err, &trap) {
-		switch *trap.Code() {
+		code := trap.Code()
+		if code == nil {
+			return ExecutionFailure, true
+		}
+		switch *code {
 		case wasmtime.UnreachableCodeReached:
 			return CallPanicked, true
 		case wasmtime.OutOfFuel:
@@ -72,6 +72,11 @@ func (f HostFunction) convert(r *WasmRuntime) func(*wasmtime.Caller, []wasmtime.
 	return func(caller *wasmtime.Caller, vals []wasmtime.Val) ([]wasmtime.Val, *wasmtime.Trap) {
 		callInfo := r.getCallInfo(caller)
 		if err := callInfo.ConsumeFuel(f.FuelCost); err != nil {
+			return nil, convertToTrap(callInfo.inst.outOfFuel("unable to pay for host call"))
+		}
+		// Charge for memory grown since the last charge before the host
+		// function can use it
+		if err := callInfo.inst.chargeMemory(); err != nil {
 			return nil, convertToTrap(err)
 		}
 		return f.Function.call(callInfo, caller, vals)
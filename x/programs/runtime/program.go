@@ -8,8 +8,10 @@ import "C"
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/bytecodealliance/wasmtime-go/v14"
 
 	"github.com/ava-labs/hypersdk/codec"
@@ -64,7 +66,9 @@ func (c *CallInfo) RemainingFuel() uint64 {
 	remaining := c.Fuel
 	usedFuel, fuelEnabled := c.inst.store.FuelConsumed()
 	if fuelEnabled {
-		remaining -= usedFuel
+		// wasmtime only checks fuel at some instructions, so a call that
+		// ran out of fuel may have consumed more than it was given
+		remaining -= min(usedFuel, remaining)
 	}
 	return remaining
 }
@@ -83,12 +87,23 @@ type ProgramInstance struct {
 	inst   *wasmtime.Instance
 	store  *wasmtime.Store
 	result []byte
+
+	// memoryPages is the number of pages of linear memory already charged
+	// (at [memoryPageCost] each)
+	memoryPageCost uint64
+	memoryPages    uint64
+
+	// fuelErr is set if the call ran out of fuel outside of wasm
+	fuelErr error
 }
 
 func (p *ProgramInstance) call(ctx context.Context, callInfo *CallInfo) ([]byte, error) {
 	if err := p.store.AddFuel(callInfo.Fuel); err != nil {
 		return nil, err
 	}
+	if err := p.chargeMemory(); err != nil {
+		return nil, err
+	}
 
 	if callInfo.Value > 0 {
 		if err := callInfo.State.TransferBalance(ctx, callInfo.Actor, callInfo.Program, callInfo.Value); err != nil {
@@ -121,8 +136,50 @@ func (p *ProgramInstance) call(ctx context.Context, callInfo *CallInfo) ([]byte,
 		return nil, errors.New("this function does not exist")
 	}
 	_, err = function.Call(p.store, paramsOffset)
+	if p.fuelErr != nil {
+		return nil, p.fuelErr
+	}
+	if err != nil {
+		return p.result, err
+	}
+	if err := p.chargeMemory(); err != nil {
+		return nil, err
+	}
+	return p.result, nil
+}
 
-	return p.result, err
+// chargeMemory consumes the fuel of the pages of linear memory that haven't
+// been charged yet. wasmtime only charges a single instruction for
+// memory.grow, so growth is charged lazily (before each host call and when the
+// call returns).
+func (p *ProgramInstance) chargeMemory() error {
+	export := p.inst.GetExport(p.store, MemoryName)
+	if export == nil || export.Memory() == nil {
+		return nil
+	}
+	pages := export.Memory().Size(p.store)
+	if pages <= p.memoryPages {
+		return nil
+	}
+	cost, err := math.Mul64(pages-p.memoryPages, p.memoryPageCost)
+	if err == nil {
+		_, err = p.store.ConsumeFuel(cost)
+	}
+	if err != nil {
+		return p.outOfFuel(fmt.Sprintf("unable to pay for %d pages of memory", pages))
+	}
+	p.memoryPages = pages
+	return nil
+}
+
+// outOfFuel consumes the remaining fuel of the call (like running out of fuel
+// in wasm does) and records [ErrOutOfFuel].
+func (p *ProgramInstance) outOfFuel(reason string) error {
+	if remaining, err := p.store.ConsumeFuel(0); err == nil {
+		_, _ = p.store.ConsumeFuel(remaining)
+	}
+	p.fuelErr = fmt.Errorf("%w: %s", ErrOutOfFuel, reason)
+	return p.fuelErr
 }
 
 func (p *ProgramInstance) writeToMemory(data []byte) (int32, error) {
@@ -71,6 +71,7 @@ func NewRuntime(
 	runtime.AddImportModule(NewBalanceModule())
 	runtime.AddImportModule(NewStateAccessModule())
 	runtime.AddImportModule(NewProgramModule(runtime))
+	runtime.AddImportModule(NewContextModule())
 
 	return runtime
 }
@@ -84,6 +85,21 @@ func (r *WasmRuntime) AddImportModule(mod *ImportModule) {
 	r.linkerNeedsInitialization = true
 }
 
+// SetFuelCost sets the fuel charged for each call to [functionName] of the
+// import module [moduleName] (returning false if it doesn't exist).
+func (r *WasmRuntime) SetFuelCost(moduleName string, functionName string, fuelCost uint64) bool {
+	if !r.hostImports.SetFuelCost(moduleName, functionName, fuelCost) {
+		return false
+	}
+	r.linkerNeedsInitialization = true
+	return true
+}
+
+// ValidateProgram returns an error if [programBytes] can't be compiled by [r].
+func (r *WasmRuntime) ValidateProgram(programBytes []byte) error {
+	return wasmtime.ModuleValidate(r.engine, programBytes)
+}
+
 func (r *WasmRuntime) getModule(ctx context.Context, callInfo *CallInfo, id ids.ID) (*wasmtime.Module, error) {
 	if mod, ok := r.programCache.Get(id); ok {
 		return mod, nil
@@ -133,11 +149,12 @@ func (r *WasmRuntime) getInstance(programModule *wasmtime.Module, imports *Impor
 
 	store := wasmtime.NewStore(r.engine)
 	store.SetEpochDeadline(1)
+	store.Limiter(r.cfg.MaxMemory, -1, -1, -1, -1)
 	inst, err := r.linker.Instantiate(store, programModule)
 	if err != nil {
 		return nil, err
 	}
-	return &ProgramInstance{inst: inst, store: store}, nil
+	return &ProgramInstance{inst: inst, store: store, memoryPageCost: r.cfg.MemoryPageCost}, nil
 }
 
 func toMapKey(storeLike wasmtime.Storelike) uintptr {